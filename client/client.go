@@ -32,9 +32,11 @@
 // # Consumers
 //
 // Provided that the pipeline does not contain processors that would discard or
-// rewrite the context, such as the batch processor, processors and exporters
-// have access to the client.Info via client.FromContext. Among other usages,
-// this data can be used to:
+// rewrite the context, processors and exporters have access to the client.Info
+// via client.FromContext. The Envelope of the client.Info, its address and a
+// bounded part of its metadata, is preserved by the batch processor, the
+// batching and the persistent sending queues of the exporters, and read with
+// client.EnvelopeFromContext. Among other usages, this data can be used to:
 //
 // - annotate data points with authentication data (username, tenant, ...)
 //
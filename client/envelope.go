@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client // import "go.opentelemetry.io/collector/client"
+
+import (
+	"context"
+	"net"
+	"slices"
+	"sort"
+)
+
+// MaxEnvelopeMetadataSize is the maximum size of the metadata of an Envelope, as the total length of its
+// keys and values.
+const MaxEnvelopeMetadataSize = 16 << 10
+
+// Envelope is the part of the Info preserved along the whole pipeline: through the processors, the
+// connectors, the batching and the persistent sending queues of the exporters, so that the components
+// down the pipeline, e.g. the exporters, can rely on it. Unlike the Auth of the Info, it only holds
+// values which can be persisted, and its size is bounded.
+//
+// The data batched together only keeps the part of their envelopes they all agree on: the metadata keys
+// with the same values, and the address if it is the same.
+type Envelope struct {
+	// Addr is the address of the client, if known.
+	Addr net.Addr
+
+	// Metadata is the request metadata from the client, with a size of at most MaxEnvelopeMetadataSize.
+	Metadata Metadata
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// NewEnvelope returns the Envelope of the info. If its metadata is larger than MaxEnvelopeMetadataSize, the
+// keys, in lexical order, exceeding it are dropped.
+func NewEnvelope(info Info) Envelope {
+	keys := make([]string, 0, len(info.Metadata.data))
+	for k := range info.Metadata.data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var md map[string][]string
+	size := 0
+	for _, k := range keys {
+		vals := info.Metadata.data[k]
+		kvSize := len(k)
+		for _, v := range vals {
+			kvSize += len(v)
+		}
+		if size+kvSize > MaxEnvelopeMetadataSize {
+			continue
+		}
+		size += kvSize
+		if md == nil {
+			md = make(map[string][]string, len(keys))
+		}
+		md[k] = vals
+	}
+	return Envelope{Addr: info.Addr, Metadata: Metadata{data: md}}
+}
+
+// EnvelopeFromContext returns the Envelope of the Info of the ctx.
+func EnvelopeFromContext(ctx context.Context) Envelope {
+	return NewEnvelope(FromContext(ctx))
+}
+
+// Info returns an Info with the address and metadata of the envelope.
+func (e Envelope) Info() Info {
+	return Info{Addr: e.Addr, Metadata: e.Metadata}
+}
+
+// IsEmpty returns whether the envelope has neither an address nor metadata.
+func (e Envelope) IsEmpty() bool {
+	return e.Addr == nil && len(e.Metadata.data) == 0
+}
+
+// Merge returns the envelope of the data of e batched with the data of other: the metadata keys with
+// the same values in both, and the address if it is the same.
+func (e Envelope) Merge(other Envelope) Envelope {
+	var merged Envelope
+	if sameAddr(e.Addr, other.Addr) {
+		merged.Addr = e.Addr
+	}
+	for k, vals := range e.Metadata.data {
+		if otherVals, ok := other.Metadata.data[k]; ok && slices.Equal(vals, otherVals) {
+			if merged.Metadata.data == nil {
+				merged.Metadata.data = make(map[string][]string, len(e.Metadata.data))
+			}
+			merged.Metadata.data[k] = vals
+		}
+	}
+	return merged
+}
+
+func sameAddr(a, b net.Addr) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Network() == b.Network() && a.String() == b.String()
+}
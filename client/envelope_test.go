@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEnvelope(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 4317}
+	info := Info{
+		Addr:     addr,
+		Metadata: NewMetadata(map[string][]string{"Tenant": {"acme"}, "region": {"eu", "us"}}),
+	}
+	e := EnvelopeFromContext(NewContext(context.Background(), info))
+	assert.Equal(t, addr, e.Addr)
+	assert.Equal(t, []string{"acme"}, e.Metadata.Get("tenant"))
+	assert.Equal(t, []string{"eu", "us"}, e.Metadata.Get("region"))
+	assert.Equal(t, Info{Addr: addr, Metadata: e.Metadata}, e.Info())
+	assert.False(t, e.IsEmpty())
+	assert.True(t, EnvelopeFromContext(context.Background()).IsEmpty())
+}
+
+func TestNewEnvelopeMaxSize(t *testing.T) {
+	large := strings.Repeat("x", MaxEnvelopeMetadataSize/2)
+	e := NewEnvelope(Info{Metadata: NewMetadata(map[string][]string{
+		"a": {large},
+		"b": {large},
+		"c": {"small"},
+	})})
+	// "b" exceeds the size once "a" is kept, the keys after it still fit.
+	assert.Equal(t, []string{large}, e.Metadata.Get("a"))
+	assert.Nil(t, e.Metadata.Get("b"))
+	assert.Equal(t, []string{"small"}, e.Metadata.Get("c"))
+}
+
+func TestEnvelopeMerge(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 4317}
+	e1 := NewEnvelope(Info{
+		Addr:     addr,
+		Metadata: NewMetadata(map[string][]string{"tenant": {"acme"}, "region": {"eu"}, "id": {"1"}}),
+	})
+	e2 := NewEnvelope(Info{
+		Addr:     &net.TCPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 4317},
+		Metadata: NewMetadata(map[string][]string{"tenant": {"acme"}, "region": {"eu", "us"}}),
+	})
+
+	merged := e1.Merge(e2)
+	assert.Equal(t, addr, merged.Addr)
+	assert.Equal(t, []string{"tenant"}, keys(merged.Metadata))
+	assert.Equal(t, []string{"acme"}, merged.Metadata.Get("tenant"))
+
+	other := NewEnvelope(Info{Addr: &net.TCPAddr{IP: net.IPv4(5, 6, 7, 8), Port: 4317}})
+	assert.True(t, merged.Merge(other).IsEmpty())
+	assert.True(t, Envelope{}.Merge(e1).IsEmpty())
+}
+
+func keys(md Metadata) []string {
+	var ks []string
+	for k := range md.Keys() {
+		ks = append(ks, k)
+	}
+	return ks
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// ErrIncompatibleComponent is returned when a component requires a version of the core collector API
+// incompatible with the one of the distribution.
+var ErrIncompatibleComponent = errors.New("component incompatible with the core collector version")
+
+// errCoreModule is returned instead of the go.mod file of a core module, which is not downloaded
+var errCoreModule = errors.New("core module")
+
+const coreModulePrefix = "go.opentelemetry.io/collector/"
+
+// checkCompatibility checks, before resolving the dependencies of the distribution, that the modules of
+// its components require the core collector modules at versions compatible with the core collector version
+// of the builder: the same minor version of the unstable (v0) modules, which may break their API in any
+// minor version, and no newer version of the stable modules.
+func checkCompatibility(cfg *Config) error {
+	var errs []error
+	for _, kind := range []struct {
+		name string
+		mods []Module
+	}{
+		{"extension", cfg.Extensions},
+		{"receiver", cfg.Receivers},
+		{"exporter", cfg.Exporters},
+		{"processor", cfg.Processors},
+		{"connector", cfg.Connectors},
+		{"provider", cfg.ConfmapProviders},
+		{"converter", cfg.ConfmapConverters},
+	} {
+		for _, mod := range kind.mods {
+			modPath, _, _ := strings.Cut(mod.GoMod, " ")
+			if modPath == cfg.Distribution.Module {
+				continue
+			}
+			gomod, err := componentGoMod(cfg, mod)
+			switch {
+			case errors.Is(err, errCoreModule):
+				// The core modules are released along with the core collector, at the same versions.
+				_, version, _ := strings.Cut(mod.GoMod, " ")
+				err = checkRequirement(cfg, module.Version{Path: modPath, Version: version})
+			case err != nil:
+				// The go commands resolving the dependencies report why the module is not available.
+				cfg.Logger.Warn("Skipping the compatibility check of the component",
+					zap.String("kind", kind.name), zap.String("gomod", mod.GoMod), zap.Error(err))
+				continue
+			default:
+				err = checkModuleCompatibility(cfg, gomod)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s %q: %w", kind.name, mod.GoMod, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func checkModuleCompatibility(cfg *Config, gomod *modfile.File) error {
+	for _, req := range gomod.Require {
+		if err := checkRequirement(cfg, req.Mod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRequirement checks that the required version of a core module is compatible with the core collector
+// version of the builder.
+func checkRequirement(cfg *Config, req module.Version) error {
+	if !isCoreModule(req.Path) {
+		return nil
+	}
+	if semver.Major(req.Version) == "v0" {
+		if semver.MajorMinor(req.Version) != semver.MajorMinor(cfg.OtelColVersion) {
+			return fmt.Errorf("%w %s: it requires %s %s: use the release of the component for the collector %s, or the builder of the collector %s",
+				ErrIncompatibleComponent, cfg.OtelColVersion, req.Path, req.Version, semver.MajorMinor(cfg.OtelColVersion), semver.MajorMinor(req.Version))
+		}
+		return nil
+	}
+	if semver.Major(req.Version) != semver.Major(defaultStableOtelColVersion) || semver.Compare(req.Version, defaultStableOtelColVersion) > 0 {
+		return fmt.Errorf("%w %s: it requires %s %s: use an older release of the component, or a newer builder",
+			ErrIncompatibleComponent, defaultStableOtelColVersion, req.Path, req.Version)
+	}
+	return nil
+}
+
+// isCoreModule returns whether the module is a public API module of the core collector, released at the
+// core collector versions. The commands and the internal modules, e.g. internal/panicguard, are not part of
+// the API, and may be required at other versions.
+func isCoreModule(path string) bool {
+	rest, ok := strings.CutPrefix(path, coreModulePrefix)
+	if !ok || strings.HasPrefix(rest, "cmd/") {
+		return false
+	}
+	return !slices.Contains(strings.Split(rest, "/"), "internal")
+}
+
+// componentGoMod returns the go.mod file of the module of the component, read from its local copy when
+// it is replaced by one, and downloaded otherwise, except for the core modules.
+func componentGoMod(cfg *Config, mod Module) (*modfile.File, error) {
+	if mod.Path != "" {
+		return readGoMod(mod.Path)
+	}
+	modPath, version, _ := strings.Cut(mod.GoMod, " ")
+	for _, r := range slices.Concat(cfg.Replaces, cfg.LocalReplaces) {
+		old, replacement, ok := strings.Cut(r, "=>")
+		if oldFields := strings.Fields(old); !ok || len(oldFields) == 0 || oldFields[0] != modPath {
+			continue
+		}
+		fields := strings.Fields(replacement)
+		if len(fields) == 1 && modfile.IsDirectoryPath(fields[0]) {
+			// The relative paths of the replaces are relative to the go.mod of the distribution.
+			return readGoMod(resolveDir(cfg.Distribution.OutputPath, fields[0]))
+		}
+		if len(fields) == 2 {
+			modPath, version = fields[0], fields[1]
+		}
+		break
+	}
+	if isCoreModule(modPath) {
+		return nil, errCoreModule
+	}
+
+	out, err := runGoCommand(cfg, "mod", "download", "-json", modPath+"@"+version)
+	if err != nil {
+		return nil, err
+	}
+	var download struct {
+		GoMod string
+	}
+	if err = json.Unmarshal(out, &download); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Clean(download.GoMod))
+	if err != nil {
+		return nil, err
+	}
+	return modfile.Parse(download.GoMod, data, nil)
+}
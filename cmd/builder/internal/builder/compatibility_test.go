@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
+)
+
+func writeComponentModule(t *testing.T, module string, requires ...string) string {
+	dir := filepath.Join(t.TempDir(), "component")
+	content := "module " + module + "\n\ngo 1.24\n"
+	for _, req := range requires {
+		content += "\nrequire " + req + "\n"
+	}
+	writeTestFile(t, filepath.Join(dir, "go.mod"), content)
+	return dir
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	tests := []struct {
+		name     string
+		requires []string
+		err      string
+	}{
+		{
+			name: "compatible",
+			requires: []string{
+				"go.opentelemetry.io/collector/component " + defaultStableOtelColVersion,
+				"go.opentelemetry.io/collector/processor/xprocessor " + defaultBetaOtelColVersion,
+				"go.opentelemetry.io/collector/pdata v1.0.0",
+				"github.com/stretchr/testify v1.11.1",
+			},
+		},
+		{
+			name: "internal module at another version",
+			requires: []string{
+				"go.opentelemetry.io/collector/internal/panicguard v0.0.0-00010101000000-000000000000",
+				"go.opentelemetry.io/collector/confmap/internal/e2e v0.130.0",
+				"go.opentelemetry.io/collector/processor/xprocessor " + defaultBetaOtelColVersion,
+			},
+		},
+		{
+			name:     "older beta module",
+			requires: []string{"go.opentelemetry.io/collector/processor/xprocessor v0.130.0"},
+			err: `processor "example.com/myprocessor v0.1.0": component incompatible with the core collector version ` + defaultBetaOtelColVersion +
+				`: it requires go.opentelemetry.io/collector/processor/xprocessor v0.130.0: use the release of the component for the collector ` +
+				semver.MajorMinor(defaultBetaOtelColVersion) + `, or the builder of the collector v0.130`,
+		},
+		{
+			name:     "newer stable module",
+			requires: []string{"go.opentelemetry.io/collector/component v1.99.0"},
+			err: `processor "example.com/myprocessor v0.1.0": component incompatible with the core collector version ` + defaultStableOtelColVersion +
+				`: it requires go.opentelemetry.io/collector/component v1.99.0: use an older release of the component, or a newer builder`,
+		},
+		{
+			name:     "next major stable module",
+			requires: []string{"go.opentelemetry.io/collector/component/v2 v2.0.0"},
+			err: `processor "example.com/myprocessor v0.1.0": component incompatible with the core collector version ` + defaultStableOtelColVersion +
+				`: it requires go.opentelemetry.io/collector/component/v2 v2.0.0: use an older release of the component, or a newer builder`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig(t)
+			cfg.ConfmapProviders = nil
+			cfg.Processors = []Module{{GoMod: "example.com/myprocessor v0.1.0", Path: writeComponentModule(t, "example.com/myprocessor", tt.requires...)}}
+			err := checkCompatibility(cfg)
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrIncompatibleComponent)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestIsCoreModule(t *testing.T) {
+	assert.True(t, isCoreModule("go.opentelemetry.io/collector/component"))
+	assert.True(t, isCoreModule("go.opentelemetry.io/collector/processor/xprocessor"))
+	assert.False(t, isCoreModule("go.opentelemetry.io/collector/cmd/builder"))
+	assert.False(t, isCoreModule("go.opentelemetry.io/collector/internal/panicguard"))
+	assert.False(t, isCoreModule("go.opentelemetry.io/collector/confmap/internal/e2e"))
+	assert.False(t, isCoreModule("go.opentelemetry.io/collector-contrib/receiver/foo"))
+	assert.False(t, isCoreModule("github.com/stretchr/testify"))
+}
+
+func TestCheckCompatibilityOfReplacedModule(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.ConfmapProviders = nil
+	cfg.Distribution.OutputPath = t.TempDir()
+	dir := writeComponentModule(t, "example.com/myreceiver", "go.opentelemetry.io/collector/receiver/xreceiver v0.1.0")
+	cfg.Receivers = []Module{{GoMod: "example.com/myreceiver v0.1.0"}}
+	cfg.Replaces = []string{"example.com/myreceiver => " + dir}
+	require.ErrorIs(t, checkCompatibility(cfg), ErrIncompatibleComponent)
+
+	rel, err := filepath.Rel(cfg.Distribution.OutputPath, dir)
+	require.NoError(t, err)
+	cfg.Replaces = nil
+	cfg.LocalReplaces = []string{"example.com/myreceiver v0.1.0 => " + rel}
+	require.ErrorIs(t, checkCompatibility(cfg), ErrIncompatibleComponent)
+}
+
+func TestCheckCompatibilityOfUnavailableModule(t *testing.T) {
+	t.Setenv("GOPROXY", "off")
+	t.Setenv("GOFLAGS", "-mod=mod")
+	cfg := newTestConfig(t)
+	cfg.Logger = zap.NewNop()
+	cfg.ConfmapProviders = nil
+	cfg.Distribution.Go = "go"
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.Exporters = []Module{{GoMod: "example.com/unavailable v0.1.0"}}
+	// The go commands resolving the dependencies report the unavailable module.
+	assert.NoError(t, checkCompatibility(cfg))
+}
+
+func TestCheckCompatibilityOfCoreModule(t *testing.T) {
+	cfg := newTestConfig(t)
+	require.NoError(t, checkCompatibility(cfg))
+
+	cfg.Exporters = []Module{{GoMod: "go.opentelemetry.io/collector/exporter/otlpexporter v0.112.0"}}
+	require.ErrorIs(t, checkCompatibility(cfg), ErrIncompatibleComponent)
+}
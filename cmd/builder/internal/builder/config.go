@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
 	"time"
@@ -25,6 +26,30 @@ const (
 // errMissingGoMod indicates an empty gomod field
 var errMissingGoMod = errors.New("missing gomod specification for module")
 
+// errInvalidSBOMFormat indicates an unsupported SBOM format
+var errInvalidSBOMFormat = errors.New("invalid SBOM format")
+
+// errInvalidEmbeddedConfig indicates an embedded configuration without a valid name or path, or listed twice
+var errInvalidEmbeddedConfig = errors.New("invalid embedded configuration")
+
+// embeddedConfigName matches the valid names of embedded configurations, also their file names
+var embeddedConfigName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// errInvalidImageTag indicates an image tag not allowed by the OCI distribution specification
+var errInvalidImageTag = errors.New("invalid image tag")
+
+// imageTag matches the tags allowed by the OCI distribution specification
+var imageTag = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]{0,127}$`)
+
+// errInvalidSignal indicates a signal unknown to the collector, or listed twice
+var errInvalidSignal = errors.New("invalid signal")
+
+// signals lists the signals of the collector, which the distribution may leave out
+var signals = []string{"traces", "metrics", "logs", "profiles"}
+
+// errInvalidTarget indicates a target missing its goos or goarch, or listed twice
+var errInvalidTarget = errors.New("invalid target")
+
 // Config holds the builder's configuration
 type Config struct {
 	Logger *zap.Logger
@@ -34,11 +59,13 @@ type Config struct {
 	SkipCompilation      bool   `mapstructure:"-"`
 	SkipGetModules       bool   `mapstructure:"-"`
 	SkipStrictVersioning bool   `mapstructure:"-"`
+	SkipCompatibility    bool   `mapstructure:"-"`
 	LDFlags              string `mapstructure:"-"`
 	LDSet                bool   `mapstructure:"-"` // only used to override LDFlags
 	GCFlags              string `mapstructure:"-"`
 	GCSet                bool   `mapstructure:"-"` // only used to override GCFlags
 	Verbose              bool   `mapstructure:"-"`
+	BuilderVersion       string `mapstructure:"-"` // only used in the SBOM and the provenance
 
 	Distribution      Distribution `mapstructure:"dist"`
 	Exporters         []Module     `mapstructure:"exporters"`
@@ -50,8 +77,10 @@ type Config struct {
 	ConfmapConverters []Module     `mapstructure:"converters"`
 	Replaces          []string     `mapstructure:"replaces"`
 	Excludes          []string     `mapstructure:"excludes"`
+	LocalReplaces     []string     `mapstructure:"-"` // the replaces of the local modules, set by ParseModules
 
 	ConfResolver ConfResolver `mapstructure:"conf_resolver"`
+	Image        Image        `mapstructure:"image"`
 
 	downloadModules retry `mapstructure:"-"`
 }
@@ -61,18 +90,127 @@ type ConfResolver struct {
 	// which determines how the Collector interprets URIs that have no scheme, such as ${ENV}.
 	// See https://pkg.go.dev/go.opentelemetry.io/collector/confmap#ResolverSettings for more details.
 	DefaultURIScheme string `mapstructure:"default_uri_scheme"`
+	// When set, will be used to set the CollectorSettings.ConfResolver.URIs value, the configuration
+	// used when the Collector is run without the --config flag, e.g. "embedded:default".
+	DefaultURIs []string `mapstructure:"default_uris"`
+	// The configuration files embedded in the binary, served by its "embedded" provider.
+	EmbeddedConfigs []EmbeddedConfig `mapstructure:"embedded_configs"`
+}
+
+// EmbeddedConfig represents a configuration file embedded in the binary
+type EmbeddedConfig struct {
+	Name string `mapstructure:"name"` // the name of the configuration, in its "embedded:<name>" URI
+	Path string `mapstructure:"path"` // the path to the configuration file, relative to the current dir, or a full path
+}
+
+// Image holds the parameters of the OCI image of the distribution
+type Image struct {
+	Enabled bool   `mapstructure:"enabled"` // whether the OCI image layout of the distribution is written
+	Tag     string `mapstructure:"tag"`     // the tag of the image, the version of the distribution or "latest" when empty
+	Base    string `mapstructure:"base"`    // the OCI image layout or the reference of the base image, distroless static when empty
+	Config  string `mapstructure:"config"`  // the configuration file given to the collector of the image, if any
+	User    string `mapstructure:"user"`    // the user running the collector, "10001" when empty
+}
+
+func (i Image) tag(version string) string {
+	switch {
+	case i.Tag != "":
+		return i.Tag
+	case version != "":
+		return version
+	}
+	return "latest"
+}
+
+func (i Image) base() string {
+	if i.Base == "" {
+		return defaultImageBase
+	}
+	return i.Base
+}
+
+func (i Image) user() string {
+	if i.User == "" {
+		return defaultImageUser
+	}
+	return i.User
 }
 
 // Distribution holds the parameters for the final binary
 type Distribution struct {
-	Module           string `mapstructure:"module"`
-	Name             string `mapstructure:"name"`
-	Go               string `mapstructure:"go"`
-	Description      string `mapstructure:"description"`
-	OutputPath       string `mapstructure:"output_path"`
-	Version          string `mapstructure:"version"`
-	BuildTags        string `mapstructure:"build_tags"`
-	DebugCompilation bool   `mapstructure:"debug_compilation"`
+	Module           string   `mapstructure:"module"`
+	Name             string   `mapstructure:"name"`
+	Go               string   `mapstructure:"go"`
+	Description      string   `mapstructure:"description"`
+	OutputPath       string   `mapstructure:"output_path"`
+	Version          string   `mapstructure:"version"`
+	BuildTags        string   `mapstructure:"build_tags"`
+	DebugCompilation bool     `mapstructure:"debug_compilation"`
+	SBOM             string   `mapstructure:"sbom"`        // the format of the SBOM written next to the binary, none when empty
+	Provenance       bool     `mapstructure:"provenance"`  // whether the SLSA provenance is written next to the binary
+	GoWork           string   `mapstructure:"go_work"`     // the go.work file whose local modules are used, none when empty
+	Targets          []Target `mapstructure:"targets"`     // the platforms to compile the distribution for, the host when empty
+	Signals          []string `mapstructure:"signals"`     // the signals supported by the distribution, all of them when empty
+	FIPS             string   `mapstructure:"fips"`        // the FIPS 140-3 mode of the distribution, go or boringcrypto, none when empty
+	FIPSModule       string   `mapstructure:"fips_module"` // the version of the Go Cryptographic Module in the go FIPS mode, latest when empty
+}
+
+// buildTags returns the build tags of the distribution, with the tags leaving out the code of the signals
+// it does not support.
+func (d Distribution) buildTags() string {
+	var tags []string
+	if d.BuildTags != "" {
+		tags = append(tags, d.BuildTags)
+	}
+	if len(d.Signals) > 0 {
+		for _, signal := range signals {
+			if !slices.Contains(d.Signals, signal) {
+				tags = append(tags, "otelcol_no_"+signal)
+			}
+		}
+	}
+	return strings.Join(tags, ",")
+}
+
+// Target represents a platform the distribution is compiled for
+type Target struct {
+	GOOS       string `mapstructure:"goos"`
+	GOARCH     string `mapstructure:"goarch"`
+	GOARM      string `mapstructure:"goarm"`       // the ARM version, for the arm architecture only. Optional.
+	CGOEnabled bool   `mapstructure:"cgo_enabled"` // cgo is disabled by default, so that the binary is static
+	CC         string `mapstructure:"cc"`          // the C compiler used when cgo is enabled. Optional.
+}
+
+// String returns the name of the target, also the directory of its binary in the output path.
+func (t Target) String() string {
+	if t.GOARM != "" {
+		return t.GOOS + "_" + t.GOARCH + "_" + t.GOARM
+	}
+	return t.GOOS + "_" + t.GOARCH
+}
+
+// binary returns the path of the binary of the target, relative to the output path.
+func (t Target) binary(name string) string {
+	if t.GOOS == "windows" {
+		name += ".exe"
+	}
+	return filepath.Join(t.String(), name)
+}
+
+// env returns the environment variables of the go build command of the target.
+func (t Target) env() []string {
+	env := []string{"GOOS=" + t.GOOS, "GOARCH=" + t.GOARCH}
+	if t.GOARM != "" {
+		env = append(env, "GOARM="+t.GOARM)
+	}
+	if !t.CGOEnabled {
+		return append(env, "CGO_ENABLED=0")
+	}
+	env = append(env, "CGO_ENABLED=1")
+	if t.CC != "" {
+		env = append(env, "CC="+t.CC)
+	}
+	return env
 }
 
 // Module represents a receiver, exporter, processor or extension for the distribution
@@ -136,6 +274,12 @@ func NewDefaultConfig() (*Config, error) {
 // Validate checks whether the current configuration is valid
 func (c *Config) Validate() error {
 	return multierr.Combine(
+		validateSBOMFormat(c.Distribution.SBOM),
+		validateSignals(c.Distribution.Signals),
+		validateTargets(c.Distribution.Targets),
+		validateFIPS(c.Distribution),
+		validateEmbeddedConfigs(c.ConfResolver.EmbeddedConfigs),
+		validateImage(c.Image, c.Distribution.Version),
 		validateModules("extension", c.Extensions),
 		validateModules("receiver", c.Receivers),
 		validateModules("exporter", c.Exporters),
@@ -146,6 +290,66 @@ func (c *Config) Validate() error {
 	)
 }
 
+func validateSBOMFormat(format string) error {
+	switch format {
+	case "", SBOMFormatCycloneDX, SBOMFormatSPDX:
+		return nil
+	}
+	return fmt.Errorf("%w: %q, must be %q or %q", errInvalidSBOMFormat, format, SBOMFormatCycloneDX, SBOMFormatSPDX)
+}
+
+func validateSignals(list []string) error {
+	seen := map[string]bool{}
+	for i, signal := range list {
+		if !slices.Contains(signals, signal) {
+			return fmt.Errorf("signal at index %v: %w: %q, must be one of %q", i, errInvalidSignal, signal, signals)
+		}
+		if seen[signal] {
+			return fmt.Errorf("signal at index %v: %w: %q is listed twice", i, errInvalidSignal, signal)
+		}
+		seen[signal] = true
+	}
+	return nil
+}
+
+func validateTargets(targets []Target) error {
+	seen := map[string]bool{}
+	for i, t := range targets {
+		if t.GOOS == "" || t.GOARCH == "" {
+			return fmt.Errorf("target at index %v: %w: goos and goarch are required", i, errInvalidTarget)
+		}
+		if seen[t.String()] {
+			return fmt.Errorf("target at index %v: %w: %s is listed twice", i, errInvalidTarget, t)
+		}
+		seen[t.String()] = true
+	}
+	return nil
+}
+
+func validateEmbeddedConfigs(configs []EmbeddedConfig) error {
+	seen := map[string]bool{}
+	for i, c := range configs {
+		if !embeddedConfigName.MatchString(c.Name) {
+			return fmt.Errorf("embedded configuration at index %v: %w: the name %q must only contain letters, digits, '_' and '-'", i, errInvalidEmbeddedConfig, c.Name)
+		}
+		if c.Path == "" {
+			return fmt.Errorf("embedded configuration at index %v: %w: the path is required", i, errInvalidEmbeddedConfig)
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("embedded configuration at index %v: %w: %q is listed twice", i, errInvalidEmbeddedConfig, c.Name)
+		}
+		seen[c.Name] = true
+	}
+	return nil
+}
+
+func validateImage(image Image, version string) error {
+	if image.Enabled && !imageTag.MatchString(image.tag(version)) {
+		return fmt.Errorf("%w: %q, set image::tag when the version of the distribution is not a valid tag", errInvalidImageTag, image.tag(version))
+	}
+	return nil
+}
+
 // SetGoPath sets go path
 func (c *Config) SetGoPath() error {
 	if !c.SkipCompilation || !c.SkipGetModules {
@@ -200,7 +404,15 @@ func (c *Config) ParseModules() error {
 	if err != nil {
 		return err
 	}
-	return nil
+
+	if c.Distribution.GoWork != "" {
+		c.Distribution.GoWork, err = filepath.Abs(c.Distribution.GoWork)
+		if err != nil {
+			return fmt.Errorf("couldn't resolve the go.work file: %w", err)
+		}
+	}
+	c.LocalReplaces, err = localReplaces(c)
+	return err
 }
 
 func (c *Config) allComponents() []Module {
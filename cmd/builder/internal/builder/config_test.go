@@ -5,6 +5,7 @@ package builder
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -352,6 +353,48 @@ func TestBuildTagConfig(t *testing.T) {
 	assert.Equal(t, "customTag", cfg.Distribution.BuildTags)
 }
 
+func TestSignalsBuildTags(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		distribution Distribution
+		tags         string
+	}{
+		{
+			name:         "all signals",
+			distribution: Distribution{BuildTags: "customTag"},
+			tags:         "customTag",
+		},
+		{
+			name:         "logs only",
+			distribution: Distribution{Signals: []string{"logs"}},
+			tags:         "otelcol_no_traces,otelcol_no_metrics,otelcol_no_profiles",
+		},
+		{
+			name:         "with build tags",
+			distribution: Distribution{BuildTags: "customTag", Signals: []string{"traces", "metrics", "logs"}},
+			tags:         "customTag,otelcol_no_profiles",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Distribution: tt.distribution}
+			require.NoError(t, cfg.Validate())
+			assert.Equal(t, tt.tags, cfg.Distribution.buildTags())
+		})
+	}
+}
+
+func TestInvalidSignals(t *testing.T) {
+	cfg := Config{
+		Distribution: Distribution{
+			Signals: []string{"logs", "events"},
+		},
+	}
+	require.EqualError(t, cfg.Validate(), `signal at index 1: invalid signal: "events", must be one of ["traces" "metrics" "logs" "profiles"]`)
+
+	cfg.Distribution.Signals = []string{"logs", "logs"}
+	require.ErrorIs(t, cfg.Validate(), errInvalidSignal)
+}
+
 func TestDebugOptionSetConfig(t *testing.T) {
 	cfg := Config{
 		Distribution: Distribution{
@@ -378,3 +421,129 @@ func TestSkipsNilFieldValidation(t *testing.T) {
 	cfg.ConfmapConverters = nil
 	assert.NoError(t, cfg.Validate())
 }
+
+func TestTargets(t *testing.T) {
+	cfg := Config{
+		Distribution: Distribution{
+			Targets: []Target{
+				{GOOS: "linux", GOARCH: "arm", GOARM: "7"},
+				{GOOS: "windows", GOARCH: "amd64", CGOEnabled: true, CC: "x86_64-w64-mingw32-gcc"},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	arm := cfg.Distribution.Targets[0]
+	assert.Equal(t, "linux_arm_7", arm.String())
+	assert.Equal(t, filepath.Join("linux_arm_7", "otelcol"), arm.binary("otelcol"))
+	assert.Equal(t, []string{"GOOS=linux", "GOARCH=arm", "GOARM=7", "CGO_ENABLED=0"}, arm.env())
+
+	windows := cfg.Distribution.Targets[1]
+	assert.Equal(t, filepath.Join("windows_amd64", "otelcol.exe"), windows.binary("otelcol"))
+	assert.Equal(t, []string{"GOOS=windows", "GOARCH=amd64", "CGO_ENABLED=1", "CC=x86_64-w64-mingw32-gcc"}, windows.env())
+}
+
+func TestInvalidTargets(t *testing.T) {
+	cfg := Config{
+		Distribution: Distribution{
+			Targets: []Target{{GOOS: "linux"}},
+		},
+	}
+	require.ErrorIs(t, cfg.Validate(), errInvalidTarget)
+
+	cfg.Distribution.Targets = []Target{{GOOS: "linux", GOARCH: "amd64"}, {GOOS: "linux", GOARCH: "amd64", CGOEnabled: true}}
+	require.EqualError(t, cfg.Validate(), "target at index 1: invalid target: linux_amd64 is listed twice")
+}
+
+func TestFIPS(t *testing.T) {
+	cfg := Config{Distribution: Distribution{FIPS: FIPSModeGo}}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, []string{"GOFIPS140=latest"}, cfg.Distribution.fipsEnv())
+
+	cfg.Distribution.FIPSModule = "v1.0.0"
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, []string{"GOFIPS140=v1.0.0"}, cfg.Distribution.fipsEnv())
+
+	cfg.Distribution = Distribution{
+		FIPS:    FIPSModeBoringCrypto,
+		Targets: []Target{{GOOS: "linux", GOARCH: "amd64", CGOEnabled: true}},
+	}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, []string{"GOEXPERIMENT=boringcrypto", "CGO_ENABLED=1"}, cfg.Distribution.fipsEnv())
+
+	assert.Empty(t, Distribution{}.fipsEnv())
+}
+
+func TestInvalidFIPS(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		dist Distribution
+		err  string
+	}{
+		{
+			name: "unknown mode",
+			dist: Distribution{FIPS: "openssl"},
+			err:  `invalid FIPS mode: "openssl", must be "go" or "boringcrypto"`,
+		},
+		{
+			name: "module without mode",
+			dist: Distribution{FIPSModule: "v1.0.0"},
+			err:  `invalid FIPS mode: fips_module requires fips to be "go"`,
+		},
+		{
+			name: "boringcrypto without cgo",
+			dist: Distribution{FIPS: FIPSModeBoringCrypto, Targets: []Target{{GOOS: "linux", GOARCH: "amd64"}}},
+			err:  `target at index 0: invalid FIPS mode: "boringcrypto" requires cgo_enabled`,
+		},
+		{
+			name: "boringcrypto on an unsupported platform",
+			dist: Distribution{FIPS: FIPSModeBoringCrypto, Targets: []Target{{GOOS: "windows", GOARCH: "amd64", CGOEnabled: true}}},
+			err:  `target at index 0: invalid FIPS mode: "boringcrypto" is only available on ["linux_amd64" "linux_arm64"]`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Distribution: tt.dist}
+			require.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
+
+func TestInvalidEmbeddedConfigs(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		configs []EmbeddedConfig
+		err     string
+	}{
+		{
+			name:    "invalid name",
+			configs: []EmbeddedConfig{{Name: "../default", Path: "config.yaml"}},
+			err:     `embedded configuration at index 0: invalid embedded configuration: the name "../default" must only contain letters, digits, '_' and '-'`,
+		},
+		{
+			name:    "missing path",
+			configs: []EmbeddedConfig{{Name: "default"}},
+			err:     "embedded configuration at index 0: invalid embedded configuration: the path is required",
+		},
+		{
+			name:    "duplicate name",
+			configs: []EmbeddedConfig{{Name: "default", Path: "a.yaml"}, {Name: "default", Path: "b.yaml"}},
+			err:     `embedded configuration at index 1: invalid embedded configuration: "default" is listed twice`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{ConfResolver: ConfResolver{EmbeddedConfigs: tt.configs}}
+			require.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
+
+func TestInvalidImageTag(t *testing.T) {
+	cfg := Config{
+		Distribution: Distribution{Version: "1.0.0+build"},
+		Image:        Image{Enabled: true},
+	}
+	require.ErrorIs(t, cfg.Validate(), errInvalidImageTag)
+
+	cfg.Image.Tag = "1.0.0"
+	require.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	// FIPSModeGo builds the distribution with the Go Cryptographic Module, running in FIPS 140-3 mode by default.
+	FIPSModeGo = "go"
+	// FIPSModeBoringCrypto builds the distribution with BoringCrypto, restricting TLS to the FIPS-approved settings.
+	FIPSModeBoringCrypto = "boringcrypto"
+)
+
+// errInvalidFIPS indicates an unsupported FIPS mode, or a FIPS mode the targets cannot be built with
+var errInvalidFIPS = errors.New("invalid FIPS mode")
+
+// boringCryptoPlatforms are the platforms BoringCrypto is available on.
+var boringCryptoPlatforms = []string{"linux_amd64", "linux_arm64"}
+
+func validateFIPS(d Distribution) error {
+	switch d.FIPS {
+	case "":
+		if d.FIPSModule != "" {
+			return fmt.Errorf("%w: fips_module requires fips to be %q", errInvalidFIPS, FIPSModeGo)
+		}
+		return nil
+	case FIPSModeGo:
+		return nil
+	case FIPSModeBoringCrypto:
+		if d.FIPSModule != "" {
+			return fmt.Errorf("%w: fips_module requires fips to be %q", errInvalidFIPS, FIPSModeGo)
+		}
+		for i, t := range d.Targets {
+			if !t.CGOEnabled {
+				return fmt.Errorf("target at index %v: %w: %q requires cgo_enabled", i, errInvalidFIPS, FIPSModeBoringCrypto)
+			}
+			if !slices.Contains(boringCryptoPlatforms, t.String()) {
+				return fmt.Errorf("target at index %v: %w: %q is only available on %q", i, errInvalidFIPS, FIPSModeBoringCrypto, boringCryptoPlatforms)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %q, must be %q or %q", errInvalidFIPS, d.FIPS, FIPSModeGo, FIPSModeBoringCrypto)
+}
+
+// fipsEnv returns the environment variables of the go build command building the distribution in its
+// FIPS mode, if any.
+func (d Distribution) fipsEnv() []string {
+	switch d.FIPS {
+	case FIPSModeGo:
+		// Building with a Go Cryptographic Module also defaults the fips140 GODEBUG setting to on.
+		module := d.FIPSModule
+		if module == "" {
+			module = "latest"
+		}
+		return []string{"GOFIPS140=" + module}
+	case FIPSModeBoringCrypto:
+		return []string{"GOEXPERIMENT=boringcrypto", "CGO_ENABLED=1"}
+	}
+	return nil
+}
@@ -0,0 +1,468 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"debug/buildinfo"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	mediaTypeImageIndex         = "application/vnd.oci.image.index.v1+json"
+	mediaTypeImageManifest      = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeImageConfig        = "application/vnd.oci.image.config.v1+json"
+	mediaTypeImageLayerGzip     = "application/vnd.oci.image.layer.v1.tar+gzip"
+	mediaTypeImageLayer         = "application/vnd.oci.image.layer.v1.tar"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerLayerGzip    = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+	mediaTypeDockerLayer        = "application/vnd.docker.image.rootfs.diff.tar"
+
+	// annotationRefName names the image in the index of an OCI image layout.
+	annotationRefName = "org.opencontainers.image.ref.name"
+
+	// imageDir is the directory of the OCI image layout in the output path.
+	imageDir = "image"
+	// defaultImageUser is the user running the collector in its images, as in the images of the project.
+	defaultImageUser = "10001"
+	// defaultImageBase is the base image of the images, with the CA certificates, the time zones and the
+	// users of a static distroless image.
+	defaultImageBase = "gcr.io/distroless/static-debian12:nonroot"
+	// scratchImage is the base image standing for none, the image only containing the collector.
+	scratchImage = "scratch"
+)
+
+// errNoBasePlatform is returned when the base image has no manifest for the platform of a binary.
+var errNoBasePlatform = errors.New("no image for the platform in the base image")
+
+// descriptor is an OCI content descriptor.
+type descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Platform    *platform         `json:"platform,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// platform is the platform of an OCI image.
+type platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+func (p platform) String() string {
+	if p.Variant != "" {
+		return p.OS + "/" + p.Architecture + "/" + p.Variant
+	}
+	return p.OS + "/" + p.Architecture
+}
+
+// matches returns whether an image of the given platform runs on this one.
+func (p platform) matches(other platform) bool {
+	return p.OS == other.OS && p.Architecture == other.Architecture &&
+		(p.Variant == "" || other.Variant == "" || p.Variant == other.Variant)
+}
+
+type imageIndex struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType,omitempty"`
+	Manifests     []descriptor `json:"manifests"`
+}
+
+type imageManifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType,omitempty"`
+	Config        descriptor   `json:"config"`
+	Layers        []descriptor `json:"layers"`
+}
+
+// writeImage writes the OCI image layout of the distribution, with an image for each of its linux binaries,
+// when it is enabled.
+func writeImage(cfg *Config, binaries []string) error {
+	if !cfg.Image.Enabled {
+		return nil
+	}
+	created, err := sourceDate(time.Now())
+	if err != nil {
+		return err
+	}
+
+	layout := filepath.Join(cfg.Distribution.OutputPath, imageDir)
+	if err = os.RemoveAll(layout); err != nil {
+		return fmt.Errorf("failed to remove the previous image: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Join(layout, "blobs", "sha256"), 0o750); err != nil {
+		return fmt.Errorf("failed to create the image directory: %w", err)
+	}
+
+	var linuxBinaries []string
+	var platforms []platform
+	for _, binary := range binaries {
+		plat, err := binaryPlatform(binary)
+		if err != nil {
+			return fmt.Errorf("failed to read the platform of %q: %w", binary, err)
+		}
+		if plat.OS != "linux" {
+			cfg.Logger.Info("Skipping the image of the binary, not built for linux", zap.String("binary", binary))
+			continue
+		}
+		linuxBinaries = append(linuxBinaries, binary)
+		platforms = append(platforms, plat)
+	}
+	if len(linuxBinaries) == 0 {
+		return errors.New("failed to write the image: no binary is built for linux, add a linux target")
+	}
+
+	base, err := openBaseImage(cfg.Image.base())
+	if err != nil {
+		return err
+	}
+	if base != nil && base.registry != nil {
+		defer os.RemoveAll(base.dir)
+	}
+	manifests := make([]descriptor, len(linuxBinaries))
+	for i, binary := range linuxBinaries {
+		if manifests[i], err = writePlatformImage(cfg, base, layout, binary, platforms[i], created); err != nil {
+			return fmt.Errorf("failed to write the image for %s: %w", platforms[i], err)
+		}
+	}
+
+	index, err := writeBlobJSON(layout, mediaTypeImageIndex, imageIndex{SchemaVersion: 2, MediaType: mediaTypeImageIndex, Manifests: manifests})
+	if err != nil {
+		return err
+	}
+	index.Annotations = map[string]string{annotationRefName: cfg.Image.tag(cfg.Distribution.Version)}
+	if err = writeJSON(filepath.Join(layout, "oci-layout"), map[string]string{"imageLayoutVersion": "1.0.0"}, ""); err != nil {
+		return err
+	}
+	if err = writeJSON(filepath.Join(layout, "index.json"), imageIndex{SchemaVersion: 2, MediaType: mediaTypeImageIndex, Manifests: []descriptor{index}}, ""); err != nil {
+		return err
+	}
+	cfg.Logger.Info("Image written", zap.String("path", layout), zap.String("tag", index.Annotations[annotationRefName]))
+	return nil
+}
+
+// binaryPlatform returns the platform of the binary, from its build information.
+func binaryPlatform(binary string) (platform, error) {
+	info, err := buildinfo.ReadFile(binary)
+	if err != nil {
+		return platform{}, err
+	}
+	var plat platform
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "GOOS":
+			plat.OS = s.Value
+		case "GOARCH":
+			plat.Architecture = s.Value
+		case "GOARM":
+			// The floating point mode may follow the version, as in "7,softfloat".
+			plat.Variant = "v" + strings.Split(s.Value, ",")[0]
+		}
+	}
+	if plat.Architecture != "arm" {
+		plat.Variant = ""
+	}
+	return plat, nil
+}
+
+// baseImage is the base image of the images of the distribution, in an OCI image layout. The layers of an
+// image pulled from a registry are fetched from it.
+type baseImage struct {
+	dir      string
+	registry *registry
+}
+
+// openBaseImage opens the base image, an OCI image layout directory or a reference to an image pulled from
+// its registry to a temporary directory. There is none for the "scratch" image.
+func openBaseImage(ref string) (*baseImage, error) {
+	if ref == scratchImage {
+		return nil, nil
+	}
+	if info, err := os.Stat(ref); err == nil && info.IsDir() {
+		return &baseImage{dir: ref}, nil
+	}
+	dir, err := os.MkdirTemp("", "ocb-base-image-")
+	if err != nil {
+		return nil, err
+	}
+	r, err := pullImage(ref, dir)
+	if err != nil {
+		return nil, errors.Join(err, os.RemoveAll(dir))
+	}
+	return &baseImage{dir: dir, registry: r}, nil
+}
+
+// copyLayer copies the layer of the base image to the layout.
+func (b *baseImage) copyLayer(layout, digest string) error {
+	if b.registry != nil {
+		return b.registry.fetchBlob(layout, digest)
+	}
+	return copyBlob(b.dir, layout, digest)
+}
+
+// writePlatformImage writes the image of the binary, on top of the base image when there is one, and returns
+// the descriptor of its manifest.
+func writePlatformImage(cfg *Config, base *baseImage, layout, binary string, plat platform, created time.Time) (descriptor, error) {
+	config := map[string]any{}
+	var layers []descriptor
+	if base != nil {
+		manifest, baseConfig, err := readBaseImage(base.dir, plat)
+		if err != nil {
+			return descriptor{}, err
+		}
+		for _, layer := range manifest.Layers {
+			if err = base.copyLayer(layout, layer.Digest); err != nil {
+				return descriptor{}, err
+			}
+			layer.MediaType = strings.NewReplacer(mediaTypeDockerLayerGzip, mediaTypeImageLayerGzip, mediaTypeDockerLayer, mediaTypeImageLayer).Replace(layer.MediaType)
+			layers = append(layers, layer)
+		}
+		config = baseConfig
+	}
+
+	layer, diffID, err := writeDistributionLayer(cfg, layout, binary, created)
+	if err != nil {
+		return descriptor{}, err
+	}
+	layers = append(layers, layer)
+
+	name := cfg.Distribution.Name
+	runConfig, _ := config["config"].(map[string]any)
+	if runConfig == nil {
+		runConfig = map[string]any{}
+	}
+	runConfig["Entrypoint"] = []string{"/" + name}
+	if cfg.Image.Config != "" {
+		runConfig["Cmd"] = []string{"--config=/etc/" + name + "/config.yaml"}
+	} else {
+		// The collector runs with the default configuration, e.g. embedded in its binary.
+		delete(runConfig, "Cmd")
+	}
+	runConfig["User"] = cfg.Image.user()
+	rootfs, _ := config["rootfs"].(map[string]any)
+	diffIDs, _ := rootfs["diff_ids"].([]any)
+	history, _ := config["history"].([]any)
+
+	config["created"] = created.Format(time.RFC3339)
+	config["os"] = plat.OS
+	config["architecture"] = plat.Architecture
+	if plat.Variant != "" {
+		config["variant"] = plat.Variant
+	}
+	config["config"] = runConfig
+	config["rootfs"] = map[string]any{"type": "layers", "diff_ids": append(diffIDs, diffID)}
+	config["history"] = append(history, map[string]any{
+		"created":    created.Format(time.RFC3339),
+		"created_by": "ocb " + cfg.BuilderVersion,
+		"comment":    "OpenTelemetry Collector distribution " + name,
+	})
+
+	configDesc, err := writeBlobJSON(layout, mediaTypeImageConfig, config)
+	if err != nil {
+		return descriptor{}, err
+	}
+	manifest, err := writeBlobJSON(layout, mediaTypeImageManifest, imageManifest{
+		SchemaVersion: 2,
+		MediaType:     mediaTypeImageManifest,
+		Config:        configDesc,
+		Layers:        layers,
+	})
+	if err != nil {
+		return descriptor{}, err
+	}
+	manifest.Platform = &plat
+	return manifest, nil
+}
+
+// writeDistributionLayer writes the layer of the binary and of the configuration of the image, and returns its
+// descriptor and the digest of its uncompressed content.
+func writeDistributionLayer(cfg *Config, layout, binary string, created time.Time) (descriptor, string, error) {
+	tmp, err := os.CreateTemp(filepath.Join(layout, "blobs", "sha256"), "layer-*")
+	if err != nil {
+		return descriptor{}, "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	digest, diffID := sha256.New(), sha256.New()
+	gz := gzip.NewWriter(io.MultiWriter(tmp, digest))
+	tw := tar.NewWriter(io.MultiWriter(gz, diffID))
+	name := cfg.Distribution.Name
+	if err = addFile(tw, binary, name, 0o755, created); err != nil {
+		return descriptor{}, "", err
+	}
+	if cfg.Image.Config != "" {
+		for _, dir := range []string{"etc/", "etc/" + name + "/"} {
+			if err = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: dir, Mode: 0o755, ModTime: created}); err != nil {
+				return descriptor{}, "", err
+			}
+		}
+		if err = addFile(tw, cfg.Image.Config, path.Join("etc", name, "config.yaml"), 0o644, created); err != nil {
+			return descriptor{}, "", err
+		}
+	}
+	if err = tw.Close(); err != nil {
+		return descriptor{}, "", err
+	}
+	if err = gz.Close(); err != nil {
+		return descriptor{}, "", err
+	}
+	info, err := tmp.Stat()
+	if err != nil {
+		return descriptor{}, "", err
+	}
+	if err = tmp.Close(); err != nil {
+		return descriptor{}, "", err
+	}
+
+	desc := descriptor{MediaType: mediaTypeImageLayerGzip, Digest: hashDigest(digest), Size: info.Size()}
+	if err = os.Rename(tmp.Name(), blobPath(layout, desc.Digest)); err != nil {
+		return descriptor{}, "", err
+	}
+	return desc, hashDigest(diffID), nil
+}
+
+func addFile(tw *tar.Writer, src, name string, mode int64, modTime time.Time) error {
+	f, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: mode, Size: info.Size(), ModTime: modTime}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// readBaseImage returns the manifest and the configuration of the image for the platform in the OCI image
+// layout of the base image.
+func readBaseImage(dir string, plat platform) (imageManifest, map[string]any, error) {
+	var index imageIndex
+	data, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	if err != nil {
+		return imageManifest{}, nil, fmt.Errorf("failed to read the base image: %w", err)
+	}
+	if err = json.Unmarshal(data, &index); err != nil {
+		return imageManifest{}, nil, fmt.Errorf("failed to read the base image: %w", err)
+	}
+	manifest, config, err := resolveBaseImage(dir, index.Manifests, plat)
+	if err != nil {
+		return imageManifest{}, nil, fmt.Errorf("%w %s", err, plat)
+	}
+	return manifest, config, nil
+}
+
+func resolveBaseImage(dir string, manifests []descriptor, plat platform) (imageManifest, map[string]any, error) {
+	for _, desc := range manifests {
+		if desc.Platform != nil && !desc.Platform.matches(plat) {
+			continue
+		}
+		switch desc.MediaType {
+		case mediaTypeImageIndex, mediaTypeDockerManifestList:
+			var index imageIndex
+			if err := readBlobJSON(dir, desc.Digest, &index); err != nil {
+				return imageManifest{}, nil, err
+			}
+			manifest, config, err := resolveBaseImage(dir, index.Manifests, plat)
+			if !errors.Is(err, errNoBasePlatform) {
+				return manifest, config, err
+			}
+		case mediaTypeImageManifest, mediaTypeDockerManifest:
+			var manifest imageManifest
+			if err := readBlobJSON(dir, desc.Digest, &manifest); err != nil {
+				return imageManifest{}, nil, err
+			}
+			var config map[string]any
+			if err := readBlobJSON(dir, manifest.Config.Digest, &config); err != nil {
+				return imageManifest{}, nil, err
+			}
+			variant, _ := config["variant"].(string)
+			if (platform{OS: fmt.Sprint(config["os"]), Architecture: fmt.Sprint(config["architecture"]), Variant: variant}).matches(plat) {
+				return manifest, config, nil
+			}
+		}
+	}
+	return imageManifest{}, nil, errNoBasePlatform
+}
+
+// blobPath returns the path of the blob in the OCI image layout.
+func blobPath(layout, digest string) string {
+	return filepath.Join(layout, "blobs", "sha256", strings.TrimPrefix(digest, "sha256:"))
+}
+
+// validDigest checks that the digest of a blob of the base image is a SHA-256 one, which is safe in a path.
+func validDigest(digest string) error {
+	hexDigest, ok := strings.CutPrefix(digest, "sha256:")
+	if _, err := hex.DecodeString(hexDigest); !ok || err != nil || len(hexDigest) != sha256.Size*2 {
+		return fmt.Errorf("unsupported digest %q", digest)
+	}
+	return nil
+}
+
+func readBlobJSON(layout, digest string, v any) error {
+	if err := validDigest(digest); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(blobPath(layout, digest))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func copyBlob(from, to, digest string) error {
+	if err := validDigest(digest); err != nil {
+		return err
+	}
+	src, err := os.Open(blobPath(from, digest))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.Create(blobPath(to, digest))
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+func writeBlobJSON(layout, mediaType string, v any) (descriptor, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return descriptor{}, err
+	}
+	sum := sha256.Sum256(data)
+	desc := descriptor{MediaType: mediaType, Digest: "sha256:" + hex.EncodeToString(sum[:]), Size: int64(len(data))}
+	return desc, os.WriteFile(blobPath(layout, desc.Digest), data, 0o600)
+}
+
+func hashDigest(h hash.Hash) string {
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readImage returns the manifest and the configuration of the image of the current platform in the layout.
+func readImage(t *testing.T, layout string) (map[string]any, map[string]any) {
+	index := readJSON(t, filepath.Join(layout, "index.json"))
+	manifests := index["manifests"].([]any)
+	require.Len(t, manifests, 1)
+	desc := manifests[0].(map[string]any)
+	assert.Equal(t, mediaTypeImageIndex, desc["mediaType"])
+
+	index = readJSON(t, blobPath(layout, desc["digest"].(string)))
+	manifests = index["manifests"].([]any)
+	require.Len(t, manifests, 1)
+	desc = manifests[0].(map[string]any)
+	assert.Equal(t, map[string]any{"os": "linux", "architecture": runtime.GOARCH}, desc["platform"])
+
+	manifest := readJSON(t, blobPath(layout, desc["digest"].(string)))
+	config := readJSON(t, blobPath(layout, manifest["config"].(map[string]any)["digest"].(string)))
+	return manifest, config
+}
+
+func readBlob(t *testing.T, layout, digest string) []byte {
+	data, err := os.ReadFile(blobPath(layout, digest))
+	require.NoError(t, err)
+	return data
+}
+
+// readLayer returns the content of the files of the layer.
+func readLayer(t *testing.T, layout string, layer any) map[string][]byte {
+	f, err := os.Open(blobPath(layout, layer.(map[string]any)["digest"].(string)))
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	files := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+		files[hdr.Name], err = io.ReadAll(tr)
+		require.NoError(t, err)
+	}
+}
+
+func TestWriteImage(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the image is written for linux binaries only")
+	}
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	cfg := newTestConfig(t)
+	binary, _ := newTestBinary(t, cfg)
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("receivers: {}\n"), 0o600))
+	cfg.Image = Image{Enabled: true, Base: scratchImage, Config: configPath}
+	require.NoError(t, writeImage(cfg, []string{binary}))
+
+	layout := filepath.Join(cfg.Distribution.OutputPath, "image")
+	assert.Equal(t, map[string]any{"imageLayoutVersion": "1.0.0"}, readJSON(t, filepath.Join(layout, "oci-layout")))
+	index := readJSON(t, filepath.Join(layout, "index.json"))
+	assert.Equal(t, map[string]any{annotationRefName: "1.2.3"}, index["manifests"].([]any)[0].(map[string]any)["annotations"])
+
+	manifest, config := readImage(t, layout)
+	assert.Equal(t, "2023-11-14T22:13:20Z", config["created"])
+	assert.Equal(t, map[string]any{
+		"Entrypoint": []any{"/otelcol-test"},
+		"Cmd":        []any{"--config=/etc/otelcol-test/config.yaml"},
+		"User":       "10001",
+	}, config["config"])
+	layers := manifest["layers"].([]any)
+	require.Len(t, layers, 1)
+	assert.Equal(t, mediaTypeImageLayerGzip, layers[0].(map[string]any)["mediaType"])
+	assert.Len(t, config["rootfs"].(map[string]any)["diff_ids"], 1)
+
+	files := readLayer(t, layout, layers[0])
+	content, err := os.ReadFile(binary)
+	require.NoError(t, err)
+	assert.Equal(t, content, files["otelcol-test"])
+	assert.Equal(t, []byte("receivers: {}\n"), files["etc/otelcol-test/config.yaml"])
+
+	// The image of a reproducible build is reproducible.
+	indexContent, err := os.ReadFile(filepath.Join(layout, "index.json"))
+	require.NoError(t, err)
+	require.NoError(t, writeImage(cfg, []string{binary}))
+	rewritten, err := os.ReadFile(filepath.Join(layout, "index.json"))
+	require.NoError(t, err)
+	assert.Equal(t, indexContent, rewritten)
+}
+
+func TestWriteImageWithBase(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the image is written for linux binaries only")
+	}
+	// The image of a first distribution is the base of the image of a second one.
+	base := newTestConfig(t)
+	baseBinary, _ := newTestBinary(t, base)
+	base.Image = Image{Enabled: true, Base: scratchImage, User: "65532"}
+	require.NoError(t, writeImage(base, []string{baseBinary}))
+
+	cfg := newTestConfig(t)
+	binary, _ := newTestBinary(t, cfg)
+	cfg.Distribution.Name = "otelcol-custom"
+	require.NoError(t, os.Rename(binary, filepath.Join(cfg.Distribution.OutputPath, "otelcol-custom")))
+	cfg.Image = Image{Enabled: true, Tag: "custom", Base: filepath.Join(base.Distribution.OutputPath, "image")}
+	require.NoError(t, writeImage(cfg, []string{filepath.Join(cfg.Distribution.OutputPath, "otelcol-custom")}))
+
+	layout := filepath.Join(cfg.Distribution.OutputPath, "image")
+	manifest, config := readImage(t, layout)
+	layers := manifest["layers"].([]any)
+	require.Len(t, layers, 2)
+	assert.Contains(t, readLayer(t, layout, layers[0]), "otelcol-test")
+	assert.Contains(t, readLayer(t, layout, layers[1]), "otelcol-custom")
+	assert.Len(t, config["rootfs"].(map[string]any)["diff_ids"], 2)
+	assert.Len(t, config["history"], 2)
+	assert.Equal(t, map[string]any{"Entrypoint": []any{"/otelcol-custom"}, "User": "10001"}, config["config"])
+}
+
+func TestWriteImageWithPulledBase(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the image is written for linux binaries only")
+	}
+	// The image of a first distribution is served by a registry, as the base of the image of a second one.
+	base := newTestConfig(t)
+	baseBinary, _ := newTestBinary(t, base)
+	base.Image = Image{Enabled: true, Base: scratchImage}
+	require.NoError(t, writeImage(base, []string{baseBinary}))
+	baseLayout := filepath.Join(base.Distribution.OutputPath, "image")
+	baseIndex := readJSON(t, filepath.Join(baseLayout, "index.json"))["manifests"].([]any)[0].(map[string]any)
+
+	var srv *httptest.Server
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			assert.Equal(t, "repository:distroless/static:pull", r.URL.Query().Get("scope"))
+			_, _ = w.Write([]byte(`{"token":"anonymous"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer anonymous" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="test",scope="repository:distroless/static:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		kind, reference := path.Split(strings.TrimPrefix(r.URL.Path, "/v2/distroless/static/"))
+		digest := reference
+		if kind == "manifests/" {
+			if reference == "nonroot" {
+				digest = baseIndex["digest"].(string)
+			}
+			var manifest map[string]any
+			assert.NoError(t, json.Unmarshal(readBlob(t, baseLayout, digest), &manifest))
+			w.Header().Set("Content-Type", manifest["mediaType"].(string))
+		}
+		_, _ = w.Write(readBlob(t, baseLayout, digest))
+	}))
+	defer srv.Close()
+	registryClient = srv.Client()
+	defer func() { registryClient = http.DefaultClient }()
+
+	cfg := newTestConfig(t)
+	binary, _ := newTestBinary(t, cfg)
+	cfg.Distribution.Name = "otelcol-custom"
+	require.NoError(t, os.Rename(binary, filepath.Join(cfg.Distribution.OutputPath, "otelcol-custom")))
+	cfg.Image = Image{Enabled: true, Base: strings.TrimPrefix(srv.URL, "https://") + "/distroless/static:nonroot"}
+	require.NoError(t, writeImage(cfg, []string{filepath.Join(cfg.Distribution.OutputPath, "otelcol-custom")}))
+
+	layout := filepath.Join(cfg.Distribution.OutputPath, "image")
+	manifest, _ := readImage(t, layout)
+	layers := manifest["layers"].([]any)
+	require.Len(t, layers, 2)
+	assert.Contains(t, readLayer(t, layout, layers[0]), "otelcol-test")
+	assert.Contains(t, readLayer(t, layout, layers[1]), "otelcol-custom")
+}
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		ref, host, repository, reference string
+	}{
+		{ref: "gcr.io/distroless/static-debian12:nonroot", host: "gcr.io", repository: "distroless/static-debian12", reference: "nonroot"},
+		{ref: "localhost:5000/otelcol", host: "localhost:5000", repository: "otelcol", reference: "latest"},
+		{ref: "alpine:3.20", host: "registry-1.docker.io", repository: "library/alpine", reference: "3.20"},
+		{ref: "otel/opentelemetry-collector", host: "registry-1.docker.io", repository: "otel/opentelemetry-collector", reference: "latest"},
+		{
+			ref:  "gcr.io/distroless/static@sha256:" + strings.Repeat("a", 64),
+			host: "gcr.io", repository: "distroless/static", reference: "sha256:" + strings.Repeat("a", 64),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			r, reference, err := parseImageReference(tt.ref)
+			require.NoError(t, err)
+			assert.Equal(t, tt.host, r.host)
+			assert.Equal(t, tt.repository, r.repository)
+			assert.Equal(t, tt.reference, reference)
+		})
+	}
+	_, _, err := parseImageReference("gcr.io/distroless/static@sha256:../../etc")
+	require.ErrorContains(t, err, "unsupported digest")
+}
+
+func TestWriteImageWithoutLinuxBinary(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.Image = Image{Enabled: true}
+	require.EqualError(t, writeImage(cfg, nil), "failed to write the image: no binary is built for linux, add a linux target")
+}
+
+func TestWriteImageDisabled(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Distribution.OutputPath = t.TempDir()
+	require.NoError(t, writeImage(cfg, nil))
+	assert.NoDirExists(t, filepath.Join(cfg.Distribution.OutputPath, "image"))
+}
+
+func TestInvalidBaseImage(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the image is written for linux binaries only")
+	}
+	cfg := newTestConfig(t)
+	binary, _ := newTestBinary(t, cfg)
+	base := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(base, "index.json"), []byte(`{"schemaVersion":2,"manifests":[{"mediaType":"`+mediaTypeImageManifest+`","digest":"sha256:../../etc/passwd"}]}`), 0o600))
+	cfg.Image = Image{Enabled: true, Base: base}
+	require.ErrorContains(t, writeImage(cfg, []string{binary}), `unsupported digest "sha256:../../etc/passwd"`)
+
+	require.NoError(t, os.WriteFile(filepath.Join(base, "index.json"), []byte(`{"schemaVersion":2,"manifests":[]}`), 0o600))
+	require.ErrorIs(t, writeImage(cfg, []string{binary}), errNoBasePlatform)
+}
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	"go.yaml.in/yaml/v3"
 	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/semver"
 )
@@ -32,13 +33,26 @@ var (
 const otelcolPath = "go.opentelemetry.io/collector/otelcol"
 
 func runGoCommand(cfg *Config, args ...string) ([]byte, error) {
+	return runGoCommandWithEnv(cfg, nil, args...)
+}
+
+// runGoCommandWithEnv runs the go command with the given environment variables added to the ones of the builder.
+func runGoCommandWithEnv(cfg *Config, env []string, args ...string) ([]byte, error) {
 	if cfg.Verbose {
-		cfg.Logger.Info("Running go subcommand.", zap.Any("arguments", args))
+		cfg.Logger.Info("Running go subcommand.", zap.Any("arguments", args), zap.Strings("environment", env))
 	}
 
 	//nolint:gosec // #nosec G204 -- cfg.Distribution.Go is trusted to be a safe path and the caller is assumed to have carried out necessary input validation
 	cmd := exec.Command(cfg.Distribution.Go, args...)
 	cmd.Dir = cfg.Distribution.OutputPath
+	if cfg.Distribution.GoWork != "" {
+		// The local modules of the workspace are replaced in the go.mod of the distribution, which may
+		// be written to a directory of the workspace without being one of its modules.
+		env = append([]string{"GOWORK=off"}, env...)
+	}
+	if len(env) != 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -95,6 +109,19 @@ func Generate(cfg *Config) error {
 			return fmt.Errorf("failed to generate source file %q: %w", tmpl.Name(), err)
 		}
 	}
+	if cfg.Distribution.FIPS == FIPSModeBoringCrypto {
+		if err := processAndWrite(cfg, fipsTemplate, fipsTemplate.Name(), cfg); err != nil {
+			return fmt.Errorf("failed to generate source file %q: %w", fipsTemplate.Name(), err)
+		}
+	}
+	if len(cfg.ConfResolver.EmbeddedConfigs) != 0 {
+		if err := processAndWrite(cfg, embeddedTemplate, embeddedTemplate.Name(), cfg); err != nil {
+			return fmt.Errorf("failed to generate source file %q: %w", embeddedTemplate.Name(), err)
+		}
+		if err := writeEmbeddedConfigs(cfg); err != nil {
+			return err
+		}
+	}
 
 	cfg.Logger.Info("Sources created", zap.String("path", cfg.Distribution.OutputPath))
 	return nil
@@ -112,7 +139,6 @@ func Compile(cfg *Config) error {
 	ldflags := "-s -w" // we strip the symbols by default for smaller binaries
 	gcflags := ""
 
-	args := []string{"build", "-trimpath", "-o", cfg.Distribution.Name}
 	if cfg.Distribution.DebugCompilation {
 		cfg.Logger.Info("Debug compilation is enabled, the debug symbols will be left on the resulting binary")
 		ldflags = cfg.LDFlags
@@ -128,17 +154,45 @@ func Compile(cfg *Config) error {
 		}
 	}
 
-	args = append(args, "-ldflags="+ldflags, "-gcflags="+gcflags)
+	args := []string{"-ldflags=" + ldflags, "-gcflags=" + gcflags}
+
+	if tags := cfg.Distribution.buildTags(); tags != "" {
+		args = append(args, "-tags", tags)
+	}
 
-	if cfg.Distribution.BuildTags != "" {
-		args = append(args, "-tags", cfg.Distribution.BuildTags)
+	fipsEnv := cfg.Distribution.fipsEnv()
+	if cfg.Distribution.FIPS != "" {
+		cfg.Logger.Info("Compiling in FIPS 140-3 mode", zap.String("fips", cfg.Distribution.FIPS), zap.Strings("env", fipsEnv))
 	}
-	if _, err := runGoCommand(cfg, args...); err != nil {
+
+	if len(cfg.Distribution.Targets) == 0 {
+		if err := compile(cfg, cfg.Distribution.Name, fipsEnv, args); err != nil {
+			return err
+		}
+		return writeImage(cfg, []string{filepath.Join(cfg.Distribution.OutputPath, cfg.Distribution.Name)})
+	}
+	binaries := make([]string, 0, len(cfg.Distribution.Targets))
+	for _, target := range cfg.Distribution.Targets {
+		cfg.Logger.Info("Compiling for target", zap.Stringer("target", target))
+		if err := compile(cfg, target.binary(cfg.Distribution.Name), append(target.env(), fipsEnv...), args); err != nil {
+			return fmt.Errorf("target %s: %w", target, err)
+		}
+		binaries = append(binaries, filepath.Join(cfg.Distribution.OutputPath, target.binary(cfg.Distribution.Name)))
+	}
+	return writeImage(cfg, binaries)
+}
+
+// compile builds the binary, at the given path relative to the output path, with the go build flags.
+func compile(cfg *Config, binary string, env, flags []string) error {
+	args := append([]string{"build", "-trimpath", "-o", binary}, flags...)
+	startedOn := time.Now()
+	if _, err := runGoCommandWithEnv(cfg, env, args...); err != nil {
 		return fmt.Errorf("%w: %s", errCompileFailed, err.Error())
 	}
-	cfg.Logger.Info("Compiled", zap.String("binary", fmt.Sprintf("%s/%s", cfg.Distribution.OutputPath, cfg.Distribution.Name)))
+	binary = filepath.Join(cfg.Distribution.OutputPath, binary)
+	cfg.Logger.Info("Compiled", zap.String("binary", binary))
 
-	return nil
+	return writeSupplyChainMetadata(cfg, binary, startedOn, time.Now())
 }
 
 // GetModules retrieves the go modules, updating go.mod and go.sum in the process
@@ -148,6 +202,12 @@ func GetModules(cfg *Config) error {
 		return nil
 	}
 
+	if cfg.SkipCompatibility {
+		cfg.Logger.Info("Skipping the compatibility check of the components.")
+	} else if err := checkCompatibility(cfg); err != nil {
+		return fmt.Errorf("%w. Use --skip-compatibility-check to build the distribution nevertheless", err)
+	}
+
 	if _, err := runGoCommand(cfg, "mod", "tidy", "-compat=1.24"); err != nil {
 		return fmt.Errorf("failed to update go.mod: %w", err)
 	}
@@ -221,6 +281,29 @@ func processAndWrite(cfg *Config, tmpl *template.Template, outFile string, tmplP
 	return tmpl.Execute(out, tmplParams)
 }
 
+// writeEmbeddedConfigs copies the embedded configurations to the embedded directory of the sources,
+// checking that they are YAML maps.
+func writeEmbeddedConfigs(cfg *Config) error {
+	dir := filepath.Join(cfg.Distribution.OutputPath, "embedded")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create the embedded configurations directory: %w", err)
+	}
+	for _, c := range cfg.ConfResolver.EmbeddedConfigs {
+		data, err := os.ReadFile(filepath.Clean(c.Path))
+		if err != nil {
+			return fmt.Errorf("failed to read the embedded configuration %q: %w", c.Name, err)
+		}
+		var conf map[string]any
+		if err = yaml.Unmarshal(data, &conf); err != nil {
+			return fmt.Errorf("invalid embedded configuration %q: %w", c.Name, err)
+		}
+		if err = os.WriteFile(filepath.Join(dir, c.Name+".yaml"), data, 0o600); err != nil {
+			return fmt.Errorf("failed to write the embedded configuration %q: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
 func readGoModFile(cfg *Config) (string, map[string]string, error) {
 	var modPath string
 	stdout, err := runGoCommand(cfg, "mod", "edit", "-print")
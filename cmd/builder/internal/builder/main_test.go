@@ -4,9 +4,11 @@
 package builder
 
 import (
+	"debug/buildinfo"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
 	"runtime"
@@ -68,6 +70,7 @@ var replaceModules = []string{
 	"/consumer/consumertest",
 	"/connector",
 	"/connector/connectortest",
+	"/connector/metadataroutingconnector",
 	"/connector/xconnector",
 	"/exporter",
 	"/exporter/debugexporter",
@@ -85,11 +88,18 @@ var replaceModules = []string{
 	"/extension/extensionmiddleware",
 	"/extension/extensionmiddleware/extensionmiddlewaretest",
 	"/extension/extensiontest",
+	"/extension/filestorageextension",
+	"/extension/gctuningextension",
+	"/extension/healthextension",
+	"/extension/opampextension",
+	"/extension/runtimeinfoextension",
 	"/extension/zpagesextension",
 	"/extension/xextension",
 	"/featuregate",
 	"/internal/memorylimiter",
 	"/internal/fanoutconsumer",
+	"/internal/panicguard",
+	"/internal/redact",
 	"/internal/sharedcomponent",
 	"/internal/telemetry",
 	"/otelcol",
@@ -340,6 +350,97 @@ func TestGenerateAndCompile(t *testing.T) {
 	}
 }
 
+func TestCompileTargets(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.Distribution.Name = "otelcol-targets"
+	cfg.Distribution.Targets = []Target{
+		{GOOS: "linux", GOARCH: "arm64"},
+		{GOOS: "windows", GOARCH: "amd64"},
+	}
+	cfg.Replaces = append(cfg.Replaces, generateReplaces()...)
+	require.NoError(t, cfg.Validate())
+	require.NoError(t, cfg.SetGoPath())
+	require.NoError(t, cfg.ParseModules())
+	require.NoError(t, GenerateAndCompile(cfg))
+
+	assert.FileExists(t, filepath.Join(cfg.Distribution.OutputPath, "linux_arm64", "otelcol-targets"))
+	assert.FileExists(t, filepath.Join(cfg.Distribution.OutputPath, "windows_amd64", "otelcol-targets.exe"))
+	assert.NoFileExists(t, filepath.Join(cfg.Distribution.OutputPath, "otelcol-targets"))
+}
+
+func TestGenerateAndCompileEmbeddedConfigs(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("receivers:\n  embeddedtest:\n"), 0o600))
+
+	cfg := newTestConfig(t)
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.Distribution.Name = "otelcol-embedded"
+	cfg.ConfResolver.EmbeddedConfigs = []EmbeddedConfig{{Name: "default", Path: configPath}}
+	cfg.ConfResolver.DefaultURIs = []string{"embedded:default"}
+	cfg.Replaces = append(cfg.Replaces, generateReplaces()...)
+	require.NoError(t, cfg.Validate())
+	require.NoError(t, cfg.SetGoPath())
+	require.NoError(t, cfg.ParseModules())
+	require.NoError(t, GenerateAndCompile(cfg))
+
+	binary := filepath.Join(cfg.Distribution.OutputPath, cfg.Distribution.Name)
+	// The embedded configuration is used without the --config flag, and fails on its unknown receiver.
+	out, err := exec.Command(binary, "validate").CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(out), "embeddedtest")
+
+	out, err = exec.Command(binary, "validate", "--config=embedded:missing").CombinedOutput()
+	require.Error(t, err)
+	assert.Contains(t, string(out), `no configuration "missing" is embedded, the embedded configurations are: default`)
+}
+
+func TestGenerateAndCompileFIPS(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.Distribution.Name = "otelcol-fips"
+	cfg.Distribution.FIPS = FIPSModeGo
+	cfg.Replaces = append(cfg.Replaces, generateReplaces()...)
+	require.NoError(t, cfg.Validate())
+	require.NoError(t, cfg.SetGoPath())
+	require.NoError(t, cfg.ParseModules())
+	require.NoError(t, GenerateAndCompile(cfg))
+
+	info, err := buildinfo.ReadFile(filepath.Join(cfg.Distribution.OutputPath, cfg.Distribution.Name))
+	require.NoError(t, err)
+	settings := map[string]string{}
+	for _, s := range info.Settings {
+		settings[s.Key] = s.Value
+	}
+	assert.Equal(t, "latest", settings["GOFIPS140"])
+	assert.Contains(t, settings["DefaultGODEBUG"], "fips140=on")
+	assert.NoFileExists(t, filepath.Join(cfg.Distribution.OutputPath, "fips.go"))
+}
+
+func TestGenerateFIPSBoringCrypto(t *testing.T) {
+	cfg := newInitializedConfig(t)
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.Distribution.FIPS = FIPSModeBoringCrypto
+	require.NoError(t, Generate(cfg))
+
+	content, err := os.ReadFile(filepath.Join(cfg.Distribution.OutputPath, "fips.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `import _ "crypto/tls/fipsonly"`)
+}
+
+func TestGenerateInvalidEmbeddedConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("- not a map"), 0o600))
+
+	cfg := newInitializedConfig(t)
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.ConfResolver.EmbeddedConfigs = []EmbeddedConfig{{Name: "default", Path: configPath}}
+	require.ErrorContains(t, Generate(cfg), `invalid embedded configuration "default"`)
+
+	cfg.ConfResolver.EmbeddedConfigs = []EmbeddedConfig{{Name: "default", Path: filepath.Join(t.TempDir(), "missing.yaml")}}
+	require.ErrorContains(t, Generate(cfg), `failed to read the embedded configuration "default"`)
+}
+
 // Test that the go.mod files that other tests in this file
 // may generate have all their modules covered by our
 // "replace" statements created in `generateReplaces`.
@@ -399,11 +500,32 @@ func TestReplaceStatementsAreComplete(t *testing.T) {
 	}, usedNames)
 	require.NoError(t, err)
 	cfg.Extensions, err = parseModules([]Module{
+		{
+			GoMod: "go.opentelemetry.io/collector/extension/filestorageextension v1.9999.9999",
+		},
+		{
+			GoMod: "go.opentelemetry.io/collector/extension/gctuningextension v1.9999.9999",
+		},
+		{
+			GoMod: "go.opentelemetry.io/collector/extension/healthextension v1.9999.9999",
+		},
+		{
+			GoMod: "go.opentelemetry.io/collector/extension/opampextension v1.9999.9999",
+		},
+		{
+			GoMod: "go.opentelemetry.io/collector/extension/runtimeinfoextension v1.9999.9999",
+		},
 		{
 			GoMod: "go.opentelemetry.io/collector/extension/zpagesextension v1.9999.9999",
 		},
 	}, usedNames)
 	require.NoError(t, err)
+	cfg.Connectors, err = parseModules([]Module{
+		{
+			GoMod: "go.opentelemetry.io/collector/connector/metadataroutingconnector v1.9999.9999",
+		},
+	}, usedNames)
+	require.NoError(t, err)
 	cfg.Processors, err = parseModules([]Module{
 		{
 			GoMod: "go.opentelemetry.io/collector/processor/batchprocessor v1.9999.9999",
@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// registryClient is the HTTP client pulling the base images from their registries.
+var registryClient = http.DefaultClient
+
+// manifestMediaTypes are the media types of the manifests and indexes accepted from the registries.
+var manifestMediaTypes = []string{mediaTypeImageIndex, mediaTypeImageManifest, mediaTypeDockerManifestList, mediaTypeDockerManifest}
+
+// registry pulls the manifests and the blobs of an image repository from its registry, with the OCI
+// distribution API.
+type registry struct {
+	host       string
+	repository string
+	token      string
+}
+
+// parseImageReference parses a reference to an image in a registry, e.g. "gcr.io/distroless/static:nonroot",
+// into its registry, its repository, and its tag or digest. The images without registry are on Docker Hub.
+func parseImageReference(ref string) (*registry, string, error) {
+	name, reference := ref, "latest"
+	if i := strings.LastIndex(ref, "@"); i >= 0 {
+		name, reference = ref[:i], ref[i+1:]
+		if err := validDigest(reference); err != nil {
+			return nil, "", fmt.Errorf("invalid image reference %q: %w", ref, err)
+		}
+	} else if i = strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		name, reference = ref[:i], ref[i+1:]
+	}
+	host, repository, ok := strings.Cut(name, "/")
+	if !ok || (!strings.ContainsAny(host, ".:") && host != "localhost") {
+		host, repository = "registry-1.docker.io", name
+		if !strings.Contains(repository, "/") {
+			repository = "library/" + repository
+		}
+	}
+	if repository == "" || reference == "" {
+		return nil, "", fmt.Errorf("invalid image reference %q", ref)
+	}
+	return &registry{host: host, repository: repository}, reference, nil
+}
+
+// pullImage pulls the image to an OCI image layout in dir: its index or manifest, and the manifests and
+// configurations of its platforms. The layers are fetched by fetchBlob, only for the platforms used.
+func pullImage(ref, dir string) (*registry, error) {
+	r, reference, err := parseImageReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(filepath.Join(dir, "blobs", "sha256"), 0o750); err != nil {
+		return nil, err
+	}
+	desc, err := r.pullManifest(dir, reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull the base image %q: %w", ref, err)
+	}
+	if err = writeJSON(filepath.Join(dir, "index.json"), imageIndex{SchemaVersion: 2, MediaType: mediaTypeImageIndex, Manifests: []descriptor{desc}}, ""); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// pullManifest pulls the manifest or index with the given tag or digest to the blobs of the layout, with the
+// manifests and configurations it references, and returns its descriptor.
+func (r *registry) pullManifest(dir, reference string) (descriptor, error) {
+	resp, err := r.get("manifests", reference, manifestMediaTypes)
+	if err != nil {
+		return descriptor{}, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return descriptor{}, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	sum := sha256.Sum256(data)
+	desc := descriptor{MediaType: mediaType, Digest: "sha256:" + hex.EncodeToString(sum[:]), Size: int64(len(data))}
+	if strings.HasPrefix(reference, "sha256:") && reference != desc.Digest {
+		return descriptor{}, fmt.Errorf("digest mismatch of the manifest %s: got %s", reference, desc.Digest)
+	}
+	if err = os.WriteFile(blobPath(dir, desc.Digest), data, 0o600); err != nil {
+		return descriptor{}, err
+	}
+
+	switch mediaType {
+	case mediaTypeImageIndex, mediaTypeDockerManifestList:
+		var index imageIndex
+		if err = json.Unmarshal(data, &index); err != nil {
+			return descriptor{}, err
+		}
+		for _, m := range index.Manifests {
+			if err = validDigest(m.Digest); err != nil {
+				return descriptor{}, err
+			}
+			if _, err = r.pullManifest(dir, m.Digest); err != nil {
+				return descriptor{}, err
+			}
+		}
+	case mediaTypeImageManifest, mediaTypeDockerManifest:
+		var manifest imageManifest
+		if err = json.Unmarshal(data, &manifest); err != nil {
+			return descriptor{}, err
+		}
+		if err = r.fetchBlob(dir, manifest.Config.Digest); err != nil {
+			return descriptor{}, err
+		}
+	default:
+		return descriptor{}, fmt.Errorf("unsupported media type %q of the manifest", mediaType)
+	}
+	return desc, nil
+}
+
+// fetchBlob fetches the blob with the digest to the blobs of the layout, unless it is there already.
+func (r *registry) fetchBlob(dir, digest string) error {
+	if err := validDigest(digest); err != nil {
+		return err
+	}
+	path := blobPath(dir, digest)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	resp, err := r.get("blobs", digest, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	tmp, err := os.CreateTemp(filepath.Dir(path), "blob-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+	h := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(tmp, h), resp.Body); err != nil {
+		return fmt.Errorf("failed to fetch the blob %s: %w", digest, err)
+	}
+	if got := hashDigest(h); got != digest {
+		return fmt.Errorf("digest mismatch of the blob %s: got %s", digest, got)
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// get gets the manifest or the blob from the registry, authenticating with an anonymous bearer token when
+// the registry requires one.
+func (r *registry) get(kind, reference string, accept []string) (*http.Response, error) {
+	u := "https://" + r.host + "/v2/" + r.repository + "/" + kind + "/" + reference
+	resp, err := r.do(u, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && r.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if r.token, err = fetchToken(challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = r.do(u, accept); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to get %s: %s", u, resp.Status)
+	}
+	return resp, nil
+}
+
+func (r *registry) do(u string, accept []string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	if len(accept) > 0 {
+		req.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	return registryClient.Do(req)
+}
+
+// fetchToken fetches an anonymous token from the authorization service of the bearer challenge of a registry.
+func fetchToken(challenge string) (string, error) {
+	scheme, params, ok := strings.Cut(challenge, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication challenge %q of the registry", challenge)
+	}
+	var realm string
+	query := url.Values{}
+	for _, param := range strings.Split(params, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		value = strings.Trim(value, `"`)
+		switch key {
+		case "realm":
+			realm = value
+		case "service", "scope":
+			query.Set(key, value)
+		}
+	}
+	if realm == "" {
+		return "", fmt.Errorf("no realm in the authentication challenge %q of the registry", challenge)
+	}
+	resp, err := registryClient.Get(realm + "?" + query.Encode())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get a token from %s: %s", realm, resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return "", errors.New("no token in the response of the authorization service of the registry")
+	}
+	return token.Token, nil
+}
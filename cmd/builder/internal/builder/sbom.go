@@ -0,0 +1,364 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"crypto/sha256"
+	"debug/buildinfo"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// SBOMFormatCycloneDX is the CycloneDX 1.5 JSON format.
+	SBOMFormatCycloneDX = "cyclonedx"
+	// SBOMFormatSPDX is the SPDX 2.3 JSON format.
+	SBOMFormatSPDX = "spdx"
+
+	// provenanceBuildType identifies how the builder builds a distribution, in its provenance.
+	provenanceBuildType = "https://go.opentelemetry.io/collector/cmd/builder/buildtypes/v1"
+	// componentKindProperty names the kind of the collector components in the SBOM.
+	componentKindProperty = "otelcol:component:kind"
+)
+
+// builtDistribution is what the SBOM and the provenance of a distribution describe, read from the
+// build information embedded in its binary.
+type builtDistribution struct {
+	name      string
+	version   string
+	digest    string
+	goVersion string
+	settings  map[string]string
+	modules   []builtModule
+	// timestamp is the time of the SBOM, set by SOURCE_DATE_EPOCH for reproducible builds.
+	timestamp time.Time
+}
+
+// builtModule is a Go module linked in the binary of a distribution.
+type builtModule struct {
+	path    string
+	version string
+	sum     string
+	// kind is the kind of the components of the module listed in the build configuration, if any.
+	kind string
+}
+
+func (m builtModule) purl() string {
+	if m.version == "" {
+		return "pkg:golang/" + m.path
+	}
+	return "pkg:golang/" + m.path + "@" + m.version
+}
+
+// writeSupplyChainMetadata writes the SBOM and the provenance of the binary, as configured, next to it.
+func writeSupplyChainMetadata(cfg *Config, binary string, startedOn, finishedOn time.Time) error {
+	if cfg.Distribution.SBOM == "" && !cfg.Distribution.Provenance {
+		return nil
+	}
+	dist, err := readBuiltDistribution(cfg, binary)
+	if err != nil {
+		return fmt.Errorf("failed to read the build information of the distribution: %w", err)
+	}
+
+	switch cfg.Distribution.SBOM {
+	case SBOMFormatCycloneDX:
+		err = writeJSON(binary+".cdx.json", cycloneDXBOM(cfg, dist), "  ")
+	case SBOMFormatSPDX:
+		err = writeJSON(binary+".spdx.json", spdxDocument(cfg, dist), "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write the SBOM: %w", err)
+	}
+	if cfg.Distribution.SBOM != "" {
+		cfg.Logger.Info("SBOM written", zap.String("format", cfg.Distribution.SBOM))
+	}
+
+	if cfg.Distribution.Provenance {
+		// The statements of in-toto bundles are written one per line.
+		if err = writeJSON(binary+".intoto.jsonl", provenanceStatement(cfg, dist, startedOn, finishedOn), ""); err != nil {
+			return fmt.Errorf("failed to write the provenance: %w", err)
+		}
+		cfg.Logger.Info("Provenance written", zap.String("path", binary+".intoto.jsonl"))
+	}
+	return nil
+}
+
+func readBuiltDistribution(cfg *Config, binary string) (*builtDistribution, error) {
+	info, err := buildinfo.ReadFile(binary)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filepath.Clean(binary))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return nil, err
+	}
+
+	dist := &builtDistribution{
+		name:      cfg.Distribution.Name,
+		version:   cfg.Distribution.Version,
+		digest:    hex.EncodeToString(h.Sum(nil)),
+		goVersion: info.GoVersion,
+		settings:  map[string]string{},
+	}
+	if dist.timestamp, err = sourceDate(time.Now()); err != nil {
+		return nil, err
+	}
+	for _, s := range info.Settings {
+		dist.settings[s.Key] = s.Value
+	}
+
+	kinds := map[string]string{}
+	for kind, mods := range map[string][]Module{
+		"extension": cfg.Extensions,
+		"receiver":  cfg.Receivers,
+		"exporter":  cfg.Exporters,
+		"processor": cfg.Processors,
+		"connector": cfg.Connectors,
+		"provider":  cfg.ConfmapProviders,
+		"converter": cfg.ConfmapConverters,
+	} {
+		for _, mod := range mods {
+			path, _, _ := strings.Cut(mod.GoMod, " ")
+			kinds[path] = kind
+		}
+	}
+	for _, dep := range info.Deps {
+		mod := builtModule{path: dep.Path, version: dep.Version, sum: dep.Sum, kind: kinds[dep.Path]}
+		// The replacing module is the one linked in the binary.
+		if dep.Replace != nil {
+			mod.path, mod.version, mod.sum = dep.Replace.Path, dep.Replace.Version, dep.Replace.Sum
+		}
+		dist.modules = append(dist.modules, mod)
+	}
+	return dist, nil
+}
+
+// serialNumber returns a UUID derived from the digest of the binary, so that the SBOM of a reproducible
+// build is reproducible too.
+func (d *builtDistribution) serialNumber() string {
+	b, _ := hex.DecodeString(d.digest)
+	b[6] = (b[6] & 0x0f) | 0x80 // version 8, custom
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 9562 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+func cycloneDXBOM(cfg *Config, dist *builtDistribution) map[string]any {
+	mainRef := builtModule{path: cfg.Distribution.Module, version: dist.version}.purl()
+	components := make([]map[string]any, 0, len(dist.modules))
+	refs := make([]string, 0, len(dist.modules))
+	for _, mod := range dist.modules {
+		c := map[string]any{
+			"type":    "library",
+			"bom-ref": mod.purl(),
+			"name":    mod.path,
+			"version": mod.version,
+			"purl":    mod.purl(),
+			"scope":   "required",
+		}
+		if mod.kind != "" {
+			c["properties"] = []map[string]string{{"name": componentKindProperty, "value": mod.kind}}
+		}
+		components = append(components, c)
+		refs = append(refs, mod.purl())
+	}
+	return map[string]any{
+		"bomFormat":    "CycloneDX",
+		"specVersion":  "1.5",
+		"serialNumber": "urn:uuid:" + dist.serialNumber(),
+		"version":      1,
+		"metadata": map[string]any{
+			"timestamp": dist.timestamp.Format(time.RFC3339),
+			"tools": map[string]any{"components": []map[string]any{{
+				"type":    "application",
+				"name":    "ocb",
+				"version": cfg.BuilderVersion,
+			}}},
+			"component": map[string]any{
+				"type":        "application",
+				"bom-ref":     mainRef,
+				"name":        dist.name,
+				"version":     dist.version,
+				"description": cfg.Distribution.Description,
+				"purl":        mainRef,
+				"hashes":      []map[string]string{{"alg": "SHA-256", "content": dist.digest}},
+				"properties": []map[string]string{
+					{"name": "go:version", "value": dist.goVersion},
+					{"name": "go:GOOS", "value": dist.settings["GOOS"]},
+					{"name": "go:GOARCH", "value": dist.settings["GOARCH"]},
+				},
+			},
+		},
+		"components":   components,
+		"dependencies": []map[string]any{{"ref": mainRef, "dependsOn": refs}},
+	}
+}
+
+func spdxDocument(cfg *Config, dist *builtDistribution) map[string]any {
+	const mainID = "SPDXRef-Package-distribution"
+	packages := []map[string]any{{
+		"name":                  dist.name,
+		"SPDXID":                mainID,
+		"versionInfo":           dist.version,
+		"downloadLocation":      "NOASSERTION",
+		"filesAnalyzed":         false,
+		"licenseConcluded":      "NOASSERTION",
+		"licenseDeclared":       "NOASSERTION",
+		"copyrightText":         "NOASSERTION",
+		"primaryPackagePurpose": "APPLICATION",
+		"description":           cfg.Distribution.Description,
+		"checksums":             []map[string]string{{"algorithm": "SHA256", "checksumValue": dist.digest}},
+	}}
+	relationships := []map[string]string{{
+		"spdxElementId":      "SPDXRef-DOCUMENT",
+		"relationshipType":   "DESCRIBES",
+		"relatedSpdxElement": mainID,
+	}}
+	for i, mod := range dist.modules {
+		id := fmt.Sprintf("SPDXRef-Package-%d", i+1)
+		pkg := map[string]any{
+			"name":                  mod.path,
+			"SPDXID":                id,
+			"versionInfo":           mod.version,
+			"downloadLocation":      "NOASSERTION",
+			"filesAnalyzed":         false,
+			"licenseConcluded":      "NOASSERTION",
+			"licenseDeclared":       "NOASSERTION",
+			"copyrightText":         "NOASSERTION",
+			"primaryPackagePurpose": "LIBRARY",
+			"externalRefs": []map[string]string{{
+				"referenceCategory": "PACKAGE-MANAGER",
+				"referenceType":     "purl",
+				"referenceLocator":  mod.purl(),
+			}},
+		}
+		if mod.kind != "" {
+			pkg["comment"] = "OpenTelemetry Collector " + mod.kind + " module"
+		}
+		packages = append(packages, pkg)
+		relationships = append(relationships, map[string]string{
+			"spdxElementId":      mainID,
+			"relationshipType":   "DEPENDS_ON",
+			"relatedSpdxElement": id,
+		})
+	}
+	return map[string]any{
+		"spdxVersion":       "SPDX-2.3",
+		"dataLicense":       "CC0-1.0",
+		"SPDXID":            "SPDXRef-DOCUMENT",
+		"name":              dist.name,
+		"documentNamespace": "https://go.opentelemetry.io/collector/cmd/builder/spdx/" + dist.name + "-" + dist.serialNumber(),
+		"creationInfo": map[string]any{
+			"created":  dist.timestamp.Format(time.RFC3339),
+			"creators": []string{"Tool: ocb-" + cfg.BuilderVersion},
+		},
+		"packages":      packages,
+		"relationships": relationships,
+	}
+}
+
+// provenanceStatement returns the in-toto statement of the SLSA provenance of the binary.
+func provenanceStatement(cfg *Config, dist *builtDistribution, startedOn, finishedOn time.Time) map[string]any {
+	dependencies := make([]map[string]any, 0, len(dist.modules))
+	for _, mod := range dist.modules {
+		dep := map[string]any{"uri": mod.purl()}
+		if mod.sum != "" {
+			dep["annotations"] = map[string]string{"go.sum": mod.sum}
+		}
+		dependencies = append(dependencies, dep)
+	}
+	modules := func(mods []Module) []string {
+		gomods := make([]string, len(mods))
+		for i, mod := range mods {
+			gomods[i] = mod.GoMod
+		}
+		return gomods
+	}
+	return map[string]any{
+		"_type": "https://in-toto.io/Statement/v1",
+		"subject": []map[string]any{{
+			"name":   dist.name,
+			"digest": map[string]string{"sha256": dist.digest},
+		}},
+		"predicateType": "https://slsa.dev/provenance/v1",
+		"predicate": map[string]any{
+			"buildDefinition": map[string]any{
+				"buildType": provenanceBuildType,
+				"externalParameters": map[string]any{
+					"dist": map[string]any{
+						"module":            cfg.Distribution.Module,
+						"name":              cfg.Distribution.Name,
+						"version":           cfg.Distribution.Version,
+						"build_tags":        cfg.Distribution.buildTags(),
+						"debug_compilation": cfg.Distribution.DebugCompilation,
+						"fips":              cfg.Distribution.FIPS,
+					},
+					"receivers":  modules(cfg.Receivers),
+					"processors": modules(cfg.Processors),
+					"exporters":  modules(cfg.Exporters),
+					"connectors": modules(cfg.Connectors),
+					"extensions": modules(cfg.Extensions),
+					"providers":  modules(cfg.ConfmapProviders),
+					"converters": modules(cfg.ConfmapConverters),
+					"replaces":   cfg.Replaces,
+				},
+				"internalParameters": map[string]any{
+					"go":       dist.goVersion,
+					"settings": dist.settings,
+				},
+				"resolvedDependencies": dependencies,
+			},
+			"runDetails": map[string]any{
+				"builder": map[string]any{
+					"id": "https://go.opentelemetry.io/collector/cmd/builder@" + cfg.BuilderVersion,
+				},
+				"metadata": map[string]any{
+					"startedOn":  startedOn.UTC().Format(time.RFC3339),
+					"finishedOn": finishedOn.UTC().Format(time.RFC3339),
+				},
+			},
+		},
+	}
+}
+
+// sourceDate returns the time set by SOURCE_DATE_EPOCH for reproducible builds, and the given time otherwise.
+func sourceDate(otherwise time.Time) (time.Time, error) {
+	epoch := os.Getenv("SOURCE_DATE_EPOCH")
+	if epoch == "" {
+		return otherwise.UTC(), nil
+	}
+	sec, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q: %w", epoch, err)
+	}
+	return time.Unix(sec, 0).UTC(), nil
+}
+
+// writeJSON writes the value as JSON, indented when indent is not empty, and on a single line otherwise.
+func writeJSON(path string, v any, indent string) error {
+	var content []byte
+	var err error
+	if indent != "" {
+		content, err = json.MarshalIndent(v, "", indent)
+	} else {
+		content, err = json.Marshal(v)
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0o600)
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBinary copies the test binary, whose build information is read, as the binary of the distribution.
+func newTestBinary(t *testing.T, cfg *Config) (string, string) {
+	content, err := os.ReadFile(os.Args[0])
+	require.NoError(t, err)
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.Distribution.Name = "otelcol-test"
+	cfg.Distribution.Version = "1.2.3"
+	cfg.BuilderVersion = "v0.137.0"
+	cfg.Receivers = []Module{{GoMod: "github.com/stretchr/testify v1.11.1"}}
+	binary := filepath.Join(cfg.Distribution.OutputPath, cfg.Distribution.Name)
+	require.NoError(t, os.WriteFile(binary, content, 0o600))
+	digest := sha256.Sum256(content)
+	return binary, hex.EncodeToString(digest[:])
+}
+
+func readJSON(t *testing.T, path string) map[string]any {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var v map[string]any
+	require.NoError(t, json.Unmarshal(content, &v))
+	return v
+}
+
+func TestWriteCycloneDXSBOM(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	cfg := newTestConfig(t)
+	cfg.Distribution.SBOM = SBOMFormatCycloneDX
+	binary, digest := newTestBinary(t, cfg)
+	require.NoError(t, writeSupplyChainMetadata(cfg, binary, time.Now(), time.Now()))
+
+	bom := readJSON(t, binary+".cdx.json")
+	assert.Equal(t, "CycloneDX", bom["bomFormat"])
+	assert.Equal(t, "1.5", bom["specVersion"])
+	assert.Regexp(t, "^urn:uuid:"+digest[:8]+"-", bom["serialNumber"])
+	metadata := bom["metadata"].(map[string]any)
+	assert.Equal(t, "2023-11-14T22:13:20Z", metadata["timestamp"])
+	component := metadata["component"].(map[string]any)
+	assert.Equal(t, "otelcol-test", component["name"])
+	assert.Equal(t, "pkg:golang/go.opentelemetry.io/collector/cmd/builder@1.2.3", component["purl"])
+	assert.Equal(t, []any{map[string]any{"alg": "SHA-256", "content": digest}}, component["hashes"])
+
+	var testify map[string]any
+	for _, c := range bom["components"].([]any) {
+		if c.(map[string]any)["name"] == "github.com/stretchr/testify" {
+			testify = c.(map[string]any)
+		}
+	}
+	require.NotNil(t, testify)
+	assert.Equal(t, "pkg:golang/github.com/stretchr/testify@v1.11.1", testify["purl"])
+	assert.Equal(t, []any{map[string]any{"name": componentKindProperty, "value": "receiver"}}, testify["properties"])
+	assert.FileExists(t, binary+".cdx.json")
+	assert.NoFileExists(t, binary+".intoto.jsonl")
+}
+
+func TestWriteSPDXSBOM(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Distribution.SBOM = SBOMFormatSPDX
+	binary, digest := newTestBinary(t, cfg)
+	require.NoError(t, writeSupplyChainMetadata(cfg, binary, time.Now(), time.Now()))
+
+	doc := readJSON(t, binary+".spdx.json")
+	assert.Equal(t, "SPDX-2.3", doc["spdxVersion"])
+	assert.Equal(t, []any{"Tool: ocb-v0.137.0"}, doc["creationInfo"].(map[string]any)["creators"])
+	packages := doc["packages"].([]any)
+	main := packages[0].(map[string]any)
+	assert.Equal(t, "otelcol-test", main["name"])
+	assert.Equal(t, []any{map[string]any{"algorithm": "SHA256", "checksumValue": digest}}, main["checksums"])
+	// The distribution depends on each of the modules linked in its binary.
+	relationships := doc["relationships"].([]any)
+	assert.Len(t, relationships, len(packages))
+	for _, pkg := range packages[1:] {
+		assert.True(t, strings.HasPrefix(pkg.(map[string]any)["SPDXID"].(string), "SPDXRef-Package-"))
+	}
+}
+
+func TestWriteProvenance(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Distribution.Provenance = true
+	binary, digest := newTestBinary(t, cfg)
+	startedOn := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, writeSupplyChainMetadata(cfg, binary, startedOn, startedOn.Add(time.Minute)))
+
+	content, err := os.ReadFile(binary + ".intoto.jsonl")
+	require.NoError(t, err)
+	// The statement is written on a single line.
+	assert.Equal(t, 1, strings.Count(string(content), "\n"))
+	statement := readJSON(t, binary+".intoto.jsonl")
+	assert.Equal(t, "https://in-toto.io/Statement/v1", statement["_type"])
+	assert.Equal(t, "https://slsa.dev/provenance/v1", statement["predicateType"])
+	assert.Equal(t, []any{map[string]any{"name": "otelcol-test", "digest": map[string]any{"sha256": digest}}}, statement["subject"])
+
+	predicate := statement["predicate"].(map[string]any)
+	definition := predicate["buildDefinition"].(map[string]any)
+	assert.Equal(t, provenanceBuildType, definition["buildType"])
+	assert.Equal(t, []any{"github.com/stretchr/testify v1.11.1"}, definition["externalParameters"].(map[string]any)["receivers"])
+	assert.Contains(t, definition["resolvedDependencies"], map[string]any{
+		"uri":         "pkg:golang/github.com/stretchr/testify@v1.11.1",
+		"annotations": map[string]any{"go.sum": "h1:7s2iGBzp5EwR7/aIZr8ao5+dra3wiQyKjjFuvgVKu7U="},
+	})
+	assert.Equal(t, map[string]any{
+		"builder":  map[string]any{"id": "https://go.opentelemetry.io/collector/cmd/builder@v0.137.0"},
+		"metadata": map[string]any{"startedOn": "2025-01-02T03:04:05Z", "finishedOn": "2025-01-02T03:05:05Z"},
+	}, predicate["runDetails"])
+	assert.NoFileExists(t, binary+".cdx.json")
+	assert.NoFileExists(t, binary+".spdx.json")
+}
+
+func TestWriteSupplyChainMetadataDisabled(t *testing.T) {
+	// The binary is not read when neither the SBOM nor the provenance is written.
+	require.NoError(t, writeSupplyChainMetadata(newTestConfig(t), filepath.Join(t.TempDir(), "missing"), time.Now(), time.Now()))
+}
+
+func TestInvalidSBOMFormat(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Distribution.SBOM = "swid"
+	require.ErrorIs(t, cfg.Validate(), errInvalidSBOMFormat)
+}
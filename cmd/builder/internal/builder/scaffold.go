@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// errInvalidComponent indicates a component skeleton of an unknown kind, or with an invalid name or module
+var errInvalidComponent = errors.New("invalid component")
+
+// componentName matches the names of the generated components, also the prefixes of their package names
+var componentName = regexp.MustCompile(`^[a-z][a-z0-9]{0,62}$`)
+
+// componentKinds lists the core modules imported by the skeleton of each kind of component
+var componentKinds = map[string][]string{
+	"receiver":  {"consumer", "consumer/consumertest", "receiver", "receiver/receivertest"},
+	"processor": {"consumer", "consumer/consumertest", "pdata", "processor", "processor/processorhelper", "processor/processortest"},
+	"exporter":  {"config/configretry", "consumer", "exporter", "exporter/exporterhelper", "exporter/exportertest", "pdata"},
+	"connector": {"connector", "connector/connectortest", "consumer", "consumer/consumertest", "pdata"},
+	"extension": {"extension", "extension/extensiontest"},
+}
+
+// stableModules lists the stable core modules imported by the skeletons, released at defaultStableOtelColVersion
+var stableModules = []string{"component", "config/configretry", "consumer", "exporter", "extension", "pdata", "processor", "receiver"}
+
+// testifyVersion is the version of testify required by the tests of the skeletons
+const testifyVersion = "v1.11.1"
+
+// ComponentSkeleton holds the parameters of the skeleton of a new component
+type ComponentSkeleton struct {
+	Kind       string // the kind of the component: receiver, processor, exporter, connector or extension
+	Name       string // the type of the component in the configuration of the collector
+	Module     string // the path of the module of the component, example.com/<name><kind> when empty
+	OutputPath string // the directory of the module, ./<name><kind> when empty
+}
+
+// GenerateComponent writes the skeleton of a new component: a module with its factory, configuration and
+// lifecycle tests, requiring the core modules at the versions of the builder. It returns the module to add
+// to the build configuration.
+func GenerateComponent(s ComponentSkeleton) (Module, error) {
+	modules, ok := componentKinds[s.Kind]
+	if !ok {
+		kinds := make([]string, 0, len(componentKinds))
+		for kind := range componentKinds {
+			kinds = append(kinds, kind)
+		}
+		slices.Sort(kinds)
+		return Module{}, fmt.Errorf("%w: unknown type %q, must be one of %q", errInvalidComponent, s.Kind, kinds)
+	}
+	if !componentName.MatchString(s.Name) {
+		return Module{}, fmt.Errorf("%w: the name %q must start with a lowercase letter, followed by lowercase letters and digits", errInvalidComponent, s.Name)
+	}
+	pkg := s.Name + s.Kind
+	if s.Module == "" {
+		s.Module = "example.com/" + pkg
+	}
+	if err := module.CheckPath(s.Module); err != nil {
+		return Module{}, fmt.Errorf("%w: %w", errInvalidComponent, err)
+	}
+	if s.OutputPath == "" {
+		s.OutputPath = "./" + pkg
+	}
+
+	if entries, err := os.ReadDir(s.OutputPath); err == nil && len(entries) > 0 {
+		return Module{}, fmt.Errorf("the output path %q is not empty", s.OutputPath)
+	}
+	if err := os.MkdirAll(s.OutputPath, 0o750); err != nil {
+		return Module{}, fmt.Errorf("failed to create the output path: %w", err)
+	}
+
+	requires := []string{"github.com/stretchr/testify " + testifyVersion}
+	for _, mod := range slices.Concat(modules, []string{"component", "component/componenttest"}) {
+		version := defaultBetaOtelColVersion
+		if slices.Contains(stableModules, mod) {
+			version = defaultStableOtelColVersion
+		}
+		requires = append(requires, coreModulePrefix+mod+" "+version)
+	}
+	slices.Sort(requires)
+
+	data := struct {
+		ComponentSkeleton
+		Package  string
+		Struct   string
+		Requires []string
+	}{
+		ComponentSkeleton: s,
+		Package:           pkg,
+		Struct:            s.Name + strings.ToUpper(s.Kind[:1]) + s.Kind[1:],
+		Requires:          requires,
+	}
+	for file, tmpl := range map[string]string{
+		"go.mod":         "go.mod.tmpl",
+		"config.go":      "config.go.tmpl",
+		pkg + ".go":      s.Kind + ".go.tmpl",
+		pkg + "_test.go": s.Kind + "_test.go.tmpl",
+	} {
+		var buf bytes.Buffer
+		if err := componentTemplates.ExecuteTemplate(&buf, tmpl, data); err != nil {
+			return Module{}, fmt.Errorf("failed to generate %s: %w", file, err)
+		}
+		content := buf.Bytes()
+		if filepath.Ext(file) == ".go" {
+			var err error
+			if content, err = format.Source(content); err != nil {
+				return Module{}, fmt.Errorf("failed to format %s: %w", file, err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(s.OutputPath, file), content, 0o600); err != nil {
+			return Module{}, fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	return Module{GoMod: s.Module + " v0.0.0", Path: s.OutputPath}, nil
+}
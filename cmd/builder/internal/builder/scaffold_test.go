@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateComponent(t *testing.T) {
+	for kind := range componentKinds {
+		t.Run(kind, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "foo")
+			mod, err := GenerateComponent(ComponentSkeleton{
+				Kind:       kind,
+				Name:       "foo",
+				Module:     "github.com/example/foo" + kind,
+				OutputPath: dir,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, Module{GoMod: "github.com/example/foo" + kind + " v0.0.0", Path: dir}, mod)
+
+			// The skeleton uses the local core modules, as the distributions of the other tests.
+			f, err := os.OpenFile(filepath.Join(dir, "go.mod"), os.O_APPEND|os.O_WRONLY, 0)
+			require.NoError(t, err)
+			_, err = f.WriteString("\nreplace (\n\t" + strings.Join(generateReplaces(), "\n\t") + "\n)\n")
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			for _, args := range [][]string{{"mod", "tidy"}, {"vet", "./..."}, {"test", "./..."}} {
+				cmd := exec.Command("go", args...)
+				cmd.Dir = dir
+				out, err := cmd.CombinedOutput()
+				require.NoError(t, err, string(out))
+			}
+		})
+	}
+}
+
+func TestGenerateComponentDefaults(t *testing.T) {
+	t.Chdir(t.TempDir())
+	mod, err := GenerateComponent(ComponentSkeleton{Kind: "processor", Name: "foo"})
+	require.NoError(t, err)
+	assert.Equal(t, Module{GoMod: "example.com/fooprocessor v0.0.0", Path: "./fooprocessor"}, mod)
+
+	gomod, err := readGoMod(mod.Path)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/fooprocessor", gomod.Module.Mod.Path)
+	assert.FileExists(t, filepath.Join(mod.Path, "config.go"))
+	assert.FileExists(t, filepath.Join(mod.Path, "fooprocessor.go"))
+	assert.FileExists(t, filepath.Join(mod.Path, "fooprocessor_test.go"))
+
+	_, err = GenerateComponent(ComponentSkeleton{Kind: "processor", Name: "foo"})
+	require.EqualError(t, err, `the output path "./fooprocessor" is not empty`)
+}
+
+func TestGenerateInvalidComponent(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		skeleton ComponentSkeleton
+		err      string
+	}{
+		{
+			name:     "unknown kind",
+			skeleton: ComponentSkeleton{Kind: "scraper", Name: "foo"},
+			err:      `invalid component: unknown type "scraper", must be one of ["connector" "exporter" "extension" "processor" "receiver"]`,
+		},
+		{
+			name:     "invalid name",
+			skeleton: ComponentSkeleton{Kind: "receiver", Name: "Foo_bar"},
+			err:      `invalid component: the name "Foo_bar" must start with a lowercase letter, followed by lowercase letters and digits`,
+		},
+		{
+			name:     "invalid module",
+			skeleton: ComponentSkeleton{Kind: "receiver", Name: "foo", Module: "github.com/example/foo receiver"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.skeleton.OutputPath = t.TempDir()
+			_, err := GenerateComponent(tt.skeleton)
+			require.ErrorIs(t, err, errInvalidComponent)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -4,7 +4,7 @@
 package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
 
 import (
-	_ "embed"
+	"embed"
 	"text/template"
 )
 
@@ -25,9 +25,22 @@ var (
 	mainWindowsBytes    []byte
 	mainWindowsTemplate = parseTemplate("main_windows.go", mainWindowsBytes)
 
+	//go:embed templates/embedded.go.tmpl
+	embeddedBytes    []byte
+	embeddedTemplate = parseTemplate("embedded.go", embeddedBytes)
+
+	//go:embed templates/fips.go.tmpl
+	fipsBytes    []byte
+	fipsTemplate = parseTemplate("fips.go", fipsBytes)
+
 	//go:embed templates/go.mod.tmpl
 	goModBytes    []byte
 	goModTemplate = parseTemplate("go.mod", goModBytes)
+
+	// The templates of the skeletons of new components, named after their files.
+	//go:embed templates/component/*.tmpl
+	componentFS        embed.FS
+	componentTemplates = template.Must(template.ParseFS(componentFS, "templates/component/*.tmpl"))
 )
 
 func parseTemplate(name string, bytes []byte) *template.Template {
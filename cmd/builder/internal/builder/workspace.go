@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder // import "go.opentelemetry.io/collector/cmd/builder/internal/builder"
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// localReplaces returns the replace directives making the distribution use the local modules of its
+// go.work file, and the local modules replaced in the go.mod files of the local modules, which Go
+// ignores outside of the main module. The modules already replaced by the build configuration are
+// left as they are.
+func localReplaces(cfg *Config) ([]string, error) {
+	replaced := map[string]bool{}
+	var dirs []string
+	for _, mod := range cfg.allComponents() {
+		if mod.Path != "" {
+			replaced[strings.Split(mod.GoMod, " ")[0]] = true
+			dirs = append(dirs, mod.Path)
+		}
+	}
+	for _, r := range cfg.Replaces {
+		if fields := strings.Fields(r); len(fields) > 0 {
+			replaced[fields[0]] = true
+		}
+	}
+
+	var replaces []string
+	addReplace := func(old module.Version, dir string) {
+		replaced[old.Path] = true
+		replaces = append(replaces, fmt.Sprintf("%s => %s", formatVersion(old), dir))
+		dirs = append(dirs, dir)
+	}
+
+	if cfg.Distribution.GoWork != "" {
+		work, err := readGoWork(cfg.Distribution.GoWork)
+		if err != nil {
+			return nil, err
+		}
+		base := filepath.Dir(cfg.Distribution.GoWork)
+		for _, use := range work.Use {
+			dir := resolveDir(base, use.Path)
+			modPath, err := readModulePath(dir)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read the module used by %q: %w", cfg.Distribution.GoWork, err)
+			}
+			if !replaced[modPath] {
+				addReplace(module.Version{Path: modPath}, dir)
+			}
+		}
+		for _, r := range work.Replace {
+			if replaced[r.Old.Path] {
+				continue
+			}
+			if modfile.IsDirectoryPath(r.New.Path) {
+				addReplace(r.Old, resolveDir(base, r.New.Path))
+				continue
+			}
+			replaced[r.Old.Path] = true
+			replaces = append(replaces, fmt.Sprintf("%s => %s", formatVersion(r.Old), formatVersion(r.New)))
+		}
+	}
+
+	// The list of directories grows while the local modules they replace are found.
+	visited := map[string]bool{}
+	for i := 0; i < len(dirs); i++ {
+		dir := dirs[i]
+		if visited[dir] {
+			continue
+		}
+		visited[dir] = true
+		mod, err := readGoMod(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			// The go commands report the local module missing its go.mod file.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range mod.Replace {
+			if !replaced[r.Old.Path] && modfile.IsDirectoryPath(r.New.Path) {
+				addReplace(r.Old, resolveDir(dir, r.New.Path))
+			}
+		}
+	}
+	return replaces, nil
+}
+
+func readGoWork(path string) (*modfile.WorkFile, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the go.work file: %w", err)
+	}
+	return modfile.ParseWork(path, data, nil)
+}
+
+func readGoMod(dir string) (*modfile.File, error) {
+	path := filepath.Join(dir, "go.mod")
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the go.mod file of the local module: %w", err)
+	}
+	return modfile.Parse(path, data, nil)
+}
+
+func readModulePath(dir string) (string, error) {
+	mod, err := readGoMod(dir)
+	if err != nil {
+		return "", err
+	}
+	if mod.Module == nil {
+		return "", fmt.Errorf("no module directive in %s", filepath.Join(dir, "go.mod"))
+	}
+	return mod.Module.Mod.Path, nil
+}
+
+// resolveDir returns the absolute path of a directory given relative to base.
+func resolveDir(base, dir string) string {
+	if filepath.IsAbs(dir) {
+		return filepath.Clean(dir)
+	}
+	return filepath.Join(base, dir)
+}
+
+func formatVersion(v module.Version) string {
+	if v.Version == "" {
+		return v.Path
+	}
+	return v.Path + " " + v.Version
+}
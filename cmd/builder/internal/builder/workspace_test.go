@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestLocalReplacesOfNestedModules(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "receiver", "myreceiver", "go.mod"), `module example.com/receiver/myreceiver
+
+require example.com/internal/common v0.1.0
+
+replace example.com/internal/common => ../../internal/common
+
+replace example.com/remote => example.com/fork v0.2.0
+`)
+	writeTestFile(t, filepath.Join(dir, "internal", "common", "go.mod"), `module example.com/internal/common
+
+replace example.com/internal/nested v0.1.0 => ./nested
+`)
+	writeTestFile(t, filepath.Join(dir, "internal", "common", "nested", "go.mod"), `module example.com/internal/nested`)
+
+	cfg := Config{
+		Receivers: []Module{{GoMod: "example.com/receiver/myreceiver v0.1.0", Path: filepath.Join(dir, "receiver", "myreceiver")}},
+	}
+	require.NoError(t, cfg.ParseModules())
+	assert.Equal(t, []string{
+		"example.com/internal/common => " + filepath.Join(dir, "internal", "common"),
+		"example.com/internal/nested v0.1.0 => " + filepath.Join(dir, "internal", "common", "nested"),
+	}, cfg.LocalReplaces)
+}
+
+func TestLocalReplacesOfGoWork(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "go.work"), `go 1.24
+
+use (
+	./core/otelcol
+	./contrib/myexporter
+	./custom
+)
+
+replace example.com/custom v0.1.0 => ./other
+
+replace example.com/remote => example.com/fork v0.2.0
+`)
+	writeTestFile(t, filepath.Join(dir, "core", "otelcol", "go.mod"), `module go.opentelemetry.io/collector/otelcol`)
+	writeTestFile(t, filepath.Join(dir, "contrib", "myexporter", "go.mod"), `module example.com/myexporter
+
+replace example.com/internal/common => ../common
+`)
+	writeTestFile(t, filepath.Join(dir, "contrib", "common", "go.mod"), `module example.com/internal/common`)
+	writeTestFile(t, filepath.Join(dir, "custom", "go.mod"), `module example.com/custom`)
+
+	cfg := Config{
+		Distribution: Distribution{GoWork: filepath.Join(dir, "go.work")},
+		Exporters:    []Module{{GoMod: "example.com/myexporter v0.1.0"}},
+		// The replaces of the build configuration take precedence.
+		Replaces: []string{"example.com/custom => example.com/custom v0.3.0"},
+	}
+	require.NoError(t, cfg.ParseModules())
+	assert.Equal(t, []string{
+		"go.opentelemetry.io/collector/otelcol => " + filepath.Join(dir, "core", "otelcol"),
+		"example.com/myexporter => " + filepath.Join(dir, "contrib", "myexporter"),
+		"example.com/remote => example.com/fork v0.2.0",
+		"example.com/internal/common => " + filepath.Join(dir, "contrib", "common"),
+	}, cfg.LocalReplaces)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "custom", "go.mod")))
+	cfg.LocalReplaces = nil
+	require.ErrorContains(t, cfg.ParseModules(), "failed to read the module used by")
+
+	cfg.Distribution.GoWork = filepath.Join(dir, "missing.work")
+	require.ErrorContains(t, cfg.ParseModules(), "failed to read the go.work file")
+}
+
+func TestGenerateLocalReplaces(t *testing.T) {
+	cfg := newInitializedConfig(t)
+	cfg.Distribution.OutputPath = t.TempDir()
+	cfg.LocalReplaces = []string{"example.com/internal/common => /src/common"}
+	require.NoError(t, Generate(cfg))
+
+	gomod, err := os.ReadFile(filepath.Join(cfg.Distribution.OutputPath, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(gomod), "replace example.com/internal/common => /src/common\n")
+}
@@ -25,6 +25,7 @@ const (
 	skipCompilationFlag        = "skip-compilation"
 	skipGetModulesFlag         = "skip-get-modules"
 	skipStrictVersioningFlag   = "skip-strict-versioning"
+	skipCompatibilityCheckFlag = "skip-compatibility-check"
 	ldflagsFlag                = "ldflags"
 	gcflagsFlag                = "gcflags"
 	distributionOutputPathFlag = "output-path"
@@ -71,6 +72,8 @@ configuration is provided, ocb will generate a default Collector.
 
 	// version of this binary
 	cmd.AddCommand(versionCommand())
+	cmd.AddCommand(manifestCommand())
+	cmd.AddCommand(generateCommand())
 
 	return cmd, nil
 }
@@ -82,6 +85,7 @@ func initFlags(flags *flag.FlagSet) error {
 	flags.Bool(skipCompilationFlag, false, "Whether builder should only generate go code with no compile of the collector (default false)")
 	flags.Bool(skipGetModulesFlag, false, "Whether builder should skip updating go.mod and retrieve Go module list (default false)")
 	flags.Bool(skipStrictVersioningFlag, true, "Whether builder should skip strictly checking the calculated versions following dependency resolution")
+	flags.Bool(skipCompatibilityCheckFlag, false, "Whether builder should skip checking that the components require the core collector version of the builder (default false)")
 	flags.Bool(verboseFlag, false, "Whether builder should print verbose output (default false)")
 	flags.String(ldflagsFlag, "", `ldflags to include in the "go build" command`)
 	flags.String(gcflagsFlag, "", `gcflags to include in the "go build" command`)
@@ -94,6 +98,7 @@ func initConfig(flags *flag.FlagSet) (*builder.Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	cfg.BuilderVersion = version
 
 	cfg.Logger.Info("OpenTelemetry Collector Builder", zap.String("version", version))
 
@@ -140,6 +145,8 @@ func applyFlags(flags *flag.FlagSet, cfg *builder.Config) error {
 	errs = multierr.Append(errs, err)
 	cfg.SkipStrictVersioning, err = flags.GetBool(skipStrictVersioningFlag)
 	errs = multierr.Append(errs, err)
+	cfg.SkipCompatibility, err = flags.GetBool(skipCompatibilityCheckFlag)
+	errs = multierr.Append(errs, err)
 
 	if flags.Changed(ldflagsFlag) {
 		cfg.LDSet = true
@@ -69,12 +69,13 @@ func TestApplyFlags(t *testing.T) {
 		},
 		{
 			name:  "All flag values",
-			flags: []string{"--skip-generate=true", "--skip-compilation=true", "--skip-get-modules=true", "--skip-strict-versioning=true", "--ldflags=test", "--gcflags=test", "--verbose=true"},
+			flags: []string{"--skip-generate=true", "--skip-compilation=true", "--skip-get-modules=true", "--skip-strict-versioning=true", "--skip-compatibility-check=true", "--ldflags=test", "--gcflags=test", "--verbose=true"},
 			want: &builder.Config{
 				SkipGenerate:         true,
 				SkipCompilation:      true,
 				SkipGetModules:       true,
 				SkipStrictVersioning: true,
+				SkipCompatibility:    true,
 				LDFlags:              "test",
 				GCFlags:              "test",
 				Verbose:              true,
@@ -93,6 +94,7 @@ func TestApplyFlags(t *testing.T) {
 			assert.Equal(t, tt.want.SkipCompilation, cfg.SkipCompilation)
 			assert.Equal(t, tt.want.SkipGetModules, cfg.SkipGetModules)
 			assert.Equal(t, tt.want.SkipStrictVersioning, cfg.SkipStrictVersioning)
+			assert.Equal(t, tt.want.SkipCompatibility, cfg.SkipCompatibility)
 			assert.Equal(t, tt.want.LDFlags, cfg.LDFlags)
 			assert.Equal(t, tt.want.Verbose, cfg.Verbose)
 		})
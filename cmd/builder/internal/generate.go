@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/cmd/builder/internal"
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+
+	"go.opentelemetry.io/collector/cmd/builder/internal/builder"
+)
+
+const (
+	typeFlag       = "type"
+	nameFlag       = "name"
+	moduleFlag     = "module"
+	outputPathFlag = "output-path"
+)
+
+func generateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate the skeletons of new components",
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(generateComponentCommand())
+	return cmd
+}
+
+func generateComponentCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "component",
+		Short: "Generate the skeleton of a new component",
+		Long: `Generates the module of a new component, with its factory, its configuration, the wiring of the
+helpers of its kind and lifecycle tests, requiring the core modules at the versions of this builder.
+
+The module is written to the "--output-path" directory, and the entry adding it to the build
+configuration is printed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			skeleton := builder.ComponentSkeleton{}
+			skeleton.Kind, _ = cmd.Flags().GetString(typeFlag)
+			skeleton.Name, _ = cmd.Flags().GetString(nameFlag)
+			skeleton.Module, _ = cmd.Flags().GetString(moduleFlag)
+			skeleton.OutputPath, _ = cmd.Flags().GetString(outputPathFlag)
+
+			mod, err := builder.GenerateComponent(skeleton)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Generated the %s %q in %s, add it to the build configuration:\n\n", skeleton.Kind, skeleton.Name, mod.Path)
+			encoder := yaml.NewEncoder(out)
+			encoder.SetIndent(2)
+			if err = encoder.Encode(map[string][]manifestModule{
+				skeleton.Kind + "s": {{GoMod: mod.GoMod, Path: mod.Path}},
+			}); err != nil {
+				return err
+			}
+			return encoder.Close()
+		},
+	}
+	cmd.Flags().String(typeFlag, "", "kind of the component: receiver, processor, exporter, connector or extension")
+	cmd.Flags().String(nameFlag, "", "type of the component in the configuration of the collector, e.g. foo")
+	cmd.Flags().String(moduleFlag, "", "path of the module of the component, example.com/<name><type> when empty")
+	cmd.Flags().String(outputPathFlag, "", "directory of the module, ./<name><type> when empty")
+	_ = cmd.MarkFlagRequired(typeFlag)
+	_ = cmd.MarkFlagRequired(nameFlag)
+	return cmd
+}
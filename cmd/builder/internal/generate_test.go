@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateComponentCommand(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "fooprocessor")
+
+	cmd := generateCommand()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"component", "--type=processor", "--name=foo", "--module=github.com/example/fooprocessor", "--output-path=" + dir})
+	require.NoError(t, cmd.Execute())
+
+	assert.Equal(t, `Generated the processor "foo" in `+dir+`, add it to the build configuration:
+
+processors:
+  - gomod: github.com/example/fooprocessor v0.0.0
+    path: `+dir+`
+`, out.String())
+	assert.FileExists(t, filepath.Join(dir, "go.mod"))
+	assert.FileExists(t, filepath.Join(dir, "fooprocessor.go"))
+}
+
+func TestGenerateComponentCommandErrors(t *testing.T) {
+	cmd := generateCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"component", "--type=processor"})
+	require.EqualError(t, cmd.Execute(), `required flag(s) "name" not set`)
+
+	cmd = generateCommand()
+	cmd.SetArgs([]string{"component", "--type=scraper", "--name=foo", "--output-path=" + t.TempDir()})
+	require.ErrorContains(t, cmd.Execute(), `unknown type "scraper"`)
+}
@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/cmd/builder/internal"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	componentsFlag      = "components"
+	effectiveConfigFlag = "effective-config"
+	outputFlag          = "output"
+)
+
+// inventory is the output of the `components` command of a collector, in YAML or JSON.
+type inventory struct {
+	BuildInfo struct {
+		Command     string `yaml:"command"`
+		Description string `yaml:"description"`
+		Version     string `yaml:"version"`
+	} `yaml:"buildinfo"`
+	Receivers  []inventoryComponent `yaml:"receivers"`
+	Processors []inventoryComponent `yaml:"processors"`
+	Exporters  []inventoryComponent `yaml:"exporters"`
+	Connectors []inventoryComponent `yaml:"connectors"`
+	Extensions []inventoryComponent `yaml:"extensions"`
+	Providers  []inventoryComponent `yaml:"providers"`
+	Converters []inventoryComponent `yaml:"converters"`
+}
+
+type inventoryComponent struct {
+	Name   string `yaml:"name"`
+	Scheme string `yaml:"scheme"`
+	Module string `yaml:"module"`
+}
+
+// manifest is the build configuration generated from an inventory.
+type manifest struct {
+	Dist struct {
+		Name        string `yaml:"name,omitempty"`
+		Description string `yaml:"description,omitempty"`
+		Version     string `yaml:"version,omitempty"`
+		OutputPath  string `yaml:"output_path,omitempty"`
+	} `yaml:"dist"`
+	Receivers  []manifestModule `yaml:"receivers,omitempty"`
+	Processors []manifestModule `yaml:"processors,omitempty"`
+	Exporters  []manifestModule `yaml:"exporters,omitempty"`
+	Connectors []manifestModule `yaml:"connectors,omitempty"`
+	Extensions []manifestModule `yaml:"extensions,omitempty"`
+	Providers  []manifestModule `yaml:"providers,omitempty"`
+	Converters []manifestModule `yaml:"converters,omitempty"`
+}
+
+type manifestModule struct {
+	GoMod string `yaml:"gomod"`
+	Path  string `yaml:"path,omitempty"`
+}
+
+func manifestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Generate a build configuration from the components of a collector",
+		Long: `Generates the build configuration of a collector containing the components of a running collector,
+given by the output of its "components" command, in YAML or JSON.
+
+With the "--effective-config" flag, only the components defined in the given collector configuration
+are kept, along with all the providers and converters.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			componentsFile, _ := cmd.Flags().GetString(componentsFlag)
+			configFile, _ := cmd.Flags().GetString(effectiveConfigFlag)
+			outputFile, _ := cmd.Flags().GetString(outputFlag)
+
+			inv, err := readInventory(componentsFile)
+			if err != nil {
+				return err
+			}
+			if configFile != "" {
+				if err = filterInventory(inv, configFile); err != nil {
+					return err
+				}
+			}
+			m, err := newManifest(inv)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if outputFile != "" {
+				f, err := os.Create(outputFile)
+				if err != nil {
+					return fmt.Errorf("failed to create the build configuration: %w", err)
+				}
+				defer f.Close()
+				out = f
+			}
+			return writeManifest(out, m)
+		},
+	}
+	cmd.Flags().String(componentsFlag, "", `file with the output of the "components" command of the collector`)
+	cmd.Flags().String(effectiveConfigFlag, "", "configuration of the collector whose components are kept, all when empty")
+	cmd.Flags().String(outputFlag, "", "file where the build configuration is written, the standard output when empty")
+	_ = cmd.MarkFlagRequired(componentsFlag)
+	return cmd
+}
+
+func readInventory(path string) (*inventory, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the components: %w", err)
+	}
+	// JSON is valid YAML, so both outputs of the "components" command are parsed as YAML.
+	inv := &inventory{}
+	if err = yaml.Unmarshal(content, inv); err != nil {
+		return nil, fmt.Errorf("failed to parse the components: %w", err)
+	}
+	return inv, nil
+}
+
+// filterInventory keeps the components defined in the collector configuration.
+func filterInventory(inv *inventory, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the effective configuration: %w", err)
+	}
+	var cfg map[string]any
+	if err = yaml.Unmarshal(content, &cfg); err != nil {
+		return fmt.Errorf("failed to parse the effective configuration: %w", err)
+	}
+
+	var errs []error
+	filter := func(kind string, components []inventoryComponent) []inventoryComponent {
+		defined, _ := cfg[kind+"s"].(map[string]any)
+		var kept []inventoryComponent
+		for id := range defined {
+			// The component IDs are made of the component type, followed by an optional name.
+			typ, _, _ := strings.Cut(id, "/")
+			i := slices.IndexFunc(components, func(c inventoryComponent) bool { return c.Name == typ })
+			if i < 0 {
+				errs = append(errs, fmt.Errorf("%s %q is not in the components", kind, id))
+				continue
+			}
+			if !slices.Contains(kept, components[i]) {
+				kept = append(kept, components[i])
+			}
+		}
+		slices.SortFunc(kept, func(a, b inventoryComponent) int { return strings.Compare(a.Name, b.Name) })
+		return kept
+	}
+	inv.Receivers = filter("receiver", inv.Receivers)
+	inv.Processors = filter("processor", inv.Processors)
+	inv.Exporters = filter("exporter", inv.Exporters)
+	inv.Connectors = filter("connector", inv.Connectors)
+	inv.Extensions = filter("extension", inv.Extensions)
+	return errors.Join(errs...)
+}
+
+func newManifest(inv *inventory) (*manifest, error) {
+	m := &manifest{}
+	m.Dist.Name = inv.BuildInfo.Command
+	m.Dist.Description = inv.BuildInfo.Description
+	m.Dist.Version = inv.BuildInfo.Version
+	if m.Dist.Name != "" {
+		m.Dist.OutputPath = "./" + m.Dist.Name
+	}
+
+	var errs []error
+	modules := func(kind string, components []inventoryComponent) []manifestModule {
+		var mods []manifestModule
+		for _, c := range components {
+			if c.Module == "" {
+				name := c.Name
+				if name == "" {
+					name = c.Scheme
+				}
+				errs = append(errs, fmt.Errorf("the module of the %s %q is unknown", kind, name))
+				continue
+			}
+			// Providers are listed in no particular order, and may share a module.
+			if !slices.Contains(mods, manifestModule{GoMod: c.Module}) {
+				mods = append(mods, manifestModule{GoMod: c.Module})
+			}
+		}
+		return mods
+	}
+	m.Receivers = modules("receiver", inv.Receivers)
+	m.Processors = modules("processor", inv.Processors)
+	m.Exporters = modules("exporter", inv.Exporters)
+	m.Connectors = modules("connector", inv.Connectors)
+	m.Extensions = modules("extension", inv.Extensions)
+	m.Providers = modules("provider", inv.Providers)
+	m.Converters = modules("converter", inv.Converters)
+	slices.SortFunc(m.Providers, func(a, b manifestModule) int { return strings.Compare(a.GoMod, b.GoMod) })
+	return m, errors.Join(errs...)
+}
+
+func writeManifest(w io.Writer, m *manifest) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(m); err != nil {
+		return fmt.Errorf("failed to write the build configuration: %w", err)
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	flag "github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const componentsJSON = `{
+  "buildinfo": {"command": "otelcorecol", "description": "Local OpenTelemetry Collector binary", "version": "0.137.0-dev"},
+  "receivers": [
+    {"name": "nop", "module": "go.opentelemetry.io/collector/receiver/nopreceiver v0.137.0"},
+    {"name": "otlp", "module": "go.opentelemetry.io/collector/receiver/otlpreceiver v0.137.0"}
+  ],
+  "processors": [{"name": "batch", "module": "go.opentelemetry.io/collector/processor/batchprocessor v0.137.0"}],
+  "exporters": [
+    {"name": "debug", "module": "go.opentelemetry.io/collector/exporter/debugexporter v0.137.0"},
+    {"name": "otlp", "module": "go.opentelemetry.io/collector/exporter/otlpexporter v0.137.0"}
+  ],
+  "extensions": [{"name": "zpages", "module": "go.opentelemetry.io/collector/extension/zpagesextension v0.137.0"}],
+  "providers": [
+    {"scheme": "https", "module": "go.opentelemetry.io/collector/confmap/provider/httpsprovider v1.43.0"},
+    {"scheme": "env", "module": "go.opentelemetry.io/collector/confmap/provider/envprovider v1.43.0"}
+  ]
+}`
+
+func TestManifestCommand(t *testing.T) {
+	tests := []struct {
+		name            string
+		components      string
+		effectiveConfig string
+		expected        string
+		expectedErr     string
+	}{
+		{
+			name:       "all components",
+			components: componentsJSON,
+			expected: `dist:
+  name: otelcorecol
+  description: Local OpenTelemetry Collector binary
+  version: 0.137.0-dev
+  output_path: ./otelcorecol
+receivers:
+  - gomod: go.opentelemetry.io/collector/receiver/nopreceiver v0.137.0
+  - gomod: go.opentelemetry.io/collector/receiver/otlpreceiver v0.137.0
+processors:
+  - gomod: go.opentelemetry.io/collector/processor/batchprocessor v0.137.0
+exporters:
+  - gomod: go.opentelemetry.io/collector/exporter/debugexporter v0.137.0
+  - gomod: go.opentelemetry.io/collector/exporter/otlpexporter v0.137.0
+extensions:
+  - gomod: go.opentelemetry.io/collector/extension/zpagesextension v0.137.0
+providers:
+  - gomod: go.opentelemetry.io/collector/confmap/provider/envprovider v1.43.0
+  - gomod: go.opentelemetry.io/collector/confmap/provider/httpsprovider v1.43.0
+`,
+		},
+		{
+			name:       "effective configuration",
+			components: componentsJSON,
+			effectiveConfig: `receivers:
+  otlp:
+  otlp/2:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, otlp/2]
+      exporters: [debug]
+`,
+			expected: `dist:
+  name: otelcorecol
+  description: Local OpenTelemetry Collector binary
+  version: 0.137.0-dev
+  output_path: ./otelcorecol
+receivers:
+  - gomod: go.opentelemetry.io/collector/receiver/otlpreceiver v0.137.0
+exporters:
+  - gomod: go.opentelemetry.io/collector/exporter/debugexporter v0.137.0
+providers:
+  - gomod: go.opentelemetry.io/collector/confmap/provider/envprovider v1.43.0
+  - gomod: go.opentelemetry.io/collector/confmap/provider/httpsprovider v1.43.0
+`,
+		},
+		{
+			name:            "unknown component",
+			components:      componentsJSON,
+			effectiveConfig: "processors:\n  memory_limiter:\n",
+			expectedErr:     `processor "memory_limiter" is not in the components`,
+		},
+		{
+			name: "unknown module",
+			components: `receivers:
+  - name: otlp
+    module: ""
+`,
+			expectedErr: `the module of the receiver "otlp" is unknown`,
+		},
+		{
+			name:        "invalid components",
+			components:  "receivers: {",
+			expectedErr: "failed to parse the components: yaml: line 1: did not find expected node content",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			args := []string{"--components=" + filepath.Join(dir, "components.json")}
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "components.json"), []byte(tt.components), 0o600))
+			if tt.effectiveConfig != "" {
+				args = append(args, "--effective-config="+filepath.Join(dir, "config.yaml"))
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(tt.effectiveConfig), 0o600))
+			}
+
+			cmd := manifestCommand()
+			out := &bytes.Buffer{}
+			cmd.SetOut(out)
+			cmd.SetArgs(args)
+			err := cmd.Execute()
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func TestManifestCommandOutput(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "components.json"), []byte(componentsJSON), 0o600))
+
+	cmd := manifestCommand()
+	cmd.SetArgs([]string{
+		"--components=" + filepath.Join(dir, "components.json"),
+		"--output=" + filepath.Join(dir, "builder-config.yaml"),
+	})
+	require.NoError(t, cmd.Execute())
+
+	// The generated build configuration is read like the one given to the builder.
+	flags := flag.NewFlagSet("version", 1)
+	require.NoError(t, initFlags(flags))
+	require.NoError(t, flags.Set(configFlag, filepath.Join(dir, "builder-config.yaml")))
+	cfg, err := initConfig(flags)
+	require.NoError(t, err)
+	assert.Equal(t, "otelcorecol", cfg.Distribution.Name)
+	require.Len(t, cfg.Receivers, 2)
+	assert.Equal(t, "go.opentelemetry.io/collector/receiver/otlpreceiver v0.137.0", cfg.Receivers[1].GoMod)
+	assert.Len(t, cfg.ConfmapProviders, 2)
+}
@@ -6,13 +6,19 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	forwardconnector "go.opentelemetry.io/collector/connector/forwardconnector"
+	metadataroutingconnector "go.opentelemetry.io/collector/connector/metadataroutingconnector"
 	"go.opentelemetry.io/collector/exporter"
 	debugexporter "go.opentelemetry.io/collector/exporter/debugexporter"
 	nopexporter "go.opentelemetry.io/collector/exporter/nopexporter"
 	otlpexporter "go.opentelemetry.io/collector/exporter/otlpexporter"
 	otlphttpexporter "go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	"go.opentelemetry.io/collector/extension"
+	gctuningextension "go.opentelemetry.io/collector/extension/gctuningextension"
+	healthextension "go.opentelemetry.io/collector/extension/healthextension"
 	memorylimiterextension "go.opentelemetry.io/collector/extension/memorylimiterextension"
+	opampextension "go.opentelemetry.io/collector/extension/opampextension"
+	runtimeinfoextension "go.opentelemetry.io/collector/extension/runtimeinfoextension"
+	filestorageextension "go.opentelemetry.io/collector/extension/filestorageextension"
 	zpagesextension "go.opentelemetry.io/collector/extension/zpagesextension"
 	"go.opentelemetry.io/collector/otelcol"
 	"go.opentelemetry.io/collector/processor"
@@ -28,14 +34,24 @@ func components() (otelcol.Factories, error) {
 	factories := otelcol.Factories{}
 
 	factories.Extensions, err = otelcol.MakeFactoryMap[extension.Factory](
+		gctuningextension.NewFactory(),
+		healthextension.NewFactory(),
 		memorylimiterextension.NewFactory(),
+		opampextension.NewFactory(),
+		runtimeinfoextension.NewFactory(),
+		filestorageextension.NewFactory(),
 		zpagesextension.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
 	}
 	factories.ExtensionModules = make(map[component.Type]string, len(factories.Extensions))
+	factories.ExtensionModules[gctuningextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/gctuningextension v0.137.0"
+	factories.ExtensionModules[healthextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/healthextension v0.137.0"
 	factories.ExtensionModules[memorylimiterextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/memorylimiterextension v0.137.0"
+	factories.ExtensionModules[opampextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/opampextension v0.137.0"
+	factories.ExtensionModules[runtimeinfoextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/runtimeinfoextension v0.137.0"
+	factories.ExtensionModules[filestorageextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/filestorageextension v0.137.0"
 	factories.ExtensionModules[zpagesextension.NewFactory().Type()] = "go.opentelemetry.io/collector/extension/zpagesextension v0.137.0"
 
 	factories.Receivers, err = otelcol.MakeFactoryMap[receiver.Factory](
@@ -77,12 +93,14 @@ func components() (otelcol.Factories, error) {
 
 	factories.Connectors, err = otelcol.MakeFactoryMap[connector.Factory](
 		forwardconnector.NewFactory(),
+		metadataroutingconnector.NewFactory(),
 	)
 	if err != nil {
 		return otelcol.Factories{}, err
 	}
 	factories.ConnectorModules = make(map[component.Type]string, len(factories.Connectors))
 	factories.ConnectorModules[forwardconnector.NewFactory().Type()] = "go.opentelemetry.io/collector/connector/forwardconnector v0.137.0"
+	factories.ConnectorModules[metadataroutingconnector.NewFactory().Type()] = "go.opentelemetry.io/collector/connector/metadataroutingconnector v0.137.0"
 
 	return factories, nil
 }
@@ -16,13 +16,19 @@ require (
 	go.opentelemetry.io/collector/confmap/provider/yamlprovider v1.43.0
 	go.opentelemetry.io/collector/connector v0.137.0
 	go.opentelemetry.io/collector/connector/forwardconnector v0.137.0
+	go.opentelemetry.io/collector/connector/metadataroutingconnector v0.137.0
 	go.opentelemetry.io/collector/exporter v1.43.0
 	go.opentelemetry.io/collector/exporter/debugexporter v0.137.0
 	go.opentelemetry.io/collector/exporter/nopexporter v0.137.0
 	go.opentelemetry.io/collector/exporter/otlpexporter v0.137.0
 	go.opentelemetry.io/collector/exporter/otlphttpexporter v0.137.0
 	go.opentelemetry.io/collector/extension v1.43.0
+	go.opentelemetry.io/collector/extension/filestorageextension v0.137.0
+	go.opentelemetry.io/collector/extension/gctuningextension v0.137.0
+	go.opentelemetry.io/collector/extension/healthextension v0.137.0
 	go.opentelemetry.io/collector/extension/memorylimiterextension v0.137.0
+	go.opentelemetry.io/collector/extension/opampextension v0.137.0
+	go.opentelemetry.io/collector/extension/runtimeinfoextension v0.137.0
 	go.opentelemetry.io/collector/extension/zpagesextension v0.137.0
 	go.opentelemetry.io/collector/otelcol v0.137.0
 	go.opentelemetry.io/collector/processor v1.43.0
@@ -68,6 +74,7 @@ require (
 	github.com/modern-go/reflect2 v1.0.3-0.20250322232337-35a7c28c31ee // indirect
 	github.com/mostynb/go-grpc-compression v1.2.3 // indirect
 	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/open-telemetry/opamp-go v0.22.0 // indirect
 	github.com/pierrec/lz4/v4 v4.1.22 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/power-devops/perfstat v0.0.0-20240221224432-82ca36839d55 // indirect
@@ -84,6 +91,7 @@ require (
 	github.com/tklauser/go-sysconf v0.3.15 // indirect
 	github.com/tklauser/numcpus v0.10.0 // indirect
 	github.com/yusufpapurcu/wmi v1.2.4 // indirect
+	go.etcd.io/bbolt v1.4.3 // indirect
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
 	go.opentelemetry.io/collector v0.137.0 // indirect
 	go.opentelemetry.io/collector/client v1.43.0 // indirect
@@ -120,6 +128,8 @@ require (
 	go.opentelemetry.io/collector/featuregate v1.43.0 // indirect
 	go.opentelemetry.io/collector/internal/fanoutconsumer v0.137.0 // indirect
 	go.opentelemetry.io/collector/internal/memorylimiter v0.137.0 // indirect
+	go.opentelemetry.io/collector/internal/panicguard v0.137.0 // indirect
+	go.opentelemetry.io/collector/internal/redact v0.137.0 // indirect
 	go.opentelemetry.io/collector/internal/sharedcomponent v0.137.0 // indirect
 	go.opentelemetry.io/collector/internal/telemetry v0.137.0 // indirect
 	go.opentelemetry.io/collector/pdata v1.43.0 // indirect
@@ -175,6 +185,7 @@ require (
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250825161204-c5933d9347a5 // indirect
 	google.golang.org/grpc v1.76.0 // indirect
 	google.golang.org/protobuf v1.36.10 // indirect
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
@@ -242,6 +253,8 @@ replace go.opentelemetry.io/collector/connector/xconnector => ../../connector/xc
 
 replace go.opentelemetry.io/collector/connector/forwardconnector => ../../connector/forwardconnector
 
+replace go.opentelemetry.io/collector/connector/metadataroutingconnector => ../../connector/metadataroutingconnector
+
 replace go.opentelemetry.io/collector/exporter => ../../exporter
 
 replace go.opentelemetry.io/collector/exporter/debugexporter => ../../exporter/debugexporter
@@ -274,8 +287,18 @@ replace go.opentelemetry.io/collector/extension/extensionmiddleware/extensionmid
 
 replace go.opentelemetry.io/collector/extension/extensiontest => ../../extension/extensiontest
 
+replace go.opentelemetry.io/collector/extension/gctuningextension => ../../extension/gctuningextension
+
+replace go.opentelemetry.io/collector/extension/healthextension => ../../extension/healthextension
+
 replace go.opentelemetry.io/collector/extension/memorylimiterextension => ../../extension/memorylimiterextension
 
+replace go.opentelemetry.io/collector/extension/opampextension => ../../extension/opampextension
+
+replace go.opentelemetry.io/collector/extension/runtimeinfoextension => ../../extension/runtimeinfoextension
+
+replace go.opentelemetry.io/collector/extension/filestorageextension => ../../extension/filestorageextension
+
 replace go.opentelemetry.io/collector/extension/xextension => ../../extension/xextension
 
 replace go.opentelemetry.io/collector/extension/zpagesextension => ../../extension/zpagesextension
@@ -335,3 +358,7 @@ replace go.opentelemetry.io/collector/service => ../../service
 replace go.opentelemetry.io/collector/service/hostcapabilities => ../../service/hostcapabilities
 
 replace go.opentelemetry.io/collector/service/telemetry/telemetrytest => ../../service/telemetry/telemetrytest
+
+replace go.opentelemetry.io/collector/internal/panicguard => ../../internal/panicguard
+
+replace go.opentelemetry.io/collector/internal/redact => ../../internal/redact
@@ -0,0 +1,15 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import "go.opentelemetry.io/collector/featuregate"
+
+// rejectEmptyNameSegmentsGate rejects the component names containing empty segments, such as
+// "team-a//prod", which cannot be grouped by prefix.
+var rejectEmptyNameSegmentsGate = featuregate.GlobalRegistry().MustRegister(
+	"component.rejectEmptyNameSegments",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.138.0"),
+	featuregate.WithRegisterDescription("Rejects the component names containing empty segments, such as \"otlp/team-a//prod\"."),
+)
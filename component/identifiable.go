@@ -11,8 +11,14 @@ import (
 	"strings"
 )
 
-// typeAndNameSeparator is the separator that is used between type and name in type/name composite keys.
-const typeAndNameSeparator = "/"
+const (
+	// typeAndNameSeparator is the separator that is used between type and name in type/name composite keys.
+	typeAndNameSeparator = "/"
+
+	// nameSegmentSeparator is the separator that is used between the segments of hierarchical names,
+	// e.g. "team-a/prod" in "otlp/team-a/prod".
+	nameSegmentSeparator = "/"
+)
 
 var (
 	// typeRegexp is used to validate the type of component.
@@ -82,6 +88,9 @@ var (
 // * type - the Type of the component.
 // * name - the name of that component.
 // The component ID (combination type + name) is unique for a given component.Kind.
+//
+// The name can be hierarchical, with segments separated by "/" (e.g. "otlp/team-a/prod"),
+// so that components can be grouped by name prefix. See HasPrefix and HasNamePrefix.
 type ID struct {
 	typeVal Type   `mapstructure:"-"`
 	nameVal string `mapstructure:"-"`
@@ -121,6 +130,33 @@ func (id ID) Name() string {
 	return id.nameVal
 }
 
+// NameSegments returns the segments of the hierarchical name of the component,
+// or nil if the component has no name.
+func (id ID) NameSegments() []string {
+	if id.nameVal == "" {
+		return nil
+	}
+	return strings.Split(id.nameVal, nameSegmentSeparator)
+}
+
+// HasNamePrefix reports whether the name of the component is the given prefix or is nested
+// under it. Whole segments are compared: "team-a" matches the names "team-a" and "team-a/prod",
+// but not "team-ab". An empty prefix matches every name.
+func (id ID) HasNamePrefix(prefix string) bool {
+	if prefix == "" || id.nameVal == prefix {
+		return true
+	}
+	return strings.HasPrefix(id.nameVal, prefix+nameSegmentSeparator)
+}
+
+// HasPrefix reports whether the component has the same type as prefix, and its name
+// is the name of prefix or is nested under it. An ID without name is a prefix of every
+// ID of its type, e.g. "otlp/team-a" is a prefix of "otlp/team-a/prod" and "otlp" is
+// a prefix of both.
+func (id ID) HasPrefix(prefix ID) bool {
+	return id.typeVal == prefix.typeVal && id.HasNamePrefix(prefix.nameVal)
+}
+
 // MarshalText implements the encoding.TextMarshaler interface.
 // This marshals the type and name as one string in the config.
 func (id ID) MarshalText() ([]byte, error) {
@@ -176,5 +212,13 @@ func validateName(nameStr string) error {
 	if !nameRegexp.MatchString(nameStr) {
 		return fmt.Errorf("invalid character(s) in name %q", nameStr)
 	}
+	if !rejectEmptyNameSegmentsGate.IsEnabled() {
+		return nil
+	}
+	for _, segment := range strings.Split(nameStr, nameSegmentSeparator) {
+		if strings.TrimSpace(segment) == "" {
+			return fmt.Errorf("name %q must not contain empty segments", nameStr)
+		}
+	}
 	return nil
 }
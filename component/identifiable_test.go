@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
 )
 
 func TestMarshalText(t *testing.T) {
@@ -54,6 +56,14 @@ func TestUnmarshalText(t *testing.T) {
 			name:       "valid_type/1",
 			expectedID: ID{typeVal: validType, nameVal: "1"},
 		},
+		{
+			name:       "valid_type/team-a/prod",
+			expectedID: ID{typeVal: validType, nameVal: "team-a/prod"},
+		},
+		{
+			name:       "valid_type/team-a//prod",
+			expectedID: ID{typeVal: validType, nameVal: "team-a//prod"},
+		},
 		{
 			name:        "/valid_name",
 			expectedErr: true,
@@ -102,6 +112,46 @@ func TestUnmarshalText(t *testing.T) {
 	}
 }
 
+func TestRejectEmptyNameSegments(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(rejectEmptyNameSegmentsGate.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(rejectEmptyNameSegmentsGate.ID(), false))
+	}()
+
+	id := ID{}
+	require.NoError(t, id.UnmarshalText([]byte("valid_type/team-a/prod")))
+	require.EqualError(t, id.UnmarshalText([]byte("valid_type/team-a//prod")), `in "team-a//prod" id: name "team-a//prod" must not contain empty segments`)
+	require.Error(t, id.UnmarshalText([]byte("valid_type/team-a/")))
+}
+
+func TestNameSegments(t *testing.T) {
+	assert.Nil(t, MustNewID("otlp").NameSegments())
+	assert.Equal(t, []string{"prod"}, MustNewIDWithName("otlp", "prod").NameSegments())
+	assert.Equal(t, []string{"team-a", "prod"}, MustNewIDWithName("otlp", "team-a/prod").NameSegments())
+}
+
+func TestHasPrefix(t *testing.T) {
+	testCases := []struct {
+		id       ID
+		prefix   ID
+		expected bool
+	}{
+		{id: MustNewIDWithName("otlp", "team-a/prod"), prefix: MustNewID("otlp"), expected: true},
+		{id: MustNewIDWithName("otlp", "team-a/prod"), prefix: MustNewIDWithName("otlp", "team-a"), expected: true},
+		{id: MustNewIDWithName("otlp", "team-a/prod"), prefix: MustNewIDWithName("otlp", "team-a/prod"), expected: true},
+		{id: MustNewIDWithName("otlp", "team-a"), prefix: MustNewIDWithName("otlp", "team-a/prod"), expected: false},
+		{id: MustNewIDWithName("otlp", "team-ab/prod"), prefix: MustNewIDWithName("otlp", "team-a"), expected: false},
+		{id: MustNewIDWithName("otlp", "team-a/prod"), prefix: MustNewIDWithName("debug", "team-a"), expected: false},
+		{id: MustNewID("otlp"), prefix: MustNewIDWithName("otlp", "team-a"), expected: false},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.id.String()+" "+tt.prefix.String(), func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.id.HasPrefix(tt.prefix))
+			assert.Equal(t, tt.expected || tt.id.Type() != tt.prefix.Type(), tt.id.HasNamePrefix(tt.prefix.Name()))
+		})
+	}
+}
+
 func TestNewType(t *testing.T) {
 	tests := []struct {
 		name      string
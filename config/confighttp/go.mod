@@ -14,6 +14,7 @@ require (
 	go.opentelemetry.io/collector/config/configauth v1.43.0
 	go.opentelemetry.io/collector/config/configcompression v1.43.0
 	go.opentelemetry.io/collector/config/configmiddleware v1.43.0
+	go.opentelemetry.io/collector/config/confignet v1.43.0
 	go.opentelemetry.io/collector/config/configopaque v1.43.0
 	go.opentelemetry.io/collector/config/configoptional v1.43.0
 	go.opentelemetry.io/collector/config/configtls v1.43.0
@@ -30,6 +31,8 @@ require (
 	golang.org/x/net v0.43.0
 )
 
+require go.opentelemetry.io/collector/pipeline v1.43.0 // indirect
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/felixge/httpsnoop v1.0.4 // indirect
@@ -66,7 +69,7 @@ require (
 	go.uber.org/multierr v1.11.0 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
 	golang.org/x/crypto v0.41.0 // indirect
-	golang.org/x/sys v0.35.0 // indirect
+	golang.org/x/sys v0.36.0 // indirect
 	golang.org/x/text v0.28.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250804133106-a7a43d27e69b // indirect
 	google.golang.org/grpc v1.76.0 // indirect
@@ -115,3 +118,5 @@ replace go.opentelemetry.io/collector/extension/extensionmiddleware/extensionmid
 replace go.opentelemetry.io/collector/confmap => ../../confmap
 
 replace go.opentelemetry.io/collector/confmap/xconfmap => ../../confmap/xconfmap
+
+replace go.opentelemetry.io/collector/config/confignet => ../confignet
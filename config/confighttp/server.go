@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confighttp/internal"
 	"go.opentelemetry.io/collector/config/configmiddleware"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configtls"
@@ -121,9 +122,12 @@ type AuthConfig struct {
 	_ struct{}
 }
 
-// ToListener creates a net.Listener.
+// ToListener creates a net.Listener. The socket inherited from a previous process of the collector
+// is reused when it is bound to the endpoint, see confignet.ListenFDsEnv.
 func (sc *ServerConfig) ToListener(ctx context.Context) (net.Listener, error) {
-	listener, err := net.Listen("tcp", sc.Endpoint)
+	addr := confignet.NewDefaultTCPAddrConfig()
+	addr.Endpoint = sc.Endpoint
+	listener, err := addr.Listen(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +84,9 @@ type AddrConfig struct {
 
 	// DialerConfig contains options for connecting to an address.
 	DialerConfig DialerConfig `mapstructure:"dialer,omitempty"`
+
+	// SocketConfig contains low-level socket options applied when listening on or dialing this address.
+	SocketConfig SocketConfig `mapstructure:"socket,omitempty"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -92,19 +95,18 @@ type AddrConfig struct {
 func NewDefaultAddrConfig() AddrConfig {
 	return AddrConfig{
 		DialerConfig: NewDefaultDialerConfig(),
+		SocketConfig: NewDefaultSocketConfig(),
 	}
 }
 
 // Dial equivalent with net.Dialer's DialContext for this address.
 func (na *AddrConfig) Dial(ctx context.Context) (net.Conn, error) {
-	d := net.Dialer{Timeout: na.DialerConfig.Timeout}
-	return d.DialContext(ctx, string(na.Transport), na.Endpoint)
+	return dial(ctx, string(na.Transport), na.Endpoint, na.DialerConfig, na.SocketConfig)
 }
 
 // Listen equivalent with net.ListenConfig's Listen for this address.
 func (na *AddrConfig) Listen(ctx context.Context) (net.Listener, error) {
-	lc := net.ListenConfig{}
-	return lc.Listen(ctx, string(na.Transport), na.Endpoint)
+	return listen(ctx, string(na.Transport), na.Endpoint, na.SocketConfig)
 }
 
 func (na *AddrConfig) Validate() error {
@@ -138,6 +140,9 @@ type TCPAddrConfig struct {
 
 	// DialerConfig contains options for connecting to an address.
 	DialerConfig DialerConfig `mapstructure:"dialer,omitempty"`
+
+	// SocketConfig contains low-level socket options applied when listening on or dialing this address.
+	SocketConfig SocketConfig `mapstructure:"socket,omitempty"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -146,17 +151,47 @@ type TCPAddrConfig struct {
 func NewDefaultTCPAddrConfig() TCPAddrConfig {
 	return TCPAddrConfig{
 		DialerConfig: NewDefaultDialerConfig(),
+		SocketConfig: NewDefaultSocketConfig(),
 	}
 }
 
 // Dial equivalent with net.Dialer's DialContext for this address.
 func (na *TCPAddrConfig) Dial(ctx context.Context) (net.Conn, error) {
-	d := net.Dialer{Timeout: na.DialerConfig.Timeout}
-	return d.DialContext(ctx, string(TransportTypeTCP), na.Endpoint)
+	return dial(ctx, string(TransportTypeTCP), na.Endpoint, na.DialerConfig, na.SocketConfig)
 }
 
 // Listen equivalent with net.ListenConfig's Listen for this address.
 func (na *TCPAddrConfig) Listen(ctx context.Context) (net.Listener, error) {
-	lc := net.ListenConfig{}
-	return lc.Listen(ctx, string(TransportTypeTCP), na.Endpoint)
+	return listen(ctx, string(TransportTypeTCP), na.Endpoint, na.SocketConfig)
+}
+
+func dial(ctx context.Context, network, address string, dc DialerConfig, sc SocketConfig) (net.Conn, error) {
+	d := net.Dialer{
+		Timeout: dc.Timeout,
+		Control: sc.dialControl(),
+	}
+	conn, err := d.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	if err = sc.configureConn(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func listen(ctx context.Context, network, address string, sc SocketConfig) (net.Listener, error) {
+	// The socket inherited from a previous process of the collector already has its options set.
+	if ln := takeInherited(network, address); ln != nil {
+		return track(sc.wrapListener(ln), ln), nil
+	}
+	lc := net.ListenConfig{
+		Control: sc.listenControl(),
+	}
+	ln, err := lc.Listen(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	return track(sc.wrapListener(ln), ln), nil
 }
@@ -159,3 +159,83 @@ func Test_TransportType_UnmarshalText(t *testing.T) {
 	err = tt.UnmarshalText([]byte("invalid"))
 	require.Error(t, err)
 }
+
+func TestSocketConfigValidate(t *testing.T) {
+	sc := NewDefaultSocketConfig()
+	require.NoError(t, sc.Validate())
+
+	sc = SocketConfig{ReceiveBufferSize: -1}
+	require.ErrorContains(t, sc.Validate(), "receive_buffer_size")
+
+	sc = SocketConfig{SendBufferSize: -1}
+	require.ErrorContains(t, sc.Validate(), "send_buffer_size")
+
+	sc = SocketConfig{ReusePort: true}
+	if reusePortSupported {
+		require.NoError(t, sc.Validate())
+	} else {
+		require.ErrorIs(t, sc.Validate(), errReusePortNotSupported)
+	}
+}
+
+func TestAddrConfigSocketOptions(t *testing.T) {
+	noDelay := false
+	nas := &AddrConfig{
+		Endpoint:  "localhost:0",
+		Transport: TransportTypeTCP,
+		SocketConfig: SocketConfig{
+			ReceiveBufferSize: 64 * 1024,
+			SendBufferSize:    64 * 1024,
+			TCPNoDelay:        &noDelay,
+		},
+	}
+	ln, err := nas.Listen(context.Background())
+	require.NoError(t, err)
+	done := make(chan bool, 1)
+
+	go func() {
+		conn, errGo := ln.Accept()
+		assert.NoError(t, errGo)
+		buf := make([]byte, 10)
+		var numChr int
+		numChr, errGo = conn.Read(buf)
+		assert.NoError(t, errGo)
+		assert.Equal(t, "test", string(buf[:numChr]))
+		assert.NoError(t, conn.Close())
+		done <- true
+	}()
+
+	nac := &AddrConfig{
+		Endpoint:     ln.Addr().String(),
+		Transport:    TransportTypeTCP,
+		SocketConfig: nas.SocketConfig,
+	}
+	var conn net.Conn
+	conn, err = nac.Dial(context.Background())
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("test"))
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Close())
+	<-done
+	assert.NoError(t, ln.Close())
+}
+
+func TestTCPAddrConfigReusePort(t *testing.T) {
+	if !reusePortSupported {
+		t.Skip("reuse_port is not supported on this platform")
+	}
+	nas := &TCPAddrConfig{
+		Endpoint:     "localhost:0",
+		SocketConfig: SocketConfig{ReusePort: true},
+	}
+	ln1, err := nas.Listen(context.Background())
+	require.NoError(t, err)
+
+	// A second listener on the same port succeeds only with SO_REUSEPORT set.
+	nas.Endpoint = ln1.Addr().String()
+	ln2, err := nas.Listen(context.Background())
+	require.NoError(t, err)
+
+	assert.NoError(t, ln2.Close())
+	assert.NoError(t, ln1.Close())
+}
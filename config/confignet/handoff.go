@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ListenFDsEnv is the environment variable holding the number of listening sockets inherited by the
+// process, passed as the file descriptors following the standard streams, as done by systemd socket
+// activation. The listeners opened on the address of an inherited socket reuse it.
+const ListenFDsEnv = "LISTEN_FDS"
+
+var (
+	// openListeners holds the listeners opened by Listen and not closed yet, see ListenerFiles.
+	openListeners sync.Map
+
+	// inherited holds the listening sockets inherited by the process and not reused yet.
+	inheritedMu   sync.Mutex
+	inherited     []net.Listener
+	inheritedOnce sync.Once
+)
+
+// ListenerFiles returns duplicates of the listening sockets opened by Listen and not closed yet,
+// to pass them to a new process of the collector, using ListenFDsEnv, so that it listens on them
+// while this process drains its data. The caller is responsible for closing the files.
+func ListenerFiles() ([]*os.File, error) {
+	var files []*os.File
+	var errs []error
+	openListeners.Range(func(key, _ any) bool {
+		fl, ok := key.(*trackedListener).raw.(interface{ File() (*os.File, error) })
+		if !ok {
+			return true
+		}
+		f, err := fl.File()
+		if err != nil {
+			errs = append(errs, err)
+			return true
+		}
+		files = append(files, f)
+		return true
+	})
+	if err := errors.Join(errs...); err != nil {
+		for _, f := range files {
+			_ = f.Close()
+		}
+		return nil, err
+	}
+	return files, nil
+}
+
+// trackedListener removes the listener from the open listeners when it is closed.
+type trackedListener struct {
+	net.Listener
+	raw net.Listener
+}
+
+func track(ln, raw net.Listener) net.Listener {
+	tl := &trackedListener{Listener: ln, raw: raw}
+	openListeners.Store(tl, struct{}{})
+	return tl
+}
+
+func (tl *trackedListener) Close() error {
+	openListeners.Delete(tl)
+	return tl.Listener.Close()
+}
+
+// inheritListeners makes the listeners created from the given files reused by Listen.
+func inheritListeners(files []*os.File) {
+	inheritedMu.Lock()
+	defer inheritedMu.Unlock()
+	for _, f := range files {
+		ln, err := net.FileListener(f)
+		_ = f.Close()
+		if err == nil {
+			inherited = append(inherited, ln)
+		}
+	}
+}
+
+// takeInherited returns the inherited listener bound to the address, if any, which is not reused again.
+func takeInherited(network, address string) net.Listener {
+	inheritedOnce.Do(loadInheritedListeners)
+	inheritedMu.Lock()
+	defer inheritedMu.Unlock()
+	for i, ln := range inherited {
+		if addrMatches(ln.Addr(), network, address) {
+			inherited = append(inherited[:i], inherited[i+1:]...)
+			return ln
+		}
+	}
+	return nil
+}
+
+// addrMatches returns whether the listener bound to addr listens on the address. An address with the
+// port 0 never matches, as it asks for a new port.
+func addrMatches(addr net.Addr, network, address string) bool {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		if !strings.HasPrefix(network, "tcp") {
+			return false
+		}
+		want, err := net.ResolveTCPAddr(network, address)
+		if err != nil || want.Port == 0 || want.Port != a.Port {
+			return false
+		}
+		if want.IP == nil || want.IP.IsUnspecified() {
+			return a.IP.IsUnspecified()
+		}
+		return want.IP.Equal(a.IP)
+	case *net.UnixAddr:
+		return network == a.Net && address == a.Name
+	}
+	return false
+}
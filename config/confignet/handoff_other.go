@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !unix
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+// loadInheritedListeners does nothing, as sockets are not inherited on this platform.
+func loadInheritedListeners() {}
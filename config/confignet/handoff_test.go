@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package confignet
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenerFiles(t *testing.T) {
+	before, err := ListenerFiles()
+	require.NoError(t, err)
+	closeFiles(before)
+
+	addr := NewDefaultTCPAddrConfig()
+	addr.Endpoint = "localhost:0"
+	ln, err := addr.Listen(context.Background())
+	require.NoError(t, err)
+
+	files, err := ListenerFiles()
+	require.NoError(t, err)
+	assert.Len(t, files, len(before)+1)
+	closeFiles(files)
+
+	require.NoError(t, ln.Close())
+	files, err = ListenerFiles()
+	require.NoError(t, err)
+	assert.Len(t, files, len(before))
+	closeFiles(files)
+}
+
+func TestListenReusesInheritedListener(t *testing.T) {
+	prev, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f, err := prev.(*net.TCPListener).File()
+	require.NoError(t, err)
+	inheritListeners([]*os.File{f})
+	// The previous process stops listening once the new one listens.
+	require.NoError(t, prev.Close())
+
+	addr := NewDefaultTCPAddrConfig()
+	addr.Endpoint = prev.Addr().String()
+	ln, err := addr.Listen(context.Background())
+	require.NoError(t, err)
+	defer ln.Close()
+	assert.Equal(t, prev.Addr().String(), ln.Addr().String())
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	accepted, err := ln.Accept()
+	require.NoError(t, err)
+	require.NoError(t, accepted.Close())
+
+	// The inherited listener is only reused once.
+	assert.Nil(t, takeInherited("tcp", prev.Addr().String()))
+}
+
+func TestAddrMatches(t *testing.T) {
+	tcpAddr := func(ip string) net.Addr { return &net.TCPAddr{IP: net.ParseIP(ip), Port: 4317} }
+	for _, tt := range []struct {
+		name    string
+		addr    net.Addr
+		network string
+		address string
+		want    bool
+	}{
+		{name: "same", addr: tcpAddr("127.0.0.1"), network: "tcp", address: "127.0.0.1:4317", want: true},
+		{name: "unspecified", addr: tcpAddr("::"), network: "tcp", address: ":4317", want: true},
+		{name: "unspecified_ipv4", addr: tcpAddr("::"), network: "tcp", address: "0.0.0.0:4317", want: true},
+		{name: "other_port", addr: tcpAddr("127.0.0.1"), network: "tcp", address: "127.0.0.1:4318"},
+		{name: "other_ip", addr: tcpAddr("127.0.0.1"), network: "tcp", address: "10.0.0.1:4317"},
+		{name: "any_port", addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1")}, network: "tcp", address: "127.0.0.1:0"},
+		{name: "other_network", addr: tcpAddr("127.0.0.1"), network: "unix", address: "127.0.0.1:4317"},
+		{name: "unix", addr: &net.UnixAddr{Net: "unix", Name: "/tmp/otel.sock"}, network: "unix", address: "/tmp/otel.sock", want: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, addrMatches(tt.addr, tt.network, tt.address))
+		})
+	}
+}
+
+func closeFiles(files []*os.File) {
+	for _, f := range files {
+		_ = f.Close()
+	}
+}
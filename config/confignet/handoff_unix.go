@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+)
+
+// listenFDsStart is the first file descriptor of the inherited sockets, following the standard streams.
+const listenFDsStart = 3
+
+// loadInheritedListeners inherits the sockets listed by the environment, unless they are passed by
+// systemd to another process, and unsets the environment so that child processes do not inherit them.
+func loadInheritedListeners() {
+	n, err := strconv.Atoi(os.Getenv(ListenFDsEnv))
+	if pid := os.Getenv("LISTEN_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return
+	}
+	_ = os.Unsetenv(ListenFDsEnv)
+	_ = os.Unsetenv("LISTEN_PID")
+	_ = os.Unsetenv("LISTEN_FDNAMES")
+	if err != nil || n <= 0 {
+		return
+	}
+	files := make([]*os.File, 0, n)
+	for fd := listenFDsStart; fd < listenFDsStart+n; fd++ {
+		syscall.CloseOnExec(fd)
+		files = append(files, os.NewFile(uintptr(fd), "inherited listener"))
+	}
+	inheritListeners(files)
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+)
+
+var errReusePortNotSupported = errors.New("reuse_port is not supported on this platform")
+
+// SocketConfig contains low-level socket options applied to listeners and dialers.
+type SocketConfig struct {
+	// ReusePort sets SO_REUSEPORT on listening sockets, allowing multiple processes
+	// to bind the same address and have the kernel balance incoming connections between them.
+	// Only supported on Linux, the BSDs, Darwin and AIX.
+	ReusePort bool `mapstructure:"reuse_port,omitempty"`
+
+	// ReceiveBufferSize sets SO_RCVBUF in bytes. The default (0) keeps the operating system default.
+	ReceiveBufferSize int `mapstructure:"receive_buffer_size,omitempty"`
+
+	// SendBufferSize sets SO_SNDBUF in bytes. The default (0) keeps the operating system default.
+	SendBufferSize int `mapstructure:"send_buffer_size,omitempty"`
+
+	// TCPNoDelay controls TCP_NODELAY on TCP connections. If unset, the Go default (enabled) is used.
+	TCPNoDelay *bool `mapstructure:"tcp_nodelay,omitempty"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// NewDefaultSocketConfig creates a new SocketConfig with any default values set
+func NewDefaultSocketConfig() SocketConfig {
+	return SocketConfig{}
+}
+
+// Validate checks that the socket options are valid and supported on this platform.
+func (sc *SocketConfig) Validate() error {
+	var errs []error
+	if sc.ReusePort && !reusePortSupported {
+		errs = append(errs, errReusePortNotSupported)
+	}
+	if sc.ReceiveBufferSize < 0 {
+		errs = append(errs, fmt.Errorf("receive_buffer_size must be non-negative, got %d", sc.ReceiveBufferSize))
+	}
+	if sc.SendBufferSize < 0 {
+		errs = append(errs, fmt.Errorf("send_buffer_size must be non-negative, got %d", sc.SendBufferSize))
+	}
+	return errors.Join(errs...)
+}
+
+// listenControl returns a function suitable for net.ListenConfig.Control, or nil if no
+// options need to be set before binding the socket.
+func (sc *SocketConfig) listenControl() func(network, address string, c syscall.RawConn) error {
+	if !sc.ReusePort && sc.ReceiveBufferSize == 0 && sc.SendBufferSize == 0 {
+		return nil
+	}
+	return func(_, _ string, c syscall.RawConn) error {
+		return sc.control(c, sc.ReusePort)
+	}
+}
+
+// dialControl returns a function suitable for net.Dialer.Control, or nil if no
+// options need to be set before connecting the socket.
+func (sc *SocketConfig) dialControl() func(network, address string, c syscall.RawConn) error {
+	if sc.ReceiveBufferSize == 0 && sc.SendBufferSize == 0 {
+		return nil
+	}
+	return func(_, _ string, c syscall.RawConn) error {
+		return sc.control(c, false)
+	}
+}
+
+func (sc *SocketConfig) control(c syscall.RawConn, reusePort bool) error {
+	var opErr error
+	err := c.Control(func(fd uintptr) {
+		if reusePort {
+			if opErr = setReusePort(fd); opErr != nil {
+				return
+			}
+		}
+		if sc.ReceiveBufferSize > 0 {
+			if opErr = setReceiveBufferSize(fd, sc.ReceiveBufferSize); opErr != nil {
+				return
+			}
+		}
+		if sc.SendBufferSize > 0 {
+			opErr = setSendBufferSize(fd, sc.SendBufferSize)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return opErr
+}
+
+// configureConn applies the options that can only be set on an established connection.
+func (sc *SocketConfig) configureConn(conn net.Conn) error {
+	if sc.TCPNoDelay == nil {
+		return nil
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		return tcpConn.SetNoDelay(*sc.TCPNoDelay)
+	}
+	return nil
+}
+
+// wrapListener wraps the listener so that accepted connections get the
+// connection level options applied.
+func (sc *SocketConfig) wrapListener(ln net.Listener) net.Listener {
+	if sc.TCPNoDelay == nil {
+		return ln
+	}
+	return &socketListener{Listener: ln, sc: sc}
+}
+
+type socketListener struct {
+	net.Listener
+	sc *SocketConfig
+}
+
+func (sl *socketListener) Accept() (net.Conn, error) {
+	conn, err := sl.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	if err = sl.sc.configureConn(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
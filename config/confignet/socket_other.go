@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !unix && !windows
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import "errors"
+
+var errBufferSizeNotSupported = errors.New("socket buffer sizes are not supported on this platform")
+
+func setReceiveBufferSize(uintptr, int) error {
+	return errBufferSizeNotSupported
+}
+
+func setSendBufferSize(uintptr, int) error {
+	return errBufferSizeNotSupported
+}
@@ -0,0 +1,12 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+const reusePortSupported = false
+
+func setReusePort(uintptr) error {
+	return errReusePortNotSupported
+}
@@ -223,6 +223,18 @@ func (c Config) Validate() error {
 		return errors.New("invalid TLS configuration: min_version cannot be greater than max_version")
 	}
 
+	if fipsEnabled() {
+		cipherSuites, err := convertCipherSuites(c.CipherSuites)
+		if err != nil {
+			return err
+		}
+		curvePreferences, err := convertCurvePreferences(c.CurvePreferences)
+		if err != nil {
+			return err
+		}
+		return c.validateFIPS(minTLS, maxTLS, cipherSuites, curvePreferences)
+	}
+
 	return nil
 }
 
@@ -268,13 +280,14 @@ func (c Config) loadTLSConfig() (*tls.Config, error) {
 	if err != nil {
 		return nil, err
 	}
-	curvePreferences := make([]tls.CurveID, 0, len(c.CurvePreferences))
-	for _, curve := range c.CurvePreferences {
-		curveID, ok := tlsCurveTypes[curve]
-		if !ok {
-			return nil, fmt.Errorf("invalid curve type: %s. Expected values are [P-256, P-384, P-521, X25519, X25519MLKEM768]", curve)
+	curvePreferences, err := convertCurvePreferences(c.CurvePreferences)
+	if err != nil {
+		return nil, err
+	}
+	if fipsEnabled() {
+		if err = c.validateFIPS(minTLS, maxTLS, cipherSuites, curvePreferences); err != nil {
+			return nil, err
 		}
-		curvePreferences = append(curvePreferences, curveID)
 	}
 
 	return &tls.Config{
@@ -307,6 +320,18 @@ func convertCipherSuites(cipherSuites []string) ([]uint16, error) {
 	return result, errors.Join(errs...)
 }
 
+func convertCurvePreferences(curves []string) ([]tls.CurveID, error) {
+	result := make([]tls.CurveID, 0, len(curves))
+	for _, curve := range curves {
+		curveID, ok := tlsCurveTypes[curve]
+		if !ok {
+			return nil, fmt.Errorf("invalid curve type: %s. Expected values are [P-256, P-384, P-521, X25519, X25519MLKEM768]", curve)
+		}
+		result = append(result, curveID)
+	}
+	return result, nil
+}
+
 func (c Config) loadCACertPool() (*x509.CertPool, error) {
 	// There is no need to load the System Certs for RootCAs because
 	// if the value is nil, it will default to checking against th System Certs.
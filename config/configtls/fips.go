@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"crypto/fips140"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// fipsEnabled reports whether the collector runs in FIPS 140-3 mode, i.e. it was built with a Go
+// Cryptographic Module or run with GODEBUG=fips140=on. Replaced in tests.
+var fipsEnabled = fips140.Enabled
+
+// The TLS settings approved in FIPS 140-3 mode, see https://go.dev/doc/security/fips140.
+var (
+	fipsMinTLSVersion = uint16(tls.VersionTLS12)
+
+	fipsCipherSuites = []uint16{
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256,
+	}
+
+	fipsCurves = []tls.CurveID{
+		tls.CurveP256,
+		tls.CurveP384,
+		tls.CurveP521,
+		tls.X25519MLKEM768,
+	}
+)
+
+// validateFIPS returns an error listing the settings not approved in FIPS 140-3 mode, rather than
+// letting crypto/tls silently leave them out of the handshakes.
+func (c Config) validateFIPS(minTLS, maxTLS uint16, cipherSuites []uint16, curves []tls.CurveID) error {
+	var errs []error
+	if minTLS < fipsMinTLSVersion {
+		errs = append(errs, fmt.Errorf("min_version %q is not allowed in FIPS 140-3 mode, the minimum is \"1.2\"", c.MinVersion))
+	}
+	if maxTLS != defaultMaxTLSVersion && maxTLS < fipsMinTLSVersion {
+		errs = append(errs, fmt.Errorf("max_version %q is not allowed in FIPS 140-3 mode, the minimum is \"1.2\"", c.MaxVersion))
+	}
+	for i, suite := range cipherSuites {
+		if !slices.Contains(fipsCipherSuites, suite) {
+			errs = append(errs, fmt.Errorf("cipher suite %q is not approved in FIPS 140-3 mode", c.CipherSuites[i]))
+		}
+	}
+	for i, curve := range curves {
+		if !slices.Contains(fipsCurves, curve) {
+			errs = append(errs, fmt.Errorf("curve %q is not approved in FIPS 140-3 mode", c.CurvePreferences[i]))
+		}
+	}
+	return errors.Join(errs...)
+}
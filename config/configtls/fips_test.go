@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func enableFIPS(t *testing.T) {
+	prev := fipsEnabled
+	fipsEnabled = func() bool { return true }
+	t.Cleanup(func() { fipsEnabled = prev })
+}
+
+func TestConfigValidateFIPS(t *testing.T) {
+	enableFIPS(t)
+
+	tests := []struct {
+		name      string
+		tlsConfig Config
+		errorTxt  string
+	}{
+		{name: "default", tlsConfig: NewDefaultConfig()},
+		{
+			name: "approved settings",
+			tlsConfig: Config{
+				MinVersion:       "1.2",
+				MaxVersion:       "1.3",
+				CipherSuites:     []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"},
+				CurvePreferences: []string{"P256", "X25519MLKEM768"},
+			},
+		},
+		{name: "min version", tlsConfig: Config{MinVersion: "1.1"}, errorTxt: `min_version "1.1" is not allowed in FIPS 140-3 mode, the minimum is "1.2"`},
+		{
+			name:      "max version",
+			tlsConfig: Config{MinVersion: "1.0", MaxVersion: "1.1"},
+			errorTxt:  "min_version \"1.0\" is not allowed in FIPS 140-3 mode, the minimum is \"1.2\"\nmax_version \"1.1\" is not allowed in FIPS 140-3 mode, the minimum is \"1.2\"",
+		},
+		{
+			name:      "cipher suite",
+			tlsConfig: Config{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"}},
+			errorTxt:  `cipher suite "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256" is not approved in FIPS 140-3 mode`,
+		},
+		{name: "curve", tlsConfig: Config{CurvePreferences: []string{"P384", "X25519"}}, errorTxt: `curve "X25519" is not approved in FIPS 140-3 mode`},
+		{name: "invalid curve", tlsConfig: Config{CurvePreferences: []string{"P-999"}}, errorTxt: "invalid curve type: P-999. Expected values are [P-256, P-384, P-521, X25519, X25519MLKEM768]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.tlsConfig.Validate()
+			if test.errorTxt == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.errorTxt)
+			}
+		})
+	}
+}
+
+func TestLoadTLSConfigFIPS(t *testing.T) {
+	enableFIPS(t)
+
+	cfg := ClientConfig{Config: Config{CurvePreferences: []string{"X25519"}}}
+	_, err := cfg.LoadTLSConfig(t.Context())
+	assert.EqualError(t, err, `failed to load TLS config: curve "X25519" is not approved in FIPS 140-3 mode`)
+
+	cfg = ClientConfig{Config: Config{CurvePreferences: []string{"P256"}}}
+	_, err = cfg.LoadTLSConfig(t.Context())
+	assert.NoError(t, err)
+}
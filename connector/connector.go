@@ -160,6 +160,9 @@ type CreateLogsToLogsFunc func(context.Context, Settings, component.Config, cons
 
 // WithTracesToTraces overrides the default "error not supported" implementation for WithTracesToTraces and the default "undefined" stability level.
 func WithTracesToTraces(createTracesToTraces CreateTracesToTracesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.TracesEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.tracesToTracesStabilityLevel = sl
 		o.createTracesToTracesFunc = createTracesToTraces
@@ -168,6 +171,9 @@ func WithTracesToTraces(createTracesToTraces CreateTracesToTracesFunc, sl compon
 
 // WithTracesToMetrics overrides the default "error not supported" implementation for WithTracesToMetrics and the default "undefined" stability level.
 func WithTracesToMetrics(createTracesToMetrics CreateTracesToMetricsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.TracesEnabled || !internal.MetricsEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.tracesToMetricsStabilityLevel = sl
 		o.createTracesToMetricsFunc = createTracesToMetrics
@@ -176,6 +182,9 @@ func WithTracesToMetrics(createTracesToMetrics CreateTracesToMetricsFunc, sl com
 
 // WithTracesToLogs overrides the default "error not supported" implementation for WithTracesToLogs and the default "undefined" stability level.
 func WithTracesToLogs(createTracesToLogs CreateTracesToLogsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.TracesEnabled || !internal.LogsEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.tracesToLogsStabilityLevel = sl
 		o.createTracesToLogsFunc = createTracesToLogs
@@ -184,6 +193,9 @@ func WithTracesToLogs(createTracesToLogs CreateTracesToLogsFunc, sl component.St
 
 // WithMetricsToTraces overrides the default "error not supported" implementation for WithMetricsToTraces and the default "undefined" stability level.
 func WithMetricsToTraces(createMetricsToTraces CreateMetricsToTracesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.MetricsEnabled || !internal.TracesEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.metricsToTracesStabilityLevel = sl
 		o.createMetricsToTracesFunc = createMetricsToTraces
@@ -192,6 +204,9 @@ func WithMetricsToTraces(createMetricsToTraces CreateMetricsToTracesFunc, sl com
 
 // WithMetricsToMetrics overrides the default "error not supported" implementation for WithMetricsToMetrics and the default "undefined" stability level.
 func WithMetricsToMetrics(createMetricsToMetrics CreateMetricsToMetricsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.MetricsEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.metricsToMetricsStabilityLevel = sl
 		o.createMetricsToMetricsFunc = createMetricsToMetrics
@@ -200,6 +215,9 @@ func WithMetricsToMetrics(createMetricsToMetrics CreateMetricsToMetricsFunc, sl
 
 // WithMetricsToLogs overrides the default "error not supported" implementation for WithMetricsToLogs and the default "undefined" stability level.
 func WithMetricsToLogs(createMetricsToLogs CreateMetricsToLogsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.MetricsEnabled || !internal.LogsEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.metricsToLogsStabilityLevel = sl
 		o.createMetricsToLogsFunc = createMetricsToLogs
@@ -208,6 +226,9 @@ func WithMetricsToLogs(createMetricsToLogs CreateMetricsToLogsFunc, sl component
 
 // WithLogsToTraces overrides the default "error not supported" implementation for WithLogsToTraces and the default "undefined" stability level.
 func WithLogsToTraces(createLogsToTraces CreateLogsToTracesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.LogsEnabled || !internal.TracesEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.logsToTracesStabilityLevel = sl
 		o.createLogsToTracesFunc = createLogsToTraces
@@ -216,6 +237,9 @@ func WithLogsToTraces(createLogsToTraces CreateLogsToTracesFunc, sl component.St
 
 // WithLogsToMetrics overrides the default "error not supported" implementation for WithLogsToMetrics and the default "undefined" stability level.
 func WithLogsToMetrics(createLogsToMetrics CreateLogsToMetricsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.LogsEnabled || !internal.MetricsEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.logsToMetricsStabilityLevel = sl
 		o.createLogsToMetricsFunc = createLogsToMetrics
@@ -224,6 +248,9 @@ func WithLogsToMetrics(createLogsToMetrics CreateLogsToMetricsFunc, sl component
 
 // WithLogsToLogs overrides the default "error not supported" implementation for WithLogsToLogs and the default "undefined" stability level.
 func WithLogsToLogs(createLogsToLogs CreateLogsToLogsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.LogsEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.logsToLogsStabilityLevel = sl
 		o.createLogsToLogsFunc = createLogsToLogs
@@ -0,0 +1,311 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectortest // import "go.opentelemetry.io/collector/connector/connectortest"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/xconnector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+)
+
+var signals = []pipeline.Signal{pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs, xpipeline.SignalProfiles}
+
+// Topology is an in-memory graph of pipelines connected by a connector, as built by the
+// collector: the upstream pipelines export to the connector, and the downstream pipelines
+// receive from it. The connector is created once for each pair of upstream and downstream
+// signals it supports, and the data received by each downstream pipeline is recorded in a sink.
+type Topology struct {
+	upstream   map[pipeline.ID]bool
+	components []component.Component
+
+	traces   consumer.Traces
+	metrics  consumer.Metrics
+	logs     consumer.Logs
+	profiles xconsumer.Profiles
+
+	tracesSinks   map[pipeline.ID]*consumertest.TracesSink
+	metricsSinks  map[pipeline.ID]*consumertest.MetricsSink
+	logsSinks     map[pipeline.ID]*consumertest.LogsSink
+	profilesSinks map[pipeline.ID]*consumertest.ProfilesSink
+}
+
+// NewTopology creates the connector for the given upstream and downstream pipelines. As with
+// the collector, it returns an error if the connector does not support any of the downstream
+// signals for one of the upstream signals, or the reverse.
+func NewTopology(
+	ctx context.Context,
+	set connector.Settings,
+	factory connector.Factory,
+	cfg component.Config,
+	upstream, downstream []pipeline.ID,
+) (*Topology, error) {
+	t := &Topology{
+		upstream:      make(map[pipeline.ID]bool),
+		tracesSinks:   make(map[pipeline.ID]*consumertest.TracesSink),
+		metricsSinks:  make(map[pipeline.ID]*consumertest.MetricsSink),
+		logsSinks:     make(map[pipeline.ID]*consumertest.LogsSink),
+		profilesSinks: make(map[pipeline.ID]*consumertest.ProfilesSink),
+	}
+	exprSignals := make(map[pipeline.Signal]bool)
+	for _, id := range upstream {
+		t.upstream[id] = true
+		exprSignals[id.Signal()] = true
+	}
+	rcvrSignals := make(map[pipeline.Signal]bool)
+	for _, id := range downstream {
+		rcvrSignals[id.Signal()] = true
+		switch id.Signal() {
+		case pipeline.SignalTraces:
+			t.tracesSinks[id] = new(consumertest.TracesSink)
+		case pipeline.SignalMetrics:
+			t.metricsSinks[id] = new(consumertest.MetricsSink)
+		case pipeline.SignalLogs:
+			t.logsSinks[id] = new(consumertest.LogsSink)
+		case xpipeline.SignalProfiles:
+			t.profilesSinks[id] = new(consumertest.ProfilesSink)
+		default:
+			return nil, fmt.Errorf("unsupported signal of pipeline %q", id)
+		}
+	}
+
+	consumers := make(map[pipeline.Signal][]component.Component)
+	exprConnected := make(map[pipeline.Signal]bool)
+	rcvrConnected := make(map[pipeline.Signal]bool)
+	for _, expr := range signals {
+		if !exprSignals[expr] {
+			continue
+		}
+		for _, rcvr := range signals {
+			if !rcvrSignals[rcvr] {
+				continue
+			}
+			comp, err := t.create(ctx, set, factory, cfg, expr, rcvr)
+			if errors.Is(err, pipeline.ErrSignalNotSupported) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			t.components = append(t.components, comp)
+			consumers[expr] = append(consumers[expr], comp)
+			exprConnected[expr] = true
+			rcvrConnected[rcvr] = true
+		}
+	}
+	for _, signal := range signals {
+		if exprSignals[signal] && !exprConnected[signal] {
+			return nil, fmt.Errorf("connector %q used as exporter in %s pipeline but not used in any supported receiver pipeline", set.ID, signal)
+		}
+	}
+	for _, signal := range signals {
+		if rcvrSignals[signal] && !rcvrConnected[signal] {
+			return nil, fmt.Errorf("connector %q used as receiver in %s pipeline but not used in any supported exporter pipeline", set.ID, signal)
+		}
+	}
+
+	t.traces = fanoutconsumer.NewTraces(toConsumers[consumer.Traces](consumers[pipeline.SignalTraces]))
+	t.metrics = fanoutconsumer.NewMetrics(toConsumers[consumer.Metrics](consumers[pipeline.SignalMetrics]))
+	t.logs = fanoutconsumer.NewLogs(toConsumers[consumer.Logs](consumers[pipeline.SignalLogs]))
+	t.profiles = fanoutconsumer.NewProfiles(toConsumers[xconsumer.Profiles](consumers[xpipeline.SignalProfiles]))
+	return t, nil
+}
+
+func toConsumers[C any](comps []component.Component) []C {
+	consumers := make([]C, 0, len(comps))
+	for _, comp := range comps {
+		consumers = append(consumers, comp.(C))
+	}
+	return consumers
+}
+
+// create creates the connector from the expr signal to the rcvr signal, exporting to the sinks
+// of the downstream pipelines of the rcvr signal through a router.
+func (t *Topology) create(
+	ctx context.Context,
+	set connector.Settings,
+	factory connector.Factory,
+	cfg component.Config,
+	expr, rcvr pipeline.Signal,
+) (component.Component, error) {
+	xfactory, isX := factory.(xconnector.Factory)
+	if (expr == xpipeline.SignalProfiles || rcvr == xpipeline.SignalProfiles) && !isX {
+		return nil, pipeline.ErrSignalNotSupported
+	}
+
+	switch rcvr {
+	case pipeline.SignalTraces:
+		sinks := make(map[pipeline.ID]consumer.Traces, len(t.tracesSinks))
+		for id, sink := range t.tracesSinks {
+			sinks[id] = sink
+		}
+		next := connector.NewTracesRouter(sinks)
+		switch expr {
+		case pipeline.SignalTraces:
+			return factory.CreateTracesToTraces(ctx, set, cfg, next)
+		case pipeline.SignalMetrics:
+			return factory.CreateMetricsToTraces(ctx, set, cfg, next)
+		case pipeline.SignalLogs:
+			return factory.CreateLogsToTraces(ctx, set, cfg, next)
+		case xpipeline.SignalProfiles:
+			return xfactory.CreateProfilesToTraces(ctx, set, cfg, next)
+		}
+	case pipeline.SignalMetrics:
+		sinks := make(map[pipeline.ID]consumer.Metrics, len(t.metricsSinks))
+		for id, sink := range t.metricsSinks {
+			sinks[id] = sink
+		}
+		next := connector.NewMetricsRouter(sinks)
+		switch expr {
+		case pipeline.SignalTraces:
+			return factory.CreateTracesToMetrics(ctx, set, cfg, next)
+		case pipeline.SignalMetrics:
+			return factory.CreateMetricsToMetrics(ctx, set, cfg, next)
+		case pipeline.SignalLogs:
+			return factory.CreateLogsToMetrics(ctx, set, cfg, next)
+		case xpipeline.SignalProfiles:
+			return xfactory.CreateProfilesToMetrics(ctx, set, cfg, next)
+		}
+	case pipeline.SignalLogs:
+		sinks := make(map[pipeline.ID]consumer.Logs, len(t.logsSinks))
+		for id, sink := range t.logsSinks {
+			sinks[id] = sink
+		}
+		next := connector.NewLogsRouter(sinks)
+		switch expr {
+		case pipeline.SignalTraces:
+			return factory.CreateTracesToLogs(ctx, set, cfg, next)
+		case pipeline.SignalMetrics:
+			return factory.CreateMetricsToLogs(ctx, set, cfg, next)
+		case pipeline.SignalLogs:
+			return factory.CreateLogsToLogs(ctx, set, cfg, next)
+		case xpipeline.SignalProfiles:
+			return xfactory.CreateProfilesToLogs(ctx, set, cfg, next)
+		}
+	case xpipeline.SignalProfiles:
+		sinks := make(map[pipeline.ID]xconsumer.Profiles, len(t.profilesSinks))
+		for id, sink := range t.profilesSinks {
+			sinks[id] = sink
+		}
+		next := xconnector.NewProfilesRouter(sinks)
+		switch expr {
+		case pipeline.SignalTraces:
+			return xfactory.CreateTracesToProfiles(ctx, set, cfg, next)
+		case pipeline.SignalMetrics:
+			return xfactory.CreateMetricsToProfiles(ctx, set, cfg, next)
+		case pipeline.SignalLogs:
+			return xfactory.CreateLogsToProfiles(ctx, set, cfg, next)
+		case xpipeline.SignalProfiles:
+			return xfactory.CreateProfilesToProfiles(ctx, set, cfg, next)
+		}
+	}
+	return nil, pipeline.ErrSignalNotSupported
+}
+
+// Start starts the connectors.
+func (t *Topology) Start(ctx context.Context, host component.Host) error {
+	for _, comp := range t.components {
+		if err := comp.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown shuts the connectors down.
+func (t *Topology) Shutdown(ctx context.Context) error {
+	var errs error
+	for _, comp := range t.components {
+		errs = errors.Join(errs, comp.Shutdown(ctx))
+	}
+	return errs
+}
+
+func (t *Topology) checkUpstream(id pipeline.ID, signal pipeline.Signal) error {
+	if !t.upstream[id] || id.Signal() != signal {
+		return fmt.Errorf("%q is not an upstream %s pipeline", id, signal)
+	}
+	return nil
+}
+
+// ConsumeTraces exports the traces from the given upstream pipeline to the connector.
+func (t *Topology) ConsumeTraces(ctx context.Context, upstream pipeline.ID, td ptrace.Traces) error {
+	if err := t.checkUpstream(upstream, pipeline.SignalTraces); err != nil {
+		return err
+	}
+	return t.traces.ConsumeTraces(ctx, td)
+}
+
+// ConsumeMetrics exports the metrics from the given upstream pipeline to the connector.
+func (t *Topology) ConsumeMetrics(ctx context.Context, upstream pipeline.ID, md pmetric.Metrics) error {
+	if err := t.checkUpstream(upstream, pipeline.SignalMetrics); err != nil {
+		return err
+	}
+	return t.metrics.ConsumeMetrics(ctx, md)
+}
+
+// ConsumeLogs exports the logs from the given upstream pipeline to the connector.
+func (t *Topology) ConsumeLogs(ctx context.Context, upstream pipeline.ID, ld plog.Logs) error {
+	if err := t.checkUpstream(upstream, pipeline.SignalLogs); err != nil {
+		return err
+	}
+	return t.logs.ConsumeLogs(ctx, ld)
+}
+
+// ConsumeProfiles exports the profiles from the given upstream pipeline to the connector.
+func (t *Topology) ConsumeProfiles(ctx context.Context, upstream pipeline.ID, pd pprofile.Profiles) error {
+	if err := t.checkUpstream(upstream, xpipeline.SignalProfiles); err != nil {
+		return err
+	}
+	return t.profiles.ConsumeProfiles(ctx, pd)
+}
+
+// TracesSink returns the sink of the given downstream traces pipeline, or nil if there is none.
+func (t *Topology) TracesSink(downstream pipeline.ID) *consumertest.TracesSink {
+	return t.tracesSinks[downstream]
+}
+
+// MetricsSink returns the sink of the given downstream metrics pipeline, or nil if there is none.
+func (t *Topology) MetricsSink(downstream pipeline.ID) *consumertest.MetricsSink {
+	return t.metricsSinks[downstream]
+}
+
+// LogsSink returns the sink of the given downstream logs pipeline, or nil if there is none.
+func (t *Topology) LogsSink(downstream pipeline.ID) *consumertest.LogsSink {
+	return t.logsSinks[downstream]
+}
+
+// ProfilesSink returns the sink of the given downstream profiles pipeline, or nil if there is none.
+func (t *Topology) ProfilesSink(downstream pipeline.ID) *consumertest.ProfilesSink {
+	return t.profilesSinks[downstream]
+}
+
+// Reset clears the data recorded by the sinks of the downstream pipelines.
+func (t *Topology) Reset() {
+	for _, sink := range t.tracesSinks {
+		sink.Reset()
+	}
+	for _, sink := range t.metricsSinks {
+		sink.Reset()
+	}
+	for _, sink := range t.logsSinks {
+		sink.Reset()
+	}
+	for _, sink := range t.profilesSinks {
+		sink.Reset()
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package connectortest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+)
+
+type spanConnector struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.ConsumeTracesFunc
+}
+
+func (spanConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+// newSpanFactory returns a factory of connectors forwarding the traces to all the traces pipelines,
+// and converting the spans to log records.
+func newSpanFactory() connector.Factory {
+	return connector.NewFactory(
+		component.MustNewType("span"),
+		func() component.Config { return &nopConfig{} },
+		connector.WithTracesToTraces(func(_ context.Context, _ connector.Settings, _ component.Config, next consumer.Traces) (connector.Traces, error) {
+			return &spanConnector{ConsumeTracesFunc: next.ConsumeTraces}, nil
+		}, component.StabilityLevelDevelopment),
+		connector.WithTracesToLogs(func(_ context.Context, _ connector.Settings, _ component.Config, next consumer.Logs) (connector.Traces, error) {
+			return &spanConnector{ConsumeTracesFunc: func(ctx context.Context, td ptrace.Traces) error {
+				ld := plog.NewLogs()
+				records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+				for range td.SpanCount() {
+					records.AppendEmpty()
+				}
+				return next.ConsumeLogs(ctx, ld)
+			}}, nil
+		}, component.StabilityLevelDevelopment),
+	)
+}
+
+func TestTopology(t *testing.T) {
+	tracesA := pipeline.NewIDWithName(pipeline.SignalTraces, "a")
+	tracesB := pipeline.NewIDWithName(pipeline.SignalTraces, "b")
+	tracesOut1 := pipeline.NewIDWithName(pipeline.SignalTraces, "out1")
+	tracesOut2 := pipeline.NewIDWithName(pipeline.SignalTraces, "out2")
+	logsOut := pipeline.NewID(pipeline.SignalLogs)
+
+	f := newSpanFactory()
+	topo, err := NewTopology(context.Background(), NewNopSettings(f.Type()), f, f.CreateDefaultConfig(),
+		[]pipeline.ID{tracesA, tracesB}, []pipeline.ID{tracesOut1, tracesOut2, logsOut})
+	require.NoError(t, err)
+	require.NoError(t, topo.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, topo.ConsumeTraces(context.Background(), tracesA, testdata.GenerateTraces(2)))
+	require.NoError(t, topo.ConsumeTraces(context.Background(), tracesB, testdata.GenerateTraces(1)))
+	assert.Equal(t, 3, topo.TracesSink(tracesOut1).SpanCount())
+	assert.Equal(t, 3, topo.TracesSink(tracesOut2).SpanCount())
+	assert.Equal(t, 3, topo.LogsSink(logsOut).LogRecordCount())
+	assert.Nil(t, topo.MetricsSink(pipeline.NewID(pipeline.SignalMetrics)))
+
+	require.ErrorContains(t, topo.ConsumeTraces(context.Background(), tracesOut1, testdata.GenerateTraces(1)),
+		`"traces/out1" is not an upstream traces pipeline`)
+	require.Error(t, topo.ConsumeLogs(context.Background(), logsOut, testdata.GenerateLogs(1)))
+
+	topo.Reset()
+	assert.Zero(t, topo.TracesSink(tracesOut1).SpanCount())
+	assert.Zero(t, topo.LogsSink(logsOut).LogRecordCount())
+	require.NoError(t, topo.Shutdown(context.Background()))
+}
+
+func TestTopologyUnsupportedSignals(t *testing.T) {
+	f := newSpanFactory()
+	set := NewNopSettings(f.Type())
+
+	_, err := NewTopology(context.Background(), set, f, f.CreateDefaultConfig(),
+		[]pipeline.ID{pipeline.NewID(pipeline.SignalMetrics)}, []pipeline.ID{pipeline.NewID(pipeline.SignalTraces)})
+	require.ErrorContains(t, err, "used as exporter in metrics pipeline but not used in any supported receiver pipeline")
+
+	_, err = NewTopology(context.Background(), set, f, f.CreateDefaultConfig(),
+		[]pipeline.ID{pipeline.NewID(pipeline.SignalTraces)}, []pipeline.ID{pipeline.NewID(pipeline.SignalLogs), pipeline.NewID(xpipeline.SignalProfiles)})
+	require.ErrorContains(t, err, "used as receiver in profiles pipeline but not used in any supported exporter pipeline")
+}
+
+func TestTopologyAllSignals(t *testing.T) {
+	f := NewNopFactory()
+	var ids []pipeline.ID
+	for _, signal := range signals {
+		ids = append(ids, pipeline.NewID(signal))
+	}
+	topo, err := NewTopology(context.Background(), NewNopSettings(f.Type()), f, f.CreateDefaultConfig(), ids, ids)
+	require.NoError(t, err)
+	require.NoError(t, topo.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, topo.ConsumeTraces(context.Background(), ids[0], testdata.GenerateTraces(1)))
+	require.NoError(t, topo.ConsumeMetrics(context.Background(), ids[1], testdata.GenerateMetrics(1)))
+	require.NoError(t, topo.ConsumeLogs(context.Background(), ids[2], testdata.GenerateLogs(1)))
+	require.NoError(t, topo.ConsumeProfiles(context.Background(), ids[3], testdata.GenerateProfiles(1)))
+	require.NoError(t, topo.Shutdown(context.Background()))
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package forwardconnector // import "go.opentelemetry.io/collector/connector/forwardconnector"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Config defines the configuration of the forward connector.
+// Without any condition, all the data is forwarded.
+type Config struct {
+	// Include forwards only the data matching all the conditions.
+	Include Conditions `mapstructure:"include"`
+	// Exclude drops the data matching all the conditions.
+	Exclude Conditions `mapstructure:"exclude"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Conditions are matched against the resources, the log records and the spans.
+// The conditions which do not apply to a signal are ignored for this signal.
+type Conditions struct {
+	// ResourceAttributes matches the resources having all these attributes, compared as strings.
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+	// MinSeverity matches the log records with at least this severity, e.g. "warn" or "error2".
+	MinSeverity string `mapstructure:"min_severity"`
+	// SpanStatus matches the spans having one of these status codes: "unset", "ok" or "error".
+	SpanStatus []string `mapstructure:"span_status"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks if the connector configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs error
+	if _, err := cfg.Include.matcher(); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("include: %w", err))
+	}
+	if _, err := cfg.Exclude.matcher(); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("exclude: %w", err))
+	}
+	return errs
+}
+
+func (c *Conditions) matcher() (*matcher, error) {
+	m := &matcher{attributes: c.ResourceAttributes}
+	if c.MinSeverity != "" {
+		sev, err := parseSeverity(c.MinSeverity)
+		if err != nil {
+			return nil, err
+		}
+		m.minSeverity = sev
+	}
+	for _, s := range c.SpanStatus {
+		code, err := parseStatusCode(s)
+		if err != nil {
+			return nil, err
+		}
+		m.spanStatus = append(m.spanStatus, code)
+	}
+	return m, nil
+}
+
+func parseSeverity(s string) (plog.SeverityNumber, error) {
+	for sev := plog.SeverityNumberTrace; sev <= plog.SeverityNumberFatal4; sev++ {
+		if strings.EqualFold(sev.String(), s) {
+			return sev, nil
+		}
+	}
+	return plog.SeverityNumberUnspecified, fmt.Errorf("invalid min_severity %q", s)
+}
+
+func parseStatusCode(s string) (ptrace.StatusCode, error) {
+	for _, code := range []ptrace.StatusCode{ptrace.StatusCodeUnset, ptrace.StatusCodeOk, ptrace.StatusCodeError} {
+		if strings.EqualFold(code.String(), s) {
+			return code, nil
+		}
+	}
+	return ptrace.StatusCodeUnset, fmt.Errorf("invalid span_status %q", s)
+}
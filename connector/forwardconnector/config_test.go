@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package forwardconnector
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			Include: Conditions{
+				ResourceAttributes: map[string]string{"deployment.environment": "production"},
+			},
+			Exclude: Conditions{
+				MinSeverity: "debug4",
+				SpanStatus:  []string{"ok"},
+			},
+		}, cfg)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := &Config{Include: Conditions{MinSeverity: "Warn2", SpanStatus: []string{"Error", "UNSET"}}}
+	require.NoError(t, cfg.Validate())
+
+	cfg = &Config{
+		Include: Conditions{MinSeverity: "loud"},
+		Exclude: Conditions{SpanStatus: []string{"failed"}},
+	}
+	assert.EqualError(t, cfg.Validate(), "include: invalid min_severity \"loud\"\nexclude: invalid span_status \"failed\"")
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package forwardconnector // import "go.opentelemetry.io/collector/connector/forwardconnector"
+
+import (
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// matcher is the parsed form of Conditions.
+type matcher struct {
+	attributes  map[string]string
+	minSeverity plog.SeverityNumber
+	spanStatus  []ptrace.StatusCode
+}
+
+func (m *matcher) hasResourceConditions() bool {
+	return len(m.attributes) > 0
+}
+
+func (m *matcher) hasLogConditions() bool {
+	return m.hasResourceConditions() || m.minSeverity != plog.SeverityNumberUnspecified
+}
+
+func (m *matcher) hasSpanConditions() bool {
+	return m.hasResourceConditions() || len(m.spanStatus) > 0
+}
+
+func (m *matcher) matchResource(res pcommon.Resource) bool {
+	for k, v := range m.attributes {
+		attr, ok := res.Attributes().Get(k)
+		if !ok || attr.AsString() != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *matcher) matchLogRecord(lr plog.LogRecord) bool {
+	return lr.SeverityNumber() >= m.minSeverity
+}
+
+func (m *matcher) matchSpan(span ptrace.Span) bool {
+	return len(m.spanStatus) == 0 || slices.Contains(m.spanStatus, span.Status().Code())
+}
+
+// filter keeps the data matching the include conditions and not matching the exclude conditions.
+// A signal without any include condition keeps everything, a signal without any exclude
+// condition does not exclude anything.
+type filter struct {
+	include *matcher
+	exclude *matcher
+}
+
+func newFilter(cfg *Config) (*filter, error) {
+	include, err := cfg.Include.matcher()
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := cfg.Exclude.matcher()
+	if err != nil {
+		return nil, err
+	}
+	if !include.hasLogConditions() && !include.hasSpanConditions() &&
+		!exclude.hasLogConditions() && !exclude.hasSpanConditions() {
+		return nil, nil
+	}
+	return &filter{include: include, exclude: exclude}, nil
+}
+
+func (f *filter) filterTraces(td ptrace.Traces) {
+	excludes := f.exclude.hasSpanConditions()
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		included := f.include.matchResource(rs.Resource())
+		excluded := excludes && f.exclude.matchResource(rs.Resource())
+		if !included {
+			return true
+		}
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				return !f.include.matchSpan(span) || (excluded && f.exclude.matchSpan(span))
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+}
+
+func (f *filter) filterMetrics(md pmetric.Metrics) {
+	excludes := f.exclude.hasResourceConditions()
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		return !f.include.matchResource(rm.Resource()) || (excludes && f.exclude.matchResource(rm.Resource()))
+	})
+}
+
+func (f *filter) filterProfiles(pd pprofile.Profiles) {
+	excludes := f.exclude.hasResourceConditions()
+	pd.ResourceProfiles().RemoveIf(func(rp pprofile.ResourceProfiles) bool {
+		return !f.include.matchResource(rp.Resource()) || (excludes && f.exclude.matchResource(rp.Resource()))
+	})
+}
+
+func (f *filter) filterLogs(ld plog.Logs) {
+	excludes := f.exclude.hasLogConditions()
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		included := f.include.matchResource(rl.Resource())
+		excluded := excludes && f.exclude.matchResource(rl.Resource())
+		if !included {
+			return true
+		}
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				return !f.include.matchLogRecord(lr) || (excluded && f.exclude.matchLogRecord(lr))
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+}
@@ -9,22 +9,27 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/connector/forwardconnector/internal/metadata"
+	"go.opentelemetry.io/collector/connector/xconnector"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
 // NewFactory returns a connector.Factory.
 func NewFactory() connector.Factory {
-	return connector.NewFactory(
+	return xconnector.NewFactory(
 		metadata.Type,
 		createDefaultConfig,
-		connector.WithTracesToTraces(createTracesToTraces, metadata.TracesToTracesStability),
-		connector.WithMetricsToMetrics(createMetricsToMetrics, metadata.MetricsToMetricsStability),
-		connector.WithLogsToLogs(createLogsToLogs, metadata.LogsToLogsStability),
+		xconnector.WithTracesToTraces(createTracesToTraces, metadata.TracesToTracesStability),
+		xconnector.WithMetricsToMetrics(createMetricsToMetrics, metadata.MetricsToMetricsStability),
+		xconnector.WithLogsToLogs(createLogsToLogs, metadata.LogsToLogsStability),
+		xconnector.WithProfilesToProfiles(createProfilesToProfiles, metadata.ProfilesToProfilesStability),
 	)
 }
 
-type Config struct{}
-
 // createDefaultConfig creates the default configuration.
 func createDefaultConfig() component.Config {
 	return &Config{}
@@ -34,30 +39,68 @@ func createDefaultConfig() component.Config {
 func createTracesToTraces(
 	_ context.Context,
 	_ connector.Settings,
-	_ component.Config,
+	cfg component.Config,
 	nextConsumer consumer.Traces,
 ) (connector.Traces, error) {
-	return &forward{Traces: nextConsumer}, nil
+	f, err := newFilter(cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return &forward{Traces: nextConsumer}, nil
+	}
+	return &forward{Traces: &filterTraces{filter: f, next: nextConsumer}, mutatesData: true}, nil
 }
 
 // createMetricsToMetrics creates a metrics receiver based on provided config.
 func createMetricsToMetrics(
 	_ context.Context,
 	_ connector.Settings,
-	_ component.Config,
+	cfg component.Config,
 	nextConsumer consumer.Metrics,
 ) (connector.Metrics, error) {
-	return &forward{Metrics: nextConsumer}, nil
+	f, err := newFilter(cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return &forward{Metrics: nextConsumer}, nil
+	}
+	return &forward{Metrics: &filterMetrics{filter: f, next: nextConsumer}, mutatesData: true}, nil
 }
 
 // createLogsToLogs creates a log receiver based on provided config.
 func createLogsToLogs(
 	_ context.Context,
 	_ connector.Settings,
-	_ component.Config,
+	cfg component.Config,
 	nextConsumer consumer.Logs,
 ) (connector.Logs, error) {
-	return &forward{Logs: nextConsumer}, nil
+	f, err := newFilter(cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return &forward{Logs: nextConsumer}, nil
+	}
+	return &forward{Logs: &filterLogs{filter: f, next: nextConsumer}, mutatesData: true}, nil
+}
+
+// createProfilesToProfiles creates a profiles receiver based on provided config.
+func createProfilesToProfiles(
+	_ context.Context,
+	_ connector.Settings,
+	cfg component.Config,
+	nextConsumer xconsumer.Profiles,
+) (xconnector.Profiles, error) {
+	f, err := newFilter(cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	if f == nil {
+		return &forward{Profiles: nextConsumer}, nil
+	}
+	return &forward{Profiles: &filterProfiles{filter: f, next: nextConsumer}, mutatesData: true}, nil
 }
 
 // forward is used to pass signals directly from one pipeline to another.
@@ -67,10 +110,86 @@ type forward struct {
 	consumer.Traces
 	consumer.Metrics
 	consumer.Logs
+	xconsumer.Profiles
 	component.StartFunc
 	component.ShutdownFunc
+
+	// mutatesData is set when the data is filtered in place.
+	mutatesData bool
 }
 
 func (c *forward) Capabilities() consumer.Capabilities {
-	return consumer.Capabilities{MutatesData: false}
+	return consumer.Capabilities{MutatesData: c.mutatesData}
+}
+
+// filterTraces forwards only the spans kept by the filter.
+type filterTraces struct {
+	filter *filter
+	next   consumer.Traces
+}
+
+func (c *filterTraces) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (c *filterTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	c.filter.filterTraces(td)
+	if td.ResourceSpans().Len() == 0 {
+		return nil
+	}
+	return c.next.ConsumeTraces(ctx, td)
+}
+
+// filterMetrics forwards only the resource metrics kept by the filter.
+type filterMetrics struct {
+	filter *filter
+	next   consumer.Metrics
+}
+
+func (c *filterMetrics) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (c *filterMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	c.filter.filterMetrics(md)
+	if md.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	return c.next.ConsumeMetrics(ctx, md)
+}
+
+// filterLogs forwards only the log records kept by the filter.
+type filterLogs struct {
+	filter *filter
+	next   consumer.Logs
+}
+
+func (c *filterLogs) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (c *filterLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	c.filter.filterLogs(ld)
+	if ld.ResourceLogs().Len() == 0 {
+		return nil
+	}
+	return c.next.ConsumeLogs(ctx, ld)
+}
+
+// filterProfiles forwards only the resource profiles kept by the filter.
+type filterProfiles struct {
+	filter *filter
+	next   xconsumer.Profiles
+}
+
+func (c *filterProfiles) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (c *filterProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	c.filter.filterProfiles(pd)
+	if pd.ResourceProfiles().Len() == 0 {
+		return nil
+	}
+	return c.next.ConsumeProfiles(ctx, pd)
 }
@@ -11,9 +11,11 @@ import (
 
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/connector/xconnector"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -30,6 +32,7 @@ func TestForward(t *testing.T) {
 	tracesToTraces, err := f.CreateTracesToTraces(ctx, set, cfg, tracesSink)
 	require.NoError(t, err)
 	assert.NotNil(t, tracesToTraces)
+	assert.False(t, tracesToTraces.Capabilities().MutatesData)
 
 	metricsSink := new(consumertest.MetricsSink)
 	metricsToMetrics, err := f.CreateMetricsToMetrics(ctx, set, cfg, metricsSink)
@@ -62,3 +65,125 @@ func TestForward(t *testing.T) {
 	assert.Len(t, metricsSink.AllMetrics(), 2)
 	assert.Len(t, logsSink.AllLogs(), 3)
 }
+
+func TestForwardProfiles(t *testing.T) {
+	f := NewFactory().(xconnector.Factory)
+	ctx := context.Background()
+	sink := new(consumertest.ProfilesSink)
+	profilesToProfiles, err := f.CreateProfilesToProfiles(ctx, connectortest.NewNopSettings(f.Type()), f.CreateDefaultConfig(), sink)
+	require.NoError(t, err)
+	assert.False(t, profilesToProfiles.Capabilities().MutatesData)
+
+	require.NoError(t, profilesToProfiles.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, profilesToProfiles.ConsumeProfiles(ctx, pprofile.NewProfiles()))
+	require.NoError(t, profilesToProfiles.Shutdown(ctx))
+	assert.Len(t, sink.AllProfiles(), 1)
+}
+
+func TestForwardFilterProfiles(t *testing.T) {
+	f := NewFactory().(xconnector.Factory)
+	cfg := &Config{
+		Include: Conditions{SpanStatus: []string{"ok"}},
+		Exclude: Conditions{ResourceAttributes: map[string]string{"env": "dev"}},
+	}
+	sink := new(consumertest.ProfilesSink)
+	conn, err := f.CreateProfilesToProfiles(context.Background(), connectortest.NewNopSettings(f.Type()), cfg, sink)
+	require.NoError(t, err)
+	assert.True(t, conn.Capabilities().MutatesData)
+
+	pd := pprofile.NewProfiles()
+	for _, env := range []string{"prod", "dev"} {
+		pd.ResourceProfiles().AppendEmpty().Resource().Attributes().PutStr("env", env)
+	}
+	require.NoError(t, conn.ConsumeProfiles(context.Background(), pd))
+	require.Len(t, sink.AllProfiles(), 1)
+	got := sink.AllProfiles()[0]
+	require.Equal(t, 1, got.ResourceProfiles().Len())
+	env, _ := got.ResourceProfiles().At(0).Resource().Attributes().Get("env")
+	assert.Equal(t, "prod", env.Str())
+}
+
+func TestForwardFilterTraces(t *testing.T) {
+	f := NewFactory()
+	cfg := &Config{
+		Include: Conditions{ResourceAttributes: map[string]string{"env": "prod"}},
+		Exclude: Conditions{SpanStatus: []string{"ok"}, MinSeverity: "error"},
+	}
+	sink := new(consumertest.TracesSink)
+	conn, err := f.CreateTracesToTraces(context.Background(), connectortest.NewNopSettings(f.Type()), cfg, sink)
+	require.NoError(t, err)
+	assert.True(t, conn.Capabilities().MutatesData)
+
+	td := ptrace.NewTraces()
+	for _, env := range []string{"prod", "dev"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("env", env)
+		spans := rs.ScopeSpans().AppendEmpty().Spans()
+		for _, code := range []ptrace.StatusCode{ptrace.StatusCodeUnset, ptrace.StatusCodeOk, ptrace.StatusCodeError} {
+			spans.AppendEmpty().Status().SetCode(code)
+		}
+	}
+	require.NoError(t, conn.ConsumeTraces(context.Background(), td))
+	require.Len(t, sink.AllTraces(), 1)
+	got := sink.AllTraces()[0]
+	require.Equal(t, 1, got.ResourceSpans().Len())
+	env, _ := got.ResourceSpans().At(0).Resource().Attributes().Get("env")
+	assert.Equal(t, "prod", env.Str())
+	spans := got.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 2, spans.Len())
+	assert.Equal(t, ptrace.StatusCodeUnset, spans.At(0).Status().Code())
+	assert.Equal(t, ptrace.StatusCodeError, spans.At(1).Status().Code())
+
+	// Nothing is forwarded when everything is filtered out.
+	td = ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	require.NoError(t, conn.ConsumeTraces(context.Background(), td))
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestForwardFilterMetrics(t *testing.T) {
+	f := NewFactory()
+	cfg := &Config{
+		Include: Conditions{MinSeverity: "error"},
+		Exclude: Conditions{ResourceAttributes: map[string]string{"env": "dev"}},
+	}
+	sink := new(consumertest.MetricsSink)
+	conn, err := f.CreateMetricsToMetrics(context.Background(), connectortest.NewNopSettings(f.Type()), cfg, sink)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	for _, env := range []string{"prod", "dev", "test"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("env", env)
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName(env)
+	}
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), md))
+	require.Len(t, sink.AllMetrics(), 1)
+	got := sink.AllMetrics()[0]
+	require.Equal(t, 2, got.ResourceMetrics().Len())
+	assert.Equal(t, "prod", got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+	assert.Equal(t, "test", got.ResourceMetrics().At(1).ScopeMetrics().At(0).Metrics().At(0).Name())
+}
+
+func TestForwardFilterLogs(t *testing.T) {
+	f := NewFactory()
+	cfg := &Config{
+		Include: Conditions{MinSeverity: "warn"},
+		Exclude: Conditions{SpanStatus: []string{"error"}},
+	}
+	sink := new(consumertest.LogsSink)
+	conn, err := f.CreateLogsToLogs(context.Background(), connectortest.NewNopSettings(f.Type()), cfg, sink)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, sev := range []plog.SeverityNumber{plog.SeverityNumberInfo, plog.SeverityNumberWarn, plog.SeverityNumberFatal, plog.SeverityNumberUnspecified} {
+		records.AppendEmpty().SetSeverityNumber(sev)
+	}
+	require.NoError(t, conn.ConsumeLogs(context.Background(), ld))
+	require.Len(t, sink.AllLogs(), 1)
+	got := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, got.Len())
+	assert.Equal(t, plog.SeverityNumberWarn, got.At(0).SeverityNumber())
+	assert.Equal(t, plog.SeverityNumberFatal, got.At(1).SeverityNumber())
+}
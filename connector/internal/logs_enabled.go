@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !otelcol_no_logs
+
+package internal // import "go.opentelemetry.io/collector/connector/internal"
+
+// LogsEnabled reports whether the logs of the components are built, see logs_disabled.go.
+const LogsEnabled = true
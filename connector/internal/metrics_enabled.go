@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !otelcol_no_metrics
+
+package internal // import "go.opentelemetry.io/collector/connector/internal"
+
+// MetricsEnabled reports whether the metrics of the components are built, see metrics_disabled.go.
+const MetricsEnabled = true
@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build otelcol_no_traces
+
+package internal // import "go.opentelemetry.io/collector/connector/internal"
+
+// TracesEnabled is false when the distribution is built with the otelcol_no_traces build tag: the factory
+// options of the traces are then no-ops, which lets the linker leave out the traces code of the components.
+const TracesEnabled = false
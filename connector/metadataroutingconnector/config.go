@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadataroutingconnector // import "go.opentelemetry.io/collector/connector/metadataroutingconnector"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// Config defines the configuration of the metadata routing connector.
+type Config struct {
+	// Table is the ordered list of routes. The data is sent to the pipelines of the first
+	// matching route.
+	Table []RouteConfig `mapstructure:"table"`
+
+	// DefaultPipelines receive the data matching none of the routes. The data is dropped
+	// if not set.
+	DefaultPipelines []pipeline.ID `mapstructure:"default_pipelines"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// RouteConfig defines a route matching either a client metadata key or a resource attribute.
+type RouteConfig struct {
+	// MetadataKey matches the data received with this client metadata key, having Value
+	// as one of its values. The metadata is only available when the receiver propagates it,
+	// e.g. with the include_metadata setting of the OTLP receiver.
+	MetadataKey string `mapstructure:"metadata_key"`
+
+	// ResourceAttribute matches the resources having this attribute with the value Value,
+	// compared as a string.
+	ResourceAttribute string `mapstructure:"resource_attribute"`
+
+	// Value is the value to match.
+	Value string `mapstructure:"value"`
+
+	// Pipelines receive the data matching the route.
+	Pipelines []pipeline.ID `mapstructure:"pipelines"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks if the connector configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs error
+	for i, route := range cfg.Table {
+		if err := route.validate(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("table[%d]: %w", i, err))
+		}
+	}
+	return errs
+}
+
+func (cfg *RouteConfig) validate() error {
+	if (cfg.MetadataKey == "") == (cfg.ResourceAttribute == "") {
+		return errors.New("exactly one of metadata_key or resource_attribute must be set")
+	}
+	if len(cfg.Pipelines) == 0 {
+		return errors.New("pipelines must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadataroutingconnector
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			DefaultPipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "default")},
+			Table: []RouteConfig{
+				{
+					MetadataKey: "X-Tenant",
+					Value:       "acme",
+					Pipelines: []pipeline.ID{
+						pipeline.NewIDWithName(pipeline.SignalTraces, "acme"),
+						pipeline.NewIDWithName(pipeline.SignalTraces, "audit"),
+					},
+				},
+				{
+					ResourceAttribute: "service.namespace",
+					Value:             "billing",
+					Pipelines:         []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "billing")},
+				},
+			},
+		}, cfg)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestValidateConfig(t *testing.T) {
+	pipelines := []pipeline.ID{pipeline.NewID(pipeline.SignalLogs)}
+	cfg := &Config{
+		Table: []RouteConfig{
+			{MetadataKey: "tenant", Value: "a", Pipelines: pipelines},
+			{Value: "b", Pipelines: pipelines},
+			{MetadataKey: "tenant", ResourceAttribute: "tenant", Value: "c", Pipelines: pipelines},
+			{ResourceAttribute: "tenant", Value: "d"},
+		},
+	}
+	assert.EqualError(t, cfg.Validate(), "table[1]: exactly one of metadata_key or resource_attribute must be set\n"+
+		"table[2]: exactly one of metadata_key or resource_attribute must be set\n"+
+		"table[3]: pipelines must not be empty")
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadataroutingconnector // import "go.opentelemetry.io/collector/connector/metadataroutingconnector"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// When all the resources of the data go to the same pipelines, the data is passed as is.
+// Otherwise, the resources are copied to a new payload per route.
+
+type tracesConnector struct {
+	component.StartFunc
+	component.ShutdownFunc
+	router *router[consumer.Traces]
+}
+
+func (c *tracesConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *tracesConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	rss := td.ResourceSpans()
+	indexes, same := routeAll(ctx, c.router, rss.Len(), func(i int) pcommon.Resource { return rss.At(i).Resource() })
+	if same {
+		if len(indexes) == 0 || indexes[0] == noRoute {
+			return nil
+		}
+		return c.router.consumers[indexes[0]].ConsumeTraces(ctx, td)
+	}
+
+	groups := make(map[int]ptrace.Traces)
+	for i, idx := range indexes {
+		if idx == noRoute {
+			continue
+		}
+		group, ok := groups[idx]
+		if !ok {
+			group = ptrace.NewTraces()
+			groups[idx] = group
+		}
+		rss.At(i).CopyTo(group.ResourceSpans().AppendEmpty())
+	}
+	var errs error
+	for idx, group := range groups {
+		errs = errors.Join(errs, c.router.consumers[idx].ConsumeTraces(ctx, group))
+	}
+	return errs
+}
+
+type metricsConnector struct {
+	component.StartFunc
+	component.ShutdownFunc
+	router *router[consumer.Metrics]
+}
+
+func (c *metricsConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *metricsConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	rms := md.ResourceMetrics()
+	indexes, same := routeAll(ctx, c.router, rms.Len(), func(i int) pcommon.Resource { return rms.At(i).Resource() })
+	if same {
+		if len(indexes) == 0 || indexes[0] == noRoute {
+			return nil
+		}
+		return c.router.consumers[indexes[0]].ConsumeMetrics(ctx, md)
+	}
+
+	groups := make(map[int]pmetric.Metrics)
+	for i, idx := range indexes {
+		if idx == noRoute {
+			continue
+		}
+		group, ok := groups[idx]
+		if !ok {
+			group = pmetric.NewMetrics()
+			groups[idx] = group
+		}
+		rms.At(i).CopyTo(group.ResourceMetrics().AppendEmpty())
+	}
+	var errs error
+	for idx, group := range groups {
+		errs = errors.Join(errs, c.router.consumers[idx].ConsumeMetrics(ctx, group))
+	}
+	return errs
+}
+
+type logsConnector struct {
+	component.StartFunc
+	component.ShutdownFunc
+	router *router[consumer.Logs]
+}
+
+func (c *logsConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (c *logsConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	rls := ld.ResourceLogs()
+	indexes, same := routeAll(ctx, c.router, rls.Len(), func(i int) pcommon.Resource { return rls.At(i).Resource() })
+	if same {
+		if len(indexes) == 0 || indexes[0] == noRoute {
+			return nil
+		}
+		return c.router.consumers[indexes[0]].ConsumeLogs(ctx, ld)
+	}
+
+	groups := make(map[int]plog.Logs)
+	for i, idx := range indexes {
+		if idx == noRoute {
+			continue
+		}
+		group, ok := groups[idx]
+		if !ok {
+			group = plog.NewLogs()
+			groups[idx] = group
+		}
+		rls.At(i).CopyTo(group.ResourceLogs().AppendEmpty())
+	}
+	var errs error
+	for idx, group := range groups {
+		errs = errors.Join(errs, c.router.consumers[idx].ConsumeLogs(ctx, group))
+	}
+	return errs
+}
+
+type profilesConnector struct {
+	component.StartFunc
+	component.ShutdownFunc
+	router *router[xconsumer.Profiles]
+}
+
+func (c *profilesConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeProfiles routes the profiles. The resources reference the dictionary of the
+// profiles, which is copied along with them when they are split.
+func (c *profilesConnector) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	rps := pd.ResourceProfiles()
+	indexes, same := routeAll(ctx, c.router, rps.Len(), func(i int) pcommon.Resource { return rps.At(i).Resource() })
+	if same {
+		if len(indexes) == 0 || indexes[0] == noRoute {
+			return nil
+		}
+		return c.router.consumers[indexes[0]].ConsumeProfiles(ctx, pd)
+	}
+
+	groups := make(map[int]pprofile.Profiles)
+	for i, idx := range indexes {
+		if idx == noRoute {
+			continue
+		}
+		group, ok := groups[idx]
+		if !ok {
+			group = pprofile.NewProfiles()
+			pd.Dictionary().CopyTo(group.Dictionary())
+			groups[idx] = group
+		}
+		rps.At(i).CopyTo(group.ResourceProfiles().AppendEmpty())
+	}
+	var errs error
+	for idx, group := range groups {
+		errs = errors.Join(errs, c.router.consumers[idx].ConsumeProfiles(ctx, group))
+	}
+	return errs
+}
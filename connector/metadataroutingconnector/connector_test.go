@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadataroutingconnector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/connector/xconnector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+)
+
+func tenantContext(tenant string) context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {tenant}}),
+	})
+}
+
+func testConfig(signal pipeline.Signal) *Config {
+	return &Config{
+		DefaultPipelines: []pipeline.ID{pipeline.NewIDWithName(signal, "default")},
+		Table: []RouteConfig{
+			{MetadataKey: "X-Tenant", Value: "acme", Pipelines: []pipeline.ID{pipeline.NewIDWithName(signal, "acme")}},
+			{ResourceAttribute: "tenant", Value: "globex", Pipelines: []pipeline.ID{pipeline.NewIDWithName(signal, "globex")}},
+		},
+	}
+}
+
+func TestTracesRouting(t *testing.T) {
+	sinks := map[string]*consumertest.TracesSink{"acme": {}, "globex": {}, "default": {}}
+	consumers := make(map[pipeline.ID]consumer.Traces)
+	for name, sink := range sinks {
+		consumers[pipeline.NewIDWithName(pipeline.SignalTraces, name)] = sink
+	}
+
+	f := NewFactory()
+	conn, err := f.CreateTracesToTraces(context.Background(), connectortest.NewNopSettings(f.Type()),
+		testConfig(pipeline.SignalTraces), connector.NewTracesRouter(consumers))
+	require.NoError(t, err)
+	assert.False(t, conn.Capabilities().MutatesData)
+
+	td := ptrace.NewTraces()
+	for _, tenant := range []string{"globex", "initech", "globex"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("tenant", tenant)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(tenant)
+	}
+
+	// The metadata route comes first, so everything goes to acme as is.
+	require.NoError(t, conn.ConsumeTraces(tenantContext("acme"), td))
+	require.Len(t, sinks["acme"].AllTraces(), 1)
+	assert.Equal(t, td, sinks["acme"].AllTraces()[0])
+
+	// Otherwise, the resources are split per route.
+	require.NoError(t, conn.ConsumeTraces(tenantContext("initech"), td))
+	require.Len(t, sinks["globex"].AllTraces(), 1)
+	assert.Equal(t, 2, sinks["globex"].AllTraces()[0].ResourceSpans().Len())
+	require.Len(t, sinks["default"].AllTraces(), 1)
+	assert.Equal(t, 1, sinks["default"].AllTraces()[0].ResourceSpans().Len())
+	assert.Equal(t, 3, td.ResourceSpans().Len())
+}
+
+func TestMetricsRouting(t *testing.T) {
+	acme, globex := new(consumertest.MetricsSink), new(consumertest.MetricsSink)
+	f := NewFactory()
+	cfg := testConfig(pipeline.SignalMetrics)
+	cfg.DefaultPipelines = nil
+	conn, err := f.CreateMetricsToMetrics(context.Background(), connectortest.NewNopSettings(f.Type()), cfg,
+		connector.NewMetricsRouter(map[pipeline.ID]consumer.Metrics{
+			pipeline.NewIDWithName(pipeline.SignalMetrics, "acme"):   acme,
+			pipeline.NewIDWithName(pipeline.SignalMetrics, "globex"): globex,
+		}))
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	for _, tenant := range []string{"globex", "initech"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("tenant", tenant)
+		rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName(tenant)
+	}
+
+	// Without default pipelines, the data matching no route is dropped.
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), md))
+	assert.Empty(t, acme.AllMetrics())
+	require.Len(t, globex.AllMetrics(), 1)
+	assert.Equal(t, 1, globex.AllMetrics()[0].ResourceMetrics().Len())
+
+	require.NoError(t, conn.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	assert.Len(t, globex.AllMetrics(), 1)
+}
+
+func TestLogsRouting(t *testing.T) {
+	errFailed := errors.New("failed")
+	def := new(consumertest.LogsSink)
+	f := NewFactory()
+	conn, err := f.CreateLogsToLogs(context.Background(), connectortest.NewNopSettings(f.Type()), testConfig(pipeline.SignalLogs),
+		connector.NewLogsRouter(map[pipeline.ID]consumer.Logs{
+			pipeline.NewIDWithName(pipeline.SignalLogs, "acme"):    def,
+			pipeline.NewIDWithName(pipeline.SignalLogs, "globex"):  consumertest.NewErr(errFailed),
+			pipeline.NewIDWithName(pipeline.SignalLogs, "default"): def,
+		}))
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	for _, tenant := range []string{"globex", "initech"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("tenant", tenant)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(tenant)
+	}
+
+	require.ErrorIs(t, conn.ConsumeLogs(context.Background(), ld), errFailed)
+	require.Len(t, def.AllLogs(), 1)
+	assert.Equal(t, "initech", def.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
+
+func TestProfilesRouting(t *testing.T) {
+	acme, def := new(consumertest.ProfilesSink), new(consumertest.ProfilesSink)
+	f := NewFactory().(xconnector.Factory)
+	conn, err := f.CreateProfilesToProfiles(context.Background(), connectortest.NewNopSettings(f.Type()),
+		testConfig(xpipeline.SignalProfiles), xconnector.NewProfilesRouter(map[pipeline.ID]xconsumer.Profiles{
+			pipeline.NewIDWithName(xpipeline.SignalProfiles, "acme"):    acme,
+			pipeline.NewIDWithName(xpipeline.SignalProfiles, "globex"):  acme,
+			pipeline.NewIDWithName(xpipeline.SignalProfiles, "default"): def,
+		}))
+	require.NoError(t, err)
+
+	pd := pprofile.NewProfiles()
+	pd.Dictionary().StringTable().Append("", "cpu")
+	for _, tenant := range []string{"globex", "initech"} {
+		pd.ResourceProfiles().AppendEmpty().Resource().Attributes().PutStr("tenant", tenant)
+	}
+
+	require.NoError(t, conn.ConsumeProfiles(context.Background(), pd))
+	require.Len(t, acme.AllProfiles(), 1)
+	require.Len(t, def.AllProfiles(), 1)
+	for _, got := range []pprofile.Profiles{acme.AllProfiles()[0], def.AllProfiles()[0]} {
+		assert.Equal(t, 1, got.ResourceProfiles().Len())
+		assert.Equal(t, []string{"", "cpu"}, got.Dictionary().StringTable().AsRaw())
+	}
+}
+
+func TestUnknownPipeline(t *testing.T) {
+	f := NewFactory()
+	_, err := f.CreateTracesToTraces(context.Background(), connectortest.NewNopSettings(f.Type()), testConfig(pipeline.SignalTraces),
+		connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+			pipeline.NewIDWithName(pipeline.SignalTraces, "acme"): consumertest.NewNop(),
+		}))
+	require.Error(t, err)
+
+	_, err = f.CreateTracesToTraces(context.Background(), connectortest.NewNopSettings(f.Type()), testConfig(pipeline.SignalTraces),
+		consumertest.NewNop())
+	require.ErrorIs(t, err, errUnexpectedConsumer)
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package metadataroutingconnector routes signals to pipelines based on the client metadata
+// or the resource attributes.
+package metadataroutingconnector // import "go.opentelemetry.io/collector/connector/metadataroutingconnector"
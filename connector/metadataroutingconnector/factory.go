@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadataroutingconnector // import "go.opentelemetry.io/collector/connector/metadataroutingconnector"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/metadataroutingconnector/internal/metadata"
+	"go.opentelemetry.io/collector/connector/xconnector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+)
+
+var errUnexpectedConsumer = errors.New("expected consumer to be a connector router")
+
+// NewFactory returns a connector.Factory.
+func NewFactory() connector.Factory {
+	return xconnector.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		xconnector.WithTracesToTraces(createTracesToTraces, metadata.TracesToTracesStability),
+		xconnector.WithMetricsToMetrics(createMetricsToMetrics, metadata.MetricsToMetricsStability),
+		xconnector.WithLogsToLogs(createLogsToLogs, metadata.LogsToLogsStability),
+		xconnector.WithProfilesToProfiles(createProfilesToProfiles, metadata.ProfilesToProfilesStability),
+	)
+}
+
+// createDefaultConfig creates the default configuration.
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
+
+// createTracesToTraces creates a traces connector based on provided config.
+func createTracesToTraces(
+	_ context.Context,
+	_ connector.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Traces,
+) (connector.Traces, error) {
+	tr, ok := nextConsumer.(connector.TracesRouterAndConsumer)
+	if !ok {
+		return nil, errUnexpectedConsumer
+	}
+	r, err := newRouter(cfg.(*Config), tr.Consumer)
+	if err != nil {
+		return nil, err
+	}
+	return &tracesConnector{router: r}, nil
+}
+
+// createMetricsToMetrics creates a metrics connector based on provided config.
+func createMetricsToMetrics(
+	_ context.Context,
+	_ connector.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Metrics,
+) (connector.Metrics, error) {
+	mr, ok := nextConsumer.(connector.MetricsRouterAndConsumer)
+	if !ok {
+		return nil, errUnexpectedConsumer
+	}
+	r, err := newRouter(cfg.(*Config), mr.Consumer)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsConnector{router: r}, nil
+}
+
+// createLogsToLogs creates a logs connector based on provided config.
+func createLogsToLogs(
+	_ context.Context,
+	_ connector.Settings,
+	cfg component.Config,
+	nextConsumer consumer.Logs,
+) (connector.Logs, error) {
+	lr, ok := nextConsumer.(connector.LogsRouterAndConsumer)
+	if !ok {
+		return nil, errUnexpectedConsumer
+	}
+	r, err := newRouter(cfg.(*Config), lr.Consumer)
+	if err != nil {
+		return nil, err
+	}
+	return &logsConnector{router: r}, nil
+}
+
+// createProfilesToProfiles creates a profiles connector based on provided config.
+func createProfilesToProfiles(
+	_ context.Context,
+	_ connector.Settings,
+	cfg component.Config,
+	nextConsumer xconsumer.Profiles,
+) (xconnector.Profiles, error) {
+	pr, ok := nextConsumer.(xconnector.ProfilesRouterAndConsumer)
+	if !ok {
+		return nil, errUnexpectedConsumer
+	}
+	r, err := newRouter(cfg.(*Config), pr.Consumer)
+	if err != nil {
+		return nil, err
+	}
+	return &profilesConnector{router: r}, nil
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metadataroutingconnector // import "go.opentelemetry.io/collector/connector/metadataroutingconnector"
+
+import (
+	"context"
+	"slices"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// noRoute is returned by the router for the data matching no route, without default pipelines.
+const noRoute = -1
+
+// router resolves the consumers of the routes for a signal.
+type router[C any] struct {
+	routes []RouteConfig
+	// consumers holds the consumer of each route, followed by the consumer of the default pipelines.
+	consumers  []C
+	hasDefault bool
+}
+
+func newRouter[C any](cfg *Config, consumerFor func(...pipeline.ID) (C, error)) (*router[C], error) {
+	r := &router[C]{routes: cfg.Table}
+	for _, route := range cfg.Table {
+		cons, err := consumerFor(route.Pipelines...)
+		if err != nil {
+			return nil, err
+		}
+		r.consumers = append(r.consumers, cons)
+	}
+	if len(cfg.DefaultPipelines) > 0 {
+		cons, err := consumerFor(cfg.DefaultPipelines...)
+		if err != nil {
+			return nil, err
+		}
+		r.consumers = append(r.consumers, cons)
+		r.hasDefault = true
+	}
+	return r, nil
+}
+
+// route returns the index of the consumer of a resource, or noRoute.
+func (r *router[C]) route(info client.Info, res pcommon.Resource) int {
+	for i, route := range r.routes {
+		if route.MetadataKey != "" {
+			if slices.Contains(info.Metadata.Get(route.MetadataKey), route.Value) {
+				return i
+			}
+			continue
+		}
+		if attr, ok := res.Attributes().Get(route.ResourceAttribute); ok && attr.AsString() == route.Value {
+			return i
+		}
+	}
+	if r.hasDefault {
+		return len(r.routes)
+	}
+	return noRoute
+}
+
+// routeAll returns the index of the consumer of each resource, and whether they all go to the same consumer.
+func routeAll[C any](ctx context.Context, r *router[C], resources int, resource func(int) pcommon.Resource) ([]int, bool) {
+	info := client.FromContext(ctx)
+	indexes := make([]int, resources)
+	same := true
+	for i := range indexes {
+		indexes[i] = r.route(info, resource(i))
+		same = same && indexes[i] == indexes[0]
+	}
+	return indexes, same
+}
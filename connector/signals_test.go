@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build otelcol_no_traces
+
+package connector // import "go.opentelemetry.io/collector/connector"
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestNewFactoryWithoutTraces(t *testing.T) {
+	defaultCfg := struct{}{}
+	factory := NewFactory(testType, func() component.Config { return &defaultCfg },
+		WithTracesToMetrics(createTracesToMetrics, component.StabilityLevelAlpha),
+		WithMetricsToTraces(createMetricsToTraces, component.StabilityLevelAlpha),
+		WithMetricsToMetrics(createMetricsToMetrics, component.StabilityLevelAlpha))
+
+	assert.Equal(t, component.StabilityLevelUndefined, factory.TracesToMetricsStability())
+	_, err := factory.CreateTracesToMetrics(context.Background(), Settings{ID: testID}, &defaultCfg, consumertest.NewNop())
+	require.ErrorIs(t, err, pipeline.ErrSignalNotSupported)
+	assert.Equal(t, component.StabilityLevelUndefined, factory.MetricsToTracesStability())
+	_, err = factory.CreateMetricsToTraces(context.Background(), Settings{ID: testID}, &defaultCfg, consumertest.NewNop())
+	require.ErrorIs(t, err, pipeline.ErrSignalNotSupported)
+
+	assert.Equal(t, component.StabilityLevelAlpha, factory.MetricsToMetricsStability())
+	_, err = factory.CreateMetricsToMetrics(context.Background(), Settings{ID: testID}, &defaultCfg, consumertest.NewNop())
+	require.NoError(t, err)
+}
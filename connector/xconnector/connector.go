@@ -88,6 +88,9 @@ func (f factoryOptionFunc) applyOption(o *factoryOpts) {
 	f(o)
 }
 
+// factoryOpts collects the options of the base factory. Those options are created by the With functions,
+// and not by the closures they return, for the closures not to keep the create functions of the signals
+// left out of the distribution.
 type factoryOpts struct {
 	opts []connector.FactoryOption
 
@@ -96,69 +99,81 @@ type factoryOpts struct {
 
 // WithTracesToTraces overrides the default "error not supported" implementation for WithTracesToTraces and the default "undefined" stability level.
 func WithTracesToTraces(createTracesToTraces connector.CreateTracesToTracesFunc, sl component.StabilityLevel) FactoryOption {
+	opt := connector.WithTracesToTraces(createTracesToTraces, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, connector.WithTracesToTraces(createTracesToTraces, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithTracesToMetrics overrides the default "error not supported" implementation for WithTracesToMetrics and the default "undefined" stability level.
 func WithTracesToMetrics(createTracesToMetrics connector.CreateTracesToMetricsFunc, sl component.StabilityLevel) FactoryOption {
+	opt := connector.WithTracesToMetrics(createTracesToMetrics, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, connector.WithTracesToMetrics(createTracesToMetrics, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithTracesToLogs overrides the default "error not supported" implementation for WithTracesToLogs and the default "undefined" stability level.
 func WithTracesToLogs(createTracesToLogs connector.CreateTracesToLogsFunc, sl component.StabilityLevel) FactoryOption {
+	opt := connector.WithTracesToLogs(createTracesToLogs, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, connector.WithTracesToLogs(createTracesToLogs, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithMetricsToTraces overrides the default "error not supported" implementation for WithMetricsToTraces and the default "undefined" stability level.
 func WithMetricsToTraces(createMetricsToTraces connector.CreateMetricsToTracesFunc, sl component.StabilityLevel) FactoryOption {
+	opt := connector.WithMetricsToTraces(createMetricsToTraces, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, connector.WithMetricsToTraces(createMetricsToTraces, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithMetricsToMetrics overrides the default "error not supported" implementation for WithMetricsToMetrics and the default "undefined" stability level.
 func WithMetricsToMetrics(createMetricsToMetrics connector.CreateMetricsToMetricsFunc, sl component.StabilityLevel) FactoryOption {
+	opt := connector.WithMetricsToMetrics(createMetricsToMetrics, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, connector.WithMetricsToMetrics(createMetricsToMetrics, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithMetricsToLogs overrides the default "error not supported" implementation for WithMetricsToLogs and the default "undefined" stability level.
 func WithMetricsToLogs(createMetricsToLogs connector.CreateMetricsToLogsFunc, sl component.StabilityLevel) FactoryOption {
+	opt := connector.WithMetricsToLogs(createMetricsToLogs, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, connector.WithMetricsToLogs(createMetricsToLogs, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithLogsToTraces overrides the default "error not supported" implementation for WithLogsToTraces and the default "undefined" stability level.
 func WithLogsToTraces(createLogsToTraces connector.CreateLogsToTracesFunc, sl component.StabilityLevel) FactoryOption {
+	opt := connector.WithLogsToTraces(createLogsToTraces, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, connector.WithLogsToTraces(createLogsToTraces, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithLogsToMetrics overrides the default "error not supported" implementation for WithLogsToMetrics and the default "undefined" stability level.
 func WithLogsToMetrics(createLogsToMetrics connector.CreateLogsToMetricsFunc, sl component.StabilityLevel) FactoryOption {
+	opt := connector.WithLogsToMetrics(createLogsToMetrics, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, connector.WithLogsToMetrics(createLogsToMetrics, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithLogsToLogs overrides the default "error not supported" implementation for WithLogsToLogs and the default "undefined" stability level.
 func WithLogsToLogs(createLogsToLogs connector.CreateLogsToLogsFunc, sl component.StabilityLevel) FactoryOption {
+	opt := connector.WithLogsToLogs(createLogsToLogs, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, connector.WithLogsToLogs(createLogsToLogs, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithTracesToProfiles overrides the default "error not supported" implementation for WithTracesToProfiles and the default "undefined" stability level.
 func WithTracesToProfiles(createTracesToProfiles CreateTracesToProfilesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.TracesEnabled || !internal.ProfilesEnabled {
+		return factoryOptionFunc(func(*factoryOpts) {})
+	}
 	return factoryOptionFunc(func(o *factoryOpts) {
 		o.tracesToProfilesStabilityLevel = sl
 		o.createTracesToProfilesFunc = createTracesToProfiles
@@ -167,6 +182,9 @@ func WithTracesToProfiles(createTracesToProfiles CreateTracesToProfilesFunc, sl
 
 // WithMetricsToProfiles overrides the default "error not supported" implementation for WithMetricsToProfiles and the default "undefined" stability level.
 func WithMetricsToProfiles(createMetricsToProfiles CreateMetricsToProfilesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.MetricsEnabled || !internal.ProfilesEnabled {
+		return factoryOptionFunc(func(*factoryOpts) {})
+	}
 	return factoryOptionFunc(func(o *factoryOpts) {
 		o.metricsToProfilesStabilityLevel = sl
 		o.createMetricsToProfilesFunc = createMetricsToProfiles
@@ -175,6 +193,9 @@ func WithMetricsToProfiles(createMetricsToProfiles CreateMetricsToProfilesFunc,
 
 // WithLogsToProfiles overrides the default "error not supported" implementation for WithLogsToProfiles and the default "undefined" stability level.
 func WithLogsToProfiles(createLogsToProfiles CreateLogsToProfilesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.LogsEnabled || !internal.ProfilesEnabled {
+		return factoryOptionFunc(func(*factoryOpts) {})
+	}
 	return factoryOptionFunc(func(o *factoryOpts) {
 		o.logsToProfilesStabilityLevel = sl
 		o.createLogsToProfilesFunc = createLogsToProfiles
@@ -183,6 +204,9 @@ func WithLogsToProfiles(createLogsToProfiles CreateLogsToProfilesFunc, sl compon
 
 // WithProfilesToProfiles overrides the default "error not supported" implementation for WithProfilesToProfiles and the default "undefined" stability level.
 func WithProfilesToProfiles(createProfilesToProfiles CreateProfilesToProfilesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.ProfilesEnabled {
+		return factoryOptionFunc(func(*factoryOpts) {})
+	}
 	return factoryOptionFunc(func(o *factoryOpts) {
 		o.profilesToProfilesStabilityLevel = sl
 		o.createProfilesToProfilesFunc = createProfilesToProfiles
@@ -191,6 +215,9 @@ func WithProfilesToProfiles(createProfilesToProfiles CreateProfilesToProfilesFun
 
 // WithProfilesToTraces overrides the default "error not supported" implementation for WithProfilesToTraces and the default "undefined" stability level.
 func WithProfilesToTraces(createProfilesToTraces CreateProfilesToTracesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.ProfilesEnabled || !internal.TracesEnabled {
+		return factoryOptionFunc(func(*factoryOpts) {})
+	}
 	return factoryOptionFunc(func(o *factoryOpts) {
 		o.profilesToTracesStabilityLevel = sl
 		o.createProfilesToTracesFunc = createProfilesToTraces
@@ -199,6 +226,9 @@ func WithProfilesToTraces(createProfilesToTraces CreateProfilesToTracesFunc, sl
 
 // WithProfilesToMetrics overrides the default "error not supported" implementation for WithProfilesToMetrics and the default "undefined" stability level.
 func WithProfilesToMetrics(createProfilesToMetrics CreateProfilesToMetricsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.ProfilesEnabled || !internal.MetricsEnabled {
+		return factoryOptionFunc(func(*factoryOpts) {})
+	}
 	return factoryOptionFunc(func(o *factoryOpts) {
 		o.profilesToMetricsStabilityLevel = sl
 		o.createProfilesToMetricsFunc = createProfilesToMetrics
@@ -207,6 +237,9 @@ func WithProfilesToMetrics(createProfilesToMetrics CreateProfilesToMetricsFunc,
 
 // WithProfilesToLogs overrides the default "error not supported" implementation for WithProfilesToLogs and the default "undefined" stability level.
 func WithProfilesToLogs(createProfilesToLogs CreateProfilesToLogsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.ProfilesEnabled || !internal.LogsEnabled {
+		return factoryOptionFunc(func(*factoryOpts) {})
+	}
 	return factoryOptionFunc(func(o *factoryOpts) {
 		o.profilesToLogsStabilityLevel = sl
 		o.createProfilesToLogsFunc = createProfilesToLogs
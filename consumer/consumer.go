@@ -12,6 +12,21 @@ import (
 // Capabilities describes the capabilities of a Processor.
 type Capabilities = internal.Capabilities
 
+// Mutations is a set of parts of the data modified by a consumer, see [Capabilities].
+type Mutations = internal.Mutations
+
+const (
+	// MutatesResources indicates that the resources are modified, including their attributes.
+	MutatesResources = internal.MutatesResources
+	// MutatesScopes indicates that the instrumentation scopes are modified, including their attributes.
+	MutatesScopes = internal.MutatesScopes
+	// MutatesRecords indicates that the records (spans, data points, log records or profiles) are
+	// modified, including their attributes.
+	MutatesRecords = internal.MutatesRecords
+	// MutatesStructure indicates that resources, scopes or records are added, removed or reordered.
+	MutatesStructure = internal.MutatesStructure
+)
+
 var errNilFunc = errors.New("nil consumer func")
 
 // Option to construct new consumers.
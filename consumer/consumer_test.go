@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilitiesMerge(t *testing.T) {
+	readOnly := Capabilities{}
+	mutatesAll := Capabilities{MutatesData: true}
+	mutatesResources := Capabilities{MutatesData: true, Mutations: MutatesResources}
+	mutatesScopes := Capabilities{MutatesData: true, Mutations: MutatesScopes}
+
+	assert.Equal(t, readOnly, readOnly.Merge(readOnly))
+	assert.Equal(t, mutatesResources, readOnly.Merge(mutatesResources))
+	assert.Equal(t, mutatesResources, mutatesResources.Merge(readOnly))
+	assert.Equal(t, mutatesAll, mutatesResources.Merge(mutatesAll))
+	assert.Equal(t, mutatesAll, mutatesAll.Merge(mutatesScopes))
+	assert.Equal(t, Capabilities{MutatesData: true, Mutations: MutatesResources | MutatesScopes}, mutatesResources.Merge(mutatesScopes))
+}
+
+func TestCapabilitiesMutatesOnly(t *testing.T) {
+	assert.True(t, Capabilities{}.MutatesOnly(MutatesResources))
+	assert.False(t, Capabilities{MutatesData: true}.MutatesOnly(MutatesResources))
+	assert.True(t, Capabilities{MutatesData: true, Mutations: MutatesResources}.MutatesOnly(MutatesResources|MutatesScopes))
+	assert.False(t, Capabilities{MutatesData: true, Mutations: MutatesResources | MutatesRecords}.MutatesOnly(MutatesResources|MutatesScopes))
+}
@@ -20,4 +20,26 @@
 // may be done by the component itself, however typically it is done by the original sender, after
 // the receiver in the pipeline returns a response to the sender indicating that the Collector is
 // currently overloaded and the request must be retried.
+//
+// # Backpressure
+//
+// The consumers signal backpressure with the following errors, which the receivers, the exporter
+// helper and the connectors pass upstream:
+//
+//   - NewRetryableErrorWithDelay: the data can be retried once the delay elapsed. The receivers
+//     tell the sender when to retry, and the exporter helper waits at least the delay before retrying.
+//     See RetryDelay.
+//   - NewPermanent: the data must not be retried.
+//   - NewPartial: the data was consumed except the given number of items, which were rejected. The
+//     data must not be retried. The receivers report a partial success to the sender, and the
+//     exporter helper counts only the rejected items as failed. See Rejected.
+//   - NewPartialItems: like NewPartial, with the location of each rejected item in the data and the
+//     reason it was rejected. See RejectedItems. To have only some of the items retried, e.g. the
+//     ones rejected because of a transient failure, return NewTraces, NewMetrics or NewLogs with the
+//     items extracted by ExtractTraces, ExtractMetrics or ExtractLogs instead: the exporter helper
+//     then retries only these items.
+//
+// A consumer fanning out the data, e.g. to the pipelines of a receiver or of a connector, returns
+// a partial error only if all the consumers which failed returned one. Otherwise, it returns the
+// other errors, so that the data is retried if they are retryable.
 package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
@@ -7,9 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"go.opentelemetry.io/collector/consumer/consumererror/internal/statusconversion"
 )
@@ -25,6 +28,7 @@ type Error struct {
 	httpStatus  int
 	grpcStatus  *status.Status
 	isRetryable bool
+	retryDelay  time.Duration
 }
 
 var _ error = (*Error)(nil)
@@ -74,6 +78,12 @@ func NewRetryableError(origErr error) error {
 	return &Error{error: origErr, isRetryable: true}
 }
 
+// NewRetryableErrorWithDelay records that this error is retryable once the given delay elapsed,
+// typically because the consumer is overloaded or throttled by its destination.
+func NewRetryableErrorWithDelay(origErr error, delay time.Duration) error {
+	return &Error{error: origErr, isRetryable: true, retryDelay: delay}
+}
+
 // Error implements the error interface.
 //
 // If an error object was given, that is used.
@@ -119,6 +129,32 @@ func (e *Error) IsRetryable() bool {
 	return e.isRetryable
 }
 
+// RetryDelay returns the delay to wait before retrying, if the error was created with
+// NewRetryableErrorWithDelay. Otherwise, returns zero.
+func (e *Error) RetryDelay() time.Duration {
+	return e.retryDelay
+}
+
+// RetryDelay returns the longest delay to wait before retrying of the errors created with
+// NewRetryableErrorWithDelay in the tree of err, including the errors joined together, e.g.
+// by a consumer fanning out to several consumers. Returns zero if there is none.
+func RetryDelay(err error) time.Duration {
+	var delay time.Duration
+	for err != nil {
+		if e, ok := err.(*Error); ok {
+			delay = max(delay, e.retryDelay)
+		}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, joinedErr := range joined.Unwrap() {
+				delay = max(delay, RetryDelay(joinedErr))
+			}
+			break
+		}
+		err = errors.Unwrap(err)
+	}
+	return delay
+}
+
 // ToHTTPStatus returns an HTTP status code either directly set by the source on
 // an [Error] object, derived from a gRPC status code set by the source, or
 // derived from Retryable. When deriving the value, the OTLP specification is
@@ -166,7 +202,14 @@ func ToGRPCStatus(err error) *status.Status {
 			return statusconversion.NewStatusFromMsgAndHTTPCode(e.Error(), e.httpStatus)
 		}
 		if e.isRetryable {
-			return status.New(codes.Unavailable, e.Error())
+			st := status.New(codes.Unavailable, e.Error())
+			if e.retryDelay > 0 {
+				// Tells the client when to retry, as the Retry-After header for HTTP.
+				if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(e.retryDelay)}); err == nil {
+					return detailed
+				}
+			}
+			return st
 		}
 	}
 	if st, ok := status.FromError(err); ok {
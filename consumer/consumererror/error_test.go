@@ -5,11 +5,14 @@ package consumererror
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -52,6 +55,39 @@ func Test_NewRetryableError(t *testing.T) {
 	require.Equal(t, wantErr, newErr)
 }
 
+func Test_NewRetryableErrorWithDelay(t *testing.T) {
+	wantErr := &Error{
+		error:       errTest,
+		isRetryable: true,
+		retryDelay:  time.Second,
+	}
+
+	newErr := NewRetryableErrorWithDelay(errTest, time.Second)
+
+	require.Equal(t, wantErr, newErr)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Zero(t, RetryDelay(nil))
+	assert.Zero(t, RetryDelay(NewRetryableError(errTest)))
+	assert.Equal(t, time.Second, RetryDelay(fmt.Errorf("wrapped: %w", NewRetryableErrorWithDelay(errTest, time.Second))))
+	// The longest delay of the joined errors.
+	assert.Equal(t, 2*time.Second, RetryDelay(errors.Join(
+		NewRetryableErrorWithDelay(errTest, time.Second),
+		fmt.Errorf("wrapped: %w", NewRetryableErrorWithDelay(errTest, 2*time.Second)),
+		errTest,
+	)))
+}
+
+func TestError_ToGRPCStatusRetryDelay(t *testing.T) {
+	st := ToGRPCStatus(NewRetryableErrorWithDelay(errTest, 3*time.Second))
+	assert.Equal(t, codes.Unavailable, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, 3*time.Second, retryInfo.GetRetryDelay().AsDuration())
+}
+
 func Test_Error(t *testing.T) {
 	newErr := Error{error: errTest}
 
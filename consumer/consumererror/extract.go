@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import (
+	"go.opentelemetry.io/collector/consumer/consumererror/internal"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// ExtractTraces returns a copy of the spans of td located by the items, with their resources and scopes.
+// The items not located in td are ignored.
+func ExtractTraces(td ptrace.Traces, items []RejectedItem) ptrace.Traces {
+	dest := ptrace.NewTraces()
+	var rsDest ptrace.ResourceSpans
+	var ssDest ptrace.ScopeSpans
+	lastResource, lastScope := -1, -1
+	for _, item := range internal.SortedItems(items) {
+		if item.Resource >= td.ResourceSpans().Len() {
+			break
+		}
+		rs := td.ResourceSpans().At(item.Resource)
+		if item.Scope >= rs.ScopeSpans().Len() {
+			continue
+		}
+		ss := rs.ScopeSpans().At(item.Scope)
+		if item.Record >= ss.Spans().Len() {
+			continue
+		}
+		if item.Resource != lastResource {
+			rsDest = dest.ResourceSpans().AppendEmpty()
+			rs.Resource().CopyTo(rsDest.Resource())
+			rsDest.SetSchemaUrl(rs.SchemaUrl())
+			lastResource, lastScope = item.Resource, -1
+		}
+		if item.Scope != lastScope {
+			ssDest = rsDest.ScopeSpans().AppendEmpty()
+			ss.Scope().CopyTo(ssDest.Scope())
+			ssDest.SetSchemaUrl(ss.SchemaUrl())
+			lastScope = item.Scope
+		}
+		ss.Spans().At(item.Record).CopyTo(ssDest.Spans().AppendEmpty())
+	}
+	return dest
+}
+
+// ExtractLogs returns a copy of the log records of ld located by the items, with their resources and
+// scopes. The items not located in ld are ignored.
+func ExtractLogs(ld plog.Logs, items []RejectedItem) plog.Logs {
+	dest := plog.NewLogs()
+	var rlDest plog.ResourceLogs
+	var slDest plog.ScopeLogs
+	lastResource, lastScope := -1, -1
+	for _, item := range internal.SortedItems(items) {
+		if item.Resource >= ld.ResourceLogs().Len() {
+			break
+		}
+		rl := ld.ResourceLogs().At(item.Resource)
+		if item.Scope >= rl.ScopeLogs().Len() {
+			continue
+		}
+		sl := rl.ScopeLogs().At(item.Scope)
+		if item.Record >= sl.LogRecords().Len() {
+			continue
+		}
+		if item.Resource != lastResource {
+			rlDest = dest.ResourceLogs().AppendEmpty()
+			rl.Resource().CopyTo(rlDest.Resource())
+			rlDest.SetSchemaUrl(rl.SchemaUrl())
+			lastResource, lastScope = item.Resource, -1
+		}
+		if item.Scope != lastScope {
+			slDest = rlDest.ScopeLogs().AppendEmpty()
+			sl.Scope().CopyTo(slDest.Scope())
+			slDest.SetSchemaUrl(sl.SchemaUrl())
+			lastScope = item.Scope
+		}
+		sl.LogRecords().At(item.Record).CopyTo(slDest.LogRecords().AppendEmpty())
+	}
+	return dest
+}
+
+// ExtractMetrics returns a copy of the data points of md located by the items, with their metrics, resources
+// and scopes. The items not located in md are ignored.
+func ExtractMetrics(md pmetric.Metrics, items []RejectedItem) pmetric.Metrics {
+	dest := pmetric.NewMetrics()
+	var rmDest pmetric.ResourceMetrics
+	var smDest pmetric.ScopeMetrics
+	var mDest pmetric.Metric
+	lastResource, lastScope, lastMetric := -1, -1, -1
+	for _, item := range internal.SortedItems(items) {
+		if item.Resource >= md.ResourceMetrics().Len() {
+			break
+		}
+		rm := md.ResourceMetrics().At(item.Resource)
+		if item.Scope >= rm.ScopeMetrics().Len() {
+			continue
+		}
+		sm := rm.ScopeMetrics().At(item.Scope)
+		if item.Record >= sm.Metrics().Len() {
+			continue
+		}
+		m := sm.Metrics().At(item.Record)
+		if item.DataPoint >= dataPointCount(m) {
+			continue
+		}
+		if item.Resource != lastResource {
+			rmDest = dest.ResourceMetrics().AppendEmpty()
+			rm.Resource().CopyTo(rmDest.Resource())
+			rmDest.SetSchemaUrl(rm.SchemaUrl())
+			lastResource, lastScope = item.Resource, -1
+		}
+		if item.Scope != lastScope {
+			smDest = rmDest.ScopeMetrics().AppendEmpty()
+			sm.Scope().CopyTo(smDest.Scope())
+			smDest.SetSchemaUrl(sm.SchemaUrl())
+			lastScope, lastMetric = item.Scope, -1
+		}
+		if item.Record != lastMetric {
+			mDest = smDest.Metrics().AppendEmpty()
+			copyMetricWithoutDataPoints(m, mDest)
+			lastMetric = item.Record
+		}
+		copyDataPoint(m, item.DataPoint, mDest)
+	}
+	return dest
+}
+
+func dataPointCount(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
+
+func copyMetricWithoutDataPoints(src, dest pmetric.Metric) {
+	dest.SetName(src.Name())
+	dest.SetDescription(src.Description())
+	dest.SetUnit(src.Unit())
+	src.Metadata().CopyTo(dest.Metadata())
+	switch src.Type() {
+	case pmetric.MetricTypeGauge:
+		dest.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		dest.SetEmptySum().SetAggregationTemporality(src.Sum().AggregationTemporality())
+		dest.Sum().SetIsMonotonic(src.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		dest.SetEmptyHistogram().SetAggregationTemporality(src.Histogram().AggregationTemporality())
+	case pmetric.MetricTypeExponentialHistogram:
+		dest.SetEmptyExponentialHistogram().SetAggregationTemporality(src.ExponentialHistogram().AggregationTemporality())
+	case pmetric.MetricTypeSummary:
+		dest.SetEmptySummary()
+	}
+}
+
+func copyDataPoint(src pmetric.Metric, i int, dest pmetric.Metric) {
+	switch src.Type() {
+	case pmetric.MetricTypeGauge:
+		src.Gauge().DataPoints().At(i).CopyTo(dest.Gauge().DataPoints().AppendEmpty())
+	case pmetric.MetricTypeSum:
+		src.Sum().DataPoints().At(i).CopyTo(dest.Sum().DataPoints().AppendEmpty())
+	case pmetric.MetricTypeHistogram:
+		src.Histogram().DataPoints().At(i).CopyTo(dest.Histogram().DataPoints().AppendEmpty())
+	case pmetric.MetricTypeExponentialHistogram:
+		src.ExponentialHistogram().DataPoints().At(i).CopyTo(dest.ExponentialHistogram().DataPoints().AppendEmpty())
+	case pmetric.MetricTypeSummary:
+		src.Summary().DataPoints().At(i).CopyTo(dest.Summary().DataPoints().AppendEmpty())
+	}
+}
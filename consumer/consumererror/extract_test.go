@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestExtractTraces(t *testing.T) {
+	td := testdata.GenerateTraces(4)
+	td.ResourceSpans().At(0).CopyTo(td.ResourceSpans().AppendEmpty())
+
+	extracted := ExtractTraces(td, []RejectedItem{
+		{Resource: 1, Record: 3},
+		{Resource: 0, Record: 1},
+		{Resource: 0, Record: 1},
+		{Resource: 0, Record: 7},
+		{Resource: 0, Scope: 1},
+		{Resource: 2},
+		{Resource: -1},
+	})
+	assert.Equal(t, 2, extracted.ResourceSpans().Len())
+	assert.Equal(t, 2, extracted.SpanCount())
+	assert.Equal(t, td.ResourceSpans().At(0).Resource(), extracted.ResourceSpans().At(0).Resource())
+	assert.Equal(t, td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1),
+		extracted.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0))
+	assert.Equal(t, td.ResourceSpans().At(1).ScopeSpans().At(0).Spans().At(3),
+		extracted.ResourceSpans().At(1).ScopeSpans().At(0).Spans().At(0))
+
+	assert.Equal(t, 0, ExtractTraces(td, nil).SpanCount())
+}
+
+func TestExtractLogs(t *testing.T) {
+	ld := testdata.GenerateLogs(5)
+
+	extracted := ExtractLogs(ld, []RejectedItem{{Record: 4}, {Record: 0}, {Record: 5}})
+	assert.Equal(t, 1, extracted.ResourceLogs().Len())
+	assert.Equal(t, 2, extracted.LogRecordCount())
+	records := extracted.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0), records.At(0))
+	assert.Equal(t, ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(4), records.At(1))
+}
+
+func TestExtractMetrics(t *testing.T) {
+	md := testdata.GenerateMetricsAllTypes()
+	var items []RejectedItem
+	for i := range md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().Len() {
+		// The second data point of every metric, and a data point that does not exist.
+		items = append(items, RejectedItem{Record: i, DataPoint: 1}, RejectedItem{Record: i, DataPoint: 2})
+	}
+
+	extracted := ExtractMetrics(md, items)
+	assert.Equal(t, md.MetricCount(), extracted.MetricCount())
+	assert.Equal(t, md.MetricCount(), extracted.DataPointCount())
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	extractedMetrics := extracted.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := range metrics.Len() {
+		m, em := metrics.At(i), extractedMetrics.At(i)
+		assert.Equal(t, m.Name(), em.Name())
+		assert.Equal(t, m.Type(), em.Type())
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			assert.Equal(t, m.Gauge().DataPoints().At(1), em.Gauge().DataPoints().At(0))
+		case pmetric.MetricTypeSum:
+			assert.Equal(t, m.Sum().AggregationTemporality(), em.Sum().AggregationTemporality())
+			assert.Equal(t, m.Sum().IsMonotonic(), em.Sum().IsMonotonic())
+			assert.Equal(t, m.Sum().DataPoints().At(1), em.Sum().DataPoints().At(0))
+		case pmetric.MetricTypeHistogram:
+			assert.Equal(t, m.Histogram().AggregationTemporality(), em.Histogram().AggregationTemporality())
+			assert.Equal(t, m.Histogram().DataPoints().At(1), em.Histogram().DataPoints().At(0))
+		case pmetric.MetricTypeExponentialHistogram:
+			assert.Equal(t, m.ExponentialHistogram().DataPoints().At(1), em.ExponentialHistogram().DataPoints().At(0))
+		case pmetric.MetricTypeSummary:
+			assert.Equal(t, m.Summary().DataPoints().At(1), em.Summary().DataPoints().At(0))
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/consumer/consumererror/internal"
+
+import (
+	"cmp"
+	"slices"
+)
+
+// RejectedItem is an item rejected by a consumer, located by its indices in the data, with the reason it
+// was rejected.
+type RejectedItem struct {
+	// Resource is the index of the resource of the item.
+	Resource int
+	// Scope is the index of the scope of the item in its resource.
+	Scope int
+	// Record is the index of the span, metric, log record or profile in its scope.
+	Record int
+	// DataPoint is the index of the data point in its metric. It is only used for metrics, where
+	// the items are the data points.
+	DataPoint int
+	// Err is the reason the item was rejected.
+	Err error
+}
+
+// SortedItems returns the items sorted by their location in the data, without duplicates and without the
+// items with negative indices.
+func SortedItems(items []RejectedItem) []RejectedItem {
+	sorted := make([]RejectedItem, 0, len(items))
+	for _, item := range items {
+		if item.Resource >= 0 && item.Scope >= 0 && item.Record >= 0 && item.DataPoint >= 0 {
+			sorted = append(sorted, item)
+		}
+	}
+	slices.SortStableFunc(sorted, compareItems)
+	return slices.CompactFunc(sorted, func(a, b RejectedItem) bool {
+		return compareItems(a, b) == 0
+	})
+}
+
+func compareItems(a, b RejectedItem) int {
+	return cmp.Or(
+		cmp.Compare(a.Resource, b.Resource),
+		cmp.Compare(a.Scope, b.Scope),
+		cmp.Compare(a.Record, b.Record),
+		cmp.Compare(a.DataPoint, b.DataPoint),
+	)
+}
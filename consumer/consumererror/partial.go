@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror // import "go.opentelemetry.io/collector/consumer/consumererror"
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+
+	"go.opentelemetry.io/collector/consumer/consumererror/internal"
+)
+
+// RejectedItem is an item rejected by a consumer, located by its indices in the data, with the reason it
+// was rejected. See NewPartialItems.
+type RejectedItem = internal.RejectedItem
+
+// partial is an error of a consumer which consumed the data, except some of its items.
+type partial struct {
+	err      error
+	rejected int
+	items    []RejectedItem
+}
+
+// NewPartial wraps an error to indicate that the consumer consumed the data, except the given
+// number of items which it rejected. The consumed items must not be consumed again, so the error
+// is also permanent.
+func NewPartial(err error, rejected int) error {
+	return partial{err: err, rejected: rejected}
+}
+
+// NewPartialItems wraps an error to indicate that the consumer consumed the data, except the given
+// items which it rejected, each with its own reason. The items are the spans, the data points, the
+// log records or the profiles, so that the number of rejected items is the number of items. Like
+// with NewPartial, the error is permanent: to have the sender retry some of the rejected items,
+// return instead an error carrying them, e.g. NewTraces with the spans extracted by ExtractTraces.
+func NewPartialItems(err error, items []RejectedItem) error {
+	return partial{err: err, rejected: len(items), items: slices.Clone(items)}
+}
+
+func (p partial) Error() string {
+	return "Partially consumed, " + strconv.Itoa(p.rejected) + " items rejected: " + p.err.Error()
+}
+
+// Unwrap returns the wrapped error, as a permanent error, for functions Is and As in standard package errors.
+func (p partial) Unwrap() error {
+	return permanent{err: p.err}
+}
+
+// Rejected returns the number of items rejected by the consumer, if the error was wrapped with
+// the NewPartial function. A partial error joined with other errors, e.g. by a consumer fanning
+// out the data, is not reported, since the data was not consumed by the consumers which returned
+// the other errors.
+func Rejected(err error) (int, bool) {
+	p, ok := asPartial(err)
+	if !ok {
+		return 0, false
+	}
+	return p.rejected, true
+}
+
+// RejectedItems returns the items rejected by the consumer, if the error was wrapped with the
+// NewPartialItems function. Like with Rejected, a partial error joined with other errors is not
+// reported.
+func RejectedItems(err error) ([]RejectedItem, bool) {
+	p, ok := asPartial(err)
+	if !ok || p.items == nil {
+		return nil, false
+	}
+	return slices.Clone(p.items), true
+}
+
+// asPartial returns the partial error in the chain of the error, unless it is joined with other errors.
+func asPartial(err error) (partial, bool) {
+	for err != nil {
+		if p, ok := err.(partial); ok {
+			return p, true
+		}
+		if _, joined := err.(interface{ Unwrap() []error }); joined {
+			return partial{}, false
+		}
+		err = errors.Unwrap(err)
+	}
+	return partial{}, false
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package consumererror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartial(t *testing.T) {
+	err := errors.New("invalid spans")
+	partialErr := NewPartial(err, 3)
+	assert.EqualError(t, partialErr, "Partially consumed, 3 items rejected: invalid spans")
+	assert.ErrorIs(t, partialErr, err)
+	assert.True(t, IsPermanent(partialErr))
+
+	rejected, ok := Rejected(fmt.Errorf("%w", partialErr))
+	assert.True(t, ok)
+	assert.Equal(t, 3, rejected)
+}
+
+func TestRejectedNotPartial(t *testing.T) {
+	_, ok := Rejected(nil)
+	assert.False(t, ok)
+	_, ok = Rejected(NewPermanent(errors.New("testError")))
+	assert.False(t, ok)
+}
+
+func TestPartialItems(t *testing.T) {
+	err := errors.New("invalid spans")
+	items := []RejectedItem{
+		{Record: 1, Err: errors.New("missing trace id")},
+		{Resource: 1, Record: 0, Err: errors.New("span too large")},
+	}
+	partialErr := NewPartialItems(err, items)
+	assert.EqualError(t, partialErr, "Partially consumed, 2 items rejected: invalid spans")
+	assert.True(t, IsPermanent(partialErr))
+
+	rejected, ok := Rejected(fmt.Errorf("%w", partialErr))
+	assert.True(t, ok)
+	assert.Equal(t, 2, rejected)
+	rejectedItems, ok := RejectedItems(fmt.Errorf("%w", partialErr))
+	assert.True(t, ok)
+	assert.Equal(t, items, rejectedItems)
+
+	// The items of the error cannot be modified.
+	items[0].Record = 5
+	rejectedItems[1].Record = 5
+	rejectedItems, _ = RejectedItems(partialErr)
+	assert.Equal(t, 1, rejectedItems[0].Record)
+	assert.Equal(t, 0, rejectedItems[1].Record)
+}
+
+func TestRejectedItemsNotListed(t *testing.T) {
+	_, ok := RejectedItems(nil)
+	assert.False(t, ok)
+	_, ok = RejectedItems(NewPartial(errors.New("testError"), 3))
+	assert.False(t, ok)
+}
+
+func TestRejectedJoined(t *testing.T) {
+	partialErr := NewPartial(errors.New("invalid spans"), 3)
+	_, ok := Rejected(errors.Join(partialErr, NewRetryableError(errors.New("unavailable"))))
+	assert.False(t, ok)
+	_, ok = RejectedItems(errors.Join(NewPartialItems(errors.New("invalid spans"), []RejectedItem{{Record: 1}})))
+	assert.False(t, ok)
+
+	// The reason of a partial error can be joined errors.
+	rejected, ok := Rejected(NewPartial(errors.Join(errors.New("a"), errors.New("b")), 2))
+	assert.True(t, ok)
+	assert.Equal(t, 2, rejected)
+}
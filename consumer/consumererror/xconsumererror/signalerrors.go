@@ -4,6 +4,7 @@
 package xconsumererror // import "go.opentelemetry.io/collector/consumer/consumererror/xconsumererror"
 
 import (
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumererror/internal"
 	"go.opentelemetry.io/collector/pdata/pprofile"
 )
@@ -23,3 +24,40 @@ func NewProfiles(err error, data pprofile.Profiles) error {
 		},
 	}
 }
+
+// ExtractProfiles returns a copy of the profiles of pd located by the items, with their resources and
+// scopes, and the dictionary of pd. The items not located in pd are ignored.
+func ExtractProfiles(pd pprofile.Profiles, items []consumererror.RejectedItem) pprofile.Profiles {
+	dest := pprofile.NewProfiles()
+	pd.Dictionary().CopyTo(dest.Dictionary())
+	var rpDest pprofile.ResourceProfiles
+	var spDest pprofile.ScopeProfiles
+	lastResource, lastScope := -1, -1
+	for _, item := range internal.SortedItems(items) {
+		if item.Resource >= pd.ResourceProfiles().Len() {
+			break
+		}
+		rp := pd.ResourceProfiles().At(item.Resource)
+		if item.Scope >= rp.ScopeProfiles().Len() {
+			continue
+		}
+		sp := rp.ScopeProfiles().At(item.Scope)
+		if item.Record >= sp.Profiles().Len() {
+			continue
+		}
+		if item.Resource != lastResource {
+			rpDest = dest.ResourceProfiles().AppendEmpty()
+			rp.Resource().CopyTo(rpDest.Resource())
+			rpDest.SetSchemaUrl(rp.SchemaUrl())
+			lastResource, lastScope = item.Resource, -1
+		}
+		if item.Scope != lastScope {
+			spDest = rpDest.ScopeProfiles().AppendEmpty()
+			sp.Scope().CopyTo(spDest.Scope())
+			spDest.SetSchemaUrl(sp.SchemaUrl())
+			lastScope = item.Scope
+		}
+		sp.Profiles().At(item.Record).CopyTo(spDest.Profiles().AppendEmpty())
+	}
+	return dest
+}
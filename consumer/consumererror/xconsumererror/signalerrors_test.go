@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
@@ -44,3 +45,14 @@ type testErrorType struct {
 func (t testErrorType) Error() string {
 	return ""
 }
+
+func TestExtractProfiles(t *testing.T) {
+	pd := testdata.GenerateProfiles(3)
+
+	extracted := ExtractProfiles(pd, []consumererror.RejectedItem{{Record: 2}, {Record: 5}, {Resource: 1}})
+	assert.Equal(t, pd.Dictionary(), extracted.Dictionary())
+	require.Equal(t, 1, extracted.ResourceProfiles().Len())
+	profiles := extracted.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles()
+	require.Equal(t, 1, profiles.Len())
+	assert.Equal(t, pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(2), profiles.At(0))
+}
@@ -11,6 +11,45 @@ type Capabilities struct {
 	// does not modify the data it MUST set this flag to false. If the processor creates
 	// a copy of the data before modifying then this flag can be safely set to false.
 	MutatesData bool
+
+	// Mutations narrows down the parts of the data modified by the processor when MutatesData
+	// is true, which allows the data to be copied more cheaply when it is shared with other
+	// consumers. The zero value means that any part of the data may be modified.
+	Mutations Mutations
+}
+
+// Mutations is a set of parts of the data modified by a consumer.
+type Mutations uint8
+
+const (
+	// MutatesResources indicates that the resources are modified, including their attributes.
+	MutatesResources Mutations = 1 << iota
+	// MutatesScopes indicates that the instrumentation scopes are modified, including their attributes.
+	MutatesScopes
+	// MutatesRecords indicates that the records (spans, data points, log records or profiles) are
+	// modified, including their attributes.
+	MutatesRecords
+	// MutatesStructure indicates that resources, scopes or records are added, removed or reordered.
+	MutatesStructure
+)
+
+// Merge returns the capabilities of a consumer passing the same data to both consumers.
+func (c Capabilities) Merge(other Capabilities) Capabilities {
+	switch {
+	case !other.MutatesData:
+		return c
+	case !c.MutatesData:
+		return other
+	case c.Mutations == 0 || other.Mutations == 0:
+		return Capabilities{MutatesData: true}
+	}
+	return Capabilities{MutatesData: true, Mutations: c.Mutations | other.Mutations}
+}
+
+// MutatesOnly returns whether the consumer only modifies the given parts of the data.
+// It returns true if the consumer does not modify the data.
+func (c Capabilities) MutatesOnly(parts Mutations) bool {
+	return !c.MutatesData || (c.Mutations != 0 && c.Mutations&^parts == 0)
 }
 
 type BaseConsumer interface {
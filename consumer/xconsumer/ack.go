@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconsumer // import "go.opentelemetry.io/collector/consumer/xconsumer"
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+type ackKey struct{}
+
+// Ack is the delivery confirmation of the data consumed with the context returned by ContextWithAck. It
+// is done once the receiver released it and all the components that took the data over asynchronously,
+// e.g. the exporters with a sending queue, are done with it.
+//
+// A receiver of an at-least-once source requests it to delay its own acknowledgement until the data
+// was exported:
+//
+//	ctx, ack := xconsumer.ContextWithAck(ctx)
+//	ack.Release(next.ConsumeLogs(ctx, ld))
+//	if err := ack.Wait(ctx); err != nil {
+//		// Do not acknowledge the data to the source.
+//	}
+type Ack struct {
+	mu      sync.Mutex
+	pending int
+	errs    []error
+	done    chan struct{}
+	release func(error)
+}
+
+// ContextWithAck returns a context requesting the delivery confirmation of the data consumed with it,
+// and the confirmation. The caller must call Release once the Consume call returned. If the ctx
+// already requests a confirmation, it is held until the new one is done.
+func ContextWithAck(ctx context.Context) (context.Context, *Ack) {
+	a := &Ack{done: make(chan struct{})}
+	a.release = a.hold()
+	if parent := HoldAck(ctx); parent != nil {
+		go func() {
+			<-a.done
+			parent(a.Err())
+		}()
+	}
+	return context.WithValue(ctx, ackKey{}, a), a
+}
+
+// HoldAck holds the delivery confirmation requested by the ctx, if any, until the returned function is
+// called with the result of the delivery, e.g. the error of the export. The components taking the data
+// over asynchronously, i.e. returning from a Consume call before they are done with it, call it before
+// returning. The function is nil if no confirmation is requested, and does nothing after its first call.
+func HoldAck(ctx context.Context) func(error) {
+	a, ok := ctx.Value(ackKey{}).(*Ack)
+	if !ok {
+		return nil
+	}
+	return a.hold()
+}
+
+func (a *Ack) hold() func(error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.pending == 0 && a.release != nil {
+		// The confirmation is already done: the data must not be taken over after the Consume call returned.
+		return func(error) {}
+	}
+	a.pending++
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { a.unhold(err) })
+	}
+}
+
+func (a *Ack) unhold(err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err != nil {
+		a.errs = append(a.errs, err)
+	}
+	a.pending--
+	if a.pending == 0 {
+		close(a.done)
+	}
+}
+
+// Release releases the hold of the receiver, with the error returned by the Consume call.
+func (a *Ack) Release(err error) {
+	a.release(err)
+}
+
+// Done returns a channel closed once the confirmation is done.
+func (a *Ack) Done() <-chan struct{} {
+	return a.done
+}
+
+// Err returns nil if the data was delivered, or the errors of the delivery otherwise. It is only
+// meaningful once Done is closed.
+func (a *Ack) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return errors.Join(a.errs...)
+}
+
+// Wait waits until the confirmation is done, and returns Err, or until the ctx is done, and returns
+// its error.
+func (a *Ack) Wait(ctx context.Context) error {
+	select {
+	case <-a.done:
+		return a.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAck(t *testing.T) {
+	ctx, ack := ContextWithAck(context.Background())
+	first := HoldAck(ctx)
+	second := HoldAck(ctx)
+	require.NotNil(t, first)
+	require.NotNil(t, second)
+
+	ack.Release(nil)
+	first(nil)
+	// Releasing a hold again does nothing.
+	first(errors.New("ignored"))
+	select {
+	case <-ack.Done():
+		t.Fatal("the confirmation is done while still held")
+	default:
+	}
+
+	second(nil)
+	require.NoError(t, ack.Wait(context.Background()))
+
+	// The data cannot be held after the confirmation is done.
+	HoldAck(ctx)(errors.New("ignored"))
+	require.NoError(t, ack.Err())
+}
+
+func TestAckErrors(t *testing.T) {
+	ctx, ack := ContextWithAck(context.Background())
+	hold := HoldAck(ctx)
+	ack.Release(errors.New("rejected"))
+	hold(errors.New("export failed"))
+	assert.EqualError(t, ack.Wait(context.Background()), "rejected\nexport failed")
+}
+
+func TestAckWaitCanceled(t *testing.T) {
+	ctx, ack := ContextWithAck(context.Background())
+	HoldAck(ctx)
+	ack.Release(nil)
+
+	waitCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, ack.Wait(waitCtx), context.Canceled)
+}
+
+func TestAckNested(t *testing.T) {
+	ctx, parent := ContextWithAck(context.Background())
+	nestedCtx, nested := ContextWithAck(ctx)
+	hold := HoldAck(nestedCtx)
+	nested.Release(nil)
+	parent.Release(nil)
+
+	select {
+	case <-parent.Done():
+		t.Fatal("the parent confirmation is done while the nested one is held")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	hold(errors.New("export failed"))
+	require.EqualError(t, nested.Wait(context.Background()), "export failed")
+	require.EqualError(t, parent.Wait(context.Background()), "export failed")
+}
+
+func TestHoldAckNotRequested(t *testing.T) {
+	assert.Nil(t, HoldAck(context.Background()))
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconsumer // import "go.opentelemetry.io/collector/consumer/xconsumer"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/internal"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Entry is one of the payloads of a batch, with the context it was received with. The context carries the
+// request-scoped metadata of the payload, e.g. the client.Info of its sender or the span that received it,
+// so that a batch-aware consumer does not need to merge the contexts of the payloads of the batch.
+type Entry[T any] struct {
+	// Context is the context the payload was received with.
+	Context context.Context
+	// Data is the payload.
+	Data T
+}
+
+// TracesBatch is a consumer.Traces that can also receive a batch of ptrace.Traces in one call.
+type TracesBatch interface {
+	consumer.Traces
+	// ConsumeTracesBatch processes the batch. The ctx is the context of the call, while the context of each
+	// entry is the one its traces were received with. After the function returns, the traces are no longer
+	// accessible, and accessing them is considered undefined behavior.
+	ConsumeTracesBatch(ctx context.Context, batch []Entry[ptrace.Traces]) error
+}
+
+// ConsumeTracesBatchFunc is a helper function that is similar to ConsumeTracesBatch.
+type ConsumeTracesBatchFunc func(ctx context.Context, batch []Entry[ptrace.Traces]) error
+
+// ConsumeTracesBatch calls f(ctx, batch).
+func (f ConsumeTracesBatchFunc) ConsumeTracesBatch(ctx context.Context, batch []Entry[ptrace.Traces]) error {
+	return f(ctx, batch)
+}
+
+// ConsumeTraces calls f with a batch of one entry.
+func (f ConsumeTracesBatchFunc) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return f(ctx, []Entry[ptrace.Traces]{{Context: ctx, Data: td}})
+}
+
+type baseTracesBatch struct {
+	*internal.BaseImpl
+	ConsumeTracesBatchFunc
+}
+
+// NewTracesBatch returns a TracesBatch configured with the provided options. Its ConsumeTraces calls
+// consume with a batch of one entry.
+func NewTracesBatch(consume ConsumeTracesBatchFunc, options ...consumer.Option) (TracesBatch, error) {
+	if consume == nil {
+		return nil, errNilFunc
+	}
+	return &baseTracesBatch{
+		BaseImpl:               internal.NewBaseImpl(options...),
+		ConsumeTracesBatchFunc: consume,
+	}, nil
+}
+
+// ConsumeTracesBatch sends the batch to next in one call if it is a TracesBatch. Otherwise, it calls
+// next.ConsumeTraces for each entry, with the context of the entry, and returns the joined errors.
+func ConsumeTracesBatch(ctx context.Context, next consumer.Traces, batch []Entry[ptrace.Traces]) error {
+	if bc, ok := next.(TracesBatch); ok {
+		return bc.ConsumeTracesBatch(ctx, batch)
+	}
+	return consumeEach(batch, next.ConsumeTraces)
+}
+
+// MetricsBatch is a consumer.Metrics that can also receive a batch of pmetric.Metrics in one call.
+type MetricsBatch interface {
+	consumer.Metrics
+	// ConsumeMetricsBatch processes the batch. The ctx is the context of the call, while the context of each
+	// entry is the one its metrics were received with. After the function returns, the metrics are no longer
+	// accessible, and accessing them is considered undefined behavior.
+	ConsumeMetricsBatch(ctx context.Context, batch []Entry[pmetric.Metrics]) error
+}
+
+// ConsumeMetricsBatchFunc is a helper function that is similar to ConsumeMetricsBatch.
+type ConsumeMetricsBatchFunc func(ctx context.Context, batch []Entry[pmetric.Metrics]) error
+
+// ConsumeMetricsBatch calls f(ctx, batch).
+func (f ConsumeMetricsBatchFunc) ConsumeMetricsBatch(ctx context.Context, batch []Entry[pmetric.Metrics]) error {
+	return f(ctx, batch)
+}
+
+// ConsumeMetrics calls f with a batch of one entry.
+func (f ConsumeMetricsBatchFunc) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return f(ctx, []Entry[pmetric.Metrics]{{Context: ctx, Data: md}})
+}
+
+type baseMetricsBatch struct {
+	*internal.BaseImpl
+	ConsumeMetricsBatchFunc
+}
+
+// NewMetricsBatch returns a MetricsBatch configured with the provided options. Its ConsumeMetrics calls
+// consume with a batch of one entry.
+func NewMetricsBatch(consume ConsumeMetricsBatchFunc, options ...consumer.Option) (MetricsBatch, error) {
+	if consume == nil {
+		return nil, errNilFunc
+	}
+	return &baseMetricsBatch{
+		BaseImpl:                internal.NewBaseImpl(options...),
+		ConsumeMetricsBatchFunc: consume,
+	}, nil
+}
+
+// ConsumeMetricsBatch sends the batch to next in one call if it is a MetricsBatch. Otherwise, it calls
+// next.ConsumeMetrics for each entry, with the context of the entry, and returns the joined errors.
+func ConsumeMetricsBatch(ctx context.Context, next consumer.Metrics, batch []Entry[pmetric.Metrics]) error {
+	if bc, ok := next.(MetricsBatch); ok {
+		return bc.ConsumeMetricsBatch(ctx, batch)
+	}
+	return consumeEach(batch, next.ConsumeMetrics)
+}
+
+// LogsBatch is a consumer.Logs that can also receive a batch of plog.Logs in one call.
+type LogsBatch interface {
+	consumer.Logs
+	// ConsumeLogsBatch processes the batch. The ctx is the context of the call, while the context of each
+	// entry is the one its logs were received with. After the function returns, the logs are no longer
+	// accessible, and accessing them is considered undefined behavior.
+	ConsumeLogsBatch(ctx context.Context, batch []Entry[plog.Logs]) error
+}
+
+// ConsumeLogsBatchFunc is a helper function that is similar to ConsumeLogsBatch.
+type ConsumeLogsBatchFunc func(ctx context.Context, batch []Entry[plog.Logs]) error
+
+// ConsumeLogsBatch calls f(ctx, batch).
+func (f ConsumeLogsBatchFunc) ConsumeLogsBatch(ctx context.Context, batch []Entry[plog.Logs]) error {
+	return f(ctx, batch)
+}
+
+// ConsumeLogs calls f with a batch of one entry.
+func (f ConsumeLogsBatchFunc) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return f(ctx, []Entry[plog.Logs]{{Context: ctx, Data: ld}})
+}
+
+type baseLogsBatch struct {
+	*internal.BaseImpl
+	ConsumeLogsBatchFunc
+}
+
+// NewLogsBatch returns a LogsBatch configured with the provided options. Its ConsumeLogs calls consume
+// with a batch of one entry.
+func NewLogsBatch(consume ConsumeLogsBatchFunc, options ...consumer.Option) (LogsBatch, error) {
+	if consume == nil {
+		return nil, errNilFunc
+	}
+	return &baseLogsBatch{
+		BaseImpl:             internal.NewBaseImpl(options...),
+		ConsumeLogsBatchFunc: consume,
+	}, nil
+}
+
+// ConsumeLogsBatch sends the batch to next in one call if it is a LogsBatch. Otherwise, it calls
+// next.ConsumeLogs for each entry, with the context of the entry, and returns the joined errors.
+func ConsumeLogsBatch(ctx context.Context, next consumer.Logs, batch []Entry[plog.Logs]) error {
+	if bc, ok := next.(LogsBatch); ok {
+		return bc.ConsumeLogsBatch(ctx, batch)
+	}
+	return consumeEach(batch, next.ConsumeLogs)
+}
+
+// ProfilesBatch is a Profiles that can also receive a batch of pprofile.Profiles in one call.
+type ProfilesBatch interface {
+	Profiles
+	// ConsumeProfilesBatch processes the batch. The ctx is the context of the call, while the context of each
+	// entry is the one its profiles were received with. After the function returns, the profiles are no
+	// longer accessible, and accessing them is considered undefined behavior.
+	ConsumeProfilesBatch(ctx context.Context, batch []Entry[pprofile.Profiles]) error
+}
+
+// ConsumeProfilesBatchFunc is a helper function that is similar to ConsumeProfilesBatch.
+type ConsumeProfilesBatchFunc func(ctx context.Context, batch []Entry[pprofile.Profiles]) error
+
+// ConsumeProfilesBatch calls f(ctx, batch).
+func (f ConsumeProfilesBatchFunc) ConsumeProfilesBatch(ctx context.Context, batch []Entry[pprofile.Profiles]) error {
+	return f(ctx, batch)
+}
+
+// ConsumeProfiles calls f with a batch of one entry.
+func (f ConsumeProfilesBatchFunc) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	return f(ctx, []Entry[pprofile.Profiles]{{Context: ctx, Data: pd}})
+}
+
+type baseProfilesBatch struct {
+	*internal.BaseImpl
+	ConsumeProfilesBatchFunc
+}
+
+// NewProfilesBatch returns a ProfilesBatch configured with the provided options. Its ConsumeProfiles calls
+// consume with a batch of one entry.
+func NewProfilesBatch(consume ConsumeProfilesBatchFunc, options ...consumer.Option) (ProfilesBatch, error) {
+	if consume == nil {
+		return nil, errNilFunc
+	}
+	return &baseProfilesBatch{
+		BaseImpl:                 internal.NewBaseImpl(options...),
+		ConsumeProfilesBatchFunc: consume,
+	}, nil
+}
+
+// ConsumeProfilesBatch sends the batch to next in one call if it is a ProfilesBatch. Otherwise, it calls
+// next.ConsumeProfiles for each entry, with the context of the entry, and returns the joined errors.
+func ConsumeProfilesBatch(ctx context.Context, next Profiles, batch []Entry[pprofile.Profiles]) error {
+	if bc, ok := next.(ProfilesBatch); ok {
+		return bc.ConsumeProfilesBatch(ctx, batch)
+	}
+	return consumeEach(batch, next.ConsumeProfiles)
+}
+
+func consumeEach[T any](batch []Entry[T], consume func(context.Context, T) error) error {
+	var errs []error
+	for _, entry := range batch {
+		errs = append(errs, consume(entry.Context, entry.Data))
+	}
+	return errors.Join(errs...)
+}
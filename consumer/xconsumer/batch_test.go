@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type senderKey struct{}
+
+func withSender(sender string) context.Context {
+	return context.WithValue(context.Background(), senderKey{}, sender)
+}
+
+func sender(ctx context.Context) string {
+	s, _ := ctx.Value(senderKey{}).(string)
+	return s
+}
+
+func TestNilFuncBatch(t *testing.T) {
+	_, err := NewTracesBatch(nil)
+	assert.Equal(t, errNilFunc, err)
+	_, err = NewMetricsBatch(nil)
+	assert.Equal(t, errNilFunc, err)
+	_, err = NewLogsBatch(nil)
+	assert.Equal(t, errNilFunc, err)
+	_, err = NewProfilesBatch(nil)
+	assert.Equal(t, errNilFunc, err)
+}
+
+func TestTracesBatch(t *testing.T) {
+	var senders []string
+	tb, err := NewTracesBatch(func(_ context.Context, batch []Entry[ptrace.Traces]) error {
+		for _, entry := range batch {
+			senders = append(senders, sender(entry.Context))
+		}
+		return nil
+	}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Capabilities{MutatesData: true}, tb.Capabilities())
+
+	require.NoError(t, tb.ConsumeTraces(withSender("a"), ptrace.NewTraces()))
+	require.NoError(t, ConsumeTracesBatch(context.Background(), tb, []Entry[ptrace.Traces]{
+		{Context: withSender("b"), Data: ptrace.NewTraces()},
+		{Context: withSender("c"), Data: ptrace.NewTraces()},
+	}))
+	assert.Equal(t, []string{"a", "b", "c"}, senders)
+}
+
+func TestMetricsBatch(t *testing.T) {
+	var senders []string
+	mb, err := NewMetricsBatch(func(_ context.Context, batch []Entry[pmetric.Metrics]) error {
+		for _, entry := range batch {
+			senders = append(senders, sender(entry.Context))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, consumer.Capabilities{MutatesData: false}, mb.Capabilities())
+
+	require.NoError(t, mb.ConsumeMetrics(withSender("a"), pmetric.NewMetrics()))
+	require.NoError(t, ConsumeMetricsBatch(context.Background(), mb, []Entry[pmetric.Metrics]{
+		{Context: withSender("b"), Data: pmetric.NewMetrics()},
+	}))
+	assert.Equal(t, []string{"a", "b"}, senders)
+}
+
+func TestLogsBatch(t *testing.T) {
+	want := errors.New("my_error")
+	lb, err := NewLogsBatch(func(context.Context, []Entry[plog.Logs]) error { return want })
+	require.NoError(t, err)
+	assert.Equal(t, want, lb.ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Equal(t, want, ConsumeLogsBatch(context.Background(), lb, []Entry[plog.Logs]{
+		{Context: context.Background(), Data: plog.NewLogs()},
+	}))
+}
+
+func TestProfilesBatch(t *testing.T) {
+	calls := 0
+	pb, err := NewProfilesBatch(func(_ context.Context, batch []Entry[pprofile.Profiles]) error {
+		calls++
+		assert.Len(t, batch, 2)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, ConsumeProfilesBatch(context.Background(), pb, []Entry[pprofile.Profiles]{
+		{Context: context.Background(), Data: pprofile.NewProfiles()},
+		{Context: context.Background(), Data: pprofile.NewProfiles()},
+	}))
+	assert.Equal(t, 1, calls)
+}
+
+func TestConsumeBatchFallback(t *testing.T) {
+	var senders []string
+	tc, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		senders = append(senders, sender(ctx))
+		if sender(ctx) == "b" {
+			return errors.New("rejected b")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = ConsumeTracesBatch(context.Background(), tc, []Entry[ptrace.Traces]{
+		{Context: withSender("a"), Data: ptrace.NewTraces()},
+		{Context: withSender("b"), Data: ptrace.NewTraces()},
+		{Context: withSender("c"), Data: ptrace.NewTraces()},
+	})
+	require.EqualError(t, err, "rejected b")
+	assert.Equal(t, []string{"a", "b", "c"}, senders)
+
+	mc, err := consumer.NewMetrics(func(context.Context, pmetric.Metrics) error { return nil })
+	require.NoError(t, err)
+	require.NoError(t, ConsumeMetricsBatch(context.Background(), mc, []Entry[pmetric.Metrics]{
+		{Context: context.Background(), Data: pmetric.NewMetrics()},
+	}))
+
+	lc, err := consumer.NewLogs(func(context.Context, plog.Logs) error { return nil })
+	require.NoError(t, err)
+	require.NoError(t, ConsumeLogsBatch(context.Background(), lc, nil))
+
+	pc, err := NewProfiles(func(context.Context, pprofile.Profiles) error { return nil })
+	require.NoError(t, err)
+	require.NoError(t, ConsumeProfilesBatch(context.Background(), pc, []Entry[pprofile.Profiles]{
+		{Context: context.Background(), Data: pprofile.NewProfiles()},
+	}))
+}
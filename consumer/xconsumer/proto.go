@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconsumer // import "go.opentelemetry.io/collector/consumer/xconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type protoKey struct{}
+
+// protoEncoding is the OTLP protobuf encoding of the data it was received as.
+type protoEncoding struct {
+	data any
+	buf  []byte
+}
+
+// ContextWithTracesProto returns a context carrying buf, the OTLP protobuf encoding td was received as,
+// so that the exporters sending td as OTLP protobuf can send buf as is instead of encoding td again.
+// td is marked read-only: the consumers mutating the data get a copy of it, which buf does not match.
+// A receiver only calls it when its next consumer does not mutate the data, as making these copies
+// costs more than encoding the data.
+func ContextWithTracesProto(ctx context.Context, td ptrace.Traces, buf []byte) context.Context {
+	td.MarkReadOnly()
+	return context.WithValue(ctx, protoKey{}, protoEncoding{data: td, buf: buf})
+}
+
+// TracesProto returns the OTLP protobuf encoding carried by ctx if it is the one of td.
+func TracesProto(ctx context.Context, td ptrace.Traces) ([]byte, bool) {
+	return protoOf(ctx, td)
+}
+
+// ContextWithMetricsProto is like ContextWithTracesProto for metrics.
+func ContextWithMetricsProto(ctx context.Context, md pmetric.Metrics, buf []byte) context.Context {
+	md.MarkReadOnly()
+	return context.WithValue(ctx, protoKey{}, protoEncoding{data: md, buf: buf})
+}
+
+// MetricsProto returns the OTLP protobuf encoding carried by ctx if it is the one of md.
+func MetricsProto(ctx context.Context, md pmetric.Metrics) ([]byte, bool) {
+	return protoOf(ctx, md)
+}
+
+// ContextWithLogsProto is like ContextWithTracesProto for logs.
+func ContextWithLogsProto(ctx context.Context, ld plog.Logs, buf []byte) context.Context {
+	ld.MarkReadOnly()
+	return context.WithValue(ctx, protoKey{}, protoEncoding{data: ld, buf: buf})
+}
+
+// LogsProto returns the OTLP protobuf encoding carried by ctx if it is the one of ld.
+func LogsProto(ctx context.Context, ld plog.Logs) ([]byte, bool) {
+	return protoOf(ctx, ld)
+}
+
+// protoOf returns the encoding carried by ctx if it was attached to the same data, which, being
+// read-only, cannot have been modified since.
+func protoOf(ctx context.Context, data any) ([]byte, bool) {
+	enc, ok := ctx.Value(protoKey{}).(protoEncoding)
+	if !ok || enc.data != data {
+		return nil, false
+	}
+	return enc.buf, true
+}
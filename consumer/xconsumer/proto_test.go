@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestTracesProto(t *testing.T) {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+
+	ctx := ContextWithTracesProto(context.Background(), td, buf)
+	assert.True(t, td.IsReadOnly())
+	got, ok := TracesProto(ctx, td)
+	require.True(t, ok)
+	assert.Equal(t, buf, got)
+
+	// A copy of the data, e.g. made for a mutating consumer, does not match the encoding.
+	cloned := ptrace.NewTraces()
+	td.CopyTo(cloned)
+	_, ok = TracesProto(ctx, cloned)
+	assert.False(t, ok)
+
+	_, ok = TracesProto(context.Background(), td)
+	assert.False(t, ok)
+}
+
+func TestMetricsProto(t *testing.T) {
+	md := pmetric.NewMetrics()
+	ctx := ContextWithMetricsProto(context.Background(), md, []byte{1})
+	assert.True(t, md.IsReadOnly())
+	got, ok := MetricsProto(ctx, md)
+	require.True(t, ok)
+	assert.Equal(t, []byte{1}, got)
+	_, ok = MetricsProto(ctx, pmetric.NewMetrics())
+	assert.False(t, ok)
+}
+
+func TestLogsProto(t *testing.T) {
+	ld := plog.NewLogs()
+	ctx := ContextWithLogsProto(context.Background(), ld, []byte{1})
+	assert.True(t, ld.IsReadOnly())
+	got, ok := LogsProto(ctx, ld)
+	require.True(t, ok)
+	assert.Equal(t, []byte{1}, got)
+	_, ok = LogsProto(ctx, plog.NewLogs())
+	assert.False(t, ok)
+	// The encoding of other data is not the one of the logs.
+	_, ok = TracesProto(ctx, ptrace.NewTraces())
+	assert.False(t, ok)
+}
@@ -8,6 +8,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/internal"
 	"go.opentelemetry.io/collector/exporter/internal/experr"
 	"go.opentelemetry.io/collector/pipeline"
 )
@@ -169,6 +170,9 @@ func (f *factory) CreateLogs(ctx context.Context, set Settings, cfg component.Co
 
 // WithTraces overrides the default "error not supported" implementation for Factory.CreateTraces and the default "undefined" stability level.
 func WithTraces(createTraces CreateTracesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.TracesEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.tracesStabilityLevel = sl
 		o.createTracesFunc = createTraces
@@ -177,6 +181,9 @@ func WithTraces(createTraces CreateTracesFunc, sl component.StabilityLevel) Fact
 
 // WithMetrics overrides the default "error not supported" implementation for Factory.CreateMetrics and the default "undefined" stability level.
 func WithMetrics(createMetrics CreateMetricsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.MetricsEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.metricsStabilityLevel = sl
 		o.createMetricsFunc = createMetrics
@@ -185,6 +192,9 @@ func WithMetrics(createMetrics CreateMetricsFunc, sl component.StabilityLevel) F
 
 // WithLogs overrides the default "error not supported" implementation for Factory.CreateLogs and the default "undefined" stability level.
 func WithLogs(createLogs CreateLogsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.LogsEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.logsStabilityLevel = sl
 		o.createLogsFunc = createLogs
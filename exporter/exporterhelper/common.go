@@ -37,6 +37,15 @@ func WithRetry(config configretry.BackOffConfig) Option {
 	return internal.WithRetry(config)
 }
 
+// WithPanicRecovery recovers the panics of the export functionality instead of crashing the collector.
+// A recovered panic is converted to a permanent error, which is not retried, counted by the
+// otelcol_exporter_panics metric and reported as a permanent error status event. The exporter is
+// then considered failed: the export functionality is no longer called, and all the subsequent
+// data is rejected with the same error.
+func WithPanicRecovery() Option {
+	return internal.WithPanicRecovery()
+}
+
 // WithCapabilities overrides the default Capabilities() function for a Consumer.
 // The default is non-mutable data.
 // TODO: Verify if we can change the default to be mutable as we do for processors.
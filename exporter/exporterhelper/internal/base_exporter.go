@@ -38,6 +38,7 @@ type BaseExporter struct {
 	// Most of the senders are optional, and initialized with a no-op path-through sender.
 	QueueSender sender.Sender[request.Request]
 	RetrySender sender.Sender[request.Request]
+	PanicSender sender.Sender[request.Request]
 
 	firstSender sender.Sender[request.Request]
 
@@ -48,6 +49,8 @@ type BaseExporter struct {
 
 	queueBatchSettings queuebatch.Settings[request.Request]
 	queueCfg           queuebatch.Config
+
+	recoverPanics bool
 }
 
 func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sender.SendFunc[request.Request], options ...Option) (*BaseExporter, error) {
@@ -65,6 +68,15 @@ func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sende
 	// Consumer Sender is always initialized.
 	be.firstSender = sender.NewSender(pusher)
 
+	// Recover only the panics of the export functionality, as for the timeout.
+	if be.recoverPanics {
+		var err error
+		if be.PanicSender, err = newPanicSender(set, be.firstSender); err != nil {
+			return nil, err
+		}
+		be.firstSender = be.PanicSender
+	}
+
 	// Next setup the timeout Sender since we want the timeout to control only the export functionality.
 	// Only initialize if not explicitly disabled.
 	if be.timeoutCfg.Timeout != 0 {
@@ -77,7 +89,7 @@ func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sende
 	}
 
 	var err error
-	be.firstSender, err = newObsReportSender(set, signal, be.firstSender)
+	be.firstSender, err = newObsReportSender(set, signal, be.queueCfg.Enabled && !be.queueCfg.WaitForResult, be.firstSender)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +133,12 @@ func (be *BaseExporter) Send(ctx context.Context, req request.Request) error {
 }
 
 func (be *BaseExporter) Start(ctx context.Context, host component.Host) error {
+	if be.PanicSender != nil {
+		if err := be.PanicSender.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+
 	// First start the wrapped exporter.
 	if err := be.StartFunc.Start(ctx, host); err != nil {
 		return err
@@ -151,6 +169,23 @@ func (be *BaseExporter) Shutdown(ctx context.Context) error {
 	return multierr.Append(err, be.ShutdownFunc.Shutdown(ctx))
 }
 
+// QueueFill returns the current size and the capacity of the sending queue, both zero if the queue is disabled.
+func (be *BaseExporter) QueueFill() (size, capacity int64) {
+	if qb, ok := be.QueueSender.(*queuebatch.QueueBatch); ok {
+		return qb.QueueFill()
+	}
+	return 0, 0
+}
+
+// BatchFill returns the size of the batches being accumulated by the sending queue and the minimum size
+// flushing them, both zero if batching is disabled.
+func (be *BaseExporter) BatchFill() (size, minSize int64) {
+	if qb, ok := be.QueueSender.(*queuebatch.QueueBatch); ok {
+		return qb.BatchFill()
+	}
+	return 0, 0
+}
+
 // WithStart overrides the default Start function for an exporter.
 // The default start function does nothing and always returns nil.
 func WithStart(start component.StartFunc) Option {
@@ -222,6 +257,15 @@ func WithQueueBatch(cfg queuebatch.Config, set queuebatch.Settings[request.Reque
 	}
 }
 
+// WithPanicRecovery recovers the panics of the export functionality instead of crashing the collector.
+// See exporterhelper.WithPanicRecovery.
+func WithPanicRecovery() Option {
+	return func(o *BaseExporter) error {
+		o.recoverPanics = true
+		return nil
+	}
+}
+
 // WithCapabilities overrides the default Capabilities() function for a Consumer.
 // The default is non-mutable data.
 // TODO: Verify if we can change the default to be mutable as we do for processors.
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +16,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
@@ -62,6 +64,30 @@ func TestQueueOptionsWithRequestExporter(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestBaseExporterFill(t *testing.T) {
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport)
+	require.NoError(t, err)
+	size, capacity := be.QueueFill()
+	assert.Zero(t, size)
+	assert.Zero(t, capacity)
+	size, minSize := be.BatchFill()
+	assert.Zero(t, size)
+	assert.Zero(t, minSize)
+
+	qCfg := NewDefaultQueueConfig()
+	qCfg.Batch = configoptional.Some(queuebatch.BatchConfig{FlushTimeout: time.Hour, Sizer: request.SizerTypeItems, MinSize: 10})
+	be, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport,
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	size, capacity = be.QueueFill()
+	assert.Equal(t, int64(1), size)
+	assert.Equal(t, int64(1000), capacity)
+	_, minSize = be.BatchFill()
+	assert.Equal(t, int64(10), minSize)
+}
+
 func TestBaseExporterLogging(t *testing.T) {
 	set := exportertest.NewNopSettings(exportertest.NopType)
 	logger, observed := observer.New(zap.DebugLevel)
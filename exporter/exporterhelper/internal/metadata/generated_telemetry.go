@@ -31,10 +31,13 @@ type TelemetryBuilder struct {
 	ExporterEnqueueFailedLogRecords   metric.Int64Counter
 	ExporterEnqueueFailedMetricPoints metric.Int64Counter
 	ExporterEnqueueFailedSpans        metric.Int64Counter
+	ExporterItems                     metric.Int64Counter
+	ExporterPanics                    metric.Int64Counter
 	ExporterQueueBatchSendSize        metric.Int64Histogram
 	ExporterQueueBatchSendSizeBytes   metric.Int64Histogram
 	ExporterQueueCapacity             metric.Int64ObservableGauge
 	ExporterQueueSize                 metric.Int64ObservableGauge
+	ExporterSendDuration              metric.Float64Histogram
 	ExporterSendFailedLogRecords      metric.Int64Counter
 	ExporterSendFailedMetricPoints    metric.Int64Counter
 	ExporterSendFailedSpans           metric.Int64Counter
@@ -130,6 +133,18 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterItems, err = builder.meter.Int64Counter(
+		"otelcol_exporter_items",
+		metric.WithDescription("Number of items passed to the exporter, by outcome (accepted, refused with a retryable error, failed with a permanent error, or dropped). [alpha]"),
+		metric.WithUnit("{items}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ExporterPanics, err = builder.meter.Int64Counter(
+		"otelcol_exporter_panics",
+		metric.WithDescription("Number of panics recovered while sending data. [alpha]"),
+		metric.WithUnit("{panics}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterQueueBatchSendSize, err = builder.meter.Int64Histogram(
 		"otelcol_exporter_queue_batch_send_size",
 		metric.WithDescription("Number of units in the batch"),
@@ -156,6 +171,13 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{batches}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterSendDuration, err = builder.meter.Float64Histogram(
+		"otelcol_exporter_send_duration",
+		metric.WithDescription("Duration of the attempts to send data to destination, including the retries. The measurements have exemplars linking them to the export spans when the internal traces are enabled. [alpha]"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries([]float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10, 30, 60}...),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterSendFailedLogRecords, err = builder.meter.Int64Counter(
 		"otelcol_exporter_send_failed_log_records",
 		metric.WithDescription("Number of log records in failed attempts to send to destination. [alpha]"),
@@ -5,6 +5,7 @@ package internal // import "go.opentelemetry.io/collector/exporter/exporterhelpe
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -12,11 +13,13 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/internal/telemetry"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -46,10 +49,16 @@ type obsReportSender[K request.Request] struct {
 	metricAttr      metric.MeasurementOption
 	itemsSentInst   metric.Int64Counter
 	itemsFailedInst metric.Int64Counter
-	next            sender.Sender[K]
+	durationInst    metric.Float64Histogram
+	itemsInst       metric.Int64Counter
+	signal          pipeline.Signal
+	// async is true if the sender is behind a sending queue not waiting for the result, so that the
+	// failures to send are not returned to the caller.
+	async bool
+	next  sender.Sender[K]
 }
 
-func newObsReportSender[K request.Request](set exporter.Settings, signal pipeline.Signal, next sender.Sender[K]) (sender.Sender[K], error) {
+func newObsReportSender[K request.Request](set exporter.Settings, signal pipeline.Signal, async bool, next sender.Sender[K]) (sender.Sender[K], error) {
 	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
 	if err != nil {
 		return nil, err
@@ -59,11 +68,15 @@ func newObsReportSender[K request.Request](set exporter.Settings, signal pipelin
 	expAttr := attribute.String(ExporterKey, idStr)
 
 	or := &obsReportSender[K]{
-		spanName:   ExporterKey + spanNameSep + idStr + spanNameSep + signal.String(),
-		tracer:     metadata.Tracer(set.TelemetrySettings),
-		spanAttrs:  trace.WithAttributes(expAttr, attribute.String(DataTypeKey, signal.String())),
-		metricAttr: metric.WithAttributeSet(attribute.NewSet(expAttr)),
-		next:       next,
+		spanName:     ExporterKey + spanNameSep + idStr + spanNameSep + signal.String(),
+		tracer:       metadata.Tracer(set.TelemetrySettings),
+		spanAttrs:    trace.WithAttributes(expAttr, attribute.String(DataTypeKey, signal.String())),
+		metricAttr:   metric.WithAttributeSet(attribute.NewSet(expAttr)),
+		durationInst: telemetryBuilder.ExporterSendDuration,
+		itemsInst:    telemetryBuilder.ExporterItems,
+		signal:       signal,
+		async:        async,
+		next:         next,
 	}
 
 	switch signal {
@@ -87,10 +100,11 @@ func (ors *obsReportSender[K]) Send(ctx context.Context, req K) error {
 	// Have to read the number of items before sending the request since the request can
 	// be modified by the downstream components like the batcher.
 	c := ors.startOp(ctx)
+	start := time.Now()
 	items := req.ItemsCount()
 	// Forward the data to the next consumer (this pusher is the next).
 	err := ors.next.Send(c, req)
-	ors.endOp(c, items, err)
+	ors.endOp(c, items, time.Since(start), err)
 	return err
 }
 
@@ -105,17 +119,24 @@ func (ors *obsReportSender[K]) startOp(ctx context.Context) context.Context {
 }
 
 // EndOp completes the export operation that was started with StartOp.
-func (ors *obsReportSender[K]) endOp(ctx context.Context, numLogRecords int, err error) {
+func (ors *obsReportSender[K]) endOp(ctx context.Context, numLogRecords int, duration time.Duration, err error) {
 	numSent, numFailedToSend := toNumItems(numLogRecords, err)
 
-	// No metrics recorded for profiles.
-	if ors.itemsSentInst != nil {
-		ors.itemsSentInst.Add(ctx, numSent, ors.metricAttr)
-	}
-	// No metrics recorded for profiles.
-	if ors.itemsFailedInst != nil {
-		ors.itemsFailedInst.Add(ctx, numFailedToSend, ors.metricAttr)
+	// The ctx has the span of the operation, so that the measurements link to it with an exemplar.
+	ors.durationInst.Record(ctx, duration.Seconds(), ors.metricAttr)
+
+	if telemetry.LegacyOutcomeMetricsGate.IsEnabled() {
+		// No metrics recorded for profiles.
+		if ors.itemsSentInst != nil {
+			ors.itemsSentInst.Add(ctx, numSent, ors.metricAttr)
+		}
+		// No metrics recorded for profiles.
+		if ors.itemsFailedInst != nil {
+			ors.itemsFailedInst.Add(ctx, numFailedToSend, ors.metricAttr)
+		}
 	}
+	telemetry.AddItems(ctx, ors.itemsInst, ors.signal, telemetry.OutcomeAccepted, int(numSent), ors.metricAttr)
+	telemetry.AddItems(ctx, ors.itemsInst, ors.signal, failedOutcome(err, ors.async), int(numFailedToSend), ors.metricAttr)
 
 	span := trace.SpanFromContext(ctx)
 	defer span.End()
@@ -132,8 +153,25 @@ func (ors *obsReportSender[K]) endOp(ctx context.Context, numLogRecords int, err
 }
 
 func toNumItems(numExportedItems int, err error) (int64, int64) {
+	if rejected, ok := consumererror.Rejected(err); ok {
+		// Only the rejected items failed to be sent.
+		rejected = min(rejected, numExportedItems)
+		return int64(numExportedItems - rejected), int64(rejected)
+	}
 	if err != nil {
 		return 0, int64(numExportedItems)
 	}
 	return int64(numExportedItems), 0
 }
+
+// failedOutcome returns the outcome of the items failed to be sent with the err.
+func failedOutcome(err error, async bool) string {
+	if async {
+		// The error is not returned to the caller, which already returned.
+		return telemetry.OutcomeDropped
+	}
+	if _, partial := consumererror.Rejected(err); partial || consumererror.IsPermanent(err) {
+		return telemetry.OutcomeFailed
+	}
+	return telemetry.OutcomeRefused
+}
@@ -6,6 +6,7 @@ package internal
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,11 +18,14 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/telemetry"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -42,6 +46,7 @@ func TestExportTraceDataOp(t *testing.T) {
 	obsrep, err := newObsReportSender(
 		exporter.Settings{ID: exporterID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
 		pipeline.SignalTraces,
+		false,
 		sender.NewSender(func(context.Context, request.Request) error { return exporterErr }),
 	)
 	require.NoError(t, err)
@@ -110,6 +115,7 @@ func TestExportMetricsOp(t *testing.T) {
 	obsrep, err := newObsReportSender(
 		exporter.Settings{ID: exporterID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
 		pipeline.SignalMetrics,
+		false,
 		sender.NewSender(func(context.Context, request.Request) error { return exporterErr }),
 	)
 	require.NoError(t, err)
@@ -178,6 +184,7 @@ func TestExportLogsOp(t *testing.T) {
 	obsrep, err := newObsReportSender(
 		exporter.Settings{ID: exporterID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
 		pipeline.SignalLogs,
+		false,
 		sender.NewSender(func(context.Context, request.Request) error { return exporterErr }),
 	)
 	require.NoError(t, err)
@@ -239,3 +246,100 @@ type testParams struct {
 	items int
 	err   error
 }
+
+func TestExportDurationExemplar(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	obsrep, err := newObsReportSender(
+		exporter.Settings{ID: exporterID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
+		pipeline.SignalLogs,
+		false,
+		sender.NewSender(func(context.Context, request.Request) error { return nil }),
+	)
+	require.NoError(t, err)
+	require.NoError(t, obsrep.Send(context.Background(), &requesttest.FakeRequest{Items: 3}))
+
+	spans := tt.SpanRecorder.Ended()
+	require.Len(t, spans, 1)
+	got, err := tt.GetMetric("otelcol_exporter_send_duration")
+	require.NoError(t, err)
+	dps := got.Data.(metricdata.Histogram[float64]).DataPoints
+	require.Len(t, dps, 1)
+	assert.Equal(t, attribute.NewSet(attribute.String("exporter", exporterID.String())), dps[0].Attributes)
+	assert.Equal(t, uint64(1), dps[0].Count)
+	require.Len(t, dps[0].Exemplars, 1)
+	traceID, spanID := spans[0].SpanContext().TraceID(), spans[0].SpanContext().SpanID()
+	assert.Equal(t, traceID[:], dps[0].Exemplars[0].TraceID)
+	assert.Equal(t, spanID[:], dps[0].Exemplars[0].SpanID)
+}
+
+func TestExportItemsByOutcome(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	var exporterErr error
+	set := exporter.Settings{ID: exporterID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()}
+	next := sender.NewSender(func(context.Context, request.Request) error { return exporterErr })
+	obsrep, err := newObsReportSender(set, pipeline.SignalLogs, false, next)
+	require.NoError(t, err)
+	asyncObsrep, err := newObsReportSender(set, pipeline.SignalLogs, true, next)
+	require.NoError(t, err)
+
+	for _, param := range []testParams{
+		{items: 7, err: nil},
+		{items: 5, err: errFake},
+		{items: 3, err: consumererror.NewPermanent(errFake)},
+		{items: 4, err: consumererror.NewPartial(errFake, 1)},
+	} {
+		exporterErr = param.err
+		require.Equal(t, param.err, obsrep.Send(context.Background(), &requesttest.FakeRequest{Items: param.items}))
+	}
+	exporterErr = errFake
+	require.ErrorIs(t, asyncObsrep.Send(context.Background(), &requesttest.FakeRequest{Items: 2}), errFake)
+
+	itemsAttrs := func(outcome string) attribute.Set {
+		return attribute.NewSet(
+			attribute.String("exporter", exporterID.String()),
+			attribute.String(telemetry.SignalKey, pipeline.SignalLogs.String()),
+			attribute.String(telemetry.OutcomeKey, outcome))
+	}
+	metadatatest.AssertEqualExporterItems(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{Attributes: itemsAttrs(telemetry.OutcomeAccepted), Value: 10},
+			{Attributes: itemsAttrs(telemetry.OutcomeRefused), Value: 5},
+			{Attributes: itemsAttrs(telemetry.OutcomeFailed), Value: 4},
+			{Attributes: itemsAttrs(telemetry.OutcomeDropped), Value: 2},
+		}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+}
+
+func TestLegacyOutcomeMetricsGateDisabled(t *testing.T) {
+	originalState := telemetry.LegacyOutcomeMetricsGate.IsEnabled()
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(telemetry.LegacyOutcomeMetricsGate.ID(), originalState))
+	})
+	require.NoError(t, featuregate.GlobalRegistry().Set(telemetry.LegacyOutcomeMetricsGate.ID(), false))
+
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	obsrep, err := newObsReportSender(
+		exporter.Settings{ID: exporterID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
+		pipeline.SignalTraces,
+		false,
+		sender.NewSender(func(context.Context, request.Request) error { return nil }),
+	)
+	require.NoError(t, err)
+	require.NoError(t, obsrep.Send(context.Background(), &requesttest.FakeRequest{Items: 3}))
+
+	_, err = tt.GetMetric("otelcol_exporter_sent_spans")
+	require.Error(t, err)
+	_, err = tt.GetMetric("otelcol_exporter_items")
+	require.NoError(t, err)
+}
+
+func TestToNumItemsPartial(t *testing.T) {
+	sent, failed := toNumItems(10, fmt.Errorf("export failed: %w", consumererror.NewPartial(errFake, 3)))
+	assert.Equal(t, int64(7), sent)
+	assert.Equal(t, int64(3), failed)
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/internal/panicguard"
+)
+
+// panicSender recovers the panics of the next sender and fails the exporter after the first one:
+// all the subsequent requests are rejected with the error of the first panic.
+type panicSender[T any] struct {
+	component.ShutdownFunc
+	*panicguard.Guard
+	next sender.Sender[T]
+}
+
+func newPanicSender[T any](set exporter.Settings, next sender.Sender[T]) (*panicSender[T], error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	metricAttr := metric.WithAttributeSet(attribute.NewSet(attribute.String(ExporterKey, set.ID.String())))
+	return &panicSender[T]{
+		Guard: panicguard.New(component.KindExporter, set.ID, set.Logger, func(ctx context.Context) {
+			telemetryBuilder.ExporterPanics.Add(ctx, 1, metricAttr)
+		}),
+		next: next,
+	}, nil
+}
+
+func (ps *panicSender[T]) Send(ctx context.Context, req T) error {
+	return ps.Call(ctx, func() error {
+		return ps.next.Send(ctx, req)
+	})
+}
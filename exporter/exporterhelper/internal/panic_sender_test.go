@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// statusHost records the status events reported by the exporter.
+type statusHost struct {
+	component.Host
+	events []*componentstatus.Event
+}
+
+func (h *statusHost) Report(event *componentstatus.Event) {
+	h.events = append(h.events, event)
+}
+
+func TestBaseExporterPanicRecovery(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	calls := 0
+	be, err := NewBaseExporter(
+		exporter.Settings{ID: exporterID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
+		pipeline.SignalTraces,
+		func(context.Context, request.Request) error {
+			calls++
+			panic("boom")
+		},
+		WithRetry(configretry.NewDefaultBackOffConfig()),
+		WithPanicRecovery(),
+	)
+	require.NoError(t, err)
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, be.Start(context.Background(), host))
+
+	err = be.Send(context.Background(), &requesttest.FakeRequest{Items: 2})
+	require.ErrorContains(t, err, `Permanent error: exporter "fakeExporter" panicked: boom`)
+	assert.True(t, consumererror.IsPermanent(err))
+	require.Len(t, host.events, 1)
+	assert.Equal(t, componentstatus.StatusPermanentError, host.events[0].Status())
+
+	// The permanent error is not retried, and the exporter failed: the export function is no longer called.
+	require.Error(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	assert.Equal(t, 1, calls)
+	assert.Len(t, host.events, 1)
+
+	metadatatest.AssertEqualExporterPanics(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(attribute.String("exporter", exporterID.String())),
+				Value:      1,
+			},
+		}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+	require.NoError(t, be.Shutdown(context.Background()))
+}
+
+func TestBaseExporterPanicNotRecoveredByDefault(t *testing.T) {
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalTraces,
+		func(context.Context, request.Request) error { panic("boom") })
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = be.Send(context.Background(), &requesttest.FakeRequest{Items: 2})
+	})
+	require.NoError(t, be.Shutdown(context.Background()))
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 )
 
@@ -123,6 +124,9 @@ func (mq *memoryQueue[T]) add(ctx context.Context, el T, elSize int64) (*blockin
 	done.reset(elSize, mq)
 
 	if !mq.waitForResult {
+		// The element is exported after this function returns, so hold its delivery confirmation, if
+		// requested by the receiver, until it is done.
+		done.ack = xconsumer.HoldAck(ctx)
 		// Prevent cancellation and deadline to propagate to the context stored in the queue.
 		// The grpc/http based receivers will cancel the request context after this function returns.
 		ctx = context.WithoutCancel(ctx)
@@ -159,6 +163,10 @@ func (mq *memoryQueue[T]) Read(context.Context) (context.Context, T, Done, bool)
 }
 
 func (mq *memoryQueue[T]) onDone(bd *blockingDone, err error) {
+	if bd.ack != nil {
+		bd.ack(err)
+		bd.ack = nil
+	}
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
 	mq.size -= bd.elSize
@@ -235,6 +243,7 @@ type blockingDone struct {
 	}
 	elSize int64
 	ch     chan error
+	ack    func(error)
 }
 
 func (bd *blockingDone) reset(elSize int64, queue interface{ onDone(*blockingDone, error) }) {
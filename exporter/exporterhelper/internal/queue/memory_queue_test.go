@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 )
 
@@ -96,6 +97,25 @@ func TestMemoryQueueDrainWhenShutdown(t *testing.T) {
 	require.NoError(t, q.Shutdown(context.Background()))
 }
 
+func TestMemoryQueueHoldsAck(t *testing.T) {
+	set := newSettings(request.SizerTypeItems, 7)
+	q := newMemoryQueue[intRequest](set)
+	require.NoError(t, q.Start(context.Background(), componenttest.NewNopHost()))
+
+	ctx, ack := xconsumer.ContextWithAck(context.Background())
+	ack.Release(q.Offer(ctx, 1))
+	select {
+	case <-ack.Done():
+		t.Fatal("the confirmation is done while the element is in the queue")
+	default:
+	}
+
+	err := errors.New("export failed")
+	assert.True(t, consume(q, func(context.Context, intRequest) error { return err }))
+	require.ErrorIs(t, ack.Wait(context.Background()), err)
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
 func TestMemoryQueueOfferInvalidSize(t *testing.T) {
 	set := newSettings(request.SizerTypeItems, 1)
 	q := newMemoryQueue[intRequest](set)
@@ -10,8 +10,10 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/internal/telemetry"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -29,6 +31,8 @@ type obsQueue[T request.Request] struct {
 	tb                      *metadata.TelemetryBuilder
 	metricAttr              metric.MeasurementOption
 	enqueueFailedInst       metric.Int64Counter
+	itemsInst               metric.Int64Counter
+	signal                  pipeline.Signal
 	queueBatchSizeInst      metric.Int64Histogram
 	queueBatchSizeBytesInst metric.Int64Histogram
 	tracer                  trace.Tracer
@@ -64,6 +68,8 @@ func newObsQueue[T request.Request](set Settings[T], delegate Queue[T]) (Queue[T
 		Queue:      delegate,
 		tb:         tb,
 		metricAttr: metric.WithAttributeSet(attribute.NewSet(exporterAttr)),
+		itemsInst:  tb.ExporterItems,
+		signal:     set.Signal,
 		tracer:     tracer,
 	}
 
@@ -99,9 +105,18 @@ func (or *obsQueue[T]) Offer(ctx context.Context, req T) error {
 	err := or.Queue.Offer(ctx, req)
 	span.End()
 
+	if err == nil {
+		return nil
+	}
 	// No metrics recorded for profiles, remove enqueueFailedInst check with nil when profiles metrics available.
-	if err != nil && or.enqueueFailedInst != nil {
+	if telemetry.LegacyOutcomeMetricsGate.IsEnabled() && or.enqueueFailedInst != nil {
 		or.enqueueFailedInst.Add(ctx, int64(numItems), or.metricAttr)
 	}
+	// The error is returned to the caller.
+	outcome := telemetry.OutcomeRefused
+	if consumererror.IsPermanent(err) {
+		outcome = telemetry.OutcomeFailed
+	}
+	telemetry.AddItems(ctx, or.itemsInst, or.signal, outcome, numItems, or.metricAttr)
 	return err
 }
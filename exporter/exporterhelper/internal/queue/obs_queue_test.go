@@ -15,10 +15,12 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/telemetry"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -99,6 +101,40 @@ func TestObsQueueLogsFailure(t *testing.T) {
 		}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
 }
 
+func TestObsQueueFailureItems(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	set := Settings[request.Request]{
+		Signal:    pipeline.SignalLogs,
+		ID:        exporterID,
+		Telemetry: tt.NewTelemetrySettings(),
+	}
+	te, err := newObsQueue[request.Request](set, newFakeQueue[request.Request](errors.New("my error"), 7, 9))
+	require.NoError(t, err)
+	require.Error(t, te.Offer(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	te, err = newObsQueue[request.Request](set, newFakeQueue[request.Request](consumererror.NewPermanent(errors.New("my error")), 7, 9))
+	require.NoError(t, err)
+	require.Error(t, te.Offer(context.Background(), &requesttest.FakeRequest{Items: 3}))
+	metadatatest.AssertEqualExporterItems(t, tt,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(
+					attribute.String(exporterKey, exporterID.String()),
+					attribute.String(telemetry.SignalKey, pipeline.SignalLogs.String()),
+					attribute.String(telemetry.OutcomeKey, telemetry.OutcomeRefused)),
+				Value: int64(2),
+			},
+			{
+				Attributes: attribute.NewSet(
+					attribute.String(exporterKey, exporterID.String()),
+					attribute.String(telemetry.SignalKey, pipeline.SignalLogs.String()),
+					attribute.String(telemetry.OutcomeKey, telemetry.OutcomeFailed)),
+				Value: int64(3),
+			},
+		}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+}
+
 func TestObsQueueTracesSizeCapacity(t *testing.T) {
 	tt := componenttest.NewTelemetry()
 	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
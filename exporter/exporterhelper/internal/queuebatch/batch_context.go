@@ -7,6 +7,8 @@ import (
 	"context"
 
 	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/client"
 )
 
 type traceContextKeyType int
@@ -37,3 +39,13 @@ func contextWithMergedLinks(mergedCtx, ctx1, ctx2 context.Context) context.Conte
 		batchSpanLinksKey,
 		append(parentsFromContext(ctx1), parentsFromContext(ctx2)...))
 }
+
+// contextWithMergedEnvelope returns a new context with the part of the client envelopes of ctx1 and ctx2
+// they agree on, so that the batched requests keep it.
+func contextWithMergedEnvelope(ctx1, ctx2 context.Context) context.Context {
+	envelope := client.EnvelopeFromContext(ctx1).Merge(client.EnvelopeFromContext(ctx2))
+	if envelope.IsEmpty() {
+		return context.Background() //nolint:contextcheck
+	}
+	return client.NewContext(context.Background(), envelope.Info()) //nolint:contextcheck
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component/componenttest"
 )
 
@@ -70,3 +71,18 @@ func TestMergedContext_GetValue(t *testing.T) {
 	batchContext := mergeContextHelper(ctx1, ctx2)
 	require.Equal(t, 2345, batchContext.Value(testTimestampKey))
 }
+
+func TestMergedContext_ClientEnvelope(t *testing.T) {
+	ctx1 := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"tenant": {"acme"}, "id": {"1"}}),
+	})
+	ctx2 := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"tenant": {"acme"}, "id": {"2"}}),
+	})
+	batchContext := contextWithMergedEnvelope(ctx1, ctx2)
+	md := client.FromContext(batchContext).Metadata
+	require.Equal(t, []string{"acme"}, md.Get("tenant"))
+	require.Nil(t, md.Get("id"))
+
+	require.Equal(t, client.Info{}, client.FromContext(contextWithMergedEnvelope(ctx1, context.Background())))
+}
@@ -87,3 +87,14 @@ func (mb *multiBatcher) Shutdown(ctx context.Context) error {
 	wg.Wait()
 	return nil
 }
+
+// fill returns the size of the batches being accumulated across the partitions, and the minimum
+// size flushing the batch of a partition.
+func (mb *multiBatcher) fill() (size, minSize int64) {
+	mb.shards.Range(func(_, shard any) bool {
+		s, _ := shard.(*partitionBatcher).fill()
+		size += s
+		return true
+	})
+	return size, mb.cfg.MinSize
+}
@@ -151,9 +151,11 @@ func (qb *partitionBatcher) Consume(ctx context.Context, req request.Request, do
 	qb.currentBatch.req = reqList[0]
 	qb.currentBatch.done = append(qb.currentBatch.done, done)
 
-	mergedCtx := context.Background() //nolint:contextcheck
+	var mergedCtx context.Context
 	if qb.mergeCtx != nil {
 		mergedCtx = qb.mergeCtx(qb.currentBatch.ctx, ctx)
+	} else {
+		mergedCtx = contextWithMergedEnvelope(qb.currentBatch.ctx, ctx)
 	}
 	qb.currentBatch.ctx = contextWithMergedLinks(mergedCtx, qb.currentBatch.ctx, ctx)
 
@@ -301,3 +303,13 @@ func (rcd *refCountDone) OnDone(err error) {
 		rcd.done.OnDone(rcd.err)
 	}
 }
+
+// fill returns the size of the batch being accumulated and the minimum size flushing it.
+func (qb *partitionBatcher) fill() (size, minSize int64) {
+	qb.currentBatchMu.Lock()
+	defer qb.currentBatchMu.Unlock()
+	if qb.currentBatch != nil {
+		size = qb.sizer.Sizeof(qb.currentBatch.req)
+	}
+	return size, qb.cfg.MinSize
+}
@@ -98,3 +98,17 @@ func (qs *QueueBatch) Shutdown(ctx context.Context) error {
 func (qs *QueueBatch) Send(ctx context.Context, req request.Request) error {
 	return qs.queue.Offer(ctx, req)
 }
+
+// QueueFill returns the current size and the capacity of the queue, in the units of its sizer.
+func (qs *QueueBatch) QueueFill() (size, capacity int64) {
+	return qs.queue.Size(), qs.queue.Capacity()
+}
+
+// BatchFill returns the size of the batches being accumulated and the minimum size flushing them,
+// in the units of the batch sizer, both zero if batching is disabled.
+func (qs *QueueBatch) BatchFill() (size, minSize int64) {
+	if b, ok := qs.batcher.(interface{ fill() (int64, int64) }); ok {
+		return b.fill()
+	}
+	return 0, 0
+}
@@ -119,6 +119,45 @@ func TestQueueBatchHappyPath(t *testing.T) {
 	require.NoError(t, qb.Shutdown(context.Background()))
 }
 
+func TestQueueBatchFill(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.QueueSize = 56
+	cfg.Batch.Get().FlushTimeout = time.Hour
+	sink := requesttest.NewSink()
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, sink.Export)
+	require.NoError(t, err)
+
+	for range 3 {
+		require.NoError(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 5}))
+	}
+	size, capacity := qb.QueueFill()
+	assert.Equal(t, int64(15), size)
+	assert.Equal(t, int64(56), capacity)
+	size, minSize := qb.BatchFill()
+	assert.Zero(t, size)
+	assert.Equal(t, int64(2048), minSize)
+
+	// The batch is only flushed on shutdown, its items are released from the queue once exported.
+	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		size, _ := qb.BatchFill()
+		assert.Equal(c, int64(15), size)
+	}, 1*time.Second, 10*time.Millisecond)
+	size, _ = qb.QueueFill()
+	assert.Equal(t, int64(15), size)
+	require.NoError(t, qb.Shutdown(context.Background()))
+	size, _ = qb.QueueFill()
+	assert.Zero(t, size)
+	assert.Equal(t, 15, sink.ItemsCount())
+
+	cfg.Batch = configoptional.Optional[BatchConfig]{}
+	qb, err = NewQueueBatch(newFakeRequestSettings(), cfg, sink.Export)
+	require.NoError(t, err)
+	size, minSize = qb.BatchFill()
+	assert.Zero(t, size)
+	assert.Zero(t, minSize)
+}
+
 func TestQueueBatchDifferentSizers(t *testing.T) {
 	// Set up the config so that the request is accepted in the queue
 	// because the bytes size is used for the queue,
@@ -111,6 +111,7 @@ func (rs *retrySender) Send(ctx context.Context, req request.Request) error {
 		if errors.As(err, &throttleErr) {
 			backoffDelay = max(backoffDelay, throttleErr.delay)
 		}
+		backoffDelay = max(backoffDelay, consumererror.RetryDelay(err))
 
 		nextRetryTime := time.Now().Add(backoffDelay)
 		if !maxElapsedTime.IsZero() && maxElapsedTime.Before(nextRetryTime) {
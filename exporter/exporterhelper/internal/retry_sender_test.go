@@ -93,6 +93,21 @@ func TestRetrySenderThrottleError(t *testing.T) {
 	require.NoError(t, rs.Shutdown(context.Background()))
 }
 
+func TestRetrySenderRetryDelayError(t *testing.T) {
+	rCfg := configretry.NewDefaultBackOffConfig()
+	rCfg.InitialInterval = 10 * time.Millisecond
+	sink := requesttest.NewSink()
+	rs := newRetrySender(rCfg, exportertest.NewNopSettings(exportertest.NopType), sender.NewSender(sink.Export))
+	require.NoError(t, rs.Start(context.Background(), componenttest.NewNopHost()))
+	start := time.Now()
+	sink.SetExportErr(consumererror.NewRetryableErrorWithDelay(errors.New("overloaded"), 100*time.Millisecond))
+	require.NoError(t, rs.Send(context.Background(), &requesttest.FakeRequest{Items: 5}))
+	// The initial backoff is 10ms, but because of the delay this should wait at least 100ms.
+	assert.Less(t, 100*time.Millisecond, time.Since(start))
+	assert.Equal(t, 5, sink.ItemsCount())
+	require.NoError(t, rs.Shutdown(context.Background()))
+}
+
 func TestRetrySenderWithContextTimeout(t *testing.T) {
 	const testTimeout = 10 * time.Second
 	rCfg := configretry.NewDefaultBackOffConfig()
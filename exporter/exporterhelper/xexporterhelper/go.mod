@@ -47,6 +47,7 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
 	go.opentelemetry.io/collector/client v1.43.0 // indirect
+	go.opentelemetry.io/collector/component/componentstatus v0.137.0 // indirect
 	go.opentelemetry.io/collector/config/configoptional v1.43.0 // indirect
 	go.opentelemetry.io/collector/config/configretry v1.43.0 // indirect
 	go.opentelemetry.io/collector/confmap v1.43.0 // indirect
@@ -54,6 +55,7 @@ require (
 	go.opentelemetry.io/collector/extension v1.43.0 // indirect
 	go.opentelemetry.io/collector/extension/xextension v0.137.0 // indirect
 	go.opentelemetry.io/collector/featuregate v1.43.0 // indirect
+	go.opentelemetry.io/collector/internal/panicguard v0.137.0 // indirect
 	go.opentelemetry.io/collector/internal/telemetry v0.137.0 // indirect
 	go.opentelemetry.io/collector/pipeline v1.43.0 // indirect
 	go.opentelemetry.io/collector/receiver v1.43.0 // indirect
@@ -133,3 +135,7 @@ replace go.opentelemetry.io/collector/confmap => ../../../confmap
 replace go.opentelemetry.io/collector/confmap/xconfmap => ../../../confmap/xconfmap
 
 replace go.opentelemetry.io/collector/exporter/exporterhelper => ../
+
+replace go.opentelemetry.io/collector/component/componentstatus => ../../../component/componentstatus
+
+replace go.opentelemetry.io/collector/internal/panicguard => ../../../internal/panicguard
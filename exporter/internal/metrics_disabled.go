@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build otelcol_no_metrics
+
+package internal // import "go.opentelemetry.io/collector/exporter/internal"
+
+// MetricsEnabled is false when the distribution is built with the otelcol_no_metrics build tag: the factory
+// options of the metrics are then no-ops, which lets the linker leave out the metrics code of the components.
+const MetricsEnabled = false
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !otelcol_no_traces
+
+package internal // import "go.opentelemetry.io/collector/exporter/internal"
+
+// TracesEnabled reports whether the traces of the components are built, see traces_disabled.go.
+const TracesEnabled = true
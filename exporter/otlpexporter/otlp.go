@@ -18,8 +18,10 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/grpcraw"
 	"go.opentelemetry.io/collector/internal/statusutil"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
@@ -43,6 +45,8 @@ type baseExporter struct {
 	clientConn      *grpc.ClientConn
 	metadata        metadata.MD
 	callOptions     []grpc.CallOption
+	// protoCallOptions are the callOptions of the requests sent as their OTLP protobuf encoding.
+	protoCallOptions []grpc.CallOption
 
 	settings component.TelemetrySettings
 
@@ -78,6 +82,7 @@ func (e *baseExporter) start(ctx context.Context, host component.Host) (err erro
 	e.callOptions = []grpc.CallOption{
 		grpc.WaitForReady(e.config.ClientConfig.WaitForReady),
 	}
+	e.protoCallOptions = append(e.callOptions[:len(e.callOptions):len(e.callOptions)], grpc.ForceCodecV2(grpcraw.Codec{}))
 
 	return err
 }
@@ -90,8 +95,14 @@ func (e *baseExporter) shutdown(context.Context) error {
 }
 
 func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
-	req := ptraceotlp.NewExportRequestFromTraces(td)
-	resp, respErr := e.traceExporter.Export(ctx, req, e.callOptions...)
+	var resp ptraceotlp.ExportResponse
+	var respErr error
+	if buf, ok := xconsumer.TracesProto(ctx, td); ok {
+		resp = ptraceotlp.NewExportResponse()
+		respErr = e.exportProto(ctx, grpcraw.TracesExportMethod, buf, resp.UnmarshalProto)
+	} else {
+		resp, respErr = e.traceExporter.Export(ctx, ptraceotlp.NewExportRequestFromTraces(td), e.callOptions...)
+	}
 	if err := processError(respErr); err != nil {
 		return err
 	}
@@ -106,8 +117,14 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 }
 
 func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
-	req := pmetricotlp.NewExportRequestFromMetrics(md)
-	resp, respErr := e.metricExporter.Export(ctx, req, e.callOptions...)
+	var resp pmetricotlp.ExportResponse
+	var respErr error
+	if buf, ok := xconsumer.MetricsProto(ctx, md); ok {
+		resp = pmetricotlp.NewExportResponse()
+		respErr = e.exportProto(ctx, grpcraw.MetricsExportMethod, buf, resp.UnmarshalProto)
+	} else {
+		resp, respErr = e.metricExporter.Export(ctx, pmetricotlp.NewExportRequestFromMetrics(md), e.callOptions...)
+	}
 	if err := processError(respErr); err != nil {
 		return err
 	}
@@ -122,8 +139,14 @@ func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) erro
 }
 
 func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
-	req := plogotlp.NewExportRequestFromLogs(ld)
-	resp, respErr := e.logExporter.Export(ctx, req, e.callOptions...)
+	var resp plogotlp.ExportResponse
+	var respErr error
+	if buf, ok := xconsumer.LogsProto(ctx, ld); ok {
+		resp = plogotlp.NewExportResponse()
+		respErr = e.exportProto(ctx, grpcraw.LogsExportMethod, buf, resp.UnmarshalProto)
+	} else {
+		resp, respErr = e.logExporter.Export(ctx, plogotlp.NewExportRequestFromLogs(ld), e.callOptions...)
+	}
 	if err := processError(respErr); err != nil {
 		return err
 	}
@@ -153,6 +176,16 @@ func (e *baseExporter) pushProfiles(ctx context.Context, td pprofile.Profiles) e
 	return nil
 }
 
+// exportProto sends buf, the OTLP protobuf encoding of the data as received, to the method as is instead
+// of encoding the data again, and decodes the response with unmarshalResp.
+func (e *baseExporter) exportProto(ctx context.Context, method string, buf []byte, unmarshalResp func([]byte) error) error {
+	var resp grpcraw.Message
+	if err := e.clientConn.Invoke(ctx, method, &grpcraw.Message{Data: buf}, &resp, e.protoCallOptions...); err != nil {
+		return err
+	}
+	return unmarshalResp(resp.Data)
+}
+
 func processError(err error) error {
 	if err == nil {
 		// Request is successful, we are done.
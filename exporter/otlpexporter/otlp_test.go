@@ -29,6 +29,8 @@ import (
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/xexporter"
@@ -395,6 +397,56 @@ func TestSendTraces(t *testing.T) {
 	assert.Contains(t, observed.FilterLevelExact(zap.WarnLevel).All()[0].Message, "Partial success")
 }
 
+func TestSendTracesProto(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:")
+	require.NoError(t, err, "Failed to find an available address to run the gRPC server: %v", err)
+	rcv, _ := otlpTracesReceiverOnGRPCServer(ln, false)
+	defer rcv.srv.GracefulStop()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.QueueConfig.Enabled = false
+	cfg.ClientConfig = configgrpc.ClientConfig{
+		Endpoint: ln.Addr().String(),
+		TLS: configtls.ClientConfig{
+			Insecure: true,
+		},
+	}
+	set := exportertest.NewNopSettings(factory.Type())
+	logger, observed := observer.New(zap.DebugLevel)
+	set.Logger = zap.New(logger)
+	exp, err := factory.CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	// The encoding passed through is sent as is: the receiver gets 3 spans instead of the 2 of td.
+	expected := testdata.GenerateTraces(3)
+	buf, err := ptraceotlp.NewExportRequestFromTraces(expected).MarshalProto()
+	require.NoError(t, err)
+	td := testdata.GenerateTraces(2)
+	require.NoError(t, exp.ConsumeTraces(xconsumer.ContextWithTracesProto(context.Background(), td, buf), td))
+	assert.EqualValues(t, 3, rcv.totalItems.Load())
+	assert.Equal(t, expected, rcv.getLastRequest())
+
+	// The response is decoded as usual.
+	rcv.setExportResponse(func() ptraceotlp.ExportResponse {
+		response := ptraceotlp.NewExportResponse()
+		response.PartialSuccess().SetErrorMessage("Some spans were not ingested")
+		response.PartialSuccess().SetRejectedSpans(1)
+		return response
+	})
+	require.NoError(t, exp.ConsumeTraces(xconsumer.ContextWithTracesProto(context.Background(), td, buf), td))
+	assert.Len(t, observed.FilterLevelExact(zap.WarnLevel).All(), 1)
+
+	rcv.setExportError(status.Error(codes.InvalidArgument, "invalid"))
+	err = exp.ConsumeTraces(xconsumer.ContextWithTracesProto(context.Background(), td, buf), td)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+}
+
 func TestSendTracesWhenEndpointHasHttpScheme(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -21,6 +21,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/internal/statusutil"
@@ -99,7 +100,11 @@ func (e *baseExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 	case EncodingJSON:
 		request, err = tr.MarshalJSON()
 	case EncodingProto:
-		request, err = tr.MarshalProto()
+		if buf, ok := xconsumer.TracesProto(ctx, td); ok {
+			request = buf
+		} else {
+			request, err = tr.MarshalProto()
+		}
 	default:
 		err = fmt.Errorf("invalid encoding: %s", e.config.Encoding)
 	}
@@ -120,7 +125,11 @@ func (e *baseExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) erro
 	case EncodingJSON:
 		request, err = tr.MarshalJSON()
 	case EncodingProto:
-		request, err = tr.MarshalProto()
+		if buf, ok := xconsumer.MetricsProto(ctx, md); ok {
+			request = buf
+		} else {
+			request, err = tr.MarshalProto()
+		}
 	default:
 		err = fmt.Errorf("invalid encoding: %s", e.config.Encoding)
 	}
@@ -140,7 +149,11 @@ func (e *baseExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 	case EncodingJSON:
 		request, err = tr.MarshalJSON()
 	case EncodingProto:
-		request, err = tr.MarshalProto()
+		if buf, ok := xconsumer.LogsProto(ctx, ld); ok {
+			request = buf
+		} else {
+			request, err = tr.MarshalProto()
+		}
 	default:
 		err = fmt.Errorf("invalid encoding: %s", e.config.Encoding)
 	}
@@ -27,6 +27,7 @@ import (
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/otlphttpexporter/internal/metadata"
@@ -38,6 +39,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
 const (
@@ -1160,6 +1162,56 @@ func TestEncoding(t *testing.T) {
 	})
 }
 
+func TestEncodingPassthrough(t *testing.T) {
+	// The encoding passed through differs from the one of td to tell them apart.
+	buf, err := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(3)).MarshalProto()
+	require.NoError(t, err)
+	td := testdata.GenerateTraces(2)
+	tdJSON, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalJSON()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		encoding EncodingType
+		expected []byte
+	}{
+		{
+			name:     "proto_encoding",
+			encoding: EncodingProto,
+			expected: buf,
+		},
+		{
+			name:     "json_encoding",
+			encoding: EncodingJSON,
+			expected: tdJSON,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := createBackend("/v1/traces", func(writer http.ResponseWriter, request *http.Request) {
+				body, err := io.ReadAll(request.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, body)
+				writer.WriteHeader(http.StatusOK)
+			})
+			defer srv.Close()
+
+			cfg := &Config{
+				TracesEndpoint: srv.URL + "/v1/traces",
+				Encoding:       tt.encoding,
+			}
+			exp, err := createTraces(context.Background(), exportertest.NewNopSettings(metadata.Type), cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			t.Cleanup(func() {
+				require.NoError(t, exp.Shutdown(context.Background()))
+			})
+
+			require.NoError(t, exp.ConsumeTraces(xconsumer.ContextWithTracesProto(context.Background(), td, buf), td))
+		})
+	}
+}
+
 func createBackend(endpoint string, handler func(writer http.ResponseWriter, request *http.Request)) *httptest.Server {
 	mux := http.NewServeMux()
 	mux.HandleFunc(endpoint, handler)
@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/internal"
 	"go.opentelemetry.io/collector/exporter/internal/experr"
 	"go.opentelemetry.io/collector/pipeline"
 )
@@ -44,6 +45,9 @@ func (f factoryOptionFunc) applyOption(o *factoryOpts) {
 	f(o)
 }
 
+// factoryOpts collects the options of the base factory. Those options are created by the With functions,
+// and not by the closures they return, for the closures not to keep the create functions of the signals
+// left out of the distribution.
 type factoryOpts struct {
 	opts []exporter.FactoryOption
 	*factory
@@ -54,27 +58,33 @@ type CreateProfilesFunc func(context.Context, exporter.Settings, component.Confi
 
 // WithTraces overrides the default "error not supported" implementation for CreateTraces and the default "undefined" stability level.
 func WithTraces(createTraces exporter.CreateTracesFunc, sl component.StabilityLevel) FactoryOption {
+	opt := exporter.WithTraces(createTraces, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, exporter.WithTraces(createTraces, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithMetrics overrides the default "error not supported" implementation for CreateMetrics and the default "undefined" stability level.
 func WithMetrics(createMetrics exporter.CreateMetricsFunc, sl component.StabilityLevel) FactoryOption {
+	opt := exporter.WithMetrics(createMetrics, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, exporter.WithMetrics(createMetrics, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithLogs overrides the default "error not supported" implementation for CreateLogs and the default "undefined" stability level.
 func WithLogs(createLogs exporter.CreateLogsFunc, sl component.StabilityLevel) FactoryOption {
+	opt := exporter.WithLogs(createLogs, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, exporter.WithLogs(createLogs, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithProfiles overrides the default "error not supported" implementation for CreateProfilesExporter and the default "undefined" stability level.
 func WithProfiles(createProfiles CreateProfilesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.ProfilesEnabled {
+		return factoryOptionFunc(func(*factoryOpts) {})
+	}
 	return factoryOptionFunc(func(o *factoryOpts) {
 		o.profilesStabilityLevel = sl
 		o.createProfilesFunc = createProfiles
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package extensionauthhelper // import "go.opentelemetry.io/collector/extension/extensionauth/extensionauthhelper"
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+// credentialsCache is an LRU cache of the authentication data of the verified credentials, keyed by
+// their hash so that the credentials themselves are not kept. A nil cache caches nothing.
+type credentialsCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]*list.Element
+	// The entries from the most to the least recently used.
+	lru *list.List
+}
+
+type cacheEntry struct {
+	key      [sha256.Size]byte
+	authData client.AuthData
+	expires  time.Time
+}
+
+func newCredentialsCache(ttl time.Duration, maxEntries int) *credentialsCache {
+	return &credentialsCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[[sha256.Size]byte]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// get returns the authentication data of the credentials, false if they are not cached or expired.
+func (c *credentialsCache) get(credentials string, now time.Time) (client.AuthData, bool) {
+	if c == nil {
+		return nil, false
+	}
+	key := sha256.Sum256([]byte(credentials))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !now.Before(entry.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.authData, true
+}
+
+// put caches the authentication data of the credentials, evicting the least recently used entry if full.
+func (c *credentialsCache) put(credentials string, authData client.AuthData, now time.Time) {
+	if c == nil {
+		return
+	}
+	key := sha256.Sum256([]byte(credentials))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.lru.Remove(elem)
+	} else if c.lru.Len() >= c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key: key, authData: authData, expires: now.Add(c.ttl)})
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package extensionauthhelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialsCacheExpiry(t *testing.T) {
+	c := newCredentialsCache(time.Minute, 10)
+	now := time.Now()
+	c.put("token", subjectAuthData("user"), now)
+
+	authData, ok := c.get("token", now.Add(30*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, subjectAuthData("user"), authData)
+
+	_, ok = c.get("token", now.Add(time.Minute))
+	assert.False(t, ok)
+	assert.Empty(t, c.entries)
+}
+
+func TestCredentialsCacheEviction(t *testing.T) {
+	c := newCredentialsCache(time.Minute, 2)
+	now := time.Now()
+	c.put("a", subjectAuthData("a"), now)
+	c.put("b", subjectAuthData("b"), now)
+	// Reading "a" makes "b" the least recently used entry.
+	_, ok := c.get("a", now)
+	assert.True(t, ok)
+	c.put("c", subjectAuthData("c"), now)
+
+	_, ok = c.get("b", now)
+	assert.False(t, ok)
+	for _, credentials := range []string{"a", "c"} {
+		authData, ok := c.get(credentials, now)
+		assert.True(t, ok)
+		assert.Equal(t, subjectAuthData(credentials), authData)
+	}
+	assert.Equal(t, 2, c.lru.Len())
+}
+
+func TestNilCredentialsCache(t *testing.T) {
+	var c *credentialsCache
+	c.put("token", subjectAuthData("user"), time.Now())
+	_, ok := c.get("token", time.Now())
+	assert.False(t, ok)
+}
@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package extensionauthhelper implements the common plumbing of the server authenticators: extracting
+// the credentials from the request metadata, caching the verified ones, recording the outcome of the
+// authentications and adding the identity of the client to its client.Info. Authenticator extensions
+// only implement a VerifyFunc.
+package extensionauthhelper // import "go.opentelemetry.io/collector/extension/extensionauth/extensionauthhelper"
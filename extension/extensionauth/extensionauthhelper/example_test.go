@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package extensionauthhelper_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/extension/extensionauth"
+	"go.opentelemetry.io/collector/extension/extensionauth/extensionauthhelper"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+// tenantAuthData identifies the tenant owning an API key.
+type tenantAuthData string
+
+func (t tenantAuthData) GetAttribute(name string) any {
+	if name == "tenant" {
+		return string(t)
+	}
+	return nil
+}
+
+func (tenantAuthData) GetAttributeNames() []string {
+	return []string{"tenant"}
+}
+
+// Example demonstrates a server authenticator verifying bearer tokens against a static list.
+func Example() {
+	tenants := map[string]string{"secret": "acme"}
+	verify := func(_ context.Context, token string) (client.AuthData, error) {
+		tenant, ok := tenants[token]
+		if !ok {
+			return nil, errors.New("unknown token")
+		}
+		return tenantAuthData(tenant), nil
+	}
+
+	ext, err := extensionauthhelper.NewServer(extensiontest.NewNopSettings(extensiontest.NopType), verify,
+		extensionauthhelper.WithScheme("Bearer"),
+		extensionauthhelper.WithCache(time.Minute, 1000))
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, err := ext.(extensionauth.Server).Authenticate(context.Background(), map[string][]string{"Authorization": {"Bearer secret"}})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(client.FromContext(ctx).Auth.GetAttribute("tenant"))
+
+	// Output:
+	// acme
+}
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package extensionauthhelper // import "go.opentelemetry.io/collector/extension/extensionauth/extensionauthhelper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensionauth"
+	"go.opentelemetry.io/collector/extension/extensionauth/extensionauthhelper/internal/metadata"
+)
+
+const defaultHeader = "authorization"
+
+// ErrMissingCredentials is returned by the server authenticators when the request has no credentials.
+var ErrMissingCredentials = errors.New("missing credentials")
+
+var errWrongScheme = errors.New("wrong authentication scheme")
+
+// VerifyFunc verifies the credentials extracted from the request, and returns the authentication data of the
+// client they identify. It returns an error if the credentials are invalid. The deadline and cancellation of
+// the context must be respected.
+type VerifyFunc func(ctx context.Context, credentials string) (client.AuthData, error)
+
+// Option apply changes to serverSettings.
+type Option interface {
+	apply(*serverSettings)
+}
+
+type optionFunc func(*serverSettings)
+
+func (of optionFunc) apply(s *serverSettings) {
+	of(s)
+}
+
+// WithStart overrides the default Start function for a server authenticator.
+// The default start function does nothing and always returns nil.
+func WithStart(start component.StartFunc) Option {
+	return optionFunc(func(s *serverSettings) {
+		s.StartFunc = start
+	})
+}
+
+// WithShutdown overrides the default Shutdown function for a server authenticator.
+// The default shutdown function does nothing and always returns nil.
+func WithShutdown(shutdown component.ShutdownFunc) Option {
+	return optionFunc(func(s *serverSettings) {
+		s.ShutdownFunc = shutdown
+	})
+}
+
+// WithHeader overrides the header the credentials are extracted from, matched case-insensitively.
+// The default header is "authorization".
+func WithHeader(header string) Option {
+	return optionFunc(func(s *serverSettings) {
+		s.header = header
+	})
+}
+
+// WithScheme sets the authentication scheme of the credentials, e.g. "Bearer", matched case-insensitively
+// and stripped from the credentials passed to the VerifyFunc. The credentials with another scheme are refused.
+// By default, the whole value of the header is passed to the VerifyFunc.
+func WithScheme(scheme string) Option {
+	return optionFunc(func(s *serverSettings) {
+		s.scheme = scheme
+	})
+}
+
+// WithCache caches the authentication data of the verified credentials for the ttl, so that the VerifyFunc
+// is only called once per ttl for the same credentials. At most maxEntries credentials are cached, the least
+// recently used ones being evicted first. The refused credentials are not cached.
+func WithCache(ttl time.Duration, maxEntries int) Option {
+	return optionFunc(func(s *serverSettings) {
+		s.cacheTTL = ttl
+		s.cacheMaxEntries = maxEntries
+	})
+}
+
+type serverSettings struct {
+	component.StartFunc
+	component.ShutdownFunc
+	header          string
+	scheme          string
+	cacheTTL        time.Duration
+	cacheMaxEntries int
+}
+
+var _ extensionauth.Server = (*server)(nil)
+
+type server struct {
+	component.StartFunc
+	component.ShutdownFunc
+	header    string
+	scheme    string
+	verify    VerifyFunc
+	cache     *credentialsCache
+	telemetry *metadata.TelemetryBuilder
+}
+
+// NewServer creates an extension.Extension implementing extensionauth.Server, which extracts the credentials
+// from the request metadata and verifies them with the given VerifyFunc. The authentication data it returns
+// is set as the client.Info.Auth of the authenticated requests.
+func NewServer(set extension.Settings, verify VerifyFunc, options ...Option) (extension.Extension, error) {
+	if verify == nil {
+		return nil, errors.New("nil verify")
+	}
+	ss := &serverSettings{header: defaultHeader}
+	for _, op := range options {
+		op.apply(ss)
+	}
+	if ss.cacheTTL < 0 || ss.cacheMaxEntries < 0 {
+		return nil, errors.New("the cache ttl and max entries must not be negative")
+	}
+
+	telemetry, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	s := &server{
+		StartFunc:    ss.StartFunc,
+		ShutdownFunc: ss.ShutdownFunc,
+		header:       ss.header,
+		scheme:       ss.scheme,
+		verify:       verify,
+		telemetry:    telemetry,
+	}
+	if ss.cacheTTL > 0 && ss.cacheMaxEntries > 0 {
+		s.cache = newCredentialsCache(ss.cacheTTL, ss.cacheMaxEntries)
+	}
+	return s, nil
+}
+
+func (s *server) Authenticate(ctx context.Context, sources map[string][]string) (context.Context, error) {
+	credentials, err := s.credentials(sources)
+	if err != nil {
+		s.telemetry.AuthServerRefusedRequests.Add(ctx, 1)
+		return ctx, err
+	}
+
+	authData, ok := s.cache.get(credentials, time.Now())
+	if ok {
+		s.telemetry.AuthServerCacheHits.Add(ctx, 1)
+	} else {
+		if authData, err = s.verify(ctx, credentials); err != nil {
+			s.telemetry.AuthServerRefusedRequests.Add(ctx, 1)
+			return ctx, fmt.Errorf("invalid credentials: %w", err)
+		}
+		s.cache.put(credentials, authData, time.Now())
+	}
+	s.telemetry.AuthServerAcceptedRequests.Add(ctx, 1)
+
+	info := client.FromContext(ctx)
+	info.Auth = authData
+	return client.NewContext(ctx, info), nil
+}
+
+// credentials returns the credentials of the request, without their scheme.
+func (s *server) credentials(sources map[string][]string) (string, error) {
+	var value string
+	for key, values := range sources {
+		if strings.EqualFold(key, s.header) && len(values) > 0 {
+			value = values[0]
+			break
+		}
+	}
+	if value == "" {
+		return "", fmt.Errorf("%w in the %q header", ErrMissingCredentials, s.header)
+	}
+	if s.scheme == "" {
+		return value, nil
+	}
+	scheme, credentials, ok := strings.Cut(value, " ")
+	if !ok || !strings.EqualFold(scheme, s.scheme) {
+		return "", fmt.Errorf("%w in the %q header, expected %q", errWrongScheme, s.header, s.scheme)
+	}
+	if credentials = strings.TrimSpace(credentials); credentials == "" {
+		return "", fmt.Errorf("%w in the %q header", ErrMissingCredentials, s.header)
+	}
+	return credentials, nil
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package extensionauthhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensionauth"
+	"go.opentelemetry.io/collector/extension/extensionauth/extensionauthhelper/internal/metadatatest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+type subjectAuthData string
+
+func (s subjectAuthData) GetAttribute(name string) any {
+	if name == "subject" {
+		return string(s)
+	}
+	return nil
+}
+
+func (subjectAuthData) GetAttributeNames() []string {
+	return []string{"subject"}
+}
+
+var errUnknownToken = errors.New("unknown token")
+
+// countingVerify accepts the "valid" credentials, and counts its calls.
+func countingVerify(calls *int) VerifyFunc {
+	return func(_ context.Context, credentials string) (client.AuthData, error) {
+		*calls++
+		if credentials != "valid" {
+			return nil, errUnknownToken
+		}
+		return subjectAuthData("user"), nil
+	}
+}
+
+func newSettings(tt *componenttest.Telemetry) extension.Settings {
+	set := extensiontest.NewNopSettings(extensiontest.NopType)
+	set.TelemetrySettings = tt.NewTelemetrySettings()
+	return set
+}
+
+func newTestServer(t *testing.T, tt *componenttest.Telemetry, verify VerifyFunc, options ...Option) extensionauth.Server {
+	ext, err := NewServer(newSettings(tt), verify, options...)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+	return ext.(extensionauth.Server)
+}
+
+func TestNewServerErrors(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	_, err := NewServer(newSettings(tt), nil)
+	require.Error(t, err)
+
+	var calls int
+	_, err = NewServer(newSettings(tt), countingVerify(&calls), WithCache(-time.Second, 10))
+	require.Error(t, err)
+}
+
+func TestServerAuthenticate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		sources map[string][]string
+		wantErr error
+	}{
+		{
+			name:    "default header",
+			sources: map[string][]string{"authorization": {"valid"}},
+		},
+		{
+			name:    "case insensitive header",
+			sources: map[string][]string{"Authorization": {"valid"}},
+		},
+		{
+			name:    "custom header",
+			options: []Option{WithHeader("x-api-key")},
+			sources: map[string][]string{"X-Api-Key": {"valid"}},
+		},
+		{
+			name:    "scheme",
+			options: []Option{WithScheme("Bearer")},
+			sources: map[string][]string{"authorization": {"bearer valid"}},
+		},
+		{
+			name:    "missing header",
+			sources: map[string][]string{"x-api-key": {"valid"}},
+			wantErr: ErrMissingCredentials,
+		},
+		{
+			name:    "empty header",
+			sources: map[string][]string{"authorization": {}},
+			wantErr: ErrMissingCredentials,
+		},
+		{
+			name:    "missing credentials after scheme",
+			options: []Option{WithScheme("Bearer")},
+			sources: map[string][]string{"authorization": {"Bearer  "}},
+			wantErr: ErrMissingCredentials,
+		},
+		{
+			name:    "other scheme",
+			options: []Option{WithScheme("Bearer")},
+			sources: map[string][]string{"authorization": {"Basic valid"}},
+			wantErr: errWrongScheme,
+		},
+		{
+			name:    "invalid credentials",
+			sources: map[string][]string{"authorization": {"invalid"}},
+			wantErr: errUnknownToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			srv := newTestServer(t, componenttest.NewTelemetry(), countingVerify(&calls), tt.options...)
+			ctx, err := srv.Authenticate(context.Background(), tt.sources)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user", client.FromContext(ctx).Auth.GetAttribute("subject"))
+		})
+	}
+}
+
+func TestServerKeepsClientInfo(t *testing.T) {
+	var calls int
+	srv := newTestServer(t, componenttest.NewTelemetry(), countingVerify(&calls))
+	ctx := client.NewContext(context.Background(), client.Info{Metadata: client.NewMetadata(map[string][]string{"tenant": {"acme"}})})
+
+	ctx, err := srv.Authenticate(ctx, map[string][]string{"authorization": {"valid"}})
+	require.NoError(t, err)
+	info := client.FromContext(ctx)
+	assert.Equal(t, []string{"acme"}, info.Metadata.Get("tenant"))
+	assert.Equal(t, "user", info.Auth.GetAttribute("subject"))
+}
+
+func TestServerStartShutdown(t *testing.T) {
+	var started, stopped bool
+	var calls int
+	ext, err := NewServer(extensiontest.NewNopSettings(extensiontest.NopType), countingVerify(&calls),
+		WithStart(func(context.Context, component.Host) error {
+			started = true
+			return nil
+		}),
+		WithShutdown(func(context.Context) error {
+			stopped = true
+			return nil
+		}))
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	assert.True(t, started)
+	require.NoError(t, ext.Shutdown(context.Background()))
+	assert.True(t, stopped)
+}
+
+func TestServerTelemetry(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	var calls int
+	srv := newTestServer(t, tt, countingVerify(&calls), WithCache(time.Minute, 10))
+
+	for range 3 {
+		_, err := srv.Authenticate(context.Background(), map[string][]string{"authorization": {"valid"}})
+		require.NoError(t, err)
+	}
+	_, err := srv.Authenticate(context.Background(), map[string][]string{"authorization": {"invalid"}})
+	require.Error(t, err)
+	_, err = srv.Authenticate(context.Background(), map[string][]string{})
+	require.Error(t, err)
+
+	// The valid credentials are only verified once, the invalid ones are not cached.
+	assert.Equal(t, 2, calls)
+	metadatatest.AssertEqualAuthServerAcceptedRequests(t, tt, []metricdata.DataPoint[int64]{{Value: 3}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualAuthServerRefusedRequests(t, tt, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualAuthServerCacheHits(t, tt, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+}
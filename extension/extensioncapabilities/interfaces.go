@@ -14,13 +14,50 @@ import (
 )
 
 // Dependent is an optional interface that can be implemented by extensions
-// that depend on other extensions and must be started only after their dependencies.
+// that depend on other extensions and must be started only after their dependencies,
+// e.g. an authenticator reading its credentials from a secrets extension.
+//
+// Extensions are started in dependency order and shut down in the reverse order; the
+// extensions that do not depend on each other keep the order of service::extensions.
+// The collector fails to start if a dependency is not enabled or if the dependencies
+// form a cycle.
 // See https://github.com/open-telemetry/opentelemetry-collector/pull/8768 for examples.
 type Dependent interface {
 	extension.Extension
 	Dependencies() []component.ID
 }
 
+// ExtensionDependent is an optional interface that can be implemented by the configuration
+// of any component that requires specific extensions, e.g. an exporter that needs a storage
+// and an authenticator extension.
+//
+// The collector validates that the extensions are enabled in the service when loading the
+// configuration. Pipeline components are always started after, and shut down before, the
+// extensions; extensions are started after the extensions they depend on.
+type ExtensionDependent interface {
+	// ExtensionDependencies returns the IDs of the extensions required by the component.
+	ExtensionDependencies() []component.ID
+}
+
+// MemoryLimitSetter is an optional interface that can be implemented by the configuration of an
+// extension setting the soft memory limit of the Go runtime, e.g. the GC tuning extension.
+//
+// The collector rejects a configuration enabling such an extension while the service sets the soft
+// memory limit too, with service::runtime::memory_limit_percentage, as they would override each other.
+type MemoryLimitSetter interface {
+	// SetsMemoryLimit returns whether the extension sets the soft memory limit.
+	SetsMemoryLimit() bool
+}
+
+// FileWriter is an optional interface that can be implemented by the configuration of an extension
+// writing files while the collector runs, e.g. the OpAMP extension writing the remote configuration.
+//
+// When the sandbox of the service is enabled, the collector can still write the directories of the files.
+type FileWriter interface {
+	// WrittenFiles returns the paths of the files written by the extension.
+	WrittenFiles() []string
+}
+
 // PipelineWatcher is an extra interface for Extension hosted by the OpenTelemetry
 // Collector that is to be implemented by extensions interested in changes to pipeline
 // states. Typically this will be used by extensions that change their behavior if data is
@@ -38,6 +75,23 @@ type PipelineWatcher interface {
 	NotReady() error
 }
 
+// PipelineLifecycleHooks is an optional interface that can be implemented by extensions
+// that need to run logic before the pipeline components start and after they stop,
+// e.g.: warm caches, register in service discovery, or flush local state.
+//
+// The hooks of all extensions are run in the extensions start order for
+// BeforePipelinesStart, and in the reverse order for AfterPipelinesShutdown,
+// so an extension's hooks always run inside those of the extensions it depends on.
+type PipelineLifecycleHooks interface {
+	// BeforePipelinesStart is called after all extensions are started and before
+	// any pipeline component is started. Returning an error aborts the startup.
+	BeforePipelinesStart(ctx context.Context) error
+
+	// AfterPipelinesShutdown is called after all pipeline components are shut down
+	// and before any extension is shut down.
+	AfterPipelinesShutdown(ctx context.Context) error
+}
+
 // ConfigWatcher is an interface that should be implemented by an extension that
 // wishes to be notified of the Collector's effective configuration.
 type ConfigWatcher interface {
@@ -47,3 +101,12 @@ type ConfigWatcher interface {
 	// instances of `conf`.
 	NotifyConfig(ctx context.Context, conf *confmap.Conf) error
 }
+
+// ConfigStatusWatcher is an interface that should be implemented by an extension that
+// wishes to be notified whether the Collector's configuration could be reloaded.
+type ConfigStatusWatcher interface {
+	// NotifyConfigStatus notifies the extension of the outcome of the last configuration reload:
+	// the error of the configuration which failed to be loaded while the Collector keeps running
+	// its last good configuration, or nil once a configuration is loaded again.
+	NotifyConfigStatus(err error)
+}
@@ -0,0 +1,361 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+var (
+	// defaultBucket holds the entries of the client.
+	defaultBucket = []byte("default")
+	// expiryBucket holds the expiration time of the entries set with a TTL, in Unix nanoseconds.
+	expiryBucket = []byte("expiry")
+)
+
+// iterateBatchSize is the number of entries read in each transaction while iterating, so that
+// the iteration does not hold a transaction while the entries are yielded.
+const iterateBatchSize = 100
+
+var errClientClosed = errors.New("the storage client is closed")
+
+var (
+	_ storage.TransactionClient = (*fileStorageClient)(nil)
+	_ storage.IterableClient    = (*fileStorageClient)(nil)
+	_ storage.ExpiringClient    = (*fileStorageClient)(nil)
+	_ storage.CompactableClient = (*fileStorageClient)(nil)
+)
+
+type fileStorageClient struct {
+	path   string
+	config *Config
+
+	// mu is held for writing while the database file is compacted, as it is replaced.
+	mu sync.RWMutex
+	db *bbolt.DB
+}
+
+func newFileStorageClient(path string, config *Config) (*fileStorageClient, error) {
+	c := &fileStorageClient{path: path, config: config}
+	var err error
+	if c.db, err = c.open(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// open opens the database file, creating its buckets.
+func (c *fileStorageClient) open() (*bbolt.DB, error) {
+	db, err := bbolt.Open(c.path, 0o600, &bbolt.Options{
+		Timeout:        c.config.Timeout,
+		NoSync:         !c.config.FSync,
+		NoFreelistSync: true,
+		FreelistType:   bbolt.FreelistMapType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the storage file %s: %w", c.path, err)
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range [][]byte{defaultBucket, expiryBucket} {
+			if _, errBucket := tx.CreateBucketIfNotExists(name); errBucket != nil {
+				return errBucket
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to create the buckets of the storage file %s: %w", c.path, err), db.Close())
+	}
+	return db, nil
+}
+
+// Get returns the value of the key, nil if not found or expired.
+func (c *fileStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	if err := c.Batch(ctx, op); err != nil {
+		return nil, err
+	}
+	return op.Value, nil
+}
+
+// Set sets the value of the key, without expiration.
+func (c *fileStorageClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+// SetWithTTL sets the value of the key until the ttl elapses. A zero ttl never expires.
+func (c *fileStorageClient) SetWithTTL(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.Batch(ctx, storage.SetWithTTLOperation(key, value, ttl))
+}
+
+// Delete deletes the key.
+func (c *fileStorageClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+// Batch applies the operations in a single transaction, in order.
+func (c *fileStorageClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
+	for _, op := range ops {
+		if op.Type == storage.CompareAndSwap {
+			return errors.New("compare-and-swap operations are only supported by Transaction")
+		}
+	}
+	return c.Transaction(ctx, ops...)
+}
+
+// Transaction applies the operations atomically, in order.
+func (c *fileStorageClient) Transaction(ctx context.Context, ops ...*storage.Operation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.db == nil {
+		return errClientClosed
+	}
+
+	readOnly := true
+	for _, op := range ops {
+		if op.Type != storage.Get {
+			readOnly = false
+			break
+		}
+	}
+	now := time.Now()
+	apply := func(tx *bbolt.Tx) error {
+		for _, op := range ops {
+			if err := applyOperation(tx, op, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if readOnly {
+		return c.db.View(apply)
+	}
+	return c.db.Update(apply)
+}
+
+func applyOperation(tx *bbolt.Tx, op *storage.Operation, now time.Time) error {
+	entries := tx.Bucket(defaultBucket)
+	expiries := tx.Bucket(expiryBucket)
+	key := []byte(op.Key)
+	switch op.Type {
+	case storage.Get:
+		op.Value = get(entries, expiries, key, now)
+		return nil
+	case storage.Set:
+		return set(entries, expiries, key, op.Value, op.TTL, now)
+	case storage.Delete:
+		return errors.Join(entries.Delete(key), expiries.Delete(key))
+	case storage.CompareAndSwap:
+		current := get(entries, expiries, key, now)
+		if (current == nil) != (op.Expected == nil) || !bytes.Equal(current, op.Expected) {
+			return storage.ErrCompareAndSwapFailed
+		}
+		return set(entries, expiries, key, op.Value, op.TTL, now)
+	}
+	return fmt.Errorf("unsupported operation type %d", op.Type)
+}
+
+// get returns a copy of the value of the key, as the values of bbolt are only valid during the transaction.
+func get(entries, expiries *bbolt.Bucket, key []byte, now time.Time) []byte {
+	value := entries.Get(key)
+	if value == nil || expired(expiries, key, now) {
+		return nil
+	}
+	return bytes.Clone(value)
+}
+
+func set(entries, expiries *bbolt.Bucket, key, value []byte, ttl time.Duration, now time.Time) error {
+	if value == nil {
+		// bbolt does not tell a nil value from an empty one.
+		value = []byte{}
+	}
+	if err := entries.Put(key, value); err != nil {
+		return err
+	}
+	if ttl <= 0 {
+		return expiries.Delete(key)
+	}
+	return expiries.Put(key, binary.BigEndian.AppendUint64(nil, uint64(now.Add(ttl).UnixNano()))) //nolint:gosec // G115 the times are after the epoch
+}
+
+func expired(expiries *bbolt.Bucket, key []byte, now time.Time) bool {
+	expiry := expiries.Get(key)
+	return len(expiry) == 8 && int64(binary.BigEndian.Uint64(expiry)) <= now.UnixNano() //nolint:gosec // G115 the times are after the epoch
+}
+
+// Iterate calls yield with the keys starting with the prefix, in ascending order, and their value.
+// The entries are read in batches, so that yield can use the client.
+func (c *fileStorageClient) Iterate(ctx context.Context, prefix string, yield func(key string, value []byte) bool) error {
+	type entry struct {
+		key   string
+		value []byte
+	}
+	after := prefix
+	first := true
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var batch []entry
+		err := c.view(func(tx *bbolt.Tx) error {
+			expiries := tx.Bucket(expiryBucket)
+			now := time.Now()
+			cursor := tx.Bucket(defaultBucket).Cursor()
+			k, v := cursor.Seek([]byte(after))
+			if !first && k != nil && string(k) == after {
+				k, v = cursor.Next()
+			}
+			for ; k != nil && strings.HasPrefix(string(k), prefix) && len(batch) < iterateBatchSize; k, v = cursor.Next() {
+				if !expired(expiries, k, now) {
+					batch = append(batch, entry{key: string(k), value: bytes.Clone(v)})
+				}
+				after = string(k)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		first = false
+		for _, e := range batch {
+			if !yield(e.key, e.value) {
+				return nil
+			}
+		}
+	}
+}
+
+func (c *fileStorageClient) view(fn func(tx *bbolt.Tx) error) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.db == nil {
+		return errClientClosed
+	}
+	return c.db.View(fn)
+}
+
+// Compact deletes the expired entries, then rewrites the database file to reclaim the space of the
+// deleted entries. The other operations are blocked until done.
+func (c *fileStorageClient) Compact(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.db == nil {
+		return errClientClosed
+	}
+
+	if err := c.db.Update(deleteExpired); err != nil {
+		return fmt.Errorf("failed to delete the expired entries: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(c.config.compactionDirectory(), "tempdb")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	if err = tmpFile.Close(); err != nil {
+		return errors.Join(err, os.Remove(tmpPath))
+	}
+	compacted, err := bbolt.Open(tmpPath, 0o600, &bbolt.Options{Timeout: c.config.Timeout, NoSync: true})
+	if err != nil {
+		return errors.Join(err, os.Remove(tmpPath))
+	}
+	if err = bbolt.Compact(compacted, c.db, c.config.Compaction.MaxTransactionSize); err != nil {
+		return errors.Join(fmt.Errorf("failed to compact the storage file %s: %w", c.path, err), compacted.Close(), os.Remove(tmpPath))
+	}
+	if err = compacted.Sync(); err != nil {
+		return errors.Join(err, compacted.Close(), os.Remove(tmpPath))
+	}
+	if err = compacted.Close(); err != nil {
+		return errors.Join(err, os.Remove(tmpPath))
+	}
+
+	// The database file is replaced by the compacted one, and opened again, even if failing to be replaced.
+	err = c.db.Close()
+	if err == nil {
+		err = moveFile(tmpPath, c.path)
+	}
+	db, errOpen := c.open()
+	c.db = db
+	if err != nil || errOpen != nil {
+		return errors.Join(err, errOpen, removeIfExists(tmpPath))
+	}
+	return nil
+}
+
+func deleteExpired(tx *bbolt.Tx) error {
+	entries := tx.Bucket(defaultBucket)
+	expiries := tx.Bucket(expiryBucket)
+	now := time.Now()
+	var keys [][]byte
+	err := expiries.ForEach(func(k, _ []byte) error {
+		if expired(expiries, k, now) {
+			keys = append(keys, bytes.Clone(k))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if err = errors.Join(entries.Delete(k), expiries.Delete(k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveFile renames the file, or copies it if it is on another file system than the destination.
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(dst, data, 0o600); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Close closes the database file.
+func (c *fileStorageClient) Close(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.db == nil {
+		return nil
+	}
+	err := c.db.Close()
+	c.db = nil
+	return err
+}
@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+func newTestClient(t *testing.T) *fileStorageClient {
+	cfg := newTestConfig(t)
+	client, err := newFileStorageClient(filepath.Join(cfg.Directory, "client"), cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close(context.Background())) })
+	return client
+}
+
+func TestClientGetSetDelete(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	require.NoError(t, client.Set(ctx, "empty", nil))
+	value, err = client.Get(ctx, "empty")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{}, value)
+
+	require.NoError(t, client.Delete(ctx, "key"))
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, client.Delete(ctx, "missing"))
+}
+
+func TestClientBatch(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	require.NoError(t, client.Set(ctx, "deleted", []byte("value")))
+
+	get := storage.GetOperation("set")
+	require.NoError(t, client.Batch(ctx,
+		storage.SetOperation("set", []byte("value")),
+		storage.DeleteOperation("deleted"),
+		get,
+	))
+	assert.Equal(t, []byte("value"), get.Value)
+
+	deleted := storage.GetOperation("deleted")
+	require.NoError(t, client.Batch(ctx, deleted))
+	assert.Nil(t, deleted.Value)
+
+	require.Error(t, client.Batch(ctx, storage.CompareAndSwapOperation("set", []byte("value"), []byte("new"))))
+}
+
+func TestClientTransaction(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	require.NoError(t, client.Set(ctx, "key", []byte("old")))
+
+	require.NoError(t, client.Transaction(ctx,
+		storage.CompareAndSwapOperation("key", []byte("old"), []byte("new")),
+		storage.CompareAndSwapOperation("created", nil, []byte("value")),
+	))
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new"), value)
+	value, err = client.Get(ctx, "created")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	// A mismatch applies none of the operations.
+	err = client.Transaction(ctx,
+		storage.SetOperation("other", []byte("value")),
+		storage.CompareAndSwapOperation("key", []byte("old"), []byte("newer")),
+	)
+	require.ErrorIs(t, err, storage.ErrCompareAndSwapFailed)
+	value, err = client.Get(ctx, "other")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new"), value)
+
+	// A missing key does not match an empty value.
+	require.ErrorIs(t, client.Transaction(ctx, storage.CompareAndSwapOperation("missing", []byte{}, []byte("value"))), storage.ErrCompareAndSwapFailed)
+}
+
+func TestClientIterate(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	want := make(map[string][]byte)
+	var wantKeys []string
+	for i := range 2*iterateBatchSize + 10 {
+		key := fmt.Sprintf("item/%04d", i)
+		want[key] = []byte(key)
+		wantKeys = append(wantKeys, key)
+		require.NoError(t, client.Set(ctx, key, []byte(key)))
+	}
+	require.NoError(t, client.Set(ctx, "itemz", []byte("other")))
+	require.NoError(t, client.Set(ctx, "a", []byte("other")))
+
+	var keys []string
+	require.NoError(t, client.Iterate(ctx, "item/", func(key string, value []byte) bool {
+		keys = append(keys, key)
+		assert.Equal(t, want[key], value)
+		// The client can be used while iterating.
+		require.NoError(t, client.Set(ctx, "a", value))
+		return true
+	}))
+	assert.Equal(t, wantKeys, keys)
+
+	keys = nil
+	require.NoError(t, client.Iterate(ctx, "item/", func(key string, _ []byte) bool {
+		keys = append(keys, key)
+		return len(keys) < 3
+	}))
+	assert.Equal(t, wantKeys[:3], keys)
+
+	require.NoError(t, client.Iterate(ctx, "missing", func(string, []byte) bool {
+		assert.Fail(t, "no entry expected")
+		return true
+	}))
+}
+
+func TestClientTTL(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	require.NoError(t, client.SetWithTTL(ctx, "expired", []byte("value"), time.Nanosecond))
+	require.NoError(t, client.SetWithTTL(ctx, "alive", []byte("value"), time.Hour))
+	require.NoError(t, client.SetWithTTL(ctx, "persisted", []byte("value"), time.Nanosecond))
+	require.NoError(t, client.Set(ctx, "persisted", []byte("value")))
+	time.Sleep(time.Millisecond)
+
+	value, err := client.Get(ctx, "expired")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	value, err = client.Get(ctx, "alive")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	value, err = client.Get(ctx, "persisted")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	var keys []string
+	require.NoError(t, client.Iterate(ctx, "", func(key string, _ []byte) bool {
+		keys = append(keys, key)
+		return true
+	}))
+	assert.Equal(t, []string{"alive", "persisted"}, keys)
+
+	// An expired entry does not match its value anymore.
+	require.ErrorIs(t, client.Transaction(ctx, storage.CompareAndSwapOperation("expired", []byte("value"), []byte("new"))), storage.ErrCompareAndSwapFailed)
+	require.NoError(t, client.Transaction(ctx, storage.CompareAndSwapOperation("expired", nil, []byte("new"))))
+}
+
+func TestClientCompact(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	value := make([]byte, 1024)
+	for i := range 1000 {
+		require.NoError(t, client.Set(ctx, fmt.Sprintf("key%d", i), value))
+	}
+	require.NoError(t, client.SetWithTTL(ctx, "expired", value, time.Nanosecond))
+	for i := range 999 {
+		require.NoError(t, client.Delete(ctx, fmt.Sprintf("key%d", i)))
+	}
+	before, err := os.Stat(client.path)
+	require.NoError(t, err)
+
+	require.NoError(t, client.Compact(ctx))
+	after, err := os.Stat(client.path)
+	require.NoError(t, err)
+	assert.Less(t, after.Size(), before.Size())
+
+	got, err := client.Get(ctx, "key999")
+	require.NoError(t, err)
+	assert.Equal(t, value, got)
+	var keys []string
+	require.NoError(t, client.Iterate(ctx, "", func(key string, _ []byte) bool {
+		keys = append(keys, key)
+		return true
+	}))
+	assert.Equal(t, []string{"key999"}, keys)
+	require.NoError(t, client.Set(ctx, "key", value))
+}
+
+func TestClientClosed(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	require.NoError(t, client.Close(ctx))
+
+	_, err := client.Get(ctx, "key")
+	require.ErrorIs(t, err, errClientClosed)
+	require.ErrorIs(t, client.Set(ctx, "key", nil), errClientClosed)
+	require.ErrorIs(t, client.Iterate(ctx, "", func(string, []byte) bool { return true }), errClientClosed)
+	require.ErrorIs(t, client.Compact(ctx), errClientClosed)
+}
+
+func TestClientCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := newTestClient(t)
+
+	_, err := client.Get(ctx, "key")
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, client.Set(ctx, "key", nil), context.Canceled)
+	require.ErrorIs(t, client.Compact(ctx), context.Canceled)
+}
+
+func TestClientLockedFile(t *testing.T) {
+	client := newTestClient(t)
+	cfg := *client.config
+	cfg.Timeout = 10 * time.Millisecond
+	_, err := newFileStorageClient(client.path, &cfg)
+	require.Error(t, err)
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config has the configuration of the file storage extension.
+type Config struct {
+	// Directory is the directory of the database files, one per client.
+	// (default = /var/lib/otelcol/file_storage, %ProgramData%\Otelcol\FileStorage on Windows)
+	Directory string `mapstructure:"directory"`
+
+	// Timeout is how long to wait for the lock of a database file, held by another process.
+	// (default = 1s)
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Compaction configures the compaction of the database files, which reclaims the space of
+	// the deleted and expired entries.
+	Compaction CompactionConfig `mapstructure:"compaction"`
+
+	// FSync syncs the database files to disk after each write, surviving a crash of the host
+	// instead of only a crash of the collector.
+	// (default = false)
+	FSync bool `mapstructure:"fsync"`
+
+	// CreateDirectory creates the directory if it does not exist, with DirectoryPermissions.
+	// (default = false)
+	CreateDirectory bool `mapstructure:"create_directory"`
+
+	// DirectoryPermissions are the octal permissions of the created directory.
+	// (default = 0750)
+	DirectoryPermissions string `mapstructure:"directory_permissions"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// CompactionConfig has the configuration of the compaction of the database files.
+type CompactionConfig struct {
+	// OnStart compacts the database file of each client when the client is created.
+	// (default = false)
+	OnStart bool `mapstructure:"on_start"`
+
+	// Directory is the directory of the temporary files written while compacting.
+	// (default = the directory of the database files)
+	Directory string `mapstructure:"directory"`
+
+	// MaxTransactionSize is the maximum number of bytes copied in each transaction while compacting.
+	// (default = 65536)
+	MaxTransactionSize int64 `mapstructure:"max_transaction_size"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Directory == "" {
+		return errors.New("\"directory\" must be specified")
+	}
+	if !cfg.CreateDirectory {
+		if err := checkDirectory(cfg.Directory); err != nil {
+			return fmt.Errorf("\"directory\": %w", err)
+		}
+	}
+	if _, err := cfg.directoryPermissions(); err != nil {
+		return fmt.Errorf("\"directory_permissions\" must be octal permissions: %w", err)
+	}
+	if cfg.Compaction.Directory != "" {
+		if err := checkDirectory(cfg.Compaction.Directory); err != nil {
+			return fmt.Errorf("\"compaction::directory\": %w", err)
+		}
+	}
+	if cfg.Timeout < 0 {
+		return errors.New("\"timeout\" must not be negative")
+	}
+	if cfg.Compaction.MaxTransactionSize < 0 {
+		return errors.New("\"compaction::max_transaction_size\" must not be negative")
+	}
+	return nil
+}
+
+func (cfg *Config) directoryPermissions() (fs.FileMode, error) {
+	perm, err := strconv.ParseUint(cfg.DirectoryPermissions, 8, 32)
+	if err != nil {
+		return 0, err
+	}
+	if perm&^uint64(fs.ModePerm) != 0 {
+		return 0, fmt.Errorf("invalid permissions %q", cfg.DirectoryPermissions)
+	}
+	return fs.FileMode(perm), nil
+}
+
+// compactionDirectory returns the directory of the temporary files written while compacting.
+func (cfg *Config) compactionDirectory() string {
+	if cfg.Compaction.Directory != "" {
+		return cfg.Compaction.Directory
+	}
+	return cfg.Directory
+}
+
+func checkDirectory(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t, &Config{
+		Directory: "testdata",
+		Timeout:   2 * time.Second,
+		Compaction: CompactionConfig{
+			OnStart:            true,
+			Directory:          "testdata",
+			MaxTransactionSize: 1024,
+		},
+		FSync:                true,
+		CreateDirectory:      true,
+		DirectoryPermissions: "0700",
+	}, cfg)
+	require.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{name: "valid"},
+		{
+			name:    "missing_directory",
+			modify:  func(cfg *Config) { cfg.Directory = "" },
+			wantErr: `"directory" must be specified`,
+		},
+		{
+			name:    "nonexistent_directory",
+			modify:  func(cfg *Config) { cfg.Directory = filepath.Join(dir, "missing") },
+			wantErr: `"directory": stat `,
+		},
+		{
+			name: "created_directory",
+			modify: func(cfg *Config) {
+				cfg.Directory = filepath.Join(dir, "missing")
+				cfg.CreateDirectory = true
+			},
+		},
+		{
+			name:    "file_directory",
+			modify:  func(cfg *Config) { cfg.Directory = file },
+			wantErr: `"directory": ` + file + " is not a directory",
+		},
+		{
+			name:    "invalid_permissions",
+			modify:  func(cfg *Config) { cfg.DirectoryPermissions = "rwx" },
+			wantErr: `"directory_permissions" must be octal permissions`,
+		},
+		{
+			name:    "out_of_range_permissions",
+			modify:  func(cfg *Config) { cfg.DirectoryPermissions = "1777" },
+			wantErr: `"directory_permissions" must be octal permissions: invalid permissions "1777"`,
+		},
+		{
+			name:    "nonexistent_compaction_directory",
+			modify:  func(cfg *Config) { cfg.Compaction.Directory = filepath.Join(dir, "missing") },
+			wantErr: `"compaction::directory": stat `,
+		},
+		{
+			name:    "negative_timeout",
+			modify:  func(cfg *Config) { cfg.Timeout = -time.Second },
+			wantErr: `"timeout" must not be negative`,
+		},
+		{
+			name:    "negative_max_transaction_size",
+			modify:  func(cfg *Config) { cfg.Compaction.MaxTransactionSize = -1 },
+			wantErr: `"compaction::max_transaction_size" must not be negative`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.Directory = dir
+			if tt.modify != nil {
+				tt.modify(cfg)
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDirectoryPermissions(t *testing.T) {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	perm, err := cfg.directoryPermissions()
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0o750), perm)
+}
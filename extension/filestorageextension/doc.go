@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package filestorageextension implements a storage extension persisting the data of the
+// components in bbolt database files, e.g. for the persistent queue of the exporters.
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
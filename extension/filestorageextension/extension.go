@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+var _ storage.Extension = (*fileStorage)(nil)
+
+type fileStorage struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+}
+
+func newFileStorage(config *Config, telemetry component.TelemetrySettings) *fileStorage {
+	return &fileStorage{
+		config:    config,
+		telemetry: telemetry,
+	}
+}
+
+func (fs *fileStorage) Start(context.Context, component.Host) error {
+	if !fs.config.CreateDirectory {
+		return nil
+	}
+	perm, err := fs.config.directoryPermissions()
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(fs.config.Directory, perm); err != nil {
+		return fmt.Errorf("failed to create the storage directory: %w", err)
+	}
+	return nil
+}
+
+// Shutdown does nothing, the components close their clients when they shut down.
+func (fs *fileStorage) Shutdown(context.Context) error {
+	return nil
+}
+
+// GetClient returns a client storing the data of the component in its own database file. The file is named
+// after the kind and ID of the component, and the storage name.
+func (fs *fileStorage) GetClient(ctx context.Context, kind component.Kind, id component.ID, storageName string) (storage.Client, error) {
+	name := strings.ToLower(kind.String()) + "_" + id.Type().String() + "_" + id.Name()
+	if storageName != "" {
+		name += "_" + storageName
+	}
+	path := filepath.Join(fs.config.Directory, sanitize(name))
+
+	client, err := newFileStorageClient(path, fs.config)
+	if err != nil {
+		return nil, err
+	}
+	if fs.config.Compaction.OnStart {
+		fs.telemetry.Logger.Debug("Compacting the storage file on start", zap.String("path", path))
+		if err = client.Compact(ctx); err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to compact the storage file %s: %w", path, err), client.Close(ctx))
+		}
+	}
+	return client, nil
+}
+
+// sanitize replaces the characters of the name unsafe in a file name with a tilde followed by their
+// Unicode code point, e.g. "/" with "~002F".
+func sanitize(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if isSafe(r) {
+			b.WriteRune(r)
+			continue
+		}
+		fmt.Fprintf(&b, "~%04X", r)
+	}
+	return b.String()
+}
+
+func isSafe(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.'
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/extension/filestorageextension/internal/metadata"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+)
+
+func newTestExtension(t *testing.T, cfg *Config) storage.Extension {
+	ext, err := NewFactory().Create(context.Background(), extensiontest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+	return ext.(storage.Extension)
+}
+
+func newTestConfig(t *testing.T) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	return cfg
+}
+
+func TestGetClientFileName(t *testing.T) {
+	tests := []struct {
+		name        string
+		kind        component.Kind
+		id          component.ID
+		storageName string
+		want        string
+	}{
+		{
+			name: "exporter",
+			kind: component.KindExporter,
+			id:   component.MustNewID("otlp"),
+			want: "exporter_otlp_",
+		},
+		{
+			name:        "storage_name",
+			kind:        component.KindReceiver,
+			id:          component.MustNewIDWithName("filelog", "app"),
+			storageName: "checkpoints",
+			want:        "receiver_filelog_app_checkpoints",
+		},
+		{
+			name: "unsafe_name",
+			kind: component.KindExporter,
+			id:   component.MustNewIDWithName("otlp", "a/b:c"),
+			want: "exporter_otlp_a~002Fb~003Ac",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig(t)
+			ext := newTestExtension(t, cfg)
+			client, err := ext.GetClient(context.Background(), tt.kind, tt.id, tt.storageName)
+			require.NoError(t, err)
+			require.NoError(t, client.Close(context.Background()))
+			assert.FileExists(t, filepath.Join(cfg.Directory, tt.want))
+		})
+	}
+}
+
+func TestGetClientPersistence(t *testing.T) {
+	cfg := newTestConfig(t)
+	ext := newTestExtension(t, cfg)
+	id := component.MustNewID("otlp")
+
+	client, err := ext.GetClient(context.Background(), component.KindExporter, id, "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(context.Background(), "key", []byte("value")))
+	require.NoError(t, client.Close(context.Background()))
+
+	// Another extension with the same directory, as after a restart of the collector.
+	client, err = newTestExtension(t, cfg).GetClient(context.Background(), component.KindExporter, id, "")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, client.Close(context.Background())) }()
+	value, err := client.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestGetClientSeparateFiles(t *testing.T) {
+	ext := newTestExtension(t, newTestConfig(t))
+
+	first, err := ext.GetClient(context.Background(), component.KindExporter, component.MustNewID("otlp"), "")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, first.Close(context.Background())) }()
+	second, err := ext.GetClient(context.Background(), component.KindExporter, component.MustNewIDWithName("otlp", "second"), "")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, second.Close(context.Background())) }()
+
+	require.NoError(t, first.Set(context.Background(), "key", []byte("value")))
+	value, err := second.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestGetClientCompactOnStart(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Compaction.OnStart = true
+	cfg.Compaction.Directory = t.TempDir()
+	ext := newTestExtension(t, cfg)
+	id := component.MustNewID("otlp")
+
+	client, err := ext.GetClient(context.Background(), component.KindExporter, id, "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(context.Background(), "key", []byte("value")))
+	require.NoError(t, client.Close(context.Background()))
+
+	client, err = ext.GetClient(context.Background(), component.KindExporter, id, "")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, client.Close(context.Background())) }()
+	value, err := client.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	entries, err := os.ReadDir(cfg.Compaction.Directory)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the temporary files must be removed")
+}
+
+func TestStartCreateDirectory(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Directory = filepath.Join(cfg.Directory, "nested", "storage")
+	cfg.CreateDirectory = true
+	cfg.DirectoryPermissions = "0700"
+	ext := newTestExtension(t, cfg)
+
+	info, err := os.Stat(cfg.Directory)
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	client, err := ext.GetClient(context.Background(), component.KindExporter, component.MustNewID("otlp"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Close(context.Background()))
+}
+
+func TestGetClientMissingDirectory(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Directory = filepath.Join(cfg.Directory, "missing")
+	_, err := newTestExtension(t, cfg).GetClient(context.Background(), component.KindExporter, component.MustNewID("otlp"), "")
+	require.Error(t, err)
+}
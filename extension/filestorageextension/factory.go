@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension // import "go.opentelemetry.io/collector/extension/filestorageextension"
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/filestorageextension/internal/metadata"
+)
+
+const (
+	defaultTimeout              = time.Second
+	defaultMaxTransactionSize   = 65536
+	defaultDirectoryPermissions = "0750"
+)
+
+// NewFactory creates a factory for the file storage extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Directory: defaultDirectory(),
+		Timeout:   defaultTimeout,
+		Compaction: CompactionConfig{
+			MaxTransactionSize: defaultMaxTransactionSize,
+		},
+		DirectoryPermissions: defaultDirectoryPermissions,
+	}
+}
+
+func defaultDirectory() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("ProgramData"), "Otelcol", "FileStorage")
+	}
+	return "/var/lib/otelcol/file_storage"
+}
+
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newFileStorage(cfg.(*Config), set.TelemetrySettings), nil
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorageextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/extension/filestorageextension/internal/metadata"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, defaultDirectory(), cfg.Directory)
+	assert.Equal(t, defaultTimeout, cfg.Timeout)
+	assert.Equal(t, int64(defaultMaxTransactionSize), cfg.Compaction.MaxTransactionSize)
+	assert.Equal(t, defaultDirectoryPermissions, cfg.DirectoryPermissions)
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestFactoryCreate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	ext, err := NewFactory().Create(context.Background(), extensiontest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gctuningextension // import "go.opentelemetry.io/collector/extension/gctuningextension"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+)
+
+// Config has the configuration of the GC tuning extension.
+type Config struct {
+	// MemoryLimitMiB is the soft memory limit of the Go runtime, in MiB. It has a higher
+	// precedence than MemoryLimitPercentage.
+	MemoryLimitMiB uint64 `mapstructure:"memory_limit_mib"`
+
+	// MemoryLimitPercentage is the soft memory limit of the Go runtime, in % of the memory
+	// available to the collector: the limit of its cgroup if any, and the total memory otherwise.
+	// Zero keeps the current limit.
+	// (default = 80)
+	MemoryLimitPercentage uint32 `mapstructure:"memory_limit_percentage"`
+
+	// GCPercent is the GC target percentage of the Go runtime. -1 only runs the GC when reaching
+	// the soft memory limit. Zero keeps the current percentage.
+	GCPercent int `mapstructure:"gc_percent"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var (
+	_ component.Config                        = (*Config)(nil)
+	_ extensioncapabilities.MemoryLimitSetter = (*Config)(nil)
+)
+
+// SetsMemoryLimit returns whether the extension sets the soft memory limit of the Go runtime.
+func (cfg *Config) SetsMemoryLimit() bool {
+	return cfg.MemoryLimitMiB > 0 || cfg.MemoryLimitPercentage > 0
+}
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MemoryLimitPercentage > 100 {
+		return errors.New("\"memory_limit_percentage\" must not be greater than 100")
+	}
+	if cfg.GCPercent < -1 {
+		return errors.New("\"gc_percent\" must be -1 or greater")
+	}
+	if cfg.GCPercent == -1 && cfg.MemoryLimitMiB == 0 && cfg.MemoryLimitPercentage == 0 {
+		return errors.New("\"gc_percent\" can only be -1 with a memory limit")
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gctuningextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t, &Config{
+		MemoryLimitMiB:        2048,
+		MemoryLimitPercentage: defaultMemoryLimitPercentage,
+		GCPercent:             -1,
+	}, cfg)
+	require.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestInvalidConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		cfg  *Config
+		err  string
+	}{
+		{
+			name: "percentage above 100",
+			cfg:  &Config{MemoryLimitPercentage: 101},
+			err:  `"memory_limit_percentage" must not be greater than 100`,
+		},
+		{
+			name: "gc percent below -1",
+			cfg:  &Config{MemoryLimitPercentage: 80, GCPercent: -2},
+			err:  `"gc_percent" must be -1 or greater`,
+		},
+		{
+			name: "gc off without memory limit",
+			cfg:  &Config{GCPercent: -1},
+			err:  `"gc_percent" can only be -1 with a memory limit`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.EqualError(t, tt.cfg.Validate(), tt.err)
+		})
+	}
+}
+
+func TestSetsMemoryLimit(t *testing.T) {
+	assert.True(t, (&Config{MemoryLimitPercentage: 80}).SetsMemoryLimit())
+	assert.True(t, (&Config{MemoryLimitMiB: 2048}).SetsMemoryLimit())
+	assert.False(t, (&Config{GCPercent: 200}).SetsMemoryLimit())
+}
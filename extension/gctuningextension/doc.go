@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package gctuningextension sets the soft memory limit and the GC target percentage of the
+// Go runtime, from its configuration or from the memory available to the collector.
+package gctuningextension // import "go.opentelemetry.io/collector/extension/gctuningextension"
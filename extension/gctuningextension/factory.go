@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gctuningextension // import "go.opentelemetry.io/collector/extension/gctuningextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/gctuningextension/internal/metadata"
+)
+
+const defaultMemoryLimitPercentage = 80
+
+// NewFactory creates a factory for the GC tuning extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		MemoryLimitPercentage: defaultMemoryLimitPercentage,
+	}
+}
+
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newGCTuning(cfg.(*Config), set.TelemetrySettings)
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gctuningextension // import "go.opentelemetry.io/collector/extension/gctuningextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"runtime/debug"
+	"runtime/metrics"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/gctuningextension/internal/metadata"
+	"go.opentelemetry.io/collector/internal/memorylimiter/iruntime"
+)
+
+const (
+	mibBytes = 1024 * 1024
+
+	memoryLimitEnv = "GOMEMLIMIT"
+	gcPercentEnv   = "GOGC"
+)
+
+// The runtime metrics reported by the extension, see 'go doc runtime/metrics'.
+const (
+	memoryLimitMetric = "/gc/gomemlimit:bytes"
+	gcPercentMetric   = "/gc/gogc:percent"
+	heapGoalMetric    = "/gc/heap/goal:bytes"
+	gcCyclesMetric    = "/gc/cycles/total:gc-cycles"
+)
+
+type gcTuning struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	builder   *metadata.TelemetryBuilder
+
+	// totalMemory returns the memory available to the collector, replaced in tests.
+	totalMemory func() (uint64, error)
+
+	// The settings before the extension started, restored on shutdown.
+	prevMemoryLimit int64
+	prevGCPercent   int
+	setMemoryLimit  bool
+	setGCPercent    bool
+}
+
+func newGCTuning(config *Config, telemetry component.TelemetrySettings) (*gcTuning, error) {
+	builder, err := metadata.NewTelemetryBuilder(telemetry)
+	if err != nil {
+		return nil, err
+	}
+	return &gcTuning{
+		config:      config,
+		telemetry:   telemetry,
+		builder:     builder,
+		totalMemory: iruntime.TotalMemory,
+	}, nil
+}
+
+func (gt *gcTuning) Start(context.Context, component.Host) error {
+	if _, ok := os.LookupEnv(memoryLimitEnv); ok {
+		gt.telemetry.Logger.Info("Keeping the soft memory limit set by the environment", zap.String(memoryLimitEnv, os.Getenv(memoryLimitEnv)))
+	} else {
+		limit, err := gt.memoryLimit()
+		if err != nil {
+			return err
+		}
+		if limit > 0 {
+			gt.prevMemoryLimit = debug.SetMemoryLimit(limit)
+			gt.setMemoryLimit = true
+			gt.telemetry.Logger.Info("Set the soft memory limit", zap.Int64("limit_mib", limit/mibBytes))
+		}
+	}
+
+	if _, ok := os.LookupEnv(gcPercentEnv); ok {
+		gt.telemetry.Logger.Info("Keeping the GC target percentage set by the environment", zap.String(gcPercentEnv, os.Getenv(gcPercentEnv)))
+	} else if gt.config.GCPercent != 0 {
+		gt.prevGCPercent = debug.SetGCPercent(gt.config.GCPercent)
+		gt.setGCPercent = true
+		gt.telemetry.Logger.Info("Set the GC target percentage", zap.Int("gc_percent", gt.config.GCPercent))
+	}
+
+	return errors.Join(
+		gt.builder.RegisterGcTuningMemoryLimitCallback(func(_ context.Context, o metric.Int64Observer) error {
+			// The runtime reports the maximum value when no limit is set.
+			if limit := readRuntimeMetric(memoryLimitMetric); limit < math.MaxInt64 {
+				o.Observe(int64(limit))
+			}
+			return nil
+		}),
+		gt.builder.RegisterGcTuningGcPercentCallback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(readRuntimeMetric(gcPercentMetric))) //nolint:gosec // G115 -1 is reported as its two's complement
+			return nil
+		}),
+		gt.builder.RegisterGcTuningHeapGoalCallback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(readRuntimeMetric(heapGoalMetric))) //nolint:gosec // G115 the memory fits in an int64
+			return nil
+		}),
+		gt.builder.RegisterGcTuningGcCyclesCallback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(readRuntimeMetric(gcCyclesMetric))) //nolint:gosec // G115 the cycles fit in an int64
+			return nil
+		}),
+	)
+}
+
+// memoryLimit returns the soft memory limit to set in bytes, zero to keep the current limit.
+func (gt *gcTuning) memoryLimit() (int64, error) {
+	if gt.config.MemoryLimitMiB > 0 {
+		return int64(gt.config.MemoryLimitMiB) * mibBytes, nil //nolint:gosec // G115 the memory fits in an int64
+	}
+	if gt.config.MemoryLimitPercentage == 0 {
+		return 0, nil
+	}
+	total, err := gt.totalMemory()
+	if err != nil {
+		return 0, fmt.Errorf("failed to detect the memory available to the collector: %w", err)
+	}
+	return iruntime.MemoryLimit(total, gt.config.MemoryLimitPercentage), nil
+}
+
+// Shutdown restores the soft memory limit and the GC target percentage set before the extension started.
+func (gt *gcTuning) Shutdown(context.Context) error {
+	gt.builder.Shutdown()
+	if gt.setMemoryLimit {
+		debug.SetMemoryLimit(gt.prevMemoryLimit)
+		gt.setMemoryLimit = false
+	}
+	if gt.setGCPercent {
+		debug.SetGCPercent(gt.prevGCPercent)
+		gt.setGCPercent = false
+	}
+	return nil
+}
+
+func readRuntimeMetric(name string) uint64 {
+	sample := []metrics.Sample{{Name: name}}
+	metrics.Read(sample)
+	return sample[0].Value.Uint64()
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gctuningextension
+
+import (
+	"context"
+	"math"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/gctuningextension/internal/metadatatest"
+)
+
+// currentSettings returns the soft memory limit and the GC target percentage of the runtime.
+func currentSettings() (int64, int) {
+	gcPercent := debug.SetGCPercent(100)
+	debug.SetGCPercent(gcPercent)
+	return debug.SetMemoryLimit(-1), gcPercent
+}
+
+func newTestGCTuning(t *testing.T, cfg *Config, tel *componenttest.Telemetry) *gcTuning {
+	ext, err := create(context.Background(), metadatatest.NewSettings(tel), cfg)
+	require.NoError(t, err)
+	return ext.(*gcTuning)
+}
+
+func TestGCTuning(t *testing.T) {
+	prevMemoryLimit, prevGCPercent := currentSettings()
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	gt := newTestGCTuning(t, &Config{MemoryLimitMiB: 512, MemoryLimitPercentage: 80, GCPercent: -1}, tel)
+	require.NoError(t, gt.Start(context.Background(), componenttest.NewNopHost()))
+	memoryLimit, gcPercent := currentSettings()
+	assert.Equal(t, int64(512*mibBytes), memoryLimit)
+	assert.Equal(t, -1, gcPercent)
+
+	metadatatest.AssertEqualGcTuningMemoryLimit(t, tel, []metricdata.DataPoint[int64]{{Value: 512 * mibBytes}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualGcTuningGcPercent(t, tel, []metricdata.DataPoint[int64]{{Value: -1}}, metricdatatest.IgnoreTimestamp())
+	got, err := tel.GetMetric("otelcol_gc_tuning_heap_goal")
+	require.NoError(t, err)
+	assert.Positive(t, got.Data.(metricdata.Gauge[int64]).DataPoints[0].Value)
+	_, err = tel.GetMetric("otelcol_gc_tuning_gc_cycles")
+	require.NoError(t, err)
+
+	// The settings are restored on shutdown.
+	require.NoError(t, gt.Shutdown(context.Background()))
+	memoryLimit, gcPercent = currentSettings()
+	assert.Equal(t, prevMemoryLimit, memoryLimit)
+	assert.Equal(t, prevGCPercent, gcPercent)
+}
+
+func TestGCTuningMemoryLimitPercentage(t *testing.T) {
+	prevMemoryLimit, prevGCPercent := currentSettings()
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	gt := newTestGCTuning(t, &Config{MemoryLimitPercentage: 50}, tel)
+	gt.totalMemory = func() (uint64, error) { return 1024 * mibBytes, nil }
+	require.NoError(t, gt.Start(context.Background(), componenttest.NewNopHost()))
+	memoryLimit, gcPercent := currentSettings()
+	assert.Equal(t, int64(512*mibBytes), memoryLimit)
+	assert.Equal(t, prevGCPercent, gcPercent)
+	require.NoError(t, gt.Shutdown(context.Background()))
+	memoryLimit, _ = currentSettings()
+	assert.Equal(t, prevMemoryLimit, memoryLimit)
+
+	gt = newTestGCTuning(t, &Config{MemoryLimitPercentage: 50}, tel)
+	gt.totalMemory = func() (uint64, error) { return 0, assert.AnError }
+	require.ErrorIs(t, gt.Start(context.Background(), componenttest.NewNopHost()), assert.AnError)
+	require.NoError(t, gt.Shutdown(context.Background()))
+}
+
+func TestGCTuningKeepsEnvironment(t *testing.T) {
+	t.Setenv(memoryLimitEnv, "1GiB")
+	t.Setenv(gcPercentEnv, "50")
+	prevMemoryLimit, prevGCPercent := currentSettings()
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	gt := newTestGCTuning(t, &Config{MemoryLimitMiB: 512, GCPercent: 200}, tel)
+	require.NoError(t, gt.Start(context.Background(), componenttest.NewNopHost()))
+	memoryLimit, gcPercent := currentSettings()
+	assert.Equal(t, prevMemoryLimit, memoryLimit)
+	assert.Equal(t, prevGCPercent, gcPercent)
+	require.NoError(t, gt.Shutdown(context.Background()))
+}
+
+func TestGCTuningNoMemoryLimit(t *testing.T) {
+	if limit, _ := currentSettings(); limit != math.MaxInt64 {
+		t.Skip("the soft memory limit is set by the environment")
+	}
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	gt := newTestGCTuning(t, &Config{}, tel)
+	require.NoError(t, gt.Start(context.Background(), componenttest.NewNopHost()))
+	// No memory limit is reported while none is set.
+	_, err := tel.GetMetric("otelcol_gc_tuning_memory_limit")
+	require.Error(t, err)
+	require.NoError(t, gt.Shutdown(context.Background()))
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+)
+
+// Config has the configuration of the health extension.
+type Config struct {
+	// HTTP configures the HTTP endpoint serving the health as JSON. It is served with its
+	// default settings when neither HTTP nor gRPC is configured.
+	HTTP configoptional.Optional[HTTPConfig] `mapstructure:"http"`
+
+	// GRPC configures the gRPC endpoint implementing the grpc.health.v1.Health service.
+	GRPC configoptional.Optional[configgrpc.ServerConfig] `mapstructure:"grpc"`
+
+	// RecoveryDuration is how long a component can report a recoverable error before
+	// it is considered unhealthy. Zero makes it unhealthy as soon as it reports the error.
+	// (default = 1m)
+	RecoveryDuration time.Duration `mapstructure:"recovery_duration"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// HTTPConfig has the configuration of the HTTP endpoint.
+type HTTPConfig struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// Path is the path serving the health.
+	// (default = /health)
+	Path string `mapstructure:"path"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.RecoveryDuration < 0 {
+		return errors.New("\"recovery_duration\" must not be negative")
+	}
+	if cfg.HTTP.HasValue() && !strings.HasPrefix(cfg.HTTP.Get().Path, "/") {
+		return errors.New("\"http::path\" must start with a slash")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+
+	httpCfg := confighttp.NewDefaultServerConfig()
+	httpCfg.Endpoint = "localhost:13233"
+	grpcCfg := configgrpc.NewDefaultServerConfig()
+	grpcCfg.NetAddr.Endpoint = "localhost:13232"
+	assert.Equal(t, &Config{
+		HTTP:             configoptional.Some(HTTPConfig{ServerConfig: httpCfg, Path: "/status"}),
+		GRPC:             configoptional.Some(grpcCfg),
+		RecoveryDuration: 30 * time.Second,
+	}, cfg)
+	require.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestUnmarshalGRPCConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_grpc.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+
+	// Only the configured protocols are served.
+	assert.False(t, cfg.(*Config).HTTP.HasValue())
+	require.True(t, cfg.(*Config).GRPC.HasValue())
+	assert.Equal(t, defaultGRPCEndpoint, cfg.(*Config).GRPC.Get().NetAddr.Endpoint)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RecoveryDuration = -time.Second
+	require.EqualError(t, cfg.Validate(), `"recovery_duration" must not be negative`)
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.HTTP.GetOrInsertDefault().Path = "health"
+	require.EqualError(t, cfg.Validate(), `"http::path" must start with a slash`)
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package healthextension serves the health of the collector, aggregated from the status
+// of its components, over HTTP and gRPC.
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
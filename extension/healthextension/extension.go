@@ -0,0 +1,295 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+// pipelineGroupPrefix prefixes the id of the pipelines in the health document.
+const pipelineGroupPrefix = "pipeline:"
+
+var (
+	_ componentstatus.Watcher                   = (*healthExtension)(nil)
+	_ extensioncapabilities.PipelineWatcher     = (*healthExtension)(nil)
+	_ extensioncapabilities.ConfigStatusWatcher = (*healthExtension)(nil)
+)
+
+type healthExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	tracker   *healthTracker
+
+	// watchesLifecycle is whether the state of the collector is watched from the host, instead of
+	// being set when the pipelines are ready and not ready.
+	watchesLifecycle atomic.Bool
+	unwatch          func()
+
+	httpServer   *http.Server
+	grpcServer   *grpc.Server
+	healthServer *health.Server
+	stopWG       sync.WaitGroup
+
+	// The timer updating the gRPC serving statuses when a recoverable error outlasts the recovery duration.
+	recheckMu sync.Mutex
+	recheck   *time.Timer
+	stopped   bool
+}
+
+func newHealthExtension(config *Config, telemetry component.TelemetrySettings) *healthExtension {
+	he := &healthExtension{
+		config:    config,
+		telemetry: telemetry,
+		tracker:   newHealthTracker(config.RecoveryDuration),
+	}
+	if config.GRPC.HasValue() {
+		// The health server is created upfront, as the status events can be reported before the extension starts.
+		he.healthServer = health.NewServer()
+	}
+	return he
+}
+
+func (he *healthExtension) Start(ctx context.Context, host component.Host) error {
+	if lc, ok := host.(hostcapabilities.Lifecycle); ok {
+		he.watchesLifecycle.Store(true)
+		he.unwatch = lc.WatchLifecycleState(he.lifecycleStateChanged)
+	}
+	if he.config.HTTP.HasValue() {
+		if err := he.startHTTP(ctx, host); err != nil {
+			return err
+		}
+	}
+	if he.config.GRPC.HasValue() {
+		if err := he.startGRPC(ctx, host); err != nil {
+			return errors.Join(err, he.Shutdown(ctx))
+		}
+	}
+	return nil
+}
+
+func (he *healthExtension) startHTTP(ctx context.Context, host component.Host) error {
+	cfg := he.config.HTTP.Get()
+	mux := http.NewServeMux()
+	mux.HandleFunc(cfg.Path, he.handleHealth)
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := cfg.ToListener(ctx)
+	if err != nil {
+		return err
+	}
+	he.httpServer, err = cfg.ToServer(ctx, host, he.telemetry, mux)
+	if err != nil {
+		return errors.Join(err, ln.Close())
+	}
+
+	he.telemetry.Logger.Info("Starting health HTTP server", zap.String("endpoint", cfg.Endpoint), zap.String("path", cfg.Path))
+	he.stopWG.Add(1)
+	go func() {
+		defer he.stopWG.Done()
+		if errHTTP := he.httpServer.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+	return nil
+}
+
+func (he *healthExtension) startGRPC(ctx context.Context, host component.Host) error {
+	cfg := he.config.GRPC.Get()
+	var err error
+	if he.grpcServer, err = cfg.ToServer(ctx, host, he.telemetry); err != nil {
+		return err
+	}
+	healthpb.RegisterHealthServer(he.grpcServer, he.healthServer)
+	he.updateServingStatus()
+
+	ln, err := cfg.NetAddr.Listen(ctx)
+	if err != nil {
+		return err
+	}
+
+	he.telemetry.Logger.Info("Starting health gRPC server", zap.String("endpoint", cfg.NetAddr.Endpoint))
+	he.stopWG.Add(1)
+	go func() {
+		defer he.stopWG.Done()
+		if errGrpc := he.grpcServer.Serve(ln); errGrpc != nil && !errors.Is(errGrpc, grpc.ErrServerStopped) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errGrpc))
+		}
+	}()
+	return nil
+}
+
+func (he *healthExtension) Shutdown(context.Context) error {
+	if he.unwatch != nil {
+		he.unwatch()
+	}
+	he.recheckMu.Lock()
+	he.stopped = true
+	if he.recheck != nil {
+		he.recheck.Stop()
+	}
+	he.recheckMu.Unlock()
+
+	var err error
+	if he.httpServer != nil {
+		err = he.httpServer.Close()
+	}
+	if he.grpcServer != nil {
+		// Let the clients watching the health know the server is going away.
+		he.healthServer.Shutdown()
+		he.grpcServer.Stop()
+	}
+	he.stopWG.Wait()
+	return err
+}
+
+// ComponentStatusChanged records the status of the component.
+func (he *healthExtension) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	he.tracker.record(source, event)
+	he.updateServingStatus()
+	he.scheduleRecheck()
+}
+
+// NotifyConfigStatus records whether the collector failed to reload its configuration.
+func (he *healthExtension) NotifyConfigStatus(err error) {
+	he.tracker.recordConfig(err, time.Now())
+	he.updateServingStatus()
+	he.scheduleRecheck()
+}
+
+// scheduleRecheck updates the gRPC serving statuses again when the next recoverable error
+// outlasts the recovery duration.
+func (he *healthExtension) scheduleRecheck() {
+	deadline, ok := he.tracker.nextDeadline(time.Now())
+	if !ok {
+		return
+	}
+	he.recheckMu.Lock()
+	defer he.recheckMu.Unlock()
+	if he.stopped {
+		return
+	}
+	if he.recheck == nil {
+		he.recheck = time.AfterFunc(time.Until(deadline), func() {
+			he.updateServingStatus()
+			he.scheduleRecheck()
+		})
+		return
+	}
+	he.recheck.Reset(time.Until(deadline))
+}
+
+// lifecycleStateChanged records the state of the collector in its lifecycle.
+func (he *healthExtension) lifecycleStateChanged(state hostcapabilities.LifecycleState) {
+	he.tracker.setLifecycleState(state)
+	he.updateServingStatus()
+}
+
+// Ready marks the collector healthy, once all its pipelines are started, when the host does not
+// provide its lifecycle state.
+func (he *healthExtension) Ready() error {
+	if !he.watchesLifecycle.Load() {
+		he.lifecycleStateChanged(hostcapabilities.LifecycleReady)
+	}
+	return nil
+}
+
+// NotReady marks the collector unhealthy, before its pipelines are stopped, when the host does not
+// provide its lifecycle state.
+func (he *healthExtension) NotReady() error {
+	if !he.watchesLifecycle.Load() {
+		he.lifecycleStateChanged(hostcapabilities.LifecycleDraining)
+	}
+	return nil
+}
+
+// updateServingStatus sets the gRPC serving status of the collector, as the empty service, and of each
+// pipeline, as the service named after the pipeline id.
+func (he *healthExtension) updateServingStatus() {
+	if he.healthServer == nil {
+		return
+	}
+	h := he.tracker.health(time.Now())
+	he.healthServer.SetServingStatus("", servingStatus(h))
+	for group, gh := range h.Components {
+		if id, ok := strings.CutPrefix(group, pipelineGroupPrefix); ok {
+			he.healthServer.SetServingStatus(id, servingStatus(gh))
+		}
+	}
+}
+
+func servingStatus(h *Health) healthpb.HealthCheckResponse_ServingStatus {
+	if h.Healthy {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+// handleHealth serves the health of the collector, or of the pipeline given by the "pipeline" query
+// parameter, as JSON. The status code is 200 if healthy, and 503 otherwise.
+func (he *healthExtension) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if probe := r.URL.Query().Get("probe"); probe != "" {
+		he.handleProbe(w, probe)
+		return
+	}
+	h := he.tracker.health(time.Now())
+	if id := r.URL.Query().Get("pipeline"); id != "" {
+		var ok bool
+		if h, ok = h.Components[pipelineGroupPrefix+id]; !ok {
+			http.Error(w, "unknown pipeline "+id, http.StatusNotFound)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if h.Healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(h); err != nil {
+		he.telemetry.Logger.Warn("Failed to write the health", zap.Error(err))
+	}
+}
+
+// handleProbe serves the startup, readiness or liveness probe of the collector, from the state of
+// the collector in its lifecycle. The status code is 200 if the probe passes, and 503 otherwise.
+func (he *healthExtension) handleProbe(w http.ResponseWriter, probe string) {
+	state := he.tracker.lifecycleState()
+	var pass bool
+	switch probe {
+	case "startup":
+		pass = state.Started()
+	case "readiness":
+		pass = state.Ready()
+	case "liveness":
+		pass = state.Alive()
+	default:
+		http.Error(w, "unknown probe "+probe, http.StatusBadRequest)
+		return
+	}
+	if !pass {
+		http.Error(w, state.String(), http.StatusServiceUnavailable)
+		return
+	}
+	_, _ = io.WriteString(w, state.String()+"\n")
+}
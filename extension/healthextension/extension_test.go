@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+func getHealth(t *testing.T, url string) (int, *Health) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return resp.StatusCode, nil
+	}
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var h Health
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&h))
+	return resp.StatusCode, &h
+}
+
+func TestHealthExtensionHTTP(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	httpCfg := cfg.HTTP.GetOrInsertDefault()
+	httpCfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	url := "http://" + httpCfg.Endpoint + defaultPath
+
+	he := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, he.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, he.Shutdown(context.Background())) })
+
+	code, h := getHealth(t, url)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, h.Healthy)
+
+	he.ComponentStatusChanged(otlpReceiver, componentstatus.NewEvent(componentstatus.StatusOK))
+	he.ComponentStatusChanged(debugExporter, componentstatus.NewEvent(componentstatus.StatusOK))
+	require.NoError(t, he.Ready())
+	code, h = getHealth(t, url)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, h.Healthy)
+	assert.Equal(t, componentstatus.StatusOK.String(), h.Components["pipeline:traces"].Components["exporter:debug"].Status)
+
+	he.ComponentStatusChanged(debugExporter, componentstatus.NewPermanentErrorEvent(assert.AnError))
+	code, h = getHealth(t, url)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, componentstatus.StatusPermanentError.String(), h.Status)
+
+	// The health of a single pipeline.
+	code, h = getHealth(t, url+"?pipeline=metrics")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"receiver:otlp"}, keys(h.Components))
+	code, _ = getHealth(t, url+"?pipeline=logs")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	require.NoError(t, he.NotReady())
+	code, _ = getHealth(t, url+"?pipeline=metrics")
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = getHealth(t, url)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+// lifecycleHost is a host providing the lifecycle state of the collector.
+type lifecycleHost struct {
+	component.Host
+	state   hostcapabilities.LifecycleState
+	watcher func(hostcapabilities.LifecycleState)
+}
+
+func (h *lifecycleHost) LifecycleState() hostcapabilities.LifecycleState {
+	return h.state
+}
+
+func (h *lifecycleHost) WatchLifecycleState(fn func(hostcapabilities.LifecycleState)) func() {
+	fn(h.state)
+	h.watcher = fn
+	return func() { h.watcher = nil }
+}
+
+func (h *lifecycleHost) setState(state hostcapabilities.LifecycleState) {
+	h.state = state
+	if h.watcher != nil {
+		h.watcher(state)
+	}
+}
+
+func getProbe(t *testing.T, url string) int {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestHealthExtensionLifecycle(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	httpCfg := cfg.HTTP.GetOrInsertDefault()
+	httpCfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	url := "http://" + httpCfg.Endpoint + defaultPath
+
+	host := &lifecycleHost{Host: componenttest.NewNopHost()}
+	he := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, he.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, he.Shutdown(context.Background())) })
+
+	assert.Equal(t, http.StatusServiceUnavailable, getProbe(t, url+"?probe=startup"))
+	assert.Equal(t, http.StatusOK, getProbe(t, url+"?probe=liveness"))
+	assert.Equal(t, http.StatusBadRequest, getProbe(t, url+"?probe=unknown"))
+
+	// The readiness of the pipelines is ignored, the state of the collector being watched.
+	require.NoError(t, he.Ready())
+	code, h := getHealth(t, url)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "Starting", h.Lifecycle)
+
+	// A degraded collector is ready.
+	host.setState(hostcapabilities.LifecycleDegraded)
+	assert.Equal(t, http.StatusOK, getProbe(t, url+"?probe=startup"))
+	assert.Equal(t, http.StatusOK, getProbe(t, url+"?probe=readiness"))
+	code, h = getHealth(t, url)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Degraded", h.Lifecycle)
+
+	host.setState(hostcapabilities.LifecycleDraining)
+	assert.Equal(t, http.StatusServiceUnavailable, getProbe(t, url+"?probe=readiness"))
+	host.setState(hostcapabilities.LifecycleStopped)
+	assert.Equal(t, http.StatusServiceUnavailable, getProbe(t, url+"?probe=liveness"))
+}
+
+func keys(m map[string]*Health) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	return ks
+}
+
+func TestHealthExtensionGRPC(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RecoveryDuration = 100 * time.Millisecond
+	grpcCfg := cfg.GRPC.GetOrInsertDefault()
+	grpcCfg.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	he := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, he.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, he.Shutdown(context.Background())) })
+
+	conn, err := grpc.NewClient(grpcCfg.NetAddr.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+	client := healthpb.NewHealthClient(conn)
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, errCheck := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, errCheck)
+		return resp.Status
+	}
+
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+
+	he.ComponentStatusChanged(otlpReceiver, componentstatus.NewEvent(componentstatus.StatusOK))
+	he.ComponentStatusChanged(debugExporter, componentstatus.NewEvent(componentstatus.StatusOK))
+	require.NoError(t, he.Ready())
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("traces"))
+
+	// The recoverable error makes the pipeline unhealthy once it outlasts the recovery duration.
+	he.ComponentStatusChanged(debugExporter, componentstatus.NewRecoverableErrorEvent(assert.AnError))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("traces"))
+	assert.Eventually(t, func() bool {
+		return check("traces") == healthpb.HealthCheckResponse_NOT_SERVING
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check("metrics"))
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "logs"})
+	require.Error(t, err)
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/healthextension/internal/metadata"
+)
+
+const (
+	defaultHTTPEndpoint = "localhost:13133"
+	defaultGRPCEndpoint = "localhost:13132"
+	defaultPath         = "/health"
+)
+
+// NewFactory creates a factory for the health extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	httpCfg := confighttp.NewDefaultServerConfig()
+	httpCfg.Endpoint = defaultHTTPEndpoint
+	grpcCfg := configgrpc.NewDefaultServerConfig()
+	grpcCfg.NetAddr.Endpoint = defaultGRPCEndpoint
+
+	return &Config{
+		HTTP: configoptional.Default(HTTPConfig{
+			ServerConfig: httpCfg,
+			Path:         defaultPath,
+		}),
+		GRPC:             configoptional.Default(grpcCfg),
+		RecoveryDuration: time.Minute,
+	}
+}
+
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	oCfg := *cfg.(*Config)
+	if !oCfg.HTTP.HasValue() && !oCfg.GRPC.HasValue() {
+		oCfg.HTTP.GetOrInsertDefault()
+	}
+	return newHealthExtension(&oCfg, set.TelemetrySettings), nil
+}
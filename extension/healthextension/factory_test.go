@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/extension/healthextension/internal/metadata"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.False(t, cfg.HTTP.HasValue())
+	assert.False(t, cfg.GRPC.HasValue())
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+	require.NoError(t, cfg.Validate())
+}
+
+func TestFactoryCreate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	ext, err := create(context.Background(), extensiontest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+
+	// The HTTP endpoint is served when no protocol is configured, without changing the configuration.
+	he := ext.(*healthExtension)
+	require.True(t, he.config.HTTP.HasValue())
+	assert.Equal(t, defaultHTTPEndpoint, he.config.HTTP.Get().Endpoint)
+	assert.Equal(t, defaultPath, he.config.HTTP.Get().Path)
+	assert.False(t, he.config.GRPC.HasValue())
+	assert.False(t, cfg.HTTP.HasValue())
+}
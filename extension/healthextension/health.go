@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+// extensionsGroup is the key of the group of the extensions in the health document,
+// next to the pipelines keyed by "pipeline:<pipeline id>".
+const extensionsGroup = "extensions"
+
+// configGroup is the key of the group of the status of the collector configuration in the health document.
+const configGroup = "config"
+
+// severity orders the statuses when aggregating them, the status of a group being the
+// most severe status of its components.
+var severity = map[componentstatus.Status]int{
+	componentstatus.StatusNone:             0,
+	componentstatus.StatusOK:               1,
+	componentstatus.StatusStarting:         2,
+	componentstatus.StatusStopped:          3,
+	componentstatus.StatusStopping:         4,
+	componentstatus.StatusRecoverableError: 5,
+	componentstatus.StatusPermanentError:   6,
+	componentstatus.StatusFatalError:       7,
+}
+
+// Health is the health of the collector, of a pipeline or of a component, as served by the extension.
+type Health struct {
+	// Lifecycle is the state of the collector in its lifecycle, only set for the collector.
+	Lifecycle string `json:"lifecycle,omitempty"`
+	// Healthy is whether the collector, all the components of the pipeline or the component are healthy.
+	Healthy bool `json:"healthy"`
+	// Status is the status of the component, or the most severe status of the components of the group.
+	Status string `json:"status"`
+	// Error is the error reported with the status of the component.
+	Error string `json:"error,omitempty"`
+	// StatusTime is when the component reported its status.
+	StatusTime *time.Time `json:"status_time,omitempty"`
+	// Components are the pipelines and extensions of the collector, or the components of a pipeline.
+	Components map[string]*Health `json:"components,omitempty"`
+}
+
+// componentHealth is the last status event reported by a component instance.
+type componentHealth struct {
+	status componentstatus.Status
+	err    error
+	time   time.Time
+}
+
+// healthy reports whether the component is running, or has been reporting a recoverable error for less
+// than the recovery duration.
+func (c componentHealth) healthy(now time.Time, recoveryDuration time.Duration) bool {
+	switch c.status {
+	case componentstatus.StatusOK:
+		return true
+	case componentstatus.StatusRecoverableError:
+		return now.Sub(c.time) < recoveryDuration
+	}
+	return false
+}
+
+// healthTracker aggregates the status events of the components into the health of the
+// collector, of each pipeline and of each component.
+type healthTracker struct {
+	recoveryDuration time.Duration
+
+	mu    sync.Mutex
+	state hostcapabilities.LifecycleState
+	// The components of each group, keyed by "<kind>:<component id>".
+	groups map[string]map[string]componentHealth
+}
+
+func newHealthTracker(recoveryDuration time.Duration) *healthTracker {
+	return &healthTracker{
+		recoveryDuration: recoveryDuration,
+		groups:           make(map[string]map[string]componentHealth),
+	}
+}
+
+// record records the status event of a component instance in the groups it belongs to: its
+// pipelines, or the extensions group.
+func (t *healthTracker) record(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	key := strings.ToLower(source.Kind().String()) + ":" + source.ComponentID().String()
+	ch := componentHealth{status: event.Status(), err: event.Err(), time: event.Timestamp()}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	grouped := false
+	source.AllPipelineIDs(func(id pipeline.ID) bool {
+		t.set("pipeline:"+id.String(), key, ch)
+		grouped = true
+		return true
+	})
+	if !grouped {
+		t.set(extensionsGroup, key, ch)
+	}
+}
+
+// recordConfig records the status of the collector configuration: a recoverable error while the
+// collector runs its last good configuration, the reloaded one failing.
+func (t *healthTracker) recordConfig(err error, now time.Time) {
+	ch := componentHealth{status: componentstatus.StatusOK, time: now}
+	if err != nil {
+		ch = componentHealth{status: componentstatus.StatusRecoverableError, err: err, time: now}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.set(configGroup, configGroup, ch)
+}
+
+func (t *healthTracker) set(group, key string, ch componentHealth) {
+	if t.groups[group] == nil {
+		t.groups[group] = make(map[string]componentHealth)
+	}
+	t.groups[group][key] = ch
+}
+
+// setLifecycleState records the state of the collector in its lifecycle, which accepts data while
+// it is ready.
+func (t *healthTracker) setLifecycleState(state hostcapabilities.LifecycleState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.state = state
+}
+
+func (t *healthTracker) lifecycleState() hostcapabilities.LifecycleState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.state
+}
+
+// health returns the health of the collector at the given time. The collector is healthy
+// once all its pipelines are started, while all its components are healthy.
+func (t *healthTracker) health(now time.Time) *Health {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	overall := &Health{
+		Lifecycle:  t.state.String(),
+		Healthy:    t.state.Ready(),
+		Components: make(map[string]*Health, len(t.groups)),
+	}
+	overallStatus := componentstatus.StatusStarting
+	if t.state.Ready() {
+		overallStatus = componentstatus.StatusOK
+	}
+	for group, components := range t.groups {
+		gh := &Health{
+			Healthy:    true,
+			Components: make(map[string]*Health, len(components)),
+		}
+		groupStatus := componentstatus.StatusNone
+		for key, ch := range components {
+			statusTime := ch.time
+			h := &Health{
+				Healthy:    ch.healthy(now, t.recoveryDuration),
+				Status:     ch.status.String(),
+				StatusTime: &statusTime,
+			}
+			if ch.err != nil {
+				h.Error = ch.err.Error()
+			}
+			gh.Components[key] = h
+			gh.Healthy = gh.Healthy && h.Healthy
+			if severity[ch.status] > severity[groupStatus] {
+				groupStatus = ch.status
+			}
+		}
+		gh.Status = groupStatus.String()
+		overall.Components[group] = gh
+		overall.Healthy = overall.Healthy && gh.Healthy
+		if severity[groupStatus] > severity[overallStatus] {
+			overallStatus = groupStatus
+		}
+	}
+	overall.Status = overallStatus.String()
+	return overall
+}
+
+// nextDeadline returns the earliest time after now when a component reporting a recoverable error
+// becomes unhealthy, false if there is none.
+func (t *healthTracker) nextDeadline(now time.Time) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var next time.Time
+	for _, components := range t.groups {
+		for _, ch := range components {
+			if ch.status != componentstatus.StatusRecoverableError {
+				continue
+			}
+			if deadline := ch.time.Add(t.recoveryDuration); deadline.After(now) && (next.IsZero() || deadline.Before(next)) {
+				next = deadline
+			}
+		}
+	}
+	return next, !next.IsZero()
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+var (
+	tracesID  = pipeline.NewID(pipeline.SignalTraces)
+	metricsID = pipeline.NewID(pipeline.SignalMetrics)
+
+	otlpReceiver  = componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindReceiver, tracesID, metricsID)
+	debugExporter = componentstatus.NewInstanceID(component.MustNewID("debug"), component.KindExporter, tracesID)
+	zpages        = componentstatus.NewInstanceID(component.MustNewID("zpages"), component.KindExtension)
+)
+
+func TestHealthTracker(t *testing.T) {
+	tr := newHealthTracker(time.Minute)
+	now := time.Now()
+	h := tr.health(now)
+	assert.False(t, h.Healthy)
+	assert.Equal(t, componentstatus.StatusStarting.String(), h.Status)
+	assert.Empty(t, h.Components)
+
+	tr.record(otlpReceiver, componentstatus.NewEvent(componentstatus.StatusOK))
+	tr.record(debugExporter, componentstatus.NewEvent(componentstatus.StatusOK))
+	tr.record(zpages, componentstatus.NewEvent(componentstatus.StatusOK))
+	tr.setLifecycleState(hostcapabilities.LifecycleReady)
+	h = tr.health(now)
+	assert.True(t, h.Healthy)
+	assert.Equal(t, componentstatus.StatusOK.String(), h.Status)
+	require.Len(t, h.Components, 3)
+	assert.Len(t, h.Components["pipeline:traces"].Components, 2)
+	assert.Contains(t, h.Components["pipeline:traces"].Components, "receiver:otlp")
+	assert.Contains(t, h.Components["pipeline:traces"].Components, "exporter:debug")
+	assert.Contains(t, h.Components["pipeline:metrics"].Components, "receiver:otlp")
+	assert.Contains(t, h.Components[extensionsGroup].Components, "extension:zpages")
+
+	tr.setLifecycleState(hostcapabilities.LifecycleDraining)
+	assert.False(t, tr.health(now).Healthy)
+}
+
+func TestHealthTrackerRecoverableError(t *testing.T) {
+	tr := newHealthTracker(time.Minute)
+	tr.setLifecycleState(hostcapabilities.LifecycleReady)
+	tr.record(otlpReceiver, componentstatus.NewEvent(componentstatus.StatusOK))
+	ev := componentstatus.NewRecoverableErrorEvent(assert.AnError)
+	tr.record(debugExporter, ev)
+
+	// The recoverable error is tolerated during the recovery duration.
+	h := tr.health(ev.Timestamp())
+	assert.True(t, h.Healthy)
+	assert.Equal(t, componentstatus.StatusRecoverableError.String(), h.Status)
+	traces := h.Components["pipeline:traces"]
+	assert.True(t, traces.Healthy)
+	assert.Equal(t, assert.AnError.Error(), traces.Components["exporter:debug"].Error)
+	assert.Equal(t, ev.Timestamp(), *traces.Components["exporter:debug"].StatusTime)
+	deadline, ok := tr.nextDeadline(ev.Timestamp())
+	require.True(t, ok)
+	assert.Equal(t, ev.Timestamp().Add(time.Minute), deadline)
+
+	h = tr.health(deadline)
+	assert.False(t, h.Healthy)
+	assert.False(t, h.Components["pipeline:traces"].Healthy)
+	assert.True(t, h.Components["pipeline:metrics"].Healthy)
+	_, ok = tr.nextDeadline(deadline)
+	assert.False(t, ok)
+
+	tr.record(debugExporter, componentstatus.NewEvent(componentstatus.StatusOK))
+	assert.True(t, tr.health(deadline).Healthy)
+}
+
+func TestHealthTrackerMostSevereStatus(t *testing.T) {
+	tr := newHealthTracker(time.Minute)
+	tr.setLifecycleState(hostcapabilities.LifecycleReady)
+	tr.record(otlpReceiver, componentstatus.NewPermanentErrorEvent(assert.AnError))
+	tr.record(debugExporter, componentstatus.NewRecoverableErrorEvent(assert.AnError))
+	tr.record(zpages, componentstatus.NewEvent(componentstatus.StatusStopping))
+
+	h := tr.health(time.Now())
+	assert.False(t, h.Healthy)
+	assert.Equal(t, componentstatus.StatusPermanentError.String(), h.Status)
+	assert.Equal(t, componentstatus.StatusPermanentError.String(), h.Components["pipeline:metrics"].Status)
+	assert.Equal(t, componentstatus.StatusStopping.String(), h.Components[extensionsGroup].Status)
+	assert.False(t, h.Components[extensionsGroup].Healthy)
+}
+
+func TestHealthTrackerConfig(t *testing.T) {
+	tr := newHealthTracker(time.Minute)
+	tr.setLifecycleState(hostcapabilities.LifecycleReady)
+	now := time.Now()
+	tr.recordConfig(assert.AnError, now)
+
+	// The stale configuration is tolerated during the recovery duration.
+	h := tr.health(now)
+	assert.True(t, h.Healthy)
+	assert.Equal(t, componentstatus.StatusRecoverableError.String(), h.Components[configGroup].Status)
+	assert.Equal(t, assert.AnError.Error(), h.Components[configGroup].Components[configGroup].Error)
+	assert.False(t, tr.health(now.Add(time.Minute)).Healthy)
+
+	tr.recordConfig(nil, now.Add(time.Minute))
+	h = tr.health(now.Add(time.Minute))
+	assert.True(t, h.Healthy)
+	assert.Equal(t, componentstatus.StatusOK.String(), h.Components[configGroup].Status)
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "go.opentelemetry.io/collector/extension/opampextension"
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+)
+
+// Config has the configuration of the opamp extension.
+type Config struct {
+	// Server configures the HTTP client of the OpAMP server, whose endpoint is required,
+	// e.g. https://opamp.example.com/v1/opamp.
+	Server confighttp.ClientConfig `mapstructure:"server"`
+
+	// PollingInterval is the interval between the messages sent to the server, which
+	// replies with its messages to the collector.
+	// (default = 30s)
+	PollingInterval time.Duration `mapstructure:"polling_interval"`
+
+	// InstanceUID is the UUID identifying the collector to the server. A UUID v7 is generated
+	// when the collector starts if empty.
+	InstanceUID string `mapstructure:"instance_uid"`
+
+	// AgentDescription configures the description of the collector reported to the server.
+	AgentDescription AgentDescription `mapstructure:"agent_description"`
+
+	// Capabilities configures what the collector reports to the server.
+	Capabilities Capabilities `mapstructure:"capabilities"`
+
+	// RemoteConfig configures the remote configuration of the collector, accepted from the server
+	// only if configured.
+	RemoteConfig configoptional.Optional[RemoteConfig] `mapstructure:"remote_config"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// AgentDescription has the configuration of the description of the collector.
+type AgentDescription struct {
+	// NonIdentifyingAttributes are added to, or override, the non identifying attributes of the collector
+	// reported to the server: host.name, os.type and host.arch.
+	NonIdentifyingAttributes map[string]string `mapstructure:"non_identifying_attributes"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Capabilities has the configuration of what the collector reports to the server.
+type Capabilities struct {
+	// ReportsEffectiveConfig reports the configuration of the collector, without the values of the
+	// keys looking like secrets.
+	// (default = true)
+	ReportsEffectiveConfig bool `mapstructure:"reports_effective_config"`
+
+	// ReportsHealth reports the health of the collector and of its components.
+	// (default = true)
+	ReportsHealth bool `mapstructure:"reports_health"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// RemoteConfig has the configuration of the remote configuration.
+type RemoteConfig struct {
+	// Path is the file the remote configuration is written to, loaded by the collector with
+	// the opamp config provider, e.g. --config=opamp:/var/lib/otelcol/remote.yaml.
+	Path string `mapstructure:"path"`
+
+	// FeatureGates sets the runtime safe feature gates with the remote configuration file named
+	// "feature_gates", mapping their ID to whether they are enabled, instead of merging it in the
+	// configuration of the collector.
+	// (default = false)
+	FeatureGates bool `mapstructure:"feature_gates"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var (
+	_ component.Config                 = (*Config)(nil)
+	_ extensioncapabilities.FileWriter = (*Config)(nil)
+)
+
+// WrittenFiles returns the file the remote configuration is written to, if enabled.
+func (cfg *Config) WrittenFiles() []string {
+	if !cfg.RemoteConfig.HasValue() {
+		return nil
+	}
+	return []string{cfg.RemoteConfig.Get().Path}
+}
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Server.Endpoint == "" {
+		return errors.New("\"server::endpoint\" must be specified")
+	}
+	if cfg.PollingInterval <= 0 {
+		return errors.New("\"polling_interval\" must be positive")
+	}
+	if cfg.InstanceUID != "" {
+		if _, err := uuid.Parse(cfg.InstanceUID); err != nil {
+			return errors.New("\"instance_uid\" must be a UUID")
+		}
+	}
+	if cfg.RemoteConfig.HasValue() && cfg.RemoteConfig.Get().Path == "" {
+		return errors.New("\"remote_config::path\" must be specified")
+	}
+	return nil
+}
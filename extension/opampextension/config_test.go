@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+
+	server := confighttp.NewDefaultClientConfig()
+	server.Endpoint = "https://opamp.example.com/v1/opamp"
+	assert.Equal(t, &Config{
+		Server:          server,
+		PollingInterval: 10 * time.Second,
+		InstanceUID:     "0199b2c4-6f2e-7c3a-9d41-5e8f0a1b2c3d",
+		AgentDescription: AgentDescription{
+			NonIdentifyingAttributes: map[string]string{"deployment.environment": "production"},
+		},
+		Capabilities: Capabilities{ReportsHealth: true},
+		RemoteConfig: configoptional.Some(RemoteConfig{Path: "/var/lib/otelcol/remote.yaml", FeatureGates: true}),
+	}, cfg)
+	require.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{
+			name:    "missing endpoint",
+			modify:  func(cfg *Config) { cfg.Server.Endpoint = "" },
+			wantErr: `"server::endpoint" must be specified`,
+		},
+		{
+			name:    "zero polling interval",
+			modify:  func(cfg *Config) { cfg.PollingInterval = 0 },
+			wantErr: `"polling_interval" must be positive`,
+		},
+		{
+			name:    "invalid instance uid",
+			modify:  func(cfg *Config) { cfg.InstanceUID = "collector-1" },
+			wantErr: `"instance_uid" must be a UUID`,
+		},
+		{
+			name:    "missing remote config path",
+			modify:  func(cfg *Config) { cfg.RemoteConfig = configoptional.Some(RemoteConfig{}) },
+			wantErr: `"remote_config::path" must be specified`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Server.Endpoint = "http://localhost:4320/v1/opamp"
+			tt.modify(cfg)
+			require.EqualError(t, cfg.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestWrittenFiles(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Empty(t, cfg.WrittenFiles())
+	cfg.RemoteConfig = configoptional.Some(RemoteConfig{Path: "/var/lib/otelcol/remote.yaml"})
+	assert.Equal(t, []string{"/var/lib/otelcol/remote.yaml"}, cfg.WrittenFiles())
+}
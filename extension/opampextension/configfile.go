@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "go.opentelemetry.io/collector/extension/opampextension"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.yaml.in/yaml/v3"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/internal/redact"
+)
+
+// marshalEffectiveConfig returns the configuration of the collector as YAML, without the values of the
+// keys looking like secrets.
+func marshalEffectiveConfig(conf *confmap.Conf) ([]byte, error) {
+	return yaml.Marshal(redact.ByKey("", conf.ToStringMap()))
+}
+
+// writeRemoteConfig merges the remote configuration files, in the order of their names, and writes the
+// result to the file. It returns false if the file already had this configuration.
+func writeRemoteConfig(path string, files map[string][]byte) (bool, error) {
+	conf := confmap.New()
+	for _, name := range sortedKeys(files) {
+		var raw map[string]any
+		if err := yaml.Unmarshal(files[name], &raw); err != nil {
+			return false, fmt.Errorf("invalid remote configuration file %q: %w", name, err)
+		}
+		if err := conf.Merge(confmap.NewFromStringMap(raw)); err != nil {
+			return false, err
+		}
+	}
+	content, err := yaml.Marshal(conf.ToStringMap())
+	if err != nil {
+		return false, err
+	}
+
+	prev, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(prev, content) {
+		return false, nil
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return false, err
+	}
+	// Replace the file at once, so that the collector never loads a partial configuration.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return false, err
+	}
+	_, err = tmp.Write(content)
+	err = errors.Join(err, tmp.Close())
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		return false, errors.Join(err, os.Remove(tmp.Name()))
+	}
+	return true, nil
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestMarshalEffectiveConfig(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"extensions": map[string]any{"basicauth": map[string]any{
+			"client_auth": map[string]any{"username": "user", "password": "pass"},
+		}},
+		"exporters": map[string]any{"otlphttp": map[string]any{
+			"endpoint": "https://example.com",
+			"headers":  map[string]any{"x-api-key": "key"},
+			"api_key":  []any{"first", "second"},
+			"timeout":  nil,
+		}},
+	})
+	effective, err := marshalEffectiveConfig(conf)
+	require.NoError(t, err)
+	assert.YAMLEq(t, `
+extensions:
+  basicauth:
+    client_auth:
+      username: user
+      password: "[REDACTED]"
+exporters:
+  otlphttp:
+    endpoint: https://example.com
+    headers:
+      x-api-key: "[REDACTED]"
+    api_key: ["[REDACTED]", "[REDACTED]"]
+    timeout: null
+`, string(effective))
+}
+
+func TestWriteRemoteConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "remote.yaml")
+	files := map[string][]byte{
+		"base":     []byte("processors:\n  batch: {}\nservice:\n  pipelines: {}\n"),
+		"override": []byte("processors:\n  batch:\n    timeout: 1s\n"),
+	}
+	changed, err := writeRemoteConfig(path, files)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.YAMLEq(t, "processors:\n  batch:\n    timeout: 1s\nservice:\n  pipelines: {}\n", string(content))
+
+	changed, err = writeRemoteConfig(path, files)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// An empty remote configuration clears the file.
+	changed, err = writeRemoteConfig(path, nil)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{}\n", string(content))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package opampextension implements the client side of the OpAMP protocol, reporting the description,
+// health and effective configuration of the collector to an OpAMP server, and accepting its remote
+// configuration.
+package opampextension // import "go.opentelemetry.io/collector/extension/opampextension"
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "go.opentelemetry.io/collector/extension/opampextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/opampextension/internal/metadata"
+)
+
+// NewFactory creates a factory for the opamp extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Server:          confighttp.NewDefaultClientConfig(),
+		PollingInterval: 30 * time.Second,
+		Capabilities: Capabilities{
+			ReportsEffectiveConfig: true,
+			ReportsHealth:          true,
+		},
+	}
+}
+
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newOpAMPExtension(cfg.(*Config), set)
+}
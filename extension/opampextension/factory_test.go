@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/extension/opampextension/internal/metadata"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, componenttest.CheckConfigStruct(cfg))
+	// The endpoint of the server must be configured.
+	require.Error(t, cfg.Validate())
+	assert.True(t, cfg.Capabilities.ReportsEffectiveConfig)
+	assert.True(t, cfg.Capabilities.ReportsHealth)
+	assert.False(t, cfg.RemoteConfig.HasValue())
+}
+
+func TestFactoryCreate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Server.Endpoint = "http://localhost:4320/v1/opamp"
+	ext, err := create(context.Background(), extensiontest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+
+	// The generated instance uid is kept when the extension is recreated.
+	ext2, err := create(context.Background(), extensiontest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	uid := ext.(*opampExtension).instanceUID
+	assert.Equal(t, uid, ext2.(*opampExtension).instanceUID)
+	assert.Equal(t, uuid.Version(7), uuid.UUID(uid).Version())
+
+	cfg.InstanceUID = "0199b2c4-6f2e-7c3a-9d41-5e8f0a1b2c3d"
+	ext, err = create(context.Background(), extensiontest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.MustParse(cfg.InstanceUID), uuid.UUID(ext.(*opampExtension).instanceUID))
+}
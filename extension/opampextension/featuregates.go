@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "go.opentelemetry.io/collector/extension/opampextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.yaml.in/yaml/v3"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// featureGatesFile is the name of the remote configuration file setting the feature gates, see RemoteConfig.FeatureGates.
+const featureGatesFile = "feature_gates"
+
+// setFeatureGates sets the runtime safe feature gates of the file, mapping their ID to whether they are enabled.
+// No gate is set unless all of them are runtime safe.
+func setFeatureGates(reg *featuregate.Registry, content []byte) error {
+	var enabled map[string]bool
+	if err := yaml.Unmarshal(content, &enabled); err != nil {
+		return fmt.Errorf("invalid remote configuration file %q: %w", featureGatesFile, err)
+	}
+
+	gates := make(map[string]*featuregate.Gate)
+	reg.VisitAll(func(g *featuregate.Gate) {
+		gates[g.ID()] = g
+	})
+	var errs error
+	for _, id := range sortedKeys(enabled) {
+		switch g, ok := gates[id]; {
+		case !ok:
+			errs = errors.Join(errs, fmt.Errorf("no such feature gate %q", id))
+		case !g.IsRuntimeSafe():
+			errs = errors.Join(errs, fmt.Errorf("feature gate %q is not runtime safe", id))
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+	for _, id := range sortedKeys(enabled) {
+		errs = errors.Join(errs, reg.SetAtRuntime(id, enabled[id]))
+	}
+	return errs
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func TestSetFeatureGates(t *testing.T) {
+	reg := featuregate.NewRegistry()
+	alpha := reg.MustRegister("alpha", featuregate.StageAlpha, featuregate.WithRegisterRuntimeSafe())
+	beta := reg.MustRegister("beta", featuregate.StageBeta, featuregate.WithRegisterRuntimeSafe())
+	unsafe := reg.MustRegister("unsafe", featuregate.StageAlpha)
+
+	require.NoError(t, setFeatureGates(reg, []byte("alpha: true\nbeta: false\n")))
+	assert.True(t, alpha.IsEnabled())
+	assert.False(t, beta.IsEnabled())
+
+	// No gate is set if one of them cannot be.
+	err := setFeatureGates(reg, []byte("alpha: false\nunsafe: true\nmissing: true\n"))
+	require.ErrorContains(t, err, `feature gate "unsafe" is not runtime safe`)
+	require.ErrorContains(t, err, `no such feature gate "missing"`)
+	assert.True(t, alpha.IsEnabled())
+	assert.False(t, unsafe.IsEnabled())
+
+	require.ErrorContains(t, setFeatureGates(reg, []byte("alpha: [")), `invalid remote configuration file "feature_gates"`)
+	require.Error(t, setFeatureGates(reg, []byte("alpha: maybe\n")))
+	require.NoError(t, setFeatureGates(reg, nil))
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package remoteconfig tracks the remote configuration files written by the OpAMP extension, shared with the
+// opamp config provider loading them. The state lives as long as the process, as the extension is recreated
+// when the collector reloads its configuration.
+package remoteconfig // import "go.opentelemetry.io/collector/extension/opampextension/internal/remoteconfig"
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// State is the state of the last remote configuration written to a file.
+type State struct {
+	// Hash is the hash of the remote configuration, given by the server.
+	Hash []byte
+	// Status is the status of the remote configuration.
+	Status protobufs.RemoteConfigStatuses
+	// Err is the error applying the remote configuration, if it failed.
+	Err string
+}
+
+type file struct {
+	state State
+	// watchers are the watchers of the providers which loaded the file, by provider.
+	watchers map[any]confmap.WatcherFunc
+}
+
+var (
+	mu    sync.Mutex
+	files = make(map[string]*file)
+)
+
+func getFile(path string) *file {
+	path = filepath.Clean(path)
+	f, ok := files[path]
+	if !ok {
+		f = &file{watchers: make(map[any]confmap.WatcherFunc)}
+		files[path] = f
+	}
+	return f
+}
+
+// GetState returns the state of the last remote configuration written to the file.
+func GetState(path string) State {
+	mu.Lock()
+	defer mu.Unlock()
+	return getFile(path).state
+}
+
+// SetState sets the state of the last remote configuration written to the file.
+func SetState(path string, state State) {
+	mu.Lock()
+	defer mu.Unlock()
+	getFile(path).state = state
+}
+
+// Watch registers the watcher of the provider which loaded the file, replacing its previous one.
+// A nil watcher unregisters the provider.
+func Watch(path string, provider any, watcher confmap.WatcherFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	f := getFile(path)
+	if watcher == nil {
+		delete(f.watchers, provider)
+		return
+	}
+	f.watchers[provider] = watcher
+}
+
+// Unwatch unregisters the watchers of the provider from all the files.
+func Unwatch(provider any) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, f := range files {
+		delete(f.watchers, provider)
+	}
+}
+
+// Loaded returns whether a provider loaded the file.
+func Loaded(path string) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return len(getFile(path).watchers) > 0
+}
+
+// Notify notifies the providers which loaded the file that it changed, so that the collector reloads
+// its configuration.
+func Notify(path string) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, watcher := range getFile(path).watchers {
+		// The watchers can block until the collector is done reloading, which shuts down the extension.
+		go watcher(&confmap.ChangeEvent{})
+	}
+}
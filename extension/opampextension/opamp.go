@@ -0,0 +1,489 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "go.opentelemetry.io/collector/extension/opampextension"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+	"go.opentelemetry.io/collector/extension/opampextension/internal/remoteconfig"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// maxMessageSize limits the size of the messages read from the server.
+const maxMessageSize = 64 << 20
+
+var (
+	_ componentstatus.Watcher                   = (*opampExtension)(nil)
+	_ extensioncapabilities.PipelineWatcher     = (*opampExtension)(nil)
+	_ extensioncapabilities.ConfigWatcher       = (*opampExtension)(nil)
+	_ extensioncapabilities.ConfigStatusWatcher = (*opampExtension)(nil)
+)
+
+var (
+	instanceUIDsMu sync.Mutex
+	// instanceUIDs are the instance uids of the collector by configured instance uid, the empty one
+	// being generated, kept across the reloads of the configuration. The server can replace them.
+	instanceUIDs = make(map[string][]byte)
+)
+
+type opampExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	buildInfo component.BuildInfo
+	client    *http.Client
+	startTime time.Time
+
+	mu          sync.Mutex
+	instanceUID []byte
+	sequenceNum uint64
+	// reportFullState is whether the next message reports the full state of the collector, instead
+	// of what changed since the previous message.
+	reportFullState        bool
+	healthChanged          bool
+	effectiveConfigChanged bool
+	remoteConfigChanged    bool
+	ready                  bool
+	components             map[string]*protobufs.ComponentHealth
+	effectiveConfig        []byte
+
+	// trigger sends a message without waiting for the polling interval.
+	trigger chan struct{}
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func newOpAMPExtension(config *Config, set extension.Settings) (*opampExtension, error) {
+	uid, err := instanceUID(config.InstanceUID)
+	if err != nil {
+		return nil, err
+	}
+	return &opampExtension{
+		config:          config,
+		telemetry:       set.TelemetrySettings,
+		buildInfo:       set.BuildInfo,
+		instanceUID:     uid,
+		reportFullState: true,
+		components:      make(map[string]*protobufs.ComponentHealth),
+		trigger:         make(chan struct{}, 1),
+	}, nil
+}
+
+// instanceUID returns the instance uid of the collector, the configured one unless the server replaced it.
+func instanceUID(configured string) ([]byte, error) {
+	instanceUIDsMu.Lock()
+	defer instanceUIDsMu.Unlock()
+	if uid, ok := instanceUIDs[configured]; ok {
+		return uid, nil
+	}
+	var id uuid.UUID
+	var err error
+	if configured == "" {
+		id, err = uuid.NewV7()
+	} else {
+		id, err = uuid.Parse(configured)
+	}
+	if err != nil {
+		return nil, err
+	}
+	instanceUIDs[configured] = id[:]
+	return id[:], nil
+}
+
+func (oe *opampExtension) Start(ctx context.Context, host component.Host) error {
+	client, err := oe.config.Server.ToClient(ctx, host, oe.telemetry)
+	if err != nil {
+		return err
+	}
+	oe.client = client
+	oe.startTime = time.Now()
+
+	// The directory of the remote configuration is created before the collector is sandboxed, which
+	// only allows writing the existing directories.
+	if oe.config.RemoteConfig.HasValue() {
+		if err = os.MkdirAll(filepath.Dir(oe.config.RemoteConfig.Get().Path), 0o700); err != nil {
+			return err
+		}
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	oe.cancel = cancel
+	oe.done = make(chan struct{})
+	go oe.run(runCtx)
+	return nil
+}
+
+// Shutdown stops polling the server, and lets it know the collector disconnects.
+func (oe *opampExtension) Shutdown(ctx context.Context) error {
+	if oe.cancel == nil {
+		return nil
+	}
+	oe.cancel()
+	<-oe.done
+
+	msg := oe.nextMessage()
+	msg.AgentDisconnect = &protobufs.AgentDisconnect{}
+	if _, err := oe.send(ctx, msg); err != nil {
+		oe.telemetry.Logger.Warn("Failed to notify the OpAMP server of the disconnection", zap.Error(err))
+	}
+	return nil
+}
+
+func (oe *opampExtension) run(ctx context.Context) {
+	defer close(oe.done)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-oe.trigger:
+		}
+		timer.Reset(oe.poll(ctx))
+	}
+}
+
+// poll sends the next message to the server, handles its reply, and returns when to send the next one.
+func (oe *opampExtension) poll(ctx context.Context) time.Duration {
+	reply, err := oe.send(ctx, oe.nextMessage())
+	if err != nil {
+		if ctx.Err() == nil {
+			oe.telemetry.Logger.Warn("Failed to exchange messages with the OpAMP server", zap.Error(err))
+		}
+		oe.setReportFullState()
+		return oe.config.PollingInterval
+	}
+
+	if reply.GetFlags()&flagReportFullState != 0 {
+		oe.setReportFullState()
+	}
+	if uid := reply.GetAgentIdentification().GetNewInstanceUid(); len(uid) > 0 {
+		oe.setInstanceUID(uid)
+	}
+	if reply.GetRemoteConfig() != nil && oe.config.RemoteConfig.HasValue() {
+		oe.applyRemoteConfig(reply.GetRemoteConfig())
+	}
+	if errResp := reply.GetErrorResponse(); errResp != nil {
+		oe.telemetry.Logger.Warn("The OpAMP server replied with an error", zap.String("error", errResp.GetErrorMessage()))
+		oe.setReportFullState()
+		if delay := retryAfter(errResp); delay > 0 {
+			return delay
+		}
+	}
+	return oe.config.PollingInterval
+}
+
+// send sends the message to the server, and returns its reply.
+func (oe *opampExtension) send(ctx context.Context, msg *protobufs.AgentToServer) (*protobufs.ServerToAgent, error) {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, oe.config.Server.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := oe.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err = io.ReadAll(io.LimitReader(resp.Body, maxMessageSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %q", resp.Status)
+	}
+	reply := &protobufs.ServerToAgent{}
+	if err := proto.Unmarshal(body, reply); err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+	return reply, nil
+}
+
+// nextMessage returns the next message to the server, reporting what changed since the previous one.
+func (oe *opampExtension) nextMessage() *protobufs.AgentToServer {
+	oe.mu.Lock()
+	defer oe.mu.Unlock()
+	msg := &protobufs.AgentToServer{
+		InstanceUid:  oe.instanceUID,
+		SequenceNum:  oe.sequenceNum,
+		Capabilities: oe.capabilities(),
+	}
+	oe.sequenceNum++
+
+	full := oe.reportFullState
+	if full {
+		msg.AgentDescription = oe.description()
+	}
+	if oe.config.Capabilities.ReportsHealth && (full || oe.healthChanged) {
+		msg.Health = oe.health()
+	}
+	if oe.config.Capabilities.ReportsEffectiveConfig && (full || oe.effectiveConfigChanged) {
+		msg.EffectiveConfig = newEffectiveConfig(oe.effectiveConfig)
+	}
+	if oe.config.RemoteConfig.HasValue() && (full || oe.remoteConfigChanged) {
+		state := remoteconfig.GetState(oe.config.RemoteConfig.Get().Path)
+		msg.RemoteConfigStatus = &protobufs.RemoteConfigStatus{LastRemoteConfigHash: state.Hash, Status: state.Status, ErrorMessage: state.Err}
+	}
+	oe.reportFullState = false
+	oe.healthChanged = false
+	oe.effectiveConfigChanged = false
+	oe.remoteConfigChanged = false
+	return msg
+}
+
+func (oe *opampExtension) capabilities() uint64 {
+	capabilities := capabilityReportsStatus
+	if oe.config.Capabilities.ReportsEffectiveConfig {
+		capabilities |= capabilityReportsEffectiveConfig
+	}
+	if oe.config.Capabilities.ReportsHealth {
+		capabilities |= capabilityReportsHealth
+	}
+	if oe.config.RemoteConfig.HasValue() {
+		capabilities |= capabilityAcceptsRemoteConfig | capabilityReportsRemoteConfig
+	}
+	return capabilities
+}
+
+func (oe *opampExtension) description() *protobufs.AgentDescription {
+	identifying := map[string]string{
+		"service.name":        oe.buildInfo.Command,
+		"service.version":     oe.buildInfo.Version,
+		"service.instance.id": uuid.UUID(oe.instanceUID).String(),
+	}
+	nonIdentifying := map[string]string{
+		"os.type":   runtime.GOOS,
+		"host.arch": runtime.GOARCH,
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		nonIdentifying["host.name"] = hostname
+	}
+	for key, value := range oe.config.AgentDescription.NonIdentifyingAttributes {
+		nonIdentifying[key] = value
+	}
+	return newAgentDescription(identifying, nonIdentifying)
+}
+
+// unhealthyStatuses are the statuses of the unhealthy components, from the most severe.
+var unhealthyStatuses = []componentstatus.Status{
+	componentstatus.StatusFatalError,
+	componentstatus.StatusPermanentError,
+	componentstatus.StatusRecoverableError,
+	componentstatus.StatusStopping,
+	componentstatus.StatusStopped,
+}
+
+// health returns the health of the collector, healthy once all its pipelines are started while all its
+// components are running.
+func (oe *opampExtension) health() *protobufs.ComponentHealth {
+	h := &protobufs.ComponentHealth{
+		Healthy:            oe.ready,
+		StartTimeUnixNano:  unixNano(oe.startTime),
+		Status:             componentstatus.StatusStarting.String(),
+		ComponentHealthMap: maps.Clone(oe.components),
+	}
+	if oe.ready {
+		h.Status = componentstatus.StatusOK.String()
+	}
+	for _, status := range unhealthyStatuses {
+		for _, key := range sortedKeys(oe.components) {
+			if ch := oe.components[key]; ch.Status == status.String() {
+				h.Healthy = false
+				h.Status = ch.Status
+				h.LastError = ch.LastError
+				return h
+			}
+		}
+	}
+	return h
+}
+
+// ComponentStatusChanged records the status of the component.
+func (oe *opampExtension) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	key := strings.ToLower(source.Kind().String()) + ":" + source.ComponentID().String()
+	ch := &protobufs.ComponentHealth{
+		Healthy:            event.Status() == componentstatus.StatusOK,
+		Status:             event.Status().String(),
+		StatusTimeUnixNano: unixNano(event.Timestamp()),
+	}
+	if event.Err() != nil {
+		ch.LastError = event.Err().Error()
+	}
+
+	oe.mu.Lock()
+	oe.components[key] = ch
+	oe.healthChanged = true
+	oe.mu.Unlock()
+	oe.triggerSend()
+}
+
+// NotifyConfigStatus marks the remote configuration failed if the collector failed to reload it.
+func (oe *opampExtension) NotifyConfigStatus(err error) {
+	if err == nil {
+		return
+	}
+	oe.mu.Lock()
+	oe.updateRemoteConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, err.Error())
+	oe.mu.Unlock()
+	oe.triggerSend()
+}
+
+// Ready marks the collector healthy, once all its pipelines are started.
+func (oe *opampExtension) Ready() error {
+	oe.setReady(true)
+	return nil
+}
+
+// NotReady marks the collector unhealthy, before its pipelines are stopped.
+func (oe *opampExtension) NotReady() error {
+	oe.setReady(false)
+	return nil
+}
+
+func (oe *opampExtension) setReady(ready bool) {
+	oe.mu.Lock()
+	oe.ready = ready
+	oe.healthChanged = true
+	oe.mu.Unlock()
+	oe.triggerSend()
+}
+
+// NotifyConfig records the effective configuration of the collector, which includes the remote
+// configuration being applied if any.
+func (oe *opampExtension) NotifyConfig(_ context.Context, conf *confmap.Conf) error {
+	effectiveConfig, err := marshalEffectiveConfig(conf)
+	if err != nil {
+		oe.telemetry.Logger.Warn("Failed to marshal the effective configuration", zap.Error(err))
+	}
+
+	oe.mu.Lock()
+	if err == nil && !bytes.Equal(effectiveConfig, oe.effectiveConfig) {
+		oe.effectiveConfig = effectiveConfig
+		oe.effectiveConfigChanged = true
+	}
+	oe.updateRemoteConfigStatus(protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, "")
+	oe.mu.Unlock()
+	oe.triggerSend()
+	return nil
+}
+
+// updateRemoteConfigStatus sets the outcome of the remote configuration being applied, if any.
+// It must be called with the lock held.
+func (oe *opampExtension) updateRemoteConfigStatus(status protobufs.RemoteConfigStatuses, errMsg string) {
+	if !oe.config.RemoteConfig.HasValue() {
+		return
+	}
+	path := oe.config.RemoteConfig.Get().Path
+	state := remoteconfig.GetState(path)
+	if state.Status != protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING {
+		return
+	}
+	state.Status = status
+	state.Err = errMsg
+	remoteconfig.SetState(path, state)
+	oe.remoteConfigChanged = true
+}
+
+// applyRemoteConfig writes the remote configuration to its file, and notifies the opamp config providers
+// loading it. It is applied once the collector notifies the extension of its new configuration.
+func (oe *opampExtension) applyRemoteConfig(rc *protobufs.AgentRemoteConfig) {
+	path := oe.config.RemoteConfig.Get().Path
+	hash := rc.GetConfigHash()
+	if prev := remoteconfig.GetState(path); prev.Status != protobufs.RemoteConfigStatuses_RemoteConfigStatuses_UNSET && bytes.Equal(prev.Hash, hash) {
+		return
+	}
+
+	files := remoteConfigFiles(rc)
+	var err error
+	if gates, ok := files[featureGatesFile]; ok && oe.config.RemoteConfig.Get().FeatureGates {
+		delete(files, featureGatesFile)
+		if err = setFeatureGates(featuregate.GlobalRegistry(), gates); err != nil {
+			err = fmt.Errorf("failed to set the feature gates: %w", err)
+		}
+	}
+
+	state := remoteconfig.State{Hash: hash, Status: protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING}
+	changed := false
+	if err == nil {
+		changed, err = writeRemoteConfig(path, files)
+	}
+	switch {
+	case err != nil:
+		state.Status = protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED
+		state.Err = err.Error()
+	case !remoteconfig.Loaded(path):
+		state.Status = protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED
+		state.Err = fmt.Sprintf("the remote configuration is not loaded, the collector must be started with --config=opamp:%s", path)
+	case !changed:
+		state.Status = protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED
+	}
+	remoteconfig.SetState(path, state)
+
+	if state.Status == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED {
+		oe.telemetry.Logger.Warn("Failed to apply the remote configuration", zap.String("error", state.Err))
+	} else if changed {
+		oe.telemetry.Logger.Info("Applying the remote configuration", zap.String("path", path))
+		remoteconfig.Notify(path)
+	}
+
+	oe.mu.Lock()
+	oe.remoteConfigChanged = true
+	oe.mu.Unlock()
+	oe.triggerSend()
+}
+
+func (oe *opampExtension) setReportFullState() {
+	oe.mu.Lock()
+	defer oe.mu.Unlock()
+	oe.reportFullState = true
+}
+
+func (oe *opampExtension) setInstanceUID(uid []byte) {
+	if _, err := uuid.FromBytes(uid); err != nil {
+		oe.telemetry.Logger.Warn("Ignoring the invalid instance uid assigned by the OpAMP server", zap.Error(err))
+		return
+	}
+	instanceUIDsMu.Lock()
+	instanceUIDs[oe.config.InstanceUID] = uid
+	instanceUIDsMu.Unlock()
+
+	oe.mu.Lock()
+	defer oe.mu.Unlock()
+	oe.instanceUID = uid
+	oe.reportFullState = true
+}
+
+// triggerSend sends a message to the server without waiting for the polling interval.
+func (oe *opampExtension) triggerSend() {
+	select {
+	case oe.trigger <- struct{}{}:
+	default:
+	}
+}
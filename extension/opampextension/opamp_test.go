@@ -0,0 +1,352 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/extension/opampextension/internal/metadata"
+	"go.opentelemetry.io/collector/extension/opampextension/internal/remoteconfig"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// testServer is an OpAMP server recording the messages of the collector.
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	messages []*protobufs.AgentToServer
+	reply    func(*protobufs.AgentToServer) *protobufs.ServerToAgent
+}
+
+func newTestServer(t *testing.T) *testServer {
+	ts := &testServer{reply: func(*protobufs.AgentToServer) *protobufs.ServerToAgent { return &protobufs.ServerToAgent{} }}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		msg := &protobufs.AgentToServer{}
+		assert.NoError(t, proto.Unmarshal(body, msg))
+
+		ts.mu.Lock()
+		ts.messages = append(ts.messages, msg)
+		reply := ts.reply(msg)
+		ts.mu.Unlock()
+		body, err = proto.Marshal(reply)
+		assert.NoError(t, err)
+		_, err = w.Write(body)
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func (ts *testServer) setReply(reply func(*protobufs.AgentToServer) *protobufs.ServerToAgent) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.reply = reply
+}
+
+// lastMessage returns the last message matching the condition.
+func (ts *testServer) lastMessage(cond func(*protobufs.AgentToServer) bool) *protobufs.AgentToServer {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	for i := len(ts.messages) - 1; i >= 0; i-- {
+		if cond(ts.messages[i]) {
+			return ts.messages[i]
+		}
+	}
+	return nil
+}
+
+func (ts *testServer) eventually(t *testing.T, cond func(*protobufs.AgentToServer) bool) *protobufs.AgentToServer {
+	var msg *protobufs.AgentToServer
+	require.Eventually(t, func() bool {
+		msg = ts.lastMessage(cond)
+		return msg != nil
+	}, 5*time.Second, 5*time.Millisecond)
+	return msg
+}
+
+func newTestExtension(t *testing.T, ts *testServer, modify func(*Config)) *opampExtension {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Server.Endpoint = ts.URL
+	cfg.PollingInterval = 10 * time.Millisecond
+	// Each test has its own instance uid, as the server can replace them.
+	cfg.InstanceUID = uuid.NewString()
+	if modify != nil {
+		modify(cfg)
+	}
+	ext, err := newOpAMPExtension(cfg, extensiontest.NewNopSettings(metadata.Type))
+	require.NoError(t, err)
+	return ext
+}
+
+func start(t *testing.T, oe *opampExtension) {
+	require.NoError(t, oe.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, oe.Shutdown(context.Background())) })
+}
+
+func TestReportState(t *testing.T) {
+	ts := newTestServer(t)
+	oe := newTestExtension(t, ts, nil)
+	require.NoError(t, oe.Start(context.Background(), componenttest.NewNopHost()))
+
+	first := ts.eventually(t, func(msg *protobufs.AgentToServer) bool { return msg.GetSequenceNum() == 0 })
+	assert.Equal(t, oe.instanceUID, first.GetInstanceUid())
+	assert.Equal(t, capabilityReportsStatus|capabilityReportsEffectiveConfig|capabilityReportsHealth, first.GetCapabilities())
+	assert.Equal(t, "otelcol", attributes(first)["service.name"])
+	assert.Equal(t, uuid.UUID(oe.instanceUID).String(), attributes(first)["service.instance.id"])
+	assert.Contains(t, attributes(first), "os.type")
+	require.NotNil(t, first.GetHealth())
+	assert.False(t, first.GetHealth().GetHealthy())
+	assert.Equal(t, "StatusStarting", first.GetHealth().GetStatus())
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"exporters": map[string]any{"otlp": map[string]any{
+			"endpoint": "localhost:4317",
+			"headers":  map[string]any{"x-tenant": "acme"},
+			"auth":     map[string]any{"password": "secret"},
+		}},
+	})
+	require.NoError(t, oe.NotifyConfig(context.Background(), conf))
+	msg := ts.eventually(t, func(msg *protobufs.AgentToServer) bool {
+		return msg.GetEffectiveConfig() != nil && msg.GetSequenceNum() > 0
+	})
+	assert.Contains(t, string(effectiveConfig(msg)), "endpoint: localhost:4317")
+	assert.NotContains(t, string(effectiveConfig(msg)), "acme")
+	assert.NotContains(t, string(effectiveConfig(msg)), "secret")
+
+	oe.ComponentStatusChanged(componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindReceiver), componentstatus.NewEvent(componentstatus.StatusOK))
+	require.NoError(t, oe.Ready())
+	msg = ts.eventually(t, func(msg *protobufs.AgentToServer) bool { return msg.GetHealth().GetHealthy() })
+	assert.Equal(t, "StatusOK", msg.GetHealth().GetStatus())
+	assert.Equal(t, map[string]string{"receiver:otlp": "StatusOK"}, componentStatuses(msg.GetHealth()))
+	// Only the changes are reported after the first message.
+	assert.Nil(t, attributes(msg))
+
+	oe.ComponentStatusChanged(componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindExporter), componentstatus.NewPermanentErrorEvent(errors.New("unauthorized")))
+	msg = ts.eventually(t, func(msg *protobufs.AgentToServer) bool { return msg.GetHealth().GetLastError() != "" })
+	assert.False(t, msg.GetHealth().GetHealthy())
+	assert.Equal(t, "StatusPermanentError", msg.GetHealth().GetStatus())
+	assert.Equal(t, "unauthorized", msg.GetHealth().GetLastError())
+
+	require.NoError(t, oe.Shutdown(context.Background()))
+	last := ts.lastMessage(func(*protobufs.AgentToServer) bool { return true })
+	assert.NotNil(t, last.GetAgentDisconnect())
+}
+
+func TestReportFullStateOnRequest(t *testing.T) {
+	ts := newTestServer(t)
+	ts.setReply(func(msg *protobufs.AgentToServer) *protobufs.ServerToAgent {
+		if msg.GetSequenceNum() == 1 {
+			return &protobufs.ServerToAgent{Flags: flagReportFullState}
+		}
+		return &protobufs.ServerToAgent{}
+	})
+	oe := newTestExtension(t, ts, func(cfg *Config) { cfg.Capabilities.ReportsEffectiveConfig = false })
+	start(t, oe)
+
+	msg := ts.eventually(t, func(msg *protobufs.AgentToServer) bool { return msg.GetSequenceNum() == 2 })
+	assert.NotNil(t, attributes(msg))
+	assert.NotNil(t, msg.GetHealth())
+	assert.Equal(t, capabilityReportsStatus|capabilityReportsHealth, msg.GetCapabilities())
+	msg = ts.eventually(t, func(msg *protobufs.AgentToServer) bool { return msg.GetSequenceNum() == 1 })
+	assert.Nil(t, attributes(msg))
+}
+
+func TestNewInstanceUID(t *testing.T) {
+	newUID := uuid.New()
+	ts := newTestServer(t)
+	ts.setReply(func(msg *protobufs.AgentToServer) *protobufs.ServerToAgent {
+		if msg.GetSequenceNum() == 0 {
+			return &protobufs.ServerToAgent{AgentIdentification: &protobufs.AgentIdentification{NewInstanceUid: newUID[:]}}
+		}
+		return &protobufs.ServerToAgent{}
+	})
+	oe := newTestExtension(t, ts, nil)
+	start(t, oe)
+
+	msg := ts.eventually(t, func(msg *protobufs.AgentToServer) bool { return msg.GetSequenceNum() == 1 })
+	assert.Equal(t, newUID[:], msg.GetInstanceUid())
+	assert.Equal(t, newUID.String(), attributes(msg)["service.instance.id"])
+
+	// The assigned instance uid is kept when the extension is recreated.
+	uid, err := instanceUID(oe.config.InstanceUID)
+	require.NoError(t, err)
+	assert.Equal(t, newUID[:], uid)
+}
+
+func TestRetryAfterUnavailable(t *testing.T) {
+	ts := newTestServer(t)
+	ts.setReply(func(*protobufs.AgentToServer) *protobufs.ServerToAgent {
+		return &protobufs.ServerToAgent{ErrorResponse: &protobufs.ServerErrorResponse{
+			Type:         protobufs.ServerErrorResponseType_ServerErrorResponseType_Unavailable,
+			ErrorMessage: "overloaded",
+			Details:      &protobufs.ServerErrorResponse_RetryInfo{RetryInfo: &protobufs.RetryInfo{RetryAfterNanoseconds: uint64(time.Hour)}},
+		}}
+	})
+	oe := newTestExtension(t, ts, nil)
+	oe.client = ts.Client()
+	assert.Equal(t, time.Hour, oe.poll(context.Background()))
+
+	ts.setReply(func(*protobufs.AgentToServer) *protobufs.ServerToAgent { return &protobufs.ServerToAgent{} })
+	assert.Equal(t, oe.config.PollingInterval, oe.poll(context.Background()))
+
+	// The full state is reported again after a failed exchange.
+	ts.Close()
+	assert.Equal(t, oe.config.PollingInterval, oe.poll(context.Background()))
+	assert.True(t, oe.reportFullState)
+}
+
+func TestRemoteConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "remote", "config.yaml")
+	notified := make(chan struct{}, 10)
+	remoteconfig.Watch(path, t, func(*confmap.ChangeEvent) { notified <- struct{}{} })
+	t.Cleanup(func() { remoteconfig.Unwatch(t) })
+
+	ts := newTestServer(t)
+	remote := newRemoteConfig(map[string]string{"": "receivers:\n  otlp:\n    protocols: {}\n", "extra": "receivers:\n  nop: {}\n"}, 1)
+	ts.setReply(func(*protobufs.AgentToServer) *protobufs.ServerToAgent {
+		return &protobufs.ServerToAgent{RemoteConfig: remote}
+	})
+	oe := newTestExtension(t, ts, func(cfg *Config) { cfg.RemoteConfig = configoptional.Some(RemoteConfig{Path: path}) })
+	start(t, oe)
+
+	msg := ts.eventually(t, func(msg *protobufs.AgentToServer) bool {
+		return msg.GetRemoteConfigStatus().GetStatus() == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING
+	})
+	assert.Equal(t, []byte{1}, msg.GetRemoteConfigStatus().GetLastRemoteConfigHash())
+	assert.Equal(t, capabilityAcceptsRemoteConfig|capabilityReportsRemoteConfig, msg.GetCapabilities()&(capabilityAcceptsRemoteConfig|capabilityReportsRemoteConfig))
+	<-notified
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "receivers:\n    nop: {}\n    otlp:\n        protocols: {}\n", string(content))
+
+	// The remote configuration is applied once the collector reloaded its configuration.
+	require.NoError(t, oe.NotifyConfig(context.Background(), confmap.New()))
+	ts.eventually(t, func(msg *protobufs.AgentToServer) bool {
+		return msg.GetRemoteConfigStatus().GetStatus() == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED
+	})
+
+	// The collector fails to reload the next remote configuration.
+	ts.setReply(func(*protobufs.AgentToServer) *protobufs.ServerToAgent {
+		return &protobufs.ServerToAgent{RemoteConfig: newRemoteConfig(map[string]string{"": "receivers: {}\n"}, 2)}
+	})
+	<-notified
+	oe.NotifyConfigStatus(errors.New("no receivers"))
+	msg = ts.eventually(t, func(msg *protobufs.AgentToServer) bool {
+		return msg.GetRemoteConfigStatus().GetStatus() == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED
+	})
+	assert.Equal(t, []byte{2}, msg.GetRemoteConfigStatus().GetLastRemoteConfigHash())
+	assert.Equal(t, "no receivers", msg.GetRemoteConfigStatus().GetErrorMessage())
+	assert.Empty(t, notified)
+}
+
+func TestRemoteConfigFailed(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		watch   bool
+		wantErr string
+	}{
+		{
+			name:    "not loaded",
+			body:    "receivers: {}\n",
+			wantErr: "the remote configuration is not loaded, the collector must be started with --config=opamp:",
+		},
+		{
+			name:    "invalid",
+			body:    "receivers: [",
+			watch:   true,
+			wantErr: `invalid remote configuration file ""`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if tt.watch {
+				remoteconfig.Watch(path, t, func(*confmap.ChangeEvent) {})
+				t.Cleanup(func() { remoteconfig.Unwatch(t) })
+			}
+			ts := newTestServer(t)
+			ts.setReply(func(*protobufs.AgentToServer) *protobufs.ServerToAgent {
+				return &protobufs.ServerToAgent{RemoteConfig: newRemoteConfig(map[string]string{"": tt.body}, 1)}
+			})
+			oe := newTestExtension(t, ts, func(cfg *Config) { cfg.RemoteConfig = configoptional.Some(RemoteConfig{Path: path}) })
+			start(t, oe)
+
+			msg := ts.eventually(t, func(msg *protobufs.AgentToServer) bool {
+				return msg.GetRemoteConfigStatus().GetStatus() == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED
+			})
+			assert.Contains(t, msg.GetRemoteConfigStatus().GetErrorMessage(), tt.wantErr)
+		})
+	}
+}
+
+func TestRemoteConfigFeatureGates(t *testing.T) {
+	gate := featuregate.GlobalRegistry().MustRegister("opamp.test.remote", featuregate.StageAlpha, featuregate.WithRegisterRuntimeSafe())
+	t.Cleanup(func() { require.NoError(t, featuregate.GlobalRegistry().Set(gate.ID(), false)) })
+
+	// The remote configuration of the collector is already empty.
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("{}\n"), 0o600))
+	remoteconfig.Watch(path, t, func(*confmap.ChangeEvent) {})
+	t.Cleanup(func() { remoteconfig.Unwatch(t) })
+	ts := newTestServer(t)
+	ts.setReply(func(*protobufs.AgentToServer) *protobufs.ServerToAgent {
+		return &protobufs.ServerToAgent{RemoteConfig: newRemoteConfig(map[string]string{featureGatesFile: gate.ID() + ": true\n"}, 1)}
+	})
+	oe := newTestExtension(t, ts, func(cfg *Config) {
+		cfg.RemoteConfig = configoptional.Some(RemoteConfig{Path: path, FeatureGates: true})
+	})
+	start(t, oe)
+
+	// The gates are set without reloading the configuration of the collector.
+	ts.eventually(t, func(msg *protobufs.AgentToServer) bool {
+		return msg.GetRemoteConfigStatus().GetStatus() == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED
+	})
+	assert.True(t, gate.IsEnabled())
+
+	ts.setReply(func(*protobufs.AgentToServer) *protobufs.ServerToAgent {
+		return &protobufs.ServerToAgent{RemoteConfig: newRemoteConfig(map[string]string{featureGatesFile: "opamp.test.missing: true\n"}, 2)}
+	})
+	msg := ts.eventually(t, func(msg *protobufs.AgentToServer) bool {
+		return msg.GetRemoteConfigStatus().GetStatus() == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED
+	})
+	assert.Equal(t, `failed to set the feature gates: no such feature gate "opamp.test.missing"`, msg.GetRemoteConfigStatus().GetErrorMessage())
+}
+
+func TestRemoteConfigIgnoredWithoutPath(t *testing.T) {
+	ts := newTestServer(t)
+	ts.setReply(func(*protobufs.AgentToServer) *protobufs.ServerToAgent {
+		return &protobufs.ServerToAgent{RemoteConfig: newRemoteConfig(map[string]string{"": "receivers: {}\n"}, 1)}
+	})
+	oe := newTestExtension(t, ts, nil)
+	start(t, oe)
+
+	ts.eventually(t, func(msg *protobufs.AgentToServer) bool { return msg.GetSequenceNum() == 3 })
+	assert.Nil(t, ts.lastMessage(func(msg *protobufs.AgentToServer) bool { return msg.GetRemoteConfigStatus() != nil }))
+}
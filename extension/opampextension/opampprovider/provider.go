@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package opampprovider provides the configuration received from an OpAMP server by the opamp extension.
+package opampprovider // import "go.opentelemetry.io/collector/extension/opampextension/opampprovider"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension/opampextension/internal/remoteconfig"
+)
+
+const schemeName = "opamp"
+
+type provider struct{}
+
+// NewFactory returns a factory for a confmap.Provider that reads the remote configuration written by
+// the opamp extension to a file, and reloads the collector configuration when the extension receives
+// a new remote configuration.
+//
+// This Provider supports "opamp" scheme, and can be called with a "uri" that follows:
+//
+//	opamp-uri		= "opamp:" local-path
+//
+// The "local-path" is the "remote_config::path" of the opamp extension. The configuration is empty
+// until the extension receives a remote configuration, so it is usually merged over a local one, e.g.
+// `--config=file:config.yaml --config=opamp:/var/lib/otelcol/remote.yaml`.
+func NewFactory() confmap.ProviderFactory {
+	return confmap.NewProviderFactory(newProvider)
+}
+
+func newProvider(confmap.ProviderSettings) confmap.Provider {
+	return &provider{}
+}
+
+func (p *provider) Retrieve(_ context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+
+	path := filepath.Clean(uri[len(schemeName)+1:])
+	remoteconfig.Watch(path, p, watcher)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		// No remote configuration was received yet.
+		return confmap.NewRetrieved(map[string]any{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the file %v: %w", uri, err)
+	}
+	return confmap.NewRetrievedFromYAML(content)
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (p *provider) Shutdown(context.Context) error {
+	remoteconfig.Unwatch(p)
+	return nil
+}
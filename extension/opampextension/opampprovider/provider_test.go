@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/extension/opampextension/internal/remoteconfig"
+)
+
+func TestUnsupportedScheme(t *testing.T) {
+	p := NewFactory().Create(confmaptest.NewNopProviderSettings())
+	_, err := p.Retrieve(context.Background(), "file:remote.yaml", nil)
+	require.Error(t, err)
+	assert.Equal(t, "opamp", p.Scheme())
+	require.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestRetrieve(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "remote.yaml")
+	p := NewFactory().Create(confmaptest.NewNopProviderSettings())
+	changed := make(chan *confmap.ChangeEvent, 1)
+	watcher := func(event *confmap.ChangeEvent) { changed <- event }
+
+	// The configuration is empty until a remote configuration is received.
+	ret, err := p.Retrieve(context.Background(), "opamp:"+path, watcher)
+	require.NoError(t, err)
+	raw, err := ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{}, raw)
+	assert.True(t, remoteconfig.Loaded(path))
+
+	require.NoError(t, os.WriteFile(path, []byte("processors:\n  batch: {}\n"), 0o600))
+	remoteconfig.Notify(path)
+	event := <-changed
+	require.NoError(t, event.Error)
+
+	ret, err = p.Retrieve(context.Background(), "opamp:"+path, watcher)
+	require.NoError(t, err)
+	raw, err = ret.AsRaw()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"processors": map[string]any{"batch": map[string]any{}}}, raw)
+
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.False(t, remoteconfig.Loaded(path))
+}
+
+func TestRetrieveUnreadable(t *testing.T) {
+	p := NewFactory().Create(confmaptest.NewNopProviderSettings())
+	_, err := p.Retrieve(context.Background(), "opamp:"+t.TempDir(), nil)
+	require.Error(t, err)
+	require.NoError(t, p.Shutdown(context.Background()))
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension // import "go.opentelemetry.io/collector/extension/opampextension"
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+)
+
+// This file builds and reads the OpAMP messages used by the extension, see
+// https://github.com/open-telemetry/opamp-spec/blob/main/proto/opamp.proto for their definition.
+
+// configContentType is the content type of the collector configuration files.
+const configContentType = "text/yaml"
+
+// The capabilities of the agent, see AgentCapabilities.
+var (
+	capabilityReportsStatus          = uint64(protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus)
+	capabilityAcceptsRemoteConfig    = uint64(protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig)
+	capabilityReportsEffectiveConfig = uint64(protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig)
+	capabilityReportsHealth          = uint64(protobufs.AgentCapabilities_AgentCapabilities_ReportsHealth)
+	capabilityReportsRemoteConfig    = uint64(protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig)
+)
+
+// flagReportFullState asks the agent to report its full state in its next message, see ServerToAgentFlags.
+var flagReportFullState = uint64(protobufs.ServerToAgentFlags_ServerToAgentFlags_ReportFullState)
+
+// newAgentDescription returns the description of the agent, with string attributes.
+func newAgentDescription(identifying, nonIdentifying map[string]string) *protobufs.AgentDescription {
+	return &protobufs.AgentDescription{
+		IdentifyingAttributes:    keyValues(identifying),
+		NonIdentifyingAttributes: keyValues(nonIdentifying),
+	}
+}
+
+func keyValues(attrs map[string]string) []*protobufs.KeyValue {
+	kvs := make([]*protobufs.KeyValue, 0, len(attrs))
+	for _, key := range sortedKeys(attrs) {
+		kvs = append(kvs, &protobufs.KeyValue{
+			Key:   key,
+			Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: attrs[key]}},
+		})
+	}
+	return kvs
+}
+
+// newEffectiveConfig returns the effective configuration of the collector, as a single unnamed file.
+func newEffectiveConfig(body []byte) *protobufs.EffectiveConfig {
+	return &protobufs.EffectiveConfig{ConfigMap: &protobufs.AgentConfigMap{
+		ConfigMap: map[string]*protobufs.AgentConfigFile{"": {Body: body, ContentType: configContentType}},
+	}}
+}
+
+// remoteConfigFiles returns the body of each file of the remote configuration by name.
+func remoteConfigFiles(rc *protobufs.AgentRemoteConfig) map[string][]byte {
+	configMap := rc.GetConfig().GetConfigMap()
+	files := make(map[string][]byte, len(configMap))
+	for name, file := range configMap {
+		files[name] = file.GetBody()
+	}
+	return files
+}
+
+// retryAfter returns when to retry after the error response of an overloaded server, zero for the other errors.
+func retryAfter(resp *protobufs.ServerErrorResponse) time.Duration {
+	if resp.GetType() != protobufs.ServerErrorResponseType_ServerErrorResponseType_Unavailable {
+		return 0
+	}
+	return time.Duration(min(resp.GetRetryInfo().GetRetryAfterNanoseconds(), math.MaxInt64))
+}
+
+func unixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano()) //nolint:gosec // G115 the times are after the epoch
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+)
+
+// attributes returns the string attributes of the description of the agent, nil if the message has none.
+func attributes(msg *protobufs.AgentToServer) map[string]string {
+	desc := msg.GetAgentDescription()
+	if desc == nil {
+		return nil
+	}
+	attrs := make(map[string]string)
+	for _, kv := range append(desc.GetIdentifyingAttributes(), desc.GetNonIdentifyingAttributes()...) {
+		attrs[kv.GetKey()] = kv.GetValue().GetStringValue()
+	}
+	return attrs
+}
+
+// effectiveConfig returns the body of the effective configuration reported by the agent.
+func effectiveConfig(msg *protobufs.AgentToServer) []byte {
+	return msg.GetEffectiveConfig().GetConfigMap().GetConfigMap()[""].GetBody()
+}
+
+// componentStatuses returns the status of the components by key.
+func componentStatuses(h *protobufs.ComponentHealth) map[string]string {
+	statuses := make(map[string]string)
+	for key, ch := range h.GetComponentHealthMap() {
+		statuses[key] = ch.GetStatus()
+	}
+	return statuses
+}
+
+// newRemoteConfig returns a remote configuration with the files by name.
+func newRemoteConfig(files map[string]string, hash byte) *protobufs.AgentRemoteConfig {
+	configMap := make(map[string]*protobufs.AgentConfigFile, len(files))
+	for name, body := range files {
+		configMap[name] = &protobufs.AgentConfigFile{Body: []byte(body), ContentType: configContentType}
+	}
+	return &protobufs.AgentRemoteConfig{Config: &protobufs.AgentConfigMap{ConfigMap: configMap}, ConfigHash: []byte{hash}}
+}
+
+func TestNewAgentDescription(t *testing.T) {
+	desc := newAgentDescription(map[string]string{"service.name": "otelcol"}, map[string]string{"os.type": "linux", "host.arch": "amd64"})
+	assert.Len(t, desc.GetIdentifyingAttributes(), 1)
+	// The attributes are sorted by key, so that the descriptions are stable.
+	assert.Equal(t, "host.arch", desc.GetNonIdentifyingAttributes()[0].GetKey())
+	assert.Equal(t, "amd64", desc.GetNonIdentifyingAttributes()[0].GetValue().GetStringValue())
+	assert.Equal(t, "os.type", desc.GetNonIdentifyingAttributes()[1].GetKey())
+	assert.Equal(t, map[string]string{"service.name": "otelcol", "os.type": "linux", "host.arch": "amd64"},
+		attributes(&protobufs.AgentToServer{AgentDescription: desc}))
+}
+
+func TestRemoteConfigFiles(t *testing.T) {
+	files := map[string]string{"": "a: b\n", "extra": "c: d\n"}
+	assert.Equal(t, map[string][]byte{"": []byte("a: b\n"), "extra": []byte("c: d\n")}, remoteConfigFiles(newRemoteConfig(files, 1)))
+	assert.Empty(t, remoteConfigFiles(&protobufs.AgentRemoteConfig{}))
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *protobufs.ServerErrorResponse
+		want time.Duration
+	}{
+		{
+			name: "unavailable",
+			resp: &protobufs.ServerErrorResponse{
+				Type:    protobufs.ServerErrorResponseType_ServerErrorResponseType_Unavailable,
+				Details: &protobufs.ServerErrorResponse_RetryInfo{RetryInfo: &protobufs.RetryInfo{RetryAfterNanoseconds: uint64(time.Minute)}},
+			},
+			want: time.Minute,
+		},
+		{
+			name: "unavailable without retry info",
+			resp: &protobufs.ServerErrorResponse{Type: protobufs.ServerErrorResponseType_ServerErrorResponseType_Unavailable},
+		},
+		{
+			name: "bad request",
+			resp: &protobufs.ServerErrorResponse{
+				Type:    protobufs.ServerErrorResponseType_ServerErrorResponseType_BadRequest,
+				Details: &protobufs.ServerErrorResponse_RetryInfo{RetryInfo: &protobufs.RetryInfo{RetryAfterNanoseconds: uint64(time.Minute)}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, retryAfter(tt.resp))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeinfoextension // import "go.opentelemetry.io/collector/extension/runtimeinfoextension"
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration of the runtimeinfo extension.
+type Config struct {
+	// ServerConfig configures the HTTP server of the API. The clients must be authenticated
+	// with "auth" unless the server only listens on the loopback interface.
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// AllowFeatureGateToggle allows the authenticated clients to enable or disable the runtime safe
+	// feature gates, making the API no longer read-only. It requires "auth", even on the loopback interface.
+	// (default = false)
+	AllowFeatureGateToggle bool `mapstructure:"allow_feature_gate_toggle"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" must be specified")
+	}
+	if !cfg.Auth.HasValue() && !isLocalEndpoint(cfg.Endpoint) {
+		return errors.New("\"auth\" must be configured when the endpoint is not on the loopback interface")
+	}
+	if cfg.AllowFeatureGateToggle && !cfg.Auth.HasValue() {
+		return errors.New("\"auth\" must be configured to allow toggling the feature gates")
+	}
+	return nil
+}
+
+// isLocalEndpoint returns whether the endpoint only listens on the loopback interface.
+func isLocalEndpoint(endpoint string) bool {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && addr.IsLoopback()
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeinfoextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.New().Unmarshal(&cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cfg := NewFactory().CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+
+	serverCfg := confighttp.NewDefaultServerConfig()
+	serverCfg.Endpoint = "0.0.0.0:13234"
+	serverCfg.Auth = configoptional.Some(confighttp.AuthConfig{
+		Config: configauth.Config{AuthenticatorID: component.MustNewID("bearertokenauth")},
+	})
+	assert.Equal(t, &Config{ServerConfig: serverCfg}, cfg)
+	require.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		auth     bool
+		toggle   bool
+		wantErr  string
+	}{
+		{name: "localhost", endpoint: "localhost:13134"},
+		{name: "loopback_ipv4", endpoint: "127.0.0.1:13134"},
+		{name: "loopback_ipv6", endpoint: "[::1]:13134"},
+		{name: "authenticated", endpoint: "0.0.0.0:13134", auth: true},
+		{name: "authenticated_toggle", endpoint: "localhost:13134", auth: true, toggle: true},
+		{
+			name:    "missing_endpoint",
+			wantErr: `"endpoint" must be specified`,
+		},
+		{
+			name:     "unauthenticated",
+			endpoint: ":13134",
+			wantErr:  `"auth" must be configured when the endpoint is not on the loopback interface`,
+		},
+		{
+			name:     "unauthenticated_toggle",
+			endpoint: "localhost:13134",
+			toggle:   true,
+			wantErr:  `"auth" must be configured to allow toggling the feature gates`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = tt.endpoint
+			cfg.AllowFeatureGateToggle = tt.toggle
+			if tt.auth {
+				cfg.Auth = configoptional.Some(confighttp.AuthConfig{
+					Config: configauth.Config{AuthenticatorID: component.MustNewID("bearertokenauth")},
+				})
+			}
+			err := cfg.Validate()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
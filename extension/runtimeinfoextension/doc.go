@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package runtimeinfoextension serves the effective configuration of the collector, with its
+// secrets masked, its build information, its feature gates and its components over a read-only
+// HTTP API.
+package runtimeinfoextension // import "go.opentelemetry.io/collector/extension/runtimeinfoextension"
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeinfoextension // import "go.opentelemetry.io/collector/extension/runtimeinfoextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/runtimeinfoextension/internal/metadata"
+)
+
+const defaultEndpoint = "localhost:13134"
+
+// NewFactory creates a factory for the runtimeinfo extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	serverCfg := confighttp.NewDefaultServerConfig()
+	serverCfg.Endpoint = defaultEndpoint
+	return &Config{
+		ServerConfig: serverCfg,
+	}
+}
+
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newRuntimeInfoExtension(cfg.(*Config), set), nil
+}
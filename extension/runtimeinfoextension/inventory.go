@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeinfoextension // import "go.opentelemetry.io/collector/extension/runtimeinfoextension"
+
+import (
+	"slices"
+	"sort"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+// componentInfo describes a component in use in the collector.
+type componentInfo struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+	// Module is the Go module providing the component, with its version.
+	Module  string `json:"module,omitempty"`
+	Version string `json:"version,omitempty"`
+	// Pipelines are the pipelines the component is part of, empty for the extensions.
+	Pipelines []string `json:"pipelines,omitempty"`
+}
+
+// serviceConfig is the part of the service configuration listing the components in use.
+type serviceConfig struct {
+	Extensions []string                  `mapstructure:"extensions"`
+	Pipelines  map[string]pipelineConfig `mapstructure:"pipelines"`
+}
+
+type pipelineConfig struct {
+	Receivers  []string `mapstructure:"receivers"`
+	Processors []string `mapstructure:"processors"`
+	Exporters  []string `mapstructure:"exporters"`
+}
+
+// inventory returns the components in use in the effective configuration, sorted by kind and ID, with
+// the module providing them if the host reports it.
+func inventory(conf *confmap.Conf, host component.Host) []componentInfo {
+	var svc serviceConfig
+	if sub, err := conf.Sub("service"); err == nil {
+		// The configuration was validated by the collector, the unused keys are ignored.
+		_ = sub.Unmarshal(&svc, confmap.WithIgnoreUnused())
+	}
+	connectors := make(map[string]bool)
+	if sub, err := conf.Sub("connectors"); err == nil {
+		for id := range sub.ToStringMap() {
+			connectors[id] = true
+		}
+	}
+
+	type kindID struct {
+		kind component.Kind
+		id   string
+	}
+	components := make(map[kindID]*componentInfo)
+	add := func(kind component.Kind, id, pipelineID string) {
+		if kind != component.KindExtension && connectors[id] {
+			kind = component.KindConnector
+		}
+		key := kindID{kind: kind, id: id}
+		info, ok := components[key]
+		if !ok {
+			info = &componentInfo{Kind: kind.String(), ID: id}
+			components[key] = info
+		}
+		if pipelineID != "" && !slices.Contains(info.Pipelines, pipelineID) {
+			info.Pipelines = append(info.Pipelines, pipelineID)
+		}
+	}
+	for _, id := range svc.Extensions {
+		add(component.KindExtension, id, "")
+	}
+	for pipelineID, pipeline := range svc.Pipelines {
+		for _, id := range pipeline.Receivers {
+			add(component.KindReceiver, id, pipelineID)
+		}
+		for _, id := range pipeline.Processors {
+			add(component.KindProcessor, id, pipelineID)
+		}
+		for _, id := range pipeline.Exporters {
+			add(component.KindExporter, id, pipelineID)
+		}
+	}
+
+	moduleInfo, _ := host.(hostcapabilities.ModuleInfo)
+	infos := make([]componentInfo, 0, len(components))
+	for key, info := range components {
+		sort.Strings(info.Pipelines)
+		if moduleInfo != nil {
+			info.Module, info.Version = componentModule(moduleInfo, key.kind, key.id)
+		}
+		infos = append(infos, *info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Kind != infos[j].Kind {
+			return infos[i].Kind < infos[j].Kind
+		}
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// componentModule returns the Go module providing the component and its version.
+func componentModule(moduleInfo hostcapabilities.ModuleInfo, kind component.Kind, idStr string) (string, string) {
+	var id component.ID
+	if err := id.UnmarshalText([]byte(idStr)); err != nil {
+		return "", ""
+	}
+	modules := moduleInfo.GetModuleInfos()
+	switch kind {
+	case component.KindReceiver:
+		info := modules.Receiver[id.Type()]
+		return modulePath(info.Path, info.BuilderRef), info.Version
+	case component.KindProcessor:
+		info := modules.Processor[id.Type()]
+		return modulePath(info.Path, info.BuilderRef), info.Version
+	case component.KindExporter:
+		info := modules.Exporter[id.Type()]
+		return modulePath(info.Path, info.BuilderRef), info.Version
+	case component.KindConnector:
+		info := modules.Connector[id.Type()]
+		return modulePath(info.Path, info.BuilderRef), info.Version
+	case component.KindExtension:
+		info := modules.Extension[id.Type()]
+		return modulePath(info.Path, info.BuilderRef), info.Version
+	}
+	return "", ""
+}
+
+// modulePath returns the module path recorded in the build information, or the builder reference
+// of the component if the module was not found.
+func modulePath(path, builderRef string) string {
+	if path != "" {
+		return path
+	}
+	return builderRef
+}
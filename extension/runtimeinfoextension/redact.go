@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeinfoextension // import "go.opentelemetry.io/collector/extension/runtimeinfoextension"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/internal/redact"
+)
+
+// componentSections are the sections of the configuration holding the configurations of the components.
+var componentSections = map[string]component.Kind{
+	"receivers":  component.KindReceiver,
+	"processors": component.KindProcessor,
+	"exporters":  component.KindExporter,
+	"connectors": component.KindConnector,
+	"extensions": component.KindExtension,
+}
+
+// componentFactories returns the factory of the components, as implemented by the host.
+type componentFactories interface {
+	GetFactory(kind component.Kind, componentType component.Type) component.Factory
+}
+
+// redactConfig returns the effective configuration with its secrets masked. The configuration of each
+// component is decoded with the factory of the component, so that its configopaque values are masked as
+// by the print-config command. The configuration of the components without a factory, and the rest of
+// the configuration, are masked after the names of their keys.
+func redactConfig(conf *confmap.Conf, factories componentFactories) map[string]any {
+	raw := conf.ToStringMap()
+	redacted := make(map[string]any, len(raw))
+	for key, value := range raw {
+		kind, ok := componentSections[key]
+		section, isMap := value.(map[string]any)
+		if !ok || !isMap {
+			redacted[key] = redact.ByKey(key, value)
+			continue
+		}
+		components := make(map[string]any, len(section))
+		for idStr, compValue := range section {
+			components[idStr] = redactComponent(conf, key, kind, idStr, compValue, factories)
+		}
+		redacted[key] = components
+	}
+	return redacted
+}
+
+// redactComponent returns the configuration of the component, decoded and encoded again with its type.
+func redactComponent(conf *confmap.Conf, section string, kind component.Kind, idStr string, value any, factories componentFactories) any {
+	var id component.ID
+	if factories == nil || id.UnmarshalText([]byte(idStr)) != nil {
+		return redact.ByKey("", value)
+	}
+	factory := factories.GetFactory(kind, id.Type())
+	if factory == nil {
+		return redact.ByKey("", value)
+	}
+	// Get the configuration from the confmap.Conf to preserve internal representation.
+	sub, err := conf.Sub(section + confmap.KeyDelimiter + idStr)
+	if err != nil {
+		return redact.ByKey("", value)
+	}
+	cfg := factory.CreateDefaultConfig()
+	if err = sub.Unmarshal(&cfg); err != nil {
+		return redact.ByKey("", value)
+	}
+	typed := confmap.New()
+	if err = typed.Marshal(cfg); err != nil {
+		return redact.ByKey("", value)
+	}
+	return formatDurations(typed.ToStringMap())
+}
+
+// formatDurations formats the durations of the typed configuration as in the YAML configuration, e.g. "1m30s",
+// instead of as their number of nanoseconds.
+func formatDurations(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, val := range v {
+			v[k] = formatDurations(val)
+		}
+	case []any:
+		for i, val := range v {
+			v[i] = formatDurations(val)
+		}
+	case time.Duration:
+		return v.String()
+	}
+	return value
+}
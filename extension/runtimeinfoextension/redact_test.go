@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeinfoextension
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/internal/redact"
+)
+
+type secretConfig struct {
+	Endpoint string              `mapstructure:"endpoint"`
+	Secret   configopaque.String `mapstructure:"secret"`
+	Timeout  time.Duration       `mapstructure:"timeout"`
+}
+
+// factoriesHost is a host returning the factories of the extensions only.
+type factoriesHost struct {
+	component.Host
+	extensions map[component.Type]extension.Factory
+}
+
+func (h *factoriesHost) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
+	if kind != component.KindExtension {
+		return nil
+	}
+	if factory, ok := h.extensions[componentType]; ok {
+		return factory
+	}
+	return nil
+}
+
+func newFactoriesHost() *factoriesHost {
+	secretFactory := extension.NewFactory(
+		component.MustNewType("secret"),
+		func() component.Config { return &secretConfig{Timeout: time.Second} },
+		func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+			return extensiontest.NewNopFactory().Create(context.Background(), extensiontest.NewNopSettings(extensiontest.NopType), nil)
+		},
+		component.StabilityLevelDevelopment,
+	)
+	return &factoriesHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.Type]extension.Factory{secretFactory.Type(): secretFactory},
+	}
+}
+
+func TestRedactConfig(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"extensions": map[string]any{
+			// Decoded with the factory of the extension, the configopaque values are masked.
+			"secret/1": map[string]any{"endpoint": "localhost:1234", "secret": "s3cr3t"},
+			// Without a factory, the values of the sensitive keys are masked.
+			"unknown": map[string]any{"password": "p4ssw0rd", "user": "admin"},
+		},
+		"receivers": map[string]any{
+			"otlp": map[string]any{
+				"protocols": map[string]any{
+					"http": map[string]any{
+						"endpoint": "localhost:4318",
+						"headers":  map[string]any{"x-scope": "tenant"},
+					},
+				},
+			},
+			"nop": nil,
+		},
+		"service": map[string]any{
+			"extensions": []any{"secret/1", "unknown"},
+			"telemetry": map[string]any{
+				"metrics": map[string]any{
+					"readers": []any{map[string]any{
+						"periodic": map[string]any{
+							"exporter": map[string]any{
+								"otlp": map[string]any{
+									"endpoint": "https://backend:4318",
+									"headers":  []any{map[string]any{"name": "api-key", "value": "k3y"}},
+								},
+							},
+						},
+					}},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, map[string]any{
+		"extensions": map[string]any{
+			"secret/1": map[string]any{"endpoint": "localhost:1234", "secret": redact.Redacted, "timeout": "1s"},
+			"unknown":  map[string]any{"password": redact.Redacted, "user": "admin"},
+		},
+		"receivers": map[string]any{
+			"otlp": map[string]any{
+				"protocols": map[string]any{
+					"http": map[string]any{
+						"endpoint": "localhost:4318",
+						"headers":  map[string]any{"x-scope": redact.Redacted},
+					},
+				},
+			},
+			"nop": nil,
+		},
+		"service": map[string]any{
+			"extensions": []any{"secret/1", "unknown"},
+			"telemetry": map[string]any{
+				"metrics": map[string]any{
+					"readers": []any{map[string]any{
+						"periodic": map[string]any{
+							"exporter": map[string]any{
+								"otlp": map[string]any{
+									"endpoint": "https://backend:4318",
+									"headers":  []any{map[string]any{"name": redact.Redacted, "value": redact.Redacted}},
+								},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}, redactConfig(conf, newFactoriesHost()))
+}
@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeinfoextension // import "go.opentelemetry.io/collector/extension/runtimeinfoextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// The paths of the API.
+const (
+	configPath       = "/v1/config"
+	buildInfoPath    = "/v1/buildinfo"
+	featureGatesPath = "/v1/featuregates"
+	componentsPath   = "/v1/components"
+)
+
+// maxRequestSize limits the size of the request bodies.
+const maxRequestSize = 4096
+
+var _ extensioncapabilities.ConfigWatcher = (*runtimeInfoExtension)(nil)
+
+type runtimeInfoExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	buildInfo component.BuildInfo
+
+	host   component.Host
+	server *http.Server
+	stopWG sync.WaitGroup
+
+	// The effective configuration and components of the collector, set by NotifyConfig.
+	mu         sync.RWMutex
+	effective  map[string]any
+	components []componentInfo
+}
+
+// buildInfo is the document served on buildInfoPath.
+type buildInfo struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+	GoVersion   string `json:"go_version"`
+	OS          string `json:"os"`
+	Arch        string `json:"arch"`
+}
+
+// featureGate is an entry of the document served on featureGatesPath.
+type featureGate struct {
+	ID           string          `json:"id"`
+	Enabled      bool            `json:"enabled"`
+	Stage        string          `json:"stage"`
+	RuntimeSafe  bool            `json:"runtime_safe"`
+	Scopes       map[string]bool `json:"scopes,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	FromVersion  string          `json:"from_version,omitempty"`
+	ToVersion    string          `json:"to_version,omitempty"`
+	ReferenceURL string          `json:"reference_url,omitempty"`
+}
+
+func newRuntimeInfoExtension(config *Config, set extension.Settings) *runtimeInfoExtension {
+	return &runtimeInfoExtension{
+		config:    config,
+		telemetry: set.TelemetrySettings,
+		buildInfo: set.BuildInfo,
+	}
+}
+
+func (ri *runtimeInfoExtension) Start(ctx context.Context, host component.Host) error {
+	ri.host = host
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(configPath, readOnly(ri.handleConfig))
+	mux.HandleFunc(buildInfoPath, readOnly(ri.handleBuildInfo))
+	mux.HandleFunc(featureGatesPath, readOnly(ri.handleFeatureGates))
+	mux.HandleFunc(componentsPath, readOnly(ri.handleComponents))
+	if ri.config.AllowFeatureGateToggle {
+		mux.HandleFunc(featureGatesPath+"/{id}", ri.handleSetFeatureGate)
+	}
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := ri.config.ToListener(ctx)
+	if err != nil {
+		return err
+	}
+	ri.server, err = ri.config.ToServer(ctx, host, ri.telemetry, mux)
+	if err != nil {
+		return errors.Join(err, ln.Close())
+	}
+
+	ri.telemetry.Logger.Info("Starting runtime info server", zap.String("endpoint", ri.config.Endpoint))
+	ri.stopWG.Add(1)
+	go func() {
+		defer ri.stopWG.Done()
+		if errHTTP := ri.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+	return nil
+}
+
+func (ri *runtimeInfoExtension) Shutdown(context.Context) error {
+	var err error
+	if ri.server != nil {
+		err = ri.server.Close()
+	}
+	ri.stopWG.Wait()
+	return err
+}
+
+// NotifyConfig records the effective configuration, with its secrets masked, and the components in use.
+func (ri *runtimeInfoExtension) NotifyConfig(_ context.Context, conf *confmap.Conf) error {
+	factories, _ := ri.host.(componentFactories)
+	effective := redactConfig(conf, factories)
+	components := inventory(conf, ri.host)
+
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	ri.effective = effective
+	ri.components = components
+	return nil
+}
+
+func (ri *runtimeInfoExtension) handleConfig(w http.ResponseWriter, _ *http.Request) {
+	ri.mu.RLock()
+	effective := ri.effective
+	ri.mu.RUnlock()
+	if effective == nil {
+		http.Error(w, "the configuration of the collector is not available yet", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, ri.telemetry.Logger, effective)
+}
+
+func (ri *runtimeInfoExtension) handleBuildInfo(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, ri.telemetry.Logger, buildInfo{
+		Command:     ri.buildInfo.Command,
+		Description: ri.buildInfo.Description,
+		Version:     ri.buildInfo.Version,
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+	})
+}
+
+func (ri *runtimeInfoExtension) handleComponents(w http.ResponseWriter, _ *http.Request) {
+	ri.mu.RLock()
+	components := ri.components
+	ri.mu.RUnlock()
+	if components == nil {
+		http.Error(w, "the configuration of the collector is not available yet", http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, ri.telemetry.Logger, map[string]any{"components": components})
+}
+
+func (ri *runtimeInfoExtension) handleFeatureGates(w http.ResponseWriter, _ *http.Request) {
+	gates := []featureGate{}
+	featuregate.GlobalRegistry().VisitAll(func(gate *featuregate.Gate) {
+		gates = append(gates, newFeatureGate(gate))
+	})
+	writeJSON(w, ri.telemetry.Logger, map[string]any{"feature_gates": gates})
+}
+
+// handleSetFeatureGate enables or disables a runtime safe feature gate, with a {"enabled": bool} document.
+func (ri *runtimeInfoExtension) handleSetFeatureGate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.Header().Set("Allow", "PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	var body struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestSize)).Decode(&body); err != nil || body.Enabled == nil {
+		http.Error(w, `the request body must be {"enabled": true|false}`, http.StatusBadRequest)
+		return
+	}
+
+	id := r.PathValue("id")
+	var gate *featuregate.Gate
+	featuregate.GlobalRegistry().VisitAll(func(g *featuregate.Gate) {
+		if g.ID() == id {
+			gate = g
+		}
+	})
+	if gate == nil {
+		http.Error(w, fmt.Sprintf("no such feature gate %q", id), http.StatusNotFound)
+		return
+	}
+	if err := featuregate.GlobalRegistry().SetAtRuntime(id, *body.Enabled); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	ri.telemetry.Logger.Info("Feature gate set at runtime", zap.String("id", id), zap.Bool("enabled", *body.Enabled))
+	writeJSON(w, ri.telemetry.Logger, newFeatureGate(gate))
+}
+
+func newFeatureGate(gate *featuregate.Gate) featureGate {
+	return featureGate{
+		ID:           gate.ID(),
+		Enabled:      gate.IsEnabled(),
+		Stage:        gate.Stage().String(),
+		RuntimeSafe:  gate.IsRuntimeSafe(),
+		Scopes:       gate.Scopes(),
+		Description:  gate.Description(),
+		FromVersion:  gate.FromVersion(),
+		ToVersion:    gate.ToVersion(),
+		ReferenceURL: gate.ReferenceURL(),
+	}
+}
+
+// readOnly refuses the requests other than GET and HEAD.
+func readOnly(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, logger *zap.Logger, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Debug("Failed to write the runtime info response", zap.Error(err))
+	}
+}
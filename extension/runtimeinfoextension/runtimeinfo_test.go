@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtimeinfoextension
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/extension/runtimeinfoextension/internal/metadata"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/redact"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+func startExtension(t *testing.T) (*runtimeInfoExtension, string) {
+	return startExtensionWithConfig(t, createDefaultConfig().(*Config))
+}
+
+func startExtensionWithConfig(t *testing.T, cfg *Config) (*runtimeInfoExtension, string) {
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	set := extensiontest.NewNopSettings(metadata.Type)
+	set.BuildInfo = component.BuildInfo{Command: "otelcol", Description: "Collector", Version: "1.2.3"}
+	ri := newRuntimeInfoExtension(cfg, set)
+	require.NoError(t, ri.Start(context.Background(), newFactoriesHost()))
+	t.Cleanup(func() { require.NoError(t, ri.Shutdown(context.Background())) })
+	return ri, "http://" + cfg.Endpoint
+}
+
+func get(t *testing.T, url string, v any) int {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+	return resp.StatusCode
+}
+
+func TestConfigAndComponents(t *testing.T) {
+	ri, url := startExtension(t)
+
+	// The configuration is not available before the collector notifies it.
+	assert.Equal(t, http.StatusServiceUnavailable, get(t, url+configPath, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, get(t, url+componentsPath, nil))
+
+	require.NoError(t, ri.NotifyConfig(context.Background(), confmap.NewFromStringMap(map[string]any{
+		"receivers":  map[string]any{"otlp": nil},
+		"processors": map[string]any{"batch": nil},
+		"exporters":  map[string]any{"otlp": map[string]any{"headers": map[string]any{"authorization": "Bearer t0k3n"}}},
+		"connectors": map[string]any{"forward": nil},
+		"extensions": map[string]any{"secret": map[string]any{"secret": "s3cr3t"}},
+		"service": map[string]any{
+			"extensions": []any{"secret"},
+			"pipelines": map[string]any{
+				"traces":     map[string]any{"receivers": []any{"otlp"}, "processors": []any{"batch"}, "exporters": []any{"forward"}},
+				"traces/out": map[string]any{"receivers": []any{"forward"}, "processors": []any{"batch"}, "exporters": []any{"otlp"}},
+			},
+		},
+	})))
+
+	var effective map[string]any
+	require.Equal(t, http.StatusOK, get(t, url+configPath, &effective))
+	assert.Equal(t, map[string]any{"otlp": map[string]any{"headers": map[string]any{"authorization": redact.Redacted}}}, effective["exporters"])
+	assert.Equal(t, map[string]any{"secret": map[string]any{"endpoint": "", "secret": redact.Redacted, "timeout": "1s"}}, effective["extensions"])
+
+	var components struct {
+		Components []componentInfo `json:"components"`
+	}
+	require.Equal(t, http.StatusOK, get(t, url+componentsPath, &components))
+	assert.Equal(t, []componentInfo{
+		{Kind: "Connector", ID: "forward", Pipelines: []string{"traces", "traces/out"}},
+		{Kind: "Exporter", ID: "otlp", Pipelines: []string{"traces/out"}},
+		{Kind: "Extension", ID: "secret"},
+		{Kind: "Processor", ID: "batch", Pipelines: []string{"traces", "traces/out"}},
+		{Kind: "Receiver", ID: "otlp", Pipelines: []string{"traces"}},
+	}, components.Components)
+}
+
+func TestBuildInfo(t *testing.T) {
+	_, url := startExtension(t)
+
+	var info buildInfo
+	require.Equal(t, http.StatusOK, get(t, url+buildInfoPath, &info))
+	assert.Equal(t, buildInfo{
+		Command:     "otelcol",
+		Description: "Collector",
+		Version:     "1.2.3",
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+	}, info)
+}
+
+func TestFeatureGates(t *testing.T) {
+	scoped := featuregate.GlobalRegistry().MustRegister("runtimeinfo.test.scoped", featuregate.StageAlpha)
+	require.NoError(t, featuregate.GlobalRegistry().SetFor(scoped.ID(), "exporter/otlp/canary", true))
+	_, url := startExtension(t)
+
+	var gates struct {
+		FeatureGates []featureGate `json:"feature_gates"`
+	}
+	require.Equal(t, http.StatusOK, get(t, url+featureGatesPath, &gates))
+	assert.NotNil(t, gates.FeatureGates)
+	for _, gate := range gates.FeatureGates {
+		assert.NotEmpty(t, gate.ID)
+		assert.NotEmpty(t, gate.Stage)
+		if gate.ID == scoped.ID() {
+			assert.Equal(t, map[string]bool{"exporter/otlp/canary": true}, gate.Scopes)
+		}
+	}
+}
+
+func setFeatureGate(t *testing.T, url, body string) (int, featureGate) {
+	req, err := http.NewRequest(http.MethodPut, url, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var gate featureGate
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&gate))
+	}
+	return resp.StatusCode, gate
+}
+
+func TestSetFeatureGate(t *testing.T) {
+	safeGate := featuregate.GlobalRegistry().MustRegister("runtimeinfo.test.safe", featuregate.StageAlpha, featuregate.WithRegisterRuntimeSafe())
+	unsafeGate := featuregate.GlobalRegistry().MustRegister("runtimeinfo.test.unsafe", featuregate.StageAlpha)
+	t.Cleanup(func() { require.NoError(t, featuregate.GlobalRegistry().Set(safeGate.ID(), false)) })
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.AllowFeatureGateToggle = true
+	_, url := startExtensionWithConfig(t, cfg)
+
+	status, gate := setFeatureGate(t, url+featureGatesPath+"/"+safeGate.ID(), `{"enabled": true}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, featureGate{ID: safeGate.ID(), Enabled: true, Stage: "Alpha", RuntimeSafe: true, FromVersion: "v<nil>", ToVersion: "v<nil>"}, gate)
+	assert.True(t, safeGate.IsEnabled())
+
+	status, _ = setFeatureGate(t, url+featureGatesPath+"/"+unsafeGate.ID(), `{"enabled": true}`)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.False(t, unsafeGate.IsEnabled())
+
+	status, _ = setFeatureGate(t, url+featureGatesPath+"/runtimeinfo.test.missing", `{"enabled": true}`)
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = setFeatureGate(t, url+featureGatesPath+"/"+safeGate.ID(), `{}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.True(t, safeGate.IsEnabled())
+
+	resp, err := http.Post(url+featureGatesPath+"/"+safeGate.ID(), "application/json", strings.NewReader(`{"enabled": false}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	assert.Equal(t, "PUT", resp.Header.Get("Allow"))
+}
+
+func TestSetFeatureGateNotAllowed(t *testing.T) {
+	safeGate := featuregate.GlobalRegistry().MustRegister("runtimeinfo.test.notallowed", featuregate.StageAlpha, featuregate.WithRegisterRuntimeSafe())
+	_, url := startExtension(t)
+
+	status, _ := setFeatureGate(t, url+featureGatesPath+"/"+safeGate.ID(), `{"enabled": true}`)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.False(t, safeGate.IsEnabled())
+}
+
+func TestReadOnly(t *testing.T) {
+	_, url := startExtension(t)
+
+	for _, path := range []string{configPath, buildInfoPath, featureGatesPath, componentsPath} {
+		resp, err := http.Post(url+path, "application/json", strings.NewReader("{}"))
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode, path)
+		assert.Equal(t, "GET, HEAD", resp.Header.Get("Allow"), path)
+	}
+}
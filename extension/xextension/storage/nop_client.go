@@ -3,12 +3,22 @@
 
 package storage // import "go.opentelemetry.io/collector/extension/xextension/storage"
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type nopClient struct{}
 
 var nopClientInstance Client = &nopClient{}
 
+var (
+	_ TransactionClient = (*nopClient)(nil)
+	_ IterableClient    = (*nopClient)(nil)
+	_ ExpiringClient    = (*nopClient)(nil)
+	_ CompactableClient = (*nopClient)(nil)
+)
+
 // NewNopClient returns a nop client
 func NewNopClient() Client {
 	return nopClientInstance
@@ -38,3 +48,23 @@ func (c nopClient) Close(context.Context) error {
 func (c nopClient) Batch(context.Context, ...*Operation) error {
 	return nil // no result, but no problem
 }
+
+// Transaction does nothing, and returns nil
+func (c nopClient) Transaction(context.Context, ...*Operation) error {
+	return nil // no result, but no problem
+}
+
+// Iterate does nothing, and returns nil
+func (c nopClient) Iterate(context.Context, string, func(string, []byte) bool) error {
+	return nil // no keys, but no problem
+}
+
+// SetWithTTL does nothing and returns nil
+func (c nopClient) SetWithTTL(context.Context, string, []byte, time.Duration) error {
+	return nil // no problem
+}
+
+// Compact does nothing and returns nil
+func (c nopClient) Compact(context.Context) error {
+	return nil // no problem
+}
@@ -6,6 +6,7 @@ package storage // import "go.opentelemetry.io/collector/extension/xextension/st
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -54,12 +55,58 @@ type Client interface {
 	Close(ctx context.Context) error
 }
 
+// TransactionClient is the interface that storage clients able to apply several operations
+// atomically must implement. Components depending on dependent writes being all applied, or
+// none of them after a crash, type-assert the Client to it.
+type TransactionClient interface {
+	Client
+
+	// Transaction handles specified operations atomically, in order: either all of them are
+	// applied, or none is. Get operation results are put in-place. If the current value of the
+	// key of a CompareAndSwap operation is not its Expected value, no operation is applied and
+	// ErrCompareAndSwapFailed is returned.
+	Transaction(ctx context.Context, ops ...*Operation) error
+}
+
+// IterableClient is the interface that storage clients able to list their keys must implement.
+type IterableClient interface {
+	Client
+
+	// Iterate calls yield with each key starting with the specified prefix, in ascending order,
+	// and its value, until yield returns false. An empty prefix iterates over all the keys.
+	// The keys set or deleted during the iteration may or may not be seen.
+	Iterate(ctx context.Context, prefix string, yield func(key string, value []byte) bool) error
+}
+
+// ExpiringClient is the interface that storage clients able to expire their entries must implement.
+// They honor the TTL of the set operations, which the other clients ignore.
+type ExpiringClient interface {
+	Client
+
+	// SetWithTTL will store data until the ttl elapses, after which the key is not found.
+	// A zero ttl never expires
+	SetWithTTL(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// CompactableClient is the interface that storage clients able to reclaim the space of their
+// deleted and expired entries on demand must implement.
+type CompactableClient interface {
+	Client
+
+	// Compact reclaims the space of the deleted and expired entries. It may block the other
+	// operations of the client until done
+	Compact(ctx context.Context) error
+}
+
 type OpType int
 
 const (
 	Get OpType = iota
 	Set
 	Delete
+	// CompareAndSwap sets the value of the key if its current value is the expected one.
+	// It is only supported by Transaction.
+	CompareAndSwap
 )
 
 type Operation struct {
@@ -69,6 +116,12 @@ type Operation struct {
 	Value []byte
 	// Type describes the operation type
 	Type OpType
+	// Expected specifies the value the key must have for a compare-and-swap operation to
+	// be applied, nil if the key must not be found
+	Expected []byte
+	// TTL specifies how long the value of a set or compare-and-swap operation is kept when
+	// the client is an ExpiringClient. Zero never expires
+	TTL time.Duration
 }
 
 func SetOperation(key string, value []byte) *Operation {
@@ -79,6 +132,17 @@ func SetOperation(key string, value []byte) *Operation {
 	}
 }
 
+// SetWithTTLOperation returns an operation setting the value of the key until the ttl elapses,
+// if the client is an ExpiringClient.
+func SetWithTTLOperation(key string, value []byte, ttl time.Duration) *Operation {
+	return &Operation{
+		Key:   key,
+		Value: value,
+		Type:  Set,
+		TTL:   ttl,
+	}
+}
+
 func GetOperation(key string) *Operation {
 	return &Operation{
 		Key:  key,
@@ -93,4 +157,19 @@ func DeleteOperation(key string) *Operation {
 	}
 }
 
+// CompareAndSwapOperation returns an operation setting the value of the key, if its current value
+// is the expected one. A nil expected value expects the key not to be found.
+func CompareAndSwapOperation(key string, expected, value []byte) *Operation {
+	return &Operation{
+		Key:      key,
+		Value:    value,
+		Expected: expected,
+		Type:     CompareAndSwap,
+	}
+}
+
 var ErrStorageFull = errors.New("the storage extension has run out of available space")
+
+// ErrCompareAndSwapFailed is returned by Transaction when the current value of the key of a
+// compare-and-swap operation is not the expected one.
+var ErrCompareAndSwapFailed = errors.New("the current value of the key is not the expected one")
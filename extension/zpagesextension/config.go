@@ -5,6 +5,9 @@ package zpagesextension // import "go.opentelemetry.io/collector/extension/zpage
 
 import (
 	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -14,7 +17,17 @@ import (
 type Config struct {
 	confighttp.ServerConfig `mapstructure:",squash"`
 
+	// AllowedNetworks restricts the clients of the zPages to the given networks, in CIDR
+	// notation (e.g. 10.0.0.0/8) or as IP addresses. The other clients are refused with
+	// 403 Forbidden, before being authenticated.
+	// (default = all clients are allowed)
+	AllowedNetworks []string `mapstructure:"allowed_networks"`
+
 	Expvar ExpvarConfig `mapstructure:"expvar"`
+
+	Pprof PprofConfig `mapstructure:"pprof"`
+
+	Tap TapConfig `mapstructure:"tap"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -28,6 +41,33 @@ type ExpvarConfig struct {
 	_ struct{}
 }
 
+// PprofConfig has the configuration for the pprof endpoints.
+type PprofConfig struct {
+	// Enabled indicates whether to expose the net/http/pprof endpoints.
+	// (default = false)
+	Enabled bool `mapstructure:"enabled"`
+	// BlockProfileRate sets the rate of the goroutine blocking events reported in the block
+	// profile, see runtime.SetBlockProfileRate.
+	// (default = 0, the block profile is disabled)
+	BlockProfileRate int `mapstructure:"block_profile_rate"`
+	// MutexProfileFraction sets the fraction of the mutex contention events reported in the
+	// mutex profile, see runtime.SetMutexProfileFraction.
+	// (default = 0, the mutex profile is disabled)
+	MutexProfileFraction int `mapstructure:"mutex_profile_fraction"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// TapConfig has the configuration for the tapz zPage of the host.
+type TapConfig struct {
+	// Enabled indicates whether to serve the tapz zPage, which streams the data passing the
+	// pipelines, and so can expose the telemetry of the applications to the clients.
+	// (default = false)
+	Enabled bool `mapstructure:"enabled"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the extension configuration is valid
@@ -35,5 +75,33 @@ func (cfg *Config) Validate() error {
 	if cfg.Endpoint == "" {
 		return errors.New("\"endpoint\" is required when using the \"zpages\" extension")
 	}
+	if _, err := parseNetworks(cfg.AllowedNetworks); err != nil {
+		return err
+	}
+	if cfg.Pprof.BlockProfileRate < 0 || cfg.Pprof.MutexProfileFraction < 0 {
+		return errors.New("\"pprof::block_profile_rate\" and \"pprof::mutex_profile_fraction\" must not be negative")
+	}
 	return nil
 }
+
+// parseNetworks parses the allowed networks, the IP addresses being networks of a single address.
+func parseNetworks(networks []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(networks))
+	for _, network := range networks {
+		if !strings.Contains(network, "/") {
+			addr, err := netip.ParseAddr(network)
+			if err != nil {
+				return nil, fmt.Errorf("\"allowed_networks\": invalid network %q: %w", network, err)
+			}
+			addr = addr.Unmap().WithZone("")
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return nil, fmt.Errorf("\"allowed_networks\": invalid network %q: %w", network, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
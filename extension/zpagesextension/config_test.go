@@ -4,6 +4,7 @@
 package zpagesextension
 
 import (
+	"net/netip"
 	"path/filepath"
 	"testing"
 
@@ -24,6 +25,29 @@ func TestUnmarshalDefaultConfig(t *testing.T) {
 
 func TestInvalidConfig(t *testing.T) {
 	assert.Error(t, (&Config{}).Validate())
+	assert.Error(t, (&Config{
+		ServerConfig: confighttp.ServerConfig{Endpoint: "localhost:55679"},
+		Pprof:        PprofConfig{Enabled: true, BlockProfileRate: -1},
+	}).Validate())
+	assert.EqualError(t, (&Config{
+		ServerConfig:    confighttp.ServerConfig{Endpoint: "localhost:55679"},
+		AllowedNetworks: []string{"10.0.0.0/33"},
+	}).Validate(), `"allowed_networks": invalid network "10.0.0.0/33": netip.ParsePrefix("10.0.0.0/33"): prefix length out of range`)
+	assert.Error(t, (&Config{
+		ServerConfig:    confighttp.ServerConfig{Endpoint: "localhost:55679"},
+		AllowedNetworks: []string{"localhost"},
+	}).Validate())
+}
+
+func TestParseNetworks(t *testing.T) {
+	networks, err := parseNetworks([]string{"10.1.2.3/8", "192.168.0.1", "::ffff:127.0.0.1", "fd00::/8"})
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.0.1/32"),
+		netip.MustParsePrefix("127.0.0.1/32"),
+		netip.MustParsePrefix("fd00::/8"),
+	}, networks)
 }
 
 func TestUnmarshalConfig(t *testing.T) {
@@ -39,3 +63,39 @@ func TestUnmarshalConfig(t *testing.T) {
 			},
 		}, cfg)
 }
+
+func TestUnmarshalAllowedNetworksConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_allowed_networks.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			ServerConfig: confighttp.ServerConfig{
+				Endpoint: "0.0.0.0:56888",
+			},
+			AllowedNetworks: []string{"10.0.0.0/8", "127.0.0.1"},
+		}, cfg)
+	require.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestUnmarshalPprofConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_pprof.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.Equal(t,
+		&Config{
+			ServerConfig: confighttp.ServerConfig{
+				Endpoint: "localhost:56888",
+			},
+			Pprof: PprofConfig{
+				Enabled:              true,
+				BlockProfileRate:     1,
+				MutexProfileFraction: 10,
+			},
+		}, cfg)
+	require.NoError(t, cfg.(*Config).Validate())
+}
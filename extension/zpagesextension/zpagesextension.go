@@ -7,8 +7,12 @@ import (
 	"context"
 	"errors"
 	"expvar"
+	"net"
 	"net/http"
+	"net/http/pprof"
+	"net/netip"
 	"path"
+	"runtime"
 
 	"go.opentelemetry.io/contrib/zpages"
 	traceSdk "go.opentelemetry.io/otel/sdk/trace"
@@ -22,6 +26,8 @@ import (
 const (
 	tracezPath  = "tracez"
 	expvarzPath = "expvarz"
+	pprofPath   = "pprof"
+	tapzPath    = "tapz"
 )
 
 type zpagesExtension struct {
@@ -30,6 +36,9 @@ type zpagesExtension struct {
 	zpagesSpanProcessor *zpages.SpanProcessor
 	server              *http.Server
 	stopCh              chan struct{}
+
+	// The mutex profile fraction before the extension started, restored on shutdown.
+	prevMutexProfileFraction int
 }
 
 // registerableTracerProvider is a tracer that supports
@@ -74,6 +83,11 @@ func (zpe *zpagesExtension) Start(ctx context.Context, host component.Host) erro
 		zpe.telemetry.Logger.Info("Registered zPages expvar handler")
 	}
 
+	if zpe.config.Pprof.Enabled {
+		zpe.registerPprof(zPagesMux)
+		zpe.telemetry.Logger.Info("Registered zPages pprof handlers")
+	}
+
 	hostZPages, ok := host.(interface {
 		RegisterZPages(mux *http.ServeMux, pathPrefix string)
 	})
@@ -92,10 +106,25 @@ func (zpe *zpagesExtension) Start(ctx context.Context, host component.Host) erro
 	}
 
 	zpe.telemetry.Logger.Info("Starting zPages extension", zap.Any("config", zpe.config))
-	zpe.server, err = zpe.config.ToServer(ctx, host, zpe.telemetry, zPagesMux)
+	var handler http.Handler = zPagesMux
+	if !zpe.config.Tap.Enabled {
+		handler = disablePath(path.Join("/debug", tapzPath), handler)
+	}
+	zpe.server, err = zpe.config.ToServer(ctx, host, zpe.telemetry, handler)
 	if err != nil {
 		return err
 	}
+	if len(zpe.config.AllowedNetworks) > 0 {
+		networks, errNetworks := parseNetworks(zpe.config.AllowedNetworks)
+		if errNetworks != nil {
+			return errNetworks
+		}
+		// The networks are checked before any other handler, in particular the authentication.
+		zpe.server.Handler = allowNetworks(networks, zpe.server.Handler)
+	} else if !zpe.config.TLS.HasValue() && !zpe.config.Auth.HasValue() && !isLocalEndpoint(zpe.config.Endpoint) {
+		zpe.telemetry.Logger.Warn("zPages are served over unauthenticated cleartext HTTP to all networks, " +
+			"configure \"tls\", \"auth\" or \"allowed_networks\" to protect them")
+	}
 	zpe.stopCh = make(chan struct{})
 	go func() {
 		defer close(zpe.stopCh)
@@ -117,6 +146,10 @@ func (zpe *zpagesExtension) Shutdown(context.Context) error {
 		<-zpe.stopCh
 	}
 
+	if zpe.config.Pprof.Enabled {
+		zpe.unregisterPprof()
+	}
+
 	sdktracer, ok := zpe.telemetry.TracerProvider.(registerableTracerProvider)
 	if ok {
 		sdktracer.UnregisterSpanProcessor(zpe.zpagesSpanProcessor)
@@ -128,6 +161,77 @@ func (zpe *zpagesExtension) Shutdown(context.Context) error {
 	return err
 }
 
+// allowNetworks refuses the requests of the clients outside of the networks with 403 Forbidden.
+func allowNetworks(networks []netip.Prefix, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addrPort, err := netip.ParseAddrPort(r.RemoteAddr)
+		if err == nil {
+			addr := addrPort.Addr().Unmap().WithZone("")
+			for _, network := range networks {
+				if network.Contains(addr) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+		}
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	})
+}
+
+// disablePath refuses the requests to the given path with 404 Not Found, in place of the handler.
+func disablePath(disabled string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if path.Clean(r.URL.Path) == disabled {
+			http.NotFound(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLocalEndpoint returns whether the endpoint only listens on the loopback interface.
+func isLocalEndpoint(endpoint string) bool {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && addr.IsLoopback()
+}
+
+// registerPprof exposes the net/http/pprof endpoints under /debug/pprof/, and enables the block
+// and mutex profiles if configured. The profiles are process-wide.
+func (zpe *zpagesExtension) registerPprof(mux *http.ServeMux) {
+	prefix := path.Join("/debug", pprofPath)
+	// The index also serves the named profiles, e.g. /debug/pprof/heap.
+	mux.HandleFunc(prefix+"/", pprof.Index)
+	mux.HandleFunc(path.Join(prefix, "cmdline"), pprof.Cmdline)
+	mux.HandleFunc(path.Join(prefix, "profile"), pprof.Profile)
+	mux.HandleFunc(path.Join(prefix, "symbol"), pprof.Symbol)
+	mux.HandleFunc(path.Join(prefix, "trace"), pprof.Trace)
+
+	if zpe.config.Pprof.BlockProfileRate > 0 {
+		runtime.SetBlockProfileRate(zpe.config.Pprof.BlockProfileRate)
+	}
+	zpe.prevMutexProfileFraction = runtime.SetMutexProfileFraction(-1)
+	if zpe.config.Pprof.MutexProfileFraction > 0 {
+		runtime.SetMutexProfileFraction(zpe.config.Pprof.MutexProfileFraction)
+	}
+}
+
+// unregisterPprof disables the block profile and restores the mutex profile fraction.
+func (zpe *zpagesExtension) unregisterPprof() {
+	if zpe.config.Pprof.BlockProfileRate > 0 {
+		runtime.SetBlockProfileRate(0)
+	}
+	if zpe.config.Pprof.MutexProfileFraction > 0 {
+		runtime.SetMutexProfileFraction(zpe.prevMutexProfileFraction)
+	}
+}
+
 func newServer(config *Config, telemetry component.TelemetrySettings) *zpagesExtension {
 	return &zpagesExtension{
 		config:              config,
@@ -7,9 +7,11 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"path"
 	"runtime"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
@@ -30,7 +32,9 @@ func newZPagesHost() *zpagesHost {
 	return &zpagesHost{Host: componenttest.NewNopHost()}
 }
 
-func (*zpagesHost) RegisterZPages(*http.ServeMux, string) {}
+func (*zpagesHost) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
+	mux.HandleFunc(path.Join(pathPrefix, tapzPath), func(http.ResponseWriter, *http.Request) {})
+}
 
 var (
 	_ registerableTracerProvider = (*registerableProvider)(nil)
@@ -77,6 +81,52 @@ func TestZPagesExtensionUsage(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func TestZPagesExtensionAllowedNetworks(t *testing.T) {
+	tests := []struct {
+		name            string
+		allowedNetworks []string
+		wantStatus      int
+	}{
+		{
+			name:            "allowed",
+			allowedNetworks: []string{"10.0.0.0/8", "127.0.0.0/8", "::1"},
+			wantStatus:      http.StatusOK,
+		},
+		{
+			name:            "refused",
+			allowedNetworks: []string{"10.0.0.0/8", "fd00::/8"},
+			wantStatus:      http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				ServerConfig: confighttp.ServerConfig{
+					Endpoint: testutil.GetAvailableLocalAddress(t),
+				},
+				AllowedNetworks: tt.allowedNetworks,
+			}
+			zpagesExt := newServer(cfg, newZpagesTelemetrySettings())
+			require.NoError(t, zpagesExt.Start(context.Background(), newZPagesHost()))
+			t.Cleanup(func() { require.NoError(t, zpagesExt.Shutdown(context.Background())) })
+
+			resp, err := http.Get("http://" + cfg.Endpoint + "/debug/tracez")
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestIsLocalEndpoint(t *testing.T) {
+	assert.True(t, isLocalEndpoint("localhost:55679"))
+	assert.True(t, isLocalEndpoint("127.0.0.1:55679"))
+	assert.True(t, isLocalEndpoint("[::1]:55679"))
+	assert.False(t, isLocalEndpoint(":55679"))
+	assert.False(t, isLocalEndpoint("0.0.0.0:55679"))
+	assert.False(t, isLocalEndpoint("collector.example.com:55679"))
+}
+
 func TestZPagesExtensionBadAuthExtension(t *testing.T) {
 	cfg := &Config{
 		ServerConfig: confighttp.ServerConfig{
@@ -183,3 +233,90 @@ func TestZPagesEnableExpvar(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
+
+func TestZPagesEnablePprof(t *testing.T) {
+	cfg := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		Pprof: PprofConfig{
+			Enabled:              true,
+			MutexProfileFraction: 5,
+		},
+	}
+
+	zpagesExt := newServer(cfg, newZpagesTelemetrySettings())
+	require.NotNil(t, zpagesExt)
+
+	prev := runtime.SetMutexProfileFraction(-1)
+	require.NoError(t, zpagesExt.Start(context.Background(), newZPagesHost()))
+	require.Equal(t, 5, runtime.SetMutexProfileFraction(-1))
+
+	// Give a chance for the server goroutine to run.
+	runtime.Gosched()
+
+	_, zpagesPort, err := net.SplitHostPort(cfg.Endpoint)
+	require.NoError(t, err)
+
+	client := &http.Client{}
+	for _, p := range []string{"/debug/pprof/", "/debug/pprof/heap?debug=1", "/debug/pprof/cmdline"} {
+		resp, err := client.Get("http://localhost:" + zpagesPort + p)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode, p)
+	}
+
+	require.NoError(t, zpagesExt.Shutdown(context.Background()))
+	require.Equal(t, prev, runtime.SetMutexProfileFraction(-1))
+}
+
+func TestZPagesTap(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		cfg := &Config{
+			ServerConfig: confighttp.ServerConfig{
+				Endpoint: testutil.GetAvailableLocalAddress(t),
+			},
+			Tap: TapConfig{Enabled: enabled},
+		}
+
+		zpagesExt := newServer(cfg, newZpagesTelemetrySettings())
+		require.NoError(t, zpagesExt.Start(context.Background(), newZPagesHost()))
+
+		resp, err := http.Get("http://" + cfg.Endpoint + "/debug/tapz")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		if enabled {
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		} else {
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		}
+		require.NoError(t, zpagesExt.Shutdown(context.Background()))
+	}
+}
+
+func TestZPagesPprofDisabled(t *testing.T) {
+	cfg := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+	}
+
+	zpagesExt := newServer(cfg, newZpagesTelemetrySettings())
+	require.NotNil(t, zpagesExt)
+
+	require.NoError(t, zpagesExt.Start(context.Background(), newZPagesHost()))
+	t.Cleanup(func() { require.NoError(t, zpagesExt.Shutdown(context.Background())) })
+
+	// Give a chance for the server goroutine to run.
+	runtime.Gosched()
+
+	_, zpagesPort, err := net.SplitHostPort(cfg.Endpoint)
+	require.NoError(t, err)
+
+	client := &http.Client{}
+	resp, err := client.Get("http://localhost:" + zpagesPort + "/debug/pprof/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
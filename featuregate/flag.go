@@ -5,6 +5,8 @@ package featuregate // import "go.opentelemetry.io/collector/featuregate"
 
 import (
 	"flag"
+	"maps"
+	"slices"
 	"strings"
 
 	"go.uber.org/multierr"
@@ -12,7 +14,8 @@ import (
 
 const (
 	featureGatesFlag            = "feature-gates"
-	featureGatesFlagDescription = "Comma-delimited list of feature gate identifiers. Prefix with '-' to disable the feature. '+' or no prefix will enable the feature."
+	featureGatesFlagDescription = "Comma-delimited list of feature gate identifiers. Prefix with '-' to disable the feature. '+' or no prefix will enable the feature. " +
+		"Suffix with '@<kind>/<type>[/<name>]' to only set the feature for a component instance, e.g. '@exporter/otlp/canary'."
 )
 
 // RegisterFlagsOption is an option for RegisterFlags.
@@ -39,11 +42,11 @@ func (f *flagValue) String() string {
 
 	var ids []string
 	f.reg.VisitAll(func(g *Gate) {
-		id := g.ID()
-		if !g.IsEnabled() {
-			id = "-" + id
+		ids = append(ids, flagEntry(g.ID(), g.IsEnabled()))
+		scopes := g.Scopes()
+		for _, scope := range slices.Sorted(maps.Keys(scopes)) {
+			ids = append(ids, flagEntry(g.ID()+"@"+scope, scopes[scope]))
 		}
-		ids = append(ids, id)
 	})
 	return strings.Join(ids, ",")
 }
@@ -65,7 +68,18 @@ func (f *flagValue) Set(s string) error {
 		case '+':
 			id = id[1:]
 		}
+		if id, scope, ok := strings.Cut(id, "@"); ok {
+			errs = multierr.Append(errs, f.reg.SetFor(id, scope, val))
+			continue
+		}
 		errs = multierr.Append(errs, f.reg.Set(id, val))
 	}
 	return errs
 }
+
+func flagEntry(id string, enabled bool) string {
+	if !enabled {
+		return "-" + id
+	}
+	return id
+}
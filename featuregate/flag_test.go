@@ -100,6 +100,32 @@ func TestNewFlag(t *testing.T) {
 			expected:       map[string]bool{"alpha": false, "beta": true, "deprecated": false, "stable": true},
 			expectedStr:    "-alpha,beta,-deprecated,stable",
 		},
+		{
+			name:        "enable alpha for a component",
+			input:       "alpha@exporter/otlp/canary",
+			expected:    map[string]bool{"alpha": false, "beta": true, "deprecated": false, "stable": true},
+			expectedStr: "-alpha,alpha@exporter/otlp/canary,beta,-deprecated,stable",
+		},
+		{
+			name:        "disable beta for components",
+			input:       "-beta@receiver/otlp,+beta@exporter/otlp",
+			expected:    map[string]bool{"alpha": false, "beta": true, "deprecated": false, "stable": true},
+			expectedStr: "-alpha,beta,beta@exporter/otlp,-beta@receiver/otlp,-deprecated,stable",
+		},
+		{
+			name:           "disable stable for a component",
+			input:          "-stable@exporter/otlp",
+			expectedSetErr: true,
+			expected:       map[string]bool{"alpha": false, "beta": true, "deprecated": false, "stable": true},
+			expectedStr:    "-alpha,beta,-deprecated,stable",
+		},
+		{
+			name:           "invalid scope",
+			input:          "alpha@otlp",
+			expectedSetErr: true,
+			expected:       map[string]bool{"alpha": false, "beta": true, "deprecated": false, "stable": true},
+			expectedStr:    "-alpha,beta,-deprecated,stable",
+		},
 		{
 			name:           "disable missing",
 			input:          "missing",
@@ -5,6 +5,8 @@ package featuregate // import "go.opentelemetry.io/collector/featuregate"
 
 import (
 	"fmt"
+	"maps"
+	"strings"
 	"sync/atomic"
 
 	"github.com/hashicorp/go-version"
@@ -19,7 +21,10 @@ type Gate struct {
 	fromVersion  *version.Version
 	toVersion    *version.Version
 	stage        Stage
+	runtimeSafe  bool
 	enabled      *atomic.Bool
+	// scopes are the enabled values overriding enabled for some component instances, by scope.
+	scopes atomic.Pointer[map[string]bool]
 }
 
 // ID returns the id of the Gate.
@@ -32,6 +37,38 @@ func (g *Gate) IsEnabled() bool {
 	return g.enabled.Load()
 }
 
+// IsEnabledByDefault returns true if the feature described by the Gate is enabled when the Gate is not set,
+// i.e. if the Gate is in StageBeta or StageStable.
+func (g *Gate) IsEnabledByDefault() bool {
+	return g.stage == StageBeta || g.stage == StageStable
+}
+
+// IsEnabledFor returns true if the feature described by the Gate is enabled for the component instance
+// identified by scope, see ComponentScope. It is IsEnabled unless the Gate was set for this scope.
+func (g *Gate) IsEnabledFor(scope string) bool {
+	if scopes := g.scopes.Load(); scopes != nil {
+		if enabled, ok := (*scopes)[scope]; ok {
+			return enabled
+		}
+	}
+	return g.IsEnabled()
+}
+
+// Scopes returns the enabled values of the Gate set for some component instances, by scope.
+func (g *Gate) Scopes() map[string]bool {
+	scopes := g.scopes.Load()
+	if scopes == nil {
+		return nil
+	}
+	return maps.Clone(*scopes)
+}
+
+// ComponentScope returns the scope identifying a component instance, "<kind>/<type>[/<name>]",
+// e.g. "exporter/otlp/canary", from the string representations of its kind and ID.
+func ComponentScope(kind, id string) string {
+	return strings.ToLower(kind) + "/" + id
+}
+
 // Description returns the description for the Gate.
 func (g *Gate) Description() string {
 	return g.description
@@ -42,6 +79,12 @@ func (g *Gate) Stage() Stage {
 	return g.stage
 }
 
+// IsRuntimeSafe returns true if the Gate can be enabled or disabled while the Collector is running,
+// see WithRegisterRuntimeSafe.
+func (g *Gate) IsRuntimeSafe() bool {
+	return g.runtimeSafe
+}
+
 // ReferenceURL returns the URL to the contextual information about the Gate.
 func (g *Gate) ReferenceURL() string {
 	return g.referenceURL
@@ -56,3 +99,17 @@ func (g *Gate) FromVersion() string {
 func (g *Gate) ToVersion() string {
 	return fmt.Sprintf("v%s", g.toVersion)
 }
+
+// IsPastRemoval returns true if the Gate has a "ToVersion" and the given Collector version, e.g. the version
+// of the running Collector, is after it: the Gate was due to be removed and setting it may break once it is.
+// The pre-release part of the versions is ignored, and it returns false if the version is not valid.
+func (g *Gate) IsPastRemoval(collectorVersion string) bool {
+	if g.toVersion == nil {
+		return false
+	}
+	v, err := version.NewVersion(collectorVersion)
+	if err != nil {
+		return false
+	}
+	return v.Core().GreaterThan(g.toVersion.Core())
+}
@@ -37,4 +37,22 @@ func TestGate(t *testing.T) {
 	assert.Equal(t, "http://example.com", g.ReferenceURL())
 	assert.Equal(t, "v0.61.0", g.FromVersion())
 	assert.Equal(t, "v0.64.0", g.ToVersion())
+	assert.False(t, g.IsRuntimeSafe())
+	assert.True(t, g.IsEnabledFor("exporter/otlp"))
+	assert.Nil(t, g.Scopes())
+	assert.False(t, g.IsEnabledByDefault())
+}
+
+func TestGateIsPastRemoval(t *testing.T) {
+	reg := NewRegistry()
+	g := reg.MustRegister("test.removed", StageDeprecated, WithRegisterToVersion("v0.64.0"))
+	assert.False(t, g.IsPastRemoval("v0.63.0"))
+	assert.False(t, g.IsPastRemoval("0.64.0"))
+	assert.False(t, g.IsPastRemoval("v0.64.0-rc.1"))
+	assert.True(t, g.IsPastRemoval("v0.65.0-dev"))
+	assert.True(t, g.IsPastRemoval("1.0.0"))
+	assert.False(t, g.IsPastRemoval("latest"))
+
+	assert.False(t, reg.MustRegister("test.alpha", StageAlpha).IsPastRemoval("v1.0.0"))
+	assert.True(t, reg.MustRegister("test.beta", StageBeta).IsEnabledByDefault())
 }
@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"maps"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 
@@ -18,6 +21,9 @@ import (
 var (
 	globalRegistry = NewRegistry()
 
+	// componentKinds are the kinds of the component instances a Gate can be set for, see ComponentScope.
+	componentKinds = []string{"receiver", "processor", "exporter", "extension", "connector"}
+
 	// idRegexp is used to validate the ID of a Gate.
 	// IDs' characters must be alphanumeric or dots.
 	idRegexp = regexp.MustCompile(`^[0-9a-zA-Z.]*$`)
@@ -33,6 +39,17 @@ func GlobalRegistry() *Registry {
 
 type Registry struct {
 	gates sync.Map
+
+	subscribersMu sync.Mutex
+	subscribers   []*subscriber
+
+	// scopesMu serializes the updates of the scopes of the gates.
+	scopesMu sync.Mutex
+}
+
+// subscriber is a function subscribed to the changes of the gates, see Registry.Subscribe.
+type subscriber struct {
+	fn func(*Gate)
 }
 
 // NewRegistry returns a new empty Registry.
@@ -105,6 +122,16 @@ func WithRegisterToVersion(toVersion string) RegisterOption {
 	})
 }
 
+// WithRegisterRuntimeSafe marks the Gate as safe to be enabled or disabled while the Collector is running,
+// see Registry.SetAtRuntime. The components using a runtime safe Gate must check it every time it matters,
+// or subscribe to its changes with Registry.Subscribe, instead of checking it once when they start.
+func WithRegisterRuntimeSafe() RegisterOption {
+	return registerOptionFunc(func(g *Gate) error {
+		g.runtimeSafe = true
+		return nil
+	})
+}
+
 // MustRegister like Register but panics if an invalid ID or gate options are provided.
 func (r *Registry) MustRegister(id string, stage Stage, opts ...RegisterOption) *Gate {
 	g, err := r.Register(id, stage, opts...)
@@ -168,15 +195,10 @@ func (r *Registry) Register(id string, stage Stage, opts ...RegisterOption) (*Ga
 
 // Set the enabled valued for a Gate identified by the given id.
 func (r *Registry) Set(id string, enabled bool) error {
-	v, ok := r.gates.Load(id)
-	if !ok {
-		validGates := []string{}
-		r.VisitAll(func(g *Gate) {
-			validGates = append(validGates, g.ID())
-		})
-		return fmt.Errorf("no such feature gate %q. valid gates: %v", id, validGates)
+	g, err := r.load(id)
+	if err != nil {
+		return err
 	}
-	g := v.(*Gate)
 
 	switch g.stage {
 	case StageStable:
@@ -190,11 +212,102 @@ func (r *Registry) Set(id string, enabled bool) error {
 		}
 		fmt.Printf("Feature gate %q is deprecated and already disabled. It will be removed in version %v and continued use of the gate after version %v will result in an error.\n", id, g.toVersion, g.toVersion)
 	default:
-		g.enabled.Store(enabled)
+		if g.enabled.Swap(enabled) != enabled {
+			r.notify(g)
+		}
+	}
+	return nil
+}
+
+func (r *Registry) load(id string) (*Gate, error) {
+	v, ok := r.gates.Load(id)
+	if !ok {
+		validGates := []string{}
+		r.VisitAll(func(g *Gate) {
+			validGates = append(validGates, g.ID())
+		})
+		return nil, fmt.Errorf("no such feature gate %q. valid gates: %v", id, validGates)
+	}
+	return v.(*Gate), nil
+}
+
+// validateScope checks that the scope identifies a component instance, see ComponentScope.
+func validateScope(scope string) error {
+	kind, id, ok := strings.Cut(scope, "/")
+	if !ok || id == "" || strings.HasPrefix(id, "/") {
+		return errors.New("must be <kind>/<type>[/<name>]")
+	}
+	if !slices.Contains(componentKinds, kind) {
+		return fmt.Errorf("unknown component kind %q, must be one of %v", kind, componentKinds)
+	}
+	return nil
+}
+
+// SetFor sets the enabled value for a Gate identified by the given id, only for the component instance
+// identified by scope, see ComponentScope. The other component instances keep the value of the Gate.
+func (r *Registry) SetFor(id, scope string, enabled bool) error {
+	if err := validateScope(scope); err != nil {
+		return fmt.Errorf("invalid scope %q for feature gate %q: %w", scope, id, err)
+	}
+	g, err := r.load(id)
+	if err != nil {
+		return err
+	}
+	switch {
+	case g.stage == StageStable && !enabled:
+		return fmt.Errorf("feature gate %q is stable, can not be disabled", id)
+	case g.stage == StageDeprecated && enabled:
+		return fmt.Errorf("feature gate %q is deprecated, can not be enabled", id)
+	}
+
+	r.scopesMu.Lock()
+	prev := g.IsEnabledFor(scope)
+	scopes := map[string]bool{}
+	if current := g.scopes.Load(); current != nil {
+		scopes = maps.Clone(*current)
+	}
+	scopes[scope] = enabled
+	g.scopes.Store(&scopes)
+	r.scopesMu.Unlock()
+
+	if prev != enabled {
+		r.notify(g)
 	}
 	return nil
 }
 
+// SetAtRuntime sets the enabled value for a Gate identified by the given id, like Set, while the
+// Collector is running. The Gate must be runtime safe, see WithRegisterRuntimeSafe.
+func (r *Registry) SetAtRuntime(id string, enabled bool) error {
+	if v, ok := r.gates.Load(id); ok && !v.(*Gate).runtimeSafe {
+		return fmt.Errorf("feature gate %q is not runtime safe, can only be set when the collector starts", id)
+	}
+	return r.Set(id, enabled)
+}
+
+// Subscribe calls fn with each Gate whose enabled value changes, until the returned function is called.
+// fn is called synchronously by Set and SetAtRuntime, so it must not block.
+func (r *Registry) Subscribe(fn func(*Gate)) (unsubscribe func()) {
+	s := &subscriber{fn: fn}
+	r.subscribersMu.Lock()
+	r.subscribers = append(r.subscribers, s)
+	r.subscribersMu.Unlock()
+	return func() {
+		r.subscribersMu.Lock()
+		defer r.subscribersMu.Unlock()
+		r.subscribers = slices.DeleteFunc(r.subscribers, func(other *subscriber) bool { return other == s })
+	}
+}
+
+func (r *Registry) notify(g *Gate) {
+	r.subscribersMu.Lock()
+	subscribers := slices.Clone(r.subscribers)
+	r.subscribersMu.Unlock()
+	for _, s := range subscribers {
+		s.fn(g)
+	}
+}
+
 // VisitAll visits all the gates in lexicographical order, calling fn for each.
 func (r *Registry) VisitAll(fn func(*Gate)) {
 	var gates []*Gate
@@ -4,6 +4,7 @@
 package featuregate
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -67,6 +68,86 @@ func TestRegistryApply(t *testing.T) {
 	assert.True(t, fooGate.IsEnabled())
 }
 
+func TestRegistrySetAtRuntime(t *testing.T) {
+	r := NewRegistry()
+	safeGate := r.MustRegister("safe", StageAlpha, WithRegisterRuntimeSafe())
+	unsafeGate := r.MustRegister("unsafe", StageAlpha)
+	stableGate := r.MustRegister("stable", StageStable, WithRegisterRuntimeSafe(), WithRegisterToVersion("v1.0.0"))
+	assert.True(t, safeGate.IsRuntimeSafe())
+	assert.False(t, unsafeGate.IsRuntimeSafe())
+
+	require.NoError(t, r.SetAtRuntime(safeGate.ID(), true))
+	assert.True(t, safeGate.IsEnabled())
+	require.NoError(t, r.SetAtRuntime(safeGate.ID(), false))
+	assert.False(t, safeGate.IsEnabled())
+
+	require.ErrorContains(t, r.SetAtRuntime(unsafeGate.ID(), true), `feature gate "unsafe" is not runtime safe`)
+	assert.False(t, unsafeGate.IsEnabled())
+	require.Error(t, r.SetAtRuntime(stableGate.ID(), false))
+	assert.True(t, stableGate.IsEnabled())
+	require.ErrorContains(t, r.SetAtRuntime("missing", true), `no such feature gate "missing"`)
+}
+
+func TestRegistrySubscribe(t *testing.T) {
+	r := NewRegistry()
+	fooGate := r.MustRegister("foo", StageAlpha, WithRegisterRuntimeSafe())
+	barGate := r.MustRegister("bar", StageBeta)
+
+	var first, second []string
+	unsubscribeFirst := r.Subscribe(func(g *Gate) {
+		first = append(first, fmt.Sprintf("%s=%v", g.ID(), g.IsEnabled()))
+	})
+	unsubscribeSecond := r.Subscribe(func(g *Gate) {
+		second = append(second, g.ID())
+	})
+
+	require.NoError(t, r.SetAtRuntime(fooGate.ID(), true))
+	// Setting the current value is not a change.
+	require.NoError(t, r.SetAtRuntime(fooGate.ID(), true))
+	require.NoError(t, r.Set(barGate.ID(), false))
+	unsubscribeFirst()
+	require.NoError(t, r.SetAtRuntime(fooGate.ID(), false))
+	unsubscribeSecond()
+	unsubscribeSecond()
+	require.NoError(t, r.SetAtRuntime(fooGate.ID(), true))
+
+	assert.Equal(t, []string{"foo=true", "bar=false"}, first)
+	assert.Equal(t, []string{"foo", "bar", "foo"}, second)
+}
+
+func TestRegistrySetFor(t *testing.T) {
+	r := NewRegistry()
+	g := r.MustRegister("foo", StageAlpha)
+	stable := r.MustRegister("stable", StageStable, WithRegisterToVersion("v1.0.0"))
+	canary := ComponentScope("Exporter", "otlp/canary")
+	assert.Equal(t, "exporter/otlp/canary", canary)
+
+	var changed []string
+	defer r.Subscribe(func(g *Gate) { changed = append(changed, g.ID()) })()
+
+	require.NoError(t, r.SetFor(g.ID(), canary, true))
+	assert.True(t, g.IsEnabledFor(canary))
+	assert.False(t, g.IsEnabledFor("exporter/otlp"))
+	assert.False(t, g.IsEnabled())
+	assert.Equal(t, map[string]bool{canary: true}, g.Scopes())
+
+	// The scoped value overrides the value of the gate.
+	require.NoError(t, r.Set(g.ID(), true))
+	require.NoError(t, r.SetFor(g.ID(), "exporter/otlp", false))
+	assert.True(t, g.IsEnabledFor(canary))
+	assert.False(t, g.IsEnabledFor("exporter/otlp"))
+	assert.True(t, g.IsEnabledFor("receiver/otlp"))
+	require.NoError(t, r.SetFor(g.ID(), "exporter/otlp", false))
+	assert.Equal(t, []string{"foo", "foo", "foo"}, changed)
+
+	require.ErrorContains(t, r.SetFor(stable.ID(), canary, false), `feature gate "stable" is stable, can not be disabled`)
+	require.NoError(t, r.SetFor(stable.ID(), canary, true))
+	require.ErrorContains(t, r.SetFor("missing", canary, true), `no such feature gate "missing"`)
+	for _, scope := range []string{"", "exporter", "exporter/", "exporter//canary", "pipeline/traces"} {
+		require.ErrorContains(t, r.SetFor(g.ID(), scope, true), "invalid scope", scope)
+	}
+}
+
 func TestRegisterGateLifecycle(t *testing.T) {
 	for _, tc := range []struct {
 		name      string
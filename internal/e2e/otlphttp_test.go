@@ -123,6 +123,8 @@ func TestTraceRoundTrip(t *testing.T) {
 			}, 1*time.Second, 10*time.Millisecond)
 			allTraces := sink.AllTraces()
 			require.Len(t, allTraces, 1)
+			// The data received as OTLP protobuf is read-only to pass its encoding through to the exporters.
+			td.MarkReadOnly()
 			assert.Equal(t, td, allTraces[0])
 		})
 	}
@@ -176,6 +178,8 @@ func TestMetricsRoundTrip(t *testing.T) {
 			}, 1*time.Second, 10*time.Millisecond)
 			allMetrics := sink.AllMetrics()
 			require.Len(t, allMetrics, 1)
+			// The data received as OTLP protobuf is read-only to pass its encoding through to the exporters.
+			md.MarkReadOnly()
 			assert.Equal(t, md, allMetrics[0])
 		})
 	}
@@ -229,6 +233,8 @@ func TestLogsRoundTrip(t *testing.T) {
 			}, 1*time.Second, 10*time.Millisecond)
 			allLogs := sink.AllLogs()
 			require.Len(t, allLogs, 1)
+			// The data received as OTLP protobuf is read-only to pass its encoding through to the exporters.
+			md.MarkReadOnly()
 			assert.Equal(t, md, allLogs[0])
 		})
 	}
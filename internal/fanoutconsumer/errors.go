@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/internal/fanoutconsumer"
+
+import (
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// joinErrors returns the error of the consumers the data was fanned out to. The data was
+// partially consumed only if all the consumers which failed partially consumed it, in which case
+// the most items rejected by one of them are reported. Otherwise, only the other errors are
+// returned, so that the sender retries the data if they are retryable.
+func joinErrors(errs error) error {
+	var partials, others []error
+	rejected := 0
+	for _, err := range multierr.Errors(errs) {
+		n, ok := consumererror.Rejected(err)
+		if !ok {
+			others = append(others, err)
+			continue
+		}
+		partials = append(partials, err)
+		rejected = max(rejected, n)
+	}
+	switch {
+	case len(others) > 0:
+		return multierr.Combine(others...)
+	case len(partials) == 1:
+		return partials[0]
+	case len(partials) > 1:
+		return consumererror.NewPartial(multierr.Combine(partials...), rejected)
+	}
+	return nil
+}
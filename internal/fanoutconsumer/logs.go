@@ -12,24 +12,34 @@ import (
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/xpdata/pref"
 )
 
 // NewLogs wraps multiple log consumers in a single one.
 // It fans out the incoming data to all the consumers, and does smart routing:
 //   - Clones only to the consumer that needs to mutate the data.
+//   - Only copies the resources and scopes for the consumers mutating nothing else.
 //   - If all consumers needs to mutate the data one will get the original mutable data.
 func NewLogs(lcs []consumer.Logs) consumer.Logs {
+	return NewLogsWithClonePolicy(lcs, CloneEager)
+}
+
+// NewLogsWithClonePolicy is like NewLogs, copying the data for the mutating consumers according to the given policy.
+func NewLogsWithClonePolicy(lcs []consumer.Logs, policy ClonePolicy) consumer.Logs {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(lcs) == 1 && !lcs[0].Capabilities().MutatesData {
 		return lcs[0]
 	}
 
-	lc := &logsConsumer{}
+	lc := &logsConsumer{lazy: policy == CloneLazy}
 	for i := range lcs {
-		if lcs[i].Capabilities().MutatesData {
-			lc.mutable = append(lc.mutable, lcs[i])
-		} else {
+		switch caps := lcs[i].Capabilities(); {
+		case !caps.MutatesData || policy == CloneReadOnly:
 			lc.readonly = append(lc.readonly, lcs[i])
+		case caps.MutatesOnly(shallowMutations):
+			lc.shallow = append(lc.shallow, lcs[i])
+		default:
+			lc.mutable = append(lc.mutable, lcs[i])
 		}
 	}
 	return lc
@@ -37,34 +47,47 @@ func NewLogs(lcs []consumer.Logs) consumer.Logs {
 
 type logsConsumer struct {
 	mutable  []consumer.Logs
+	shallow  []consumer.Logs
 	readonly []consumer.Logs
+
+	// Whether the read-only consumers are called first, see CloneLazy.
+	lazy bool
 }
 
 func (lsc *logsConsumer) Capabilities() consumer.Capabilities {
-	// If all consumers are mutating, then the original data will be passed to one of them.
-	return consumer.Capabilities{MutatesData: len(lsc.mutable) > 0 && len(lsc.readonly) == 0}
+	// If all consumers are mutating, or with the lazy clone policy, then the original data will be passed to one of them.
+	if len(lsc.readonly) > 0 && !lsc.lazy {
+		return consumer.Capabilities{}
+	}
+	if len(lsc.shallow) > 0 {
+		return lsc.shallow[len(lsc.shallow)-1].Capabilities()
+	}
+	if len(lsc.mutable) > 0 {
+		return lsc.mutable[len(lsc.mutable)-1].Capabilities()
+	}
+	return consumer.Capabilities{}
 }
 
 // ConsumeLogs exports the plog.Logs to all consumers wrapped by the current one.
 func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	var errs error
 
-	if len(lsc.mutable) > 0 {
-		// Clone the data before sending to all mutating consumers except the last one.
-		for i := 0; i < len(lsc.mutable)-1; i++ {
-			errs = multierr.Append(errs, lsc.mutable[i].ConsumeLogs(ctx, cloneLogs(ld)))
-		}
-		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
-		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
-		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
-		lastConsumer := lsc.mutable[len(lsc.mutable)-1]
-		if len(lsc.readonly) == 0 && !ld.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeLogs(ctx, ld))
-		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeLogs(ctx, cloneLogs(ld)))
+	// With the lazy clone policy, the non-mutating consumers are called first with the original data, which
+	// can then be sent as is to a mutating consumer, as they are expected not to use the data once they return.
+	if lsc.lazy && len(lsc.mutable)+len(lsc.shallow) > 0 {
+		for _, lc := range lsc.readonly {
+			errs = multierr.Append(errs, lc.ConsumeLogs(ctx, ld))
 		}
+		return joinErrors(multierr.Append(errs, lsc.consumeMutating(ctx, ld, !ld.IsReadOnly())))
 	}
 
+	// Send data as is to the last mutating consumer only if there are no non-mutating consumers and the
+	// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
+	// non-mutating consumer may process data async and the mutating consumer may change the data before that.
+	// The data is preferably sent as is to a consumer mutating only the resources and scopes, as the others
+	// must get a full copy of the data anyway, so that they don't modify the records shared with it.
+	errs = lsc.consumeMutating(ctx, ld, len(lsc.readonly) == 0 && !ld.IsReadOnly())
+
 	// Mark the data as read-only if it will be sent to more than one read-only consumer.
 	if len(lsc.readonly) > 1 && !ld.IsReadOnly() {
 		ld.MarkReadOnly()
@@ -73,6 +96,26 @@ func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 		errs = multierr.Append(errs, lc.ConsumeLogs(ctx, ld))
 	}
 
+	return joinErrors(errs)
+}
+
+// consumeMutating passes the data to the mutating consumers, sending it as is to the last one if sendAsIs.
+func (lsc *logsConsumer) consumeMutating(ctx context.Context, ld plog.Logs, sendAsIs bool) error {
+	var errs error
+	for i, lc := range lsc.mutable {
+		if sendAsIs && len(lsc.shallow) == 0 && i == len(lsc.mutable)-1 {
+			errs = multierr.Append(errs, lc.ConsumeLogs(ctx, ld))
+		} else {
+			errs = multierr.Append(errs, lc.ConsumeLogs(ctx, cloneLogs(ld)))
+		}
+	}
+	for i, lc := range lsc.shallow {
+		if sendAsIs && i == len(lsc.shallow)-1 {
+			errs = multierr.Append(errs, lc.ConsumeLogs(ctx, ld))
+		} else {
+			errs = multierr.Append(errs, lc.ConsumeLogs(ctx, pref.ShallowCloneLogs(ld)))
+		}
+	}
 	return errs
 }
 
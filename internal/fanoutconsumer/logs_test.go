@@ -231,6 +231,32 @@ func TestLogsWhenErrors(t *testing.T) {
 	assert.Equal(t, ld, p3.AllLogs()[1])
 }
 
+func TestLogsMultiplexingShallowMutating(t *testing.T) {
+	p1 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+	p2 := &shallowMutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+	p3 := &shallowMutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+
+	fc := NewLogs([]consumer.Logs{p1, p2, p3})
+	assert.Equal(t, consumer.Capabilities{MutatesData: true, Mutations: consumer.MutatesResources}, fc.Capabilities())
+	ld := testdata.GenerateLogs(1)
+	require.NoError(t, fc.ConsumeLogs(context.Background(), ld))
+
+	// The last consumer mutating only the resources gets the original data.
+	p3.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().PutStr("p3", "true")
+	_, found := ld.ResourceLogs().At(0).Resource().Attributes().Get("p3")
+	assert.True(t, found)
+
+	// The other one gets a copy of the resources, sharing the records with the original data.
+	p2.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().PutStr("p2", "true")
+	_, found = ld.ResourceLogs().At(0).Resource().Attributes().Get("p2")
+	assert.False(t, found)
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetSeverityText("changed")
+	assert.Equal(t, "changed", p2.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityText())
+
+	// The consumer mutating the records gets a full copy.
+	assert.NotEqual(t, "changed", p1.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityText())
+}
+
 type mutatingLogsSink struct {
 	*consumertest.LogsSink
 }
@@ -246,3 +272,11 @@ type mutatingErr struct {
 func (mts mutatingErr) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+type shallowMutatingLogsSink struct {
+	*consumertest.LogsSink
+}
+
+func (mts *shallowMutatingLogsSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true, Mutations: consumer.MutatesResources}
+}
@@ -10,24 +10,34 @@ import (
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/xpdata/pref"
 )
 
 // NewMetrics wraps multiple metrics consumers in a single one.
 // It fans out the incoming data to all the consumers, and does smart routing:
 //   - Clones only to the consumer that needs to mutate the data.
+//   - Only copies the resources and scopes for the consumers mutating nothing else.
 //   - If all consumers needs to mutate the data one will get the original mutable data.
 func NewMetrics(mcs []consumer.Metrics) consumer.Metrics {
+	return NewMetricsWithClonePolicy(mcs, CloneEager)
+}
+
+// NewMetricsWithClonePolicy is like NewMetrics, copying the data for the mutating consumers according to the given policy.
+func NewMetricsWithClonePolicy(mcs []consumer.Metrics, policy ClonePolicy) consumer.Metrics {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(mcs) == 1 && !mcs[0].Capabilities().MutatesData {
 		return mcs[0]
 	}
 
-	mc := &metricsConsumer{}
+	mc := &metricsConsumer{lazy: policy == CloneLazy}
 	for i := range mcs {
-		if mcs[i].Capabilities().MutatesData {
-			mc.mutable = append(mc.mutable, mcs[i])
-		} else {
+		switch caps := mcs[i].Capabilities(); {
+		case !caps.MutatesData || policy == CloneReadOnly:
 			mc.readonly = append(mc.readonly, mcs[i])
+		case caps.MutatesOnly(shallowMutations):
+			mc.shallow = append(mc.shallow, mcs[i])
+		default:
+			mc.mutable = append(mc.mutable, mcs[i])
 		}
 	}
 	return mc
@@ -35,34 +45,47 @@ func NewMetrics(mcs []consumer.Metrics) consumer.Metrics {
 
 type metricsConsumer struct {
 	mutable  []consumer.Metrics
+	shallow  []consumer.Metrics
 	readonly []consumer.Metrics
+
+	// Whether the read-only consumers are called first, see CloneLazy.
+	lazy bool
 }
 
 func (msc *metricsConsumer) Capabilities() consumer.Capabilities {
-	// If all consumers are mutating, then the original data will be passed to one of them.
-	return consumer.Capabilities{MutatesData: len(msc.mutable) > 0 && len(msc.readonly) == 0}
+	// If all consumers are mutating, or with the lazy clone policy, then the original data will be passed to one of them.
+	if len(msc.readonly) > 0 && !msc.lazy {
+		return consumer.Capabilities{}
+	}
+	if len(msc.shallow) > 0 {
+		return msc.shallow[len(msc.shallow)-1].Capabilities()
+	}
+	if len(msc.mutable) > 0 {
+		return msc.mutable[len(msc.mutable)-1].Capabilities()
+	}
+	return consumer.Capabilities{}
 }
 
 // ConsumeMetrics exports the pmetric.Metrics to all consumers wrapped by the current one.
 func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
 	var errs error
 
-	if len(msc.mutable) > 0 {
-		// Clone the data before sending to all mutating consumers except the last one.
-		for i := 0; i < len(msc.mutable)-1; i++ {
-			errs = multierr.Append(errs, msc.mutable[i].ConsumeMetrics(ctx, cloneMetrics(md)))
-		}
-		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
-		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
-		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
-		lastConsumer := msc.mutable[len(msc.mutable)-1]
-		if len(msc.readonly) == 0 && !md.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeMetrics(ctx, md))
-		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeMetrics(ctx, cloneMetrics(md)))
+	// With the lazy clone policy, the non-mutating consumers are called first with the original data, which
+	// can then be sent as is to a mutating consumer, as they are expected not to use the data once they return.
+	if msc.lazy && len(msc.mutable)+len(msc.shallow) > 0 {
+		for _, mc := range msc.readonly {
+			errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, md))
 		}
+		return joinErrors(multierr.Append(errs, msc.consumeMutating(ctx, md, !md.IsReadOnly())))
 	}
 
+	// Send data as is to the last mutating consumer only if there are no non-mutating consumers and the
+	// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
+	// non-mutating consumer may process data async and the mutating consumer may change the data before that.
+	// The data is preferably sent as is to a consumer mutating only the resources and scopes, as the others
+	// must get a full copy of the data anyway, so that they don't modify the records shared with it.
+	errs = msc.consumeMutating(ctx, md, len(msc.readonly) == 0 && !md.IsReadOnly())
+
 	// Mark the data as read-only if it will be sent to more than one read-only consumer.
 	if len(msc.readonly) > 1 && !md.IsReadOnly() {
 		md.MarkReadOnly()
@@ -71,6 +94,26 @@ func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 		errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, md))
 	}
 
+	return joinErrors(errs)
+}
+
+// consumeMutating passes the data to the mutating consumers, sending it as is to the last one if sendAsIs.
+func (msc *metricsConsumer) consumeMutating(ctx context.Context, md pmetric.Metrics, sendAsIs bool) error {
+	var errs error
+	for i, mc := range msc.mutable {
+		if sendAsIs && len(msc.shallow) == 0 && i == len(msc.mutable)-1 {
+			errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, md))
+		} else {
+			errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, cloneMetrics(md)))
+		}
+	}
+	for i, mc := range msc.shallow {
+		if sendAsIs && i == len(msc.shallow)-1 {
+			errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, md))
+		} else {
+			errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, pref.ShallowCloneMetrics(md)))
+		}
+	}
 	return errs
 }
 
@@ -231,6 +231,32 @@ func TestMetricsWhenErrors(t *testing.T) {
 	assert.Equal(t, md, p3.AllMetrics()[1])
 }
 
+func TestMetricsMultiplexingShallowMutating(t *testing.T) {
+	p1 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+	p2 := &shallowMutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+	p3 := &shallowMutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+
+	fc := NewMetrics([]consumer.Metrics{p1, p2, p3})
+	assert.Equal(t, consumer.Capabilities{MutatesData: true, Mutations: consumer.MutatesResources}, fc.Capabilities())
+	md := testdata.GenerateMetrics(1)
+	require.NoError(t, fc.ConsumeMetrics(context.Background(), md))
+
+	// The last consumer mutating only the resources gets the original data.
+	p3.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().PutStr("p3", "true")
+	_, found := md.ResourceMetrics().At(0).Resource().Attributes().Get("p3")
+	assert.True(t, found)
+
+	// The other one gets a copy of the resources, sharing the records with the original data.
+	p2.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().PutStr("p2", "true")
+	_, found = md.ResourceMetrics().At(0).Resource().Attributes().Get("p2")
+	assert.False(t, found)
+	md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName("changed")
+	assert.Equal(t, "changed", p2.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+
+	// The consumer mutating the records gets a full copy.
+	assert.NotEqual(t, "changed", p1.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+}
+
 type mutatingMetricsSink struct {
 	*consumertest.MetricsSink
 }
@@ -238,3 +264,11 @@ type mutatingMetricsSink struct {
 func (mts *mutatingMetricsSink) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+type shallowMutatingMetricsSink struct {
+	*consumertest.MetricsSink
+}
+
+func (mts *shallowMutatingMetricsSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true, Mutations: consumer.MutatesResources}
+}
@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/internal/fanoutconsumer"
+
+import "go.opentelemetry.io/collector/consumer"
+
+// shallowMutations are the parts of the data which can be modified by a consumer
+// getting a copy sharing the records with the original data.
+const shallowMutations = consumer.MutatesResources | consumer.MutatesScopes
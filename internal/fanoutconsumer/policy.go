@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/internal/fanoutconsumer"
+
+// ClonePolicy defines how the data is copied for the consumers mutating it.
+type ClonePolicy int
+
+const (
+	// CloneEager copies the data for each consumer mutating it, unless all the consumers mutate
+	// it, in which case the last one gets the original data. This is the default.
+	CloneEager ClonePolicy = iota
+	// CloneLazy calls the consumers not mutating the data first, then passes the original data to
+	// the last consumer mutating it, saving a copy. The consumers not mutating the data must not
+	// use it once they return, e.g. by queuing it.
+	CloneLazy
+	// CloneReadOnly passes the data as is to all the consumers, as if none of them mutated it. The
+	// data is marked read-only if passed to several consumers, so that a consumer mutating it panics.
+	CloneReadOnly
+)
@@ -11,24 +11,34 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/xpdata/pref"
 )
 
 // NewProfiles wraps multiple profile consumers in a single one.
 // It fans out the incoming data to all the consumers, and does smart routing:
 //   - Clones only to the consumer that needs to mutate the data.
+//   - Only copies the resources and scopes for the consumers mutating nothing else.
 //   - If all consumers needs to mutate the data one will get the original mutable data.
 func NewProfiles(tcs []xconsumer.Profiles) xconsumer.Profiles {
+	return NewProfilesWithClonePolicy(tcs, CloneEager)
+}
+
+// NewProfilesWithClonePolicy is like NewProfiles, copying the data for the mutating consumers according to the given policy.
+func NewProfilesWithClonePolicy(tcs []xconsumer.Profiles, policy ClonePolicy) xconsumer.Profiles {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(tcs) == 1 && !tcs[0].Capabilities().MutatesData {
 		return tcs[0]
 	}
 
-	tc := &profilesConsumer{}
+	tc := &profilesConsumer{lazy: policy == CloneLazy}
 	for i := range tcs {
-		if tcs[i].Capabilities().MutatesData {
-			tc.mutable = append(tc.mutable, tcs[i])
-		} else {
+		switch caps := tcs[i].Capabilities(); {
+		case !caps.MutatesData || policy == CloneReadOnly:
 			tc.readonly = append(tc.readonly, tcs[i])
+		case caps.MutatesOnly(shallowMutations):
+			tc.shallow = append(tc.shallow, tcs[i])
+		default:
+			tc.mutable = append(tc.mutable, tcs[i])
 		}
 	}
 	return tc
@@ -36,34 +46,47 @@ func NewProfiles(tcs []xconsumer.Profiles) xconsumer.Profiles {
 
 type profilesConsumer struct {
 	mutable  []xconsumer.Profiles
+	shallow  []xconsumer.Profiles
 	readonly []xconsumer.Profiles
+
+	// Whether the read-only consumers are called first, see CloneLazy.
+	lazy bool
 }
 
 func (tsc *profilesConsumer) Capabilities() consumer.Capabilities {
-	// If all consumers are mutating, then the original data will be passed to one of them.
-	return consumer.Capabilities{MutatesData: len(tsc.mutable) > 0 && len(tsc.readonly) == 0}
+	// If all consumers are mutating, or with the lazy clone policy, then the original data will be passed to one of them.
+	if len(tsc.readonly) > 0 && !tsc.lazy {
+		return consumer.Capabilities{}
+	}
+	if len(tsc.shallow) > 0 {
+		return tsc.shallow[len(tsc.shallow)-1].Capabilities()
+	}
+	if len(tsc.mutable) > 0 {
+		return tsc.mutable[len(tsc.mutable)-1].Capabilities()
+	}
+	return consumer.Capabilities{}
 }
 
 // ConsumeProfiles exports the pprofile.Profiles to all consumers wrapped by the current one.
 func (tsc *profilesConsumer) ConsumeProfiles(ctx context.Context, td pprofile.Profiles) error {
 	var errs error
 
-	if len(tsc.mutable) > 0 {
-		// Clone the data before sending to all mutating consumers except the last one.
-		for i := 0; i < len(tsc.mutable)-1; i++ {
-			errs = multierr.Append(errs, tsc.mutable[i].ConsumeProfiles(ctx, cloneProfiles(td)))
-		}
-		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
-		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
-		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
-		lastConsumer := tsc.mutable[len(tsc.mutable)-1]
-		if len(tsc.readonly) == 0 && !td.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeProfiles(ctx, td))
-		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeProfiles(ctx, cloneProfiles(td)))
+	// With the lazy clone policy, the non-mutating consumers are called first with the original data, which
+	// can then be sent as is to a mutating consumer, as they are expected not to use the data once they return.
+	if tsc.lazy && len(tsc.mutable)+len(tsc.shallow) > 0 {
+		for _, tc := range tsc.readonly {
+			errs = multierr.Append(errs, tc.ConsumeProfiles(ctx, td))
 		}
+		return joinErrors(multierr.Append(errs, tsc.consumeMutating(ctx, td, !td.IsReadOnly())))
 	}
 
+	// Send data as is to the last mutating consumer only if there are no non-mutating consumers and the
+	// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
+	// non-mutating consumer may process data async and the mutating consumer may change the data before that.
+	// The data is preferably sent as is to a consumer mutating only the resources and scopes, as the others
+	// must get a full copy of the data anyway, so that they don't modify the records shared with it.
+	errs = tsc.consumeMutating(ctx, td, len(tsc.readonly) == 0 && !td.IsReadOnly())
+
 	// Mark the data as read-only if it will be sent to more than one read-only consumer.
 	if len(tsc.readonly) > 1 && !td.IsReadOnly() {
 		td.MarkReadOnly()
@@ -72,6 +95,26 @@ func (tsc *profilesConsumer) ConsumeProfiles(ctx context.Context, td pprofile.Pr
 		errs = multierr.Append(errs, tc.ConsumeProfiles(ctx, td))
 	}
 
+	return joinErrors(errs)
+}
+
+// consumeMutating passes the data to the mutating consumers, sending it as is to the last one if sendAsIs.
+func (tsc *profilesConsumer) consumeMutating(ctx context.Context, td pprofile.Profiles, sendAsIs bool) error {
+	var errs error
+	for i, tc := range tsc.mutable {
+		if sendAsIs && len(tsc.shallow) == 0 && i == len(tsc.mutable)-1 {
+			errs = multierr.Append(errs, tc.ConsumeProfiles(ctx, td))
+		} else {
+			errs = multierr.Append(errs, tc.ConsumeProfiles(ctx, cloneProfiles(td)))
+		}
+	}
+	for i, tc := range tsc.shallow {
+		if sendAsIs && i == len(tsc.shallow)-1 {
+			errs = multierr.Append(errs, tc.ConsumeProfiles(ctx, td))
+		} else {
+			errs = multierr.Append(errs, tc.ConsumeProfiles(ctx, pref.ShallowCloneProfiles(td)))
+		}
+	}
 	return errs
 }
 
@@ -233,6 +233,32 @@ func TestProfilesWhenErrors(t *testing.T) {
 	assert.Equal(t, td, p3.AllProfiles()[1])
 }
 
+func TestProfilesMultiplexingShallowMutating(t *testing.T) {
+	p1 := &mutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
+	p2 := &shallowMutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
+	p3 := &shallowMutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
+
+	fc := NewProfiles([]xconsumer.Profiles{p1, p2, p3})
+	assert.Equal(t, consumer.Capabilities{MutatesData: true, Mutations: consumer.MutatesResources}, fc.Capabilities())
+	pd := testdata.GenerateProfiles(1)
+	require.NoError(t, fc.ConsumeProfiles(context.Background(), pd))
+
+	// The last consumer mutating only the resources gets the original data.
+	p3.AllProfiles()[0].ResourceProfiles().At(0).Resource().Attributes().PutStr("p3", "true")
+	_, found := pd.ResourceProfiles().At(0).Resource().Attributes().Get("p3")
+	assert.True(t, found)
+
+	// The other one gets a copy of the resources, sharing the records with the original data.
+	p2.AllProfiles()[0].ResourceProfiles().At(0).Resource().Attributes().PutStr("p2", "true")
+	_, found = pd.ResourceProfiles().At(0).Resource().Attributes().Get("p2")
+	assert.False(t, found)
+	pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0).SetDroppedAttributesCount(42)
+	assert.Equal(t, uint32(42), p2.AllProfiles()[0].ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0).DroppedAttributesCount())
+
+	// The consumer mutating the records gets a full copy.
+	assert.NotEqual(t, uint32(42), p1.AllProfiles()[0].ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0).DroppedAttributesCount())
+}
+
 type mutatingProfilesSink struct {
 	*consumertest.ProfilesSink
 }
@@ -240,3 +266,11 @@ type mutatingProfilesSink struct {
 func (mts *mutatingProfilesSink) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+type shallowMutatingProfilesSink struct {
+	*consumertest.ProfilesSink
+}
+
+func (mts *shallowMutatingProfilesSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true, Mutations: consumer.MutatesResources}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
@@ -214,6 +215,40 @@ func TestTracesMultiplexingMixLastNonMutating(t *testing.T) {
 	assert.False(t, td.IsReadOnly())
 }
 
+func TestTracesMultiplexingLazy(t *testing.T) {
+	p1 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p2 := new(consumertest.TracesSink)
+	p3 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+
+	tfc := NewTracesWithClonePolicy([]consumer.Traces{p1, p2, p3}, CloneLazy)
+	assert.True(t, tfc.Capabilities().MutatesData)
+	td := testdata.GenerateTraces(1)
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, td, p1.AllTraces()[0])
+	assert.Equal(t, td, p2.AllTraces()[0])
+
+	// The last mutating consumer gets the original data, which is not marked as read only.
+	assert.False(t, td.IsReadOnly())
+	td.ResourceSpans().At(0).Resource().Attributes().PutStr("mutated", "true")
+	assert.Equal(t, td, p3.AllTraces()[0])
+	assert.NotEqual(t, td, p1.AllTraces()[0])
+}
+
+func TestTracesMultiplexingReadOnly(t *testing.T) {
+	p1 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p2 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+
+	tfc := NewTracesWithClonePolicy([]consumer.Traces{p1, p2}, CloneReadOnly)
+	assert.False(t, tfc.Capabilities().MutatesData)
+	td := testdata.GenerateTraces(1)
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+
+	// The data is shared by both consumers.
+	assert.True(t, td.IsReadOnly())
+	assert.True(t, p1.AllTraces()[0].IsReadOnly())
+	assert.True(t, p2.AllTraces()[0].IsReadOnly())
+}
+
 func TestTracesWhenErrors(t *testing.T) {
 	p1 := mutatingErr{Consumer: consumertest.NewErr(errors.New("my error"))}
 	p2 := consumertest.NewErr(errors.New("my error"))
@@ -232,6 +267,54 @@ func TestTracesWhenErrors(t *testing.T) {
 	assert.Equal(t, td, p3.AllTraces()[1])
 }
 
+func TestTracesWhenPartialErrors(t *testing.T) {
+	partial2 := consumertest.NewErr(consumererror.NewPartial(errors.New("invalid spans"), 2))
+	partial3 := consumertest.NewErr(consumererror.NewPartial(errors.New("invalid spans"), 3))
+	retryable := consumertest.NewErr(errors.New("unavailable"))
+	sink := new(consumertest.TracesSink)
+
+	// The data is partially consumed if all the consumers which failed partially consumed it.
+	err := NewTraces([]consumer.Traces{partial2, sink}).ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	rejected, ok := consumererror.Rejected(err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, rejected)
+	err = NewTraces([]consumer.Traces{partial2, partial3, sink}).ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	rejected, ok = consumererror.Rejected(err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, rejected)
+
+	// Otherwise, the other errors are returned, so that the data is retried.
+	err = NewTraces([]consumer.Traces{partial2, retryable, sink}).ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	require.EqualError(t, err, "unavailable")
+	assert.False(t, consumererror.IsPermanent(err))
+}
+
+func TestTracesMultiplexingShallowMutating(t *testing.T) {
+	p1 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p2 := &shallowMutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p3 := &shallowMutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+
+	fc := NewTraces([]consumer.Traces{p1, p2, p3})
+	assert.Equal(t, consumer.Capabilities{MutatesData: true, Mutations: consumer.MutatesResources}, fc.Capabilities())
+	td := testdata.GenerateTraces(1)
+	require.NoError(t, fc.ConsumeTraces(context.Background(), td))
+
+	// The last consumer mutating only the resources gets the original data.
+	p3.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().PutStr("p3", "true")
+	_, found := td.ResourceSpans().At(0).Resource().Attributes().Get("p3")
+	assert.True(t, found)
+
+	// The other one gets a copy of the resources, sharing the records with the original data.
+	p2.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().PutStr("p2", "true")
+	_, found = td.ResourceSpans().At(0).Resource().Attributes().Get("p2")
+	assert.False(t, found)
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("changed")
+	assert.Equal(t, "changed", p2.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+
+	// The consumer mutating the records gets a full copy.
+	assert.NotEqual(t, "changed", p1.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
 type mutatingTracesSink struct {
 	*consumertest.TracesSink
 }
@@ -239,3 +322,11 @@ type mutatingTracesSink struct {
 func (mts *mutatingTracesSink) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+type shallowMutatingTracesSink struct {
+	*consumertest.TracesSink
+}
+
+func (mts *shallowMutatingTracesSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true, Mutations: consumer.MutatesResources}
+}
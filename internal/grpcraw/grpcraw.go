@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package grpcraw sends and receives the OTLP requests and responses over gRPC as their protobuf
+// encoding, without decoding nor encoding them.
+package grpcraw // import "go.opentelemetry.io/collector/internal/grpcraw"
+
+import (
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/proto" // Registers the proto codec.
+	"google.golang.org/grpc/mem"
+)
+
+// The full names of the Export methods of the OTLP services.
+const (
+	TracesExportMethod  = "/opentelemetry.proto.collector.trace.v1.TraceService/Export"
+	MetricsExportMethod = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+	LogsExportMethod    = "/opentelemetry.proto.collector.logs.v1.LogsService/Export"
+)
+
+// codecName is the name of the gRPC codec of the protobuf messages, sent in the content-subtype.
+const codecName = "proto"
+
+// Message is a gRPC message sent and received as its protobuf encoding.
+type Message struct {
+	Data []byte
+}
+
+// Codec is the gRPC codec of the Message, passing the other messages to the registered proto codec.
+type Codec struct{}
+
+var _ encoding.CodecV2 = Codec{}
+
+func (Codec) Marshal(v any) (mem.BufferSlice, error) {
+	if msg, ok := v.(*Message); ok {
+		return mem.BufferSlice{mem.SliceBuffer(msg.Data)}, nil
+	}
+	return encoding.GetCodecV2(codecName).Marshal(v)
+}
+
+func (Codec) Unmarshal(data mem.BufferSlice, v any) error {
+	if msg, ok := v.(*Message); ok {
+		// The data is copied since its buffers are reused once the call returns.
+		msg.Data = data.Materialize()
+		return nil
+	}
+	return encoding.GetCodecV2(codecName).Unmarshal(data, v)
+}
+
+func (Codec) Name() string {
+	return codecName
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package grpcraw
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/mem"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestCodecMessage(t *testing.T) {
+	codec := Codec{}
+	assert.Equal(t, "proto", codec.Name())
+
+	data, err := codec.Marshal(&Message{Data: []byte{1, 2, 3}})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, data.Materialize())
+
+	var msg Message
+	require.NoError(t, codec.Unmarshal(mem.BufferSlice{mem.SliceBuffer([]byte{4, 5})}, &msg))
+	assert.Equal(t, []byte{4, 5}, msg.Data)
+}
+
+func TestCodecProto(t *testing.T) {
+	codec := Codec{}
+	data, err := codec.Marshal(wrapperspb.String("value"))
+	require.NoError(t, err)
+
+	var msg Message
+	require.NoError(t, codec.Unmarshal(data, &msg))
+	got := &wrapperspb.StringValue{}
+	require.NoError(t, codec.Unmarshal(mem.BufferSlice{mem.SliceBuffer(msg.Data)}, got))
+	assert.Equal(t, "value", got.GetValue())
+}
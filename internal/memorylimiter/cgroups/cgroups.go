@@ -28,6 +28,7 @@
 package cgroups // import "go.opentelemetry.io/collector/internal/memorylimiter/cgroups"
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -50,9 +51,19 @@ const (
 
 	_cgroupMemoryLimitBytes = "memory.limit_in_bytes"
 
+	// _cgroupCPUCFSQuotaUsParam is the file name for the CGroup CFS quota
+	// parameter.
+	_cgroupCPUCFSQuotaUsParam = "cpu.cfs_quota_us"
+	// _cgroupCPUCFSPeriodUsParam is the file name for the CGroup CFS period
+	// parameter.
+	_cgroupCPUCFSPeriodUsParam = "cpu.cfs_period_us"
+
 	// _cgroupv2MemoryMax is the file name for the CGroup-V2 Memory max
 	// parameter.
 	_cgroupv2MemoryMax = "memory.max"
+	// _cgroupv2CPUMax is the file name for the CGroup-V2 CPU max and period
+	// parameter.
+	_cgroupv2CPUMax = "cpu.max"
 	// _cgroupFSType is the Linux CGroup-V2 file system type used in
 	// `/proc/$PID/mountinfo`.
 	_cgroupv2FSType = "cgroup2"
@@ -128,6 +139,28 @@ func (cg CGroups) MemoryQuota() (int64, bool, error) {
 	return memLimitBytes, true, nil
 }
 
+// CPUQuota returns the CPU quota applied with the CPU cgroup controller.
+// It is a result of `cpu.cfs_quota_us / cpu.cfs_period_us`. If the value of
+// `cpu.cfs_quota_us` was not set (-1), the method returns `(-1, false, nil)`.
+func (cg CGroups) CPUQuota() (float64, bool, error) {
+	cpuCGroup, exists := cg[_cgroupSubsysCPU]
+	if !exists {
+		return -1, false, nil
+	}
+
+	cfsQuotaUs, err := cpuCGroup.readInt(_cgroupCPUCFSQuotaUsParam)
+	if defined := cfsQuotaUs > 0; err != nil || !defined {
+		return -1, defined, err
+	}
+
+	cfsPeriodUs, err := cpuCGroup.readInt(_cgroupCPUCFSPeriodUsParam)
+	if defined := cfsPeriodUs > 0; err != nil || !defined {
+		return -1, defined, err
+	}
+
+	return float64(cfsQuotaUs) / float64(cfsPeriodUs), true, nil
+}
+
 // IsCGroupV2 returns true if the system supports and uses cgroup2.
 // It gets the required information for deciding from mountinfo file.
 func IsCGroupV2() (bool, error) {
@@ -180,3 +213,52 @@ func memoryQuotaV2(cgroupv2MountPoint, cgroupv2MemoryMax string) (int64, bool, e
 	}
 	return -1, false, io.ErrUnexpectedEOF
 }
+
+// CPUQuotaV2 returns the CPU quota of the process.
+// It is a result of cgroupv2 `cpu.max`, holding the quota and the period. If
+// the quota was not set (max), the method returns `(-1, false, nil)`.
+func CPUQuotaV2() (float64, bool, error) {
+	return cpuQuotaV2(_cgroupv2MountPoint, _cgroupv2CPUMax)
+}
+
+func cpuQuotaV2(cgroupv2MountPoint, cgroupv2CPUMax string) (float64, bool, error) {
+	cpuMaxParams, err := os.Open(filepath.Clean(filepath.Join(cgroupv2MountPoint, cgroupv2CPUMax)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return -1, false, nil
+		}
+		return -1, false, err
+	}
+	defer cpuMaxParams.Close()
+
+	scanner := bufio.NewScanner(cpuMaxParams)
+	if scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || len(fields) > 2 {
+			return -1, false, fmt.Errorf("invalid format of %s", cgroupv2CPUMax)
+		}
+		if fields[0] == "max" {
+			return -1, false, nil
+		}
+		quota, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return -1, false, err
+		}
+		// The period defaults to 100ms when it is not set.
+		period := int64(100000)
+		if len(fields) == 2 {
+			period, err = strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return -1, false, err
+			}
+			if period <= 0 {
+				return -1, false, fmt.Errorf("invalid period %d in %s", period, cgroupv2CPUMax)
+			}
+		}
+		return float64(quota) / float64(period), true, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return -1, false, err
+	}
+	return -1, false, io.ErrUnexpectedEOF
+}
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package iruntime // import "go.opentelemetry.io/collector/internal/memorylimiter/iruntime"
+
+import "go.opentelemetry.io/collector/internal/memorylimiter/cgroups"
+
+// CPUQuota returns the number of CPUs the process is allowed to use by its cgroup, which may be
+// fractional, and whether a quota is set.
+func CPUQuota() (float64, bool, error) {
+	isV2, err := cgroups.IsCGroupV2()
+	if err != nil {
+		return -1, false, err
+	}
+	if isV2 {
+		return cgroups.CPUQuotaV2()
+	}
+
+	cgv1, err := cgroups.NewCGroupsForCurrentProcess()
+	if err != nil {
+		return -1, false, err
+	}
+	return cgv1.CPUQuota()
+}
@@ -0,0 +1,11 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package iruntime // import "go.opentelemetry.io/collector/internal/memorylimiter/iruntime"
+
+// CPUQuota returns no quota, since the cgroups are only supported on Linux.
+func CPUQuota() (float64, bool, error) {
+	return -1, false, nil
+}
@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package iruntime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCPUQuota(t *testing.T) {
+	quota, defined, err := CPUQuota()
+	require.NoError(t, err)
+	if defined {
+		assert.Positive(t, quota)
+	} else {
+		assert.InDelta(t, -1.0, quota, 0)
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package iruntime // import "go.opentelemetry.io/collector/internal/memorylimiter/iruntime"
+
+import "math"
+
+// MemoryLimit returns the soft memory limit of the Go runtime, in bytes, set to the percentage of the
+// total memory available to the collector, see TotalMemory. It is computed without overflowing, and
+// capped to the maximum limit, e.g. when the cgroup of the collector has no memory limit.
+func MemoryLimit(total uint64, percentage uint32) int64 {
+	p := uint64(percentage)
+	if p > 0 && total/100 > math.MaxUint64/p {
+		return math.MaxInt64
+	}
+	limit := total/100*p + total%100*p/100
+	return int64(min(limit, math.MaxInt64)) //nolint:gosec // G115 the limit is capped to the maximum int64
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package iruntime
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		total      uint64
+		percentage uint32
+		expected   int64
+	}{
+		{name: "exact", total: 1000, percentage: 80, expected: 800},
+		{name: "rounded down", total: 1099, percentage: 50, expected: 549},
+		{name: "below one hundred bytes", total: 99, percentage: 50, expected: 49},
+		{name: "zero", total: 1000, percentage: 0, expected: 0},
+		{name: "all", total: 1 << 30, percentage: 100, expected: 1 << 30},
+		{name: "unlimited", total: math.MaxUint64, percentage: 80, expected: math.MaxInt64},
+		{name: "large", total: math.MaxInt64, percentage: 50, expected: math.MaxInt64 / 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MemoryLimit(tt.total, tt.percentage))
+		})
+	}
+}
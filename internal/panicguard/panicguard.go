@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package panicguard recovers the panics of the components built with the processor and
+// exporter helpers, so that a panicking component fails instead of crashing the collector.
+package panicguard // import "go.opentelemetry.io/collector/internal/panicguard"
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// Guard recovers the panics of the calls made to a component and fails the component after the
+// first one: the subsequent calls are rejected with the error of the first panic.
+type Guard struct {
+	kind   string
+	id     component.ID
+	logger *zap.Logger
+	// recordPanic counts the recovered panics in the telemetry of the component.
+	recordPanic func(context.Context)
+
+	mu     sync.Mutex
+	host   component.Host
+	failed error
+}
+
+// New returns a Guard of the component, counting the recovered panics with recordPanic.
+func New(kind component.Kind, id component.ID, logger *zap.Logger, recordPanic func(context.Context)) *Guard {
+	return &Guard{
+		kind:        strings.ToLower(kind.String()),
+		id:          id,
+		logger:      logger,
+		recordPanic: recordPanic,
+	}
+}
+
+// Start keeps the host of the component, which the status is reported to.
+func (g *Guard) Start(_ context.Context, host component.Host) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.host = host
+	return nil
+}
+
+// Call calls f, converting its panic to a permanent error, which is logged, counted and reported
+// as a permanent error status event. It returns the error of the first panic without calling f
+// once the component failed.
+func (g *Guard) Call(ctx context.Context, f func() error) (err error) {
+	g.mu.Lock()
+	failed := g.failed
+	g.mu.Unlock()
+	if failed != nil {
+		return failed
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = g.fail(ctx, r)
+		}
+	}()
+	return f()
+}
+
+func (g *Guard) fail(ctx context.Context, r any) error {
+	err := consumererror.NewPermanent(fmt.Errorf("%s %q panicked: %v", g.kind, g.id, r))
+	g.recordPanic(ctx)
+	g.logger.Error("Recovered from a panic, the "+g.kind+" now rejects all data", zap.Error(err), zap.ByteString("stack", debug.Stack()))
+
+	g.mu.Lock()
+	if g.failed != nil {
+		// Another call panicked concurrently and already failed the component.
+		g.mu.Unlock()
+		return err
+	}
+	g.failed = err
+	host := g.host
+	g.mu.Unlock()
+	if host != nil {
+		componentstatus.ReportStatus(host, componentstatus.NewPermanentErrorEvent(err))
+	}
+	return err
+}
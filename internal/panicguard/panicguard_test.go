@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package panicguard
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// statusHost records the status events reported by the component.
+type statusHost struct {
+	component.Host
+	events []*componentstatus.Event
+}
+
+func (h *statusHost) Report(event *componentstatus.Event) {
+	h.events = append(h.events, event)
+}
+
+func TestGuard(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	panics := 0
+	g := New(component.KindProcessor, component.MustNewID("batch"), zap.New(core), func(context.Context) { panics++ })
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, g.Start(context.Background(), host))
+
+	errTest := errors.New("test")
+	require.ErrorIs(t, g.Call(context.Background(), func() error { return errTest }), errTest)
+	assert.Empty(t, host.events)
+
+	err := g.Call(context.Background(), func() error { panic("boom") })
+	require.EqualError(t, err, "Permanent error: processor \"batch\" panicked: boom")
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 1, panics)
+	require.Len(t, host.events, 1)
+	assert.Equal(t, componentstatus.StatusPermanentError, host.events[0].Status())
+	require.Equal(t, 1, logs.FilterMessage("Recovered from a panic, the processor now rejects all data").Len())
+
+	// The component failed, f is no longer called.
+	require.ErrorIs(t, g.Call(context.Background(), func() error {
+		t.Fatal("called after the component failed")
+		return nil
+	}), err)
+	assert.Equal(t, 1, panics)
+	assert.Len(t, host.events, 1)
+}
+
+func TestGuardNotStarted(t *testing.T) {
+	g := New(component.KindExporter, component.MustNewID("otlp"), zap.NewNop(), func(context.Context) {})
+	err := g.Call(context.Background(), func() error { panic("boom") })
+	require.EqualError(t, err, "Permanent error: exporter \"otlp\" panicked: boom")
+}
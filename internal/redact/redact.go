@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redact masks the secrets of the untyped configurations of the collector, after the names of
+// their keys, e.g. when the configuration is reported by an extension.
+package redact // import "go.opentelemetry.io/collector/internal/redact"
+
+import "strings"
+
+// Redacted replaces the sensitive values of the configuration.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are the parts of the configuration keys whose values are masked. The configuration is
+// untyped, so that its configopaque values cannot be told apart.
+var sensitiveKeys = []string{"password", "secret", "token", "api_key", "apikey", "authorization", "credential", "headers", "key_pem"}
+
+// ByKey returns the value with the values of the sensitive keys masked, with all the values nested under
+// them. The key is the key of the value itself, empty if it has none.
+func ByKey(key string, value any) any {
+	if IsSensitive(key) {
+		return All(value)
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for k, val := range v {
+			redacted[k] = ByKey(k, val)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, val := range v {
+			redacted[i] = ByKey("", val)
+		}
+		return redacted
+	}
+	return value
+}
+
+// All returns the value with all its values masked, keeping the structure of the maps and lists.
+func All(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for k, val := range v {
+			redacted[k] = All(val)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, val := range v {
+			redacted[i] = All(val)
+		}
+		return redacted
+	case nil:
+		return nil
+	}
+	return Redacted
+}
+
+// IsSensitive returns whether the values of the configuration key are masked.
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitiveKey := range sensitiveKeys {
+		if strings.Contains(key, sensitiveKey) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value any
+		want  any
+	}{
+		{
+			name:  "plain value",
+			value: "localhost:4317",
+			want:  "localhost:4317",
+		},
+		{
+			name:  "sensitive value",
+			key:   "Password",
+			value: "hunter2",
+			want:  Redacted,
+		},
+		{
+			name:  "nil sensitive value",
+			key:   "token",
+			value: nil,
+			want:  nil,
+		},
+		{
+			name: "nested values",
+			value: map[string]any{
+				"endpoint": "localhost:4318",
+				"headers":  map[string]any{"x-scope": "tenant", "list": []any{"a", 1}},
+				"auth":     []any{map[string]any{"api_key": 42, "user": "admin"}},
+			},
+			want: map[string]any{
+				"endpoint": "localhost:4318",
+				"headers":  map[string]any{"x-scope": Redacted, "list": []any{Redacted, Redacted}},
+				"auth":     []any{map[string]any{"api_key": Redacted, "user": "admin"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ByKey(tt.key, tt.value))
+		})
+	}
+}
+
+func TestIsSensitive(t *testing.T) {
+	assert.True(t, IsSensitive("client_secret"))
+	assert.True(t, IsSensitive("TLS_KEY_PEM"))
+	assert.False(t, IsSensitive("endpoint"))
+	assert.False(t, IsSensitive(""))
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componentattribute // import "go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap/zapcore"
+)
+
+type fieldsCoreWithAttributes struct {
+	zapcore.Core
+	transform func([]zapcore.Field) []zapcore.Field
+}
+
+var _ coreWithAttributes = (*fieldsCoreWithAttributes)(nil)
+
+// NewFieldsCoreWithAttributes wraps a Zap core in order to transform the fields of the logs, e.g. to
+// redact them, before they reach any of the cores it wraps. The logs are checked by the wrapped core as
+// usual, so that its cores only write the logs they are enabled for.
+func NewFieldsCoreWithAttributes(c zapcore.Core, transform func([]zapcore.Field) []zapcore.Field) zapcore.Core {
+	return &fieldsCoreWithAttributes{Core: c, transform: transform}
+}
+
+func (fcwa *fieldsCoreWithAttributes) withAttributeSet(attrs attribute.Set) zapcore.Core {
+	return NewFieldsCoreWithAttributes(tryWithAttributeSet(fcwa.Core, attrs), fcwa.transform)
+}
+
+func (fcwa *fieldsCoreWithAttributes) With(fields []zapcore.Field) zapcore.Core {
+	return NewFieldsCoreWithAttributes(fcwa.Core.With(fcwa.transform(fields)), fcwa.transform)
+}
+
+func (fcwa *fieldsCoreWithAttributes) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	// The cores of the wrapped core are collected in their own entry, written with the transformed
+	// fields when the entry is.
+	checked := fcwa.Core.Check(entry, nil)
+	if checked == nil {
+		return ce
+	}
+	cc := &checkedFieldsCore{Core: fcwa.Core, checked: checked, transform: fcwa.transform}
+	ce = ce.AddCore(entry, cc)
+	cc.entry = ce
+	return ce
+}
+
+func (fcwa *fieldsCoreWithAttributes) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return fcwa.Core.Write(entry, fcwa.transform(fields))
+}
+
+// checkedFieldsCore writes an entry checked by the core wrapped by a fieldsCoreWithAttributes.
+type checkedFieldsCore struct {
+	zapcore.Core
+	checked   *zapcore.CheckedEntry
+	transform func([]zapcore.Field) []zapcore.Field
+	// entry is the entry the core was added to, whose error output reports the errors of the cores.
+	entry *zapcore.CheckedEntry
+}
+
+func (cc *checkedFieldsCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	// The caller and the stack of the entry are set by the logger once checked.
+	cc.checked.Entry = entry
+	cc.checked.ErrorOutput = cc.entry.ErrorOutput
+	cc.checked.Write(cc.transform(fields)...)
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componentattribute
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFieldsCoreWithAttributes(t *testing.T) {
+	infoCore, infoLogs := observer.New(zapcore.InfoLevel)
+	errorCore, errorLogs := observer.New(zapcore.ErrorLevel)
+	redact := func(fields []zapcore.Field) []zapcore.Field {
+		redacted := make([]zapcore.Field, len(fields))
+		for i, f := range fields {
+			redacted[i] = f
+			if f.Key == "token" {
+				redacted[i] = zap.String(f.Key, "[REDACTED]")
+			}
+		}
+		return redacted
+	}
+	core := NewConsoleCoreWithAttributes(zapcore.NewTee(infoCore, errorCore), attribute.NewSet())
+	logger := zap.New(NewFieldsCoreWithAttributes(core, redact), zap.AddCaller())
+
+	receiverLogger := ZapLoggerWithAttributes(logger, attribute.NewSet(attribute.String(ComponentIDKey, "otlp")))
+	receiverLogger.With(zap.String("token", "secret")).Info("with token")
+	receiverLogger.Error("failed", zap.String("token", "secret"), zap.String("key", "value"))
+	logger.Debug("disabled", zap.String("token", "secret"))
+
+	// The cores only get the logs they are enabled for.
+	require.Len(t, infoLogs.All(), 2)
+	require.Len(t, errorLogs.All(), 1)
+	assert.Equal(t, map[string]any{ComponentIDKey: "otlp", "token": "[REDACTED]"}, infoLogs.All()[0].ContextMap())
+	assert.Equal(t, map[string]any{ComponentIDKey: "otlp", "token": "[REDACTED]", "key": "value"}, errorLogs.All()[0].ContextMap())
+	// The entries written have the caller set by the logger once checked.
+	assert.True(t, errorLogs.All()[0].Caller.Defined)
+}
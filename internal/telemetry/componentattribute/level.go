@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componentattribute // import "go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogLevels holds the minimum levels of the logs of the collector, which can be changed at runtime: the
+// level of all the logs, and the levels overriding it for the logs of some components, identified by
+// their kind and ID, e.g. "receiver/otlp". The level of a component type, e.g. "receiver/otlp", also
+// applies to the components of this type with a name, e.g. "receiver/otlp/2", without their own level.
+// A level set with a TTL reverts once the TTL elapsed.
+type LogLevels struct {
+	// configured holds the configured levels by component, the empty component being all the logs.
+	configured map[string]zapcore.Level
+	// threshold is the lowest of the levels, enabling the logs of the cores wrapped by
+	// NewLevelCoreWithAttributes.
+	threshold zap.AtomicLevel
+	// current holds the levels by component, the empty component being all the logs.
+	current atomic.Pointer[map[string]zapcore.Level]
+
+	mu     sync.Mutex
+	levels map[string]*logLevel
+}
+
+type logLevel struct {
+	level   zapcore.Level
+	expires time.Time
+	timer   *time.Timer
+	// base is the level restored once the level expired, nil to restore the configured level of the
+	// component, or to remove it if not configured.
+	base *zapcore.Level
+}
+
+// LogLevel is the level of the logs of a component, or of all the logs for an empty Component.
+type LogLevel struct {
+	Component string
+	Level     zapcore.Level
+	// Expires is the time the level reverts at, zero if the level was set without TTL.
+	Expires time.Time
+}
+
+// NewLogLevels returns the LogLevels with the configured level for all the logs, and the configured levels
+// of the logs of the components.
+func NewLogLevels(configured zapcore.Level, components map[string]zapcore.Level) *LogLevels {
+	l := &LogLevels{
+		configured: map[string]zapcore.Level{"": configured},
+		threshold:  zap.NewAtomicLevelAt(configured),
+		levels:     map[string]*logLevel{"": {level: configured}},
+	}
+	for component, level := range components {
+		l.configured[component] = level
+		l.levels[component] = &logLevel{level: level}
+	}
+	l.update()
+	return l
+}
+
+// LogLevelsOf returns the levels of the logger, if its core was wrapped last by NewLevelCoreWithAttributes.
+func LogLevelsOf(logger *zap.Logger) *LogLevels {
+	if c, ok := logger.Core().(*levelCoreWithAttributes); ok {
+		return c.levels
+	}
+	return nil
+}
+
+// Threshold returns the level enabling the logs of all the levels, to be used by the cores wrapped by
+// NewLevelCoreWithAttributes.
+func (l *LogLevels) Threshold() zap.AtomicLevel {
+	return l.threshold
+}
+
+// Level returns the level of the logs of the component, or of all the logs for an empty component.
+func (l *LogLevels) Level(component string) zapcore.Level {
+	levels := *l.current.Load()
+	if level, ok := levels[component]; ok {
+		return level
+	}
+	if level, ok := levels[componentType(component)]; ok {
+		return level
+	}
+	return levels[""]
+}
+
+// componentType returns the kind and type of the component with a name, e.g. "receiver/otlp" for
+// "receiver/otlp/2", or an empty string for the components without name.
+func componentType(component string) string {
+	kind, id, _ := strings.Cut(component, "/")
+	typ, _, ok := strings.Cut(id, "/")
+	if !ok {
+		return ""
+	}
+	return kind + "/" + typ
+}
+
+// SetLevel sets the level of the logs of the component, or of all the logs for an empty component. With a
+// positive ttl, the level reverts to the one set without TTL once the ttl elapsed.
+func (l *LogLevels) SetLevel(component string, level zapcore.Level, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	prev := l.levels[component]
+	next := &logLevel{level: level}
+	if prev != nil && prev.timer != nil {
+		prev.timer.Stop()
+	}
+	if ttl > 0 {
+		switch {
+		case prev == nil:
+		case prev.timer != nil:
+			next.base = prev.base
+		default:
+			next.base = &prev.level
+		}
+		next.expires = time.Now().Add(ttl)
+		next.timer = time.AfterFunc(ttl, func() { l.expire(component, next) })
+	}
+	l.levels[component] = next
+	l.update()
+}
+
+// ResetLevel restores the configured level of the logs of the component, or of all the logs for an empty
+// component, and removes the level of a component without configured level.
+func (l *LogLevels) ResetLevel(component string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if prev := l.levels[component]; prev != nil && prev.timer != nil {
+		prev.timer.Stop()
+	}
+	l.restore(component)
+	l.update()
+}
+
+// restore restores the configured level of the component, or removes its level if not configured.
+func (l *LogLevels) restore(component string) {
+	if level, ok := l.configured[component]; ok {
+		l.levels[component] = &logLevel{level: level}
+	} else {
+		delete(l.levels, component)
+	}
+}
+
+// All returns the level of all the logs, followed by the levels of the components sorted by component.
+func (l *LogLevels) All() []LogLevel {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	all := make([]LogLevel, 0, len(l.levels))
+	for component, level := range l.levels {
+		all = append(all, LogLevel{Component: component, Level: level.level, Expires: level.expires})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Component < all[j].Component })
+	return all
+}
+
+func (l *LogLevels) expire(component string, expired *logLevel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.levels[component] != expired {
+		// The level was set again since.
+		return
+	}
+	if expired.base == nil {
+		l.restore(component)
+	} else {
+		l.levels[component] = &logLevel{level: *expired.base}
+	}
+	l.update()
+}
+
+// update publishes the levels, and lowers or raises the threshold to the lowest of them.
+func (l *LogLevels) update() {
+	current := make(map[string]zapcore.Level, len(l.levels))
+	threshold := zapcore.InvalidLevel
+	for component, level := range l.levels {
+		current[component] = level.level
+		if threshold == zapcore.InvalidLevel || level.level < threshold {
+			threshold = level.level
+		}
+	}
+	l.current.Store(&current)
+	l.threshold.SetLevel(threshold)
+}
+
+type levelCoreWithAttributes struct {
+	zapcore.Core
+	levels    *LogLevels
+	component string
+}
+
+var _ coreWithAttributes = (*levelCoreWithAttributes)(nil)
+
+// NewLevelCoreWithAttributes wraps a Zap core in order to filter its logs with the levels: the level of the
+// component identified by the component attributes if set, or else the level of all the logs. The wrapped
+// core must enable the logs of the threshold of the levels.
+func NewLevelCoreWithAttributes(c zapcore.Core, levels *LogLevels, attrs attribute.Set) zapcore.Core {
+	return &levelCoreWithAttributes{
+		Core:      c,
+		levels:    levels,
+		component: componentOf(attrs),
+	}
+}
+
+// componentOf returns the kind and ID of the component identified by the attributes, e.g. "receiver/otlp",
+// or an empty string if they don't identify a component.
+func componentOf(attrs attribute.Set) string {
+	kind, okKind := attrs.Value(ComponentKindKey)
+	id, okID := attrs.Value(ComponentIDKey)
+	if !okKind || !okID {
+		return ""
+	}
+	return kind.AsString() + "/" + id.AsString()
+}
+
+func (lcwa *levelCoreWithAttributes) withAttributeSet(attrs attribute.Set) zapcore.Core {
+	return NewLevelCoreWithAttributes(tryWithAttributeSet(lcwa.Core, attrs), lcwa.levels, attrs)
+}
+
+func (lcwa *levelCoreWithAttributes) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCoreWithAttributes{
+		Core:      lcwa.Core.With(fields),
+		levels:    lcwa.levels,
+		component: lcwa.component,
+	}
+}
+
+func (lcwa *levelCoreWithAttributes) Level() zapcore.Level {
+	return lcwa.levels.Level(lcwa.component)
+}
+
+func (lcwa *levelCoreWithAttributes) Enabled(level zapcore.Level) bool {
+	return level >= lcwa.Level()
+}
+
+func (lcwa *levelCoreWithAttributes) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !lcwa.Enabled(entry.Level) {
+		return ce
+	}
+	return lcwa.Core.Check(entry, ce)
+}
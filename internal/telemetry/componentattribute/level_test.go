@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componentattribute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogLevels(t *testing.T) {
+	levels := NewLogLevels(zapcore.InfoLevel, nil)
+	assert.Equal(t, zapcore.InfoLevel, levels.Level(""))
+	assert.Equal(t, zapcore.InfoLevel, levels.Level("receiver/otlp"))
+
+	levels.SetLevel("receiver/otlp", zapcore.DebugLevel, 0)
+	levels.SetLevel("", zapcore.WarnLevel, 0)
+	assert.Equal(t, zapcore.DebugLevel, levels.Level("receiver/otlp"))
+	assert.Equal(t, zapcore.WarnLevel, levels.Level("exporter/otlp"))
+	assert.Equal(t, zapcore.DebugLevel, levels.Threshold().Level())
+	assert.Equal(t, []LogLevel{
+		{Component: "", Level: zapcore.WarnLevel},
+		{Component: "receiver/otlp", Level: zapcore.DebugLevel},
+	}, levels.All())
+
+	levels.ResetLevel("receiver/otlp")
+	levels.ResetLevel("")
+	assert.Equal(t, zapcore.InfoLevel, levels.Level("receiver/otlp"))
+	assert.Equal(t, zapcore.InfoLevel, levels.Threshold().Level())
+	assert.Equal(t, []LogLevel{{Component: "", Level: zapcore.InfoLevel}}, levels.All())
+}
+
+func TestLogLevelsConfigured(t *testing.T) {
+	levels := NewLogLevels(zapcore.InfoLevel, map[string]zapcore.Level{
+		"receiver/otlp":          zapcore.DebugLevel,
+		"processor/batch/traces": zapcore.ErrorLevel,
+	})
+	assert.Equal(t, zapcore.DebugLevel, levels.Level("receiver/otlp"))
+	// The level of a component type applies to the components of this type with a name.
+	assert.Equal(t, zapcore.DebugLevel, levels.Level("receiver/otlp/2"))
+	assert.Equal(t, zapcore.ErrorLevel, levels.Level("processor/batch/traces"))
+	assert.Equal(t, zapcore.InfoLevel, levels.Level("processor/batch"))
+	assert.Equal(t, zapcore.DebugLevel, levels.Threshold().Level())
+
+	levels.SetLevel("receiver/otlp", zapcore.WarnLevel, 0)
+	assert.Equal(t, zapcore.WarnLevel, levels.Level("receiver/otlp/2"))
+	assert.Equal(t, zapcore.InfoLevel, levels.Threshold().Level())
+	levels.ResetLevel("receiver/otlp")
+	assert.Equal(t, zapcore.DebugLevel, levels.Level("receiver/otlp"))
+
+	levels.SetLevel("processor/batch/traces", zapcore.DebugLevel, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return levels.Level("processor/batch/traces") == zapcore.ErrorLevel
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestLogLevelsTTL(t *testing.T) {
+	levels := NewLogLevels(zapcore.InfoLevel, nil)
+	levels.SetLevel("", zapcore.WarnLevel, 0)
+	levels.SetLevel("", zapcore.DebugLevel, time.Hour)
+	// A level set with a TTL replacing another one reverts to the level set without TTL.
+	levels.SetLevel("", zapcore.ErrorLevel, 10*time.Millisecond)
+	levels.SetLevel("receiver/otlp", zapcore.DebugLevel, 10*time.Millisecond)
+
+	all := levels.All()
+	require.Len(t, all, 2)
+	assert.False(t, all[0].Expires.IsZero())
+	assert.Equal(t, zapcore.DebugLevel, levels.Threshold().Level())
+
+	assert.Eventually(t, func() bool {
+		return len(levels.All()) == 1 && levels.Level("") == zapcore.WarnLevel
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, zapcore.WarnLevel, levels.Threshold().Level())
+}
+
+func TestLevelCoreWithAttributes(t *testing.T) {
+	levels := NewLogLevels(zapcore.InfoLevel, nil)
+	core, observed := observer.New(levels.Threshold())
+	logger := zap.New(NewLevelCoreWithAttributes(NewConsoleCoreWithAttributes(core, attribute.NewSet()), levels, attribute.NewSet()))
+	require.Same(t, levels, LogLevelsOf(logger))
+
+	receiverLogger := ZapLoggerWithAttributes(logger, attribute.NewSet(
+		attribute.String(ComponentKindKey, "receiver"),
+		attribute.String(ComponentIDKey, "otlp"),
+	)).With(zap.String("key", "value"))
+	require.Same(t, levels, LogLevelsOf(receiverLogger))
+
+	levels.SetLevel("receiver/otlp", zapcore.DebugLevel, 0)
+	logger.Debug("service debug")
+	receiverLogger.Debug("receiver debug")
+	logger.Info("service info")
+	assert.Equal(t, zapcore.DebugLevel, receiverLogger.Level())
+	assert.Equal(t, zapcore.InfoLevel, logger.Level())
+
+	levels.ResetLevel("receiver/otlp")
+	receiverLogger.Debug("receiver debug after reset")
+
+	entries := observed.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "receiver debug", entries[0].Message)
+	assert.Equal(t, "otlp", entries[0].ContextMap()[ComponentIDKey])
+	assert.Equal(t, "service info", entries[1].Message)
+}
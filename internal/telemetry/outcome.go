@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry // import "go.opentelemetry.io/collector/internal/telemetry"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// LegacyOutcomeMetricsGate keeps recording the per-signal metrics of the items accepted, refused or
+// failed by the receivers, and sent or failed to be sent by the exporters, which the metrics of the
+// items of each component kind with an OutcomeKey attribute replace.
+var LegacyOutcomeMetricsGate = featuregate.GlobalRegistry().MustRegister(
+	"telemetry.legacyOutcomeMetrics",
+	featuregate.StageBeta,
+	featuregate.WithRegisterFromVersion("v0.138.0"),
+	featuregate.WithRegisterDescription("Records the per-signal metrics of the receivers and exporters, e.g. otelcol_receiver_accepted_spans, "+
+		"in addition to the otelcol_<kind>_items metrics with an outcome attribute"),
+)
+
+const (
+	// SignalKey is the attribute of the signal of the items handled by a component.
+	SignalKey = "otel.signal"
+	// OutcomeKey is the attribute of the outcome of the items handled by a component.
+	OutcomeKey = "outcome"
+
+	// OutcomeAccepted is the outcome of the items consumed successfully.
+	OutcomeAccepted = "accepted"
+	// OutcomeRefused is the outcome of the items rejected with a retryable error returned to the caller.
+	OutcomeRefused = "refused"
+	// OutcomeFailed is the outcome of the items rejected with a permanent error returned to the caller,
+	// including the items rejected by a partial success.
+	OutcomeFailed = "failed"
+	// OutcomeDropped is the outcome of the items discarded by the component without returning an error to
+	// the caller, e.g. filtered out by a processor or failed to be exported from a sending queue.
+	OutcomeDropped = "dropped"
+)
+
+// AddItems adds num items of the signal with the outcome to the counter of the items of a component,
+// unless num is zero.
+func AddItems(ctx context.Context, counter metric.Int64Counter, signal pipeline.Signal, outcome string, num int, opts ...metric.AddOption) {
+	if num <= 0 {
+		return
+	}
+	opts = append(opts, metric.WithAttributeSet(attribute.NewSet(
+		attribute.String(SignalKey, signal.String()),
+		attribute.String(OutcomeKey, outcome),
+	)))
+	counter.Add(ctx, int64(num), opts...)
+}
@@ -12,9 +12,11 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"reflect"
 	"sync"
 	"sync/atomic"
 	"syscall"
+	"time"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -24,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 	"go.opentelemetry.io/collector/otelcol/internal/grpclog"
+	"go.opentelemetry.io/collector/otelcol/internal/moduleversion"
 	"go.opentelemetry.io/collector/service"
 	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
 )
@@ -84,6 +87,23 @@ type CollectorSettings struct {
 
 	// SkipSettingGRPCLogger avoids setting the grpc logger
 	SkipSettingGRPCLogger bool
+
+	// SinkExporters runs the pipelines with their exporters replaced by sinks dropping the data, and
+	// logs the throughput of each pipeline instead.
+	SinkExporters bool
+
+	// ConfigReloadDebounce is the time without any change of the watched configuration after
+	// which it is reloaded, so that several changes in quick succession reload it once.
+	// The configuration is reloaded on every change when zero.
+	ConfigReloadDebounce time.Duration
+
+	// FeatureGateRemovalPolicy is how the feature gates set past their removal version, compared to the
+	// BuildInfo version, are handled when the collector starts.
+	FeatureGateRemovalPolicy FeatureGateRemovalPolicy
+
+	// featureGates sets the feature gates of the configuration, recorded by the converter added by the
+	// command, once it is validated.
+	featureGates *featureGates
 }
 
 // (Internal note) Collector Lifecycle:
@@ -93,7 +113,8 @@ type CollectorSettings struct {
 //   If configuration parser fails, collector's config can be reloaded.
 //   Collector can be shutdown if parser gets a shutdown error.
 // - Run runs runAndWaitForShutdownEvent and waits for a shutdown event.
-//   SIGINT and SIGTERM, errors, and (*Collector).Shutdown can trigger the shutdown events.
+//   SIGINT and SIGTERM, SIGUSR2 once a new process took over the listening sockets, errors,
+//   and (*Collector).Shutdown can trigger the shutdown events.
 // - Upon shutdown, pipelines are notified, then pipelines and extensions are shut down.
 // - Users can call (*Collector).Shutdown anytime to shut down the collector.
 
@@ -103,6 +124,8 @@ type Collector struct {
 
 	configProvider *ConfigProvider
 
+	// config is the configuration of the running service.
+	config        *Config
 	serviceConfig *service.Config
 	service       *service.Service
 	state         *atomic.Int64
@@ -117,6 +140,10 @@ type Collector struct {
 	asyncErrorChannel          chan error
 	bc                         *bufferedCore
 	updateConfigProviderLogger func(core zapcore.Core)
+
+	// paused is whether the intake of the pipelines must be paused, applied by Run when notified on pauseChan.
+	paused    atomic.Bool
+	pauseChan chan struct{}
 }
 
 // NewCollector creates and returns a new instance of Collector.
@@ -141,8 +168,9 @@ func NewCollector(set CollectorSettings) (*Collector, error) {
 		shutdownChan: make(chan struct{}),
 		// Per signal.Notify documentation, a size of the channel equaled with
 		// the number of signals getting notified on is recommended.
-		signalsChannel:             make(chan os.Signal, 3),
+		signalsChannel:             make(chan os.Signal, 4),
 		asyncErrorChannel:          make(chan error),
+		pauseChan:                  make(chan struct{}, 1),
 		configProvider:             configProvider,
 		bc:                         bc,
 		updateConfigProviderLogger: cc.SetCore,
@@ -161,11 +189,26 @@ func (col *Collector) Shutdown() {
 	})
 }
 
-func buildModuleInfo(m map[component.Type]string) map[component.Type]service.ModuleInfo {
+// SetPipelinesPaused pauses or resumes the intake of all the pipelines of the collector, including
+// the pipelines of the services started when the configuration is reloaded. It does not block.
+func (col *Collector) SetPipelinesPaused(paused bool) {
+	col.paused.Store(paused)
+	select {
+	case col.pauseChan <- struct{}{}:
+	default:
+	}
+}
+
+func buildModuleInfo[F component.Factory](factories map[component.Type]F, m map[component.Type]string) map[component.Type]service.ModuleInfo {
 	moduleInfo := make(map[component.Type]service.ModuleInfo)
 	for k, v := range m {
 		moduleInfo[k] = service.ModuleInfo{BuilderRef: v}
 	}
+	for k, factory := range factories {
+		info := moduleInfo[k]
+		info.Path, info.Version = moduleversion.Lookup(factory)
+		moduleInfo[k] = info
+	}
 	return moduleInfo
 }
 
@@ -174,27 +217,60 @@ func buildModuleInfo(m map[component.Type]string) map[component.Type]service.Mod
 func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	col.setCollectorState(StateStarting)
 
+	factories, cfg, err := col.loadConfiguration(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err = col.applyFeatureGates(); err != nil {
+		return newExitError(ExitCodeConfigValidation, err)
+	}
+	return col.startService(ctx, factories, cfg)
+}
+
+// loadConfiguration retrieves and validates the configuration.
+func (col *Collector) loadConfiguration(ctx context.Context) (Factories, *Config, error) {
 	factories, err := col.set.Factories()
 	if err != nil {
-		return fmt.Errorf("failed to initialize factories: %w", err)
+		return Factories{}, nil, fmt.Errorf("failed to initialize factories: %w", err)
 	}
 	cfg, err := col.configProvider.Get(ctx, factories)
 	if err != nil {
-		return fmt.Errorf("failed to get config: %w", err)
+		return Factories{}, nil, fmt.Errorf("failed to get config: %w", err)
 	}
 
 	if err = xconfmap.Validate(cfg); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
+		return Factories{}, nil, newExitError(ExitCodeConfigValidation, fmt.Errorf("invalid configuration: %w", err))
+	}
+	if err = col.validateSandbox(cfg); err != nil {
+		return Factories{}, nil, newExitError(ExitCodeConfigValidation, fmt.Errorf("invalid configuration: %w", err))
 	}
+	return factories, cfg, nil
+}
 
+// applyFeatureGates sets the feature gates of the configuration loaded last, once it is validated. It
+// returns the function restoring the feature gates of the running configuration.
+func (col *Collector) applyFeatureGates() (rollback func(), err error) {
+	if col.set.featureGates == nil {
+		return func() {}, nil
+	}
+	return col.set.featureGates.apply()
+}
+
+// startService creates and starts the service for the given configuration.
+func (col *Collector) startService(ctx context.Context, factories Factories, cfg *Config) error {
+	col.config = cfg
 	col.serviceConfig = &cfg.Service
 
 	conf := confmap.New()
 
-	if err = conf.Marshal(cfg); err != nil {
+	if err := conf.Marshal(cfg); err != nil {
 		return fmt.Errorf("could not marshal configuration: %w", err)
 	}
 
+	svcCfg := cfg.Service
+	svcCfg.Sandbox = col.sandboxConfig(cfg)
+
+	var err error
 	col.service, err = service.New(ctx, service.Settings{
 		BuildInfo:     col.set.BuildInfo,
 		CollectorConf: conf,
@@ -211,21 +287,22 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		ExtensionsFactories: factories.Extensions,
 
 		ModuleInfos: service.ModuleInfos{
-			Receiver:  buildModuleInfo(factories.ReceiverModules),
-			Processor: buildModuleInfo(factories.ProcessorModules),
-			Exporter:  buildModuleInfo(factories.ExporterModules),
-			Extension: buildModuleInfo(factories.ExtensionModules),
-			Connector: buildModuleInfo(factories.ConnectorModules),
+			Receiver:  buildModuleInfo(factories.Receivers, factories.ReceiverModules),
+			Processor: buildModuleInfo(factories.Processors, factories.ProcessorModules),
+			Exporter:  buildModuleInfo(factories.Exporters, factories.ExporterModules),
+			Extension: buildModuleInfo(factories.Extensions, factories.ExtensionModules),
+			Connector: buildModuleInfo(factories.Connectors, factories.ConnectorModules),
 		},
 		AsyncErrorChannel: col.asyncErrorChannel,
 		LoggingOptions:    col.set.LoggingOptions,
+		SinkExporters:     col.set.SinkExporters,
 
 		// TODO: inject the telemetry factory through factories.
 		// See https://github.com/open-telemetry/opentelemetry-collector/issues/4970
 		TelemetryFactory: otelconftelemetry.NewFactory(),
-	}, cfg.Service)
+	}, svcCfg)
 	if err != nil {
-		return err
+		return newExitError(ExitCodeStartFailure, err)
 	}
 	if col.updateConfigProviderLogger != nil {
 		col.updateConfigProviderLogger(col.service.Logger().Core())
@@ -245,7 +322,10 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 	}
 
 	if err = col.service.Start(ctx); err != nil {
-		return multierr.Combine(err, col.service.Shutdown(ctx))
+		return newExitError(ExitCodeStartFailure, multierr.Combine(err, col.service.Shutdown(ctx)))
+	}
+	if col.paused.Load() {
+		col.service.SetPipelinesPaused(true)
 	}
 	col.setCollectorState(StateRunning)
 
@@ -253,20 +333,158 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 }
 
 func (col *Collector) reloadConfiguration(ctx context.Context) error {
-	col.service.Logger().Warn("Config updated, restart service")
+	factories, cfg, err := col.loadConfiguration(ctx)
+	var rollbackFeatureGates func()
+	if err == nil {
+		rollbackFeatureGates, err = col.applyFeatureGates()
+	}
+	if err != nil {
+		// Keep running the last good configuration, and report that it is stale.
+		col.service.Logger().Error("Failed to reload the configuration, keeping the running one", zap.Error(err))
+		col.service.ReportConfigStatus(err)
+		return nil
+	}
+	if col.reloadExporters(ctx, cfg) || col.reloadPipelines(ctx, factories, cfg) {
+		col.service.ReportConfigStatus(nil)
+		return nil
+	}
+
+	logger := col.service.Logger()
+	logger.Warn("Config updated, restart service")
 	col.setCollectorState(StateClosing)
 
 	if err := col.service.Shutdown(ctx); err != nil {
 		return fmt.Errorf("failed to shutdown the retiring config: %w", err)
 	}
 
-	if err := col.setupConfigurationComponents(ctx); err != nil {
-		return fmt.Errorf("failed to setup configuration components: %w", err)
+	col.setCollectorState(StateStarting)
+	lastGood := col.config
+	if err := col.startService(ctx, factories, cfg); err != nil {
+		// Restart the last good configuration, and report that it is stale.
+		logger.Error("Failed to start the new configuration, restarting the previous one", zap.Error(err))
+		rollbackFeatureGates()
+		col.config, col.serviceConfig = lastGood, &lastGood.Service
+		if errRestart := col.restartService(ctx); errRestart != nil {
+			return multierr.Combine(fmt.Errorf("failed to setup configuration components: %w", err), errRestart)
+		}
+		col.service.ReportConfigStatus(err)
 	}
 
 	return nil
 }
 
+// restartService starts the service again with the running configuration, once it was shut down.
+func (col *Collector) restartService(ctx context.Context) error {
+	factories, err := col.set.Factories()
+	if err != nil {
+		return fmt.Errorf("failed to initialize factories: %w", err)
+	}
+	col.setCollectorState(StateStarting)
+	if err = col.startService(ctx, factories, col.config); err != nil {
+		return fmt.Errorf("failed to setup configuration components: %w", err)
+	}
+	return nil
+}
+
+// reloadExporters recreates the exporters whose configuration changed, if nothing else changed
+// in the configuration. It returns false if the service must be restarted instead.
+func (col *Collector) reloadExporters(ctx context.Context, cfg *Config) bool {
+	changed := changedExporters(col.config, cfg)
+	if len(changed) == 0 {
+		return false
+	}
+
+	conf := confmap.New()
+	if err := conf.Marshal(cfg); err != nil {
+		return false
+	}
+
+	ids := make([]string, 0, len(changed))
+	for id := range changed {
+		ids = append(ids, id.String())
+	}
+	col.service.Logger().Info("Config updated, reload exporters", zap.Strings("exporters", ids))
+	if err := col.service.ReloadExporters(ctx, conf, changed); err != nil {
+		col.service.Logger().Warn("Failed to reload exporters", zap.Error(err))
+		return false
+	}
+	col.config = cfg
+	col.serviceConfig = &cfg.Service
+	return true
+}
+
+// changedExporters returns the exporters whose configuration differs between both configurations,
+// or nil if anything else differs.
+func changedExporters(prev, cfg *Config) map[component.ID]component.Config {
+	if prev == nil || len(prev.Exporters) != len(cfg.Exporters) ||
+		!reflect.DeepEqual(prev.Receivers, cfg.Receivers) ||
+		!reflect.DeepEqual(prev.Processors, cfg.Processors) ||
+		!reflect.DeepEqual(prev.Connectors, cfg.Connectors) ||
+		!reflect.DeepEqual(prev.Extensions, cfg.Extensions) ||
+		!reflect.DeepEqual(prev.Service, cfg.Service) {
+		return nil
+	}
+
+	changed := make(map[component.ID]component.Config)
+	for id, expCfg := range cfg.Exporters {
+		prevCfg, ok := prev.Exporters[id]
+		if !ok {
+			return nil
+		}
+		if !reflect.DeepEqual(prevCfg, expCfg) {
+			changed[id] = expCfg
+		}
+	}
+	return changed
+}
+
+// reloadPipelines reconciles the pipelines with the configuration, keeping the components which
+// did not change running, if the extensions and the telemetry did not change. It returns false if
+// the service must be restarted instead.
+func (col *Collector) reloadPipelines(ctx context.Context, factories Factories, cfg *Config) bool {
+	if !canReloadPipelines(col.config, cfg) {
+		return false
+	}
+
+	conf := confmap.New()
+	if err := conf.Marshal(cfg); err != nil {
+		return false
+	}
+
+	col.service.Logger().Info("Config updated, reconcile pipelines")
+	err := col.service.ReloadPipelines(ctx, service.Settings{
+		CollectorConf:       conf,
+		ReceiversConfigs:    cfg.Receivers,
+		ReceiversFactories:  factories.Receivers,
+		ProcessorsConfigs:   cfg.Processors,
+		ProcessorsFactories: factories.Processors,
+		ExportersConfigs:    cfg.Exporters,
+		ExportersFactories:  factories.Exporters,
+		ConnectorsConfigs:   cfg.Connectors,
+		ConnectorsFactories: factories.Connectors,
+	}, cfg.Service)
+	if err != nil {
+		col.service.Logger().Warn("Failed to reconcile pipelines", zap.Error(err))
+		return false
+	}
+	col.config = cfg
+	col.serviceConfig = &cfg.Service
+	return true
+}
+
+// canReloadPipelines returns whether only the pipelines and their components differ between both
+// configurations, and none of them has feedback edges. Any other change of the service, e.g. to its
+// telemetry, health or runtime settings, requires a full restart.
+func canReloadPipelines(prev, cfg *Config) bool {
+	if prev == nil || len(prev.Service.Feedback) > 0 || len(cfg.Service.Feedback) > 0 ||
+		!reflect.DeepEqual(prev.Extensions, cfg.Extensions) {
+		return false
+	}
+	prevSvc, svc := prev.Service, cfg.Service
+	prevSvc.Pipelines, svc.Pipelines = nil, nil
+	return reflect.DeepEqual(prevSvc, svc)
+}
+
 func (col *Collector) DryRun(ctx context.Context) error {
 	factories, err := col.set.Factories()
 	if err != nil {
@@ -277,11 +495,14 @@ func (col *Collector) DryRun(ctx context.Context) error {
 		return fmt.Errorf("failed to get config: %w", err)
 	}
 
-	if err := xconfmap.Validate(cfg); err != nil {
-		return err
+	if err = xconfmap.Validate(cfg); err != nil {
+		return newExitError(ExitCodeConfigValidation, err)
+	}
+	if err = col.validateSandbox(cfg); err != nil {
+		return newExitError(ExitCodeConfigValidation, err)
 	}
 
-	return service.Validate(ctx, service.Settings{
+	err = service.Validate(ctx, service.Settings{
 		BuildInfo:           col.set.BuildInfo,
 		ReceiversConfigs:    cfg.Receivers,
 		ReceiversFactories:  factories.Receivers,
@@ -294,6 +515,10 @@ func (col *Collector) DryRun(ctx context.Context) error {
 	}, service.Config{
 		Pipelines: cfg.Service.Pipelines,
 	})
+	if err != nil {
+		return newExitError(ExitCodeConfigValidation, err)
+	}
+	return nil
 }
 
 func newFallbackLogger(options []zap.Option) (*zap.Logger, error) {
@@ -333,40 +558,108 @@ func (col *Collector) Run(ctx context.Context) error {
 
 		return err
 	}
+	// The previous process of the collector handing off its listening sockets waits for this one to be ready.
+	notifyHandoffReady()
 
-	// Always notify with SIGHUP for configuration reloading.
+	// Always notify with SIGHUP for configuration reloading, and reopening the log files.
 	signal.Notify(col.signalsChannel, syscall.SIGHUP)
 	defer signal.Stop(col.signalsChannel)
 
-	// Only notify with SIGTERM and SIGINT if graceful shutdown is enabled.
+	// Only notify with SIGTERM, SIGINT and the handoff signals if graceful shutdown is enabled.
 	if !col.set.DisableGracefulShutdown {
-		signal.Notify(col.signalsChannel, os.Interrupt, syscall.SIGTERM)
+		signal.Notify(col.signalsChannel, append([]os.Signal{os.Interrupt, syscall.SIGTERM}, handoffSignals...)...)
 	}
 
 	// Control loop: selects between channels for various interrupts - when this loop is broken, the collector exits.
 	// If a configuration reload fails, we return without waiting for graceful shutdown.
+	// The errors terminating the collector are returned along with the errors of the shutdown.
+	var runErr error
+	// The reload of the configuration is delayed until it did not change for the debounce time.
+	var reloadTimer *time.Timer
+	var reloadChan <-chan time.Time
+	defer func() {
+		if reloadTimer != nil {
+			reloadTimer.Stop()
+		}
+	}()
 LOOP:
 	for {
 		select {
 		case err := <-col.configProvider.Watch():
 			if err != nil {
-				col.service.Logger().Error("Config watch failed", zap.Error(err))
-				break LOOP
+				col.service.Logger().Error("Config watch failed, keeping the running configuration", zap.Error(err))
+				col.service.ReportConfigStatus(err)
+				continue
+			}
+			if col.set.ConfigReloadDebounce > 0 {
+				if reloadTimer == nil {
+					reloadTimer = time.NewTimer(col.set.ConfigReloadDebounce)
+				} else {
+					reloadTimer.Reset(col.set.ConfigReloadDebounce)
+				}
+				reloadChan = reloadTimer.C
+				continue
 			}
 			if err := col.reloadConfiguration(ctx); err != nil {
 				return err
 			}
+		case <-reloadChan:
+			reloadChan = nil
+			if err := col.reloadConfiguration(ctx); err != nil {
+				return err
+			}
 		case err := <-col.asyncErrorChannel:
 			col.service.Logger().Error("Asynchronous error received, terminating process", zap.Error(err))
+			runErr = newExitError(ExitCodeRuntimeError, err)
 			break LOOP
 		case s := <-col.signalsChannel:
 			col.service.Logger().Info("Received signal from OS", zap.String("signal", s.String()))
+			if isHandoffSignal(s) && os.Getenv(supervisedEnv) != "" {
+				// The supervisor would see the collector exit successfully once the new process is
+				// ready, and stop, leaving the new process unsupervised: it restarts the collector instead.
+				col.service.Logger().Info("Supervised collector, forwarding the signal to the supervisor to restart it")
+				if err := signalParent(s); err != nil {
+					col.service.Logger().Error("Failed to forward the signal to the supervisor", zap.Error(err))
+				}
+				continue
+			}
+			if isHandoffSignal(s) {
+				stopped, err := col.handoff(ctx)
+				if err != nil {
+					col.service.Logger().Error("Failed to hand off the listening sockets to a new process, continuing", zap.Error(err))
+					if stopped {
+						if err = col.restartService(ctx); err != nil {
+							return err
+						}
+					}
+					continue
+				}
+				col.service.Logger().Info("New process ready with the listening sockets, shutting down")
+				if stopped {
+					col.setCollectorState(StateClosing)
+					err = col.configProvider.Shutdown(ctx)
+					col.setCollectorState(StateClosed)
+					return err
+				}
+				break LOOP
+			}
 			if s != syscall.SIGHUP {
 				break LOOP
 			}
+			// The log files are reopened, e.g. after logrotate moved them, along with the reload.
+			if err := col.service.ReopenLogs(ctx); err != nil {
+				col.service.Logger().Error("Failed to reopen the log files", zap.Error(err))
+			}
+			// The reload reads all the config locations, including the ones changed since the last reload.
+			if reloadTimer != nil {
+				reloadTimer.Stop()
+				reloadChan = nil
+			}
 			if err := col.reloadConfiguration(ctx); err != nil {
 				return err
 			}
+		case <-col.pauseChan:
+			col.service.SetPipelinesPaused(col.paused.Load())
 		case <-col.shutdownChan:
 			col.service.Logger().Info("Received shutdown request")
 			break LOOP
@@ -376,7 +669,7 @@ LOOP:
 			return col.shutdown(context.Background()) //nolint:contextcheck
 		}
 	}
-	return col.shutdown(ctx)
+	return errors.Join(runErr, col.shutdown(ctx))
 }
 
 func (col *Collector) shutdown(ctx context.Context) error {
@@ -7,11 +7,14 @@ package otelcol
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -24,8 +27,17 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/pipelines"
 )
 
 func TestStateString(t *testing.T) {
@@ -167,6 +179,488 @@ func TestCollectorStateAfterConfigChange(t *testing.T) {
 	assert.Equal(t, StateClosed, col.GetState())
 }
 
+type reloadableExporterConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+type reloadableExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+}
+
+func TestCollectorReloadExporters(t *testing.T) {
+	expType := component.MustNewType("reloadable")
+	var created atomic.Int64
+	factories := func() (Factories, error) {
+		factories, err := nopFactories()
+		if err != nil {
+			return Factories{}, err
+		}
+		factories.Exporters[expType] = exporter.NewFactory(expType,
+			func() component.Config { return &reloadableExporterConfig{} },
+			exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+				created.Add(1)
+				traces, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error { return nil })
+				return &reloadableExporter{Traces: traces}, err
+			}, component.StabilityLevelDevelopment))
+		return factories, nil
+	}
+
+	var watcher confmap.WatcherFunc
+	var retrieved atomic.Int64
+	provider := newFakeProvider("file", func(_ context.Context, _ string, w confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		watcher = w
+		return confmap.NewRetrieved(map[string]any{
+			"receivers": map[string]any{"nop": nil},
+			"exporters": map[string]any{
+				// Only the exporter configuration changes on reload.
+				"reloadable": map[string]any{"endpoint": fmt.Sprintf("localhost:%d", retrieved.Add(1))},
+			},
+			"service": map[string]any{
+				"telemetry": map[string]any{"metrics": map[string]any{"level": "none"}},
+				"pipelines": map[string]any{
+					"traces": map[string]any{
+						"receivers": []any{"nop"},
+						"exporters": []any{"reloadable"},
+					},
+				},
+			},
+		})
+	})
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: factories,
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:              []string{"file:reloadable.yaml"},
+				ProviderFactories: []confmap.ProviderFactory{provider},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 10*time.Millisecond)
+	srv := col.service
+
+	watcher(&confmap.ChangeEvent{})
+	assert.Eventually(t, func() bool {
+		return created.Load() == 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	col.Shutdown()
+	wg.Wait()
+	// The exporter was reloaded within the running service.
+	assert.Same(t, srv, col.service)
+	assert.Equal(t, "localhost:2", col.config.Exporters[component.NewID(expType)].(*reloadableExporterConfig).Endpoint)
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
+func TestCollectorReloadExportersAfterFailedRestart(t *testing.T) {
+	expType := component.MustNewType("reloadable")
+	extType := component.MustNewType("counting")
+	var created, extensions atomic.Int64
+	factories := func() (Factories, error) {
+		factories, err := nopFactories()
+		if err != nil {
+			return Factories{}, err
+		}
+		factories.Exporters[expType] = exporter.NewFactory(expType,
+			func() component.Config { return &reloadableExporterConfig{} },
+			exporter.WithTraces(func(_ context.Context, _ exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+				created.Add(1)
+				if cfg.(*reloadableExporterConfig).Endpoint == "fail" {
+					return nil, errors.New("failed to create the exporter")
+				}
+				traces, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error { return nil })
+				return &reloadableExporter{Traces: traces}, err
+			}, component.StabilityLevelDevelopment))
+		factories.Extensions[extType] = extension.NewFactory(extType,
+			func() component.Config { return &struct{}{} },
+			func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+				extensions.Add(1)
+				return &struct {
+					component.StartFunc
+					component.ShutdownFunc
+				}{}, nil
+			}, component.StabilityLevelDevelopment)
+		return factories, nil
+	}
+
+	var watcher confmap.WatcherFunc
+	var retrieved atomic.Int64
+	provider := newFakeProvider("file", func(_ context.Context, _ string, w confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		watcher = w
+		n := retrieved.Add(1)
+		endpoint, level := fmt.Sprintf("localhost:%d", n), "info"
+		if n == 2 {
+			// The second configuration changes the telemetry, which restarts the service, and fails to start.
+			endpoint, level = "fail", "debug"
+		}
+		return confmap.NewRetrieved(map[string]any{
+			"receivers":  map[string]any{"nop": nil},
+			"exporters":  map[string]any{"reloadable": map[string]any{"endpoint": endpoint}},
+			"extensions": map[string]any{"counting": nil},
+			"service": map[string]any{
+				"extensions": []any{"counting"},
+				"telemetry": map[string]any{
+					"logs":    map[string]any{"level": level},
+					"metrics": map[string]any{"level": "none"},
+				},
+				"pipelines": map[string]any{
+					"traces": map[string]any{
+						"receivers": []any{"nop"},
+						"exporters": []any{"reloadable"},
+					},
+				},
+			},
+		})
+	})
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: factories,
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:              []string{"file:reloadable.yaml"},
+				ProviderFactories: []confmap.ProviderFactory{provider},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// The last good configuration is restarted once the new one failed to start.
+	watcher(&confmap.ChangeEvent{})
+	assert.Eventually(t, func() bool {
+		return created.Load() == 3 && StateRunning == col.GetState()
+	}, 2*time.Second, 10*time.Millisecond)
+	started := extensions.Load()
+
+	// Only the exporter changed since the last good configuration, so it is reloaded within the
+	// running service.
+	watcher(&confmap.ChangeEvent{})
+	assert.Eventually(t, func() bool {
+		return created.Load() == 4
+	}, 2*time.Second, 10*time.Millisecond)
+
+	col.Shutdown()
+	wg.Wait()
+	assert.Equal(t, started, extensions.Load())
+	assert.Equal(t, "localhost:3", col.config.Exporters[component.NewID(expType)].(*reloadableExporterConfig).Endpoint)
+	assert.Same(t, &col.config.Service, col.serviceConfig)
+	assert.Equal(t, StateClosed, col.GetState())
+}
+
+func TestCollectorConfigReloadDebounce(t *testing.T) {
+	var watcher confmap.WatcherFunc
+	var retrieved atomic.Int64
+	provider := newFakeProvider("file", func(_ context.Context, _ string, w confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		watcher = w
+		retrieved.Add(1)
+		return confmap.NewRetrieved(map[string]any{
+			"receivers": map[string]any{"nop": nil},
+			"exporters": map[string]any{"nop": nil},
+			"service": map[string]any{
+				"telemetry": map[string]any{"metrics": map[string]any{"level": "none"}},
+				"pipelines": map[string]any{"traces": map[string]any{"receivers": []any{"nop"}, "exporters": []any{"nop"}}},
+			},
+		})
+	})
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: nopFactories,
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:              []string{"file:config.yaml"},
+				ProviderFactories: []confmap.ProviderFactory{provider},
+			},
+		},
+		ConfigReloadDebounce: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 10*time.Millisecond)
+	require.EqualValues(t, 1, retrieved.Load())
+
+	// Changes in quick succession reload the configuration once.
+	for range 3 {
+		watcher(&confmap.ChangeEvent{})
+	}
+	assert.Eventually(t, func() bool {
+		return retrieved.Load() == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	time.Sleep(200 * time.Millisecond)
+	assert.EqualValues(t, 2, retrieved.Load())
+
+	// A reload on SIGHUP cancels the pending one.
+	watcher(&confmap.ChangeEvent{})
+	assert.Eventually(t, func() bool {
+		return len(col.configProvider.Watch()) == 0
+	}, 2*time.Second, time.Millisecond)
+	col.signalsChannel <- syscall.SIGHUP
+	assert.Eventually(t, func() bool {
+		return retrieved.Load() == 3
+	}, 2*time.Second, 10*time.Millisecond)
+	time.Sleep(200 * time.Millisecond)
+	assert.EqualValues(t, 3, retrieved.Load())
+
+	col.Shutdown()
+	wg.Wait()
+}
+
+func TestCollectorReportsStaleConfig(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	var mux sync.Mutex
+	var errs []error
+	factory := newConfigStatusWatcherExtensionFactory(func(err error) {
+		mux.Lock()
+		defer mux.Unlock()
+		errs = append(errs, err)
+	})
+	factories.Extensions[factory.Type()] = factory
+	failingType := component.MustNewType("failing")
+	factories.Extensions[failingType] = extension.NewFactory(failingType,
+		func() component.Config { return &struct{}{} },
+		func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+			return &failingExtension{}, nil
+		}, component.StabilityLevelStable)
+	getErrs := func() []error {
+		mux.Lock()
+		defer mux.Unlock()
+		return slices.Clone(errs)
+	}
+
+	var watcher confmap.WatcherFunc
+	var retrieved atomic.Int64
+	provider := newFakeProvider("file", func(_ context.Context, _ string, w confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		watcher = w
+		traces := map[string]any{"receivers": []any{"nop"}, "exporters": []any{"nop"}}
+		extensions := []any{"configstatuswatcher"}
+		switch retrieved.Add(1) {
+		case 2:
+			// The second configuration references a processor which is not configured.
+			traces["processors"] = []any{"invalid"}
+		case 3:
+			// The third configuration fails to start, after the running service is shut down.
+			extensions = append(extensions, "failing")
+		}
+		return confmap.NewRetrieved(map[string]any{
+			"receivers":  map[string]any{"nop": nil},
+			"exporters":  map[string]any{"nop": nil},
+			"extensions": map[string]any{"configstatuswatcher": nil, "failing": nil},
+			"service": map[string]any{
+				"telemetry":  map[string]any{"metrics": map[string]any{"level": "none"}},
+				"extensions": extensions,
+				"pipelines":  map[string]any{"traces": traces},
+			},
+		})
+	})
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: func() (Factories, error) { return factories, nil },
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:              []string{"file:config.yaml"},
+				ProviderFactories: []confmap.ProviderFactory{provider},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 10*time.Millisecond)
+	srv := col.service
+	assert.Empty(t, getErrs())
+
+	// The invalid configuration is reported, and the last good one keeps running.
+	watcher(&confmap.ChangeEvent{})
+	assert.Eventually(t, func() bool {
+		return len(getErrs()) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	require.ErrorContains(t, getErrs()[0], `references processor "invalid" which is not configured`)
+	assert.Same(t, srv, col.service)
+
+	// A failing watch is not reported again, while the configuration is already reported as stale.
+	watcher(&confmap.ChangeEvent{Error: errors.New("provider unreachable")})
+	// The configuration failing to start is reported by the service restarted with the last good one.
+	watcher(&confmap.ChangeEvent{})
+	assert.Eventually(t, func() bool {
+		return len(getErrs()) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	require.ErrorContains(t, getErrs()[1], "failed to start extensions")
+	assert.Equal(t, StateRunning, col.GetState())
+	assert.NotContains(t, col.config.Service.Extensions, component.NewID(failingType))
+
+	watcher(&confmap.ChangeEvent{})
+	assert.Eventually(t, func() bool {
+		return len(getErrs()) == 3
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.NoError(t, getErrs()[2])
+	assert.Equal(t, StateRunning, col.GetState())
+
+	col.Shutdown()
+	wg.Wait()
+}
+
+func TestCollectorFeatureGatesRollback(t *testing.T) {
+	reg := featuregate.NewRegistry()
+	first := reg.MustRegister("test.first", featuregate.StageAlpha, featuregate.WithRegisterRuntimeSafe())
+	second := reg.MustRegister("test.second", featuregate.StageAlpha, featuregate.WithRegisterRuntimeSafe())
+	flgs := flags(reg)
+	require.NoError(t, flgs.Parse(nil))
+	gates := newFeatureGates(flgs)
+
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	statuses := make(chan error, 10)
+	factory := newConfigStatusWatcherExtensionFactory(func(err error) { statuses <- err })
+	factories.Extensions[factory.Type()] = factory
+	failingType := component.MustNewType("failing")
+	factories.Extensions[failingType] = extension.NewFactory(failingType,
+		func() component.Config { return &struct{}{} },
+		func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+			return &failingExtension{}, nil
+		}, component.StabilityLevelStable)
+
+	var watcher confmap.WatcherFunc
+	var retrieved atomic.Int64
+	provider := newFakeProvider("file", func(_ context.Context, _ string, w confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		watcher = w
+		traces := map[string]any{"receivers": []any{"nop"}, "exporters": []any{"nop"}}
+		extensions := []any{"configstatuswatcher"}
+		featureGates := map[string]any{"test.first": true}
+		switch retrieved.Add(1) {
+		case 2:
+			// The second configuration is invalid.
+			featureGates = map[string]any{"test.second": true}
+			traces["processors"] = []any{"invalid"}
+		case 3:
+			// The third configuration fails to start.
+			featureGates = map[string]any{"test.second": true}
+			extensions = append(extensions, "failing")
+		}
+		return confmap.NewRetrieved(map[string]any{
+			"receivers":  map[string]any{"nop": nil},
+			"exporters":  map[string]any{"nop": nil},
+			"extensions": map[string]any{"configstatuswatcher": nil, "failing": nil},
+			"service": map[string]any{
+				"telemetry":     map[string]any{"metrics": map[string]any{"level": "none"}},
+				"extensions":    extensions,
+				"pipelines":     map[string]any{"traces": traces},
+				"feature_gates": featureGates,
+			},
+		})
+	})
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: func() (Factories, error) { return factories, nil },
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:               []string{"file:config.yaml"},
+				ProviderFactories:  []confmap.ProviderFactory{provider},
+				ConverterFactories: []confmap.ConverterFactory{newFeatureGatesConverterFactory(gates, "v0.138.0", FeatureGateRemovalWarn)},
+			},
+		},
+		featureGates: gates,
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.True(t, first.IsEnabled())
+
+	// The gates of the invalid configuration are not set.
+	watcher(&confmap.ChangeEvent{})
+	require.ErrorContains(t, <-statuses, `references processor "invalid" which is not configured`)
+	assert.True(t, first.IsEnabled())
+	assert.False(t, second.IsEnabled())
+
+	// The gates of the configuration failing to start are rolled back.
+	watcher(&confmap.ChangeEvent{})
+	require.ErrorContains(t, <-statuses, "failed to start extensions")
+	assert.True(t, first.IsEnabled())
+	assert.False(t, second.IsEnabled())
+
+	col.Shutdown()
+	wg.Wait()
+}
+
+func TestChangedExporters(t *testing.T) {
+	expID := component.MustNewID("exp")
+	newConfig := func(endpoint string) *Config {
+		return &Config{
+			Receivers: map[component.ID]component.Config{component.MustNewID("rcv"): &reloadableExporterConfig{}},
+			Exporters: map[component.ID]component.Config{
+				expID:                            &reloadableExporterConfig{Endpoint: endpoint},
+				component.MustNewID("unchanged"): &reloadableExporterConfig{},
+			},
+		}
+	}
+
+	assert.Nil(t, changedExporters(nil, newConfig("a")))
+	assert.Empty(t, changedExporters(newConfig("a"), newConfig("a")))
+	assert.Equal(t, map[component.ID]component.Config{expID: &reloadableExporterConfig{Endpoint: "b"}},
+		changedExporters(newConfig("a"), newConfig("b")))
+
+	cfg := newConfig("b")
+	cfg.Receivers[component.MustNewIDWithName("rcv", "2")] = &reloadableExporterConfig{}
+	assert.Nil(t, changedExporters(newConfig("a"), cfg))
+
+	cfg = newConfig("b")
+	delete(cfg.Exporters, component.MustNewID("unchanged"))
+	cfg.Exporters[component.MustNewID("added")] = &reloadableExporterConfig{}
+	assert.Nil(t, changedExporters(newConfig("a"), cfg))
+}
+
+func TestCanReloadPipelines(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			Receivers:  map[component.ID]component.Config{component.MustNewID("rcv"): &reloadableExporterConfig{}},
+			Extensions: map[component.ID]component.Config{component.MustNewID("ext"): &reloadableExporterConfig{}},
+		}
+	}
+
+	assert.False(t, canReloadPipelines(nil, newConfig()))
+	assert.True(t, canReloadPipelines(newConfig(), newConfig()))
+
+	cfg := newConfig()
+	cfg.Receivers[component.MustNewIDWithName("rcv", "2")] = &reloadableExporterConfig{}
+	cfg.Service.Pipelines = pipelines.Config{pipeline.NewID(pipeline.SignalTraces): {}}
+	assert.True(t, canReloadPipelines(newConfig(), cfg))
+
+	cfg = newConfig()
+	cfg.Extensions[component.MustNewID("ext")] = &reloadableExporterConfig{Endpoint: "b"}
+	assert.False(t, canReloadPipelines(newConfig(), cfg))
+
+	cfg = newConfig()
+	cfg.Service.Feedback = map[component.ID]pipelines.FeedbackConfig{component.MustNewID("conn"): {}}
+	assert.False(t, canReloadPipelines(newConfig(), cfg))
+
+	// Any change of the service settings other than its pipelines requires a restart.
+	cfg = newConfig()
+	cfg.Service.LazyExporters = []component.ID{component.MustNewID("exp")}
+	assert.False(t, canReloadPipelines(newConfig(), cfg))
+
+	cfg = newConfig()
+	cfg.Service.Health.Timeouts.Start = time.Second
+	assert.False(t, canReloadPipelines(newConfig(), cfg))
+}
+
 func TestCollectorReportError(t *testing.T) {
 	col, err := NewCollector(CollectorSettings{
 		BuildInfo:              component.NewDefaultBuildInfo(),
@@ -175,7 +669,8 @@ func TestCollectorReportError(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	wg := startCollector(context.Background(), t, col)
+	runErr := make(chan error, 1)
+	go func() { runErr <- col.Run(context.Background()) }()
 
 	assert.Eventually(t, func() bool {
 		return StateRunning == col.GetState()
@@ -183,7 +678,9 @@ func TestCollectorReportError(t *testing.T) {
 
 	col.asyncErrorChannel <- errors.New("err2")
 
-	wg.Wait()
+	err = <-runErr
+	require.EqualError(t, err, "err2")
+	assert.Equal(t, ExitCodeRuntimeError, ExitCode(err))
 	assert.Equal(t, StateClosed, col.GetState())
 }
 
@@ -214,6 +711,38 @@ func (e statusWatcherExtension) ComponentStatusChanged(source *componentstatus.I
 	e.onStatusChanged(source, event)
 }
 
+func newConfigStatusWatcherExtensionFactory(onConfigStatus func(err error)) extension.Factory {
+	return extension.NewFactory(
+		component.MustNewType("configstatuswatcher"),
+		func() component.Config {
+			return &struct{}{}
+		},
+		func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+			return &configStatusWatcherExtension{onConfigStatus: onConfigStatus}, nil
+		},
+		component.StabilityLevelStable)
+}
+
+// configStatusWatcherExtension receives the status of the collector configuration for testing purposes.
+type configStatusWatcherExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	onConfigStatus func(err error)
+}
+
+func (e configStatusWatcherExtension) NotifyConfigStatus(err error) {
+	e.onConfigStatus(err)
+}
+
+// failingExtension fails to start.
+type failingExtension struct {
+	component.ShutdownFunc
+}
+
+func (failingExtension) Start(context.Context, component.Host) error {
+	return errors.New("failed to start")
+}
+
 func TestComponentStatusWatcher(t *testing.T) {
 	factories, err := nopFactories()
 	require.NoError(t, err)
@@ -329,6 +858,47 @@ func TestCollectorSendSignal(t *testing.T) {
 	assert.Equal(t, StateClosed, col.GetState())
 }
 
+func TestCollectorReopenLogsOnSIGHUP(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "collector.log")
+	logsConfigPath := filepath.Join(dir, "logs.yaml")
+	logsConfig := fmt.Sprintf("service:\n  telemetry:\n    logs:\n      output_paths: [%q]\n", logPath)
+	require.NoError(t, os.WriteFile(logsConfigPath, []byte(logsConfig), 0o600))
+
+	col, err := NewCollector(CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: nopFactories,
+		ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{
+			filepath.Join("testdata", "otelcol-nop.yaml"),
+			"file:" + logsConfigPath,
+		}),
+	})
+	require.NoError(t, err)
+
+	wg := startCollector(context.Background(), t, col)
+
+	assert.Eventually(t, func() bool {
+		return StateRunning == col.GetState()
+	}, 2*time.Second, 200*time.Millisecond)
+
+	// The log file is moved, as logrotate does, and reopened on SIGHUP: the logs written after it,
+	// e.g. by the shutdown of the service restarted with the reload, are not written to the moved file.
+	require.NoError(t, os.Rename(logPath, logPath+".1"))
+	col.signalsChannel <- syscall.SIGHUP
+	col.signalsChannel <- syscall.SIGTERM
+
+	wg.Wait()
+	assert.Equal(t, StateClosed, col.GetState())
+
+	moved, err := os.ReadFile(logPath + ".1")
+	require.NoError(t, err)
+	assert.Contains(t, string(moved), "Received signal from OS")
+	assert.NotContains(t, string(moved), "Starting shutdown")
+	reopened, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(reopened), "Starting shutdown")
+}
+
 func TestCollectorFailedShutdown(t *testing.T) {
 	t.Skip("This test was using telemetry shutdown failure, switch to use a component that errors on shutdown.")
 
@@ -366,6 +936,53 @@ func TestCollectorStartInvalidConfig(t *testing.T) {
 	assert.EqualError(t, col.Run(context.Background()), "invalid configuration: service::pipelines::traces: references processor \"invalid\" which is not configured")
 }
 
+func TestCollectorStartExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		set      ConfigProviderSettings
+		exitCode int
+	}{
+		{
+			name: "resolution failure",
+			set: ConfigProviderSettings{
+				ResolverSettings: confmap.ResolverSettings{
+					URIs:              []string{"file:otelcol-nop.yaml"},
+					ProviderFactories: []confmap.ProviderFactory{confmap.NewProviderFactory(newFailureProvider)},
+				},
+			},
+			exitCode: ExitCodeConfigResolution,
+		},
+		{
+			name:     "unknown component type",
+			set:      newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-invalid-receiver-type.yaml")}),
+			exitCode: ExitCodeConfigValidation,
+		},
+		{
+			name:     "validation failure",
+			set:      newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-invalid.yaml")}),
+			exitCode: ExitCodeConfigValidation,
+		},
+		{
+			name:     "start failure",
+			set:      newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-invalidprop.yaml")}),
+			exitCode: ExitCodeStartFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col, err := NewCollector(CollectorSettings{
+				BuildInfo:              component.NewDefaultBuildInfo(),
+				Factories:              nopFactories,
+				ConfigProviderSettings: tt.set,
+			})
+			require.NoError(t, err)
+			err = col.Run(context.Background())
+			require.Error(t, err)
+			assert.Equal(t, tt.exitCode, ExitCode(err))
+		})
+	}
+}
+
 func TestNewCollectorInvalidConfigProviderSettings(t *testing.T) {
 	_, err := NewCollector(CollectorSettings{
 		BuildInfo:              component.NewDefaultBuildInfo(),
@@ -549,6 +1166,7 @@ func TestCollectorDryRun(t *testing.T) {
 				require.NoError(t, err)
 			} else {
 				require.EqualError(t, err, test.expectedErr)
+				assert.Equal(t, ExitCodeConfigValidation, ExitCode(err))
 			}
 		})
 	}
@@ -698,3 +1316,22 @@ func TestProviderAndConverterModules(t *testing.T) {
 	col.Shutdown()
 	wg.Wait()
 }
+
+func TestBuildModuleInfo(t *testing.T) {
+	nop := receivertest.NewNopFactory()
+	infos := buildModuleInfo(
+		map[component.Type]receiver.Factory{nop.Type(): nop},
+		map[component.Type]string{
+			nop.Type():                     "go.opentelemetry.io/collector/receiver/receivertest v1.2.3",
+			component.MustNewType("other"): "example.com/otherreceiver v1.2.3",
+		},
+	)
+	assert.Equal(t, map[component.Type]service.ModuleInfo{
+		nop.Type(): {
+			BuilderRef: "go.opentelemetry.io/collector/receiver/receivertest v1.2.3",
+			Path:       "go.opentelemetry.io/collector/receiver/receivertest",
+			Version:    "(devel)",
+		},
+		component.MustNewType("other"): {BuilderRef: "example.com/otherreceiver v1.2.3"},
+	}, infos)
+}
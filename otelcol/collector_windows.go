@@ -7,6 +7,7 @@ package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -14,6 +15,7 @@ import (
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"golang.org/x/sys/windows/registry"
 	"golang.org/x/sys/windows/svc"
 	"golang.org/x/sys/windows/svc/eventlog"
 
@@ -22,6 +24,20 @@ import (
 	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
 )
 
+const (
+	// serviceParametersKey is the registry key, under HKEY_LOCAL_MACHINE, holding the parameters
+	// of the service with the given name.
+	serviceParametersKey = `SYSTEM\CurrentControlSet\Services\%s\Parameters`
+	// serviceConfigValue is the registry value, REG_SZ or REG_MULTI_SZ, holding the locations of
+	// the configuration of the service.
+	serviceConfigValue = "Config"
+	// serviceConfigEnv is the environment variable holding the location of the configuration of
+	// the service, which can be set for one service with the Environment value of its registry key.
+	serviceConfigEnv = "OTELCOL_CONFIG"
+
+	serviceAccepts = svc.AcceptStop | svc.AcceptShutdown | svc.AcceptPauseAndContinue
+)
+
 type windowsService struct {
 	settings CollectorSettings
 	col      *Collector
@@ -49,17 +65,30 @@ func (s *windowsService) Execute(args []string, requests <-chan svc.ChangeReques
 	colErrorChannel := make(chan error, 1)
 
 	changes <- svc.Status{State: svc.StartPending}
-	if err = s.start(elog, colErrorChannel); err != nil {
+	if err = s.start(args[0], args[1:], elog, colErrorChannel); err != nil {
 		_ = elog.Error(3, fmt.Sprintf("failed to start service: %v", err))
+		// The classes of failures are reported as service specific exit codes.
+		if code := ExitCode(err); code != 1 {
+			return true, uint32(code)
+		}
 		return false, 1064 // 1064: ERROR_EXCEPTION_IN_SERVICE
 	}
-	changes <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+	changes <- svc.Status{State: svc.Running, Accepts: serviceAccepts}
 
 	for req := range requests {
 		switch req.Cmd {
 		case svc.Interrogate:
 			changes <- req.CurrentStatus
 
+		case svc.Pause:
+			// Pausing the service pauses the intake of the pipelines, the components keep running.
+			s.col.SetPipelinesPaused(true)
+			changes <- svc.Status{State: svc.Paused, Accepts: serviceAccepts}
+
+		case svc.Continue:
+			s.col.SetPipelinesPaused(false)
+			changes <- svc.Status{State: svc.Running, Accepts: serviceAccepts}
+
 		case svc.Stop, svc.Shutdown:
 			changes <- svc.Status{State: svc.StopPending}
 			if err = s.stop(colErrorChannel); err != nil {
@@ -77,11 +106,28 @@ func (s *windowsService) Execute(args []string, requests <-chan svc.ChangeReques
 	return false, 0
 }
 
-func (s *windowsService) start(elog *eventlog.Log, colErrorChannel chan error) error {
+// start starts the collector of the service with the given name. The flags are read from the
+// command line of the service, then from its start parameters. Without config flag, the locations
+// of the configuration are read from the registry key of the service, or from the environment.
+func (s *windowsService) start(name string, startParams []string, elog *eventlog.Log, colErrorChannel chan error) error {
 	// Parse all the flags manually.
 	if err := s.flags.Parse(os.Args[1:]); err != nil {
 		return err
 	}
+	if err := s.flags.Parse(startParams); err != nil {
+		return err
+	}
+	if len(s.flags.Lookup(configFlag).Value.(*configFlagValue).values) == 0 {
+		uris, err := serviceConfigLocations(registry.LOCAL_MACHINE, name)
+		if err != nil {
+			return err
+		}
+		for _, uri := range uris {
+			if err = s.flags.Set(configFlag, uri); err != nil {
+				return err
+			}
+		}
+	}
 
 	var err error
 	err = updateSettingsUsingFlags(&s.settings, s.flags)
@@ -130,6 +176,31 @@ func (s *windowsService) stop(colErrorChannel chan error) error {
 	return <-colErrorChannel
 }
 
+// serviceConfigLocations returns the locations of the configuration of the service held by its
+// registry key under the given root key, or else by the environment, if any.
+func serviceConfigLocations(root registry.Key, serviceName string) ([]string, error) {
+	key, err := registry.OpenKey(root, fmt.Sprintf(serviceParametersKey, serviceName), registry.QUERY_VALUE)
+	if err == nil {
+		defer key.Close()
+		uris, _, err := key.GetStringsValue(serviceConfigValue)
+		if errors.Is(err, registry.ErrUnexpectedType) {
+			var uri string
+			uri, _, err = key.GetStringValue(serviceConfigValue)
+			uris = []string{uri}
+		}
+		switch {
+		case err == nil:
+			return uris, nil
+		case !errors.Is(err, registry.ErrNotExist):
+			return nil, fmt.Errorf("failed to read the %s registry value of the service: %w", serviceConfigValue, err)
+		}
+	}
+	if uri := os.Getenv(serviceConfigEnv); uri != "" {
+		return []string{uri}, nil
+	}
+	return nil, nil
+}
+
 func openEventLog(serviceName string) (*eventlog.Log, error) {
 	elog, err := eventlog.Open(serviceName)
 	if err != nil {
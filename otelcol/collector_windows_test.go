@@ -6,11 +6,14 @@
 package otelcol
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows/registry"
 	"golang.org/x/sys/windows/svc"
 
 	"go.opentelemetry.io/collector/component"
@@ -43,3 +46,64 @@ func TestNewSvcHandler(t *testing.T) {
 	assert.Equal(t, svc.Stopped, (<-changes).State)
 	<-colDone
 }
+
+func TestSvcHandlerStartParametersAndPause(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+	filePath := filepath.Join("testdata", "otelcol-nop.yaml")
+	os.Args = []string{"otelcol"}
+
+	s := NewSvcHandler(CollectorSettings{BuildInfo: component.NewDefaultBuildInfo(), Factories: nopFactories, ConfigProviderSettings: newDefaultConfigProviderSettings(t, nil)})
+
+	colDone := make(chan struct{})
+	requests := make(chan svc.ChangeRequest)
+	changes := make(chan svc.Status)
+	go func() {
+		defer close(colDone)
+		// The configuration is given by the start parameters of the service.
+		ssec, errno := s.Execute([]string{"svc name", "--config", filePath}, requests, changes)
+		assert.Equal(t, uint32(0), errno)
+		assert.False(t, ssec)
+	}()
+
+	assert.Equal(t, svc.StartPending, (<-changes).State)
+	running := <-changes
+	assert.Equal(t, svc.Running, running.State)
+	assert.NotZero(t, running.Accepts&svc.AcceptPauseAndContinue)
+	requests <- svc.ChangeRequest{Cmd: svc.Pause}
+	assert.Equal(t, svc.Paused, (<-changes).State)
+	requests <- svc.ChangeRequest{Cmd: svc.Continue}
+	assert.Equal(t, svc.Running, (<-changes).State)
+	requests <- svc.ChangeRequest{Cmd: svc.Stop}
+	assert.Equal(t, svc.StopPending, (<-changes).State)
+	assert.Equal(t, svc.Stopped, (<-changes).State)
+	<-colDone
+}
+
+func TestServiceConfigLocations(t *testing.T) {
+	const serviceName = "otelcol-test-config-locations"
+	path := fmt.Sprintf(serviceParametersKey, serviceName)
+	t.Cleanup(func() {
+		_ = registry.DeleteKey(registry.CURRENT_USER, path)
+	})
+
+	// Without registry value, the location is read from the environment.
+	t.Setenv(serviceConfigEnv, "file:from-env.yaml")
+	uris, err := serviceConfigLocations(registry.CURRENT_USER, serviceName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"file:from-env.yaml"}, uris)
+
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, path, registry.SET_VALUE)
+	require.NoError(t, err)
+	defer key.Close()
+
+	require.NoError(t, key.SetStringValue(serviceConfigValue, "file:from-registry.yaml"))
+	uris, err = serviceConfigLocations(registry.CURRENT_USER, serviceName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"file:from-registry.yaml"}, uris)
+
+	require.NoError(t, key.SetStringsValue(serviceConfigValue, []string{"file:first.yaml", "file:second.yaml"}))
+	uris, err = serviceConfigLocations(registry.CURRENT_USER, serviceName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"file:first.yaml", "file:second.yaml"}, uris)
+}
@@ -7,11 +7,15 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"maps"
 	"os"
+	"os/exec"
+	"slices"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/featuregate"
 )
 
@@ -31,6 +35,24 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			pidFile, umask, workingDir, daemon := getProcessFlags(flagSet)
+			if daemon && os.Getenv(daemonEnv) == "" {
+				return daemonize(set)
+			}
+			if err = setupProcess(umask, workingDir); err != nil {
+				return err
+			}
+			// The pid file of a supervised collector is written by its supervisor.
+			if pidFile != "" && os.Getenv(supervisedEnv) == "" {
+				removePIDFile, pidErr := writePIDFile(pidFile)
+				if pidErr != nil {
+					return pidErr
+				}
+				defer removePIDFile()
+			}
+			if supervise, healthEndpoint, crashDir := getSupervisorFlags(flagSet); supervise && os.Getenv(supervisedEnv) == "" {
+				return runSupervisor(cmd.Context(), set, healthEndpoint, crashDir)
+			}
 
 			col, err := NewCollector(set)
 			if err != nil {
@@ -58,6 +80,18 @@ func updateSettingsUsingFlags(set *CollectorSettings, flags *flag.FlagSet) error
 	if len(resolverSet.URIs) == 0 {
 		return errors.New("at least one config flag must be provided")
 	}
+	if err := useStdinConfig(resolverSet); err != nil {
+		return err
+	}
+	useUnwatchedConfig(resolverSet, getUnwatchedConfigFlag(flags))
+	if ops := getSetListFlags(flags); len(ops) > 0 {
+		resolverSet.ConverterFactories = append(slices.Clip(resolverSet.ConverterFactories), confmap.NewConverterFactory(func(confmap.ConverterSettings) confmap.Converter {
+			return setListConverter{ops: ops}
+		}))
+	}
+
+	set.featureGates = newFeatureGates(flags)
+	resolverSet.ConverterFactories = append(slices.Clip(resolverSet.ConverterFactories), newFeatureGatesConverterFactory(set.featureGates, set.BuildInfo.Version, set.FeatureGateRemovalPolicy))
 
 	if set.ConfigProviderSettings.ResolverSettings.DefaultScheme == "" {
 		set.ConfigProviderSettings.ResolverSettings.DefaultScheme = "env"
@@ -67,6 +101,21 @@ func updateSettingsUsingFlags(set *CollectorSettings, flags *flag.FlagSet) error
 		return errors.New("at least one Provider must be supplied")
 	}
 
+	if plugins := getPluginFlag(flags); len(plugins) > 0 {
+		commands := make([]func() *exec.Cmd, len(plugins))
+		for i, path := range plugins {
+			commands[i] = func() *exec.Cmd { return exec.Command(path) }
+		}
+		set.Factories = withPlugins(set.Factories, commands)
+	}
+
+	if getSinkExportersFlag(flags) {
+		set.SinkExporters = true
+	}
+	if debounce := getConfigReloadDebounceFlag(flags); debounce > 0 {
+		set.ConfigReloadDebounce = debounce
+	}
+
 	return nil
 }
 
@@ -84,6 +133,11 @@ func newFeatureGateCommand() *cobra.Command {
 						fmt.Printf("Feature: %s\n", g.ID())
 						fmt.Printf("Enabled: %v\n", g.IsEnabled())
 						fmt.Printf("Stage: %s\n", g.Stage())
+						fmt.Printf("Runtime Safe: %v\n", g.IsRuntimeSafe())
+						scopes := g.Scopes()
+						for _, scope := range slices.Sorted(maps.Keys(scopes)) {
+							fmt.Printf("Enabled for %s: %v\n", scope, scopes[scope])
+						}
 						fmt.Printf("Description: %s\n", g.Description())
 						fmt.Printf("From Version: %s\n", g.FromVersion())
 						if g.ToVersion() != "" {
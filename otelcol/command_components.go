@@ -4,49 +4,66 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	yaml "go.yaml.in/yaml/v3"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/otelcol/internal/moduleversion"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 )
 
 type componentWithStability struct {
-	Name      component.Type
-	Module    string
-	Stability map[string]string
+	Name      component.Type    `json:"name"`
+	Module    string            `json:"module"`
+	Version   string            `yaml:",omitempty" json:"version,omitempty"`
+	Stability map[string]string `json:"stability"`
+	// DefaultConfig is the default configuration of the component, only output in JSON.
+	DefaultConfig map[string]any `yaml:"-" json:"default_config"`
 }
 
 type componentWithoutStability struct {
-	Scheme string `yaml:",omitempty"`
-	Module string
+	Scheme string `yaml:",omitempty" json:"scheme,omitempty"`
+	Module string `json:"module"`
+}
+
+type buildInfo struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
 }
 
 type componentsOutput struct {
-	BuildInfo  component.BuildInfo
-	Receivers  []componentWithStability
-	Processors []componentWithStability
-	Exporters  []componentWithStability
-	Connectors []componentWithStability
-	Extensions []componentWithStability
-	Providers  []componentWithoutStability
-	Converters []componentWithoutStability `yaml:",omitempty"`
+	BuildInfo  buildInfo                   `json:"buildinfo"`
+	Receivers  []componentWithStability    `json:"receivers"`
+	Processors []componentWithStability    `json:"processors"`
+	Exporters  []componentWithStability    `json:"exporters"`
+	Connectors []componentWithStability    `json:"connectors"`
+	Extensions []componentWithStability    `json:"extensions"`
+	Providers  []componentWithoutStability `json:"providers"`
+	Converters []componentWithoutStability `yaml:",omitempty" json:"converters,omitempty"`
 }
 
 // newComponentsCommand constructs a new components command using the given CollectorSettings.
 func newComponentsCommand(set CollectorSettings) *cobra.Command {
-	return &cobra.Command{
+	var outputFormat string
+	cmd := &cobra.Command{
 		Use:   "components",
 		Short: "Outputs available components in this collector distribution",
-		Long:  "Outputs available components in this collector distribution including their stability levels. The output format is not stable and can change between releases.",
-		Args:  cobra.ExactArgs(0),
+		Long: `Outputs available components in this collector distribution including their stability levels. The output format is not stable and can change between releases.
+
+The output prints in YAML by default. To print JSON, including the default configuration of each component, use --format=json.`,
+		Args: cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			factories, err := set.Factories()
 			if err != nil {
@@ -56,8 +73,9 @@ func newComponentsCommand(set CollectorSettings) *cobra.Command {
 			components := componentsOutput{}
 			for _, con := range sortFactoriesByType[connector.Factory](factories.Connectors) {
 				components.Connectors = append(components.Connectors, componentWithStability{
-					Name:   con.Type(),
-					Module: factories.ConnectorModules[con.Type()],
+					Name:    con.Type(),
+					Module:  factories.ConnectorModules[con.Type()],
+					Version: moduleVersion(con),
 					Stability: map[string]string{
 						"logs-to-logs":    con.LogsToLogsStability().String(),
 						"logs-to-metrics": con.LogsToMetricsStability().String(),
@@ -75,8 +93,9 @@ func newComponentsCommand(set CollectorSettings) *cobra.Command {
 			}
 			for _, ext := range sortFactoriesByType[extension.Factory](factories.Extensions) {
 				components.Extensions = append(components.Extensions, componentWithStability{
-					Name:   ext.Type(),
-					Module: factories.ExtensionModules[ext.Type()],
+					Name:    ext.Type(),
+					Module:  factories.ExtensionModules[ext.Type()],
+					Version: moduleVersion(ext),
 					Stability: map[string]string{
 						"extension": ext.Stability().String(),
 					},
@@ -84,8 +103,9 @@ func newComponentsCommand(set CollectorSettings) *cobra.Command {
 			}
 			for _, prs := range sortFactoriesByType[processor.Factory](factories.Processors) {
 				components.Processors = append(components.Processors, componentWithStability{
-					Name:   prs.Type(),
-					Module: factories.ProcessorModules[prs.Type()],
+					Name:    prs.Type(),
+					Module:  factories.ProcessorModules[prs.Type()],
+					Version: moduleVersion(prs),
 					Stability: map[string]string{
 						"logs":    prs.LogsStability().String(),
 						"metrics": prs.MetricsStability().String(),
@@ -95,8 +115,9 @@ func newComponentsCommand(set CollectorSettings) *cobra.Command {
 			}
 			for _, rcv := range sortFactoriesByType[receiver.Factory](factories.Receivers) {
 				components.Receivers = append(components.Receivers, componentWithStability{
-					Name:   rcv.Type(),
-					Module: factories.ReceiverModules[rcv.Type()],
+					Name:    rcv.Type(),
+					Module:  factories.ReceiverModules[rcv.Type()],
+					Version: moduleVersion(rcv),
 					Stability: map[string]string{
 						"logs":    rcv.LogsStability().String(),
 						"metrics": rcv.MetricsStability().String(),
@@ -106,8 +127,9 @@ func newComponentsCommand(set CollectorSettings) *cobra.Command {
 			}
 			for _, exp := range sortFactoriesByType[exporter.Factory](factories.Exporters) {
 				components.Exporters = append(components.Exporters, componentWithStability{
-					Name:   exp.Type(),
-					Module: factories.ExporterModules[exp.Type()],
+					Name:    exp.Type(),
+					Module:  factories.ExporterModules[exp.Type()],
+					Version: moduleVersion(exp),
 					Stability: map[string]string{
 						"logs":    exp.LogsStability().String(),
 						"metrics": exp.MetricsStability().String(),
@@ -115,7 +137,11 @@ func newComponentsCommand(set CollectorSettings) *cobra.Command {
 					},
 				})
 			}
-			components.BuildInfo = set.BuildInfo
+			components.BuildInfo = buildInfo{
+				Command:     set.BuildInfo.Command,
+				Description: set.BuildInfo.Description,
+				Version:     set.BuildInfo.Version,
+			}
 
 			for providerScheme, providerModuleModule := range set.ProviderModules {
 				components.Providers = append(components.Providers, componentWithoutStability{
@@ -130,14 +156,54 @@ func newComponentsCommand(set CollectorSettings) *cobra.Command {
 				})
 			}
 
-			yamlData, err := yaml.Marshal(components)
-			if err != nil {
-				return err
+			switch {
+			case strings.EqualFold(outputFormat, "yaml"):
+				yamlData, err := yaml.Marshal(components)
+				if err != nil {
+					return err
+				}
+				fmt.Fprint(cmd.OutOrStdout(), string(yamlData))
+				return nil
+			case strings.EqualFold(outputFormat, "json"):
+				if err = withDefaultConfigs(&components, factories); err != nil {
+					return err
+				}
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(components)
 			}
-			fmt.Fprint(cmd.OutOrStdout(), string(yamlData))
-			return nil
+			return fmt.Errorf("unrecognized output format: %s", outputFormat)
 		},
 	}
+	cmd.Flags().StringVar(&outputFormat, "format", "yaml", "Output format: yaml (default), json")
+	return cmd
+}
+
+// withDefaultConfigs sets the default configuration of the listed components.
+func withDefaultConfigs(components *componentsOutput, factories Factories) error {
+	set := func(list []componentWithStability, factoryOf func(component.Type) component.Factory) error {
+		for i := range list {
+			conf := confmap.New()
+			if err := conf.Marshal(factoryOf(list[i].Name).CreateDefaultConfig()); err != nil {
+				return fmt.Errorf("failed to marshal the default configuration of %q: %w", list[i].Name, err)
+			}
+			list[i].DefaultConfig = conf.ToStringMap()
+		}
+		return nil
+	}
+	return errors.Join(
+		set(components.Receivers, func(t component.Type) component.Factory { return factories.Receivers[t] }),
+		set(components.Processors, func(t component.Type) component.Factory { return factories.Processors[t] }),
+		set(components.Exporters, func(t component.Type) component.Factory { return factories.Exporters[t] }),
+		set(components.Connectors, func(t component.Type) component.Factory { return factories.Connectors[t] }),
+		set(components.Extensions, func(t component.Type) component.Factory { return factories.Extensions[t] }),
+	)
+}
+
+// moduleVersion returns the version of the Go module providing the component created by the factory.
+func moduleVersion(factory component.Factory) string {
+	_, version := moduleversion.Lookup(factory)
+	return version
 }
 
 func sortFactoriesByType[T component.Factory](factories map[component.Type]T) []T {
@@ -5,6 +5,8 @@ package otelcol
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,6 +16,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/xreceiver"
 )
 
 func TestNewBuildSubCommand(t *testing.T) {
@@ -43,3 +48,51 @@ func TestNewBuildSubCommand(t *testing.T) {
 	// line that makes the test fail.
 	assert.Equal(t, strings.ReplaceAll(strings.ReplaceAll(string(ExpectedOutput), "\n", ""), "\r", ""), strings.ReplaceAll(strings.ReplaceAll(b.String(), "\n", ""), "\r", ""))
 }
+
+func TestComponentsCommandJSON(t *testing.T) {
+	testR := component.MustNewType("r")
+	set := CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: func() (Factories, error) {
+			factories, err := nopFactories()
+			factories.Receivers[testR] = xreceiver.NewFactory(testR,
+				func() component.Config { return &printReceiverConfig{Opaque: "1234", Other: "lala"} },
+				xreceiver.WithLogs(func(context.Context, receiver.Settings, component.Config, consumer.Logs) (receiver.Logs, error) {
+					return nil, nil
+				}, component.StabilityLevelAlpha))
+			return factories, err
+		},
+		ConfigProviderSettings: newDefaultConfigProviderSettings(t, []string{filepath.Join("testdata", "otelcol-nop.yaml")}),
+	}
+	cmd := NewCommand(set)
+	cmd.SetArgs([]string{"components", "--format=json"})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	require.NoError(t, cmd.Execute())
+
+	var output struct {
+		BuildInfo struct {
+			Command string `json:"command"`
+		} `json:"buildinfo"`
+		Receivers []struct {
+			Name          string            `json:"name"`
+			Module        string            `json:"module"`
+			Stability     map[string]string `json:"stability"`
+			DefaultConfig map[string]any    `json:"default_config"`
+		} `json:"receivers"`
+	}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &output))
+	assert.Equal(t, "otelcol", output.BuildInfo.Command)
+	require.Len(t, output.Receivers, 2)
+	assert.Equal(t, "nop", output.Receivers[0].Name)
+	assert.Equal(t, "go.opentelemetry.io/collector/receiver/receivertest v1.2.3", output.Receivers[0].Module)
+	assert.Equal(t, "r", output.Receivers[1].Name)
+	assert.Equal(t, "Alpha", output.Receivers[1].Stability["logs"])
+	// The sensitive fields of the default configuration are redacted.
+	assert.Equal(t, map[string]any{"opaque": "[REDACTED]", "other": "lala"}, output.Receivers[1].DefaultConfig)
+
+	cmd = NewCommand(set)
+	cmd.SetArgs([]string{"components", "--format=toml"})
+	cmd.SetOut(bytes.NewBufferString(""))
+	require.EqualError(t, cmd.Execute(), "unrecognized output format: toml")
+}
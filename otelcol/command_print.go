@@ -33,6 +33,7 @@ func newConfigPrintSubCommand(set CollectorSettings, flagSet *flag.FlagSet) *cob
 	var outputFormat string
 	var mode string
 	var validate bool
+	var components []string
 
 	cmd := &cobra.Command{
 		Use:     "print-config",
@@ -48,6 +49,10 @@ however this is considered unstable.
 
 Validation is enabled by default, as a safety measure.
 
+To print only some components, list them with --components, as a
+section (e.g. receivers, service) or a component of a section
+(e.g. exporters::otlp/2).
+
 All modes are experimental, requiring the otelcol.printInitialConfig feature gate.`,
 		Args: cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -57,6 +62,7 @@ All modes are experimental, requiring the otelcol.printInitialConfig feature gat
 				set:          set,
 				outputFormat: outputFormat,
 				validate:     validate,
+				components:   components,
 			}
 			return pc.configPrintSubCommand(flagSet, mode)
 		},
@@ -71,6 +77,9 @@ All modes are experimental, requiring the otelcol.printInitialConfig feature gat
 	validateHelp := "Validation mode: true (default), false"
 	cmd.Flags().BoolVar(&validate, "validate", true, validateHelp)
 
+	componentsHelp := "Comma-separated sections or components to print, e.g. receivers::otlp,service (default all)"
+	cmd.Flags().StringSliceVar(&components, "components", nil, componentsHelp)
+
 	cmd.Flags().AddGoFlagSet(flagSet)
 	return cmd
 }
@@ -81,6 +90,7 @@ type printContext struct {
 	set          CollectorSettings
 	outputFormat string
 	validate     bool
+	components   []string
 }
 
 func (pctx *printContext) configPrintSubCommand(flagSet *flag.FlagSet, mode string) error {
@@ -104,6 +114,11 @@ func (pctx *printContext) configPrintSubCommand(flagSet *flag.FlagSet, mode stri
 
 // printConfigData formats and prints configuration data in yaml or json format.
 func (pctx *printContext) printConfigData(data map[string]any) error {
+	data, err := selectComponents(data, pctx.components)
+	if err != nil {
+		return err
+	}
+
 	format := pctx.outputFormat
 	if format == "" {
 		format = "yaml"
@@ -127,6 +142,37 @@ func (pctx *printContext) printConfigData(data map[string]any) error {
 	return fmt.Errorf("unrecognized print format: %s", format)
 }
 
+// selectComponents returns the sections and components of the configuration with the given
+// selectors, either a section like "receivers" or a component of a section like "receivers::otlp".
+// All the configuration is returned without selector.
+func selectComponents(data map[string]any, selectors []string) (map[string]any, error) {
+	if len(selectors) == 0 {
+		return data, nil
+	}
+	selected := make(map[string]any)
+	for _, selector := range selectors {
+		section, id, isComponent := strings.Cut(selector, confmap.KeyDelimiter)
+		value, ok := data[section]
+		if !ok {
+			return nil, fmt.Errorf("section %q not found in the configuration", section)
+		}
+		if !isComponent {
+			selected[section] = value
+			continue
+		}
+		components, _ := value.(map[string]any)
+		cfg, ok := components[id]
+		if !ok {
+			return nil, fmt.Errorf("component %q not found in the %q section of the configuration", id, section)
+		}
+		if _, isSection := selected[section].(map[string]any); !isSection {
+			selected[section] = make(map[string]any)
+		}
+		selected[section].(map[string]any)[id] = cfg
+	}
+	return selected, nil
+}
+
 func (pctx *printContext) getPrintableConfig() (any, error) {
 	var factories Factories
 	if pctx.set.Factories != nil {
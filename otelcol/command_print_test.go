@@ -253,3 +253,61 @@ func TestPrintCommand(t *testing.T) {
 		}
 	}
 }
+
+func TestPrintCommandComponents(t *testing.T) {
+	fg := featuregate.GlobalRegistry()
+	require.NoError(t, fg.Set(featureGateName, true))
+	defer func() { require.NoError(t, fg.Set(featureGateName, false)) }()
+
+	tests := []struct {
+		name       string
+		components string
+		want       string
+		errString  string
+	}{
+		{
+			name:       "component",
+			components: "exporters::e",
+			want:       "exporters:\n    e:\n        timeout: 5s\n\n",
+		},
+		{
+			name:       "section and component",
+			components: "receivers::r,service",
+			want: "receivers:\n    r:\n        opaque: OOO\nservice:\n    pipelines:\n        logs:\n" +
+				"            exporters:\n                - e\n            receivers:\n                - r\n\n",
+		},
+		{
+			name:       "unknown section",
+			components: "processors",
+			errString:  `section "processors" not found in the configuration`,
+		},
+		{
+			name:       "unknown component",
+			components: "receivers::otlp",
+			errString:  `component "otlp" not found in the "receivers" section of the configuration`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := confmap.ResolverSettings{
+				URIs:              []string{fmt.Sprint("file:", filepath.Join("testdata", "print.yaml"))},
+				ProviderFactories: []confmap.ProviderFactory{fileprovider.NewFactory()},
+				DefaultScheme:     "file",
+			}
+			var stdout bytes.Buffer
+			cmd := newConfigPrintSubCommand(CollectorSettings{
+				Factories:              nopFactories,
+				ConfigProviderSettings: ConfigProviderSettings{ResolverSettings: set},
+			}, flags(featuregate.GlobalRegistry()))
+			cmd.SetOut(&stdout)
+			cmd.SetArgs([]string{"--mode=unredacted", "--validate=false", "--components", test.components})
+			err := cmd.Execute()
+			if test.errString != "" {
+				require.EqualError(t, err, test.errString)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, stdout.String())
+		})
+	}
+}
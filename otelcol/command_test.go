@@ -76,6 +76,22 @@ func TestAddFlagToSettings(t *testing.T) {
 	require.Len(t, set.ConfigProviderSettings.ResolverSettings.URIs, 1)
 }
 
+func TestSinkExportersFlag(t *testing.T) {
+	fileProvider := newFakeProvider("file", func(context.Context, string, confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		return &confmap.Retrieved{}, nil
+	})
+	set := CollectorSettings{
+		ConfigProviderSettings: ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{ProviderFactories: []confmap.ProviderFactory{fileProvider}},
+		},
+	}
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--config=otelcol-nop.yaml", "--sink-exporters"}))
+
+	require.NoError(t, updateSettingsUsingFlags(&set, flgs))
+	assert.True(t, set.SinkExporters)
+}
+
 func TestInvalidCollectorSettings(t *testing.T) {
 	set := CollectorSettings{
 		ConfigProviderSettings: ConfigProviderSettings{
@@ -190,7 +206,9 @@ func TestNewFeatureGateCommand(t *testing.T) {
 
 		// Register a test feature gate in the global registry
 		featuregate.GlobalRegistry().MustRegister("test.feature", featuregate.StageBeta,
-			featuregate.WithRegisterDescription("Test feature description"))
+			featuregate.WithRegisterDescription("Test feature description"),
+			featuregate.WithRegisterRuntimeSafe())
+		require.NoError(t, featuregate.GlobalRegistry().SetFor("test.feature", "exporter/otlp/canary", false))
 
 		// Capture stdout
 		oldStdout := os.Stdout
@@ -208,6 +226,8 @@ func TestNewFeatureGateCommand(t *testing.T) {
 		assert.Contains(t, output, "Feature: test.feature")
 		assert.Contains(t, output, "Description: Test feature description")
 		assert.Contains(t, output, "Stage: Beta")
+		assert.Contains(t, output, "Runtime Safe: true")
+		assert.Contains(t, output, "Enabled for exporter/otlp/canary: false")
 	})
 
 	t.Run("non-existent featuregate", func(t *testing.T) {
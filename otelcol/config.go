@@ -6,8 +6,11 @@ package otelcol // import "go.opentelemetry.io/collector/otelcol"
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
 	"go.opentelemetry.io/collector/service"
 	"go.opentelemetry.io/collector/service/pipelines"
 )
@@ -121,5 +124,119 @@ func (cfg *Config) Validate() error {
 			return fmt.Errorf("service::pipelines::%s: references exporter %q which is not configured", pipelineID.String(), ref)
 		}
 	}
+
+	if err := cfg.validateMemoryLimit(); err != nil {
+		return err
+	}
+	return cfg.validateExtensionDependencies()
+}
+
+// validateMemoryLimit checks that the soft memory limit of the Go runtime is not set both by the service
+// and by an extension enabled in the service, through extensioncapabilities.MemoryLimitSetter.
+func (cfg *Config) validateMemoryLimit() error {
+	if cfg.Service.Runtime.MemoryLimitPercentage == 0 {
+		return nil
+	}
+	for _, ref := range cfg.Service.Extensions {
+		if setter, ok := cfg.Extensions[ref].(extensioncapabilities.MemoryLimitSetter); ok && setter.SetsMemoryLimit() {
+			return fmt.Errorf("service::runtime::memory_limit_percentage: cannot be set with extension %q, which sets the soft memory limit", ref)
+		}
+	}
+	return nil
+}
+
+// validateExtensionDependencies checks that the extensions required by the components
+// in use, through extensioncapabilities.ExtensionDependent, are enabled in the service.
+func (cfg *Config) validateExtensionDependencies() error {
+	enabled := make(map[component.ID]bool, len(cfg.Service.Extensions))
+	for _, ref := range cfg.Service.Extensions {
+		enabled[ref] = true
+	}
+	check := func(kind string, id component.ID, compCfg component.Config) error {
+		dep, ok := compCfg.(extensioncapabilities.ExtensionDependent)
+		if !ok {
+			return nil
+		}
+		for _, extID := range dep.ExtensionDependencies() {
+			if !enabled[extID] {
+				return fmt.Errorf("%s::%s: depends on extension %q which is not enabled in the service", kind, id, extID)
+			}
+		}
+		return nil
+	}
+
+	for _, ref := range cfg.Service.Extensions {
+		if err := check("extensions", ref, cfg.Extensions[ref]); err != nil {
+			return err
+		}
+	}
+	if err := cfg.validateExtensionCycles(); err != nil {
+		return err
+	}
+	for _, pipeline := range cfg.Service.Pipelines {
+		for _, ref := range pipeline.Receivers {
+			if err := check("receivers", ref, cfg.Receivers[ref]); err != nil {
+				return err
+			}
+			if err := check("connectors", ref, cfg.Connectors[ref]); err != nil {
+				return err
+			}
+		}
+		for _, ref := range pipeline.Processors {
+			if err := check("processors", ref, cfg.Processors[ref]); err != nil {
+				return err
+			}
+		}
+		for _, ref := range pipeline.Exporters {
+			if err := check("exporters", ref, cfg.Exporters[ref]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateExtensionCycles checks that the extensions enabled in the service do not depend on
+// each other in a cycle, through extensioncapabilities.ExtensionDependent, as they could not be
+// started in dependency order.
+func (cfg *Config) validateExtensionCycles() error {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[component.ID]int, len(cfg.Service.Extensions))
+	var path []component.ID
+	var visit func(id component.ID) error
+	visit = func(id component.ID) error {
+		switch state[id] {
+		case visiting:
+			var names []string
+			for _, extID := range path[slices.Index(path, id):] {
+				names = append(names, extID.String())
+			}
+			names = append(names, id.String())
+			return fmt.Errorf("service::extensions: dependency cycle found [%s]", strings.Join(names, " -> "))
+		case visited:
+			return nil
+		}
+		state[id] = visiting
+		path = append(path, id)
+		if dep, ok := cfg.Extensions[id].(extensioncapabilities.ExtensionDependent); ok {
+			for _, depID := range dep.ExtensionDependencies() {
+				if err := visit(depID); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = visited
+		return nil
+	}
+
+	for _, ref := range cfg.Service.Extensions {
+		if err := visit(ref); err != nil {
+			return err
+		}
+	}
 	return nil
 }
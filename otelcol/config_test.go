@@ -238,6 +238,82 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`service::pipelines::traces: references exporter "nop/conn2" which is not configured`),
 		},
+		{
+			name: "valid-extension-dependency",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Exporters[component.MustNewID("nop")] = extensionDependentConfig{component.MustNewID("nop")}
+				return cfg
+			},
+		},
+		{
+			name: "exporter-extension-dependency-not-enabled",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Exporters[component.MustNewID("nop")] = extensionDependentConfig{component.MustNewID("nop")}
+				cfg.Service.Extensions = nil
+				return cfg
+			},
+			expected: errors.New(`exporters::nop: depends on extension "nop" which is not enabled in the service`),
+		},
+		{
+			name: "extension-extension-dependency-not-enabled",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Extensions[component.MustNewID("nop")] = extensionDependentConfig{component.MustNewIDWithName("nop", "2")}
+				return cfg
+			},
+			expected: errors.New(`extensions::nop: depends on extension "nop/2" which is not enabled in the service`),
+		},
+		{
+			name: "extension-dependency-cycle",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				nop2ID := component.MustNewIDWithName("nop", "2")
+				cfg.Extensions[component.MustNewID("nop")] = extensionDependentConfig{nop2ID}
+				cfg.Extensions[nop2ID] = extensionDependentConfig{component.MustNewID("nop")}
+				cfg.Service.Extensions = append(cfg.Service.Extensions, nop2ID)
+				return cfg
+			},
+			expected: errors.New(`service::extensions: dependency cycle found [nop -> nop/2 -> nop]`),
+		},
+		{
+			name: "extension-self-dependency",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Extensions[component.MustNewID("nop")] = extensionDependentConfig{component.MustNewID("nop")}
+				return cfg
+			},
+			expected: errors.New(`service::extensions: dependency cycle found [nop -> nop]`),
+		},
+		{
+			name: "memory-limit-set-by-service-only",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Runtime.MemoryLimitPercentage = 80
+				cfg.Extensions[component.MustNewID("nop")] = memoryLimitSetterConfig(false)
+				return cfg
+			},
+		},
+		{
+			name: "memory-limit-set-by-service-and-extension",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Runtime.MemoryLimitPercentage = 80
+				cfg.Extensions[component.MustNewID("nop")] = memoryLimitSetterConfig(true)
+				return cfg
+			},
+			expected: errors.New(`service::runtime::memory_limit_percentage: cannot be set with extension "nop", which sets the soft memory limit`),
+		},
+		{
+			name: "memory-limit-set-by-disabled-extension",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Runtime.MemoryLimitPercentage = 80
+				cfg.Extensions[component.MustNewIDWithName("nop", "2")] = memoryLimitSetterConfig(true)
+				return cfg
+			},
+		},
 		{
 			name: "invalid-service-config",
 			cfgFn: func() *Config {
@@ -340,6 +416,18 @@ func newPtr[T int | string](str T) *T {
 	return &str
 }
 
+type extensionDependentConfig []component.ID
+
+func (cfg extensionDependentConfig) ExtensionDependencies() []component.ID {
+	return cfg
+}
+
+type memoryLimitSetterConfig bool
+
+func (cfg memoryLimitSetterConfig) SetsMemoryLimit() bool {
+	return bool(cfg)
+}
+
 type fakeTelemetryConfig struct {
 	Invalid bool `mapstructure:"invalid"`
 }
@@ -55,12 +55,12 @@ func NewConfigProvider(set ConfigProviderSettings) (*ConfigProvider, error) {
 func (cm *ConfigProvider) Get(ctx context.Context, factories Factories) (*Config, error) {
 	conf, err := cm.mapResolver.Resolve(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("cannot resolve the configuration: %w", err)
+		return nil, newExitError(ExitCodeConfigResolution, fmt.Errorf("cannot resolve the configuration: %w", err))
 	}
 
 	var cfg *configSettings
 	if cfg, err = unmarshal(conf, factories); err != nil {
-		return nil, fmt.Errorf("cannot unmarshal the configuration: %w", err)
+		return nil, newExitError(ExitCodeConfigValidation, fmt.Errorf("cannot unmarshal the configuration: %w", err))
 	}
 
 	return &Config{
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// driveLetterRegexp matches the Windows paths, which the resolver reads with the file provider.
+var driveLetterRegexp = regexp.MustCompile(`^[A-z]:`)
+
+// useUnwatchedConfig wraps the providers so that they do not watch the given config locations,
+// whose changes then do not reload the configuration.
+func useUnwatchedConfig(set *confmap.ResolverSettings, locations []string) {
+	if len(locations) == 0 {
+		return
+	}
+	uris := make(map[string]struct{}, len(locations))
+	for _, location := range locations {
+		// The resolver retrieves the locations without scheme with the file provider.
+		if location == stdinConfigFlag {
+			location = stdinScheme + ":"
+		} else if driveLetterRegexp.MatchString(location) || !strings.Contains(location, ":") {
+			location = "file:" + location
+		}
+		uris[location] = struct{}{}
+	}
+
+	factories := make([]confmap.ProviderFactory, len(set.ProviderFactories))
+	for i, factory := range set.ProviderFactories {
+		factories[i] = confmap.NewProviderFactory(func(ps confmap.ProviderSettings) confmap.Provider {
+			return unwatchedProvider{Provider: factory.Create(ps), uris: uris}
+		})
+	}
+	set.ProviderFactories = factories
+}
+
+// unwatchedProvider is a provider which does not watch the configuration it retrieves from some URIs.
+type unwatchedProvider struct {
+	confmap.Provider
+	uris map[string]struct{}
+}
+
+func (p unwatchedProvider) Retrieve(ctx context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if _, ok := p.uris[uri]; ok {
+		watcher = nil
+	}
+	return p.Provider.Retrieve(ctx, uri, watcher)
+}
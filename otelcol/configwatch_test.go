@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestUseUnwatchedConfig(t *testing.T) {
+	watched := map[string]bool{}
+	retrieve := func(_ context.Context, uri string, watcher confmap.WatcherFunc) (*confmap.Retrieved, error) {
+		watched[uri] = watcher != nil
+		return confmap.NewRetrieved(map[string]any{})
+	}
+	set := confmap.ResolverSettings{
+		URIs:              []string{"file:watched.yaml", "file:unwatched.yaml", "local.yaml", "yaml:{}"},
+		ProviderFactories: []confmap.ProviderFactory{newFakeProvider("file", retrieve), newFakeProvider("yaml", retrieve)},
+	}
+	useUnwatchedConfig(&set, []string{"file:unwatched.yaml", "local.yaml"})
+
+	resolver, err := confmap.NewResolver(set)
+	require.NoError(t, err)
+	_, err = resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		"file:watched.yaml":   true,
+		"file:unwatched.yaml": false,
+		"file:local.yaml":     false,
+		"yaml:{}":             true,
+	}, watched)
+	require.NoError(t, resolver.Shutdown(context.Background()))
+}
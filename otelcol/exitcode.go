@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import "errors"
+
+// The exit codes of the collector for each class of failure, following the sysexits.h conventions,
+// so that init systems and orchestrators can branch on them. Other failures exit with the code 1.
+const (
+	// ExitCodeConfigResolution is the exit code when the configuration cannot be resolved
+	// from its locations.
+	ExitCodeConfigResolution = 66 // EX_NOINPUT
+	// ExitCodeStartFailure is the exit code when the components cannot be created or started.
+	ExitCodeStartFailure = 69 // EX_UNAVAILABLE
+	// ExitCodeRuntimeError is the exit code when a component reports a fatal error while running.
+	ExitCodeRuntimeError = 70 // EX_SOFTWARE
+	// ExitCodeConfigValidation is the exit code when the configuration is invalid.
+	ExitCodeConfigValidation = 78 // EX_CONFIG
+)
+
+// ExitError is an error of the collector carrying the code the process exits with.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func newExitError(code int, err error) error {
+	return &ExitError{Code: code, Err: err}
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the code the process exits with after the given error returned by the collector
+// or its command: 0 without error, the code of the first ExitError in its tree, else 1.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return 1
+}
@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, 0, ExitCode(nil))
+	assert.Equal(t, 1, ExitCode(errors.New("failure")))
+
+	err := newExitError(ExitCodeConfigValidation, errors.New("invalid"))
+	assert.EqualError(t, err, "invalid")
+	assert.Equal(t, ExitCodeConfigValidation, ExitCode(err))
+	assert.Equal(t, ExitCodeConfigValidation, ExitCode(fmt.Errorf("failed to setup configuration components: %w", err)))
+	assert.Equal(t, ExitCodeConfigValidation, ExitCode(errors.Join(err, errors.New("failed to shutdown"))))
+}
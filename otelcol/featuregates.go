@@ -0,0 +1,293 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+// featureGatesFlag is the flag registered by featuregate.Registry.RegisterFlags.
+const featureGatesFlag = "feature-gates"
+
+// featureGatesKey is the key of the feature gates in the configuration, mapping their ID, suffixed with
+// "@<kind>/<type>[/<name>]" to only set them for a component instance, to whether they are enabled.
+const featureGatesKey = "service::feature_gates"
+
+// FeatureGateRemovalPolicy is how the collector handles the feature gates set by the --feature-gates flag
+// or the configuration while past their removal version, see featuregate.Gate.IsPastRemoval.
+type FeatureGateRemovalPolicy int
+
+const (
+	// FeatureGateRemovalWarn logs a warning for each feature gate set past its removal version when the
+	// collector starts. It is the default.
+	FeatureGateRemovalWarn FeatureGateRemovalPolicy = iota
+	// FeatureGateRemovalError fails to start the collector if a feature gate is set past its removal version.
+	FeatureGateRemovalError
+	// FeatureGateRemovalIgnore ignores the removal versions of the feature gates, e.g. for a distribution
+	// not versioned like the collector.
+	FeatureGateRemovalIgnore
+)
+
+// featureGatesFlagValue records the feature gates set by the --feature-gates flag, which take precedence
+// over the feature gates of the configuration.
+type featureGatesFlagValue struct {
+	flag.Value
+	reg  *featuregate.Registry
+	keys []string
+}
+
+func (f *featureGatesFlagValue) String() string {
+	// The flag package calls String on a zero value, see featuregate.flagValue.String.
+	if f.Value == nil {
+		return ""
+	}
+	return f.Value.String()
+}
+
+func (f *featureGatesFlagValue) Set(s string) error {
+	for entry := range strings.SplitSeq(s, ",") {
+		if key := strings.TrimLeft(entry, "+-"); key != "" {
+			f.keys = append(f.keys, key)
+		}
+	}
+	return f.Value.Set(s)
+}
+
+// recordFeatureGatesFlag wraps the --feature-gates flag registered by the registry to record its gates.
+func recordFeatureGatesFlag(flagSet *flag.FlagSet, reg *featuregate.Registry) {
+	f := flagSet.Lookup(featureGatesFlag)
+	f.Value = &featureGatesFlagValue{Value: f.Value, reg: reg}
+}
+
+// featureGates sets the feature gates of the configuration in the registry of the --feature-gates flag. The
+// converter records the gates of each resolved configuration, and the collector applies those of the
+// configuration it runs at once, after it is validated, so that the gates of an invalid configuration are
+// never set.
+type featureGates struct {
+	reg *featuregate.Registry
+	// flagKeys are the gates set by the --feature-gates flag, ignored in the configuration.
+	flagKeys []string
+	// pending are the gates of the last resolved configuration, by key.
+	pending map[string]bool
+	// applied are the gates of the running configuration, by key, nil until a configuration is applied.
+	applied map[string]bool
+	// defaults are the values of the applied gates before the configuration set them, by key, restored
+	// once they are removed from the configuration.
+	defaults map[string]bool
+}
+
+func newFeatureGates(flagSet *flag.FlagSet) *featureGates {
+	f := flagSet.Lookup(featureGatesFlag).Value.(*featureGatesFlagValue)
+	return &featureGates{reg: f.reg, flagKeys: slices.Clone(f.keys)}
+}
+
+func (fg *featureGates) gates() map[string]*featuregate.Gate {
+	gates := make(map[string]*featuregate.Gate)
+	fg.reg.VisitAll(func(g *featuregate.Gate) {
+		gates[g.ID()] = g
+	})
+	return gates
+}
+
+// targets returns the value of each gate once the gates of a configuration are applied: the gates no longer
+// in the configuration are restored to their value before it set them.
+func (fg *featureGates) targets(enabled map[string]bool) map[string]bool {
+	targets := maps.Clone(enabled)
+	if targets == nil {
+		targets = make(map[string]bool)
+	}
+	for key := range fg.applied {
+		if _, ok := targets[key]; !ok {
+			targets[key] = fg.defaults[key]
+		}
+	}
+	return targets
+}
+
+// apply sets the pending gates in the registry. If one of them cannot be set, the gates already set are
+// restored. It returns the function restoring the gates of the previous configuration, e.g. when the
+// collector fails to start with the new one.
+func (fg *featureGates) apply() (rollback func(), err error) {
+	gates := fg.gates()
+	prevApplied, prevDefaults := fg.applied, maps.Clone(fg.defaults)
+	if fg.defaults == nil {
+		fg.defaults = make(map[string]bool)
+	}
+	type change struct {
+		key  string
+		prev bool
+	}
+	var changes []change
+	rollback = func() {
+		for i := len(changes) - 1; i >= 0; i-- {
+			_ = fg.set(changes[i].key, changes[i].prev)
+		}
+		fg.applied, fg.defaults = prevApplied, prevDefaults
+	}
+
+	targets := fg.targets(fg.pending)
+	for _, key := range slices.Sorted(maps.Keys(targets)) {
+		id, scope, scoped := strings.Cut(key, "@")
+		current := gates[id].IsEnabled()
+		if scoped {
+			current = gates[id].IsEnabledFor(scope)
+		}
+		_, seen := fg.defaults[key]
+		if !seen {
+			fg.defaults[key] = current
+		} else if current == targets[key] {
+			continue
+		}
+		if err = fg.set(key, targets[key]); err != nil {
+			rollback()
+			return nil, fmt.Errorf("cannot set the feature gates of %q: %w", featureGatesKey, err)
+		}
+		changes = append(changes, change{key: key, prev: current})
+	}
+
+	fg.applied = maps.Clone(fg.pending)
+	if fg.applied == nil {
+		fg.applied = make(map[string]bool)
+	}
+	for key := range fg.defaults {
+		if _, ok := fg.applied[key]; !ok {
+			delete(fg.defaults, key)
+		}
+	}
+	return rollback, nil
+}
+
+func (fg *featureGates) set(key string, enabled bool) error {
+	if id, scope, scoped := strings.Cut(key, "@"); scoped {
+		return fg.reg.SetFor(id, scope, enabled)
+	}
+	return fg.reg.Set(key, enabled)
+}
+
+// newFeatureGatesConverterFactory returns the factory of the converter recording the feature gates of the
+// configuration, to be applied by the collector.
+func newFeatureGatesConverterFactory(gates *featureGates, version string, removalPolicy FeatureGateRemovalPolicy) confmap.ConverterFactory {
+	return confmap.NewConverterFactory(func(set confmap.ConverterSettings) confmap.Converter {
+		logger := set.Logger
+		if logger == nil {
+			logger = zap.NewNop()
+		}
+		return &featureGatesConverter{
+			gates:         gates,
+			version:       version,
+			removalPolicy: removalPolicy,
+			logger:        logger,
+		}
+	})
+}
+
+// featureGatesConverter validates the feature gates of the configuration once it is resolved, and records
+// them to be applied once the configuration is validated. When the configuration is reloaded, only the
+// runtime safe gates can change.
+type featureGatesConverter struct {
+	gates *featureGates
+	// version is the version of the collector, checked against the removal versions of the gates.
+	version       string
+	removalPolicy FeatureGateRemovalPolicy
+	logger        *zap.Logger
+}
+
+func (c *featureGatesConverter) Convert(_ context.Context, conf *confmap.Conf) error {
+	var enabled map[string]bool
+	if conf.IsSet(featureGatesKey) {
+		sub, err := conf.Sub(featureGatesKey)
+		if err == nil {
+			err = sub.Unmarshal(&enabled)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid %q: %w", featureGatesKey, err)
+		}
+	}
+	for key := range enabled {
+		if slices.Contains(c.gates.flagKeys, key) {
+			delete(enabled, key)
+		}
+	}
+	initial := c.gates.applied == nil
+
+	gates := c.gates.gates()
+	if initial {
+		if err := c.checkRemoval(gates, append(slices.Clone(c.gates.flagKeys), slices.Sorted(maps.Keys(enabled))...)); err != nil {
+			return err
+		}
+	}
+
+	var errs error
+	for _, key := range slices.Sorted(maps.Keys(enabled)) {
+		id, _, _ := strings.Cut(key, "@")
+		g, ok := gates[id]
+		switch {
+		case !ok:
+			errs = errors.Join(errs, fmt.Errorf("no such feature gate %q", id))
+		case g.Stage() == featuregate.StageStable && !enabled[key]:
+			errs = errors.Join(errs, fmt.Errorf("feature gate %q is stable, can not be disabled", id))
+		case g.Stage() == featuregate.StageDeprecated && enabled[key]:
+			errs = errors.Join(errs, fmt.Errorf("feature gate %q is deprecated, can not be enabled", id))
+		}
+	}
+	if errs == nil && !initial {
+		// Once the collector runs, the gates changed by the configuration, or restored as they were removed
+		// from it, must be runtime safe.
+		targets := c.gates.targets(enabled)
+		for _, key := range slices.Sorted(maps.Keys(targets)) {
+			id, scope, scoped := strings.Cut(key, "@")
+			g := gates[id]
+			current := g.IsEnabled()
+			if scoped {
+				current = g.IsEnabledFor(scope)
+			}
+			if current != targets[key] && !g.IsRuntimeSafe() {
+				errs = errors.Join(errs, fmt.Errorf("feature gate %q is not runtime safe, the collector must be restarted to set it", id))
+			}
+		}
+	}
+	if errs != nil {
+		return fmt.Errorf("cannot set the feature gates of %q: %w", featureGatesKey, errs)
+	}
+	c.gates.pending = enabled
+	return nil
+}
+
+// checkRemoval applies the removal policy to the gates set by the keys past their removal version.
+func (c *featureGatesConverter) checkRemoval(gates map[string]*featuregate.Gate, keys []string) error {
+	if c.removalPolicy == FeatureGateRemovalIgnore {
+		return nil
+	}
+	var errs error
+	checked := make(map[string]bool)
+	for _, key := range keys {
+		id, _, _ := strings.Cut(key, "@")
+		g, ok := gates[id]
+		if !ok || checked[id] || !g.IsPastRemoval(c.version) {
+			continue
+		}
+		checked[id] = true
+		if c.removalPolicy == FeatureGateRemovalError {
+			errs = errors.Join(errs, fmt.Errorf("feature gate %q was due to be removed after version %s, it must no longer be set", id, g.ToVersion()))
+			continue
+		}
+		c.logger.Warn("Feature gate set past its removal version, it may be removed in any version",
+			zap.String("feature_gate", id),
+			zap.String("stage", g.Stage().String()),
+			zap.String("removal_version", g.ToVersion()),
+			zap.String("version", c.version))
+	}
+	return errs
+}
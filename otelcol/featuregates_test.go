@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func newFeatureGatesConverter(t *testing.T, reg *featuregate.Registry, args ...string) (confmap.Converter, *featureGates) {
+	flgs := flags(reg)
+	require.NoError(t, flgs.Parse(args))
+	gates := newFeatureGates(flgs)
+	return newFeatureGatesConverterFactory(gates, "v0.138.0", FeatureGateRemovalWarn).Create(confmap.ConverterSettings{}), gates
+}
+
+func featureGatesConf(gates map[string]any) *confmap.Conf {
+	return confmap.NewFromStringMap(map[string]any{"service": map[string]any{"feature_gates": gates}})
+}
+
+func TestFeatureGatesConverter(t *testing.T) {
+	reg := featuregate.NewRegistry()
+	alpha := reg.MustRegister("test.alpha", featuregate.StageAlpha)
+	beta := reg.MustRegister("test.beta", featuregate.StageBeta)
+	flagged := reg.MustRegister("test.flagged", featuregate.StageAlpha)
+	converter, gates := newFeatureGatesConverter(t, reg, "--feature-gates=-test.flagged")
+
+	require.NoError(t, converter.Convert(context.Background(), featureGatesConf(map[string]any{
+		"test.alpha":                     true,
+		"test.beta@exporter/otlp/canary": false,
+		"test.flagged":                   true,
+	})))
+	// The gates are only set once the configuration is validated.
+	assert.False(t, alpha.IsEnabled())
+	assert.True(t, beta.IsEnabledFor("exporter/otlp/canary"))
+
+	_, err := gates.apply()
+	require.NoError(t, err)
+	assert.True(t, alpha.IsEnabled())
+	assert.True(t, beta.IsEnabled())
+	assert.False(t, beta.IsEnabledFor("exporter/otlp/canary"))
+	// The flag takes precedence.
+	assert.False(t, flagged.IsEnabled())
+}
+
+func TestFeatureGatesConverterReload(t *testing.T) {
+	reg := featuregate.NewRegistry()
+	safe := reg.MustRegister("test.safe", featuregate.StageAlpha, featuregate.WithRegisterRuntimeSafe())
+	unsafe := reg.MustRegister("test.unsafe", featuregate.StageAlpha)
+	converter, gates := newFeatureGatesConverter(t, reg)
+
+	require.NoError(t, converter.Convert(context.Background(), featureGatesConf(map[string]any{"test.unsafe": true})))
+	_, err := gates.apply()
+	require.NoError(t, err)
+	assert.True(t, unsafe.IsEnabled())
+
+	// Once the collector runs, only the runtime safe gates can change.
+	require.NoError(t, converter.Convert(context.Background(), featureGatesConf(map[string]any{"test.safe": true, "test.unsafe": true})))
+	_, err = gates.apply()
+	require.NoError(t, err)
+	assert.True(t, safe.IsEnabled())
+	err = converter.Convert(context.Background(), featureGatesConf(map[string]any{"test.unsafe": false}))
+	require.ErrorContains(t, err, `feature gate "test.unsafe" is not runtime safe, the collector must be restarted to set it`)
+	err = converter.Convert(context.Background(), featureGatesConf(map[string]any{"test.safe": true}))
+	require.ErrorContains(t, err, `feature gate "test.unsafe" is not runtime safe, the collector must be restarted to set it`)
+	assert.True(t, unsafe.IsEnabled())
+
+	// The gates no longer in the configuration are restored.
+	require.NoError(t, converter.Convert(context.Background(), featureGatesConf(map[string]any{"test.unsafe": true})))
+	_, err = gates.apply()
+	require.NoError(t, err)
+	assert.False(t, safe.IsEnabled())
+	assert.True(t, unsafe.IsEnabled())
+}
+
+func TestFeatureGatesRollback(t *testing.T) {
+	reg := featuregate.NewRegistry()
+	first := reg.MustRegister("test.first", featuregate.StageAlpha, featuregate.WithRegisterRuntimeSafe())
+	second := reg.MustRegister("test.second", featuregate.StageAlpha, featuregate.WithRegisterRuntimeSafe())
+	converter, gates := newFeatureGatesConverter(t, reg)
+
+	require.NoError(t, converter.Convert(context.Background(), featureGatesConf(map[string]any{"test.first": true})))
+	_, err := gates.apply()
+	require.NoError(t, err)
+
+	// The collector fails to start with the new configuration, and restores the gates of the previous one.
+	require.NoError(t, converter.Convert(context.Background(), featureGatesConf(map[string]any{"test.second": true})))
+	rollback, err := gates.apply()
+	require.NoError(t, err)
+	assert.False(t, first.IsEnabled())
+	assert.True(t, second.IsEnabled())
+	rollback()
+	assert.True(t, first.IsEnabled())
+	assert.False(t, second.IsEnabled())
+
+	// The gates already set are restored when one of them cannot be set.
+	require.NoError(t, converter.Convert(context.Background(), featureGatesConf(map[string]any{"test.second": true, "test.second@otlp": true})))
+	_, err = gates.apply()
+	require.ErrorContains(t, err, `invalid scope "otlp"`)
+	assert.True(t, first.IsEnabled())
+	assert.False(t, second.IsEnabled())
+
+	require.NoError(t, converter.Convert(context.Background(), confmap.New()))
+	_, err = gates.apply()
+	require.NoError(t, err)
+	assert.False(t, first.IsEnabled())
+}
+
+func TestFeatureGatesConverterErrors(t *testing.T) {
+	reg := featuregate.NewRegistry()
+	reg.MustRegister("test.stable", featuregate.StageStable, featuregate.WithRegisterToVersion("v1.0.0"))
+	reg.MustRegister("test.deprecated", featuregate.StageDeprecated, featuregate.WithRegisterToVersion("v1.0.0"))
+
+	tests := []struct {
+		name        string
+		conf        *confmap.Conf
+		expectedErr string
+	}{
+		{
+			name:        "not a map",
+			conf:        confmap.NewFromStringMap(map[string]any{"service": map[string]any{"feature_gates": []any{"test.stable"}}}),
+			expectedErr: `invalid "service::feature_gates"`,
+		},
+		{
+			name:        "not a bool",
+			conf:        featureGatesConf(map[string]any{"test.stable": "maybe"}),
+			expectedErr: `invalid "service::feature_gates"`,
+		},
+		{
+			name:        "missing",
+			conf:        featureGatesConf(map[string]any{"test.missing": true}),
+			expectedErr: `cannot set the feature gates of "service::feature_gates": no such feature gate "test.missing"`,
+		},
+		{
+			name:        "disabled stable",
+			conf:        featureGatesConf(map[string]any{"test.stable": false}),
+			expectedErr: `feature gate "test.stable" is stable, can not be disabled`,
+		},
+		{
+			name:        "enabled deprecated",
+			conf:        featureGatesConf(map[string]any{"test.deprecated": true}),
+			expectedErr: `feature gate "test.deprecated" is deprecated, can not be enabled`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converter, _ := newFeatureGatesConverter(t, reg)
+			err := converter.Convert(context.Background(), tt.conf)
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestFeatureGatesConverterRemoval(t *testing.T) {
+	reg := featuregate.NewRegistry()
+	reg.MustRegister("test.removed", featuregate.StageDeprecated, featuregate.WithRegisterToVersion("v0.130.0"))
+	reg.MustRegister("test.flagged", featuregate.StageStable, featuregate.WithRegisterToVersion("v0.131.0"))
+	reg.MustRegister("test.current", featuregate.StageStable, featuregate.WithRegisterToVersion("v0.138.0"))
+	conf := featureGatesConf(map[string]any{"test.removed": false, "test.current": true})
+
+	newConverter := func(policy FeatureGateRemovalPolicy, logger *zap.Logger) confmap.Converter {
+		flgs := flags(reg)
+		require.NoError(t, flgs.Parse([]string{"--feature-gates=test.flagged"}))
+		return newFeatureGatesConverterFactory(newFeatureGates(flgs), "v0.138.0-dev", policy).Create(confmap.ConverterSettings{Logger: logger})
+	}
+
+	core, logs := observer.New(zap.WarnLevel)
+	require.NoError(t, newConverter(FeatureGateRemovalWarn, zap.New(core)).Convert(context.Background(), conf))
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, "test.flagged", logs.All()[0].ContextMap()["feature_gate"])
+	assert.Equal(t, "test.removed", logs.All()[1].ContextMap()["feature_gate"])
+	assert.Equal(t, "v0.130.0", logs.All()[1].ContextMap()["removal_version"])
+
+	err := newConverter(FeatureGateRemovalError, zap.NewNop()).Convert(context.Background(), conf)
+	require.ErrorContains(t, err, `feature gate "test.flagged" was due to be removed after version v0.131.0`)
+	require.ErrorContains(t, err, `feature gate "test.removed" was due to be removed after version v0.130.0`)
+
+	core, logs = observer.New(zap.WarnLevel)
+	require.NoError(t, newConverter(FeatureGateRemovalIgnore, zap.New(core)).Convert(context.Background(), conf))
+	assert.Equal(t, 0, logs.Len())
+}
@@ -4,20 +4,46 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
-	"errors"
 	"flag"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/featuregate"
 )
 
 const (
-	configFlag = "config"
+	configFlag               = "config"
+	unwatchedConfigFlag      = "unwatched-config"
+	configReloadDebounceFlag = "config-reload-debounce"
+	sinkExportersFlag        = "sink-exporters"
+	supervisorFlag           = "supervisor"
+	supervisorHealthFlag     = "supervisor-health-endpoint"
+	supervisorCrashDirFlag   = "supervisor-crash-dir"
+	pidFileFlag              = "pid-file"
+	umaskFlag                = "umask"
+	workingDirFlag           = "working-dir"
+	daemonFlag               = "daemon"
+	pluginFlag               = "plugin"
 )
 
+type pluginFlagValue []string
+
+func (s *pluginFlagValue) Set(val string) error {
+	*s = append(*s, val)
+	return nil
+}
+
+func (s *pluginFlagValue) String() string {
+	return "[" + strings.Join(*s, ", ") + "]"
+}
+
 type configFlagValue struct {
 	values []string
 	sets   []string
+	// listSets are the --set flags setting elements of lists, applied once the configuration is resolved.
+	listSets []*setListOp
+	// unwatched are the locations, among values, whose changes do not reload the configuration.
+	unwatched []string
 }
 
 func (s *configFlagValue) Set(val string) error {
@@ -36,24 +62,94 @@ func flags(reg *featuregate.Registry) *flag.FlagSet {
 	flagSet.Var(cfgs, configFlag, "Locations to the config file(s), note that only a"+
 		" single location can be set per flag entry e.g. `--config=file:/path/to/first --config=file:path/to/second`.")
 
+	flagSet.Func(unwatchedConfigFlag, "Location to a config file, like --config, whose changes do not reload the"+
+		" configuration, e.g. a local file layered over a watched remote configuration.",
+		func(s string) error {
+			cfgs.values = append(cfgs.values, s)
+			cfgs.unwatched = append(cfgs.unwatched, s)
+			return nil
+		})
+	flagSet.Duration(configReloadDebounceFlag, 0, "Time without any change of the watched config locations after which"+
+		" the configuration is reloaded, so that the changes of several locations in quick succession reload it once."+
+		" Reloaded on every change when zero.")
+
 	flagSet.Func("set",
 		"Set arbitrary component config property. The component has to be defined in the config file and the flag"+
-			" has a higher precedence. Array config properties are overridden and maps are joined. Example --set=processors.batch.timeout=2s",
+			" has a higher precedence. Values are typed as in YAML, array config properties are overridden and maps are joined."+
+			" Array elements are set with an index or appended with [+]. Example --set=processors.batch.timeout=2s"+
+			" --set=service.pipelines.traces.receivers[+]=otlp",
 		func(s string) error {
-			idx := strings.Index(s, "=")
-			if idx == -1 {
-				// No need for more context, see TestSetFlag/invalid_set.
-				return errors.New("missing equal sign")
+			uri, op, err := parseSetFlag(s)
+			if err != nil {
+				return err
 			}
-			cfgs.sets = append(cfgs.sets, "yaml:"+strings.TrimSpace(strings.ReplaceAll(s[:idx], ".", "::"))+": "+strings.TrimSpace(s[idx+1:]))
+			if op != nil {
+				cfgs.listSets = append(cfgs.listSets, op)
+				return nil
+			}
+			cfgs.sets = append(cfgs.sets, uri)
 			return nil
 		})
 
+	flagSet.Bool(sinkExportersFlag, false, "Run the pipelines with their exporters replaced by sinks dropping the data,"+
+		" and log the throughput of each pipeline instead. Useful to validate a configuration against production traffic.")
+
+	flagSet.Bool(supervisorFlag, false, "Run the collector as a child process, restarted with backoff when it crashes.")
+	flagSet.String(supervisorHealthFlag, "", "Endpoint of the health check served by the supervisor while the collector"+
+		" restarts, e.g. `localhost:13134`. Disabled when empty.")
+	flagSet.String(supervisorCrashDirFlag, "", "Directory where the supervisor writes a report of each crash of the collector,"+
+		" with the end of its standard error. Disabled when empty.")
+
+	flagSet.String(pidFileFlag, "", "File where the pid of the collector, or of its supervisor, is written, and removed"+
+		" on exit. Disabled when empty.")
+	flagSet.String(umaskFlag, "", "Umask of the collector, in octal, e.g. `027`. Inherited when empty. Not supported on Windows.")
+	flagSet.String(workingDirFlag, "", "Absolute path of the working directory of the collector, where the relative"+
+		" paths are resolved from. Inherited when empty.")
+	flagSet.Bool(daemonFlag, false, "Detach the collector from the terminal in a new session, with its standard streams"+
+		" discarded, once it is ready. Not supported on Windows.")
+
+	flagSet.Var(new(pluginFlagValue), pluginFlag, "Path to the program of a plugin, whose receiver, processor or"+
+		" exporter is added to the components of the collector. Can be set several times.")
+
 	reg.RegisterFlags(flagSet)
+	recordFeatureGatesFlag(flagSet, reg)
 	return flagSet
 }
 
+func getSinkExportersFlag(flagSet *flag.FlagSet) bool {
+	return flagSet.Lookup(sinkExportersFlag).Value.(flag.Getter).Get().(bool)
+}
+
+func getSupervisorFlags(flagSet *flag.FlagSet) (enabled bool, healthEndpoint, crashDir string) {
+	return flagSet.Lookup(supervisorFlag).Value.(flag.Getter).Get().(bool),
+		flagSet.Lookup(supervisorHealthFlag).Value.String(),
+		flagSet.Lookup(supervisorCrashDirFlag).Value.String()
+}
+
+func getProcessFlags(flagSet *flag.FlagSet) (pidFile, umask, workingDir string, daemon bool) {
+	return flagSet.Lookup(pidFileFlag).Value.String(),
+		flagSet.Lookup(umaskFlag).Value.String(),
+		flagSet.Lookup(workingDirFlag).Value.String(),
+		flagSet.Lookup(daemonFlag).Value.(flag.Getter).Get().(bool)
+}
+
+func getPluginFlag(flagSet *flag.FlagSet) []string {
+	return *flagSet.Lookup(pluginFlag).Value.(*pluginFlagValue)
+}
+
 func getConfigFlag(flagSet *flag.FlagSet) []string {
 	cfv := flagSet.Lookup(configFlag).Value.(*configFlagValue)
 	return append(cfv.values, cfv.sets...)
 }
+
+func getUnwatchedConfigFlag(flagSet *flag.FlagSet) []string {
+	return flagSet.Lookup(configFlag).Value.(*configFlagValue).unwatched
+}
+
+func getConfigReloadDebounceFlag(flagSet *flag.FlagSet) time.Duration {
+	return flagSet.Lookup(configReloadDebounceFlag).Value.(flag.Getter).Get().(time.Duration)
+}
+
+func getSetListFlags(flagSet *flag.FlagSet) []*setListOp {
+	return flagSet.Lookup(configFlag).Value.(*configFlagValue).listSets
+}
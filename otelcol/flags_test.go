@@ -5,6 +5,7 @@ package otelcol
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,6 +50,11 @@ func TestSetFlag(t *testing.T) {
 			args:            []string{"--config=file:testdata/otelcol-nop.yaml", "--set=key=value"},
 			expectedConfigs: []string{"file:testdata/otelcol-nop.yaml", "yaml:key: value"},
 		},
+		{
+			name:            "unwatched config",
+			args:            []string{"--config=file:testdata/otelcol-nop.yaml", "--unwatched-config=file:local.yaml", "--set=key=value"},
+			expectedConfigs: []string{"file:testdata/otelcol-nop.yaml", "file:local.yaml", "yaml:key: value"},
+		},
 		{
 			name:        "invalid set",
 			args:        []string{"--set=key:name"},
@@ -69,3 +75,43 @@ func TestSetFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestSupervisorFlags(t *testing.T) {
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse(nil))
+	enabled, healthEndpoint, crashDir := getSupervisorFlags(flgs)
+	assert.False(t, enabled)
+	assert.Empty(t, healthEndpoint)
+	assert.Empty(t, crashDir)
+
+	flgs = flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--supervisor", "--supervisor-health-endpoint=localhost:13134", "--supervisor-crash-dir=/var/crash"}))
+	enabled, healthEndpoint, crashDir = getSupervisorFlags(flgs)
+	assert.True(t, enabled)
+	assert.Equal(t, "localhost:13134", healthEndpoint)
+	assert.Equal(t, "/var/crash", crashDir)
+}
+
+func TestConfigWatchFlags(t *testing.T) {
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--unwatched-config=file:a.yaml", "--config=file:b.yaml", "--unwatched-config=c.yaml", "--config-reload-debounce=5s"}))
+	assert.Equal(t, []string{"file:a.yaml", "file:b.yaml", "c.yaml"}, getConfigFlag(flgs))
+	assert.Equal(t, []string{"file:a.yaml", "c.yaml"}, getUnwatchedConfigFlag(flgs))
+	assert.Equal(t, 5*time.Second, getConfigReloadDebounceFlag(flgs))
+}
+
+func TestProcessFlags(t *testing.T) {
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--pid-file=/run/otelcol.pid", "--umask=027", "--working-dir=/var/lib/otelcol", "--daemon"}))
+	pidFile, umask, workingDir, daemon := getProcessFlags(flgs)
+	assert.Equal(t, "/run/otelcol.pid", pidFile)
+	assert.Equal(t, "027", umask)
+	assert.Equal(t, "/var/lib/otelcol", workingDir)
+	assert.True(t, daemon)
+}
+
+func TestPluginFlag(t *testing.T) {
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--plugin=/opt/plugins/a", "--plugin=b"}))
+	assert.Equal(t, []string{"/opt/plugins/a", "b"}, getPluginFlag(flgs))
+}
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !unix
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"errors"
+	"os"
+)
+
+// handoffSignals is empty, as the listening sockets cannot be handed off on this platform.
+var handoffSignals []os.Signal
+
+func isHandoffSignal(os.Signal) bool {
+	return false
+}
+
+func (*Collector) handoff(context.Context) (bool, error) {
+	return false, errors.New("handoff of the listening sockets is not supported on this platform")
+}
+
+func signalParent(os.Signal) error {
+	return errors.New("signals cannot be sent on this platform")
+}
+
+func notifyHandoffReady() {}
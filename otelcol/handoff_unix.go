@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/collector/config/confignet"
+)
+
+// handoffReadyFDEnv is the environment variable holding the file descriptor on which the new process
+// of the collector notifies the previous one that it is ready.
+const handoffReadyFDEnv = "OTELCOL_HANDOFF_READY_FD"
+
+// handoffReadyTimeout is how long the previous process waits for the new one to be ready.
+var handoffReadyTimeout = time.Minute
+
+// handoffSignals are the signals asking the collector to hand off its listening sockets to a new process.
+var handoffSignals = []os.Signal{syscall.SIGUSR2}
+
+func isHandoffSignal(s os.Signal) bool {
+	return s == syscall.SIGUSR2
+}
+
+// handoff starts a new process of the collector, with the same executable and arguments, passing it
+// the listening sockets, and waits until it is ready. The caller then shuts down, draining its data.
+// If a storage extension is running, the service is shut down before starting the new process, so
+// that the new process can open the files it locks, and stopped is true: the caller then starts the
+// service again if the handoff failed, and must not shut it down otherwise.
+func (col *Collector) handoff(ctx context.Context) (stopped bool, err error) {
+	files, err := confignet.ListenerFiles()
+	if err != nil {
+		return false, fmt.Errorf("failed to get the listening sockets: %w", err)
+	}
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+
+	exe, err := os.Executable()
+	if err != nil {
+		return false, fmt.Errorf("failed to find the executable: %w", err)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+
+	// The sockets stay open meanwhile, the connections waiting to be accepted by the new process.
+	if col.service.HoldsStorage() {
+		col.service.Logger().Info("Shutting down the service to release the storage before handing off")
+		stopped = true
+		col.setCollectorState(StateClosing)
+		if err = col.service.Shutdown(ctx); err != nil {
+			_ = w.Close()
+			return stopped, fmt.Errorf("failed to shutdown the service: %w", err)
+		}
+	}
+
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Stdin = childStdin()
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = append(files, w)
+	cmd.Env = append(handoffEnviron(os.Environ()),
+		confignet.ListenFDsEnv+"="+strconv.Itoa(len(files)),
+		handoffReadyFDEnv+"="+strconv.Itoa(3+len(files)),
+	)
+	err = cmd.Start()
+	_ = w.Close()
+	if err != nil {
+		return stopped, fmt.Errorf("failed to start the new process: %w", err)
+	}
+
+	if err = waitHandoffReady(r, handoffReadyTimeout); err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return stopped, err
+	}
+	return stopped, cmd.Process.Release()
+}
+
+// signalParent sends the signal to the parent process, e.g. the supervisor of the collector.
+func signalParent(s os.Signal) error {
+	return syscall.Kill(os.Getppid(), s.(syscall.Signal))
+}
+
+// handoffEnviron returns the environment without the variables describing the sockets inherited
+// by this process, which are not passed to the new one.
+func handoffEnviron(environ []string) []string {
+	env := make([]string, 0, len(environ))
+	for _, kv := range environ {
+		if strings.HasPrefix(kv, "LISTEN_") || strings.HasPrefix(kv, handoffReadyFDEnv+"=") {
+			continue
+		}
+		env = append(env, kv)
+	}
+	return env
+}
+
+// waitHandoffReady waits until the new process writes on the pipe, failing if it exits before.
+func waitHandoffReady(r *os.File, timeout time.Duration) error {
+	if err := r.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	if _, err := r.Read(make([]byte, 1)); err != nil {
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return fmt.Errorf("new process not ready after %v", timeout)
+		}
+		return fmt.Errorf("new process exited before being ready: %w", err)
+	}
+	return nil
+}
+
+// notifyHandoffReady notifies the previous process of the collector, if any, that this one is ready.
+func notifyHandoffReady() {
+	fd, err := strconv.Atoi(os.Getenv(handoffReadyFDEnv))
+	_ = os.Unsetenv(handoffReadyFDEnv)
+	if err != nil {
+		return
+	}
+	f := os.NewFile(uintptr(fd), "handoff ready")
+	_, _ = f.Write([]byte{1})
+	_ = f.Close()
+}
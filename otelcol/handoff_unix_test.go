@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package otelcol
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyHandoffReady(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	fd, err := syscall.Dup(int(w.Fd()))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	t.Setenv(handoffReadyFDEnv, strconv.Itoa(fd))
+	notifyHandoffReady()
+	_, found := os.LookupEnv(handoffReadyFDEnv)
+	assert.False(t, found)
+
+	require.NoError(t, waitHandoffReady(r, time.Second))
+}
+
+func TestWaitHandoffReadyFailure(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	defer w.Close()
+	require.ErrorContains(t, waitHandoffReady(r, 10*time.Millisecond), "not ready")
+
+	require.NoError(t, w.Close())
+	require.ErrorContains(t, waitHandoffReady(r, time.Second), "exited before being ready")
+}
+
+func TestHandoffEnviron(t *testing.T) {
+	assert.Equal(t, []string{"HOME=/root", "LISTEN=1"}, handoffEnviron([]string{
+		"HOME=/root",
+		"LISTEN_FDS=2",
+		"LISTEN_PID=42",
+		"LISTEN=1",
+		handoffReadyFDEnv + "=5",
+	}))
+}
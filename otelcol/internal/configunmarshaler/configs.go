@@ -6,6 +6,7 @@ package configunmarshaler // import "go.opentelemetry.io/collector/otelcol/inter
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	"golang.org/x/exp/maps"
 
@@ -17,18 +18,34 @@ type Configs[F component.Factory] struct {
 	cfgs map[component.ID]component.Config
 
 	factories map[component.Type]F
+
+	// groups holds the configurations of the component groups, by group ID.
+	groups *confmap.Conf
 }
 
 func NewConfigs[F component.Factory](factories map[component.Type]F) *Configs[F] {
 	return &Configs[F]{factories: factories}
 }
 
+// SetGroups sets the configurations of the component groups. The configuration of a group
+// applies to the components whose ID has the ID of the group as prefix, such as "otlp/team-a"
+// for "otlp/team-a/prod", before their own configuration and from the least to the most
+// specific group.
+func (c *Configs[F]) SetGroups(groups *confmap.Conf) {
+	c.groups = groups
+}
+
 func (c *Configs[F]) Unmarshal(conf *confmap.Conf) error {
 	rawCfgs := make(map[component.ID]map[string]any)
 	if err := conf.Unmarshal(&rawCfgs); err != nil {
 		return err
 	}
 
+	groupIDs, err := c.groupIDs(rawCfgs)
+	if err != nil {
+		return err
+	}
+
 	// Prepare resulting map.
 	c.cfgs = make(map[component.ID]component.Config)
 	// Iterate over raw configs and create a config for each.
@@ -40,7 +57,7 @@ func (c *Configs[F]) Unmarshal(conf *confmap.Conf) error {
 		}
 
 		// Get the configuration from the confmap.Conf to preserve internal representation.
-		sub, err := conf.Sub(id.String())
+		sub, err := c.componentConf(conf, id, groupIDs)
 		if err != nil {
 			return errorUnmarshalError(id, err)
 		}
@@ -60,6 +77,61 @@ func (c *Configs[F]) Unmarshal(conf *confmap.Conf) error {
 	return nil
 }
 
+// groupIDs returns the IDs of the groups, from the least to the most specific.
+func (c *Configs[F]) groupIDs(rawCfgs map[component.ID]map[string]any) ([]component.ID, error) {
+	if c.groups == nil {
+		return nil, nil
+	}
+	rawGroups := make(map[component.ID]map[string]any)
+	if err := c.groups.Unmarshal(&rawGroups); err != nil {
+		return nil, err
+	}
+	groupIDs := make([]component.ID, 0, len(rawGroups))
+	for groupID := range rawGroups {
+		if _, ok := c.factories[groupID.Type()]; !ok {
+			return nil, errorUnknownType(groupID, maps.Keys(c.factories))
+		}
+		used := false
+		for id := range rawCfgs {
+			used = used || id.HasPrefix(groupID)
+		}
+		if !used {
+			return nil, fmt.Errorf("group %q does not match any component", groupID)
+		}
+		groupIDs = append(groupIDs, groupID)
+	}
+	slices.SortFunc(groupIDs, func(a, b component.ID) int {
+		return len(a.NameSegments()) - len(b.NameSegments())
+	})
+	return groupIDs, nil
+}
+
+// componentConf returns the configuration of the component, merged on top of the configurations
+// of its groups.
+func (c *Configs[F]) componentConf(conf *confmap.Conf, id component.ID, groupIDs []component.ID) (*confmap.Conf, error) {
+	sub, err := conf.Sub(id.String())
+	if err != nil || len(groupIDs) == 0 {
+		return sub, err
+	}
+	merged := confmap.New()
+	for _, groupID := range groupIDs {
+		if !id.HasPrefix(groupID) {
+			continue
+		}
+		groupSub, err := c.groups.Sub(groupID.String())
+		if err != nil {
+			return nil, fmt.Errorf("group %q: %w", groupID, err)
+		}
+		if err = merged.Merge(groupSub); err != nil {
+			return nil, fmt.Errorf("group %q: %w", groupID, err)
+		}
+	}
+	if err = merged.Merge(sub); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
 func (c *Configs[F]) Configs() map[component.ID]component.Config {
 	return c.cfgs
 }
@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 )
 
@@ -154,3 +155,90 @@ func TestUnmarshal_LoggingExporter(t *testing.T) {
 	err := cfgs.Unmarshal(conf)
 	assert.ErrorContains(t, err, "the logging exporter has been deprecated, use the debug exporter instead")
 }
+
+type groupedConfig struct {
+	Endpoint string            `mapstructure:"endpoint"`
+	Timeout  int               `mapstructure:"timeout"`
+	Headers  map[string]string `mapstructure:"headers"`
+}
+
+func TestUnmarshalGroups(t *testing.T) {
+	factories := map[component.Type]receiver.Factory{
+		nopType: receiver.NewFactory(nopType, func() component.Config {
+			return &groupedConfig{Timeout: 5}
+		}),
+	}
+	cfgs := NewConfigs(factories)
+	cfgs.SetGroups(confmap.NewFromStringMap(map[string]any{
+		"nop/team-a/prod": map[string]any{"timeout": 30},
+		"nop":             map[string]any{"headers": map[string]any{"tenant": "all", "region": "eu"}},
+		"nop/team-a":      map[string]any{"endpoint": "team-a:4317", "headers": map[string]any{"tenant": "team-a"}},
+	}))
+	conf := confmap.NewFromStringMap(map[string]any{
+		"nop":               nil,
+		"nop/team-a/prod":   map[string]any{"headers": map[string]any{"env": "prod"}},
+		"nop/team-a/dev":    nil,
+		"nop/team-ab":       nil,
+		"nop/team-a/prod/2": map[string]any{"endpoint": "other:4317"},
+	})
+	require.NoError(t, cfgs.Unmarshal(conf))
+
+	// The groups are applied from the least to the most specific, then the component configuration.
+	assert.Equal(t, map[component.ID]component.Config{
+		component.NewID(nopType): &groupedConfig{
+			Timeout: 5,
+			Headers: map[string]string{"tenant": "all", "region": "eu"},
+		},
+		component.MustNewIDWithName("nop", "team-a/prod"): &groupedConfig{
+			Endpoint: "team-a:4317",
+			Timeout:  30,
+			Headers:  map[string]string{"tenant": "team-a", "region": "eu", "env": "prod"},
+		},
+		component.MustNewIDWithName("nop", "team-a/dev"): &groupedConfig{
+			Endpoint: "team-a:4317",
+			Timeout:  5,
+			Headers:  map[string]string{"tenant": "team-a", "region": "eu"},
+		},
+		component.MustNewIDWithName("nop", "team-ab"): &groupedConfig{
+			Timeout: 5,
+			Headers: map[string]string{"tenant": "all", "region": "eu"},
+		},
+		component.MustNewIDWithName("nop", "team-a/prod/2"): &groupedConfig{
+			Endpoint: "other:4317",
+			Timeout:  30,
+			Headers:  map[string]string{"tenant": "team-a", "region": "eu"},
+		},
+	}, cfgs.Configs())
+}
+
+func TestUnmarshalGroupsError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		groups      map[string]any
+		expectedErr string
+	}{
+		{
+			name:        "unknown-type",
+			groups:      map[string]any{"unknown/team-a": nil},
+			expectedErr: "unknown type: \"unknown\" for id: \"unknown/team-a\" (valid values: [nop])",
+		},
+		{
+			name:        "unused",
+			groups:      map[string]any{"nop/team-b": nil},
+			expectedErr: "group \"nop/team-b\" does not match any component",
+		},
+		{
+			name:        "invalid-group-config",
+			groups:      map[string]any{"nop/team-a": map[string]any{"unknown": true}},
+			expectedErr: "error reading configuration for \"nop/team-a/prod\": decoding failed due to the following error(s):\n\n'' has invalid keys: unknown",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgs := NewConfigs(map[component.Type]receiver.Factory{nopType: receivertest.NewNopFactory()})
+			cfgs.SetGroups(confmap.NewFromStringMap(tt.groups))
+			err := cfgs.Unmarshal(confmap.NewFromStringMap(map[string]any{"nop/team-a/prod": nil}))
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package moduleversion finds the Go module providing a component from the build information
+// embedded in the binary.
+package moduleversion // import "go.opentelemetry.io/collector/otelcol/internal/moduleversion"
+
+import (
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// develVersion is the version reported by Go for the main module, also used for modules replaced
+// by a local directory as their version doesn't identify the code built into the binary.
+const develVersion = "(devel)"
+
+var readBuildInfo = sync.OnceValues(debug.ReadBuildInfo)
+
+// Lookup returns the path and version of the Go module providing the component created by the factory.
+// The module is found from the package of the default configuration of the component, as the type of
+// the factory usually belongs to the package of the component kind. Empty strings are returned if the
+// module cannot be found.
+func Lookup(factory component.Factory) (path, version string) {
+	bi, ok := readBuildInfo()
+	if !ok {
+		return "", ""
+	}
+	t := reflect.TypeOf(factory.CreateDefaultConfig())
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil {
+		return "", ""
+	}
+	return lookup(t.PkgPath(), bi)
+}
+
+// lookup returns the module with the longest path containing the package.
+func lookup(pkgPath string, bi *debug.BuildInfo) (path, version string) {
+	if pkgPath == "" {
+		return "", ""
+	}
+	for _, mod := range append([]*debug.Module{&bi.Main}, bi.Deps...) {
+		if len(mod.Path) <= len(path) || (pkgPath != mod.Path && !strings.HasPrefix(pkgPath, mod.Path+"/")) {
+			continue
+		}
+		path, version = mod.Path, mod.Version
+		if mod.Replace != nil {
+			version = mod.Replace.Version
+			if version == "" {
+				version = develVersion
+			}
+		}
+	}
+	return path, version
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package moduleversion
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+func TestLookup(t *testing.T) {
+	bi := &debug.BuildInfo{
+		Main: debug.Module{Path: "example.com/otelcol", Version: develVersion},
+		Deps: []*debug.Module{
+			{Path: "go.opentelemetry.io/collector/receiver", Version: "v1.43.0"},
+			{Path: "go.opentelemetry.io/collector/receiver/otlpreceiver", Version: "v0.137.0"},
+			{Path: "go.opentelemetry.io/collector/exporter/otlpexporter", Version: "v0.137.0", Replace: &debug.Module{Path: "../otlpexporter"}},
+			{Path: "go.opentelemetry.io/collector/exporter/debugexporter", Version: "v0.137.0", Replace: &debug.Module{Path: "example.com/fork/debugexporter", Version: "v0.1.0"}},
+		},
+	}
+	for _, tt := range []struct {
+		pkgPath string
+		path    string
+		version string
+	}{
+		{pkgPath: "go.opentelemetry.io/collector/receiver/otlpreceiver", path: "go.opentelemetry.io/collector/receiver/otlpreceiver", version: "v0.137.0"},
+		{pkgPath: "go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metadata", path: "go.opentelemetry.io/collector/receiver/otlpreceiver", version: "v0.137.0"},
+		{pkgPath: "go.opentelemetry.io/collector/receiver/receiverhelper", path: "go.opentelemetry.io/collector/receiver", version: "v1.43.0"},
+		{pkgPath: "go.opentelemetry.io/collector/receiver/otlpreceiverx", path: "go.opentelemetry.io/collector/receiver", version: "v1.43.0"},
+		{pkgPath: "go.opentelemetry.io/collector/exporter/otlpexporter", path: "go.opentelemetry.io/collector/exporter/otlpexporter", version: develVersion},
+		{pkgPath: "go.opentelemetry.io/collector/exporter/debugexporter", path: "go.opentelemetry.io/collector/exporter/debugexporter", version: "v0.1.0"},
+		{pkgPath: "example.com/otelcol/internal/myreceiver", path: "example.com/otelcol", version: develVersion},
+		{pkgPath: "example.com/unknown"},
+		{pkgPath: ""},
+	} {
+		t.Run(tt.pkgPath, func(t *testing.T) {
+			path, version := lookup(tt.pkgPath, bi)
+			assert.Equal(t, tt.path, path)
+			assert.Equal(t, tt.version, version)
+		})
+	}
+}
+
+func TestLookupFactory(t *testing.T) {
+	path, version := Lookup(receivertest.NewNopFactory())
+	assert.Equal(t, "go.opentelemetry.io/collector/receiver/receivertest", path)
+	assert.NotEmpty(t, version)
+
+	// A nil configuration doesn't identify the module of the component.
+	path, version = Lookup(nilConfigFactory{})
+	assert.Empty(t, path)
+	assert.Empty(t, version)
+}
+
+type nilConfigFactory struct{}
+
+func (nilConfigFactory) Type() component.Type {
+	return component.MustNewType("nil")
+}
+
+func (nilConfigFactory) CreateDefaultConfig() component.Config {
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pluginrpc implements the protocol between the collector and the processes of the components
+// it loads as plugins.
+//
+// The collector runs the plugin with the ProtocolEnv environment variable set to ProtocolVersion.
+// Without IDEnv, the plugin writes the JSON Description of its component on its standard output, and
+// exits. With ValidateEnv, it reads the configuration of the component as a JSON object on its standard
+// input, writes the error of its validation, if any, on its standard output, and exits. Otherwise, it reads the configuration of the component as a JSON object on its standard input,
+// creates the component with the ID in IDEnv for the signal in SignalEnv, starts it, and writes Ready
+// followed by a newline on its standard output. It shuts the component down, and exits, once its
+// standard input is closed.
+//
+// The telemetry flows between them as OTLP over gRPC, on unix sockets: the plugin serves its processor
+// or exporter at the path in ListenEnv, and sends the telemetry of its receiver or processor to the
+// collector at the path in NextEnv.
+package pluginrpc // import "go.opentelemetry.io/collector/otelcol/internal/pluginrpc"
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+const (
+	// ProtocolEnv is the environment variable holding the version of the protocol used by the collector.
+	ProtocolEnv = "OTELCOL_PLUGIN_PROTOCOL"
+	// ProtocolVersion is the version of the protocol.
+	ProtocolVersion = "1"
+	// ValidateEnv is the environment variable set to validate the configuration of the component.
+	ValidateEnv = "OTELCOL_PLUGIN_VALIDATE"
+	// IDEnv is the environment variable holding the ID of the component to run.
+	IDEnv = "OTELCOL_PLUGIN_ID"
+	// SignalEnv is the environment variable holding the signal of the component to run.
+	SignalEnv = "OTELCOL_PLUGIN_SIGNAL"
+	// ListenEnv is the environment variable holding the path of the socket where the plugin serves
+	// its processor or exporter.
+	ListenEnv = "OTELCOL_PLUGIN_LISTEN"
+	// NextEnv is the environment variable holding the path of the socket where the collector receives
+	// the telemetry of the receiver or processor of the plugin.
+	NextEnv = "OTELCOL_PLUGIN_NEXT"
+	// Ready is written by the plugin once its component started.
+	Ready = "ready"
+)
+
+// Description describes the component of a plugin.
+type Description struct {
+	// Type is the type of the component.
+	Type string `json:"type"`
+	// Kind is the kind of the component: receiver, processor or exporter.
+	Kind string `json:"kind"`
+	// Stability maps the signals supported by the component to their stability level.
+	Stability map[string]string `json:"stability"`
+}
+
+// NewServer returns a gRPC server passing the telemetry it receives to the non-nil consumers.
+func NewServer(traces consumer.Traces, metrics consumer.Metrics, logs consumer.Logs) *grpc.Server {
+	// The peers are processes of the same collector, the size of the messages is not limited.
+	server := grpc.NewServer(grpc.MaxRecvMsgSize(math.MaxInt32))
+	if traces != nil {
+		ptraceotlp.RegisterGRPCServer(server, &tracesServer{next: traces})
+	}
+	if metrics != nil {
+		pmetricotlp.RegisterGRPCServer(server, &metricsServer{next: metrics})
+	}
+	if logs != nil {
+		plogotlp.RegisterGRPCServer(server, &logsServer{next: logs})
+	}
+	return server
+}
+
+// Dial returns a connection to the server listening on the socket at the given path.
+func Dial(path string) (*grpc.ClientConn, error) {
+	return grpc.NewClient("unix:"+path,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallSendMsgSize(math.MaxInt32)))
+}
+
+// NewTraces returns a consumer sending the traces to the server of the connection.
+func NewTraces(conn *grpc.ClientConn) consumer.Traces {
+	client := ptraceotlp.NewGRPCClient(conn)
+	traces, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		_, err := client.Export(ctx, ptraceotlp.NewExportRequestFromTraces(td))
+		return fromStatus(err)
+	})
+	return traces
+}
+
+// NewMetrics returns a consumer sending the metrics to the server of the connection.
+func NewMetrics(conn *grpc.ClientConn) consumer.Metrics {
+	client := pmetricotlp.NewGRPCClient(conn)
+	metrics, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		_, err := client.Export(ctx, pmetricotlp.NewExportRequestFromMetrics(md))
+		return fromStatus(err)
+	})
+	return metrics
+}
+
+// NewLogs returns a consumer sending the logs to the server of the connection.
+func NewLogs(conn *grpc.ClientConn) consumer.Logs {
+	client := plogotlp.NewGRPCClient(conn)
+	logs, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		_, err := client.Export(ctx, plogotlp.NewExportRequestFromLogs(ld))
+		return fromStatus(err)
+	})
+	return logs
+}
+
+type tracesServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	next consumer.Traces
+}
+
+func (s *tracesServer) Export(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	return ptraceotlp.NewExportResponse(), toStatus(s.next.ConsumeTraces(ctx, req.Traces()))
+}
+
+type metricsServer struct {
+	pmetricotlp.UnimplementedGRPCServer
+	next consumer.Metrics
+}
+
+func (s *metricsServer) Export(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	return pmetricotlp.NewExportResponse(), toStatus(s.next.ConsumeMetrics(ctx, req.Metrics()))
+}
+
+type logsServer struct {
+	plogotlp.UnimplementedGRPCServer
+	next consumer.Logs
+}
+
+func (s *logsServer) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
+	return plogotlp.NewExportResponse(), toStatus(s.next.ConsumeLogs(ctx, req.Logs()))
+}
+
+// toStatus converts the error of a consumer to a gRPC status, keeping whether it is permanent.
+func toStatus(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case consumererror.IsPermanent(err):
+		// The error is made permanent again by the client.
+		return status.Error(codes.InvalidArgument, strings.TrimPrefix(err.Error(), "Permanent error: "))
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
+
+// fromStatus converts a gRPC status back to the error of a consumer.
+func fromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	if st.Code() == codes.InvalidArgument {
+		return consumererror.NewPermanent(errors.New(st.Message()))
+	}
+	return errors.New(st.Message())
+}
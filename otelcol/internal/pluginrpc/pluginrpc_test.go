@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pluginrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestServerAndConsumers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plugin.sock")
+	lis, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	var receivedTraces []ptrace.Traces
+	traces, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		receivedTraces = append(receivedTraces, td)
+		return nil
+	})
+	require.NoError(t, err)
+	var receivedMetrics []pmetric.Metrics
+	metrics, err := consumer.NewMetrics(func(_ context.Context, md pmetric.Metrics) error {
+		receivedMetrics = append(receivedMetrics, md)
+		return nil
+	})
+	require.NoError(t, err)
+	logs, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		return consumererror.NewPermanent(errors.New("rejected"))
+	})
+	require.NoError(t, err)
+	server := NewServer(traces, metrics, logs)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	conn, err := Dial(path)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, NewTraces(conn).ConsumeTraces(context.Background(), td))
+	require.Len(t, receivedTraces, 1)
+	assert.Equal(t, td, receivedTraces[0])
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	require.NoError(t, NewMetrics(conn).ConsumeMetrics(context.Background(), md))
+	require.Len(t, receivedMetrics, 1)
+	assert.Equal(t, md, receivedMetrics[0])
+
+	err = NewLogs(conn).ConsumeLogs(context.Background(), plog.NewLogs())
+	require.EqualError(t, err, "Permanent error: rejected")
+	assert.True(t, consumererror.IsPermanent(err))
+}
+
+func TestConsumerUnavailable(t *testing.T) {
+	conn, err := Dial(filepath.Join(t.TempDir(), "missing.sock"))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	err = NewTraces(conn).ConsumeTraces(context.Background(), ptrace.NewTraces())
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+}
@@ -0,0 +1,319 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package plugin runs a receiver, a processor or an exporter as a plugin of the collector: a separate
+// program, loaded by the collector with its --plugin flag, so that the component is added to a
+// collector without rebuilding it.
+//
+// The main function of the plugin passes the factory of its component to Serve:
+//
+//	func main() {
+//		plugin.Serve(myreceiver.NewFactory())
+//	}
+//
+// The collector runs a process of the plugin for each instance of the component, and exchanges the
+// telemetry with it as OTLP. The component can neither access the extensions of the collector, nor
+// report its telemetry, other than its logs written on the standard error.
+package plugin // import "go.opentelemetry.io/collector/otelcol/plugin"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol/internal/pluginrpc"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// Serve runs the component created by the factory, a receiver.Factory, a processor.Factory or an
+// exporter.Factory, as a plugin of the collector. It returns once the collector stops the component,
+// and exits the process on failure, or when it is not run by a collector.
+func Serve(factory component.Factory) {
+	if err := serve(factory, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func serve(factory component.Factory, stdin io.Reader, stdout io.Writer) error {
+	switch version := os.Getenv(pluginrpc.ProtocolEnv); version {
+	case pluginrpc.ProtocolVersion:
+	case "":
+		return errors.New("this program is a plugin of the OpenTelemetry Collector, loaded with its --plugin flag")
+	default:
+		return fmt.Errorf("unsupported version %q of the plugin protocol, expected %q", version, pluginrpc.ProtocolVersion)
+	}
+
+	desc, err := describe(factory)
+	if err != nil {
+		return err
+	}
+	// The configuration is followed by the standard input staying open until the collector stops the plugin.
+	decoder := json.NewDecoder(stdin)
+	decoder.UseNumber()
+	if os.Getenv(pluginrpc.ValidateEnv) != "" {
+		if _, err = readConfig(factory, decoder); err != nil {
+			_, err = fmt.Fprintln(stdout, err)
+		}
+		return err
+	}
+	if os.Getenv(pluginrpc.IDEnv) == "" {
+		return json.NewEncoder(stdout).Encode(desc)
+	}
+
+	var id component.ID
+	if err = id.UnmarshalText([]byte(os.Getenv(pluginrpc.IDEnv))); err != nil {
+		return err
+	}
+	sig, err := parseSignal(os.Getenv(pluginrpc.SignalEnv))
+	if err != nil {
+		return err
+	}
+
+	cfg, err := readConfig(factory, decoder)
+	if err != nil {
+		return fmt.Errorf("invalid configuration of %q: %w", id, err)
+	}
+
+	logger, err := zap.NewProduction()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = logger.Sync() }()
+	set := component.TelemetrySettings{
+		Logger: logger.With(
+			zap.String("otelcol.component.id", id.String()),
+			zap.String("otelcol.component.kind", desc.Kind),
+			zap.String("otelcol.signal", sig.String())),
+		TracerProvider: nooptrace.NewTracerProvider(),
+		MeterProvider:  noopmetric.NewMeterProvider(),
+		Resource:       pcommon.NewResource(),
+	}
+
+	ctx := context.Background()
+	comp, server, conn, err := create(ctx, factory, id, sig, set, cfg)
+	if conn != nil {
+		defer conn.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create %q: %w", id, err)
+	}
+
+	h := &host{fatal: make(chan error, 1)}
+	if err = comp.Start(ctx, h); err != nil {
+		return fmt.Errorf("failed to start %q: %w", id, err)
+	}
+	if server != nil {
+		var lis net.Listener
+		if lis, err = net.Listen("unix", os.Getenv(pluginrpc.ListenEnv)); err != nil {
+			return errors.Join(err, comp.Shutdown(ctx))
+		}
+		go func() { _ = server.Serve(lis) }()
+	}
+	if _, err = fmt.Fprintln(stdout, pluginrpc.Ready); err != nil {
+		return errors.Join(err, comp.Shutdown(ctx))
+	}
+
+	// The collector shuts the plugins down itself, and sends no interrupt to them.
+	signal.Ignore(os.Interrupt)
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+	closed := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(io.Discard, io.MultiReader(decoder.Buffered(), stdin))
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-sigs:
+	case err = <-h.fatal:
+		err = fmt.Errorf("fatal error in %q: %w", id, err)
+	}
+	if server != nil {
+		server.GracefulStop()
+	}
+	return errors.Join(err, comp.Shutdown(ctx))
+}
+
+// readConfig reads the configuration of the component, and validates it.
+func readConfig(factory component.Factory, decoder *json.Decoder) (component.Config, error) {
+	var raw map[string]any
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to read the configuration: %w", err)
+	}
+	cfg := factory.CreateDefaultConfig()
+	if err := confmap.NewFromStringMap(normalizeNumbers(raw).(map[string]any)).Unmarshal(&cfg); err != nil {
+		return nil, err
+	}
+	if err := xconfmap.Validate(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// describe returns the description of the component of the factory.
+func describe(factory component.Factory) (pluginrpc.Description, error) {
+	desc := pluginrpc.Description{Type: factory.Type().String(), Stability: map[string]string{}}
+	var traces, metrics, logs component.StabilityLevel
+	switch f := factory.(type) {
+	case receiver.Factory:
+		desc.Kind = "receiver"
+		traces, metrics, logs = f.TracesStability(), f.MetricsStability(), f.LogsStability()
+	case processor.Factory:
+		desc.Kind = "processor"
+		traces, metrics, logs = f.TracesStability(), f.MetricsStability(), f.LogsStability()
+	case exporter.Factory:
+		desc.Kind = "exporter"
+		traces, metrics, logs = f.TracesStability(), f.MetricsStability(), f.LogsStability()
+	default:
+		return desc, fmt.Errorf("unsupported factory %T, must be a receiver, a processor or an exporter factory", factory)
+	}
+	for sig, sl := range map[pipeline.Signal]component.StabilityLevel{
+		pipeline.SignalTraces:  traces,
+		pipeline.SignalMetrics: metrics,
+		pipeline.SignalLogs:    logs,
+	} {
+		if sl != component.StabilityLevelUndefined {
+			desc.Stability[sig.String()] = sl.String()
+		}
+	}
+	return desc, nil
+}
+
+// create creates the component, with the gRPC server of processors and exporters, and the connection
+// to the collector of receivers and processors.
+func create(ctx context.Context, factory component.Factory, id component.ID, sig pipeline.Signal,
+	set component.TelemetrySettings, cfg component.Config,
+) (comp component.Component, server *grpc.Server, conn *grpc.ClientConn, err error) {
+	buildInfo := component.NewDefaultBuildInfo()
+	var traces consumer.Traces
+	var metrics consumer.Metrics
+	var logs consumer.Logs
+	if _, ok := factory.(exporter.Factory); !ok {
+		if conn, err = pluginrpc.Dial(os.Getenv(pluginrpc.NextEnv)); err != nil {
+			return nil, nil, nil, err
+		}
+		traces, metrics, logs = pluginrpc.NewTraces(conn), pluginrpc.NewMetrics(conn), pluginrpc.NewLogs(conn)
+	}
+
+	switch f := factory.(type) {
+	case receiver.Factory:
+		rset := receiver.Settings{ID: id, TelemetrySettings: set, BuildInfo: buildInfo}
+		switch sig {
+		case pipeline.SignalTraces:
+			comp, err = f.CreateTraces(ctx, rset, cfg, traces)
+		case pipeline.SignalMetrics:
+			comp, err = f.CreateMetrics(ctx, rset, cfg, metrics)
+		case pipeline.SignalLogs:
+			comp, err = f.CreateLogs(ctx, rset, cfg, logs)
+		}
+		return comp, nil, conn, err
+	case processor.Factory:
+		pset := processor.Settings{ID: id, TelemetrySettings: set, BuildInfo: buildInfo}
+		switch sig {
+		case pipeline.SignalTraces:
+			comp, err = f.CreateTraces(ctx, pset, cfg, traces)
+		case pipeline.SignalMetrics:
+			comp, err = f.CreateMetrics(ctx, pset, cfg, metrics)
+		case pipeline.SignalLogs:
+			comp, err = f.CreateLogs(ctx, pset, cfg, logs)
+		}
+	case exporter.Factory:
+		eset := exporter.Settings{ID: id, TelemetrySettings: set, BuildInfo: buildInfo}
+		switch sig {
+		case pipeline.SignalTraces:
+			comp, err = f.CreateTraces(ctx, eset, cfg)
+		case pipeline.SignalMetrics:
+			comp, err = f.CreateMetrics(ctx, eset, cfg)
+		case pipeline.SignalLogs:
+			comp, err = f.CreateLogs(ctx, eset, cfg)
+		}
+	}
+	if err != nil {
+		return nil, nil, conn, err
+	}
+
+	// The processors and exporters consume the telemetry sent by the collector.
+	switch sig {
+	case pipeline.SignalTraces:
+		server = pluginrpc.NewServer(comp.(consumer.Traces), nil, nil)
+	case pipeline.SignalMetrics:
+		server = pluginrpc.NewServer(nil, comp.(consumer.Metrics), nil)
+	case pipeline.SignalLogs:
+		server = pluginrpc.NewServer(nil, nil, comp.(consumer.Logs))
+	}
+	return comp, server, conn, nil
+}
+
+func parseSignal(s string) (pipeline.Signal, error) {
+	for _, sig := range []pipeline.Signal{pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs} {
+		if sig.String() == s {
+			return sig, nil
+		}
+	}
+	return pipeline.Signal{}, fmt.Errorf("unsupported signal %q", s)
+}
+
+// normalizeNumbers converts the numbers decoded from JSON to integers when they are, and to floats otherwise,
+// as they would be decoded from YAML.
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = normalizeNumbers(e)
+		}
+		return v
+	case []any:
+		for i, e := range v {
+			v[i] = normalizeNumbers(e)
+		}
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return v
+}
+
+// host is the host of the component, which has no extensions, and stops the plugin on fatal errors.
+type host struct {
+	fatal chan error
+}
+
+func (*host) GetExtensions() map[component.ID]component.Component {
+	return nil
+}
+
+func (h *host) Report(ev *componentstatus.Event) {
+	if ev.Status() == componentstatus.StatusFatalError {
+		select {
+		case h.fatal <- ev.Err():
+		default:
+		}
+	}
+}
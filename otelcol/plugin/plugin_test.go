@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/otelcol/internal/pluginrpc"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+func newTestFactory() receiver.Factory {
+	return receiver.NewFactory(component.MustNewType("test"), func() component.Config { return &struct{}{} },
+		receiver.WithTraces(func(context.Context, receiver.Settings, component.Config, consumer.Traces) (receiver.Traces, error) {
+			return nil, nil
+		}, component.StabilityLevelAlpha),
+		receiver.WithLogs(func(context.Context, receiver.Settings, component.Config, consumer.Logs) (receiver.Logs, error) {
+			return nil, nil
+		}, component.StabilityLevelStable))
+}
+
+func TestServeNotRunByCollector(t *testing.T) {
+	t.Setenv(pluginrpc.ProtocolEnv, "")
+	err := serve(newTestFactory(), strings.NewReader(""), &bytes.Buffer{})
+	require.EqualError(t, err, "this program is a plugin of the OpenTelemetry Collector, loaded with its --plugin flag")
+}
+
+func TestServeUnsupportedProtocol(t *testing.T) {
+	t.Setenv(pluginrpc.ProtocolEnv, "0")
+	err := serve(newTestFactory(), strings.NewReader(""), &bytes.Buffer{})
+	require.EqualError(t, err, `unsupported version "0" of the plugin protocol, expected "1"`)
+}
+
+func TestServeDescribe(t *testing.T) {
+	t.Setenv(pluginrpc.ProtocolEnv, pluginrpc.ProtocolVersion)
+	t.Setenv(pluginrpc.IDEnv, "")
+	out := &bytes.Buffer{}
+	require.NoError(t, serve(newTestFactory(), strings.NewReader(""), out))
+
+	var desc pluginrpc.Description
+	require.NoError(t, json.Unmarshal(out.Bytes(), &desc))
+	assert.Equal(t, pluginrpc.Description{
+		Type:      "test",
+		Kind:      "receiver",
+		Stability: map[string]string{"traces": "Alpha", "logs": "Stable"},
+	}, desc)
+}
+
+func TestServeValidate(t *testing.T) {
+	t.Setenv(pluginrpc.ProtocolEnv, pluginrpc.ProtocolVersion)
+	t.Setenv(pluginrpc.ValidateEnv, "true")
+	out := &bytes.Buffer{}
+	require.NoError(t, serve(newTestFactory(), strings.NewReader("{}\n"), out))
+	assert.Empty(t, out.String())
+
+	require.NoError(t, serve(newTestFactory(), strings.NewReader(`{"unknown": 1}`+"\n"), out))
+	assert.Contains(t, out.String(), "has invalid keys: unknown")
+}
+
+func TestServeUnsupportedFactory(t *testing.T) {
+	t.Setenv(pluginrpc.ProtocolEnv, pluginrpc.ProtocolVersion)
+	factory := extension.NewFactory(component.MustNewType("test"), func() component.Config { return nil }, nil, component.StabilityLevelAlpha)
+	err := serve(factory, strings.NewReader(""), &bytes.Buffer{})
+	require.ErrorContains(t, err, "must be a receiver, a processor or an exporter factory")
+}
+
+func TestNormalizeNumbers(t *testing.T) {
+	decoder := json.NewDecoder(strings.NewReader(`{"int": 1, "float": 1.5, "list": [2, {"big": 12345678901}], "null": null}`))
+	decoder.UseNumber()
+	var raw map[string]any
+	require.NoError(t, decoder.Decode(&raw))
+	assert.Equal(t, map[string]any{
+		"int":   1,
+		"float": 1.5,
+		"list":  []any{2, map[string]any{"big": 12345678901}},
+		"null":  nil,
+	}, normalizeNumbers(raw))
+}
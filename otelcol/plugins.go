@@ -0,0 +1,420 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol/internal/pluginrpc"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// pluginRunTimeout is the time given to a plugin to describe its component, or to validate its configuration.
+const pluginRunTimeout = 10 * time.Second
+
+// withPlugins returns the factories, with the ones of the components of the plugins run by the commands.
+// The plugins are described once, the first time the factories are returned.
+func withPlugins(factories func() (Factories, error), commands []func() *exec.Cmd) func() (Factories, error) {
+	describe := sync.OnceValues(func() ([]pluginrpc.Description, error) {
+		descs := make([]pluginrpc.Description, len(commands))
+		for i, newCmd := range commands {
+			var err error
+			if descs[i], err = describePlugin(newCmd); err != nil {
+				return nil, err
+			}
+		}
+		return descs, nil
+	})
+	return func() (Factories, error) {
+		f, err := factories()
+		if err != nil {
+			return f, err
+		}
+		descs, err := describe()
+		if err != nil {
+			return f, err
+		}
+		for i, desc := range descs {
+			if err = addPluginFactory(&f, desc, commands[i]); err != nil {
+				return f, err
+			}
+		}
+		return f, nil
+	}
+}
+
+// describePlugin runs the plugin to get the description of its component.
+func describePlugin(newCmd func() *exec.Cmd) (pluginrpc.Description, error) {
+	var desc pluginrpc.Description
+	cmd := newCmd()
+	out, err := runPlugin(cmd, nil)
+	if err != nil {
+		return desc, fmt.Errorf("failed to describe the plugin %q: %w", cmd.Path, err)
+	}
+	if err = json.Unmarshal(out, &desc); err != nil {
+		return desc, fmt.Errorf("invalid description of the plugin %q: %w", cmd.Path, err)
+	}
+	return desc, nil
+}
+
+// runPlugin runs the plugin until it exits, with the given standard input, and returns its standard output.
+func runPlugin(cmd *exec.Cmd, stdin io.Reader, env ...string) ([]byte, error) {
+	cmd.Env = append(append(cmd.Environ(), pluginrpc.ProtocolEnv+"="+pluginrpc.ProtocolVersion), env...)
+	cmd.Stdin = stdin
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+	timer := time.AfterFunc(pluginRunTimeout, func() {
+		if cmd.Process != nil {
+			_ = cmd.Process.Kill()
+		}
+	})
+	defer timer.Stop()
+	return cmd.Output()
+}
+
+// addPluginFactory adds the factory of the component of the plugin to the factories.
+func addPluginFactory(f *Factories, desc pluginrpc.Description, newCmd func() *exec.Cmd) error {
+	typ, err := component.NewType(desc.Type)
+	if err != nil {
+		return fmt.Errorf("invalid plugin type: %w", err)
+	}
+	stability := map[pipeline.Signal]component.StabilityLevel{}
+	for signal, level := range desc.Stability {
+		var sig pipeline.Signal
+		switch signal {
+		case pipeline.SignalTraces.String():
+			sig = pipeline.SignalTraces
+		case pipeline.SignalMetrics.String():
+			sig = pipeline.SignalMetrics
+		case pipeline.SignalLogs.String():
+			sig = pipeline.SignalLogs
+		default:
+			return fmt.Errorf("plugin %q: unsupported signal %q", typ, signal)
+		}
+		var sl component.StabilityLevel
+		if err = sl.UnmarshalText([]byte(level)); err != nil {
+			return fmt.Errorf("plugin %q: %w", typ, err)
+		}
+		stability[sig] = sl
+	}
+
+	newComponent := func(set component.TelemetrySettings, id component.ID, cfg component.Config, sig pipeline.Signal) *pluginComponent {
+		return &pluginComponent{
+			newCmd: newCmd,
+			id:     id,
+			signal: sig,
+			conf:   cfg.(*pluginConfig).conf,
+			logger: set.Logger,
+			exited: make(chan struct{}),
+		}
+	}
+	createDefaultConfig := func() component.Config { return &pluginConfig{newCmd: newCmd} }
+
+	switch desc.Kind {
+	case "receiver":
+		var opts []receiver.FactoryOption
+		for sig, sl := range stability {
+			switch sig {
+			case pipeline.SignalTraces:
+				opts = append(opts, receiver.WithTraces(func(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+					p := newComponent(set.TelemetrySettings, set.ID, cfg, sig)
+					p.nextTraces = next
+					return p, nil
+				}, sl))
+			case pipeline.SignalMetrics:
+				opts = append(opts, receiver.WithMetrics(func(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+					p := newComponent(set.TelemetrySettings, set.ID, cfg, sig)
+					p.nextMetrics = next
+					return p, nil
+				}, sl))
+			case pipeline.SignalLogs:
+				opts = append(opts, receiver.WithLogs(func(_ context.Context, set receiver.Settings, cfg component.Config, next consumer.Logs) (receiver.Logs, error) {
+					p := newComponent(set.TelemetrySettings, set.ID, cfg, sig)
+					p.nextLogs = next
+					return p, nil
+				}, sl))
+			}
+		}
+		return addFactory(&f.Receivers, receiver.NewFactory(typ, createDefaultConfig, opts...))
+	case "processor":
+		var opts []processor.FactoryOption
+		for sig, sl := range stability {
+			switch sig {
+			case pipeline.SignalTraces:
+				opts = append(opts, processor.WithTraces(func(_ context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+					p := newComponent(set.TelemetrySettings, set.ID, cfg, sig)
+					p.nextTraces, p.serves = next, true
+					return p, nil
+				}, sl))
+			case pipeline.SignalMetrics:
+				opts = append(opts, processor.WithMetrics(func(_ context.Context, set processor.Settings, cfg component.Config, next consumer.Metrics) (processor.Metrics, error) {
+					p := newComponent(set.TelemetrySettings, set.ID, cfg, sig)
+					p.nextMetrics, p.serves = next, true
+					return p, nil
+				}, sl))
+			case pipeline.SignalLogs:
+				opts = append(opts, processor.WithLogs(func(_ context.Context, set processor.Settings, cfg component.Config, next consumer.Logs) (processor.Logs, error) {
+					p := newComponent(set.TelemetrySettings, set.ID, cfg, sig)
+					p.nextLogs, p.serves = next, true
+					return p, nil
+				}, sl))
+			}
+		}
+		return addFactory(&f.Processors, processor.NewFactory(typ, createDefaultConfig, opts...))
+	case "exporter":
+		var opts []exporter.FactoryOption
+		for sig, sl := range stability {
+			switch sig {
+			case pipeline.SignalTraces:
+				opts = append(opts, exporter.WithTraces(func(_ context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+					p := newComponent(set.TelemetrySettings, set.ID, cfg, sig)
+					p.serves = true
+					return p, nil
+				}, sl))
+			case pipeline.SignalMetrics:
+				opts = append(opts, exporter.WithMetrics(func(_ context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+					p := newComponent(set.TelemetrySettings, set.ID, cfg, sig)
+					p.serves = true
+					return p, nil
+				}, sl))
+			case pipeline.SignalLogs:
+				opts = append(opts, exporter.WithLogs(func(_ context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+					p := newComponent(set.TelemetrySettings, set.ID, cfg, sig)
+					p.serves = true
+					return p, nil
+				}, sl))
+			}
+		}
+		return addFactory(&f.Exporters, exporter.NewFactory(typ, createDefaultConfig, opts...))
+	}
+	return fmt.Errorf("plugin %q: unsupported kind %q", typ, desc.Kind)
+}
+
+// addFactory adds the factory of a plugin to the factories of its kind, unless there is one of the same type.
+func addFactory[F component.Factory](factories *map[component.Type]F, factory F) error {
+	if _, ok := (*factories)[factory.Type()]; ok {
+		return fmt.Errorf("duplicate component factory %q of a plugin", factory.Type())
+	}
+	if *factories == nil {
+		*factories = map[component.Type]F{}
+	}
+	(*factories)[factory.Type()] = factory
+	return nil
+}
+
+// pluginConfig is the configuration of a component of a plugin, passed as is to the plugin, which validates it.
+type pluginConfig struct {
+	newCmd func() *exec.Cmd
+	conf   map[string]any
+}
+
+// Validate runs the plugin to validate the configuration.
+func (c *pluginConfig) Validate() error {
+	in, err := json.Marshal(c.conf)
+	if err != nil {
+		return err
+	}
+	cmd := c.newCmd()
+	out, err := runPlugin(cmd, bytes.NewReader(in), pluginrpc.ValidateEnv+"=true")
+	if err != nil {
+		return fmt.Errorf("failed to validate the configuration with the plugin %q: %w", cmd.Path, err)
+	}
+	if msg := strings.TrimSpace(string(out)); msg != "" {
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func (c *pluginConfig) Unmarshal(conf *confmap.Conf) error {
+	c.conf = conf.ToStringMap()
+	return nil
+}
+
+func (c *pluginConfig) Marshal(conf *confmap.Conf) error {
+	return conf.Merge(confmap.NewFromStringMap(c.conf))
+}
+
+// pluginComponent is a component running in a process of its plugin.
+type pluginComponent struct {
+	newCmd func() *exec.Cmd
+	id     component.ID
+	signal pipeline.Signal
+	conf   map[string]any
+	logger *zap.Logger
+
+	// nextTraces, nextMetrics or nextLogs receive the telemetry of receivers and processors.
+	nextTraces  consumer.Traces
+	nextMetrics consumer.Metrics
+	nextLogs    consumer.Logs
+	// serves is whether the plugin serves the telemetry sent to a processor or an exporter.
+	serves bool
+
+	dir     string
+	server  *grpc.Server
+	conn    *grpc.ClientConn
+	traces  consumer.Traces
+	metrics consumer.Metrics
+	logs    consumer.Logs
+
+	cmd      *exec.Cmd
+	stdin    io.WriteCloser
+	ready    bool
+	stopping atomic.Bool
+	exited   chan struct{}
+	exitErr  error
+}
+
+func (p *pluginComponent) Start(ctx context.Context, host component.Host) error {
+	var err error
+	if p.dir, err = os.MkdirTemp("", "otelcol-plugin-"); err != nil {
+		return err
+	}
+	cmd := p.newCmd()
+	cmd.Env = append(cmd.Environ(),
+		pluginrpc.ProtocolEnv+"="+pluginrpc.ProtocolVersion,
+		pluginrpc.IDEnv+"="+p.id.String(),
+		pluginrpc.SignalEnv+"="+p.signal.String())
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+
+	if p.nextTraces != nil || p.nextMetrics != nil || p.nextLogs != nil {
+		path := filepath.Join(p.dir, "next.sock")
+		lis, err := net.Listen("unix", path)
+		if err != nil {
+			return err
+		}
+		p.server = pluginrpc.NewServer(p.nextTraces, p.nextMetrics, p.nextLogs)
+		go func() { _ = p.server.Serve(lis) }()
+		cmd.Env = append(cmd.Env, pluginrpc.NextEnv+"="+path)
+	}
+	if p.serves {
+		path := filepath.Join(p.dir, "plugin.sock")
+		if p.conn, err = pluginrpc.Dial(path); err != nil {
+			return err
+		}
+		p.traces, p.metrics, p.logs = pluginrpc.NewTraces(p.conn), pluginrpc.NewMetrics(p.conn), pluginrpc.NewLogs(p.conn)
+		cmd.Env = append(cmd.Env, pluginrpc.ListenEnv+"="+path)
+	}
+
+	if p.stdin, err = cmd.StdinPipe(); err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err = cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start the plugin of %q: %w", p.id, err)
+	}
+	p.cmd = cmd
+	p.logger.Debug("Plugin started", zap.Int("pid", cmd.Process.Pid))
+
+	ready := make(chan bool, 1)
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		isReady := false
+		for !isReady && scanner.Scan() {
+			isReady = scanner.Text() == pluginrpc.Ready
+		}
+		ready <- isReady
+		_, _ = io.Copy(io.Discard, stdout)
+		p.exitErr = cmd.Wait()
+		close(p.exited)
+		if isReady && !p.stopping.Load() {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(fmt.Errorf("the plugin of %q exited: %w", p.id, p.exitErr)))
+		}
+	}()
+	// The configuration is written while the plugin may already fail, e.g. on an unsupported protocol version.
+	_ = json.NewEncoder(p.stdin).Encode(p.conf)
+
+	select {
+	case isReady := <-ready:
+		if !isReady {
+			<-p.exited
+			return fmt.Errorf("the plugin of %q exited before being ready: %w", p.id, p.exitErr)
+		}
+		p.ready = true
+		return nil
+	case <-ctx.Done():
+		p.stopping.Store(true)
+		_ = cmd.Process.Kill()
+		<-p.exited
+		return ctx.Err()
+	}
+}
+
+func (p *pluginComponent) Shutdown(ctx context.Context) error {
+	p.stopping.Store(true)
+	var err error
+	if p.cmd != nil {
+		// The plugin shuts its component down, and exits, once its standard input is closed.
+		_ = p.stdin.Close()
+		select {
+		case <-p.exited:
+			// The plugin failing to start has already been reported.
+			if p.ready && p.exitErr != nil {
+				err = fmt.Errorf("the plugin of %q failed: %w", p.id, p.exitErr)
+			}
+		case <-ctx.Done():
+			_ = p.cmd.Process.Kill()
+			<-p.exited
+			err = ctx.Err()
+		}
+	}
+	if p.conn != nil {
+		err = errors.Join(err, p.conn.Close())
+	}
+	if p.server != nil {
+		p.server.Stop()
+	}
+	if p.dir != "" {
+		err = errors.Join(err, os.RemoveAll(p.dir))
+	}
+	return err
+}
+
+func (*pluginComponent) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (p *pluginComponent) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return p.traces.ConsumeTraces(ctx, td)
+}
+
+func (p *pluginComponent) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return p.metrics.ConsumeMetrics(ctx, md)
+}
+
+func (p *pluginComponent) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return p.logs.ConsumeLogs(ctx, ld)
+}
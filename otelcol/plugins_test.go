@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/otelcol/plugin"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+const pluginHelperEnv = "OTELCOL_PLUGIN_HELPER"
+
+type pluginTestConfig struct {
+	Value string `mapstructure:"value"`
+}
+
+func (c *pluginTestConfig) Validate() error {
+	if c.Value == "" {
+		return errors.New("value must not be empty")
+	}
+	return nil
+}
+
+// pluginTestHost is the host of the components of the plugins in the tests, which has no extensions.
+type pluginTestHost struct{}
+
+func (pluginTestHost) GetExtensions() map[component.ID]component.Component {
+	return nil
+}
+
+type pluginTestComponent struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+}
+
+// TestPluginHelperProcess is run as a plugin by the plugin tests.
+func TestPluginHelperProcess(*testing.T) {
+	createDefaultConfig := func() component.Config { return &pluginTestConfig{} }
+	switch os.Getenv(pluginHelperEnv) {
+	case "receiver":
+		plugin.Serve(receiver.NewFactory(component.MustNewType("pluginreceiver"), createDefaultConfig,
+			receiver.WithTraces(func(_ context.Context, _ receiver.Settings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+				return &pluginTestComponent{StartFunc: func(ctx context.Context, _ component.Host) error {
+					td := ptrace.NewTraces()
+					td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("value", cfg.(*pluginTestConfig).Value)
+					return next.ConsumeTraces(ctx, td)
+				}}, nil
+			}, component.StabilityLevelAlpha)))
+	case "processor":
+		plugin.Serve(processor.NewFactory(component.MustNewType("pluginprocessor"), createDefaultConfig,
+			processor.WithTraces(func(_ context.Context, _ processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+				traces, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+					td.ResourceSpans().At(0).Resource().Attributes().PutStr("value", cfg.(*pluginTestConfig).Value)
+					return next.ConsumeTraces(ctx, td)
+				})
+				return &pluginTestComponent{Traces: traces}, err
+			}, component.StabilityLevelBeta)))
+	case "exporter":
+		plugin.Serve(exporter.NewFactory(component.MustNewType("pluginexporter"), createDefaultConfig,
+			exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+				traces, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+					return consumererror.NewPermanent(errors.New("rejected"))
+				})
+				return &pluginTestComponent{Traces: traces}, err
+			}, component.StabilityLevelDevelopment)))
+	default:
+		return
+	}
+	os.Exit(0)
+}
+
+func pluginHelperCommand(kind string) func() *exec.Cmd {
+	return func() *exec.Cmd {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestPluginHelperProcess$")
+		cmd.Env = append(os.Environ(), pluginHelperEnv+"="+kind)
+		return cmd
+	}
+}
+
+func loadPluginFactories(t *testing.T, kinds ...string) Factories {
+	commands := make([]func() *exec.Cmd, len(kinds))
+	for i, kind := range kinds {
+		commands[i] = pluginHelperCommand(kind)
+	}
+	factories, err := withPlugins(func() (Factories, error) { return Factories{}, nil }, commands)()
+	require.NoError(t, err)
+	return factories
+}
+
+func pluginTestConfigOf(t *testing.T, factory component.Factory, conf map[string]any) component.Config {
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.NewFromStringMap(conf).Unmarshal(&cfg))
+	return cfg
+}
+
+func TestPluginReceiver(t *testing.T) {
+	factories := loadPluginFactories(t, "receiver")
+	factory := factories.Receivers[component.MustNewType("pluginreceiver")]
+	require.NotNil(t, factory)
+	assert.Equal(t, component.StabilityLevelAlpha, factory.TracesStability())
+	assert.Equal(t, component.StabilityLevelUndefined, factory.LogsStability())
+
+	var received []ptrace.Traces
+	sink, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		received = append(received, td)
+		return nil
+	})
+	require.NoError(t, err)
+	set := receivertest.NewNopSettings(factory.Type())
+	rcv, err := factory.CreateTraces(context.Background(), set, pluginTestConfigOf(t, factory, map[string]any{"value": "a"}), sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), pluginTestHost{}))
+	require.Len(t, received, 1)
+	value, _ := received[0].ResourceSpans().At(0).Resource().Attributes().Get("value")
+	assert.Equal(t, "a", value.Str())
+	require.NoError(t, rcv.Shutdown(context.Background()))
+}
+
+func TestPluginProcessor(t *testing.T) {
+	factories := loadPluginFactories(t, "processor", "exporter")
+	factory := factories.Processors[component.MustNewType("pluginprocessor")]
+	require.NotNil(t, factory)
+	assert.Equal(t, component.StabilityLevelBeta, factory.TracesStability())
+
+	var received []ptrace.Traces
+	sink, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+		received = append(received, td)
+		return nil
+	})
+	require.NoError(t, err)
+	set := processortest.NewNopSettings(factory.Type())
+	proc, err := factory.CreateTraces(context.Background(), set, pluginTestConfigOf(t, factory, map[string]any{"value": "b"}), sink)
+	require.NoError(t, err)
+	require.NoError(t, proc.Start(context.Background(), pluginTestHost{}))
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, proc.ConsumeTraces(context.Background(), td))
+	require.Len(t, received, 1)
+	value, _ := received[0].ResourceSpans().At(0).Resource().Attributes().Get("value")
+	assert.Equal(t, "b", value.Str())
+	assert.Equal(t, "span", received[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	require.NoError(t, proc.Shutdown(context.Background()))
+}
+
+func TestPluginExporterPermanentError(t *testing.T) {
+	factories := loadPluginFactories(t, "exporter")
+	factory := factories.Exporters[component.MustNewType("pluginexporter")]
+	require.NotNil(t, factory)
+
+	set := exportertest.NewNopSettings(factory.Type())
+	exp, err := factory.CreateTraces(context.Background(), set, pluginTestConfigOf(t, factory, map[string]any{"value": "c"}))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), pluginTestHost{}))
+	err = exp.ConsumeTraces(context.Background(), ptrace.NewTraces())
+	require.EqualError(t, err, "Permanent error: rejected")
+	assert.True(t, consumererror.IsPermanent(err))
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestPluginInvalidConfig(t *testing.T) {
+	factories := loadPluginFactories(t, "exporter")
+	factory := factories.Exporters[component.MustNewType("pluginexporter")]
+
+	// The plugin validates the configuration when the collector loads it.
+	cfg := pluginTestConfigOf(t, factory, map[string]any{})
+	require.EqualError(t, xconfmap.Validate(cfg), "value must not be empty")
+	require.NoError(t, xconfmap.Validate(pluginTestConfigOf(t, factory, map[string]any{"value": "e"})))
+
+	set := exportertest.NewNopSettings(factory.Type())
+	exp, err := factory.CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	err = exp.Start(context.Background(), pluginTestHost{})
+	require.ErrorContains(t, err, "exited before being ready: exit status 1")
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestPluginShutdownTimeout(t *testing.T) {
+	factories := loadPluginFactories(t, "exporter")
+	factory := factories.Exporters[component.MustNewType("pluginexporter")]
+
+	set := exportertest.NewNopSettings(factory.Type())
+	exp, err := factory.CreateTraces(context.Background(), set, pluginTestConfigOf(t, factory, map[string]any{"value": "d"}))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), pluginTestHost{}))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	// The plugin is killed, when it does not exit in time.
+	require.ErrorIs(t, exp.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+func TestPluginDuplicateType(t *testing.T) {
+	_, err := withPlugins(func() (Factories, error) { return Factories{}, nil },
+		[]func() *exec.Cmd{pluginHelperCommand("exporter"), pluginHelperCommand("exporter")})()
+	require.EqualError(t, err, `duplicate component factory "pluginexporter" of a plugin`)
+}
+
+func TestPluginDescribeFailure(t *testing.T) {
+	_, err := withPlugins(func() (Factories, error) { return Factories{}, nil },
+		[]func() *exec.Cmd{pluginHelperCommand("none")})()
+	require.ErrorContains(t, err, "invalid description of the plugin")
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// daemonEnv is set in the environment of the collector detached from its parent, so that it does not
+// detach itself again.
+const daemonEnv = "OTELCOL_DAEMON"
+
+// setupProcess sets the umask, given in octal, and the working directory of the process, if not empty.
+func setupProcess(umask, workingDir string) error {
+	if umask != "" {
+		mask, err := strconv.ParseUint(umask, 8, 32)
+		if err != nil || mask > 0o777 {
+			return fmt.Errorf("invalid umask %q: must be an octal number up to 777", umask)
+		}
+		if err = setUmask(int(mask)); err != nil {
+			return err
+		}
+	}
+	if workingDir != "" {
+		// The processes started by the collector with the same flags, e.g. on handoff, change to the same directory.
+		if !filepath.IsAbs(workingDir) {
+			return fmt.Errorf("invalid working directory %q: must be an absolute path", workingDir)
+		}
+		if err := os.Chdir(workingDir); err != nil {
+			return fmt.Errorf("failed to change the working directory: %w", err)
+		}
+	}
+	return nil
+}
+
+// writePIDFile writes the pid of the process to the file, and returns the function removing it, unless
+// another process, e.g. the new process of a handoff, wrote its own pid to it meanwhile.
+func writePIDFile(path string) (func(), error) {
+	pid := []byte(strconv.Itoa(os.Getpid()) + "\n")
+	// The file is renamed, so that it is never read partially written.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, pid, 0o644); err != nil { //nolint:gosec // The pid file is readable by the init scripts.
+		return nil, fmt.Errorf("failed to write the pid file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return nil, fmt.Errorf("failed to write the pid file: %w", err)
+	}
+	return func() {
+		if content, err := os.ReadFile(path); err == nil && bytes.Equal(content, pid) {
+			_ = os.Remove(path)
+		}
+	}, nil
+}
@@ -0,0 +1,16 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !unix
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import "errors"
+
+func setUmask(int) error {
+	return errors.New("the umask cannot be set on this platform")
+}
+
+func daemonize(CollectorSettings) error {
+	return errors.New("the collector cannot be detached on this platform, run it as a service instead")
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupProcess(t *testing.T) {
+	require.NoError(t, setupProcess("", ""))
+	require.EqualError(t, setupProcess("abc", ""), `invalid umask "abc": must be an octal number up to 777`)
+	require.EqualError(t, setupProcess("1777", ""), `invalid umask "1777": must be an octal number up to 777`)
+	require.EqualError(t, setupProcess("", "relative"), `invalid working directory "relative": must be an absolute path`)
+	require.ErrorContains(t, setupProcess("", filepath.Join(t.TempDir(), "missing")), "failed to change the working directory")
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	require.NoError(t, setupProcess("", dir))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, dir, wd)
+}
+
+func TestWritePIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otelcol.pid")
+	remove, err := writePIDFile(path)
+	require.NoError(t, err)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(os.Getpid())+"\n", string(content))
+	remove()
+	assert.NoFileExists(t, path)
+
+	// The pid file written by another process meanwhile is kept.
+	remove, err = writePIDFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("1\n"), 0o600))
+	remove()
+	assert.FileExists(t, path)
+
+	_, err = writePIDFile(filepath.Join(t.TempDir(), "missing", "otelcol.pid"))
+	require.ErrorContains(t, err, "failed to write the pid file")
+}
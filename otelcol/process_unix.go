@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"syscall"
+)
+
+func setUmask(mask int) error {
+	syscall.Umask(mask)
+	return nil
+}
+
+// daemonize starts the collector, with the same executable and arguments, detached from the terminal
+// in a new session, and waits until it is ready. Its standard streams are discarded, except the
+// configuration read from the standard input.
+func daemonize(set CollectorSettings) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the executable: %w", err)
+	}
+	devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer devNull.Close()
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Stdin = devNull
+	if slices.Contains(set.ConfigProviderSettings.ResolverSettings.URIs, stdinScheme+":") {
+		data, stdinErr := stdinConfig.get()
+		if stdinErr != nil {
+			_ = w.Close()
+			return fmt.Errorf("unable to read the standard input: %w", stdinErr)
+		}
+		cmd.Stdin = bytes.NewReader(data)
+	}
+	cmd.Stdout = devNull
+	cmd.Stderr = devNull
+	cmd.ExtraFiles = []*os.File{w}
+	cmd.Env = append(os.Environ(), daemonEnv+"=true", handoffReadyFDEnv+"=3")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	err = cmd.Start()
+	_ = w.Close()
+	if err != nil {
+		return fmt.Errorf("failed to start the collector: %w", err)
+	}
+
+	if err = waitHandoffReady(r, handoffReadyTimeout); err != nil {
+		_ = cmd.Process.Kill()
+		// The collector exiting before being ready is reported with its exit code.
+		var exitErr *exec.ExitError
+		if waitErr := cmd.Wait(); errors.As(waitErr, &exitErr) && exitErr.ExitCode() > 0 {
+			return newExitError(exitErr.ExitCode(), fmt.Errorf("collector failed to start, see its logs: %w", waitErr))
+		}
+		return err
+	}
+	return cmd.Process.Release()
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+	"go.opentelemetry.io/collector/service/sandbox"
+)
+
+// validateSandbox checks that the collector can still hand off its listening sockets once sandboxed,
+// which runs a new process of the collector.
+func (col *Collector) validateSandbox(cfg *Config) error {
+	if cfg.Service.Sandbox.Enabled && !cfg.Service.Sandbox.AllowExec && col.handsOffSockets() {
+		return errors.New("service::sandbox::allow_exec: must be set along with the sandbox, since the handoff of the listening sockets runs a new process of the collector")
+	}
+	return nil
+}
+
+// handsOffSockets returns whether the collector itself hands off its listening sockets to a new process
+// on the handoff signals, rather than its supervisor.
+func (col *Collector) handsOffSockets() bool {
+	return len(handoffSignals) > 0 && !col.set.DisableGracefulShutdown && os.Getenv(supervisedEnv) == ""
+}
+
+// sandboxConfig returns the sandbox configuration of the service, with the paths the collector uses
+// once sandboxed added to the configured ones: the directories of the configuration files, read again
+// on reload, the directories of the files written by the extensions, e.g. the remote configuration of
+// the OpAMP extension, and the executable of the collector, run on handoff.
+func (col *Collector) sandboxConfig(cfg *Config) sandbox.Config {
+	sb := cfg.Service.Sandbox
+	if !sb.Enabled {
+		return sb
+	}
+	readOnly, readWrite := slices.Clone(sb.ReadOnlyPaths), slices.Clone(sb.ReadWritePaths)
+	addPath := func(paths []string, path string) []string {
+		if path, err := filepath.Abs(path); err == nil && !slices.Contains(paths, path) {
+			return append(paths, path)
+		}
+		return paths
+	}
+
+	for _, uri := range col.set.ConfigProviderSettings.ResolverSettings.URIs {
+		if path, ok := configFilePath(uri); ok {
+			// The directory is allowed, since a file replaced by a rename, e.g. by an editor or a
+			// Kubernetes ConfigMap, is a new file.
+			readOnly = addPath(readOnly, filepath.Dir(path))
+		}
+	}
+	for _, id := range cfg.Service.Extensions {
+		if writer, ok := cfg.Extensions[id].(extensioncapabilities.FileWriter); ok {
+			for _, path := range writer.WrittenFiles() {
+				readWrite = addPath(readWrite, filepath.Dir(path))
+			}
+		}
+	}
+	if sb.AllowExec && col.handsOffSockets() {
+		if exe, err := os.Executable(); err == nil {
+			readOnly = addPath(readOnly, exe)
+		}
+	}
+
+	sb.ReadOnlyPaths, sb.ReadWritePaths = readOnly, readWrite
+	return sb
+}
+
+// configFilePath returns the path of the configuration file read by the file provider from the URI.
+func configFilePath(uri string) (string, bool) {
+	// The resolver retrieves the locations without scheme with the file provider.
+	if driveLetterRegexp.MatchString(uri) || !strings.Contains(uri, ":") {
+		return uri, true
+	}
+	if path, ok := strings.CutPrefix(uri, "file:"); ok && path != "" {
+		return path, true
+	}
+	return "", false
+}
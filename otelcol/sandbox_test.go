@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/sandbox"
+)
+
+type fileWriterConfig []string
+
+func (cfg fileWriterConfig) WrittenFiles() []string {
+	return cfg
+}
+
+func newSandboxConfig(sb sandbox.Config) *Config {
+	return &Config{
+		Extensions: map[component.ID]component.Config{
+			component.MustNewID("writer"):  fileWriterConfig{"/var/lib/otelcol/remote.yaml"},
+			component.MustNewID("unused"):  fileWriterConfig{"/var/lib/unused/file"},
+			component.MustNewID("nowrite"): struct{}{},
+		},
+		Service: service.Config{
+			Extensions: []component.ID{component.MustNewID("writer"), component.MustNewID("nowrite")},
+			Sandbox:    sb,
+		},
+	}
+}
+
+func TestValidateSandbox(t *testing.T) {
+	col := &Collector{}
+	require.NoError(t, col.validateSandbox(newSandboxConfig(sandbox.Config{})))
+	require.NoError(t, col.validateSandbox(newSandboxConfig(sandbox.Config{Enabled: true, AllowExec: true})))
+
+	err := col.validateSandbox(newSandboxConfig(sandbox.Config{Enabled: true}))
+	if len(handoffSignals) == 0 {
+		require.NoError(t, err)
+		return
+	}
+	require.EqualError(t, err, "service::sandbox::allow_exec: must be set along with the sandbox, since the handoff of the listening sockets runs a new process of the collector")
+
+	// Nothing is handed off without graceful shutdown, and the supervisor hands off the sockets instead.
+	col.set.DisableGracefulShutdown = true
+	require.NoError(t, col.validateSandbox(newSandboxConfig(sandbox.Config{Enabled: true})))
+	col.set.DisableGracefulShutdown = false
+	t.Setenv(supervisedEnv, "1")
+	require.NoError(t, col.validateSandbox(newSandboxConfig(sandbox.Config{Enabled: true})))
+}
+
+func TestSandboxConfig(t *testing.T) {
+	dir := t.TempDir()
+	col := &Collector{set: CollectorSettings{DisableGracefulShutdown: true}}
+	col.set.ConfigProviderSettings.ResolverSettings.URIs = []string{
+		filepath.Join(dir, "config.yaml"),
+		"file:" + filepath.Join(dir, "extra.yaml"),
+		"file:/etc/otelcol/config.yaml",
+		"opamp:/var/lib/otelcol/remote.yaml",
+		"env:OTELCOL_CONFIG",
+	}
+
+	assert.Equal(t, sandbox.Config{}, col.sandboxConfig(newSandboxConfig(sandbox.Config{})))
+
+	cfg := newSandboxConfig(sandbox.Config{
+		Enabled:        true,
+		ReadOnlyPaths:  []string{"/etc/otelcol"},
+		ReadWritePaths: []string{"/var/lib/storage"},
+	})
+	sb := col.sandboxConfig(cfg)
+	assert.Equal(t, []string{"/etc/otelcol", dir}, sb.ReadOnlyPaths)
+	assert.Equal(t, []string{"/var/lib/storage", "/var/lib/otelcol"}, sb.ReadWritePaths)
+	// The configuration of the service is left unchanged.
+	assert.Equal(t, []string{"/etc/otelcol"}, cfg.Service.Sandbox.ReadOnlyPaths)
+
+	if len(handoffSignals) > 0 {
+		col.set.DisableGracefulShutdown = false
+		cfg.Service.Sandbox.AllowExec = true
+		exe, err := os.Executable()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/etc/otelcol", dir, exe}, col.sandboxConfig(cfg).ReadOnlyPaths)
+	}
+}
+
+func TestConfigFilePath(t *testing.T) {
+	for _, tt := range []struct {
+		uri  string
+		path string
+		ok   bool
+	}{
+		{uri: "config.yaml", path: "config.yaml", ok: true},
+		{uri: "file:/etc/otelcol/config.yaml", path: "/etc/otelcol/config.yaml", ok: true},
+		{uri: `C:\otelcol\config.yaml`, path: `C:\otelcol\config.yaml`, ok: true},
+		{uri: "file:"},
+		{uri: "env:OTELCOL_CONFIG"},
+		{uri: "opamp:/var/lib/otelcol/remote.yaml"},
+	} {
+		t.Run(tt.uri, func(t *testing.T) {
+			path, ok := configFilePath(tt.uri)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.path, path)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// appendIndex is the index of a path segment appending to a list, written `[+]`.
+const appendIndex = -1
+
+// setSegment is a segment of the path of a --set flag, either a map key or a list index.
+type setSegment struct {
+	key   string
+	index int
+	list  bool
+}
+
+// setListOp is a --set flag setting an element of a list, e.g. `service.pipelines.traces.receivers[+]=otlp`.
+type setListOp struct {
+	flag  string
+	path  []setSegment
+	value any
+}
+
+// parseSetFlag parses the value of a --set flag. It returns the URI of the yaml provider merging the
+// value into the configuration, or the operation setting the value in a list when the path has indices.
+func parseSetFlag(s string) (string, *setListOp, error) {
+	idx := strings.Index(s, "=")
+	if idx == -1 {
+		// No need for more context, see TestSetFlag/invalid_set.
+		return "", nil, errors.New("missing equal sign")
+	}
+	key, value := strings.TrimSpace(s[:idx]), strings.TrimSpace(s[idx+1:])
+	if !strings.Contains(key, "[") {
+		return "yaml:" + strings.ReplaceAll(key, ".", confmap.KeyDelimiter) + ": " + value, nil, nil
+	}
+
+	path, err := parseSetPath(key)
+	if err != nil {
+		return "", nil, err
+	}
+	op := &setListOp{flag: key, path: path}
+	// The value is typed as in a YAML document, so that `30s` is a string decoded as a duration, and `true` a boolean.
+	if err = yaml.Unmarshal([]byte(value), &op.value); err != nil {
+		return "", nil, fmt.Errorf("invalid value: %w", err)
+	}
+	return "", op, nil
+}
+
+// parseSetPath parses a path made of keys separated by dots, each followed by list indices, e.g. `a.b[0][+].c`.
+func parseSetPath(key string) ([]setSegment, error) {
+	var path []setSegment
+	for part := range strings.SplitSeq(key, ".") {
+		name, indices, _ := strings.Cut(part, "[")
+		if name == "" {
+			return nil, fmt.Errorf("invalid key %q: empty map key", key)
+		}
+		path = append(path, setSegment{key: name})
+		if indices == "" {
+			continue
+		}
+		for index := range strings.SplitSeq("["+indices, "]") {
+			if index == "" {
+				continue
+			}
+			if !strings.HasPrefix(index, "[") {
+				return nil, fmt.Errorf("invalid key %q: unexpected %q after a list index", key, index)
+			}
+			index = index[1:]
+			if index == "+" {
+				path = append(path, setSegment{index: appendIndex, list: true})
+				continue
+			}
+			i, err := strconv.Atoi(index)
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("invalid key %q: list index %q is neither a non-negative integer nor +", key, index)
+			}
+			path = append(path, setSegment{index: i, list: true})
+		}
+		if !strings.HasSuffix(part, "]") {
+			return nil, fmt.Errorf("invalid key %q: unclosed list index", key)
+		}
+	}
+	return path, nil
+}
+
+// setListConverter applies the --set flags setting elements of lists, in order, once the
+// configuration is resolved.
+type setListConverter struct {
+	ops []*setListOp
+}
+
+func (c setListConverter) Convert(_ context.Context, conf *confmap.Conf) error {
+	for _, op := range c.ops {
+		// The keys before the first list index are the key of the list in the configuration.
+		first := slices.IndexFunc(op.path, func(s setSegment) bool { return s.list })
+		keys := make([]string, first)
+		for i, s := range op.path[:first] {
+			keys[i] = s.key
+		}
+		listKey := strings.Join(keys, confmap.KeyDelimiter)
+
+		list, err := setPath(conf.Get(listKey), op.path[first:], op.value)
+		if err != nil {
+			return fmt.Errorf("cannot set %q: %w", op.flag, err)
+		}
+		// The list is replaced, rather than merged, since merging lists may append to them.
+		conf.Delete(listKey)
+		update := list
+		for i := len(keys) - 1; i >= 0; i-- {
+			update = map[string]any{keys[i]: update}
+		}
+		if err = conf.Merge(confmap.NewFromStringMap(update.(map[string]any))); err != nil {
+			return fmt.Errorf("cannot set %q: %w", op.flag, err)
+		}
+	}
+	return nil
+}
+
+// setPath sets the value at the path in the given value, and returns it.
+func setPath(current any, path []setSegment, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	seg := path[0]
+	if !seg.list {
+		m, ok := current.(map[string]any)
+		if current != nil && !ok {
+			return nil, fmt.Errorf("%q is not in a map", seg.key)
+		}
+		m = maps.Clone(m)
+		if m == nil {
+			m = map[string]any{}
+		}
+		v, err := setPath(m[seg.key], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		m[seg.key] = v
+		return m, nil
+	}
+
+	list, ok := current.([]any)
+	if current != nil && !ok {
+		return nil, errors.New("not a list")
+	}
+	list = slices.Clone(list)
+	if seg.index == appendIndex {
+		v, err := setPath(nil, path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		return append(list, v), nil
+	}
+	if seg.index >= len(list) {
+		return nil, fmt.Errorf("index %d out of range of a list of %d elements", seg.index, len(list))
+	}
+	v, err := setPath(list[seg.index], path[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	list[seg.index] = v
+	return list, nil
+}
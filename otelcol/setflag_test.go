@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func TestParseSetFlag(t *testing.T) {
+	tests := []struct {
+		name        string
+		flag        string
+		expectedURI string
+		expectedOp  *setListOp
+		expectedErr string
+	}{
+		{
+			name:        "map key",
+			flag:        "exporters.otlp.timeout=30s",
+			expectedURI: "yaml:exporters::otlp::timeout: 30s",
+		},
+		{
+			name: "append",
+			flag: "service.pipelines.traces.receivers[+]=otlp",
+			expectedOp: &setListOp{
+				flag:  "service.pipelines.traces.receivers[+]",
+				path:  []setSegment{{key: "service"}, {key: "pipelines"}, {key: "traces"}, {key: "receivers"}, {index: appendIndex, list: true}},
+				value: "otlp",
+			},
+		},
+		{
+			name: "nested indices",
+			flag: "a[1][+].b = {c: 1}",
+			expectedOp: &setListOp{
+				flag:  "a[1][+].b",
+				path:  []setSegment{{key: "a"}, {index: 1, list: true}, {index: appendIndex, list: true}, {key: "b"}},
+				value: map[string]any{"c": 1},
+			},
+		},
+		{
+			name: "empty value",
+			flag: "a[0]=",
+			expectedOp: &setListOp{
+				flag: "a[0]",
+				path: []setSegment{{key: "a"}, {index: 0, list: true}},
+			},
+		},
+		{
+			name:        "unclosed index",
+			flag:        "a[0=b",
+			expectedErr: `invalid key "a[0": unclosed list index`,
+		},
+		{
+			name:        "invalid index",
+			flag:        "a[-1]=b",
+			expectedErr: `invalid key "a[-1]": list index "-1" is neither a non-negative integer nor +`,
+		},
+		{
+			name:        "text after index",
+			flag:        "a[0]b=c",
+			expectedErr: `invalid key "a[0]b": unexpected "b" after a list index`,
+		},
+		{
+			name:        "empty key",
+			flag:        "a.[0]=c",
+			expectedErr: `invalid key "a.[0]": empty map key`,
+		},
+		{
+			name:        "invalid value",
+			flag:        "a[0]=[b",
+			expectedErr: "invalid value: yaml: line 1: did not find expected ',' or ']'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri, op, err := parseSetFlag(tt.flag)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedURI, uri)
+			assert.Equal(t, tt.expectedOp, op)
+		})
+	}
+}
+
+func TestSetListConverter(t *testing.T) {
+	tests := []struct {
+		name        string
+		flags       []string
+		expected    map[string]any
+		expectedErr string
+	}{
+		{
+			name:  "append",
+			flags: []string{"receivers[+]=otlp", "receivers[+]=[c]"},
+			expected: map[string]any{
+				"receivers": []any{"a", "b", "otlp", []any{"c"}},
+				"exporters": []any{map[string]any{"name": "x", "port": 1}},
+			},
+		},
+		{
+			name:  "replace",
+			flags: []string{"receivers[1]=otlp", "exporters[0].port=2", "exporters[0].tls.insecure=true"},
+			expected: map[string]any{
+				"receivers": []any{"a", "otlp"},
+				"exporters": []any{map[string]any{"name": "x", "port": 2, "tls": map[string]any{"insecure": true}}},
+			},
+		},
+		{
+			name:  "new list",
+			flags: []string{"processors.batch.keys[+]=a"},
+			expected: map[string]any{
+				"receivers":  []any{"a", "b"},
+				"exporters":  []any{map[string]any{"name": "x", "port": 1}},
+				"processors": map[string]any{"batch": map[string]any{"keys": []any{"a"}}},
+			},
+		},
+		{
+			name:        "out of range",
+			flags:       []string{"receivers[2]=otlp"},
+			expectedErr: `cannot set "receivers[2]": index 2 out of range of a list of 2 elements`,
+		},
+		{
+			name:        "not a list",
+			flags:       []string{"exporters[0].name[0]=y"},
+			expectedErr: `cannot set "exporters[0].name[0]": not a list`,
+		},
+		{
+			name:        "not a map",
+			flags:       []string{"receivers[0].name=y"},
+			expectedErr: `cannot set "receivers[0].name": "name" is not in a map`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ops []*setListOp
+			for _, flag := range tt.flags {
+				_, op, err := parseSetFlag(flag)
+				require.NoError(t, err)
+				ops = append(ops, op)
+			}
+			conf := confmap.NewFromStringMap(map[string]any{
+				"receivers": []any{"a", "b"},
+				"exporters": []any{map[string]any{"name": "x", "port": 1}},
+			})
+			err := setListConverter{ops: ops}.Convert(context.Background(), conf)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, conf.ToStringMap())
+		})
+	}
+}
+
+func TestSetFlagTypedValues(t *testing.T) {
+	type config struct {
+		Timeout   time.Duration     `mapstructure:"timeout"`
+		Enabled   bool              `mapstructure:"enabled"`
+		Count     int               `mapstructure:"count"`
+		Name      string            `mapstructure:"name"`
+		Receivers []string          `mapstructure:"receivers"`
+		Ports     []int             `mapstructure:"ports"`
+		Headers   map[string]string `mapstructure:"headers"`
+	}
+
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{
+		"--config=yaml:{timeout: 1s, enabled: false, count: 1, name: a, receivers: [a, b], ports: [1, 2], headers: {a: b}}",
+		"--set=timeout=30s",
+		"--set=enabled=true",
+		"--set=count=5",
+		`--set=name="123"`,
+		"--set=receivers[+]=otlp",
+		"--set=ports[0]=3",
+		"--set=headers={c: d}",
+	}))
+	set := CollectorSettings{ConfigProviderSettings: ConfigProviderSettings{ResolverSettings: confmap.ResolverSettings{
+		DefaultScheme: "yaml",
+		ProviderFactories: []confmap.ProviderFactory{newFakeProvider("yaml", func(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+			return confmap.NewRetrievedFromYAML([]byte(uri[len("yaml:"):]))
+		})},
+	}}}
+	require.NoError(t, updateSettingsUsingFlags(&set, flgs))
+	resolver, err := confmap.NewResolver(set.ConfigProviderSettings.ResolverSettings)
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+
+	var cfg config
+	require.NoError(t, conf.Unmarshal(&cfg))
+	assert.Equal(t, config{
+		Timeout:   30 * time.Second,
+		Enabled:   true,
+		Count:     5,
+		Name:      "123",
+		Receivers: []string{"a", "b", "otlp"},
+		Ports:     []int{3, 2},
+		Headers:   map[string]string{"a": "b", "c": "d"},
+	}, cfg)
+}
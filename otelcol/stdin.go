@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	// stdinConfigFlag is the config flag value reading the configuration from the standard input.
+	stdinConfigFlag = "-"
+	stdinScheme     = "stdin"
+)
+
+// stdinContent reads the standard input once, so that the configuration read from it is reused
+// when the configuration is reloaded, and passed to the processes started by the collector.
+type stdinContent struct {
+	r    io.Reader
+	once sync.Once
+	read atomic.Bool
+	data []byte
+	err  error
+}
+
+func (s *stdinContent) get() ([]byte, error) {
+	s.once.Do(func() {
+		s.data, s.err = io.ReadAll(s.r)
+		s.read.Store(true)
+	})
+	return s.data, s.err
+}
+
+var stdinConfig = &stdinContent{r: os.Stdin}
+
+// childStdin returns the standard input of a process of the collector started by this one, which
+// is the content read by this one if it read its configuration from the standard input.
+func childStdin() io.Reader {
+	if !stdinConfig.read.Load() {
+		return os.Stdin
+	}
+	return bytes.NewReader(stdinConfig.data)
+}
+
+// useStdinConfig replaces the config flag reading the standard input by the location of the stdin provider.
+func useStdinConfig(set *confmap.ResolverSettings) error {
+	i := slices.Index(set.URIs, stdinConfigFlag)
+	if i < 0 {
+		return nil
+	}
+	if slices.Contains(set.URIs[i+1:], stdinConfigFlag) {
+		return errors.New("the configuration can be read from the standard input only once")
+	}
+	set.URIs = slices.Clone(set.URIs)
+	set.URIs[i] = stdinScheme + ":"
+	set.ProviderFactories = append(slices.Clip(set.ProviderFactories), confmap.NewProviderFactory(newStdinProvider))
+	return nil
+}
+
+// stdinProvider reads the configuration from the standard input. It does not watch it, as it is read once.
+type stdinProvider struct{}
+
+func newStdinProvider(confmap.ProviderSettings) confmap.Provider {
+	return stdinProvider{}
+}
+
+func (stdinProvider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if uri != stdinScheme+":" {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, stdinScheme)
+	}
+	data, err := stdinConfig.get()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the standard input: %w", err)
+	}
+	return confmap.NewRetrievedFromYAML(data)
+}
+
+func (stdinProvider) Scheme() string {
+	return stdinScheme
+}
+
+func (stdinProvider) Shutdown(context.Context) error {
+	return nil
+}
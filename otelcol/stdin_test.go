@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func setStdinConfig(t *testing.T, data []byte) {
+	prev := stdinConfig
+	stdinConfig = &stdinContent{r: bytes.NewReader(data)}
+	t.Cleanup(func() { stdinConfig = prev })
+}
+
+func TestStdinConfig(t *testing.T) {
+	yamlBytes, err := os.ReadFile(filepath.Join("testdata", "otelcol-nop.yaml"))
+	require.NoError(t, err)
+	setStdinConfig(t, yamlBytes)
+	assert.Equal(t, os.Stdin, childStdin())
+
+	set := CollectorSettings{ConfigProviderSettings: newDefaultConfigProviderSettings(t, nil)}
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--config=-", "--set=service.telemetry.logs.level=warn"}))
+	require.NoError(t, updateSettingsUsingFlags(&set, flgs))
+	assert.Equal(t, []string{"stdin:", "yaml:service::telemetry::logs::level: warn"}, set.ConfigProviderSettings.ResolverSettings.URIs)
+
+	set.ConfigProviderSettings.ResolverSettings.URIs = set.ConfigProviderSettings.ResolverSettings.URIs[:1]
+	cp, err := NewConfigProvider(set.ConfigProviderSettings)
+	require.NoError(t, err)
+	factories, err := nopFactories()
+	require.NoError(t, err)
+	configNop, err := newConfig(yamlBytes, factories)
+	require.NoError(t, err)
+
+	// The configuration is read once, and reused when it is reloaded.
+	for range 2 {
+		cfg, err := cp.Get(context.Background(), factories)
+		require.NoError(t, err)
+		assert.Equal(t, configNop, cfg)
+	}
+	require.NoError(t, cp.Shutdown(context.Background()))
+
+	data, err := io.ReadAll(childStdin())
+	require.NoError(t, err)
+	assert.Equal(t, yamlBytes, data)
+}
+
+func TestStdinConfigTwice(t *testing.T) {
+	set := CollectorSettings{ConfigProviderSettings: newDefaultConfigProviderSettings(t, nil)}
+	flgs := flags(featuregate.NewRegistry())
+	require.NoError(t, flgs.Parse([]string{"--config=-", "--config=-"}))
+	require.EqualError(t, updateSettingsUsingFlags(&set, flgs), "the configuration can be read from the standard input only once")
+}
+
+func TestStdinProviderUnsupportedURI(t *testing.T) {
+	_, err := newStdinProvider(confmap.ProviderSettings{}).Retrieve(context.Background(), "stdin:config.yaml", nil)
+	require.EqualError(t, err, `"stdin:config.yaml" uri is not supported by "stdin" provider`)
+}
@@ -0,0 +1,307 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol // import "go.opentelemetry.io/collector/otelcol"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// supervisedEnv is set in the environment of the collector run by a supervisor, so that it does not
+// start a supervisor itself.
+const supervisedEnv = "OTELCOL_SUPERVISED"
+
+// crashReportTailSize is the size of the end of the standard error kept in a crash report.
+const crashReportTailSize = 64 * 1024
+
+const (
+	supervisorStatusStarting   = "starting"
+	supervisorStatusRunning    = "running"
+	supervisorStatusRestarting = "restarting"
+	supervisorStatusStopping   = "stopping"
+)
+
+// crashReport describes a crash of the collector run by a supervisor.
+type crashReport struct {
+	Time   time.Time `json:"time"`
+	Error  string    `json:"error"`
+	Uptime string    `json:"uptime"`
+	// File is the file the report is written to, if any.
+	File string `json:"file,omitempty"`
+}
+
+// supervisorStatus is the response of the health check of the supervisor.
+type supervisorStatus struct {
+	Status    string       `json:"status"`
+	Restarts  int          `json:"restarts"`
+	LastCrash *crashReport `json:"last_crash,omitempty"`
+}
+
+// supervisor runs the collector as a child process, restarts it with backoff when it crashes, and
+// serves a health check while it restarts.
+type supervisor struct {
+	logger *zap.Logger
+	// command returns the command running the collector.
+	command  func() *exec.Cmd
+	name     string
+	crashDir string
+	// signals receives the signals to forward to the collector, or to stop it.
+	signals    chan os.Signal
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	mu        sync.Mutex
+	status    string
+	restarts  int
+	lastCrash *crashReport
+}
+
+// runSupervisor runs the collector, with the same executable and arguments, under a supervisor.
+func runSupervisor(ctx context.Context, set CollectorSettings, healthEndpoint, crashDir string) error {
+	logger, err := newFallbackLogger(set.LoggingOptions)
+	if err != nil {
+		return err
+	}
+	// The supervisor is ready for the process which detached it, and the collector is not notifying it.
+	notifyHandoffReady()
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the executable: %w", err)
+	}
+	// The collector is restarted with the configuration read from the standard input, if any.
+	if slices.Contains(set.ConfigProviderSettings.ResolverSettings.URIs, stdinScheme+":") {
+		if _, err = stdinConfig.get(); err != nil {
+			return fmt.Errorf("unable to read the standard input: %w", err)
+		}
+	}
+	s := newSupervisor(logger, func() *exec.Cmd {
+		cmd := exec.Command(exe, os.Args[1:]...)
+		cmd.Stdin = childStdin()
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		cmd.Env = append(os.Environ(), supervisedEnv+"=true")
+		return cmd
+	})
+	s.name = set.BuildInfo.Command
+	s.crashDir = crashDir
+	signal.Notify(s.signals, append([]os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP}, handoffSignals...)...)
+	defer signal.Stop(s.signals)
+	return s.run(ctx, healthEndpoint)
+}
+
+func newSupervisor(logger *zap.Logger, command func() *exec.Cmd) *supervisor {
+	return &supervisor{
+		logger:     logger,
+		command:    command,
+		name:       "otelcol",
+		signals:    make(chan os.Signal, 4),
+		minBackoff: time.Second,
+		maxBackoff: time.Minute,
+		status:     supervisorStatusStarting,
+	}
+}
+
+// run runs the collector until it exits successfully or with an invalid configuration, or until it is
+// stopped by a signal or the context.
+// SIGHUP is forwarded to the collector, and the handoff signals restart it without backoff.
+func (s *supervisor) run(ctx context.Context, healthEndpoint string) error {
+	if healthEndpoint != "" {
+		ln, err := net.Listen("tcp", healthEndpoint)
+		if err != nil {
+			return fmt.Errorf("failed to listen on the supervisor health endpoint: %w", err)
+		}
+		srv := &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+		go func() { _ = srv.Serve(ln) }()
+		defer srv.Close()
+	}
+
+	backoff := s.minBackoff
+	for {
+		cmd := s.command()
+		tail := &tailWriter{max: crashReportTailSize}
+		if cmd.Stderr != nil {
+			cmd.Stderr = io.MultiWriter(cmd.Stderr, tail)
+		} else {
+			cmd.Stderr = tail
+		}
+		started := time.Now()
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start the collector: %w", err)
+		}
+		s.setStatus(supervisorStatusRunning)
+
+		exited := make(chan error, 1)
+		go func() { exited <- cmd.Wait() }()
+		stopping, restarting, err := s.wait(ctx, cmd.Process, exited)
+		uptime := time.Since(started)
+		switch {
+		case stopping:
+			return nil
+		case restarting:
+			s.logger.Info("Collector stopped, restarting it")
+			backoff = s.minBackoff
+			continue
+		case err == nil:
+			s.logger.Info("Collector exited")
+			return nil
+		}
+
+		s.recordCrash(err, uptime, tail.Bytes())
+		// Restarting the collector does not fix its configuration.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == ExitCodeConfigValidation {
+			s.logger.Error("Collector exited with an invalid configuration", zap.Error(err))
+			s.setStatus(supervisorStatusStopping)
+			return newExitError(ExitCodeConfigValidation, fmt.Errorf("collector exited with an invalid configuration: %w", err))
+		}
+		s.mu.Lock()
+		s.status = supervisorStatusRestarting
+		s.restarts++
+		s.mu.Unlock()
+		if uptime >= s.maxBackoff {
+			backoff = s.minBackoff
+		}
+		s.logger.Error("Collector crashed, restarting it", zap.Error(err), zap.Duration("backoff", backoff))
+		if !s.sleep(ctx, backoff) {
+			return nil
+		}
+		backoff = min(2*backoff, s.maxBackoff)
+	}
+}
+
+// wait waits for the collector to exit, forwarding it the signals, and returns whether it was stopped or restarted.
+func (s *supervisor) wait(ctx context.Context, p *os.Process, exited <-chan error) (stopping, restarting bool, err error) {
+	done := ctx.Done()
+	for {
+		select {
+		case err = <-exited:
+			return stopping, restarting, err
+		case sig := <-s.signals:
+			if sig == syscall.SIGHUP {
+				_ = p.Signal(sig)
+				continue
+			}
+			if isHandoffSignal(sig) {
+				restarting = true
+				s.setStatus(supervisorStatusRestarting)
+			} else {
+				stopping = true
+				s.setStatus(supervisorStatusStopping)
+			}
+			stopProcess(p)
+		case <-done:
+			done = nil
+			stopping = true
+			s.setStatus(supervisorStatusStopping)
+			stopProcess(p)
+		}
+	}
+}
+
+// sleep waits for the backoff, and returns false if the supervisor is stopped meanwhile.
+func (s *supervisor) sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			return true
+		case sig := <-s.signals:
+			if sig == syscall.SIGHUP || isHandoffSignal(sig) {
+				continue
+			}
+			s.setStatus(supervisorStatusStopping)
+			return false
+		case <-ctx.Done():
+			s.setStatus(supervisorStatusStopping)
+			return false
+		}
+	}
+}
+
+// stopProcess asks the process to shut down gracefully, or kills it on platforms where it cannot be interrupted.
+func stopProcess(p *os.Process) {
+	if err := p.Signal(os.Interrupt); err != nil {
+		_ = p.Kill()
+	}
+}
+
+func (s *supervisor) setStatus(status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+// recordCrash records the last crash, writing its report with the end of the standard error in the crash directory.
+func (s *supervisor) recordCrash(err error, uptime time.Duration, stderr []byte) {
+	report := &crashReport{
+		Time:   time.Now(),
+		Error:  err.Error(),
+		Uptime: uptime.Round(time.Millisecond).String(),
+	}
+	if s.crashDir != "" {
+		file := filepath.Join(s.crashDir, fmt.Sprintf("%s-crash-%s.log", s.name, report.Time.Format("20060102T150405.000")))
+		content := fmt.Sprintf("Time: %s\nError: %s\nUptime: %s\n\n%s", report.Time.Format(time.RFC3339Nano), report.Error, report.Uptime, stderr)
+		if werr := os.WriteFile(file, []byte(content), 0o600); werr != nil {
+			s.logger.Error("Failed to write the crash report", zap.Error(werr))
+		} else {
+			report.File = file
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastCrash = report
+}
+
+// ServeHTTP serves the health check of the supervisor, which succeeds while the collector is running.
+func (s *supervisor) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	status := supervisorStatus{Status: s.status, Restarts: s.restarts, LastCrash: s.lastCrash}
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if status.Status != supervisorStatusRunning {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// tailWriter keeps the last max bytes written to it.
+type tailWriter struct {
+	mu  sync.Mutex
+	max int
+	buf []byte
+}
+
+func (t *tailWriter) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.buf = append(t.buf, p...)
+	if over := len(t.buf) - t.max; over > 0 {
+		t.buf = append(t.buf[:0], t.buf[over:]...)
+	}
+	return len(p), nil
+}
+
+func (t *tailWriter) Bytes() []byte {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]byte(nil), t.buf...)
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelcol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const supervisorHelperEnv = "OTELCOL_SUPERVISOR_HELPER"
+
+// TestSupervisorHelperProcess is run as the collector by the supervisor tests.
+func TestSupervisorHelperProcess(*testing.T) {
+	switch os.Getenv(supervisorHelperEnv) {
+	case "crash":
+		fmt.Fprintln(os.Stderr, "panic: boom")
+		os.Exit(2)
+	case "exit":
+		os.Exit(0)
+	case "invalid":
+		os.Exit(ExitCodeConfigValidation)
+	case "run":
+		time.Sleep(time.Minute)
+		os.Exit(0)
+	}
+}
+
+// helperCommand returns a command running the helper process in the given modes, one per start,
+// the last mode being repeated.
+func helperCommand(t *testing.T, modes ...string) func() *exec.Cmd {
+	starts := 0
+	return func() *exec.Cmd {
+		mode := modes[min(starts, len(modes)-1)]
+		starts++
+		cmd := exec.Command(os.Args[0], "-test.run=^TestSupervisorHelperProcess$")
+		cmd.Env = append(os.Environ(), supervisorHelperEnv+"="+mode)
+		t.Logf("starting the helper process in %q mode", mode)
+		return cmd
+	}
+}
+
+func getSupervisorStatus(t *testing.T, s *supervisor) (int, supervisorStatus) {
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	var status supervisorStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	return rec.Code, status
+}
+
+func TestSupervisorRestartsCrashedCollector(t *testing.T) {
+	s := newSupervisor(zap.NewNop(), helperCommand(t, "crash", "crash", "run"))
+	s.minBackoff = time.Millisecond
+	s.crashDir = t.TempDir()
+
+	code, status := getSupervisorStatus(t, s)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, supervisorStatusStarting, status.Status)
+
+	done := make(chan error, 1)
+	go func() { done <- s.run(context.Background(), "") }()
+
+	require.Eventually(t, func() bool {
+		code, status = getSupervisorStatus(t, s)
+		return code == http.StatusOK && status.Restarts == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, supervisorStatusRunning, status.Status)
+	require.NotNil(t, status.LastCrash)
+	assert.Contains(t, status.LastCrash.Error, "exit status 2")
+
+	reports, err := filepath.Glob(filepath.Join(s.crashDir, "otelcol-crash-*.log"))
+	require.NoError(t, err)
+	require.NotEmpty(t, reports)
+	assert.Contains(t, reports, status.LastCrash.File)
+	content, err := os.ReadFile(status.LastCrash.File)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Error: exit status 2")
+	assert.Contains(t, string(content), "panic: boom")
+
+	s.signals <- os.Interrupt
+	require.NoError(t, <-done)
+	_, status = getSupervisorStatus(t, s)
+	assert.Equal(t, supervisorStatusStopping, status.Status)
+}
+
+func TestSupervisorCollectorExits(t *testing.T) {
+	s := newSupervisor(zap.NewNop(), helperCommand(t, "exit"))
+	require.NoError(t, s.run(context.Background(), ""))
+	_, status := getSupervisorStatus(t, s)
+	assert.Equal(t, 0, status.Restarts)
+	assert.Nil(t, status.LastCrash)
+}
+
+func TestSupervisorInvalidConfig(t *testing.T) {
+	s := newSupervisor(zap.NewNop(), helperCommand(t, "invalid"))
+	err := s.run(context.Background(), "")
+	require.ErrorContains(t, err, "collector exited with an invalid configuration")
+	assert.Equal(t, ExitCodeConfigValidation, ExitCode(err))
+	_, status := getSupervisorStatus(t, s)
+	assert.Equal(t, supervisorStatusStopping, status.Status)
+	assert.Equal(t, 0, status.Restarts)
+	require.NotNil(t, status.LastCrash)
+}
+
+func TestSupervisorContextDone(t *testing.T) {
+	s := newSupervisor(zap.NewNop(), helperCommand(t, "crash"))
+	s.minBackoff = time.Hour
+	s.maxBackoff = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.run(ctx, "") }()
+
+	require.Eventually(t, func() bool {
+		_, status := getSupervisorStatus(t, s)
+		return status.Restarts == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestSupervisorHealthEndpoint(t *testing.T) {
+	s := newSupervisor(zap.NewNop(), func() *exec.Cmd { return exec.Command(filepath.Join(t.TempDir(), "missing")) })
+	require.ErrorContains(t, s.run(context.Background(), "localhost:-1"), "failed to listen on the supervisor health endpoint")
+	require.ErrorContains(t, s.run(context.Background(), "localhost:0"), "failed to start the collector")
+}
+
+func TestTailWriter(t *testing.T) {
+	tw := &tailWriter{max: 4}
+	_, err := tw.Write([]byte("ab"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ab"), tw.Bytes())
+	n, err := tw.Write([]byte("cdef"))
+	require.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []byte("cdef"), tw.Bytes())
+}
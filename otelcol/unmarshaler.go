@@ -4,6 +4,8 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
@@ -12,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service"
+	"go.opentelemetry.io/collector/service/goruntime"
 	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
 )
 
@@ -22,6 +25,9 @@ type configSettings struct {
 	Connectors *configunmarshaler.Configs[connector.Factory] `mapstructure:"connectors"`
 	Extensions *configunmarshaler.Configs[extension.Factory] `mapstructure:"extensions"`
 	Service    service.Config                                `mapstructure:"service"`
+	// Groups holds the configurations of the component groups, by kind. They are applied to
+	// the components while unmarshaling their configuration.
+	Groups map[string]any `mapstructure:"groups"`
 }
 
 // unmarshal the configSettings from a confmap.Conf.
@@ -42,8 +48,40 @@ func unmarshal(v *confmap.Conf, factories Factories) (*configSettings, error) {
 		// TODO: Add a component.ServiceFactory to allow this to be defined by the Service.
 		Service: service.Config{
 			Telemetry: defaultTelConfig,
+			Runtime:   goruntime.NewDefaultConfig(),
 		},
 	}
+	if err := setGroups(v, cfg); err != nil {
+		return cfg, err
+	}
 	err := v.Unmarshal(&cfg)
 	return cfg, err
 }
+
+// setGroups sets the configurations of the component groups, under "groups::<kind>", to the
+// configurations of the components of the kind.
+func setGroups(v *confmap.Conf, cfg *configSettings) error {
+	groups, err := v.Sub("groups")
+	if err != nil {
+		return err
+	}
+	kinds := map[string]interface{ SetGroups(*confmap.Conf) }{
+		"receivers":  cfg.Receivers,
+		"processors": cfg.Processors,
+		"exporters":  cfg.Exporters,
+		"connectors": cfg.Connectors,
+		"extensions": cfg.Extensions,
+	}
+	for kind := range groups.ToStringMap() {
+		configs, ok := kinds[kind]
+		if !ok {
+			return fmt.Errorf("groups: unknown kind %q", kind)
+		}
+		sub, err := groups.Sub(kind)
+		if err != nil {
+			return fmt.Errorf("groups: %w", err)
+		}
+		configs.SetGroups(sub)
+	}
+	return nil
+}
@@ -195,3 +195,35 @@ func TestServiceUnmarshalError(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalGroups(t *testing.T) {
+	factories, err := nopFactories()
+	require.NoError(t, err)
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"groups": map[string]any{
+			"receivers": map[string]any{"nop/team-a": nil},
+		},
+		"receivers": map[string]any{"nop/team-a/prod": nil},
+	})
+	cfg, err := unmarshal(conf, factories)
+	require.NoError(t, err)
+	assert.Len(t, cfg.Receivers.Configs(), 1)
+
+	conf = confmap.NewFromStringMap(map[string]any{
+		"groups": map[string]any{
+			"receivers": map[string]any{"nop/team-b": nil},
+		},
+		"receivers": map[string]any{"nop/team-a/prod": nil},
+	})
+	_, err = unmarshal(conf, factories)
+	require.ErrorContains(t, err, `group "nop/team-b" does not match any component`)
+
+	conf = confmap.NewFromStringMap(map[string]any{
+		"groups": map[string]any{
+			"pipelines": map[string]any{},
+		},
+	})
+	_, err = unmarshal(conf, factories)
+	require.EqualError(t, err, `groups: unknown kind "pipelines"`)
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pref // import "go.opentelemetry.io/collector/pdata/xpdata/pref"
+
+import (
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpcollectorprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/profiles/v1development"
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	otlpprofiles "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1development"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// The ShallowClone functions return a copy of the data in which only the resources and the scopes
+// are copied, while the records are shared with the original data. This makes the copy much cheaper
+// than a full copy, but the records of both the copy and the original must not be modified, nor added
+// or removed. The resources and scopes of the copy can be modified independently of the original.
+//
+// A full copy is returned if the proto pooling is enabled, as the shared records could be released
+// twice otherwise.
+
+// ShallowCloneTraces returns a copy of td sharing the spans with it.
+func ShallowCloneTraces(td ptrace.Traces) ptrace.Traces {
+	if internal.UseProtoPooling.IsEnabled() {
+		dest := ptrace.NewTraces()
+		td.CopyTo(dest)
+		return dest
+	}
+	src := internal.GetOrigTraces(internal.Traces(td))
+	dest := &otlpcollectortrace.ExportTraceServiceRequest{
+		ResourceSpans: make([]*otlptrace.ResourceSpans, len(src.ResourceSpans)),
+	}
+	for i, srcRS := range src.ResourceSpans {
+		rs := &otlptrace.ResourceSpans{
+			ScopeSpans: make([]*otlptrace.ScopeSpans, len(srcRS.ScopeSpans)),
+			SchemaUrl:  srcRS.SchemaUrl,
+		}
+		internal.CopyOrigResource(&rs.Resource, &srcRS.Resource)
+		for j, srcSS := range srcRS.ScopeSpans {
+			ss := &otlptrace.ScopeSpans{
+				Spans:     slices.Clone(srcSS.Spans),
+				SchemaUrl: srcSS.SchemaUrl,
+			}
+			internal.CopyOrigInstrumentationScope(&ss.Scope, &srcSS.Scope)
+			rs.ScopeSpans[j] = ss
+		}
+		dest.ResourceSpans[i] = rs
+	}
+	return ptrace.Traces(internal.NewTraces(dest, internal.NewState()))
+}
+
+// ShallowCloneMetrics returns a copy of md sharing the metrics with it.
+func ShallowCloneMetrics(md pmetric.Metrics) pmetric.Metrics {
+	if internal.UseProtoPooling.IsEnabled() {
+		dest := pmetric.NewMetrics()
+		md.CopyTo(dest)
+		return dest
+	}
+	src := internal.GetOrigMetrics(internal.Metrics(md))
+	dest := &otlpcollectormetrics.ExportMetricsServiceRequest{
+		ResourceMetrics: make([]*otlpmetrics.ResourceMetrics, len(src.ResourceMetrics)),
+	}
+	for i, srcRM := range src.ResourceMetrics {
+		rm := &otlpmetrics.ResourceMetrics{
+			ScopeMetrics: make([]*otlpmetrics.ScopeMetrics, len(srcRM.ScopeMetrics)),
+			SchemaUrl:    srcRM.SchemaUrl,
+		}
+		internal.CopyOrigResource(&rm.Resource, &srcRM.Resource)
+		for j, srcSM := range srcRM.ScopeMetrics {
+			sm := &otlpmetrics.ScopeMetrics{
+				Metrics:   slices.Clone(srcSM.Metrics),
+				SchemaUrl: srcSM.SchemaUrl,
+			}
+			internal.CopyOrigInstrumentationScope(&sm.Scope, &srcSM.Scope)
+			rm.ScopeMetrics[j] = sm
+		}
+		dest.ResourceMetrics[i] = rm
+	}
+	return pmetric.Metrics(internal.NewMetrics(dest, internal.NewState()))
+}
+
+// ShallowCloneLogs returns a copy of ld sharing the log records with it.
+func ShallowCloneLogs(ld plog.Logs) plog.Logs {
+	if internal.UseProtoPooling.IsEnabled() {
+		dest := plog.NewLogs()
+		ld.CopyTo(dest)
+		return dest
+	}
+	src := internal.GetOrigLogs(internal.Logs(ld))
+	dest := &otlpcollectorlog.ExportLogsServiceRequest{
+		ResourceLogs: make([]*otlplogs.ResourceLogs, len(src.ResourceLogs)),
+	}
+	for i, srcRL := range src.ResourceLogs {
+		rl := &otlplogs.ResourceLogs{
+			ScopeLogs: make([]*otlplogs.ScopeLogs, len(srcRL.ScopeLogs)),
+			SchemaUrl: srcRL.SchemaUrl,
+		}
+		internal.CopyOrigResource(&rl.Resource, &srcRL.Resource)
+		for j, srcSL := range srcRL.ScopeLogs {
+			sl := &otlplogs.ScopeLogs{
+				LogRecords: slices.Clone(srcSL.LogRecords),
+				SchemaUrl:  srcSL.SchemaUrl,
+			}
+			internal.CopyOrigInstrumentationScope(&sl.Scope, &srcSL.Scope)
+			rl.ScopeLogs[j] = sl
+		}
+		dest.ResourceLogs[i] = rl
+	}
+	return plog.Logs(internal.NewLogs(dest, internal.NewState()))
+}
+
+// ShallowCloneProfiles returns a copy of pd sharing the profiles with it.
+// The dictionary is copied, as the profiles may reference new entries in it.
+func ShallowCloneProfiles(pd pprofile.Profiles) pprofile.Profiles {
+	if internal.UseProtoPooling.IsEnabled() {
+		dest := pprofile.NewProfiles()
+		pd.CopyTo(dest)
+		return dest
+	}
+	src := internal.GetOrigProfiles(internal.Profiles(pd))
+	dest := &otlpcollectorprofiles.ExportProfilesServiceRequest{
+		ResourceProfiles: make([]*otlpprofiles.ResourceProfiles, len(src.ResourceProfiles)),
+	}
+	internal.CopyOrigProfilesDictionary(&dest.Dictionary, &src.Dictionary)
+	for i, srcRP := range src.ResourceProfiles {
+		rp := &otlpprofiles.ResourceProfiles{
+			ScopeProfiles: make([]*otlpprofiles.ScopeProfiles, len(srcRP.ScopeProfiles)),
+			SchemaUrl:     srcRP.SchemaUrl,
+		}
+		internal.CopyOrigResource(&rp.Resource, &srcRP.Resource)
+		for j, srcSP := range srcRP.ScopeProfiles {
+			sp := &otlpprofiles.ScopeProfiles{
+				Profiles:  slices.Clone(srcSP.Profiles),
+				SchemaUrl: srcSP.SchemaUrl,
+			}
+			internal.CopyOrigInstrumentationScope(&sp.Scope, &srcSP.Scope)
+			rp.ScopeProfiles[j] = sp
+		}
+		dest.ResourceProfiles[i] = rp
+	}
+	return pprofile.Profiles(internal.NewProfiles(dest, internal.NewState()))
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pref
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/internal"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func setPoolingForTest(t *testing.T, enabled bool) {
+	prev := UseProtoPooling.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(UseProtoPooling.ID(), enabled))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(UseProtoPooling.ID(), prev))
+	})
+}
+
+func TestShallowCloneTraces(t *testing.T) {
+	for _, pooling := range []bool{false, true} {
+		setPoolingForTest(t, pooling)
+		td := testdata.GenerateTraces(2)
+		clone := ShallowCloneTraces(td)
+		assert.True(t, EqualTraces(td, clone))
+
+		clone.ResourceSpans().At(0).Resource().Attributes().PutStr("cloned", "true")
+		clone.ResourceSpans().At(0).ScopeSpans().At(0).Scope().SetName("cloned")
+		_, found := td.ResourceSpans().At(0).Resource().Attributes().Get("cloned")
+		assert.False(t, found)
+		assert.NotEqual(t, "cloned", td.ResourceSpans().At(0).ScopeSpans().At(0).Scope().Name())
+
+		src := internal.GetOrigTraces(internal.Traces(td)).ResourceSpans[0].ScopeSpans[0].Spans[0]
+		dest := internal.GetOrigTraces(internal.Traces(clone)).ResourceSpans[0].ScopeSpans[0].Spans[0]
+		assert.Equal(t, !pooling, src == dest)
+	}
+}
+
+func TestShallowCloneMetrics(t *testing.T) {
+	for _, pooling := range []bool{false, true} {
+		setPoolingForTest(t, pooling)
+		md := testdata.GenerateMetrics(2)
+		clone := ShallowCloneMetrics(md)
+		assert.True(t, EqualMetrics(md, clone))
+
+		clone.ResourceMetrics().At(0).Resource().Attributes().PutStr("cloned", "true")
+		_, found := md.ResourceMetrics().At(0).Resource().Attributes().Get("cloned")
+		assert.False(t, found)
+
+		src := internal.GetOrigMetrics(internal.Metrics(md)).ResourceMetrics[0].ScopeMetrics[0].Metrics[0]
+		dest := internal.GetOrigMetrics(internal.Metrics(clone)).ResourceMetrics[0].ScopeMetrics[0].Metrics[0]
+		assert.Equal(t, !pooling, src == dest)
+	}
+}
+
+func TestShallowCloneLogs(t *testing.T) {
+	for _, pooling := range []bool{false, true} {
+		setPoolingForTest(t, pooling)
+		ld := testdata.GenerateLogs(2)
+		clone := ShallowCloneLogs(ld)
+		assert.True(t, EqualLogs(ld, clone))
+
+		clone.ResourceLogs().At(0).Resource().Attributes().PutStr("cloned", "true")
+		_, found := ld.ResourceLogs().At(0).Resource().Attributes().Get("cloned")
+		assert.False(t, found)
+
+		src := internal.GetOrigLogs(internal.Logs(ld)).ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+		dest := internal.GetOrigLogs(internal.Logs(clone)).ResourceLogs[0].ScopeLogs[0].LogRecords[0]
+		assert.Equal(t, !pooling, src == dest)
+	}
+}
+
+func TestShallowCloneProfiles(t *testing.T) {
+	for _, pooling := range []bool{false, true} {
+		setPoolingForTest(t, pooling)
+		pd := testdata.GenerateProfiles(2)
+		clone := ShallowCloneProfiles(pd)
+		assert.True(t, EqualProfiles(pd, clone))
+
+		clone.ResourceProfiles().At(0).Resource().Attributes().PutStr("cloned", "true")
+		_, found := pd.ResourceProfiles().At(0).Resource().Attributes().Get("cloned")
+		assert.False(t, found)
+
+		src := internal.GetOrigProfiles(internal.Profiles(pd)).ResourceProfiles[0].ScopeProfiles[0].Profiles[0]
+		dest := internal.GetOrigProfiles(internal.Profiles(clone)).ResourceProfiles[0].ScopeProfiles[0].Profiles[0]
+		assert.Equal(t, !pooling, src == dest)
+	}
+}
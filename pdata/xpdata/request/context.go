@@ -18,8 +18,10 @@ import (
 func encodeContext(ctx context.Context) internal.RequestContext {
 	rc := internal.RequestContext{}
 	encodeSpanContext(ctx, &rc)
-	encodeClientMetadata(ctx, &rc)
-	encodeClientAddress(ctx, &rc)
+	// Only the envelope of the client info is preserved, which bounds the size of its metadata.
+	envelope := client.EnvelopeFromContext(ctx)
+	encodeClientMetadata(envelope.Metadata, &rc)
+	encodeClientAddress(envelope.Addr, &rc)
 	return rc
 }
 
@@ -39,8 +41,7 @@ func encodeSpanContext(ctx context.Context, rc *internal.RequestContext) {
 	}
 }
 
-func encodeClientMetadata(ctx context.Context, rc *internal.RequestContext) {
-	clientMetadata := client.FromContext(ctx).Metadata
+func encodeClientMetadata(clientMetadata client.Metadata, rc *internal.RequestContext) {
 	for k := range clientMetadata.Keys() {
 		vals := clientMetadata.Get(k)
 		switch len(vals) {
@@ -62,8 +63,8 @@ func encodeClientMetadata(ctx context.Context, rc *internal.RequestContext) {
 	}
 }
 
-func encodeClientAddress(ctx context.Context, rc *internal.RequestContext) {
-	switch a := client.FromContext(ctx).Addr.(type) {
+func encodeClientAddress(addr net.Addr, rc *internal.RequestContext) {
+	switch a := addr.(type) {
 	case *net.IPAddr:
 		rc.ClientAddress = &internal.RequestContext_Ip{Ip: &internal.IPAddr{
 			Ip:   a.IP,
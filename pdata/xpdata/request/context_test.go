@@ -6,6 +6,7 @@ package request // import "go.opentelemetry.io/collector/pdata/xpdata/request"
 import (
 	"context"
 	"net"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -93,3 +94,14 @@ func TestEncodeDecodeContext(t *testing.T) {
 	// Decode a nil context
 	assert.Equal(t, context.Background(), decodeContext(context.Background(), nil))
 }
+
+func TestEncodeContextMaxMetadataSize(t *testing.T) {
+	ctx := client.NewContext(context.Background(), client.Info{Metadata: client.NewMetadata(map[string][]string{
+		"large": {strings.Repeat("x", client.MaxEnvelopeMetadataSize)},
+		"small": {"value"},
+	})})
+	reqCtx := encodeContext(ctx)
+	gotCtx := decodeContext(context.Background(), &reqCtx)
+	assert.Nil(t, client.FromContext(gotCtx).Metadata.Get("large"))
+	assert.Equal(t, []string{"value"}, client.FromContext(gotCtx).Metadata.Get("small"))
+}
@@ -20,6 +20,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -81,15 +82,55 @@ type shard[T any] struct {
 	// corresponding with this shard set.
 	exportCtx context.Context
 
+	// envelopes are the client envelopes of the data items
+	// not exported yet, in the order they were added.
+	envelopes []pendingEnvelope
+
 	// timer informs the shard send a batch.
 	timer *time.Timer
 
 	// newItem is used to receive data items from producers.
-	newItem chan T
+	newItem chan dataItem[T]
 
 	// batch is an in-flight data item containing one of the
 	// underlying data types.
 	batch batch[T]
+
+	// added and exported count the items added to the batch
+	// and exported since the shard started.
+	added, exported int
+
+	// acks are the held delivery confirmations of the data
+	// items not exported yet, in the order they were added.
+	acks []pendingAck
+}
+
+// dataItem is a data item received from a producer, with the
+// client envelope of its context and the hold of its delivery
+// confirmation if the producer requested one.
+type dataItem[T any] struct {
+	data     T
+	envelope client.Envelope
+	ack      func(error)
+}
+
+func newDataItem[T any](ctx context.Context, data T) dataItem[T] {
+	return dataItem[T]{data: data, envelope: client.EnvelopeFromContext(ctx), ack: xconsumer.HoldAck(ctx)}
+}
+
+// pendingEnvelope is the client envelope of a data item whose
+// items end before end.
+type pendingEnvelope struct {
+	end      int
+	envelope client.Envelope
+}
+
+// pendingAck is the held delivery confirmation of a data item,
+// released once all its items in [start, end) were exported.
+type pendingAck struct {
+	start, end int
+	release    func(error)
+	err        error
 }
 
 // batch is an interface generalizing the individual signal types.
@@ -156,7 +197,7 @@ func (bp *batchProcessor[T]) newShard(md map[string][]string) *shard[T] {
 	})
 	b := &shard[T]{
 		processor: bp,
-		newItem:   make(chan T, runtime.NumCPU()),
+		newItem:   make(chan dataItem[T], runtime.NumCPU()),
 		exportCtx: exportCtx,
 		batch:     bp.batchFunc(),
 	}
@@ -227,8 +268,21 @@ func (b *shard[T]) startLoop() {
 	}
 }
 
-func (b *shard[T]) processItem(item T) {
-	b.batch.add(item)
+func (b *shard[T]) processItem(item dataItem[T]) {
+	before := b.batch.itemCount()
+	b.batch.add(item.data)
+	count := b.batch.itemCount() - before
+	if item.ack != nil {
+		if count == 0 {
+			item.ack(nil)
+		} else {
+			b.acks = append(b.acks, pendingAck{start: b.added, end: b.added + count, release: item.ack})
+		}
+	}
+	if count > 0 {
+		b.envelopes = append(b.envelopes, pendingEnvelope{end: b.added + count, envelope: item.envelope})
+	}
+	b.added += count
 	sent := false
 	for b.batch.itemCount() > 0 && (!b.hasTimer() || b.batch.itemCount() >= b.processor.sendBatchSize) {
 		sent = true
@@ -270,7 +324,10 @@ func (b *shard[T]) sendItems(trigger trigger) {
 		bytes = b.batch.sizeBytes(req)
 	}
 
-	err := b.batch.export(b.exportCtx, req)
+	err := b.batch.export(b.exportContext(sent), req)
+	b.exported += sent
+	b.releaseAcks(err)
+	b.releaseEnvelopes()
 	if err != nil {
 		// b.processor.logger.Warn("Sender failed", zap.Error(err))
 		b.processor.logger.Debug("Send items done")
@@ -281,6 +338,64 @@ func (b *shard[T]) sendItems(trigger trigger) {
 	bpt.record(trigger, int64(sent), int64(bytes))
 }
 
+// exportContext returns the context exporting the next sent
+// items: with the metadata key-values of the shard, and the
+// part of the client envelopes of their data items they all
+// agree on.
+func (b *shard[T]) exportContext(sent int) context.Context {
+	if len(b.envelopes) == 0 {
+		return b.exportCtx
+	}
+	envelope := b.envelopes[0].envelope
+	for i := 1; i < len(b.envelopes) && b.envelopes[i-1].end < b.exported+sent; i++ {
+		envelope = envelope.Merge(b.envelopes[i].envelope)
+	}
+	if envelope.IsEmpty() {
+		return b.exportCtx
+	}
+	md := map[string][]string{}
+	for k := range envelope.Metadata.Keys() {
+		md[k] = envelope.Metadata.Get(k)
+	}
+	shardMetadata := client.FromContext(b.exportCtx).Metadata
+	for k := range shardMetadata.Keys() {
+		md[k] = shardMetadata.Get(k)
+	}
+	return client.NewContext(context.Background(), client.Info{
+		Addr:     envelope.Addr,
+		Metadata: client.NewMetadata(md),
+	})
+}
+
+// releaseEnvelopes drops the client envelopes of the data items
+// entirely exported.
+func (b *shard[T]) releaseEnvelopes() {
+	released := 0
+	for released < len(b.envelopes) && b.envelopes[released].end <= b.exported {
+		released++
+	}
+	b.envelopes = b.envelopes[released:]
+}
+
+// releaseAcks records the export error for the data items with
+// exported items, and releases the confirmations of the items
+// entirely exported.
+func (b *shard[T]) releaseAcks(err error) {
+	released := 0
+	for i := range b.acks {
+		a := &b.acks[i]
+		if a.start >= b.exported {
+			break
+		}
+		a.err = errors.Join(a.err, err)
+		if a.end <= b.exported {
+			a.release(a.err)
+			released++
+		}
+	}
+	b.acks = b.acks[released:]
+}
+
 // singleShardBatcher is used when metadataKeys is empty, to avoid the
 // additional lock and map operations used in multiBatcher.
 type singleShardBatcher[T any] struct {
@@ -293,8 +408,8 @@ func (sb *singleShardBatcher[T]) start(context.Context) error {
 	return nil
 }
 
-func (sb *singleShardBatcher[T]) consume(_ context.Context, data T) error {
-	sb.single.newItem <- data
+func (sb *singleShardBatcher[T]) consume(ctx context.Context, data T) error {
+	sb.single.newItem <- newDataItem(ctx, data)
 	return nil
 }
 
@@ -365,7 +480,7 @@ func (mb *multiShardBatcher[T]) consume(ctx context.Context, data T) error {
 		}
 		mb.lock.Unlock()
 	}
-	b.(*shard[T]).newItem <- data
+	b.(*shard[T]).newItem <- newDataItem(ctx, data)
 	return nil
 }
 
@@ -5,8 +5,10 @@ package batchprocessor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"net"
 	"strconv"
 	"sync"
 	"testing"
@@ -23,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -355,6 +358,49 @@ func TestBatchProcessorSentBySizeWithMaxSize(t *testing.T) {
 	require.NoError(t, tel.Shutdown(context.Background()))
 }
 
+func TestBatchProcessorHoldsAck(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 4
+	cfg.SendBatchMaxSize = 4
+	cfg.Timeout = time.Hour
+	traces, err := NewFactory().CreateTraces(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, traces.Start(context.Background(), componenttest.NewNopHost()))
+
+	firstCtx, first := xconsumer.ContextWithAck(context.Background())
+	first.Release(traces.ConsumeTraces(firstCtx, testdata.GenerateTraces(3)))
+	secondCtx, second := xconsumer.ContextWithAck(context.Background())
+	second.Release(traces.ConsumeTraces(secondCtx, testdata.GenerateTraces(3)))
+
+	// The first batch has the spans of the first request and one of the second request.
+	require.NoError(t, first.Wait(context.Background()))
+	assert.Equal(t, 4, sink.SpanCount())
+	select {
+	case <-second.Done():
+		t.Fatal("the confirmation is done while spans are still batched")
+	default:
+	}
+
+	require.NoError(t, traces.Shutdown(context.Background()))
+	require.NoError(t, second.Wait(context.Background()))
+	assert.Equal(t, 6, sink.SpanCount())
+}
+
+func TestBatchProcessorHoldsAckExportError(t *testing.T) {
+	exportErr := errors.New("export failed")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Timeout = time.Hour
+	logs, err := NewFactory().CreateLogs(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, consumertest.NewErr(exportErr))
+	require.NoError(t, err)
+	require.NoError(t, logs.Start(context.Background(), componenttest.NewNopHost()))
+
+	ctx, ack := xconsumer.ContextWithAck(context.Background())
+	ack.Release(logs.ConsumeLogs(ctx, testdata.GenerateLogs(3)))
+	require.NoError(t, logs.Shutdown(context.Background()))
+	require.ErrorIs(t, ack.Wait(context.Background()), exportErr)
+}
+
 func TestBatchProcessorSentByTimeout(t *testing.T) {
 	sink := new(consumertest.TracesSink)
 	cfg := createDefaultConfig().(*Config)
@@ -1120,6 +1166,39 @@ func TestBatchProcessorSpansBatchedByMetadata(t *testing.T) {
 	}
 }
 
+func TestBatchProcessorPreservesClientEnvelope(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 2
+	cfg.SendBatchMaxSize = 2
+	cfg.Timeout = 10 * time.Minute
+	traces, err := NewFactory().CreateTraces(context.Background(), processortest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, traces.Start(context.Background(), componenttest.NewNopHost()))
+
+	addr := &net.TCPAddr{IP: net.IPv4(1, 2, 3, 4), Port: 4317}
+	newCtx := func(id string) context.Context {
+		return client.NewContext(context.Background(), client.Info{
+			Addr:     addr,
+			Metadata: client.NewMetadata(map[string][]string{"tenant": {"acme"}, "id": {id}}),
+		})
+	}
+	// The first batch has the spans of both requests, the second one only of the second request.
+	require.NoError(t, traces.ConsumeTraces(newCtx("1"), testdata.GenerateTraces(1)))
+	require.NoError(t, traces.ConsumeTraces(newCtx("2"), testdata.GenerateTraces(3)))
+	require.NoError(t, traces.Shutdown(context.Background()))
+
+	ctxs := sink.Contexts()
+	require.Len(t, ctxs, 2)
+	info := client.FromContext(ctxs[0])
+	assert.Equal(t, addr, info.Addr)
+	assert.Equal(t, []string{"acme"}, info.Metadata.Get("tenant"))
+	assert.Nil(t, info.Metadata.Get("id"))
+	info = client.FromContext(ctxs[1])
+	assert.Equal(t, []string{"acme"}, info.Metadata.Get("tenant"))
+	assert.Equal(t, []string{"2"}, info.Metadata.Get("id"))
+}
+
 func TestBatchProcessorDuplicateMetadataKeys(t *testing.T) {
 	cfg := createDefaultConfig().(*Config)
 	cfg.MetadataKeys = []string{"myTOKEN", "mytoken"}
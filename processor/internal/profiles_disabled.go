@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build otelcol_no_profiles
+
+package internal // import "go.opentelemetry.io/collector/processor/internal"
+
+// ProfilesEnabled is false when the distribution is built with the otelcol_no_profiles build tag: the factory
+// options of the profiles are then no-ops, which lets the linker leave out the profiles code of the components.
+const ProfilesEnabled = false
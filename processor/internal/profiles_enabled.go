@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !otelcol_no_profiles
+
+package internal // import "go.opentelemetry.io/collector/processor/internal"
+
+// ProfilesEnabled reports whether the profiles of the components are built, see profiles_disabled.go.
+const ProfilesEnabled = true
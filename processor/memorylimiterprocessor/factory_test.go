@@ -30,6 +30,7 @@ func TestCreateDefaultConfig(t *testing.T) {
 	cfg := factory.CreateDefaultConfig()
 	assert.NotNil(t, cfg, "failed to create default config")
 	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+	assert.True(t, factory.SharedInstance())
 }
 
 func TestCreateProcessor(t *testing.T) {
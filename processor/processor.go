@@ -172,6 +172,9 @@ type CreateLogsFunc func(context.Context, Settings, component.Config, consumer.L
 
 // WithTraces overrides the default "error not supported" implementation for CreateTraces and the default "undefined" stability level.
 func WithTraces(createTraces CreateTracesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.TracesEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.tracesStabilityLevel = sl
 		o.createTracesFunc = createTraces
@@ -180,6 +183,9 @@ func WithTraces(createTraces CreateTracesFunc, sl component.StabilityLevel) Fact
 
 // WithMetrics overrides the default "error not supported" implementation for CreateMetrics and the default "undefined" stability level.
 func WithMetrics(createMetrics CreateMetricsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.MetricsEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.metricsStabilityLevel = sl
 		o.createMetricsFunc = createMetrics
@@ -188,6 +194,9 @@ func WithMetrics(createMetrics CreateMetricsFunc, sl component.StabilityLevel) F
 
 // WithLogs overrides the default "error not supported" implementation for CreateLogs and the default "undefined" stability level.
 func WithLogs(createLogs CreateLogsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.LogsEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.logsStabilityLevel = sl
 		o.createLogsFunc = createLogs
@@ -28,7 +28,9 @@ type TelemetryBuilder struct {
 	registrations             []metric.Registration
 	ProcessorIncomingItems    metric.Int64Counter
 	ProcessorInternalDuration metric.Float64Histogram
+	ProcessorItems            metric.Int64Counter
 	ProcessorOutgoingItems    metric.Int64Counter
+	ProcessorPanics           metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -72,11 +74,23 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("s"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorItems, err = builder.meter.Int64Counter(
+		"otelcol_processor_items",
+		metric.WithDescription("Number of items passed to the processor, by outcome (accepted, refused with a retryable error, failed with a permanent error, or dropped). [alpha]"),
+		metric.WithUnit("{items}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorOutgoingItems, err = builder.meter.Int64Counter(
 		"otelcol_processor_outgoing_items",
 		metric.WithDescription("Number of items emitted from the processor. [alpha]"),
 		metric.WithUnit("{items}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorPanics, err = builder.meter.Int64Counter(
+		"otelcol_processor_panics",
+		metric.WithDescription("Number of panics recovered while processing data. [alpha]"),
+		metric.WithUnit("{panics}"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
@@ -47,6 +47,7 @@ func NewLogs(
 
 	eventOptions := spanAttributes(set.ID)
 	bs := fromOptions(options)
+	guard := newPanicGuard(set, obs, bs)
 	logsConsumer, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
@@ -55,19 +56,21 @@ func NewLogs(
 
 		recordsIn := ld.LogRecordCount()
 
-		var errFunc error
-		ld, errFunc = logsFunc(ctx, ld)
+		errFunc := guard.call(ctx, func() (err error) {
+			ld, err = logsFunc(ctx, ld)
+			return err
+		})
 		obs.recordInternalDuration(ctx, startTime)
 		span.AddEvent("End processing.", eventOptions)
 		if errFunc != nil {
-			obs.recordInOut(ctx, recordsIn, 0)
+			obs.recordInOut(ctx, recordsIn, 0, errFunc)
 			if errors.Is(errFunc, ErrSkipProcessingData) {
 				return nil
 			}
 			return errFunc
 		}
 		recordsOut := ld.LogRecordCount()
-		obs.recordInOut(ctx, recordsIn, recordsOut)
+		obs.recordInOut(ctx, recordsIn, recordsOut, nil)
 		return nextConsumer.ConsumeLogs(ctx, ld)
 	}, bs.consumerOptions...)
 	if err != nil {
@@ -47,6 +47,7 @@ func NewMetrics(
 
 	eventOptions := spanAttributes(set.ID)
 	bs := fromOptions(options)
+	guard := newPanicGuard(set, obs, bs)
 	metricsConsumer, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
@@ -55,19 +56,21 @@ func NewMetrics(
 
 		pointsIn := md.DataPointCount()
 
-		var errFunc error
-		md, errFunc = metricsFunc(ctx, md)
+		errFunc := guard.call(ctx, func() (err error) {
+			md, err = metricsFunc(ctx, md)
+			return err
+		})
 		obs.recordInternalDuration(ctx, startTime)
 		span.AddEvent("End processing.", eventOptions)
 		if errFunc != nil {
-			obs.recordInOut(ctx, pointsIn, 0)
+			obs.recordInOut(ctx, pointsIn, 0, errFunc)
 			if errors.Is(errFunc, ErrSkipProcessingData) {
 				return nil
 			}
 			return errFunc
 		}
 		pointsOut := md.DataPointCount()
-		obs.recordInOut(ctx, pointsIn, pointsOut)
+		obs.recordInOut(ctx, pointsIn, pointsOut, nil)
 		return nextConsumer.ConsumeMetrics(ctx, md)
 	}, bs.consumerOptions...)
 	if err != nil {
@@ -5,11 +5,14 @@ package processorhelper // import "go.opentelemetry.io/collector/processor/proce
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/telemetry"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/internal"
@@ -19,6 +22,7 @@ import (
 const signalKey = "otel.signal"
 
 type obsReport struct {
+	signal           pipeline.Signal
 	otelAttrs        metric.MeasurementOption
 	telemetryBuilder *metadata.TelemetryBuilder
 }
@@ -29,6 +33,7 @@ func newObsReport(set processor.Settings, signal pipeline.Signal) (*obsReport, e
 		return nil, err
 	}
 	return &obsReport{
+		signal: signal,
 		otelAttrs: metric.WithAttributeSet(attribute.NewSet(
 			attribute.String(internal.ProcessorKey, set.ID.String()),
 			attribute.String(signalKey, signal.String()),
@@ -37,12 +42,31 @@ func newObsReport(set processor.Settings, signal pipeline.Signal) (*obsReport, e
 	}, nil
 }
 
-func (or *obsReport) recordInOut(ctx context.Context, incoming, outgoing int) {
+// recordInOut records the items passed to and emitted from the processor, and the items passed to the
+// processor by outcome given the error returned by the processing function, if any.
+func (or *obsReport) recordInOut(ctx context.Context, incoming, outgoing int, err error) {
 	or.telemetryBuilder.ProcessorIncomingItems.Add(ctx, int64(incoming), or.otelAttrs)
 	or.telemetryBuilder.ProcessorOutgoingItems.Add(ctx, int64(outgoing), or.otelAttrs)
+
+	switch {
+	case err == nil:
+		// The items filtered out by the processor are dropped.
+		telemetry.AddItems(ctx, or.telemetryBuilder.ProcessorItems, or.signal, telemetry.OutcomeAccepted, min(incoming, outgoing), or.otelAttrs)
+		telemetry.AddItems(ctx, or.telemetryBuilder.ProcessorItems, or.signal, telemetry.OutcomeDropped, incoming-outgoing, or.otelAttrs)
+	case errors.Is(err, ErrSkipProcessingData):
+		telemetry.AddItems(ctx, or.telemetryBuilder.ProcessorItems, or.signal, telemetry.OutcomeDropped, incoming, or.otelAttrs)
+	case consumererror.IsPermanent(err):
+		telemetry.AddItems(ctx, or.telemetryBuilder.ProcessorItems, or.signal, telemetry.OutcomeFailed, incoming, or.otelAttrs)
+	default:
+		telemetry.AddItems(ctx, or.telemetryBuilder.ProcessorItems, or.signal, telemetry.OutcomeRefused, incoming, or.otelAttrs)
+	}
 }
 
 func (or *obsReport) recordInternalDuration(ctx context.Context, startTime time.Time) {
 	duration := time.Since(startTime)
 	or.telemetryBuilder.ProcessorInternalDuration.Record(ctx, duration.Seconds(), or.otelAttrs)
 }
+
+func (or *obsReport) recordPanic(ctx context.Context) {
+	or.telemetryBuilder.ProcessorPanics.Add(ctx, 1, or.otelAttrs)
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/panicguard"
+	"go.opentelemetry.io/collector/processor"
+)
+
+// WithPanicRecovery recovers the panics of the processing function instead of crashing the collector.
+// A recovered panic is converted to a permanent error, counted by the otelcol_processor_panics metric
+// and reported as a permanent error status event. The processor is then considered failed: the
+// processing function is no longer called, and all the subsequent data is rejected with the same error.
+// Panics of the next consumer are not recovered, as they belong to another component.
+func WithPanicRecovery() Option {
+	return optionFunc(func(o *baseSettings) {
+		o.recoverPanics = true
+	})
+}
+
+// panicGuard recovers the panics of the processing function and fails the processor after the first one.
+type panicGuard struct {
+	*panicguard.Guard
+}
+
+// newPanicGuard returns nil if the panics must not be recovered. bs.StartFunc is wrapped
+// to keep the host, which the status is reported to.
+func newPanicGuard(set processor.Settings, obs *obsReport, bs *baseSettings) *panicGuard {
+	if !bs.recoverPanics {
+		return nil
+	}
+	g := &panicGuard{Guard: panicguard.New(component.KindProcessor, set.ID, set.Logger, obs.recordPanic)}
+	start := bs.StartFunc
+	bs.StartFunc = func(ctx context.Context, host component.Host) error {
+		_ = g.Start(ctx, host)
+		return start.Start(ctx, host)
+	}
+	return g
+}
+
+// call calls f, converting its panic to an error. It returns the error of the first panic without
+// calling f once the processor failed.
+func (g *panicGuard) call(ctx context.Context, f func() error) error {
+	if g == nil {
+		return f()
+	}
+	return g.Call(ctx, f)
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package processorhelper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/processor/processorhelper/internal/metadatatest"
+)
+
+// statusHost records the status events reported by the processor.
+type statusHost struct {
+	component.Host
+	events []*componentstatus.Event
+}
+
+func (h *statusHost) Report(event *componentstatus.Event) {
+	h.events = append(h.events, event)
+}
+
+func TestTracesPanicRecovery(t *testing.T) {
+	calls := 0
+	tel := componenttest.NewTelemetry()
+	sink := new(consumertest.TracesSink)
+	tp, err := NewTraces(context.Background(), newSettings(tel), &testTracesCfg, sink,
+		func(context.Context, ptrace.Traces) (ptrace.Traces, error) {
+			calls++
+			panic("boom")
+		}, WithPanicRecovery())
+	require.NoError(t, err)
+
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, tp.Start(context.Background(), host))
+	err = tp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2))
+	require.EqualError(t, err, "Permanent error: processor \"processorhelper\" panicked: boom")
+	assert.True(t, consumererror.IsPermanent(err))
+	require.Len(t, host.events, 1)
+	assert.Equal(t, componentstatus.StatusPermanentError, host.events[0].Status())
+
+	// The processor failed, the processing function is no longer called.
+	require.ErrorIs(t, tp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)), err)
+	assert.Equal(t, 1, calls)
+	assert.Len(t, host.events, 1)
+	assert.Empty(t, sink.AllTraces())
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	metadatatest.AssertEqualProcessorPanics(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Value:      1,
+				Attributes: attribute.NewSet(attribute.String("processor", "processorhelper"), attribute.String("otel.signal", "traces")),
+			},
+		}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorIncomingItems(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Value:      3,
+				Attributes: attribute.NewSet(attribute.String("processor", "processorhelper"), attribute.String("otel.signal", "traces")),
+			},
+		}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, tel.Shutdown(context.Background()))
+}
+
+func TestMetricsPanicRecovery(t *testing.T) {
+	mp, err := NewMetrics(context.Background(), newSettings(componenttest.NewTelemetry()), &testMetricsCfg, consumertest.NewNop(),
+		func(context.Context, pmetric.Metrics) (pmetric.Metrics, error) {
+			panic("boom")
+		}, WithPanicRecovery())
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+	assert.EqualError(t, mp.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)), "Permanent error: processor \"processorhelper\" panicked: boom")
+}
+
+func TestLogsPanicRecovery(t *testing.T) {
+	lp, err := NewLogs(context.Background(), newSettings(componenttest.NewTelemetry()), &testLogsCfg, consumertest.NewNop(),
+		func(context.Context, plog.Logs) (plog.Logs, error) {
+			panic("boom")
+		}, WithPanicRecovery())
+	require.NoError(t, err)
+	// The panic is recovered even if the processor was not started.
+	assert.EqualError(t, lp.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)), "Permanent error: processor \"processorhelper\" panicked: boom")
+}
+
+func TestPanicNotRecoveredByDefault(t *testing.T) {
+	tp, err := NewTraces(context.Background(), newSettings(componenttest.NewTelemetry()), &testTracesCfg, consumertest.NewNop(),
+		func(context.Context, ptrace.Traces) (ptrace.Traces, error) {
+			panic("boom")
+		})
+	require.NoError(t, err)
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = tp.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	})
+}
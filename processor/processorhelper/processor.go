@@ -60,6 +60,7 @@ type baseSettings struct {
 	component.StartFunc
 	component.ShutdownFunc
 	consumerOptions []consumer.Option
+	recoverPanics   bool
 }
 
 // fromOptions returns the internal settings starting from the default and applying all options.
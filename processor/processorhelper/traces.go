@@ -47,6 +47,7 @@ func NewTraces(
 
 	eventOptions := spanAttributes(set.ID)
 	bs := fromOptions(options)
+	guard := newPanicGuard(set, obs, bs)
 	traceConsumer, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
 		span := trace.SpanFromContext(ctx)
 		span.AddEvent("Start processing.", eventOptions)
@@ -55,19 +56,21 @@ func NewTraces(
 
 		spansIn := td.SpanCount()
 
-		var errFunc error
-		td, errFunc = tracesFunc(ctx, td)
+		errFunc := guard.call(ctx, func() (err error) {
+			td, err = tracesFunc(ctx, td)
+			return err
+		})
 		obs.recordInternalDuration(ctx, startTime)
 		span.AddEvent("End processing.", eventOptions)
 		if errFunc != nil {
-			obs.recordInOut(ctx, spansIn, 0)
+			obs.recordInOut(ctx, spansIn, 0, errFunc)
 			if errors.Is(errFunc, ErrSkipProcessingData) {
 				return nil
 			}
 			return errFunc
 		}
 		spansOut := td.SpanCount()
-		obs.recordInOut(ctx, spansIn, spansOut)
+		obs.recordInOut(ctx, spansIn, spansOut, nil)
 		return nextConsumer.ConsumeTraces(ctx, td)
 	}, bs.consumerOptions...)
 	if err != nil {
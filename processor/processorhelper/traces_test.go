@@ -18,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processorhelper/internal/metadatatest"
@@ -144,6 +145,17 @@ func TestTraces_RecordInOut(t *testing.T) {
 				Attributes: attribute.NewSet(attribute.String("processor", "processorhelper"), attribute.String("otel.signal", "traces")),
 			},
 		}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorItems(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Value:      1,
+				Attributes: attribute.NewSet(attribute.String("processor", "processorhelper"), attribute.String("otel.signal", "traces"), attribute.String("outcome", "accepted")),
+			},
+			{
+				Value:      3,
+				Attributes: attribute.NewSet(attribute.String("processor", "processorhelper"), attribute.String("otel.signal", "traces"), attribute.String("outcome", "dropped")),
+			},
+		}, metricdatatest.IgnoreTimestamp())
 }
 
 func TestTraces_RecordIn_ErrorOut(t *testing.T) {
@@ -183,6 +195,39 @@ func TestTraces_RecordIn_ErrorOut(t *testing.T) {
 				Attributes: attribute.NewSet(attribute.String("processor", "processorhelper"), attribute.String("otel.signal", "traces")),
 			},
 		}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorItems(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Value:      4,
+				Attributes: attribute.NewSet(attribute.String("processor", "processorhelper"), attribute.String("otel.signal", "traces"), attribute.String("outcome", "refused")),
+			},
+		}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestTraces_RecordItems(t *testing.T) {
+	incomingTraces := ptrace.NewTraces()
+	incomingTraces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+
+	tel := componenttest.NewTelemetry()
+	tp, err := NewTraces(context.Background(), newSettings(tel), &testTracesCfg, consumertest.NewNop(), newTestTProcessor(ErrSkipProcessingData))
+	require.NoError(t, err)
+	require.NoError(t, tp.ConsumeTraces(context.Background(), incomingTraces))
+
+	tp, err = NewTraces(context.Background(), newSettings(tel), &testTracesCfg, consumertest.NewNop(), newTestTProcessor(consumererror.NewPermanent(errors.New("fake"))))
+	require.NoError(t, err)
+	require.Error(t, tp.ConsumeTraces(context.Background(), incomingTraces))
+
+	metadatatest.AssertEqualProcessorItems(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Value:      1,
+				Attributes: attribute.NewSet(attribute.String("processor", "processorhelper"), attribute.String("otel.signal", "traces"), attribute.String("outcome", "dropped")),
+			},
+			{
+				Value:      1,
+				Attributes: attribute.NewSet(attribute.String("processor", "processorhelper"), attribute.String("otel.signal", "traces"), attribute.String("outcome", "failed")),
+			},
+		}, metricdatatest.IgnoreTimestamp())
 }
 
 func TestTraces_ProcessInternalDuration(t *testing.T) {
@@ -27,6 +27,10 @@ type Factory interface {
 
 	// ProfilesStability gets the stability level of the Profiles processor.
 	ProfilesStability() component.StabilityLevel
+
+	// SharedInstance returns whether the pipelines using the same processor configuration
+	// for a given signal can share a single instance of the processor. See [WithSharedInstance].
+	SharedInstance() bool
 }
 
 // Profiles is a processor that can consume profiles.
@@ -56,12 +60,17 @@ type factory struct {
 	processor.Factory
 	createProfilesFunc     CreateProfilesFunc
 	profilesStabilityLevel component.StabilityLevel
+	sharedInstance         bool
 }
 
 func (f factory) ProfilesStability() component.StabilityLevel {
 	return f.profilesStabilityLevel
 }
 
+func (f factory) SharedInstance() bool {
+	return f.sharedInstance
+}
+
 func (f factory) CreateProfiles(ctx context.Context, set processor.Settings, cfg component.Config, next xconsumer.Profiles) (Profiles, error) {
 	if f.createProfilesFunc == nil {
 		return nil, pipeline.ErrSignalNotSupported
@@ -72,6 +81,9 @@ func (f factory) CreateProfiles(ctx context.Context, set processor.Settings, cfg
 	return f.createProfilesFunc(ctx, set, cfg, next)
 }
 
+// factoryOpts collects the options of the base factory. Those options are created by the With functions,
+// and not by the closures they return, for the closures not to keep the create functions of the signals
+// left out of the distribution.
 type factoryOpts struct {
 	opts []processor.FactoryOption
 	*factory
@@ -79,33 +91,52 @@ type factoryOpts struct {
 
 // WithTraces overrides the default "error not supported" implementation for CreateTraces and the default "undefined" stability level.
 func WithTraces(createTraces processor.CreateTracesFunc, sl component.StabilityLevel) FactoryOption {
+	opt := processor.WithTraces(createTraces, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, processor.WithTraces(createTraces, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithMetrics overrides the default "error not supported" implementation for CreateMetrics and the default "undefined" stability level.
 func WithMetrics(createMetrics processor.CreateMetricsFunc, sl component.StabilityLevel) FactoryOption {
+	opt := processor.WithMetrics(createMetrics, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, processor.WithMetrics(createMetrics, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithLogs overrides the default "error not supported" implementation for CreateLogs and the default "undefined" stability level.
 func WithLogs(createLogs processor.CreateLogsFunc, sl component.StabilityLevel) FactoryOption {
+	opt := processor.WithLogs(createLogs, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, processor.WithLogs(createLogs, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithProfiles overrides the default "error not supported" implementation for CreateProfiles and the default "undefined" stability level.
 func WithProfiles(createProfiles CreateProfilesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.ProfilesEnabled {
+		return factoryOptionFunc(func(*factoryOpts) {})
+	}
 	return factoryOptionFunc(func(o *factoryOpts) {
 		o.profilesStabilityLevel = sl
 		o.createProfilesFunc = createProfiles
 	})
 }
 
+// WithSharedInstance declares that the processor can be shared by all the pipelines of a signal
+// using the same processor configuration, instead of creating one instance per pipeline.
+//
+// The service then creates a single instance, whose next consumer routes the data back to the
+// pipeline it was received from. This requires the processor to hold no state specific to a
+// pipeline, and to call the next consumer synchronously with the context it was called with,
+// or one derived from it. Processors buffering data, such as the batch processor, must not use it.
+func WithSharedInstance() FactoryOption {
+	return factoryOptionFunc(func(o *factoryOpts) {
+		o.sharedInstance = true
+	})
+}
+
 // NewFactory returns a Factory.
 func NewFactory(cfgType component.Type, createDefaultConfig component.CreateDefaultConfigFunc, options ...FactoryOption) Factory {
 	opts := factoryOpts{factory: &factory{}}
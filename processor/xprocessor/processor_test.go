@@ -31,6 +31,7 @@ func TestNewFactoryWithProfiles(t *testing.T) {
 	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
 
 	assert.Equal(t, component.StabilityLevelAlpha, factory.ProfilesStability())
+	assert.False(t, factory.SharedInstance())
 	_, err := factory.CreateProfiles(context.Background(), processor.Settings{ID: testID}, &defaultCfg, consumertest.NewNop())
 	require.NoError(t, err)
 
@@ -40,6 +41,16 @@ func TestNewFactoryWithProfiles(t *testing.T) {
 	assert.EqualError(t, err, wrongIDErrStr)
 }
 
+func TestNewFactoryWithSharedInstance(t *testing.T) {
+	factory := NewFactory(
+		component.MustNewType("test"),
+		func() component.Config { return &struct{}{} },
+		WithProfiles(createProfiles, component.StabilityLevelAlpha),
+		WithSharedInstance(),
+	)
+	assert.True(t, factory.SharedInstance())
+}
+
 var nopInstance = &nopProcessor{
 	Consumer: consumertest.NewNop(),
 }
@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build otelcol_no_logs
+
+package internal // import "go.opentelemetry.io/collector/receiver/internal"
+
+// LogsEnabled is false when the distribution is built with the otelcol_no_logs build tag: the factory
+// options of the logs are then no-ops, which lets the linker leave out the logs code of the components.
+const LogsEnabled = false
@@ -6,8 +6,10 @@ package errors // import "go.opentelemetry.io/collector/receiver/otlpreceiver/in
 import (
 	"net/http"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"go.opentelemetry.io/collector/consumer/consumererror"
 )
@@ -23,6 +25,12 @@ func GetStatusFromError(err error) error {
 			code = codes.Internal
 		}
 		s = status.New(code, err.Error())
+		if delay := consumererror.RetryDelay(err); delay > 0 && code == codes.Unavailable {
+			// Tell the sender when to retry, also as the Retry-After header for HTTP.
+			if detailed, detailsErr := s.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}); detailsErr == nil {
+				s = detailed
+			}
+		}
 	}
 	return s.Err()
 }
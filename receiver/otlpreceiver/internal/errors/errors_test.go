@@ -7,12 +7,14 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/statusutil"
 )
 
 func Test_GetStatusFromError(t *testing.T) {
@@ -45,6 +47,13 @@ func Test_GetStatusFromError(t *testing.T) {
 	}
 }
 
+func Test_GetStatusFromErrorRetryDelay(t *testing.T) {
+	s, ok := status.FromError(GetStatusFromError(consumererror.NewRetryableErrorWithDelay(errors.New("test"), 5*time.Second)))
+	assert.True(t, ok)
+	assert.Equal(t, codes.Unavailable, s.Code())
+	assert.Equal(t, 5*time.Second, statusutil.GetRetryInfo(s).GetRetryDelay().AsDuration())
+}
+
 func Test_GetHTTPStatusCodeFromStatus(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -7,6 +7,8 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
@@ -19,6 +21,9 @@ type Receiver struct {
 	plogotlp.UnimplementedGRPCServer
 	nextConsumer consumer.Logs
 	obsreport    *receiverhelper.ObsReport
+	// passthrough is whether the next consumer does not mutate the data, so that the exporters can send
+	// the OTLP protobuf encoding the data was received as.
+	passthrough bool
 }
 
 // New creates a new Receiver reference.
@@ -26,9 +31,24 @@ func New(nextConsumer consumer.Logs, obsreport *receiverhelper.ObsReport) *Recei
 	return &Receiver{
 		nextConsumer: nextConsumer,
 		obsreport:    obsreport,
+		passthrough:  !nextConsumer.Capabilities().MutatesData,
 	}
 }
 
+// Passthrough returns whether the exporters can send the OTLP protobuf encoding the data was received as.
+func (r *Receiver) Passthrough() bool {
+	return r.passthrough
+}
+
+// ExportProto is like Export for a request received as its OTLP protobuf encoding buf, which the
+// exporters can send as is if Passthrough.
+func (r *Receiver) ExportProto(ctx context.Context, req plogotlp.ExportRequest, buf []byte) (plogotlp.ExportResponse, error) {
+	if r.passthrough {
+		ctx = xconsumer.ContextWithLogsProto(ctx, req.Logs(), buf)
+	}
+	return r.Export(ctx, req)
+}
+
 // Export implements the service Export logs func.
 func (r *Receiver) Export(ctx context.Context, req plogotlp.ExportRequest) (plogotlp.ExportResponse, error) {
 	ld := req.Logs()
@@ -47,6 +67,13 @@ func (r *Receiver) Export(ctx context.Context, req plogotlp.ExportRequest) (plog
 	// So, convert the error to appropriate grpc status and return the error
 	// NonPermanent errors will be converted to codes.Unavailable (equivalent to HTTP 503)
 	// Permanent errors will be converted to codes.InvalidArgument (equivalent to HTTP 400)
+	if rejected, ok := consumererror.Rejected(err); ok {
+		// The data was consumed except the rejected items, which must not be retried.
+		resp := plogotlp.NewExportResponse()
+		resp.PartialSuccess().SetRejectedLogRecords(int64(rejected))
+		resp.PartialSuccess().SetErrorMessage(err.Error())
+		return resp, nil
+	}
 	if err != nil {
 		return plogotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
@@ -6,6 +6,7 @@ package profiles // import "go.opentelemetry.io/collector/receiver/otlpreceiver/
 import (
 	"context"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/pdata/pprofile/pprofileotlp"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
@@ -40,6 +41,13 @@ func (r *Receiver) Export(ctx context.Context, req pprofileotlp.ExportRequest) (
 	// So, convert the error to appropriate grpc status and return the error
 	// NonPermanent errors will be converted to codes.Unavailable (equivalent to HTTP 503)
 	// Permanent errors will be converted to codes.InvalidArgument (equivalent to HTTP 400)
+	if rejected, ok := consumererror.Rejected(err); ok {
+		// The data was consumed except the rejected items, which must not be retried.
+		resp := pprofileotlp.NewExportResponse()
+		resp.PartialSuccess().SetRejectedProfiles(int64(rejected))
+		resp.PartialSuccess().SetErrorMessage(err.Error())
+		return resp, nil
+	}
 	if err != nil {
 		return pprofileotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
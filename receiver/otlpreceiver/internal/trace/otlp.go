@@ -7,6 +7,8 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
@@ -19,6 +21,9 @@ type Receiver struct {
 	ptraceotlp.UnimplementedGRPCServer
 	nextConsumer consumer.Traces
 	obsreport    *receiverhelper.ObsReport
+	// passthrough is whether the next consumer does not mutate the data, so that the exporters can send
+	// the OTLP protobuf encoding the data was received as.
+	passthrough bool
 }
 
 // New creates a new Receiver reference.
@@ -26,9 +31,24 @@ func New(nextConsumer consumer.Traces, obsreport *receiverhelper.ObsReport) *Rec
 	return &Receiver{
 		nextConsumer: nextConsumer,
 		obsreport:    obsreport,
+		passthrough:  !nextConsumer.Capabilities().MutatesData,
 	}
 }
 
+// Passthrough returns whether the exporters can send the OTLP protobuf encoding the data was received as.
+func (r *Receiver) Passthrough() bool {
+	return r.passthrough
+}
+
+// ExportProto is like Export for a request received as its OTLP protobuf encoding buf, which the
+// exporters can send as is if Passthrough.
+func (r *Receiver) ExportProto(ctx context.Context, req ptraceotlp.ExportRequest, buf []byte) (ptraceotlp.ExportResponse, error) {
+	if r.passthrough {
+		ctx = xconsumer.ContextWithTracesProto(ctx, req.Traces(), buf)
+	}
+	return r.Export(ctx, req)
+}
+
 // Export implements the service Export traces func.
 func (r *Receiver) Export(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
 	td := req.Traces()
@@ -48,6 +68,13 @@ func (r *Receiver) Export(ctx context.Context, req ptraceotlp.ExportRequest) (pt
 	// So, convert the error to appropriate grpc status and return the error
 	// NonPermanent errors will be converted to codes.Unavailable (equivalent to HTTP 503)
 	// Permanent errors will be converted to codes.InvalidArgument (equivalent to HTTP 400)
+	if rejected, ok := consumererror.Rejected(err); ok {
+		// The data was consumed except the rejected items, which must not be retried.
+		resp := ptraceotlp.NewExportResponse()
+		resp.PartialSuccess().SetRejectedSpans(int64(rejected))
+		resp.PartialSuccess().SetErrorMessage(err.Error())
+		return resp, nil
+	}
 	if err != nil {
 		return ptraceotlp.NewExportResponse(), errors.GetStatusFromError(err)
 	}
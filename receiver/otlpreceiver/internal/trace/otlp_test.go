@@ -20,6 +20,8 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metadata"
@@ -71,6 +73,58 @@ func TestExport_PermanentErrorConsumer(t *testing.T) {
 	assert.Equal(t, ptraceotlp.ExportResponse{}, resp)
 }
 
+func TestExport_PartialErrorConsumer(t *testing.T) {
+	td := testdata.GenerateTraces(3)
+	req := ptraceotlp.NewExportRequestFromTraces(td)
+
+	traceClient := makeTraceServiceClient(t, consumertest.NewErr(consumererror.NewPartial(errors.New("my error"), 2)))
+	resp, err := traceClient.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "Partially consumed, 2 items rejected: my error", resp.PartialSuccess().ErrorMessage())
+}
+
+func TestExportProto(t *testing.T) {
+	for _, mutatesData := range []bool{false, true} {
+		td := testdata.GenerateTraces(1)
+		req := ptraceotlp.NewExportRequestFromTraces(td)
+		buf, err := req.MarshalProto()
+		require.NoError(t, err)
+
+		var got []byte
+		var ok bool
+		next, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+			got, ok = xconsumer.TracesProto(ctx, td)
+			return nil
+		}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: mutatesData}))
+		require.NoError(t, err)
+
+		r := New(next, newObsReport(t))
+		assert.Equal(t, !mutatesData, r.Passthrough())
+		_, err = r.ExportProto(context.Background(), req, buf)
+		require.NoError(t, err)
+		if mutatesData {
+			assert.False(t, ok)
+			assert.False(t, td.IsReadOnly())
+		} else {
+			assert.True(t, ok)
+			assert.Equal(t, buf, got)
+		}
+	}
+}
+
+func TestExport_PartialAndOtherErrorConsumer(t *testing.T) {
+	td := testdata.GenerateTraces(3)
+	req := ptraceotlp.NewExportRequestFromTraces(td)
+
+	// The error of another consumer is not hidden by the partial success.
+	joinedErr := errors.Join(consumererror.NewPartial(errors.New("my error"), 2), errors.New("unavailable"))
+	traceClient := makeTraceServiceClient(t, consumertest.NewErr(joinedErr))
+	resp, err := traceClient.Export(context.Background(), req)
+	require.ErrorContains(t, err, "unavailable")
+	assert.Equal(t, ptraceotlp.ExportResponse{}, resp)
+}
+
 func makeTraceServiceClient(t *testing.T, tc consumer.Traces) ptraceotlp.GRPCClient {
 	addr := otlpReceiverOnGRPCServer(t, tc)
 	cc, err := grpc.NewClient(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -90,15 +144,7 @@ func otlpReceiverOnGRPCServer(t *testing.T, tc consumer.Traces) net.Addr {
 		require.NoError(t, ln.Close())
 	})
 
-	set := receivertest.NewNopSettings(metadata.Type)
-	set.ID = component.MustNewIDWithName("otlp", "trace")
-	obsreport, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
-		ReceiverID:             set.ID,
-		Transport:              "grpc",
-		ReceiverCreateSettings: set,
-	})
-	require.NoError(t, err)
-	r := New(tc, obsreport)
+	r := New(tc, newObsReport(t))
 	// Now run it as a gRPC server
 	srv := grpc.NewServer()
 	ptraceotlp.RegisterGRPCServer(srv, r)
@@ -108,3 +154,15 @@ func otlpReceiverOnGRPCServer(t *testing.T, tc consumer.Traces) net.Addr {
 
 	return ln.Addr()
 }
+
+func newObsReport(t *testing.T) *receiverhelper.ObsReport {
+	set := receivertest.NewNopSettings(metadata.Type)
+	set.ID = component.MustNewIDWithName("otlp", "trace")
+	obsreport, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
+		ReceiverID:             set.ID,
+		Transport:              "grpc",
+		ReceiverCreateSettings: set,
+	})
+	require.NoError(t, err)
+	return obsreport
+}
@@ -15,9 +15,11 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/internal/grpcraw"
 	"go.opentelemetry.io/collector/internal/telemetry"
 	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
@@ -92,22 +94,58 @@ func (r *otlpReceiver) startGRPCServer(ctx context.Context, host component.Host)
 		return nil
 	}
 
+	var (
+		tracesReceiver  *trace.Receiver
+		metricsReceiver *metrics.Receiver
+		logsReceiver    *logs.Receiver
+		passthrough     bool
+	)
+	if r.nextTraces != nil {
+		tracesReceiver = trace.New(r.nextTraces, r.obsrepGRPC)
+		passthrough = passthrough || tracesReceiver.Passthrough()
+	}
+	if r.nextMetrics != nil {
+		metricsReceiver = metrics.New(r.nextMetrics, r.obsrepGRPC)
+		passthrough = passthrough || metricsReceiver.Passthrough()
+	}
+	if r.nextLogs != nil {
+		logsReceiver = logs.New(r.nextLogs, r.obsrepGRPC)
+		passthrough = passthrough || logsReceiver.Passthrough()
+	}
+
 	grpcCfg := r.cfg.GRPC.Get()
+	var opts []configgrpc.ToServerOption
+	if passthrough {
+		// Receives the requests of the signals passed through as their protobuf encoding.
+		opts = append(opts, configgrpc.WithGrpcServerOption(grpc.ForceServerCodecV2(grpcraw.Codec{})))
+	}
 	var err error
-	if r.serverGRPC, err = grpcCfg.ToServer(ctx, host, r.settings.TelemetrySettings); err != nil {
+	if r.serverGRPC, err = grpcCfg.ToServer(ctx, host, r.settings.TelemetrySettings, opts...); err != nil {
 		return err
 	}
 
-	if r.nextTraces != nil {
-		ptraceotlp.RegisterGRPCServer(r.serverGRPC, trace.New(r.nextTraces, r.obsrepGRPC))
+	switch {
+	case tracesReceiver == nil:
+	case tracesReceiver.Passthrough():
+		registerPassthroughService(r.serverGRPC, grpcraw.TracesExportMethod, ptraceotlp.NewExportRequest, tracesReceiver.ExportProto)
+	default:
+		ptraceotlp.RegisterGRPCServer(r.serverGRPC, tracesReceiver)
 	}
 
-	if r.nextMetrics != nil {
-		pmetricotlp.RegisterGRPCServer(r.serverGRPC, metrics.New(r.nextMetrics, r.obsrepGRPC))
+	switch {
+	case metricsReceiver == nil:
+	case metricsReceiver.Passthrough():
+		registerPassthroughService(r.serverGRPC, grpcraw.MetricsExportMethod, pmetricotlp.NewExportRequest, metricsReceiver.ExportProto)
+	default:
+		pmetricotlp.RegisterGRPCServer(r.serverGRPC, metricsReceiver)
 	}
 
-	if r.nextLogs != nil {
-		plogotlp.RegisterGRPCServer(r.serverGRPC, logs.New(r.nextLogs, r.obsrepGRPC))
+	switch {
+	case logsReceiver == nil:
+	case logsReceiver.Passthrough():
+		registerPassthroughService(r.serverGRPC, grpcraw.LogsExportMethod, plogotlp.NewExportRequest, logsReceiver.ExportProto)
+	default:
+		plogotlp.RegisterGRPCServer(r.serverGRPC, logsReceiver)
 	}
 
 	if r.nextProfiles != nil {
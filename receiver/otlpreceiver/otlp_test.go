@@ -41,8 +41,10 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/pprofile"
@@ -416,6 +418,48 @@ func TestProtoHttp(t *testing.T) {
 	}
 }
 
+func TestProtoPassthrough(t *testing.T) {
+	grpcAddr := testutil.GetAvailableLocalAddress(t)
+	httpAddr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.GetOrInsertDefault().NetAddr.Endpoint = grpcAddr
+	cfg.HTTP.GetOrInsertDefault().ServerConfig.Endpoint = httpAddr
+	sink := &protoSink{Consumer: consumertest.NewNop()}
+	recv := newReceiver(t, componenttest.NewNopTelemetrySettings(), cfg, otlpReceiverID, sink)
+	require.NoError(t, recv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+	cc, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, cc.Close()) })
+
+	tracesReq := ptraceotlp.NewExportRequestFromTraces(testdata.GenerateTraces(2))
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), tracesReq)
+	require.NoError(t, err)
+	metricsReq := pmetricotlp.NewExportRequestFromMetrics(testdata.GenerateMetrics(2))
+	_, err = pmetricotlp.NewGRPCClient(cc).Export(context.Background(), metricsReq)
+	require.NoError(t, err)
+	logsReq := plogotlp.NewExportRequestFromLogs(testdata.GenerateLogs(2))
+	_, err = plogotlp.NewGRPCClient(cc).Export(context.Background(), logsReq)
+	require.NoError(t, err)
+
+	tracesProto, err := tracesReq.MarshalProto()
+	require.NoError(t, err)
+	metricsProto, err := metricsReq.MarshalProto()
+	require.NoError(t, err)
+	logsProto, err := logsReq.MarshalProto()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{tracesProto, metricsProto, logsProto}, sink.protos())
+
+	sink.reset()
+	for _, dr := range generateDataRequests(t)[:3] {
+		doHTTPRequest(t, "http://"+httpAddr+dr.path, "", "application/x-protobuf", dr.protoBytes, 0)
+		// The data received as JSON has no OTLP protobuf encoding to pass through.
+		doHTTPRequest(t, "http://"+httpAddr+dr.path, "", "application/json", dr.jsonBytes, 0)
+	}
+	assert.Equal(t, [][]byte{tracesProto, nil, metricsProto, nil, logsProto, nil}, sink.protos())
+}
+
 func TestOTLPReceiverInvalidContentEncoding(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1275,25 +1319,75 @@ func (esc *errOrSinkConsumer) Reset() {
 }
 
 // Reset deletes any stored in the sinks, resets error to nil.
+// protoSink records the OTLP protobuf encoding passed through with the data it consumes, or nil.
+type protoSink struct {
+	consumertest.Consumer
+	mu  sync.Mutex
+	buf [][]byte
+}
+
+func (ps *protoSink) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	buf, _ := xconsumer.TracesProto(ctx, td)
+	ps.record(buf)
+	return nil
+}
+
+func (ps *protoSink) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	buf, _ := xconsumer.MetricsProto(ctx, md)
+	ps.record(buf)
+	return nil
+}
+
+func (ps *protoSink) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	buf, _ := xconsumer.LogsProto(ctx, ld)
+	ps.record(buf)
+	return nil
+}
+
+func (ps *protoSink) record(buf []byte) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.buf = append(ps.buf, buf)
+}
+
+func (ps *protoSink) protos() [][]byte {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.buf
+}
+
+func (ps *protoSink) reset() {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.buf = nil
+}
+
 func (esc *errOrSinkConsumer) checkData(t *testing.T, data any, dataLen int) {
 	switch data.(type) {
 	case ptrace.Traces:
 		allTraces := esc.AllTraces()
 		require.Len(t, allTraces, dataLen)
 		if dataLen > 0 {
-			require.Equal(t, allTraces[0], data)
+			// The data decoded from protobuf may be read-only to pass its encoding through, compare a copy.
+			got := ptrace.NewTraces()
+			allTraces[0].CopyTo(got)
+			require.Equal(t, data, got)
 		}
 	case pmetric.Metrics:
 		allMetrics := esc.AllMetrics()
 		require.Len(t, allMetrics, dataLen)
 		if dataLen > 0 {
-			require.Equal(t, allMetrics[0], data)
+			got := pmetric.NewMetrics()
+			allMetrics[0].CopyTo(got)
+			require.Equal(t, data, got)
 		}
 	case plog.Logs:
 		allLogs := esc.AllLogs()
 		require.Len(t, allLogs, dataLen)
 		if dataLen > 0 {
-			require.Equal(t, allLogs[0], data)
+			got := plog.NewLogs()
+			allLogs[0].CopyTo(got)
+			require.Equal(t, data, got)
 		}
 	case pprofile.Profiles:
 		allProfiles := esc.AllProfiles()
@@ -14,6 +14,9 @@ import (
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/internal/statusutil"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/errors"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
@@ -43,7 +46,12 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 		return
 	}
 
-	otlpResp, err := tracesReceiver.Export(req.Context(), otlpReq)
+	var otlpResp ptraceotlp.ExportResponse
+	if enc == pbEncoder {
+		otlpResp, err = tracesReceiver.ExportProto(req.Context(), otlpReq, body)
+	} else {
+		otlpResp, err = tracesReceiver.Export(req.Context(), otlpReq)
+	}
 	if err != nil {
 		writeError(resp, enc, err, http.StatusInternalServerError)
 		return
@@ -74,7 +82,12 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 		return
 	}
 
-	otlpResp, err := metricsReceiver.Export(req.Context(), otlpReq)
+	var otlpResp pmetricotlp.ExportResponse
+	if enc == pbEncoder {
+		otlpResp, err = metricsReceiver.ExportProto(req.Context(), otlpReq, body)
+	} else {
+		otlpResp, err = metricsReceiver.Export(req.Context(), otlpReq)
+	}
 	if err != nil {
 		writeError(resp, enc, err, http.StatusInternalServerError)
 		return
@@ -105,7 +118,12 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 		return
 	}
 
-	otlpResp, err := logsReceiver.Export(req.Context(), otlpReq)
+	var otlpResp plogotlp.ExportResponse
+	if enc == pbEncoder {
+		otlpResp, err = logsReceiver.ExportProto(req.Context(), otlpReq, body)
+	} else {
+		otlpResp, err = logsReceiver.Export(req.Context(), otlpReq)
+	}
 	if err != nil {
 		writeError(resp, enc, err, http.StatusInternalServerError)
 		return
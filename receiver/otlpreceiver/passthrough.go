@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/internal/grpcraw"
+)
+
+type protoRequest interface {
+	UnmarshalProto([]byte) error
+}
+
+type protoResponse interface {
+	MarshalProto() ([]byte, error)
+}
+
+// registerPassthroughService registers the gRPC service of the OTLP Export method, which receives the
+// requests as their protobuf encoding, decodes them, and passes the encoding to export along with the
+// request so that the exporters can send it as is. The server must use the grpcraw.Codec.
+func registerPassthroughService[Req protoRequest, Resp protoResponse](
+	s *grpc.Server,
+	method string,
+	newRequest func() Req,
+	export func(context.Context, Req, []byte) (Resp, error),
+) {
+	serviceName, methodName, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	handle := func(ctx context.Context, msg any) (any, error) {
+		buf := msg.(*grpcraw.Message).Data
+		req := newRequest()
+		if err := req.UnmarshalProto(buf); err != nil {
+			return nil, status.Errorf(codes.Internal, "grpc: error unmarshalling request: %v", err)
+		}
+		resp, err := export(ctx, req, buf)
+		if err != nil {
+			return nil, err
+		}
+		data, err := resp.MarshalProto()
+		if err != nil {
+			return nil, err
+		}
+		return &grpcraw.Message{Data: data}, nil
+	}
+
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: serviceName,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: methodName,
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				msg := &grpcraw.Message{}
+				if err := dec(msg); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return handle(ctx, msg)
+				}
+				return interceptor(ctx, msg, &grpc.UnaryServerInfo{Server: srv, FullMethod: method}, handle)
+			},
+		}},
+	}, struct{}{})
+}
@@ -179,6 +179,9 @@ func (f *factory) CreateLogs(ctx context.Context, set Settings, cfg component.Co
 
 // WithTraces overrides the default "error not supported" implementation for Factory.CreateTraces and the default "undefined" stability level.
 func WithTraces(createTraces CreateTracesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.TracesEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.tracesStabilityLevel = sl
 		o.createTracesFunc = createTraces
@@ -187,6 +190,9 @@ func WithTraces(createTraces CreateTracesFunc, sl component.StabilityLevel) Fact
 
 // WithMetrics overrides the default "error not supported" implementation for Factory.CreateMetrics and the default "undefined" stability level.
 func WithMetrics(createMetrics CreateMetricsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.MetricsEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.metricsStabilityLevel = sl
 		o.createMetricsFunc = createMetrics
@@ -195,6 +201,9 @@ func WithMetrics(createMetrics CreateMetricsFunc, sl component.StabilityLevel) F
 
 // WithLogs overrides the default "error not supported" implementation for Factory.CreateLogs and the default "undefined" stability level.
 func WithLogs(createLogs CreateLogsFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.LogsEnabled {
+		return factoryOptionFunc(func(*factory) {})
+	}
 	return factoryOptionFunc(func(o *factory) {
 		o.logsStabilityLevel = sl
 		o.createLogsFunc = createLogs
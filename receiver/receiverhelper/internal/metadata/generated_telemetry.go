@@ -32,9 +32,11 @@ type TelemetryBuilder struct {
 	ReceiverFailedLogRecords     metric.Int64Counter
 	ReceiverFailedMetricPoints   metric.Int64Counter
 	ReceiverFailedSpans          metric.Int64Counter
+	ReceiverItems                metric.Int64Counter
 	ReceiverRefusedLogRecords    metric.Int64Counter
 	ReceiverRefusedMetricPoints  metric.Int64Counter
 	ReceiverRefusedSpans         metric.Int64Counter
+	ReceiverRequestDuration      metric.Float64Histogram
 	ReceiverRequests             metric.Int64Counter
 }
 
@@ -103,6 +105,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ReceiverItems, err = builder.meter.Int64Counter(
+		"otelcol_receiver_items",
+		metric.WithDescription("Number of items received, by outcome (accepted, refused with a retryable error, failed with a permanent error, or dropped). [alpha]"),
+		metric.WithUnit("{items}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ReceiverRefusedLogRecords, err = builder.meter.Int64Counter(
 		"otelcol_receiver_refused_log_records",
 		metric.WithDescription("Number of log records that could not be pushed into the pipeline. [alpha]"),
@@ -121,6 +129,13 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ReceiverRequestDuration, err = builder.meter.Float64Histogram(
+		"otelcol_receiver_request_duration",
+		metric.WithDescription("Duration of the requests, until the data was pushed into the pipeline or failed to be. The measurements have exemplars linking them to the receive spans when the internal traces are enabled. [alpha]"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries([]float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10, 30, 60}...),
+	)
+	errs = errors.Join(errs, err)
 	builder.ReceiverRequests, err = builder.meter.Int64Counter(
 		"otelcol_receiver_requests",
 		metric.WithDescription("The number of requests performed. [alpha]"),
@@ -25,9 +25,11 @@ func TestSetupTelemetry(t *testing.T) {
 	tb.ReceiverFailedLogRecords.Add(context.Background(), 1)
 	tb.ReceiverFailedMetricPoints.Add(context.Background(), 1)
 	tb.ReceiverFailedSpans.Add(context.Background(), 1)
+	tb.ReceiverItems.Add(context.Background(), 1)
 	tb.ReceiverRefusedLogRecords.Add(context.Background(), 1)
 	tb.ReceiverRefusedMetricPoints.Add(context.Background(), 1)
 	tb.ReceiverRefusedSpans.Add(context.Background(), 1)
+	tb.ReceiverRequestDuration.Record(context.Background(), 1)
 	tb.ReceiverRequests.Add(context.Background(), 1)
 	AssertEqualReceiverAcceptedLogRecords(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
@@ -47,6 +49,9 @@ func TestSetupTelemetry(t *testing.T) {
 	AssertEqualReceiverFailedSpans(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualReceiverItems(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualReceiverRefusedLogRecords(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
@@ -56,6 +61,9 @@ func TestSetupTelemetry(t *testing.T) {
 	AssertEqualReceiverRefusedSpans(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualReceiverRequestDuration(t, testTel,
+		[]metricdata.HistogramDataPoint[float64]{{}}, metricdatatest.IgnoreValue(),
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualReceiverRequests(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
@@ -7,6 +7,7 @@ package receiverhelper // import "go.opentelemetry.io/collector/receiver/receive
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -15,12 +16,16 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/telemetry"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper/internal"
 	"go.opentelemetry.io/collector/receiver/receiverhelper/internal/metadata"
 )
 
+// startTimeKey is the context key of the start time of a receive operation.
+type startTimeKey struct{}
+
 // ObsReport is a helper to add observability to a receiver.
 type ObsReport struct {
 	spanNamePrefix string
@@ -148,7 +153,7 @@ func (rec *ObsReport) startOp(receiverCtx context.Context, operationSuffix strin
 	if rec.transport != "" {
 		span.SetAttributes(attribute.String(internal.TransportKey, rec.transport))
 	}
-	return ctx
+	return context.WithValue(ctx, startTimeKey{}, time.Now())
 }
 
 // endOp records the observability signals at the end of an operation.
@@ -179,7 +184,10 @@ func (rec *ObsReport) endOp(
 
 	span := trace.SpanFromContext(receiverCtx)
 
-	rec.recordMetrics(receiverCtx, signal, numAccepted, numRefused, numFailedErrors)
+	if telemetry.LegacyOutcomeMetricsGate.IsEnabled() {
+		rec.recordMetrics(receiverCtx, signal, numAccepted, numRefused, numFailedErrors)
+	}
+	rec.recordItems(receiverCtx, signal, numReceivedItems, err)
 
 	// The new otelcol_receiver_requests metric is only emitted when the feature gate is enabled.
 	if NewReceiverMetricsGate.IsEnabled() {
@@ -192,7 +200,12 @@ func (rec *ObsReport) endOp(
 		default:
 			outcome = "failure"
 		}
-		rec.telemetryBuilder.ReceiverRequests.Add(receiverCtx, 1, rec.otelAttrs, metric.WithAttributeSet(attribute.NewSet(attribute.String("outcome", outcome))))
+		outcomeAttr := metric.WithAttributeSet(attribute.NewSet(attribute.String("outcome", outcome)))
+		// The receiverCtx has the span of the operation, so that the measurements link to it with an exemplar.
+		rec.telemetryBuilder.ReceiverRequests.Add(receiverCtx, 1, rec.otelAttrs, outcomeAttr)
+		if start, ok := receiverCtx.Value(startTimeKey{}).(time.Time); ok {
+			rec.telemetryBuilder.ReceiverRequestDuration.Record(receiverCtx, time.Since(start).Seconds(), rec.otelAttrs, outcomeAttr)
+		}
 	}
 
 	// end span according to errors
@@ -247,3 +260,22 @@ func (rec *ObsReport) recordMetrics(receiverCtx context.Context, signal pipeline
 	refusedMeasure.Add(receiverCtx, int64(numRefused), rec.otelAttrs)
 	failedMeasure.Add(receiverCtx, int64(numFailedErrors), rec.otelAttrs)
 }
+
+// recordItems records the received items by outcome, as the processors and exporters do.
+func (rec *ObsReport) recordItems(receiverCtx context.Context, signal pipeline.Signal, numReceivedItems int, err error) {
+	numAccepted, numRefused, numFailed := numReceivedItems, 0, 0
+	if rejected, ok := consumererror.Rejected(err); ok {
+		numFailed = min(rejected, numReceivedItems)
+		numAccepted -= numFailed
+	} else if err != nil {
+		numAccepted = 0
+		if consumererror.IsPermanent(err) {
+			numFailed = numReceivedItems
+		} else {
+			numRefused = numReceivedItems
+		}
+	}
+	telemetry.AddItems(receiverCtx, rec.telemetryBuilder.ReceiverItems, signal, telemetry.OutcomeAccepted, numAccepted, rec.otelAttrs)
+	telemetry.AddItems(receiverCtx, rec.telemetryBuilder.ReceiverItems, signal, telemetry.OutcomeRefused, numRefused, rec.otelAttrs)
+	telemetry.AddItems(receiverCtx, rec.telemetryBuilder.ReceiverItems, signal, telemetry.OutcomeFailed, numFailed, rec.otelAttrs)
+}
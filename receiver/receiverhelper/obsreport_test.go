@@ -19,6 +19,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/telemetry"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper/internal"
 	"go.opentelemetry.io/collector/receiver/receiverhelper/internal/metadatatest"
@@ -607,6 +608,111 @@ func TestCheckReceiverLogsViews(t *testing.T) {
 		}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
 }
 
+func TestReceiveRequestDurationExemplar(t *testing.T) {
+	originalState := NewReceiverMetricsGate.IsEnabled()
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(NewReceiverMetricsGate.ID(), originalState))
+	})
+	require.NoError(t, featuregate.GlobalRegistry().Set(NewReceiverMetricsGate.ID(), true))
+
+	testTelemetry(t, func(t *testing.T, tt *componenttest.Telemetry) {
+		rec, err := newReceiver(ObsReportSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: receiver.Settings{ID: receiverID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
+		})
+		require.NoError(t, err)
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 5, nil)
+
+		spans := tt.SpanRecorder.Ended()
+		require.Len(t, spans, 1)
+		got, err := tt.GetMetric("otelcol_receiver_request_duration")
+		require.NoError(t, err)
+		dps := got.Data.(metricdata.Histogram[float64]).DataPoints
+		require.Len(t, dps, 1)
+		assert.Equal(t, attribute.NewSet(
+			attribute.String(internal.ReceiverKey, receiverID.String()),
+			attribute.String(internal.TransportKey, transport),
+			attribute.String("outcome", "success")), dps[0].Attributes)
+		assert.Equal(t, uint64(1), dps[0].Count)
+		require.Len(t, dps[0].Exemplars, 1)
+		traceID, spanID := spans[0].SpanContext().TraceID(), spans[0].SpanContext().SpanID()
+		assert.Equal(t, traceID[:], dps[0].Exemplars[0].TraceID)
+		assert.Equal(t, spanID[:], dps[0].Exemplars[0].SpanID)
+	})
+}
+
+func TestReceiveItemsByOutcome(t *testing.T) {
+	testTelemetry(t, func(t *testing.T, tt *componenttest.Telemetry) {
+		rec, err := newReceiver(ObsReportSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: receiver.Settings{ID: receiverID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
+		})
+		require.NoError(t, err)
+		for _, param := range []testParams{
+			{items: 7, err: nil},
+			{items: 5, err: errFake},
+			{items: 3, err: consumererror.NewPermanent(errFake)},
+			{items: 4, err: consumererror.NewPartial(errFake, 1)},
+		} {
+			ctx := rec.StartLogsOp(context.Background())
+			rec.EndLogsOp(ctx, format, param.items, param.err)
+		}
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 2, nil)
+
+		itemsAttrs := func(signal, outcome string) attribute.Set {
+			return attribute.NewSet(
+				attribute.String(internal.ReceiverKey, receiverID.String()),
+				attribute.String(internal.TransportKey, transport),
+				attribute.String(telemetry.SignalKey, signal),
+				attribute.String(telemetry.OutcomeKey, outcome))
+		}
+		metadatatest.AssertEqualReceiverItems(t, tt,
+			[]metricdata.DataPoint[int64]{
+				{Attributes: itemsAttrs("logs", telemetry.OutcomeAccepted), Value: 10},
+				{Attributes: itemsAttrs("logs", telemetry.OutcomeRefused), Value: 5},
+				{Attributes: itemsAttrs("logs", telemetry.OutcomeFailed), Value: 4},
+				{Attributes: itemsAttrs("traces", telemetry.OutcomeAccepted), Value: 2},
+			}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+	})
+}
+
+func TestLegacyOutcomeMetricsGateDisabled(t *testing.T) {
+	originalState := telemetry.LegacyOutcomeMetricsGate.IsEnabled()
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(telemetry.LegacyOutcomeMetricsGate.ID(), originalState))
+	})
+	require.NoError(t, featuregate.GlobalRegistry().Set(telemetry.LegacyOutcomeMetricsGate.ID(), false))
+
+	testTelemetry(t, func(t *testing.T, tt *componenttest.Telemetry) {
+		rec, err := newReceiver(ObsReportSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: receiver.Settings{ID: receiverID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
+		})
+		require.NoError(t, err)
+		ctx := rec.StartTracesOp(context.Background())
+		rec.EndTracesOp(ctx, format, 7, nil)
+
+		_, err = tt.GetMetric("otelcol_receiver_accepted_spans")
+		require.Error(t, err)
+		metadatatest.AssertEqualReceiverItems(t, tt,
+			[]metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(
+						attribute.String(internal.ReceiverKey, receiverID.String()),
+						attribute.String(internal.TransportKey, transport),
+						attribute.String(telemetry.SignalKey, "traces"),
+						attribute.String(telemetry.OutcomeKey, telemetry.OutcomeAccepted)),
+					Value: 7,
+				},
+			}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+	})
+}
+
 func testTelemetry(t *testing.T, testFunc func(t *testing.T, tt *componenttest.Telemetry)) {
 	tt := componenttest.NewTelemetry()
 	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
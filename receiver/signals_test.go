@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build otelcol_no_traces
+
+package receiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestNewFactoryWithoutTraces(t *testing.T) {
+	defaultCfg := struct{}{}
+	f := NewFactory(
+		testType,
+		func() component.Config { return &defaultCfg },
+		WithTraces(createTraces, component.StabilityLevelAlpha))
+	assert.Equal(t, component.StabilityLevelUndefined, f.TracesStability())
+	_, err := f.CreateTraces(context.Background(), Settings{ID: testID}, &defaultCfg, nil)
+	require.ErrorIs(t, err, pipeline.ErrSignalNotSupported)
+}
@@ -74,6 +74,9 @@ func (f *factory) CreateProfiles(ctx context.Context, set receiver.Settings, cfg
 	return f.createProfilesFunc(ctx, set, cfg, next)
 }
 
+// factoryOpts collects the options of the base factory. Those options are created by the With functions,
+// and not by the closures they return, for the closures not to keep the create functions of the signals
+// left out of the distribution.
 type factoryOpts struct {
 	opts []receiver.FactoryOption
 	*factory
@@ -81,27 +84,33 @@ type factoryOpts struct {
 
 // WithTraces overrides the default "error not supported" implementation for Factory.CreateTraces and the default "undefined" stability level.
 func WithTraces(createTraces receiver.CreateTracesFunc, sl component.StabilityLevel) FactoryOption {
+	opt := receiver.WithTraces(createTraces, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, receiver.WithTraces(createTraces, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithMetrics overrides the default "error not supported" implementation for Factory.CreateMetrics and the default "undefined" stability level.
 func WithMetrics(createMetrics receiver.CreateMetricsFunc, sl component.StabilityLevel) FactoryOption {
+	opt := receiver.WithMetrics(createMetrics, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, receiver.WithMetrics(createMetrics, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithLogs overrides the default "error not supported" implementation for Factory.CreateLogs and the default "undefined" stability level.
 func WithLogs(createLogs receiver.CreateLogsFunc, sl component.StabilityLevel) FactoryOption {
+	opt := receiver.WithLogs(createLogs, sl)
 	return factoryOptionFunc(func(o *factoryOpts) {
-		o.opts = append(o.opts, receiver.WithLogs(createLogs, sl))
+		o.opts = append(o.opts, opt)
 	})
 }
 
 // WithProfiles overrides the default "error not supported" implementation for Factory.CreateProfiles and the default "undefined" stability level.
 func WithProfiles(createProfiles CreateProfilesFunc, sl component.StabilityLevel) FactoryOption {
+	if !internal.ProfilesEnabled {
+		return factoryOptionFunc(func(*factoryOpts) {})
+	}
 	return factoryOptionFunc(func(o *factoryOpts) {
 		o.profilesStabilityLevel = sl
 		o.createProfilesFunc = createProfiles
@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 	"go.opentelemetry.io/collector/scraper"
@@ -75,7 +76,15 @@ type controllerOptions struct {
 	factoriesWithConfig []factoryWithConfig
 }
 
+// intakePauser is implemented by the hosts able to pause the intake of the pipelines,
+// see hostcapabilities.PipelineIntake in the service module.
+type intakePauser interface {
+	IsReceiverPaused(id component.ID, signal pipeline.Signal) bool
+}
+
 type controller[T component.Component] struct {
+	id                 component.ID
+	signal             pipeline.Signal
 	collectionInterval time.Duration
 	initialDelay       time.Duration
 	timeout            time.Duration
@@ -88,11 +97,15 @@ type controller[T component.Component] struct {
 	wg   sync.WaitGroup
 
 	obsrecv *receiverhelper.ObsReport
+
+	// paused is set if the host can pause the pipelines the receiver emits to.
+	paused intakePauser
 }
 
 func newController[T component.Component](
 	cfg *ControllerConfig,
 	rSet receiver.Settings,
+	signal pipeline.Signal,
 	scrapers []T,
 	scrapeFunc func(*controller[T]),
 	tickerCh <-chan time.Time,
@@ -107,6 +120,8 @@ func newController[T component.Component](
 	}
 
 	cs := &controller[T]{
+		id:                 rSet.ID,
+		signal:             signal,
 		collectionInterval: cfg.CollectionInterval,
 		initialDelay:       cfg.InitialDelay,
 		timeout:            cfg.Timeout,
@@ -128,6 +143,7 @@ func (sc *controller[T]) Start(ctx context.Context, host component.Host) error {
 		}
 	}
 
+	sc.paused, _ = host.(intakePauser)
 	sc.startScraping()
 	return nil
 }
@@ -168,11 +184,11 @@ func (sc *controller[T]) startScraping() {
 		// Call scrape method during initialization to ensure
 		// that scrapers start from when the component starts
 		// instead of waiting for the full duration to start.
-		sc.scrapeFunc(sc)
+		sc.scrape()
 		for {
 			select {
 			case <-sc.tickerCh:
-				sc.scrapeFunc(sc)
+				sc.scrape()
 			case <-sc.done:
 				return
 			}
@@ -180,6 +196,14 @@ func (sc *controller[T]) startScraping() {
 	}()
 }
 
+// scrape scrapes, unless the pipelines the receiver emits to are paused.
+func (sc *controller[T]) scrape() {
+	if sc.paused != nil && sc.paused.IsReceiverPaused(sc.id, sc.signal) {
+		return
+	}
+	sc.scrapeFunc(sc)
+}
+
 // NewLogsController creates a receiver.Logs with the configured options, that can control multiple scraper.Logs.
 func NewLogsController(cfg *ControllerConfig,
 	rSet receiver.Settings,
@@ -201,7 +225,7 @@ func NewLogsController(cfg *ControllerConfig,
 		scrapers = append(scrapers, s)
 	}
 	return newController[scraper.Logs](
-		cfg, rSet, scrapers, func(c *controller[scraper.Logs]) { scrapeLogs(c, nextConsumer) }, co.tickerCh)
+		cfg, rSet, pipeline.SignalLogs, scrapers, func(c *controller[scraper.Logs]) { scrapeLogs(c, nextConsumer) }, co.tickerCh)
 }
 
 // NewMetricsController creates a receiver.Metrics with the configured options, that can control multiple scraper.Metrics.
@@ -225,7 +249,7 @@ func NewMetricsController(cfg *ControllerConfig,
 		scrapers = append(scrapers, s)
 	}
 	return newController[scraper.Metrics](
-		cfg, rSet, scrapers, func(c *controller[scraper.Metrics]) { scrapeMetrics(c, nextConsumer) }, co.tickerCh)
+		cfg, rSet, pipeline.SignalMetrics, scrapers, func(c *controller[scraper.Metrics]) { scrapeMetrics(c, nextConsumer) }, co.tickerCh)
 }
 
 func scrapeLogs(c *controller[scraper.Logs], nextConsumer consumer.Logs) {
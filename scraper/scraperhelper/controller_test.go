@@ -6,6 +6,7 @@ package scraperhelper
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.opentelemetry.io/collector/scraper"
@@ -603,6 +605,51 @@ func TestSingleMetricsScraperPerInterval(t *testing.T) {
 	}
 }
 
+// pausingHost is a host able to pause the pipelines of the receivers.
+type pausingHost struct {
+	component.Host
+	paused atomic.Bool
+	signal atomic.Value
+}
+
+func (h *pausingHost) IsReceiverPaused(_ component.ID, signal pipeline.Signal) bool {
+	h.signal.Store(signal)
+	return h.paused.Load()
+}
+
+func TestMetricsScraperSkipsWhilePaused(t *testing.T) {
+	scrapeCh := make(chan int, 10)
+	ts := &testScrape{ch: scrapeCh}
+	tickerCh := make(chan time.Time)
+
+	scp, err := scraper.NewMetrics(ts.scrapeMetrics)
+	require.NoError(t, err)
+
+	recv, err := NewMetricsController(
+		newTestNoDelaySettings(),
+		receivertest.NewNopSettings(receivertest.NopType),
+		new(consumertest.MetricsSink),
+		AddScraper(component.MustNewType("scraper"), scp),
+		WithTickerChannel(tickerCh),
+	)
+	require.NoError(t, err)
+
+	host := &pausingHost{Host: componenttest.NewNopHost()}
+	host.paused.Store(true)
+	require.NoError(t, recv.Start(context.Background(), host))
+	defer func() { require.NoError(t, recv.Shutdown(context.Background())) }()
+
+	// The initial scrape and the ticks are skipped while paused.
+	tickerCh <- time.Now()
+	tickerCh <- time.Now()
+	assert.Empty(t, scrapeCh)
+	assert.Equal(t, pipeline.SignalMetrics, host.signal.Load())
+
+	host.paused.Store(false)
+	tickerCh <- time.Now()
+	assert.Equal(t, 1, <-scrapeCh)
+}
+
 func TestLogsScraperControllerStartsOnInit(t *testing.T) {
 	t.Parallel()
 
@@ -6,7 +6,10 @@ package service // import "go.opentelemetry.io/collector/service"
 import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/goruntime"
+	"go.opentelemetry.io/collector/service/health"
 	"go.opentelemetry.io/collector/service/pipelines"
+	"go.opentelemetry.io/collector/service/sandbox"
 )
 
 // Config defines the configurable components of the Service.
@@ -20,6 +23,39 @@ type Config struct {
 	// Pipelines are the set of data pipelines configured for the service.
 	Pipelines pipelines.Config `mapstructure:"pipelines"`
 
+	// Health configures how the service manages the health of components.
+	Health health.Config `mapstructure:"health,omitempty"`
+
+	// LazyExporters are the exporters that are created with the other components but only
+	// started when they first receive data, e.g. to avoid starting exporters that are rarely used.
+	LazyExporters []component.ID `mapstructure:"lazy_exporters,omitempty"`
+
+	// FanIn defines how the connectors receive the data exported to them by several pipelines.
+	// The connectors without fan-in settings receive each call made by the pipelines as is.
+	FanIn map[component.ID]pipelines.FanInConfig `mapstructure:"fan_in,omitempty"`
+
+	// Buffer defines bounded queues decoupling the connectors from the pipelines exporting to them,
+	// so that a slow downstream pipeline does not block the upstream pipelines.
+	Buffer map[component.ID]pipelines.BufferConfig `mapstructure:"buffer,omitempty"`
+
+	// Feedback marks the edges from some pipelines to connectors as feedback edges, backed by a
+	// bounded buffer, allowing these pipelines to form cycles.
+	Feedback map[component.ID]pipelines.FeedbackConfig `mapstructure:"feedback,omitempty"`
+
+	// Runtime configures the settings of the Go runtime derived from the resources the collector is
+	// allowed to use, e.g. GOMAXPROCS from the CPU quota of its container.
+	Runtime goruntime.Config `mapstructure:"runtime,omitempty"`
+
+	// Sandbox configures the restrictions applied to the collector process once the components are
+	// started, e.g. the paths of the filesystem it can still access.
+	Sandbox sandbox.Config `mapstructure:"sandbox,omitempty"`
+
+	// FeatureGates maps the IDs of feature gates to whether they are enabled, like the --feature-gates flag,
+	// which takes precedence. An ID suffixed with "@<kind>/<type>[/<name>]" only sets the gate for a component
+	// instance. The collector sets them once the configuration is validated, before creating the components, and
+	// restores them when they are removed from the configuration or when it fails to start.
+	FeatureGates map[string]bool `mapstructure:"feature_gates,omitempty"`
+
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/health"
 	"go.opentelemetry.io/collector/service/pipelines"
 )
 
@@ -57,6 +58,46 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New("telemetry: invalid config"),
 		},
+		{
+			name: "invalid-health-detection-config",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Health.Detection.FailureThreshold = -1
+				return cfg
+			},
+			expected: errors.New("health::detection: failure_threshold must be non-negative"),
+		},
+		{
+			name: "invalid-health-restart-config",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Health.Restart.Exporters = map[component.ID]health.RestartPolicy{
+					component.MustNewID("nop"): {MaxRetries: -1},
+				}
+				return cfg
+			},
+			expected: errors.New("health::restart::exporters::nop: max_retries must be non-negative"),
+		},
+		{
+			name: "invalid-health-timeouts-config",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Health.Timeouts.Receivers = map[component.ID]health.ComponentTimeouts{
+					component.MustNewID("nop"): {Start: -1},
+				}
+				return cfg
+			},
+			expected: errors.New("health::timeouts::receivers::nop: start must be non-negative"),
+		},
+		{
+			name: "invalid-runtime-config",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Runtime.MemoryLimitPercentage = 101
+				return cfg
+			},
+			expected: errors.New("runtime: memory_limit_percentage must be at most 100"),
+		},
 	}
 
 	for _, tt := range testCases {
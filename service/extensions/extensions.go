@@ -5,8 +5,10 @@ package extensions // import "go.opentelemetry.io/collector/service/extensions"
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"sort"
 
 	"go.uber.org/multierr"
@@ -19,9 +21,11 @@ import (
 	"go.opentelemetry.io/collector/extension/extensioncapabilities"
 	"go.opentelemetry.io/collector/internal/telemetry"
 	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/service/health"
 	"go.opentelemetry.io/collector/service/internal/attribute"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/timeout"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
@@ -34,6 +38,10 @@ type Extensions struct {
 	instanceIDs  map[component.ID]*componentstatus.InstanceID
 	extensionIDs []component.ID // start order (and reverse stop order)
 	reporter     status.Reporter
+	timeouts     health.TimeoutConfig
+	// The guards of the lifecycle calls made to the extensions, so that an extension is not
+	// shut down while its start, which timed out, is still running.
+	lifecycles map[component.ID]*timeout.Guard
 }
 
 // Start starts all extensions.
@@ -49,11 +57,19 @@ func (bes *Extensions) Start(ctx context.Context, host component.Host) error {
 			instanceID,
 			componentstatus.NewEvent(componentstatus.StatusStarting),
 		)
-		if err := ext.Start(ctx, host); err != nil {
+		timeouts := bes.timeouts.For(component.KindExtension, extID)
+		if err := bes.lifecycle(extID).Call(ctx, timeouts.Start, func(ctx context.Context) error { return ext.Start(ctx, host) }); err != nil {
+			if errors.Is(err, timeout.ErrTimeout) {
+				err = fmt.Errorf("failed to start extension %q: %w", extID, err)
+			}
 			bes.reporter.ReportStatus(
 				instanceID,
 				componentstatus.NewPermanentErrorEvent(err),
 			)
+			if errors.Is(err, timeout.ErrTimeout) && timeouts.ContinueOnStartTimeout {
+				extLogger.Warn("Extension did not start in time, continuing in degraded state", zap.Error(err))
+				continue
+			}
 			// We log with zap.AddStacktrace(zap.DPanicLevel) to avoid adding the stack trace to the error log
 			extLogger.WithOptions(zap.AddStacktrace(zap.DPanicLevel)).Error("Failed to start extension", zap.Error(err))
 			return err
@@ -64,6 +80,19 @@ func (bes *Extensions) Start(ctx context.Context, host component.Host) error {
 	return nil
 }
 
+// lifecycle returns the guard of the lifecycle calls made to the extension.
+func (bes *Extensions) lifecycle(extID component.ID) *timeout.Guard {
+	if bes.lifecycles == nil {
+		bes.lifecycles = make(map[component.ID]*timeout.Guard)
+	}
+	guard, ok := bes.lifecycles[extID]
+	if !ok {
+		guard = &timeout.Guard{}
+		bes.lifecycles[extID] = guard
+	}
+	return guard
+}
+
 // Shutdown stops all extensions.
 func (bes *Extensions) Shutdown(ctx context.Context) error {
 	bes.telemetry.Logger.Info("Stopping extensions...")
@@ -76,7 +105,10 @@ func (bes *Extensions) Shutdown(ctx context.Context) error {
 			instanceID,
 			componentstatus.NewEvent(componentstatus.StatusStopping),
 		)
-		if err := ext.Shutdown(ctx); err != nil {
+		if err := bes.lifecycle(extID).Call(ctx, bes.timeouts.For(component.KindExtension, extID).Shutdown, ext.Shutdown); err != nil {
+			if errors.Is(err, timeout.ErrTimeout) || errors.Is(err, timeout.ErrAbandoned) {
+				err = fmt.Errorf("failed to shutdown extension %q: %w", extID, err)
+			}
 			bes.reporter.ReportStatus(
 				instanceID,
 				componentstatus.NewPermanentErrorEvent(err),
@@ -116,6 +148,36 @@ func (bes *Extensions) NotifyPipelineNotReady() error {
 	return errs
 }
 
+// BeforePipelinesStart calls the extensions implementing extensioncapabilities.PipelineLifecycleHooks
+// in start order, and stops at the first error.
+func (bes *Extensions) BeforePipelinesStart(ctx context.Context) error {
+	for _, extID := range bes.extensionIDs {
+		ext := bes.extMap[extID]
+		if h, ok := ext.(extensioncapabilities.PipelineLifecycleHooks); ok {
+			if err := h.BeforePipelinesStart(ctx); err != nil {
+				return fmt.Errorf("failed to run pre-start hook of extension %q: %w", extID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// AfterPipelinesShutdown calls the extensions implementing extensioncapabilities.PipelineLifecycleHooks
+// in reverse start order.
+func (bes *Extensions) AfterPipelinesShutdown(ctx context.Context) error {
+	var errs error
+	for i := len(bes.extensionIDs) - 1; i >= 0; i-- {
+		extID := bes.extensionIDs[i]
+		ext := bes.extMap[extID]
+		if h, ok := ext.(extensioncapabilities.PipelineLifecycleHooks); ok {
+			if err := h.AfterPipelinesShutdown(ctx); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("failed to run post-shutdown hook of extension %q: %w", extID, err))
+			}
+		}
+	}
+	return errs
+}
+
 func (bes *Extensions) NotifyConfig(ctx context.Context, conf *confmap.Conf) error {
 	var errs error
 	for _, extID := range bes.extensionIDs {
@@ -128,6 +190,15 @@ func (bes *Extensions) NotifyConfig(ctx context.Context, conf *confmap.Conf) err
 	return errs
 }
 
+func (bes *Extensions) NotifyConfigStatus(err error) {
+	for _, extID := range bes.extensionIDs {
+		ext := bes.extMap[extID]
+		if cw, ok := ext.(extensioncapabilities.ConfigStatusWatcher); ok {
+			cw.NotifyConfigStatus(err)
+		}
+	}
+}
+
 func (bes *Extensions) NotifyComponentStatusChange(source *componentstatus.InstanceID, event *componentstatus.Event) {
 	for _, extID := range bes.extensionIDs {
 		ext := bes.extMap[extID]
@@ -180,6 +251,12 @@ type Settings struct {
 	Extensions builders.Extension
 }
 
+// configProvider is implemented by the extension builders that give access to
+// the configuration of the extensions.
+type configProvider interface {
+	Config(component.ID) component.Config
+}
+
 type Option interface {
 	apply(*Extensions)
 }
@@ -196,6 +273,13 @@ func WithReporter(reporter status.Reporter) Option {
 	})
 }
 
+// WithTimeouts bounds the duration of the start and shutdown of the extensions.
+func WithTimeouts(timeouts health.TimeoutConfig) Option {
+	return optionFunc(func(e *Extensions) {
+		e.timeouts = timeouts
+	})
+}
+
 // New creates a new Extensions from Config.
 func New(ctx context.Context, set Settings, cfg Config, options ...Option) (*Extensions, error) {
 	exts := &Extensions{
@@ -210,6 +294,12 @@ func New(ctx context.Context, set Settings, cfg Config, options ...Option) (*Ext
 		opt.apply(exts)
 	}
 
+	for extID := range exts.timeouts.Extensions {
+		if !slices.Contains(cfg, extID) {
+			return nil, fmt.Errorf("timeouts reference extension %q which is not enabled", extID)
+		}
+	}
+
 	for _, extID := range cfg {
 		instanceID := componentstatus.NewInstanceID(extID, component.KindExtension)
 		extSet := extension.Settings{
@@ -231,7 +321,11 @@ func New(ctx context.Context, set Settings, cfg Config, options ...Option) (*Ext
 		exts.extMap[extID] = ext
 		exts.instanceIDs[extID] = instanceID
 	}
-	order, err := computeOrder(exts)
+	configs := func(component.ID) component.Config { return nil }
+	if cp, ok := set.Extensions.(configProvider); ok {
+		configs = cp.Config
+	}
+	order, err := computeOrder(exts, cfg, configs)
 	if err != nil {
 		return nil, err
 	}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,8 +20,10 @@ import (
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/extensioncapabilities"
 	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/service/health"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/timeout"
 )
 
 func TestBuildExtensions(t *testing.T) {
@@ -113,7 +116,7 @@ func TestOrdering(t *testing.T) {
 		{
 			testName:   "no_deps",
 			extensions: []testOrderExt{{name: ""}, {name: "foo"}, {name: "bar"}},
-			order:      nil, // no predictable order
+			order:      []string{"recording", "recording/foo", "recording/bar"},
 		},
 		{
 			testName: "deps",
@@ -135,6 +138,16 @@ func TestOrdering(t *testing.T) {
 			// baz -> foo -> bar
 			order: []string{"recording/bar", "recording/foo", "recording/baz"},
 		},
+		{
+			testName: "deps_keep_config_order",
+			extensions: []testOrderExt{
+				{name: "foo", deps: []string{"baz"}}, // foo -> baz
+				{name: "bar"},
+				{name: "baz"},
+				{name: "qux"},
+			},
+			order: []string{"recording/bar", "recording/baz", "recording/foo", "recording/qux"},
+		},
 		{
 			testName: "unknown_dep",
 			extensions: []testOrderExt{
@@ -151,6 +164,13 @@ func TestOrdering(t *testing.T) {
 			},
 			err: "unable to order extensions",
 		},
+		{
+			testName: "self",
+			extensions: []testOrderExt{
+				{name: "foo", deps: []string{"foo"}},
+			},
+			err: "extension recording/foo depends on itself",
+		},
 	}
 	for _, testCase := range tests {
 		t.Run(testCase.testName, testCase.testOrdering)
@@ -204,6 +224,141 @@ func (tc testOrderCase) testOrdering(t *testing.T) {
 	}
 }
 
+func TestPipelineLifecycleHooks(t *testing.T) {
+	var calls []string
+	var hookErr error
+	recordingExtensionFactory := newRecordingExtensionFactory(
+		func(extension.Settings, component.Host) error { return nil },
+		func(extension.Settings) error { return nil })
+	factory := extension.NewFactory(
+		recordingExtensionFactory.Type(),
+		recordingExtensionFactory.CreateDefaultConfig,
+		func(ctx context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+			ext, err := recordingExtensionFactory.Create(ctx, set, cfg)
+			if err != nil {
+				return nil, err
+			}
+			return &hooksExtension{recordingExtension: ext.(*recordingExtension), calls: &calls, err: &hookErr}, nil
+		},
+		component.StabilityLevelDevelopment,
+	)
+
+	// foo depends on bar, and nop doesn't implement the hooks.
+	fooID := component.MustNewIDWithName("recording", "foo")
+	barID := component.MustNewIDWithName("recording", "bar")
+	nopID := component.MustNewID("nop")
+	exts, err := New(context.Background(), Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Extensions: builders.NewExtension(
+			map[component.ID]component.Config{
+				fooID: recordingExtensionConfig{dependencies: []string{"bar"}},
+				barID: recordingExtensionConfig{},
+				nopID: extensiontest.NewNopFactory().CreateDefaultConfig(),
+			},
+			map[component.Type]extension.Factory{
+				factory.Type():                       factory,
+				extensiontest.NewNopFactory().Type(): extensiontest.NewNopFactory(),
+			}),
+	}, Config{fooID, nopID, barID})
+	require.NoError(t, err)
+
+	require.NoError(t, exts.BeforePipelinesStart(context.Background()))
+	require.NoError(t, exts.AfterPipelinesShutdown(context.Background()))
+	assert.Equal(t, []string{
+		"before:recording/bar",
+		"before:recording/foo",
+		"after:recording/foo",
+		"after:recording/bar",
+	}, calls)
+
+	// The first pre-start error aborts, while all post-shutdown hooks are run.
+	calls = nil
+	hookErr = assert.AnError
+	require.ErrorIs(t, exts.BeforePipelinesStart(context.Background()), assert.AnError)
+	assert.Equal(t, []string{"before:recording/bar"}, calls)
+
+	calls = nil
+	err = exts.AfterPipelinesShutdown(context.Background())
+	require.ErrorIs(t, err, assert.AnError)
+	require.ErrorContains(t, err, `failed to run post-shutdown hook of extension "recording/foo"`)
+	assert.Equal(t, []string{"after:recording/foo", "after:recording/bar"}, calls)
+}
+
+type hooksExtension struct {
+	*recordingExtension
+	calls *[]string
+	err   *error
+}
+
+var _ extensioncapabilities.PipelineLifecycleHooks = (*hooksExtension)(nil)
+
+func (ext *hooksExtension) BeforePipelinesStart(context.Context) error {
+	*ext.calls = append(*ext.calls, "before:"+ext.createSettings.ID.String())
+	return *ext.err
+}
+
+func (ext *hooksExtension) AfterPipelinesShutdown(context.Context) error {
+	*ext.calls = append(*ext.calls, "after:"+ext.createSettings.ID.String())
+	return *ext.err
+}
+
+type extensionDependentConfig struct {
+	deps []component.ID
+}
+
+func (cfg extensionDependentConfig) ExtensionDependencies() []component.ID {
+	return cfg.deps
+}
+
+func TestOrderingFromConfig(t *testing.T) {
+	dependentType := component.MustNewType("dependent")
+	dependentFactory := extension.NewFactory(
+		dependentType,
+		func() component.Config { return extensionDependentConfig{} },
+		func(ctx context.Context, _ extension.Settings, _ component.Config) (extension.Extension, error) {
+			nopFactory := extensiontest.NewNopFactory()
+			return nopFactory.Create(ctx, extensiontest.NewNopSettings(nopFactory.Type()), nopFactory.CreateDefaultConfig())
+		},
+		component.StabilityLevelDevelopment,
+	)
+
+	fooID := component.NewIDWithName(dependentType, "foo")
+	barID := component.NewIDWithName(dependentType, "bar")
+	bazID := component.NewIDWithName(dependentType, "baz")
+	newExtensions := func(cfgs map[component.ID]component.Config) (*Extensions, error) {
+		return New(context.Background(), Settings{
+			Telemetry: componenttest.NewNopTelemetrySettings(),
+			BuildInfo: component.NewDefaultBuildInfo(),
+			Extensions: builders.NewExtension(cfgs,
+				map[component.Type]extension.Factory{dependentType: dependentFactory}),
+		}, Config{fooID, barID, bazID})
+	}
+
+	// foo -> bar -> baz
+	exts, err := newExtensions(map[component.ID]component.Config{
+		fooID: extensionDependentConfig{deps: []component.ID{barID}},
+		barID: extensionDependentConfig{deps: []component.ID{bazID}},
+		bazID: extensionDependentConfig{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []component.ID{bazID, barID, fooID}, exts.extensionIDs)
+
+	_, err = newExtensions(map[component.ID]component.Config{
+		fooID: extensionDependentConfig{deps: []component.ID{component.MustNewID("unknown")}},
+		barID: extensionDependentConfig{},
+		bazID: extensionDependentConfig{},
+	})
+	require.ErrorContains(t, err, "unable to find extension unknown on which extension dependent/foo depends")
+
+	_, err = newExtensions(map[component.ID]component.Config{
+		fooID: extensionDependentConfig{deps: []component.ID{barID}},
+		barID: extensionDependentConfig{deps: []component.ID{fooID}},
+		bazID: extensionDependentConfig{},
+	})
+	require.ErrorContains(t, err, "unable to order extensions by dependencies, cycle found")
+}
+
 func TestNotifyConfig(t *testing.T) {
 	notificationError := errors.New("Error processing config")
 	nopExtensionFactory := extensiontest.NewNopFactory()
@@ -444,6 +599,61 @@ func TestStatusReportedOnStartupShutdown(t *testing.T) {
 	}
 }
 
+func TestTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	factory := newRecordingExtensionFactory(func(set extension.Settings, _ component.Host) error {
+		if set.ID.Name() == "hanging" {
+			<-release
+		}
+		return nil
+	}, func(extension.Settings) error {
+		<-release
+		return nil
+	})
+	hangingID := component.NewIDWithName(factory.Type(), "hanging")
+	okID := component.NewIDWithName(factory.Type(), "ok")
+	newExtensions := func(timeouts health.TimeoutConfig) (*Extensions, error) {
+		return New(context.Background(), Settings{
+			Telemetry: componenttest.NewNopTelemetrySettings(),
+			BuildInfo: component.NewDefaultBuildInfo(),
+			Extensions: builders.NewExtension(
+				map[component.ID]component.Config{hangingID: recordingExtensionConfig{}, okID: recordingExtensionConfig{}},
+				map[component.Type]extension.Factory{factory.Type(): factory},
+			),
+		}, []component.ID{hangingID, okID}, WithTimeouts(timeouts))
+	}
+
+	exts, err := newExtensions(health.TimeoutConfig{Start: 10 * time.Millisecond})
+	require.NoError(t, err)
+	err = exts.Start(context.Background(), componenttest.NewNopHost())
+	require.ErrorIs(t, err, timeout.ErrTimeout)
+	assert.EqualError(t, err, `failed to start extension "recording/hanging": timed out after 10ms`)
+
+	exts, err = newExtensions(health.TimeoutConfig{
+		Shutdown: 10 * time.Millisecond,
+		Extensions: map[component.ID]health.ComponentTimeouts{
+			hangingID: {Start: 10 * time.Millisecond, ContinueOnStartTimeout: true},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, exts.Start(context.Background(), componenttest.NewNopHost()))
+	err = exts.Shutdown(context.Background())
+	require.ErrorIs(t, err, timeout.ErrTimeout)
+	// The hanging extension is not shut down while its start is still running.
+	require.ErrorIs(t, err, timeout.ErrAbandoned)
+	assert.ErrorContains(t, err, `failed to shutdown extension "recording/hanging": abandoned, a previous call is still running after 10ms`)
+	assert.ErrorContains(t, err, `failed to shutdown extension "recording/ok": timed out after 10ms`)
+
+	_, err = newExtensions(health.TimeoutConfig{
+		Extensions: map[component.ID]health.ComponentTimeouts{
+			component.MustNewID("unknown"): {Start: time.Second},
+		},
+	})
+	require.EqualError(t, err, `timeouts reference extension "unknown" which is not enabled`)
+
+	close(release)
+}
+
 type statusTestExtension struct {
 	startErr    error
 	shutdownErr error
@@ -4,8 +4,10 @@
 package extensions // import "go.opentelemetry.io/collector/service/extensions"
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"gonum.org/v1/gonum/graph"
@@ -25,10 +27,17 @@ func (n node) ID() int64 {
 	return n.nodeID
 }
 
-func computeOrder(exts *Extensions) ([]component.ID, error) {
+// computeOrder sorts the extensions so that each one is started after the extensions it
+// depends on, either through extensioncapabilities.Dependent or through the
+// extensioncapabilities.ExtensionDependent configuration returned by configs.
+// The extensions that do not depend on each other keep the order of cfg.
+func computeOrder(exts *Extensions, cfg Config, configs func(component.ID) component.Config) ([]component.ID, error) {
 	graph := simple.NewDirectedGraph()
 	nodes := make(map[component.ID]*node)
-	for extID := range exts.extMap {
+	for _, extID := range cfg {
+		if _, ok := nodes[extID]; ok {
+			continue
+		}
 		n := &node{
 			nodeID: int64(len(nodes) + 1),
 			extID:  extID,
@@ -36,19 +45,27 @@ func computeOrder(exts *Extensions) ([]component.ID, error) {
 		graph.AddNode(n)
 		nodes[extID] = n
 	}
-	for extID, ext := range exts.extMap {
-		n := nodes[extID]
+	for extID, n := range nodes {
+		ext := exts.extMap[extID]
+		var deps []component.ID
 		if dep, ok := ext.(extensioncapabilities.Dependent); ok {
-			for _, depID := range dep.Dependencies() {
-				d, ok := nodes[depID]
-				if !ok {
-					return nil, fmt.Errorf("unable to find extension %s on which extension %s depends", depID, extID)
-				}
-				graph.SetEdge(graph.NewEdge(d, n))
+			deps = append(deps, dep.Dependencies()...)
+		}
+		if dep, ok := configs(extID).(extensioncapabilities.ExtensionDependent); ok {
+			deps = append(deps, dep.ExtensionDependencies()...)
+		}
+		for _, depID := range deps {
+			if depID == extID {
+				return nil, fmt.Errorf("extension %s depends on itself", extID)
 			}
+			d, ok := nodes[depID]
+			if !ok {
+				return nil, fmt.Errorf("unable to find extension %s on which extension %s depends", depID, extID)
+			}
+			graph.SetEdge(graph.NewEdge(d, n))
 		}
 	}
-	orderedNodes, err := topo.Sort(graph)
+	orderedNodes, err := topo.SortStabilized(graph, byConfigOrder)
 	if err != nil {
 		return nil, cycleErr(err, topo.DirectedCyclesIn(graph))
 	}
@@ -60,6 +77,13 @@ func computeOrder(exts *Extensions) ([]component.ID, error) {
 	return order, nil
 }
 
+// byConfigOrder sorts the nodes in the order of the extensions in the configuration.
+func byConfigOrder(nodes []graph.Node) {
+	slices.SortFunc(nodes, func(a, b graph.Node) int {
+		return cmp.Compare(a.ID(), b.ID())
+	})
+}
+
 func cycleErr(err error, cycles [][]graph.Node) error {
 	var topoErr topo.Unorderable
 	if !errors.As(err, &topoErr) || len(cycles) == 0 || len(cycles[0]) == 0 {
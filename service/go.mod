@@ -9,6 +9,7 @@ require (
 	github.com/shirou/gopsutil/v4 v4.25.9
 	github.com/stretchr/testify v1.11.1
 	go.opentelemetry.io/collector v0.137.0
+	go.opentelemetry.io/collector/client v1.43.0
 	go.opentelemetry.io/collector/component v1.43.0
 	go.opentelemetry.io/collector/component/componentstatus v0.137.0
 	go.opentelemetry.io/collector/component/componenttest v0.137.0
@@ -29,9 +30,11 @@ require (
 	go.opentelemetry.io/collector/extension v1.43.0
 	go.opentelemetry.io/collector/extension/extensioncapabilities v0.137.0
 	go.opentelemetry.io/collector/extension/extensiontest v0.137.0
+	go.opentelemetry.io/collector/extension/xextension v0.137.0
 	go.opentelemetry.io/collector/extension/zpagesextension v0.137.0
 	go.opentelemetry.io/collector/featuregate v1.43.0
 	go.opentelemetry.io/collector/internal/fanoutconsumer v0.137.0
+	go.opentelemetry.io/collector/internal/memorylimiter v0.137.0
 	go.opentelemetry.io/collector/internal/telemetry v0.137.0
 	go.opentelemetry.io/collector/otelcol v0.137.0
 	go.opentelemetry.io/collector/pdata v1.43.0
@@ -58,7 +61,9 @@ require (
 	go.uber.org/goleak v1.3.0
 	go.uber.org/multierr v1.11.0
 	go.uber.org/zap v1.27.0
+	golang.org/x/sys v0.36.0
 	gonum.org/v1/gonum v0.16.0
+	gopkg.in/natefinch/lumberjack.v2 v2.2.1
 )
 
 require (
@@ -106,10 +111,10 @@ require (
 	github.com/tklauser/numcpus v0.10.0 // indirect
 	github.com/yusufpapurcu/wmi v1.2.4 // indirect
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
-	go.opentelemetry.io/collector/client v1.43.0 // indirect
 	go.opentelemetry.io/collector/config/configauth v1.43.0 // indirect
 	go.opentelemetry.io/collector/config/configcompression v1.43.0 // indirect
 	go.opentelemetry.io/collector/config/configmiddleware v1.43.0 // indirect
+	go.opentelemetry.io/collector/config/confignet v1.43.0 // indirect
 	go.opentelemetry.io/collector/config/configopaque v1.43.0 // indirect
 	go.opentelemetry.io/collector/config/configoptional v1.43.0 // indirect
 	go.opentelemetry.io/collector/config/configtls v1.43.0 // indirect
@@ -137,13 +142,11 @@ require (
 	golang.org/x/crypto v0.42.0 // indirect
 	golang.org/x/exp v0.0.0-20240506185415-9bf2ced13842 // indirect
 	golang.org/x/net v0.44.0 // indirect
-	golang.org/x/sys v0.36.0 // indirect
 	golang.org/x/text v0.29.0 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20250825161204-c5933d9347a5 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250825161204-c5933d9347a5 // indirect
 	google.golang.org/grpc v1.76.0 // indirect
 	google.golang.org/protobuf v1.36.10 // indirect
-	gopkg.in/natefinch/lumberjack.v2 v2.2.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
@@ -159,6 +162,8 @@ replace go.opentelemetry.io/collector/component/componenttest => ../component/co
 
 replace go.opentelemetry.io/collector/internal/telemetry => ../internal/telemetry/
 
+replace go.opentelemetry.io/collector/internal/memorylimiter => ../internal/memorylimiter
+
 replace go.opentelemetry.io/collector/component/componentstatus => ../component/componentstatus
 
 replace go.opentelemetry.io/collector/pdata => ../pdata
@@ -256,3 +261,7 @@ replace go.opentelemetry.io/collector/pdata/xpdata => ../pdata/xpdata
 replace go.opentelemetry.io/collector/exporter/exporterhelper => ../exporter/exporterhelper
 
 replace go.opentelemetry.io/collector/config/configoptional => ../config/configoptional
+
+replace go.opentelemetry.io/collector/config/confignet => ../config/confignet
+
+replace go.opentelemetry.io/collector/internal/panicguard => ../internal/panicguard
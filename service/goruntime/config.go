@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package goruntime defines the configuration of the settings of the Go runtime the service derives from
+// the resources the collector is allowed to use, e.g. by the cgroups of its container.
+package goruntime // import "go.opentelemetry.io/collector/service/goruntime"
+
+import (
+	"errors"
+)
+
+// Config defines the settings of the Go runtime the service derives from the resources of the collector.
+// The settings are applied when the service is created and restored when it is shut down.
+type Config struct {
+	// MaxProcsFromCPUQuota sets GOMAXPROCS to the CPU quota of the cgroup of the collector, rounded down
+	// to at least one CPU, so that the Go runtime does not schedule more threads than the collector is
+	// allowed to run. It has no effect without a quota or when the GOMAXPROCS environment variable is set.
+	MaxProcsFromCPUQuota bool `mapstructure:"maxprocs_from_cpu_quota"`
+
+	// MemoryLimitPercentage sets the soft memory limit of the Go runtime to this percentage of the memory
+	// available to the collector, i.e. the memory limit of its cgroup or the total memory of the host,
+	// so that the garbage collector runs more often as the collector gets close to it. Zero disables it,
+	// and it has no effect when the GOMEMLIMIT environment variable is set.
+	MemoryLimitPercentage uint32 `mapstructure:"memory_limit_percentage,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// NewDefaultConfig returns the default Config, setting GOMAXPROCS from the CPU quota.
+func NewDefaultConfig() Config {
+	return Config{MaxProcsFromCPUQuota: true}
+}
+
+// Validate checks that the configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MemoryLimitPercentage > 100 {
+		return errors.New("memory_limit_percentage must be at most 100")
+	}
+	return nil
+}
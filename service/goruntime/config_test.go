@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package goruntime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cfg := NewDefaultConfig()
+	require.NoError(t, cfg.Validate())
+	assert.True(t, cfg.MaxProcsFromCPUQuota)
+
+	cfg.MemoryLimitPercentage = 100
+	require.NoError(t, cfg.Validate())
+
+	cfg.MemoryLimitPercentage = 101
+	require.EqualError(t, cfg.Validate(), "memory_limit_percentage must be at most 100")
+}
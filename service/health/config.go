@@ -0,0 +1,366 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package health defines the configuration of how the service manages the health of components.
+package health // import "go.opentelemetry.io/collector/service/health"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// DefaultFailureThreshold is used when DetectionConfig.FailureThreshold is not set.
+	DefaultFailureThreshold = 5
+	// DefaultRecoveryThreshold is used when DetectionConfig.RecoveryThreshold is not set.
+	DefaultRecoveryThreshold = 1
+	// DefaultQueueSaturation is used when DetectionConfig.QueueSaturation is not set.
+	DefaultQueueSaturation = 0.9
+	// DefaultQueueCheckInterval is used when DetectionConfig.QueueCheckInterval is not set.
+	DefaultQueueCheckInterval = 10 * time.Second
+
+	// DefaultRestartMaxRetries is used when RestartPolicy.MaxRetries is not set.
+	DefaultRestartMaxRetries = 5
+	// DefaultRestartInitialInterval is used when RestartPolicy.InitialInterval is not set.
+	DefaultRestartInitialInterval = time.Second
+	// DefaultRestartMaxInterval is used when RestartPolicy.MaxInterval is not set.
+	DefaultRestartMaxInterval = 30 * time.Second
+	// DefaultRestartMultiplier is used when RestartPolicy.Multiplier is not set.
+	DefaultRestartMultiplier = 2.0
+)
+
+// Config defines how the service manages the health of components.
+type Config struct {
+	// Detection configures the derivation of component health from the outcome of the
+	// data flowing through the pipelines, in addition to the status events reported by
+	// the components themselves.
+	Detection DetectionConfig `mapstructure:"detection"`
+
+	// Restart configures the components that are recreated in-process after they
+	// report a permanent or fatal error.
+	Restart RestartConfig `mapstructure:"restart,omitempty"`
+
+	// Timeouts bounds the duration of the start and shutdown of components.
+	Timeouts TimeoutConfig `mapstructure:"timeouts,omitempty"`
+
+	// OnStartFailure configures what happens when individual components fail to start.
+	OnStartFailure StartFailureConfig `mapstructure:"on_start_failure,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// DetectionConfig defines when a component is considered degraded based on the
+// outcome of the calls made to it, and on the internal metrics it records.
+//
+// The signals are the calls made to processors and exporters, the items sent or failed to
+// be sent by exporters, including the ones sending from their queue, the saturation of the
+// sending queues of exporters, and the items accepted or refused by receivers. Each signal
+// is accounted separately: a component is degraded as soon as one of them fails
+// FailureThreshold consecutive times, and healthy again once all of them recovered.
+//
+// A degraded component is reported with componentstatus.StatusRecoverableError, and
+// reported with componentstatus.StatusOK again once it recovers.
+type DetectionConfig struct {
+	// Enabled turns on the detection of degraded components.
+	Enabled bool `mapstructure:"enabled"`
+
+	// FailureThreshold is the number of consecutive failures of a signal after which the
+	// component is reported as degraded. Errors caused by downstream components are not
+	// accounted to the component. Defaults to DefaultFailureThreshold.
+	FailureThreshold int `mapstructure:"failure_threshold,omitempty"`
+
+	// RecoveryThreshold is the number of consecutive successes of a failed signal after
+	// which it recovers. Defaults to DefaultRecoveryThreshold.
+	RecoveryThreshold int `mapstructure:"recovery_threshold,omitempty"`
+
+	// QueueSaturation is the fraction of the capacity of the sending queue of an exporter
+	// from which the queue is saturated. Each check of a saturated queue, and each item
+	// rejected because the queue is full, is a failure. Defaults to DefaultQueueSaturation.
+	QueueSaturation float64 `mapstructure:"queue_saturation,omitempty"`
+
+	// QueueCheckInterval is the interval at which the sending queues of the exporters are
+	// checked. Defaults to DefaultQueueCheckInterval.
+	QueueCheckInterval time.Duration `mapstructure:"queue_check_interval,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *DetectionConfig) Validate() error {
+	if cfg.FailureThreshold < 0 {
+		return errors.New("failure_threshold must be non-negative")
+	}
+	if cfg.RecoveryThreshold < 0 {
+		return errors.New("recovery_threshold must be non-negative")
+	}
+	if cfg.QueueSaturation < 0 || cfg.QueueSaturation > 1 {
+		return fmt.Errorf("queue_saturation must be between 0 and 1, got %v", cfg.QueueSaturation)
+	}
+	if cfg.QueueCheckInterval < 0 {
+		return errors.New("queue_check_interval must be non-negative")
+	}
+	return nil
+}
+
+// RestartConfig defines the restart policy of individual components, keyed by component ID.
+//
+// A component with a restart policy that reports componentstatus.StatusPermanentError or
+// componentstatus.StatusFatalError is shut down, recreated from its configuration and started
+// again. A fatal error only stops the collector once the retries are exhausted.
+type RestartConfig struct {
+	// Receivers holds the restart policy of receivers.
+	Receivers map[component.ID]RestartPolicy `mapstructure:"receivers,omitempty"`
+
+	// Exporters holds the restart policy of exporters.
+	Exporters map[component.ID]RestartPolicy `mapstructure:"exporters,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// RestartPolicy defines how many times and how often a component is restarted.
+// The interval between restarts grows exponentially from InitialInterval up to MaxInterval.
+type RestartPolicy struct {
+	// MaxRetries is the maximum number of times the component is restarted.
+	// Defaults to DefaultRestartMaxRetries.
+	MaxRetries int `mapstructure:"max_retries,omitempty"`
+
+	// InitialInterval is the time to wait before the first restart.
+	// Defaults to DefaultRestartInitialInterval.
+	InitialInterval time.Duration `mapstructure:"initial_interval,omitempty"`
+
+	// MaxInterval is the upper bound on the time to wait between restarts.
+	// Defaults to DefaultRestartMaxInterval.
+	MaxInterval time.Duration `mapstructure:"max_interval,omitempty"`
+
+	// Multiplier is the factor by which the interval grows after each restart.
+	// Defaults to DefaultRestartMultiplier.
+	Multiplier float64 `mapstructure:"multiplier,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (p *RestartPolicy) Validate() error {
+	var errs []error
+	if p.MaxRetries < 0 {
+		errs = append(errs, errors.New("max_retries must be non-negative"))
+	}
+	if p.InitialInterval < 0 {
+		errs = append(errs, errors.New("initial_interval must be non-negative"))
+	}
+	if p.MaxInterval < 0 {
+		errs = append(errs, errors.New("max_interval must be non-negative"))
+	}
+	if p.InitialInterval > 0 && p.MaxInterval > 0 && p.InitialInterval > p.MaxInterval {
+		errs = append(errs, fmt.Errorf("initial_interval (%v) must not be greater than max_interval (%v)", p.InitialInterval, p.MaxInterval))
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		errs = append(errs, fmt.Errorf("multiplier must be at least 1, got %v", p.Multiplier))
+	}
+	return errors.Join(errs...)
+}
+
+// WithDefaults returns a copy of the policy where the unset fields have their default value.
+func (p RestartPolicy) WithDefaults() RestartPolicy {
+	if p.MaxRetries == 0 {
+		p.MaxRetries = DefaultRestartMaxRetries
+	}
+	if p.InitialInterval == 0 {
+		p.InitialInterval = DefaultRestartInitialInterval
+	}
+	if p.MaxInterval == 0 {
+		p.MaxInterval = max(DefaultRestartMaxInterval, p.InitialInterval)
+	}
+	if p.Multiplier == 0 {
+		p.Multiplier = DefaultRestartMultiplier
+	}
+	return p
+}
+
+// Backoff returns the time to wait before the given restart attempt, starting at 0.
+func (p RestartPolicy) Backoff(attempt int) time.Duration {
+	interval := float64(p.InitialInterval)
+	for range attempt {
+		interval *= p.Multiplier
+		if interval >= float64(p.MaxInterval) {
+			return p.MaxInterval
+		}
+	}
+	return time.Duration(interval)
+}
+
+type TimeoutConfig struct {
+	// Start is the maximum duration of the start of each component.
+	// There is no limit if zero.
+	Start time.Duration `mapstructure:"start,omitempty"`
+
+	// Shutdown is the maximum duration of the shutdown of each component.
+	// There is no limit if zero.
+	Shutdown time.Duration `mapstructure:"shutdown,omitempty"`
+
+	// Receivers overrides the timeouts of receivers.
+	Receivers map[component.ID]ComponentTimeouts `mapstructure:"receivers,omitempty"`
+
+	// Processors overrides the timeouts of processors.
+	Processors map[component.ID]ComponentTimeouts `mapstructure:"processors,omitempty"`
+
+	// Exporters overrides the timeouts of exporters.
+	Exporters map[component.ID]ComponentTimeouts `mapstructure:"exporters,omitempty"`
+
+	// Connectors overrides the timeouts of connectors.
+	Connectors map[component.ID]ComponentTimeouts `mapstructure:"connectors,omitempty"`
+
+	// Extensions overrides the timeouts of extensions.
+	Extensions map[component.ID]ComponentTimeouts `mapstructure:"extensions,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *TimeoutConfig) Validate() error {
+	var errs []error
+	if cfg.Start < 0 {
+		errs = append(errs, errors.New("start must be non-negative"))
+	}
+	if cfg.Shutdown < 0 {
+		errs = append(errs, errors.New("shutdown must be non-negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// For returns the timeouts of the component of the given kind and ID,
+// defaulting to the timeouts set for all components.
+func (cfg TimeoutConfig) For(kind component.Kind, id component.ID) ComponentTimeouts {
+	var overrides map[component.ID]ComponentTimeouts
+	switch kind {
+	case component.KindReceiver:
+		overrides = cfg.Receivers
+	case component.KindProcessor:
+		overrides = cfg.Processors
+	case component.KindExporter:
+		overrides = cfg.Exporters
+	case component.KindConnector:
+		overrides = cfg.Connectors
+	case component.KindExtension:
+		overrides = cfg.Extensions
+	}
+	timeouts := overrides[id]
+	if timeouts.Start == 0 {
+		timeouts.Start = cfg.Start
+	}
+	if timeouts.Shutdown == 0 {
+		timeouts.Shutdown = cfg.Shutdown
+	}
+	return timeouts
+}
+
+type ComponentTimeouts struct {
+	// Start is the maximum duration of the start of the component.
+	// Defaults to TimeoutConfig.Start.
+	Start time.Duration `mapstructure:"start,omitempty"`
+
+	// Shutdown is the maximum duration of the shutdown of the component.
+	// Defaults to TimeoutConfig.Shutdown.
+	Shutdown time.Duration `mapstructure:"shutdown,omitempty"`
+
+	// ContinueOnStartTimeout marks the component as non-critical: if its start times out,
+	// the component is reported with a permanent error and the collector keeps starting
+	// in a degraded state instead of failing.
+	ContinueOnStartTimeout bool `mapstructure:"continue_on_start_timeout,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (t *ComponentTimeouts) Validate() error {
+	var errs []error
+	if t.Start < 0 {
+		errs = append(errs, errors.New("start must be non-negative"))
+	}
+	if t.Shutdown < 0 {
+		errs = append(errs, errors.New("shutdown must be non-negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// StartFailurePolicy defines what happens when a component fails to start.
+type StartFailurePolicy string
+
+const (
+	// StartFailureFail stops the collector from starting. This is the default.
+	StartFailureFail StartFailurePolicy = "fail"
+	// StartFailureDisable reports the component with a permanent error and disables the
+	// pipelines it belongs to: the data entering these pipelines is dropped.
+	StartFailureDisable StartFailurePolicy = "disable"
+	// StartFailureRetry reports the component with a recoverable error and disables the
+	// pipelines it belongs to until a start retried in the background succeeds.
+	StartFailureRetry StartFailurePolicy = "retry"
+)
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (p *StartFailurePolicy) UnmarshalText(text []byte) error {
+	switch policy := StartFailurePolicy(text); policy {
+	case StartFailureFail, StartFailureDisable, StartFailureRetry:
+		*p = policy
+		return nil
+	}
+	return fmt.Errorf("unknown start failure policy %q, must be one of %q, %q or %q", text, StartFailureFail, StartFailureDisable, StartFailureRetry)
+}
+
+// Strictest returns the strictest of the two policies, an unset policy being the strictest.
+// A component shared by several pipelines uses the strictest of their policies.
+func (p StartFailurePolicy) Strictest(other StartFailurePolicy) StartFailurePolicy {
+	rank := func(p StartFailurePolicy) int {
+		switch p {
+		case StartFailureDisable:
+			return 0
+		case StartFailureRetry:
+			return 1
+		}
+		return 2
+	}
+	if rank(other) > rank(p) {
+		return other
+	}
+	return p
+}
+
+// StartFailureConfig defines the start failure policy of individual components, keyed by
+// component ID. It overrides the policy of the pipelines the components belong to.
+type StartFailureConfig struct {
+	// Receivers holds the start failure policy of receivers.
+	Receivers map[component.ID]StartFailurePolicy `mapstructure:"receivers,omitempty"`
+
+	// Processors holds the start failure policy of processors.
+	Processors map[component.ID]StartFailurePolicy `mapstructure:"processors,omitempty"`
+
+	// Exporters holds the start failure policy of exporters.
+	Exporters map[component.ID]StartFailurePolicy `mapstructure:"exporters,omitempty"`
+
+	// Connectors holds the start failure policy of connectors.
+	Connectors map[component.ID]StartFailurePolicy `mapstructure:"connectors,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// For returns the start failure policy of the component of the given kind and ID,
+// or an empty policy if the component has none.
+func (cfg StartFailureConfig) For(kind component.Kind, id component.ID) StartFailurePolicy {
+	switch kind {
+	case component.KindReceiver:
+		return cfg.Receivers[id]
+	case component.KindProcessor:
+		return cfg.Processors[id]
+	case component.KindExporter:
+		return cfg.Exporters[id]
+	case component.KindConnector:
+		return cfg.Connectors[id]
+	}
+	return ""
+}
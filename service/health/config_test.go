@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestDetectionConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         DetectionConfig
+		expectedErr string
+	}{
+		{
+			name: "default",
+			cfg:  DetectionConfig{},
+		},
+		{
+			name: "valid",
+			cfg:  DetectionConfig{Enabled: true, FailureThreshold: 3, RecoveryThreshold: 2},
+		},
+		{
+			name:        "negative failure threshold",
+			cfg:         DetectionConfig{FailureThreshold: -1},
+			expectedErr: "failure_threshold must be non-negative",
+		},
+		{
+			name:        "negative recovery threshold",
+			cfg:         DetectionConfig{RecoveryThreshold: -1},
+			expectedErr: "recovery_threshold must be non-negative",
+		},
+		{
+			name:        "queue saturation above 1",
+			cfg:         DetectionConfig{QueueSaturation: 1.5},
+			expectedErr: "queue_saturation must be between 0 and 1, got 1.5",
+		},
+		{
+			name:        "negative queue check interval",
+			cfg:         DetectionConfig{QueueCheckInterval: -time.Second},
+			expectedErr: "queue_check_interval must be non-negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRestartPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         RestartPolicy
+		expectedErr string
+	}{
+		{
+			name: "default",
+			cfg:  RestartPolicy{},
+		},
+		{
+			name: "valid",
+			cfg:  RestartPolicy{MaxRetries: 3, InitialInterval: time.Second, MaxInterval: time.Minute, Multiplier: 1.5},
+		},
+		{
+			name:        "negative max retries",
+			cfg:         RestartPolicy{MaxRetries: -1},
+			expectedErr: "max_retries must be non-negative",
+		},
+		{
+			name:        "negative intervals",
+			cfg:         RestartPolicy{InitialInterval: -1, MaxInterval: -1},
+			expectedErr: "initial_interval must be non-negative\nmax_interval must be non-negative",
+		},
+		{
+			name:        "initial greater than max",
+			cfg:         RestartPolicy{InitialInterval: time.Minute, MaxInterval: time.Second},
+			expectedErr: "initial_interval (1m0s) must not be greater than max_interval (1s)",
+		},
+		{
+			name:        "multiplier below one",
+			cfg:         RestartPolicy{Multiplier: 0.5},
+			expectedErr: "multiplier must be at least 1, got 0.5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRestartPolicyWithDefaults(t *testing.T) {
+	assert.Equal(t, RestartPolicy{
+		MaxRetries:      DefaultRestartMaxRetries,
+		InitialInterval: DefaultRestartInitialInterval,
+		MaxInterval:     DefaultRestartMaxInterval,
+		Multiplier:      DefaultRestartMultiplier,
+	}, RestartPolicy{}.WithDefaults())
+
+	// The default max interval is never lower than the configured initial interval.
+	p := RestartPolicy{InitialInterval: time.Minute}.WithDefaults()
+	assert.Equal(t, time.Minute, p.MaxInterval)
+}
+
+func TestRestartPolicyBackoff(t *testing.T) {
+	p := RestartPolicy{MaxRetries: 10, InitialInterval: time.Second, MaxInterval: 5 * time.Second, Multiplier: 2}
+	assert.Equal(t, time.Second, p.Backoff(0))
+	assert.Equal(t, 2*time.Second, p.Backoff(1))
+	assert.Equal(t, 4*time.Second, p.Backoff(2))
+	assert.Equal(t, 5*time.Second, p.Backoff(3))
+	assert.Equal(t, 5*time.Second, p.Backoff(9))
+}
+
+func TestTimeoutConfigValidate(t *testing.T) {
+	assert.NoError(t, (&TimeoutConfig{}).Validate())
+	assert.NoError(t, (&TimeoutConfig{Start: time.Second, Shutdown: time.Second}).Validate())
+	assert.EqualError(t, (&TimeoutConfig{Start: -1, Shutdown: -1}).Validate(), "start must be non-negative\nshutdown must be non-negative")
+	assert.EqualError(t, (&ComponentTimeouts{Start: -1, Shutdown: -1}).Validate(), "start must be non-negative\nshutdown must be non-negative")
+}
+
+func TestTimeoutConfigFor(t *testing.T) {
+	otlp := component.MustNewID("otlp")
+	cfg := TimeoutConfig{
+		Start:    time.Second,
+		Shutdown: 2 * time.Second,
+		Receivers: map[component.ID]ComponentTimeouts{
+			otlp: {Start: time.Minute, ContinueOnStartTimeout: true},
+		},
+	}
+	assert.Equal(t, ComponentTimeouts{Start: time.Minute, Shutdown: 2 * time.Second, ContinueOnStartTimeout: true}, cfg.For(component.KindReceiver, otlp))
+	// The overrides only apply to the components of their kind.
+	assert.Equal(t, ComponentTimeouts{Start: time.Second, Shutdown: 2 * time.Second}, cfg.For(component.KindExporter, otlp))
+	assert.Equal(t, ComponentTimeouts{}, TimeoutConfig{}.For(component.KindExtension, otlp))
+}
+
+func TestStartFailurePolicyUnmarshalText(t *testing.T) {
+	var policy StartFailurePolicy
+	assert.NoError(t, policy.UnmarshalText([]byte("retry")))
+	assert.Equal(t, StartFailureRetry, policy)
+	assert.EqualError(t, policy.UnmarshalText([]byte("ignore")), `unknown start failure policy "ignore", must be one of "fail", "disable" or "retry"`)
+}
+
+func TestStartFailurePolicyStrictest(t *testing.T) {
+	assert.Equal(t, StartFailureRetry, StartFailureDisable.Strictest(StartFailureRetry))
+	assert.Equal(t, StartFailureRetry, StartFailureRetry.Strictest(StartFailureDisable))
+	assert.Equal(t, StartFailureFail, StartFailureRetry.Strictest(StartFailureFail))
+	// An unset policy fails the start.
+	assert.Equal(t, StartFailurePolicy(""), StartFailureDisable.Strictest(""))
+}
+
+func TestStartFailureConfigFor(t *testing.T) {
+	otlp := component.MustNewID("otlp")
+	cfg := StartFailureConfig{
+		Exporters: map[component.ID]StartFailurePolicy{otlp: StartFailureDisable},
+	}
+	assert.Equal(t, StartFailureDisable, cfg.For(component.KindExporter, otlp))
+	assert.Equal(t, StartFailurePolicy(""), cfg.For(component.KindReceiver, otlp))
+}
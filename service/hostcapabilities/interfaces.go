@@ -6,7 +6,10 @@
 package hostcapabilities // import "go.opentelemetry.io/collector/service/hostcapabilities"
 
 import (
+	"net/http"
+
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/service/internal/moduleinfo"
 )
@@ -34,3 +37,104 @@ type ComponentFactory interface {
 	// component type
 	GetFactory(kind component.Kind, componentType component.Type) component.Factory
 }
+
+// ComponentStatusWatchers is an interface that may be implemented by the host to
+// let any component observe the stream of component status events, not only the
+// extensions implementing componentstatus.Watcher.
+type ComponentStatusWatchers interface {
+	// WatchComponentStatus registers the watcher to be notified of every component
+	// status change. The current status of every component is replayed to the watcher
+	// before the call returns, so no transition is missed. The returned function
+	// unregisters the watcher and must be called before the watching component shuts down.
+	// The status changes are notified in order; those reported from within
+	// ComponentStatusChanged are notified once it returned. The watcher must not call
+	// WatchComponentStatus or the unwatch function from within ComponentStatusChanged.
+	WatchComponentStatus(watcher componentstatus.Watcher) (unwatch func())
+}
+
+// DebugHandlers is an interface that may be implemented by the host to let any
+// component expose its internal state (e.g. cache contents, per-tenant counters)
+// over HTTP, without starting its own listener. The handlers are served by the
+// extension serving the host's zPages (e.g. the zpages extension), under
+// "<prefix>/componentz/<kind>/<component id>/", and are thereby subject to the
+// authentication and TLS settings of that extension.
+type DebugHandlers interface {
+	// RegisterDebugHandler registers the handler of the component of the given kind and ID.
+	// The handler receives the requests with the component path prefix stripped, and may
+	// serve any sub-path. Only one handler can be registered per component. The returned
+	// function unregisters the handler and must be called before the component shuts down.
+	RegisterDebugHandler(kind component.Kind, id component.ID, handler http.Handler) (unregister func(), err error)
+}
+
+// PipelineIntake is an interface that may be implemented by the host to let receivers
+// know whether the intake of the pipelines they emit to is paused, e.g. during a
+// maintenance window of a backend. The pipelines reject the data entering them while
+// paused, so that pushing receivers report a retryable error to their clients, while
+// scraping receivers can skip their scrapes instead of collecting data to be rejected.
+type PipelineIntake interface {
+	// IsReceiverPaused reports whether all the pipelines the receiver with the given ID
+	// emits data of the given signal to are paused.
+	IsReceiverPaused(id component.ID, signal pipeline.Signal) bool
+}
+
+// LifecycleState is the state of the collector in its lifecycle.
+type LifecycleState int
+
+const (
+	// LifecycleStarting is the state of the collector while its components start.
+	LifecycleStarting LifecycleState = iota
+	// LifecycleReady is the state of the running collector whose components are all healthy.
+	LifecycleReady
+	// LifecycleDegraded is the state of the running collector with a component reporting an error,
+	// including the configuration failing to be reloaded.
+	LifecycleDegraded
+	// LifecycleDraining is the state of the collector while its components shut down, draining their data.
+	LifecycleDraining
+	// LifecycleStopped is the state of the collector whose components are shut down.
+	LifecycleStopped
+)
+
+func (s LifecycleState) String() string {
+	switch s {
+	case LifecycleStarting:
+		return "Starting"
+	case LifecycleReady:
+		return "Ready"
+	case LifecycleDegraded:
+		return "Degraded"
+	case LifecycleDraining:
+		return "Draining"
+	case LifecycleStopped:
+		return "Stopped"
+	}
+	return "Unknown"
+}
+
+// Started reports whether the collector started, which a startup probe checks.
+func (s LifecycleState) Started() bool {
+	return s != LifecycleStarting
+}
+
+// Ready reports whether the collector is ready to receive data, which a readiness probe checks.
+// A degraded collector keeps receiving data, so it is ready.
+func (s LifecycleState) Ready() bool {
+	return s == LifecycleReady || s == LifecycleDegraded
+}
+
+// Alive reports whether the collector did not stop, which a liveness probe checks.
+func (s LifecycleState) Alive() bool {
+	return s != LifecycleStopped
+}
+
+// Lifecycle is an interface that may be implemented by the host to let components know
+// the state of the collector in its lifecycle, e.g. an extension serving the startup,
+// readiness and liveness probes of the collector.
+type Lifecycle interface {
+	// LifecycleState returns the current state of the collector.
+	LifecycleState() LifecycleState
+	// WatchLifecycleState registers the function to be called with every change of the state of
+	// the collector. It is called with the current state before the call returns. The returned
+	// function unregisters it and must be called before the watching component shuts down, but
+	// not from the function. The function is called in order, may read the state, and must not block.
+	WatchLifecycleState(fn func(LifecycleState)) (unwatch func())
+}
@@ -17,6 +17,10 @@ import (
 const (
 	capabiltiesKind = "capabilities"
 	fanoutKind      = "fanout"
+	feedbackKind    = "feedback"
+	shadowKind      = "shadow"
+
+	tenantKey = "otelcol.tenant"
 )
 
 type Attributes struct {
@@ -64,6 +68,15 @@ func Processor(pipelineID pipeline.ID, id component.ID) Attributes {
 	)
 }
 
+// SharedProcessor returns the attributes of a processor instance shared by the pipelines of a signal.
+func SharedProcessor(pipelineType pipeline.Signal, id component.ID) Attributes {
+	return newAttributes(
+		attribute.String(componentattribute.ComponentKindKey, strings.ToLower(component.KindProcessor.String())),
+		attribute.String(componentattribute.SignalKey, pipelineType.String()),
+		attribute.String(componentattribute.ComponentIDKey, id.String()),
+	)
+}
+
 func Exporter(pipelineType pipeline.Signal, id component.ID) Attributes {
 	return newAttributes(
 		attribute.String(componentattribute.ComponentKindKey, strings.ToLower(component.KindExporter.String())),
@@ -101,3 +114,27 @@ func Fanout(pipelineID pipeline.ID) Attributes {
 		attribute.String(componentattribute.PipelineIDKey, pipelineID.String()),
 	)
 }
+
+// Feedback returns the attributes of the feedback edge from a pipeline to a connector.
+func Feedback(pipelineID pipeline.ID, rcvrPipelineType pipeline.Signal, id component.ID) Attributes {
+	return newAttributes(
+		attribute.String(componentattribute.ComponentKindKey, feedbackKind),
+		attribute.String(componentattribute.PipelineIDKey, pipelineID.String()),
+		attribute.String(componentattribute.SignalOutputKey, rcvrPipelineType.String()),
+		attribute.String(componentattribute.ComponentIDKey, id.String()),
+	)
+}
+
+// Shadow returns the attributes of the edge copying the input of a primary pipeline to its shadow pipeline.
+func Shadow(pipelineID pipeline.ID) Attributes {
+	return newAttributes(
+		attribute.String(componentattribute.ComponentKindKey, shadowKind),
+		attribute.String(componentattribute.PipelineIDKey, pipelineID.String()),
+	)
+}
+
+// Tenant returns the attributes of the instance of a component for a tenant of a pipeline
+// partitioned by tenant.
+func Tenant(a Attributes, tenant string) Attributes {
+	return newAttributes(append(a.set.ToSlice(), attribute.String(tenantKey, tenant))...)
+}
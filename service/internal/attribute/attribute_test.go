@@ -79,6 +79,28 @@ func TestProcessor(t *testing.T) {
 	}
 }
 
+func TestSharedProcessor(t *testing.T) {
+	for _, sig := range signals {
+		for _, id := range cIDs {
+			p := attribute.SharedProcessor(sig, id)
+			componentKind, ok := p.Set().Value(componentattribute.ComponentKindKey)
+			require.True(t, ok)
+			require.Equal(t, "processor", componentKind.AsString())
+
+			signal, ok := p.Set().Value(componentattribute.SignalKey)
+			require.True(t, ok)
+			require.Equal(t, sig.String(), signal.AsString())
+
+			_, ok = p.Set().Value(componentattribute.PipelineIDKey)
+			require.False(t, ok)
+
+			componentID, ok := p.Set().Value(componentattribute.ComponentIDKey)
+			require.True(t, ok)
+			require.Equal(t, id.String(), componentID.AsString())
+		}
+	}
+}
+
 func TestExporter(t *testing.T) {
 	for _, sig := range signals {
 		for _, id := range cIDs {
@@ -195,3 +217,13 @@ func createExampleSets() []attribute.Attributes {
 
 	return sets
 }
+
+func TestTenant(t *testing.T) {
+	e := attribute.Exporter(pipeline.SignalTraces, component.MustNewID("foo"))
+	a, b := attribute.Tenant(e, "a"), attribute.Tenant(e, "b")
+	tenant, ok := a.Set().Value("otelcol.tenant")
+	require.True(t, ok)
+	require.Equal(t, "a", tenant.AsString())
+	require.NotEqual(t, e.ID(), a.ID())
+	require.NotEqual(t, a.ID(), b.ID())
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bufferconsumer decouples a connector from the pipelines exporting to it with a bounded queue.
+package bufferconsumer // import "go.opentelemetry.io/collector/service/internal/bufferconsumer"
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	// ErrBufferFull is returned when the data is refused because the buffer is full.
+	ErrBufferFull = errors.New("connector buffer is full")
+
+	errBufferStopped = errors.New("connector buffer is stopped")
+)
+
+// Settings configures the buffer.
+type Settings struct {
+	// QueueSize is the maximum number of calls waiting to be consumed.
+	QueueSize int
+	// NumConsumers is the number of goroutines consuming the queue, 1 if not set.
+	NumConsumers int
+	// Logger logs the errors returned by the next consumer, which cannot be returned to the caller.
+	Logger *zap.Logger
+}
+
+// The buffered data is owned by the buffer once the call returns.
+var mutatesData = consumer.Capabilities{MutatesData: true}
+
+// Traces is a consumer.Traces buffering the consumed traces. The queue is consumed between
+// Start and Shutdown, which waits for the queued data to be consumed.
+type Traces struct {
+	buffer[ptrace.Traces]
+}
+
+// NewTraces returns a Traces buffering the traces before passing them to next.
+func NewTraces(next consumer.Traces, set Settings) *Traces {
+	return &Traces{buffer: newBuffer(next.ConsumeTraces, set)}
+}
+
+func (*Traces) Capabilities() consumer.Capabilities {
+	return mutatesData
+}
+
+func (c *Traces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return c.consume(ctx, td)
+}
+
+// Metrics is a consumer.Metrics buffering the consumed metrics.
+type Metrics struct {
+	buffer[pmetric.Metrics]
+}
+
+// NewMetrics returns a Metrics buffering the metrics before passing them to next.
+func NewMetrics(next consumer.Metrics, set Settings) *Metrics {
+	return &Metrics{buffer: newBuffer(next.ConsumeMetrics, set)}
+}
+
+func (*Metrics) Capabilities() consumer.Capabilities {
+	return mutatesData
+}
+
+func (c *Metrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return c.consume(ctx, md)
+}
+
+// Logs is a consumer.Logs buffering the consumed logs.
+type Logs struct {
+	buffer[plog.Logs]
+}
+
+// NewLogs returns a Logs buffering the logs before passing them to next.
+func NewLogs(next consumer.Logs, set Settings) *Logs {
+	return &Logs{buffer: newBuffer(next.ConsumeLogs, set)}
+}
+
+func (*Logs) Capabilities() consumer.Capabilities {
+	return mutatesData
+}
+
+func (c *Logs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return c.consume(ctx, ld)
+}
+
+// Profiles is a xconsumer.Profiles buffering the consumed profiles.
+type Profiles struct {
+	buffer[pprofile.Profiles]
+}
+
+// NewProfiles returns a Profiles buffering the profiles before passing them to next.
+func NewProfiles(next xconsumer.Profiles, set Settings) *Profiles {
+	return &Profiles{buffer: newBuffer(next.ConsumeProfiles, set)}
+}
+
+func (*Profiles) Capabilities() consumer.Capabilities {
+	return mutatesData
+}
+
+func (c *Profiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	return c.consume(ctx, pd)
+}
+
+type request[T any] struct {
+	ctx  context.Context
+	data T
+}
+
+// buffer queues the consumed data, and returns without waiting for it to be consumed.
+// The data is refused with ErrBufferFull when the queue is full, so that the exporting
+// pipelines get backpressure instead of being blocked by a slow downstream pipeline.
+type buffer[T any] struct {
+	set   Settings
+	next  func(context.Context, T) error
+	queue chan request[T]
+
+	mu      sync.RWMutex
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+func newBuffer[T any](next func(context.Context, T) error, set Settings) buffer[T] {
+	if set.NumConsumers <= 0 {
+		set.NumConsumers = 1
+	}
+	return buffer[T]{set: set, next: next, queue: make(chan request[T], set.QueueSize)}
+}
+
+func (b *buffer[T]) consume(ctx context.Context, data T) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.stopped {
+		return errBufferStopped
+	}
+	select {
+	case b.queue <- request[T]{ctx: context.WithoutCancel(ctx), data: data}:
+		return nil
+	default:
+		return ErrBufferFull
+	}
+}
+
+// Start starts consuming the queue.
+func (b *buffer[T]) Start(context.Context, component.Host) error {
+	for range b.set.NumConsumers {
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			for req := range b.queue {
+				if err := b.next(req.ctx, req.data); err != nil {
+					b.set.Logger.Error("Failed to consume buffered data", zap.Error(err))
+				}
+			}
+		}()
+	}
+	return nil
+}
+
+// Shutdown refuses the new data and waits for the queued data to be consumed.
+func (b *buffer[T]) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.stopped {
+		b.stopped = true
+		close(b.queue)
+	}
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package bufferconsumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestTracesBuffer(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cons := NewTraces(sink, Settings{QueueSize: 2, Logger: zap.NewNop()})
+	assert.True(t, cons.Capabilities().MutatesData)
+	require.NoError(t, cons.Start(context.Background(), componenttest.NewNopHost()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, cons.ConsumeTraces(ctx, testdata.GenerateTraces(1)))
+	// The queued data is still consumed once the call returned and its context is canceled.
+	cancel()
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+
+	require.NoError(t, cons.Shutdown(context.Background()))
+	assert.Equal(t, 3, sink.SpanCount())
+	assert.Error(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+}
+
+func TestLogsBufferFull(t *testing.T) {
+	release := make(chan struct{})
+	blocked, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+	cons := NewLogs(blocked, Settings{QueueSize: 1, Logger: zap.NewNop()})
+	require.NoError(t, cons.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The consumer blocks on the first logs, the second ones fill the queue.
+	require.NoError(t, cons.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.NoError(c, cons.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	}, time.Second, time.Millisecond)
+	require.ErrorIs(t, cons.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)), ErrBufferFull)
+
+	close(release)
+	require.NoError(t, cons.Shutdown(context.Background()))
+}
+
+func TestMetricsBufferLogsErrors(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	cons := NewMetrics(consumertest.NewErr(assert.AnError), Settings{QueueSize: 1, NumConsumers: 2, Logger: zap.New(core)})
+	require.NoError(t, cons.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, cons.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	require.NoError(t, cons.Shutdown(context.Background()))
+	assert.Equal(t, 1, logs.FilterMessage("Failed to consume buffered data").Len())
+}
+
+func TestProfilesBufferShutdownTimeout(t *testing.T) {
+	release := make(chan struct{})
+	blocked, err := xconsumer.NewProfiles(func(context.Context, pprofile.Profiles) error {
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+	cons := NewProfiles(blocked, Settings{QueueSize: 1, Logger: zap.NewNop()})
+	require.NoError(t, cons.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, cons.ConsumeProfiles(context.Background(), testdata.GenerateProfiles(1)))
+
+	// The shutdown waits for the queued data to be consumed, up to the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, cons.Shutdown(ctx), context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, cons.Shutdown(context.Background()))
+}
@@ -371,6 +371,14 @@ func TestConnectorBuilderGetters(t *testing.T) {
 	assert.Nil(t, b.Factory(component.MustNewID("bar").Type()))
 }
 
+func TestConnectorBuilderConfig(t *testing.T) {
+	cfg := &struct{}{}
+	b := builders.NewConnector(map[component.ID]component.Config{component.MustNewID("foo"): cfg}, nil)
+
+	assert.Same(t, cfg, b.Config(component.MustNewID("foo")))
+	assert.Nil(t, b.Config(component.MustNewID("bar")))
+}
+
 func TestNewNopConnectorConfigsAndFactories(t *testing.T) {
 	configs, factories := builders.NewNopConnectorConfigsAndFactories()
 	builder := builders.NewConnector(configs, factories)
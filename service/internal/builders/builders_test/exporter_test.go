@@ -150,6 +150,42 @@ func TestExporterBuilderFactory(t *testing.T) {
 	assert.Nil(t, b.Factory(component.MustNewID("bar").Type()))
 }
 
+func TestExporterBuilderWithConfigs(t *testing.T) {
+	var created []component.Config
+	factories, err := otelcol.MakeFactoryMap([]exporter.Factory{
+		exporter.NewFactory(component.MustNewType("foo"), nil,
+			exporter.WithTraces(func(_ context.Context, _ exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+				created = append(created, cfg)
+				return exportertest.NewNopFactory().CreateTraces(context.Background(), exportertest.NewNopSettings(exportertest.NopType), cfg)
+			}, component.StabilityLevelDevelopment)),
+	}...)
+	require.NoError(t, err)
+
+	fooID := component.MustNewID("foo")
+	barID := component.MustNewIDWithName("foo", "bar")
+	oldCfg, newCfg, barCfg := &struct{ v int }{v: 1}, &struct{ v int }{v: 2}, &struct{ v int }{v: 3}
+	b := builders.NewExporter(map[component.ID]component.Config{fooID: oldCfg, barID: barCfg}, factories)
+	reloaded := b.WithConfigs(map[component.ID]component.Config{fooID: newCfg})
+
+	for _, builder := range []*builders.ExporterBuilder{b, reloaded} {
+		for _, id := range []component.ID{fooID, barID} {
+			set := exportertest.NewNopSettings(id.Type())
+			set.ID = id
+			_, err = builder.CreateTraces(context.Background(), set)
+			require.NoError(t, err)
+		}
+	}
+	assert.Equal(t, []component.Config{oldCfg, barCfg, newCfg, barCfg}, created)
+}
+
+func TestExporterBuilderConfig(t *testing.T) {
+	cfg := &struct{}{}
+	b := builders.NewExporter(map[component.ID]component.Config{component.MustNewID("foo"): cfg}, nil)
+
+	assert.Same(t, cfg, b.Config(component.MustNewID("foo")))
+	assert.Nil(t, b.Config(component.MustNewID("bar")))
+}
+
 func TestNewNopExporterConfigsAndFactories(t *testing.T) {
 	configs, factories := builders.NewNopExporterConfigsAndFactories()
 	builder := builders.NewExporter(configs, factories)
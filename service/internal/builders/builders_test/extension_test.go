@@ -67,6 +67,14 @@ func TestExtensionBuilderFactory(t *testing.T) {
 	assert.Nil(t, b.Factory(component.MustNewID("bar").Type()))
 }
 
+func TestExtensionBuilderConfig(t *testing.T) {
+	cfg := &struct{}{}
+	b := builders.NewExtension(map[component.ID]component.Config{component.MustNewID("foo"): cfg}, nil)
+
+	assert.Same(t, cfg, b.Config(component.MustNewID("foo")))
+	assert.Nil(t, b.Config(component.MustNewID("bar")))
+}
+
 func TestNewNopExtensionConfigsAndFactories(t *testing.T) {
 	configs, factories := builders.NewNopExtensionConfigsAndFactories()
 	builder := builders.NewExtension(configs, factories)
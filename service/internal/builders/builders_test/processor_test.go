@@ -181,6 +181,29 @@ func TestProcessorBuilderFactory(t *testing.T) {
 	assert.Nil(t, b.Factory(component.MustNewID("bar").Type()))
 }
 
+func TestProcessorBuilderSharedInstance(t *testing.T) {
+	factories, err := otelcol.MakeFactoryMap([]processor.Factory{
+		processor.NewFactory(component.MustNewType("foo"), nil),
+		xprocessor.NewFactory(component.MustNewType("bar"), nil),
+		xprocessor.NewFactory(component.MustNewType("shared"), nil, xprocessor.WithSharedInstance()),
+	}...)
+	require.NoError(t, err)
+
+	b := builders.NewProcessor(map[component.ID]component.Config{}, factories)
+	assert.False(t, b.SharedInstance(component.MustNewID("foo")))
+	assert.False(t, b.SharedInstance(component.MustNewID("bar")))
+	assert.True(t, b.SharedInstance(component.MustNewIDWithName("shared", "1")))
+	assert.False(t, b.SharedInstance(component.MustNewID("missing")))
+}
+
+func TestProcessorBuilderConfig(t *testing.T) {
+	cfg := &struct{}{}
+	b := builders.NewProcessor(map[component.ID]component.Config{component.MustNewID("foo"): cfg}, nil)
+
+	assert.Same(t, cfg, b.Config(component.MustNewID("foo")))
+	assert.Nil(t, b.Config(component.MustNewID("bar")))
+}
+
 func TestNewNopProcessorBuilder(t *testing.T) {
 	configs, factories := builders.NewNopProcessorConfigsAndFactories()
 	builder := builders.NewProcessor(configs, factories)
@@ -181,6 +181,14 @@ func TestReceiverBuilderFactory(t *testing.T) {
 	assert.Nil(t, b.Factory(component.MustNewID("bar").Type()))
 }
 
+func TestReceiverBuilderConfig(t *testing.T) {
+	cfg := &struct{}{}
+	b := builders.NewReceiver(map[component.ID]component.Config{component.MustNewID("foo"): cfg}, nil)
+
+	assert.Same(t, cfg, b.Config(component.MustNewID("foo")))
+	assert.Nil(t, b.Config(component.MustNewID("bar")))
+}
+
 func TestNewNopReceiverConfigsAndFactories(t *testing.T) {
 	configs, factories := builders.NewNopReceiverConfigsAndFactories()
 	builder := builders.NewReceiver(configs, factories)
@@ -94,10 +94,27 @@ func (b *ExporterBuilder) CreateProfiles(ctx context.Context, set exporter.Setti
 	return f.CreateProfiles(ctx, set, cfg)
 }
 
+// Config returns the configuration of the exporter, or nil if it is not configured.
+func (b *ExporterBuilder) Config(id component.ID) component.Config {
+	return b.cfgs[id]
+}
+
 func (b *ExporterBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
 
+// WithConfigs returns a new ExporterBuilder where the given configurations replace the existing ones.
+func (b *ExporterBuilder) WithConfigs(cfgs map[component.ID]component.Config) *ExporterBuilder {
+	merged := make(map[component.ID]component.Config, len(b.cfgs)+len(cfgs))
+	for id, cfg := range b.cfgs {
+		merged[id] = cfg
+	}
+	for id, cfg := range cfgs {
+		merged[id] = cfg
+	}
+	return &ExporterBuilder{cfgs: merged, factories: b.factories}
+}
+
 // NewNopExporterConfigsAndFactories returns a configuration and factories that allows building a new nop exporter.
 func NewNopExporterConfigsAndFactories() (map[component.ID]component.Config, map[component.Type]exporter.Factory) {
 	nopFactory := exportertest.NewNopFactory()
@@ -52,6 +52,11 @@ func (b *ExtensionBuilder) Create(ctx context.Context, set extension.Settings) (
 	return f.Create(ctx, set, cfg)
 }
 
+// Config returns the configuration of the extension, or nil if it is not configured.
+func (b *ExtensionBuilder) Config(id component.ID) component.Config {
+	return b.cfgs[id]
+}
+
 func (b *ExtensionBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
@@ -108,6 +108,17 @@ func (b *ProcessorBuilder) CreateProfiles(ctx context.Context, set processor.Set
 	return f.CreateProfiles(ctx, set, cfg, next)
 }
 
+// SharedInstance returns whether the processor can be shared by the pipelines of a signal.
+func (b *ProcessorBuilder) SharedInstance(id component.ID) bool {
+	f, ok := b.factories[id.Type()].(xprocessor.Factory)
+	return ok && f.SharedInstance()
+}
+
+// Config returns the configuration of the processor, or nil if it is not configured.
+func (b *ProcessorBuilder) Config(id component.ID) component.Config {
+	return b.cfgs[id]
+}
+
 func (b *ProcessorBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package conversionconsumer // import "go.opentelemetry.io/collector/service/internal/conversionconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// NewInputLogs wraps the consumer of a connector consuming logs.
+func NewInputLogs(logs consumer.Logs, conv *Conversion) consumer.Logs {
+	return inputLogs{Logs: logs, conv: conv}
+}
+
+type inputLogs struct {
+	consumer.Logs
+	conv *Conversion
+}
+
+func (c inputLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	ctx, done := c.conv.consume(ctx, ld.LogRecordCount())
+	err := c.Logs.ConsumeLogs(ctx, ld)
+	done(err)
+	return err
+}
+
+// NewOutputLogs wraps a consumer the connector emits logs to.
+func NewOutputLogs(logs consumer.Logs, conv *Conversion) consumer.Logs {
+	return outputLogs{Logs: logs, conv: conv}
+}
+
+type outputLogs struct {
+	consumer.Logs
+	conv *Conversion
+}
+
+func (c outputLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	done := c.conv.emit(ctx)
+	err := c.Logs.ConsumeLogs(ctx, ld)
+	done(err)
+	return err
+}
+
+// NewInputMetrics wraps the consumer of a connector consuming metrics.
+func NewInputMetrics(metrics consumer.Metrics, conv *Conversion) consumer.Metrics {
+	return inputMetrics{Metrics: metrics, conv: conv}
+}
+
+type inputMetrics struct {
+	consumer.Metrics
+	conv *Conversion
+}
+
+func (c inputMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	ctx, done := c.conv.consume(ctx, md.DataPointCount())
+	err := c.Metrics.ConsumeMetrics(ctx, md)
+	done(err)
+	return err
+}
+
+// NewOutputMetrics wraps a consumer the connector emits metrics to.
+func NewOutputMetrics(metrics consumer.Metrics, conv *Conversion) consumer.Metrics {
+	return outputMetrics{Metrics: metrics, conv: conv}
+}
+
+type outputMetrics struct {
+	consumer.Metrics
+	conv *Conversion
+}
+
+func (c outputMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	done := c.conv.emit(ctx)
+	err := c.Metrics.ConsumeMetrics(ctx, md)
+	done(err)
+	return err
+}
+
+// NewInputTraces wraps the consumer of a connector consuming traces.
+func NewInputTraces(traces consumer.Traces, conv *Conversion) consumer.Traces {
+	return inputTraces{Traces: traces, conv: conv}
+}
+
+type inputTraces struct {
+	consumer.Traces
+	conv *Conversion
+}
+
+func (c inputTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	ctx, done := c.conv.consume(ctx, td.SpanCount())
+	err := c.Traces.ConsumeTraces(ctx, td)
+	done(err)
+	return err
+}
+
+// NewOutputTraces wraps a consumer the connector emits traces to.
+func NewOutputTraces(traces consumer.Traces, conv *Conversion) consumer.Traces {
+	return outputTraces{Traces: traces, conv: conv}
+}
+
+type outputTraces struct {
+	consumer.Traces
+	conv *Conversion
+}
+
+func (c outputTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	done := c.conv.emit(ctx)
+	err := c.Traces.ConsumeTraces(ctx, td)
+	done(err)
+	return err
+}
+
+// NewInputProfiles wraps the consumer of a connector consuming profiles.
+func NewInputProfiles(profiles xconsumer.Profiles, conv *Conversion) xconsumer.Profiles {
+	return inputProfiles{Profiles: profiles, conv: conv}
+}
+
+type inputProfiles struct {
+	xconsumer.Profiles
+	conv *Conversion
+}
+
+func (c inputProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	ctx, done := c.conv.consume(ctx, pd.SampleCount())
+	err := c.Profiles.ConsumeProfiles(ctx, pd)
+	done(err)
+	return err
+}
+
+// NewOutputProfiles wraps a consumer the connector emits profiles to.
+func NewOutputProfiles(profiles xconsumer.Profiles, conv *Conversion) xconsumer.Profiles {
+	return outputProfiles{Profiles: profiles, conv: conv}
+}
+
+type outputProfiles struct {
+	xconsumer.Profiles
+	conv *Conversion
+}
+
+func (c outputProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	done := c.conv.emit(ctx)
+	err := c.Profiles.ConsumeProfiles(ctx, pd)
+	done(err)
+	return err
+}
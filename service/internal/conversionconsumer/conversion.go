@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package conversionconsumer wraps the consumers of a connector to report how the data
+// passed to the connector converts into the data it emits: the items dropped and the time
+// spent converting them.
+package conversionconsumer // import "go.opentelemetry.io/collector/service/internal/conversionconsumer"
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Settings defines the instruments used to report the conversion of a connector.
+type Settings struct {
+	// DroppedItems is the metric counting the items the connector failed to consume,
+	// excluding the items refused by the next consumers.
+	DroppedItems metric.Int64Counter
+
+	// Duration is the metric recording the time spent by the connector consuming a payload,
+	// excluding the time spent in the next consumers called synchronously.
+	Duration metric.Float64Histogram
+
+	// Attributes identify the connector instance and its pair of signals.
+	Attributes attribute.Set
+}
+
+// Conversion records the conversion of the data by one connector instance. It is shared by
+// the input consumer of the instance and the consumers of the pipelines it emits to.
+type Conversion struct {
+	set   Settings
+	attrs metric.MeasurementOption
+}
+
+// NewConversion returns a new Conversion reporting to the given instruments.
+func NewConversion(set Settings) *Conversion {
+	return &Conversion{
+		set:   set,
+		attrs: metric.WithAttributeSet(set.Attributes),
+	}
+}
+
+type callKey struct{}
+
+// call tracks one call to the connector, so that the time spent in the next consumers
+// can be subtracted from its duration, and the errors of the next consumers can be told
+// apart from the failures of the connector.
+type call struct {
+	downstream atomic.Int64
+	refused    atomic.Bool
+}
+
+// consume tracks a call passing the given items to the connector, and returns the context to
+// pass to the connector along with the function to call with the result of the connector.
+func (c *Conversion) consume(ctx context.Context, items int) (context.Context, func(error)) {
+	cl := &call{}
+	start := time.Now()
+	return context.WithValue(ctx, callKey{}, cl), func(err error) {
+		// The next consumers may be called concurrently, or after the connector returned.
+		if d := time.Since(start) - time.Duration(cl.downstream.Load()); d > 0 {
+			c.set.Duration.Record(ctx, d.Seconds(), c.attrs)
+		}
+		if err != nil && !cl.refused.Load() {
+			c.set.DroppedItems.Add(ctx, int64(items), c.attrs)
+		}
+	}
+}
+
+// emit tracks data emitted from the connector, and returns the function to call with the
+// result of the next consumer. The data emitted outside of a call to the connector, for
+// instance from a timer, is not tracked.
+func (*Conversion) emit(ctx context.Context) func(error) {
+	cl, _ := ctx.Value(callKey{}).(*call)
+	if cl == nil {
+		return func(error) {}
+	}
+	start := time.Now()
+	return func(err error) {
+		cl.downstream.Add(int64(time.Since(start)))
+		if err != nil {
+			cl.refused.Store(true)
+		}
+	}
+}
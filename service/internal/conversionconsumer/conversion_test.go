@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package conversionconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+	"go.opentelemetry.io/collector/service/internal/metadatatest"
+)
+
+var testAttrs = attribute.NewSet(attribute.String("otelcol.component.id", "spanmetrics"))
+
+func newTestConversion(t *testing.T) (*componenttest.Telemetry, *Conversion) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	tb, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	return tel, NewConversion(Settings{
+		DroppedItems: tb.ConnectorConversionDroppedItems,
+		Duration:     tb.ConnectorConversionDuration,
+		Attributes:   testAttrs,
+	})
+}
+
+// newSpanMetrics returns a connector emitting one metric with one data point per span.
+func newSpanMetrics(t *testing.T, conv *Conversion, next consumer.Metrics) consumer.Traces {
+	out := NewOutputMetrics(next, conv)
+	cons, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		md := pmetric.NewMetrics()
+		dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptySum().DataPoints()
+		for range td.SpanCount() {
+			dps.AppendEmpty()
+		}
+		return out.ConsumeMetrics(ctx, md)
+	})
+	require.NoError(t, err)
+	return NewInputTraces(cons, conv)
+}
+
+// durationCount returns the number of calls to the connector whose duration was recorded.
+func durationCount(t *testing.T, tel *componenttest.Telemetry) uint64 {
+	got, err := tel.GetMetric("otelcol.connector.conversion.duration")
+	require.NoError(t, err)
+	dps := got.Data.(metricdata.Histogram[float64]).DataPoints
+	require.Len(t, dps, 1)
+	assert.Equal(t, testAttrs, dps[0].Attributes)
+	return dps[0].Count
+}
+
+func TestConversion(t *testing.T) {
+	tel, conv := newTestConversion(t)
+	sink := new(consumertest.MetricsSink)
+	conn := newSpanMetrics(t, conv, sink)
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	require.NoError(t, conn.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.Equal(t, 5, sink.DataPointCount())
+
+	assert.Equal(t, uint64(2), durationCount(t, tel))
+	_, err := tel.GetMetric("otelcol.connector.conversion.dropped.items")
+	require.Error(t, err)
+}
+
+func TestConversionDropped(t *testing.T) {
+	tel, conv := newTestConversion(t)
+
+	// The errors of the next consumers are not counted as dropped by the connector.
+	conn := newSpanMetrics(t, conv, consumertest.NewErr(assert.AnError))
+	require.ErrorIs(t, conn.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)), assert.AnError)
+
+	failing, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+		return errors.New("invalid span")
+	})
+	require.NoError(t, err)
+	require.Error(t, NewInputTraces(failing, conv).ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+
+	metadatatest.AssertEqualConnectorConversionDroppedItems(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2, Attributes: testAttrs}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestConversionDurationExcludesDownstream(t *testing.T) {
+	tel, conv := newTestConversion(t)
+	next, err := consumer.NewMetrics(func(context.Context, pmetric.Metrics) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	require.NoError(t, err)
+	conn := newSpanMetrics(t, conv, next)
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+
+	got, err := tel.GetMetric("otelcol.connector.conversion.duration")
+	require.NoError(t, err)
+	dps := got.Data.(metricdata.Histogram[float64]).DataPoints
+	require.Len(t, dps, 1)
+	assert.Equal(t, uint64(1), dps[0].Count)
+	assert.Less(t, dps[0].Sum, (50 * time.Millisecond).Seconds())
+}
+
+func TestConversionEmitOutsideCall(t *testing.T) {
+	tel, conv := newTestConversion(t)
+
+	// Data emitted from a timer does not affect any call, even when refused.
+	require.NoError(t, NewOutputLogs(consumertest.NewNop(), conv).ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	require.NoError(t, NewOutputProfiles(consumertest.NewNop(), conv).ConsumeProfiles(context.Background(), testdata.GenerateProfiles(3)))
+	require.Error(t, NewOutputTraces(consumertest.NewErr(assert.AnError), conv).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	_, err := tel.GetMetric("otelcol.connector.conversion.duration")
+	require.Error(t, err)
+	_, err = tel.GetMetric("otelcol.connector.conversion.dropped.items")
+	require.Error(t, err)
+}
+
+func TestConversionInputSignals(t *testing.T) {
+	tel, conv := newTestConversion(t)
+
+	require.NoError(t, NewInputLogs(consumertest.NewNop(), conv).ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	require.NoError(t, NewInputMetrics(consumertest.NewNop(), conv).ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	require.NoError(t, NewInputProfiles(consumertest.NewNop(), conv).ConsumeProfiles(context.Background(), pprofile.NewProfiles()))
+	require.NoError(t, NewInputLogs(consumertest.NewNop(), conv).ConsumeLogs(context.Background(), plog.NewLogs()))
+
+	assert.Equal(t, uint64(4), durationCount(t, tel))
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package countconsumer // import "go.opentelemetry.io/collector/service/internal/countconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func NewLogs(logs consumer.Logs, counts *Counts) consumer.Logs {
+	if counts == nil {
+		return logs
+	}
+	return countLogs{Logs: logs, counts: counts}
+}
+
+type countLogs struct {
+	consumer.Logs
+	counts *Counts
+}
+
+func (c countLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	// Count the items first, as the data may be modified or released by the next consumer.
+	items := ld.LogRecordCount()
+	err := c.Logs.ConsumeLogs(ctx, ld)
+	c.counts.Record(items, err)
+	return err
+}
+
+func NewMetrics(metrics consumer.Metrics, counts *Counts) consumer.Metrics {
+	if counts == nil {
+		return metrics
+	}
+	return countMetrics{Metrics: metrics, counts: counts}
+}
+
+type countMetrics struct {
+	consumer.Metrics
+	counts *Counts
+}
+
+func (c countMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	items := md.DataPointCount()
+	err := c.Metrics.ConsumeMetrics(ctx, md)
+	c.counts.Record(items, err)
+	return err
+}
+
+func NewTraces(traces consumer.Traces, counts *Counts) consumer.Traces {
+	if counts == nil {
+		return traces
+	}
+	return countTraces{Traces: traces, counts: counts}
+}
+
+type countTraces struct {
+	consumer.Traces
+	counts *Counts
+}
+
+func (c countTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	items := td.SpanCount()
+	err := c.Traces.ConsumeTraces(ctx, td)
+	c.counts.Record(items, err)
+	return err
+}
+
+func NewProfiles(profiles xconsumer.Profiles, counts *Counts) xconsumer.Profiles {
+	if counts == nil {
+		return profiles
+	}
+	return countProfiles{Profiles: profiles, counts: counts}
+}
+
+type countProfiles struct {
+	xconsumer.Profiles
+	counts *Counts
+}
+
+func (c countProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	items := pd.SampleCount()
+	err := c.Profiles.ConsumeProfiles(ctx, pd)
+	c.counts.Record(items, err)
+	return err
+}
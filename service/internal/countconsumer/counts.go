@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package countconsumer wraps consumers to count the items accepted and refused by the
+// consumer they wrap, for the live statistics of the pipelines.
+package countconsumer // import "go.opentelemetry.io/collector/service/internal/countconsumer"
+
+import (
+	"sync/atomic"
+)
+
+// Counts holds the number of items accepted and refused by a consumer. A nil Counts counts nothing.
+type Counts struct {
+	accepted atomic.Int64
+	refused  atomic.Int64
+}
+
+// Snapshot is the state of the counts at a point in time.
+type Snapshot struct {
+	Accepted int64
+	Refused  int64
+}
+
+// Add returns the sum of two snapshots.
+func (s Snapshot) Add(other Snapshot) Snapshot {
+	return Snapshot{
+		Accepted: s.Accepted + other.Accepted,
+		Refused:  s.Refused + other.Refused,
+	}
+}
+
+// Snapshot returns the current state of the counts.
+func (c *Counts) Snapshot() Snapshot {
+	if c == nil {
+		return Snapshot{}
+	}
+	return Snapshot{
+		Accepted: c.accepted.Load(),
+		Refused:  c.refused.Load(),
+	}
+}
+
+// Record counts the items by the outcome of consuming them.
+func (c *Counts) Record(items int, err error) {
+	if c == nil {
+		return
+	}
+	if err != nil {
+		c.refused.Add(int64(items))
+		return
+	}
+	c.accepted.Add(int64(items))
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package countconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestNil(t *testing.T) {
+	var counts *Counts
+	counts.Record(1, nil)
+	assert.Equal(t, Snapshot{}, counts.Snapshot())
+
+	cons := consumertest.NewNop()
+	assert.Same(t, cons, NewLogs(cons, counts))
+	assert.Same(t, cons, NewMetrics(cons, counts))
+	assert.Same(t, cons, NewTraces(cons, counts))
+	assert.Same(t, cons, NewProfiles(cons, counts))
+}
+
+func TestCounts(t *testing.T) {
+	counts := new(Counts)
+	cons := consumertest.NewNop()
+	require.NoError(t, NewLogs(cons, counts).ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	require.NoError(t, NewMetrics(cons, counts).ConsumeMetrics(context.Background(), testdata.GenerateMetrics(2)))
+	require.NoError(t, NewTraces(cons, counts).ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, NewProfiles(cons, counts).ConsumeProfiles(context.Background(), testdata.GenerateProfiles(2)))
+	// Each generated metric has 2 data points.
+	assert.Equal(t, Snapshot{Accepted: 2 + 4 + 2 + 2}, counts.Snapshot())
+
+	errCons := consumertest.NewErr(errors.New("refused"))
+	require.Error(t, NewLogs(errCons, counts).ConsumeLogs(context.Background(), testdata.GenerateLogs(3)))
+	require.Error(t, NewTraces(errCons, counts).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, Snapshot{Accepted: 10, Refused: 4}, counts.Snapshot())
+	assert.Equal(t, Snapshot{Accepted: 11, Refused: 6}, counts.Snapshot().Add(Snapshot{Accepted: 1, Refused: 2}))
+}
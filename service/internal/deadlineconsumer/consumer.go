@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deadlineconsumer // import "go.opentelemetry.io/collector/service/internal/deadlineconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// NewLogs applies the deadline to the logs entering a pipeline.
+func NewLogs(logs consumer.Logs, d *Deadline) consumer.Logs {
+	if d == nil {
+		return logs
+	}
+	return deadlineLogs{Logs: logs, deadline: d}
+}
+
+type deadlineLogs struct {
+	consumer.Logs
+	deadline *Deadline
+}
+
+func (c deadlineLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	ctx, cancel := c.deadline.begin(ctx)
+	defer cancel()
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+// NewGuardLogs checks the deadline of the pipelines before passing logs to a component.
+func NewGuardLogs(logs consumer.Logs, g *Guard) consumer.Logs {
+	if g == nil {
+		return logs
+	}
+	return guardLogs{Logs: logs, guard: g}
+}
+
+type guardLogs struct {
+	consumer.Logs
+	guard *Guard
+}
+
+func (c guardLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if err := c.guard.check(ctx); err != nil {
+		return err
+	}
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+// NewMetrics applies the deadline to the metrics entering a pipeline.
+func NewMetrics(metrics consumer.Metrics, d *Deadline) consumer.Metrics {
+	if d == nil {
+		return metrics
+	}
+	return deadlineMetrics{Metrics: metrics, deadline: d}
+}
+
+type deadlineMetrics struct {
+	consumer.Metrics
+	deadline *Deadline
+}
+
+func (c deadlineMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	ctx, cancel := c.deadline.begin(ctx)
+	defer cancel()
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+// NewGuardMetrics checks the deadline of the pipelines before passing metrics to a component.
+func NewGuardMetrics(metrics consumer.Metrics, g *Guard) consumer.Metrics {
+	if g == nil {
+		return metrics
+	}
+	return guardMetrics{Metrics: metrics, guard: g}
+}
+
+type guardMetrics struct {
+	consumer.Metrics
+	guard *Guard
+}
+
+func (c guardMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if err := c.guard.check(ctx); err != nil {
+		return err
+	}
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+// NewTraces applies the deadline to the traces entering a pipeline.
+func NewTraces(traces consumer.Traces, d *Deadline) consumer.Traces {
+	if d == nil {
+		return traces
+	}
+	return deadlineTraces{Traces: traces, deadline: d}
+}
+
+type deadlineTraces struct {
+	consumer.Traces
+	deadline *Deadline
+}
+
+func (c deadlineTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	ctx, cancel := c.deadline.begin(ctx)
+	defer cancel()
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+// NewGuardTraces checks the deadline of the pipelines before passing traces to a component.
+func NewGuardTraces(traces consumer.Traces, g *Guard) consumer.Traces {
+	if g == nil {
+		return traces
+	}
+	return guardTraces{Traces: traces, guard: g}
+}
+
+type guardTraces struct {
+	consumer.Traces
+	guard *Guard
+}
+
+func (c guardTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if err := c.guard.check(ctx); err != nil {
+		return err
+	}
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+// NewProfiles applies the deadline to the profiles entering a pipeline.
+func NewProfiles(profiles xconsumer.Profiles, d *Deadline) xconsumer.Profiles {
+	if d == nil {
+		return profiles
+	}
+	return deadlineProfiles{Profiles: profiles, deadline: d}
+}
+
+type deadlineProfiles struct {
+	xconsumer.Profiles
+	deadline *Deadline
+}
+
+func (c deadlineProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	ctx, cancel := c.deadline.begin(ctx)
+	defer cancel()
+	return c.Profiles.ConsumeProfiles(ctx, pd)
+}
+
+// NewGuardProfiles checks the deadline of the pipelines before passing profiles to a component.
+func NewGuardProfiles(profiles xconsumer.Profiles, g *Guard) xconsumer.Profiles {
+	if g == nil {
+		return profiles
+	}
+	return guardProfiles{Profiles: profiles, guard: g}
+}
+
+type guardProfiles struct {
+	xconsumer.Profiles
+	guard *Guard
+}
+
+func (c guardProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	if err := c.guard.check(ctx); err != nil {
+		return err
+	}
+	return c.Profiles.ConsumeProfiles(ctx, pd)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package deadlineconsumer bounds the time the data spends in a pipeline: the data entering
+// the pipeline gets a deadline, which is checked before calling each component.
+package deadlineconsumer // import "go.opentelemetry.io/collector/service/internal/deadlineconsumer"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+type deadlineKey struct{}
+
+// Deadline is the deadline of a pipeline.
+type Deadline struct {
+	pipelineID pipeline.ID
+	timeout    time.Duration
+}
+
+// NewDeadline returns the deadline of the given pipeline, or nil if the timeout is not positive.
+func NewDeadline(pipelineID pipeline.ID, timeout time.Duration) *Deadline {
+	if timeout <= 0 {
+		return nil
+	}
+	return &Deadline{pipelineID: pipelineID, timeout: timeout}
+}
+
+// begin returns the context bounded by the deadline. The context is returned as is if it
+// already expires earlier, for instance when the data comes from a pipeline with a shorter
+// deadline through a connector.
+func (d *Deadline) begin(ctx context.Context) (context.Context, context.CancelFunc) {
+	if parent, ok := ctx.Deadline(); ok && !time.Now().Add(d.timeout).Before(parent) {
+		return ctx, func() {}
+	}
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	return context.WithValue(ctx, deadlineKey{}, d), cancel
+}
+
+// Guard checks the deadline of the pipelines before calling a component.
+type Guard struct {
+	kind string
+	id   component.ID
+}
+
+// NewGuard returns the guard of the given component.
+func NewGuard(kind component.Kind, id component.ID) *Guard {
+	return &Guard{kind: strings.ToLower(kind.String()), id: id}
+}
+
+// check returns an error if the deadline of the pipeline the data comes from is exceeded.
+// The contexts canceled or expired for other reasons are left to the component.
+func (g *Guard) check(ctx context.Context) error {
+	d, ok := ctx.Value(deadlineKey{}).(*Deadline)
+	if !ok || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil
+	}
+	return fmt.Errorf("deadline of %v of pipeline %q exceeded before calling %s %q: %w",
+		d.timeout, d.pipelineID.String(), g.kind, g.id.String(), context.DeadlineExceeded)
+}
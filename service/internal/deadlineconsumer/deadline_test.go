@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deadlineconsumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestDisabled(t *testing.T) {
+	assert.Nil(t, NewDeadline(pipeline.NewID(pipeline.SignalLogs), 0))
+
+	cons := consumertest.NewNop()
+	assert.Same(t, cons, NewLogs(cons, nil))
+	assert.Same(t, cons, NewMetrics(cons, nil))
+	assert.Same(t, cons, NewTraces(cons, nil))
+	assert.Same(t, cons, NewProfiles(cons, nil))
+	assert.Same(t, cons, NewGuardLogs(cons, nil))
+	assert.Same(t, cons, NewGuardMetrics(cons, nil))
+	assert.Same(t, cons, NewGuardTraces(cons, nil))
+	assert.Same(t, cons, NewGuardProfiles(cons, nil))
+}
+
+func TestDeadlineExceeded(t *testing.T) {
+	d := NewDeadline(pipeline.NewID(pipeline.SignalTraces), 10*time.Millisecond)
+	sink := new(consumertest.TracesSink)
+	exporter := NewGuardTraces(sink, NewGuard(component.KindExporter, component.MustNewID("otlp")))
+
+	// The processor ignores the context and takes longer than the deadline.
+	processor, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		time.Sleep(time.Until(deadline) + 10*time.Millisecond)
+		return exporter.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+
+	err = NewTraces(processor, d).ConsumeTraces(context.Background(), ptrace.NewTraces())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.EqualError(t, err, `deadline of 10ms of pipeline "traces" exceeded before calling exporter "otlp": context deadline exceeded`)
+	assert.Empty(t, sink.AllTraces())
+}
+
+func TestDeadlineNotExceeded(t *testing.T) {
+	d := NewDeadline(pipeline.NewID(pipeline.SignalLogs), time.Minute)
+	guard := NewGuard(component.KindProcessor, component.MustNewID("batch"))
+
+	sink := new(consumertest.LogsSink)
+	require.NoError(t, NewLogs(NewGuardLogs(sink, guard), d).ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Len(t, sink.AllLogs(), 1)
+
+	metrics := new(consumertest.MetricsSink)
+	require.NoError(t, NewMetrics(NewGuardMetrics(metrics, guard), d).ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	assert.Len(t, metrics.AllMetrics(), 1)
+
+	profiles := new(consumertest.ProfilesSink)
+	require.NoError(t, NewProfiles(NewGuardProfiles(profiles, guard), d).ConsumeProfiles(context.Background(), pprofile.NewProfiles()))
+	assert.Len(t, profiles.AllProfiles(), 1)
+}
+
+func TestDeadlineKeepsEarlierDeadline(t *testing.T) {
+	upstream := NewDeadline(pipeline.NewIDWithName(pipeline.SignalLogs, "in"), 10*time.Millisecond)
+	downstream := NewDeadline(pipeline.NewIDWithName(pipeline.SignalLogs, "out"), time.Minute)
+	exporter := NewGuardLogs(consumertest.NewNop(), NewGuard(component.KindExporter, component.MustNewID("debug")))
+
+	processor, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		<-ctx.Done()
+		return exporter.ConsumeLogs(ctx, ld)
+	})
+	require.NoError(t, err)
+	connector := NewLogs(processor, downstream)
+
+	err = NewLogs(connector, upstream).ConsumeLogs(context.Background(), plog.NewLogs())
+	require.EqualError(t, err, `deadline of 10ms of pipeline "logs/in" exceeded before calling exporter "debug": context deadline exceeded`)
+}
+
+func TestGuardIgnoresOtherCancellations(t *testing.T) {
+	guard := NewGuard(component.KindExporter, component.MustNewID("otlp"))
+	sink := new(consumertest.LogsSink)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	// The deadline of the receiver is left to the components.
+	require.NoError(t, NewGuardLogs(sink, guard).ConsumeLogs(ctx, plog.NewLogs()))
+	assert.Len(t, sink.AllLogs(), 1)
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package edgeconsumer // import "go.opentelemetry.io/collector/service/internal/edgeconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	tracesSizer   = &ptrace.ProtoMarshaler{}
+	metricsSizer  = &pmetric.ProtoMarshaler{}
+	logsSizer     = &plog.ProtoMarshaler{}
+	profilesSizer = &pprofile.ProtoMarshaler{}
+)
+
+// NewTraces wraps the consumer of an edge passing traces.
+func NewTraces(traces consumer.Traces, edge *Edge) consumer.Traces {
+	return edgeTraces{Traces: traces, edge: edge}
+}
+
+type edgeTraces struct {
+	consumer.Traces
+	edge *Edge
+}
+
+func (c edgeTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	// The data is measured before it is consumed, as the consuming node may mutate it.
+	done := c.edge.consume(ctx, td.SpanCount(), func() int { return tracesSizer.TracesSize(td) })
+	err := c.Traces.ConsumeTraces(ctx, td)
+	done(err)
+	return err
+}
+
+// NewMetrics wraps the consumer of an edge passing metrics.
+func NewMetrics(metrics consumer.Metrics, edge *Edge) consumer.Metrics {
+	return edgeMetrics{Metrics: metrics, edge: edge}
+}
+
+type edgeMetrics struct {
+	consumer.Metrics
+	edge *Edge
+}
+
+func (c edgeMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	done := c.edge.consume(ctx, md.DataPointCount(), func() int { return metricsSizer.MetricsSize(md) })
+	err := c.Metrics.ConsumeMetrics(ctx, md)
+	done(err)
+	return err
+}
+
+// NewLogs wraps the consumer of an edge passing logs.
+func NewLogs(logs consumer.Logs, edge *Edge) consumer.Logs {
+	return edgeLogs{Logs: logs, edge: edge}
+}
+
+type edgeLogs struct {
+	consumer.Logs
+	edge *Edge
+}
+
+func (c edgeLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	done := c.edge.consume(ctx, ld.LogRecordCount(), func() int { return logsSizer.LogsSize(ld) })
+	err := c.Logs.ConsumeLogs(ctx, ld)
+	done(err)
+	return err
+}
+
+// NewProfiles wraps the consumer of an edge passing profiles.
+func NewProfiles(profiles xconsumer.Profiles, edge *Edge) xconsumer.Profiles {
+	return edgeProfiles{Profiles: profiles, edge: edge}
+}
+
+type edgeProfiles struct {
+	xconsumer.Profiles
+	edge *Edge
+}
+
+func (c edgeProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	done := c.edge.consume(ctx, pd.SampleCount(), func() int { return profilesSizer.ProfilesSize(pd) })
+	err := c.Profiles.ConsumeProfiles(ctx, pd)
+	done(err)
+	return err
+}
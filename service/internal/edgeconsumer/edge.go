@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package edgeconsumer wraps the consumer of an edge of the pipeline graph to report the data
+// passed along the edge: the items and their size, and the time spent by the consuming node.
+package edgeconsumer // import "go.opentelemetry.io/collector/service/internal/edgeconsumer"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Outcome is the attribute telling whether the consuming node of the edge returned an error.
+const Outcome = "otelcol.component.outcome"
+
+// Settings defines the instruments used to report the data passed along an edge.
+type Settings struct {
+	// Items is the metric counting the items passed along the edge.
+	Items metric.Int64Counter
+
+	// Size is the metric counting the size of the items passed along the edge.
+	Size metric.Int64Counter
+
+	// Duration is the metric recording the time spent by the consuming node of the edge,
+	// including the time spent in the downstream nodes called synchronously.
+	Duration metric.Float64Histogram
+
+	// Attributes identify the edge by its producing and consuming nodes.
+	Attributes attribute.Set
+}
+
+// Edge records the data passed along one edge of the graph.
+type Edge struct {
+	set          Settings
+	successAttrs metric.MeasurementOption
+	failureAttrs metric.MeasurementOption
+}
+
+// NewEdge returns a new Edge reporting to the given instruments.
+func NewEdge(set Settings) *Edge {
+	return &Edge{
+		set:          set,
+		successAttrs: metric.WithAttributeSet(attribute.NewSet(append(set.Attributes.ToSlice(), attribute.String(Outcome, "success"))...)),
+		failureAttrs: metric.WithAttributeSet(attribute.NewSet(append(set.Attributes.ToSlice(), attribute.String(Outcome, "failure"))...)),
+	}
+}
+
+type enabledInstrument interface {
+	Enabled(context.Context) bool
+}
+
+func isEnabled(ctx context.Context, inst any) bool {
+	ei, ok := inst.(enabledInstrument)
+	return !ok || ei.Enabled(ctx)
+}
+
+// consume records that the given items are passed along the edge, and returns the function to call
+// with the result of the consuming node. The size is only computed when its instrument is enabled,
+// and nothing is recorded when no instrument is, which is the case below the detailed telemetry level.
+func (e *Edge) consume(ctx context.Context, items int, size func() int) func(error) {
+	itemsEnabled, durationEnabled := isEnabled(ctx, e.set.Items), isEnabled(ctx, e.set.Duration)
+	bytes := -1
+	if isEnabled(ctx, e.set.Size) {
+		bytes = size()
+	}
+	if !itemsEnabled && !durationEnabled && bytes < 0 {
+		return func(error) {}
+	}
+	start := time.Now()
+	return func(err error) {
+		attrs := e.successAttrs
+		if err != nil {
+			attrs = e.failureAttrs
+		}
+		if durationEnabled {
+			e.set.Duration.Record(ctx, time.Since(start).Seconds(), attrs)
+		}
+		if itemsEnabled {
+			e.set.Items.Add(ctx, int64(items), attrs)
+		}
+		if bytes >= 0 {
+			e.set.Size.Add(ctx, int64(bytes), attrs)
+		}
+	}
+}
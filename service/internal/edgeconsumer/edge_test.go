@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package edgeconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+	"go.opentelemetry.io/collector/service/internal/metadatatest"
+)
+
+var testAttrs = []attribute.KeyValue{
+	attribute.String("otelcol.edge.from.id", "otlp"),
+	attribute.String("otelcol.edge.to.id", "batch"),
+}
+
+func newTestEdge(t *testing.T) (*componenttest.Telemetry, *Edge) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	tb, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	return tel, NewEdge(Settings{
+		Items:      tb.GraphEdgeItems,
+		Size:       tb.GraphEdgeSize,
+		Duration:   tb.GraphEdgeDuration,
+		Attributes: attribute.NewSet(testAttrs...),
+	})
+}
+
+func outcomeAttrs(outcome string) attribute.Set {
+	return attribute.NewSet(append(testAttrs, attribute.String(Outcome, outcome))...)
+}
+
+func TestEdge(t *testing.T) {
+	tel, edge := newTestEdge(t)
+	td := testdata.GenerateTraces(2)
+	size := int64((&ptrace.ProtoMarshaler{}).TracesSize(td))
+
+	require.NoError(t, NewTraces(consumertest.NewNop(), edge).ConsumeTraces(context.Background(), td))
+	require.Error(t, NewTraces(consumertest.NewErr(errors.New("refused")), edge).ConsumeTraces(context.Background(), td))
+
+	metadatatest.AssertEqualGraphEdgeItems(t, tel, []metricdata.DataPoint[int64]{
+		{Attributes: outcomeAttrs("success"), Value: 2},
+		{Attributes: outcomeAttrs("failure"), Value: 2},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualGraphEdgeSize(t, tel, []metricdata.DataPoint[int64]{
+		{Attributes: outcomeAttrs("success"), Value: size},
+		{Attributes: outcomeAttrs("failure"), Value: size},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualGraphEdgeDuration(t, tel, []metricdata.HistogramDataPoint[float64]{
+		{Attributes: outcomeAttrs("success")},
+		{Attributes: outcomeAttrs("failure")},
+	}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
+}
+
+func TestEdgeSignals(t *testing.T) {
+	tel, edge := newTestEdge(t)
+	require.NoError(t, NewMetrics(consumertest.NewNop(), edge).ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	require.NoError(t, NewLogs(consumertest.NewNop(), edge).ConsumeLogs(context.Background(), testdata.GenerateLogs(3)))
+	require.NoError(t, NewProfiles(consumertest.NewNop(), edge).ConsumeProfiles(context.Background(), testdata.GenerateProfiles(1)))
+
+	items := int64(testdata.GenerateMetrics(1).DataPointCount() + 3 + testdata.GenerateProfiles(1).SampleCount())
+	size := int64((&pmetric.ProtoMarshaler{}).MetricsSize(testdata.GenerateMetrics(1)) +
+		(&plog.ProtoMarshaler{}).LogsSize(testdata.GenerateLogs(3)) +
+		(&pprofile.ProtoMarshaler{}).ProfilesSize(testdata.GenerateProfiles(1)))
+	metadatatest.AssertEqualGraphEdgeItems(t, tel, []metricdata.DataPoint[int64]{
+		{Attributes: outcomeAttrs("success"), Value: items},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualGraphEdgeSize(t, tel, []metricdata.DataPoint[int64]{
+		{Attributes: outcomeAttrs("success"), Value: size},
+	}, metricdatatest.IgnoreTimestamp())
+}
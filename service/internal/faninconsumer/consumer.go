@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package faninconsumer merges the data consumed concurrently by a connector from several pipelines.
+package faninconsumer // import "go.opentelemetry.io/collector/service/internal/faninconsumer"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Settings configures the merge of the consumed data.
+type Settings struct {
+	// Window is the maximum time the data waits to be merged with the following data.
+	Window time.Duration
+	// MaxItems sends the merged data as soon as it holds this number of items. Unlimited if zero.
+	MaxItems int
+}
+
+// The merged data is moved out of the consumed data, which is then mutated.
+var mutatesData = consumer.Capabilities{MutatesData: true}
+
+// NewTraces returns a consumer.Traces merging the traces consumed during a window.
+func NewTraces(next consumer.Traces, set Settings) consumer.Traces {
+	return &mergeTraces{merger: merger[ptrace.Traces]{
+		set:      set,
+		newEmpty: ptrace.NewTraces,
+		move: func(src, dest ptrace.Traces) int {
+			count := src.SpanCount()
+			src.ResourceSpans().MoveAndAppendTo(dest.ResourceSpans())
+			return count
+		},
+		next: next.ConsumeTraces,
+	}}
+}
+
+type mergeTraces struct {
+	merger[ptrace.Traces]
+}
+
+func (*mergeTraces) Capabilities() consumer.Capabilities {
+	return mutatesData
+}
+
+func (c *mergeTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return c.consume(ctx, td)
+}
+
+// NewMetrics returns a consumer.Metrics merging the metrics consumed during a window.
+func NewMetrics(next consumer.Metrics, set Settings) consumer.Metrics {
+	return &mergeMetrics{merger: merger[pmetric.Metrics]{
+		set:      set,
+		newEmpty: pmetric.NewMetrics,
+		move: func(src, dest pmetric.Metrics) int {
+			count := src.DataPointCount()
+			src.ResourceMetrics().MoveAndAppendTo(dest.ResourceMetrics())
+			return count
+		},
+		next: next.ConsumeMetrics,
+	}}
+}
+
+type mergeMetrics struct {
+	merger[pmetric.Metrics]
+}
+
+func (*mergeMetrics) Capabilities() consumer.Capabilities {
+	return mutatesData
+}
+
+func (c *mergeMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return c.consume(ctx, md)
+}
+
+// NewLogs returns a consumer.Logs merging the logs consumed during a window.
+func NewLogs(next consumer.Logs, set Settings) consumer.Logs {
+	return &mergeLogs{merger: merger[plog.Logs]{
+		set:      set,
+		newEmpty: plog.NewLogs,
+		move: func(src, dest plog.Logs) int {
+			count := src.LogRecordCount()
+			src.ResourceLogs().MoveAndAppendTo(dest.ResourceLogs())
+			return count
+		},
+		next: next.ConsumeLogs,
+	}}
+}
+
+type mergeLogs struct {
+	merger[plog.Logs]
+}
+
+func (*mergeLogs) Capabilities() consumer.Capabilities {
+	return mutatesData
+}
+
+func (c *mergeLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return c.consume(ctx, ld)
+}
+
+// merger accumulates the consumed data until the window elapses or the maximum number
+// of items is reached, then consumes the merged data with the context of the first call.
+// Each call waits for the merged data to be consumed and returns its error, so that the
+// backpressure and the errors are still propagated to the exporting pipelines. A call whose
+// context is canceled returns without error, since its data is already in the batch and
+// is consumed anyway, so that the caller does not retry it.
+type merger[T any] struct {
+	set      Settings
+	newEmpty func() T
+	move     func(src, dest T) int
+	next     func(context.Context, T) error
+
+	mu      sync.Mutex
+	pending *batch[T]
+}
+
+type batch[T any] struct {
+	ctx   context.Context
+	data  T
+	items int
+	timer *time.Timer
+	done  chan struct{}
+	err   error
+}
+
+func (m *merger[T]) consume(ctx context.Context, data T) error {
+	m.mu.Lock()
+	b := m.pending
+	if b == nil {
+		b = &batch[T]{ctx: context.WithoutCancel(ctx), data: m.newEmpty(), done: make(chan struct{})}
+		b.timer = time.AfterFunc(m.set.Window, func() { m.flush(b) })
+		m.pending = b
+	}
+	b.items += m.move(data, b.data)
+	full := m.set.MaxItems > 0 && b.items >= m.set.MaxItems
+	if full {
+		m.pending = nil
+	}
+	m.mu.Unlock()
+
+	if full {
+		b.timer.Stop()
+		m.send(b)
+	}
+	select {
+	case <-b.done:
+		return b.err
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// flush sends the batch when its window elapsed, unless it was already sent because it was full.
+func (m *merger[T]) flush(b *batch[T]) {
+	m.mu.Lock()
+	if m.pending != b {
+		m.mu.Unlock()
+		return
+	}
+	m.pending = nil
+	m.mu.Unlock()
+	m.send(b)
+}
+
+func (m *merger[T]) send(b *batch[T]) {
+	b.err = m.next(b.ctx, b.data)
+	close(b.done)
+}
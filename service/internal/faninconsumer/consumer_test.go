@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package faninconsumer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestTracesMergeWindow(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cons := NewTraces(sink, Settings{Window: 50 * time.Millisecond})
+	assert.True(t, cons.Capabilities().MutatesData)
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+		}()
+	}
+	wg.Wait()
+
+	// The calls made during the window are merged, the calls return once the merged data is consumed.
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, 3, sink.SpanCount())
+}
+
+func TestMetricsMergeMaxItems(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cons := NewMetrics(sink, Settings{Window: time.Minute, MaxItems: 4})
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, cons.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 2, sink.AllMetrics()[0].ResourceMetrics().Len())
+}
+
+func TestLogsMergeError(t *testing.T) {
+	cons := NewLogs(consumertest.NewErr(assert.AnError), Settings{Window: time.Millisecond})
+	assert.ErrorIs(t, cons.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)), assert.AnError)
+}
+
+func TestMergeCanceled(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	cons := NewLogs(sink, Settings{Window: 50 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ld := testdata.GenerateLogs(2)
+	// The data is in the batch, the call succeeds so that it is not retried.
+	require.NoError(t, cons.ConsumeLogs(ctx, ld))
+	// The data was moved out of the consumed logs, and is still consumed once the window elapses.
+	assert.Equal(t, 0, ld.LogRecordCount())
+	assert.Eventually(t, func() bool { return sink.LogRecordCount() == 2 }, time.Second, time.Millisecond)
+}
+
+func TestMergeEmpty(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	cons := NewTraces(sink, Settings{Window: time.Millisecond})
+	require.NoError(t, cons.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.Len(t, sink.AllTraces(), 1)
+
+	msink := new(consumertest.MetricsSink)
+	require.NoError(t, NewMetrics(msink, Settings{Window: time.Millisecond}).ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	require.Len(t, msink.AllMetrics(), 1)
+
+	lsink := new(consumertest.LogsSink)
+	require.NoError(t, NewLogs(lsink, Settings{Window: time.Millisecond}).ConsumeLogs(context.Background(), plog.NewLogs()))
+	require.Len(t, lsink.AllLogs(), 1)
+}
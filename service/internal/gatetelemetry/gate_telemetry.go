@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gatetelemetry // import "go.opentelemetry.io/collector/service/internal/gatetelemetry"
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+)
+
+// gateState is the state of a feature gate not in its default state, for all the component instances or
+// for the one identified by scope.
+type gateState struct {
+	gate    *featuregate.Gate
+	scope   string
+	enabled bool
+}
+
+// nonDefaultStates returns the states of the feature gates of the registry not in their default state,
+// ordered by gate ID.
+func nonDefaultStates(reg *featuregate.Registry) []gateState {
+	var states []gateState
+	reg.VisitAll(func(g *featuregate.Gate) {
+		if g.IsEnabled() != g.IsEnabledByDefault() {
+			states = append(states, gateState{gate: g, enabled: g.IsEnabled()})
+		}
+		scopes := g.Scopes()
+		for _, scope := range slices.Sorted(maps.Keys(scopes)) {
+			states = append(states, gateState{gate: g, scope: scope, enabled: scopes[scope]})
+		}
+	})
+	return states
+}
+
+// String returns the state in the format of the --feature-gates flag, e.g. "-id" or "+id@scope".
+func (s gateState) String() string {
+	str := "-" + s.gate.ID()
+	if s.enabled {
+		str = "+" + s.gate.ID()
+	}
+	if s.scope != "" {
+		str += "@" + s.scope
+	}
+	return str
+}
+
+func (s gateState) attributes() metric.MeasurementOption {
+	attrs := []attribute.KeyValue{
+		attribute.String("feature_gate", s.gate.ID()),
+		attribute.String("stage", s.gate.Stage().String()),
+	}
+	// ToVersion formats the missing removal version as "v<nil>".
+	if toVersion := s.gate.ToVersion(); toVersion != "v<nil>" {
+		attrs = append(attrs, attribute.String("removal_version", toVersion))
+	}
+	if s.scope != "" {
+		attrs = append(attrs, attribute.String("scope", s.scope))
+	}
+	return metric.WithAttributeSet(attribute.NewSet(attrs...))
+}
+
+// RegisterFeatureGateMetrics logs the feature gates of the registry not in their default state, and
+// registers the metric of their states, so that the collectors of a fleet relying on the gates can be
+// tracked before the gates are removed. The metric reflects the changes of the gates at runtime.
+func RegisterFeatureGateMetrics(set component.TelemetrySettings, reg *featuregate.Registry) error {
+	if states := nonDefaultStates(reg); len(states) > 0 {
+		strs := make([]string, 0, len(states))
+		for _, s := range states {
+			strs = append(strs, s.String())
+		}
+		set.Logger.Info("Feature gates not in their default state", zap.Strings("feature_gates", strs))
+	}
+
+	tb, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return err
+	}
+	return tb.RegisterFeatureGateEnabledCallback(func(_ context.Context, o metric.Int64Observer) error {
+		for _, s := range nonDefaultStates(reg) {
+			var enabled int64
+			if s.enabled {
+				enabled = 1
+			}
+			o.Observe(enabled, s.attributes())
+		}
+		return nil
+	})
+}
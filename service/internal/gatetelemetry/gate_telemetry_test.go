@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gatetelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/service/internal/metadatatest"
+)
+
+func TestRegisterFeatureGateMetrics(t *testing.T) {
+	reg := featuregate.NewRegistry()
+	reg.MustRegister("test.alpha", featuregate.StageAlpha, featuregate.WithRegisterToVersion("v0.140.0"))
+	reg.MustRegister("test.beta", featuregate.StageBeta)
+	reg.MustRegister("test.default", featuregate.StageAlpha)
+	require.NoError(t, reg.Set("test.alpha", true))
+	require.NoError(t, reg.SetFor("test.beta", "exporter/otlp/canary", false))
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	set := tel.NewTelemetrySettings()
+	core, logs := observer.New(zap.InfoLevel)
+	set.Logger = zap.New(core)
+	require.NoError(t, RegisterFeatureGateMetrics(set, reg))
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, []any{"+test.alpha", "-test.beta@exporter/otlp/canary"}, logs.All()[0].ContextMap()["feature_gates"])
+
+	metadatatest.AssertEqualFeatureGateEnabled(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(
+					attribute.String("feature_gate", "test.alpha"),
+					attribute.String("stage", "Alpha"),
+					attribute.String("removal_version", "v0.140.0")),
+				Value: 1,
+			},
+			{
+				Attributes: attribute.NewSet(
+					attribute.String("feature_gate", "test.beta"),
+					attribute.String("stage", "Beta"),
+					attribute.String("scope", "exporter/otlp/canary")),
+				Value: 0,
+			},
+		}, metricdatatest.IgnoreTimestamp())
+
+	// The metric reflects the gates set at runtime.
+	require.NoError(t, reg.Set("test.alpha", false))
+	metadatatest.AssertEqualFeatureGateEnabled(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Attributes: attribute.NewSet(
+					attribute.String("feature_gate", "test.beta"),
+					attribute.String("stage", "Beta"),
+					attribute.String("scope", "exporter/otlp/canary")),
+				Value: 0,
+			},
+		}, metricdatatest.IgnoreTimestamp())
+}
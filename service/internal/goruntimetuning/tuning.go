@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package goruntimetuning applies the settings of the Go runtime configured by goruntime.Config.
+package goruntimetuning // import "go.opentelemetry.io/collector/service/internal/goruntimetuning"
+
+import (
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/internal/memorylimiter/iruntime"
+	"go.opentelemetry.io/collector/service/goruntime"
+)
+
+const (
+	mibBytes = 1024 * 1024
+
+	maxProcsEnv    = "GOMAXPROCS"
+	memoryLimitEnv = "GOMEMLIMIT"
+)
+
+// tuner reads the resources of the collector, replaced in tests.
+type tuner struct {
+	cpuQuota    func() (float64, bool, error)
+	totalMemory func() (uint64, error)
+	numCPU      int
+}
+
+// Apply applies the settings of the Go runtime derived from the resources of the collector, and returns
+// a function restoring the previous settings. A failure to read the resources is logged, since the
+// collector can still run with the default settings.
+func Apply(logger *zap.Logger, cfg goruntime.Config) func() {
+	t := tuner{
+		cpuQuota:    iruntime.CPUQuota,
+		totalMemory: iruntime.TotalMemory,
+		numCPU:      runtime.NumCPU(),
+	}
+	return t.apply(logger, cfg)
+}
+
+func (t tuner) apply(logger *zap.Logger, cfg goruntime.Config) func() {
+	var restores []func()
+	if cfg.MaxProcsFromCPUQuota {
+		if restore := t.setMaxProcs(logger); restore != nil {
+			restores = append(restores, restore)
+		}
+	}
+	if cfg.MemoryLimitPercentage > 0 {
+		if restore := t.setMemoryLimit(logger, cfg.MemoryLimitPercentage); restore != nil {
+			restores = append(restores, restore)
+		}
+	}
+	return func() {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+}
+
+func (t tuner) setMaxProcs(logger *zap.Logger) func() {
+	if v, ok := os.LookupEnv(maxProcsEnv); ok {
+		logger.Info("Keeping GOMAXPROCS set by the environment", zap.String(maxProcsEnv, v))
+		return nil
+	}
+	quota, defined, err := t.cpuQuota()
+	if err != nil {
+		logger.Warn("Failed to read the CPU quota, keeping GOMAXPROCS", zap.Error(err))
+		return nil
+	}
+	if !defined {
+		return nil
+	}
+
+	procs := min(max(int(math.Floor(quota)), 1), t.numCPU)
+	prev := runtime.GOMAXPROCS(procs)
+	logger.Info("Set GOMAXPROCS from the CPU quota", zap.Int(maxProcsEnv, procs), zap.Float64("cpu_quota", quota))
+	return func() {
+		runtime.GOMAXPROCS(prev)
+	}
+}
+
+func (t tuner) setMemoryLimit(logger *zap.Logger, percentage uint32) func() {
+	if v, ok := os.LookupEnv(memoryLimitEnv); ok {
+		logger.Info("Keeping the soft memory limit set by the environment", zap.String(memoryLimitEnv, v))
+		return nil
+	}
+	total, err := t.totalMemory()
+	if err != nil {
+		logger.Warn("Failed to read the available memory, keeping the soft memory limit", zap.Error(err))
+		return nil
+	}
+
+	limit := iruntime.MemoryLimit(total, percentage)
+	prev := debug.SetMemoryLimit(limit)
+	logger.Info("Set the soft memory limit from the available memory", zap.Int64("limit_mib", limit/mibBytes), zap.Uint32("percentage", percentage))
+	return func() {
+		debug.SetMemoryLimit(prev)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package goruntimetuning
+
+import (
+	"errors"
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/service/goruntime"
+)
+
+func newTestTuner(quota float64, numCPU int, totalMemory uint64) tuner {
+	return tuner{
+		cpuQuota: func() (float64, bool, error) {
+			return quota, quota > 0, nil
+		},
+		totalMemory: func() (uint64, error) {
+			return totalMemory, nil
+		},
+		numCPU: numCPU,
+	}
+}
+
+func TestMaxProcsFromCPUQuota(t *testing.T) {
+	tests := []struct {
+		name     string
+		quota    float64
+		numCPU   int
+		expected int
+	}{
+		{name: "rounded down", quota: 2.5, numCPU: 8, expected: 2},
+		{name: "at least one", quota: 0.5, numCPU: 8, expected: 1},
+		{name: "at most the CPUs", quota: 16, numCPU: 4, expected: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := runtime.GOMAXPROCS(0)
+			restore := newTestTuner(tt.quota, tt.numCPU, 0).apply(zap.NewNop(), goruntime.NewDefaultConfig())
+			assert.Equal(t, tt.expected, runtime.GOMAXPROCS(0))
+			restore()
+			assert.Equal(t, prev, runtime.GOMAXPROCS(0))
+		})
+	}
+}
+
+func TestMaxProcsKept(t *testing.T) {
+	prev := runtime.GOMAXPROCS(0)
+
+	// No quota.
+	newTestTuner(0, 8, 0).apply(zap.NewNop(), goruntime.NewDefaultConfig())()
+	assert.Equal(t, prev, runtime.GOMAXPROCS(0))
+
+	// Disabled.
+	newTestTuner(float64(prev+1), prev+1, 0).apply(zap.NewNop(), goruntime.Config{})()
+	assert.Equal(t, prev, runtime.GOMAXPROCS(0))
+
+	// Failed to read the quota.
+	core, logs := observer.New(zap.WarnLevel)
+	tr := newTestTuner(0, 8, 0)
+	tr.cpuQuota = func() (float64, bool, error) { return -1, false, errors.New("no cgroup") }
+	tr.apply(zap.New(core), goruntime.NewDefaultConfig())()
+	assert.Equal(t, prev, runtime.GOMAXPROCS(0))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "Failed to read the CPU quota, keeping GOMAXPROCS", logs.All()[0].Message)
+
+	// Set by the environment.
+	t.Setenv("GOMAXPROCS", "3")
+	core, logs = observer.New(zap.InfoLevel)
+	newTestTuner(float64(prev+1), prev+1, 0).apply(zap.New(core), goruntime.NewDefaultConfig())()
+	assert.Equal(t, prev, runtime.GOMAXPROCS(0))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "Keeping GOMAXPROCS set by the environment", logs.All()[0].Message)
+}
+
+func TestMemoryLimit(t *testing.T) {
+	prev := debug.SetMemoryLimit(-1)
+	restore := newTestTuner(0, 1, 1000*mibBytes).apply(zap.NewNop(), goruntime.Config{MemoryLimitPercentage: 80})
+	assert.Equal(t, int64(800*mibBytes), debug.SetMemoryLimit(-1))
+	restore()
+	assert.Equal(t, prev, debug.SetMemoryLimit(-1))
+
+	// Disabled.
+	newTestTuner(0, 1, 1000*mibBytes).apply(zap.NewNop(), goruntime.Config{})()
+	assert.Equal(t, prev, debug.SetMemoryLimit(-1))
+
+	// Set by the environment.
+	t.Setenv("GOMEMLIMIT", "100MiB")
+	core, logs := observer.New(zap.InfoLevel)
+	newTestTuner(0, 1, 1000*mibBytes).apply(zap.New(core), goruntime.Config{MemoryLimitPercentage: 80})()
+	assert.Equal(t, prev, debug.SetMemoryLimit(-1))
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "Keeping the soft memory limit set by the environment", logs.All()[0].Message)
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/service/internal/bufferconsumer"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// validateBuffer checks that the buffer settings only reference connectors of the graph.
+func (g *Graph) validateBuffer(cfg map[component.ID]pipelines.BufferConfig) error {
+	used := g.usedConnectors()
+	for id := range cfg {
+		if !used[id] {
+			return fmt.Errorf("buffer references connector %q which is not used by any pipeline", id)
+		}
+	}
+	return nil
+}
+
+// bufferedConnector starts the buffer after the connector, and drains it before shutting the connector down.
+type bufferedConnector struct {
+	component.Component
+	buffer component.Component
+}
+
+func (c *bufferedConnector) Start(ctx context.Context, host component.Host) error {
+	if err := c.Component.Start(ctx, host); err != nil {
+		return err
+	}
+	return c.buffer.Start(ctx, host)
+}
+
+func (c *bufferedConnector) Shutdown(ctx context.Context) error {
+	return errors.Join(c.buffer.Shutdown(ctx), c.Component.Shutdown(ctx))
+}
+
+// withBuffer queues the data exported to a connector, if a buffer is configured for it.
+// The returned component consumes the queue while the connector runs.
+func withBuffer(signal pipeline.Signal, comp component.Component, cons baseConsumer, cfg pipelines.BufferConfig, logger *zap.Logger) (component.Component, baseConsumer) {
+	if cfg.QueueSize == 0 {
+		return comp, cons
+	}
+	set := bufferconsumer.Settings{QueueSize: cfg.QueueSize, NumConsumers: cfg.NumConsumers, Logger: logger}
+	var buf interface {
+		component.Component
+		baseConsumer
+	}
+	switch signal {
+	case pipeline.SignalTraces:
+		buf = bufferconsumer.NewTraces(cons.(consumer.Traces), set)
+	case pipeline.SignalMetrics:
+		buf = bufferconsumer.NewMetrics(cons.(consumer.Metrics), set)
+	case pipeline.SignalLogs:
+		buf = bufferconsumer.NewLogs(cons.(consumer.Logs), set)
+	case xpipeline.SignalProfiles:
+		buf = bufferconsumer.NewProfiles(cons.(xconsumer.Profiles), set)
+	default:
+		return comp, cons
+	}
+	return &bufferedConnector{Component: comp, buffer: buf}, buf
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func TestBuffer(t *testing.T) {
+	set := newFanInSettings(nil)
+	set.Buffer = map[component.ID]pipelines.BufferConfig{
+		component.MustNewID("exampleconnector"): {QueueSize: 10},
+	}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	receivers := pg.getReceivers()[pipeline.SignalTraces]
+	for _, name := range []string{"a", "b"} {
+		rcvr := receivers[component.MustNewIDWithName("examplereceiver", name)].(*testcomponents.ExampleReceiver)
+		require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	}
+
+	// The shutdown drains the buffer before shutting the connector and the exporter down.
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+	exp := pg.GetExporters()[pipeline.SignalTraces][component.MustNewID("exampleexporter")].(*testcomponents.ExampleExporter)
+	assert.Len(t, exp.Traces, 2)
+}
+
+func TestBufferUnknownConnector(t *testing.T) {
+	set := newFanInSettings(nil)
+	set.Buffer = map[component.ID]pipelines.BufferConfig{
+		component.MustNewID("forward"): {QueueSize: 10},
+	}
+	_, err := Build(context.Background(), set)
+	require.EqualError(t, err, `buffer references connector "forward" which is not used by any pipeline`)
+}
@@ -4,10 +4,13 @@
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
+	"sync/atomic"
+
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/service/internal/attribute"
+	"go.opentelemetry.io/collector/service/internal/countconsumer"
 )
 
 var _ consumerNode = (*capabilitiesNode)(nil)
@@ -25,6 +28,18 @@ type capabilitiesNode struct {
 	consumer.ConsumeMetricsFunc
 	consumer.ConsumeLogsFunc
 	xconsumer.ConsumeProfilesFunc
+
+	// The number of components of the pipeline which failed to start, see startFailures.
+	failed atomic.Int32
+
+	// Whether the processors or exporters of the pipeline started shutting down, see countWhileClosing.
+	closing atomic.Bool
+
+	// The items accepted and refused by the pipeline, see countOutcomes.
+	counts *countconsumer.Counts
+
+	// Routes the data to the components of its tenant, if the pipeline is partitioned by tenant.
+	tenants *tenantRouter
 }
 
 func newCapabilitiesNode(pipelineID pipeline.ID) *capabilitiesNode {
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"go.opentelemetry.io/collector/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// fanOutClonePolicy returns the clone policy of the fan out of a pipeline to its exporters.
+func fanOutClonePolicy(policy pipelines.ClonePolicy) fanoutconsumer.ClonePolicy {
+	switch policy {
+	case pipelines.CloneLazy:
+		return fanoutconsumer.CloneLazy
+	case pipelines.CloneReadOnly:
+		return fanoutconsumer.CloneReadOnly
+	}
+	return fanoutconsumer.CloneEager
+}
+
+// receiverClonePolicy returns the clone policy of the fan out of a receiver to its pipelines,
+// which is lazy if all of them are. The pipelines with the read-only policy report that they do
+// not mutate the data instead, see capabilitiesNode.
+func (g *Graph) receiverClonePolicy(nodeID int64) fanoutconsumer.ClonePolicy {
+	nexts := g.componentGraph.From(nodeID)
+	if nexts.Len() == 0 {
+		return fanoutconsumer.CloneEager
+	}
+	for nexts.Next() {
+		n, ok := nexts.Node().(*capabilitiesNode)
+		if !ok || g.set.PipelineConfigs[n.pipelineID].ClonePolicy != pipelines.CloneLazy {
+			return fanoutconsumer.CloneEager
+		}
+	}
+	return fanoutconsumer.CloneLazy
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/attribute"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func TestClonePolicy(t *testing.T) {
+	rcvrID, procID := component.MustNewID("examplereceiver"), component.MustNewIDWithName("exampleprocessor", "mutate")
+	expID, exp2ID := component.MustNewID("exampleexporter"), component.MustNewIDWithName("exampleexporter", "2")
+	tracesID, traces2ID := pipeline.NewID(pipeline.SignalTraces), pipeline.NewIDWithName(pipeline.SignalTraces, "2")
+
+	for _, tt := range []struct {
+		name         string
+		policy       pipelines.ClonePolicy
+		policy2      pipelines.ClonePolicy
+		wantReceiver fanoutconsumer.ClonePolicy
+		wantMutates  bool
+	}{
+		{name: "default", wantReceiver: fanoutconsumer.CloneEager, wantMutates: true},
+		{name: "lazy", policy: pipelines.CloneLazy, policy2: pipelines.CloneLazy, wantReceiver: fanoutconsumer.CloneLazy, wantMutates: true},
+		{name: "partly lazy", policy: pipelines.CloneLazy, wantReceiver: fanoutconsumer.CloneEager, wantMutates: true},
+		{name: "read only", policy: pipelines.CloneReadOnly, wantReceiver: fanoutconsumer.CloneEager, wantMutates: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			pg, err := Build(context.Background(), newReconcileSettings(pipelines.Config{
+				tracesID: {
+					Receivers: []component.ID{rcvrID}, Processors: []component.ID{procID}, Exporters: []component.ID{expID},
+					ClonePolicy: tt.policy,
+				},
+				traces2ID: {
+					Receivers: []component.ID{rcvrID}, Exporters: []component.ID{exp2ID},
+					ClonePolicy: tt.policy2,
+				},
+			}, map[component.ID]component.Config{expID: &exampleConfig{}, exp2ID: &exampleConfig{}}))
+			require.NoError(t, err)
+
+			rcvrNode := pg.componentGraph.Node(attribute.Receiver(pipeline.SignalTraces, rcvrID).ID())
+			assert.Equal(t, tt.wantReceiver, rcvrNode.(*receiverNode).clonePolicy)
+			assert.Equal(t, tt.wantMutates, pg.pipelines[tracesID].capabilitiesNode.getConsumer().Capabilities().MutatesData)
+		})
+	}
+}
@@ -19,9 +19,13 @@ import (
 	"go.opentelemetry.io/collector/service/internal/attribute"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
+	"go.opentelemetry.io/collector/service/internal/conversionconsumer"
+	"go.opentelemetry.io/collector/service/internal/countconsumer"
 	"go.opentelemetry.io/collector/service/internal/metadata"
 	"go.opentelemetry.io/collector/service/internal/obsconsumer"
 	"go.opentelemetry.io/collector/service/internal/refconsumer"
+	"go.opentelemetry.io/collector/service/internal/usageconsumer"
+	"go.opentelemetry.io/collector/service/pipelines"
 )
 
 const pipelineIDAttrKey = "otelcol.pipeline.id"
@@ -35,6 +39,8 @@ type connectorNode struct {
 	rcvrPipelineType pipeline.Signal
 	component.Component
 	consumer baseConsumer
+	usage    *usageconsumer.Usage
+	counts   *countconsumer.Counts
 }
 
 func newConnectorNode(exprPipelineType, rcvrPipelineType pipeline.Signal, connID component.ID) *connectorNode {
@@ -55,7 +61,9 @@ func (n *connectorNode) buildComponent(
 	tel component.TelemetrySettings,
 	info component.BuildInfo,
 	builder *builders.ConnectorBuilder,
-	nexts []baseConsumer,
+	nexts map[pipeline.ID]baseConsumer,
+	fanIn pipelines.FanInConfig,
+	buffer pipelines.BufferConfig,
 ) error {
 	set := connector.Settings{
 		ID:                n.componentID,
@@ -63,16 +71,37 @@ func (n *connectorNode) buildComponent(
 		BuildInfo:         info,
 	}
 
+	tb, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return err
+	}
+	conv := conversionconsumer.NewConversion(conversionconsumer.Settings{
+		DroppedItems: tb.ConnectorConversionDroppedItems,
+		Duration:     tb.ConnectorConversionDuration,
+		Attributes:   *n.Set(),
+	})
+
 	switch n.rcvrPipelineType {
 	case pipeline.SignalTraces:
-		return n.buildTraces(ctx, set, builder, nexts)
+		err = n.buildTraces(ctx, set, builder, nexts, conv)
 	case pipeline.SignalMetrics:
-		return n.buildMetrics(ctx, set, builder, nexts)
+		err = n.buildMetrics(ctx, set, builder, nexts, conv)
 	case pipeline.SignalLogs:
-		return n.buildLogs(ctx, set, builder, nexts)
+		err = n.buildLogs(ctx, set, builder, nexts, conv)
 	case xpipeline.SignalProfiles:
-		return n.buildProfiles(ctx, set, builder, nexts)
+		err = n.buildProfiles(ctx, set, builder, nexts, conv)
 	}
+	if err != nil || n.consumer == nil {
+		return err
+	}
+
+	n.consumer = withConversion(n.exprPipelineType, n.consumer, conv)
+	n.usage = newUsage(tb, true)
+	n.consumer = withUsage(n.exprPipelineType, n.consumer, n.usage)
+	n.counts = new(countconsumer.Counts)
+	n.consumer = withCounts(n.exprPipelineType, n.consumer, n.counts)
+	n.consumer = withFanIn(n.exprPipelineType, n.consumer, fanIn)
+	n.Component, n.consumer = withBuffer(n.exprPipelineType, n.Component, n.consumer, buffer, set.Logger)
 	return nil
 }
 
@@ -80,7 +109,8 @@ func (n *connectorNode) buildTraces(
 	ctx context.Context,
 	set connector.Settings,
 	builder *builders.ConnectorBuilder,
-	nexts []baseConsumer,
+	nexts map[pipeline.ID]baseConsumer,
+	conv *conversionconsumer.Conversion,
 ) error {
 	tb, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
 	if err != nil {
@@ -99,16 +129,19 @@ func (n *connectorNode) buildTraces(
 	}
 
 	consumers := make(map[pipeline.ID]consumer.Traces, len(nexts))
-	for _, next := range nexts {
-		consumers[next.(*capabilitiesNode).pipelineID] = obsconsumer.NewTraces(
-			next.(consumer.Traces),
-			producedSettings,
-			obsconsumer.WithStaticDataPointAttribute(
-				otelattr.String(
-					pipelineIDAttrKey,
-					next.(*capabilitiesNode).pipelineID.String(),
+	for pipelineID, next := range nexts {
+		consumers[pipelineID] = conversionconsumer.NewOutputTraces(
+			obsconsumer.NewTraces(
+				next.(consumer.Traces),
+				producedSettings,
+				obsconsumer.WithStaticDataPointAttribute(
+					otelattr.String(
+						pipelineIDAttrKey,
+						pipelineID.String(),
+					),
 				),
 			),
+			conv,
 		)
 	}
 	next := connector.NewTracesRouter(consumers)
@@ -158,7 +191,8 @@ func (n *connectorNode) buildMetrics(
 	ctx context.Context,
 	set connector.Settings,
 	builder *builders.ConnectorBuilder,
-	nexts []baseConsumer,
+	nexts map[pipeline.ID]baseConsumer,
+	conv *conversionconsumer.Conversion,
 ) error {
 	tb, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
 	if err != nil {
@@ -177,16 +211,19 @@ func (n *connectorNode) buildMetrics(
 	}
 
 	consumers := make(map[pipeline.ID]consumer.Metrics, len(nexts))
-	for _, next := range nexts {
-		consumers[next.(*capabilitiesNode).pipelineID] = obsconsumer.NewMetrics(
-			next.(consumer.Metrics),
-			producedSettings,
-			obsconsumer.WithStaticDataPointAttribute(
-				otelattr.String(
-					pipelineIDAttrKey,
-					next.(*capabilitiesNode).pipelineID.String(),
+	for pipelineID, next := range nexts {
+		consumers[pipelineID] = conversionconsumer.NewOutputMetrics(
+			obsconsumer.NewMetrics(
+				next.(consumer.Metrics),
+				producedSettings,
+				obsconsumer.WithStaticDataPointAttribute(
+					otelattr.String(
+						pipelineIDAttrKey,
+						pipelineID.String(),
+					),
 				),
 			),
+			conv,
 		)
 	}
 	next := connector.NewMetricsRouter(consumers)
@@ -236,7 +273,8 @@ func (n *connectorNode) buildLogs(
 	ctx context.Context,
 	set connector.Settings,
 	builder *builders.ConnectorBuilder,
-	nexts []baseConsumer,
+	nexts map[pipeline.ID]baseConsumer,
+	conv *conversionconsumer.Conversion,
 ) error {
 	tb, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
 	if err != nil {
@@ -255,16 +293,19 @@ func (n *connectorNode) buildLogs(
 	}
 
 	consumers := make(map[pipeline.ID]consumer.Logs, len(nexts))
-	for _, next := range nexts {
-		consumers[next.(*capabilitiesNode).pipelineID] = obsconsumer.NewLogs(
-			next.(consumer.Logs),
-			producedSettings,
-			obsconsumer.WithStaticDataPointAttribute(
-				otelattr.String(
-					pipelineIDAttrKey,
-					next.(*capabilitiesNode).pipelineID.String(),
+	for pipelineID, next := range nexts {
+		consumers[pipelineID] = conversionconsumer.NewOutputLogs(
+			obsconsumer.NewLogs(
+				next.(consumer.Logs),
+				producedSettings,
+				obsconsumer.WithStaticDataPointAttribute(
+					otelattr.String(
+						pipelineIDAttrKey,
+						pipelineID.String(),
+					),
 				),
 			),
+			conv,
 		)
 	}
 	next := connector.NewLogsRouter(consumers)
@@ -314,7 +355,8 @@ func (n *connectorNode) buildProfiles(
 	ctx context.Context,
 	set connector.Settings,
 	builder *builders.ConnectorBuilder,
-	nexts []baseConsumer,
+	nexts map[pipeline.ID]baseConsumer,
+	conv *conversionconsumer.Conversion,
 ) error {
 	tb, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
 	if err != nil {
@@ -333,16 +375,19 @@ func (n *connectorNode) buildProfiles(
 	}
 
 	consumers := make(map[pipeline.ID]xconsumer.Profiles, len(nexts))
-	for _, next := range nexts {
-		consumers[next.(*capabilitiesNode).pipelineID] = obsconsumer.NewProfiles(
-			next.(xconsumer.Profiles),
-			producedSettings,
-			obsconsumer.WithStaticDataPointAttribute(
-				otelattr.String(
-					pipelineIDAttrKey,
-					next.(*capabilitiesNode).pipelineID.String(),
+	for pipelineID, next := range nexts {
+		consumers[pipelineID] = conversionconsumer.NewOutputProfiles(
+			obsconsumer.NewProfiles(
+				next.(xconsumer.Profiles),
+				producedSettings,
+				obsconsumer.WithStaticDataPointAttribute(
+					otelattr.String(
+						pipelineIDAttrKey,
+						pipelineID.String(),
+					),
 				),
 			),
+			conv,
 		)
 	}
 	next := xconnector.NewProfilesRouter(consumers)
@@ -388,11 +433,26 @@ func (n *connectorNode) buildProfiles(
 	return nil
 }
 
+// withConversion wraps the consumer of the given signal to report the conversion of the data by the connector.
+func withConversion(signal pipeline.Signal, cons baseConsumer, conv *conversionconsumer.Conversion) baseConsumer {
+	switch signal {
+	case pipeline.SignalTraces:
+		return conversionconsumer.NewInputTraces(cons.(consumer.Traces), conv)
+	case pipeline.SignalMetrics:
+		return conversionconsumer.NewInputMetrics(cons.(consumer.Metrics), conv)
+	case pipeline.SignalLogs:
+		return conversionconsumer.NewInputLogs(cons.(consumer.Logs), conv)
+	case xpipeline.SignalProfiles:
+		return conversionconsumer.NewInputProfiles(cons.(xconsumer.Profiles), conv)
+	}
+	return cons
+}
+
 // When connecting pipelines of the same data type, the connector must
 // inherit the capabilities of pipelines in which it is acting as a receiver.
 // Since the incoming and outgoing data types are the same, we must also consider
 // that the connector itself may mutate the data and pass it along.
-func aggregateCap(base baseConsumer, nexts []baseConsumer) consumer.Capabilities {
+func aggregateCap(base baseConsumer, nexts map[pipeline.ID]baseConsumer) consumer.Capabilities {
 	capabilities := base.Capabilities()
 	for _, next := range nexts {
 		capabilities.MutatesData = capabilities.MutatesData || next.Capabilities().MutatesData
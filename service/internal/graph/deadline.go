@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/service/internal/deadlineconsumer"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// newDeadlineGuard returns the guard checking the deadline of the pipelines before calling
+// the given component, or nil if no pipeline has a deadline. The components check the deadline
+// of any pipeline, as the data can flow through connectors into pipelines without deadline.
+func newDeadlineGuard(cfgs pipelines.Config, kind component.Kind, id component.ID) *deadlineconsumer.Guard {
+	if !anyDeadline(cfgs) {
+		return nil
+	}
+	return deadlineconsumer.NewGuard(kind, id)
+}
+
+// anyDeadline returns whether any pipeline has a deadline.
+func anyDeadline(cfgs pipelines.Config) bool {
+	for _, cfg := range cfgs {
+		if cfg.Deadline > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// withDeadlineGuard wraps the consumer of the given signal to check the deadline of the pipelines.
+func withDeadlineGuard(signal pipeline.Signal, cons baseConsumer, guard *deadlineconsumer.Guard) baseConsumer {
+	switch signal {
+	case pipeline.SignalTraces:
+		return deadlineconsumer.NewGuardTraces(cons.(consumer.Traces), guard)
+	case pipeline.SignalMetrics:
+		return deadlineconsumer.NewGuardMetrics(cons.(consumer.Metrics), guard)
+	case pipeline.SignalLogs:
+		return deadlineconsumer.NewGuardLogs(cons.(consumer.Logs), guard)
+	case xpipeline.SignalProfiles:
+		return deadlineconsumer.NewGuardProfiles(cons.(xconsumer.Profiles), guard)
+	}
+	return cons
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// newDeadlineSettings returns the settings of two pipelines connected by a connector, where the
+// processor of the second pipeline is stuck for the given duration, ignoring its context.
+func newDeadlineSettings(stuck time.Duration, sink *consumertest.TracesSink, in, out time.Duration) Settings {
+	rcvrID := component.MustNewID("examplereceiver")
+	procID := component.MustNewID("stuck")
+	connID := component.MustNewID("exampleconnector")
+	expID := component.MustNewID("sink")
+	stuckFactory := processor.NewFactory(procID.Type(), func() component.Config { return &struct{}{} },
+		processor.WithTraces(func(_ context.Context, _ processor.Settings, _ component.Config, next consumer.Traces) (processor.Traces, error) {
+			cons, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+				time.Sleep(stuck)
+				return next.ConsumeTraces(ctx, td)
+			})
+			return struct {
+				component.StartFunc
+				component.ShutdownFunc
+				consumer.Traces
+			}{Traces: cons}, err
+		}, component.StabilityLevelDevelopment))
+	sinkFactory := exporter.NewFactory(expID.Type(), func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			return &sinkExporter{TracesSink: sink}, nil
+		}, component.StabilityLevelDevelopment))
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{procID: stuckFactory.CreateDefaultConfig()},
+			map[component.Type]processor.Factory{stuckFactory.Type(): stuckFactory},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{expID: sinkFactory.CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{sinkFactory.Type(): sinkFactory},
+		),
+		ConnectorBuilder: builders.NewConnector(
+			map[component.ID]component.Config{connID: testcomponents.ExampleConnectorFactory.CreateDefaultConfig()},
+			map[component.Type]connector.Factory{testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory},
+		),
+		PipelineConfigs: pipelines.Config{
+			pipeline.NewIDWithName(pipeline.SignalTraces, "in"): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{connID},
+				Deadline:  in,
+			},
+			pipeline.NewIDWithName(pipeline.SignalTraces, "out"): {
+				Receivers:  []component.ID{connID},
+				Processors: []component.ID{procID},
+				Exporters:  []component.ID{expID},
+				Deadline:   out,
+			},
+		},
+	}
+}
+
+func TestPipelineDeadline(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		stuck   time.Duration
+		in, out time.Duration
+		wantErr string
+	}{
+		{
+			name:  "no_deadline",
+			stuck: 20 * time.Millisecond,
+		},
+		{
+			name:  "not_exceeded",
+			stuck: time.Millisecond,
+			out:   time.Minute,
+		},
+		{
+			name:    "exceeded",
+			stuck:   20 * time.Millisecond,
+			out:     10 * time.Millisecond,
+			wantErr: `deadline of 10ms of pipeline "traces/out" exceeded before calling exporter "sink": context deadline exceeded`,
+		},
+		{
+			// The deadline of the data flowing from an upstream pipeline is kept.
+			name:    "exceeded_upstream",
+			stuck:   20 * time.Millisecond,
+			in:      10 * time.Millisecond,
+			wantErr: `deadline of 10ms of pipeline "traces/in" exceeded before calling exporter "sink": context deadline exceeded`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.TracesSink)
+			pg, err := Build(context.Background(), newDeadlineSettings(tt.stuck, sink, tt.in, tt.out))
+			require.NoError(t, err)
+			host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+			require.NoError(t, pg.StartAll(context.Background(), host))
+			defer func() { require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter)) }()
+
+			rcvr := pg.getReceivers()[pipeline.SignalTraces][component.MustNewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+			err = rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				assert.Empty(t, sink.AllTraces())
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, sink.AllTraces(), 1)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+const zComponentDebugPath = "componentz"
+
+// debugHandlers holds the debug handlers registered by the components.
+// The zero value is ready to use.
+type debugHandlers struct {
+	mu       sync.RWMutex
+	handlers map[string]http.Handler
+}
+
+// debugHandlerPath returns the path of the handler of a component, relative to the componentz page.
+func debugHandlerPath(kind component.Kind, id component.ID) string {
+	return "/" + strings.ToLower(kind.String()) + "/" + id.String() + "/"
+}
+
+func (dh *debugHandlers) register(kind component.Kind, id component.ID, handler http.Handler) (func(), error) {
+	p := debugHandlerPath(kind, id)
+	dh.mu.Lock()
+	defer dh.mu.Unlock()
+	if _, ok := dh.handlers[p]; ok {
+		return nil, fmt.Errorf("debug handler already registered for %s %q", strings.ToLower(kind.String()), id)
+	}
+	if dh.handlers == nil {
+		dh.handlers = make(map[string]http.Handler)
+	}
+	dh.handlers[p] = handler
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			dh.mu.Lock()
+			defer dh.mu.Unlock()
+			delete(dh.handlers, p)
+		})
+	}, nil
+}
+
+// lookup returns the handler serving the given path, along with the path prefix of the handler.
+// Component names may contain slashes, so the longest matching path wins.
+func (dh *debugHandlers) lookup(reqPath string) (string, http.Handler) {
+	dh.mu.RLock()
+	defer dh.mu.RUnlock()
+	var prefix string
+	var handler http.Handler
+	for p, h := range dh.handlers {
+		if strings.HasPrefix(reqPath, p) && len(p) > len(prefix) {
+			prefix, handler = p, h
+		}
+	}
+	return prefix, handler
+}
+
+func (dh *debugHandlers) paths() []string {
+	dh.mu.RLock()
+	defer dh.mu.RUnlock()
+	paths := make([]string, 0, len(dh.handlers))
+	for p := range dh.handlers {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// handler returns the handler serving the registered handlers under the given prefix.
+func (dh *debugHandlers) handler(prefix string) http.Handler {
+	return http.StripPrefix(prefix, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, h := dh.lookup(r.URL.Path)
+		if h == nil {
+			http.NotFound(w, r)
+			return
+		}
+		http.StripPrefix(strings.TrimSuffix(p, "/"), h).ServeHTTP(w, r)
+	}))
+}
+
+func (dh *debugHandlers) handleZPages(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Component Debug Handlers"})
+	for _, p := range dh.paths() {
+		zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+			Name:              strings.Trim(p, "/"),
+			ComponentEndpoint: path.Join(zComponentDebugPath, p) + "/",
+			Link:              true,
+		})
+	}
+	zpages.WriteHTMLPageFooter(w)
+}
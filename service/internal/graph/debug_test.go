@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestRegisterDebugHandler(t *testing.T) {
+	host := &Host{}
+	mux := http.NewServeMux()
+	host.RegisterZPages(mux, "/debug")
+
+	get := func(path string) (int, string) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		body, err := io.ReadAll(rec.Body)
+		require.NoError(t, err)
+		return rec.Code, string(body)
+	}
+	echoPath := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, name+" "+r.URL.Path)
+		})
+	}
+
+	unregister, err := host.RegisterDebugHandler(component.KindReceiver, component.MustNewID("otlp"), echoPath("a"))
+	require.NoError(t, err)
+	_, err = host.RegisterDebugHandler(component.KindReceiver, component.MustNewIDWithName("otlp", "b"), echoPath("b"))
+	require.NoError(t, err)
+	_, err = host.RegisterDebugHandler(component.KindExporter, component.MustNewID("otlp"), echoPath("c"))
+	require.NoError(t, err)
+
+	_, err = host.RegisterDebugHandler(component.KindReceiver, component.MustNewID("otlp"), echoPath("d"))
+	require.EqualError(t, err, `debug handler already registered for receiver "otlp"`)
+
+	code, body := get("/debug/componentz/receiver/otlp/cache")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "a /cache", body)
+	code, body = get("/debug/componentz/receiver/otlp/b/tenants")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "b /tenants", body)
+	code, body = get("/debug/componentz/exporter/otlp/")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "c /", body)
+
+	code, body = get("/debug/componentz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, body, `href="componentz/receiver/otlp/b/"`)
+	assert.Contains(t, body, `href="componentz/exporter/otlp/"`)
+
+	unregister()
+	unregister()
+	code, _ = get("/debug/componentz/receiver/otlp/cache")
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = get("/debug/componentz/processor/batch/")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// The handler can be registered again once unregistered.
+	_, err = host.RegisterDebugHandler(component.KindReceiver, component.MustNewID("otlp"), echoPath("d"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/health"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+type failingExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumertest.Consumer
+}
+
+func newFailingExporterFactory() exporter.Factory {
+	return exporter.NewFactory(component.MustNewType("failing"),
+		func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			return &failingExporter{Consumer: consumertest.NewErr(assert.AnError)}, nil
+		}, component.StabilityLevelDevelopment),
+	)
+}
+
+func TestStatusDetection(t *testing.T) {
+	rcvrID := component.MustNewID("examplereceiver")
+	expID := component.MustNewID("failing")
+	pipelineID := pipeline.NewID(pipeline.SignalTraces)
+
+	reported := make(map[component.ID][]componentstatus.Status)
+	rep := status.NewReporter(func(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+		reported[id.ComponentID()] = append(reported[id.ComponentID()], ev.Status())
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{expID: &struct{}{}},
+			map[component.Type]exporter.Factory{expID.Type(): newFailingExporterFactory()},
+		),
+		ConnectorBuilder: builders.NewConnector(nil, nil),
+		PipelineConfigs: pipelines.Config{
+			pipelineID: {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+		},
+		StatusDetector: status.NewDetector(rep, health.DetectionConfig{Enabled: true, FailureThreshold: 2}),
+	}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), &Host{Reporter: rep}))
+
+	cons := pg.pipelines[pipelineID].capabilitiesNode.getConsumer().(consumer.Traces)
+	require.Error(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusStarting, componentstatus.StatusOK}, reported[expID])
+
+	require.Error(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusStarting,
+		componentstatus.StatusOK,
+		componentstatus.StatusRecoverableError,
+	}, reported[expID])
+
+	// The receiver is not affected by the failures of the exporter.
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusStarting, componentstatus.StatusOK}, reported[rcvrID])
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), rep))
+}
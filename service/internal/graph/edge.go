@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/service/internal/edgeconsumer"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+)
+
+const (
+	edgeFromKindKey = "otelcol.edge.from.kind"
+	edgeFromIDKey   = "otelcol.edge.from.id"
+	edgeToKindKey   = "otelcol.edge.to.kind"
+	edgeToIDKey     = "otelcol.edge.to.id"
+)
+
+// edgeConsumer returns the consumer of the next node for the edge from the given node, measured by
+// the metrics of the edge and sampled by its tap.
+func (g *Graph) edgeConsumer(from graph.Node, next graph.Node, cons baseConsumer) baseConsumer {
+	return g.tapEdge(from, next, g.measureEdge(from, next, cons))
+}
+
+// measureEdge returns the consumer of the next node, reporting the data passed along the edge from
+// the given node.
+func (g *Graph) measureEdge(from graph.Node, next graph.Node, cons baseConsumer) baseConsumer {
+	if g.edgeTelemetry == nil {
+		tb, err := metadata.NewTelemetryBuilder(g.telemetry)
+		if err != nil {
+			g.telemetry.Logger.Warn("Failed to create the metrics of the edges of the pipeline graph", zap.Error(err))
+			return cons
+		}
+		g.edgeTelemetry = tb
+	}
+	edge := edgeconsumer.NewEdge(edgeconsumer.Settings{
+		Items:      g.edgeTelemetry.GraphEdgeItems,
+		Size:       g.edgeTelemetry.GraphEdgeSize,
+		Duration:   g.edgeTelemetry.GraphEdgeDuration,
+		Attributes: edgeAttributes(from, next),
+	})
+	switch outputSignal(from) {
+	case pipeline.SignalTraces:
+		return edgeconsumer.NewTraces(cons.(consumer.Traces), edge)
+	case pipeline.SignalMetrics:
+		return edgeconsumer.NewMetrics(cons.(consumer.Metrics), edge)
+	case pipeline.SignalLogs:
+		return edgeconsumer.NewLogs(cons.(consumer.Logs), edge)
+	case xpipeline.SignalProfiles:
+		return edgeconsumer.NewProfiles(cons.(xconsumer.Profiles), edge)
+	}
+	return cons
+}
+
+// edgeAttributes identifies the edge by the kind and ID of its nodes, which is the ID of the pipeline
+// for the nodes internal to the graph, the signal of the data passed along it, and the pipeline it
+// belongs to, which is the pipeline of the producing node, or of the consuming node for the edges
+// from a receiver or a connector.
+func edgeAttributes(from, to graph.Node) attribute.Set {
+	fromSet, toSet := from.(interface{ Set() *attribute.Set }).Set(), to.(interface{ Set() *attribute.Set }).Set()
+	endpoint := func(set *attribute.Set) (string, string) {
+		kind, _ := set.Value(componentattribute.ComponentKindKey)
+		if id, ok := set.Value(componentattribute.ComponentIDKey); ok {
+			return kind.AsString(), id.AsString()
+		}
+		id, _ := set.Value(componentattribute.PipelineIDKey)
+		return kind.AsString(), id.AsString()
+	}
+	fromKind, fromID := endpoint(fromSet)
+	toKind, toID := endpoint(toSet)
+	pipelineID, ok := fromSet.Value(componentattribute.PipelineIDKey)
+	if !ok {
+		pipelineID, _ = toSet.Value(componentattribute.PipelineIDKey)
+	}
+	return attribute.NewSet(
+		attribute.String(edgeFromKindKey, fromKind),
+		attribute.String(edgeFromIDKey, fromID),
+		attribute.String(edgeToKindKey, toKind),
+		attribute.String(edgeToIDKey, toID),
+		attribute.String(componentattribute.SignalKey, outputSignal(from).String()),
+		attribute.String(componentattribute.PipelineIDKey, pipelineID.AsString()),
+	)
+}
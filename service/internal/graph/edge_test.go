@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+)
+
+func TestEdgeMetrics(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	set := newDeadlineSettings(0, new(consumertest.TracesSink), 0, 0)
+	set.Telemetry = tel.NewTelemetrySettings()
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	defer func() { require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter)) }()
+
+	rcvr := pg.getReceivers()[pipeline.SignalTraces][component.MustNewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+
+	items, err := tel.GetMetric("otelcol.graph.edge.items")
+	require.NoError(t, err)
+	got := make(map[string]int64)
+	for _, dp := range items.Data.(metricdata.Sum[int64]).DataPoints {
+		attr := func(key string) string {
+			v, _ := dp.Attributes.Value(attribute.Key(key))
+			return v.AsString()
+		}
+		assert.Equal(t, "traces", attr("otelcol.signal"))
+		assert.Equal(t, "success", attr("otelcol.component.outcome"))
+		got[attr(edgeFromKindKey)+"/"+attr(edgeFromIDKey)+" -> "+attr(edgeToKindKey)+"/"+attr(edgeToIDKey)+" in "+attr("otelcol.pipeline.id")] = dp.Value
+	}
+	assert.Equal(t, map[string]int64{
+		"receiver/examplereceiver -> capabilities/traces/in in traces/in":     2,
+		"capabilities/traces/in -> fanout/traces/in in traces/in":             2,
+		"fanout/traces/in -> connector/exampleconnector in traces/in":         2,
+		"connector/exampleconnector -> capabilities/traces/out in traces/out": 2,
+		"capabilities/traces/out -> processor/stuck in traces/out":            2,
+		"processor/stuck -> fanout/traces/out in traces/out":                  2,
+		"fanout/traces/out -> exporter/sink in traces/out":                    2,
+	}, got)
+
+	duration, err := tel.GetMetric("otelcol.graph.edge.duration")
+	require.NoError(t, err)
+	assert.Len(t, duration.Data.(metricdata.Histogram[float64]).DataPoints, 7)
+}
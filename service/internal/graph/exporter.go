@@ -16,9 +16,16 @@ import (
 	"go.opentelemetry.io/collector/pipeline/xpipeline"
 	"go.opentelemetry.io/collector/service/internal/attribute"
 	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/countconsumer"
+	"go.opentelemetry.io/collector/service/internal/deadlineconsumer"
+	"go.opentelemetry.io/collector/service/internal/lazyconsumer"
 	"go.opentelemetry.io/collector/service/internal/metadata"
 	"go.opentelemetry.io/collector/service/internal/obsconsumer"
 	"go.opentelemetry.io/collector/service/internal/refconsumer"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/statusconsumer"
+	"go.opentelemetry.io/collector/service/internal/swapconsumer"
+	"go.opentelemetry.io/collector/service/internal/usageconsumer"
 )
 
 var _ consumerNode = (*exporterNode)(nil)
@@ -31,6 +38,14 @@ type exporterNode struct {
 	pipelineType pipeline.Signal
 	component.Component
 	consumer baseConsumer
+	usage    *usageconsumer.Usage
+	counts   *countconsumer.Counts
+
+	// lazy is set for exporters started when they first receive data.
+	lazy *lazyStarter
+
+	// deadlineGuard is set if the pipelines have a deadline, which is checked before exporting.
+	deadlineGuard *deadlineconsumer.Guard
 }
 
 func newExporterNode(pipelineType pipeline.Signal, exprID component.ID) *exporterNode {
@@ -50,16 +65,37 @@ func (n *exporterNode) buildComponent(
 	tel component.TelemetrySettings,
 	info component.BuildInfo,
 	builder *builders.ExporterBuilder,
+	tracker *status.Tracker,
 ) error {
+	comp, cons, err := n.createComponent(ctx, tel, info, builder, tracker)
+	if err != nil {
+		return err
+	}
+	n.Component = comp
+	n.setConsumer(cons)
+	return nil
+}
+
+// createComponent creates the exporter and the consumer wrapping it, without modifying the node.
+func (n *exporterNode) createComponent(
+	ctx context.Context,
+	tel component.TelemetrySettings,
+	info component.BuildInfo,
+	builder *builders.ExporterBuilder,
+	tracker *status.Tracker,
+) (component.Component, baseConsumer, error) {
 	set := exporter.Settings{
 		ID:                n.componentID,
 		TelemetrySettings: telemetry.WithAttributeSet(tel, *n.Set()),
 		BuildInfo:         info,
 	}
+	// The items sent, failed to be sent and enqueued by the exporter, and the saturation of its
+	// sending queue, are accounted to its status.
+	set.MeterProvider = tracker.MeterProvider(set.MeterProvider)
 
 	tb, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	consumedSettings := obsconsumer.Settings{
@@ -68,37 +104,78 @@ func (n *exporterNode) buildComponent(
 		Logger:      set.Logger,
 	}
 
+	// The usage and counts are kept when the exporter is recreated.
+	if n.usage == nil {
+		n.usage = newUsage(tb, true)
+	}
+	if n.counts == nil {
+		n.counts = new(countconsumer.Counts)
+	}
+
 	switch n.pipelineType {
 	case pipeline.SignalTraces:
-		n.Component, err = builder.CreateTraces(ctx, set)
+		comp, err := builder.CreateTraces(ctx, set)
 		if err != nil {
-			return fmt.Errorf("failed to create %q exporter for data type %q: %w", set.ID, n.pipelineType, err)
+			return nil, nil, fmt.Errorf("failed to create %q exporter for data type %q: %w", set.ID, n.pipelineType, err)
 		}
-		n.consumer = obsconsumer.NewTraces(n.Component.(consumer.Traces), consumedSettings)
-		n.consumer = refconsumer.NewTraces(n.consumer.(consumer.Traces))
+		cons := obsconsumer.NewTraces(statusconsumer.NewTraces(lazyconsumer.NewTraces(comp, n.beforeConsume()), tracker), consumedSettings)
+		return comp, withDeadlineGuard(n.pipelineType, withCounts(n.pipelineType, withUsage(n.pipelineType, refconsumer.NewTraces(cons), n.usage), n.counts), n.deadlineGuard), nil
 	case pipeline.SignalMetrics:
-		n.Component, err = builder.CreateMetrics(ctx, set)
+		comp, err := builder.CreateMetrics(ctx, set)
 		if err != nil {
-			return fmt.Errorf("failed to create %q exporter for data type %q: %w", set.ID, n.pipelineType, err)
+			return nil, nil, fmt.Errorf("failed to create %q exporter for data type %q: %w", set.ID, n.pipelineType, err)
 		}
-		n.consumer = obsconsumer.NewMetrics(n.Component.(consumer.Metrics), consumedSettings)
-		n.consumer = refconsumer.NewMetrics(n.consumer.(consumer.Metrics))
+		cons := obsconsumer.NewMetrics(statusconsumer.NewMetrics(lazyconsumer.NewMetrics(comp, n.beforeConsume()), tracker), consumedSettings)
+		return comp, withDeadlineGuard(n.pipelineType, withCounts(n.pipelineType, withUsage(n.pipelineType, refconsumer.NewMetrics(cons), n.usage), n.counts), n.deadlineGuard), nil
 	case pipeline.SignalLogs:
-		n.Component, err = builder.CreateLogs(ctx, set)
+		comp, err := builder.CreateLogs(ctx, set)
 		if err != nil {
-			return fmt.Errorf("failed to create %q exporter for data type %q: %w", set.ID, n.pipelineType, err)
+			return nil, nil, fmt.Errorf("failed to create %q exporter for data type %q: %w", set.ID, n.pipelineType, err)
 		}
-		n.consumer = obsconsumer.NewLogs(n.Component.(consumer.Logs), consumedSettings)
-		n.consumer = refconsumer.NewLogs(n.consumer.(consumer.Logs))
+		cons := obsconsumer.NewLogs(statusconsumer.NewLogs(lazyconsumer.NewLogs(comp, n.beforeConsume()), tracker), consumedSettings)
+		return comp, withDeadlineGuard(n.pipelineType, withCounts(n.pipelineType, withUsage(n.pipelineType, refconsumer.NewLogs(cons), n.usage), n.counts), n.deadlineGuard), nil
 	case xpipeline.SignalProfiles:
-		n.Component, err = builder.CreateProfiles(ctx, set)
+		comp, err := builder.CreateProfiles(ctx, set)
 		if err != nil {
-			return fmt.Errorf("failed to create %q exporter for data type %q: %w", set.ID, n.pipelineType, err)
+			return nil, nil, fmt.Errorf("failed to create %q exporter for data type %q: %w", set.ID, n.pipelineType, err)
 		}
-		n.consumer = obsconsumer.NewProfiles(n.Component.(xconsumer.Profiles), consumedSettings)
-		n.consumer = refconsumer.NewProfiles(n.consumer.(xconsumer.Profiles))
+		cons := obsconsumer.NewProfiles(statusconsumer.NewProfiles(lazyconsumer.NewProfiles(comp, n.beforeConsume()), tracker), consumedSettings)
+		return comp, withDeadlineGuard(n.pipelineType, withCounts(n.pipelineType, withUsage(n.pipelineType, refconsumer.NewProfiles(cons), n.usage), n.counts), n.deadlineGuard), nil
+	}
+	return nil, nil, fmt.Errorf("error creating exporter %q for data type %q is not supported", set.ID, n.pipelineType)
+}
+
+// beforeConsume returns the function starting a lazy exporter, nil otherwise.
+func (n *exporterNode) beforeConsume() lazyconsumer.BeforeFunc {
+	if n.lazy == nil {
+		return nil
+	}
+	return n.lazy.start
+}
+
+// setConsumer sets the consumer exposed by the node. Exporters expose their consumer through
+// a swapconsumer, so that they can be recreated, when restarted or reloaded, without rebuilding
+// the components sending data to them.
+func (n *exporterNode) setConsumer(cons baseConsumer) {
+	switch sc := n.consumer.(type) {
+	case *swapconsumer.Traces:
+		sc.Store(cons.(consumer.Traces))
+	case *swapconsumer.Metrics:
+		sc.Store(cons.(consumer.Metrics))
+	case *swapconsumer.Logs:
+		sc.Store(cons.(consumer.Logs))
+	case *swapconsumer.Profiles:
+		sc.Store(cons.(xconsumer.Profiles))
 	default:
-		return fmt.Errorf("error creating exporter %q for data type %q is not supported", set.ID, n.pipelineType)
+		switch n.pipelineType {
+		case pipeline.SignalTraces:
+			n.consumer = swapconsumer.NewTraces(cons.(consumer.Traces))
+		case pipeline.SignalMetrics:
+			n.consumer = swapconsumer.NewMetrics(cons.(consumer.Metrics))
+		case pipeline.SignalLogs:
+			n.consumer = swapconsumer.NewLogs(cons.(consumer.Logs))
+		case xpipeline.SignalProfiles:
+			n.consumer = swapconsumer.NewProfiles(cons.(xconsumer.Profiles))
+		}
 	}
-	return nil
 }
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/faninconsumer"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// validateFanIn checks that the fan-in settings only reference connectors of the graph.
+func (g *Graph) validateFanIn(cfg map[component.ID]pipelines.FanInConfig) error {
+	used := g.usedConnectors()
+	for id := range cfg {
+		if !used[id] {
+			return fmt.Errorf("fan_in references connector %q which is not used by any pipeline", id)
+		}
+	}
+	return nil
+}
+
+// usedConnectors returns the IDs of the connectors used by the pipelines.
+func (g *Graph) usedConnectors() map[component.ID]bool {
+	used := make(map[component.ID]bool)
+	for _, instanceID := range g.instanceIDs {
+		if instanceID.Kind() == component.KindConnector {
+			used[instanceID.ComponentID()] = true
+		}
+	}
+	return used
+}
+
+// withFanIn merges the data exported to a connector according to its fan-in mode.
+// Profiles are always passed through, since their dictionaries cannot be merged.
+func withFanIn(signal pipeline.Signal, cons baseConsumer, cfg pipelines.FanInConfig) baseConsumer {
+	if cfg.Mode != pipelines.FanInMerge {
+		return cons
+	}
+	set := faninconsumer.Settings{Window: cfg.Window, MaxItems: cfg.MaxItems}
+	switch signal {
+	case pipeline.SignalTraces:
+		return faninconsumer.NewTraces(cons.(consumer.Traces), set)
+	case pipeline.SignalMetrics:
+		return faninconsumer.NewMetrics(cons.(consumer.Metrics), set)
+	case pipeline.SignalLogs:
+		return faninconsumer.NewLogs(cons.(consumer.Logs), set)
+	}
+	return cons
+}
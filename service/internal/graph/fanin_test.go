@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func newFanInSettings(fanIn map[component.ID]pipelines.FanInConfig) Settings {
+	rcvrAID := component.MustNewIDWithName("examplereceiver", "a")
+	rcvrBID := component.MustNewIDWithName("examplereceiver", "b")
+	connID := component.MustNewID("exampleconnector")
+	expID := component.MustNewID("exampleexporter")
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				rcvrAID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+				rcvrBID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory},
+		),
+		ConnectorBuilder: builders.NewConnector(
+			map[component.ID]component.Config{connID: testcomponents.ExampleConnectorFactory.CreateDefaultConfig()},
+			map[component.Type]connector.Factory{testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory},
+		),
+		PipelineConfigs: pipelines.Config{
+			pipeline.NewIDWithName(pipeline.SignalTraces, "a"): {
+				Receivers: []component.ID{rcvrAID},
+				Exporters: []component.ID{connID},
+			},
+			pipeline.NewIDWithName(pipeline.SignalTraces, "b"): {
+				Receivers: []component.ID{rcvrBID},
+				Exporters: []component.ID{connID},
+			},
+			pipeline.NewIDWithName(pipeline.SignalTraces, "out"): {
+				Receivers: []component.ID{connID},
+				Exporters: []component.ID{expID},
+			},
+		},
+		FanIn: fanIn,
+	}
+}
+
+func TestFanInMerge(t *testing.T) {
+	set := newFanInSettings(map[component.ID]pipelines.FanInConfig{
+		component.MustNewID("exampleconnector"): {Mode: pipelines.FanInMerge, Window: time.Minute, MaxItems: 2},
+	})
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	receivers := pg.getReceivers()[pipeline.SignalTraces]
+	var wg sync.WaitGroup
+	for _, name := range []string{"a", "b"} {
+		rcvr := receivers[component.MustNewIDWithName("examplereceiver", name)].(*testcomponents.ExampleReceiver)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+		}()
+	}
+	wg.Wait()
+
+	// The spans exported by both pipelines are received by the exporter in a single call.
+	exp := pg.GetExporters()[pipeline.SignalTraces][component.MustNewID("exampleexporter")].(*testcomponents.ExampleExporter)
+	require.Len(t, exp.Traces, 1)
+	assert.Equal(t, 2, exp.Traces[0].SpanCount())
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestFanInUnknownConnector(t *testing.T) {
+	set := newFanInSettings(map[component.ID]pipelines.FanInConfig{
+		component.MustNewID("forward"): {Mode: pipelines.FanInMerge, Window: time.Second},
+	})
+	_, err := Build(context.Background(), set)
+	require.EqualError(t, err, `fan_in references connector "forward" which is not used by any pipeline`)
+}
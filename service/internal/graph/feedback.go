@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/service/internal/attribute"
+	"go.opentelemetry.io/collector/service/internal/bufferconsumer"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+var _ consumerNode = (*feedbackNode)(nil)
+
+// feedbackNode replaces the edge from the fan-out node of a pipeline to a connector when this
+// edge is marked as a feedback edge. It has no outgoing edge, so that the graph stays acyclic,
+// and queues the data in a buffer consumed by the connector.
+type feedbackNode struct {
+	attribute.Attributes
+	pipelineID pipeline.ID
+	target     *connectorNode
+	buffer     interface {
+		component.Component
+		baseConsumer
+	}
+}
+
+func newFeedbackNode(pipelineID pipeline.ID, target *connectorNode) *feedbackNode {
+	return &feedbackNode{
+		Attributes: attribute.Feedback(pipelineID, target.rcvrPipelineType, target.componentID),
+		pipelineID: pipelineID,
+		target:     target,
+	}
+}
+
+func (n *feedbackNode) getConsumer() baseConsumer {
+	return n.buffer
+}
+
+// buildComponent creates the buffer. The connector is built after the feedback node since the
+// graph has no edge between them, so the buffer looks up the consumer of the connector on each call.
+func (n *feedbackNode) buildComponent(cfg pipelines.BufferConfig, logger *zap.Logger) {
+	set := bufferconsumer.Settings{
+		QueueSize:    cfg.QueueSize,
+		NumConsumers: cfg.NumConsumers,
+		Logger: logger.With(
+			zap.String("connector", n.target.componentID.String()),
+			zap.String("pipeline", n.pipelineID.String()),
+		),
+	}
+	next := connectorConsumer{target: n.target}
+	switch n.pipelineID.Signal() {
+	case pipeline.SignalTraces:
+		n.buffer = bufferconsumer.NewTraces(next, set)
+	case pipeline.SignalMetrics:
+		n.buffer = bufferconsumer.NewMetrics(next, set)
+	case pipeline.SignalLogs:
+		n.buffer = bufferconsumer.NewLogs(next, set)
+	case xpipeline.SignalProfiles:
+		n.buffer = bufferconsumer.NewProfiles(next, set)
+	}
+}
+
+// connectorConsumer consumes with the consumer of a connector node, looked up on each call.
+type connectorConsumer struct {
+	target *connectorNode
+}
+
+func (c connectorConsumer) Capabilities() consumer.Capabilities {
+	return c.target.getConsumer().Capabilities()
+}
+
+func (c connectorConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return c.target.getConsumer().(consumer.Traces).ConsumeTraces(ctx, td)
+}
+
+func (c connectorConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return c.target.getConsumer().(consumer.Metrics).ConsumeMetrics(ctx, md)
+}
+
+func (c connectorConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return c.target.getConsumer().(consumer.Logs).ConsumeLogs(ctx, ld)
+}
+
+func (c connectorConsumer) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	return c.target.getConsumer().(xconsumer.Profiles).ConsumeProfiles(ctx, pd)
+}
+
+// validateFeedback checks that the feedback edges are edges of the graph.
+func (g *Graph) validateFeedback(cfg map[component.ID]pipelines.FeedbackConfig) error {
+	used := g.usedConnectors()
+	for id, feedback := range cfg {
+		if !used[id] {
+			return fmt.Errorf("feedback references connector %q which is not used by any pipeline", id)
+		}
+		for _, pipelineID := range feedback.Pipelines {
+			pipe, ok := g.set.PipelineConfigs[pipelineID]
+			if !ok || !slices.Contains(pipe.Exporters, id) {
+				return fmt.Errorf("feedback of connector %q references pipeline %q which does not export to it", id, pipelineID.String())
+			}
+		}
+	}
+	return nil
+}
+
+// isFeedback returns whether the edge from the pipeline to the connector is a feedback edge.
+func (g *Graph) isFeedback(pipelineID pipeline.ID, connID component.ID) bool {
+	feedback, ok := g.set.Feedback[connID]
+	return ok && slices.Contains(feedback.Pipelines, pipelineID)
+}
+
+// startFeedback starts consuming the buffers of the feedback edges.
+func (g *Graph) startFeedback(ctx context.Context, host component.Host) error {
+	for _, n := range g.feedbackNodes {
+		if err := n.buffer.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shutdownFeedback drains the buffers of the feedback edges of a connector, before the connector
+// is shut down. The data fed back while draining is refused.
+func (g *Graph) shutdownFeedback(ctx context.Context, conn *connectorNode) error {
+	for _, n := range g.feedbackNodes {
+		if n.target != conn {
+			continue
+		}
+		if err := n.buffer.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
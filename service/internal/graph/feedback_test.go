@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// loopProcessor counts the times the spans went through it, and drops them after maxLoops.
+type loopProcessor struct {
+	component.StartFunc
+	component.ShutdownFunc
+	next consumer.Traces
+}
+
+const maxLoops = 3
+
+func (*loopProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
+func (p *loopProcessor) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		loops, _ := rs.Resource().Attributes().Get("loops")
+		if loops.Int() >= maxLoops {
+			return true
+		}
+		rs.Resource().Attributes().PutInt("loops", loops.Int()+1)
+		return false
+	})
+	if td.ResourceSpans().Len() == 0 {
+		return nil
+	}
+	return p.next.ConsumeTraces(ctx, td)
+}
+
+type sinkExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	*consumertest.TracesSink
+}
+
+func newFeedbackSettings(feedback map[component.ID]pipelines.FeedbackConfig, sink *consumertest.TracesSink) Settings {
+	rcvrID := component.MustNewID("examplereceiver")
+	procID := component.MustNewID("loop")
+	connID := component.MustNewID("exampleconnector")
+	expID := component.MustNewID("sink")
+	loopFactory := processor.NewFactory(procID.Type(), func() component.Config { return &struct{}{} },
+		processor.WithTraces(func(_ context.Context, _ processor.Settings, _ component.Config, next consumer.Traces) (processor.Traces, error) {
+			return &loopProcessor{next: next}, nil
+		}, component.StabilityLevelDevelopment))
+	sinkFactory := exporter.NewFactory(expID.Type(), func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			return &sinkExporter{TracesSink: sink}, nil
+		}, component.StabilityLevelDevelopment))
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{procID: loopFactory.CreateDefaultConfig()},
+			map[component.Type]processor.Factory{loopFactory.Type(): loopFactory},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{expID: sinkFactory.CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{sinkFactory.Type(): sinkFactory},
+		),
+		ConnectorBuilder: builders.NewConnector(
+			map[component.ID]component.Config{connID: testcomponents.ExampleConnectorFactory.CreateDefaultConfig()},
+			map[component.Type]connector.Factory{testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory},
+		),
+		PipelineConfigs: pipelines.Config{
+			pipeline.NewIDWithName(pipeline.SignalTraces, "in"): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{connID},
+			},
+			pipeline.NewIDWithName(pipeline.SignalTraces, "loop"): {
+				Receivers:  []component.ID{connID},
+				Processors: []component.ID{procID},
+				Exporters:  []component.ID{connID, expID},
+			},
+		},
+		Feedback: feedback,
+	}
+}
+
+func TestFeedback(t *testing.T) {
+	_, err := Build(context.Background(), newFeedbackSettings(nil, new(consumertest.TracesSink)))
+	require.ErrorContains(t, err, "cycle detected: ")
+
+	sink := new(consumertest.TracesSink)
+	set := newFeedbackSettings(map[component.ID]pipelines.FeedbackConfig{
+		component.MustNewID("exampleconnector"): {
+			Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "loop")},
+			Buffer:    pipelines.BufferConfig{QueueSize: 10},
+		},
+	}, sink)
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	rcvr := pg.getReceivers()[pipeline.SignalTraces][component.MustNewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+
+	// The spans go through the loop until the processor drops them.
+	assert.Eventually(t, func() bool { return len(sink.AllTraces()) == maxLoops }, time.Second, time.Millisecond)
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestFeedbackInvalid(t *testing.T) {
+	set := newFeedbackSettings(map[component.ID]pipelines.FeedbackConfig{
+		component.MustNewID("forward"): {Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "loop")}},
+	}, new(consumertest.TracesSink))
+	_, err := Build(context.Background(), set)
+	require.EqualError(t, err, `feedback references connector "forward" which is not used by any pipeline`)
+
+	set = newFeedbackSettings(map[component.ID]pipelines.FeedbackConfig{
+		component.MustNewID("exampleconnector"): {Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "out")}},
+	}, new(consumertest.TracesSink))
+	_, err = Build(context.Background(), set)
+	require.EqualError(t, err, `feedback of connector "exampleconnector" references pipeline "traces/out" which does not export to it`)
+}
@@ -17,6 +17,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -30,13 +31,18 @@ import (
 	"go.opentelemetry.io/collector/connector/xconnector"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
-	"go.opentelemetry.io/collector/internal/fanoutconsumer"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/service/health"
 	"go.opentelemetry.io/collector/service/hostcapabilities"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
+	"go.opentelemetry.io/collector/service/internal/deadlineconsumer"
+	"go.opentelemetry.io/collector/service/internal/limitconsumer"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+	"go.opentelemetry.io/collector/service/internal/sinkexporters"
 	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/timeout"
 	"go.opentelemetry.io/collector/service/pipelines"
 )
 
@@ -54,6 +60,36 @@ type Settings struct {
 	PipelineConfigs pipelines.Config
 
 	ReportStatus status.ServiceStatusFunc
+
+	// StatusDetector derives the status of receivers, processors and exporters from the outcome
+	// of the calls made to them and from their internal metrics. Detection is disabled if nil.
+	StatusDetector *status.Detector
+
+	// RestartConfig holds the restart policies of receivers and exporters.
+	RestartConfig health.RestartConfig
+
+	// LazyExporters are the exporters started when they first receive data,
+	// instead of when the pipelines are started.
+	LazyExporters []component.ID
+
+	// Timeouts bounds the duration of the start and shutdown of the components.
+	Timeouts health.TimeoutConfig
+
+	// StartFailure holds the start failure policies of individual components, overriding
+	// the policies of the pipelines.
+	StartFailure health.StartFailureConfig
+
+	// FanIn defines how the connectors receive the data exported to them by several pipelines.
+	FanIn map[component.ID]pipelines.FanInConfig
+
+	// Buffer defines the bounded queues decoupling the connectors from the pipelines exporting to them.
+	Buffer map[component.ID]pipelines.BufferConfig
+
+	// Feedback defines the edges from pipelines to connectors allowed to form cycles.
+	Feedback map[component.ID]pipelines.FeedbackConfig
+
+	// SinkStats counts the items entering each pipeline, if set.
+	SinkStats *sinkexporters.Stats
 }
 
 type Graph struct {
@@ -66,18 +102,67 @@ type Graph struct {
 	// Keep track of status source per node
 	instanceIDs map[int64]*componentstatus.InstanceID
 
+	// The nodes replacing the feedback edges, see feedbackNode.
+	feedbackNodes []*feedbackNode
+
+	// The nodes copying the input of the primary pipelines to their shadow pipelines, see shadowNode.
+	shadowNodes []*shadowNode
+
+	// Restarts components according to their restart policy, nil if there is none.
+	restarter *restarter
+
+	// Handles the components failing to start, nil if no component or pipeline has a policy.
+	startFailures *startFailures
+
+	// The nodes of the previous graph whose running component is reused, keyed by node ID, see Reconcile.
+	reused map[int64]graph.Node
+
+	// The taps sampling the data passing the edges, see HandleTapZPages.
+	taps *edgeTaps
+
+	// Whether the intake of each pipeline is paused, see SetPaused.
+	pauses *pauses
+
+	// The last samples of the counts of the nodes, see HandleStatsZPages.
+	stats *liveStats
+
+	// The metrics of the edges, created with the first edge, see measureEdge.
+	edgeTelemetry *metadata.TelemetryBuilder
+
+	// The settings used to recreate components at runtime, and the lock serializing their recreation.
+	set       Settings
+	rebuildMu sync.Mutex
+
+	// The guards of the lifecycle calls made to the components of the nodes, see lifecycle.
+	lifecyclesMu sync.Mutex
+	lifecycles   map[graph.Node]*timeout.Guard
+
 	telemetry component.TelemetrySettings
 }
 
 // Build builds a full pipeline graph.
 // Build also validates the configuration of the pipelines and does the actual initialization of each Component in the Graph.
 func Build(ctx context.Context, set Settings) (*Graph, error) {
+	return build(ctx, set, nil)
+}
+
+// build builds the graph, reusing the components of the previous graph, if any, see Reconcile.
+func build(ctx context.Context, set Settings, prev *Graph) (*Graph, error) {
 	pipelines := &Graph{
 		componentGraph: simple.NewDirectedGraph(),
 		pipelines:      make(map[pipeline.ID]*pipelineNodes, len(set.PipelineConfigs)),
 		instanceIDs:    make(map[int64]*componentstatus.InstanceID),
+		taps:           newEdgeTaps(),
+		pauses:         newPauses(),
+		stats:          &liveStats{},
+		set:            set,
 		telemetry:      set.Telemetry,
 	}
+	if prev != nil {
+		pipelines.taps = prev.taps
+		pipelines.pauses = prev.pauses
+		pipelines.stats = prev.stats
+	}
 	for pipelineID := range set.PipelineConfigs {
 		pipelines.pipelines[pipelineID] = &pipelineNodes{
 			receivers: make(map[int64]graph.Node),
@@ -87,8 +172,39 @@ func Build(ctx context.Context, set Settings) (*Graph, error) {
 	if err := pipelines.createNodes(set); err != nil {
 		return nil, err
 	}
+	if err := pipelines.validateFeedback(set.Feedback); err != nil {
+		return nil, err
+	}
+	if err := pipelines.validateShadows(); err != nil {
+		return nil, err
+	}
 	pipelines.createEdges()
-	err := pipelines.buildComponents(ctx, set)
+	if err := pipelines.markLazyExporters(set.LazyExporters); err != nil {
+		return nil, err
+	}
+	if err := pipelines.validateTimeouts(set.Timeouts); err != nil {
+		return nil, err
+	}
+	if err := pipelines.validateFanIn(set.FanIn); err != nil {
+		return nil, err
+	}
+	if err := pipelines.validateBuffer(set.Buffer); err != nil {
+		return nil, err
+	}
+	if err := pipelines.validateTenants(); err != nil {
+		return nil, err
+	}
+	if prev != nil {
+		pipelines.reuseFrom(prev)
+	}
+	var err error
+	if pipelines.restarter, err = newRestarter(pipelines, set); err != nil {
+		return nil, err
+	}
+	if pipelines.startFailures, err = newStartFailures(pipelines, set); err != nil {
+		return nil, err
+	}
+	err = pipelines.buildComponents(ctx, set)
 	return pipelines, err
 }
 
@@ -102,6 +218,9 @@ func (g *Graph) createNodes(set Settings) error {
 	connectorsAsExporter := make(map[component.ID][]pipeline.ID)
 	connectorsAsReceiver := make(map[component.ID][]pipeline.ID)
 
+	// Keep track of the processors whose instance can be shared by the pipelines of a signal.
+	sharedProcessors := make(map[sharedProcessorKey]*sharedProcessor)
+
 	// Build each pipelineNodes struct for each pipeline by parsing the pipelineCfg.
 	// Also populates the connectors, connectorsAsExporter and connectorsAsReceiver maps.
 	for pipelineID, pipelineCfg := range set.PipelineConfigs {
@@ -122,6 +241,14 @@ func (g *Graph) createNodes(set Settings) error {
 		for _, procID := range pipelineCfg.Processors {
 			procNode := g.createProcessor(pipelineID, procID)
 			pipe.processors = append(pipe.processors, procNode)
+			if set.ProcessorBuilder.SharedInstance(procID) {
+				key := sharedProcessorKey{componentID: procID, signal: pipelineID.Signal()}
+				if sharedProcessors[key] == nil {
+					sharedProcessors[key] = newSharedProcessor(pipelineID.Signal(), procID)
+				}
+				procNode.shared = sharedProcessors[key]
+				procNode.shared.addPipeline(g.instanceIDs[procNode.ID()])
+			}
 		}
 
 		pipe.fanOutNode = newFanOutNode(pipelineID)
@@ -137,6 +264,15 @@ func (g *Graph) createNodes(set Settings) error {
 		}
 	}
 
+	// A processor used by a single pipeline keeps its own instance.
+	for _, pipe := range g.pipelines {
+		for _, node := range pipe.processors {
+			if procNode := node.(*processorNode); procNode.shared != nil && len(procNode.shared.instanceIDs) == 1 {
+				procNode.shared = nil
+			}
+		}
+	}
+
 	for connID := range connectors {
 		factory := set.ConnectorBuilder.Factory(connID.Type())
 		if factory == nil {
@@ -262,7 +398,7 @@ func (g *Graph) createConnector(exprPipelineID, rcvrPipelineID pipeline.ID, conn
 
 // Iterates through the pipelines and creates edges between components.
 func (g *Graph) createEdges() {
-	for _, pg := range g.pipelines {
+	for pipelineID, pg := range g.pipelines {
 		// Draw edges from each receiver to the capability node.
 		for _, receiver := range pg.receivers {
 			g.componentGraph.SetEdge(g.componentGraph.NewEdge(receiver, pg.capabilitiesNode))
@@ -282,8 +418,25 @@ func (g *Graph) createEdges() {
 		g.componentGraph.SetEdge(g.componentGraph.NewEdge(from, to))
 
 		for _, exporter := range pg.exporters {
+			if conn, ok := exporter.(*connectorNode); ok && g.isFeedback(pipelineID, conn.componentID) {
+				// The feedback node has no edge to the connector, which breaks the cycles.
+				fbNode := newFeedbackNode(pipelineID, conn)
+				g.componentGraph.AddNode(fbNode)
+				g.feedbackNodes = append(g.feedbackNodes, fbNode)
+				g.componentGraph.SetEdge(g.componentGraph.NewEdge(pg.fanOutNode, fbNode))
+				continue
+			}
 			g.componentGraph.SetEdge(g.componentGraph.NewEdge(pg.fanOutNode, exporter))
 		}
+
+		// The shadow pipeline receives the input of its primary pipeline.
+		if shadow := g.set.PipelineConfigs[pipelineID].Shadow; shadow != nil {
+			shadowNode := newShadowNode(pipelineID, shadow.Of)
+			g.componentGraph.AddNode(shadowNode)
+			g.shadowNodes = append(g.shadowNodes, shadowNode)
+			g.componentGraph.SetEdge(g.componentGraph.NewEdge(g.pipelines[shadow.Of].capabilitiesNode, shadowNode))
+			g.componentGraph.SetEdge(g.componentGraph.NewEdge(shadowNode, pg.capabilitiesNode))
+		}
 	}
 }
 
@@ -298,72 +451,91 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 
 	for i := len(nodes) - 1; i >= 0; i-- {
 		node := nodes[i]
+		if prev, ok := g.reused[node.ID()]; ok {
+			reuseNode(node, prev)
+			continue
+		}
 
 		switch n := node.(type) {
 		case *receiverNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
+			n.clonePolicy = g.receiverClonePolicy(n.ID())
+			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()),
+				set.StatusDetector.Tracker(g.instanceIDs[n.ID()]))
 		case *processorNode:
 			// nextConsumers is guaranteed to be length 1.  Either it is the next processor or it is the fanout node for the exporters.
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
+			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0],
+				set.StatusDetector.Tracker(g.instanceIDs[n.ID()]))
+			if err == nil {
+				guard := newDeadlineGuard(set.PipelineConfigs, component.KindProcessor, n.componentID)
+				n.consumer = withDeadlineGuard(n.pipelineID.Signal(), n.consumer, guard)
+			}
 		case *exporterNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder)
+			n.deadlineGuard = newDeadlineGuard(set.PipelineConfigs, component.KindExporter, n.componentID)
+			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder,
+				set.StatusDetector.Tracker(g.instanceIDs[n.ID()]))
 		case *connectorNode:
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextConsumers(n.ID()))
-		case *capabilitiesNode:
-			capability := consumer.Capabilities{
-				// The fanOutNode represents the aggregate capabilities of the exporters in the pipeline.
-				MutatesData: g.pipelines[n.pipelineID].fanOutNode.getConsumer().Capabilities().MutatesData,
+			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ConnectorBuilder, g.nextPipelineConsumers(n.ID()), set.FanIn[n.componentID], set.Buffer[n.componentID])
+			if err == nil && n.consumer != nil {
+				guard := newDeadlineGuard(set.PipelineConfigs, component.KindConnector, n.componentID)
+				n.consumer = withDeadlineGuard(n.exprPipelineType, n.consumer, guard)
 			}
+		case *feedbackNode:
+			n.buildComponent(set.Feedback[n.target.componentID].Buffer, set.Telemetry.Logger)
+		case *shadowNode:
+			n.buildComponent(*set.PipelineConfigs[n.pipelineID].Shadow, g.nextConsumers(n.ID())[0], set.Telemetry.Logger)
+		case *capabilitiesNode:
+			// The fanOutNode represents the aggregate capabilities of the exporters in the pipeline.
+			capability := g.pipelines[n.pipelineID].fanOutNode.getConsumer().Capabilities()
 			for _, proc := range g.pipelines[n.pipelineID].processors {
-				capability.MutatesData = capability.MutatesData || proc.(*processorNode).getConsumer().Capabilities().MutatesData
+				capability = capability.Merge(proc.(*processorNode).getConsumer().Capabilities())
 			}
-			next := g.nextConsumers(n.ID())[0]
+			if set.PipelineConfigs[n.pipelineID].ClonePolicy == pipelines.CloneReadOnly {
+				capability = consumer.Capabilities{}
+			}
+			next, shadows := g.capabilitiesNexts(n)
+			if tenants := set.PipelineConfigs[n.pipelineID].Tenants; tenants != nil {
+				n.tenants = newTenantRouter(g, n.pipelineID, *tenants, next)
+				next = n.tenants
+			}
+			deadline := deadlineconsumer.NewDeadline(n.pipelineID, set.PipelineConfigs[n.pipelineID].Deadline)
+			limit := limitconsumer.NewLimit(n.pipelineID, set.PipelineConfigs[n.pipelineID].MaxConcurrency)
 			switch n.pipelineID.Signal() {
 			case pipeline.SignalTraces:
 				cc := capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
 				n.baseConsumer = cc
-				n.ConsumeTracesFunc = cc.ConsumeTraces
+				n.ConsumeTracesFunc = deadlineconsumer.NewTraces(limitconsumer.NewTraces(cc, limit), deadline).ConsumeTraces
 			case pipeline.SignalMetrics:
 				cc := capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability)
 				n.baseConsumer = cc
-				n.ConsumeMetricsFunc = cc.ConsumeMetrics
+				n.ConsumeMetricsFunc = deadlineconsumer.NewMetrics(limitconsumer.NewMetrics(cc, limit), deadline).ConsumeMetrics
 			case pipeline.SignalLogs:
 				cc := capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
 				n.baseConsumer = cc
-				n.ConsumeLogsFunc = cc.ConsumeLogs
+				n.ConsumeLogsFunc = deadlineconsumer.NewLogs(limitconsumer.NewLogs(cc, limit), deadline).ConsumeLogs
 			case xpipeline.SignalProfiles:
 				cc := capabilityconsumer.NewProfiles(next.(xconsumer.Profiles), capability)
 				n.baseConsumer = cc
-				n.ConsumeProfilesFunc = cc.ConsumeProfiles
+				n.ConsumeProfilesFunc = deadlineconsumer.NewProfiles(limitconsumer.NewProfiles(cc, limit), deadline).ConsumeProfiles
 			}
-		case *fanOutNode:
-			nexts := g.nextConsumers(n.ID())
-			switch n.pipelineID.Signal() {
-			case pipeline.SignalTraces:
-				consumers := make([]consumer.Traces, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Traces))
-				}
-				n.baseConsumer = fanoutconsumer.NewTraces(consumers)
-			case pipeline.SignalMetrics:
-				consumers := make([]consumer.Metrics, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Metrics))
-				}
-				n.baseConsumer = fanoutconsumer.NewMetrics(consumers)
-			case pipeline.SignalLogs:
-				consumers := make([]consumer.Logs, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(consumer.Logs))
-				}
-				n.baseConsumer = fanoutconsumer.NewLogs(consumers)
-			case xpipeline.SignalProfiles:
-				consumers := make([]xconsumer.Profiles, 0, len(nexts))
-				for _, next := range nexts {
-					consumers = append(consumers, next.(xconsumer.Profiles))
-				}
-				n.baseConsumer = fanoutconsumer.NewProfiles(consumers)
+			n.mirrorTo(shadows)
+			var tb *metadata.TelemetryBuilder
+			if tb, err = metadata.NewTelemetryBuilder(set.Telemetry); err != nil {
+				break
+			}
+			if g.startFailures != nil {
+				n.dropWhileFailed(tb.PipelineDisabledDroppedItems)
 			}
+			n.rejectWhilePaused(g.pauses.flag(n.pipelineID))
+			n.countOutcomes()
+			n.countWhileClosing(tb.PipelineShutdownLostItems)
+			if set.PipelineConfigs[n.pipelineID].MeasureLatency {
+				n.measureLatency(tb.PipelineLatency)
+			}
+			if set.SinkStats != nil {
+				n.countItems(set.SinkStats.Counter(n.pipelineID))
+			}
+		case *fanOutNode:
+			n.baseConsumer = fanOut(n.pipelineID.Signal(), g.nextConsumers(n.ID()), fanOutClonePolicy(set.PipelineConfigs[n.pipelineID].ClonePolicy))
 		}
 		if err != nil {
 			return err
@@ -374,10 +546,24 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 
 // Find all nodes
 func (g *Graph) nextConsumers(nodeID int64) []baseConsumer {
+	from := g.componentGraph.Node(nodeID)
 	nextNodes := g.componentGraph.From(nodeID)
 	nexts := make([]baseConsumer, 0, nextNodes.Len())
 	for nextNodes.Next() {
-		nexts = append(nexts, nextNodes.Node().(consumerNode).getConsumer())
+		next := nextNodes.Node()
+		nexts = append(nexts, g.edgeConsumer(from, next, next.(consumerNode).getConsumer()))
+	}
+	return nexts
+}
+
+// nextPipelineConsumers returns the consumers of the pipelines the connector emits to, keyed by pipeline.
+func (g *Graph) nextPipelineConsumers(nodeID int64) map[pipeline.ID]baseConsumer {
+	from := g.componentGraph.Node(nodeID)
+	nextNodes := g.componentGraph.From(nodeID)
+	nexts := make(map[pipeline.ID]baseConsumer, nextNodes.Len())
+	for nextNodes.Next() {
+		next := nextNodes.Node().(*capabilitiesNode)
+		nexts[next.pipelineID] = g.edgeConsumer(from, next, next.getConsumer())
 	}
 	return nexts
 }
@@ -411,45 +597,98 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 		return err
 	}
 
+	g.setTenantsHost(host)
+
+	// The feedback buffers are only fed once the receivers are started.
+	if err = g.startFeedback(ctx, host); err != nil {
+		return err
+	}
+
 	// Start in reverse topological order so that downstream components
 	// are started before upstream components. This ensures that each
 	// component's consumer is ready to consume.
 	for i := len(nodes) - 1; i >= 0; i-- {
-		node := nodes[i]
-		comp, ok := node.(component.Component)
-
-		if !ok {
-			// Skip capabilities/fanout nodes
-			continue
+		if err = g.startNode(ctx, host, nodes[i]); err != nil {
+			return err
 		}
+	}
 
-		instanceID := g.instanceIDs[node.ID()]
+	g.restarter.start(host)
+	return nil
+}
+
+// startNode starts the component of the node, if any. It returns an error if the collector must
+// not keep starting, according to the timeouts and start failure policy of the component.
+func (g *Graph) startNode(ctx context.Context, host *Host, node graph.Node) error {
+	comp, ok := node.(component.Component)
+	if !ok {
+		// Skip capabilities/fanout nodes
+		return nil
+	}
+
+	if n, isShadow := node.(*shadowNode); isShadow {
+		// The buffer of a shadow pipeline is not a component with a status.
+		return n.Start(ctx, host)
+	}
+
+	if n, isExporter := node.(*exporterNode); isExporter && n.lazy != nil {
+		// Started when it first receives data.
+		n.lazy.enable(host)
+		return nil
+	}
+
+	instanceID := g.instanceIDs[node.ID()]
+	host.Reporter.ReportStatus(
+		instanceID,
+		componentstatus.NewEvent(componentstatus.StatusStarting),
+	)
+
+	timeouts := g.set.Timeouts.For(instanceID.Kind(), instanceID.ComponentID())
+	compErr := g.lifecycle(node).Call(ctx, timeouts.Start, func(ctx context.Context) error {
+		return comp.Start(ctx, &HostWrapper{Host: host, InstanceID: instanceID})
+	})
+	if compErr != nil {
+		policy := g.startFailures.policy(instanceID)
+		if policy == health.StartFailureRetry {
+			host.Reporter.ReportStatus(instanceID, componentstatus.NewRecoverableErrorEvent(compErr))
+			g.startFailures.failed(host, node, instanceID, compErr)
+			return nil
+		}
 		host.Reporter.ReportStatus(
 			instanceID,
-			componentstatus.NewEvent(componentstatus.StatusStarting),
+			componentstatus.NewPermanentErrorEvent(compErr),
 		)
-
-		if compErr := comp.Start(ctx, &HostWrapper{Host: host, InstanceID: instanceID}); compErr != nil {
-			host.Reporter.ReportStatus(
-				instanceID,
-				componentstatus.NewPermanentErrorEvent(compErr),
+		if policy == health.StartFailureDisable {
+			g.startFailures.failed(host, node, instanceID, compErr)
+			return nil
+		}
+		if errors.Is(compErr, timeout.ErrTimeout) && timeouts.ContinueOnStartTimeout {
+			g.telemetry.Logger.Warn("Component did not start in time, continuing in degraded state",
+				zap.Error(compErr),
+				zap.String("type", instanceID.Kind().String()),
+				zap.String("id", instanceID.ComponentID().String()),
 			)
-			// We log with zap.AddStacktrace(zap.DPanicLevel) to avoid adding the stack trace to the error log
-			g.telemetry.Logger.WithOptions(zap.AddStacktrace(zap.DPanicLevel)).
-				Error("Failed to start component",
-					zap.Error(compErr),
-					zap.String("type", instanceID.Kind().String()),
-					zap.String("id", instanceID.ComponentID().String()),
-				)
-			return fmt.Errorf("failed to start %q %s: %w", instanceID.ComponentID().String(), strings.ToLower(instanceID.Kind().String()), compErr)
+			return nil
 		}
-
-		host.Reporter.ReportOKIfStarting(instanceID)
+		// We log with zap.AddStacktrace(zap.DPanicLevel) to avoid adding the stack trace to the error log
+		g.telemetry.Logger.WithOptions(zap.AddStacktrace(zap.DPanicLevel)).
+			Error("Failed to start component",
+				zap.Error(compErr),
+				zap.String("type", instanceID.Kind().String()),
+				zap.String("id", instanceID.ComponentID().String()),
+			)
+		return fmt.Errorf("failed to start %q %s: %w", instanceID.ComponentID().String(), strings.ToLower(instanceID.Kind().String()), compErr)
 	}
+
+	host.Reporter.ReportOKIfStarting(instanceID)
 	return nil
 }
 
 func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error {
+	// Make sure no component is being restarted while shutting down.
+	g.restarter.stop()
+	g.startFailures.stop()
+
 	nodes, err := topo.Sort(g.componentGraph)
 	if err != nil {
 		return err
@@ -459,39 +698,92 @@ func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error
 	// are stopped before downstream components.  This ensures
 	// that each component has a chance to drain to its consumer
 	// before the consumer is stopped.
+	g.sortForShutdown(nodes)
 	var errs error
 	for i := range nodes {
-		node := nodes[i]
-		comp, ok := node.(component.Component)
-
-		if !ok {
-			// Skip capabilities/fanout nodes
-			continue
+		g.markClosing(nodes[i])
+		errs = multierr.Append(errs, g.shutdownNode(ctx, reporter, nodes[i]))
+		// The components of the tenants are shut down before the components of the pipeline.
+		if n, ok := nodes[i].(*capabilitiesNode); ok {
+			errs = multierr.Append(errs, n.tenants.shutdown(ctx))
 		}
+	}
+	return errs
+}
 
-		instanceID := g.instanceIDs[node.ID()]
-		reporter.ReportStatus(
-			instanceID,
-			componentstatus.NewEvent(componentstatus.StatusStopping),
-		)
+// shutdownNode shuts down the component of the node, if any.
+func (g *Graph) shutdownNode(ctx context.Context, reporter status.Reporter, node graph.Node) error {
+	comp, ok := node.(component.Component)
+	if !ok {
+		// Skip capabilities/fanout nodes
+		return nil
+	}
 
-		if compErr := comp.Shutdown(ctx); compErr != nil {
-			errs = multierr.Append(errs, compErr)
-			reporter.ReportStatus(
-				instanceID,
-				componentstatus.NewPermanentErrorEvent(compErr),
-			)
-			continue
-		}
+	if n, isShadow := node.(*shadowNode); isShadow {
+		return n.Shutdown(ctx)
+	}
 
+	if n, isExporter := node.(*exporterNode); isExporter && n.lazy != nil && !n.lazy.stop() {
+		// The exporter was never started, so there is no status to report.
+		return comp.Shutdown(ctx)
+	}
+
+	var errs error
+	if n, isConnector := node.(*connectorNode); isConnector {
+		errs = multierr.Append(errs, g.shutdownFeedback(ctx, n))
+	}
+
+	instanceID := g.instanceIDs[node.ID()]
+	reporter.ReportStatus(
+		instanceID,
+		componentstatus.NewEvent(componentstatus.StatusStopping),
+	)
+
+	shutdownTimeout := g.set.Timeouts.For(instanceID.Kind(), instanceID.ComponentID()).Shutdown
+	if deadline := g.drainDeadline(node); deadline > 0 && (shutdownTimeout <= 0 || deadline < shutdownTimeout) {
+		shutdownTimeout = deadline
+	}
+	if compErr := g.lifecycle(node).Call(ctx, shutdownTimeout, comp.Shutdown); compErr != nil {
+		if errors.Is(compErr, timeout.ErrTimeout) || errors.Is(compErr, timeout.ErrAbandoned) {
+			compErr = fmt.Errorf("failed to shutdown %q %s: %w", instanceID.ComponentID().String(), strings.ToLower(instanceID.Kind().String()), compErr)
+		}
 		reporter.ReportStatus(
 			instanceID,
-			componentstatus.NewEvent(componentstatus.StatusStopped),
+			componentstatus.NewPermanentErrorEvent(compErr),
 		)
+		return multierr.Append(errs, compErr)
 	}
+
+	reporter.ReportStatus(
+		instanceID,
+		componentstatus.NewEvent(componentstatus.StatusStopped),
+	)
 	return errs
 }
 
+// lifecycle returns the guard of the lifecycle calls made to the component of the node, so that
+// it is not shut down while its start, which timed out, is still running.
+func (g *Graph) lifecycle(node graph.Node) *timeout.Guard {
+	g.lifecyclesMu.Lock()
+	defer g.lifecyclesMu.Unlock()
+	if g.lifecycles == nil {
+		g.lifecycles = make(map[graph.Node]*timeout.Guard)
+	}
+	guard, ok := g.lifecycles[node]
+	if !ok {
+		guard = &timeout.Guard{}
+		g.lifecycles[node] = guard
+	}
+	return guard
+}
+
+// forgetLifecycle discards the guard of the node, once its component is shut down for good.
+func (g *Graph) forgetLifecycle(node graph.Node) {
+	g.lifecyclesMu.Lock()
+	defer g.lifecyclesMu.Unlock()
+	delete(g.lifecycles, node)
+}
+
 func (g *Graph) GetExporters() map[pipeline.Signal]map[component.ID]component.Component {
 	exportersMap := make(map[pipeline.Signal]map[component.ID]component.Component)
 	exportersMap[pipeline.SignalTraces] = make(map[component.ID]component.Component)
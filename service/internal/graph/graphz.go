@@ -0,0 +1,320 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	otelattr "go.opentelemetry.io/otel/attribute"
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+const (
+	zGraphPath = "graphz"
+
+	// Kinds of the internal nodes
+	capabilitiesKind = "capabilities"
+	fanoutKind       = "fanout"
+	feedbackKind     = "feedback"
+	shadowKind       = "shadow"
+
+	// URL Params
+	zGraphFormat = "format"
+)
+
+// graphNode is a node of the pipeline graph, as rendered by the graphz page.
+type graphNode struct {
+	// ID identifies the node in the edges, it is derived from its attributes.
+	ID string `json:"id"`
+	// Kind is the kind of the component, or the kind of the internal node: capabilities,
+	// fanout, feedback or shadow.
+	Kind         string `json:"kind"`
+	Component    string `json:"component,omitempty"`
+	Signal       string `json:"signal,omitempty"`
+	SignalOutput string `json:"signal_output,omitempty"`
+	// Pipelines are the pipelines the node belongs to.
+	Pipelines []string `json:"pipelines"`
+	// Shared is true if the component instance is shared by several pipelines.
+	Shared bool `json:"shared,omitempty"`
+	// Capabilities are the capabilities of the consumer of the node, nil for the receivers.
+	// The capabilities node of a pipeline has the capabilities aggregated from its processors,
+	// which decide whether the data is cloned when it is fanned out to several pipelines.
+	Capabilities *graphCapabilities `json:"capabilities,omitempty"`
+}
+
+type graphCapabilities struct {
+	MutatesData bool `json:"mutates_data"`
+}
+
+type graphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type pipelineGraph struct {
+	Nodes []graphNode `json:"nodes"`
+	Edges []graphEdge `json:"edges"`
+}
+
+// nodeID returns the ID of a node in the rendered graph.
+func nodeID(node graph.Node) string {
+	return node.(interface{ Set() *otelattr.Set }).Set().Encoded(otelattr.DefaultEncoder())
+}
+
+// describe returns the resolved pipeline graph, sorted so that it renders the same way for the same configuration.
+func (g *Graph) describe() pipelineGraph {
+	pipelinesOf := make(map[int64][]string)
+	for pipelineID, pn := range g.pipelines {
+		add := func(n graph.Node) {
+			pipelinesOf[n.ID()] = append(pipelinesOf[n.ID()], pipelineID.String())
+		}
+		for _, n := range pn.receivers {
+			add(n)
+		}
+		add(pn.capabilitiesNode)
+		for _, n := range pn.processors {
+			add(n)
+		}
+		add(pn.fanOutNode)
+		for _, n := range pn.exporters {
+			add(n)
+		}
+	}
+	for _, n := range g.feedbackNodes {
+		pipelinesOf[n.ID()] = append(pipelinesOf[n.ID()], n.pipelineID.String())
+	}
+	for _, n := range g.shadowNodes {
+		pipelinesOf[n.ID()] = append(pipelinesOf[n.ID()], n.pipelineID.String())
+	}
+
+	var desc pipelineGraph
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		n := nodes.Node()
+		set := n.(interface{ Set() *otelattr.Set }).Set()
+		get := func(key string) string {
+			v, _ := set.Value(otelattr.Key(key))
+			return v.AsString()
+		}
+		pipelines := pipelinesOf[n.ID()]
+		slices.Sort(pipelines)
+		pn, isProcessor := n.(*processorNode)
+		gn := graphNode{
+			ID:           nodeID(n),
+			Kind:         get(componentattribute.ComponentKindKey),
+			Component:    get(componentattribute.ComponentIDKey),
+			Signal:       get(componentattribute.SignalKey),
+			SignalOutput: get(componentattribute.SignalOutputKey),
+			Pipelines:    slices.Compact(pipelines),
+			Shared:       len(slices.Compact(pipelines)) > 1 || (isProcessor && pn.shared != nil),
+		}
+		if cn, ok := n.(consumerNode); ok && cn.getConsumer() != nil {
+			gn.Capabilities = &graphCapabilities{MutatesData: cn.getConsumer().Capabilities().MutatesData}
+		}
+		desc.Nodes = append(desc.Nodes, gn)
+
+		to := g.componentGraph.From(n.ID())
+		for to.Next() {
+			desc.Edges = append(desc.Edges, graphEdge{From: nodeID(n), To: nodeID(to.Node())})
+		}
+	}
+	slices.SortFunc(desc.Nodes, func(a, b graphNode) int { return strings.Compare(a.ID, b.ID) })
+	slices.SortFunc(desc.Edges, func(a, b graphEdge) int {
+		if c := strings.Compare(a.From, b.From); c != 0 {
+			return c
+		}
+		return strings.Compare(a.To, b.To)
+	})
+	return desc
+}
+
+// label returns the label of the node in the DOT graph.
+func (n graphNode) label() string {
+	switch n.Kind {
+	case capabilitiesKind, fanoutKind, shadowKind:
+		return n.Kind + "\n" + n.Pipelines[0]
+	case feedbackKind:
+		return n.Kind + "\n" + strings.Join(n.Pipelines, ", ")
+	}
+	label := n.Kind + "\n" + n.Component
+	if n.SignalOutput != "" {
+		label += "\n" + n.Signal + " → " + n.SignalOutput
+	}
+	if n.Shared {
+		label += "\n(shared)"
+	}
+	if n.Capabilities != nil && n.Capabilities.MutatesData {
+		label += "\n(mutates data)"
+	}
+	return label
+}
+
+var dotShapes = map[string]string{
+	"receiver":       "invhouse",
+	"processor":      "box",
+	"exporter":       "house",
+	"connector":      "diamond",
+	capabilitiesKind: "point",
+	fanoutKind:       "point",
+	feedbackKind:     "cds",
+	shadowKind:       "cds",
+}
+
+// writeDOT writes the graph in the DOT language of Graphviz, which can render it e.g. as SVG.
+func (desc pipelineGraph) writeDOT(w io.Writer) {
+	fmt.Fprintln(w, "digraph pipelines {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	for _, n := range desc.Nodes {
+		fmt.Fprintf(w, "  %q [label=%q, shape=%q];\n", n.ID, n.label(), dotShapes[n.Kind])
+	}
+	for _, e := range desc.Edges {
+		fmt.Fprintf(w, "  %q -> %q;\n", e.From, e.To)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// The dimensions of the SVG rendering, in pixels.
+const (
+	svgMargin     = 20
+	svgNodeWidth  = 200
+	svgLineHeight = 16
+	svgColumnGap  = 60
+	svgRowGap     = 20
+	svgPointSize  = 12
+)
+
+var svgFills = map[string]string{
+	"receiver":   "#dbeafe",
+	"processor":  "#fef3c7",
+	"exporter":   "#dcfce7",
+	"connector":  "#ede9fe",
+	feedbackKind: "#fee2e2",
+	shadowKind:   "#f3f4f6",
+}
+
+// layers assigns each node to the column of the longest path reaching it, so that all the
+// edges go from left to right. Returns the nodes of each column, in the order of desc.Nodes.
+func (desc pipelineGraph) layers() [][]int {
+	index := make(map[string]int, len(desc.Nodes))
+	for i, n := range desc.Nodes {
+		index[n.ID] = i
+	}
+	incoming := make([]int, len(desc.Nodes))
+	outgoing := make([][]int, len(desc.Nodes))
+	for _, e := range desc.Edges {
+		from, to := index[e.From], index[e.To]
+		incoming[to]++
+		outgoing[from] = append(outgoing[from], to)
+	}
+	layer := make([]int, len(desc.Nodes))
+	var ready []int
+	for i := range desc.Nodes {
+		if incoming[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	for len(ready) > 0 {
+		i := ready[0]
+		ready = ready[1:]
+		for _, to := range outgoing[i] {
+			layer[to] = max(layer[to], layer[i]+1)
+			if incoming[to]--; incoming[to] == 0 {
+				ready = append(ready, to)
+			}
+		}
+	}
+	var layers [][]int
+	for i := range desc.Nodes {
+		for len(layers) <= layer[i] {
+			layers = append(layers, nil)
+		}
+		layers[layer[i]] = append(layers[layer[i]], i)
+	}
+	return layers
+}
+
+// writeSVG renders the graph as SVG, laid out in columns from the receivers to the exporters.
+// The internal nodes are drawn as points, labeled by their tooltip.
+func (desc pipelineGraph) writeSVG(w io.Writer) {
+	type box struct{ x, y, width, height int }
+	boxes := make(map[string]box, len(desc.Nodes))
+	width, height := 0, 0
+	for col, nodes := range desc.layers() {
+		x, y := svgMargin+col*(svgNodeWidth+svgColumnGap), svgMargin
+		for _, i := range nodes {
+			n := desc.Nodes[i]
+			b := box{x: x, y: y, width: svgNodeWidth, height: len(strings.Split(n.label(), "\n"))*svgLineHeight + svgLineHeight/2}
+			if _, ok := svgFills[n.Kind]; !ok {
+				b = box{x: x + (svgNodeWidth-svgPointSize)/2, y: y, width: svgPointSize, height: svgPointSize}
+			}
+			boxes[n.ID] = b
+			y += b.height + svgRowGap
+			width = max(width, x+svgNodeWidth+svgMargin)
+		}
+		height = max(height, y-svgRowGap+svgMargin)
+	}
+
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`+"\n", width, height)
+	fmt.Fprintln(w, `<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto"><path d="M0,0 L10,5 L0,10 z"/></marker></defs>`)
+	for _, e := range desc.Edges {
+		from, to := boxes[e.From], boxes[e.To]
+		fmt.Fprintf(w, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black" marker-end="url(#arrow)"/>`+"\n",
+			from.x+from.width, from.y+from.height/2, to.x, to.y+to.height/2)
+	}
+	for _, n := range desc.Nodes {
+		b := boxes[n.ID]
+		lines := strings.Split(n.label(), "\n")
+		fmt.Fprintf(w, "<g><title>%s</title>", template.HTMLEscapeString(strings.Join(lines, " ")))
+		fill, ok := svgFills[n.Kind]
+		if !ok {
+			fmt.Fprintf(w, `<circle cx="%d" cy="%d" r="%d"/></g>`+"\n", b.x+b.width/2, b.y+b.height/2, svgPointSize/2)
+			continue
+		}
+		fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" rx="4" fill="%s" stroke="black"/>`, b.x, b.y, b.width, b.height, fill)
+		for l, line := range lines {
+			fmt.Fprintf(w, `<text x="%d" y="%d" text-anchor="middle">%s</text>`, b.x+b.width/2, b.y+(l+1)*svgLineHeight, template.HTMLEscapeString(line))
+		}
+		fmt.Fprintln(w, "</g>")
+	}
+	fmt.Fprintln(w, "</svg>")
+}
+
+// HandleGraphZPages renders the resolved pipeline graph, as an HTML page or, with the format
+// parameter, in the DOT language, as SVG or as JSON.
+func (g *Graph) HandleGraphZPages(w http.ResponseWriter, r *http.Request) {
+	desc := g.describe()
+	switch r.URL.Query().Get(zGraphFormat) {
+	case "svg":
+		w.Header().Set("Content-Type", "image/svg+xml")
+		desc.writeSVG(w)
+	case "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		desc.writeDOT(w)
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(desc)
+	case "":
+		var dot strings.Builder
+		desc.writeDOT(&dot)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Pipeline Graph"})
+		desc.writeSVG(w)
+		fmt.Fprintf(w, "<p>Download as <a href=\"?%[1]s=svg\">SVG</a>, <a href=\"?%[1]s=dot\">DOT</a>, to render with Graphviz, or as <a href=\"?%[1]s=json\">JSON</a>.</p>\n", zGraphFormat)
+		fmt.Fprintf(w, "<pre>%s</pre>\n", template.HTMLEscapeString(dot.String()))
+		zpages.WriteHTMLPageFooter(w)
+	default:
+		http.Error(w, "unsupported format, must be svg, dot or json", http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestGraphZPages(t *testing.T) {
+	// Two pipelines connected through a connector, see newDeadlineSettings.
+	pg, err := Build(context.Background(), newDeadlineSettings(0, new(consumertest.TracesSink), 0, 0))
+	require.NoError(t, err)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		pg.HandleGraphZPages(rr, httptest.NewRequest(http.MethodGet, "/graphz"+query, http.NoBody))
+		return rr
+	}
+
+	rr := get("?format=json")
+	require.Equal(t, http.StatusOK, rr.Code)
+	var desc pipelineGraph
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &desc))
+
+	kinds := make(map[string]int)
+	ids := make(map[string]bool)
+	for _, n := range desc.Nodes {
+		kinds[n.Kind]++
+		ids[n.ID] = true
+	}
+	assert.Equal(t, map[string]int{
+		"receiver":     1,
+		"processor":    1,
+		"exporter":     1,
+		"connector":    1,
+		"capabilities": 2,
+		"fanout":       2,
+	}, kinds)
+	// receiver -> capabilities -> fanout -> connector -> capabilities -> processor -> fanout -> exporter
+	assert.Len(t, desc.Edges, 7)
+	for _, e := range desc.Edges {
+		assert.True(t, ids[e.From], e.From)
+		assert.True(t, ids[e.To], e.To)
+	}
+	for _, n := range desc.Nodes {
+		if n.Kind == "connector" {
+			assert.Equal(t, []string{"traces/in", "traces/out"}, n.Pipelines)
+			assert.True(t, n.Shared)
+			assert.Equal(t, "traces", n.SignalOutput)
+		}
+		// All the nodes but the receivers consume data and have capabilities.
+		if n.Kind == "receiver" {
+			assert.Nil(t, n.Capabilities)
+		} else {
+			require.NotNil(t, n.Capabilities, n.ID)
+			assert.False(t, n.Capabilities.MutatesData)
+		}
+	}
+	assert.Contains(t, rr.Body.String(), `"mutates_data": false`)
+
+	rr = get("?format=dot")
+	require.Equal(t, http.StatusOK, rr.Code)
+	dot := rr.Body.String()
+	assert.True(t, strings.HasPrefix(dot, "digraph pipelines {\n"))
+	assert.Contains(t, dot, `[label="processor\nstuck", shape="box"];`)
+	assert.Contains(t, dot, `[label="connector\nexampleconnector\ntraces → traces\n(shared)", shape="diamond"];`)
+	assert.Equal(t, 7, strings.Count(dot, " -> "))
+	assert.Equal(t, dot, get("?format=dot").Body.String())
+
+	rr = get("?format=svg")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "image/svg+xml", rr.Header().Get("Content-Type"))
+	svg := rr.Body.String()
+	require.NoError(t, xml.Unmarshal([]byte(svg), new(struct{})))
+	assert.Equal(t, 7, strings.Count(svg, "<line "))
+	assert.Equal(t, 4, strings.Count(svg, "<rect "))
+	assert.Equal(t, 4, strings.Count(svg, "<circle "))
+	assert.Contains(t, svg, `<text x="`)
+	assert.Contains(t, svg, `>exampleconnector</text>`)
+
+	rr = get("")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "<svg ")
+	assert.Contains(t, rr.Body.String(), "digraph pipelines {")
+	assert.Contains(t, rr.Body.String(), `href="?format=json"`)
+
+	assert.Equal(t, http.StatusBadRequest, get("?format=png").Code)
+}
+
+func TestGraphZLayers(t *testing.T) {
+	desc := pipelineGraph{
+		Nodes: []graphNode{{ID: "exporter"}, {ID: "receiver"}, {ID: "processor"}, {ID: "connector"}},
+		Edges: []graphEdge{
+			{From: "receiver", To: "processor"},
+			{From: "processor", To: "exporter"},
+			{From: "receiver", To: "connector"},
+			{From: "connector", To: "exporter"},
+		},
+	}
+	// The nodes are in the column of the longest path reaching them.
+	assert.Equal(t, [][]int{{1}, {2, 3}, {0}}, desc.layers())
+}
@@ -7,11 +7,15 @@ import (
 	"net/http"
 	"path"
 	"runtime"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/hostcapabilities"
@@ -22,10 +26,14 @@ import (
 )
 
 var (
-	_ component.Host                    = (*Host)(nil)
-	_ hostcapabilities.ModuleInfo       = (*Host)(nil)
-	_ hostcapabilities.ExposeExporters  = (*Host)(nil)
-	_ hostcapabilities.ComponentFactory = (*Host)(nil)
+	_ component.Host                           = (*Host)(nil)
+	_ hostcapabilities.ModuleInfo              = (*Host)(nil)
+	_ hostcapabilities.ExposeExporters         = (*Host)(nil)
+	_ hostcapabilities.ComponentFactory        = (*Host)(nil)
+	_ hostcapabilities.ComponentStatusWatchers = (*Host)(nil)
+	_ hostcapabilities.DebugHandlers           = (*Host)(nil)
+	_ hostcapabilities.PipelineIntake          = (*Host)(nil)
+	_ hostcapabilities.Lifecycle               = (*Host)(nil)
 )
 
 type Host struct {
@@ -39,10 +47,20 @@ type Host struct {
 	ModuleInfos moduleinfo.ModuleInfos
 	BuildInfo   component.BuildInfo
 
+	// Pipelines is the graph of the pipelines. It is replaced when the pipelines are reloaded, so once
+	// the host is in use it is read with GetPipelines and replaced with SetPipelines.
 	Pipelines         *Graph
+	pipelinesMu       sync.RWMutex
 	ServiceExtensions *extensions.Extensions
 
-	Reporter status.Reporter
+	Reporter  status.Reporter
+	Lifecycle *status.Lifecycle
+
+	// LogLevels are the levels of the logs of the collector changed by the loglevelz page, if supported
+	// by the logger.
+	LogLevels *componentattribute.LogLevels
+
+	debugHandlers debugHandlers
 }
 
 func (host *Host) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
@@ -76,16 +94,59 @@ func (host *Host) GetModuleInfos() moduleinfo.ModuleInfos {
 // https://github.com/open-telemetry/opentelemetry-collector/pull/7390#issuecomment-1483710184
 // for additional information.
 func (host *Host) GetExporters() map[pipeline.Signal]map[component.ID]component.Component {
-	return host.Pipelines.GetExporters()
+	return host.GetPipelines().GetExporters()
+}
+
+// GetPipelines returns the current graph of the pipelines.
+func (host *Host) GetPipelines() *Graph {
+	host.pipelinesMu.RLock()
+	defer host.pipelinesMu.RUnlock()
+	return host.Pipelines
+}
+
+// SetPipelines replaces the graph of the pipelines, e.g. once they are reloaded.
+func (host *Host) SetPipelines(pg *Graph) {
+	host.pipelinesMu.Lock()
+	defer host.pipelinesMu.Unlock()
+	host.Pipelines = pg
+}
+
+// pipelinesHandler returns the handler calling the given method of the current graph of the
+// pipelines, so that the zPages show the pipelines once they are reloaded.
+func (host *Host) pipelinesHandler(handle func(*Graph, http.ResponseWriter, *http.Request)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handle(host.GetPipelines(), w, r)
+	}
 }
 
 func (host *Host) NotifyComponentStatusChange(source *componentstatus.InstanceID, event *componentstatus.Event) {
 	host.ServiceExtensions.NotifyComponentStatusChange(source, event)
-	if event.Status() == componentstatus.StatusFatalError {
+	// A fatal error doesn't stop the collector if the component is restarted.
+	if event.Status() == componentstatus.StatusFatalError && !host.GetPipelines().willRestart(source) {
 		host.AsyncErrorChannel <- event.Err()
 	}
 }
 
+func (host *Host) WatchComponentStatus(watcher componentstatus.Watcher) func() {
+	return host.Reporter.Watch(watcher)
+}
+
+func (host *Host) LifecycleState() hostcapabilities.LifecycleState {
+	return host.Lifecycle.LifecycleState()
+}
+
+func (host *Host) WatchLifecycleState(fn func(hostcapabilities.LifecycleState)) func() {
+	return host.Lifecycle.WatchLifecycleState(fn)
+}
+
+func (host *Host) IsReceiverPaused(id component.ID, signal pipeline.Signal) bool {
+	return host.GetPipelines().IsReceiverPaused(id, signal)
+}
+
+func (host *Host) RegisterDebugHandler(kind component.Kind, id component.ID, handler http.Handler) (func(), error) {
+	return host.debugHandlers.register(kind, id, handler)
+}
+
 const (
 	// Paths
 	zServicePath   = "servicez"
@@ -109,9 +170,18 @@ func init() {
 
 func (host *Host) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, zServicePath), host.zPagesRequest)
-	mux.HandleFunc(path.Join(pathPrefix, zPipelinePath), host.Pipelines.HandleZPages)
+	mux.HandleFunc(path.Join(pathPrefix, zPipelinePath), host.pipelinesHandler((*Graph).HandleZPages))
+	mux.HandleFunc(path.Join(pathPrefix, zGraphPath), host.pipelinesHandler((*Graph).HandleGraphZPages))
+	mux.HandleFunc(path.Join(pathPrefix, zTapPath), host.pipelinesHandler((*Graph).HandleTapZPages))
+	mux.HandleFunc(path.Join(pathPrefix, zPausePath), host.pipelinesHandler((*Graph).HandlePauseZPages))
+	mux.HandleFunc(path.Join(pathPrefix, zStatsPath), host.pipelinesHandler((*Graph).HandleStatsZPages))
 	mux.HandleFunc(path.Join(pathPrefix, zExtensionPath), host.ServiceExtensions.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), handleFeaturezRequest)
+	if host.LogLevels != nil {
+		mux.HandleFunc(path.Join(pathPrefix, zLogLevelPath), host.handleLogLevelZPages)
+	}
+	mux.HandleFunc(path.Join(pathPrefix, zComponentDebugPath), host.debugHandlers.handleZPages)
+	mux.Handle(path.Join(pathPrefix, zComponentDebugPath)+"/", host.debugHandlers.handler(path.Join(pathPrefix, zComponentDebugPath)))
 }
 
 func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
@@ -119,11 +189,32 @@ func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Service " + host.BuildInfo.Command})
 	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Build Info", Properties: getBuildInfoProperties(host.BuildInfo)})
 	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Runtime Info", Properties: runtimeInfoVar})
+	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Component Modules", Properties: host.getComponentModuleProperties()})
 	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 		Name:              "Pipelines",
 		ComponentEndpoint: zPipelinePath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Pipeline Graph",
+		ComponentEndpoint: zGraphPath,
+		Link:              true,
+	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Data Taps",
+		ComponentEndpoint: zTapPath,
+		Link:              true,
+	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Pipeline Intake",
+		ComponentEndpoint: zPausePath,
+		Link:              true,
+	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Pipeline Statistics",
+		ComponentEndpoint: zStatsPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 		Name:              "Extensions",
 		ComponentEndpoint: zExtensionPath,
@@ -134,6 +225,18 @@ func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
 		ComponentEndpoint: zFeaturePath,
 		Link:              true,
 	})
+	if host.LogLevels != nil {
+		zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+			Name:              "Log Levels",
+			ComponentEndpoint: zLogLevelPath,
+			Link:              true,
+		})
+	}
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Components",
+		ComponentEndpoint: zComponentDebugPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLPageFooter(w)
 }
 
@@ -160,6 +263,50 @@ func getFeaturesTableData() zpages.FeatureGateTableData {
 	return data
 }
 
+// getComponentModuleProperties returns the Go module of each type of component in use.
+func (host *Host) getComponentModuleProperties() [][2]string {
+	type kindType struct {
+		kind component.Kind
+		typ  component.Type
+	}
+	used := make(map[kindType]bool)
+	if pg := host.GetPipelines(); pg != nil {
+		for _, instanceID := range pg.instanceIDs {
+			used[kindType{kind: instanceID.Kind(), typ: instanceID.ComponentID().Type()}] = true
+		}
+	}
+	if host.ServiceExtensions != nil {
+		for id := range host.ServiceExtensions.GetExtensions() {
+			used[kindType{kind: component.KindExtension, typ: id.Type()}] = true
+		}
+	}
+
+	props := make([][2]string, 0, len(used))
+	for kt := range used {
+		var infos map[component.Type]moduleinfo.ModuleInfo
+		switch kt.kind {
+		case component.KindReceiver:
+			infos = host.ModuleInfos.Receiver
+		case component.KindProcessor:
+			infos = host.ModuleInfos.Processor
+		case component.KindExporter:
+			infos = host.ModuleInfos.Exporter
+		case component.KindConnector:
+			infos = host.ModuleInfos.Connector
+		case component.KindExtension:
+			infos = host.ModuleInfos.Extension
+		}
+		info := infos[kt.typ]
+		module := info.BuilderRef
+		if info.Path != "" {
+			module = info.Path + " " + info.Version
+		}
+		props = append(props, [2]string{strings.ToLower(kt.kind.String()) + "/" + kt.typ.String(), module})
+	}
+	sort.Slice(props, func(i, j int) bool { return props[i][0] < props[j][0] })
+	return props
+}
+
 func getBuildInfoProperties(buildInfo component.BuildInfo) [][2]string {
 	return [][2]string{
 		{"Command", buildInfo.Command},
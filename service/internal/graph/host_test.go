@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/service/health"
+	"go.opentelemetry.io/collector/service/internal/moduleinfo"
+)
+
+func TestComponentModuleProperties(t *testing.T) {
+	expFactory, _ := newFlakyExporterFactory(func(int64) *failingExporter {
+		return &failingExporter{Consumer: consumertest.NewNop()}
+	})
+	pg, err := Build(context.Background(), newRestartSettings(expFactory, health.RestartConfig{}))
+	require.NoError(t, err)
+
+	host := &Host{
+		Pipelines: pg,
+		ModuleInfos: moduleinfo.ModuleInfos{
+			Receiver: map[component.Type]moduleinfo.ModuleInfo{
+				component.MustNewType("examplereceiver"): {
+					BuilderRef: "example.com/examplereceiver v1.2.3",
+					Path:       "example.com/examplereceiver",
+					Version:    "v1.2.4",
+				},
+			},
+			Exporter: map[component.Type]moduleinfo.ModuleInfo{
+				component.MustNewType("flaky"): {BuilderRef: "example.com/flakyexporter v1.2.3"},
+			},
+			// Not in use.
+			Processor: map[component.Type]moduleinfo.ModuleInfo{
+				component.MustNewType("batch"): {Path: "example.com/batchprocessor", Version: "v1.2.3"},
+			},
+		},
+	}
+	assert.Equal(t, [][2]string{
+		{"exporter/flaky", "example.com/flakyexporter v1.2.3"},
+		{"receiver/examplereceiver", "example.com/examplereceiver v1.2.4"},
+	}, host.getComponentModuleProperties())
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"time"
+
+	otelattr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// measureLatency makes the pipeline record the time from the data entering it until all its exporters
+// exported it. The pipeline requests the delivery confirmation of the data, see xconsumer.ContextWithAck,
+// so that the time the components taking the data over asynchronously, e.g. the exporters with a sending
+// queue, spend on it is included. The data not delivered is not recorded.
+func (n *capabilitiesNode) measureLatency(latency metric.Float64Histogram) {
+	attrs := metric.WithAttributeSet(otelattr.NewSet(otelattr.String(pipelineIDAttrKey, n.pipelineID.String())))
+	measure := func(ctx context.Context, consume func(context.Context) error) error {
+		start := time.Now()
+		ackCtx, ack := xconsumer.ContextWithAck(ctx)
+		err := consume(ackCtx)
+		ack.Release(err)
+		if err != nil {
+			return err
+		}
+		record := func() {
+			if ack.Err() == nil {
+				latency.Record(ctx, time.Since(start).Seconds(), attrs)
+			}
+		}
+		select {
+		case <-ack.Done():
+			record()
+		default:
+			go func() {
+				<-ack.Done()
+				record()
+			}()
+		}
+		return nil
+	}
+	if next := n.ConsumeTracesFunc; next != nil {
+		n.ConsumeTracesFunc = func(ctx context.Context, td ptrace.Traces) error {
+			return measure(ctx, func(ctx context.Context) error { return next(ctx, td) })
+		}
+	}
+	if next := n.ConsumeMetricsFunc; next != nil {
+		n.ConsumeMetricsFunc = func(ctx context.Context, md pmetric.Metrics) error {
+			return measure(ctx, func(ctx context.Context) error { return next(ctx, md) })
+		}
+	}
+	if next := n.ConsumeLogsFunc; next != nil {
+		n.ConsumeLogsFunc = func(ctx context.Context, ld plog.Logs) error {
+			return measure(ctx, func(ctx context.Context) error { return next(ctx, ld) })
+		}
+	}
+	if next := n.ConsumeProfilesFunc; next != nil {
+		n.ConsumeProfilesFunc = func(ctx context.Context, pd pprofile.Profiles) error {
+			return measure(ctx, func(ctx context.Context) error { return next(ctx, pd) })
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+)
+
+func TestMeasureLatency(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	tb, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+
+	// The exporter takes the data over asynchronously, as with a sending queue.
+	holds := make(chan func(error), 3)
+	n := newCapabilitiesNode(pipeline.NewID(pipeline.SignalLogs))
+	n.ConsumeLogsFunc = func(ctx context.Context, _ plog.Logs) error {
+		holds <- xconsumer.HoldAck(ctx)
+		return nil
+	}
+	n.measureLatency(tb.PipelineLatency)
+
+	for range 3 {
+		require.NoError(t, n.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	}
+	latencyCount := func() uint64 {
+		got, err := tel.GetMetric("otelcol.pipeline.latency")
+		if err != nil {
+			return 0
+		}
+		dps := got.Data.(metricdata.Histogram[float64]).DataPoints
+		require.Len(t, dps, 1)
+		assert.Equal(t, attribute.NewSet(attribute.String(pipelineIDAttrKey, "logs")), dps[0].Attributes)
+		return dps[0].Count
+	}
+	assert.Zero(t, latencyCount())
+
+	// Only the data delivered is recorded, once it is.
+	(<-holds)(nil)
+	(<-holds)(errors.New("export failed"))
+	(<-holds)(nil)
+	assert.Eventually(t, func() bool { return latencyCount() == 2 }, time.Second, 10*time.Millisecond)
+}
+
+func TestMeasureLatencyRefused(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	tb, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+
+	n := newCapabilitiesNode(pipeline.NewID(pipeline.SignalLogs))
+	n.ConsumeLogsFunc = func(context.Context, plog.Logs) error { return errors.New("refused") }
+	n.measureLatency(tb.PipelineLatency)
+
+	require.Error(t, n.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	_, err = tel.GetMetric("otelcol.pipeline.latency")
+	require.Error(t, err)
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+)
+
+var errLazyNotStarted = errors.New("component cannot be started outside of the service lifetime")
+
+// lazyStarter starts an exporter when it first receives data instead of when the pipelines are started.
+type lazyStarter struct {
+	node       *exporterNode
+	instanceID *componentstatus.InstanceID
+	logger     *zap.Logger
+
+	started atomic.Bool
+
+	mu      sync.Mutex
+	host    *Host
+	stopped bool
+	err     error
+}
+
+// enable allows the component to be started with the given host from now on.
+func (s *lazyStarter) enable(host *Host) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.host = host
+}
+
+// start starts the component if it is not started yet. It is called before every call
+// to the component, so it must be cheap once the component is started.
+func (s *lazyStarter) start(context.Context) error {
+	if s.started.Load() {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started.Load() {
+		return nil
+	}
+	if s.err != nil {
+		return s.err
+	}
+	if s.host == nil || s.stopped {
+		return errLazyNotStarted
+	}
+
+	s.logger.Info("Starting component on first use",
+		zap.String("type", s.instanceID.Kind().String()),
+		zap.String("id", s.instanceID.ComponentID().String()),
+	)
+	s.host.Reporter.ReportStatus(s.instanceID, componentstatus.NewEvent(componentstatus.StatusStarting))
+	// The context of the call is not used, as it may be cancelled once the data is consumed.
+	if err := s.node.Start(context.Background(), &HostWrapper{Host: s.host, InstanceID: s.instanceID}); err != nil {
+		s.host.Reporter.ReportStatus(s.instanceID, componentstatus.NewPermanentErrorEvent(err))
+		s.err = fmt.Errorf("failed to start %q exporter: %w", s.instanceID.ComponentID().String(), err)
+		return s.err
+	}
+	s.host.Reporter.ReportOKIfStarting(s.instanceID)
+	s.started.Store(true)
+	return nil
+}
+
+// stop prevents the component from being started from now on,
+// and returns whether it was started.
+func (s *lazyStarter) stop() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	return s.started.Load()
+}
+
+// restarted records that the component was recreated and started.
+func (s *lazyStarter) restarted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = nil
+	s.started.Store(true)
+}
+
+// markLazyExporters sets up the lazy start of the given exporters.
+func (g *Graph) markLazyExporters(ids []component.ID) error {
+	lazy := make(map[component.ID]bool, len(ids))
+	for _, id := range ids {
+		lazy[id] = true
+	}
+
+	used := make(map[component.ID]bool, len(ids))
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		n, ok := nodes.Node().(*exporterNode)
+		if !ok || !lazy[n.componentID] {
+			continue
+		}
+		n.lazy = &lazyStarter{
+			node:       n,
+			instanceID: g.instanceIDs[n.ID()],
+			logger:     g.telemetry.Logger,
+		}
+		used[n.componentID] = true
+	}
+
+	for _, id := range ids {
+		if !used[id] {
+			return fmt.Errorf("lazy exporter %q is not used by any pipeline", id)
+		}
+	}
+	return nil
+}
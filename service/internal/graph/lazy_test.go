@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/health"
+	"go.opentelemetry.io/collector/service/internal/status"
+)
+
+func TestLazyExporter(t *testing.T) {
+	var starts, shutdowns int
+	expFactory, _ := newFlakyExporterFactory(func(int64) *failingExporter {
+		return &failingExporter{
+			StartFunc: func(context.Context, component.Host) error {
+				starts++
+				return nil
+			},
+			ShutdownFunc: func(context.Context) error {
+				shutdowns++
+				return nil
+			},
+			Consumer: consumertest.NewNop(),
+		}
+	})
+	expID := component.NewID(expFactory.Type())
+
+	set := newRestartSettings(expFactory, health.RestartConfig{})
+	set.LazyExporters = []component.ID{expID}
+
+	rec := &statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)}
+	host := &Host{Reporter: status.NewReporter(rec.record, func(err error) { require.NoError(t, err) })}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	assert.Zero(t, starts)
+	assert.Empty(t, rec.get(expID))
+
+	cons := pg.pipelines[pipeline.NewID(pipeline.SignalTraces)].capabilitiesNode.getConsumer().(consumer.Traces)
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, 1, starts)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusStarting, componentstatus.StatusOK}, rec.get(expID))
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+	assert.Equal(t, 1, shutdowns)
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusStarting,
+		componentstatus.StatusOK,
+		componentstatus.StatusStopping,
+		componentstatus.StatusStopped,
+	}, rec.get(expID))
+}
+
+func TestLazyExporterNeverUsed(t *testing.T) {
+	var starts, shutdowns int
+	expFactory, _ := newFlakyExporterFactory(func(int64) *failingExporter {
+		return &failingExporter{
+			StartFunc: func(context.Context, component.Host) error {
+				starts++
+				return nil
+			},
+			ShutdownFunc: func(context.Context) error {
+				shutdowns++
+				return nil
+			},
+			Consumer: consumertest.NewNop(),
+		}
+	})
+	expID := component.NewID(expFactory.Type())
+
+	set := newRestartSettings(expFactory, health.RestartConfig{})
+	set.LazyExporters = []component.ID{expID}
+
+	rec := &statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)}
+	host := &Host{Reporter: status.NewReporter(rec.record, func(err error) { require.NoError(t, err) })}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+
+	// The exporter is not started once the service is shut down.
+	cons := pg.pipelines[pipeline.NewID(pipeline.SignalTraces)].capabilitiesNode.getConsumer().(consumer.Traces)
+	require.ErrorIs(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)), errLazyNotStarted)
+
+	assert.Zero(t, starts)
+	assert.Equal(t, 1, shutdowns)
+	assert.Empty(t, rec.get(expID))
+}
+
+func TestLazyExporterStartError(t *testing.T) {
+	expFactory, _ := newFlakyExporterFactory(func(int64) *failingExporter {
+		return &failingExporter{
+			StartFunc: func(context.Context, component.Host) error { return assert.AnError },
+			Consumer:  consumertest.NewNop(),
+		}
+	})
+	expID := component.NewID(expFactory.Type())
+
+	set := newRestartSettings(expFactory, health.RestartConfig{})
+	set.LazyExporters = []component.ID{expID}
+
+	rec := &statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)}
+	host := &Host{Reporter: status.NewReporter(rec.record, func(error) {})}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	cons := pg.pipelines[pipeline.NewID(pipeline.SignalTraces)].capabilitiesNode.getConsumer().(consumer.Traces)
+	for range 2 {
+		err = cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+		require.ErrorIs(t, err, assert.AnError)
+		require.ErrorContains(t, err, `failed to start "flaky" exporter`)
+	}
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusStarting, componentstatus.StatusPermanentError}, rec.get(expID))
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestLazyExporterUnused(t *testing.T) {
+	expFactory, _ := newFlakyExporterFactory(func(int64) *failingExporter {
+		return &failingExporter{Consumer: consumertest.NewNop()}
+	})
+
+	set := newRestartSettings(expFactory, health.RestartConfig{})
+	set.LazyExporters = []component.ID{component.MustNewID("unused")}
+	_, err := Build(context.Background(), set)
+	require.EqualError(t, err, `lazy exporter "unused" is not used by any pipeline`)
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/health"
+	"go.opentelemetry.io/collector/service/internal/status"
+)
+
+func TestPipelineMaxConcurrency(t *testing.T) {
+	expType := component.MustNewType("blocking")
+	entered, release := make(chan struct{}, 1), make(chan struct{})
+	expFactory := exporter.NewFactory(expType, func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			cons, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+				entered <- struct{}{}
+				<-release
+				return nil
+			})
+			return struct {
+				component.StartFunc
+				component.ShutdownFunc
+				consumer.Traces
+			}{Traces: cons}, err
+		}, component.StabilityLevelDevelopment))
+	set := newRestartSettings(expFactory, health.RestartConfig{})
+	set.PipelineConfigs[pipeline.NewID(pipeline.SignalTraces)].MaxConcurrency = 1
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	t.Cleanup(func() {
+		assert.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+	})
+
+	cons := pg.pipelines[pipeline.NewID(pipeline.SignalTraces)].capabilitiesNode.getConsumer().(consumer.Traces)
+	done := make(chan error)
+	go func() {
+		done <- cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	}()
+	<-entered
+
+	// The second call waits for the first one to complete.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, cons.ConsumeTraces(ctx, testdata.GenerateTraces(1)), context.DeadlineExceeded)
+
+	close(release)
+	require.NoError(t, <-done)
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	<-entered
+}
@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+const (
+	zLogLevelPath = "loglevelz"
+
+	// Form values
+	zLogLevelComponent = "component"
+	zLogLevelLevel     = "level"
+	zLogLevelTTL       = "ttl"
+	zLogLevelFormat    = "format"
+)
+
+// logComponentKinds are the kinds of the components whose logs can have their own level.
+var logComponentKinds = []component.Kind{
+	component.KindReceiver,
+	component.KindProcessor,
+	component.KindExporter,
+	component.KindConnector,
+	component.KindExtension,
+}
+
+// logLevel is the level of the logs of a component, or of all the logs, as rendered by the loglevelz page.
+type logLevel struct {
+	Component string     `json:"component,omitempty"`
+	Level     string     `json:"level"`
+	Expires   *time.Time `json:"expires,omitempty"`
+}
+
+// checkLogComponent checks the component is empty, for all the logs, or its kind and ID, e.g. "receiver/otlp".
+func checkLogComponent(comp string) error {
+	if comp == "" {
+		return nil
+	}
+	kind, id, ok := strings.Cut(comp, "/")
+	if ok {
+		for _, k := range logComponentKinds {
+			if kind == strings.ToLower(k.String()) {
+				return new(component.ID).UnmarshalText([]byte(id))
+			}
+		}
+	}
+	return fmt.Errorf("invalid component %q, must be <kind>/<id>, e.g. receiver/otlp", comp)
+}
+
+func (host *Host) logLevels() []logLevel {
+	all := host.LogLevels.All()
+	levels := make([]logLevel, 0, len(all))
+	for _, l := range all {
+		level := logLevel{Component: l.Component, Level: l.Level.String()}
+		if !l.Expires.IsZero() {
+			level.Expires = &l.Expires
+		}
+		levels = append(levels, level)
+	}
+	return levels
+}
+
+// handleLogLevelZPages lists the level of all the logs and the levels of the logs of the components overriding
+// it, as an HTML page or, with the format parameter, as JSON. A PUT request with the level, and optionally the
+// component and ttl form values, sets the level of the logs of the component, or of all the logs, until the ttl
+// elapsed. A DELETE request with the optional component parameter resets it.
+func (host *Host) handleLogLevelZPages(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodDelete:
+		comp := r.FormValue(zLogLevelComponent)
+		if err := checkLogComponent(comp); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			host.LogLevels.ResetLevel(comp)
+			break
+		}
+		level, err := zapcore.ParseLevel(r.FormValue(zLogLevelLevel))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var ttl time.Duration
+		if v := r.FormValue(zLogLevelTTL); v != "" {
+			if ttl, err = time.ParseDuration(v); err != nil || ttl <= 0 {
+				http.Error(w, fmt.Sprintf("invalid ttl %q, must be a positive duration", v), http.StatusBadRequest)
+				return
+			}
+		}
+		host.LogLevels.SetLevel(comp, level, ttl)
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	levels := host.logLevels()
+	if r.URL.Query().Get(zLogLevelFormat) == "json" || r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(levels)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Log Levels"})
+	props := make([][2]string, 0, len(levels))
+	for _, l := range levels {
+		comp := l.Component
+		if comp == "" {
+			comp = "all the logs"
+		}
+		level := l.Level
+		if l.Expires != nil {
+			level += " until " + l.Expires.UTC().Format(time.RFC3339)
+		}
+		props = append(props, [2]string{comp, level})
+	}
+	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Levels", Properties: props})
+	fmt.Fprintf(w, "<p>PUT the %s, and optionally the %s (e.g. receiver/otlp) and %s (e.g. 10m) form values to set the level "+
+		"of the logs of a component, or of all the logs. DELETE with the optional %s parameter to reset it.</p>\n",
+		zLogLevelLevel, zLogLevelComponent, zLogLevelTTL, zLogLevelComponent)
+	zpages.WriteHTMLPageFooter(w)
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+)
+
+func TestLogLevelZPages(t *testing.T) {
+	host := &Host{LogLevels: componentattribute.NewLogLevels(zapcore.InfoLevel, nil)}
+	do := func(method string, values url.Values) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		var req *http.Request
+		if method == http.MethodPut {
+			req = httptest.NewRequest(method, "/loglevelz", strings.NewReader(values.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		} else {
+			req = httptest.NewRequest(method, "/loglevelz?"+values.Encode(), http.NoBody)
+		}
+		host.handleLogLevelZPages(rr, req)
+		return rr
+	}
+
+	rr := do(http.MethodPut, url.Values{zLogLevelComponent: {"receiver/otlp"}, zLogLevelLevel: {"debug"}})
+	require.Equal(t, http.StatusOK, rr.Code)
+	var levels []logLevel
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &levels))
+	assert.Equal(t, []logLevel{{Level: "info"}, {Component: "receiver/otlp", Level: "debug"}}, levels)
+	assert.Equal(t, zapcore.DebugLevel, host.LogLevels.Level("receiver/otlp"))
+
+	rr = do(http.MethodPut, url.Values{zLogLevelLevel: {"warn"}, zLogLevelTTL: {"1h"}})
+	require.Equal(t, http.StatusOK, rr.Code)
+	levels = nil
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &levels))
+	require.Len(t, levels, 2)
+	assert.Equal(t, "warn", levels[0].Level)
+	assert.NotNil(t, levels[0].Expires)
+
+	rr = do(http.MethodGet, nil)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "receiver/otlp")
+	assert.Contains(t, rr.Body.String(), "warn until ")
+
+	require.Equal(t, http.StatusOK, do(http.MethodDelete, url.Values{zLogLevelComponent: {"receiver/otlp"}}).Code)
+	require.Equal(t, http.StatusOK, do(http.MethodDelete, nil).Code)
+	assert.Equal(t, []componentattribute.LogLevel{{Level: zapcore.InfoLevel}}, host.LogLevels.All())
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, url.Values{zLogLevelLevel: {"verbose"}}).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, url.Values{zLogLevelComponent: {"otlp"}, zLogLevelLevel: {"debug"}}).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, url.Values{zLogLevelComponent: {"pipeline/traces"}, zLogLevelLevel: {"debug"}}).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, url.Values{zLogLevelLevel: {"debug"}, zLogLevelTTL: {"-1m"}}).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, do(http.MethodPost, nil).Code)
+}
@@ -0,0 +1,194 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/attribute"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+const (
+	zPausePath = "pausez"
+
+	// Form values
+	zPausePipeline = "pipeline"
+	zPauseFormat   = "format"
+)
+
+// pauses holds whether the intake of each pipeline is paused. It is kept when the graph is
+// reconciled, so that the pipelines paused by an operator stay paused after a reload.
+type pauses struct {
+	mu    sync.Mutex
+	flags map[pipeline.ID]*atomic.Bool
+}
+
+func newPauses() *pauses {
+	return &pauses{flags: make(map[pipeline.ID]*atomic.Bool)}
+}
+
+// flag returns the flag set while the intake of the pipeline is paused.
+func (p *pauses) flag(pipelineID pipeline.ID) *atomic.Bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.flags[pipelineID] == nil {
+		p.flags[pipelineID] = new(atomic.Bool)
+	}
+	return p.flags[pipelineID]
+}
+
+// rejectWhilePaused makes the pipeline reject the data entering it while its intake is paused,
+// with a retryable error so that the receivers let their clients retry later.
+func (n *capabilitiesNode) rejectWhilePaused(paused *atomic.Bool) {
+	errPaused := consumererror.NewRetryableError(fmt.Errorf("pipeline %q is paused", n.pipelineID.String()))
+	if next := n.ConsumeTracesFunc; next != nil {
+		n.ConsumeTracesFunc = func(ctx context.Context, td ptrace.Traces) error {
+			if paused.Load() {
+				return errPaused
+			}
+			return next(ctx, td)
+		}
+	}
+	if next := n.ConsumeMetricsFunc; next != nil {
+		n.ConsumeMetricsFunc = func(ctx context.Context, md pmetric.Metrics) error {
+			if paused.Load() {
+				return errPaused
+			}
+			return next(ctx, md)
+		}
+	}
+	if next := n.ConsumeLogsFunc; next != nil {
+		n.ConsumeLogsFunc = func(ctx context.Context, ld plog.Logs) error {
+			if paused.Load() {
+				return errPaused
+			}
+			return next(ctx, ld)
+		}
+	}
+	if next := n.ConsumeProfilesFunc; next != nil {
+		n.ConsumeProfilesFunc = func(ctx context.Context, pd pprofile.Profiles) error {
+			if paused.Load() {
+				return errPaused
+			}
+			return next(ctx, pd)
+		}
+	}
+}
+
+// SetPaused pauses or resumes the intake of the pipeline.
+func (g *Graph) SetPaused(pipelineID pipeline.ID, paused bool) error {
+	if _, ok := g.pipelines[pipelineID]; !ok {
+		return fmt.Errorf("pipeline %q not found", pipelineID.String())
+	}
+	if g.pauses.flag(pipelineID).Swap(paused) != paused {
+		action := "Resumed"
+		if paused {
+			action = "Paused"
+		}
+		g.telemetry.Logger.Info(action + " the intake of the pipeline " + pipelineID.String())
+	}
+	return nil
+}
+
+// SetAllPaused pauses or resumes the intake of all the pipelines.
+func (g *Graph) SetAllPaused(paused bool) {
+	for pipelineID := range g.pipelines {
+		_ = g.SetPaused(pipelineID, paused)
+	}
+}
+
+// IsReceiverPaused reports whether all the pipelines the receiver emits data of the signal to are paused.
+func (g *Graph) IsReceiverPaused(id component.ID, signal pipeline.Signal) bool {
+	nodeID := attribute.Receiver(signal, id).ID()
+	if g.componentGraph.Node(nodeID) == nil {
+		return false
+	}
+	nexts := g.componentGraph.From(nodeID)
+	if nexts.Len() == 0 {
+		return false
+	}
+	for nexts.Next() {
+		if n, ok := nexts.Node().(*capabilitiesNode); !ok || !g.pauses.flag(n.pipelineID).Load() {
+			return false
+		}
+	}
+	return true
+}
+
+// pipelineIntake is the intake state of a pipeline, as rendered by the pausez page.
+type pipelineIntake struct {
+	Pipeline string `json:"pipeline"`
+	Paused   bool   `json:"paused"`
+}
+
+func (g *Graph) intakes() []pipelineIntake {
+	intakes := make([]pipelineIntake, 0, len(g.pipelines))
+	for pipelineID := range g.pipelines {
+		intakes = append(intakes, pipelineIntake{Pipeline: pipelineID.String(), Paused: g.pauses.flag(pipelineID).Load()})
+	}
+	slices.SortFunc(intakes, func(a, b pipelineIntake) int { return strings.Compare(a.Pipeline, b.Pipeline) })
+	return intakes
+}
+
+// HandlePauseZPages lists whether the intake of each pipeline is paused, as an HTML page or, with the
+// format parameter, as JSON. A PUT request with the pipeline form value pauses the intake of a
+// pipeline, and a DELETE request with the pipeline parameter resumes it. POST is not accepted, so
+// that the intake cannot be changed by the forms of other sites.
+func (g *Graph) HandlePauseZPages(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodDelete:
+		var pipelineID pipeline.ID
+		if err := pipelineID.UnmarshalText([]byte(r.FormValue(zPausePipeline))); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := g.SetPaused(pipelineID, r.Method == http.MethodPut); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	intakes := g.intakes()
+	if r.URL.Query().Get(zPauseFormat) == "json" || r.Method != http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(intakes)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Pipeline Intake"})
+	props := make([][2]string, 0, len(intakes))
+	for _, intake := range intakes {
+		state := "running"
+		if intake.Paused {
+			state = "paused"
+		}
+		props = append(props, [2]string{intake.Pipeline, state})
+	}
+	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Pipelines", Properties: props})
+	fmt.Fprintf(w, "<p>PUT the %s form value to pause the intake of a pipeline. DELETE with the %s parameter to resume it.</p>\n",
+		zPausePipeline, zPausePipeline)
+	zpages.WriteHTMLPageFooter(w)
+}
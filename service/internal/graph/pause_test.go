@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+)
+
+func TestPausePipeline(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	set := newDeadlineSettings(0, sink, 0, 0)
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := &Host{Pipelines: pg, Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	defer func() { require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter)) }()
+
+	rcvrID := component.MustNewID("examplereceiver")
+	in := pipeline.NewIDWithName(pipeline.SignalTraces, "in")
+	out := pipeline.NewIDWithName(pipeline.SignalTraces, "out")
+	rcvr := pg.getReceivers()[pipeline.SignalTraces][rcvrID].(*testcomponents.ExampleReceiver)
+
+	pause := func(pipelineID string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/pausez", strings.NewReader(url.Values{zPausePipeline: {pipelineID}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		pg.HandlePauseZPages(rr, req)
+		return rr
+	}
+	resume := func(pipelineID string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		pg.HandlePauseZPages(rr, httptest.NewRequest(http.MethodDelete, "/pausez?"+url.Values{zPausePipeline: {pipelineID}}.Encode(), http.NoBody))
+		return rr
+	}
+
+	// Pausing the downstream pipeline makes the connector fail, but not the receiver intake.
+	require.Equal(t, http.StatusOK, pause(out.String()).Code)
+	assert.False(t, pg.IsReceiverPaused(rcvrID, pipeline.SignalTraces))
+	err = rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	var consumerErr *consumererror.Error
+	require.ErrorAs(t, err, &consumerErr)
+	assert.True(t, consumerErr.IsRetryable())
+
+	rr := pause(in.String())
+	require.Equal(t, http.StatusOK, rr.Code)
+	var intakes []pipelineIntake
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &intakes))
+	assert.Equal(t, []pipelineIntake{{Pipeline: "traces/in", Paused: true}, {Pipeline: "traces/out", Paused: true}}, intakes)
+	assert.True(t, pg.IsReceiverPaused(rcvrID, pipeline.SignalTraces))
+	assert.True(t, host.IsReceiverPaused(rcvrID, pipeline.SignalTraces))
+	assert.False(t, pg.IsReceiverPaused(rcvrID, pipeline.SignalMetrics))
+	err = rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	require.EqualError(t, err, `pipeline "traces/in" is paused`)
+
+	// The pipelines stay paused when the graph is reconciled.
+	next, err := build(context.Background(), set, pg)
+	require.NoError(t, err)
+	assert.True(t, next.IsReceiverPaused(rcvrID, pipeline.SignalTraces))
+
+	require.Equal(t, http.StatusOK, resume(in.String()).Code)
+	require.Equal(t, http.StatusOK, resume(out.String()).Code)
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, sink.AllTraces(), 1)
+
+	assert.Equal(t, http.StatusNotFound, pause("logs").Code)
+	assert.Equal(t, http.StatusBadRequest, resume("").Code)
+
+	rr = httptest.NewRecorder()
+	pg.HandlePauseZPages(rr, httptest.NewRequest(http.MethodGet, "/pausez", http.NoBody))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "traces/in")
+
+	rr = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/pausez", strings.NewReader(url.Values{zPausePipeline: {in.String()}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	pg.HandlePauseZPages(rr, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	assert.False(t, pg.IsReceiverPaused(rcvrID, pipeline.SignalTraces))
+}
@@ -16,21 +16,32 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/service/internal/attribute"
 	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/countconsumer"
 	"go.opentelemetry.io/collector/service/internal/metadata"
 	"go.opentelemetry.io/collector/service/internal/obsconsumer"
 	"go.opentelemetry.io/collector/service/internal/refconsumer"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/statusconsumer"
+	"go.opentelemetry.io/collector/service/internal/usageconsumer"
 )
 
 var _ consumerNode = (*processorNode)(nil)
 
-// Every processor instance is unique to one pipeline.
+// Every processor node is unique to one pipeline.
 // Therefore, nodeID is derived from "pipeline ID" and "component ID".
+// The nodes of a processor declaring a shared instance all use the same component.
 type processorNode struct {
 	attribute.Attributes
 	componentID component.ID
 	pipelineID  pipeline.ID
 	component.Component
 	consumer baseConsumer
+	usage    *usageconsumer.Usage
+	shared   *sharedProcessor
+
+	// The items consumed and produced by the processor, the difference being the items it dropped.
+	counts   *countconsumer.Counts
+	produced *countconsumer.Counts
 }
 
 func newProcessorNode(pipelineID pipeline.ID, procID component.ID) *processorNode {
@@ -50,6 +61,7 @@ func (n *processorNode) buildComponent(ctx context.Context,
 	info component.BuildInfo,
 	builder *builders.ProcessorBuilder,
 	next baseConsumer,
+	tracker *status.Tracker,
 ) error {
 	set := processor.Settings{
 		ID:                n.componentID,
@@ -73,42 +85,67 @@ func (n *processorNode) buildComponent(ctx context.Context,
 		Logger:      set.Logger,
 	}
 
+	n.produced = new(countconsumer.Counts)
+	next = withCounts(n.pipelineID.Signal(), next, n.produced)
+
+	var produced baseConsumer
+	switch n.pipelineID.Signal() {
+	case pipeline.SignalTraces:
+		produced = obsconsumer.NewTraces(next.(consumer.Traces), producedSettings)
+	case pipeline.SignalMetrics:
+		produced = obsconsumer.NewMetrics(next.(consumer.Metrics), producedSettings)
+	case pipeline.SignalLogs:
+		produced = obsconsumer.NewLogs(next.(consumer.Logs), producedSettings)
+	case xpipeline.SignalProfiles:
+		produced = obsconsumer.NewProfiles(next.(xconsumer.Profiles), producedSettings)
+	default:
+		return fmt.Errorf("error creating processor %q in pipeline %q, data type %q is not supported", set.ID, n.pipelineID.String(), n.pipelineID.Signal())
+	}
+
+	var cons baseConsumer
+	if n.shared != nil {
+		cons, err = n.shared.build(ctx, tel, info, builder, n.pipelineID, produced)
+		n.Component = n.shared
+	} else {
+		n.Component, err = createProcessor(ctx, set, builder, n.pipelineID.Signal(), produced)
+		cons, _ = n.Component.(baseConsumer)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID.String(), err)
+	}
+
 	switch n.pipelineID.Signal() {
 	case pipeline.SignalTraces:
-		n.Component, err = builder.CreateTraces(ctx, set,
-			obsconsumer.NewTraces(next.(consumer.Traces), producedSettings),
-		)
-		if err != nil {
-			return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID.String(), err)
-		}
-		n.consumer = obsconsumer.NewTraces(n.Component.(consumer.Traces), consumedSettings)
+		n.consumer = obsconsumer.NewTraces(statusconsumer.NewTraces(cons.(consumer.Traces), tracker), consumedSettings)
 		n.consumer = refconsumer.NewTraces(n.consumer.(consumer.Traces))
 	case pipeline.SignalMetrics:
-		n.Component, err = builder.CreateMetrics(ctx, set,
-			obsconsumer.NewMetrics(next.(consumer.Metrics), producedSettings))
-		if err != nil {
-			return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID.String(), err)
-		}
-		n.consumer = obsconsumer.NewMetrics(n.Component.(consumer.Metrics), consumedSettings)
+		n.consumer = obsconsumer.NewMetrics(statusconsumer.NewMetrics(cons.(consumer.Metrics), tracker), consumedSettings)
 		n.consumer = refconsumer.NewMetrics(n.consumer.(consumer.Metrics))
 	case pipeline.SignalLogs:
-		n.Component, err = builder.CreateLogs(ctx, set,
-			obsconsumer.NewLogs(next.(consumer.Logs), producedSettings))
-		if err != nil {
-			return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID.String(), err)
-		}
-		n.consumer = obsconsumer.NewLogs(n.Component.(consumer.Logs), consumedSettings)
+		n.consumer = obsconsumer.NewLogs(statusconsumer.NewLogs(cons.(consumer.Logs), tracker), consumedSettings)
 		n.consumer = refconsumer.NewLogs(n.consumer.(consumer.Logs))
 	case xpipeline.SignalProfiles:
-		n.Component, err = builder.CreateProfiles(ctx, set,
-			obsconsumer.NewProfiles(next.(xconsumer.Profiles), producedSettings))
-		if err != nil {
-			return fmt.Errorf("failed to create %q processor, in pipeline %q: %w", set.ID, n.pipelineID.String(), err)
-		}
-		n.consumer = obsconsumer.NewProfiles(n.Component.(xconsumer.Profiles), consumedSettings)
+		n.consumer = obsconsumer.NewProfiles(statusconsumer.NewProfiles(cons.(xconsumer.Profiles), tracker), consumedSettings)
 		n.consumer = refconsumer.NewProfiles(n.consumer.(xconsumer.Profiles))
-	default:
-		return fmt.Errorf("error creating processor %q in pipeline %q, data type %q is not supported", set.ID, n.pipelineID.String(), n.pipelineID.Signal())
 	}
+	n.usage = newUsage(tb, true)
+	n.consumer = withUsage(n.pipelineID.Signal(), n.consumer, n.usage)
+	n.counts = new(countconsumer.Counts)
+	n.consumer = withCounts(n.pipelineID.Signal(), n.consumer, n.counts)
 	return nil
 }
+
+// createProcessor creates the processor of the given signal, sending its data to next.
+func createProcessor(ctx context.Context, set processor.Settings, builder *builders.ProcessorBuilder, signal pipeline.Signal, next baseConsumer) (component.Component, error) {
+	switch signal {
+	case pipeline.SignalTraces:
+		return builder.CreateTraces(ctx, set, next.(consumer.Traces))
+	case pipeline.SignalMetrics:
+		return builder.CreateMetrics(ctx, set, next.(consumer.Metrics))
+	case pipeline.SignalLogs:
+		return builder.CreateLogs(ctx, set, next.(consumer.Logs))
+	case xpipeline.SignalProfiles:
+		return builder.CreateProfiles(ctx, set, next.(xconsumer.Profiles))
+	}
+	return nil, fmt.Errorf("data type %q is not supported", signal)
+}
@@ -17,8 +17,12 @@ import (
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/internal/attribute"
 	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/countconsumer"
 	"go.opentelemetry.io/collector/service/internal/metadata"
 	"go.opentelemetry.io/collector/service/internal/obsconsumer"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/swapconsumer"
+	"go.opentelemetry.io/collector/service/internal/usageconsumer"
 )
 
 // A receiver instance can be shared by multiple pipelines of the same type.
@@ -28,6 +32,12 @@ type receiverNode struct {
 	componentID  component.ID
 	pipelineType pipeline.Signal
 	component.Component
+	usage  *usageconsumer.Usage
+	counts *countconsumer.Counts
+
+	// The consumer the receiver emits to, replaced when the graph is reconciled.
+	next        baseConsumer
+	clonePolicy fanoutconsumer.ClonePolicy
 }
 
 func newReceiverNode(pipelineType pipeline.Signal, recvID component.ID) *receiverNode {
@@ -43,12 +53,15 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 	info component.BuildInfo,
 	builder *builders.ReceiverBuilder,
 	nexts []baseConsumer,
+	tracker *status.Tracker,
 ) error {
 	set := receiver.Settings{
 		ID:                n.componentID,
 		TelemetrySettings: telemetry.WithAttributeSet(tel, *n.Set()),
 		BuildInfo:         info,
 	}
+	// The items refused by the receiver are accounted to its status.
+	set.MeterProvider = tracker.MeterProvider(set.MeterProvider)
 
 	tb, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
 	if err != nil {
@@ -60,42 +73,83 @@ func (n *receiverNode) buildComponent(ctx context.Context,
 		SizeCounter: tb.ReceiverProducedSize,
 		Logger:      set.Logger,
 	}
+	n.usage = newUsage(tb, false)
+	n.counts = new(countconsumer.Counts)
 
+	// The receiver emits to a swapconsumer, so that it can keep running when the graph is reconciled.
 	switch n.pipelineType {
 	case pipeline.SignalTraces:
-		var consumers []consumer.Traces
+		next := swapconsumer.NewTraces(fanOut(n.pipelineType, nexts, n.clonePolicy).(consumer.Traces))
+		n.next = next
+		n.Component, err = builder.CreateTraces(ctx, set,
+			obsconsumer.NewTraces(usageconsumer.NewTraces(countconsumer.NewTraces(next, n.counts), n.usage), producedSettings),
+		)
+	case pipeline.SignalMetrics:
+		next := swapconsumer.NewMetrics(fanOut(n.pipelineType, nexts, n.clonePolicy).(consumer.Metrics))
+		n.next = next
+		n.Component, err = builder.CreateMetrics(ctx, set,
+			obsconsumer.NewMetrics(usageconsumer.NewMetrics(countconsumer.NewMetrics(next, n.counts), n.usage), producedSettings))
+	case pipeline.SignalLogs:
+		next := swapconsumer.NewLogs(fanOut(n.pipelineType, nexts, n.clonePolicy).(consumer.Logs))
+		n.next = next
+		n.Component, err = builder.CreateLogs(ctx, set,
+			obsconsumer.NewLogs(usageconsumer.NewLogs(countconsumer.NewLogs(next, n.counts), n.usage), producedSettings))
+	case xpipeline.SignalProfiles:
+		next := swapconsumer.NewProfiles(fanOut(n.pipelineType, nexts, n.clonePolicy).(xconsumer.Profiles))
+		n.next = next
+		n.Component, err = builder.CreateProfiles(ctx, set,
+			obsconsumer.NewProfiles(usageconsumer.NewProfiles(countconsumer.NewProfiles(next, n.counts), n.usage), producedSettings))
+	default:
+		return fmt.Errorf("error creating receiver %q for data type %q is not supported", set.ID, n.pipelineType)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create %q receiver for data type %q: %w", set.ID, n.pipelineType, err)
+	}
+	return nil
+}
+
+// rewire makes the receiver emit to the given consumers, without restarting it.
+func (n *receiverNode) rewire(nexts []baseConsumer, clonePolicy fanoutconsumer.ClonePolicy) {
+	n.clonePolicy = clonePolicy
+	switch next := n.next.(type) {
+	case *swapconsumer.Traces:
+		next.Store(fanOut(n.pipelineType, nexts, n.clonePolicy).(consumer.Traces))
+	case *swapconsumer.Metrics:
+		next.Store(fanOut(n.pipelineType, nexts, n.clonePolicy).(consumer.Metrics))
+	case *swapconsumer.Logs:
+		next.Store(fanOut(n.pipelineType, nexts, n.clonePolicy).(consumer.Logs))
+	case *swapconsumer.Profiles:
+		next.Store(fanOut(n.pipelineType, nexts, n.clonePolicy).(xconsumer.Profiles))
+	}
+}
+
+// fanOut returns the consumer passing the data of the given signal to all the given consumers.
+func fanOut(signal pipeline.Signal, nexts []baseConsumer, clonePolicy fanoutconsumer.ClonePolicy) baseConsumer {
+	switch signal {
+	case pipeline.SignalTraces:
+		consumers := make([]consumer.Traces, 0, len(nexts))
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Traces))
 		}
-		n.Component, err = builder.CreateTraces(ctx, set,
-			obsconsumer.NewTraces(fanoutconsumer.NewTraces(consumers), producedSettings),
-		)
+		return fanoutconsumer.NewTracesWithClonePolicy(consumers, clonePolicy)
 	case pipeline.SignalMetrics:
-		var consumers []consumer.Metrics
+		consumers := make([]consumer.Metrics, 0, len(nexts))
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Metrics))
 		}
-		n.Component, err = builder.CreateMetrics(ctx, set,
-			obsconsumer.NewMetrics(fanoutconsumer.NewMetrics(consumers), producedSettings))
+		return fanoutconsumer.NewMetricsWithClonePolicy(consumers, clonePolicy)
 	case pipeline.SignalLogs:
-		var consumers []consumer.Logs
+		consumers := make([]consumer.Logs, 0, len(nexts))
 		for _, next := range nexts {
 			consumers = append(consumers, next.(consumer.Logs))
 		}
-		n.Component, err = builder.CreateLogs(ctx, set,
-			obsconsumer.NewLogs(fanoutconsumer.NewLogs(consumers), producedSettings))
+		return fanoutconsumer.NewLogsWithClonePolicy(consumers, clonePolicy)
 	case xpipeline.SignalProfiles:
-		var consumers []xconsumer.Profiles
+		consumers := make([]xconsumer.Profiles, 0, len(nexts))
 		for _, next := range nexts {
 			consumers = append(consumers, next.(xconsumer.Profiles))
 		}
-		n.Component, err = builder.CreateProfiles(ctx, set,
-			obsconsumer.NewProfiles(fanoutconsumer.NewProfiles(consumers), producedSettings))
-	default:
-		return fmt.Errorf("error creating receiver %q for data type %q is not supported", set.ID, n.pipelineType)
-	}
-	if err != nil {
-		return fmt.Errorf("failed to create %q receiver for data type %q: %w", set.ID, n.pipelineType, err)
+		return fanoutconsumer.NewProfilesWithClonePolicy(consumers, clonePolicy)
 	}
 	return nil
 }
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"go.uber.org/zap"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/topo"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Reconcile builds the graph of the given settings from the running graph g, as a diff: the
+// components of g whose configuration, pipelines and downstream components did not change keep
+// running, the added and changed components are started, and the removed and changed components
+// are shut down. The receivers which keep running are rewired to the new pipelines.
+//
+// The receivers which are replaced are shut down first, so that the new receivers can use the same
+// endpoints. The other replaced components are shut down upstream first, once the new components
+// are started and the receivers rewired, so that the data in flight drains through them.
+//
+// The graph g must not be used once Reconcile is called, except for being shut down if Reconcile
+// fails without returning a graph. If the returned graph comes with an error, it must be shut down.
+func (g *Graph) Reconcile(ctx context.Context, host *Host, set Settings) (*Graph, error) {
+	if host == nil {
+		return nil, errors.New("host cannot be nil")
+	}
+	if len(g.feedbackNodes) > 0 || len(set.Feedback) > 0 {
+		return nil, errors.New("cannot reconcile pipelines with feedback edges")
+	}
+
+	// No component of g may be restarted while it is reused or shut down.
+	g.restarter.stop()
+	g.startFailures.stop()
+	g.rebuildMu.Lock()
+	defer g.rebuildMu.Unlock()
+
+	next, err := build(ctx, set, g)
+	if err != nil {
+		return nil, err
+	}
+	prevNodes, err := topo.Sort(g.componentGraph)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := topo.Sort(next.componentGraph)
+	if err != nil {
+		return nil, err
+	}
+
+	var started, stopped int
+	done := make(map[int64]bool, len(prevNodes))
+	shutdown := func(node graph.Node) {
+		if n, ok := node.(*capabilitiesNode); ok {
+			// The pipelines are rebuilt, with new components for their tenants.
+			if shutdownErr := n.tenants.shutdown(ctx); shutdownErr != nil {
+				g.telemetry.Logger.Warn("Failed to shut down tenants replaced by the reconciled pipelines", zap.Error(shutdownErr))
+			}
+			return
+		}
+		if _, isComponent := node.(component.Component); !isComponent || done[node.ID()] || next.reused[node.ID()] != nil {
+			return
+		}
+		done[node.ID()] = true
+		stopped++
+		if shutdownErr := g.shutdownNode(ctx, host.Reporter, node); shutdownErr != nil {
+			g.telemetry.Logger.Warn("Failed to shut down component replaced by the reconciled pipelines", zap.Error(shutdownErr))
+		}
+	}
+	start := func(node graph.Node) error {
+		if _, isComponent := node.(component.Component); !isComponent || next.reused[node.ID()] != nil {
+			return nil
+		}
+		started++
+		return next.startNode(ctx, host, node)
+	}
+
+	// Stop the replaced receivers first, so that no data enters the replaced components anymore.
+	for _, node := range prevNodes {
+		if _, isReceiver := node.(*receiverNode); isReceiver {
+			shutdown(node)
+		}
+	}
+
+	// Start the new components in reverse topological order, except the receivers.
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if _, isReceiver := nodes[i].(*receiverNode); isReceiver {
+			continue
+		}
+		if err = start(nodes[i]); err != nil {
+			for _, node := range prevNodes {
+				shutdown(node)
+			}
+			return next, err
+		}
+	}
+
+	// Rewire the receivers which keep running.
+	next.setTenantsHost(host)
+	for _, node := range nodes {
+		if n, isReceiver := node.(*receiverNode); isReceiver && next.reused[n.ID()] != nil {
+			n.rewire(next.nextConsumers(n.ID()), next.receiverClonePolicy(n.ID()))
+		}
+	}
+
+	// Shut down the replaced components in topological order, so that they drain their data.
+	for _, node := range prevNodes {
+		shutdown(node)
+	}
+
+	// Start the new receivers.
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if _, isReceiver := nodes[i].(*receiverNode); !isReceiver {
+			continue
+		}
+		if err = start(nodes[i]); err != nil {
+			return next, err
+		}
+	}
+
+	g.telemetry.Logger.Info("Pipelines reconciled",
+		zap.Int("kept", len(next.reused)),
+		zap.Int("started", started),
+		zap.Int("stopped", stopped),
+	)
+	next.restarter.start(host)
+	return next, nil
+}
+
+// reuseFrom selects the nodes of the previous graph whose running component can be reused by g.
+func (g *Graph) reuseFrom(prev *Graph) {
+	g.reused = make(map[int64]graph.Node)
+	// The processors, exporters and connectors check the deadlines only if a pipeline has one.
+	if anyDeadline(g.set.PipelineConfigs) != anyDeadline(prev.set.PipelineConfigs) {
+		return
+	}
+	nodes, err := topo.Sort(g.componentGraph)
+	if err != nil {
+		// Reported when building the components.
+		return
+	}
+
+	// A node is reusable if all the nodes it emits to are reusable, except receivers which are rewired.
+	reusable := make(map[int64]bool, len(nodes))
+	for i := len(nodes) - 1; i >= 0; i-- {
+		node := nodes[i]
+		prevNode := prev.componentGraph.Node(node.ID())
+		if prevNode == nil || !g.canReuse(prev, node, prevNode) {
+			continue
+		}
+		if _, isReceiver := node.(*receiverNode); !isReceiver && !g.sameNextNodes(prev, node.ID(), reusable) {
+			continue
+		}
+		reusable[node.ID()] = true
+		if _, isComponent := node.(component.Component); isComponent {
+			g.reused[node.ID()] = prevNode
+			// The running component reports its status with its original instance ID.
+			g.instanceIDs[node.ID()] = prev.instanceIDs[node.ID()]
+		}
+	}
+}
+
+// canReuse returns whether the component of the previous node has the configuration and the
+// pipelines of the node, regardless of the nodes it emits to.
+func (g *Graph) canReuse(prev *Graph, node, prevNode graph.Node) bool {
+	if reflect.TypeOf(node) != reflect.TypeOf(prevNode) {
+		return false
+	}
+	if _, isComponent := node.(component.Component); isComponent {
+		instanceID, prevInstanceID := g.instanceIDs[node.ID()], prev.instanceIDs[node.ID()]
+		if *instanceID != *prevInstanceID || prev.startFailures.isFailed(prevInstanceID) {
+			return false
+		}
+	}
+	switch n := node.(type) {
+	case *receiverNode:
+		return reflect.DeepEqual(g.set.ReceiverBuilder.Config(n.componentID), prev.set.ReceiverBuilder.Config(n.componentID))
+	case *processorNode:
+		return n.shared == nil && prevNode.(*processorNode).shared == nil &&
+			reflect.DeepEqual(g.set.ProcessorBuilder.Config(n.componentID), prev.set.ProcessorBuilder.Config(n.componentID))
+	case *exporterNode:
+		return n.lazy == nil && prevNode.(*exporterNode).lazy == nil &&
+			reflect.DeepEqual(g.set.ExporterBuilder.Config(n.componentID), prev.set.ExporterBuilder.Config(n.componentID))
+	case *connectorNode:
+		return reflect.DeepEqual(g.set.ConnectorBuilder.Config(n.componentID), prev.set.ConnectorBuilder.Config(n.componentID)) &&
+			reflect.DeepEqual(g.set.FanIn[n.componentID], prev.set.FanIn[n.componentID]) &&
+			reflect.DeepEqual(g.set.Buffer[n.componentID], prev.set.Buffer[n.componentID])
+	case *capabilitiesNode, *fanOutNode:
+		return true
+	}
+	return false
+}
+
+// sameNextNodes returns whether the node emits to the same reusable nodes in both graphs.
+func (g *Graph) sameNextNodes(prev *Graph, nodeID int64, reusable map[int64]bool) bool {
+	nexts, prevNexts := g.componentGraph.From(nodeID), prev.componentGraph.From(nodeID)
+	if nexts.Len() != prevNexts.Len() {
+		return false
+	}
+	for nexts.Next() {
+		next := nexts.Node()
+		if !reusable[next.ID()] || !prev.componentGraph.HasEdgeFromTo(nodeID, next.ID()) {
+			return false
+		}
+	}
+	return true
+}
+
+// reuseNode makes the node use the running component of the previous node.
+func reuseNode(node, prev graph.Node) {
+	switch n := node.(type) {
+	case *receiverNode:
+		*n = *prev.(*receiverNode)
+	case *processorNode:
+		*n = *prev.(*processorNode)
+	case *exporterNode:
+		*n = *prev.(*exporterNode)
+	case *connectorNode:
+		*n = *prev.(*connectorNode)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// newReconcileSettings returns the settings of the given pipelines of example components, where
+// the exporters have the given configurations. Each receiver has its own configuration, since the
+// example receivers are shared by configuration.
+func newReconcileSettings(pipes pipelines.Config, expCfgs map[component.ID]component.Config) Settings {
+	rcvrCfgs := make(map[component.ID]component.Config)
+	procCfgs := make(map[component.ID]component.Config)
+	for _, pipe := range pipes {
+		for _, id := range pipe.Receivers {
+			rcvrCfgs[id] = &exampleConfig{}
+		}
+		for _, id := range pipe.Processors {
+			procCfgs[id] = &exampleConfig{}
+		}
+	}
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(rcvrCfgs,
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory}),
+		ProcessorBuilder: builders.NewProcessor(procCfgs,
+			map[component.Type]processor.Factory{testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory}),
+		ExporterBuilder: builders.NewExporter(expCfgs,
+			map[component.Type]exporter.Factory{testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory}),
+		ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{},
+			map[component.Type]connector.Factory{testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory}),
+		PipelineConfigs: pipes,
+	}
+}
+
+type exampleConfig struct {
+	Version int
+}
+
+func (g *Graph) exampleReceiver(id component.ID) *testcomponents.ExampleReceiver {
+	return g.getReceivers()[pipeline.SignalTraces][id].(*testcomponents.ExampleReceiver)
+}
+
+func (g *Graph) exampleExporter(id component.ID) *testcomponents.ExampleExporter {
+	return g.GetExporters()[pipeline.SignalTraces][id].(*testcomponents.ExampleExporter)
+}
+
+func (g *Graph) exampleProcessor(pipelineID pipeline.ID) *testcomponents.ExampleProcessor {
+	return g.pipelines[pipelineID].processors[0].(*processorNode).Component.(*testcomponents.ExampleProcessor)
+}
+
+func TestReconcile(t *testing.T) {
+	rcvrID, rcvr2ID, oldRcvrID, newRcvrID := component.MustNewID("examplereceiver"), component.MustNewIDWithName("examplereceiver", "2"),
+		component.MustNewIDWithName("examplereceiver", "old"), component.MustNewIDWithName("examplereceiver", "new")
+	procID := component.MustNewID("exampleprocessor")
+	expID, exp2ID, oldExpID, newExpID := component.MustNewID("exampleexporter"), component.MustNewIDWithName("exampleexporter", "2"),
+		component.MustNewIDWithName("exampleexporter", "old"), component.MustNewIDWithName("exampleexporter", "new")
+	tracesID, traces2ID := pipeline.NewID(pipeline.SignalTraces), pipeline.NewIDWithName(pipeline.SignalTraces, "2")
+	oldID, newID := pipeline.NewIDWithName(pipeline.SignalTraces, "old"), pipeline.NewIDWithName(pipeline.SignalTraces, "new")
+
+	prevSet := newReconcileSettings(pipelines.Config{
+		tracesID:  {Receivers: []component.ID{rcvrID}, Processors: []component.ID{procID}, Exporters: []component.ID{expID}},
+		traces2ID: {Receivers: []component.ID{rcvr2ID}, Exporters: []component.ID{exp2ID}},
+		oldID:     {Receivers: []component.ID{oldRcvrID}, Exporters: []component.ID{oldExpID}},
+	}, map[component.ID]component.Config{expID: &exampleConfig{}, exp2ID: &exampleConfig{}, oldExpID: &exampleConfig{}})
+	set := newReconcileSettings(pipelines.Config{
+		// Unchanged.
+		tracesID: {Receivers: []component.ID{rcvrID}, Processors: []component.ID{procID}, Exporters: []component.ID{expID}},
+		// A processor is added.
+		traces2ID: {Receivers: []component.ID{rcvr2ID}, Processors: []component.ID{procID}, Exporters: []component.ID{exp2ID}},
+		newID:     {Receivers: []component.ID{newRcvrID}, Exporters: []component.ID{newExpID}},
+	}, map[component.ID]component.Config{expID: &exampleConfig{}, exp2ID: &exampleConfig{}, newExpID: &exampleConfig{}})
+
+	prev, err := Build(context.Background(), prevSet)
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, prev.StartAll(context.Background(), host))
+
+	next, err := prev.Reconcile(context.Background(), host, set)
+	require.NoError(t, err)
+
+	// The unchanged pipeline keeps running.
+	assert.Same(t, prev.exampleReceiver(rcvrID), next.exampleReceiver(rcvrID))
+	assert.Same(t, prev.exampleProcessor(tracesID), next.exampleProcessor(tracesID))
+	assert.Same(t, prev.exampleExporter(expID), next.exampleExporter(expID))
+	assert.False(t, next.exampleReceiver(rcvrID).Stopped())
+
+	// The receiver and the exporter of the changed pipeline keep running, around a new processor.
+	assert.Same(t, prev.exampleReceiver(rcvr2ID), next.exampleReceiver(rcvr2ID))
+	assert.Same(t, prev.exampleExporter(exp2ID), next.exampleExporter(exp2ID))
+	assert.True(t, next.exampleProcessor(traces2ID).Started())
+	assert.False(t, next.exampleReceiver(rcvr2ID).Stopped())
+
+	// The removed pipeline is shut down and the added one is started.
+	assert.True(t, prev.exampleReceiver(oldRcvrID).Stopped())
+	assert.True(t, prev.exampleExporter(oldExpID).Stopped())
+	assert.True(t, next.exampleReceiver(newRcvrID).Started())
+	assert.True(t, next.exampleExporter(newExpID).Started())
+
+	// The data of the rewired receiver goes through the new processor.
+	require.NoError(t, next.exampleReceiver(rcvr2ID).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, next.exampleExporter(exp2ID).Traces, 1)
+
+	require.NoError(t, next.ShutdownAll(context.Background(), host.Reporter))
+	assert.True(t, next.exampleReceiver(rcvrID).Stopped())
+	assert.True(t, next.exampleExporter(exp2ID).Stopped())
+}
+
+func TestReconcileChangedExporter(t *testing.T) {
+	rcvrID, procID, expID := component.MustNewID("examplereceiver"), component.MustNewID("exampleprocessor"), component.MustNewID("exampleexporter")
+	tracesID := pipeline.NewID(pipeline.SignalTraces)
+	pipes := pipelines.Config{
+		tracesID: {Receivers: []component.ID{rcvrID}, Processors: []component.ID{procID}, Exporters: []component.ID{expID}},
+	}
+
+	prev, err := Build(context.Background(), newReconcileSettings(pipes, map[component.ID]component.Config{expID: &exampleConfig{Version: 1}}))
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, prev.StartAll(context.Background(), host))
+
+	next, err := prev.Reconcile(context.Background(), host, newReconcileSettings(pipes, map[component.ID]component.Config{expID: &exampleConfig{Version: 2}}))
+	require.NoError(t, err)
+
+	// The exporter and the components upstream of it are replaced, except the receiver which is rewired.
+	assert.Same(t, prev.exampleReceiver(rcvrID), next.exampleReceiver(rcvrID))
+	assert.NotSame(t, prev.exampleProcessor(tracesID), next.exampleProcessor(tracesID))
+	assert.NotSame(t, prev.exampleExporter(expID), next.exampleExporter(expID))
+	assert.True(t, prev.exampleProcessor(tracesID).Stopped())
+	assert.True(t, prev.exampleExporter(expID).Stopped())
+
+	require.NoError(t, next.exampleReceiver(rcvrID).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Empty(t, prev.exampleExporter(expID).Traces)
+	assert.Len(t, next.exampleExporter(expID).Traces, 1)
+	require.NoError(t, next.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestReconcileFeedback(t *testing.T) {
+	prev, err := Build(context.Background(), newFeedbackSettings(map[component.ID]pipelines.FeedbackConfig{
+		component.MustNewID("exampleconnector"): {
+			Pipelines: []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalTraces, "loop")},
+			Buffer:    pipelines.BufferConfig{QueueSize: 10},
+		},
+	}, new(consumertest.TracesSink)))
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	_, err = prev.Reconcile(context.Background(), host, prev.set)
+	require.EqualError(t, err, "cannot reconcile pipelines with feedback edges")
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/internal/builders"
+)
+
+// ReloadExporters recreates the given exporters from the configurations of the builder, without
+// restarting the other components. Each new exporter is started before the data is routed to it,
+// then the previous exporter is shut down.
+//
+// The exporters reloaded before an error is returned keep their new configuration, so the caller
+// is expected to rebuild the whole graph on error.
+func (g *Graph) ReloadExporters(ctx context.Context, host *Host, builder *builders.ExporterBuilder, ids []component.ID) error {
+	g.rebuildMu.Lock()
+	defer g.rebuildMu.Unlock()
+
+	reload := make(map[component.ID]bool, len(ids))
+	for _, id := range ids {
+		reload[id] = true
+	}
+
+	var nodes []*exporterNode
+	used := make(map[component.ID]bool, len(ids))
+	it := g.componentGraph.Nodes()
+	for it.Next() {
+		n, ok := it.Node().(*exporterNode)
+		if !ok || !reload[n.componentID] {
+			continue
+		}
+		if n.lazy != nil {
+			return fmt.Errorf("cannot reload lazy exporter %q", n.componentID)
+		}
+		nodes = append(nodes, n)
+		used[n.componentID] = true
+	}
+	for _, id := range ids {
+		if !used[id] {
+			return fmt.Errorf("cannot reload exporter %q which is not used by any pipeline", id)
+		}
+	}
+
+	g.set.ExporterBuilder = builder
+	for _, n := range nodes {
+		if err := g.reloadExporter(ctx, host, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Graph) reloadExporter(ctx context.Context, host *Host, n *exporterNode) error {
+	instanceID := g.instanceIDs[n.ID()]
+	logger := g.telemetry.Logger.With(
+		zap.String("type", instanceID.Kind().String()),
+		zap.String("id", instanceID.ComponentID().String()),
+	)
+	logger.Info("Reloading component")
+
+	comp, cons, err := n.createComponent(ctx, g.set.Telemetry, g.set.BuildInfo, g.set.ExporterBuilder, g.set.StatusDetector.Tracker(instanceID))
+	if err != nil {
+		return err
+	}
+	// The components sending data to the exporter rely on its capabilities, which cannot change.
+	if cons.Capabilities() != n.consumer.Capabilities() {
+		return errors.Join(
+			fmt.Errorf("cannot reload %q exporter for data type %q: its capabilities changed", n.componentID, n.pipelineType),
+			comp.Shutdown(ctx),
+		)
+	}
+	if err = comp.Start(ctx, &HostWrapper{Host: host, InstanceID: instanceID}); err != nil {
+		return errors.Join(
+			fmt.Errorf("failed to start %q exporter for data type %q: %w", n.componentID, n.pipelineType, err),
+			comp.Shutdown(ctx),
+		)
+	}
+
+	prev := n.Component
+	n.Component = comp
+	n.setConsumer(cons)
+	if err = prev.Shutdown(ctx); err != nil {
+		logger.Warn("Failed to shut down the previous instance of the component", zap.Error(err))
+	}
+	return nil
+}
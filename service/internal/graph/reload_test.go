@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/health"
+	"go.opentelemetry.io/collector/service/internal/status"
+)
+
+type sinkConsumer struct {
+	consumertest.Consumer
+	sink    *consumertest.TracesSink
+	mutates bool
+}
+
+func (c sinkConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: c.mutates}
+}
+
+func (c sinkConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return c.sink.ConsumeTraces(ctx, td)
+}
+
+// reloadTest records the sinks of the created exporters along with their number of shutdowns.
+type reloadTest struct {
+	mu        sync.Mutex
+	sinks     []*consumertest.TracesSink
+	shutdowns []int
+}
+
+func (rt *reloadTest) exporter(n int64, startErr error, mutates bool) *failingExporter {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	sink := new(consumertest.TracesSink)
+	rt.sinks = append(rt.sinks, sink)
+	rt.shutdowns = append(rt.shutdowns, 0)
+	return &failingExporter{
+		StartFunc: func(context.Context, component.Host) error { return startErr },
+		ShutdownFunc: func(context.Context) error {
+			rt.mu.Lock()
+			defer rt.mu.Unlock()
+			rt.shutdowns[n]++
+			return nil
+		},
+		Consumer: sinkConsumer{Consumer: consumertest.NewNop(), sink: sink, mutates: mutates},
+	}
+}
+
+func TestReloadExporters(t *testing.T) {
+	rt := &reloadTest{}
+	expFactory, created := newFlakyExporterFactory(func(n int64) *failingExporter {
+		return rt.exporter(n, nil, false)
+	})
+	expID := component.NewID(expFactory.Type())
+	set := newRestartSettings(expFactory, health.RestartConfig{})
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	builder := set.ExporterBuilder.WithConfigs(map[component.ID]component.Config{expID: &struct{}{}})
+	require.NoError(t, pg.ReloadExporters(context.Background(), host, builder, []component.ID{expID}))
+	assert.Equal(t, int64(2), created.Load())
+	assert.Equal(t, []int{1, 0}, rt.shutdowns)
+	assert.Same(t, rt.sinks[1], pg.GetExporters()[pipeline.SignalTraces][expID].(*failingExporter).Consumer.(sinkConsumer).sink)
+
+	cons := pg.pipelines[pipeline.NewID(pipeline.SignalTraces)].capabilitiesNode.getConsumer().(consumer.Traces)
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, 0, rt.sinks[0].SpanCount())
+	assert.Equal(t, 1, rt.sinks[1].SpanCount())
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+	assert.Equal(t, []int{1, 1}, rt.shutdowns)
+}
+
+func TestReloadExportersFailure(t *testing.T) {
+	testCases := []struct {
+		name        string
+		startErr    error
+		mutates     bool
+		expectedErr string
+	}{
+		{
+			name:        "start_error",
+			startErr:    assert.AnError,
+			expectedErr: `failed to start "flaky" exporter for data type "traces": ` + assert.AnError.Error(),
+		},
+		{
+			name:        "capabilities_changed",
+			mutates:     true,
+			expectedErr: `cannot reload "flaky" exporter for data type "traces": its capabilities changed`,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &reloadTest{}
+			expFactory, _ := newFlakyExporterFactory(func(n int64) *failingExporter {
+				if n == 0 {
+					return rt.exporter(n, nil, false)
+				}
+				return rt.exporter(n, tt.startErr, tt.mutates)
+			})
+			expID := component.NewID(expFactory.Type())
+			set := newRestartSettings(expFactory, health.RestartConfig{})
+			host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+
+			pg, err := Build(context.Background(), set)
+			require.NoError(t, err)
+			require.NoError(t, pg.StartAll(context.Background(), host))
+
+			err = pg.ReloadExporters(context.Background(), host, set.ExporterBuilder, []component.ID{expID})
+			require.EqualError(t, err, tt.expectedErr)
+			// The new exporter is discarded, and the data is still sent to the previous one.
+			assert.Equal(t, []int{0, 1}, rt.shutdowns)
+			cons := pg.pipelines[pipeline.NewID(pipeline.SignalTraces)].capabilitiesNode.getConsumer().(consumer.Traces)
+			require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+			assert.Equal(t, 1, rt.sinks[0].SpanCount())
+
+			require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+		})
+	}
+}
+
+func TestReloadExportersInvalid(t *testing.T) {
+	expFactory, _ := newFlakyExporterFactory(func(int64) *failingExporter {
+		return &failingExporter{Consumer: consumertest.NewNop()}
+	})
+	expID := component.NewID(expFactory.Type())
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+
+	set := newRestartSettings(expFactory, health.RestartConfig{})
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	err = pg.ReloadExporters(context.Background(), host, set.ExporterBuilder, []component.ID{component.MustNewID("unused")})
+	require.EqualError(t, err, `cannot reload exporter "unused" which is not used by any pipeline`)
+
+	set.LazyExporters = []component.ID{expID}
+	pg, err = Build(context.Background(), set)
+	require.NoError(t, err)
+	err = pg.ReloadExporters(context.Background(), host, set.ExporterBuilder, []component.ID{expID})
+	require.EqualError(t, err, `cannot reload lazy exporter "flaky"`)
+}
@@ -0,0 +1,304 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/service/health"
+)
+
+var _ componentstatus.Watcher = (*restarter)(nil)
+
+// restarter recreates the receivers and exporters that report a permanent or fatal error,
+// according to their health.RestartPolicy.
+type restarter struct {
+	graph *Graph
+
+	// The policies and nodes of the restartable component instances.
+	policies map[*componentstatus.InstanceID]health.RestartPolicy
+	nodes    map[*componentstatus.InstanceID]graph.Node
+
+	mu      sync.Mutex
+	host    *Host
+	unwatch func()
+	// The instances sharing their component with others, such as the receivers created for
+	// several signals with sharedcomponent, which are restarted together.
+	shared   map[*componentstatus.InstanceID][]*componentstatus.InstanceID
+	attempts map[*componentstatus.InstanceID]int
+	// The instances whose restart is scheduled or in progress.
+	pending map[*componentstatus.InstanceID]bool
+	stopped bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// newRestarter validates the restart policies against the graph.
+// It returns nil if no component has a restart policy.
+func newRestarter(g *Graph, set Settings) (*restarter, error) {
+	cfg := set.RestartConfig
+	if len(cfg.Receivers) == 0 && len(cfg.Exporters) == 0 {
+		return nil, nil
+	}
+
+	r := &restarter{
+		graph:    g,
+		policies: make(map[*componentstatus.InstanceID]health.RestartPolicy),
+		nodes:    make(map[*componentstatus.InstanceID]graph.Node),
+		attempts: make(map[*componentstatus.InstanceID]int),
+		pending:  make(map[*componentstatus.InstanceID]bool),
+		stopCh:   make(chan struct{}),
+	}
+
+	used := make(map[component.ID]bool)
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		node := nodes.Node()
+		var policy health.RestartPolicy
+		var ok bool
+		switch n := node.(type) {
+		case *receiverNode:
+			policy, ok = cfg.Receivers[n.componentID]
+		case *exporterNode:
+			policy, ok = cfg.Exporters[n.componentID]
+		}
+		if !ok {
+			continue
+		}
+		instanceID := g.instanceIDs[node.ID()]
+		used[instanceID.ComponentID()] = true
+		r.policies[instanceID] = policy.WithDefaults()
+		r.nodes[instanceID] = node
+	}
+
+	for id := range cfg.Receivers {
+		if !used[id] {
+			return nil, fmt.Errorf("restart policy references receiver %q which is not used by any pipeline", id)
+		}
+	}
+	for id := range cfg.Exporters {
+		if !used[id] {
+			return nil, fmt.Errorf("restart policy references exporter %q which is not used by any pipeline", id)
+		}
+	}
+	return r, nil
+}
+
+// start begins watching the status of the components. It must be called once all
+// the components are started.
+func (r *restarter) start(host *Host) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.host = host
+	r.shared = r.sharedInstances()
+	r.mu.Unlock()
+	unwatch := host.Reporter.Watch(r)
+	r.mu.Lock()
+	r.unwatch = unwatch
+	r.mu.Unlock()
+}
+
+// sharedInstances returns the receiver instances of the same component ID which share
+// their component, by instance.
+func (r *restarter) sharedInstances() map[*componentstatus.InstanceID][]*componentstatus.InstanceID {
+	byComponentID := make(map[component.ID][]*componentstatus.InstanceID)
+	for instanceID, node := range r.nodes {
+		if _, ok := node.(*receiverNode); ok {
+			byComponentID[instanceID.ComponentID()] = append(byComponentID[instanceID.ComponentID()], instanceID)
+		}
+	}
+	shared := make(map[*componentstatus.InstanceID][]*componentstatus.InstanceID)
+	for _, instanceIDs := range byComponentID {
+		for _, instanceID := range instanceIDs {
+			comp := r.nodes[instanceID].(*receiverNode).Component
+			if comp == nil || !reflect.TypeOf(comp).Comparable() {
+				continue
+			}
+			for _, other := range instanceIDs {
+				if other != instanceID && r.nodes[other].(*receiverNode).Component == comp {
+					shared[instanceID] = append(shared[instanceID], other)
+				}
+			}
+		}
+	}
+	return shared
+}
+
+// stop cancels the pending restarts and waits for the ones in progress to complete.
+func (r *restarter) stop() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	if r.stopped {
+		r.mu.Unlock()
+		return
+	}
+	r.stopped = true
+	close(r.stopCh)
+	unwatch := r.unwatch
+	r.mu.Unlock()
+
+	if unwatch != nil {
+		unwatch()
+	}
+	r.wg.Wait()
+}
+
+// willRestart returns whether the instance is restarted after it reports a fatal error.
+func (g *Graph) willRestart(instanceID *componentstatus.InstanceID) bool {
+	if g == nil {
+		return false
+	}
+	return g.restarter.willRestart(instanceID)
+}
+
+func (r *restarter) willRestart(instanceID *componentstatus.InstanceID) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	policy, ok := r.policies[instanceID]
+	return ok && !r.stopped && (r.pending[instanceID] || r.attempts[instanceID] < policy.MaxRetries)
+}
+
+// ComponentStatusChanged is called by the status reporter while it notifies the status
+// changes in order, so restarts are performed asynchronously. A component shared by several instances
+// reports its status to all of them, and is restarted once for all of them.
+func (r *restarter) ComponentStatusChanged(instanceID *componentstatus.InstanceID, event *componentstatus.Event) {
+	if event.Status() != componentstatus.StatusPermanentError && event.Status() != componentstatus.StatusFatalError {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	policy, ok := r.policies[instanceID]
+	if !ok || r.host == nil || r.stopped || r.pending[instanceID] {
+		return
+	}
+
+	attempt := r.attempts[instanceID]
+	if attempt >= policy.MaxRetries {
+		r.graph.telemetry.Logger.Error("Component restart retries exhausted",
+			zap.Error(event.Err()),
+			zap.String("type", instanceID.Kind().String()),
+			zap.String("id", instanceID.ComponentID().String()),
+			zap.Int("retries", policy.MaxRetries),
+		)
+		return
+	}
+	instanceIDs := append([]*componentstatus.InstanceID{instanceID}, r.shared[instanceID]...)
+	for _, id := range instanceIDs {
+		r.attempts[id] = attempt + 1
+		r.pending[id] = true
+	}
+
+	r.wg.Add(1)
+	go r.restart(instanceIDs, policy.Backoff(attempt), attempt+1)
+}
+
+// restart recreates the component of the instances, the first of which reported the error.
+func (r *restarter) restart(instanceIDs []*componentstatus.InstanceID, backoff time.Duration, attempt int) {
+	defer r.wg.Done()
+
+	timer := time.NewTimer(backoff)
+	select {
+	case <-r.stopCh:
+		timer.Stop()
+		return
+	case <-timer.C:
+	}
+
+	instanceID := instanceIDs[0]
+	logger := r.graph.telemetry.Logger.With(
+		zap.String("type", instanceID.Kind().String()),
+		zap.String("id", instanceID.ComponentID().String()),
+	)
+	logger.Info("Restarting component", zap.Int("attempt", attempt))
+
+	ctx := context.Background()
+	reporter := r.host.Reporter
+
+	r.graph.rebuildMu.Lock()
+	defer r.graph.rebuildMu.Unlock()
+
+	// The instances share their component, so it is only shut down once.
+	for _, id := range instanceIDs {
+		reporter.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusStopping))
+	}
+	timeouts := r.graph.set.Timeouts.For(instanceID.Kind(), instanceID.ComponentID())
+	if err := r.graph.lifecycle(r.nodes[instanceID]).Call(ctx, timeouts.Shutdown, r.nodes[instanceID].(component.Component).Shutdown); err != nil {
+		logger.Warn("Failed to shut down component before restart", zap.Error(err))
+	}
+	for _, id := range instanceIDs {
+		reporter.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusStopped))
+	}
+
+	// The component is recreated, so it starts a new lifecycle. The instances of a shared
+	// component are rebuilt before any is started, so that they share the new component.
+	var err error
+	for _, id := range instanceIDs {
+		reporter.Restart(id)
+		if buildErr := r.graph.rebuild(ctx, r.nodes[id], id); buildErr != nil {
+			err = buildErr
+		}
+	}
+
+	// The errors reported from now on are handled by a new restart.
+	r.mu.Lock()
+	for _, id := range instanceIDs {
+		delete(r.pending, id)
+	}
+	r.mu.Unlock()
+
+	for i := 0; err == nil && i < len(instanceIDs); i++ {
+		node, host := r.nodes[instanceIDs[i]], &HostWrapper{Host: r.host, InstanceID: instanceIDs[i]}
+		err = r.graph.lifecycle(node).Call(ctx, timeouts.Start, func(ctx context.Context) error {
+			return node.(component.Component).Start(ctx, host)
+		})
+	}
+	if err != nil {
+		logger.Error("Failed to restart component", zap.Error(err))
+		for _, id := range instanceIDs {
+			reporter.ReportStatus(id, componentstatus.NewPermanentErrorEvent(err))
+		}
+		return
+	}
+	if n, ok := r.nodes[instanceID].(*exporterNode); ok && n.lazy != nil {
+		n.lazy.restarted()
+	}
+	for _, id := range instanceIDs {
+		reporter.ReportOKIfStarting(id)
+	}
+}
+
+// rebuild recreates the component of a receiver or exporter instance, so that it can be started
+// again: the receivers shared with sharedcomponent, for instance, only start once. The previous
+// receiver is kept if it cannot be recreated, as it is safe to shut it down again.
+func (g *Graph) rebuild(ctx context.Context, node graph.Node, instanceID *componentstatus.InstanceID) error {
+	set := g.set
+	switch n := node.(type) {
+	case *receiverNode:
+		prev := n.Component
+		if err := n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()), set.StatusDetector.Tracker(instanceID)); err != nil {
+			n.Component = prev
+			return err
+		}
+	case *exporterNode:
+		return n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder, set.StatusDetector.Tracker(instanceID))
+	}
+	return nil
+}
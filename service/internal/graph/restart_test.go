@@ -0,0 +1,345 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/health"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// newFlakyExporterFactory returns a factory of exporters built by the given function,
+// which receives the number of exporters created before.
+func newFlakyExporterFactory(create func(n int64) *failingExporter) (exporter.Factory, *atomic.Int64) {
+	created := &atomic.Int64{}
+	return exporter.NewFactory(component.MustNewType("flaky"),
+		func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			return create(created.Add(1) - 1), nil
+		}, component.StabilityLevelDevelopment),
+	), created
+}
+
+type statusRecorder struct {
+	mu       sync.Mutex
+	statuses map[component.ID][]componentstatus.Status
+}
+
+func (r *statusRecorder) record(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statuses[id.ComponentID()] = append(r.statuses[id.ComponentID()], ev.Status())
+}
+
+func (r *statusRecorder) get(id component.ID) []componentstatus.Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]componentstatus.Status(nil), r.statuses[id]...)
+}
+
+func newRestartSettings(expFactory exporter.Factory, restart health.RestartConfig) Settings {
+	rcvrID := component.MustNewID("examplereceiver")
+	expID := component.NewID(expFactory.Type())
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{expID: &struct{}{}},
+			map[component.Type]exporter.Factory{expID.Type(): expFactory},
+		),
+		ConnectorBuilder: builders.NewConnector(nil, nil),
+		PipelineConfigs: pipelines.Config{
+			pipeline.NewID(pipeline.SignalTraces): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+		},
+		RestartConfig: restart,
+	}
+}
+
+func TestRestartAfterFatalError(t *testing.T) {
+	expFactory, created := newFlakyExporterFactory(func(n int64) *failingExporter {
+		if n == 0 {
+			return &failingExporter{
+				StartFunc: func(_ context.Context, host component.Host) error {
+					componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(assert.AnError))
+					return nil
+				},
+				Consumer: consumertest.NewErr(assert.AnError),
+			}
+		}
+		return &failingExporter{Consumer: consumertest.NewNop()}
+	})
+	expID := component.NewID(expFactory.Type())
+
+	set := newRestartSettings(expFactory, health.RestartConfig{
+		Exporters: map[component.ID]health.RestartPolicy{
+			expID: {MaxRetries: 1, InitialInterval: time.Millisecond},
+		},
+	})
+
+	rec := &statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)}
+	host := &Host{AsyncErrorChannel: make(chan error, 1)}
+	var pg *Graph
+	host.Reporter = status.NewReporter(func(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+		rec.record(id, ev)
+		if ev.Status() == componentstatus.StatusFatalError && !pg.willRestart(id) {
+			host.AsyncErrorChannel <- ev.Err()
+		}
+	}, func(error) {})
+
+	var err error
+	pg, err = Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	assert.Eventually(t, func() bool {
+		statuses := rec.get(expID)
+		return statuses[len(statuses)-1] == componentstatus.StatusOK
+	}, 5*time.Second, time.Millisecond)
+	assert.Equal(t, int64(2), created.Load())
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusStarting,
+		componentstatus.StatusFatalError,
+		componentstatus.StatusStarting,
+		componentstatus.StatusOK,
+	}, rec.get(expID))
+	assert.Empty(t, host.AsyncErrorChannel)
+
+	// Data is sent to the recreated exporter.
+	cons := pg.pipelines[pipeline.NewID(pipeline.SignalTraces)].capabilitiesNode.getConsumer().(consumer.Traces)
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestRestartRetriesExhausted(t *testing.T) {
+	startErr := errors.New("cannot start")
+	expFactory, created := newFlakyExporterFactory(func(n int64) *failingExporter {
+		if n == 0 {
+			return &failingExporter{
+				StartFunc: func(_ context.Context, host component.Host) error {
+					componentstatus.ReportStatus(host, componentstatus.NewPermanentErrorEvent(assert.AnError))
+					return nil
+				},
+				Consumer: consumertest.NewNop(),
+			}
+		}
+		return &failingExporter{
+			StartFunc: func(context.Context, component.Host) error { return startErr },
+			Consumer:  consumertest.NewNop(),
+		}
+	})
+	expID := component.NewID(expFactory.Type())
+
+	set := newRestartSettings(expFactory, health.RestartConfig{
+		Exporters: map[component.ID]health.RestartPolicy{
+			expID: {MaxRetries: 2, InitialInterval: time.Millisecond},
+		},
+	})
+
+	rec := &statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)}
+	host := &Host{Reporter: status.NewReporter(rec.record, func(error) {})}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	assert.Eventually(t, func() bool {
+		return len(rec.get(expID)) == 10
+	}, 5*time.Second, time.Millisecond)
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusStarting,
+		componentstatus.StatusPermanentError,
+		componentstatus.StatusStopping,
+		componentstatus.StatusStopped,
+		componentstatus.StatusStarting,
+		componentstatus.StatusPermanentError,
+		componentstatus.StatusStopping,
+		componentstatus.StatusStopped,
+		componentstatus.StatusStarting,
+		componentstatus.StatusPermanentError,
+	}, rec.get(expID))
+	assert.Equal(t, int64(3), created.Load())
+	for instanceID := range pg.restarter.policies {
+		assert.False(t, pg.willRestart(instanceID))
+	}
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestRestartUnusedComponent(t *testing.T) {
+	expFactory, _ := newFlakyExporterFactory(func(int64) *failingExporter {
+		return &failingExporter{Consumer: consumertest.NewNop()}
+	})
+
+	set := newRestartSettings(expFactory, health.RestartConfig{
+		Exporters: map[component.ID]health.RestartPolicy{
+			component.MustNewID("unused"): {},
+		},
+	})
+	_, err := Build(context.Background(), set)
+	require.EqualError(t, err, `restart policy references exporter "unused" which is not used by any pipeline`)
+
+	set = newRestartSettings(expFactory, health.RestartConfig{
+		Receivers: map[component.ID]health.RestartPolicy{
+			component.MustNewID("unused"): {},
+		},
+	})
+	_, err = Build(context.Background(), set)
+	require.EqualError(t, err, `restart policy references receiver "unused" which is not used by any pipeline`)
+}
+
+// sharedReceiver is a receiver created once for all the signals, like the receivers created
+// with sharedcomponent, which is recreated once it is shut down.
+type sharedReceiver struct {
+	mu       sync.Mutex
+	hosts    []component.Host
+	shutdown bool
+}
+
+func (r *sharedReceiver) Start(_ context.Context, host component.Host) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hosts = append(r.hosts, host)
+	return nil
+}
+
+func (r *sharedReceiver) Shutdown(context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.shutdown = true
+	return nil
+}
+
+// report reports the status to all the instances of the receiver, like sharedcomponent does.
+func (r *sharedReceiver) report(ev *componentstatus.Event) {
+	r.mu.Lock()
+	hosts := append([]component.Host(nil), r.hosts...)
+	r.mu.Unlock()
+	for _, host := range hosts {
+		componentstatus.ReportStatus(host, ev)
+	}
+}
+
+func TestRestartSharedReceiver(t *testing.T) {
+	var mu sync.Mutex
+	var receivers []*sharedReceiver
+	create := func() *sharedReceiver {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(receivers) == 0 || receivers[len(receivers)-1].shutdown {
+			receivers = append(receivers, &sharedReceiver{})
+		}
+		return receivers[len(receivers)-1]
+	}
+	rcvrFactory := receiver.NewFactory(component.MustNewType("shared"),
+		func() component.Config { return &struct{}{} },
+		receiver.WithTraces(func(context.Context, receiver.Settings, component.Config, consumer.Traces) (receiver.Traces, error) {
+			return create(), nil
+		}, component.StabilityLevelDevelopment),
+		receiver.WithMetrics(func(context.Context, receiver.Settings, component.Config, consumer.Metrics) (receiver.Metrics, error) {
+			return create(), nil
+		}, component.StabilityLevelDevelopment),
+	)
+	rcvrID := component.NewID(rcvrFactory.Type())
+	expID := component.NewID(testcomponents.ExampleExporterFactory.Type())
+
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{rcvrID: &struct{}{}},
+			map[component.Type]receiver.Factory{rcvrID.Type(): rcvrFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{expID.Type(): testcomponents.ExampleExporterFactory},
+		),
+		ConnectorBuilder: builders.NewConnector(nil, nil),
+		PipelineConfigs: pipelines.Config{
+			pipeline.NewID(pipeline.SignalTraces): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+			pipeline.NewID(pipeline.SignalMetrics): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+		},
+		RestartConfig: health.RestartConfig{
+			Receivers: map[component.ID]health.RestartPolicy{
+				rcvrID: {MaxRetries: 1, InitialInterval: time.Millisecond},
+			},
+		},
+	}
+
+	rec := &statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)}
+	host := &Host{AsyncErrorChannel: make(chan error, 2)}
+	var pg *Graph
+	host.Reporter = status.NewReporter(func(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+		rec.record(id, ev)
+		if ev.Status() == componentstatus.StatusFatalError && !pg.willRestart(id) {
+			host.AsyncErrorChannel <- ev.Err()
+		}
+	}, func(error) {})
+
+	var err error
+	pg, err = Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	require.Len(t, receivers, 1)
+
+	// The error is reported to both instances, but the receiver is only recreated once.
+	receivers[0].report(componentstatus.NewFatalErrorEvent(assert.AnError))
+	assert.Eventually(t, func() bool {
+		return len(rec.get(rcvrID)) == 10
+	}, 5*time.Second, time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, receivers, 2)
+	assert.True(t, receivers[0].shutdown)
+	assert.Len(t, receivers[1].hosts, 2)
+	counts := make(map[componentstatus.Status]int)
+	for _, st := range rec.get(rcvrID) {
+		counts[st]++
+	}
+	assert.Equal(t, map[componentstatus.Status]int{
+		componentstatus.StatusStarting:   4,
+		componentstatus.StatusFatalError: 2,
+		componentstatus.StatusOK:         4,
+	}, counts)
+	assert.Empty(t, host.AsyncErrorChannel)
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/service/internal/attribute"
+	"go.opentelemetry.io/collector/service/internal/bufferconsumer"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+const defaultShadowQueueSize = 100
+
+var (
+	_ consumerNode        = (*shadowNode)(nil)
+	_ component.Component = (*shadowNode)(nil)
+)
+
+// shadowNode is on the edge from the capabilities node of a primary pipeline to the capabilities
+// node of its shadow pipeline. It copies a sample of the data entering the primary pipeline into
+// a buffer consumed by the shadow pipeline, and never returns an error, so that the shadow pipeline
+// never affects the primary pipeline.
+type shadowNode struct {
+	attribute.Attributes
+	pipelineID pipeline.ID
+	primary    pipeline.ID
+	ratio      float64
+	logger     *zap.Logger
+	buffer     interface {
+		component.Component
+		baseConsumer
+	}
+}
+
+func newShadowNode(pipelineID, primary pipeline.ID) *shadowNode {
+	return &shadowNode{
+		Attributes: attribute.Shadow(pipelineID),
+		pipelineID: pipelineID,
+		primary:    primary,
+	}
+}
+
+func (n *shadowNode) getConsumer() baseConsumer {
+	return n
+}
+
+func (n *shadowNode) Start(ctx context.Context, host component.Host) error {
+	return n.buffer.Start(ctx, host)
+}
+
+func (n *shadowNode) Shutdown(ctx context.Context) error {
+	return n.buffer.Shutdown(ctx)
+}
+
+// buildComponent creates the buffer consumed by the shadow pipeline.
+func (n *shadowNode) buildComponent(cfg pipelines.ShadowConfig, next baseConsumer, logger *zap.Logger) {
+	n.ratio = 1
+	if cfg.SamplingRatio != nil {
+		n.ratio = *cfg.SamplingRatio
+	}
+	n.logger = logger.With(zap.String("pipeline", n.pipelineID.String()), zap.String("primary", n.primary.String()))
+	set := bufferconsumer.Settings{QueueSize: cfg.QueueSize, Logger: n.logger}
+	if set.QueueSize == 0 {
+		set.QueueSize = defaultShadowQueueSize
+	}
+	switch n.pipelineID.Signal() {
+	case pipeline.SignalTraces:
+		n.buffer = bufferconsumer.NewTraces(next.(consumer.Traces), set)
+	case pipeline.SignalMetrics:
+		n.buffer = bufferconsumer.NewMetrics(next.(consumer.Metrics), set)
+	case pipeline.SignalLogs:
+		n.buffer = bufferconsumer.NewLogs(next.(consumer.Logs), set)
+	case xpipeline.SignalProfiles:
+		n.buffer = bufferconsumer.NewProfiles(next.(xconsumer.Profiles), set)
+	}
+}
+
+// Copies are made before queuing, so the primary pipeline can mutate its data.
+func (*shadowNode) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (n *shadowNode) sampled() bool {
+	return n.ratio >= 1 || rand.Float64() < n.ratio // #nosec G404 -- sampling does not need a secure random source
+}
+
+// dropped logs the copies refused by the buffer, which is full when the shadow pipeline lags behind.
+func (n *shadowNode) dropped(err error) {
+	if err != nil {
+		n.logger.Debug("Dropped the copy of the data for the shadow pipeline", zap.Error(err))
+	}
+}
+
+func (n *shadowNode) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if n.sampled() {
+		cp := ptrace.NewTraces()
+		td.CopyTo(cp)
+		n.dropped(n.buffer.(consumer.Traces).ConsumeTraces(ctx, cp))
+	}
+	return nil
+}
+
+func (n *shadowNode) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if n.sampled() {
+		cp := pmetric.NewMetrics()
+		md.CopyTo(cp)
+		n.dropped(n.buffer.(consumer.Metrics).ConsumeMetrics(ctx, cp))
+	}
+	return nil
+}
+
+func (n *shadowNode) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if n.sampled() {
+		cp := plog.NewLogs()
+		ld.CopyTo(cp)
+		n.dropped(n.buffer.(consumer.Logs).ConsumeLogs(ctx, cp))
+	}
+	return nil
+}
+
+func (n *shadowNode) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	if n.sampled() {
+		cp := pprofile.NewProfiles()
+		pd.CopyTo(cp)
+		n.dropped(n.buffer.(xconsumer.Profiles).ConsumeProfiles(ctx, cp))
+	}
+	return nil
+}
+
+// validateShadows checks that the shadow pipelines are the shadows of primary pipelines of the same signal.
+func (g *Graph) validateShadows() error {
+	for pipelineID, cfg := range g.set.PipelineConfigs {
+		if cfg.Shadow == nil {
+			continue
+		}
+		primary, ok := g.set.PipelineConfigs[cfg.Shadow.Of]
+		switch {
+		case !ok:
+			return fmt.Errorf("shadow pipeline %q references pipeline %q which does not exist", pipelineID.String(), cfg.Shadow.Of.String())
+		case cfg.Shadow.Of.Signal() != pipelineID.Signal():
+			return fmt.Errorf("shadow pipeline %q references pipeline %q of another signal", pipelineID.String(), cfg.Shadow.Of.String())
+		case primary.Shadow != nil:
+			return fmt.Errorf("shadow pipeline %q references pipeline %q which is itself a shadow pipeline", pipelineID.String(), cfg.Shadow.Of.String())
+		}
+		// The exporter instances are shared by the pipelines of a signal, so the failures and
+		// latency of an exporter of the shadow pipeline would affect the primary pipeline.
+		for _, exprID := range cfg.Exporters {
+			if slices.Contains(primary.Exporters, exprID) {
+				return fmt.Errorf("shadow pipeline %q shares exporter %q with pipeline %q", pipelineID.String(), exprID.String(), cfg.Shadow.Of.String())
+			}
+		}
+	}
+	return nil
+}
+
+// mirrorTo makes the pipeline pass the data entering it to its shadow pipelines, before its processors.
+func (n *capabilitiesNode) mirrorTo(shadows []baseConsumer) {
+	if len(shadows) == 0 {
+		return
+	}
+	if next := n.ConsumeTracesFunc; next != nil {
+		n.ConsumeTracesFunc = func(ctx context.Context, td ptrace.Traces) error {
+			for _, s := range shadows {
+				_ = s.(consumer.Traces).ConsumeTraces(ctx, td)
+			}
+			return next(ctx, td)
+		}
+	}
+	if next := n.ConsumeMetricsFunc; next != nil {
+		n.ConsumeMetricsFunc = func(ctx context.Context, md pmetric.Metrics) error {
+			for _, s := range shadows {
+				_ = s.(consumer.Metrics).ConsumeMetrics(ctx, md)
+			}
+			return next(ctx, md)
+		}
+	}
+	if next := n.ConsumeLogsFunc; next != nil {
+		n.ConsumeLogsFunc = func(ctx context.Context, ld plog.Logs) error {
+			for _, s := range shadows {
+				_ = s.(consumer.Logs).ConsumeLogs(ctx, ld)
+			}
+			return next(ctx, ld)
+		}
+	}
+	if next := n.ConsumeProfilesFunc; next != nil {
+		n.ConsumeProfilesFunc = func(ctx context.Context, pd pprofile.Profiles) error {
+			for _, s := range shadows {
+				_ = s.(xconsumer.Profiles).ConsumeProfiles(ctx, pd)
+			}
+			return next(ctx, pd)
+		}
+	}
+}
+
+// capabilitiesNexts returns the consumer the capabilities node passes the data of its pipeline to,
+// which is its first processor or its fan-out node, and the consumers of its shadow pipelines.
+func (g *Graph) capabilitiesNexts(n *capabilitiesNode) (baseConsumer, []baseConsumer) {
+	var next baseConsumer
+	var shadows []baseConsumer
+	nextNodes := g.componentGraph.From(n.ID())
+	for nextNodes.Next() {
+		node := nextNodes.Node()
+		cons := g.edgeConsumer(n, node, node.(consumerNode).getConsumer())
+		if _, isShadow := node.(*shadowNode); isShadow {
+			shadows = append(shadows, cons)
+			continue
+		}
+		next = cons
+	}
+	return next, shadows
+}
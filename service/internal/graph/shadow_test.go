@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// newShadowSettings returns the settings of a primary traces pipeline exporting to the given sink,
+// and of its shadow pipeline whose exporter counts its calls and fails.
+func newShadowSettings(sink *consumertest.TracesSink, shadowCalls *atomic.Int64, shadow *pipelines.ShadowConfig) Settings {
+	rcvrID := component.MustNewID("examplereceiver")
+	expID := component.MustNewID("sink")
+	failingID := component.MustNewID("failing")
+	sinkFactory := exporter.NewFactory(expID.Type(), func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			return &sinkExporter{TracesSink: sink}, nil
+		}, component.StabilityLevelDevelopment))
+	failingFactory := exporter.NewFactory(failingID.Type(), func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			cons, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+				shadowCalls.Add(1)
+				return errors.New("canary failure")
+			})
+			return struct {
+				component.StartFunc
+				component.ShutdownFunc
+				consumer.Traces
+			}{Traces: cons}, err
+		}, component.StabilityLevelDevelopment))
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{expID: sinkFactory.CreateDefaultConfig(), failingID: failingFactory.CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{sinkFactory.Type(): sinkFactory, failingFactory.Type(): failingFactory},
+		),
+		ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			pipeline.NewID(pipeline.SignalTraces): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{expID},
+			},
+			pipeline.NewIDWithName(pipeline.SignalTraces, "canary"): {
+				Exporters: []component.ID{failingID},
+				Shadow:    shadow,
+			},
+		},
+	}
+}
+
+func TestShadowPipeline(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		ratio      *float64
+		wantShadow int64
+	}{
+		{name: "all", wantShadow: 10},
+		{name: "none", ratio: new(float64), wantShadow: 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.TracesSink)
+			var shadowCalls atomic.Int64
+			shadow := &pipelines.ShadowConfig{Of: pipeline.NewID(pipeline.SignalTraces), SamplingRatio: tt.ratio, QueueSize: 10}
+			pg, err := Build(context.Background(), newShadowSettings(sink, &shadowCalls, shadow))
+			require.NoError(t, err)
+			host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+			require.NoError(t, pg.StartAll(context.Background(), host))
+
+			rcvr := pg.getReceivers()[pipeline.SignalTraces][component.MustNewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+			for range 10 {
+				// The failures of the shadow pipeline are not reported to the receiver.
+				require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+			}
+			// The shadow pipeline is drained when shut down.
+			require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+			assert.Len(t, sink.AllTraces(), 10)
+			assert.Equal(t, tt.wantShadow, shadowCalls.Load())
+		})
+	}
+}
+
+func TestShadowPipelineCopiesData(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	n := newShadowNode(pipeline.NewIDWithName(pipeline.SignalTraces, "canary"), pipeline.NewID(pipeline.SignalTraces))
+	n.buildComponent(pipelines.ShadowConfig{QueueSize: 1}, sink, componenttest.NewNopTelemetrySettings().Logger)
+
+	td := testdata.GenerateTraces(1)
+	require.NoError(t, n.ConsumeTraces(context.Background(), td))
+	// The buffer is full, the copy is dropped without error.
+	require.NoError(t, n.ConsumeTraces(context.Background(), td))
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("mutated")
+
+	require.NoError(t, n.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, n.Shutdown(context.Background()))
+	require.Len(t, sink.AllTraces(), 1)
+	assert.NotEqual(t, "mutated", sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestShadowPipelineValidation(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		shadow  *pipelines.ShadowConfig
+		wantErr string
+	}{
+		{
+			name:    "missing_primary",
+			shadow:  &pipelines.ShadowConfig{Of: pipeline.NewIDWithName(pipeline.SignalTraces, "missing")},
+			wantErr: `shadow pipeline "traces/canary" references pipeline "traces/missing" which does not exist`,
+		},
+		{
+			name:    "itself",
+			shadow:  &pipelines.ShadowConfig{Of: pipeline.NewIDWithName(pipeline.SignalTraces, "canary")},
+			wantErr: `shadow pipeline "traces/canary" references pipeline "traces/canary" which is itself a shadow pipeline`,
+		},
+		{
+			name:    "other_signal",
+			shadow:  &pipelines.ShadowConfig{Of: pipeline.NewID(pipeline.SignalLogs)},
+			wantErr: `shadow pipeline "traces/canary" references pipeline "logs" of another signal`,
+		},
+		{
+			name:    "shared_exporter",
+			shadow:  &pipelines.ShadowConfig{Of: pipeline.NewID(pipeline.SignalTraces)},
+			wantErr: `shadow pipeline "traces/canary" shares exporter "sink" with pipeline "traces"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			set := newShadowSettings(new(consumertest.TracesSink), new(atomic.Int64), tt.shadow)
+			set.PipelineConfigs[pipeline.NewID(pipeline.SignalLogs)] = &pipelines.PipelineConfig{}
+			canary := set.PipelineConfigs[pipeline.NewIDWithName(pipeline.SignalTraces, "canary")]
+			canary.Exporters = append(canary.Exporters, component.MustNewID("sink"))
+			_, err := Build(context.Background(), set)
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/internal/telemetry"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/service/internal/attribute"
+	"go.opentelemetry.io/collector/service/internal/builders"
+)
+
+var _ component.Component = (*sharedProcessor)(nil)
+
+type sharedProcessorKey struct {
+	componentID component.ID
+	signal      pipeline.Signal
+}
+
+// pipelineIDKey is the context key holding the pipeline calling a shared processor.
+type pipelineIDKey struct{}
+
+// sharedProcessor is a processor instance used by several pipelines of the same signal.
+// Each pipeline keeps its own processorNode, so the telemetry and status of the processor
+// are still reported per pipeline, but all the nodes call the same component. The pipeline
+// is passed through the context, so that the component's next consumer can route the
+// data back to the pipeline it came from.
+type sharedProcessor struct {
+	attribute.Attributes
+	componentID component.ID
+	signal      pipeline.Signal
+
+	// The instances of the pipelines using the processor, to which its status is reported.
+	instanceIDs []*componentstatus.InstanceID
+
+	component component.Component
+	nexts     map[pipeline.ID]baseConsumer
+
+	mu      sync.Mutex
+	started bool
+	// The number of nodes which have not shut the processor down yet.
+	remaining int
+}
+
+func newSharedProcessor(signal pipeline.Signal, procID component.ID) *sharedProcessor {
+	return &sharedProcessor{
+		Attributes:  attribute.SharedProcessor(signal, procID),
+		componentID: procID,
+		signal:      signal,
+		nexts:       make(map[pipeline.ID]baseConsumer),
+	}
+}
+
+// addPipeline records that the processor is used by one more pipeline.
+func (s *sharedProcessor) addPipeline(instanceID *componentstatus.InstanceID) {
+	s.instanceIDs = append(s.instanceIDs, instanceID)
+	s.remaining++
+}
+
+// build creates the component when it is first called, and returns the consumer to use for the
+// given pipeline. The component sends the data of that pipeline to next.
+func (s *sharedProcessor) build(ctx context.Context,
+	tel component.TelemetrySettings,
+	info component.BuildInfo,
+	builder *builders.ProcessorBuilder,
+	pipelineID pipeline.ID,
+	next baseConsumer,
+) (baseConsumer, error) {
+	s.nexts[pipelineID] = next
+	if s.component == nil {
+		set := processor.Settings{
+			ID:                s.componentID,
+			TelemetrySettings: telemetry.WithAttributeSet(tel, *s.Set()),
+			BuildInfo:         info,
+		}
+		comp, err := createProcessor(ctx, set, builder, s.signal, s.router())
+		if err != nil {
+			return nil, err
+		}
+		s.component = comp
+	}
+	return withPipelineID(s.signal, s.component.(baseConsumer), pipelineID), nil
+}
+
+// Start starts the component the first time it is called.
+func (s *sharedProcessor) Start(ctx context.Context, host component.Host) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return nil
+	}
+	s.started = true
+	if hw, ok := host.(*HostWrapper); ok {
+		host = &sharedHostWrapper{HostWrapper: hw, instanceIDs: s.instanceIDs}
+	}
+	return s.component.Start(ctx, host)
+}
+
+// Shutdown shuts the component down once all the pipelines using it have called it,
+// as data may still be sent through the others until then.
+func (s *sharedProcessor) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remaining--
+	if s.remaining != 0 || s.component == nil {
+		return nil
+	}
+	return s.component.Shutdown(ctx)
+}
+
+// sharedHostWrapper reports the status of a shared processor to all the pipelines using it.
+type sharedHostWrapper struct {
+	*HostWrapper
+	instanceIDs []*componentstatus.InstanceID
+}
+
+func (host *sharedHostWrapper) Report(event *componentstatus.Event) {
+	for _, instanceID := range host.instanceIDs {
+		host.Reporter.ReportStatus(instanceID, event)
+	}
+}
+
+// next returns the consumer of the pipeline set in the context.
+func (s *sharedProcessor) next(ctx context.Context) (baseConsumer, error) {
+	pipelineID, ok := ctx.Value(pipelineIDKey{}).(pipeline.ID)
+	if !ok {
+		return nil, fmt.Errorf("shared processor %q must call the next consumer with the context it was called with", s.componentID)
+	}
+	next, ok := s.nexts[pipelineID]
+	if !ok {
+		return nil, fmt.Errorf("shared processor %q is not used in pipeline %q", s.componentID, pipelineID.String())
+	}
+	return next, nil
+}
+
+// router returns the next consumer of the component, sending the data to the pipeline set in the context.
+func (s *sharedProcessor) router() baseConsumer {
+	var cons baseConsumer
+	switch s.signal {
+	case pipeline.SignalTraces:
+		cons, _ = consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+			next, err := s.next(ctx)
+			if err != nil {
+				return err
+			}
+			return next.(consumer.Traces).ConsumeTraces(ctx, td)
+		})
+	case pipeline.SignalMetrics:
+		cons, _ = consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+			next, err := s.next(ctx)
+			if err != nil {
+				return err
+			}
+			return next.(consumer.Metrics).ConsumeMetrics(ctx, md)
+		})
+	case pipeline.SignalLogs:
+		cons, _ = consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+			next, err := s.next(ctx)
+			if err != nil {
+				return err
+			}
+			return next.(consumer.Logs).ConsumeLogs(ctx, ld)
+		})
+	case xpipeline.SignalProfiles:
+		cons, _ = xconsumer.NewProfiles(func(ctx context.Context, pd pprofile.Profiles) error {
+			next, err := s.next(ctx)
+			if err != nil {
+				return err
+			}
+			return next.(xconsumer.Profiles).ConsumeProfiles(ctx, pd)
+		})
+	}
+	return cons
+}
+
+// withPipelineID wraps the consumer of a shared processor to set the calling pipeline in the context.
+func withPipelineID(signal pipeline.Signal, cons baseConsumer, pipelineID pipeline.ID) baseConsumer {
+	switch signal {
+	case pipeline.SignalTraces:
+		return pipelineTraces{Traces: cons.(consumer.Traces), pipelineID: pipelineID}
+	case pipeline.SignalMetrics:
+		return pipelineMetrics{Metrics: cons.(consumer.Metrics), pipelineID: pipelineID}
+	case pipeline.SignalLogs:
+		return pipelineLogs{Logs: cons.(consumer.Logs), pipelineID: pipelineID}
+	case xpipeline.SignalProfiles:
+		return pipelineProfiles{Profiles: cons.(xconsumer.Profiles), pipelineID: pipelineID}
+	}
+	return cons
+}
+
+type pipelineTraces struct {
+	consumer.Traces
+	pipelineID pipeline.ID
+}
+
+func (c pipelineTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return c.Traces.ConsumeTraces(context.WithValue(ctx, pipelineIDKey{}, c.pipelineID), td)
+}
+
+type pipelineMetrics struct {
+	consumer.Metrics
+	pipelineID pipeline.ID
+}
+
+func (c pipelineMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return c.Metrics.ConsumeMetrics(context.WithValue(ctx, pipelineIDKey{}, c.pipelineID), md)
+}
+
+type pipelineLogs struct {
+	consumer.Logs
+	pipelineID pipeline.ID
+}
+
+func (c pipelineLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return c.Logs.ConsumeLogs(context.WithValue(ctx, pipelineIDKey{}, c.pipelineID), ld)
+}
+
+type pipelineProfiles struct {
+	xconsumer.Profiles
+	pipelineID pipeline.ID
+}
+
+func (c pipelineProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	return c.Profiles.ConsumeProfiles(context.WithValue(ctx, pipelineIDKey{}, c.pipelineID), pd)
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/xprocessor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// sharedTest counts the lifecycle calls of the created processors.
+type sharedTest struct {
+	created   atomic.Int64
+	started   atomic.Int64
+	shutdowns atomic.Int64
+}
+
+// passThroughProcessor sends the data to the next consumer with the context it was called with.
+type passThroughProcessor struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumertest.Consumer
+	next consumer.Traces
+}
+
+func (p *passThroughProcessor) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return p.next.ConsumeTraces(ctx, td)
+}
+
+func (st *sharedTest) factory(shared bool) processor.Factory {
+	opts := []xprocessor.FactoryOption{
+		xprocessor.WithTraces(func(_ context.Context, _ processor.Settings, _ component.Config, next consumer.Traces) (processor.Traces, error) {
+			st.created.Add(1)
+			return &passThroughProcessor{
+				StartFunc: func(context.Context, component.Host) error {
+					st.started.Add(1)
+					return nil
+				},
+				ShutdownFunc: func(context.Context) error {
+					st.shutdowns.Add(1)
+					return nil
+				},
+				Consumer: consumertest.NewNop(),
+				next:     next,
+			}, nil
+		}, component.StabilityLevelDevelopment),
+	}
+	if shared {
+		opts = append(opts, xprocessor.WithSharedInstance())
+	}
+	return xprocessor.NewFactory(component.MustNewType("shared"), func() component.Config { return &struct{}{} }, opts...)
+}
+
+func newSharedSettings(procFactory processor.Factory, sinks map[component.ID]*consumertest.TracesSink) Settings {
+	rcvrID := component.MustNewID("examplereceiver")
+	procID := component.NewID(procFactory.Type())
+	expFactory := exporter.NewFactory(component.MustNewType("sink"),
+		func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(_ context.Context, set exporter.Settings, _ component.Config) (exporter.Traces, error) {
+			return &failingExporter{Consumer: sinkConsumer{Consumer: consumertest.NewNop(), sink: sinks[set.ID]}}, nil
+		}, component.StabilityLevelDevelopment),
+	)
+
+	pipelineCfgs := pipelines.Config{}
+	expCfgs := map[component.ID]component.Config{}
+	for expID := range sinks {
+		pipelineCfgs[pipeline.NewIDWithName(pipeline.SignalTraces, expID.Name())] = &pipelines.PipelineConfig{
+			Receivers:  []component.ID{rcvrID},
+			Processors: []component.ID{procID},
+			Exporters:  []component.ID{expID},
+		}
+		expCfgs[expID] = &struct{}{}
+	}
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{procID: &struct{}{}},
+			map[component.Type]processor.Factory{procID.Type(): procFactory},
+		),
+		ExporterBuilder:  builders.NewExporter(expCfgs, map[component.Type]exporter.Factory{expFactory.Type(): expFactory}),
+		ConnectorBuilder: builders.NewConnector(nil, nil),
+		PipelineConfigs:  pipelineCfgs,
+	}
+}
+
+func TestSharedProcessor(t *testing.T) {
+	for _, shared := range []bool{false, true} {
+		t.Run(map[bool]string{false: "not_shared", true: "shared"}[shared], func(t *testing.T) {
+			st := &sharedTest{}
+			sinks := map[component.ID]*consumertest.TracesSink{
+				component.MustNewIDWithName("sink", "a"): new(consumertest.TracesSink),
+				component.MustNewIDWithName("sink", "b"): new(consumertest.TracesSink),
+			}
+			set := newSharedSettings(st.factory(shared), sinks)
+			statuses := &statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)}
+			host := &Host{Reporter: status.NewReporter(statuses.record, func(error) {})}
+
+			pg, err := Build(context.Background(), set)
+			require.NoError(t, err)
+			require.NoError(t, pg.StartAll(context.Background(), host))
+
+			instances := int64(2)
+			if shared {
+				instances = 1
+			}
+			assert.Equal(t, instances, st.created.Load())
+			assert.Equal(t, instances, st.started.Load())
+
+			// The data is sent to the pipeline it was received from.
+			cons := pg.pipelines[pipeline.NewIDWithName(pipeline.SignalTraces, "a")].capabilitiesNode.getConsumer().(consumer.Traces)
+			require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+			cons = pg.pipelines[pipeline.NewIDWithName(pipeline.SignalTraces, "b")].capabilitiesNode.getConsumer().(consumer.Traces)
+			require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+			assert.Equal(t, 1, sinks[component.MustNewIDWithName("sink", "a")].SpanCount())
+			assert.Equal(t, 2, sinks[component.MustNewIDWithName("sink", "b")].SpanCount())
+
+			require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+			assert.Equal(t, instances, st.shutdowns.Load())
+			// The status is still reported for the processor of each pipeline.
+			assert.Equal(t, []componentstatus.Status{
+				componentstatus.StatusStarting, componentstatus.StatusOK,
+				componentstatus.StatusStarting, componentstatus.StatusOK,
+				componentstatus.StatusStopping, componentstatus.StatusStopped,
+				componentstatus.StatusStopping, componentstatus.StatusStopped,
+			}, statuses.get(component.MustNewID("shared")))
+		})
+	}
+}
+
+func TestSharedProcessorSinglePipeline(t *testing.T) {
+	st := &sharedTest{}
+	sinks := map[component.ID]*consumertest.TracesSink{
+		component.MustNewIDWithName("sink", "a"): new(consumertest.TracesSink),
+	}
+	pg, err := Build(context.Background(), newSharedSettings(st.factory(true), sinks))
+	require.NoError(t, err)
+
+	procNode := pg.pipelines[pipeline.NewIDWithName(pipeline.SignalTraces, "a")].processors[0].(*processorNode)
+	assert.Nil(t, procNode.shared)
+	assert.Equal(t, int64(1), st.created.Load())
+}
+
+// lostContextProcessor calls the next consumer without the context it was called with.
+type lostContextProcessor struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumertest.Consumer
+	next consumer.Traces
+}
+
+func (p *lostContextProcessor) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
+	return p.next.ConsumeTraces(context.Background(), td)
+}
+
+func TestSharedProcessorLostContext(t *testing.T) {
+	sinks := map[component.ID]*consumertest.TracesSink{
+		component.MustNewIDWithName("sink", "a"): new(consumertest.TracesSink),
+		component.MustNewIDWithName("sink", "b"): new(consumertest.TracesSink),
+	}
+	procFactory := xprocessor.NewFactory(component.MustNewType("shared"),
+		func() component.Config { return &struct{}{} },
+		xprocessor.WithTraces(func(_ context.Context, _ processor.Settings, _ component.Config, next consumer.Traces) (processor.Traces, error) {
+			return &lostContextProcessor{Consumer: consumertest.NewNop(), next: next}, nil
+		}, component.StabilityLevelDevelopment),
+		xprocessor.WithSharedInstance(),
+	)
+	pg, err := Build(context.Background(), newSharedSettings(procFactory, sinks))
+	require.NoError(t, err)
+
+	cons := pg.pipelines[pipeline.NewIDWithName(pipeline.SignalTraces, "a")].capabilitiesNode.getConsumer().(consumer.Traces)
+	err = cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	require.EqualError(t, err, `shared processor "shared" must call the next consumer with the context it was called with`)
+	assert.Zero(t, sinks[component.MustNewIDWithName("sink", "a")].SpanCount())
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	otelattr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// sortForShutdown sorts the topologically sorted nodes in the order they are shut down: by
+// order of their pipelines, then topologically. A node is shut down with the last of its
+// pipelines, and never before the nodes emitting data to it.
+func (g *Graph) sortForShutdown(nodes []graph.Node) {
+	orders := make(map[int64]int, len(nodes))
+	for _, node := range nodes {
+		order, found := 0, false
+		for _, pipelineID := range g.nodePipelines(node) {
+			if o := g.set.PipelineConfigs[pipelineID].Shutdown.Order; !found || o > order {
+				order, found = o, true
+			}
+		}
+		froms := g.componentGraph.To(node.ID())
+		for froms.Next() {
+			if o := orders[froms.Node().ID()]; !found || o > order {
+				order, found = o, true
+			}
+		}
+		orders[node.ID()] = order
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return orders[nodes[i].ID()] < orders[nodes[j].ID()]
+	})
+}
+
+// nodePipelines returns the pipelines the node belongs to.
+func (g *Graph) nodePipelines(node graph.Node) []pipeline.ID {
+	switch n := node.(type) {
+	case *capabilitiesNode:
+		return []pipeline.ID{n.pipelineID}
+	case *fanOutNode:
+		return []pipeline.ID{n.pipelineID}
+	case *feedbackNode:
+		return []pipeline.ID{n.pipelineID}
+	case *shadowNode:
+		return []pipeline.ID{n.pipelineID}
+	}
+	instanceID, ok := g.instanceIDs[node.ID()]
+	if !ok {
+		return nil
+	}
+	var pipelineIDs []pipeline.ID
+	instanceID.AllPipelineIDs(func(pipelineID pipeline.ID) bool {
+		pipelineIDs = append(pipelineIDs, pipelineID)
+		return true
+	})
+	return pipelineIDs
+}
+
+// drainDeadline returns the strictest drain deadline of the pipelines for the stage of the
+// node, or zero if none. The connectors use the deadline of the exporters of the pipelines
+// exporting to them.
+func (g *Graph) drainDeadline(node graph.Node) time.Duration {
+	var deadline time.Duration
+	for pipelineID, pn := range g.pipelines {
+		cfg := g.set.PipelineConfigs[pipelineID].Shutdown
+		var d time.Duration
+		if _, ok := pn.exporters[node.ID()]; ok {
+			d = cfg.Exporters
+		} else if _, ok := pn.receivers[node.ID()]; ok {
+			if _, isReceiver := node.(*receiverNode); isReceiver {
+				d = cfg.Receivers
+			}
+		} else {
+			for _, proc := range pn.processors {
+				if proc.ID() == node.ID() {
+					d = cfg.Processors
+					break
+				}
+			}
+		}
+		if d > 0 && (deadline == 0 || d < deadline) {
+			deadline = d
+		}
+	}
+	return deadline
+}
+
+// markClosing marks the pipelines passing their data to the node as closing, before the node
+// is shut down.
+func (g *Graph) markClosing(node graph.Node) {
+	for _, pipelineID := range g.feeding(node) {
+		g.pipelines[pipelineID].capabilitiesNode.closing.Store(true)
+	}
+}
+
+// countWhileClosing makes the pipeline count the data entering it once its processors or exporters
+// started shutting down, e.g. from the receivers shared with pipelines shut down later, as the data
+// may not be processed anymore. The data is still passed to the pipeline.
+func (n *capabilitiesNode) countWhileClosing(lost metric.Int64Counter) {
+	attrs := metric.WithAttributeSet(otelattr.NewSet(otelattr.String(pipelineIDAttrKey, n.pipelineID.String())))
+	if next := n.ConsumeTracesFunc; next != nil {
+		n.ConsumeTracesFunc = func(ctx context.Context, td ptrace.Traces) error {
+			if n.closing.Load() {
+				lost.Add(ctx, int64(td.SpanCount()), attrs)
+			}
+			return next(ctx, td)
+		}
+	}
+	if next := n.ConsumeMetricsFunc; next != nil {
+		n.ConsumeMetricsFunc = func(ctx context.Context, md pmetric.Metrics) error {
+			if n.closing.Load() {
+				lost.Add(ctx, int64(md.DataPointCount()), attrs)
+			}
+			return next(ctx, md)
+		}
+	}
+	if next := n.ConsumeLogsFunc; next != nil {
+		n.ConsumeLogsFunc = func(ctx context.Context, ld plog.Logs) error {
+			if n.closing.Load() {
+				lost.Add(ctx, int64(ld.LogRecordCount()), attrs)
+			}
+			return next(ctx, ld)
+		}
+	}
+	if next := n.ConsumeProfilesFunc; next != nil {
+		n.ConsumeProfilesFunc = func(ctx context.Context, pd pprofile.Profiles) error {
+			if n.closing.Load() {
+				lost.Add(ctx, int64(pd.SampleCount()), attrs)
+			}
+			return next(ctx, pd)
+		}
+	}
+}
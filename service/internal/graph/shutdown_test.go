@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/health"
+	"go.opentelemetry.io/collector/service/internal/metadatatest"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// shutdownRecorder records the order in which the components start shutting down.
+type shutdownRecorder struct {
+	mu      sync.Mutex
+	stopped []component.ID
+}
+
+func (r *shutdownRecorder) record(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+	if ev.Status() != componentstatus.StatusStopping {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopped = append(r.stopped, id.ComponentID())
+}
+
+func TestShutdownOrder(t *testing.T) {
+	rcvrID, rcvr2ID, sharedID := component.MustNewID("examplereceiver"), component.MustNewIDWithName("examplereceiver", "2"),
+		component.MustNewIDWithName("examplereceiver", "shared")
+	expID, exp2ID, exp3ID := component.MustNewID("exampleexporter"), component.MustNewIDWithName("exampleexporter", "2"),
+		component.MustNewIDWithName("exampleexporter", "3")
+
+	for _, tt := range []struct {
+		name  string
+		pipes pipelines.Config
+		// The first components shut down, out of total.
+		want  []component.ID
+		total int
+	}{
+		{
+			name: "default",
+			pipes: pipelines.Config{
+				pipeline.NewID(pipeline.SignalTraces): {Receivers: []component.ID{rcvrID}, Exporters: []component.ID{expID}},
+			},
+			want:  []component.ID{rcvrID, expID},
+			total: 2,
+		},
+		{
+			name: "ordered",
+			pipes: pipelines.Config{
+				pipeline.NewID(pipeline.SignalTraces): {
+					Receivers: []component.ID{rcvrID}, Exporters: []component.ID{expID},
+					Shutdown: pipelines.ShutdownConfig{Order: 1},
+				},
+				pipeline.NewIDWithName(pipeline.SignalTraces, "2"): {
+					Receivers: []component.ID{rcvr2ID}, Exporters: []component.ID{exp2ID},
+				},
+			},
+			want:  []component.ID{rcvr2ID, exp2ID, rcvrID, expID},
+			total: 4,
+		},
+		{
+			// The pipeline is shut down with the later pipeline sharing its receiver, and the exporters
+			// emitted to by the same receiver are shut down in any order.
+			name: "shared receiver",
+			pipes: pipelines.Config{
+				pipeline.NewID(pipeline.SignalTraces): {
+					Receivers: []component.ID{sharedID}, Exporters: []component.ID{expID},
+					Shutdown: pipelines.ShutdownConfig{Order: 2},
+				},
+				pipeline.NewIDWithName(pipeline.SignalTraces, "2"): {
+					Receivers: []component.ID{sharedID}, Exporters: []component.ID{exp2ID},
+				},
+				pipeline.NewIDWithName(pipeline.SignalTraces, "3"): {
+					Receivers: []component.ID{rcvr2ID}, Exporters: []component.ID{exp3ID},
+					Shutdown: pipelines.ShutdownConfig{Order: 1},
+				},
+			},
+			want:  []component.ID{rcvr2ID, exp3ID, sharedID},
+			total: 5,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			expCfgs := make(map[component.ID]component.Config)
+			for _, pipe := range tt.pipes {
+				for _, id := range pipe.Exporters {
+					expCfgs[id] = &exampleConfig{}
+				}
+			}
+			pg, err := Build(context.Background(), newReconcileSettings(tt.pipes, expCfgs))
+			require.NoError(t, err)
+
+			rec := &shutdownRecorder{}
+			host := &Host{Reporter: status.NewReporter(rec.record, func(error) {})}
+			require.NoError(t, pg.StartAll(context.Background(), host))
+			require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+			require.Len(t, rec.stopped, tt.total)
+			assert.Equal(t, tt.want, rec.stopped[:len(tt.want)])
+		})
+	}
+}
+
+func TestShutdownDrainDeadline(t *testing.T) {
+	expFactory, _ := newFlakyExporterFactory(func(int64) *failingExporter {
+		return &failingExporter{
+			ShutdownFunc: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			Consumer: consumertest.NewNop(),
+		}
+	})
+	set := newRestartSettings(expFactory, health.RestartConfig{})
+	set.PipelineConfigs[pipeline.NewID(pipeline.SignalTraces)].Shutdown = pipelines.ShutdownConfig{Exporters: 10 * time.Millisecond}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	require.EqualError(t, pg.ShutdownAll(context.Background(), host.Reporter), `failed to shutdown "flaky" exporter: timed out after 10ms`)
+}
+
+func TestShutdownLostItems(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	expFactory, _ := newFlakyExporterFactory(func(int64) *failingExporter {
+		return &failingExporter{Consumer: consumertest.NewNop()}
+	})
+	set := newRestartSettings(expFactory, health.RestartConfig{})
+	set.Telemetry = tel.NewTelemetrySettings()
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	cons := pg.pipelines[pipeline.NewID(pipeline.SignalTraces)].capabilitiesNode.getConsumer().(consumer.Traces)
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+
+	// The data entering the pipeline once its exporter is shut down is counted as lost.
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	metadatatest.AssertEqualPipelineShutdownLostItems(t, tel, []metricdata.DataPoint[int64]{
+		{
+			Attributes: attribute.NewSet(attribute.String(pipelineIDAttrKey, "traces")),
+			Value:      3,
+		},
+	}, metricdatatest.IgnoreTimestamp())
+}
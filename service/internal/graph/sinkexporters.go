@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// countItems makes the pipeline count the items entering it, for the throughput logged with the sink exporters.
+func (n *capabilitiesNode) countItems(count *atomic.Int64) {
+	if next := n.ConsumeTracesFunc; next != nil {
+		n.ConsumeTracesFunc = func(ctx context.Context, td ptrace.Traces) error {
+			count.Add(int64(td.SpanCount()))
+			return next(ctx, td)
+		}
+	}
+	if next := n.ConsumeMetricsFunc; next != nil {
+		n.ConsumeMetricsFunc = func(ctx context.Context, md pmetric.Metrics) error {
+			count.Add(int64(md.DataPointCount()))
+			return next(ctx, md)
+		}
+	}
+	if next := n.ConsumeLogsFunc; next != nil {
+		n.ConsumeLogsFunc = func(ctx context.Context, ld plog.Logs) error {
+			count.Add(int64(ld.LogRecordCount()))
+			return next(ctx, ld)
+		}
+	}
+	if next := n.ConsumeProfilesFunc; next != nil {
+		n.ConsumeProfilesFunc = func(ctx context.Context, pd pprofile.Profiles) error {
+			count.Add(int64(pd.SampleCount()))
+			return next(ctx, pd)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/sinkexporters"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func TestSinkExportersCountItems(t *testing.T) {
+	rcvrID, expID := component.MustNewID("examplereceiver"), component.MustNewID("exampleexporter")
+	tracesID := pipeline.NewID(pipeline.SignalTraces)
+	set := newReconcileSettings(pipelines.Config{
+		tracesID: {Receivers: []component.ID{rcvrID}, Exporters: []component.ID{expID}},
+	}, map[component.ID]component.Config{expID: &exampleConfig{}})
+	set.SinkStats = sinkexporters.NewStats(zap.NewNop())
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	require.NoError(t, pg.exampleReceiver(rcvrID).ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, pg.exampleReceiver(rcvrID).ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	assert.Equal(t, int64(5), set.SinkStats.Counter(tracesID).Load())
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
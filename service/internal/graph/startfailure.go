@@ -0,0 +1,280 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	otelattr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/health"
+)
+
+// startFailures disables the pipelines of the components that fail to start according to
+// their health.StartFailurePolicy, and retries the start of the components in the background.
+type startFailures struct {
+	graph *Graph
+
+	// The policies of the component instances which do not fail the start of the collector.
+	policies map[*componentstatus.InstanceID]health.StartFailurePolicy
+
+	mu      sync.Mutex
+	stopped bool
+	// The instances which failed to start and did not start since.
+	failedIDs map[*componentstatus.InstanceID]bool
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+}
+
+// newStartFailures validates the start failure policies against the graph.
+// It returns nil if no component or pipeline has a policy.
+func newStartFailures(g *Graph, set Settings) (*startFailures, error) {
+	cfg := set.StartFailure
+	configured := len(cfg.Receivers) > 0 || len(cfg.Processors) > 0 || len(cfg.Exporters) > 0 || len(cfg.Connectors) > 0
+	for _, pipelineCfg := range set.PipelineConfigs {
+		configured = configured || pipelineCfg.OnStartFailure != ""
+	}
+	if !configured {
+		return nil, nil
+	}
+
+	type kindID struct {
+		kind component.Kind
+		id   component.ID
+	}
+	used := make(map[kindID]bool)
+	s := &startFailures{
+		graph:     g,
+		policies:  make(map[*componentstatus.InstanceID]health.StartFailurePolicy),
+		failedIDs: make(map[*componentstatus.InstanceID]bool),
+		stopCh:    make(chan struct{}),
+	}
+	for _, instanceID := range g.instanceIDs {
+		used[kindID{kind: instanceID.Kind(), id: instanceID.ComponentID()}] = true
+		policy := cfg.For(instanceID.Kind(), instanceID.ComponentID())
+		if policy == "" {
+			policy = health.StartFailureDisable
+			instanceID.AllPipelineIDs(func(pipelineID pipeline.ID) bool {
+				policy = policy.Strictest(set.PipelineConfigs[pipelineID].OnStartFailure)
+				return true
+			})
+		}
+		if policy == health.StartFailureDisable || policy == health.StartFailureRetry {
+			s.policies[instanceID] = policy
+		}
+	}
+
+	for _, overrides := range []struct {
+		kind     component.Kind
+		policies map[component.ID]health.StartFailurePolicy
+	}{
+		{kind: component.KindReceiver, policies: cfg.Receivers},
+		{kind: component.KindProcessor, policies: cfg.Processors},
+		{kind: component.KindExporter, policies: cfg.Exporters},
+		{kind: component.KindConnector, policies: cfg.Connectors},
+	} {
+		for id := range overrides.policies {
+			if !used[kindID{kind: overrides.kind, id: id}] {
+				return nil, fmt.Errorf("start failure policy references %s %q which is not used by any pipeline", strings.ToLower(overrides.kind.String()), id)
+			}
+		}
+	}
+	return s, nil
+}
+
+// policy returns the start failure policy of the instance.
+func (s *startFailures) policy(instanceID *componentstatus.InstanceID) health.StartFailurePolicy {
+	if s == nil {
+		return health.StartFailureFail
+	}
+	if policy, ok := s.policies[instanceID]; ok {
+		return policy
+	}
+	return health.StartFailureFail
+}
+
+// feeding returns the pipelines passing their data to the node, which are disabled while
+// the node is not started. The pipelines of a receiver keep receiving the data of the others.
+func (g *Graph) feeding(node graph.Node) []pipeline.ID {
+	var pipelineIDs []pipeline.ID
+	for pipelineID, pn := range g.pipelines {
+		if _, ok := pn.exporters[node.ID()]; ok {
+			pipelineIDs = append(pipelineIDs, pipelineID)
+			continue
+		}
+		for _, proc := range pn.processors {
+			if proc.ID() == node.ID() {
+				pipelineIDs = append(pipelineIDs, pipelineID)
+				break
+			}
+		}
+	}
+	return pipelineIDs
+}
+
+// failed disables the pipelines feeding the instance which failed to start, and retries its
+// start in the background if its policy is health.StartFailureRetry.
+func (s *startFailures) failed(host *Host, node graph.Node, instanceID *componentstatus.InstanceID, err error) {
+	var pipelineIDs []string
+	for _, pipelineID := range s.graph.feeding(node) {
+		s.graph.pipelines[pipelineID].capabilitiesNode.failed.Add(1)
+		pipelineIDs = append(pipelineIDs, pipelineID.String())
+	}
+	policy := s.policy(instanceID)
+	s.graph.telemetry.Logger.Warn("Component failed to start, disabling its pipelines",
+		zap.Error(err),
+		zap.String("type", instanceID.Kind().String()),
+		zap.String("id", instanceID.ComponentID().String()),
+		zap.Strings("pipelines", pipelineIDs),
+		zap.String("on_start_failure", string(policy)),
+	)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failedIDs[instanceID] = true
+	if policy != health.StartFailureRetry || s.stopped {
+		return
+	}
+	s.wg.Add(1)
+	go s.retry(host, node, instanceID)
+}
+
+// retry starts the instance until it succeeds or the graph is shut down, then enables its pipelines.
+func (s *startFailures) retry(host *Host, node graph.Node, instanceID *componentstatus.InstanceID) {
+	defer s.wg.Done()
+
+	logger := s.graph.telemetry.Logger.With(
+		zap.String("type", instanceID.Kind().String()),
+		zap.String("id", instanceID.ComponentID().String()),
+	)
+	policy := health.RestartPolicy{}.WithDefaults()
+	timeouts := s.graph.set.Timeouts.For(instanceID.Kind(), instanceID.ComponentID())
+	for attempt := 0; ; attempt++ {
+		timer := time.NewTimer(policy.Backoff(attempt))
+		select {
+		case <-s.stopCh:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.graph.rebuildMu.Lock()
+		err := s.restart(host, node, instanceID, timeouts)
+		s.graph.rebuildMu.Unlock()
+		if err != nil {
+			logger.Debug("Failed to retry the start of component", zap.Error(err), zap.Int("attempt", attempt+1))
+			continue
+		}
+
+		logger.Info("Component started after retrying, enabling its pipelines", zap.Int("attempt", attempt+1))
+		host.Reporter.ReportStatus(instanceID, componentstatus.NewEvent(componentstatus.StatusOK))
+		for _, pipelineID := range s.graph.feeding(node) {
+			s.graph.pipelines[pipelineID].capabilitiesNode.failed.Add(-1)
+		}
+		s.mu.Lock()
+		delete(s.failedIDs, instanceID)
+		s.mu.Unlock()
+		return
+	}
+}
+
+// restart starts the instance again. Receivers and exporters are shut down and recreated before,
+// as starting a component twice is a no-op for the components shared with sharedcomponent.
+func (s *startFailures) restart(host *Host, node graph.Node, instanceID *componentstatus.InstanceID, timeouts health.ComponentTimeouts) error {
+	ctx := context.Background()
+	switch node.(type) {
+	case *receiverNode, *exporterNode:
+		if err := s.graph.lifecycle(node).Call(ctx, timeouts.Shutdown, node.(component.Component).Shutdown); err != nil {
+			return fmt.Errorf("failed to shut down component before retrying its start: %w", err)
+		}
+		if err := s.graph.rebuild(ctx, node, instanceID); err != nil {
+			return err
+		}
+	}
+	return s.graph.lifecycle(node).Call(ctx, timeouts.Start, func(ctx context.Context) error {
+		return node.(component.Component).Start(ctx, &HostWrapper{Host: host, InstanceID: instanceID})
+	})
+}
+
+// isFailed returns whether the instance failed to start and did not start since.
+func (s *startFailures) isFailed(instanceID *componentstatus.InstanceID) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failedIDs[instanceID]
+}
+
+// stop cancels the pending retries and waits for the ones in progress to complete.
+func (s *startFailures) stop() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	s.stopped = true
+	close(s.stopCh)
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+// dropWhileFailed makes the pipeline drop the data entering it while some of its components
+// failed to start, counting the dropped items. The data is not rejected, as the receivers of the
+// pipeline may pass it to other pipelines, which would receive it again if their clients retried.
+func (n *capabilitiesNode) dropWhileFailed(dropped metric.Int64Counter) {
+	attrs := metric.WithAttributeSet(otelattr.NewSet(otelattr.String(pipelineIDAttrKey, n.pipelineID.String())))
+	if next := n.ConsumeTracesFunc; next != nil {
+		n.ConsumeTracesFunc = func(ctx context.Context, td ptrace.Traces) error {
+			if n.failed.Load() > 0 {
+				dropped.Add(ctx, int64(td.SpanCount()), attrs)
+				return nil
+			}
+			return next(ctx, td)
+		}
+	}
+	if next := n.ConsumeMetricsFunc; next != nil {
+		n.ConsumeMetricsFunc = func(ctx context.Context, md pmetric.Metrics) error {
+			if n.failed.Load() > 0 {
+				dropped.Add(ctx, int64(md.DataPointCount()), attrs)
+				return nil
+			}
+			return next(ctx, md)
+		}
+	}
+	if next := n.ConsumeLogsFunc; next != nil {
+		n.ConsumeLogsFunc = func(ctx context.Context, ld plog.Logs) error {
+			if n.failed.Load() > 0 {
+				dropped.Add(ctx, int64(ld.LogRecordCount()), attrs)
+				return nil
+			}
+			return next(ctx, ld)
+		}
+	}
+	if next := n.ConsumeProfilesFunc; next != nil {
+		n.ConsumeProfilesFunc = func(ctx context.Context, pd pprofile.Profiles) error {
+			if n.failed.Load() > 0 {
+				dropped.Add(ctx, int64(pd.SampleCount()), attrs)
+				return nil
+			}
+			return next(ctx, pd)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/health"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/metadatatest"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+// newStartFailureSettings returns the settings of two pipelines sharing a receiver, where the
+// exporter of the "traces/flaky" pipeline fails to start the given number of times.
+func newStartFailureSettings(failures int, sink, flakySink *consumertest.TracesSink, policy health.StartFailurePolicy) Settings {
+	rcvrID := component.MustNewID("examplereceiver")
+	sinkID := component.MustNewID("sink")
+	flakyID := component.MustNewID("flaky")
+	sinkFactory := exporter.NewFactory(sinkID.Type(), func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			return &sinkExporter{TracesSink: sink}, nil
+		}, component.StabilityLevelDevelopment))
+	var starts atomic.Int32
+	flakyFactory := exporter.NewFactory(flakyID.Type(), func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			return &sinkExporter{
+				StartFunc: func(context.Context, component.Host) error {
+					if int(starts.Add(1)) <= failures {
+						return errors.New("no such host")
+					}
+					return nil
+				},
+				TracesSink: flakySink,
+			}, nil
+		}, component.StabilityLevelDevelopment))
+	return Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{sinkID: sinkFactory.CreateDefaultConfig(), flakyID: flakyFactory.CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{sinkFactory.Type(): sinkFactory, flakyFactory.Type(): flakyFactory},
+		),
+		ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			pipeline.NewID(pipeline.SignalTraces): {
+				Receivers: []component.ID{rcvrID},
+				Exporters: []component.ID{sinkID},
+			},
+			pipeline.NewIDWithName(pipeline.SignalTraces, "flaky"): {
+				Receivers:      []component.ID{rcvrID},
+				Exporters:      []component.ID{flakyID},
+				OnStartFailure: policy,
+			},
+		},
+	}
+}
+
+// lastStatus returns the last status reported by the component.
+func (r *statusRecorder) lastStatus(id component.ID) componentstatus.Status {
+	statuses := r.get(id)
+	if len(statuses) == 0 {
+		return componentstatus.StatusNone
+	}
+	return statuses[len(statuses)-1]
+}
+
+func newRecorderHost(r *statusRecorder) *Host {
+	return &Host{Reporter: status.NewReporter(r.record, func(error) {})}
+}
+
+func TestStartFailure(t *testing.T) {
+	flakyID := component.MustNewID("flaky")
+	for _, tt := range []struct {
+		name         string
+		policy       health.StartFailurePolicy
+		override     health.StartFailurePolicy
+		wantErr      string
+		wantStatus   componentstatus.Status
+		wantRecovery bool
+	}{
+		{
+			name:    "default",
+			wantErr: `failed to start "flaky" exporter: no such host`,
+		},
+		{
+			name:    "fail",
+			policy:  health.StartFailureFail,
+			wantErr: `failed to start "flaky" exporter: no such host`,
+		},
+		{
+			name:       "disable",
+			policy:     health.StartFailureDisable,
+			wantStatus: componentstatus.StatusPermanentError,
+		},
+		{
+			name:       "component overrides pipeline",
+			policy:     health.StartFailureFail,
+			override:   health.StartFailureDisable,
+			wantStatus: componentstatus.StatusPermanentError,
+		},
+		{
+			name:         "retry",
+			policy:       health.StartFailureRetry,
+			wantStatus:   componentstatus.StatusRecoverableError,
+			wantRecovery: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sink, flakySink := new(consumertest.TracesSink), new(consumertest.TracesSink)
+			set := newStartFailureSettings(1, sink, flakySink, tt.policy)
+			if tt.override != "" {
+				set.StartFailure = health.StartFailureConfig{
+					Exporters: map[component.ID]health.StartFailurePolicy{flakyID: tt.override},
+				}
+			}
+			pg, err := Build(context.Background(), set)
+			require.NoError(t, err)
+
+			recorder := &statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)}
+			host := newRecorderHost(recorder)
+			err = pg.StartAll(context.Background(), host)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, recorder.lastStatus(flakyID))
+
+			// The data of the shared receiver still reaches the other pipeline.
+			rcvr := pg.getReceivers()[pipeline.SignalTraces][component.MustNewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+			require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+			assert.Len(t, sink.AllTraces(), 1)
+			assert.Empty(t, flakySink.AllTraces())
+
+			if tt.wantRecovery {
+				assert.Eventually(t, func() bool {
+					return recorder.lastStatus(flakyID) == componentstatus.StatusOK
+				}, 5*time.Second, 10*time.Millisecond)
+				require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+				assert.Len(t, sink.AllTraces(), 2)
+				assert.Len(t, flakySink.AllTraces(), 1)
+			}
+			require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+		})
+	}
+}
+
+func TestStartFailureRetryStoppedOnShutdown(t *testing.T) {
+	set := newStartFailureSettings(1000, new(consumertest.TracesSink), new(consumertest.TracesSink), health.StartFailureRetry)
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := newRecorderHost(&statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)})
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestStartFailureDroppedItems(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	set := newStartFailureSettings(1, new(consumertest.TracesSink), new(consumertest.TracesSink), health.StartFailureDisable)
+	set.Telemetry = tel.NewTelemetrySettings()
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	host := newRecorderHost(&statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)})
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	// The data of the disabled pipeline is counted as dropped, and not rejected.
+	rcvr := pg.getReceivers()[pipeline.SignalTraces][component.MustNewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	metadatatest.AssertEqualPipelineDisabledDroppedItems(t, tel, []metricdata.DataPoint[int64]{
+		{
+			Attributes: attribute.NewSet(attribute.String(pipelineIDAttrKey, "traces/flaky")),
+			Value:      3,
+		},
+	}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
+
+// onceReceiver is a receiver which only starts once, like the receivers created with sharedcomponent,
+// and returns the result of its first start on the following ones.
+type onceReceiver struct {
+	component.ShutdownFunc
+	err error
+}
+
+func (r *onceReceiver) Start(context.Context, component.Host) error {
+	return r.err
+}
+
+func TestStartFailureRetryRecreatesReceiver(t *testing.T) {
+	var created atomic.Int32
+	rcvrFactory := receiver.NewFactory(component.MustNewType("once"),
+		func() component.Config { return &struct{}{} },
+		receiver.WithTraces(func(context.Context, receiver.Settings, component.Config, consumer.Traces) (receiver.Traces, error) {
+			// The first receiver keeps failing, as it is not started again.
+			if created.Add(1) == 1 {
+				return &onceReceiver{err: errors.New("address already in use")}, nil
+			}
+			return &onceReceiver{}, nil
+		}, component.StabilityLevelDevelopment),
+	)
+	rcvrID := component.NewID(rcvrFactory.Type())
+	set := newStartFailureSettings(0, new(consumertest.TracesSink), new(consumertest.TracesSink), "")
+	set.ReceiverBuilder = builders.NewReceiver(
+		map[component.ID]component.Config{rcvrID: &struct{}{}},
+		map[component.Type]receiver.Factory{rcvrID.Type(): rcvrFactory},
+	)
+	set.PipelineConfigs = pipelines.Config{
+		pipeline.NewID(pipeline.SignalTraces): {
+			Receivers:      []component.ID{rcvrID},
+			Exporters:      []component.ID{component.MustNewID("sink")},
+			OnStartFailure: health.StartFailureRetry,
+		},
+	}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	recorder := &statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)}
+	host := newRecorderHost(recorder)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	assert.Eventually(t, func() bool {
+		return recorder.lastStatus(rcvrID) == componentstatus.StatusOK
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(2), created.Load())
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestStartFailureInvalid(t *testing.T) {
+	set := newStartFailureSettings(0, new(consumertest.TracesSink), new(consumertest.TracesSink), "")
+	set.StartFailure = health.StartFailureConfig{
+		Receivers: map[component.ID]health.StartFailurePolicy{component.MustNewID("otlp"): health.StartFailureDisable},
+	}
+	_, err := Build(context.Background(), set)
+	require.EqualError(t, err, `start failure policy references receiver "otlp" which is not used by any pipeline`)
+}
@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/service/internal/countconsumer"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+const (
+	zStatsPath = "statsz"
+
+	// zStatsRefresh is the number of seconds after which the statsz page reloads itself.
+	zStatsRefresh = 2
+)
+
+// statsInterval is the minimum interval between two samples of the counts. The rates are computed
+// over the interval between the last two samples, so that several viewers can reload the page at once.
+const statsInterval = time.Second
+
+// queueFiller is implemented by the exporters with a sending queue, such as the exporters of exporterhelper.
+type queueFiller interface {
+	QueueFill() (size, capacity int64)
+}
+
+// batchFiller is implemented by the exporters batching the data of their sending queue.
+type batchFiller interface {
+	BatchFill() (size, minSize int64)
+}
+
+// withCounts wraps the consumer of the given signal to count the items it accepts and refuses.
+func withCounts(signal pipeline.Signal, cons baseConsumer, counts *countconsumer.Counts) baseConsumer {
+	switch signal {
+	case pipeline.SignalTraces:
+		return countconsumer.NewTraces(cons.(consumer.Traces), counts)
+	case pipeline.SignalMetrics:
+		return countconsumer.NewMetrics(cons.(consumer.Metrics), counts)
+	case pipeline.SignalLogs:
+		return countconsumer.NewLogs(cons.(consumer.Logs), counts)
+	case xpipeline.SignalProfiles:
+		return countconsumer.NewProfiles(cons.(xconsumer.Profiles), counts)
+	}
+	return cons
+}
+
+// countOutcomes counts the items accepted and refused by the pipeline, including the ones refused while it is paused.
+func (n *capabilitiesNode) countOutcomes() {
+	n.counts = new(countconsumer.Counts)
+	counts := n.counts
+	if next := n.ConsumeTracesFunc; next != nil {
+		n.ConsumeTracesFunc = func(ctx context.Context, td ptrace.Traces) error {
+			items := td.SpanCount()
+			err := next(ctx, td)
+			counts.Record(items, err)
+			return err
+		}
+	}
+	if next := n.ConsumeMetricsFunc; next != nil {
+		n.ConsumeMetricsFunc = func(ctx context.Context, md pmetric.Metrics) error {
+			items := md.DataPointCount()
+			err := next(ctx, md)
+			counts.Record(items, err)
+			return err
+		}
+	}
+	if next := n.ConsumeLogsFunc; next != nil {
+		n.ConsumeLogsFunc = func(ctx context.Context, ld plog.Logs) error {
+			items := ld.LogRecordCount()
+			err := next(ctx, ld)
+			counts.Record(items, err)
+			return err
+		}
+	}
+	if next := n.ConsumeProfilesFunc; next != nil {
+		n.ConsumeProfilesFunc = func(ctx context.Context, pd pprofile.Profiles) error {
+			items := pd.SampleCount()
+			err := next(ctx, pd)
+			counts.Record(items, err)
+			return err
+		}
+	}
+}
+
+// itemRates are the numbers of items accepted and refused per second.
+type itemRates struct {
+	accepted float64
+	refused  float64
+}
+
+// liveStats samples the counts of the nodes to compute their rates. It is kept when the graph is
+// reconciled, so that the rates of the reused components are not reset. The counts are keyed by
+// identity, a recreated component starting over with new counts.
+type liveStats struct {
+	mu      sync.Mutex
+	sampled time.Time
+	prev    map[*countconsumer.Counts]countconsumer.Snapshot
+	rates   map[*countconsumer.Counts]itemRates
+}
+
+// sample returns the rates of the counts since the previous sample, or the last rates if it was taken
+// less than statsInterval ago. The counts seen for the first time have no rate yet.
+func (s *liveStats) sample(now time.Time, counts map[*countconsumer.Counts]countconsumer.Snapshot) map[*countconsumer.Counts]itemRates {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rates != nil && now.Sub(s.sampled) < statsInterval {
+		return s.rates
+	}
+	elapsed := now.Sub(s.sampled).Seconds()
+	rates := make(map[*countconsumer.Counts]itemRates, len(counts))
+	for c, cur := range counts {
+		if prev, ok := s.prev[c]; ok {
+			rates[c] = itemRates{
+				accepted: float64(cur.Accepted-prev.Accepted) / elapsed,
+				refused:  float64(cur.Refused-prev.Refused) / elapsed,
+			}
+		}
+	}
+	s.prev, s.sampled, s.rates = counts, now, rates
+	return rates
+}
+
+// liveCounts returns the current counts of all the nodes.
+func (g *Graph) liveCounts() map[*countconsumer.Counts]countconsumer.Snapshot {
+	counts := make(map[*countconsumer.Counts]countconsumer.Snapshot)
+	add := func(c *countconsumer.Counts) {
+		if c != nil {
+			counts[c] = c.Snapshot()
+		}
+	}
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		switch n := nodes.Node().(type) {
+		case *receiverNode:
+			add(n.counts)
+		case *capabilitiesNode:
+			add(n.counts)
+		case *processorNode:
+			add(n.counts)
+			add(n.produced)
+		case *exporterNode:
+			add(n.counts)
+		case *connectorNode:
+			add(n.counts)
+		}
+	}
+	return counts
+}
+
+// statsRow returns the row of a component with the rates of its counts, "-" while they are unknown.
+func statsRow(name string, rates map[*countconsumer.Counts]itemRates, counts *countconsumer.Counts) zpages.StatsTableRowData {
+	row := zpages.StatsTableRowData{Component: name, Accepted: "-", Refused: "-", Dropped: "-", Queue: "-", Batch: "-"}
+	if r, ok := rates[counts]; ok {
+		row.Accepted = formatRate(r.accepted)
+		row.Refused = formatRate(r.refused)
+	}
+	return row
+}
+
+// dropRate returns the rate of the items a processor dropped: the ones it accepted without producing them.
+func dropRate(rates map[*countconsumer.Counts]itemRates, n *processorNode) (float64, bool) {
+	consumed, ok := rates[n.counts]
+	if !ok {
+		return 0, false
+	}
+	produced, ok := rates[n.produced]
+	if !ok {
+		return 0, false
+	}
+	return max(0, consumed.accepted-produced.accepted-produced.refused), true
+}
+
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', 1, 64)
+}
+
+// formatFill formats the fill level of a queue or batch, "-" if it has no limit.
+func formatFill(size, limit int64) string {
+	if limit <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d (%d%%)", size, limit, size*100/limit)
+}
+
+// HandleStatsZPages shows the live rates of the items accepted, refused and dropped by each pipeline and
+// its components, and the fill levels of the sending queues and batches of the exporters. The page reloads
+// itself every few seconds.
+func (g *Graph) HandleStatsZPages(w http.ResponseWriter, _ *http.Request) {
+	rates := g.stats.sample(time.Now(), g.liveCounts())
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Pipeline Statistics", Refresh: zStatsRefresh})
+
+	pipelineIDs := make([]pipeline.ID, 0, len(g.pipelines))
+	for pipelineID := range g.pipelines {
+		pipelineIDs = append(pipelineIDs, pipelineID)
+	}
+	slices.SortFunc(pipelineIDs, func(a, b pipeline.ID) int { return strings.Compare(a.String(), b.String()) })
+
+	for _, pipelineID := range pipelineIDs {
+		p := g.pipelines[pipelineID]
+
+		var receivers, exporters []zpages.StatsTableRowData
+		for _, c := range p.receivers {
+			switch n := c.(type) {
+			case *receiverNode:
+				receivers = append(receivers, statsRow("receiver: "+n.componentID.String(), rates, n.counts))
+			case *connectorNode:
+				receivers = append(receivers, statsRow("connector: "+n.componentID.String(), rates, n.counts))
+			}
+		}
+		slices.SortFunc(receivers, func(a, b zpages.StatsTableRowData) int { return strings.Compare(a.Component, b.Component) })
+
+		intake := statsRow("pipeline intake", rates, p.capabilitiesNode.counts)
+		var dropped float64
+		droppedKnown := len(p.processors) == 0
+		processors := make([]zpages.StatsTableRowData, 0, len(p.processors))
+		for _, c := range p.processors {
+			n := c.(*processorNode)
+			row := statsRow("processor: "+n.componentID.String(), rates, n.counts)
+			if rate, ok := dropRate(rates, n); ok {
+				row.Dropped = formatRate(rate)
+				dropped += rate
+				droppedKnown = true
+			}
+			processors = append(processors, row)
+		}
+		if droppedKnown && intake.Accepted != "-" {
+			intake.Dropped = formatRate(dropped)
+		}
+
+		for _, c := range p.exporters {
+			switch n := c.(type) {
+			case *exporterNode:
+				row := statsRow("exporter: "+n.componentID.String(), rates, n.counts)
+				if q, ok := n.Component.(queueFiller); ok {
+					row.Queue = formatFill(q.QueueFill())
+				}
+				if b, ok := n.Component.(batchFiller); ok {
+					row.Batch = formatFill(b.BatchFill())
+				}
+				exporters = append(exporters, row)
+			case *connectorNode:
+				exporters = append(exporters, statsRow("connector: "+n.componentID.String(), rates, n.counts))
+			}
+		}
+		slices.SortFunc(exporters, func(a, b zpages.StatsTableRowData) int { return strings.Compare(a.Component, b.Component) })
+
+		zpages.WriteHTMLStatsTable(w, zpages.StatsTableData{
+			Name: pipelineID.String(),
+			Rows: slices.Concat([]zpages.StatsTableRowData{intake}, receivers, processors, exporters),
+		})
+	}
+	fmt.Fprintf(w, "<p>The rates are items per second over the last %s or more. The receivers, exporters and connectors "+
+		"shared by several pipelines show the same counts in each of them. The queue and batch fill levels are in the "+
+		"units of their sizers, the items being batched staying in the queue until exported.</p>\n", statsInterval)
+	zpages.WriteHTMLPageFooter(w)
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/health"
+	"go.opentelemetry.io/collector/service/internal/countconsumer"
+	"go.opentelemetry.io/collector/service/internal/status"
+)
+
+// fillingExporter reports the fill levels of a sending queue and batch, as the exporters of exporterhelper.
+type fillingExporter struct {
+	*failingExporter
+}
+
+func (fillingExporter) QueueFill() (int64, int64) { return 3, 10 }
+
+func (fillingExporter) BatchFill() (int64, int64) { return 0, 0 }
+
+func TestLiveStatsSample(t *testing.T) {
+	var s liveStats
+	c1, c2 := new(countconsumer.Counts), new(countconsumer.Counts)
+	now := time.Now()
+	assert.Empty(t, s.sample(now, map[*countconsumer.Counts]countconsumer.Snapshot{c1: c1.Snapshot()}))
+
+	c1.Record(10, nil)
+	c1.Record(4, assert.AnError)
+	// The last rates are reused within the interval.
+	assert.Empty(t, s.sample(now.Add(statsInterval/2), map[*countconsumer.Counts]countconsumer.Snapshot{c1: c1.Snapshot()}))
+
+	rates := s.sample(now.Add(2*time.Second), map[*countconsumer.Counts]countconsumer.Snapshot{c1: c1.Snapshot(), c2: c2.Snapshot()})
+	assert.Equal(t, map[*countconsumer.Counts]itemRates{c1: {accepted: 5, refused: 2}}, rates)
+}
+
+func TestDropRate(t *testing.T) {
+	n := &processorNode{counts: new(countconsumer.Counts), produced: new(countconsumer.Counts)}
+	_, ok := dropRate(map[*countconsumer.Counts]itemRates{n.counts: {accepted: 10}}, n)
+	assert.False(t, ok)
+
+	rate, ok := dropRate(map[*countconsumer.Counts]itemRates{n.counts: {accepted: 10}, n.produced: {accepted: 6, refused: 1}}, n)
+	assert.True(t, ok)
+	assert.InDelta(t, 3.0, rate, 1e-9)
+
+	// A processor producing more items than it consumed drops none.
+	rate, ok = dropRate(map[*countconsumer.Counts]itemRates{n.counts: {accepted: 10}, n.produced: {accepted: 20}}, n)
+	assert.True(t, ok)
+	assert.Zero(t, rate)
+}
+
+func TestHandleStatsZPages(t *testing.T) {
+	expFactory, _ := newFlakyExporterFactory(func(int64) *failingExporter {
+		return &failingExporter{Consumer: consumertest.NewNop()}
+	})
+	set := newRestartSettings(expFactory, health.RestartConfig{})
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	nodes := pg.componentGraph.Nodes()
+	for nodes.Next() {
+		if n, ok := nodes.Node().(*exporterNode); ok {
+			n.Component = fillingExporter{n.Component.(*failingExporter)}
+		}
+	}
+
+	// The first sample only takes the baseline of the rates.
+	rr := httptest.NewRecorder()
+	pg.HandleStatsZPages(rr, httptest.NewRequest("GET", "/", nil))
+	assert.Contains(t, rr.Body.String(), `<meta http-equiv="refresh" content="2">`)
+	assert.Contains(t, rr.Body.String(), "pipeline intake")
+	assert.Contains(t, rr.Body.String(), "exporter: flaky")
+	assert.Contains(t, rr.Body.String(), "3/10 (30%)")
+	assert.NotContains(t, rr.Body.String(), "2.0")
+
+	cons := pg.pipelines[pipeline.NewID(pipeline.SignalTraces)].capabilitiesNode.getConsumer().(consumer.Traces)
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(4)))
+	pg.stats.sampled = pg.stats.sampled.Add(-2 * time.Second)
+
+	rr = httptest.NewRecorder()
+	pg.HandleStatsZPages(rr, httptest.NewRequest("GET", "/", nil))
+	assert.Contains(t, rr.Body.String(), "2.0")
+	assert.Equal(t, countconsumer.Snapshot{Accepted: 4}, pg.pipelines[pipeline.NewID(pipeline.SignalTraces)].capabilitiesNode.counts.Snapshot())
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
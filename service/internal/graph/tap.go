@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/service/internal/tapconsumer"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+)
+
+const (
+	zTapPath = "tapz"
+
+	// URL Params
+	zTapFrom     = "from"
+	zTapTo       = "to"
+	zTapRate     = "rate"
+	zTapDuration = "duration"
+
+	defaultTapRate     = 1.0
+	maxTapRate         = 10.0
+	defaultTapDuration = time.Minute
+	maxTapDuration     = 10 * time.Minute
+	// The number of sampled batches buffered while they are written to the operator.
+	tapBufferSize = 16
+)
+
+// edgeKey identifies an edge of the graph by the IDs of its nodes.
+type edgeKey struct {
+	from, to int64
+}
+
+// edgeTaps holds the taps of the edges of the graph. It is kept when the graph is reconciled,
+// so that the taps of the edges still in the graph keep sampling.
+type edgeTaps struct {
+	mu   sync.Mutex
+	taps map[edgeKey]*tapconsumer.Tap
+}
+
+func newEdgeTaps() *edgeTaps {
+	return &edgeTaps{taps: make(map[edgeKey]*tapconsumer.Tap)}
+}
+
+func (et *edgeTaps) get(from, to int64) *tapconsumer.Tap {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+	key := edgeKey{from: from, to: to}
+	if et.taps[key] == nil {
+		et.taps[key] = new(tapconsumer.Tap)
+	}
+	return et.taps[key]
+}
+
+// tapEdge returns the consumer of the next node, sampled by the tap of the edge from the given node.
+func (g *Graph) tapEdge(from graph.Node, next graph.Node, cons baseConsumer) baseConsumer {
+	tap := g.taps.get(from.ID(), next.ID())
+	switch outputSignal(from) {
+	case pipeline.SignalTraces:
+		return tapconsumer.NewTraces(cons.(consumer.Traces), tap)
+	case pipeline.SignalMetrics:
+		return tapconsumer.NewMetrics(cons.(consumer.Metrics), tap)
+	case pipeline.SignalLogs:
+		return tapconsumer.NewLogs(cons.(consumer.Logs), tap)
+	case xpipeline.SignalProfiles:
+		return tapconsumer.NewProfiles(cons.(xconsumer.Profiles), tap)
+	}
+	return cons
+}
+
+// outputSignal returns the signal of the data emitted by the node.
+func outputSignal(node graph.Node) pipeline.Signal {
+	switch n := node.(type) {
+	case *receiverNode:
+		return n.pipelineType
+	case *capabilitiesNode:
+		return n.pipelineID.Signal()
+	case *processorNode:
+		return n.pipelineID.Signal()
+	case *fanOutNode:
+		return n.pipelineID.Signal()
+	case *connectorNode:
+		return n.rcvrPipelineType
+	case *shadowNode:
+		return n.pipelineID.Signal()
+	}
+	return pipeline.Signal{}
+}
+
+// findEdge returns the nodes of the edge between the nodes with the given IDs, as rendered by the graphz page.
+func (g *Graph) findEdge(from, to string) (graph.Node, graph.Node, bool) {
+	var fromNode, toNode graph.Node
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		switch id := nodeID(nodes.Node()); id {
+		case from:
+			fromNode = nodes.Node()
+		case to:
+			toNode = nodes.Node()
+		}
+	}
+	if fromNode == nil || toNode == nil || !g.componentGraph.HasEdgeFromTo(fromNode.ID(), toNode.ID()) {
+		return nil, nil, false
+	}
+	return fromNode, toNode, true
+}
+
+// HandleTapZPages streams a sample of the data passing an edge of the graph, as newline delimited
+// OTLP-JSON, at a bounded rate and for a bounded duration. Without edge, it lists the edges.
+func (g *Graph) HandleTapZPages(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get(zTapFrom) == "" && query.Get(zTapTo) == "" {
+		g.writeTapzEdges(w)
+		return
+	}
+	from, to, ok := g.findEdge(query.Get(zTapFrom), query.Get(zTapTo))
+	if !ok {
+		http.Error(w, "no such edge in the pipeline graph", http.StatusNotFound)
+		return
+	}
+	rate, duration := defaultTapRate, defaultTapDuration
+	var err error
+	if v := query.Get(zTapRate); v != "" {
+		if rate, err = strconv.ParseFloat(v, 64); err != nil || rate <= 0 || rate > maxTapRate {
+			http.Error(w, fmt.Sprintf("rate must be a number of batches per second in (0, %v]", maxTapRate), http.StatusBadRequest)
+			return
+		}
+	}
+	if v := query.Get(zTapDuration); v != "" {
+		if duration, err = time.ParseDuration(v); err != nil || duration <= 0 || duration > maxTapDuration {
+			http.Error(w, fmt.Sprintf("duration must be a positive duration of at most %v", maxTapDuration), http.StatusBadRequest)
+			return
+		}
+	}
+
+	session := tapconsumer.NewSession(time.Duration(float64(time.Second)/rate), tapBufferSize)
+	defer g.taps.get(from.ID(), to.ID()).Attach(session)()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	for {
+		select {
+		case sample := <-session.Samples():
+			if _, err = w.Write(append(sample, '\n')); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		case <-timer.C:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (g *Graph) writeTapzEdges(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Data Taps"})
+	fmt.Fprintf(w, "<p>Stream a sample of the data passing an edge as OTLP-JSON, at most %v batch per second for %v by default. "+
+		"Use the %s and %s parameters to change them.</p>\n", defaultTapRate, defaultTapDuration, zTapRate, zTapDuration)
+	fmt.Fprintln(w, "<ul>")
+	for _, e := range g.describe().Edges {
+		link := url.Values{zTapFrom: {e.From}, zTapTo: {e.To}}.Encode()
+		fmt.Fprintf(w, "<li><a href=\"?%s\">%s → %s</a></li>\n", template.HTMLEscapeString(link),
+			template.HTMLEscapeString(e.From), template.HTMLEscapeString(e.To))
+	}
+	fmt.Fprintln(w, "</ul>")
+	zpages.WriteHTMLPageFooter(w)
+}
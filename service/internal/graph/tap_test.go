@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+)
+
+func TestTapZPages(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	pg, err := Build(context.Background(), newDeadlineSettings(0, sink, 0, 0))
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	defer func() { require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter)) }()
+
+	srv := httptest.NewServer(http.HandlerFunc(pg.HandleTapZPages))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Tap the edge from the connector to the second pipeline.
+	desc := pg.describe()
+	kinds := make(map[string]string)
+	for _, n := range desc.Nodes {
+		kinds[n.ID] = n.Kind
+	}
+	var from, to string
+	for _, e := range desc.Edges {
+		if kinds[e.From] == "connector" {
+			from, to = e.From, e.To
+		}
+	}
+	require.NotEmpty(t, from)
+
+	for _, tt := range []struct {
+		query string
+		code  int
+	}{
+		{query: url.Values{zTapFrom: {to}, zTapTo: {from}}.Encode(), code: http.StatusNotFound},
+		{query: url.Values{zTapFrom: {from}, zTapTo: {to}, zTapRate: {"100"}}.Encode(), code: http.StatusBadRequest},
+		{query: url.Values{zTapFrom: {from}, zTapTo: {to}, zTapDuration: {"1h"}}.Encode(), code: http.StatusBadRequest},
+	} {
+		resp, err = http.Get(srv.URL + "?" + tt.query)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		assert.Equal(t, tt.code, resp.StatusCode, tt.query)
+	}
+
+	resp, err = http.Get(srv.URL + "?" + url.Values{zTapFrom: {from}, zTapTo: {to}, zTapDuration: {"1m"}}.Encode())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	td := testdata.GenerateTraces(2)
+	rcvr := pg.getReceivers()[pipeline.SignalTraces][component.MustNewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), td))
+	assert.Len(t, sink.AllTraces(), 1)
+
+	scanner := bufio.NewScanner(resp.Body)
+	require.True(t, scanner.Scan())
+	got, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(scanner.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, td.SpanCount(), got.SpanCount())
+}
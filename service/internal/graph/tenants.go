@@ -0,0 +1,375 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/attribute"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+const defaultMaxTenants = 100
+
+// validateTenants validates that the pipelines partitioned by tenant only export to exporters,
+// since the instances of the connectors cannot be partitioned.
+func (g *Graph) validateTenants() error {
+	for pipelineID, pn := range g.pipelines {
+		if g.set.PipelineConfigs[pipelineID].Tenants == nil {
+			continue
+		}
+		for _, node := range pn.exporters {
+			if n, isConnector := node.(*connectorNode); isConnector {
+				return fmt.Errorf("pipeline %q is partitioned by tenant and cannot export to connector %q", pipelineID, n.componentID)
+			}
+		}
+	}
+	return nil
+}
+
+// tenantRouter routes the data entering a pipeline partitioned by tenant to the processors and
+// exporters of its tenant, which are created and started when the tenant first sends data. The
+// data without tenant goes through the components of the pipeline in the graph.
+type tenantRouter struct {
+	graph      *Graph
+	pipelineID pipeline.ID
+	cfg        pipelines.TenantsConfig
+	shared     baseConsumer
+
+	mu        sync.Mutex
+	host      *Host
+	stopped   bool
+	instances map[string]*tenantInstance
+	wg        sync.WaitGroup
+
+	// evicting is set once the goroutine shutting down the idle tenants is started, and done is
+	// closed to stop it.
+	evicting bool
+	done     chan struct{}
+}
+
+// tenantInstance holds the processors and exporters of a pipeline for a tenant.
+type tenantInstance struct {
+	// ready is closed once the components are started, or failed to.
+	ready    chan struct{}
+	err      error
+	consumer baseConsumer
+
+	// The components, in the order they are started.
+	nodes []tenantNode
+
+	// The number of calls using the components, and the end of the last one, guarded by the
+	// mutex of the router.
+	active   int
+	lastUsed time.Time
+}
+
+// tenantNode is a component of a tenant, with the ID of the node of the graph it is an instance of.
+type tenantNode struct {
+	graph.Node
+	templateID int64
+}
+
+func newTenantRouter(g *Graph, pipelineID pipeline.ID, cfg pipelines.TenantsConfig, shared baseConsumer) *tenantRouter {
+	if cfg.MaxTenants == 0 {
+		cfg.MaxTenants = defaultMaxTenants
+	}
+	return &tenantRouter{
+		graph:      g,
+		pipelineID: pipelineID,
+		cfg:        cfg,
+		shared:     shared,
+		instances:  make(map[string]*tenantInstance),
+		done:       make(chan struct{}),
+	}
+}
+
+// setTenantsHost gives the host the components of the tenants start with to the routers of the graph.
+func (g *Graph) setTenantsHost(host *Host) {
+	for _, pn := range g.pipelines {
+		if r := pn.capabilitiesNode.tenants; r != nil {
+			r.mu.Lock()
+			r.host = host
+			r.mu.Unlock()
+		}
+	}
+}
+
+func (r *tenantRouter) Capabilities() consumer.Capabilities {
+	return r.shared.Capabilities()
+}
+
+func (r *tenantRouter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	next, release, err := r.consumer(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return next.(consumer.Traces).ConsumeTraces(ctx, td)
+}
+
+func (r *tenantRouter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	next, release, err := r.consumer(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return next.(consumer.Metrics).ConsumeMetrics(ctx, md)
+}
+
+func (r *tenantRouter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	next, release, err := r.consumer(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return next.(consumer.Logs).ConsumeLogs(ctx, ld)
+}
+
+func (r *tenantRouter) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	next, release, err := r.consumer(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return next.(xconsumer.Profiles).ConsumeProfiles(ctx, pd)
+}
+
+// consumer returns the consumer of the tenant of the data, from the client metadata of the context,
+// and the function to call once the data is consumed.
+func (r *tenantRouter) consumer(ctx context.Context) (baseConsumer, func(), error) {
+	values := client.FromContext(ctx).Metadata.Get(r.cfg.MetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return r.shared, func() {}, nil
+	}
+	inst, err := r.instance(values[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	return inst.consumer, func() { r.release(inst) }, nil
+}
+
+// instance returns the components of the tenant, created and started by the first call for the
+// tenant while the others wait for them. The components which fail to start are retried by the
+// next call. The components returned without error are not evicted until released.
+func (r *tenantRouter) instance(tenant string) (*tenantInstance, error) {
+	r.mu.Lock()
+	inst, ok := r.instances[tenant]
+	if ok {
+		inst.active++
+		r.mu.Unlock()
+		<-inst.ready
+		if inst.err != nil {
+			r.release(inst)
+		}
+		return inst, inst.err
+	}
+	switch {
+	case r.stopped:
+		r.mu.Unlock()
+		return nil, fmt.Errorf("pipeline %q is shut down", r.pipelineID)
+	case r.host == nil:
+		r.mu.Unlock()
+		return nil, fmt.Errorf("pipeline %q is not started", r.pipelineID)
+	case len(r.instances) >= r.cfg.MaxTenants:
+		r.mu.Unlock()
+		return nil, consumererror.NewPermanent(fmt.Errorf("pipeline %q reached its limit of %d tenants", r.pipelineID, r.cfg.MaxTenants))
+	}
+	inst = &tenantInstance{ready: make(chan struct{}), active: 1}
+	r.instances[tenant] = inst
+	host := r.host
+	r.wg.Add(1)
+	if r.cfg.IdleTimeout > 0 && !r.evicting {
+		r.evicting = true
+		r.wg.Add(1)
+		go r.evictIdle()
+	}
+	r.mu.Unlock()
+
+	inst.err = r.start(host, inst, tenant)
+	if inst.err != nil {
+		r.mu.Lock()
+		delete(r.instances, tenant)
+		r.mu.Unlock()
+		r.release(inst)
+	}
+	close(inst.ready)
+	r.wg.Done()
+	return inst, inst.err
+}
+
+// release marks the end of a call using the components of a tenant.
+func (r *tenantRouter) release(inst *tenantInstance) {
+	r.mu.Lock()
+	inst.active--
+	inst.lastUsed = time.Now()
+	r.mu.Unlock()
+}
+
+// evictIdle shuts down the components of the tenants which have not sent data for the idle
+// timeout, until the router is shut down. The tenants are started again by their next data.
+func (r *tenantRouter) evictIdle() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.cfg.IdleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.done:
+			return
+		case now := <-ticker.C:
+			r.evict(now.Add(-r.cfg.IdleTimeout))
+		}
+	}
+}
+
+// evict shuts down the components of the tenants unused since the given time.
+func (r *tenantRouter) evict(idleSince time.Time) {
+	r.mu.Lock()
+	idle := make(map[string]*tenantInstance)
+	for tenant, inst := range r.instances {
+		if inst.active == 0 && inst.lastUsed.Before(idleSince) {
+			idle[tenant] = inst
+			delete(r.instances, tenant)
+		}
+	}
+	r.mu.Unlock()
+
+	if err := r.shutdownInstances(context.Background(), idle); err != nil {
+		r.graph.telemetry.Logger.Warn("Failed to shut down the components of idle tenants", zap.Error(err))
+	}
+}
+
+// start creates the processors and exporters of the pipeline for the tenant, and starts them
+// downstream first. The components are shut down if one of them fails to start.
+func (r *tenantRouter) start(host *Host, inst *tenantInstance, tenant string) error {
+	g, set, pn := r.graph, r.graph.set, r.graph.pipelines[r.pipelineID]
+	ctx := context.Background()
+
+	// Sorted so that the exporters are started in the same order for all the tenants.
+	exporters := make([]*exporterNode, 0, len(pn.exporters))
+	for _, node := range pn.exporters {
+		exporters = append(exporters, node.(*exporterNode))
+	}
+	sort.Slice(exporters, func(i, j int) bool { return exporters[i].componentID.String() < exporters[j].componentID.String() })
+
+	nexts := make([]baseConsumer, 0, len(exporters))
+	for _, tmpl := range exporters {
+		// The exporters of the tenants have their own IDs, so that they do not share the resources
+		// identified by the ID of the exporter, e.g. the storage of their sending queues.
+		id := tenantID(tmpl.componentID, tenant)
+		n := newExporterNode(tmpl.pipelineType, id)
+		n.Attributes = attribute.Tenant(tmpl.Attributes, tenant)
+		n.deadlineGuard = tmpl.deadlineGuard
+		builder := set.ExporterBuilder.WithConfigs(map[component.ID]component.Config{id: set.ExporterBuilder.Config(tmpl.componentID)})
+		if err := n.buildComponent(ctx, set.Telemetry, set.BuildInfo, builder, nil); err != nil {
+			return err
+		}
+		inst.nodes = append(inst.nodes, tenantNode{Node: n, templateID: tmpl.ID()})
+		nexts = append(nexts, n.getConsumer())
+	}
+	next := fanOut(r.pipelineID.Signal(), nexts, fanOutClonePolicy(set.PipelineConfigs[r.pipelineID].ClonePolicy))
+	for i := len(pn.processors) - 1; i >= 0; i-- {
+		tmpl := pn.processors[i].(*processorNode)
+		n := newProcessorNode(r.pipelineID, tmpl.componentID)
+		n.Attributes = attribute.Tenant(n.Attributes, tenant)
+		if err := n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, next, nil); err != nil {
+			return err
+		}
+		guard := newDeadlineGuard(set.PipelineConfigs, component.KindProcessor, n.componentID)
+		n.consumer = withDeadlineGuard(r.pipelineID.Signal(), n.consumer, guard)
+		inst.nodes = append(inst.nodes, tenantNode{Node: n, templateID: tmpl.ID()})
+		next = n.getConsumer()
+	}
+	inst.consumer = next
+
+	for i, node := range inst.nodes {
+		// The components of the tenants report their status as the components of the pipeline.
+		instanceID := g.instanceIDs[node.templateID]
+		timeouts := set.Timeouts.For(instanceID.Kind(), instanceID.ComponentID())
+		err := g.lifecycle(node.Node).Call(ctx, timeouts.Start, func(ctx context.Context) error {
+			return node.Node.(component.Component).Start(ctx, &HostWrapper{Host: host, InstanceID: instanceID})
+		})
+		if err != nil {
+			_ = r.shutdownNodes(ctx, inst.nodes[:i])
+			return fmt.Errorf("failed to start the components of tenant %q of pipeline %q: %w", tenant, r.pipelineID, err)
+		}
+	}
+	return nil
+}
+
+// tenantID returns the ID of the instance of a component for a tenant, e.g. "otlp/a" for the
+// "otlp" exporter and tenant "a".
+func tenantID(id component.ID, tenant string) component.ID {
+	if id.Name() == "" {
+		return component.NewIDWithName(id.Type(), tenant)
+	}
+	return component.NewIDWithName(id.Type(), id.Name()+"/"+tenant)
+}
+
+// shutdownNodes shuts down the started components of a tenant, upstream first.
+func (r *tenantRouter) shutdownNodes(ctx context.Context, nodes []tenantNode) error {
+	var errs error
+	for i := len(nodes) - 1; i >= 0; i-- {
+		instanceID := r.graph.instanceIDs[nodes[i].templateID]
+		timeouts := r.graph.set.Timeouts.For(instanceID.Kind(), instanceID.ComponentID())
+		errs = multierr.Append(errs, r.graph.lifecycle(nodes[i].Node).Call(ctx, timeouts.Shutdown, nodes[i].Node.(component.Component).Shutdown))
+		r.graph.forgetLifecycle(nodes[i].Node)
+	}
+	return errs
+}
+
+// shutdownInstances shuts down the components of the given tenants, in the order of the tenants.
+func (r *tenantRouter) shutdownInstances(ctx context.Context, instances map[string]*tenantInstance) error {
+	tenants := make([]string, 0, len(instances))
+	for tenant := range instances {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+	var errs error
+	for _, tenant := range tenants {
+		if err := r.shutdownNodes(ctx, instances[tenant].nodes); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to shutdown the components of tenant %q of pipeline %q: %w", tenant, r.pipelineID, err))
+		}
+	}
+	return errs
+}
+
+// shutdown shuts down the components of all the tenants, once the tenants whose components are
+// starting are started. No tenant is added afterwards.
+func (r *tenantRouter) shutdown(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	if !r.stopped {
+		r.stopped = true
+		close(r.done)
+	}
+	r.mu.Unlock()
+	r.wg.Wait()
+
+	r.mu.Lock()
+	instances := r.instances
+	r.instances = nil
+	r.mu.Unlock()
+	return r.shutdownInstances(ctx, instances)
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func tenantContext(tenant string) context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {tenant}}),
+	})
+}
+
+func (g *Graph) tenantExporter(pipelineID pipeline.ID, tenant string) *testcomponents.ExampleExporter {
+	inst := g.pipelines[pipelineID].capabilitiesNode.tenants.instances[tenant]
+	return inst.nodes[0].Node.(*exporterNode).Component.(*testcomponents.ExampleExporter)
+}
+
+func TestTenants(t *testing.T) {
+	rcvrID, procID, expID := component.MustNewID("examplereceiver"), component.MustNewID("exampleprocessor"), component.MustNewID("exampleexporter")
+	tracesID := pipeline.NewID(pipeline.SignalTraces)
+	pg, err := Build(context.Background(), newReconcileSettings(pipelines.Config{
+		tracesID: {
+			Receivers: []component.ID{rcvrID}, Processors: []component.ID{procID}, Exporters: []component.ID{expID},
+			Tenants: &pipelines.TenantsConfig{MetadataKey: "x-tenant", MaxTenants: 2},
+		},
+	}, map[component.ID]component.Config{expID: &exampleConfig{}}))
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	rcvr := pg.exampleReceiver(rcvrID)
+	require.NoError(t, rcvr.ConsumeTraces(tenantContext("a"), testdata.GenerateTraces(1)))
+	require.NoError(t, rcvr.ConsumeTraces(tenantContext("b"), testdata.GenerateTraces(1)))
+	require.NoError(t, rcvr.ConsumeTraces(tenantContext("a"), testdata.GenerateTraces(1)))
+	require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+
+	// Each tenant has its own exporter, and the data without tenant goes through the pipeline's.
+	expA, expB := pg.tenantExporter(tracesID, "a"), pg.tenantExporter(tracesID, "b")
+	assert.NotSame(t, expA, expB)
+	assert.Len(t, expA.Traces, 2)
+	assert.Len(t, expB.Traces, 1)
+	assert.Len(t, pg.exampleExporter(expID).Traces, 1)
+	assert.True(t, expA.Started())
+
+	// The exporters of the tenants have their own IDs.
+	instA := pg.pipelines[tracesID].capabilitiesNode.tenants.instances["a"]
+	assert.Equal(t, component.MustNewIDWithName("exampleexporter", "a"), instA.nodes[0].Node.(*exporterNode).componentID)
+
+	// The tenants beyond the limit are refused.
+	err = rcvr.ConsumeTraces(tenantContext("c"), testdata.GenerateTraces(1))
+	require.EqualError(t, err, `Permanent error: pipeline "traces" reached its limit of 2 tenants`)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+	assert.True(t, expA.Stopped())
+	assert.True(t, expB.Stopped())
+}
+
+func TestTenantsIdleTimeout(t *testing.T) {
+	rcvrID, expID := component.MustNewID("examplereceiver"), component.MustNewID("exampleexporter")
+	tracesID := pipeline.NewID(pipeline.SignalTraces)
+	pg, err := Build(context.Background(), newReconcileSettings(pipelines.Config{
+		tracesID: {
+			Receivers: []component.ID{rcvrID}, Exporters: []component.ID{expID},
+			Tenants: &pipelines.TenantsConfig{MetadataKey: "x-tenant", MaxTenants: 1, IdleTimeout: 10 * time.Millisecond},
+		},
+	}, map[component.ID]component.Config{expID: &exampleConfig{}}))
+	require.NoError(t, err)
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	rcvr := pg.exampleReceiver(rcvrID)
+	require.NoError(t, rcvr.ConsumeTraces(tenantContext("a"), testdata.GenerateTraces(1)))
+	expA := pg.tenantExporter(tracesID, "a")
+
+	// The idle tenant is shut down, which makes room for another one.
+	router := pg.pipelines[tracesID].capabilitiesNode.tenants
+	assert.Eventually(t, func() bool {
+		router.mu.Lock()
+		defer router.mu.Unlock()
+		return len(router.instances) == 0
+	}, time.Second, 5*time.Millisecond)
+	require.NoError(t, rcvr.ConsumeTraces(tenantContext("b"), testdata.GenerateTraces(1)))
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+	assert.True(t, expA.Stopped())
+}
+
+func TestTenantsConnector(t *testing.T) {
+	set := newFeedbackSettings(nil, nil)
+	set.PipelineConfigs[pipeline.NewIDWithName(pipeline.SignalTraces, "in")].Tenants = &pipelines.TenantsConfig{MetadataKey: "x-tenant"}
+	_, err := Build(context.Background(), set)
+	require.EqualError(t, err, `pipeline "traces/in" is partitioned by tenant and cannot export to connector "exampleconnector"`)
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/health"
+)
+
+// validateTimeouts checks that the component timeouts only reference components of the graph.
+func (g *Graph) validateTimeouts(cfg health.TimeoutConfig) error {
+	type kindID struct {
+		kind component.Kind
+		id   component.ID
+	}
+	used := make(map[kindID]bool)
+	for _, instanceID := range g.instanceIDs {
+		used[kindID{kind: instanceID.Kind(), id: instanceID.ComponentID()}] = true
+	}
+
+	for _, overrides := range []struct {
+		kind     component.Kind
+		timeouts map[component.ID]health.ComponentTimeouts
+	}{
+		{kind: component.KindReceiver, timeouts: cfg.Receivers},
+		{kind: component.KindProcessor, timeouts: cfg.Processors},
+		{kind: component.KindExporter, timeouts: cfg.Exporters},
+		{kind: component.KindConnector, timeouts: cfg.Connectors},
+	} {
+		for id := range overrides.timeouts {
+			if !used[kindID{kind: overrides.kind, id: id}] {
+				return fmt.Errorf("timeouts reference %s %q which is not used by any pipeline", strings.ToLower(overrides.kind.String()), id)
+			}
+		}
+	}
+	return nil
+}
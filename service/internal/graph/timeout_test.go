@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/service/health"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/timeout"
+)
+
+// blockUntilDone blocks until the context passed to the lifecycle call is canceled.
+func blockUntilDone(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func newHangingExporterFactory(hangOnStart, hangOnShutdown bool) (component.ID, Settings) {
+	expFactory, _ := newFlakyExporterFactory(func(int64) *failingExporter {
+		exp := &failingExporter{Consumer: consumertest.NewNop()}
+		if hangOnStart {
+			exp.StartFunc = func(ctx context.Context, _ component.Host) error { return blockUntilDone(ctx) }
+		}
+		if hangOnShutdown {
+			exp.ShutdownFunc = blockUntilDone
+		}
+		return exp
+	})
+	return component.NewID(expFactory.Type()), newRestartSettings(expFactory, health.RestartConfig{})
+}
+
+func TestStartTimeout(t *testing.T) {
+	expID, set := newHangingExporterFactory(true, false)
+	set.Timeouts = health.TimeoutConfig{Start: 10 * time.Millisecond}
+
+	rec := &statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)}
+	host := &Host{Reporter: status.NewReporter(rec.record, func(error) {})}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	err = pg.StartAll(context.Background(), host)
+	require.ErrorIs(t, err, timeout.ErrTimeout)
+	assert.EqualError(t, err, `failed to start "flaky" exporter: timed out after 10ms`)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusStarting, componentstatus.StatusPermanentError}, rec.get(expID))
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestStartTimeoutContinue(t *testing.T) {
+	expID, set := newHangingExporterFactory(true, false)
+	set.Timeouts = health.TimeoutConfig{
+		Exporters: map[component.ID]health.ComponentTimeouts{
+			expID: {Start: 10 * time.Millisecond, ContinueOnStartTimeout: true},
+		},
+	}
+
+	rec := &statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)}
+	host := &Host{Reporter: status.NewReporter(rec.record, func(error) {})}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusStarting, componentstatus.StatusPermanentError}, rec.get(expID))
+	// The components upstream of the exporter are started.
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusStarting, componentstatus.StatusOK}, rec.get(component.MustNewID("examplereceiver")))
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	expID, set := newHangingExporterFactory(false, true)
+	set.Timeouts = health.TimeoutConfig{Shutdown: 10 * time.Millisecond}
+
+	rec := &statusRecorder{statuses: make(map[component.ID][]componentstatus.Status)}
+	host := &Host{Reporter: status.NewReporter(rec.record, func(error) {})}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+	err = pg.ShutdownAll(context.Background(), host.Reporter)
+	require.ErrorIs(t, err, timeout.ErrTimeout)
+	assert.EqualError(t, err, `failed to shutdown "flaky" exporter: timed out after 10ms`)
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusStarting,
+		componentstatus.StatusOK,
+		componentstatus.StatusStopping,
+		componentstatus.StatusPermanentError,
+	}, rec.get(expID))
+	// The other components are still shut down.
+	assert.Equal(t, componentstatus.StatusStopped, rec.get(component.MustNewID("examplereceiver"))[3])
+}
+
+func TestTimeoutsUnknownComponent(t *testing.T) {
+	_, set := newHangingExporterFactory(false, false)
+	set.Timeouts = health.TimeoutConfig{
+		Processors: map[component.ID]health.ComponentTimeouts{
+			component.MustNewID("batch"): {Start: time.Second},
+		},
+	}
+	_, err := Build(context.Background(), set)
+	require.EqualError(t, err, `timeouts reference processor "batch" which is not used by any pipeline`)
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+	"go.opentelemetry.io/collector/service/internal/usageconsumer"
+)
+
+// newUsage returns the usage of a component instance, or nil if the resource accounting
+// is disabled. The time is only tracked for the components consuming data, as receivers
+// produce data from their own goroutines.
+func newUsage(tb *metadata.TelemetryBuilder, consumes bool) *usageconsumer.Usage {
+	set := usageconsumer.Settings{
+		InFlightItems: tb.ComponentInFlightItems,
+		InFlightSize:  tb.ComponentInFlightSize,
+	}
+	if consumes {
+		set.BusyTime = tb.ComponentBusyTime
+	}
+	return usageconsumer.NewUsage(set)
+}
+
+// withUsage wraps the consumer of the given signal to account the resources used by the component.
+func withUsage(signal pipeline.Signal, cons baseConsumer, usage *usageconsumer.Usage) baseConsumer {
+	switch signal {
+	case pipeline.SignalTraces:
+		return usageconsumer.NewTraces(cons.(consumer.Traces), usage)
+	case pipeline.SignalMetrics:
+		return usageconsumer.NewMetrics(cons.(consumer.Metrics), usage)
+	case pipeline.SignalLogs:
+		return usageconsumer.NewLogs(cons.(consumer.Logs), usage)
+	case xpipeline.SignalProfiles:
+		return usageconsumer.NewProfiles(cons.(xconsumer.Profiles), usage)
+	}
+	return cons
+}
+
+// componentUsage returns the usage of all the instances of a component, summed across
+// the signals. The processors are only looked up in the given pipeline.
+func (g *Graph) componentUsage(kind, pipelineName, name string) usageconsumer.Snapshot {
+	var total usageconsumer.Snapshot
+	nodes := g.componentGraph.Nodes()
+	for nodes.Next() {
+		switch n := nodes.Node().(type) {
+		case *receiverNode:
+			if kind == "receiver" && n.componentID.String() == name {
+				total = total.Add(n.usage.Snapshot())
+			}
+		case *processorNode:
+			if kind == "processor" && n.pipelineID.String() == pipelineName && n.componentID.String() == name {
+				total = total.Add(n.usage.Snapshot())
+			}
+		case *exporterNode:
+			if kind == "exporter" && n.componentID.String() == name {
+				total = total.Add(n.usage.Snapshot())
+			}
+		case *connectorNode:
+			if kind == "connector" && n.componentID.String() == name {
+				total = total.Add(n.usage.Snapshot())
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/health"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/usageconsumer"
+)
+
+type slowConsumer struct {
+	consumertest.Consumer
+	consume func()
+}
+
+func (c slowConsumer) ConsumeTraces(context.Context, ptrace.Traces) error {
+	c.consume()
+	return nil
+}
+
+func TestComponentUsage(t *testing.T) {
+	setUsageConsumerGateForTest(t, true)
+
+	var pg *Graph
+	var during usageconsumer.Snapshot
+	expFactory, _ := newFlakyExporterFactory(func(int64) *failingExporter {
+		return &failingExporter{Consumer: slowConsumer{
+			Consumer: consumertest.NewNop(),
+			consume: func() {
+				during = pg.componentUsage("exporter", "", "flaky")
+				time.Sleep(10 * time.Millisecond)
+			},
+		}}
+	})
+
+	set := newRestartSettings(expFactory, health.RestartConfig{})
+	host := &Host{Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {})}
+
+	var err error
+	pg, err = Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pg.StartAll(context.Background(), host))
+
+	cons := pg.pipelines[pipeline.NewID(pipeline.SignalTraces)].capabilitiesNode.getConsumer().(consumer.Traces)
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.Equal(t, int64(2), during.InFlightItems)
+
+	usage := pg.componentUsage("exporter", "", "flaky")
+	assert.Zero(t, usage.InFlightItems)
+	assert.GreaterOrEqual(t, usage.BusyTime, 10*time.Millisecond)
+	assert.Zero(t, pg.componentUsage("receiver", "", "examplereceiver").BusyTime)
+
+	rr := httptest.NewRecorder()
+	pg.HandleZPages(rr, httptest.NewRequest("GET", "/?componentkindz=exporter&pipelinenamez=traces&componentnamez=flaky", nil))
+	assert.Contains(t, rr.Body.String(), "Resource usage")
+	assert.Contains(t, rr.Body.String(), "Busy time")
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+}
+
+func TestComponentUsageDisabled(t *testing.T) {
+	setUsageConsumerGateForTest(t, false)
+
+	expFactory, _ := newFlakyExporterFactory(func(int64) *failingExporter {
+		return &failingExporter{Consumer: consumertest.NewNop()}
+	})
+	pg, err := Build(context.Background(), newRestartSettings(expFactory, health.RestartConfig{}))
+	require.NoError(t, err)
+
+	nodes := pg.componentGraph.Nodes()
+	for nodes.Next() {
+		if n, ok := nodes.Node().(*exporterNode); ok {
+			assert.Nil(t, n.usage)
+		}
+	}
+
+	rr := httptest.NewRecorder()
+	pg.HandleZPages(rr, httptest.NewRequest("GET", "/?componentkindz=exporter&pipelinenamez=traces&componentnamez=flaky", nil))
+	assert.NotContains(t, rr.Body.String(), "Resource usage")
+}
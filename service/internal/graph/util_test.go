@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/processor/xprocessor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/xreceiver"
+	"go.opentelemetry.io/collector/service/internal/usageconsumer"
 	"go.opentelemetry.io/collector/service/pipelines"
 )
 
@@ -317,3 +318,11 @@ func setObsConsumerGateForTest(t *testing.T, enabled bool) {
 		require.NoError(t, featuregate.GlobalRegistry().Set(telemetry.NewPipelineTelemetryGate.ID(), initial))
 	})
 }
+
+func setUsageConsumerGateForTest(t *testing.T, enabled bool) {
+	initial := usageconsumer.ResourceAccountingGate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(usageconsumer.ResourceAccountingGate.ID(), enabled))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(usageconsumer.ResourceAccountingGate.ID(), initial))
+	})
+}
@@ -6,7 +6,9 @@ package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 import (
 	"net/http"
 	"sort"
+	"strconv"
 
+	"go.opentelemetry.io/collector/service/internal/usageconsumer"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
@@ -76,6 +78,17 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 			Name: componentKind + ": " + fullName,
 		})
 		// TODO: Add config + status info.
+		if usageconsumer.ResourceAccountingGate.IsEnabled() {
+			usage := g.componentUsage(componentKind, pipelineName, componentName)
+			zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{
+				Name: "Resource usage",
+				Properties: [][2]string{
+					{"In-flight items", strconv.FormatInt(usage.InFlightItems, 10)},
+					{"In-flight size (bytes)", strconv.FormatInt(usage.InFlightSize, 10)},
+					{"Busy time", usage.BusyTime.String()},
+				},
+			})
+		}
 	}
 	zpages.WriteHTMLPageFooter(w)
 }
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lazyconsumer wraps consumers to run a function, typically starting the
+// wrapped component, before any data is passed to them.
+package lazyconsumer // import "go.opentelemetry.io/collector/service/internal/lazyconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// BeforeFunc is called before every call to the wrapped consumer. If it returns an
+// error, the data is not passed to the consumer and the error is returned instead.
+type BeforeFunc func(context.Context) error
+
+func NewLogs(logs consumer.Logs, before BeforeFunc) consumer.Logs {
+	if before == nil {
+		return logs
+	}
+	return lazyLogs{Logs: logs, before: before}
+}
+
+type lazyLogs struct {
+	consumer.Logs
+	before BeforeFunc
+}
+
+func (c lazyLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if err := c.before(ctx); err != nil {
+		return err
+	}
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+func NewMetrics(metrics consumer.Metrics, before BeforeFunc) consumer.Metrics {
+	if before == nil {
+		return metrics
+	}
+	return lazyMetrics{Metrics: metrics, before: before}
+}
+
+type lazyMetrics struct {
+	consumer.Metrics
+	before BeforeFunc
+}
+
+func (c lazyMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if err := c.before(ctx); err != nil {
+		return err
+	}
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+func NewTraces(traces consumer.Traces, before BeforeFunc) consumer.Traces {
+	if before == nil {
+		return traces
+	}
+	return lazyTraces{Traces: traces, before: before}
+}
+
+type lazyTraces struct {
+	consumer.Traces
+	before BeforeFunc
+}
+
+func (c lazyTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if err := c.before(ctx); err != nil {
+		return err
+	}
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+func NewProfiles(profiles xconsumer.Profiles, before BeforeFunc) xconsumer.Profiles {
+	if before == nil {
+		return profiles
+	}
+	return lazyProfiles{Profiles: profiles, before: before}
+}
+
+type lazyProfiles struct {
+	xconsumer.Profiles
+	before BeforeFunc
+}
+
+func (c lazyProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	if err := c.before(ctx); err != nil {
+		return err
+	}
+	return c.Profiles.ConsumeProfiles(ctx, pd)
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lazyconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+// newBefore returns a BeforeFunc failing on its first call and counting its calls.
+func newBefore() (BeforeFunc, *int) {
+	calls := 0
+	return func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return assert.AnError
+		}
+		return nil
+	}, &calls
+}
+
+func TestNilBefore(t *testing.T) {
+	logs := consumertest.NewNop()
+	assert.Same(t, logs, NewLogs(logs, nil))
+	metrics := consumertest.NewNop()
+	assert.Same(t, metrics, NewMetrics(metrics, nil))
+	traces := consumertest.NewNop()
+	assert.Same(t, traces, NewTraces(traces, nil))
+	profiles := consumertest.NewNop()
+	assert.Same(t, profiles, NewProfiles(profiles, nil))
+}
+
+func TestLogs(t *testing.T) {
+	before, calls := newBefore()
+	sink := new(consumertest.LogsSink)
+	cons := NewLogs(sink, before)
+	require.ErrorIs(t, cons.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)), assert.AnError)
+	assert.Empty(t, sink.AllLogs())
+	require.NoError(t, cons.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.Len(t, sink.AllLogs(), 1)
+	assert.Equal(t, 2, *calls)
+}
+
+func TestMetrics(t *testing.T) {
+	before, calls := newBefore()
+	sink := new(consumertest.MetricsSink)
+	cons := NewMetrics(sink, before)
+	require.ErrorIs(t, cons.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)), assert.AnError)
+	assert.Empty(t, sink.AllMetrics())
+	require.NoError(t, cons.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	assert.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 2, *calls)
+}
+
+func TestTraces(t *testing.T) {
+	before, calls := newBefore()
+	sink := new(consumertest.TracesSink)
+	cons := NewTraces(sink, before)
+	require.ErrorIs(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)), assert.AnError)
+	assert.Empty(t, sink.AllTraces())
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, 2, *calls)
+}
+
+func TestProfiles(t *testing.T) {
+	before, calls := newBefore()
+	sink := new(consumertest.ProfilesSink)
+	cons := NewProfiles(sink, before)
+	require.ErrorIs(t, cons.ConsumeProfiles(context.Background(), testdata.GenerateProfiles(1)), assert.AnError)
+	assert.Empty(t, sink.AllProfiles())
+	require.NoError(t, cons.ConsumeProfiles(context.Background(), testdata.GenerateProfiles(1)))
+	assert.Len(t, sink.AllProfiles(), 1)
+	assert.Equal(t, 2, *calls)
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package limitconsumer // import "go.opentelemetry.io/collector/service/internal/limitconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// NewLogs applies the concurrency limit to the logs entering a pipeline.
+func NewLogs(logs consumer.Logs, l *Limit) consumer.Logs {
+	if l == nil {
+		return logs
+	}
+	return limitLogs{Logs: logs, limit: l}
+}
+
+type limitLogs struct {
+	consumer.Logs
+	limit *Limit
+}
+
+func (c limitLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if err := c.limit.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.limit.release()
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+// NewMetrics applies the concurrency limit to the metrics entering a pipeline.
+func NewMetrics(metrics consumer.Metrics, l *Limit) consumer.Metrics {
+	if l == nil {
+		return metrics
+	}
+	return limitMetrics{Metrics: metrics, limit: l}
+}
+
+type limitMetrics struct {
+	consumer.Metrics
+	limit *Limit
+}
+
+func (c limitMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if err := c.limit.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.limit.release()
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+// NewTraces applies the concurrency limit to the traces entering a pipeline.
+func NewTraces(traces consumer.Traces, l *Limit) consumer.Traces {
+	if l == nil {
+		return traces
+	}
+	return limitTraces{Traces: traces, limit: l}
+}
+
+type limitTraces struct {
+	consumer.Traces
+	limit *Limit
+}
+
+func (c limitTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if err := c.limit.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.limit.release()
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+// NewProfiles applies the concurrency limit to the profiles entering a pipeline.
+func NewProfiles(profiles xconsumer.Profiles, l *Limit) xconsumer.Profiles {
+	if l == nil {
+		return profiles
+	}
+	return limitProfiles{Profiles: profiles, limit: l}
+}
+
+type limitProfiles struct {
+	xconsumer.Profiles
+	limit *Limit
+}
+
+func (c limitProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	if err := c.limit.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.limit.release()
+	return c.Profiles.ConsumeProfiles(ctx, pd)
+}
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package limitconsumer bounds the number of concurrent calls flowing through a pipeline, so
+// that the concurrency of the processors does not depend on the concurrency of the receivers.
+package limitconsumer // import "go.opentelemetry.io/collector/service/internal/limitconsumer"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// Limit is the concurrency limit of a pipeline.
+type Limit struct {
+	pipelineID pipeline.ID
+	slots      chan struct{}
+}
+
+// NewLimit returns the concurrency limit of the given pipeline, or nil if the limit is not positive.
+func NewLimit(pipelineID pipeline.ID, limit int) *Limit {
+	if limit <= 0 {
+		return nil
+	}
+	return &Limit{pipelineID: pipelineID, slots: make(chan struct{}, limit)}
+}
+
+// acquire waits for a free slot, until the context is done.
+func (l *Limit) acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for the concurrency limit of %d of pipeline %q: %w", cap(l.slots), l.pipelineID.String(), ctx.Err())
+	}
+}
+
+// release frees the slot acquired by the call.
+func (l *Limit) release() {
+	<-l.slots
+}
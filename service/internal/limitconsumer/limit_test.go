@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package limitconsumer
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestDisabled(t *testing.T) {
+	assert.Nil(t, NewLimit(pipeline.NewID(pipeline.SignalLogs), 0))
+
+	cons := consumertest.NewNop()
+	assert.Same(t, cons, NewLogs(cons, nil))
+	assert.Same(t, cons, NewMetrics(cons, nil))
+	assert.Same(t, cons, NewTraces(cons, nil))
+	assert.Same(t, cons, NewProfiles(cons, nil))
+}
+
+func TestLimit(t *testing.T) {
+	const limit, calls = 2, 10
+	var inFlight, maxInFlight atomic.Int32
+	next, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			prev := maxInFlight.Load()
+			if n <= prev || maxInFlight.CompareAndSwap(prev, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+	require.NoError(t, err)
+	cons := NewTraces(next, NewLimit(pipeline.NewID(pipeline.SignalTraces), limit))
+
+	var wg sync.WaitGroup
+	for range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, cons.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(limit), maxInFlight.Load())
+}
+
+func TestLimitContextDone(t *testing.T) {
+	l := NewLimit(pipeline.NewID(pipeline.SignalLogs), 1)
+	release := make(chan struct{})
+	blocked, err := consumer.NewLogs(func(context.Context, plog.Logs) error {
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, NewLogs(blocked, l).ConsumeLogs(context.Background(), plog.NewLogs()))
+	}()
+	require.Eventually(t, func() bool { return len(l.slots) == 1 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	sink := new(consumertest.LogsSink)
+	err = NewLogs(sink, l).ConsumeLogs(ctx, plog.NewLogs())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.EqualError(t, err, `waiting for the concurrency limit of 1 of pipeline "logs": context deadline exceeded`)
+	assert.Empty(t, sink.AllLogs())
+
+	close(release)
+	<-done
+	require.NoError(t, NewLogs(sink, l).ConsumeLogs(context.Background(), plog.NewLogs()))
+	assert.Len(t, sink.AllLogs(), 1)
+}
+
+func TestSignals(t *testing.T) {
+	l := NewLimit(pipeline.NewID(pipeline.SignalMetrics), 1)
+	metrics := new(consumertest.MetricsSink)
+	require.NoError(t, NewMetrics(metrics, l).ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	assert.Len(t, metrics.AllMetrics(), 1)
+
+	profiles := new(consumertest.ProfilesSink)
+	require.NoError(t, NewProfiles(xconsumer.Profiles(profiles), l).ConsumeProfiles(context.Background(), pprofile.NewProfiles()))
+	assert.Len(t, profiles.AllProfiles(), 1)
+	assert.Empty(t, l.slots)
+}
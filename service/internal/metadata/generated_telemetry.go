@@ -28,12 +28,24 @@ type TelemetryBuilder struct {
 	meter                             metric.Meter
 	mu                                sync.Mutex
 	registrations                     []metric.Registration
+	ComponentBusyTime                 metric.Float64Counter
+	ComponentInFlightItems            metric.Int64UpDownCounter
+	ComponentInFlightSize             metric.Int64UpDownCounter
 	ConnectorConsumedItems            metric.Int64Counter
 	ConnectorConsumedSize             metric.Int64Counter
+	ConnectorConversionDroppedItems   metric.Int64Counter
+	ConnectorConversionDuration       metric.Float64Histogram
 	ConnectorProducedItems            metric.Int64Counter
 	ConnectorProducedSize             metric.Int64Counter
 	ExporterConsumedItems             metric.Int64Counter
 	ExporterConsumedSize              metric.Int64Counter
+	FeatureGateEnabled                metric.Int64ObservableGauge
+	GraphEdgeDuration                 metric.Float64Histogram
+	GraphEdgeItems                    metric.Int64Counter
+	GraphEdgeSize                     metric.Int64Counter
+	PipelineDisabledDroppedItems      metric.Int64Counter
+	PipelineLatency                   metric.Float64Histogram
+	PipelineShutdownLostItems         metric.Int64Counter
 	ProcessCPUSeconds                 metric.Float64ObservableCounter
 	ProcessMemoryRss                  metric.Int64ObservableGauge
 	ProcessRuntimeHeapAllocBytes      metric.Int64ObservableGauge
@@ -59,6 +71,21 @@ func (tbof telemetryBuilderOptionFunc) apply(mb *TelemetryBuilder) {
 	tbof(mb)
 }
 
+// RegisterFeatureGateEnabledCallback sets callback for observable FeatureGateEnabled metric.
+func (builder *TelemetryBuilder) RegisterFeatureGateEnabledCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.FeatureGateEnabled, obs: o})
+		return nil
+	}, builder.FeatureGateEnabled)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
 // RegisterProcessCPUSecondsCallback sets callback for observable ProcessCPUSeconds metric.
 func (builder *TelemetryBuilder) RegisterProcessCPUSecondsCallback(cb metric.Float64Callback) error {
 	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
@@ -187,6 +214,24 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 	}
 	builder.meter = Meter(settings)
 	var err, errs error
+	builder.ComponentBusyTime, err = builder.meter.Float64Counter(
+		"otelcol.component.busy_time",
+		metric.WithDescription("Time spent by the component consuming data, excluding the time spent in the downstream components called synchronously. Only recorded when the service.componentResourceAccounting feature gate is enabled."),
+		metric.WithUnit("s"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ComponentInFlightItems, err = builder.meter.Int64UpDownCounter(
+		"otelcol.component.in_flight.items",
+		metric.WithDescription("Number of items held by the component while it is consuming them. Only recorded when the service.componentResourceAccounting feature gate is enabled."),
+		metric.WithUnit("{item}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ComponentInFlightSize, err = builder.meter.Int64UpDownCounter(
+		"otelcol.component.in_flight.size",
+		metric.WithDescription("Size of items held by the component while it is consuming them, based on ProtoMarshaler.Sizer. Only recorded when the service.componentResourceAccounting feature gate is enabled."),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ConnectorConsumedItems, err = builder.meter.Int64Counter(
 		"otelcol.connector.consumed.items",
 		metric.WithDescription("Number of items passed to the connector."),
@@ -199,6 +244,18 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{item}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ConnectorConversionDroppedItems, err = builder.meter.Int64Counter(
+		"otelcol.connector.conversion.dropped.items",
+		metric.WithDescription("Number of items passed to the connector which the connector failed to consume, excluding the items refused by the next pipelines."),
+		metric.WithUnit("{item}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ConnectorConversionDuration, err = builder.meter.Float64Histogram(
+		"otelcol.connector.conversion.duration",
+		metric.WithDescription("Time spent by the connector consuming a payload, excluding the time spent in the next pipelines called synchronously."),
+		metric.WithUnit("s"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ConnectorProducedItems, err = builder.meter.Int64Counter(
 		"otelcol.connector.produced.items",
 		metric.WithDescription("Number of items emitted from the connector."),
@@ -223,6 +280,49 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{item}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.FeatureGateEnabled, err = builder.meter.Int64ObservableGauge(
+		"otelcol_feature_gate_enabled",
+		metric.WithDescription("Whether the feature gates not in their default state are enabled (1) or disabled (0), so that they can be tracked before upgrading the collector. [alpha]"),
+		metric.WithUnit("{state}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.GraphEdgeDuration, err = builder.meter.Float64Histogram(
+		"otelcol.graph.edge.duration",
+		metric.WithDescription("Time spent by the consuming node of an edge of the pipeline graph consuming a payload, including the time spent in the downstream nodes called synchronously. Only recorded when the telemetry level is detailed."),
+		metric.WithUnit("s"),
+	)
+	errs = errors.Join(errs, err)
+	builder.GraphEdgeItems, err = builder.meter.Int64Counter(
+		"otelcol.graph.edge.items",
+		metric.WithDescription("Number of items passed along an edge of the pipeline graph, from the producing node to the consuming node. Only recorded when the telemetry level is detailed."),
+		metric.WithUnit("{item}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.GraphEdgeSize, err = builder.meter.Int64Counter(
+		"otelcol.graph.edge.size",
+		metric.WithDescription("Size of items passed along an edge of the pipeline graph, based on ProtoMarshaler.Sizer. Only recorded when the telemetry level is detailed."),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	builder.PipelineDisabledDroppedItems, err = builder.meter.Int64Counter(
+		"otelcol.pipeline.disabled.dropped.items",
+		metric.WithDescription("Number of items dropped by the pipeline while it is disabled because one of its components failed to start."),
+		metric.WithUnit("{item}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.PipelineLatency, err = builder.meter.Float64Histogram(
+		"otelcol.pipeline.latency",
+		metric.WithDescription("Time from the data entering the pipeline until all its exporters exported it, including the time spent in the sending queues and the batch processors confirming the delivery. Only recorded for the pipelines with measure_latency enabled."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries([]float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300}...),
+	)
+	errs = errors.Join(errs, err)
+	builder.PipelineShutdownLostItems, err = builder.meter.Int64Counter(
+		"otelcol.pipeline.shutdown.lost.items",
+		metric.WithDescription("Number of items entering the pipeline after its processors or exporters started shutting down, which may not be processed."),
+		metric.WithUnit("{item}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessCPUSeconds, err = builder.meter.Float64ObservableCounter(
 		"otelcol_process_cpu_seconds",
 		metric.WithDescription("Total CPU user and system time in seconds [alpha]"),
@@ -20,6 +20,10 @@ func TestSetupTelemetry(t *testing.T) {
 	tb, err := metadata.NewTelemetryBuilder(testTel.NewTelemetrySettings())
 	require.NoError(t, err)
 	defer tb.Shutdown()
+	require.NoError(t, tb.RegisterFeatureGateEnabledCallback(func(_ context.Context, observer metric.Int64Observer) error {
+		observer.Observe(1)
+		return nil
+	}))
 	require.NoError(t, tb.RegisterProcessCPUSecondsCallback(func(_ context.Context, observer metric.Float64Observer) error {
 		observer.Observe(1)
 		return nil
@@ -44,24 +48,50 @@ func TestSetupTelemetry(t *testing.T) {
 		observer.Observe(1)
 		return nil
 	}))
+	tb.ComponentBusyTime.Add(context.Background(), 1)
+	tb.ComponentInFlightItems.Add(context.Background(), 1)
+	tb.ComponentInFlightSize.Add(context.Background(), 1)
 	tb.ConnectorConsumedItems.Add(context.Background(), 1)
 	tb.ConnectorConsumedSize.Add(context.Background(), 1)
+	tb.ConnectorConversionDroppedItems.Add(context.Background(), 1)
+	tb.ConnectorConversionDuration.Record(context.Background(), 1)
 	tb.ConnectorProducedItems.Add(context.Background(), 1)
 	tb.ConnectorProducedSize.Add(context.Background(), 1)
 	tb.ExporterConsumedItems.Add(context.Background(), 1)
 	tb.ExporterConsumedSize.Add(context.Background(), 1)
+	tb.GraphEdgeDuration.Record(context.Background(), 1)
+	tb.GraphEdgeItems.Add(context.Background(), 1)
+	tb.GraphEdgeSize.Add(context.Background(), 1)
+	tb.PipelineDisabledDroppedItems.Add(context.Background(), 1)
+	tb.PipelineLatency.Record(context.Background(), 1)
+	tb.PipelineShutdownLostItems.Add(context.Background(), 1)
 	tb.ProcessorConsumedItems.Add(context.Background(), 1)
 	tb.ProcessorConsumedSize.Add(context.Background(), 1)
 	tb.ProcessorProducedItems.Add(context.Background(), 1)
 	tb.ProcessorProducedSize.Add(context.Background(), 1)
 	tb.ReceiverProducedItems.Add(context.Background(), 1)
 	tb.ReceiverProducedSize.Add(context.Background(), 1)
+	AssertEqualComponentBusyTime(t, testTel,
+		[]metricdata.DataPoint[float64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualComponentInFlightItems(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualComponentInFlightSize(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualConnectorConsumedItems(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
 	AssertEqualConnectorConsumedSize(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualConnectorConversionDroppedItems(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualConnectorConversionDuration(t, testTel,
+		[]metricdata.HistogramDataPoint[float64]{{}}, metricdatatest.IgnoreValue(),
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualConnectorProducedItems(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
@@ -74,6 +104,27 @@ func TestSetupTelemetry(t *testing.T) {
 	AssertEqualExporterConsumedSize(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualFeatureGateEnabled(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualGraphEdgeDuration(t, testTel,
+		[]metricdata.HistogramDataPoint[float64]{{}}, metricdatatest.IgnoreValue(),
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualGraphEdgeItems(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualGraphEdgeSize(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualPipelineDisabledDroppedItems(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualPipelineLatency(t, testTel,
+		[]metricdata.HistogramDataPoint[float64]{{}}, metricdatatest.IgnoreValue(),
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualPipelineShutdownLostItems(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualProcessCPUSeconds(t, testTel,
 		[]metricdata.DataPoint[float64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
@@ -8,6 +8,15 @@ import "go.opentelemetry.io/collector/component"
 type ModuleInfo struct {
 	// BuilderRef is the raw string passed in the builder configuration used to build this service.
 	BuilderRef string
+
+	// Path is the path of the Go module providing the component, as recorded in the
+	// build information of the binary. It is empty if the module could not be found.
+	Path string
+
+	// Version is the version of the Go module providing the component, as recorded in the
+	// build information of the binary. It is "(devel)" for the main module and for the
+	// modules replaced by a local directory.
+	Version string
 }
 
 // ModuleInfos describes the go module for each component.
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sandboxing // import "go.opentelemetry.io/collector/service/internal/sandboxing"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+
+	"go.opentelemetry.io/collector/service/sandbox"
+)
+
+const (
+	landlockReadAccess = unix.LANDLOCK_ACCESS_FS_READ_FILE | unix.LANDLOCK_ACCESS_FS_READ_DIR
+
+	landlockWriteAccess = unix.LANDLOCK_ACCESS_FS_WRITE_FILE |
+		unix.LANDLOCK_ACCESS_FS_REMOVE_DIR |
+		unix.LANDLOCK_ACCESS_FS_REMOVE_FILE |
+		unix.LANDLOCK_ACCESS_FS_MAKE_CHAR |
+		unix.LANDLOCK_ACCESS_FS_MAKE_DIR |
+		unix.LANDLOCK_ACCESS_FS_MAKE_REG |
+		unix.LANDLOCK_ACCESS_FS_MAKE_SOCK |
+		unix.LANDLOCK_ACCESS_FS_MAKE_FIFO |
+		unix.LANDLOCK_ACCESS_FS_MAKE_BLOCK |
+		unix.LANDLOCK_ACCESS_FS_MAKE_SYM |
+		unix.LANDLOCK_ACCESS_FS_REFER |
+		unix.LANDLOCK_ACCESS_FS_TRUNCATE |
+		unix.LANDLOCK_ACCESS_FS_IOCTL_DEV
+
+	// landlockFileAccess are the access rights applying to files, the others only apply to directories.
+	landlockFileAccess = unix.LANDLOCK_ACCESS_FS_EXECUTE |
+		unix.LANDLOCK_ACCESS_FS_WRITE_FILE |
+		unix.LANDLOCK_ACCESS_FS_READ_FILE |
+		unix.LANDLOCK_ACCESS_FS_TRUNCATE |
+		unix.LANDLOCK_ACCESS_FS_IOCTL_DEV
+)
+
+// landlockHandledAccess returns the access rights to the filesystem supported by the version of the
+// Landlock ABI, which are all denied outside of the paths of the rules.
+func landlockHandledAccess(abi int) uint64 {
+	access := uint64(unix.LANDLOCK_ACCESS_FS_EXECUTE | landlockReadAccess | landlockWriteAccess)
+	if abi < 2 {
+		access &^= unix.LANDLOCK_ACCESS_FS_REFER
+	}
+	if abi < 3 {
+		access &^= unix.LANDLOCK_ACCESS_FS_TRUNCATE
+	}
+	if abi < 5 {
+		access &^= unix.LANDLOCK_ACCESS_FS_IOCTL_DEV
+	}
+	return access
+}
+
+// restrictFilesystem restricts the access to the filesystem to the configured paths with Landlock. The
+// files already open, like the listening sockets or the log files, are not affected.
+func restrictFilesystem(logger *zap.Logger, cfg sandbox.Config) error {
+	abi, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, 0, 0, unix.LANDLOCK_CREATE_RULESET_VERSION)
+	if errors.Is(errno, unix.ENOSYS) || errors.Is(errno, unix.EOPNOTSUPP) {
+		logger.Warn("Landlock is not supported by the kernel, the access to the filesystem is not restricted")
+		return nil
+	}
+	if errno != 0 {
+		return fmt.Errorf("failed to get the Landlock ABI version: %w", errno)
+	}
+
+	handled := landlockHandledAccess(int(abi))
+	attr := unix.LandlockRulesetAttr{Access_fs: handled}
+	fd, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr), 0)
+	if errno != 0 {
+		return fmt.Errorf("failed to create the Landlock ruleset: %w", errno)
+	}
+	defer unix.Close(int(fd))
+
+	readAccess := uint64(landlockReadAccess)
+	if cfg.AllowExec {
+		readAccess |= unix.LANDLOCK_ACCESS_FS_EXECUTE
+	}
+	for _, path := range cfg.ReadOnlyPaths {
+		if err := addLandlockRule(int(fd), path, readAccess&handled); err != nil {
+			return err
+		}
+	}
+	for _, path := range cfg.ReadWritePaths {
+		if err := addLandlockRule(int(fd), path, (readAccess|landlockWriteAccess)&handled); err != nil {
+			return err
+		}
+	}
+
+	if err := allThreadsSyscall(unix.SYS_LANDLOCK_RESTRICT_SELF, fd, 0, 0); err != nil {
+		return fmt.Errorf("failed to restrict the access to the filesystem: %w", err)
+	}
+	return nil
+}
+
+func addLandlockRule(rulesetFd int, path string, access uint64) error {
+	fd, err := unix.Open(path, unix.O_PATH|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open %q: %w", path, err)
+	}
+	defer unix.Close(fd)
+
+	var stat unix.Stat_t
+	if err = unix.Fstat(fd, &stat); err != nil {
+		return fmt.Errorf("failed to stat %q: %w", path, err)
+	}
+	if stat.Mode&unix.S_IFMT != unix.S_IFDIR {
+		access &= landlockFileAccess
+	}
+
+	attr := unix.LandlockPathBeneathAttr{Allowed_access: access, Parent_fd: int32(fd)} //nolint:gosec // G115 file descriptors fit in an int32
+	_, _, errno := unix.Syscall6(unix.SYS_LANDLOCK_ADD_RULE, uintptr(rulesetFd), unix.LANDLOCK_RULE_PATH_BENEATH, uintptr(unsafe.Pointer(&attr)), 0, 0, 0)
+	if errno != 0 {
+		return fmt.Errorf("failed to allow the access to %q: %w", path, errno)
+	}
+	return nil
+}
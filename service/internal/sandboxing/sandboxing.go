@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sandboxing applies the restrictions to the collector process configured by sandbox.Config.
+package sandboxing // import "go.opentelemetry.io/collector/service/internal/sandboxing"
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/service/sandbox"
+)
+
+var (
+	mu sync.Mutex
+	// applied is whether the restrictions were applied, since they cannot be lifted nor changed once
+	// applied, e.g. when the service is recreated to reload the configuration.
+	applied bool
+)
+
+// Apply applies the restrictions configured by cfg to the collector process, if enabled. It is called
+// once the components are started, since the restrictions can prevent them from opening their sockets
+// and files.
+func Apply(logger *zap.Logger, cfg sandbox.Config) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if applied {
+		logger.Info("Keeping the sandbox applied when the collector started, its changes take effect after a restart")
+		return nil
+	}
+	if err := apply(logger, cfg); err != nil {
+		return fmt.Errorf("failed to apply the sandbox: %w", err)
+	}
+	applied = true
+	logger.Info("Applied the sandbox",
+		zap.Strings("read_only_paths", cfg.ReadOnlyPaths),
+		zap.Strings("read_write_paths", cfg.ReadWritePaths),
+		zap.Bool("allow_exec", cfg.AllowExec),
+	)
+	return nil
+}
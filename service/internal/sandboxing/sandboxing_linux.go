@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sandboxing // import "go.opentelemetry.io/collector/service/internal/sandboxing"
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+
+	"go.opentelemetry.io/collector/service/sandbox"
+)
+
+var errCgo = errors.New("the sandbox requires a collector compiled without cgo")
+
+func apply(logger *zap.Logger, cfg sandbox.Config) error {
+	// Required to restrict the filesystem and filter the system calls without CAP_SYS_ADMIN, this also
+	// prevents the programs run by the collector from gaining privileges, e.g. with setuid.
+	if err := allThreadsSyscall(unix.SYS_PRCTL, unix.PR_SET_NO_NEW_PRIVS, 1, 0); err != nil {
+		return fmt.Errorf("failed to set no_new_privs: %w", err)
+	}
+	if err := restrictFilesystem(logger, cfg); err != nil {
+		return err
+	}
+	if err := dropCapabilities(); err != nil {
+		return err
+	}
+	return filterSyscalls(cfg.AllowExec)
+}
+
+// allThreadsSyscall makes the system call on all the threads of the process, since the restrictions
+// apply to the calling thread only. It is not supported by the Go runtime when cgo is enabled.
+func allThreadsSyscall(trap, a1, a2, a3 uintptr) error {
+	_, _, errno := syscall.AllThreadsSyscall(trap, a1, a2, a3)
+	switch errno {
+	case 0:
+		return nil
+	case syscall.ENOTSUP:
+		return errCgo
+	default:
+		return errno
+	}
+}
+
+// dropCapabilities clears the capabilities of the process. The bounding set is kept since, with
+// no_new_privs, the programs run by the collector cannot gain them back.
+func dropCapabilities() error {
+	if err := allThreadsSyscall(unix.SYS_PRCTL, unix.PR_CAP_AMBIENT, unix.PR_CAP_AMBIENT_CLEAR_ALL, 0); err != nil {
+		return fmt.Errorf("failed to clear the ambient capabilities: %w", err)
+	}
+	hdr := unix.CapUserHeader{Version: unix.LINUX_CAPABILITY_VERSION_3}
+	var data [2]unix.CapUserData
+	err := allThreadsSyscall(unix.SYS_CAPSET, uintptr(unsafe.Pointer(&hdr)), uintptr(unsafe.Pointer(&data[0])), 0)
+	runtime.KeepAlive(&hdr)
+	runtime.KeepAlive(&data)
+	if err != nil {
+		return fmt.Errorf("failed to drop the capabilities: %w", err)
+	}
+	return nil
+}
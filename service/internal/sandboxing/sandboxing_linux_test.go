@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sandboxing
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+
+	"go.opentelemetry.io/collector/service/sandbox"
+)
+
+const sandboxHelperEnv = "SANDBOX_HELPER_DIR"
+
+// runFilter runs the BPF instructions used by seccompFilter on the system call nr of arch.
+func runFilter(t *testing.T, filter []unix.SockFilter, arch, nr uint32) uint32 {
+	var acc uint32
+	for pc := 0; pc < len(filter); pc++ {
+		ins := filter[pc]
+		switch ins.Code {
+		case unix.BPF_LD | unix.BPF_W | unix.BPF_ABS:
+			acc = map[uint32]uint32{seccompDataNr: nr, seccompDataArch: arch}[ins.K]
+		case unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K:
+			pc += int(map[bool]uint8{true: ins.Jt, false: ins.Jf}[acc == ins.K])
+		case unix.BPF_JMP | unix.BPF_JGE | unix.BPF_K:
+			pc += int(map[bool]uint8{true: ins.Jt, false: ins.Jf}[acc >= ins.K])
+		case unix.BPF_RET | unix.BPF_K:
+			return ins.K
+		default:
+			t.Fatalf("unexpected instruction %v", ins)
+		}
+	}
+	t.Fatal("the filter does not return")
+	return 0
+}
+
+func TestSeccompFilter(t *testing.T) {
+	deny := unix.SECCOMP_RET_ERRNO | uint32(unix.EPERM)
+	filter := seccompFilter(unix.AUDIT_ARCH_X86_64, []uint32{101, 272})
+	assert.Equal(t, deny, runFilter(t, filter, unix.AUDIT_ARCH_X86_64, 101))
+	assert.Equal(t, deny, runFilter(t, filter, unix.AUDIT_ARCH_X86_64, 272))
+	assert.Equal(t, uint32(unix.SECCOMP_RET_ALLOW), runFilter(t, filter, unix.AUDIT_ARCH_X86_64, 0))
+	assert.Equal(t, deny, runFilter(t, filter, unix.AUDIT_ARCH_X86_64, x32SyscallBit|101))
+	assert.Equal(t, deny, runFilter(t, filter, unix.AUDIT_ARCH_I386, 0))
+
+	filter = seccompFilter(unix.AUDIT_ARCH_AARCH64, []uint32{117})
+	assert.Equal(t, deny, runFilter(t, filter, unix.AUDIT_ARCH_AARCH64, 117))
+	assert.Equal(t, uint32(unix.SECCOMP_RET_ALLOW), runFilter(t, filter, unix.AUDIT_ARCH_AARCH64, 63))
+}
+
+func TestLandlockHandledAccess(t *testing.T) {
+	assert.Zero(t, landlockHandledAccess(1)&(unix.LANDLOCK_ACCESS_FS_REFER|unix.LANDLOCK_ACCESS_FS_TRUNCATE|unix.LANDLOCK_ACCESS_FS_IOCTL_DEV))
+	assert.Equal(t, uint64(unix.LANDLOCK_ACCESS_FS_REFER), landlockHandledAccess(2)&^landlockHandledAccess(1))
+	assert.Equal(t, uint64(unix.LANDLOCK_ACCESS_FS_TRUNCATE), landlockHandledAccess(3)&^landlockHandledAccess(2))
+	assert.Equal(t, landlockHandledAccess(3), landlockHandledAccess(4))
+	assert.Equal(t, uint64(unix.LANDLOCK_ACCESS_FS_IOCTL_DEV), landlockHandledAccess(5)&^landlockHandledAccess(4))
+	assert.Equal(t, landlockHandledAccess(5), landlockHandledAccess(6))
+}
+
+func TestApplyDisabled(t *testing.T) {
+	require.NoError(t, Apply(zap.NewNop(), sandbox.Config{}))
+}
+
+// TestSandboxHelperProcess applies the sandbox in a process run by TestApply, since it cannot be lifted,
+// and prints the results of the operations it restricts.
+func TestSandboxHelperProcess(*testing.T) {
+	dir := os.Getenv(sandboxHelperEnv)
+	if dir == "" {
+		return
+	}
+	err := Apply(zap.NewNop(), sandbox.Config{
+		Enabled:        true,
+		ReadOnlyPaths:  []string{filepath.Join(dir, "read")},
+		ReadWritePaths: []string{filepath.Join(dir, "write")},
+	})
+	fmt.Println("apply:", err)
+	// A second call keeps the sandbox applied.
+	fmt.Println("reapply:", Apply(zap.NewNop(), sandbox.Config{Enabled: true}))
+
+	_, err = os.ReadFile(filepath.Join(dir, "read", "file"))
+	fmt.Println("read:", err)
+	fmt.Println("write:", os.WriteFile(filepath.Join(dir, "write", "file"), []byte("data"), 0o600))
+	fmt.Println("write read-only:", os.WriteFile(filepath.Join(dir, "read", "file"), []byte("data"), 0o600))
+	_, err = os.ReadFile(filepath.Join(dir, "denied"))
+	fmt.Println("read denied:", err)
+	// The standard streams are set since the access to /dev/null is denied.
+	cmd := exec.Command("/bin/true")
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stderr, os.Stderr
+	fmt.Println("exec:", cmd.Run())
+	fmt.Println("unshare:", unix.Unshare(unix.CLONE_NEWUSER))
+
+	var data [2]unix.CapUserData
+	err = unix.Capget(&unix.CapUserHeader{Version: unix.LINUX_CAPABILITY_VERSION_3}, &data[0])
+	fmt.Println("capabilities:", data[0].Effective|data[0].Permitted|data[1].Effective|data[1].Permitted, err)
+	os.Exit(0)
+}
+
+func TestApply(t *testing.T) {
+	if _, _, errno := syscall.AllThreadsSyscall(unix.SYS_PRCTL, unix.PR_GET_NO_NEW_PRIVS, 0, 0); errors.Is(errno, syscall.ENOTSUP) {
+		t.Skip("the sandbox requires a test binary compiled without cgo")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "read"), 0o700))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "write"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "read", "file"), []byte("data"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "denied"), []byte("data"), 0o600))
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestSandboxHelperProcess$")
+	cmd.Env = append(os.Environ(), sandboxHelperEnv+"="+dir)
+	out, err := cmd.Output()
+	require.NoError(t, err)
+	results := map[string]string{}
+	for line := range strings.Lines(string(out)) {
+		if key, value, ok := strings.Cut(strings.TrimSpace(line), ": "); ok {
+			results[key] = value
+		}
+	}
+
+	assert.Equal(t, "<nil>", results["apply"])
+	assert.Equal(t, "<nil>", results["reapply"])
+	assert.Equal(t, "<nil>", results["read"])
+	assert.Equal(t, "<nil>", results["write"])
+	assert.Equal(t, "fork/exec /bin/true: operation not permitted", results["exec"])
+	assert.Equal(t, "operation not permitted", results["unshare"])
+	assert.Equal(t, "0 <nil>", results["capabilities"])
+	if _, _, errno := unix.Syscall(unix.SYS_LANDLOCK_CREATE_RULESET, 0, 0, unix.LANDLOCK_CREATE_RULESET_VERSION); errno != 0 {
+		t.Logf("Landlock is not supported: %v", errno)
+		return
+	}
+	assert.Contains(t, results["write read-only"], "permission denied")
+	assert.Contains(t, results["read denied"], "permission denied")
+}
@@ -0,0 +1,18 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package sandboxing // import "go.opentelemetry.io/collector/service/internal/sandboxing"
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/service/sandbox"
+)
+
+func apply(*zap.Logger, sandbox.Config) error {
+	return errors.New("the sandbox is only supported on Linux")
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sandboxing // import "go.opentelemetry.io/collector/service/internal/sandboxing"
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// deniedSyscalls are the system calls administering the host or inspecting other processes, which the
+// collector does not need once started.
+var deniedSyscalls = []uint32{
+	unix.SYS_ACCT,
+	unix.SYS_ADD_KEY,
+	unix.SYS_BPF,
+	unix.SYS_CHROOT,
+	unix.SYS_CLOCK_SETTIME,
+	unix.SYS_DELETE_MODULE,
+	unix.SYS_FINIT_MODULE,
+	unix.SYS_FSCONFIG,
+	unix.SYS_FSMOUNT,
+	unix.SYS_FSOPEN,
+	unix.SYS_FSPICK,
+	unix.SYS_INIT_MODULE,
+	unix.SYS_KEXEC_LOAD,
+	unix.SYS_KEYCTL,
+	unix.SYS_MOUNT,
+	unix.SYS_MOUNT_SETATTR,
+	unix.SYS_MOVE_MOUNT,
+	unix.SYS_OPEN_BY_HANDLE_AT,
+	unix.SYS_OPEN_TREE,
+	unix.SYS_PERF_EVENT_OPEN,
+	unix.SYS_PIVOT_ROOT,
+	unix.SYS_PROCESS_VM_READV,
+	unix.SYS_PROCESS_VM_WRITEV,
+	unix.SYS_PTRACE,
+	unix.SYS_QUOTACTL,
+	unix.SYS_REBOOT,
+	unix.SYS_REQUEST_KEY,
+	unix.SYS_SETNS,
+	unix.SYS_SETTIMEOFDAY,
+	unix.SYS_SWAPOFF,
+	unix.SYS_SWAPON,
+	unix.SYS_UMOUNT2,
+	unix.SYS_UNSHARE,
+	unix.SYS_USERFAULTFD,
+}
+
+// execSyscalls are the system calls running programs, denied unless allowed by the configuration.
+var execSyscalls = []uint32{
+	unix.SYS_EXECVE,
+	unix.SYS_EXECVEAT,
+}
+
+const (
+	// The offsets of the fields of struct seccomp_data.
+	seccompDataNr   = 0
+	seccompDataArch = 4
+
+	// x32SyscallBit marks the system calls of the x32 ABI on x86_64, which have their own numbers.
+	x32SyscallBit = 0x40000000
+)
+
+// auditArch returns the AUDIT_ARCH value of the architecture the collector is compiled for, which the
+// seccomp filter checks since the system call numbers depend on it.
+func auditArch() (uint32, bool) {
+	switch runtime.GOARCH {
+	case "386":
+		return unix.AUDIT_ARCH_I386, true
+	case "amd64":
+		return unix.AUDIT_ARCH_X86_64, true
+	case "arm":
+		return unix.AUDIT_ARCH_ARM, true
+	case "arm64":
+		return unix.AUDIT_ARCH_AARCH64, true
+	case "loong64":
+		return unix.AUDIT_ARCH_LOONGARCH64, true
+	case "mips":
+		return unix.AUDIT_ARCH_MIPS, true
+	case "mipsle":
+		return unix.AUDIT_ARCH_MIPSEL, true
+	case "mips64":
+		return unix.AUDIT_ARCH_MIPS64, true
+	case "mips64le":
+		return unix.AUDIT_ARCH_MIPSEL64, true
+	case "ppc64":
+		return unix.AUDIT_ARCH_PPC64, true
+	case "ppc64le":
+		return unix.AUDIT_ARCH_PPC64LE, true
+	case "riscv64":
+		return unix.AUDIT_ARCH_RISCV64, true
+	case "s390x":
+		return unix.AUDIT_ARCH_S390X, true
+	default:
+		return 0, false
+	}
+}
+
+// seccompFilter returns the BPF program of the seccomp filter denying the syscalls with EPERM, as well
+// as any system call of another architecture.
+func seccompFilter(arch uint32, syscalls []uint32) []unix.SockFilter {
+	filter := []unix.SockFilter{
+		bpfStmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompDataArch),
+		bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, arch, 0, 0),
+		bpfStmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, seccompDataNr),
+	}
+	if arch == unix.AUDIT_ARCH_X86_64 {
+		filter = append(filter, bpfJump(unix.BPF_JMP|unix.BPF_JGE|unix.BPF_K, x32SyscallBit, 0, 0))
+	}
+	for _, nr := range syscalls {
+		filter = append(filter, bpfJump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, nr, 0, 0))
+	}
+	filter = append(filter,
+		bpfStmt(unix.BPF_RET|unix.BPF_K, unix.SECCOMP_RET_ALLOW),
+		bpfStmt(unix.BPF_RET|unix.BPF_K, unix.SECCOMP_RET_ERRNO|uint32(unix.EPERM)),
+	)
+
+	// Point the jumps to the last instruction denying the system call: the architecture check when it
+	// does not match, the other checks when they match.
+	deny := len(filter) - 1
+	filter[1].Jf = uint8(deny - 2) //nolint:gosec // G115 the filter is shorter than 256 instructions
+	for i := 3; i < deny-1; i++ {
+		filter[i].Jt = uint8(deny - i - 1) //nolint:gosec // G115 the filter is shorter than 256 instructions
+	}
+	return filter
+}
+
+func bpfStmt(code uint16, k uint32) unix.SockFilter {
+	return unix.SockFilter{Code: code, K: k}
+}
+
+func bpfJump(code uint16, k uint32, jt, jf uint8) unix.SockFilter {
+	return unix.SockFilter{Code: code, Jt: jt, Jf: jf, K: k}
+}
+
+// filterSyscalls installs the seccomp filter on all the threads of the process.
+func filterSyscalls(allowExec bool) error {
+	arch, ok := auditArch()
+	if !ok {
+		return fmt.Errorf("filtering the system calls is not supported on %s", runtime.GOARCH)
+	}
+	syscalls := deniedSyscalls
+	if !allowExec {
+		syscalls = append(syscalls[:len(syscalls):len(syscalls)], execSyscalls...)
+	}
+	filter := seccompFilter(arch, syscalls)
+	prog := unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]} //nolint:gosec // G115 the filter is shorter than 256 instructions
+	_, _, errno := unix.Syscall(unix.SYS_SECCOMP, unix.SECCOMP_SET_MODE_FILTER, unix.SECCOMP_FILTER_FLAG_TSYNC, uintptr(unsafe.Pointer(&prog)))
+	runtime.KeepAlive(filter)
+	if errno != 0 {
+		return fmt.Errorf("failed to filter the system calls: %w", errno)
+	}
+	return nil
+}
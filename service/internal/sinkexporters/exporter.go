@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sinkexporters // import "go.opentelemetry.io/collector/service/internal/sinkexporters"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Factories returns the factories of exporters dropping the data, in place of the given
+// factories. The exporters keep the type, configuration and supported signals of the replaced
+// exporters, so that the configuration is still validated.
+func Factories(factories map[component.Type]exporter.Factory) map[component.Type]exporter.Factory {
+	sinks := make(map[component.Type]exporter.Factory, len(factories))
+	for typ, f := range factories {
+		var opts []xexporter.FactoryOption
+		if sl := f.TracesStability(); sl != component.StabilityLevelUndefined {
+			opts = append(opts, xexporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+				return sink{}, nil
+			}, sl))
+		}
+		if sl := f.MetricsStability(); sl != component.StabilityLevelUndefined {
+			opts = append(opts, xexporter.WithMetrics(func(context.Context, exporter.Settings, component.Config) (exporter.Metrics, error) {
+				return sink{}, nil
+			}, sl))
+		}
+		if sl := f.LogsStability(); sl != component.StabilityLevelUndefined {
+			opts = append(opts, xexporter.WithLogs(func(context.Context, exporter.Settings, component.Config) (exporter.Logs, error) {
+				return sink{}, nil
+			}, sl))
+		}
+		if xf, ok := f.(xexporter.Factory); ok {
+			if sl := xf.ProfilesStability(); sl != component.StabilityLevelUndefined {
+				opts = append(opts, xexporter.WithProfiles(func(context.Context, exporter.Settings, component.Config) (xexporter.Profiles, error) {
+					return sink{}, nil
+				}, sl))
+			}
+		}
+		sinks[typ] = xexporter.NewFactory(typ, f.CreateDefaultConfig, opts...)
+	}
+	return sinks
+}
+
+// sink is an exporter dropping the data.
+type sink struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (sink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
+
+func (sink) ConsumeTraces(context.Context, ptrace.Traces) error {
+	return nil
+}
+
+func (sink) ConsumeMetrics(context.Context, pmetric.Metrics) error {
+	return nil
+}
+
+func (sink) ConsumeLogs(context.Context, plog.Logs) error {
+	return nil
+}
+
+func (sink) ConsumeProfiles(context.Context, pprofile.Profiles) error {
+	return nil
+}
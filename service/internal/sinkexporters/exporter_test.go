@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sinkexporters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestFactories(t *testing.T) {
+	typ := component.MustNewType("traces_only")
+	factory := exporter.NewFactory(typ, func() component.Config { return &struct{ Endpoint string }{} },
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			return nil, assert.AnError
+		}, component.StabilityLevelBeta))
+
+	sinks := Factories(map[component.Type]exporter.Factory{typ: factory})
+	sinkFactory := sinks[typ]
+	require.NotNil(t, sinkFactory)
+	assert.Equal(t, typ, sinkFactory.Type())
+	assert.Equal(t, factory.CreateDefaultConfig(), sinkFactory.CreateDefaultConfig())
+	assert.Equal(t, component.StabilityLevelBeta, sinkFactory.TracesStability())
+	assert.Equal(t, component.StabilityLevelUndefined, sinkFactory.LogsStability())
+
+	exp, err := sinkFactory.CreateTraces(context.Background(), exportertest.NewNopSettings(typ), sinkFactory.CreateDefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	_, err = sinkFactory.CreateLogs(context.Background(), exportertest.NewNopSettings(typ), sinkFactory.CreateDefaultConfig())
+	require.Error(t, err)
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sinkexporters runs the pipelines without exporting their data: the exporters are replaced
+// by sinks, and the throughput of each pipeline is logged instead.
+package sinkexporters // import "go.opentelemetry.io/collector/service/internal/sinkexporters"
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+const defaultInterval = 10 * time.Second
+
+// Stats counts the items entering each pipeline, and logs their throughput periodically.
+type Stats struct {
+	logger   *zap.Logger
+	interval time.Duration
+
+	mu      sync.Mutex
+	counts  map[pipeline.ID]*atomic.Int64
+	started time.Time
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewStats returns the stats logged with the given logger.
+func NewStats(logger *zap.Logger) *Stats {
+	return &Stats{
+		logger:   logger,
+		interval: defaultInterval,
+		counts:   make(map[pipeline.ID]*atomic.Int64),
+	}
+}
+
+// Counter returns the counter of the items entering the pipeline. The counter is kept when the
+// pipeline is rebuilt.
+func (s *Stats) Counter(pipelineID pipeline.ID) *atomic.Int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count, ok := s.counts[pipelineID]
+	if !ok {
+		count = new(atomic.Int64)
+		s.counts[pipelineID] = count
+	}
+	return count
+}
+
+// Start logs the throughput of the pipelines at each interval, until Stop is called.
+func (s *Stats) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopCh != nil {
+		return
+	}
+	s.started = time.Now()
+	s.stopCh = make(chan struct{})
+	s.wg.Add(1)
+	go s.run(s.stopCh)
+}
+
+func (s *Stats) run(stopCh chan struct{}) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	prev := s.snapshot()
+	last := time.Now()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case now := <-ticker.C:
+			counts := s.snapshot()
+			elapsed := now.Sub(last).Seconds()
+			for _, pipelineID := range sortedIDs(counts) {
+				items := counts[pipelineID] - prev[pipelineID]
+				s.logger.Info("Sink exporters throughput",
+					zap.String("pipeline", pipelineID.String()),
+					zap.Int64("items", items),
+					zap.Float64("items_per_second", float64(items)/elapsed),
+				)
+			}
+			prev, last = counts, now
+		}
+	}
+}
+
+// Stop stops logging the throughput periodically, and logs the items entering each pipeline
+// since Start.
+func (s *Stats) Stop() {
+	s.mu.Lock()
+	stopCh := s.stopCh
+	s.stopCh = nil
+	s.mu.Unlock()
+	if stopCh == nil {
+		return
+	}
+	close(stopCh)
+	s.wg.Wait()
+
+	elapsed := time.Since(s.started).Seconds()
+	counts := s.snapshot()
+	for _, pipelineID := range sortedIDs(counts) {
+		s.logger.Info("Sink exporters total",
+			zap.String("pipeline", pipelineID.String()),
+			zap.Int64("items", counts[pipelineID]),
+			zap.Float64("items_per_second", float64(counts[pipelineID])/elapsed),
+		)
+	}
+}
+
+func (s *Stats) snapshot() map[pipeline.ID]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := make(map[pipeline.ID]int64, len(s.counts))
+	for pipelineID, count := range s.counts {
+		counts[pipelineID] = count.Load()
+	}
+	return counts
+}
+
+func sortedIDs(counts map[pipeline.ID]int64) []pipeline.ID {
+	ids := make([]pipeline.ID, 0, len(counts))
+	for pipelineID := range counts {
+		ids = append(ids, pipelineID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids
+}
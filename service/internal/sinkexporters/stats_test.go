@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sinkexporters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestStats(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	stats := NewStats(zap.New(core))
+	stats.interval = 10 * time.Millisecond
+
+	tracesID, logsID := pipeline.NewID(pipeline.SignalTraces), pipeline.NewID(pipeline.SignalLogs)
+	stats.Counter(tracesID).Add(5)
+	assert.Same(t, stats.Counter(tracesID), stats.Counter(tracesID))
+	stats.Start()
+	stats.Counter(logsID).Add(2)
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("Sink exporters throughput").Len() >= 2
+	}, time.Second, 5*time.Millisecond)
+	stats.Counter(tracesID).Add(3)
+	stats.Stop()
+
+	totals := logs.FilterMessage("Sink exporters total").All()
+	require.Len(t, totals, 2)
+	assert.Equal(t, "logs", totals[0].ContextMap()["pipeline"])
+	assert.Equal(t, int64(2), totals[0].ContextMap()["items"])
+	assert.Equal(t, "traces", totals[1].ContextMap()["pipeline"])
+	assert.Equal(t, int64(8), totals[1].ContextMap()["items"])
+
+	// Stopping twice does not log the totals again.
+	stats.Stop()
+	assert.Equal(t, 2, logs.FilterMessage("Sink exporters total").Len())
+}
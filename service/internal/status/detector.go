@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status // import "go.opentelemetry.io/collector/service/internal/status"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/service/health"
+)
+
+// The signals the status of a component is derived from.
+const (
+	// signalConsume is the outcome of the calls made to the component.
+	signalConsume = "consume"
+	// signalSend is the outcome of the sending of items by an exporter, including from its queue.
+	signalSend = "send"
+	// signalQueue is the saturation of the sending queue of an exporter.
+	signalQueue = "queue"
+	// signalRefuse is the acceptance of items by a receiver.
+	signalRefuse = "refuse"
+)
+
+// Detector derives the status of components from the outcome of the calls made to them,
+// and from the internal metrics they record, so that components which do not report
+// status themselves are still reported as degraded when they keep failing.
+type Detector struct {
+	reporter           Reporter
+	failureThreshold   int
+	recoveryThreshold  int
+	queueSaturation    float64
+	queueCheckInterval time.Duration
+
+	// queues holds the sending queues checked periodically, by the goroutine running while
+	// there is at least one.
+	mu     sync.Mutex
+	queues map[*queue]struct{}
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewDetector returns a Detector reporting to the given Reporter, or nil if detection is disabled.
+func NewDetector(reporter Reporter, cfg health.DetectionConfig) *Detector {
+	if !cfg.Enabled {
+		return nil
+	}
+	d := &Detector{
+		reporter:           reporter,
+		failureThreshold:   cfg.FailureThreshold,
+		recoveryThreshold:  cfg.RecoveryThreshold,
+		queueSaturation:    cfg.QueueSaturation,
+		queueCheckInterval: cfg.QueueCheckInterval,
+	}
+	if d.failureThreshold == 0 {
+		d.failureThreshold = health.DefaultFailureThreshold
+	}
+	if d.recoveryThreshold == 0 {
+		d.recoveryThreshold = health.DefaultRecoveryThreshold
+	}
+	if d.queueSaturation == 0 {
+		d.queueSaturation = health.DefaultQueueSaturation
+	}
+	if d.queueCheckInterval == 0 {
+		d.queueCheckInterval = health.DefaultQueueCheckInterval
+	}
+	return d
+}
+
+// Tracker returns a Tracker for the given component instance. Returns nil if the Detector is nil.
+func (d *Detector) Tracker(id *componentstatus.InstanceID) *Tracker {
+	if d == nil {
+		return nil
+	}
+	return &Tracker{detector: d, id: id}
+}
+
+// addQueue starts checking the queue, and the goroutine checking the queues if it is the first one.
+func (d *Detector) addQueue(q *queue) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.queues == nil {
+		d.queues = make(map[*queue]struct{})
+	}
+	d.queues[q] = struct{}{}
+	if d.stop == nil {
+		d.stop, d.done = make(chan struct{}), make(chan struct{})
+		go d.checkQueues(d.stop, d.done)
+	}
+}
+
+// removeQueue stops checking the queue, and stops the goroutine checking the queues if it was the last one.
+func (d *Detector) removeQueue(q *queue) {
+	d.mu.Lock()
+	delete(d.queues, q)
+	if len(d.queues) > 0 || d.stop == nil {
+		d.mu.Unlock()
+		return
+	}
+	stop, done := d.stop, d.done
+	d.stop, d.done = nil, nil
+	d.mu.Unlock()
+	close(stop)
+	<-done
+}
+
+func (d *Detector) checkQueues(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(d.queueCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			d.mu.Lock()
+			queues := make([]*queue, 0, len(d.queues))
+			for q := range d.queues {
+				queues = append(queues, q)
+			}
+			d.mu.Unlock()
+			for _, q := range queues {
+				q.check(context.Background())
+			}
+		}
+	}
+}
+
+// Tracker records the outcome of the signals of a single component instance.
+type Tracker struct {
+	detector *Detector
+	id       *componentstatus.InstanceID
+
+	mu       sync.Mutex
+	signals  map[string]*signalState
+	degraded bool
+	// queue is the sending queue of the exporter, if it has one.
+	queue *queue
+}
+
+// signalState holds the consecutive outcomes of a signal of a component.
+type signalState struct {
+	failures  int
+	successes int
+	failed    bool
+}
+
+// Record records the outcome of a call made to the component. A nil error is a success.
+func (t *Tracker) Record(err error) {
+	t.record(signalConsume, err)
+}
+
+// record records an outcome of the signal. A nil error is a success.
+func (t *Tracker) record(signal string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.signals == nil {
+		t.signals = make(map[string]*signalState)
+	}
+	state, ok := t.signals[signal]
+	if !ok {
+		state = &signalState{}
+		t.signals[signal] = state
+	}
+
+	if err != nil {
+		state.successes = 0
+		state.failures++
+		if !state.failed && state.failures >= t.detector.failureThreshold {
+			state.failed = true
+			if !t.degraded {
+				t.degraded = true
+				t.detector.reporter.ReportStatus(t.id, componentstatus.NewRecoverableErrorEvent(
+					fmt.Errorf("component failed %d consecutive times: %w", state.failures, err)))
+			}
+		}
+		return
+	}
+
+	state.failures = 0
+	if !state.failed {
+		return
+	}
+	state.successes++
+	if state.successes < t.detector.recoveryThreshold {
+		return
+	}
+	state.failed = false
+	state.successes = 0
+	for _, other := range t.signals {
+		if other.failed {
+			return
+		}
+	}
+	t.degraded = false
+	t.detector.reporter.ReportStatus(t.id, componentstatus.NewEvent(componentstatus.StatusOK))
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/service/health"
+)
+
+func TestDetectorDisabled(t *testing.T) {
+	det := NewDetector(NewNopStatusReporter(), health.DetectionConfig{})
+	assert.Nil(t, det)
+	assert.Nil(t, det.Tracker(&componentstatus.InstanceID{}))
+}
+
+func TestDetectorThresholds(t *testing.T) {
+	id := &componentstatus.InstanceID{}
+	var statuses []componentstatus.Status
+	rep := NewReporter(func(_ *componentstatus.InstanceID, ev *componentstatus.Event) {
+		statuses = append(statuses, ev.Status())
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+	rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusStarting))
+	rep.ReportOKIfStarting(id)
+	statuses = nil
+
+	tracker := NewDetector(rep, health.DetectionConfig{
+		Enabled:           true,
+		FailureThreshold:  3,
+		RecoveryThreshold: 2,
+	}).Tracker(id)
+
+	// A success resets the consecutive failures.
+	tracker.Record(assert.AnError)
+	tracker.Record(assert.AnError)
+	tracker.Record(nil)
+	tracker.Record(assert.AnError)
+	tracker.Record(assert.AnError)
+	assert.Empty(t, statuses)
+
+	tracker.Record(assert.AnError)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, statuses)
+
+	// Further failures do not report again.
+	tracker.Record(assert.AnError)
+	tracker.Record(nil)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, statuses)
+
+	tracker.Record(nil)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, statuses)
+}
+
+func TestDetectorDefaults(t *testing.T) {
+	det := NewDetector(NewNopStatusReporter(), health.DetectionConfig{Enabled: true})
+	assert.Equal(t, health.DefaultFailureThreshold, det.failureThreshold)
+	assert.Equal(t, health.DefaultRecoveryThreshold, det.recoveryThreshold)
+}
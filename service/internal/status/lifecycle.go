@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status // import "go.opentelemetry.io/collector/service/internal/status"
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+// Lifecycle tracks the state of the collector in its lifecycle, from the phase of the service and,
+// while it runs, the status of its components.
+type Lifecycle struct {
+	mu sync.Mutex
+	// phase is the state set by the service, LifecycleReady while it runs.
+	phase hostcapabilities.LifecycleState
+	state hostcapabilities.LifecycleState
+	// failing are the component instances reporting an error.
+	failing map[*componentstatus.InstanceID]struct{}
+	// configFailing is whether the collector configuration failed to reload.
+	configFailing bool
+	watchers      map[int]*lifecycleWatcher
+	nextWatcherID int
+
+	// notifyMu serializes the calls to the watchers, made without holding mu so that they can
+	// read the state.
+	notifyMu sync.Mutex
+}
+
+// lifecycleWatcher is a function watching the state, with the last state it was called with.
+// last is guarded by notifyMu.
+type lifecycleWatcher struct {
+	fn   func(hostcapabilities.LifecycleState)
+	last hostcapabilities.LifecycleState
+}
+
+// NewLifecycle returns a Lifecycle in the LifecycleStarting state.
+func NewLifecycle() *Lifecycle {
+	return &Lifecycle{
+		phase:    hostcapabilities.LifecycleStarting,
+		state:    hostcapabilities.LifecycleStarting,
+		failing:  map[*componentstatus.InstanceID]struct{}{},
+		watchers: map[int]*lifecycleWatcher{},
+	}
+}
+
+// SetPhase sets the phase of the service: LifecycleReady once it started, LifecycleDraining when
+// it starts shutting down, and LifecycleStopped once it shut down.
+func (l *Lifecycle) SetPhase(phase hostcapabilities.LifecycleState) {
+	l.mu.Lock()
+	l.phase = phase
+	l.update()
+	l.mu.Unlock()
+	l.notify()
+}
+
+// ComponentStatusChanged implements componentstatus.Watcher, to track the failing components.
+func (l *Lifecycle) ComponentStatusChanged(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+	l.mu.Lock()
+	switch ev.Status() {
+	case componentstatus.StatusRecoverableError, componentstatus.StatusPermanentError, componentstatus.StatusFatalError:
+		l.failing[id] = struct{}{}
+	default:
+		delete(l.failing, id)
+	}
+	l.update()
+	l.mu.Unlock()
+	l.notify()
+}
+
+// SetConfigStatus records whether the collector configuration failed to reload, the collector
+// keeping running its last good configuration.
+func (l *Lifecycle) SetConfigStatus(err error) {
+	l.mu.Lock()
+	l.configFailing = err != nil
+	l.update()
+	l.mu.Unlock()
+	l.notify()
+}
+
+// update computes the state. Must be called holding mu.
+func (l *Lifecycle) update() {
+	state := l.phase
+	if state == hostcapabilities.LifecycleReady && (len(l.failing) > 0 || l.configFailing) {
+		state = hostcapabilities.LifecycleDegraded
+	}
+	l.state = state
+}
+
+// notify calls the watchers with the current state, if it changed since they were last called.
+// The watchers are copied, and called without holding mu. As the calls are serialized and always
+// made with the current state, a watcher never observes an older state after a newer one.
+func (l *Lifecycle) notify() {
+	l.notifyMu.Lock()
+	defer l.notifyMu.Unlock()
+	l.mu.Lock()
+	state := l.state
+	watchers := make([]*lifecycleWatcher, 0, len(l.watchers))
+	for _, w := range l.watchers {
+		watchers = append(watchers, w)
+	}
+	l.mu.Unlock()
+
+	for _, w := range watchers {
+		if w.last != state {
+			w.last = state
+			w.fn(state)
+		}
+	}
+}
+
+// LifecycleState returns the current state of the collector.
+func (l *Lifecycle) LifecycleState() hostcapabilities.LifecycleState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.state
+}
+
+// WatchLifecycleState registers the function to be called with every change of the state, after
+// calling it with the current state. The calls are serialized, and are made without holding the
+// lock of the state, so the function can read it. The returned function unregisters it, after
+// which it is not called anymore; it must not be called from the function.
+func (l *Lifecycle) WatchLifecycleState(fn func(hostcapabilities.LifecycleState)) func() {
+	l.notifyMu.Lock()
+	defer l.notifyMu.Unlock()
+	l.mu.Lock()
+	w := &lifecycleWatcher{fn: fn, last: l.state}
+	watcherID := l.nextWatcherID
+	l.nextWatcherID++
+	l.watchers[watcherID] = w
+	l.mu.Unlock()
+
+	fn(w.last)
+	return func() {
+		l.notifyMu.Lock()
+		defer l.notifyMu.Unlock()
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.watchers, watcherID)
+	}
+}
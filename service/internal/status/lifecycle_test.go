@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+func TestLifecycle(t *testing.T) {
+	l := NewLifecycle()
+	var states []hostcapabilities.LifecycleState
+	unwatch := l.WatchLifecycleState(func(state hostcapabilities.LifecycleState) {
+		states = append(states, state)
+	})
+
+	id1 := componentstatus.NewInstanceID(component.MustNewID("a"), component.KindReceiver)
+	id2 := componentstatus.NewInstanceID(component.MustNewID("b"), component.KindExporter)
+	// Errors while starting do not change the state.
+	l.ComponentStatusChanged(id1, componentstatus.NewRecoverableErrorEvent(errors.New("err")))
+	assert.Equal(t, hostcapabilities.LifecycleStarting, l.LifecycleState())
+
+	l.SetPhase(hostcapabilities.LifecycleReady)
+	assert.Equal(t, hostcapabilities.LifecycleDegraded, l.LifecycleState())
+	l.ComponentStatusChanged(id2, componentstatus.NewPermanentErrorEvent(errors.New("err")))
+	l.ComponentStatusChanged(id1, componentstatus.NewEvent(componentstatus.StatusOK))
+	assert.Equal(t, hostcapabilities.LifecycleDegraded, l.LifecycleState())
+	l.ComponentStatusChanged(id2, componentstatus.NewEvent(componentstatus.StatusStarting))
+	assert.Equal(t, hostcapabilities.LifecycleReady, l.LifecycleState())
+
+	l.SetPhase(hostcapabilities.LifecycleDraining)
+	l.ComponentStatusChanged(id1, componentstatus.NewFatalErrorEvent(errors.New("err")))
+	assert.Equal(t, hostcapabilities.LifecycleDraining, l.LifecycleState())
+	l.SetPhase(hostcapabilities.LifecycleStopped)
+
+	unwatch()
+	l.SetPhase(hostcapabilities.LifecycleStarting)
+	assert.Equal(t, []hostcapabilities.LifecycleState{
+		hostcapabilities.LifecycleStarting,
+		hostcapabilities.LifecycleDegraded,
+		hostcapabilities.LifecycleReady,
+		hostcapabilities.LifecycleDraining,
+		hostcapabilities.LifecycleStopped,
+	}, states)
+}
+
+func TestLifecycleWatcherReadsState(t *testing.T) {
+	l := NewLifecycle()
+	var states []hostcapabilities.LifecycleState
+	unwatch := l.WatchLifecycleState(func(hostcapabilities.LifecycleState) {
+		// The watchers are called without holding the lock of the state.
+		states = append(states, l.LifecycleState())
+	})
+	l.SetPhase(hostcapabilities.LifecycleReady)
+	l.SetConfigStatus(errors.New("err"))
+	l.SetConfigStatus(nil)
+	unwatch()
+	l.SetPhase(hostcapabilities.LifecycleDraining)
+
+	assert.Equal(t, []hostcapabilities.LifecycleState{
+		hostcapabilities.LifecycleStarting,
+		hostcapabilities.LifecycleReady,
+		hostcapabilities.LifecycleDegraded,
+		hostcapabilities.LifecycleReady,
+	}, states)
+}
+
+func TestLifecycleStateProbes(t *testing.T) {
+	tests := []struct {
+		state                   hostcapabilities.LifecycleState
+		name                    string
+		started, ready, isAlive bool
+	}{
+		{state: hostcapabilities.LifecycleStarting, name: "Starting", isAlive: true},
+		{state: hostcapabilities.LifecycleReady, name: "Ready", started: true, ready: true, isAlive: true},
+		{state: hostcapabilities.LifecycleDegraded, name: "Degraded", started: true, ready: true, isAlive: true},
+		{state: hostcapabilities.LifecycleDraining, name: "Draining", started: true, isAlive: true},
+		{state: hostcapabilities.LifecycleStopped, name: "Stopped", started: true},
+		{state: hostcapabilities.LifecycleState(-1), name: "Unknown", started: true, isAlive: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.name, tt.state.String())
+			assert.Equal(t, tt.started, tt.state.Started())
+			assert.Equal(t, tt.ready, tt.state.Ready())
+			assert.Equal(t, tt.isAlive, tt.state.Alive())
+		})
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status // import "go.opentelemetry.io/collector/service/internal/status"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+
+	"go.opentelemetry.io/collector/internal/telemetry"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// counterSignal is the signal recorded by a counter of the exporter and receiver helpers.
+type counterSignal struct {
+	signal string
+	// failure formats the error of the items counted, nil if they are a success.
+	failure func(items int64) error
+}
+
+func failure(format string) func(int64) error {
+	return func(items int64) error {
+		return fmt.Errorf(format, items)
+	}
+}
+
+// counterSignals are the signals of the counters recorded by the exporter and receiver helpers, by name.
+var counterSignals = map[string]counterSignal{
+	"otelcol_exporter_sent_spans":                   {signal: signalSend},
+	"otelcol_exporter_sent_metric_points":           {signal: signalSend},
+	"otelcol_exporter_sent_log_records":             {signal: signalSend},
+	"otelcol_exporter_send_failed_spans":            {signal: signalSend, failure: failure("failed to send %d spans")},
+	"otelcol_exporter_send_failed_metric_points":    {signal: signalSend, failure: failure("failed to send %d metric points")},
+	"otelcol_exporter_send_failed_log_records":      {signal: signalSend, failure: failure("failed to send %d log records")},
+	"otelcol_exporter_enqueue_failed_spans":         {signal: signalQueue, failure: failure("sending queue is full, failed to enqueue %d spans")},
+	"otelcol_exporter_enqueue_failed_metric_points": {signal: signalQueue, failure: failure("sending queue is full, failed to enqueue %d metric points")},
+	"otelcol_exporter_enqueue_failed_log_records":   {signal: signalQueue, failure: failure("sending queue is full, failed to enqueue %d log records")},
+	"otelcol_receiver_accepted_spans":               {signal: signalRefuse},
+	"otelcol_receiver_accepted_metric_points":       {signal: signalRefuse},
+	"otelcol_receiver_accepted_log_records":         {signal: signalRefuse},
+	"otelcol_receiver_refused_spans":                {signal: signalRefuse, failure: failure("refused %d spans")},
+	"otelcol_receiver_refused_metric_points":        {signal: signalRefuse, failure: failure("refused %d metric points")},
+	"otelcol_receiver_refused_log_records":          {signal: signalRefuse, failure: failure("refused %d log records")},
+}
+
+// The counters of the items of the exporter and receiver helpers, split by their outcome and signal.
+const (
+	exporterItemsCounter = "otelcol_exporter_items"
+	receiverItemsCounter = "otelcol_receiver_items"
+)
+
+// itemNames are the names of the items of the signals, in the errors of the failures.
+var itemNames = map[string]string{
+	pipeline.SignalTraces.String():  "spans",
+	pipeline.SignalMetrics.String(): "metric points",
+	pipeline.SignalLogs.String():    "log records",
+}
+
+// The gauges of the sending queue recorded by the exporter helper.
+const (
+	queueSizeGauge     = "otelcol_exporter_queue_size"
+	queueCapacityGauge = "otelcol_exporter_queue_capacity"
+)
+
+// MeterProvider returns the MeterProvider of the component, feeding the tracker with the
+// items sent, failed to be sent, enqueued and refused recorded by the exporter and receiver
+// helpers, and with the saturation of the sending queue of the exporter. Returns mp if the
+// Tracker is nil.
+func (t *Tracker) MeterProvider(mp metric.MeterProvider) metric.MeterProvider {
+	if t == nil {
+		return mp
+	}
+	return &trackingMeterProvider{MeterProvider: mp, tracker: t}
+}
+
+type trackingMeterProvider struct {
+	metric.MeterProvider
+	tracker *Tracker
+}
+
+func (mp *trackingMeterProvider) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	return &trackingMeter{Meter: mp.MeterProvider.Meter(name, opts...), tracker: mp.tracker}
+}
+
+type trackingMeter struct {
+	metric.Meter
+	tracker *Tracker
+}
+
+func (m *trackingMeter) Int64Counter(name string, opts ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	c, err := m.Meter.Int64Counter(name, opts...)
+	if err != nil {
+		return c, err
+	}
+	if signal, ok := counterSignals[name]; ok {
+		return &trackingCounter{Int64Counter: c, tracker: m.tracker, counterSignal: signal}, nil
+	}
+	if name == exporterItemsCounter || name == receiverItemsCounter {
+		return &itemsCounter{Int64Counter: c, tracker: m.tracker, exporter: name == exporterItemsCounter}, nil
+	}
+	return c, nil
+}
+
+func (m *trackingMeter) Int64ObservableGauge(name string, opts ...metric.Int64ObservableGaugeOption) (metric.Int64ObservableGauge, error) {
+	g, err := m.Meter.Int64ObservableGauge(name, opts...)
+	if err != nil || (name != queueSizeGauge && name != queueCapacityGauge) {
+		return g, err
+	}
+	return &queueGauge{Int64ObservableGauge: g, capacity: name == queueCapacityGauge}, nil
+}
+
+// RegisterCallback registers the callback, which is also called to check the sending queue
+// if it observes its size or its capacity.
+func (m *trackingMeter) RegisterCallback(f metric.Callback, instruments ...metric.Observable) (metric.Registration, error) {
+	observesQueue := false
+	unwrapped := make([]metric.Observable, len(instruments))
+	for i, inst := range instruments {
+		if g, ok := inst.(*queueGauge); ok {
+			observesQueue = true
+			inst = g.Int64ObservableGauge
+		}
+		unwrapped[i] = inst
+	}
+	if !observesQueue {
+		return m.Meter.RegisterCallback(f, instruments...)
+	}
+	reg, err := m.Meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		return f(ctx, unwrappingObserver{Observer: o})
+	}, unwrapped...)
+	if err != nil {
+		return reg, err
+	}
+	q := m.tracker.sendingQueue()
+	cb := q.add(f)
+	return &queueRegistration{Registration: reg, queue: q, callback: cb}, nil
+}
+
+// queueGauge is a gauge of the sending queue of the exporter helper, observed to check the queue.
+type queueGauge struct {
+	metric.Int64ObservableGauge
+	capacity bool
+}
+
+// unwrappingObserver observes the values of the queueGauges with the gauges they wrap.
+type unwrappingObserver struct {
+	metric.Observer
+}
+
+func (o unwrappingObserver) ObserveInt64(inst metric.Int64Observable, value int64, opts ...metric.ObserveOption) {
+	if g, ok := inst.(*queueGauge); ok {
+		inst = g.Int64ObservableGauge
+	}
+	o.Observer.ObserveInt64(inst, value, opts...)
+}
+
+// trackingCounter is a counter of the exporter or receiver helper recording its signal to the tracker.
+type trackingCounter struct {
+	metric.Int64Counter
+	counterSignal
+	tracker *Tracker
+}
+
+func (c *trackingCounter) Add(ctx context.Context, incr int64, opts ...metric.AddOption) {
+	c.Int64Counter.Add(ctx, incr, opts...)
+	// The items counters record the same items once the legacy counters are disabled.
+	if incr <= 0 || !telemetry.LegacyOutcomeMetricsGate.IsEnabled() {
+		return
+	}
+	var err error
+	if c.failure != nil {
+		err = c.failure(incr)
+	}
+	c.tracker.record(c.signal, err)
+}
+
+// Enabled reports whether the counter records measurements, if the wrapped counter supports it.
+func (c *trackingCounter) Enabled(ctx context.Context) bool {
+	if e, ok := c.Int64Counter.(interface{ Enabled(context.Context) bool }); ok {
+		return e.Enabled(ctx)
+	}
+	return true
+}
+
+// itemsCounter is the counter of the items of the exporter or receiver helper, recording the signal
+// of the outcome of the items to the tracker.
+type itemsCounter struct {
+	metric.Int64Counter
+	tracker  *Tracker
+	exporter bool
+}
+
+func (c *itemsCounter) Add(ctx context.Context, incr int64, opts ...metric.AddOption) {
+	c.Int64Counter.Add(ctx, incr, opts...)
+	// The legacy counters record the same items while they are enabled.
+	if incr <= 0 || telemetry.LegacyOutcomeMetricsGate.IsEnabled() {
+		return
+	}
+	attrs := metric.NewAddConfig(opts).Attributes()
+	outcome, ok := attrs.Value(attribute.Key(telemetry.OutcomeKey))
+	if !ok {
+		return
+	}
+	signal, _ := attrs.Value(attribute.Key(telemetry.SignalKey))
+	items, ok := itemNames[signal.AsString()]
+	if !ok {
+		// No failures are detected from the items of the other signals, as with the legacy counters.
+		return
+	}
+
+	if !c.exporter {
+		switch outcome.AsString() {
+		case telemetry.OutcomeAccepted:
+			c.tracker.record(signalRefuse, nil)
+		case telemetry.OutcomeRefused, telemetry.OutcomeFailed:
+			c.tracker.record(signalRefuse, fmt.Errorf("refused %d %s", incr, items))
+		}
+		return
+	}
+	switch outcome.AsString() {
+	case telemetry.OutcomeAccepted:
+		c.tracker.record(signalSend, nil)
+	case telemetry.OutcomeDropped:
+		// The items failed to be sent from the sending queue.
+		c.tracker.record(signalSend, fmt.Errorf("failed to send %d %s", incr, items))
+	case telemetry.OutcomeRefused, telemetry.OutcomeFailed:
+		// The error is returned to the caller by the sending queue if it failed to enqueue the items,
+		// else by the exporter failing to send them.
+		if c.tracker.hasQueue() {
+			c.tracker.record(signalQueue, fmt.Errorf("sending queue is full, failed to enqueue %d %s", incr, items))
+		} else {
+			c.tracker.record(signalSend, fmt.Errorf("failed to send %d %s", incr, items))
+		}
+	}
+}
+
+// Enabled reports whether the counter records measurements, if the wrapped counter supports it.
+func (c *itemsCounter) Enabled(ctx context.Context) bool {
+	if e, ok := c.Int64Counter.(interface{ Enabled(context.Context) bool }); ok {
+		return e.Enabled(ctx)
+	}
+	return true
+}
+
+// queue checks the sending queue of an exporter, observing its size and capacity with the
+// callbacks registered by the exporter helper.
+type queue struct {
+	tracker *Tracker
+
+	mu        sync.Mutex
+	callbacks []*metric.Callback
+}
+
+// sendingQueue returns the sending queue of the component, creating it if needed.
+func (t *Tracker) sendingQueue() *queue {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.queue == nil {
+		t.queue = &queue{tracker: t}
+	}
+	return t.queue
+}
+
+// hasQueue returns whether the exporter observes its sending queue.
+func (t *Tracker) hasQueue() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.queue != nil
+}
+
+// add adds the callback observing the queue, and returns the reference removing it.
+func (q *queue) add(f metric.Callback) *metric.Callback {
+	q.mu.Lock()
+	first := len(q.callbacks) == 0
+	cb := &f
+	q.callbacks = append(q.callbacks, cb)
+	q.mu.Unlock()
+	if first {
+		q.tracker.detector.addQueue(q)
+	}
+	return cb
+}
+
+func (q *queue) remove(cb *metric.Callback) {
+	q.mu.Lock()
+	for i, f := range q.callbacks {
+		if f == cb {
+			q.callbacks = append(q.callbacks[:i], q.callbacks[i+1:]...)
+			break
+		}
+	}
+	last := len(q.callbacks) == 0
+	q.mu.Unlock()
+	if last {
+		q.tracker.detector.removeQueue(q)
+	}
+}
+
+// check records whether the queue is saturated, once both its size and its capacity are observed.
+func (q *queue) check(ctx context.Context) {
+	q.mu.Lock()
+	callbacks := make([]metric.Callback, 0, len(q.callbacks))
+	for _, cb := range q.callbacks {
+		callbacks = append(callbacks, *cb)
+	}
+	q.mu.Unlock()
+
+	o := &queueObserver{}
+	for _, cb := range callbacks {
+		_ = cb(ctx, o)
+	}
+	if o.size == nil || o.capacity == nil || *o.capacity <= 0 {
+		return
+	}
+	var err error
+	if float64(*o.size) >= q.tracker.detector.queueSaturation*float64(*o.capacity) {
+		err = fmt.Errorf("sending queue is saturated, %d of %d used", *o.size, *o.capacity)
+	}
+	q.tracker.record(signalQueue, err)
+}
+
+// queueObserver collects the size and the capacity of a sending queue observed by its callbacks.
+type queueObserver struct {
+	embedded.Observer
+	size     *int64
+	capacity *int64
+}
+
+func (*queueObserver) ObserveFloat64(metric.Float64Observable, float64, ...metric.ObserveOption) {}
+
+func (o *queueObserver) ObserveInt64(inst metric.Int64Observable, value int64, _ ...metric.ObserveOption) {
+	if g, ok := inst.(*queueGauge); ok {
+		if g.capacity {
+			o.capacity = &value
+		} else {
+			o.size = &value
+		}
+	}
+}
+
+// queueRegistration stops checking the queue with the callback once it is unregistered.
+type queueRegistration struct {
+	metric.Registration
+	queue    *queue
+	callback *metric.Callback
+
+	once sync.Once
+}
+
+func (r *queueRegistration) Unregister() error {
+	r.once.Do(func() {
+		r.queue.remove(r.callback)
+	})
+	return r.Registration.Unregister()
+}
@@ -0,0 +1,257 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	noopmetric "go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/telemetry"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/health"
+)
+
+// statusRecorder records the statuses reported for a component.
+type statusRecorder struct {
+	mu       sync.Mutex
+	statuses []componentstatus.Status
+}
+
+func newTrackedReporter(t *testing.T, id *componentstatus.InstanceID) (Reporter, *statusRecorder) {
+	rec := &statusRecorder{}
+	rep := NewReporter(func(_ *componentstatus.InstanceID, ev *componentstatus.Event) {
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		rec.statuses = append(rec.statuses, ev.Status())
+	}, func(err error) {
+		assert.NoError(t, err)
+	})
+	rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusStarting))
+	rep.ReportOKIfStarting(id)
+	rec.statuses = nil
+	return rep, rec
+}
+
+func (r *statusRecorder) get() []componentstatus.Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]componentstatus.Status(nil), r.statuses...)
+}
+
+func TestTrackerSignals(t *testing.T) {
+	id := &componentstatus.InstanceID{}
+	rep, rec := newTrackedReporter(t, id)
+	tracker := NewDetector(rep, health.DetectionConfig{Enabled: true, FailureThreshold: 2}).Tracker(id)
+	meter := tracker.MeterProvider(noopmetric.NewMeterProvider()).Meter("exporterhelper")
+
+	sent, err := meter.Int64Counter("otelcol_exporter_sent_spans")
+	require.NoError(t, err)
+	failed, err := meter.Int64Counter("otelcol_exporter_send_failed_spans")
+	require.NoError(t, err)
+	other, err := meter.Int64Counter("otelcol_processor_incoming_items")
+	require.NoError(t, err)
+
+	// The exporter fails to send from its queue while its calls succeed.
+	failed.Add(context.Background(), 10)
+	tracker.Record(nil)
+	other.Add(context.Background(), 10)
+	failed.Add(context.Background(), 0)
+	assert.Empty(t, rec.get())
+	failed.Add(context.Background(), 10)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, rec.get())
+
+	// The component is only healthy again once all its signals recovered.
+	for range 3 {
+		tracker.Record(assert.AnError)
+	}
+	sent.Add(context.Background(), 10)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, rec.get())
+	tracker.Record(nil)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, rec.get())
+}
+
+func TestTrackerRefusedItems(t *testing.T) {
+	id := &componentstatus.InstanceID{}
+	rep, rec := newTrackedReporter(t, id)
+	tracker := NewDetector(rep, health.DetectionConfig{Enabled: true, FailureThreshold: 2}).Tracker(id)
+	meter := tracker.MeterProvider(noopmetric.NewMeterProvider()).Meter("receiverhelper")
+
+	accepted, err := meter.Int64Counter("otelcol_receiver_accepted_log_records")
+	require.NoError(t, err)
+	refused, err := meter.Int64Counter("otelcol_receiver_refused_log_records")
+	require.NoError(t, err)
+
+	refused.Add(context.Background(), 1)
+	refused.Add(context.Background(), 1)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, rec.get())
+	accepted.Add(context.Background(), 1)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, rec.get())
+}
+
+func setLegacyOutcomeMetrics(t *testing.T, enabled bool) {
+	originalState := telemetry.LegacyOutcomeMetricsGate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(telemetry.LegacyOutcomeMetricsGate.ID(), enabled))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(telemetry.LegacyOutcomeMetricsGate.ID(), originalState))
+	})
+}
+
+func TestTrackerItems(t *testing.T) {
+	setLegacyOutcomeMetrics(t, false)
+	id := &componentstatus.InstanceID{}
+	rep, rec := newTrackedReporter(t, id)
+	tracker := NewDetector(rep, health.DetectionConfig{Enabled: true, FailureThreshold: 2}).Tracker(id)
+	meter := tracker.MeterProvider(noopmetric.NewMeterProvider()).Meter("receiverhelper")
+
+	items, err := meter.Int64Counter(receiverItemsCounter)
+	require.NoError(t, err)
+	legacy, err := meter.Int64Counter("otelcol_receiver_refused_spans")
+	require.NoError(t, err)
+
+	// The legacy counters are not recorded anymore, so their items are not counted twice.
+	legacy.Add(context.Background(), 1)
+	legacy.Add(context.Background(), 1)
+	assert.Empty(t, rec.get())
+
+	telemetry.AddItems(context.Background(), items, pipeline.SignalTraces, telemetry.OutcomeRefused, 1)
+	telemetry.AddItems(context.Background(), items, pipeline.SignalTraces, telemetry.OutcomeFailed, 1)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, rec.get())
+	// The items without an outcome are ignored.
+	items.Add(context.Background(), 1, metric.WithAttributes(attribute.String(telemetry.SignalKey, "traces")))
+	assert.Len(t, rec.get(), 1)
+	telemetry.AddItems(context.Background(), items, pipeline.SignalTraces, telemetry.OutcomeAccepted, 1)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, rec.get())
+}
+
+func TestTrackerExporterItems(t *testing.T) {
+	setLegacyOutcomeMetrics(t, false)
+	tests := []struct {
+		name     string
+		outcome  string
+		queue    bool
+		recorded string
+	}{
+		{name: "dropped from the queue", outcome: telemetry.OutcomeDropped, queue: true, recorded: signalSend},
+		{name: "refused without queue", outcome: telemetry.OutcomeRefused, recorded: signalSend},
+		{name: "failed without queue", outcome: telemetry.OutcomeFailed, recorded: signalSend},
+		{name: "refused by the queue", outcome: telemetry.OutcomeRefused, queue: true, recorded: signalQueue},
+		{name: "failed by the queue", outcome: telemetry.OutcomeFailed, queue: true, recorded: signalQueue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := &componentstatus.InstanceID{}
+			rep, rec := newTrackedReporter(t, id)
+			tracker := NewDetector(rep, health.DetectionConfig{Enabled: true, FailureThreshold: 1}).Tracker(id)
+			if tt.queue {
+				tracker.sendingQueue()
+			}
+			meter := tracker.MeterProvider(noopmetric.NewMeterProvider()).Meter("exporterhelper")
+			items, err := meter.Int64Counter(exporterItemsCounter)
+			require.NoError(t, err)
+
+			telemetry.AddItems(context.Background(), items, pipeline.SignalMetrics, tt.outcome, 5)
+			assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, rec.get())
+			// Only the signal which failed recovers with the items accepted, sent from the queue.
+			telemetry.AddItems(context.Background(), items, pipeline.SignalMetrics, telemetry.OutcomeAccepted, 5)
+			if tt.recorded == signalSend {
+				assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, rec.get())
+			} else {
+				assert.Len(t, rec.get(), 1)
+			}
+		})
+	}
+}
+
+func TestTrackerQueueSaturation(t *testing.T) {
+	id := &componentstatus.InstanceID{}
+	rep, rec := newTrackedReporter(t, id)
+	det := NewDetector(rep, health.DetectionConfig{
+		Enabled:            true,
+		FailureThreshold:   2,
+		QueueSaturation:    0.8,
+		QueueCheckInterval: time.Millisecond,
+	})
+	tracker := det.Tracker(id)
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() {
+		assert.NoError(t, mp.Shutdown(context.Background()))
+	}()
+	meter := tracker.MeterProvider(mp).Meter("exporterhelper")
+
+	// The gauges are observed like the exporter helper does.
+	var size atomic.Int64
+	sizeGauge, err := meter.Int64ObservableGauge(queueSizeGauge)
+	require.NoError(t, err)
+	capacityGauge, err := meter.Int64ObservableGauge(queueCapacityGauge)
+	require.NoError(t, err)
+	sizeReg, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(sizeGauge, size.Load())
+		return nil
+	}, sizeGauge)
+	require.NoError(t, err)
+	capacityReg, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(capacityGauge, 10)
+		return nil
+	}, capacityGauge)
+	require.NoError(t, err)
+
+	// The gauges are still recorded by the wrapped provider.
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Len(t, rm.ScopeMetrics[0].Metrics, 2)
+
+	size.Store(8)
+	require.Eventually(t, func() bool {
+		return len(rec.get()) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, rec.get())
+
+	size.Store(1)
+	require.Eventually(t, func() bool {
+		return len(rec.get()) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, componentstatus.StatusOK, rec.get()[1])
+
+	// The queue is not checked anymore once the exporter unregistered its callbacks.
+	require.NoError(t, sizeReg.Unregister())
+	require.NoError(t, capacityReg.Unregister())
+	det.mu.Lock()
+	defer det.mu.Unlock()
+	assert.Empty(t, det.queues)
+	assert.Nil(t, det.stop)
+}
+
+func TestTrackerEnqueueFailures(t *testing.T) {
+	id := &componentstatus.InstanceID{}
+	rep, rec := newTrackedReporter(t, id)
+	tracker := NewDetector(rep, health.DetectionConfig{Enabled: true, FailureThreshold: 1}).Tracker(id)
+	meter := tracker.MeterProvider(noopmetric.NewMeterProvider()).Meter("exporterhelper")
+
+	enqueueFailed, err := meter.Int64Counter("otelcol_exporter_enqueue_failed_metric_points")
+	require.NoError(t, err)
+	enqueueFailed.Add(context.Background(), 5)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, rec.get())
+}
+
+func TestTrackerMeterProviderNil(t *testing.T) {
+	mp := noopmetric.NewMeterProvider()
+	var tracker *Tracker
+	assert.Equal(t, metric.MeterProvider(mp), tracker.MeterProvider(mp))
+}
@@ -18,3 +18,7 @@ func (r *nopStatusReporter) Ready() {}
 func (r *nopStatusReporter) ReportStatus(*componentstatus.InstanceID, *componentstatus.Event) {}
 
 func (r *nopStatusReporter) ReportOKIfStarting(*componentstatus.InstanceID) {}
+
+func (r *nopStatusReporter) Watch(componentstatus.Watcher) func() { return func() {} }
+
+func (r *nopStatusReporter) Restart(*componentstatus.InstanceID) {}
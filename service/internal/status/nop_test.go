@@ -9,4 +9,6 @@ func TestNopStatusReporter(*testing.T) {
 	nop := NewNopStatusReporter()
 	nop.ReportOKIfStarting(nil)
 	nop.ReportStatus(nil, nil)
+	nop.Watch(nil)()
+	nop.Restart(nil)
 }
@@ -101,6 +101,15 @@ var ErrStatusNotReady = errors.New("report component status is not ready until s
 type Reporter interface {
 	ReportStatus(id *componentstatus.InstanceID, ev *componentstatus.Event)
 	ReportOKIfStarting(id *componentstatus.InstanceID)
+	// Watch registers a componentstatus.Watcher that is notified of every subsequent status
+	// change. The current status of every component that has reported is replayed to the
+	// watcher before Watch returns. The returned function unregisters the watcher.
+	Watch(w componentstatus.Watcher) (unwatch func())
+	// Restart begins a new lifecycle for the given InstanceID once it stopped or reported a
+	// permanent or fatal error, reporting StatusStarting for it. The status of the instance is
+	// kept, so that it is never reported as unknown. This is used when a component is recreated
+	// after it failed.
+	Restart(id *componentstatus.InstanceID)
 }
 
 type reporter struct {
@@ -108,6 +117,20 @@ type reporter struct {
 	fsmMap              map[*componentstatus.InstanceID]*fsm
 	onStatusChange      NotifyStatusFunc
 	onInvalidTransition InvalidTransitionFunc
+	watchers            map[uint64]componentstatus.Watcher
+	nextWatcherID       uint64
+	// pending are the status changes, in the order of the transitions, not yet notified.
+	pending []statusChange
+
+	// notifyMu is held by the goroutine notifying the status changes, made without holding mu so
+	// that the watchers can report status themselves. It is only released by notifyPending.
+	notifyMu sync.Mutex
+}
+
+// statusChange is a status change of a component to notify.
+type statusChange struct {
+	id *componentstatus.InstanceID
+	ev *componentstatus.Event
 }
 
 // NewReporter returns a reporter that will invoke the NotifyStatusFunc when a component's status
@@ -117,6 +140,7 @@ func NewReporter(onStatusChange NotifyStatusFunc, onInvalidTransition InvalidTra
 		fsmMap:              make(map[*componentstatus.InstanceID]*fsm),
 		onStatusChange:      onStatusChange,
 		onInvalidTransition: onInvalidTransition,
+		watchers:            make(map[uint64]componentstatus.Watcher),
 	}
 }
 
@@ -126,34 +150,142 @@ func (r *reporter) ReportStatus(
 	ev *componentstatus.Event,
 ) {
 	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	if err := r.componentFSM(id).transition(ev); err != nil {
+	err := r.componentFSM(id).transition(ev)
+	r.mu.Unlock()
+	if err != nil {
 		r.onInvalidTransition(err)
 	}
+	r.notify()
 }
 
 func (r *reporter) ReportOKIfStarting(id *componentstatus.InstanceID) {
 	r.mu.Lock()
-	defer r.mu.Unlock()
+	var err error
 	fsm := r.componentFSM(id)
 	if fsm.current.Status() == componentstatus.StatusStarting {
-		if err := fsm.transition(componentstatus.NewEvent(componentstatus.StatusOK)); err != nil {
-			r.onInvalidTransition(err)
+		err = fsm.transition(componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+	r.mu.Unlock()
+	if err != nil {
+		r.onInvalidTransition(err)
+	}
+	r.notify()
+}
+
+// Watch registers a watcher for status changes. The status changes are notified in order and
+// without holding the lock of the reporter, so watchers can report status themselves; those
+// changes are notified once the current one was notified to all the watchers. Watch and the
+// returned function must not be called from a watcher.
+func (r *reporter) Watch(w componentstatus.Watcher) func() {
+	r.notifyMu.Lock()
+	// The status changes before the replay are notified to the other watchers only.
+	r.mu.Lock()
+	pending, watchers := r.takePending()
+	replay := make([]statusChange, 0, len(r.fsmMap))
+	for id, fsm := range r.fsmMap {
+		if fsm.current.Status() != componentstatus.StatusNone {
+			replay = append(replay, statusChange{id: id, ev: fsm.current})
 		}
 	}
+	watcherID := r.nextWatcherID
+	r.nextWatcherID++
+	r.watchers[watcherID] = w
+	r.mu.Unlock()
+
+	r.notifyChanges(pending, watchers)
+	for _, c := range replay {
+		w.ComponentStatusChanged(c.id, c.ev)
+	}
+	r.notifyPending()
+
+	return func() {
+		r.notifyMu.Lock()
+		r.mu.Lock()
+		delete(r.watchers, watcherID)
+		r.mu.Unlock()
+		r.notifyPending()
+	}
+}
+
+func (r *reporter) Restart(id *componentstatus.InstanceID) {
+	r.mu.Lock()
+	fsm := r.componentFSM(id)
+	switch fsm.current.Status() {
+	case componentstatus.StatusStopped, componentstatus.StatusPermanentError, componentstatus.StatusFatalError:
+	default:
+		r.mu.Unlock()
+		r.onInvalidTransition(fmt.Errorf(
+			"cannot restart from %s: %w",
+			fsm.current.Status(),
+			errInvalidStateTransition,
+		))
+		return
+	}
+	fsm.current = componentstatus.NewEvent(componentstatus.StatusStarting)
+	fsm.onTransition(fsm.current)
+	r.mu.Unlock()
+	r.notify()
 }
 
 // Note: a lock must be acquired before calling this method.
 func (r *reporter) componentFSM(id *componentstatus.InstanceID) *fsm {
 	fsm, ok := r.fsmMap[id]
 	if !ok {
-		fsm = newFSM(func(ev *componentstatus.Event) { r.onStatusChange(id, ev) })
+		fsm = newFSM(func(ev *componentstatus.Event) {
+			r.pending = append(r.pending, statusChange{id: id, ev: ev})
+		})
 		r.fsmMap[id] = fsm
 	}
 	return fsm
 }
 
+// notify notifies the pending status changes, unless another goroutine is notifying them, which
+// then notifies these ones too.
+func (r *reporter) notify() {
+	if r.notifyMu.TryLock() {
+		r.notifyPending()
+	}
+}
+
+// notifyPending notifies the pending status changes until there are none left, then releases
+// notifyMu. Must be called holding notifyMu.
+func (r *reporter) notifyPending() {
+	for {
+		r.mu.Lock()
+		if len(r.pending) == 0 {
+			// notifyMu is released holding mu, so that the status changes pending after it was
+			// released are notified by the goroutine which made them.
+			r.notifyMu.Unlock()
+			r.mu.Unlock()
+			return
+		}
+		pending, watchers := r.takePending()
+		r.mu.Unlock()
+		r.notifyChanges(pending, watchers)
+	}
+}
+
+// takePending returns the pending status changes and a copy of the watchers to notify them to.
+// Must be called holding mu.
+func (r *reporter) takePending() ([]statusChange, []componentstatus.Watcher) {
+	pending := r.pending
+	r.pending = nil
+	watchers := make([]componentstatus.Watcher, 0, len(r.watchers))
+	for _, w := range r.watchers {
+		watchers = append(watchers, w)
+	}
+	return pending, watchers
+}
+
+func (r *reporter) notifyChanges(changes []statusChange, watchers []componentstatus.Watcher) {
+	for _, c := range changes {
+		r.onStatusChange(c.id, c.ev)
+		for _, w := range watchers {
+			w.ComponentStatusChanged(c.id, c.ev)
+		}
+	}
+}
+
 // NewReportStatusFunc returns a function to be used as ReportStatus for componentstatus.TelemetrySettings
 func NewReportStatusFunc(
 	id *componentstatus.InstanceID,
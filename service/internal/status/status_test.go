@@ -8,6 +8,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component/componentstatus"
@@ -330,3 +331,175 @@ func TestReportComponentOKIfStarting(t *testing.T) {
 		})
 	}
 }
+
+type recordingWatcher struct {
+	statuses map[*componentstatus.InstanceID][]componentstatus.Status
+}
+
+func (w *recordingWatcher) ComponentStatusChanged(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+	w.statuses[id] = append(w.statuses[id], ev.Status())
+}
+
+func TestReporterWatch(t *testing.T) {
+	id1 := &componentstatus.InstanceID{}
+	id2 := &componentstatus.InstanceID{}
+
+	rep := NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {},
+		func(err error) {
+			require.NoError(t, err)
+		})
+
+	rep.ReportStatus(id1, componentstatus.NewEvent(componentstatus.StatusStarting))
+	rep.ReportStatus(id1, componentstatus.NewEvent(componentstatus.StatusOK))
+
+	// Current statuses are replayed on registration.
+	w := &recordingWatcher{statuses: make(map[*componentstatus.InstanceID][]componentstatus.Status)}
+	unwatch := rep.Watch(w)
+	require.Equal(t, map[*componentstatus.InstanceID][]componentstatus.Status{
+		id1: {componentstatus.StatusOK},
+	}, w.statuses)
+
+	rep.ReportStatus(id2, componentstatus.NewEvent(componentstatus.StatusStarting))
+	rep.ReportOKIfStarting(id2)
+	rep.ReportStatus(id1, componentstatus.NewRecoverableErrorEvent(assert.AnError))
+	require.Equal(t, map[*componentstatus.InstanceID][]componentstatus.Status{
+		id1: {componentstatus.StatusOK, componentstatus.StatusRecoverableError},
+		id2: {componentstatus.StatusStarting, componentstatus.StatusOK},
+	}, w.statuses)
+
+	// No more events are delivered once unregistered.
+	unwatch()
+	rep.ReportStatus(id1, componentstatus.NewEvent(componentstatus.StatusOK))
+	require.Equal(t, []componentstatus.Status{componentstatus.StatusOK, componentstatus.StatusRecoverableError}, w.statuses[id1])
+}
+
+// reportingWatcher reports a recoverable error for its own instance when another instance fails.
+type reportingWatcher struct {
+	rep    Reporter
+	id     *componentstatus.InstanceID
+	events []componentstatus.Status
+}
+
+func (w *reportingWatcher) ComponentStatusChanged(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+	w.events = append(w.events, ev.Status())
+	if id != w.id && ev.Status() == componentstatus.StatusPermanentError {
+		w.rep.ReportStatus(w.id, componentstatus.NewRecoverableErrorEvent(ev.Err()))
+	}
+}
+
+func TestReporterWatcherReportsStatus(t *testing.T) {
+	id := &componentstatus.InstanceID{}
+	watcherID := &componentstatus.InstanceID{}
+	var notified []componentstatus.Status
+	rep := NewReporter(func(_ *componentstatus.InstanceID, ev *componentstatus.Event) {
+		notified = append(notified, ev.Status())
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+	rep.ReportStatus(watcherID, componentstatus.NewEvent(componentstatus.StatusStarting))
+	rep.ReportOKIfStarting(watcherID)
+
+	w := &reportingWatcher{rep: rep, id: watcherID}
+	other := &recordingWatcher{statuses: make(map[*componentstatus.InstanceID][]componentstatus.Status)}
+	defer rep.Watch(w)()
+	defer rep.Watch(other)()
+
+	// The status reported by the watcher is notified once the status it watched was notified to
+	// all the watchers, without deadlocking on the lock of the reporter.
+	rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusStarting))
+	rep.ReportStatus(id, componentstatus.NewPermanentErrorEvent(assert.AnError))
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusOK,
+		componentstatus.StatusStarting,
+		componentstatus.StatusPermanentError,
+		componentstatus.StatusRecoverableError,
+	}, w.events)
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusStarting,
+		componentstatus.StatusOK,
+		componentstatus.StatusStarting,
+		componentstatus.StatusPermanentError,
+		componentstatus.StatusRecoverableError,
+	}, notified)
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusOK, componentstatus.StatusRecoverableError}, other.statuses[watcherID])
+}
+
+func TestReporterWatchConcurrent(t *testing.T) {
+	rep := NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(err error) {
+		require.NoError(t, err)
+	})
+	ids := make([]*componentstatus.InstanceID, 10)
+	for i := range ids {
+		ids[i] = &componentstatus.InstanceID{}
+	}
+
+	w := &recordingWatcher{statuses: make(map[*componentstatus.InstanceID][]componentstatus.Status)}
+	unwatch := rep.Watch(w)
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusStarting))
+			rep.ReportOKIfStarting(id)
+			rep.ReportStatus(id, componentstatus.NewRecoverableErrorEvent(assert.AnError))
+			rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusOK))
+		}()
+	}
+	wg.Wait()
+	unwatch()
+
+	// Every status change is notified, in order, once the calls reporting it returned. The
+	// notifications are serialized, so the watcher needs no lock.
+	for _, id := range ids {
+		assert.Equal(t, []componentstatus.Status{
+			componentstatus.StatusStarting,
+			componentstatus.StatusOK,
+			componentstatus.StatusRecoverableError,
+			componentstatus.StatusOK,
+		}, w.statuses[id])
+	}
+}
+
+func TestReporterRestart(t *testing.T) {
+	id := &componentstatus.InstanceID{}
+	var statuses []componentstatus.Status
+	var invalid []error
+
+	rep := NewReporter(func(_ *componentstatus.InstanceID, ev *componentstatus.Event) {
+		statuses = append(statuses, ev.Status())
+	}, func(err error) {
+		invalid = append(invalid, err)
+	})
+
+	rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusStarting))
+	rep.ReportOKIfStarting(id)
+
+	// A running instance cannot be restarted.
+	rep.Restart(id)
+	require.Len(t, invalid, 1)
+	require.ErrorIs(t, invalid[0], errInvalidStateTransition)
+
+	// A new lifecycle can be reported after a terminal status once the instance is restarted.
+	rep.ReportStatus(id, componentstatus.NewFatalErrorEvent(assert.AnError))
+	w := &recordingWatcher{statuses: make(map[*componentstatus.InstanceID][]componentstatus.Status)}
+	unwatch := rep.Watch(w)
+	defer unwatch()
+	rep.Restart(id)
+	rep.ReportOKIfStarting(id)
+
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusStarting,
+		componentstatus.StatusOK,
+		componentstatus.StatusFatalError,
+		componentstatus.StatusStarting,
+		componentstatus.StatusOK,
+	}, statuses)
+	// The watchers are notified of the new lifecycle like of any other status.
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusFatalError,
+		componentstatus.StatusStarting,
+		componentstatus.StatusOK,
+	}, w.statuses[id])
+	assert.Len(t, invalid, 1)
+}
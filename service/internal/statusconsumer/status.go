@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package statusconsumer wraps consumers to feed the outcome of every call to a status.Tracker.
+package statusconsumer // import "go.opentelemetry.io/collector/service/internal/statusconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/internal/status"
+)
+
+// record accounts the outcome of a call to the tracker. Errors returned by
+// downstream components are not the fault of the wrapped component.
+func record(tracker *status.Tracker, err error) {
+	if err != nil && consumererror.IsDownstream(err) {
+		return
+	}
+	tracker.Record(err)
+}
+
+func NewLogs(logs consumer.Logs, tracker *status.Tracker) consumer.Logs {
+	if tracker == nil {
+		return logs
+	}
+	return statusLogs{Logs: logs, tracker: tracker}
+}
+
+type statusLogs struct {
+	consumer.Logs
+	tracker *status.Tracker
+}
+
+func (c statusLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	err := c.Logs.ConsumeLogs(ctx, ld)
+	record(c.tracker, err)
+	return err
+}
+
+func NewMetrics(metrics consumer.Metrics, tracker *status.Tracker) consumer.Metrics {
+	if tracker == nil {
+		return metrics
+	}
+	return statusMetrics{Metrics: metrics, tracker: tracker}
+}
+
+type statusMetrics struct {
+	consumer.Metrics
+	tracker *status.Tracker
+}
+
+func (c statusMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	err := c.Metrics.ConsumeMetrics(ctx, md)
+	record(c.tracker, err)
+	return err
+}
+
+func NewTraces(traces consumer.Traces, tracker *status.Tracker) consumer.Traces {
+	if tracker == nil {
+		return traces
+	}
+	return statusTraces{Traces: traces, tracker: tracker}
+}
+
+type statusTraces struct {
+	consumer.Traces
+	tracker *status.Tracker
+}
+
+func (c statusTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	err := c.Traces.ConsumeTraces(ctx, td)
+	record(c.tracker, err)
+	return err
+}
+
+func NewProfiles(profiles xconsumer.Profiles, tracker *status.Tracker) xconsumer.Profiles {
+	if tracker == nil {
+		return profiles
+	}
+	return statusProfiles{Profiles: profiles, tracker: tracker}
+}
+
+type statusProfiles struct {
+	xconsumer.Profiles
+	tracker *status.Tracker
+}
+
+func (c statusProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	err := c.Profiles.ConsumeProfiles(ctx, pd)
+	record(c.tracker, err)
+	return err
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package statusconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/service/health"
+	"go.opentelemetry.io/collector/service/internal/status"
+)
+
+func newTracker(t *testing.T) (*status.Tracker, *[]componentstatus.Status) {
+	var statuses []componentstatus.Status
+	rep := status.NewReporter(func(_ *componentstatus.InstanceID, ev *componentstatus.Event) {
+		statuses = append(statuses, ev.Status())
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+	id := &componentstatus.InstanceID{}
+	rep.ReportStatus(id, componentstatus.NewEvent(componentstatus.StatusStarting))
+	rep.ReportOKIfStarting(id)
+	statuses = nil
+	det := status.NewDetector(rep, health.DetectionConfig{Enabled: true, FailureThreshold: 1})
+	return det.Tracker(id), &statuses
+}
+
+func TestNilTracker(t *testing.T) {
+	logs := consumertest.NewNop()
+	assert.Same(t, logs, NewLogs(logs, nil))
+	metrics := consumertest.NewNop()
+	assert.Same(t, metrics, NewMetrics(metrics, nil))
+	traces := consumertest.NewNop()
+	assert.Same(t, traces, NewTraces(traces, nil))
+	profiles := consumertest.NewNop()
+	assert.Same(t, profiles, NewProfiles(profiles, nil))
+}
+
+func TestLogs(t *testing.T) {
+	tracker, statuses := newTracker(t)
+	cons := NewLogs(consumertest.NewErr(assert.AnError), tracker)
+	require.Error(t, cons.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, *statuses)
+
+	cons = NewLogs(consumertest.NewNop(), tracker)
+	require.NoError(t, cons.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, *statuses)
+}
+
+func TestMetrics(t *testing.T) {
+	tracker, statuses := newTracker(t)
+	cons := NewMetrics(consumertest.NewErr(assert.AnError), tracker)
+	require.Error(t, cons.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, *statuses)
+
+	cons = NewMetrics(consumertest.NewNop(), tracker)
+	require.NoError(t, cons.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, *statuses)
+}
+
+func TestTraces(t *testing.T) {
+	tracker, statuses := newTracker(t)
+	cons := NewTraces(consumertest.NewErr(assert.AnError), tracker)
+	require.Error(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, *statuses)
+
+	cons = NewTraces(consumertest.NewNop(), tracker)
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, *statuses)
+}
+
+func TestProfiles(t *testing.T) {
+	tracker, statuses := newTracker(t)
+	cons := NewProfiles(consumertest.NewErr(assert.AnError), tracker)
+	require.Error(t, cons.ConsumeProfiles(context.Background(), testdata.GenerateProfiles(1)))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, *statuses)
+
+	cons = NewProfiles(consumertest.NewNop(), tracker)
+	require.NoError(t, cons.ConsumeProfiles(context.Background(), testdata.GenerateProfiles(1)))
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError, componentstatus.StatusOK}, *statuses)
+}
+
+func TestDownstreamErrorsIgnored(t *testing.T) {
+	tracker, statuses := newTracker(t)
+	cons := NewTraces(consumertest.NewErr(consumererror.NewDownstream(assert.AnError)), tracker)
+	require.Error(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Empty(t, *statuses)
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package swapconsumer provides consumers whose destination can be replaced at runtime,
+// so that a component can be recreated without rebuilding the components sending data to it.
+package swapconsumer // import "go.opentelemetry.io/collector/service/internal/swapconsumer"
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Logs is a consumer.Logs that forwards to the consumer most recently stored in it.
+type Logs struct {
+	cur atomic.Pointer[consumer.Logs]
+}
+
+func NewLogs(logs consumer.Logs) *Logs {
+	c := &Logs{}
+	c.Store(logs)
+	return c
+}
+
+// Store replaces the consumer the data is forwarded to.
+func (c *Logs) Store(logs consumer.Logs) {
+	c.cur.Store(&logs)
+}
+
+func (c *Logs) Capabilities() consumer.Capabilities {
+	return (*c.cur.Load()).Capabilities()
+}
+
+func (c *Logs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return (*c.cur.Load()).ConsumeLogs(ctx, ld)
+}
+
+// Metrics is a consumer.Metrics that forwards to the consumer most recently stored in it.
+type Metrics struct {
+	cur atomic.Pointer[consumer.Metrics]
+}
+
+func NewMetrics(metrics consumer.Metrics) *Metrics {
+	c := &Metrics{}
+	c.Store(metrics)
+	return c
+}
+
+// Store replaces the consumer the data is forwarded to.
+func (c *Metrics) Store(metrics consumer.Metrics) {
+	c.cur.Store(&metrics)
+}
+
+func (c *Metrics) Capabilities() consumer.Capabilities {
+	return (*c.cur.Load()).Capabilities()
+}
+
+func (c *Metrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return (*c.cur.Load()).ConsumeMetrics(ctx, md)
+}
+
+// Traces is a consumer.Traces that forwards to the consumer most recently stored in it.
+type Traces struct {
+	cur atomic.Pointer[consumer.Traces]
+}
+
+func NewTraces(traces consumer.Traces) *Traces {
+	c := &Traces{}
+	c.Store(traces)
+	return c
+}
+
+// Store replaces the consumer the data is forwarded to.
+func (c *Traces) Store(traces consumer.Traces) {
+	c.cur.Store(&traces)
+}
+
+func (c *Traces) Capabilities() consumer.Capabilities {
+	return (*c.cur.Load()).Capabilities()
+}
+
+func (c *Traces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return (*c.cur.Load()).ConsumeTraces(ctx, td)
+}
+
+// Profiles is a xconsumer.Profiles that forwards to the consumer most recently stored in it.
+type Profiles struct {
+	cur atomic.Pointer[xconsumer.Profiles]
+}
+
+func NewProfiles(profiles xconsumer.Profiles) *Profiles {
+	c := &Profiles{}
+	c.Store(profiles)
+	return c
+}
+
+// Store replaces the consumer the data is forwarded to.
+func (c *Profiles) Store(profiles xconsumer.Profiles) {
+	c.cur.Store(&profiles)
+}
+
+func (c *Profiles) Capabilities() consumer.Capabilities {
+	return (*c.cur.Load()).Capabilities()
+}
+
+func (c *Profiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	return (*c.cur.Load()).ConsumeProfiles(ctx, pd)
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package swapconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestLogs(t *testing.T) {
+	cons := NewLogs(consumertest.NewErr(assert.AnError))
+	assert.Equal(t, consumer.Capabilities{}, cons.Capabilities())
+	require.ErrorIs(t, cons.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)), assert.AnError)
+
+	sink := new(consumertest.LogsSink)
+	cons.Store(sink)
+	require.NoError(t, cons.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.Len(t, sink.AllLogs(), 1)
+}
+
+func TestMetrics(t *testing.T) {
+	cons := NewMetrics(consumertest.NewErr(assert.AnError))
+	assert.Equal(t, consumer.Capabilities{}, cons.Capabilities())
+	require.ErrorIs(t, cons.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)), assert.AnError)
+
+	sink := new(consumertest.MetricsSink)
+	cons.Store(sink)
+	require.NoError(t, cons.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	assert.Len(t, sink.AllMetrics(), 1)
+}
+
+func TestTraces(t *testing.T) {
+	cons := NewTraces(consumertest.NewErr(assert.AnError))
+	assert.Equal(t, consumer.Capabilities{}, cons.Capabilities())
+	require.ErrorIs(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)), assert.AnError)
+
+	sink := new(consumertest.TracesSink)
+	cons.Store(sink)
+	require.NoError(t, cons.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestProfiles(t *testing.T) {
+	cons := NewProfiles(consumertest.NewErr(assert.AnError))
+	assert.Equal(t, consumer.Capabilities{}, cons.Capabilities())
+	require.ErrorIs(t, cons.ConsumeProfiles(context.Background(), testdata.GenerateProfiles(1)), assert.AnError)
+
+	sink := new(consumertest.ProfilesSink)
+	cons.Store(sink)
+	require.NoError(t, cons.ConsumeProfiles(context.Background(), testdata.GenerateProfiles(1)))
+	assert.Len(t, sink.AllProfiles(), 1)
+}
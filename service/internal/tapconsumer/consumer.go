@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapconsumer // import "go.opentelemetry.io/collector/service/internal/tapconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// NewLogs makes the sessions attached to the tap sample the logs passed to the consumer.
+func NewLogs(logs consumer.Logs, t *Tap) consumer.Logs {
+	return tapLogs{Logs: logs, tap: t}
+}
+
+type tapLogs struct {
+	consumer.Logs
+	tap *Tap
+}
+
+func (c tapLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	c.tap.sample(func() ([]byte, error) { return (&plog.JSONMarshaler{}).MarshalLogs(ld) })
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+// NewMetrics makes the sessions attached to the tap sample the metrics passed to the consumer.
+func NewMetrics(metrics consumer.Metrics, t *Tap) consumer.Metrics {
+	return tapMetrics{Metrics: metrics, tap: t}
+}
+
+type tapMetrics struct {
+	consumer.Metrics
+	tap *Tap
+}
+
+func (c tapMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	c.tap.sample(func() ([]byte, error) { return (&pmetric.JSONMarshaler{}).MarshalMetrics(md) })
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+// NewTraces makes the sessions attached to the tap sample the traces passed to the consumer.
+func NewTraces(traces consumer.Traces, t *Tap) consumer.Traces {
+	return tapTraces{Traces: traces, tap: t}
+}
+
+type tapTraces struct {
+	consumer.Traces
+	tap *Tap
+}
+
+func (c tapTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	c.tap.sample(func() ([]byte, error) { return (&ptrace.JSONMarshaler{}).MarshalTraces(td) })
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+// NewProfiles makes the sessions attached to the tap sample the profiles passed to the consumer.
+func NewProfiles(profiles xconsumer.Profiles, t *Tap) xconsumer.Profiles {
+	return tapProfiles{Profiles: profiles, tap: t}
+}
+
+type tapProfiles struct {
+	xconsumer.Profiles
+	tap *Tap
+}
+
+func (c tapProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	c.tap.sample(func() ([]byte, error) { return (&pprofile.JSONMarshaler{}).MarshalProfiles(pd) })
+	return c.Profiles.ConsumeProfiles(ctx, pd)
+}
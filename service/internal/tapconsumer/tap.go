@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tapconsumer samples the data passing a point of the pipelines, so that operators can
+// watch it while debugging the pipelines, without slowing down nor altering the data flow.
+package tapconsumer // import "go.opentelemetry.io/collector/service/internal/tapconsumer"
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Tap is a point of the pipelines where sessions can be attached to sample the passing data.
+// The zero value is ready to use.
+type Tap struct {
+	mu       sync.Mutex
+	sessions atomic.Pointer[[]*Session]
+}
+
+// Attach makes the session sample the data passing the tap, until the returned function is called.
+func (t *Tap) Attach(s *Session) (detach func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.store(append(t.active(), s))
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.store(slices.DeleteFunc(slices.Clone(t.active()), func(other *Session) bool { return other == s }))
+		})
+	}
+}
+
+func (t *Tap) store(sessions []*Session) {
+	if len(sessions) == 0 {
+		t.sessions.Store(nil)
+		return
+	}
+	t.sessions.Store(&sessions)
+}
+
+// active returns the attached sessions, the returned slice must not be modified.
+func (t *Tap) active() []*Session {
+	if sessions := t.sessions.Load(); sessions != nil {
+		return *sessions
+	}
+	return nil
+}
+
+// Session receives a sample of the data passing a tap, encoded as OTLP-JSON, of at most one
+// batch per interval. The batches are dropped if the session does not keep up.
+type Session struct {
+	interval time.Duration
+	next     atomic.Int64
+	samples  chan []byte
+	dropped  atomic.Int64
+}
+
+// NewSession returns a session sampling at most one batch per interval, buffering up to size batches.
+func NewSession(interval time.Duration, size int) *Session {
+	return &Session{interval: interval, samples: make(chan []byte, size)}
+}
+
+// Samples returns the channel of the sampled batches.
+func (s *Session) Samples() <-chan []byte {
+	return s.samples
+}
+
+// Dropped returns the number of sampled batches dropped because the buffer was full.
+func (s *Session) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// due reports whether the next batch must be sampled.
+func (s *Session) due(now time.Time) bool {
+	next := s.next.Load()
+	if now.UnixNano() < next {
+		return false
+	}
+	return s.next.CompareAndSwap(next, now.Add(s.interval).UnixNano())
+}
+
+func (s *Session) offer(sample []byte) {
+	select {
+	case s.samples <- sample:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// sample offers the batch to the attached sessions which are due, marshaling it at most once.
+// The batch is marshaled before being passed along, so that it is not modified concurrently.
+func (t *Tap) sample(marshal func() ([]byte, error)) {
+	sessions := t.active()
+	if len(sessions) == 0 {
+		return
+	}
+	now := time.Now()
+	var encoded []byte
+	for _, s := range sessions {
+		if !s.due(now) {
+			continue
+		}
+		if encoded == nil {
+			var err error
+			if encoded, err = marshal(); err != nil {
+				return
+			}
+		}
+		s.offer(encoded)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tapconsumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestTapSample(t *testing.T) {
+	tap := new(Tap)
+	sink := new(consumertest.TracesSink)
+	cons := NewTraces(sink, tap)
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+
+	// Nothing is sampled without session.
+	require.NoError(t, cons.ConsumeTraces(context.Background(), td))
+
+	s := NewSession(time.Hour, 1)
+	detach := tap.Attach(s)
+	require.NoError(t, cons.ConsumeTraces(context.Background(), td))
+	// The second batch is not due yet.
+	require.NoError(t, cons.ConsumeTraces(context.Background(), td))
+	assert.Len(t, sink.AllTraces(), 3)
+
+	require.Len(t, s.Samples(), 1)
+	got, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(<-s.Samples())
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+
+	detach()
+	detach()
+	assert.Nil(t, tap.active())
+}
+
+func TestTapDropped(t *testing.T) {
+	tap := new(Tap)
+	cons := NewLogs(consumertest.NewNop(), tap)
+	s := NewSession(0, 1)
+	other := NewSession(time.Hour, 1)
+	defer tap.Attach(s)()
+	defer tap.Attach(other)()
+
+	for range 3 {
+		require.NoError(t, cons.ConsumeLogs(context.Background(), plog.NewLogs()))
+	}
+	assert.Len(t, s.Samples(), 1)
+	assert.Equal(t, int64(2), s.Dropped())
+	assert.Len(t, other.Samples(), 1)
+	assert.Equal(t, int64(0), other.Dropped())
+}
+
+func TestTapSignals(t *testing.T) {
+	tap := new(Tap)
+	s := NewSession(0, 3)
+	defer tap.Attach(s)()
+
+	require.NoError(t, NewMetrics(consumertest.NewNop(), tap).ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+	require.NoError(t, NewProfiles(consumertest.NewNop(), tap).ConsumeProfiles(context.Background(), pprofile.NewProfiles()))
+	require.NoError(t, NewTraces(consumertest.NewNop(), tap).ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Len(t, s.Samples(), 3)
+}
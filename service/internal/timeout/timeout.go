@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package timeout bounds the duration of the lifecycle calls made to components.
+package timeout // import "go.opentelemetry.io/collector/service/internal/timeout"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrTimeout is returned by Guard.Call when the called function doesn't return in time.
+var ErrTimeout = errors.New("timed out")
+
+// ErrAbandoned is returned by Guard.Call when a previous call to the component is still running
+// after the timeout, in which case the component is abandoned rather than called concurrently.
+var ErrAbandoned = errors.New("abandoned, a previous call is still running")
+
+// Guard makes sure that the lifecycle calls made to a component never run concurrently, such as
+// a Shutdown called while the Start which timed out is still running in the background.
+// The zero value is ready to use.
+type Guard struct {
+	mu sync.Mutex
+	// running is closed once the last call returns, nil if it returned in time.
+	running chan struct{}
+}
+
+// Call calls f once the previous call made through the Guard returned, and waits for it to return
+// for at most the given timeout, which also bounds the wait for the previous call. f is called
+// directly if the timeout is not positive. The context passed to f is canceled once the timeout
+// expires, but f keeps running in the background until it returns, and the next call waits for it.
+func (g *Guard) Call(ctx context.Context, timeout time.Duration, f func(context.Context) error) error {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.running != nil {
+		select {
+		case <-g.running:
+			g.running = nil
+		case <-expired:
+			return fmt.Errorf("%w after %v", ErrAbandoned, timeout)
+		}
+	}
+	if timeout <= 0 {
+		return f(ctx)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	running := make(chan struct{})
+	go func() {
+		defer close(running)
+		defer cancel()
+		done <- f(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-expired:
+		cancel()
+		g.running = running
+		return fmt.Errorf("%w after %v", ErrTimeout, timeout)
+	}
+}
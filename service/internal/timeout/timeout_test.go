@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package timeout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCall(t *testing.T) {
+	errTest := errors.New("test")
+	g := &Guard{}
+	require.ErrorIs(t, g.Call(context.Background(), 0, func(context.Context) error { return errTest }), errTest)
+	require.ErrorIs(t, g.Call(context.Background(), time.Minute, func(context.Context) error { return errTest }), errTest)
+	require.NoError(t, g.Call(context.Background(), time.Minute, func(context.Context) error { return nil }))
+}
+
+func TestCallTimeout(t *testing.T) {
+	returned := make(chan struct{})
+	err := (&Guard{}).Call(context.Background(), 10*time.Millisecond, func(ctx context.Context) error {
+		defer close(returned)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.ErrorIs(t, err, ErrTimeout)
+	assert.EqualError(t, err, "timed out after 10ms")
+	// The context is canceled so that the function can give up.
+	<-returned
+}
+
+func TestCallWaitsForTimedOutCall(t *testing.T) {
+	g := &Guard{}
+	release := make(chan struct{})
+	started := false
+	// The start ignores the cancellation of its context and keeps running after the timeout.
+	err := g.Call(context.Background(), 10*time.Millisecond, func(context.Context) error {
+		<-release
+		started = true
+		return nil
+	})
+	require.ErrorIs(t, err, ErrTimeout)
+
+	// The component is abandoned if the start is still running once the shutdown times out.
+	err = g.Call(context.Background(), 10*time.Millisecond, func(context.Context) error {
+		t.Fatal("the shutdown was called while the start is running")
+		return nil
+	})
+	require.ErrorIs(t, err, ErrAbandoned)
+	assert.EqualError(t, err, "abandoned, a previous call is still running after 10ms")
+
+	// The shutdown is called once the start returned.
+	go close(release)
+	require.NoError(t, g.Call(context.Background(), time.Minute, func(context.Context) error {
+		assert.True(t, started)
+		return nil
+	}))
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package usageconsumer // import "go.opentelemetry.io/collector/service/internal/usageconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	logsMarshaler     = &plog.ProtoMarshaler{}
+	metricsMarshaler  = &pmetric.ProtoMarshaler{}
+	tracesMarshaler   = &ptrace.ProtoMarshaler{}
+	profilesMarshaler = &pprofile.ProtoMarshaler{}
+)
+
+func NewLogs(logs consumer.Logs, usage *Usage) consumer.Logs {
+	if usage == nil {
+		return logs
+	}
+	return usageLogs{Logs: logs, usage: usage}
+}
+
+type usageLogs struct {
+	consumer.Logs
+	usage *Usage
+}
+
+func (c usageLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	var size int64
+	if c.usage.sizeEnabled(ctx) {
+		size = int64(logsMarshaler.LogsSize(ld))
+	}
+	ctx, done := c.usage.begin(ctx, int64(ld.LogRecordCount()), size)
+	defer done()
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+func NewMetrics(metrics consumer.Metrics, usage *Usage) consumer.Metrics {
+	if usage == nil {
+		return metrics
+	}
+	return usageMetrics{Metrics: metrics, usage: usage}
+}
+
+type usageMetrics struct {
+	consumer.Metrics
+	usage *Usage
+}
+
+func (c usageMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var size int64
+	if c.usage.sizeEnabled(ctx) {
+		size = int64(metricsMarshaler.MetricsSize(md))
+	}
+	ctx, done := c.usage.begin(ctx, int64(md.DataPointCount()), size)
+	defer done()
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+func NewTraces(traces consumer.Traces, usage *Usage) consumer.Traces {
+	if usage == nil {
+		return traces
+	}
+	return usageTraces{Traces: traces, usage: usage}
+}
+
+type usageTraces struct {
+	consumer.Traces
+	usage *Usage
+}
+
+func (c usageTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	var size int64
+	if c.usage.sizeEnabled(ctx) {
+		size = int64(tracesMarshaler.TracesSize(td))
+	}
+	ctx, done := c.usage.begin(ctx, int64(td.SpanCount()), size)
+	defer done()
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+func NewProfiles(profiles xconsumer.Profiles, usage *Usage) xconsumer.Profiles {
+	if usage == nil {
+		return profiles
+	}
+	return usageProfiles{Profiles: profiles, usage: usage}
+}
+
+type usageProfiles struct {
+	xconsumer.Profiles
+	usage *Usage
+}
+
+func (c usageProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	var size int64
+	if c.usage.sizeEnabled(ctx) {
+		size = int64(profilesMarshaler.ProfilesSize(pd))
+	}
+	ctx, done := c.usage.begin(ctx, int64(pd.SampleCount()), size)
+	defer done()
+	return c.Profiles.ConsumeProfiles(ctx, pd)
+}
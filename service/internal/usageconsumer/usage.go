@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package usageconsumer wraps consumers to account the resources used by the component
+// consuming the data: the items it holds and the time it spends consuming them.
+package usageconsumer // import "go.opentelemetry.io/collector/service/internal/usageconsumer"
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+var ResourceAccountingGate = featuregate.GlobalRegistry().MustRegister(
+	"service.componentResourceAccounting",
+	featuregate.StageAlpha,
+	featuregate.WithRegisterFromVersion("v0.137.0"),
+	featuregate.WithRegisterDescription("Tracks the items held and the time spent by each pipeline component, "+
+		"and reports them through internal telemetry and zpages"),
+)
+
+// Settings defines the instruments used to report the usage of a component.
+type Settings struct {
+	// InFlightItems is the metric tracking the number of items held by the component.
+	InFlightItems metric.Int64UpDownCounter
+
+	// InFlightSize is the metric tracking the size of items held by the component.
+	// The size is only computed if the instrument is enabled.
+	InFlightSize metric.Int64UpDownCounter
+
+	// BusyTime is the metric counting the time spent by the component consuming data.
+	// If nil, the time is not tracked, which is used for receivers as they produce
+	// the data instead of consuming it.
+	BusyTime metric.Float64Counter
+}
+
+// Snapshot is the usage of a component at a point in time.
+type Snapshot struct {
+	// InFlightItems is the number of items currently held by the component.
+	InFlightItems int64
+	// InFlightSize is the size in bytes of the items currently held by the component,
+	// or zero if the size is not tracked.
+	InFlightSize int64
+	// BusyTime is the total time spent by the component consuming data, excluding
+	// the time spent in the downstream components called synchronously.
+	BusyTime time.Duration
+}
+
+// Add returns the sum of both snapshots.
+func (s Snapshot) Add(other Snapshot) Snapshot {
+	return Snapshot{
+		InFlightItems: s.InFlightItems + other.InFlightItems,
+		InFlightSize:  s.InFlightSize + other.InFlightSize,
+		BusyTime:      s.BusyTime + other.BusyTime,
+	}
+}
+
+// Usage accumulates the resources used by one component instance. It is shared by all
+// the consumers of the instance.
+type Usage struct {
+	set Settings
+
+	inFlightItems atomic.Int64
+	inFlightSize  atomic.Int64
+	busyTime      atomic.Int64
+}
+
+// NewUsage returns a new Usage reporting to the given instruments, or nil if the
+// resource accounting is disabled.
+func NewUsage(set Settings) *Usage {
+	if !ResourceAccountingGate.IsEnabled() {
+		return nil
+	}
+	return &Usage{set: set}
+}
+
+// Snapshot returns the current usage. It is safe to call on a nil Usage.
+func (u *Usage) Snapshot() Snapshot {
+	if u == nil {
+		return Snapshot{}
+	}
+	return Snapshot{
+		InFlightItems: u.inFlightItems.Load(),
+		InFlightSize:  u.inFlightSize.Load(),
+		BusyTime:      time.Duration(u.busyTime.Load()),
+	}
+}
+
+type enabledInstrument interface {
+	Enabled(context.Context) bool
+}
+
+// sizeEnabled returns whether the size of the data must be computed.
+func (u *Usage) sizeEnabled(ctx context.Context) bool {
+	if u.set.InFlightSize == nil {
+		return false
+	}
+	ei, ok := u.set.InFlightSize.(enabledInstrument)
+	return !ok || ei.Enabled(ctx)
+}
+
+type callKey struct{}
+
+// call tracks one call to a consumer, so that the time spent in the downstream
+// consumers can be subtracted from its busy time.
+type call struct {
+	start      time.Time
+	downstream atomic.Int64
+}
+
+// begin records that the component holds the given data, and returns the context to
+// pass to the component along with the function to call once the data is consumed.
+func (u *Usage) begin(ctx context.Context, items, size int64) (context.Context, func()) {
+	u.inFlightItems.Add(items)
+	u.inFlightSize.Add(size)
+	if u.set.InFlightItems != nil {
+		u.set.InFlightItems.Add(ctx, items)
+	}
+	if size != 0 {
+		u.set.InFlightSize.Add(ctx, size)
+	}
+
+	if u.set.BusyTime == nil {
+		return ctx, func() { u.end(ctx, items, size) }
+	}
+
+	parent, _ := ctx.Value(callKey{}).(*call)
+	c := &call{start: time.Now()}
+	return context.WithValue(ctx, callKey{}, c), func() {
+		elapsed := time.Since(c.start)
+		if parent != nil {
+			parent.downstream.Add(int64(elapsed))
+		}
+		// The downstream calls may exceed the elapsed time if they run concurrently.
+		if busy := elapsed - time.Duration(c.downstream.Load()); busy > 0 {
+			u.busyTime.Add(int64(busy))
+			u.set.BusyTime.Add(ctx, busy.Seconds())
+		}
+		u.end(ctx, items, size)
+	}
+}
+
+func (u *Usage) end(ctx context.Context, items, size int64) {
+	u.inFlightItems.Add(-items)
+	u.inFlightSize.Add(-size)
+	if u.set.InFlightItems != nil {
+		u.set.InFlightItems.Add(ctx, -items)
+	}
+	if size != 0 {
+		u.set.InFlightSize.Add(ctx, -size)
+	}
+}
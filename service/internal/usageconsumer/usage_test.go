@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package usageconsumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+	"go.opentelemetry.io/collector/service/internal/metadatatest"
+)
+
+func setGateForTest(t *testing.T, enabled bool) {
+	initial := ResourceAccountingGate.IsEnabled()
+	require.NoError(t, featuregate.GlobalRegistry().Set(ResourceAccountingGate.ID(), enabled))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(ResourceAccountingGate.ID(), initial))
+	})
+}
+
+func newTestUsage(t *testing.T, tel *componenttest.Telemetry) *Usage {
+	tb, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	return NewUsage(Settings{
+		InFlightItems: tb.ComponentInFlightItems,
+		InFlightSize:  tb.ComponentInFlightSize,
+		BusyTime:      tb.ComponentBusyTime,
+	})
+}
+
+func TestDisabled(t *testing.T) {
+	setGateForTest(t, false)
+	usage := NewUsage(Settings{})
+	assert.Nil(t, usage)
+	assert.Equal(t, Snapshot{}, usage.Snapshot())
+
+	cons := consumertest.NewNop()
+	assert.Same(t, cons, NewLogs(cons, usage))
+	assert.Same(t, cons, NewMetrics(cons, usage))
+	assert.Same(t, cons, NewTraces(cons, usage))
+	assert.Same(t, cons, NewProfiles(cons, usage))
+}
+
+func TestInFlight(t *testing.T) {
+	setGateForTest(t, true)
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	usage := newTestUsage(t, tel)
+
+	var during Snapshot
+	inspect := func(context.Context) { during = usage.Snapshot() }
+
+	logs, err := consumer.NewLogs(func(ctx context.Context, _ plog.Logs) error {
+		inspect(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, NewLogs(logs, usage).ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	assert.Equal(t, int64(2), during.InFlightItems)
+
+	metrics, err := consumer.NewMetrics(func(ctx context.Context, _ pmetric.Metrics) error {
+		inspect(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, NewMetrics(metrics, usage).ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+	assert.Equal(t, int64(2), during.InFlightItems)
+
+	traces, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		inspect(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, NewTraces(traces, usage).ConsumeTraces(context.Background(), testdata.GenerateTraces(3)))
+	assert.Equal(t, int64(3), during.InFlightItems)
+
+	profiles := consumertest.NewErr(assert.AnError)
+	require.ErrorIs(t, NewProfiles(profiles, usage).ConsumeProfiles(context.Background(), pprofile.NewProfiles()), assert.AnError)
+
+	assert.Positive(t, during.InFlightSize)
+	assert.Zero(t, usage.Snapshot().InFlightItems)
+	assert.Zero(t, usage.Snapshot().InFlightSize)
+	metadatatest.AssertEqualComponentInFlightItems(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 0}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestBusyTimeExcludesDownstream(t *testing.T) {
+	setGateForTest(t, true)
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	upstreamUsage := newTestUsage(t, tel)
+	downstreamUsage := newTestUsage(t, tel)
+
+	downstream, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	require.NoError(t, err)
+	next := NewTraces(downstream, downstreamUsage)
+	upstream, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		return next.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, NewTraces(upstream, upstreamUsage).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.GreaterOrEqual(t, downstreamUsage.Snapshot().BusyTime, 50*time.Millisecond)
+	assert.Less(t, upstreamUsage.Snapshot().BusyTime, 50*time.Millisecond)
+}
+
+func TestNoBusyTime(t *testing.T) {
+	setGateForTest(t, true)
+	usage := NewUsage(Settings{})
+
+	var called bool
+	logs, err := consumer.NewLogs(func(ctx context.Context, _ plog.Logs) error {
+		called = true
+		assert.Nil(t, ctx.Value(callKey{}))
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, NewLogs(logs, usage).ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.True(t, called)
+	assert.Equal(t, Snapshot{}, usage.Snapshot())
+}
+
+func TestSnapshotAdd(t *testing.T) {
+	assert.Equal(t,
+		Snapshot{InFlightItems: 3, InFlightSize: 30, BusyTime: 3 * time.Second},
+		Snapshot{InFlightItems: 1, InFlightSize: 10, BusyTime: time.Second}.Add(
+			Snapshot{InFlightItems: 2, InFlightSize: 20, BusyTime: 2 * time.Second}),
+	)
+}
@@ -41,6 +41,10 @@ var (
 	propertiesTableBytes    []byte
 	propertiesTableTemplate = parseTemplate("properties_table", propertiesTableBytes)
 
+	//go:embed templates/stats_table.html
+	statsTableBytes    []byte
+	statsTableTemplate = parseTemplate("stats_table", statsTableBytes)
+
 	//go:embed templates/features_table.html
 	featuresTableBytes    []byte
 	featuresTableTemplate = parseTemplate("features_table", featuresTableBytes)
@@ -53,6 +57,8 @@ func parseTemplate(name string, bytes []byte) *template.Template {
 // HeaderData contains data for the header template.
 type HeaderData struct {
 	Title string
+	// Refresh is the number of seconds after which the browser reloads the page, zero to never reload it.
+	Refresh int
 }
 
 // WriteHTMLPageHeader writes the header.
@@ -131,6 +137,29 @@ func WriteHTMLPropertiesTable(w io.Writer, chd PropertiesTableData) {
 	}
 }
 
+// StatsTableData contains data for the statistics table of a pipeline.
+type StatsTableData struct {
+	Name string
+	Rows []StatsTableRowData
+}
+
+// StatsTableRowData contains the statistics of one component of a pipeline, already formatted.
+type StatsTableRowData struct {
+	Component string
+	Accepted  string
+	Refused   string
+	Dropped   string
+	Queue     string
+	Batch     string
+}
+
+// WriteHTMLStatsTable writes the statistics table of a pipeline.
+func WriteHTMLStatsTable(w io.Writer, std StatsTableData) {
+	if err := statsTableTemplate.Execute(w, std); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
 // WriteHTMLPageFooter writes the footer.
 func WriteHTMLPageFooter(w io.Writer) {
 	if err := footerTemplate.Execute(w, nil); err != nil {
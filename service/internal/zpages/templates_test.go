@@ -79,6 +79,25 @@ func TestNoCrash(t *testing.T) {
 			},
 		}})
 	})
+	assert.NotPanics(t, func() {
+		WriteHTMLStatsTable(buf, StatsTableData{Name: "traces", Rows: []StatsTableRowData{
+			{
+				Component: "exporter: otlp",
+				Accepted:  "12.5",
+				Queue:     "10/1000",
+			},
+		}})
+	})
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
 }
+
+func TestPageHeaderRefresh(t *testing.T) {
+	buf := new(bytes.Buffer)
+	WriteHTMLPageHeader(buf, HeaderData{Title: "Foo"})
+	assert.NotContains(t, buf.String(), "refresh")
+
+	buf.Reset()
+	WriteHTMLPageHeader(buf, HeaderData{Title: "Foo", Refresh: 2})
+	assert.Contains(t, buf.String(), `<meta http-equiv="refresh" content="2">`)
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelines // import "go.opentelemetry.io/collector/service/pipelines"
+
+import (
+	"errors"
+)
+
+// BufferConfig defines a bounded queue decoupling a connector from the pipelines exporting to it.
+type BufferConfig struct {
+	// QueueSize is the maximum number of calls waiting to be consumed by the connector.
+	// The calls are refused once the queue is full.
+	QueueSize int `mapstructure:"queue_size"`
+
+	// NumConsumers is the number of goroutines consuming the queue, 1 if not set.
+	NumConsumers int `mapstructure:"num_consumers"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *BufferConfig) Validate() error {
+	if cfg.QueueSize <= 0 {
+		return errors.New("queue_size must be positive")
+	}
+	if cfg.NumConsumers < 0 {
+		return errors.New("num_consumers must not be negative")
+	}
+	return nil
+}
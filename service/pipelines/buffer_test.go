@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestBufferConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         BufferConfig
+		expectedErr string
+	}{
+		{name: "valid", cfg: BufferConfig{QueueSize: 10, NumConsumers: 2}},
+		{name: "default_consumers", cfg: BufferConfig{QueueSize: 10}},
+		{name: "no_queue_size", cfg: BufferConfig{}, expectedErr: "queue_size must be positive"},
+		{name: "negative_consumers", cfg: BufferConfig{QueueSize: 10, NumConsumers: -1}, expectedErr: "num_consumers must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestFeedbackConfigValidate(t *testing.T) {
+	cfg := FeedbackConfig{Buffer: BufferConfig{QueueSize: 10}}
+	assert.EqualError(t, cfg.Validate(), "pipelines must not be empty")
+
+	cfg.Pipelines = []pipeline.ID{pipeline.NewID(pipeline.SignalLogs)}
+	assert.NoError(t, cfg.Validate())
+}
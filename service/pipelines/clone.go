@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelines // import "go.opentelemetry.io/collector/service/pipelines"
+
+import (
+	"fmt"
+)
+
+// ClonePolicy defines how the data is copied for the consumers mutating it where a pipeline
+// fans out its data, to its exporters or from the receivers it shares with other pipelines.
+type ClonePolicy string
+
+const (
+	// CloneEager copies the data for each consumer mutating it, unless all the consumers mutate
+	// it, in which case the last one gets the original data. This is the default.
+	CloneEager ClonePolicy = "eager"
+	// CloneLazy calls the consumers not mutating the data first, then passes the original data to
+	// the last consumer mutating it, saving a copy. The consumers not mutating the data must not
+	// use it once they return, e.g. by queuing it.
+	CloneLazy ClonePolicy = "lazy"
+	// CloneReadOnly marks the pipeline and its exporters as not mutating the data, so that the data
+	// is shared without copies. A component mutating the shared data panics.
+	CloneReadOnly ClonePolicy = "read_only"
+)
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (p *ClonePolicy) UnmarshalText(text []byte) error {
+	switch policy := ClonePolicy(text); policy {
+	case CloneEager, CloneLazy, CloneReadOnly:
+		*p = policy
+		return nil
+	}
+	return fmt.Errorf("unknown clone policy %q, must be one of %q, %q or %q", text, CloneEager, CloneLazy, CloneReadOnly)
+}
@@ -0,0 +1,22 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClonePolicyUnmarshalText(t *testing.T) {
+	for _, policy := range []ClonePolicy{CloneEager, CloneLazy, CloneReadOnly} {
+		var p ClonePolicy
+		require.NoError(t, p.UnmarshalText([]byte(policy)))
+		assert.Equal(t, policy, p)
+	}
+
+	var p ClonePolicy
+	require.EqualError(t, p.UnmarshalText([]byte("never")), `unknown clone policy "never", must be one of "eager", "lazy" or "read_only"`)
+}
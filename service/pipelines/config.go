@@ -6,17 +6,23 @@ package pipelines // import "go.opentelemetry.io/collector/service/pipelines"
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/service/health"
 )
 
 var (
 	errMissingServicePipelines         = errors.New("service must have at least one pipeline")
 	errMissingServicePipelineReceivers = errors.New("must have at least one receiver")
 	errMissingServicePipelineExporters = errors.New("must have at least one exporter")
+	errNegativeDeadline                = errors.New("deadline must not be negative")
+	errNegativeMaxConcurrency          = errors.New("max_concurrency must not be negative")
+	errShadowPipelineReceivers         = errors.New("shadow pipeline must not have receivers")
 
 	serviceProfileSupportGateID = "service.profilesSupport"
 	serviceProfileSupportGate   = featuregate.GlobalRegistry().MustRegister(
@@ -64,11 +70,57 @@ type PipelineConfig struct {
 	Receivers  []component.ID `mapstructure:"receivers"`
 	Processors []component.ID `mapstructure:"processors"`
 	Exporters  []component.ID `mapstructure:"exporters"`
+
+	// Deadline bounds the time the data entering the pipeline spends in the synchronous calls
+	// to its processors and exporters. Once the deadline is exceeded, the context passed to the
+	// components is canceled and the next components are not called. Disabled if zero.
+	Deadline time.Duration `mapstructure:"deadline,omitempty"`
+
+	// MaxConcurrency bounds the number of calls flowing concurrently through the processors and
+	// exporters of the pipeline, regardless of the concurrency of its receivers. The calls exceeding
+	// the limit wait for a slot until their context is done. Unlimited if zero.
+	MaxConcurrency int `mapstructure:"max_concurrency,omitempty"`
+
+	// MeasureLatency records the time from the data entering the pipeline until all its exporters exported
+	// it, including the time spent in their sending queues, in the otelcol.pipeline.latency histogram.
+	// Disabled by default, as it requests the delivery confirmation of every payload.
+	MeasureLatency bool `mapstructure:"measure_latency,omitempty"`
+
+	// ClonePolicy defines how the data is copied for the consumers mutating it where the pipeline
+	// fans out its data. CloneEager if not set.
+	ClonePolicy ClonePolicy `mapstructure:"clone_policy,omitempty"`
+
+	// Enabled controls whether the pipeline is built when the collector starts, so that a
+	// configuration can be shipped to several environments, e.g. with `enabled: ${env:TRACES_ENABLED}`.
+	// Pipelines are enabled by default.
+	Enabled *bool `mapstructure:"enabled,omitempty"`
+
+	// FeatureGate is the ID of a feature gate the pipeline depends on: the pipeline is only built
+	// if the gate is enabled, or if it is disabled when the ID is prefixed with '-'.
+	FeatureGate string `mapstructure:"feature_gate,omitempty"`
+
+	// OnStartFailure defines what happens when a component of the pipeline fails to start,
+	// unless the component has its own policy. The collector fails to start by default.
+	OnStartFailure health.StartFailurePolicy `mapstructure:"on_start_failure,omitempty"`
+
+	// Shutdown defines when the pipeline is shut down relative to the other pipelines, and how
+	// long each stage of the pipeline has to drain its data.
+	Shutdown ShutdownConfig `mapstructure:"shutdown,omitempty"`
+
+	// Tenants partitions the pipeline by tenant, if set.
+	Tenants *TenantsConfig `mapstructure:"tenants,omitempty"`
+
+	// Shadow makes the pipeline the shadow of another pipeline, receiving a copy of its input, if set.
+	Shadow *ShadowConfig `mapstructure:"shadow,omitempty"`
 }
 
 func (cfg *PipelineConfig) Validate() error {
-	// Validate pipeline has at least one receiver.
-	if len(cfg.Receivers) == 0 {
+	// Validate pipeline has at least one receiver, unless it receives the input of its primary pipeline.
+	if cfg.Shadow != nil {
+		if len(cfg.Receivers) != 0 {
+			return errShadowPipelineReceivers
+		}
+	} else if len(cfg.Receivers) == 0 {
 		return errMissingServicePipelineReceivers
 	}
 
@@ -87,5 +139,53 @@ func (cfg *PipelineConfig) Validate() error {
 		procSet[ref] = struct{}{}
 	}
 
+	if cfg.Deadline < 0 {
+		return errNegativeDeadline
+	}
+
+	if cfg.MaxConcurrency < 0 {
+		return errNegativeMaxConcurrency
+	}
+
+	if cfg.FeatureGate != "" {
+		if _, found := gateEnabled(strings.TrimPrefix(cfg.FeatureGate, "-")); !found {
+			return fmt.Errorf("references unknown feature gate %q", strings.TrimPrefix(cfg.FeatureGate, "-"))
+		}
+	}
+
 	return nil
 }
+
+// IsEnabled returns whether the pipeline is built when the collector starts.
+func (cfg *PipelineConfig) IsEnabled() bool {
+	if cfg.Enabled != nil && !*cfg.Enabled {
+		return false
+	}
+	if cfg.FeatureGate == "" {
+		return true
+	}
+	id, negated := strings.CutPrefix(cfg.FeatureGate, "-")
+	enabled, _ := gateEnabled(id)
+	return enabled != negated
+}
+
+// Enabled returns the pipelines built when the collector starts.
+func (cfg Config) Enabled() Config {
+	enabled := make(Config, len(cfg))
+	for pipelineID, pipe := range cfg {
+		if pipe.IsEnabled() {
+			enabled[pipelineID] = pipe
+		}
+	}
+	return enabled
+}
+
+// gateEnabled returns whether the feature gate with the given ID is enabled, and whether it is registered.
+func gateEnabled(id string) (enabled, found bool) {
+	featuregate.GlobalRegistry().VisitAll(func(g *featuregate.Gate) {
+		if g.ID() == id {
+			enabled, found = g.IsEnabled(), true
+		}
+	})
+	return enabled, found
+}
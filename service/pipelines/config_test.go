@@ -5,8 +5,12 @@ package pipelines
 
 import (
 	"errors"
+	"maps"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
@@ -46,6 +50,27 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errMissingServicePipelineReceivers,
 		},
+		{
+			name: "shadow-pipeline",
+			cfgFn: func(*testing.T) Config {
+				cfg := generateConfig(t)
+				cfg[pipeline.NewIDWithName(pipeline.SignalTraces, "canary")] = &PipelineConfig{
+					Exporters: []component.ID{component.MustNewID("nop")},
+					Shadow:    &ShadowConfig{Of: pipeline.NewID(pipeline.SignalTraces)},
+				}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "shadow-pipeline-receivers",
+			cfgFn: func(*testing.T) Config {
+				cfg := generateConfig(t)
+				cfg[pipeline.NewID(pipeline.SignalTraces)].Shadow = &ShadowConfig{Of: pipeline.NewID(pipeline.SignalTraces)}
+				return cfg
+			},
+			expected: errShadowPipelineReceivers,
+		},
 		{
 			name: "missing-pipeline-exporters",
 			cfgFn: func(*testing.T) Config {
@@ -55,6 +80,33 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errMissingServicePipelineExporters,
 		},
+		{
+			name: "negative-deadline",
+			cfgFn: func(*testing.T) Config {
+				cfg := generateConfig(t)
+				cfg[pipeline.NewID(pipeline.SignalTraces)].Deadline = -time.Second
+				return cfg
+			},
+			expected: errNegativeDeadline,
+		},
+		{
+			name: "negative-max-concurrency",
+			cfgFn: func(*testing.T) Config {
+				cfg := generateConfig(t)
+				cfg[pipeline.NewID(pipeline.SignalTraces)].MaxConcurrency = -1
+				return cfg
+			},
+			expected: errNegativeMaxConcurrency,
+		},
+		{
+			name: "unknown-feature-gate",
+			cfgFn: func(*testing.T) Config {
+				cfg := generateConfig(t)
+				cfg[pipeline.NewID(pipeline.SignalTraces)].FeatureGate = "-unknown.gate"
+				return cfg
+			},
+			expected: errors.New(`references unknown feature gate "unknown.gate"`),
+		},
 		{
 			name: "missing-pipelines",
 			cfgFn: func(*testing.T) Config {
@@ -121,6 +173,30 @@ func TestNoPipelinesFeatureGate(t *testing.T) {
 	require.NoError(t, xconfmap.Validate(cfg))
 }
 
+func TestConfigEnabled(t *testing.T) {
+	disabled := false
+	cfg := Config{
+		pipeline.NewID(pipeline.SignalTraces):                    {},
+		pipeline.NewIDWithName(pipeline.SignalTraces, "off"):     {Enabled: &disabled},
+		pipeline.NewIDWithName(pipeline.SignalTraces, "gate"):    {FeatureGate: serviceProfileSupportGateID},
+		pipeline.NewIDWithName(pipeline.SignalTraces, "ungated"): {FeatureGate: "-" + serviceProfileSupportGateID},
+		pipeline.NewIDWithName(pipeline.SignalTraces, "unknown"): {FeatureGate: "unknown.gate"},
+	}
+	assert.ElementsMatch(t, []pipeline.ID{
+		pipeline.NewID(pipeline.SignalTraces),
+		pipeline.NewIDWithName(pipeline.SignalTraces, "ungated"),
+	}, slices.Collect(maps.Keys(cfg.Enabled())))
+
+	require.NoError(t, featuregate.GlobalRegistry().Set(serviceProfileSupportGateID, true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(serviceProfileSupportGateID, false))
+	}()
+	assert.ElementsMatch(t, []pipeline.ID{
+		pipeline.NewID(pipeline.SignalTraces),
+		pipeline.NewIDWithName(pipeline.SignalTraces, "gate"),
+	}, slices.Collect(maps.Keys(cfg.Enabled())))
+}
+
 func generateConfig(t *testing.T) Config {
 	t.Helper()
 
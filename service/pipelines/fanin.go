@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelines // import "go.opentelemetry.io/collector/service/pipelines"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// FanInMode defines how a connector receives the data exported to it by several pipelines.
+type FanInMode string
+
+const (
+	// FanInPassThrough passes each call made by the exporting pipelines to the connector as is.
+	// The calls are concurrent, and no ordering is guaranteed between the pipelines.
+	FanInPassThrough FanInMode = "pass_through"
+	// FanInMerge merges the data exported during a window into a single call to the connector.
+	// The data of the merged call is ordered by arrival.
+	FanInMerge FanInMode = "merge"
+)
+
+// FanInConfig defines how a connector receives the data exported to it.
+type FanInConfig struct {
+	// Mode is the fan-in mode, FanInPassThrough if not set.
+	Mode FanInMode `mapstructure:"mode"`
+
+	// Window is the maximum time the data waits to be merged with the data exported after it.
+	// Required with FanInMerge.
+	Window time.Duration `mapstructure:"window"`
+
+	// MaxItems sends the merged data as soon as it holds at least this number of spans,
+	// data points or log records. Unlimited if not set.
+	MaxItems int `mapstructure:"max_items"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *FanInConfig) Validate() error {
+	switch cfg.Mode {
+	case "", FanInPassThrough:
+		if cfg.Window != 0 || cfg.MaxItems != 0 {
+			return errors.New("window and max_items require the merge mode")
+		}
+	case FanInMerge:
+		if cfg.Window <= 0 {
+			return errors.New("window must be positive with the merge mode")
+		}
+		if cfg.MaxItems < 0 {
+			return errors.New("max_items must not be negative")
+		}
+	default:
+		return fmt.Errorf("invalid mode %q", cfg.Mode)
+	}
+	return nil
+}
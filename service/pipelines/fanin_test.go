@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelines
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanInConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         FanInConfig
+		expectedErr string
+	}{
+		{name: "default", cfg: FanInConfig{}},
+		{name: "pass_through", cfg: FanInConfig{Mode: FanInPassThrough}},
+		{name: "merge", cfg: FanInConfig{Mode: FanInMerge, Window: time.Second, MaxItems: 100}},
+		{name: "pass_through_window", cfg: FanInConfig{Window: time.Second}, expectedErr: "window and max_items require the merge mode"},
+		{name: "merge_without_window", cfg: FanInConfig{Mode: FanInMerge}, expectedErr: "window must be positive with the merge mode"},
+		{name: "negative_max_items", cfg: FanInConfig{Mode: FanInMerge, Window: time.Second, MaxItems: -1}, expectedErr: "max_items must not be negative"},
+		{name: "invalid_mode", cfg: FanInConfig{Mode: "zip"}, expectedErr: `invalid mode "zip"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
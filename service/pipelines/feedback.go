@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelines // import "go.opentelemetry.io/collector/service/pipelines"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// FeedbackConfig marks the edges from some pipelines to a connector as feedback edges, allowing
+// these pipelines to feed data back to the connector even though this forms a cycle.
+type FeedbackConfig struct {
+	// Pipelines are the pipelines whose data exported to the connector is fed back.
+	Pipelines []pipeline.ID `mapstructure:"pipelines"`
+
+	// Buffer is the bounded queue through which the data is fed back. It breaks the cycle:
+	// the data is refused once the queue is full, instead of blocking the pipelines of the cycle.
+	Buffer BufferConfig `mapstructure:"buffer"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *FeedbackConfig) Validate() error {
+	if len(cfg.Pipelines) == 0 {
+		return errors.New("pipelines must not be empty")
+	}
+	return nil
+}
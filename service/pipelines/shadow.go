@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelines // import "go.opentelemetry.io/collector/service/pipelines"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// ShadowConfig makes a pipeline the shadow of a primary pipeline, e.g. to canary new processors or
+// exporters on live traffic. The shadow pipeline has no receivers: it receives a copy of the data
+// entering the primary pipeline, through a bounded queue, so that its failures and latency never
+// affect the primary pipeline.
+type ShadowConfig struct {
+	// Of is the ID of the primary pipeline, of the same signal.
+	Of pipeline.ID `mapstructure:"of"`
+
+	// SamplingRatio is the ratio of the batches entering the primary pipeline which are copied to
+	// the shadow pipeline, between 0 and 1. All the batches are copied if not set, and none of
+	// them if 0.
+	SamplingRatio *float64 `mapstructure:"sampling_ratio,omitempty"`
+
+	// QueueSize is the maximum number of copied batches waiting to be consumed by the shadow
+	// pipeline, 100 if not set. The copies are dropped once the queue is full.
+	QueueSize int `mapstructure:"queue_size,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *ShadowConfig) Validate() error {
+	if cfg.SamplingRatio != nil && (*cfg.SamplingRatio < 0 || *cfg.SamplingRatio > 1) {
+		return errors.New("sampling_ratio must be between 0 and 1")
+	}
+	if cfg.QueueSize < 0 {
+		return errors.New("queue_size must not be negative")
+	}
+	return nil
+}
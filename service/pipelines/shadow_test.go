@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestShadowConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         ShadowConfig
+		expectedErr string
+	}{
+		{name: "valid", cfg: ShadowConfig{Of: pipeline.NewID(pipeline.SignalTraces), SamplingRatio: ptr(0.1), QueueSize: 10}},
+		{name: "zero_sampling_ratio", cfg: ShadowConfig{Of: pipeline.NewID(pipeline.SignalTraces), SamplingRatio: ptr(0.0)}},
+		{name: "defaults", cfg: ShadowConfig{Of: pipeline.NewID(pipeline.SignalTraces)}},
+		{name: "sampling_ratio_above_one", cfg: ShadowConfig{SamplingRatio: ptr(1.5)}, expectedErr: "sampling_ratio must be between 0 and 1"},
+		{name: "negative_sampling_ratio", cfg: ShadowConfig{SamplingRatio: ptr(-0.5)}, expectedErr: "sampling_ratio must be between 0 and 1"},
+		{name: "negative_queue_size", cfg: ShadowConfig{QueueSize: -1}, expectedErr: "queue_size must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
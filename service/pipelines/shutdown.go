@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelines // import "go.opentelemetry.io/collector/service/pipelines"
+
+import (
+	"errors"
+	"time"
+)
+
+// ShutdownConfig defines the order and the drain deadlines of the shutdown of a pipeline.
+type ShutdownConfig struct {
+	// Order defines the order in which the pipelines are shut down: the pipelines with a lower
+	// order are shut down first, 0 if not set. A component shared by several pipelines is shut
+	// down with the last of them, and never before the components emitting data to it.
+	Order int `mapstructure:"order,omitempty"`
+
+	// Receivers bounds the time the receivers of the pipeline take to stop receiving data.
+	Receivers time.Duration `mapstructure:"receivers,omitempty"`
+
+	// Processors bounds the time the processors of the pipeline take to flush their data.
+	Processors time.Duration `mapstructure:"processors,omitempty"`
+
+	// Exporters bounds the time the exporters of the pipeline, and the connectors it exports to,
+	// take to drain their queues.
+	Exporters time.Duration `mapstructure:"exporters,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *ShutdownConfig) Validate() error {
+	if cfg.Receivers < 0 || cfg.Processors < 0 || cfg.Exporters < 0 {
+		return errors.New("drain deadlines must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelines
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdownConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         ShutdownConfig
+		expectedErr string
+	}{
+		{name: "default", cfg: ShutdownConfig{}},
+		{name: "valid", cfg: ShutdownConfig{Order: -1, Receivers: time.Second, Processors: 2 * time.Second, Exporters: 10 * time.Second}},
+		{name: "negative_receivers", cfg: ShutdownConfig{Receivers: -time.Second}, expectedErr: "drain deadlines must not be negative"},
+		{name: "negative_exporters", cfg: ShutdownConfig{Exporters: -time.Second}, expectedErr: "drain deadlines must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelines // import "go.opentelemetry.io/collector/service/pipelines"
+
+import (
+	"errors"
+	"time"
+)
+
+// TenantsConfig partitions a pipeline by tenant: the processors and exporters of the pipeline are
+// instantiated for each tenant, so that the tenants have their own queues and metrics.
+type TenantsConfig struct {
+	// MetadataKey is the key of the client metadata identifying the tenant of the data, e.g.
+	// "x-tenant". The data without the key goes through the processors and exporters shared by
+	// the data of all the tenants.
+	MetadataKey string `mapstructure:"metadata_key"`
+
+	// MaxTenants bounds the number of tenants of the pipeline, 100 if not set. The data of the
+	// tenants beyond the limit is refused.
+	MaxTenants int `mapstructure:"max_tenants,omitempty"`
+
+	// IdleTimeout is the duration after which the processors and exporters of a tenant which has
+	// not sent data are shut down, to be started again by its next data. The components of the
+	// tenants are kept until the pipeline is shut down if not set.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *TenantsConfig) Validate() error {
+	if cfg.MetadataKey == "" {
+		return errors.New("metadata_key must be set")
+	}
+	if cfg.MaxTenants < 0 {
+		return errors.New("max_tenants must not be negative")
+	}
+	if cfg.IdleTimeout < 0 {
+		return errors.New("idle_timeout must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelines
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenantsConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         TenantsConfig
+		expectedErr string
+	}{
+		{name: "valid", cfg: TenantsConfig{MetadataKey: "x-tenant", MaxTenants: 10}},
+		{name: "missing_metadata_key", cfg: TenantsConfig{}, expectedErr: "metadata_key must be set"},
+		{name: "negative_max_tenants", cfg: TenantsConfig{MetadataKey: "x-tenant", MaxTenants: -1}, expectedErr: "max_tenants must not be negative"},
+		{name: "negative_idle_timeout", cfg: TenantsConfig{MetadataKey: "x-tenant", IdleTimeout: -time.Second}, expectedErr: "idle_timeout must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
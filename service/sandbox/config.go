@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sandbox defines the configuration of the restrictions the service applies to the collector
+// process once its components are started, to limit what a compromised component can do.
+package sandbox // import "go.opentelemetry.io/collector/service/sandbox"
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+)
+
+// Config defines the restrictions applied to the collector process once the components of the service
+// are started, i.e. after they opened their listening sockets and the files they read at start.
+type Config struct {
+	// Enabled applies the restrictions: the process cannot gain privileges anymore and drops its
+	// capabilities, the system calls administering the host, like mount or ptrace, are denied, and
+	// the filesystem can only be accessed under ReadOnlyPaths and ReadWritePaths. It is only supported
+	// on Linux, and the restrictions are kept until the collector exits.
+	Enabled bool `mapstructure:"enabled"`
+
+	// ReadOnlyPaths are the absolute paths of the files and directories the collector can still read,
+	// e.g. the certificates reloaded by the TLS settings, or /etc/resolv.conf to resolve host names.
+	ReadOnlyPaths []string `mapstructure:"read_only_paths,omitempty"`
+
+	// ReadWritePaths are the absolute paths of the files and directories the collector can still read
+	// and write, e.g. the directory of a file storage.
+	ReadWritePaths []string `mapstructure:"read_write_paths,omitempty"`
+
+	// AllowExec allows the collector to run the programs under ReadOnlyPaths and ReadWritePaths,
+	// which is denied by default.
+	AllowExec bool `mapstructure:"allow_exec,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks that the configuration is valid.
+func (cfg *Config) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if runtime.GOOS != "linux" {
+		return errors.New("the sandbox is only supported on Linux")
+	}
+	var errs []error
+	for _, path := range cfg.ReadOnlyPaths {
+		if !filepath.IsAbs(path) {
+			errs = append(errs, fmt.Errorf("read_only_paths: %q must be an absolute path", path))
+		}
+	}
+	for _, path := range cfg.ReadWritePaths {
+		if !filepath.IsAbs(path) {
+			errs = append(errs, fmt.Errorf("read_write_paths: %q must be an absolute path", path))
+		}
+	}
+	return errors.Join(errs...)
+}
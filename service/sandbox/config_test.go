@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sandbox
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cfg := Config{ReadOnlyPaths: []string{"relative"}}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Enabled = true
+	if runtime.GOOS != "linux" {
+		require.EqualError(t, cfg.Validate(), "the sandbox is only supported on Linux")
+		return
+	}
+	cfg.ReadWritePaths = []string{"/var/lib/otelcol", "data"}
+	assert.EqualError(t, cfg.Validate(), "read_only_paths: \"relative\" must be an absolute path\nread_write_paths: \"data\" must be an absolute path")
+
+	cfg.ReadOnlyPaths = []string{"/etc/otelcol"}
+	cfg.ReadWritePaths = []string{"/var/lib/otelcol"}
+	assert.NoError(t, cfg.Validate())
+}
@@ -10,6 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"slices"
+	"strings"
+	"sync"
 
 	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
 	noopmetric "go.opentelemetry.io/otel/metric/noop"
@@ -23,16 +26,25 @@ import (
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/goruntime"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
 	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/gatetelemetry"
+	"go.opentelemetry.io/collector/service/internal/goruntimetuning"
 	"go.opentelemetry.io/collector/service/internal/graph"
 	"go.opentelemetry.io/collector/service/internal/moduleinfo"
 	"go.opentelemetry.io/collector/service/internal/proctelemetry"
+	"go.opentelemetry.io/collector/service/internal/sandboxing"
+	"go.opentelemetry.io/collector/service/internal/sinkexporters"
 	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/sandbox"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
 
@@ -93,6 +105,10 @@ type Settings struct {
 
 	// TelemetryFactory is the factory for creating internal telemetry providers.
 	TelemetryFactory telemetry.Factory
+
+	// SinkExporters replaces the exporters by sinks dropping the data, and logs the throughput of each
+	// pipeline periodically and on shutdown.
+	SinkExporters bool
 }
 
 // Service represents the implementation of a component.Host.
@@ -102,8 +118,26 @@ type Service struct {
 	host               *graph.Host
 	collectorConf      *confmap.Conf
 	loggerShutdownFunc component.ShutdownFunc
+	telemetryFactory   telemetry.Factory
 	meterProvider      telemetry.MeterProvider
 	tracerProvider     telemetry.TracerProvider
+
+	// sinkStats counts the items entering the pipelines with the sink exporters, nil otherwise.
+	sinkStats *sinkexporters.Stats
+
+	// runtime configures the settings of the Go runtime, applied again when the service is reloaded.
+	runtime goruntime.Config
+	// unwatchLifecycle stops tracking the status of the components in the lifecycle of the host.
+	unwatchLifecycle func()
+	// restoreRuntime restores the settings of the Go runtime set according to the runtime configuration.
+	restoreRuntime func()
+
+	// sandbox is applied once the components are started.
+	sandbox sandbox.Config
+
+	// configFailing is whether the collector configuration was last reported as failing to reload.
+	configMu      sync.Mutex
+	configFailing bool
 }
 
 // New creates a new Service, its telemetry, and Components.
@@ -120,8 +154,10 @@ func New(ctx context.Context, set Settings, cfg Config) (_ *Service, resultErr e
 			ModuleInfos:       set.ModuleInfos,
 			BuildInfo:         set.BuildInfo,
 			AsyncErrorChannel: set.AsyncErrorChannel,
+			Lifecycle:         status.NewLifecycle(),
 		},
 		collectorConf: set.CollectorConf,
+		sandbox:       cfg.Sandbox,
 	}
 
 	// Create the logger & LoggerProvider first. These may be used
@@ -145,6 +181,22 @@ func New(ctx context.Context, set Settings, cfg Config) (_ *Service, resultErr e
 		}
 	}()
 	srv.loggerShutdownFunc = loggerShutdownFunc
+	srv.telemetryFactory = set.TelemetryFactory
+	srv.host.LogLevels = componentattribute.LogLevelsOf(logger)
+
+	srv.runtime = cfg.Runtime
+	srv.restoreRuntime = goruntimetuning.Apply(logger, srv.runtime)
+	defer func() {
+		if resultErr != nil {
+			srv.restoreRuntime()
+		}
+	}()
+
+	if set.SinkExporters {
+		logger.Warn("Sink exporters: the exporters drop the data, and the throughput of the pipelines is logged instead")
+		srv.sinkStats = sinkexporters.NewStats(logger)
+		srv.host.Exporters = builders.NewExporter(set.ExportersConfigs, srv.exporterFactories(set.ExportersFactories))
+	}
 
 	meterSettings := telemetry.MeterSettings{
 		Settings:     telemetrySettings,
@@ -194,6 +246,12 @@ func New(ctx context.Context, set Settings, cfg Config) (_ *Service, resultErr e
 		}
 		// ignore other errors as they represent invalid state transitions and are considered benign.
 	})
+	srv.unwatchLifecycle = srv.host.Reporter.Watch(srv.host.Lifecycle)
+	defer func() {
+		if resultErr != nil {
+			srv.unwatchLifecycle()
+		}
+	}()
 
 	err = srv.initGraph(ctx, cfg)
 	if err != nil {
@@ -201,7 +259,7 @@ func New(ctx context.Context, set Settings, cfg Config) (_ *Service, resultErr e
 	}
 
 	// process the configuration and initialize the pipeline
-	err = srv.initExtensions(ctx, cfg.Extensions)
+	err = srv.initExtensions(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +267,9 @@ func New(ctx context.Context, set Settings, cfg Config) (_ *Service, resultErr e
 	if err := proctelemetry.RegisterProcessMetrics(srv.telemetrySettings); err != nil {
 		return nil, fmt.Errorf("failed to register process metrics: %w", err)
 	}
+	if err := gatetelemetry.RegisterFeatureGateMetrics(srv.telemetrySettings, featuregate.GlobalRegistry()); err != nil {
+		return nil, fmt.Errorf("failed to register feature gate metrics: %w", err)
+	}
 	return srv, nil
 }
 
@@ -234,7 +295,11 @@ func (srv *Service) Start(ctx context.Context) error {
 		}
 	}
 
-	if err := srv.host.Pipelines.StartAll(ctx, srv.host); err != nil {
+	if err := srv.host.ServiceExtensions.BeforePipelinesStart(ctx); err != nil {
+		return err
+	}
+
+	if err := srv.host.GetPipelines().StartAll(ctx, srv.host); err != nil {
 		return fmt.Errorf("cannot start pipelines: %w", err)
 	}
 
@@ -242,34 +307,159 @@ func (srv *Service) Start(ctx context.Context) error {
 		return err
 	}
 
+	if srv.sinkStats != nil {
+		srv.sinkStats.Start()
+	}
+
+	if err := sandboxing.Apply(srv.telemetrySettings.Logger, srv.sandbox); err != nil {
+		return err
+	}
+
+	srv.host.Lifecycle.SetPhase(hostcapabilities.LifecycleReady)
 	srv.telemetrySettings.Logger.Info("Everything is ready. Begin running and processing data.")
 	return nil
 }
 
+// ReloadExporters recreates the given exporters with their new configuration without restarting
+// the other components, then notifies the extensions of the new collector configuration.
+// If it fails, the service must be shut down and recreated to apply the configuration.
+func (srv *Service) ReloadExporters(ctx context.Context, conf *confmap.Conf, cfgs map[component.ID]component.Config) error {
+	ids := make([]component.ID, 0, len(cfgs))
+	for id := range cfgs {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b component.ID) int { return strings.Compare(a.String(), b.String()) })
+
+	exporters := srv.host.Exporters.WithConfigs(cfgs)
+	if err := srv.host.GetPipelines().ReloadExporters(ctx, srv.host, exporters, ids); err != nil {
+		return fmt.Errorf("failed to reload exporters: %w", err)
+	}
+	srv.host.Exporters = exporters
+	srv.reapplyRuntime()
+
+	srv.collectorConf = conf
+	if conf != nil {
+		return srv.host.ServiceExtensions.NotifyConfig(ctx, conf)
+	}
+	return nil
+}
+
+// ReloadPipelines applies the pipelines of cfg and the configurations of their components in set
+// without restarting the service: the components whose configuration, pipelines and downstream
+// components did not change keep running, the others are replaced. Only the configurations and
+// factories of the pipeline components and CollectorConf are read from set, the other settings
+// and the rest of cfg are expected to be unchanged.
+// If it fails, the service must be shut down and recreated to apply the configuration.
+func (srv *Service) ReloadPipelines(ctx context.Context, set Settings, cfg Config) error {
+	srv.host.Receivers = builders.NewReceiver(set.ReceiversConfigs, set.ReceiversFactories)
+	srv.host.Processors = builders.NewProcessor(set.ProcessorsConfigs, set.ProcessorsFactories)
+	srv.host.Exporters = builders.NewExporter(set.ExportersConfigs, srv.exporterFactories(set.ExportersFactories))
+	srv.host.Connectors = builders.NewConnector(set.ConnectorsConfigs, set.ConnectorsFactories)
+
+	pg, err := srv.host.GetPipelines().Reconcile(ctx, srv.host, srv.graphSettings(cfg))
+	if pg != nil {
+		srv.host.SetPipelines(pg)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reload pipelines: %w", err)
+	}
+	srv.reapplyRuntime()
+
+	srv.collectorConf = set.CollectorConf
+	if set.CollectorConf != nil {
+		return srv.host.ServiceExtensions.NotifyConfig(ctx, set.CollectorConf)
+	}
+	return nil
+}
+
+// reapplyRuntime applies the settings of the Go runtime again, derived from the current resources of
+// the collector, e.g. after its container was resized.
+func (srv *Service) reapplyRuntime() {
+	srv.restoreRuntime()
+	srv.restoreRuntime = goruntimetuning.Apply(srv.telemetrySettings.Logger, srv.runtime)
+}
+
+// LifecycleState returns the state of the service in the lifecycle of the collector.
+func (srv *Service) LifecycleState() hostcapabilities.LifecycleState {
+	return srv.host.Lifecycle.LifecycleState()
+}
+
+// HoldsStorage returns whether a storage extension is running, whose files, e.g. the bbolt
+// databases of the file storage, stay locked until it is shut down.
+func (srv *Service) HoldsStorage() bool {
+	for _, ext := range srv.host.ServiceExtensions.GetExtensions() {
+		if _, ok := ext.(storage.Extension); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// SetPipelinesPaused pauses or resumes the intake of all the pipelines: while paused, the pipelines
+// reject the data entering them with a retryable error, and the scraping receivers skip their scrapes.
+func (srv *Service) SetPipelinesPaused(paused bool) {
+	srv.host.GetPipelines().SetAllPaused(paused)
+}
+
+// ReportConfigStatus notifies the extensions implementing extensioncapabilities.ConfigStatusWatcher
+// of the status of the collector configuration: err when the configuration cannot be reloaded and
+// the service keeps running its last good configuration, and nil again once a configuration is
+// loaded. Only the first error of consecutive failures is reported.
+func (srv *Service) ReportConfigStatus(err error) {
+	srv.configMu.Lock()
+	defer srv.configMu.Unlock()
+	failing := err != nil
+	if failing == srv.configFailing {
+		return
+	}
+	srv.configFailing = failing
+	srv.host.Lifecycle.SetConfigStatus(err)
+	srv.host.ServiceExtensions.NotifyConfigStatus(err)
+}
+
+// ReopenLogs closes and reopens the log files of the service, e.g. after an external tool like
+// logrotate moved them.
+func (srv *Service) ReopenLogs(ctx context.Context) error {
+	return srv.telemetryFactory.ReopenLogs(ctx)
+}
+
 // Shutdown the service. Shutdown will do the following steps in order:
 // 1. Notify extensions that the pipeline is shutting down.
 // 2. Shutdown all pipelines.
-// 3. Shutdown all extensions.
-// 4. Shutdown telemetry.
+// 3. Run the post-shutdown hooks of extensions.
+// 4. Shutdown all extensions.
+// 5. Shutdown telemetry.
 func (srv *Service) Shutdown(ctx context.Context) error {
 	// Accumulate errors and proceed with shutting down remaining components.
 	var errs error
 
 	// Begin shutdown sequence.
 	srv.telemetrySettings.Logger.Info("Starting shutdown...")
+	srv.host.Lifecycle.SetPhase(hostcapabilities.LifecycleDraining)
 
 	if err := srv.host.ServiceExtensions.NotifyPipelineNotReady(); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to notify that pipeline is not ready: %w", err))
 	}
 
-	if err := srv.host.Pipelines.ShutdownAll(ctx, srv.host.Reporter); err != nil {
+	if err := srv.host.GetPipelines().ShutdownAll(ctx, srv.host.Reporter); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown pipelines: %w", err))
 	}
 
+	if srv.sinkStats != nil {
+		srv.sinkStats.Stop()
+	}
+
+	if err := srv.host.ServiceExtensions.AfterPipelinesShutdown(ctx); err != nil {
+		errs = multierr.Append(errs, err)
+	}
+
 	if err := srv.host.ServiceExtensions.Shutdown(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown extensions: %w", err))
 	}
 
+	srv.host.Lifecycle.SetPhase(hostcapabilities.LifecycleStopped)
+	srv.unwatchLifecycle()
+	srv.restoreRuntime()
 	srv.telemetrySettings.Logger.Info("Shutdown complete.")
 
 	// Shut down telemetry providers in the reverse order of creation,
@@ -288,14 +478,14 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 }
 
 // Creates extensions.
-func (srv *Service) initExtensions(ctx context.Context, cfg extensions.Config) error {
+func (srv *Service) initExtensions(ctx context.Context, cfg Config) error {
 	var err error
 	extensionsSettings := extensions.Settings{
 		Telemetry:  srv.telemetrySettings,
 		BuildInfo:  srv.buildInfo,
 		Extensions: srv.host.Extensions,
 	}
-	if srv.host.ServiceExtensions, err = extensions.New(ctx, extensionsSettings, cfg, extensions.WithReporter(srv.host.Reporter)); err != nil {
+	if srv.host.ServiceExtensions, err = extensions.New(ctx, extensionsSettings, cfg.Extensions, extensions.WithReporter(srv.host.Reporter), extensions.WithTimeouts(cfg.Health.Timeouts)); err != nil {
 		return fmt.Errorf("failed to build extensions: %w", err)
 	}
 	return nil
@@ -303,20 +493,48 @@ func (srv *Service) initExtensions(ctx context.Context, cfg extensions.Config) e
 
 // Creates the pipeline graph.
 func (srv *Service) initGraph(ctx context.Context, cfg Config) error {
+	for pipelineID, pipe := range cfg.Pipelines {
+		if !pipe.IsEnabled() {
+			srv.telemetrySettings.Logger.Info("Pipeline is disabled and will not be built", zap.String("pipeline", pipelineID.String()))
+		}
+	}
+
 	var err error
-	if srv.host.Pipelines, err = graph.Build(ctx, graph.Settings{
+	if srv.host.Pipelines, err = graph.Build(ctx, srv.graphSettings(cfg)); err != nil {
+		return fmt.Errorf("failed to build pipelines: %w", err)
+	}
+	return nil
+}
+
+// exporterFactories returns the factories of the exporters, replaced by sinks if SinkExporters is set.
+func (srv *Service) exporterFactories(factories map[component.Type]exporter.Factory) map[component.Type]exporter.Factory {
+	if srv.sinkStats == nil {
+		return factories
+	}
+	return sinkexporters.Factories(factories)
+}
+
+// graphSettings returns the settings of the pipeline graph of the configuration.
+func (srv *Service) graphSettings(cfg Config) graph.Settings {
+	return graph.Settings{
 		Telemetry:        srv.telemetrySettings,
 		BuildInfo:        srv.buildInfo,
 		ReceiverBuilder:  srv.host.Receivers,
 		ProcessorBuilder: srv.host.Processors,
 		ExporterBuilder:  srv.host.Exporters,
 		ConnectorBuilder: srv.host.Connectors,
-		PipelineConfigs:  cfg.Pipelines,
+		PipelineConfigs:  cfg.Pipelines.Enabled(),
 		ReportStatus:     srv.host.Reporter.ReportStatus,
-	}); err != nil {
-		return fmt.Errorf("failed to build pipelines: %w", err)
+		StatusDetector:   status.NewDetector(srv.host.Reporter, cfg.Health.Detection),
+		RestartConfig:    cfg.Health.Restart,
+		LazyExporters:    cfg.LazyExporters,
+		Timeouts:         cfg.Health.Timeouts,
+		StartFailure:     cfg.Health.OnStartFailure,
+		FanIn:            cfg.FanIn,
+		Buffer:           cfg.Buffer,
+		Feedback:         cfg.Feedback,
+		SinkStats:        srv.sinkStats,
 	}
-	return nil
 }
 
 // Logger returns the logger created for this service.
@@ -449,6 +667,10 @@ func configureViews(level configtelemetry.Level) []config.View {
 			dropViewOption(&config.ViewSelector{
 				MeterName:      graphScope,
 				InstrumentName: ptr("otelcol.*.produced.size"),
+			}),
+			dropViewOption(&config.ViewSelector{
+				MeterName:      graphScope,
+				InstrumentName: ptr("otelcol.graph.edge.*"),
 			}))
 	}
 
@@ -474,7 +696,7 @@ func Validate(ctx context.Context, set Settings, cfg Config) error {
 		ProcessorBuilder: builders.NewProcessor(set.ProcessorsConfigs, set.ProcessorsFactories),
 		ExporterBuilder:  builders.NewExporter(set.ExportersConfigs, set.ExportersFactories),
 		ConnectorBuilder: builders.NewConnector(set.ConnectorsConfigs, set.ConnectorsFactories),
-		PipelineConfigs:  cfg.Pipelines,
+		PipelineConfigs:  cfg.Pipelines.Enabled(),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to build pipelines: %w", err)
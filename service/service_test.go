@@ -7,12 +7,16 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	otelconf "go.opentelemetry.io/contrib/otelconf/v0.3.0"
+	"go.opentelemetry.io/otel/attribute"
 	noopmetric "go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
@@ -27,13 +31,20 @@ import (
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
 	"go.opentelemetry.io/collector/internal/testutil"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/pipeline/xpipeline"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/pipelines"
 	"go.opentelemetry.io/collector/service/telemetry"
@@ -94,6 +105,34 @@ func TestServiceGetExtensions(t *testing.T) {
 	assert.Contains(t, extMap, component.NewID(nopType))
 }
 
+type storageExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (storageExtension) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return storage.NewNopClient(), nil
+}
+
+func TestServiceHoldsStorage(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+	assert.False(t, srv.HoldsStorage())
+
+	set, cfg := newNopSettings(), newNopConfig()
+	storageType := component.MustNewType("storage")
+	storageFactory := extension.NewFactory(storageType, func() component.Config { return &struct{}{} },
+		func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+			return storageExtension{}, nil
+		}, component.StabilityLevelDevelopment)
+	set.ExtensionsConfigs = map[component.ID]component.Config{component.NewID(storageType): storageFactory.CreateDefaultConfig()}
+	set.ExtensionsFactories = map[component.Type]extension.Factory{storageType: storageFactory}
+	cfg.Extensions = []component.ID{component.NewID(storageType)}
+	srv, err = New(context.Background(), set, cfg)
+	require.NoError(t, err)
+	assert.True(t, srv.HoldsStorage())
+}
+
 func TestServiceGetExporters(t *testing.T) {
 	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
 	require.NoError(t, err)
@@ -121,6 +160,282 @@ func TestServiceGetExporters(t *testing.T) {
 	assert.Contains(t, expMap[xpipeline.SignalProfiles], component.NewID(nopType))
 }
 
+func TestServiceDisabledPipelines(t *testing.T) {
+	disabled := false
+	cfg := newNopConfig()
+	cfg.Pipelines[pipeline.NewID(pipeline.SignalMetrics)].Enabled = &disabled
+	cfg.Pipelines[pipeline.NewID(pipeline.SignalLogs)].FeatureGate = "-telemetry.UseLocalHostAsDefaultMetricsAddress"
+	srv, err := New(context.Background(), newNopSettings(), cfg)
+	require.NoError(t, err)
+
+	assert.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	//nolint:staticcheck
+	expMap := srv.host.GetExporters()
+	assert.Len(t, expMap[pipeline.SignalTraces], 1)
+	assert.Empty(t, expMap[pipeline.SignalMetrics])
+	assert.Empty(t, expMap[pipeline.SignalLogs])
+	assert.Len(t, expMap[xpipeline.SignalProfiles], 1)
+}
+
+type reloadableConfig struct {
+	Endpoint string
+}
+
+type reloadableExporter struct {
+	component.StartFunc
+	component.ShutdownFunc
+	consumer.Traces
+}
+
+func TestServiceReloadExporters(t *testing.T) {
+	expType := component.MustNewType("reloadable")
+	expID := component.NewID(expType)
+	var created []string
+
+	set := newNopSettings()
+	set.ExportersConfigs[expID] = &reloadableConfig{Endpoint: "old"}
+	set.ExportersFactories[expType] = exporter.NewFactory(expType,
+		func() component.Config { return &reloadableConfig{} },
+		exporter.WithTraces(func(_ context.Context, _ exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+			created = append(created, cfg.(*reloadableConfig).Endpoint)
+			return &reloadableExporter{Traces: consumertest.NewNop()}, nil
+		}, component.StabilityLevelDevelopment))
+	cfg := newNopConfigPipelineConfigs(pipelines.Config{
+		pipeline.NewID(pipeline.SignalTraces): {
+			Receivers: []component.ID{component.NewID(nopType)},
+			Exporters: []component.ID{expID},
+		},
+	})
+
+	srv, err := New(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	// The runtime settings are applied again on reload, once the previous ones are restored.
+	restored := 0
+	srv.restoreRuntime = func() { restored++ }
+
+	conf := confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"reloadable": map[string]any{"endpoint": "new"}}})
+	require.NoError(t, srv.ReloadExporters(context.Background(), conf, map[component.ID]component.Config{
+		expID: &reloadableConfig{Endpoint: "new"},
+	}))
+	assert.Equal(t, []string{"old", "new"}, created)
+	assert.Same(t, conf, srv.collectorConf)
+	assert.Equal(t, 1, restored)
+
+	err = srv.ReloadExporters(context.Background(), conf, map[component.ID]component.Config{
+		component.MustNewIDWithName("reloadable", "unused"): &reloadableConfig{},
+	})
+	require.EqualError(t, err, `failed to reload exporters: cannot reload exporter "reloadable/unused" which is not used by any pipeline`)
+	assert.Equal(t, 1, restored)
+}
+
+func TestServiceSinkExporters(t *testing.T) {
+	expType := component.MustNewType("reloadable")
+	expID := component.NewID(expType)
+	created := 0
+
+	set := newNopSettings()
+	set.SinkExporters = true
+	set.ExportersConfigs[expID] = &reloadableConfig{Endpoint: "first"}
+	set.ExportersFactories[expType] = exporter.NewFactory(expType,
+		func() component.Config { return &reloadableConfig{} },
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			created++
+			return &reloadableExporter{Traces: consumertest.NewNop()}, nil
+		}, component.StabilityLevelDevelopment))
+	cfg := newNopConfigPipelineConfigs(pipelines.Config{
+		pipeline.NewID(pipeline.SignalTraces): {
+			Receivers: []component.ID{component.NewID(nopType)},
+			Exporters: []component.ID{expID},
+		},
+	})
+
+	srv, err := New(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+
+	// The exporter is replaced by a sink.
+	assert.Zero(t, created)
+	exp := srv.host.Pipelines.GetExporters()[pipeline.SignalTraces][expID]
+	require.NotNil(t, exp)
+	require.NoError(t, exp.(consumer.Traces).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	_, isReal := exp.(*reloadableExporter)
+	assert.False(t, isReal)
+	require.NoError(t, srv.Shutdown(context.Background()))
+}
+
+func TestServiceSetPipelinesPaused(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	defer func() { require.NoError(t, srv.Shutdown(context.Background())) }()
+
+	srv.SetPipelinesPaused(true)
+	for _, signal := range []pipeline.Signal{pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs, xpipeline.SignalProfiles} {
+		assert.True(t, srv.host.IsReceiverPaused(component.NewID(nopType), signal))
+	}
+	srv.SetPipelinesPaused(false)
+	assert.False(t, srv.host.IsReceiverPaused(component.NewID(nopType), pipeline.SignalTraces))
+}
+
+func TestServiceReopenLogs(t *testing.T) {
+	reopened := 0
+	set := newNopSettings()
+	set.TelemetryFactory = telemetry.NewFactory(
+		func() component.Config { return nil },
+		telemetry.WithReopenLogs(func(context.Context) error {
+			reopened++
+			return nil
+		}),
+	)
+	srv, err := New(context.Background(), set, newNopConfig())
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	defer func() { require.NoError(t, srv.Shutdown(context.Background())) }()
+
+	require.NoError(t, srv.ReopenLogs(context.Background()))
+	assert.Equal(t, 1, reopened)
+}
+
+type configStatusWatcher struct {
+	component.StartFunc
+	component.ShutdownFunc
+	errs []error
+}
+
+func (w *configStatusWatcher) NotifyConfigStatus(err error) {
+	w.errs = append(w.errs, err)
+}
+
+func TestServiceReportConfigStatus(t *testing.T) {
+	extType := component.MustNewType("configstatus")
+	w := &configStatusWatcher{}
+	set := newNopSettings()
+	set.ExtensionsConfigs = map[component.ID]component.Config{component.NewID(extType): &struct{}{}}
+	set.ExtensionsFactories = map[component.Type]extension.Factory{extType: extension.NewFactory(extType,
+		func() component.Config { return &struct{}{} },
+		func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+			return w, nil
+		}, component.StabilityLevelDevelopment)}
+	cfg := newNopConfig()
+	cfg.Extensions = []component.ID{component.NewID(extType)}
+
+	srv, err := New(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+
+	// Only the first of consecutive failures is reported.
+	reloadErr := errors.New("reload failed")
+	srv.ReportConfigStatus(reloadErr)
+	srv.ReportConfigStatus(errors.New("reload failed again"))
+	srv.ReportConfigStatus(nil)
+	srv.ReportConfigStatus(nil)
+	require.NoError(t, srv.Shutdown(context.Background()))
+
+	assert.Equal(t, []error{reloadErr, nil}, w.errs)
+}
+
+func TestServiceLifecycleState(t *testing.T) {
+	srv, err := New(context.Background(), newNopSettings(), newNopConfig())
+	require.NoError(t, err)
+	var mu sync.Mutex
+	var states []hostcapabilities.LifecycleState
+	unwatch := srv.host.WatchLifecycleState(func(state hostcapabilities.LifecycleState) {
+		mu.Lock()
+		defer mu.Unlock()
+		states = append(states, state)
+	})
+	defer unwatch()
+	assert.Equal(t, hostcapabilities.LifecycleStarting, srv.LifecycleState())
+
+	require.NoError(t, srv.Start(context.Background()))
+	assert.Equal(t, hostcapabilities.LifecycleReady, srv.LifecycleState())
+	// A stale configuration degrades the collector.
+	srv.ReportConfigStatus(errors.New("reload failed"))
+	assert.Equal(t, hostcapabilities.LifecycleDegraded, srv.LifecycleState())
+	srv.ReportConfigStatus(nil)
+	require.NoError(t, srv.Shutdown(context.Background()))
+	assert.Equal(t, hostcapabilities.LifecycleStopped, srv.LifecycleState())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []hostcapabilities.LifecycleState{
+		hostcapabilities.LifecycleStarting,
+		hostcapabilities.LifecycleReady,
+		hostcapabilities.LifecycleDegraded,
+		hostcapabilities.LifecycleReady,
+		hostcapabilities.LifecycleDraining,
+		hostcapabilities.LifecycleStopped,
+	}, states)
+}
+
+func TestServiceReloadPipelines(t *testing.T) {
+	expType := component.MustNewType("reloadable")
+	expID, exp2ID := component.NewID(expType), component.NewIDWithName(expType, "2")
+	var created []string
+
+	set := newNopSettings()
+	set.ExportersConfigs[expID] = &reloadableConfig{Endpoint: "first"}
+	set.ExportersConfigs[exp2ID] = &reloadableConfig{Endpoint: "second"}
+	set.ExportersFactories[expType] = exporter.NewFactory(expType,
+		func() component.Config { return &reloadableConfig{} },
+		exporter.WithTraces(func(_ context.Context, _ exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+			created = append(created, cfg.(*reloadableConfig).Endpoint)
+			return &reloadableExporter{Traces: consumertest.NewNop()}, nil
+		}, component.StabilityLevelDevelopment))
+	cfg := newNopConfigPipelineConfigs(pipelines.Config{
+		pipeline.NewID(pipeline.SignalTraces): {
+			Receivers: []component.ID{component.NewID(nopType)},
+			Exporters: []component.ID{expID},
+		},
+	})
+
+	srv, err := New(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	})
+
+	// The zPages registered before the reload show the reloaded pipelines.
+	mux := http.NewServeMux()
+	srv.host.RegisterZPages(mux, "/debug")
+	getPipelinez := func() string {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/debug/pipelinez", http.NoBody))
+		require.Equal(t, http.StatusOK, rr.Code)
+		return rr.Body.String()
+	}
+	assert.NotContains(t, getPipelinez(), "traces/2")
+
+	// The exporter of the unchanged pipeline keeps running.
+	set.CollectorConf = confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"reloadable/2": map[string]any{"endpoint": "second"}}})
+	cfg.Pipelines = pipelines.Config{
+		pipeline.NewID(pipeline.SignalTraces): cfg.Pipelines[pipeline.NewID(pipeline.SignalTraces)],
+		pipeline.NewIDWithName(pipeline.SignalTraces, "2"): {
+			Receivers: []component.ID{component.NewID(nopType)},
+			Exporters: []component.ID{exp2ID},
+		},
+	}
+	require.NoError(t, srv.ReloadPipelines(context.Background(), set, cfg))
+	assert.Equal(t, []string{"first", "second"}, created)
+	assert.Len(t, srv.host.GetPipelines().GetExporters()[pipeline.SignalTraces], 2)
+	assert.Same(t, set.CollectorConf, srv.collectorConf)
+	assert.Contains(t, getPipelinez(), "traces/2")
+
+	cfg.Feedback = map[component.ID]pipelines.FeedbackConfig{component.NewID(nopType): {}}
+	require.EqualError(t, srv.ReloadPipelines(context.Background(), set, cfg),
+		"failed to reload pipelines: cannot reconcile pipelines with feedback edges")
+}
+
 // TestServiceTelemetryCleanupOnError tests that if newService errors due to an invalid config telemetry is cleaned up
 // and another service with a valid config can be started right after.
 func TestServiceTelemetryCleanupOnError(t *testing.T) {
@@ -166,6 +481,36 @@ func TestServiceTelemetryLogging(t *testing.T) {
 	assert.Equal(t, "warn_message", entries[0].Message)
 }
 
+func TestServiceLogLevels(t *testing.T) {
+	levels := componentattribute.NewLogLevels(zapcore.InfoLevel, nil)
+	observerCore, observedLogs := observer.New(levels.Threshold())
+	zapLogger := zap.New(componentattribute.NewLevelCoreWithAttributes(observerCore, levels, attribute.NewSet()))
+
+	set := newNopSettings()
+	set.TelemetryFactory = telemetry.NewFactory(
+		func() component.Config { return nil },
+		telemetrytest.WithLogger(zapLogger, nil),
+	)
+	srv, err := New(context.Background(), set, newNopConfig())
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(context.Background()))
+	defer func() {
+		assert.NoError(t, srv.Shutdown(context.Background()))
+	}()
+
+	// The level of the logs is changed by the loglevelz page.
+	mux := http.NewServeMux()
+	srv.host.RegisterZPages(mux, "/debug")
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/debug/loglevelz", strings.NewReader("level=debug"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	mux.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	srv.Logger().Debug("debug_message")
+	assert.Equal(t, 1, observedLogs.FilterMessage("debug_message").Len())
+}
+
 func TestServiceTelemetryMetrics(t *testing.T) {
 	// Start a service and check that metrics are produced as expected.
 	// We do this twice to ensure that the server is stopped cleanly.
@@ -289,6 +634,7 @@ func zpagesHealthy(zpagesAddr string) bool {
 	paths := []string{
 		"/debug/tracez",
 		"/debug/pipelinez",
+		"/debug/graphz",
 		"/debug/servicez",
 		"/debug/extensionz",
 	}
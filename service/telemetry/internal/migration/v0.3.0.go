@@ -4,6 +4,10 @@
 package migration // import "go.opentelemetry.io/collector/service/telemetry/internal/migration"
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
@@ -100,13 +104,26 @@ type LogsConfigV030 struct {
 	// (default = "INFO")
 	Level zapcore.Level `mapstructure:"level"`
 
+	// ComponentLevels overrides the minimum enabled logging level for the logs of some
+	// components, identified by their kind and ID. The level of a component type also
+	// applies to the components of this type with a name.
+	// Example:
+	//
+	// 		component_levels:
+	//	   		receiver/otlp: debug
+	//	   		processor/batch/traces: warn
+	//
+	// By default, all the components log at Level.
+	ComponentLevels map[string]zapcore.Level `mapstructure:"component_levels,omitempty"`
+
 	// Development puts the logger in development mode, which changes the
 	// behavior of DPanicLevel and takes stacktraces more liberally.
 	// (default = false)
 	Development bool `mapstructure:"development,omitempty"`
 
 	// Encoding sets the logger's encoding.
-	// Example values are "json", "console".
+	// The possible values are "json", "console", "logfmt", and the encodings
+	// registered by the build of the collector.
 	Encoding string `mapstructure:"encoding"`
 
 	// DisableCaller stops annotating logs with the calling function's file
@@ -120,6 +137,13 @@ type LogsConfigV030 struct {
 	// (default = false)
 	DisableStacktrace bool `mapstructure:"disable_stacktrace,omitempty"`
 
+	// DisableTraceContext stops adding the trace_id, span_id and trace_flags
+	// fields of the span context of the logs passing a context.Context as a
+	// field, e.g. zap.Any("ctx", ctx), to the logs written to the console and
+	// to the log files.
+	// (default = false)
+	DisableTraceContext bool `mapstructure:"disable_trace_context,omitempty"`
+
 	// Sampling sets a sampling policy.
 	// Default:
 	// 		sampling:
@@ -135,6 +159,8 @@ type LogsConfigV030 struct {
 	// The URLs with "file" schema must be an absolute path.
 	// The URLs without schema are treated as local file paths.
 	// "stdout" and "stderr" are interpreted as os.Stdout and os.Stderr.
+	// "syslog://host:port?facility=local0" and "syslog:///dev/log" send the logs
+	// to syslog, and "eventlog://source" writes them to the Windows Event Log.
 	// see details at Open in zap/writer.go.
 	// (default = ["stderr"])
 	OutputPaths []string `mapstructure:"output_paths"`
@@ -165,7 +191,7 @@ type LogsConfigV030 struct {
 
 	// Rotation configures log file rotation settings.
 	// When specified, log files in OutputPaths will be automatically rotated
-	// based on size limits.
+	// based on size limits, and on time if an interval is set.
 	// Example:
 	//
 	// 		rotation:
@@ -173,9 +199,38 @@ type LogsConfigV030 struct {
 	//	   		max_backups: 3
 	//	   		max_age_days: 28
 	//	   		compress: true
+	//	   		interval: daily
+	//	   		rotate_at_utc: "02:00"
+	//	   		outputs:
+	//	   			/var/log/otelcol-errors.log:
+	//	   				max_age_days: 30
 	//
 	// By default, rotation is disabled.
 	Rotation *LogsRotationConfig `mapstructure:"rotation,omitempty"`
+
+	// Redaction configures the redaction of the values of the fields of the
+	// logs whose key matches, before the logs are written or exported.
+	// Example:
+	//
+	// 		redaction:
+	//	   		keys: [authorization, "*token*", "*password*"]
+	//	   		key_regexes: ["^x-.*-key$"]
+	//
+	// By default, no value is redacted.
+	Redaction *LogsRedactionConfig `mapstructure:"redaction,omitempty"`
+
+	// Buffer enables writing the logs to the log files of OutputPaths and
+	// ErrorOutputPaths asynchronously, so that logging never waits for the
+	// files, the logs being held in memory until written.
+	// Example:
+	//
+	// 		buffer:
+	//	   		size: 4096
+	//	   		flush_interval: 1s
+	//	   		on_overflow: drop
+	//
+	// By default, the logs are written to the log files synchronously.
+	Buffer *LogsBufferConfig `mapstructure:"buffer,omitempty"`
 }
 
 // LogsSamplingConfig sets a sampling strategy for the logger. Sampling caps the
@@ -193,8 +248,15 @@ type LogsSamplingConfig struct {
 	Thereafter int `mapstructure:"thereafter"`
 }
 
+// The intervals of the time-based rotation of the log files.
+const (
+	LogsRotationIntervalHourly = "hourly"
+	LogsRotationIntervalDaily  = "daily"
+)
+
 // LogsRotationConfig configures log file rotation settings.
-// Log files will be automatically rotated when they reach the specified size.
+// Log files will be automatically rotated when they reach the specified size,
+// and once per Interval if set.
 type LogsRotationConfig struct {
 	// MaxSizeMB is the maximum size in megabytes of the log file before it gets rotated.
 	// (default = 100)
@@ -212,6 +274,174 @@ type LogsRotationConfig struct {
 	// Compress determines if the rotated log files should be compressed using gzip.
 	// (default = false)
 	Compress bool `mapstructure:"compress,omitempty"`
+
+	// Interval enables the time-based rotation of the log files, in addition to the
+	// size-based one: "hourly" rotates them at the start of every hour, "daily" every day
+	// at RotateAtUTC. The log files are rotated on the first write after the rotation time.
+	// The default is to only rotate them based on their size.
+	Interval string `mapstructure:"interval,omitempty"`
+
+	// RotateAtUTC is the time of the day, as "HH:MM" in UTC, the log files are rotated at
+	// with the "daily" interval.
+	// (default = "00:00")
+	RotateAtUTC string `mapstructure:"rotate_at_utc,omitempty"`
+
+	// Outputs overrides the rotation settings of some of the file paths of OutputPaths and
+	// ErrorOutputPaths, keyed by path. The settings not set for a path are the ones above.
+	Outputs map[string]LogsRotationConfig `mapstructure:"outputs,omitempty"`
+}
+
+// Unmarshal unmarshals the rotation settings, with the settings of each of the Outputs
+// defaulting to the ones of the rotation.
+func (c *LogsRotationConfig) Unmarshal(conf *confmap.Conf) error {
+	if err := conf.Unmarshal(c); err != nil {
+		return err
+	}
+	if len(c.Outputs) == 0 {
+		return nil
+	}
+	outputs := make(map[string]LogsRotationConfig, len(c.Outputs))
+	for path := range c.Outputs {
+		out := *c
+		out.Outputs = nil
+		sub, err := conf.Sub("outputs" + confmap.KeyDelimiter + path)
+		if err != nil {
+			return err
+		}
+		if err := sub.Unmarshal(&out); err != nil {
+			return err
+		}
+		outputs[path] = out
+	}
+	c.Outputs = outputs
+	return nil
+}
+
+// ForPath returns the rotation settings of the file path.
+func (c *LogsRotationConfig) ForPath(path string) *LogsRotationConfig {
+	if out, ok := c.Outputs[path]; ok {
+		return &out
+	}
+	return c
+}
+
+// Validate checks the time-based rotation settings.
+func (c *LogsRotationConfig) Validate() error {
+	for path, out := range c.Outputs {
+		if len(out.Outputs) > 0 {
+			return fmt.Errorf("the rotation settings of %q cannot have outputs", path)
+		}
+	}
+	switch c.Interval {
+	case "", LogsRotationIntervalHourly:
+		if c.RotateAtUTC != "" {
+			return errors.New("rotate_at_utc is only supported with the daily interval")
+		}
+	case LogsRotationIntervalDaily:
+		if _, err := c.RotateAtOffset(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported interval %q, must be %q or %q", c.Interval, LogsRotationIntervalHourly, LogsRotationIntervalDaily)
+	}
+	return nil
+}
+
+// LogsRedactedValue replaces the values of the fields redacted from the logs.
+const LogsRedactedValue = "[REDACTED]"
+
+// LogsRedactionConfig configures the redaction of the values of the fields of the logs.
+type LogsRedactionConfig struct {
+	// Keys are the patterns of the keys of the fields whose values are redacted,
+	// matched regardless of their case, "*" matching any sequence of characters.
+	Keys []string `mapstructure:"keys"`
+	// KeyRegexes are the regular expressions matching the keys of the fields
+	// whose values are redacted.
+	KeyRegexes []string `mapstructure:"key_regexes,omitempty"`
+}
+
+// Validate checks the regular expressions of the keys.
+func (c *LogsRedactionConfig) Validate() error {
+	_, err := c.KeyRegexp()
+	return err
+}
+
+// KeyRegexp returns the regular expression matching the keys of the fields whose values are
+// redacted, or nil if there are none.
+func (c *LogsRedactionConfig) KeyRegexp() (*regexp.Regexp, error) {
+	exprs := make([]string, 0, len(c.Keys)+len(c.KeyRegexes))
+	for _, key := range c.Keys {
+		expr := strings.ReplaceAll(regexp.QuoteMeta(key), `\*`, ".*")
+		exprs = append(exprs, "(?i:^"+expr+"$)")
+	}
+	for _, expr := range c.KeyRegexes {
+		if _, err := regexp.Compile(expr); err != nil {
+			return nil, fmt.Errorf("invalid key_regexes %q: %w", expr, err)
+		}
+		exprs = append(exprs, "(?:"+expr+")")
+	}
+	if len(exprs) == 0 {
+		return nil, nil
+	}
+	return regexp.Compile(strings.Join(exprs, "|"))
+}
+
+// The behaviors of the buffer of the log files when it is full.
+const (
+	LogsBufferOnOverflowDrop  = "drop"
+	LogsBufferOnOverflowBlock = "block"
+)
+
+// LogsBufferConfig configures the buffer of the logs written to the log files.
+type LogsBufferConfig struct {
+	// Size is the maximum number of logs held by the buffer of each log file
+	// until they are written.
+	// (default = 1024)
+	Size int `mapstructure:"size"`
+
+	// FlushInterval is the maximum time the logs are held by the buffer before
+	// they are written to the log file.
+	// (default = 1s)
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+
+	// OnOverflow is what happens to the logs written while the buffer is full:
+	// "drop" drops them, counting them in the otelcol.logs.dropped.records
+	// metric, "block" waits until the buffer has room for them.
+	// (default = "drop")
+	OnOverflow string `mapstructure:"on_overflow"`
+}
+
+// Validate checks the buffer settings.
+func (c *LogsBufferConfig) Validate() error {
+	if c.Size < 0 {
+		return fmt.Errorf("invalid size %d, must not be negative", c.Size)
+	}
+	if c.FlushInterval < 0 {
+		return fmt.Errorf("invalid flush_interval %v, must not be negative", c.FlushInterval)
+	}
+	switch c.OnOverflow {
+	case "", LogsBufferOnOverflowDrop, LogsBufferOnOverflowBlock:
+	default:
+		return fmt.Errorf("unsupported on_overflow %q, must be %q or %q", c.OnOverflow, LogsBufferOnOverflowDrop, LogsBufferOnOverflowBlock)
+	}
+	return nil
+}
+
+// Drops returns whether the logs written while the buffer is full are dropped.
+func (c *LogsBufferConfig) Drops() bool {
+	return c.OnOverflow != LogsBufferOnOverflowBlock
+}
+
+// RotateAtOffset returns the duration since midnight UTC of RotateAtUTC.
+func (c *LogsRotationConfig) RotateAtOffset() (time.Duration, error) {
+	if c.RotateAtUTC == "" {
+		return 0, nil
+	}
+	t, err := time.Parse("15:04", c.RotateAtUTC)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rotate_at_utc %q, must be HH:MM", c.RotateAtUTC)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
 }
 
 func (c *LogsConfigV030) Unmarshal(conf *confmap.Conf) error {
@@ -6,6 +6,7 @@ package migration
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -49,3 +50,110 @@ func TestUnmarshalMetricsConfigV030(t *testing.T) {
 	// check the endpoint is prefixed w/ https
 	require.Equal(t, "https://127.0.0.1:4317", *cfg.Readers[0].Periodic.Exporter.OTLP.Endpoint)
 }
+
+func TestUnmarshalLogsRotationConfigOutputs(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "v0.3.0_logs_rotation.yaml"))
+	require.NoError(t, err)
+
+	cfg := LogsConfigV030{}
+	require.NoError(t, cm.Unmarshal(&cfg))
+	require.NotNil(t, cfg.Rotation)
+	require.NoError(t, cfg.Rotation.Validate())
+
+	// The paths without settings use the ones of the rotation.
+	require.Same(t, cfg.Rotation, cfg.Rotation.ForPath("/var/log/otelcol.log"))
+	// The settings not set for a path default to the ones of the rotation.
+	require.Equal(t, &LogsRotationConfig{
+		MaxSizeMB:  50,
+		MaxAgeDays: 30,
+		Compress:   false,
+		Interval:   LogsRotationIntervalDaily,
+	}, cfg.Rotation.ForPath("/var/log/otelcol-errors.log"))
+}
+
+func TestLogsRotationConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     LogsRotationConfig
+		offset  time.Duration
+		wantErr string
+	}{
+		{name: "size only", cfg: LogsRotationConfig{MaxSizeMB: 10}},
+		{name: "hourly", cfg: LogsRotationConfig{Interval: "hourly"}},
+		{name: "daily", cfg: LogsRotationConfig{Interval: "daily"}},
+		{name: "daily at", cfg: LogsRotationConfig{Interval: "daily", RotateAtUTC: "02:30"}, offset: 2*time.Hour + 30*time.Minute},
+		{name: "weekly", cfg: LogsRotationConfig{Interval: "weekly"}, wantErr: `unsupported interval "weekly", must be "hourly" or "daily"`},
+		{name: "hourly at", cfg: LogsRotationConfig{Interval: "hourly", RotateAtUTC: "02:30"}, wantErr: "rotate_at_utc is only supported with the daily interval"},
+		{name: "at without interval", cfg: LogsRotationConfig{RotateAtUTC: "02:30"}, wantErr: "rotate_at_utc is only supported with the daily interval"},
+		{name: "invalid at", cfg: LogsRotationConfig{Interval: "daily", RotateAtUTC: "25:00"}, wantErr: `invalid rotate_at_utc "25:00", must be HH:MM`},
+		{
+			name: "nested outputs",
+			cfg: LogsRotationConfig{Outputs: map[string]LogsRotationConfig{
+				"a.log": {Outputs: map[string]LogsRotationConfig{"b.log": {}}},
+			}},
+			wantErr: `the rotation settings of "a.log" cannot have outputs`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			offset, err := tt.cfg.RotateAtOffset()
+			require.NoError(t, err)
+			require.Equal(t, tt.offset, offset)
+		})
+	}
+}
+
+func TestLogsRedactionConfigKeyRegexp(t *testing.T) {
+	cfg := LogsRedactionConfig{
+		Keys:       []string{"authorization", "*token*"},
+		KeyRegexes: []string{"^x-.*-key$"},
+	}
+	require.NoError(t, cfg.Validate())
+	re, err := cfg.KeyRegexp()
+	require.NoError(t, err)
+	for _, key := range []string{"authorization", "Authorization", "token", "access_token", "X-Auth-Token", "x-api-key"} {
+		require.True(t, re.MatchString(key), key)
+	}
+	for _, key := range []string{"authorization_type", "endpoint", "X-API-KEY", "tok"} {
+		require.False(t, re.MatchString(key), key)
+	}
+
+	re, err = (&LogsRedactionConfig{}).KeyRegexp()
+	require.NoError(t, err)
+	require.Nil(t, re)
+
+	cfg = LogsRedactionConfig{KeyRegexes: []string{"("}}
+	require.ErrorContains(t, cfg.Validate(), `invalid key_regexes "("`)
+}
+
+func TestLogsBufferConfigValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		cfg    LogsBufferConfig
+		drops  bool
+		errMsg string
+	}{
+		{name: "default", cfg: LogsBufferConfig{}, drops: true},
+		{name: "drop", cfg: LogsBufferConfig{Size: 10, FlushInterval: time.Second, OnOverflow: LogsBufferOnOverflowDrop}, drops: true},
+		{name: "block", cfg: LogsBufferConfig{OnOverflow: LogsBufferOnOverflowBlock}},
+		{name: "negative size", cfg: LogsBufferConfig{Size: -1}, drops: true, errMsg: "invalid size -1, must not be negative"},
+		{name: "negative flush interval", cfg: LogsBufferConfig{FlushInterval: -time.Second}, drops: true, errMsg: "invalid flush_interval -1s, must not be negative"},
+		{name: "unknown overflow", cfg: LogsBufferConfig{OnOverflow: "wait"}, drops: true, errMsg: `unsupported on_overflow "wait", must be "drop" or "block"`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.errMsg != "" {
+				require.EqualError(t, err, tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.drops, tt.cfg.Drops())
+		})
+	}
+}
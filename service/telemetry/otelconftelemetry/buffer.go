@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	defaultLogsBufferSize          = 1024
+	defaultLogsBufferFlushInterval = time.Second
+
+	// maxLogsBufferBatchSize is the size of the logs written to the log file at once, after which the
+	// buffered logs are written without waiting for the flush interval.
+	maxLogsBufferBatchSize = 64 * 1024
+)
+
+// bufferLogFiles returns the log files writing the logs to the files asynchronously, counting the logs
+// they drop in dropped. The logSinks stay logSinks, so that the logs keep the severity of their level.
+func bufferLogFiles(files []logFile, cfg *LogsBufferConfig, dropped *atomic.Int64) []logFile {
+	buffered := make([]logFile, len(files))
+	for i, f := range files {
+		bf := newBufferedFile(f, cfg, dropped)
+		if _, ok := f.(logSink); ok {
+			buffered[i] = bufferedSink{bf}
+			continue
+		}
+		buffered[i] = bf
+	}
+	return buffered
+}
+
+// stopBufferedFiles writes the logs held by the buffered log files, and stops buffering them.
+func stopBufferedFiles(files []logFile) {
+	for _, f := range files {
+		switch bf := f.(type) {
+		case *bufferedFile:
+			bf.stop()
+		case bufferedSink:
+			bf.stop()
+		}
+	}
+}
+
+// bufferedLog is a log queued by a bufferedFile, with its level for a logSink.
+type bufferedLog struct {
+	level zapcore.Level
+	p     []byte
+}
+
+// bufferedFile is a logFile queueing the logs written to another one, so that writing a log never waits
+// for the file. The queued logs are written to the file by its goroutine, at least every flush interval,
+// and before it is synced or reopened. The logs written while the queue is full are dropped and counted,
+// or wait for the queue, depending on the overflow setting. The logs queued for a logSink are written
+// one by one, with the severity of their level.
+type bufferedFile struct {
+	file    logFile
+	sink    logSink
+	drops   bool
+	dropped *atomic.Int64
+
+	queue    chan bufferedLog
+	flushes  chan chan error
+	stopped  chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+var _ logFile = (*bufferedFile)(nil)
+
+func newBufferedFile(file logFile, cfg *LogsBufferConfig, dropped *atomic.Int64) *bufferedFile {
+	size := cfg.Size
+	if size == 0 {
+		size = defaultLogsBufferSize
+	}
+	interval := cfg.FlushInterval
+	if interval == 0 {
+		interval = defaultLogsBufferFlushInterval
+	}
+	f := &bufferedFile{
+		file:    file,
+		drops:   cfg.Drops(),
+		dropped: dropped,
+		queue:   make(chan bufferedLog, size),
+		flushes: make(chan chan error),
+		stopped: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	f.sink, _ = file.(logSink)
+	go f.run(interval)
+	return f
+}
+
+func (f *bufferedFile) Write(p []byte) (int, error) {
+	return f.writeLevel(zapcore.InfoLevel, p)
+}
+
+func (f *bufferedFile) writeLevel(level zapcore.Level, p []byte) (int, error) {
+	select {
+	case <-f.stopped:
+		// The logs written once the logger was shut down are written to the file directly.
+		return f.writeNow(level, p)
+	default:
+	}
+	// The encoded log is freed by the core once written.
+	log := bufferedLog{level: level, p: slices.Clone(p)}
+	if f.drops {
+		select {
+		case f.queue <- log:
+		default:
+			f.dropped.Add(1)
+		}
+		return len(p), nil
+	}
+	select {
+	case f.queue <- log:
+		return len(p), nil
+	case <-f.stopped:
+		return f.writeNow(level, p)
+	}
+}
+
+// writeNow writes the log to the file, with the severity of its level for a logSink.
+func (f *bufferedFile) writeNow(level zapcore.Level, p []byte) (int, error) {
+	if f.sink != nil {
+		return f.sink.WriteLevel(level, p)
+	}
+	return f.file.Write(p)
+}
+
+// Sync writes the queued logs to the file, and syncs it.
+func (f *bufferedFile) Sync() error {
+	return errors.Join(f.flush(), f.file.Sync())
+}
+
+// Reopen writes the queued logs to the file, and reopens it.
+func (f *bufferedFile) Reopen() error {
+	return errors.Join(f.flush(), f.file.Reopen())
+}
+
+// flush writes the queued logs to the file, and returns the first error writing them since the last flush.
+func (f *bufferedFile) flush() error {
+	flushed := make(chan error, 1)
+	select {
+	case f.flushes <- flushed:
+		return <-flushed
+	case <-f.stopped:
+		<-f.done
+		return nil
+	}
+}
+
+// stop writes the queued logs to the file, and stops its goroutine.
+func (f *bufferedFile) stop() {
+	f.stopOnce.Do(func() { close(f.stopped) })
+	<-f.done
+}
+
+func (f *bufferedFile) run(interval time.Duration) {
+	defer close(f.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var batch []byte
+	var err error
+	write := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if _, werr := f.file.Write(batch); werr != nil && err == nil {
+			err = werr
+		}
+		batch = batch[:0]
+	}
+	// add batches the log, or sends it to the logSink at once, since each log is a message of its own.
+	add := func(log bufferedLog) {
+		if f.sink == nil {
+			batch = append(batch, log.p...)
+			return
+		}
+		if _, werr := f.sink.WriteLevel(log.level, log.p); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	drain := func() {
+		for {
+			select {
+			case log := <-f.queue:
+				add(log)
+			default:
+				write()
+				return
+			}
+		}
+	}
+	for {
+		select {
+		case log := <-f.queue:
+			add(log)
+			if len(batch) >= maxLogsBufferBatchSize {
+				write()
+			}
+		case <-ticker.C:
+			write()
+		case flushed := <-f.flushes:
+			drain()
+			flushed <- err
+			err = nil
+		case <-f.stopped:
+			drain()
+			return
+		}
+	}
+}
+
+// bufferedSink is a bufferedFile of a logSink, queueing the logs with their level.
+type bufferedSink struct {
+	*bufferedFile
+}
+
+var _ logSink = bufferedSink{}
+
+func (s bufferedSink) WriteLevel(level zapcore.Level, p []byte) (int, error) {
+	return s.writeLevel(level, p)
+}
+
+// Close writes the queued logs to the logSink, stops buffering them, and closes the logSink.
+func (s bufferedSink) Close() error {
+	s.stop()
+	return s.sink.Close()
+}
+
+// registerDroppedLogsMetric registers the metric counting the logs dropped by the buffered log files.
+func registerDroppedLogsMetric(mp metric.MeterProvider, dropped *atomic.Int64) error {
+	_, err := mp.Meter("go.opentelemetry.io/collector/service").Int64ObservableCounter(
+		"otelcol.logs.dropped.records",
+		metric.WithDescription("Number of the logs of the collector dropped because the buffer of their log file was full."),
+		metric.WithUnit("{record}"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(dropped.Load())
+			return nil
+		}),
+	)
+	return err
+}
@@ -0,0 +1,302 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/service/internal/promtest"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+// memoryFile is a logFile writing the logs in memory, whose writes wait while it is blocked.
+type memoryFile struct {
+	mu       sync.Mutex
+	buf      bytes.Buffer
+	err      error
+	unblock  chan struct{}
+	writes   atomic.Int32
+	reopened int
+}
+
+func (f *memoryFile) Write(p []byte) (int, error) {
+	f.writes.Add(1)
+	if f.unblock != nil {
+		<-f.unblock
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return 0, f.err
+	}
+	return f.buf.Write(p)
+}
+
+func (*memoryFile) Sync() error {
+	return nil
+}
+
+func (f *memoryFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reopened++
+	return nil
+}
+
+func (f *memoryFile) String() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.buf.String()
+}
+
+func TestBufferedFile(t *testing.T) {
+	file := &memoryFile{}
+	var dropped atomic.Int64
+	f := newBufferedFile(file, &LogsBufferConfig{FlushInterval: time.Hour}, &dropped)
+
+	for _, log := range []string{"first\n", "second\n"} {
+		n, err := f.Write([]byte(log))
+		require.NoError(t, err)
+		assert.Len(t, log, n)
+	}
+	// The logs are held until the flush interval elapses, or the file is synced.
+	assert.Empty(t, file.String())
+	require.NoError(t, f.Sync())
+	assert.Equal(t, "first\nsecond\n", file.String())
+
+	_, err := f.Write([]byte("third\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Reopen())
+	assert.Equal(t, "first\nsecond\nthird\n", file.String())
+	assert.Equal(t, 1, file.reopened)
+
+	// The errors writing the logs are returned by the next sync.
+	file.mu.Lock()
+	file.err = errors.New("disk full")
+	file.mu.Unlock()
+	_, err = f.Write([]byte("fourth\n"))
+	require.NoError(t, err)
+	require.EqualError(t, f.Sync(), "disk full")
+	require.NoError(t, f.Sync())
+
+	file.mu.Lock()
+	file.err = nil
+	file.mu.Unlock()
+	_, err = f.Write([]byte("fifth\n"))
+	require.NoError(t, err)
+	f.stop()
+	assert.Equal(t, "first\nsecond\nthird\nfifth\n", file.String())
+
+	// The logs written once stopped are written directly.
+	_, err = f.Write([]byte("sixth\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\nthird\nfifth\nsixth\n", file.String())
+	require.NoError(t, f.Sync())
+	assert.Zero(t, dropped.Load())
+}
+
+// memorySink is a logSink writing the logs in memory, recording their level.
+type memorySink struct {
+	memoryFile
+	levels []zapcore.Level
+	closed bool
+}
+
+func (s *memorySink) WriteLevel(level zapcore.Level, p []byte) (int, error) {
+	s.mu.Lock()
+	s.levels = append(s.levels, level)
+	s.mu.Unlock()
+	return s.Write(p)
+}
+
+func (s *memorySink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestBufferedSink(t *testing.T) {
+	sink := &memorySink{}
+	files := bufferLogFiles([]logFile{sink, &memoryFile{}}, &LogsBufferConfig{FlushInterval: time.Hour}, &atomic.Int64{})
+	defer stopBufferedFiles(files)
+	_, ok := files[1].(logSink)
+	assert.False(t, ok)
+	// The logSinks are buffered too, keeping the level of the logs.
+	bs, ok := files[0].(logSink)
+	require.True(t, ok)
+
+	_, err := bs.WriteLevel(zapcore.WarnLevel, []byte("first\n"))
+	require.NoError(t, err)
+	_, err = bs.Write([]byte("second\n"))
+	require.NoError(t, err)
+	assert.Empty(t, sink.String())
+	require.NoError(t, bs.Sync())
+	assert.Equal(t, "first\nsecond\n", sink.String())
+	assert.Equal(t, []zapcore.Level{zapcore.WarnLevel, zapcore.InfoLevel}, sink.levels)
+	assert.Equal(t, int32(2), sink.writes.Load())
+
+	_, err = bs.WriteLevel(zapcore.ErrorLevel, []byte("third\n"))
+	require.NoError(t, err)
+	require.NoError(t, bs.Close())
+	assert.Equal(t, "first\nsecond\nthird\n", sink.String())
+	assert.True(t, sink.closed)
+}
+
+func TestBufferedFileFlushInterval(t *testing.T) {
+	file := &memoryFile{}
+	f := newBufferedFile(file, &LogsBufferConfig{FlushInterval: 10 * time.Millisecond}, &atomic.Int64{})
+	defer f.stop()
+
+	_, err := f.Write([]byte("log\n"))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return file.String() == "log\n"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestBufferedFileOverflow(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		cfg      *LogsBufferConfig
+		expected string
+		dropped  int64
+	}{
+		{
+			name:     "drop",
+			cfg:      &LogsBufferConfig{Size: 1},
+			expected: "first\nsecond\n",
+			dropped:  2,
+		},
+		{
+			name:     "block",
+			cfg:      &LogsBufferConfig{Size: 1, OnOverflow: "block"},
+			expected: "first\nsecond\nthird\nfourth\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// The file blocks the first log written to it, keeping the second one in the buffer.
+			file := &memoryFile{unblock: make(chan struct{})}
+			var dropped atomic.Int64
+			f := newBufferedFile(file, &LogsBufferConfig{Size: tt.cfg.Size, FlushInterval: time.Millisecond, OnOverflow: tt.cfg.OnOverflow}, &dropped)
+
+			_, err := f.Write([]byte("first\n"))
+			require.NoError(t, err)
+			require.Eventually(t, func() bool {
+				return file.writes.Load() == 1
+			}, time.Second, time.Millisecond)
+			_, err = f.Write([]byte("second\n"))
+			require.NoError(t, err)
+
+			written := make(chan struct{})
+			go func() {
+				defer close(written)
+				for _, log := range []string{"third\n", "fourth\n"} {
+					_, err := f.Write([]byte(log))
+					assert.NoError(t, err)
+				}
+			}()
+			if f.drops {
+				<-written
+				assert.Equal(t, tt.dropped, dropped.Load())
+			} else {
+				select {
+				case <-written:
+					t.Fatal("the logs were written while the buffer was full")
+				case <-time.After(50 * time.Millisecond):
+				}
+			}
+
+			close(file.unblock)
+			<-written
+			f.stop()
+			assert.Equal(t, tt.expected, file.String())
+			assert.Equal(t, tt.dropped, dropped.Load())
+		})
+	}
+}
+
+func TestCreateLoggerBuffer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collector.log")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.OutputPaths = []string{path}
+	cfg.Logs.Rotation = &LogsRotationConfig{MaxSizeMB: 10}
+	cfg.Logs.Buffer = &LogsBufferConfig{FlushInterval: time.Hour}
+
+	logger, shutdown, err := createLogger(context.Background(), telemetry.LoggerSettings{}, cfg, &logFiles{})
+	require.NoError(t, err)
+
+	logger.Info("buffered")
+	content, err := os.ReadFile(path)
+	if err == nil {
+		assert.NotContains(t, string(content), "buffered")
+	}
+
+	// The logs held by the buffer are written when the logger is shut down.
+	require.NoError(t, shutdown(context.Background()))
+	assertFileContains(t, path, []string{"buffered"}, nil)
+}
+
+func TestDroppedLogsMetric(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() {
+		assert.NoError(t, mp.Shutdown(context.Background()))
+	}()
+
+	var dropped atomic.Int64
+	require.NoError(t, registerDroppedLogsMetric(mp, &dropped))
+	dropped.Add(3)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+	m := rm.ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, "otelcol.logs.dropped.records", m.Name)
+	assert.Equal(t, "{record}", m.Unit)
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	assert.True(t, sum.IsMonotonic)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(3), sum.DataPoints[0].Value)
+}
+
+func TestCreateMeterProviderDroppedLogs(t *testing.T) {
+	prom := promtest.GetAvailableLocalAddressPrometheus(t)
+	endpoint := fmt.Sprintf("http://%s:%d/metrics", *prom.Host, *prom.Port)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Metrics.Readers = []config.MetricReader{{
+		Pull: &config.PullMetricReader{Exporter: config.PullMetricExporter{Prometheus: prom}},
+	}}
+	cfg.Logs.Buffer = &LogsBufferConfig{}
+
+	files := &logFiles{}
+	mp, err := createMeterProvider(t.Context(), telemetry.MeterSettings{}, cfg, files)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, mp.Shutdown(t.Context()))
+	}()
+	files.dropped.Add(2)
+
+	metrics := getMetricsFromPrometheus(t, endpoint)
+	mf, ok := metrics["otelcol_logs_dropped_records"]
+	require.True(t, ok)
+	require.Len(t, mf.Metric, 1)
+	assert.InDelta(t, 2, mf.Metric[0].Counter.GetValue(), 0.01)
+}
@@ -5,7 +5,14 @@ package otelconftelemetry // import "go.opentelemetry.io/collector/service/telem
 
 import (
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/service/telemetry/internal/migration"
 )
@@ -33,6 +40,17 @@ type LogsConfig = migration.LogsConfigV030
 // to preserve a representative subset of your logs.
 type LogsSamplingConfig = migration.LogsSamplingConfig
 
+// LogsRotationConfig configures the rotation of the log files, based on their size
+// and optionally on time.
+type LogsRotationConfig = migration.LogsRotationConfig
+
+// LogsRedactionConfig configures the redaction of the values of the fields of the logs
+// whose key matches.
+type LogsRedactionConfig = migration.LogsRedactionConfig
+
+// LogsBufferConfig configures the buffer of the logs written to the log files.
+type LogsBufferConfig = migration.LogsBufferConfig
+
 // MetricsConfig exposes the common Telemetry configuration for one component.
 // Experimental: *NOTE* this structure is subject to change or removal in the future.
 type MetricsConfig = migration.MetricsConfigV030
@@ -52,5 +70,86 @@ func (c *Config) Validate() error {
 		return errors.New("service::telemetry::metrics::views can only be set when service::telemetry::metrics::level is detailed")
 	}
 
+	if encodings := logEncodings(); !slices.Contains(encodings, c.Logs.Encoding) {
+		return fmt.Errorf("service::telemetry::logs::encoding %q is unknown, must be one of %s", c.Logs.Encoding, strings.Join(encodings, ", "))
+	}
+
+	for _, path := range slices.Concat(c.Logs.OutputPaths, c.Logs.ErrorOutputPaths) {
+		if isLogSink(path) {
+			if err := checkLogSink(path); err != nil {
+				return fmt.Errorf("service::telemetry::logs: %w", err)
+			}
+		}
+	}
+
+	if _, err := logComponentLevels(c.Logs.ComponentLevels); err != nil {
+		return fmt.Errorf("service::telemetry::logs::component_levels: %w", err)
+	}
+
+	if c.Logs.Rotation != nil {
+		for path := range c.Logs.Rotation.Outputs {
+			if !slices.Contains(c.Logs.OutputPaths, path) && !slices.Contains(c.Logs.ErrorOutputPaths, path) {
+				return fmt.Errorf("service::telemetry::logs::rotation::outputs has settings for %q, which is neither in output_paths nor in error_output_paths", path)
+			}
+		}
+	}
+
 	return nil
 }
+
+// logComponentKinds are the kinds of the components whose logs can have their own level.
+var logComponentKinds = []component.Kind{
+	component.KindReceiver,
+	component.KindProcessor,
+	component.KindExporter,
+	component.KindConnector,
+	component.KindExtension,
+}
+
+// logComponentLevels returns the levels of the logs of the components keyed by their kind and ID,
+// e.g. "receiver/otlp", from the levels keyed by either this form or their type followed by their
+// kind, e.g. "otlpreceiver".
+func logComponentLevels(levels map[string]zapcore.Level) (map[string]zapcore.Level, error) {
+	if len(levels) == 0 {
+		return levels, nil
+	}
+	keyed := make(map[string]zapcore.Level, len(levels))
+	for _, comp := range slices.Sorted(maps.Keys(levels)) {
+		key, err := logComponentKey(comp)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := keyed[key]; ok {
+			return nil, fmt.Errorf("component %q has its level set more than once", key)
+		}
+		keyed[key] = levels[comp]
+	}
+	return keyed, nil
+}
+
+// logComponentKey returns the key of the component identified by its kind and ID, e.g.
+// "receiver/otlp", or by its type followed by its kind and its optional name, e.g. "otlpreceiver"
+// or "batchprocessor/traces".
+func logComponentKey(comp string) (string, error) {
+	prefix, name, hasName := strings.Cut(comp, "/")
+	for _, k := range logComponentKinds {
+		kind := strings.ToLower(k.String())
+		var id string
+		switch {
+		case prefix == kind && hasName:
+			id = name
+		case strings.HasSuffix(prefix, kind) && len(prefix) > len(kind):
+			id = strings.TrimSuffix(prefix, kind)
+			if hasName {
+				id += "/" + name
+			}
+		default:
+			continue
+		}
+		if err := new(component.ID).UnmarshalText([]byte(id)); err != nil {
+			return "", err
+		}
+		return kind + "/" + id, nil
+	}
+	return "", fmt.Errorf("invalid component %q, must be <kind>/<id> or <type><kind>, e.g. receiver/otlp or otlpreceiver", comp)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtelemetry"
@@ -53,6 +54,7 @@ func TestConfig(t *testing.T) {
 				cfg.Logs.Development = true
 				cfg.Logs.DisableCaller = true
 				cfg.Logs.DisableStacktrace = true
+				cfg.Logs.DisableTraceContext = true
 				cfg.Logs.InitialFields = map[string]any{"fieldKey": "fieldValue"}
 				cfg.Logs.Level = zap.InfoLevel
 				cfg.Logs.Sampling = &LogsSamplingConfig{
@@ -71,6 +73,28 @@ func TestConfig(t *testing.T) {
 				return cfg
 			}(),
 		},
+		"config_logs_component_levels.yaml": {
+			config: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Logs.ComponentLevels = map[string]zapcore.Level{
+					"receiver/otlp":          zap.DebugLevel,
+					"processor/batch/traces": zap.WarnLevel,
+					"debugexporter":          zap.ErrorLevel,
+				}
+				return cfg
+			}(),
+		},
+		"config_logs_buffer.yaml": {
+			config: func() *Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Logs.Buffer = &LogsBufferConfig{
+					Size:          4096,
+					FlushInterval: 500 * time.Millisecond,
+					OnOverflow:    "block",
+				}
+				return cfg
+			}(),
+		},
 		"config_metrics_empty_readers.yaml": {
 			config: func() *Config {
 				cfg := createDefaultConfig().(*Config)
@@ -88,6 +112,21 @@ func TestConfig(t *testing.T) {
 		"config_invalid_metrics_views_level.yaml": {
 			validateErr: `service::telemetry::metrics::views can only be set when service::telemetry::metrics::level is detailed`,
 		},
+		"config_invalid_logs_buffer.yaml": {
+			validateErr: `logs::buffer: unsupported on_overflow "wait", must be "drop" or "block"`,
+		},
+		"config_invalid_logs_component_levels.yaml": {
+			validateErr: `service::telemetry::logs::component_levels: invalid component "otlp", must be <kind>/<id> or <type><kind>, e.g. receiver/otlp or otlpreceiver`,
+		},
+		"config_invalid_logs_encoding.yaml": {
+			validateErr: `service::telemetry::logs::encoding "text" is unknown, must be one of console, json, logfmt`,
+		},
+		"config_invalid_logs_redaction.yaml": {
+			validateErr: `logs::redaction: invalid key_regexes "(secret"`,
+		},
+		"config_invalid_logs_rotation_outputs.yaml": {
+			validateErr: `service::telemetry::logs::rotation::outputs has settings for "/var/log/other.log", which is neither in output_paths nor in error_output_paths`,
+		},
 	}
 
 	for filename, test := range tests {
@@ -118,3 +157,30 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestLogComponentLevels(t *testing.T) {
+	levels, err := logComponentLevels(map[string]zapcore.Level{
+		"receiver/otlp":         zapcore.DebugLevel,
+		"batchprocessor":        zapcore.WarnLevel,
+		"batchprocessor/traces": zapcore.ErrorLevel,
+		"forwardconnector/a":    zapcore.InfoLevel,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]zapcore.Level{
+		"receiver/otlp":          zapcore.DebugLevel,
+		"processor/batch":        zapcore.WarnLevel,
+		"processor/batch/traces": zapcore.ErrorLevel,
+		"connector/forward/a":    zapcore.InfoLevel,
+	}, levels)
+
+	_, err = logComponentLevels(map[string]zapcore.Level{
+		"otlpreceiver":  zapcore.DebugLevel,
+		"receiver/otlp": zapcore.WarnLevel,
+	})
+	require.EqualError(t, err, `component "receiver/otlp" has its level set more than once`)
+
+	for _, comp := range []string{"otlp", "receiver", "receiver/", "exporter/1otlp", "1otlpexporter"} {
+		_, err = logComponentLevels(map[string]zapcore.Level{comp: zapcore.DebugLevel})
+		assert.Error(t, err, comp)
+	}
+}
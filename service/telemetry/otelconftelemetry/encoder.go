@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogEncoderConstructor creates the encoder of the logs from the encoder config of the collector.
+type LogEncoderConstructor func(zapcore.EncoderConfig) (zapcore.Encoder, error)
+
+var (
+	logEncodersMu sync.RWMutex
+	// logEncoders holds the constructors of the encoders by encoding, "console" and "json" being
+	// registered by zap itself.
+	logEncoders = map[string]LogEncoderConstructor{
+		"console": func(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return zapcore.NewConsoleEncoder(cfg), nil
+		},
+		"json": func(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return zapcore.NewJSONEncoder(cfg), nil
+		},
+	}
+)
+
+func init() {
+	if err := RegisterLogEncoder("logfmt", newLogfmtEncoder); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterLogEncoder registers the constructor of the encoder of the logs for the encoding name, which
+// can then be set in service::telemetry::logs::encoding. The encoder is used for the logs written to the
+// console and to the log files alike. It is meant to be called from an init function, e.g. of a package
+// of a custom build of the collector, and returns an error if the encoding is already registered.
+func RegisterLogEncoder(name string, constructor LogEncoderConstructor) error {
+	logEncodersMu.Lock()
+	defer logEncodersMu.Unlock()
+	if _, ok := logEncoders[name]; ok {
+		return fmt.Errorf("log encoder %q already registered", name)
+	}
+	if err := zap.RegisterEncoder(name, constructor); err != nil {
+		return err
+	}
+	logEncoders[name] = constructor
+	return nil
+}
+
+// newLogEncoder creates the encoder of the logs registered for the encoding name.
+func newLogEncoder(name string, cfg zapcore.EncoderConfig) (zapcore.Encoder, error) {
+	logEncodersMu.RLock()
+	constructor, ok := logEncoders[name]
+	logEncodersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no log encoder registered for %q", name)
+	}
+	return constructor(cfg)
+}
+
+// logEncodings returns the registered encodings, sorted.
+func logEncodings() []string {
+	logEncodersMu.RLock()
+	defer logEncodersMu.RUnlock()
+	names := make([]string, 0, len(logEncoders))
+	for name := range logEncoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package otelconftelemetry // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
+
+import (
+	"errors"
+	"net/url"
+)
+
+var errEventLogUnsupported = errors.New("the eventlog output paths are only supported on Windows")
+
+func newEventLogSink(*url.URL) (logSink, error) {
+	return nil, errEventLogUnsupported
+}
+
+func checkEventLogSink(*url.URL) error {
+	return errEventLogUnsupported
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package otelconftelemetry // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// eventLogSink is a logSink writing the logs to the Windows Event Log, e.g. eventlog://otelcol, as events
+// of the source named by the host of the URL, the name of the executable by default.
+type eventLogSink struct {
+	source string
+
+	mu  sync.Mutex
+	log *eventlog.Log
+}
+
+var _ logSink = (*eventLogSink)(nil)
+
+func newEventLogSink(u *url.URL) (logSink, error) {
+	source := u.Host
+	if source == "" {
+		source = strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+	}
+	log, err := eventlog.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	return &eventLogSink{source: source, log: log}, nil
+}
+
+func checkEventLogSink(*url.URL) error {
+	return nil
+}
+
+func (s *eventLogSink) Write(p []byte) (int, error) {
+	return s.WriteLevel(zapcore.InfoLevel, p)
+}
+
+// WriteLevel writes the log as an event with the type of the level, with the same event IDs as the
+// Windows service of the collector.
+func (s *eventLogSink) WriteLevel(level zapcore.Level, p []byte) (int, error) {
+	msg := strings.TrimRight(string(p), "\r\n")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	switch {
+	case level >= zapcore.ErrorLevel:
+		// golang.org/x/sys/windows/svc/eventlog does not support Critical level event logs
+		err = s.log.Error(3, msg)
+	case level == zapcore.WarnLevel:
+		err = s.log.Warning(2, msg)
+	default:
+		err = s.log.Info(1, msg)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *eventLogSink) Sync() error {
+	return nil
+}
+
+// Reopen opens the event log again.
+func (s *eventLogSink) Reopen() error {
+	log, err := eventlog.Open(s.source)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	prev := s.log
+	s.log = log
+	s.mu.Unlock()
+	return prev.Close()
+}
+
+func (s *eventLogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.log.Close()
+}
@@ -4,9 +4,11 @@
 package otelconftelemetry // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
 
 import (
+	"context"
 	"time"
 
 	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"go.opentelemetry.io/collector/component"
@@ -25,12 +27,18 @@ var useLocalHostAsDefaultMetricsAddressFeatureGate = featuregate.GlobalRegistry(
 // NewFactory creates a new telemetry.Factory that uses otelconf
 // to configure opentelemetry-go SDK telemetry providers.
 func NewFactory() telemetry.Factory {
+	files := &logFiles{}
 	return telemetry.NewFactory(
 		createDefaultConfig,
 		telemetry.WithCreateResource(createResource),
-		telemetry.WithCreateLogger(createLogger),
-		telemetry.WithCreateMeterProvider(createMeterProvider),
+		telemetry.WithCreateLogger(func(ctx context.Context, set telemetry.LoggerSettings, cfg component.Config) (*zap.Logger, component.ShutdownFunc, error) {
+			return createLogger(ctx, set, cfg, files)
+		}),
+		telemetry.WithCreateMeterProvider(func(ctx context.Context, set telemetry.MeterSettings, cfg component.Config) (telemetry.MeterProvider, error) {
+			return createMeterProvider(ctx, set, cfg, files)
+		}),
 		telemetry.WithCreateTracerProvider(createTracerProvider),
+		telemetry.WithReopenLogs(files.reopen),
 	)
 }
 
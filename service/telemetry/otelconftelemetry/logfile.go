@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// logFile is the writer of a log file of the collector, which can be closed and reopened, e.g.
+// after an external tool like logrotate moved it.
+type logFile interface {
+	zapcore.WriteSyncer
+
+	// Reopen closes the file, and opens it again at its path.
+	Reopen() error
+}
+
+// reopenableFile is a logFile opened with zap.Open, as zap opens the log files without rotation.
+type reopenableFile struct {
+	path string
+
+	mu    sync.Mutex
+	ws    zapcore.WriteSyncer
+	close func()
+}
+
+func openReopenableFile(path string) (*reopenableFile, error) {
+	ws, closeFunc, err := zap.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &reopenableFile{path: path, ws: ws, close: closeFunc}, nil
+}
+
+func (f *reopenableFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.ws.Write(p)
+}
+
+func (f *reopenableFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.ws.Sync()
+}
+
+func (f *reopenableFile) Reopen() error {
+	// The file is opened before closing the previous one, which is kept if it fails.
+	ws, closeFunc, err := zap.Open(f.path)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.close()
+	f.ws, f.close = ws, closeFunc
+	return nil
+}
+
+// logFiles holds the log files written by the loggers created by a factory, until they are shut down.
+type logFiles struct {
+	mu    sync.Mutex
+	files map[logFile]struct{}
+
+	// dropped counts the logs dropped by the buffered log files, see LogsBufferConfig.
+	dropped atomic.Int64
+}
+
+func (lf *logFiles) add(files []logFile) {
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	if lf.files == nil {
+		lf.files = make(map[logFile]struct{}, len(files))
+	}
+	for _, f := range files {
+		lf.files[f] = struct{}{}
+	}
+}
+
+func (lf *logFiles) remove(files []logFile) {
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	for _, f := range files {
+		delete(lf.files, f)
+	}
+}
+
+// reopen reopens all the log files, and returns the errors of the ones which failed to be reopened.
+func (lf *logFiles) reopen(context.Context) error {
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	var errs []error
+	for f := range lf.files {
+		errs = append(errs, f.Reopen())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func TestReopenLogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		rotation *LogsRotationConfig
+		buffer   *LogsBufferConfig
+	}{
+		{name: "without rotation"},
+		{name: "with rotation", rotation: &LogsRotationConfig{MaxSizeMB: 10}},
+		{name: "with buffer", rotation: &LogsRotationConfig{MaxSizeMB: 10}, buffer: &LogsBufferConfig{FlushInterval: time.Hour}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "collector.log")
+			cfg := createDefaultConfig().(*Config)
+			cfg.Logs.Encoding = "json"
+			cfg.Logs.OutputPaths = []string{path}
+			cfg.Logs.Rotation = tt.rotation
+			cfg.Logs.Buffer = tt.buffer
+
+			factory := NewFactory()
+			logger, shutdown, err := factory.CreateLogger(context.Background(), telemetry.LoggerSettings{}, cfg)
+			require.NoError(t, err)
+
+			logger.Info("before the move")
+			// The buffered logs are written to the log file.
+			_ = logger.Sync()
+			// The log file is moved, as logrotate does, and reopened at its path.
+			moved := filepath.Join(dir, "collector.log.1")
+			require.NoError(t, os.Rename(path, moved))
+			logger.Info("moved")
+			require.NoError(t, factory.ReopenLogs(context.Background()))
+			logger.Info("after the reopen")
+			// The buffered logs are written to the log file.
+			_ = logger.Sync()
+
+			assertFileContains(t, moved, []string{"before the move", "moved"}, []string{"after the reopen"})
+			assertFileContains(t, path, []string{"after the reopen"}, []string{"moved"})
+
+			// The log files of the loggers shut down are not reopened anymore.
+			require.NoError(t, shutdown(context.Background()))
+			require.NoError(t, os.Remove(path))
+			require.NoError(t, factory.ReopenLogs(context.Background()))
+			assert.NoFileExists(t, path)
+		})
+	}
+}
+
+func TestReopenLogsErrorOutput(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "errors.log")
+	files := &logFiles{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.ErrorOutputPaths = []string{"stderr", path}
+
+	_, shutdown, err := createLogger(context.Background(), telemetry.LoggerSettings{}, cfg, files)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, shutdown(context.Background()))
+	}()
+
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, files.reopen(context.Background()))
+	assert.FileExists(t, path)
+}
+
+func assertFileContains(t *testing.T, path string, contains, notContains []string) {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, s := range contains {
+		assert.Contains(t, string(content), s)
+	}
+	for _, s := range notContains {
+		assert.NotContains(t, string(content), s)
+	}
+}
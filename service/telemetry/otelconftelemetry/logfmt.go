@@ -0,0 +1,285 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+var logfmtPool = buffer.NewPool()
+
+// logfmtEncoder is a zapcore.Encoder writing each log as a line of key=value pairs, the values being
+// quoted when they are empty or hold spaces, equal signs, quotes or control characters. The keys of
+// the fields of objects and namespaces are prefixed by their key, e.g. resource.service.name=otelcol,
+// and arrays and reflected values are encoded as JSON.
+type logfmtEncoder struct {
+	cfg *zapcore.EncoderConfig
+	buf *buffer.Buffer
+	// prefix is the prefix of the keys of the fields added, ending with a dot unless empty.
+	prefix string
+}
+
+var _ zapcore.Encoder = (*logfmtEncoder)(nil)
+
+func newLogfmtEncoder(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) {
+	return &logfmtEncoder{cfg: &cfg, buf: logfmtPool.Get()}, nil
+}
+
+func (e *logfmtEncoder) Clone() zapcore.Encoder {
+	clone := &logfmtEncoder{cfg: e.cfg, buf: logfmtPool.Get(), prefix: e.prefix}
+	_, _ = clone.buf.Write(e.buf.Bytes())
+	return clone
+}
+
+func (e *logfmtEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	final := &logfmtEncoder{cfg: e.cfg, buf: logfmtPool.Get()}
+	if e.cfg.TimeKey != "" && e.cfg.EncodeTime != nil {
+		final.addEncoded(e.cfg.TimeKey, func(enc zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeTime(ent.Time, enc) })
+	}
+	if e.cfg.LevelKey != "" && e.cfg.EncodeLevel != nil {
+		final.addEncoded(e.cfg.LevelKey, func(enc zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeLevel(ent.Level, enc) })
+	}
+	if ent.LoggerName != "" && e.cfg.NameKey != "" {
+		encodeName := e.cfg.EncodeName
+		if encodeName == nil {
+			encodeName = zapcore.FullNameEncoder
+		}
+		final.addEncoded(e.cfg.NameKey, func(enc zapcore.PrimitiveArrayEncoder) { encodeName(ent.LoggerName, enc) })
+	}
+	if ent.Caller.Defined {
+		if e.cfg.CallerKey != "" && e.cfg.EncodeCaller != nil {
+			final.addEncoded(e.cfg.CallerKey, func(enc zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeCaller(ent.Caller, enc) })
+		}
+		if e.cfg.FunctionKey != "" {
+			final.AddString(e.cfg.FunctionKey, ent.Caller.Function)
+		}
+	}
+	if e.cfg.MessageKey != "" {
+		final.AddString(e.cfg.MessageKey, ent.Message)
+	}
+	if e.buf.Len() > 0 {
+		if final.buf.Len() > 0 {
+			final.buf.AppendByte(' ')
+		}
+		_, _ = final.buf.Write(e.buf.Bytes())
+	}
+	final.prefix = e.prefix
+	for _, f := range fields {
+		f.AddTo(final)
+	}
+	final.prefix = ""
+	if ent.Stack != "" && e.cfg.StacktraceKey != "" {
+		final.AddString(e.cfg.StacktraceKey, ent.Stack)
+	}
+	if e.cfg.LineEnding != "" {
+		final.buf.AppendString(e.cfg.LineEnding)
+	} else {
+		final.buf.AppendString(zapcore.DefaultLineEnding)
+	}
+	return final.buf, nil
+}
+
+func (e *logfmtEncoder) AddArray(key string, arr zapcore.ArrayMarshaler) error {
+	m := zapcore.NewMapObjectEncoder()
+	if err := m.AddArray(key, arr); err != nil {
+		return err
+	}
+	return e.addJSON(key, m.Fields[key])
+}
+
+func (e *logfmtEncoder) AddObject(key string, obj zapcore.ObjectMarshaler) error {
+	return obj.MarshalLogObject(&logfmtEncoder{cfg: e.cfg, buf: e.buf, prefix: e.prefix + key + "."})
+}
+
+func (e *logfmtEncoder) AddBinary(key string, value []byte) {
+	e.AddString(key, base64.StdEncoding.EncodeToString(value))
+}
+
+func (e *logfmtEncoder) AddByteString(key string, value []byte) {
+	e.AddString(key, string(value))
+}
+
+func (e *logfmtEncoder) AddBool(key string, value bool) {
+	e.addKey(key)
+	e.buf.AppendBool(value)
+}
+
+func (e *logfmtEncoder) AddComplex128(key string, value complex128) {
+	e.AddString(key, strconv.FormatComplex(value, 'g', -1, 128))
+}
+
+func (e *logfmtEncoder) AddComplex64(key string, value complex64) {
+	e.AddString(key, strconv.FormatComplex(complex128(value), 'g', -1, 64))
+}
+
+func (e *logfmtEncoder) AddDuration(key string, value time.Duration) {
+	if e.cfg.EncodeDuration == nil {
+		e.AddInt64(key, int64(value))
+		return
+	}
+	e.addEncoded(key, func(enc zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeDuration(value, enc) })
+}
+
+func (e *logfmtEncoder) AddFloat64(key string, value float64) {
+	e.addKey(key)
+	e.buf.AppendFloat(value, 64)
+}
+
+func (e *logfmtEncoder) AddFloat32(key string, value float32) {
+	e.addKey(key)
+	e.buf.AppendFloat(float64(value), 32)
+}
+
+func (e *logfmtEncoder) AddInt(key string, value int) { e.AddInt64(key, int64(value)) }
+
+func (e *logfmtEncoder) AddInt64(key string, value int64) {
+	e.addKey(key)
+	e.buf.AppendInt(value)
+}
+
+func (e *logfmtEncoder) AddInt32(key string, value int32) { e.AddInt64(key, int64(value)) }
+
+func (e *logfmtEncoder) AddInt16(key string, value int16) { e.AddInt64(key, int64(value)) }
+
+func (e *logfmtEncoder) AddInt8(key string, value int8) { e.AddInt64(key, int64(value)) }
+
+func (e *logfmtEncoder) AddString(key, value string) {
+	e.addKey(key)
+	e.appendValue(value)
+}
+
+func (e *logfmtEncoder) AddTime(key string, value time.Time) {
+	if e.cfg.EncodeTime == nil {
+		e.AddInt64(key, value.UnixNano())
+		return
+	}
+	e.addEncoded(key, func(enc zapcore.PrimitiveArrayEncoder) { e.cfg.EncodeTime(value, enc) })
+}
+
+func (e *logfmtEncoder) AddUint(key string, value uint) { e.AddUint64(key, uint64(value)) }
+
+func (e *logfmtEncoder) AddUint64(key string, value uint64) {
+	e.addKey(key)
+	e.buf.AppendUint(value)
+}
+
+func (e *logfmtEncoder) AddUint32(key string, value uint32) { e.AddUint64(key, uint64(value)) }
+
+func (e *logfmtEncoder) AddUint16(key string, value uint16) { e.AddUint64(key, uint64(value)) }
+
+func (e *logfmtEncoder) AddUint8(key string, value uint8) { e.AddUint64(key, uint64(value)) }
+
+func (e *logfmtEncoder) AddUintptr(key string, value uintptr) { e.AddUint64(key, uint64(value)) }
+
+func (e *logfmtEncoder) AddReflected(key string, value any) error {
+	if s, ok := value.(string); ok {
+		e.AddString(key, s)
+		return nil
+	}
+	return e.addJSON(key, value)
+}
+
+func (e *logfmtEncoder) OpenNamespace(key string) {
+	e.prefix += key + "."
+}
+
+// addJSON adds the value encoded as JSON.
+func (e *logfmtEncoder) addJSON(key string, value any) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	e.AddString(key, string(b))
+	return nil
+}
+
+// addEncoded adds the values appended by encode, separated by commas.
+func (e *logfmtEncoder) addEncoded(key string, encode func(zapcore.PrimitiveArrayEncoder)) {
+	var values logfmtValues
+	encode(&values)
+	e.AddString(key, strings.Join(values, ","))
+}
+
+// addKey appends the key, with its prefix, followed by an equal sign, the characters which can't be
+// part of a key being replaced by underscores.
+func (e *logfmtEncoder) addKey(key string) {
+	if e.buf.Len() > 0 {
+		e.buf.AppendByte(' ')
+	}
+	e.buf.AppendString(strings.Map(func(r rune) rune {
+		if logfmtNeedsQuote(r) {
+			return '_'
+		}
+		return r
+	}, e.prefix+key))
+	e.buf.AppendByte('=')
+}
+
+func (e *logfmtEncoder) appendValue(value string) {
+	if value == "" || strings.IndexFunc(value, logfmtNeedsQuote) >= 0 {
+		e.buf.AppendString(strconv.Quote(value))
+		return
+	}
+	e.buf.AppendString(value)
+}
+
+func logfmtNeedsQuote(r rune) bool {
+	return r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError || unicode.IsControl(r)
+}
+
+// logfmtValues collects the values appended by the encoders of the EncoderConfig.
+type logfmtValues []string
+
+func (v *logfmtValues) AppendBool(value bool) { *v = append(*v, strconv.FormatBool(value)) }
+
+func (v *logfmtValues) AppendByteString(value []byte) { *v = append(*v, string(value)) }
+
+func (v *logfmtValues) AppendComplex128(value complex128) {
+	*v = append(*v, strconv.FormatComplex(value, 'g', -1, 128))
+}
+
+func (v *logfmtValues) AppendComplex64(value complex64) {
+	*v = append(*v, strconv.FormatComplex(complex128(value), 'g', -1, 64))
+}
+
+func (v *logfmtValues) AppendFloat64(value float64) {
+	*v = append(*v, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func (v *logfmtValues) AppendFloat32(value float32) {
+	*v = append(*v, strconv.FormatFloat(float64(value), 'g', -1, 32))
+}
+
+func (v *logfmtValues) AppendInt(value int) { v.AppendInt64(int64(value)) }
+
+func (v *logfmtValues) AppendInt64(value int64) { *v = append(*v, strconv.FormatInt(value, 10)) }
+
+func (v *logfmtValues) AppendInt32(value int32) { v.AppendInt64(int64(value)) }
+
+func (v *logfmtValues) AppendInt16(value int16) { v.AppendInt64(int64(value)) }
+
+func (v *logfmtValues) AppendInt8(value int8) { v.AppendInt64(int64(value)) }
+
+func (v *logfmtValues) AppendString(value string) { *v = append(*v, value) }
+
+func (v *logfmtValues) AppendUint(value uint) { v.AppendUint64(uint64(value)) }
+
+func (v *logfmtValues) AppendUint64(value uint64) { *v = append(*v, strconv.FormatUint(value, 10)) }
+
+func (v *logfmtValues) AppendUint32(value uint32) { v.AppendUint64(uint64(value)) }
+
+func (v *logfmtValues) AppendUint16(value uint16) { v.AppendUint64(uint64(value)) }
+
+func (v *logfmtValues) AppendUint8(value uint8) { v.AppendUint64(uint64(value)) }
+
+func (v *logfmtValues) AppendUintptr(value uintptr) { v.AppendUint64(uint64(value)) }
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogfmtEncoder(t *testing.T) {
+	ec := zap.NewProductionEncoderConfig()
+	ec.EncodeTime = zapcore.ISO8601TimeEncoder
+	encoder, err := newLogfmtEncoder(ec)
+	require.NoError(t, err)
+
+	encoder.AddString("component", "receiver/otlp")
+	encoder.OpenNamespace("otelcol")
+	ent := zapcore.Entry{
+		Level:      zapcore.WarnLevel,
+		Time:       time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC),
+		LoggerName: "service",
+		Message:    `export failed: "timeout"`,
+		Caller:     zapcore.NewEntryCaller(0, "/src/otelcol/exporter.go", 42, true),
+	}
+	buf, err := encoder.EncodeEntry(ent, []zapcore.Field{
+		zap.Int("retries", 3),
+		zap.Bool("dropped", false),
+		zap.Duration("backoff", 1500*time.Millisecond),
+		zap.String("empty", ""),
+		zap.String("key with space", "a=b"),
+		zap.Strings("endpoints", []string{"a:4317", "b:4317"}),
+		zap.Dict("resource", zap.String("service.name", "otelcol")),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `ts=2025-03-01T02:00:00.000Z level=warn logger=service caller=otelcol/exporter.go:42 `+
+		`msg="export failed: \"timeout\"" component=receiver/otlp otelcol.retries=3 otelcol.dropped=false `+
+		`otelcol.backoff=1.5 otelcol.empty="" otelcol.key_with_space="a=b" `+
+		`otelcol.endpoints="[\"a:4317\",\"b:4317\"]" otelcol.resource.service.name=otelcol`+"\n", buf.String())
+	buf.Free()
+
+	// The context fields of the encoder are not changed by the encoded entries.
+	buf, err = encoder.Clone().EncodeEntry(zapcore.Entry{Message: "ok"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ts=0001-01-01T00:00:00.000Z level=info msg=ok component=receiver/otlp\n", buf.String())
+	buf.Free()
+}
+
+func TestRegisterLogEncoder(t *testing.T) {
+	assert.Equal(t, []string{"console", "json", "logfmt"}, logEncodings())
+	require.Error(t, RegisterLogEncoder("logfmt", newLogfmtEncoder))
+
+	// zap does not allow to unregister an encoder, the name is unique for the test to be repeatable.
+	name := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	errEncoder := errors.New("no encoder")
+	require.NoError(t, RegisterLogEncoder(name, func(zapcore.EncoderConfig) (zapcore.Encoder, error) {
+		return nil, errEncoder
+	}))
+	t.Cleanup(func() {
+		logEncodersMu.Lock()
+		defer logEncodersMu.Unlock()
+		delete(logEncoders, name)
+	})
+	assert.Contains(t, logEncodings(), name)
+	_, err := newLogEncoder(name, zapcore.EncoderConfig{})
+	require.ErrorIs(t, err, errEncoder)
+	_, err = newLogEncoder("unknown", zapcore.EncoderConfig{})
+	require.Error(t, err)
+}
@@ -5,17 +5,18 @@ package otelconftelemetry // import "go.opentelemetry.io/collector/service/telem
 
 import (
 	"context"
+	"slices"
 	"strings"
 
 	otelconf "go.opentelemetry.io/contrib/otelconf/v0.3.0"
 	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"gopkg.in/natefinch/lumberjack.v2"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
 	"go.opentelemetry.io/collector/service/telemetry"
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration"
 )
 
 // createLogger creates a Logger and a LoggerProvider from Config.
@@ -23,6 +24,7 @@ func createLogger(
 	ctx context.Context,
 	set telemetry.LoggerSettings,
 	componentConfig component.Config,
+	files *logFiles,
 ) (*zap.Logger, component.ShutdownFunc, error) {
 	cfg := componentConfig.(*Config)
 	res := newResource(set.Settings, cfg)
@@ -31,16 +33,12 @@ func createLogger(
 	ec := zap.NewProductionEncoderConfig()
 	ec.EncodeTime = zapcore.ISO8601TimeEncoder
 
-	// Separate output paths if rotation is enabled
-	outputPaths := cfg.Logs.OutputPaths
-	errorOutputPaths := cfg.Logs.ErrorOutputPaths
-	var filePaths, errorFilePaths []string
+	// Separate the console and file paths: the log files are written by writers which can rotate
+	// them, and reopen them, e.g. after an external tool like logrotate moved them.
+	outputPaths, filePaths := separateOutputPaths(cfg.Logs.OutputPaths)
+	errorOutputPaths, errorFilePaths := separateOutputPaths(cfg.Logs.ErrorOutputPaths)
 
 	if cfg.Logs.Rotation != nil {
-		// Separate console and file paths for rotation
-		outputPaths, filePaths = separateOutputPaths(cfg.Logs.OutputPaths)
-		errorOutputPaths, errorFilePaths = separateOutputPaths(cfg.Logs.ErrorOutputPaths)
-
 		// If no console paths remain, add stderr as default
 		if len(outputPaths) == 0 {
 			outputPaths = []string{"stderr"}
@@ -50,8 +48,36 @@ func createLogger(
 		}
 	}
 
+	outputFiles, err := openLogFiles(filePaths, cfg.Logs.Rotation)
+	if err != nil {
+		return nil, nil, err
+	}
+	errorOutputFiles, err := openLogFiles(errorFilePaths, cfg.Logs.Rotation)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The levels, configured for all the logs and for the logs of some components, can be changed at
+	// runtime, the level of the cores enabling the logs of the lowest of them.
+	componentLevels, err := logComponentLevels(cfg.Logs.ComponentLevels)
+	if err != nil {
+		return nil, nil, err
+	}
+	levels := componentattribute.NewLogLevels(cfg.Logs.Level, componentLevels)
+
+	// The values of the fields of the logs matching the redaction settings are redacted before the
+	// logs reach any core, including the initial fields.
+	redact, err := newRedactor(cfg.Logs.Redaction)
+	if err != nil {
+		return nil, nil, err
+	}
+	initialFields := cfg.Logs.InitialFields
+	if redact != nil {
+		initialFields, _ = redactMap[any](redact, initialFields, migration.LogsRedactedValue)
+	}
+
 	zapCfg := &zap.Config{
-		Level:             zap.NewAtomicLevelAt(cfg.Logs.Level),
+		Level:             levels.Threshold(),
 		Development:       cfg.Logs.Development,
 		Encoding:          cfg.Logs.Encoding,
 		EncoderConfig:     ec,
@@ -59,7 +85,7 @@ func createLogger(
 		ErrorOutputPaths:  errorOutputPaths,
 		DisableCaller:     cfg.Logs.DisableCaller,
 		DisableStacktrace: cfg.Logs.DisableStacktrace,
-		InitialFields:     cfg.Logs.InitialFields,
+		InitialFields:     initialFields,
 	}
 
 	if zapCfg.Encoding == "console" {
@@ -72,59 +98,72 @@ func createLogger(
 		return nil, nil, err
 	}
 
-	// Add log rotation for file outputs if configured
-	if cfg.Logs.Rotation != nil && (len(filePaths) > 0 || len(errorFilePaths) > 0) {
-		logger = logger.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
-			cores := []zapcore.Core{c} // Start with the existing core (console outputs)
-
-			// Create encoder based on configuration
-			var encoder zapcore.Encoder
-			if zapCfg.Encoding == "json" {
-				encoder = zapcore.NewJSONEncoder(zapCfg.EncoderConfig)
-			} else {
-				encoder = zapcore.NewConsoleEncoder(zapCfg.EncoderConfig)
-			}
+	// The log files are encoded like the console outputs, with the encoder registered for the
+	// encoding, see RegisterLogEncoder.
+	var encoder zapcore.Encoder
+	if len(outputFiles) > 0 || (cfg.Logs.Rotation != nil && len(errorOutputFiles) > 0) {
+		encoder, err = newLogEncoder(zapCfg.Encoding, zapCfg.EncoderConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 
-			// Add rotating file cores for regular output paths
-			for _, path := range filePaths {
-				w := zapcore.AddSync(&lumberjack.Logger{
-					Filename:   path,
-					MaxSize:    cfg.Logs.Rotation.MaxSizeMB,
-					MaxBackups: cfg.Logs.Rotation.MaxBackups,
-					MaxAge:     cfg.Logs.Rotation.MaxAgeDays,
-					Compress:   cfg.Logs.Rotation.Compress,
-				})
-
-				fileCore := zapcore.NewCore(
-					encoder,
-					w,
-					zapCfg.Level,
-				)
-				cores = append(cores, fileCore)
-			}
+	// The logs written to the log files are buffered if set, so that logging does not wait for them.
+	if cfg.Logs.Buffer != nil {
+		outputFiles = bufferLogFiles(outputFiles, cfg.Logs.Buffer, &files.dropped)
+		errorOutputFiles = bufferLogFiles(errorOutputFiles, cfg.Logs.Buffer, &files.dropped)
+	}
 
-			// Add rotating file cores for error output paths
-			// Error outputs typically only capture error-level logs
-			for _, path := range errorFilePaths {
-				w := zapcore.AddSync(&lumberjack.Logger{
-					Filename:   path,
-					MaxSize:    cfg.Logs.Rotation.MaxSizeMB,
-					MaxBackups: cfg.Logs.Rotation.MaxBackups,
-					MaxAge:     cfg.Logs.Rotation.MaxAgeDays,
-					Compress:   cfg.Logs.Rotation.Compress,
-				})
+	// Add the cores of the log files, and the fields of the span context to the logs written to the
+	// console outputs and to the log files.
+	logger = logger.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		cores := []zapcore.Core{c} // Start with the existing core (console outputs)
+
+		// Add file cores for regular output paths
+		for _, w := range outputFiles {
+			fileCore := newLogFileCore(
+				encoder,
+				w,
+				zapCfg.Level,
+			)
+			cores = append(cores, fileCore)
+		}
 
+		// Add rotating file cores for error output paths
+		// Error outputs typically only capture error-level logs
+		if cfg.Logs.Rotation != nil {
+			for _, w := range errorOutputFiles {
 				// Error output paths should only log errors
-				errorCore := zapcore.NewCore(
+				errorCore := newLogFileCore(
 					encoder,
 					w,
 					zapcore.ErrorLevel,
 				)
 				cores = append(cores, errorCore)
 			}
+		}
 
-			return zapcore.NewTee(cores...)
-		}))
+		if !cfg.Logs.DisableTraceContext {
+			for i, core := range cores {
+				cores[i] = newTraceContextCore(core)
+			}
+		}
+		return zapcore.NewTee(cores...)
+	}))
+
+	// Without rotation, the error output files receive the internal errors of the logger,
+	// along with the console error outputs.
+	if cfg.Logs.Rotation == nil && len(errorOutputFiles) > 0 {
+		errSink, _, err := zap.Open(errorOutputPaths...)
+		if err != nil {
+			stopBufferedFiles(slices.Concat(outputFiles, errorOutputFiles))
+			return nil, nil, err
+		}
+		sinks := []zapcore.WriteSyncer{errSink}
+		for _, w := range errorOutputFiles {
+			sinks = append(sinks, w)
+		}
+		logger = logger.WithOptions(zap.ErrorOutput(zapcore.Lock(zapcore.NewMultiWriteSyncer(sinks...))))
 	}
 
 	// The attributes in res.Attributes(), which are generated in telemetry.go,
@@ -163,6 +202,7 @@ func createLogger(
 		},
 	})
 	if err != nil {
+		stopBufferedFiles(slices.Concat(outputFiles, errorOutputFiles))
 		return nil, nil, err
 	}
 
@@ -178,14 +218,52 @@ func createLogger(
 			"go.opentelemetry.io/collector/service",
 			attribute.NewSet(),
 		)
+		if redact != nil {
+			core = componentattribute.NewFieldsCoreWithAttributes(core, redact.fields)
+		}
+		core = componentattribute.NewLevelCoreWithAttributes(core, levels, attribute.NewSet())
 		return core
 	}))
 
-	return logger, sdk.Shutdown, nil
+	// The log files are reopened until the logger is shut down.
+	opened := slices.Concat(outputFiles, errorOutputFiles)
+	files.add(opened)
+	return logger, func(ctx context.Context) error {
+		files.remove(opened)
+		stopBufferedFiles(opened)
+		return sdk.Shutdown(ctx)
+	}, nil
+}
+
+// openLogFiles opens the log files at paths, rotated with rotation if set, and the logSinks of the paths
+// which are their URLs, e.g. syslog://localhost:514.
+func openLogFiles(paths []string, rotation *LogsRotationConfig) ([]logFile, error) {
+	files := make([]logFile, 0, len(paths))
+	for _, path := range paths {
+		if isLogSink(path) {
+			// The logs sent to the native logging facility of the host are never rotated.
+			s, err := openLogSink(path)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, s)
+			continue
+		}
+		if rotation != nil {
+			files = append(files, newRotatingWriter(path, rotation.ForPath(path)))
+			continue
+		}
+		f, err := openReopenableFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
 }
 
 // separateOutputPaths separates output paths into console outputs (stdout/stderr)
-// and file paths for rotation.
+// and file paths.
 func separateOutputPaths(paths []string) (consolePaths []string, filePaths []string) {
 	for _, path := range paths {
 		// Check if path is stdout, stderr, or starts with file:// scheme pointing to stdout/stderr
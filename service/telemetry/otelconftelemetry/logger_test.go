@@ -9,18 +9,24 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
+	"go.opentelemetry.io/otel/attribute"
 	semconv "go.opentelemetry.io/otel/semconv/v1.18.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
@@ -140,6 +146,264 @@ func TestCreateLogger(t *testing.T) {
 	}
 }
 
+func TestCreateLoggerLogLevels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collector.log")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Encoding = "json"
+	cfg.Logs.OutputPaths = []string{path}
+	cfg.Logs.Sampling = nil
+
+	logger, shutdown, err := createLogger(context.Background(), telemetry.LoggerSettings{}, cfg, &logFiles{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, shutdown(context.Background()))
+	}()
+	levels := componentattribute.LogLevelsOf(logger)
+	require.NotNil(t, levels)
+	assert.Equal(t, zapcore.InfoLevel, levels.Level(""))
+
+	// The level of the logs of a component is changed at runtime.
+	receiverLogger := componentattribute.ZapLoggerWithAttributes(logger, attribute.NewSet(
+		attribute.String(componentattribute.ComponentKindKey, "receiver"),
+		attribute.String(componentattribute.ComponentIDKey, "otlp"),
+	))
+	levels.SetLevel("receiver/otlp", zapcore.DebugLevel, 0)
+	logger.Debug("service debug")
+	receiverLogger.Debug("receiver debug")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "receiver debug")
+	assert.NotContains(t, string(content), "service debug")
+}
+
+func TestCreateLoggerLogfmt(t *testing.T) {
+	tests := []struct {
+		name     string
+		rotation *LogsRotationConfig
+	}{
+		{name: "without rotation"},
+		{name: "with rotation", rotation: &LogsRotationConfig{MaxSizeMB: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "collector.log")
+			cfg := createDefaultConfig().(*Config)
+			cfg.Logs.Encoding = "logfmt"
+			cfg.Logs.OutputPaths = []string{path}
+			cfg.Logs.Rotation = tt.rotation
+			require.NoError(t, cfg.Validate())
+
+			logger, shutdown, err := createLogger(context.Background(), telemetry.LoggerSettings{}, cfg, &logFiles{})
+			require.NoError(t, err)
+			logger.Info("Everything is ready", zap.String("component", "receiver/otlp"))
+			require.NoError(t, shutdown(context.Background()))
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Regexp(t, `^ts=\S+ level=info caller=\S+ msg="Everything is ready" resource\.service\.instance\.id=\S+ .* component=receiver/otlp\n$`, string(content))
+		})
+	}
+}
+
+func TestCreateLoggerTraceContext(t *testing.T) {
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+		SpanID:     trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	tests := []struct {
+		name    string
+		disable bool
+	}{
+		{name: "enabled"},
+		{name: "disabled", disable: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var exportedSpanIDs []string
+			srv := createBackend("/v1/logs", func(_ http.ResponseWriter, request *http.Request) {
+				body, err := io.ReadAll(request.Body)
+				assert.NoError(t, err)
+				req := plogotlp.NewExportRequest()
+				assert.NoError(t, req.UnmarshalProto(body))
+				record := req.Logs().ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+				exportedSpanIDs = append(exportedSpanIDs, record.SpanID().String())
+			})
+			defer srv.Close()
+
+			path := filepath.Join(t.TempDir(), "collector.log")
+			cfg := createDefaultConfig().(*Config)
+			cfg.Logs.Encoding = "json"
+			cfg.Logs.OutputPaths = []string{path}
+			cfg.Logs.DisableTraceContext = tt.disable
+			cfg.Logs.Processors = []config.LogRecordProcessor{{
+				Simple: &config.SimpleLogRecordProcessor{
+					Exporter: config.LogRecordExporter{
+						OTLP: &config.OTLP{
+							Endpoint: ptr(srv.URL),
+							Protocol: ptr("http/protobuf"),
+							Insecure: ptr(true),
+						},
+					},
+				},
+			}}
+
+			logger, shutdown, err := createLogger(context.Background(), telemetry.LoggerSettings{}, cfg, &logFiles{})
+			require.NoError(t, err)
+			logger.Info("Exporting failed", zap.Any("ctx", ctx))
+			require.NoError(t, shutdown(context.Background()))
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			if tt.disable {
+				assert.NotContains(t, string(content), `"span_id"`)
+			} else {
+				assert.Contains(t, string(content), `"trace_id":"0102030405060708090a0b0c0d0e0f10","span_id":"0102030405060708","trace_flags":"01"`)
+			}
+			// The logs copied to the LoggerProvider get the span context of the context as is.
+			assert.Equal(t, []string{"0102030405060708"}, exportedSpanIDs)
+		})
+	}
+}
+
+func TestCreateLoggerRedaction(t *testing.T) {
+	var mu sync.Mutex
+	var exported []map[string]any
+	srv := createBackend("/v1/logs", func(_ http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		assert.NoError(t, err)
+		req := plogotlp.NewExportRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		mu.Lock()
+		defer mu.Unlock()
+		record := req.Logs().ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+		exported = append(exported, record.Attributes().AsRaw())
+	})
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "collector.log")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Encoding = "json"
+	cfg.Logs.OutputPaths = []string{path}
+	cfg.Logs.Redaction = &LogsRedactionConfig{Keys: []string{"authorization", "*token*"}}
+	cfg.Logs.Processors = []config.LogRecordProcessor{{
+		Simple: &config.SimpleLogRecordProcessor{
+			Exporter: config.LogRecordExporter{
+				OTLP: &config.OTLP{
+					Endpoint: ptr(srv.URL),
+					Protocol: ptr("http/protobuf"),
+					Insecure: ptr(true),
+				},
+			},
+		},
+	}}
+
+	logger, shutdown, err := createLogger(context.Background(), telemetry.LoggerSettings{}, cfg, &logFiles{})
+	require.NoError(t, err)
+	receiverLogger := componentattribute.ZapLoggerWithAttributes(logger, attribute.NewSet(
+		attribute.String(componentattribute.ComponentKindKey, "receiver"),
+		attribute.String(componentattribute.ComponentIDKey, "otlp"),
+	))
+	receiverLogger.With(zap.String("token", "with-secret")).Info("Request received", zap.String("Authorization", "Bearer secret"))
+	require.NoError(t, shutdown(context.Background()))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "secret")
+	assert.Contains(t, string(content), `"Authorization":"[REDACTED]"`)
+	assert.Contains(t, string(content), `"caller":"otelconftelemetry/logger_test.go`)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, exported, 1)
+	assert.Equal(t, "[REDACTED]", exported[0]["Authorization"])
+	assert.Equal(t, "[REDACTED]", exported[0]["token"])
+}
+
+func TestCreateLoggerComponentLevels(t *testing.T) {
+	var mu sync.Mutex
+	var exported []string
+	srv := createBackend("/v1/logs", func(_ http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		assert.NoError(t, err)
+		req := plogotlp.NewExportRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		mu.Lock()
+		defer mu.Unlock()
+		rls := req.Logs().ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			sls := rls.At(i).ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				records := sls.At(j).LogRecords()
+				for k := 0; k < records.Len(); k++ {
+					exported = append(exported, records.At(k).Body().AsString())
+				}
+			}
+		}
+	})
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "collector.log")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Encoding = "json"
+	cfg.Logs.OutputPaths = []string{path}
+	cfg.Logs.Sampling = nil
+	cfg.Logs.ComponentLevels = map[string]zapcore.Level{
+		"receiver/otlp": zapcore.DebugLevel,
+		// The level of a component can also be keyed by its type followed by its kind.
+		"batchprocessor": zapcore.WarnLevel,
+	}
+	cfg.Logs.Processors = []config.LogRecordProcessor{{
+		Simple: &config.SimpleLogRecordProcessor{
+			Exporter: config.LogRecordExporter{
+				OTLP: &config.OTLP{
+					Endpoint: ptr(srv.URL),
+					Protocol: ptr("http/protobuf"),
+					Insecure: ptr(true),
+				},
+			},
+		},
+	}}
+
+	logger, shutdown, err := createLogger(context.Background(), telemetry.LoggerSettings{}, cfg, &logFiles{})
+	require.NoError(t, err)
+	componentLogger := func(kind, id string) *zap.Logger {
+		return componentattribute.ZapLoggerWithAttributes(logger, attribute.NewSet(
+			attribute.String(componentattribute.ComponentKindKey, kind),
+			attribute.String(componentattribute.ComponentIDKey, id),
+		))
+	}
+	logger.Debug("service debug")
+	logger.Info("service info")
+	componentLogger("receiver", "otlp").Debug("receiver debug")
+	// The level of the component type applies to the components of this type with a name.
+	componentLogger("processor", "batch/traces").Info("processor info")
+	componentLogger("processor", "batch/traces").Warn("processor warn")
+
+	// A component level reset at runtime restores its configured level.
+	levels := componentattribute.LogLevelsOf(logger)
+	levels.SetLevel("receiver/otlp", zapcore.ErrorLevel, 0)
+	levels.ResetLevel("receiver/otlp")
+	assert.Equal(t, zapcore.DebugLevel, levels.Level("receiver/otlp"))
+	require.NoError(t, shutdown(context.Background()))
+
+	want := []string{"service info", "receiver debug", "processor warn"}
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, msg := range want {
+		assert.Contains(t, string(content), msg)
+	}
+	for _, msg := range []string{"service debug", "processor info"} {
+		assert.NotContains(t, string(content), msg)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, want, exported)
+}
+
 func TestCreateLoggerWithResource(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -235,7 +499,7 @@ func TestCreateLoggerWithResource(t *testing.T) {
 				Resource: tt.resourceConfig,
 			}
 
-			logger, loggerProvider, err := createLogger(t.Context(), set, cfg)
+			logger, loggerProvider, err := createLogger(t.Context(), set, cfg, &logFiles{})
 			require.NoError(t, err)
 			defer func() {
 				assert.NoError(t, loggerProvider.Shutdown(t.Context()))
@@ -340,7 +604,7 @@ func newOTLPLogger(t *testing.T, level zapcore.Level, handler http.HandlerFunc)
 		},
 	}
 
-	logger, shutdown, err := createLogger(t.Context(), telemetry.LoggerSettings{}, cfg)
+	logger, shutdown, err := createLogger(t.Context(), telemetry.LoggerSettings{}, cfg, &logFiles{})
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		assert.NoError(t, shutdown.Shutdown(context.WithoutCancel(t.Context())))
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// The schemes of the output paths writing the logs to the native logging facility of the host.
+const (
+	syslogScheme   = "syslog"
+	eventLogScheme = "eventlog"
+)
+
+func init() {
+	for scheme, factory := range map[string]func(*url.URL) (zap.Sink, error){
+		syslogScheme:   func(u *url.URL) (zap.Sink, error) { return newSyslogSink(u) },
+		eventLogScheme: func(u *url.URL) (zap.Sink, error) { return newEventLogSink(u) },
+	} {
+		if err := zap.RegisterSink(scheme, factory); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// logSink is a logFile writing the logs to the native logging facility of the host, e.g. syslog, with
+// the severity of their level.
+type logSink interface {
+	logFile
+	io.Closer
+	// WriteLevel writes the log encoded in p with the severity of the level.
+	WriteLevel(level zapcore.Level, p []byte) (int, error)
+}
+
+// isLogSink returns whether the output path is the URL of a logSink, e.g. syslog://localhost:514.
+func isLogSink(path string) bool {
+	return strings.HasPrefix(path, syslogScheme+"://") || strings.HasPrefix(path, eventLogScheme+"://")
+}
+
+// openLogSink opens the logSink of the output path.
+func openLogSink(path string) (logSink, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output path %q: %w", path, err)
+	}
+	switch u.Scheme {
+	case syslogScheme:
+		return newSyslogSink(u)
+	case eventLogScheme:
+		return newEventLogSink(u)
+	}
+	return nil, fmt.Errorf("unsupported output path %q", path)
+}
+
+// checkLogSink checks the URL of the logSink of the output path, without opening it.
+func checkLogSink(path string) error {
+	u, err := url.Parse(path)
+	if err != nil {
+		return fmt.Errorf("invalid output path %q: %w", path, err)
+	}
+	if u.Scheme == eventLogScheme {
+		return checkEventLogSink(u)
+	}
+	_, err = newSyslogSink(u)
+	return err
+}
+
+// newLogFileCore returns the core writing the logs to the log file, with the severity of their level
+// for a logSink.
+func newLogFileCore(enc zapcore.Encoder, w logFile, enab zapcore.LevelEnabler) zapcore.Core {
+	if s, ok := w.(logSink); ok {
+		return &logSinkCore{LevelEnabler: enab, enc: enc, sink: s}
+	}
+	return zapcore.NewCore(enc, w, enab)
+}
+
+// logSinkCore is a core writing the logs to a logSink, like the cores created by zapcore.NewCore
+// write them to a zapcore.WriteSyncer.
+type logSinkCore struct {
+	zapcore.LevelEnabler
+	enc  zapcore.Encoder
+	sink logSink
+}
+
+func (c *logSinkCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.LevelEnabler)
+}
+
+func (c *logSinkCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	return &logSinkCore{LevelEnabler: c.LevelEnabler, enc: enc, sink: c.sink}
+}
+
+func (c *logSinkCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *logSinkCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	_, err = c.sink.WriteLevel(ent.Level, buf.Bytes())
+	buf.Free()
+	if err != nil {
+		return err
+	}
+	if ent.Level > zapcore.ErrorLevel {
+		// Since we may be crashing the program, sync the output.
+		return c.Sync()
+	}
+	return nil
+}
+
+func (c *logSinkCore) Sync() error {
+	return c.sink.Sync()
+}
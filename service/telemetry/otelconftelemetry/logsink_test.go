@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsLogSink(t *testing.T) {
+	assert.True(t, isLogSink("syslog://localhost:514"))
+	assert.True(t, isLogSink("eventlog://otelcol"))
+	assert.False(t, isLogSink("/var/log/otelcol.log"))
+	assert.False(t, isLogSink("file:///var/log/otelcol.log"))
+	assert.False(t, isLogSink("stderr"))
+}
+
+func TestCheckEventLogSink(t *testing.T) {
+	err := checkLogSink("eventlog://otelcol")
+	if runtime.GOOS == "windows" {
+		require.NoError(t, err)
+		return
+	}
+	require.ErrorContains(t, err, "only supported on Windows")
+}
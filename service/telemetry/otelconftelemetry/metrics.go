@@ -5,6 +5,7 @@ package otelconftelemetry // import "go.opentelemetry.io/collector/service/telem
 
 import (
 	"context"
+	"errors"
 
 	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
 	noopmetric "go.opentelemetry.io/otel/metric/noop"
@@ -18,6 +19,7 @@ func createMeterProvider(
 	ctx context.Context,
 	set telemetry.MeterSettings,
 	componentConfig component.Config,
+	files *logFiles,
 ) (telemetry.MeterProvider, error) {
 	cfg := componentConfig.(*Config)
 	if cfg.Metrics.Level == configtelemetry.LevelNone {
@@ -35,7 +37,15 @@ func createMeterProvider(
 	if err != nil {
 		return nil, err
 	}
-	return sdk.MeterProvider().(telemetry.MeterProvider), nil
+	mp := sdk.MeterProvider().(telemetry.MeterProvider)
+
+	// The logs dropped by the buffered log files are only counted if they can be.
+	if cfg.Logs.Buffer != nil && cfg.Logs.Buffer.Drops() {
+		if err := registerDroppedLogsMetric(mp, &files.dropped); err != nil {
+			return nil, errors.Join(err, sdk.Shutdown(ctx))
+		}
+	}
+	return mp, nil
 }
 
 type noopMeterProvider struct {
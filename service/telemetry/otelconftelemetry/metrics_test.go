@@ -119,7 +119,7 @@ func TestCreateMeterProvider(t *testing.T) {
 		}
 
 		t.Run(tt.name, func(t *testing.T) {
-			mp, err := createMeterProvider(t.Context(), telemetry.MeterSettings{}, cfg)
+			mp, err := createMeterProvider(t.Context(), telemetry.MeterSettings{}, cfg, &logFiles{})
 			require.NoError(t, err)
 			defer func() {
 				assert.NoError(t, mp.Shutdown(t.Context()))
@@ -209,7 +209,7 @@ func TestCreateMeterProvider_Invalid(t *testing.T) {
 		// Invalid -- no OTLP protocol defined
 		Periodic: &config.PeriodicMetricReader{Exporter: config.PushMetricExporter{OTLP: &config.OTLPMetric{}}},
 	}}
-	_, err := createMeterProvider(t.Context(), telemetry.MeterSettings{}, cfg)
+	_, err := createMeterProvider(t.Context(), telemetry.MeterSettings{}, cfg, &logFiles{})
 	require.EqualError(t, err, "no valid metric exporter")
 }
 
@@ -232,7 +232,7 @@ func TestCreateMeterProvider_Disabled(t *testing.T) {
 	// Setting Metrics.Level to LevelNone disables metrics,
 	// so the invalid configuration should not cause an error.
 	cfg.Metrics.Level = configtelemetry.LevelNone
-	mp, err := createMeterProvider(t.Context(), settings, cfg)
+	mp, err := createMeterProvider(t.Context(), settings, cfg, &logFiles{})
 	require.NoError(t, err)
 	assert.NoError(t, mp.Shutdown(t.Context()))
 
@@ -249,7 +249,7 @@ func TestInstrumentEnabled(t *testing.T) {
 		Pull: &config.PullMetricReader{Exporter: config.PullMetricExporter{Prometheus: prom}},
 	}}
 
-	meterProvider, err := createMeterProvider(t.Context(), telemetry.MeterSettings{}, cfg)
+	meterProvider, err := createMeterProvider(t.Context(), telemetry.MeterSettings{}, cfg, &logFiles{})
 	require.NoError(t, err)
 	defer func() {
 		assert.NoError(t, meterProvider.Shutdown(t.Context()))
@@ -0,0 +1,332 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration"
+)
+
+// redactedURLValue replaces the passwords and the query parameters redacted from URLs, as url.URL.Redacted does.
+const redactedURLValue = "xxxxx"
+
+// redactor redacts the values of the fields of the logs whose key matches: the fields of the logs, the
+// fields of their objects, the entries of their maps keyed by strings, and the query parameters of the
+// URLs in their string values. The passwords of the URLs are always redacted.
+type redactor struct {
+	keys *regexp.Regexp
+}
+
+// newRedactor returns the redactor configured by cfg, nil if no key is redacted.
+func newRedactor(cfg *LogsRedactionConfig) (*redactor, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	keys, err := cfg.KeyRegexp()
+	if err != nil || keys == nil {
+		return nil, err
+	}
+	return &redactor{keys: keys}, nil
+}
+
+// fields returns the fields with their values redacted, the fields themselves if none is.
+func (r *redactor) fields(fields []zapcore.Field) []zapcore.Field {
+	var redacted []zapcore.Field
+	for i, f := range fields {
+		rf, ok := r.field(f)
+		switch {
+		case ok && redacted == nil:
+			redacted = make([]zapcore.Field, 0, len(fields))
+			redacted = append(redacted, fields[:i]...)
+			redacted = append(redacted, rf)
+		case ok:
+			redacted = append(redacted, rf)
+		case redacted != nil:
+			redacted = append(redacted, f)
+		}
+	}
+	if redacted == nil {
+		return fields
+	}
+	return redacted
+}
+
+// field returns the field with its value redacted, and whether it is.
+func (r *redactor) field(f zapcore.Field) (zapcore.Field, bool) {
+	if f.Type == zapcore.NamespaceType || f.Type == zapcore.SkipType {
+		return f, false
+	}
+	if r.keys.MatchString(f.Key) {
+		return zap.String(f.Key, migration.LogsRedactedValue), true
+	}
+	switch f.Type {
+	case zapcore.StringType:
+		if s, ok := r.url(f.String); ok {
+			return zap.String(f.Key, s), true
+		}
+	case zapcore.ObjectMarshalerType:
+		if obj, ok := f.Interface.(zapcore.ObjectMarshaler); ok {
+			return zap.Object(f.Key, redactedObject{ObjectMarshaler: obj, r: r}), true
+		}
+	case zapcore.ReflectType:
+		if v, ok := r.reflected(f.Interface); ok {
+			return zap.Any(f.Key, v), true
+		}
+	}
+	return f, false
+}
+
+// reflected returns the copy of the map keyed by strings with its values redacted, and whether one is.
+func (r *redactor) reflected(value any) (any, bool) {
+	switch v := value.(type) {
+	case http.Header:
+		m, ok := redactMap(r, v, []string{migration.LogsRedactedValue})
+		return http.Header(m), ok
+	case map[string][]string:
+		return redactMap(r, v, []string{migration.LogsRedactedValue})
+	case map[string]string:
+		return redactMap(r, v, migration.LogsRedactedValue)
+	case map[string]any:
+		return redactMap[any](r, v, migration.LogsRedactedValue)
+	}
+	return value, false
+}
+
+func redactMap[V any](r *redactor, m map[string]V, redacted V) (map[string]V, bool) {
+	var copied map[string]V
+	for k := range m {
+		if !r.keys.MatchString(k) {
+			continue
+		}
+		if copied == nil {
+			copied = make(map[string]V, len(m))
+			for k, v := range m {
+				copied[k] = v
+			}
+		}
+		copied[k] = redacted
+	}
+	if copied == nil {
+		return m, false
+	}
+	return copied, true
+}
+
+// url returns the URL with its password and the query parameters whose name matches redacted, and
+// whether one is, if s is an URL.
+func (r *redactor) url(s string) (string, bool) {
+	if !strings.Contains(s, "://") {
+		return s, false
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return s, false
+	}
+	_, redacted := u.User.Password()
+	if u.RawQuery != "" {
+		query := u.Query()
+		redactedQuery := false
+		for name := range query {
+			if r.keys.MatchString(name) {
+				query[name] = []string{redactedURLValue}
+				redactedQuery = true
+			}
+		}
+		if redactedQuery {
+			u.RawQuery = query.Encode()
+			redacted = true
+		}
+	}
+	if !redacted {
+		return s, false
+	}
+	return u.Redacted(), true
+}
+
+// redactedObject marshals an object with the values of its fields redacted.
+type redactedObject struct {
+	zapcore.ObjectMarshaler
+	r *redactor
+}
+
+func (o redactedObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return o.ObjectMarshaler.MarshalLogObject(&redactingEncoder{ObjectEncoder: enc, r: o.r})
+}
+
+// redactingEncoder is a zapcore.ObjectEncoder redacting the values of the fields whose key matches.
+type redactingEncoder struct {
+	zapcore.ObjectEncoder
+	r *redactor
+}
+
+// redact adds the redacted value of the field if its key matches, and returns whether it does.
+func (e *redactingEncoder) redact(key string) bool {
+	if !e.r.keys.MatchString(key) {
+		return false
+	}
+	e.ObjectEncoder.AddString(key, migration.LogsRedactedValue)
+	return true
+}
+
+func (e *redactingEncoder) AddArray(key string, arr zapcore.ArrayMarshaler) error {
+	if e.redact(key) {
+		return nil
+	}
+	return e.ObjectEncoder.AddArray(key, arr)
+}
+
+func (e *redactingEncoder) AddObject(key string, obj zapcore.ObjectMarshaler) error {
+	if e.redact(key) {
+		return nil
+	}
+	return e.ObjectEncoder.AddObject(key, redactedObject{ObjectMarshaler: obj, r: e.r})
+}
+
+func (e *redactingEncoder) AddBinary(key string, value []byte) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddBinary(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddByteString(key string, value []byte) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddByteString(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddBool(key string, value bool) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddBool(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddComplex128(key string, value complex128) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddComplex128(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddComplex64(key string, value complex64) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddComplex64(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddDuration(key string, value time.Duration) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddDuration(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddFloat64(key string, value float64) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddFloat64(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddFloat32(key string, value float32) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddFloat32(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddInt(key string, value int) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddInt(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddInt64(key string, value int64) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddInt64(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddInt32(key string, value int32) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddInt32(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddInt16(key string, value int16) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddInt16(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddInt8(key string, value int8) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddInt8(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddString(key, value string) {
+	if e.redact(key) {
+		return
+	}
+	if s, ok := e.r.url(value); ok {
+		value = s
+	}
+	e.ObjectEncoder.AddString(key, value)
+}
+
+func (e *redactingEncoder) AddTime(key string, value time.Time) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddTime(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddUint(key string, value uint) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddUint(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddUint64(key string, value uint64) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddUint64(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddUint32(key string, value uint32) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddUint32(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddUint16(key string, value uint16) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddUint16(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddUint8(key string, value uint8) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddUint8(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddUintptr(key string, value uintptr) {
+	if !e.redact(key) {
+		e.ObjectEncoder.AddUintptr(key, value)
+	}
+}
+
+func (e *redactingEncoder) AddReflected(key string, value any) error {
+	if e.redact(key) {
+		return nil
+	}
+	if v, ok := e.r.reflected(value); ok {
+		value = v
+	}
+	return e.ObjectEncoder.AddReflected(key, value)
+}
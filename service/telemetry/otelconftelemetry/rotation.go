@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"go.opentelemetry.io/collector/service/telemetry/internal/migration"
+)
+
+// now returns the current time, overridden in tests.
+var now = time.Now
+
+// newRotatingWriter returns the writer of the log file at path, rotated based on its size,
+// and on time if cfg sets an interval.
+func newRotatingWriter(path string, cfg *LogsRotationConfig) logFile {
+	logger := &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+		MaxAge:     cfg.MaxAgeDays,
+		Compress:   cfg.Compress,
+	}
+	if cfg.Interval == "" {
+		return &rotatingFile{WriteSyncer: zapcore.AddSync(logger), logger: logger}
+	}
+	// The offset was checked by the validation of the config.
+	offset, _ := cfg.RotateAtOffset()
+	w := newTimeRotatingWriter(logger, nextRotation(cfg.Interval, offset), now)
+	return &rotatingFile{WriteSyncer: zapcore.AddSync(w), logger: logger}
+}
+
+// rotatingFile is a logFile written through a lumberjack.Logger, which opens the file again on
+// the first write after it was closed.
+type rotatingFile struct {
+	zapcore.WriteSyncer
+	logger *lumberjack.Logger
+}
+
+func (f *rotatingFile) Reopen() error {
+	return f.logger.Close()
+}
+
+// nextRotation returns the function returning the first rotation time after a time, for the interval
+// and the offset of the daily rotations since midnight UTC.
+func nextRotation(interval string, offset time.Duration) func(time.Time) time.Time {
+	if interval == migration.LogsRotationIntervalHourly {
+		return func(t time.Time) time.Time {
+			return t.UTC().Truncate(time.Hour).Add(time.Hour)
+		}
+	}
+	return func(t time.Time) time.Time {
+		// Truncating a time to a day returns the midnight UTC of its day.
+		next := t.UTC().Truncate(24 * time.Hour).Add(offset)
+		if !next.After(t) {
+			next = next.Add(24 * time.Hour)
+		}
+		return next
+	}
+}
+
+// timeRotatingWriter rotates the log file of a lumberjack.Logger on the first write after each
+// rotation time, so that each file only holds the logs written between two rotation times.
+type timeRotatingWriter struct {
+	mu       sync.Mutex
+	logger   *lumberjack.Logger
+	next     func(time.Time) time.Time
+	now      func() time.Time
+	rotateAt time.Time
+}
+
+func newTimeRotatingWriter(logger *lumberjack.Logger, next func(time.Time) time.Time, now func() time.Time) *timeRotatingWriter {
+	// An existing log file written before the last rotation time, e.g. before the collector restarted,
+	// is rotated on the first write.
+	last := now()
+	if info, err := os.Stat(logger.Filename); err == nil {
+		last = info.ModTime()
+	}
+	return &timeRotatingWriter{
+		logger:   logger,
+		next:     next,
+		now:      now,
+		rotateAt: next(last),
+	}
+}
+
+func (w *timeRotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if now := w.now(); !now.Before(w.rotateAt) {
+		w.rotateAt = w.next(now)
+		if err := w.logger.Rotate(); err != nil {
+			return 0, err
+		}
+	}
+	return w.logger.Write(p)
+}
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func TestNextRotation(t *testing.T) {
+	at := func(s string) time.Time {
+		tm, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return tm
+	}
+	tests := []struct {
+		name     string
+		interval string
+		offset   time.Duration
+		t        time.Time
+		want     time.Time
+	}{
+		{name: "hourly", interval: "hourly", t: at("2025-03-01T10:15:00Z"), want: at("2025-03-01T11:00:00Z")},
+		{name: "hourly on the hour", interval: "hourly", t: at("2025-03-01T10:00:00Z"), want: at("2025-03-01T11:00:00Z")},
+		{name: "hourly not UTC", interval: "hourly", t: at("2025-03-01T10:15:00+05:30"), want: at("2025-03-01T05:00:00Z")},
+		{name: "daily", interval: "daily", t: at("2025-03-01T10:15:00Z"), want: at("2025-03-02T00:00:00Z")},
+		{name: "daily before offset", interval: "daily", offset: 2 * time.Hour, t: at("2025-03-01T01:59:00Z"), want: at("2025-03-01T02:00:00Z")},
+		{name: "daily at offset", interval: "daily", offset: 2 * time.Hour, t: at("2025-03-01T02:00:00Z"), want: at("2025-03-02T02:00:00Z")},
+		{name: "daily not UTC", interval: "daily", offset: 2 * time.Hour, t: at("2025-03-01T01:00:00-05:00"), want: at("2025-03-02T02:00:00Z")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextRotation(tt.interval, tt.offset)(tt.t))
+		})
+	}
+}
+
+// fakeClock is a clock advanced by the tests.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = t
+}
+
+// readLogFiles returns the contents of the log files in dir, the rotated ones first.
+func readLogFiles(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	// The rotated files are named after the log file with the time of the rotation.
+	sort.Slice(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j]) || (len(names[i]) == len(names[j]) && names[i] < names[j])
+	})
+	var contents []string
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		contents = append(contents, string(data))
+	}
+	return contents
+}
+
+func TestTimeRotatingWriter(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{t: time.Date(2025, 3, 1, 23, 59, 0, 0, time.UTC)}
+	logger := &lumberjack.Logger{Filename: filepath.Join(dir, "collector.log")}
+	t.Cleanup(func() { require.NoError(t, logger.Close()) })
+	w := newTimeRotatingWriter(logger, nextRotation("daily", 0), clock.now)
+
+	_, err := w.Write([]byte("before\n"))
+	require.NoError(t, err)
+	clock.set(time.Date(2025, 3, 2, 0, 0, 1, 0, time.UTC))
+	_, err = w.Write([]byte("after\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("same day\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"before\n", "after\nsame day\n"}, readLogFiles(t, dir))
+}
+
+func TestTimeRotatingWriterExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "collector.log")
+	require.NoError(t, os.WriteFile(path, []byte("previous run\n"), 0o600))
+	lastWrite := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(path, lastWrite, lastWrite))
+
+	clock := &fakeClock{t: time.Date(2025, 3, 1, 13, 30, 0, 0, time.UTC)}
+	logger := &lumberjack.Logger{Filename: path}
+	t.Cleanup(func() { require.NoError(t, logger.Close()) })
+	w := newTimeRotatingWriter(logger, nextRotation("hourly", 0), clock.now)
+
+	// The file was last written before the rotation time at 13:00.
+	_, err := w.Write([]byte("restarted\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"previous run\n", "restarted\n"}, readLogFiles(t, dir))
+}
+
+func TestCreateLoggerTimeRotation(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 3, 1, 1, 59, 0, 0, time.UTC)}
+	now = clock.now
+	t.Cleanup(func() { now = time.Now })
+
+	dir := t.TempDir()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Encoding = "json"
+	cfg.Logs.OutputPaths = []string{filepath.Join(dir, "collector.log")}
+	cfg.Logs.ErrorOutputPaths = []string{"stderr"}
+	cfg.Logs.Rotation = &LogsRotationConfig{Interval: "daily", RotateAtUTC: "02:00"}
+	require.NoError(t, cfg.Logs.Rotation.Validate())
+
+	logger, shutdown, err := createLogger(context.Background(), telemetry.LoggerSettings{}, cfg, &logFiles{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, shutdown(context.Background()))
+	}()
+
+	logger.Info("before the rotation")
+	clock.set(time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC))
+	logger.Info("after the rotation")
+
+	contents := readLogFiles(t, dir)
+	require.Len(t, contents, 2)
+	assert.Contains(t, contents[0], "before the rotation")
+	assert.NotContains(t, contents[0], "after the rotation")
+	assert.Contains(t, contents[1], "after the rotation")
+}
+
+func TestCreateLoggerRotationPerOutput(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 3, 1, 1, 59, 0, 0, time.UTC)}
+	now = clock.now
+	t.Cleanup(func() { now = time.Now })
+
+	mainDir, hourlyDir := t.TempDir(), t.TempDir()
+	hourlyPath := filepath.Join(hourlyDir, "collector.log")
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Encoding = "json"
+	cfg.Logs.OutputPaths = []string{filepath.Join(mainDir, "collector.log"), hourlyPath}
+	cfg.Logs.ErrorOutputPaths = []string{"stderr"}
+	cfg.Logs.Rotation = &LogsRotationConfig{
+		MaxSizeMB: 10,
+		Outputs: map[string]LogsRotationConfig{
+			hourlyPath: {MaxSizeMB: 10, Interval: "hourly"},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	logger, shutdown, err := createLogger(context.Background(), telemetry.LoggerSettings{}, cfg, &logFiles{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, shutdown(context.Background()))
+	}()
+
+	logger.Info("before the rotation")
+	clock.set(time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC))
+	logger.Info("after the rotation")
+
+	// Only the output with the hourly interval is rotated.
+	assert.Len(t, readLogFiles(t, mainDir), 1)
+	assert.Len(t, readLogFiles(t, hourlyDir), 2)
+}
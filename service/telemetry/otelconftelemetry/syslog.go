@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// syslogFacilities are the codes of the syslog facilities by name.
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19, "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// syslogTimeout bounds the connection to the server and the writes of the messages, so that a server
+// not responding does not hold the logs. Once the connection failed, the messages are not sent until
+// the timeout elapsed, so that the logs written meanwhile do not wait for it again.
+var syslogTimeout = 5 * time.Second
+
+// syslogSeverity returns the syslog severity of the level.
+func syslogSeverity(level zapcore.Level) int {
+	switch {
+	case level <= zapcore.DebugLevel:
+		return 7 // debug
+	case level == zapcore.InfoLevel:
+		return 6 // informational
+	case level == zapcore.WarnLevel:
+		return 4 // warning
+	case level == zapcore.ErrorLevel:
+		return 3 // error
+	case level == zapcore.FatalLevel:
+		return 1 // alert
+	default:
+		return 2 // critical
+	}
+}
+
+// syslogSink is a logSink sending the logs to a syslog server, e.g. syslog://localhost:514?facility=local0
+// over UDP, or to the local syslog daemon, e.g. syslog:///dev/log. The optional query parameters are the
+// facility ("user" by default), the network ("udp" by default, or "unixgram" for a socket path), and the
+// tag of the messages (the name of the executable by default).
+type syslogSink struct {
+	network  string
+	addr     string
+	facility int
+	tag      string
+	hostname string
+
+	mu      sync.Mutex
+	conn    net.Conn
+	dialErr error
+	retryAt time.Time
+}
+
+var _ logSink = (*syslogSink)(nil)
+
+func newSyslogSink(u *url.URL) (*syslogSink, error) {
+	s := &syslogSink{
+		facility: syslogFacilities["user"],
+		tag:      filepath.Base(os.Args[0]),
+	}
+	s.hostname, _ = os.Hostname()
+	for name, values := range u.Query() {
+		value := values[0]
+		switch name {
+		case "facility":
+			facility, ok := syslogFacilities[value]
+			if !ok {
+				return nil, fmt.Errorf("unknown syslog facility %q in %q", value, u.Redacted())
+			}
+			s.facility = facility
+		case "network":
+			s.network = value
+		case "tag":
+			s.tag = value
+		default:
+			return nil, fmt.Errorf("unknown parameter %q in %q, must be facility, network or tag", name, u.Redacted())
+		}
+	}
+	switch {
+	case u.Host != "":
+		s.addr = u.Host
+		if u.Port() == "" {
+			s.addr = net.JoinHostPort(u.Hostname(), "514")
+		}
+		if s.network == "" {
+			s.network = "udp"
+		}
+	case u.Path != "":
+		s.addr = u.Path
+		if s.network == "" {
+			s.network = "unixgram"
+		}
+	default:
+		return nil, fmt.Errorf("missing syslog address in %q, e.g. syslog://localhost:514 or syslog:///dev/log", u.Redacted())
+	}
+	switch s.network {
+	case "udp", "tcp", "unix", "unixgram":
+	default:
+		return nil, fmt.Errorf("unsupported syslog network %q in %q, must be udp, tcp, unix or unixgram", s.network, u.Redacted())
+	}
+	return s, nil
+}
+
+func (s *syslogSink) Write(p []byte) (int, error) {
+	return s.WriteLevel(zapcore.InfoLevel, p)
+}
+
+// WriteLevel sends the log as a message with the facility of the sink and the severity of the level,
+// connecting to the server first if not connected, and once again if sending it over a stream failed.
+// Connecting and sending each take up to syslogTimeout.
+func (s *syslogSink) WriteLevel(level zapcore.Level, p []byte) (int, error) {
+	msg := fmt.Sprintf("<%d>%s %s %s[%d]: %s", s.facility*8+syslogSeverity(level),
+		now().Format(time.RFC3339), s.hostname, s.tag, os.Getpid(), strings.TrimRight(string(p), "\r\n"))
+	if s.network == "tcp" || s.network == "unix" {
+		msg += "\n"
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for attempt := 0; ; attempt++ {
+		if s.conn == nil {
+			if time.Now().Before(s.retryAt) {
+				return 0, s.dialErr
+			}
+			conn, err := net.DialTimeout(s.network, s.addr, syslogTimeout)
+			if err != nil {
+				s.dialErr, s.retryAt = err, time.Now().Add(syslogTimeout)
+				return 0, err
+			}
+			s.conn = conn
+		}
+		err := s.conn.SetWriteDeadline(time.Now().Add(syslogTimeout))
+		if err == nil {
+			_, err = s.conn.Write([]byte(msg))
+		}
+		if err == nil {
+			return len(p), nil
+		}
+		_ = s.conn.Close()
+		s.conn = nil
+		if attempt > 0 {
+			return 0, err
+		}
+	}
+}
+
+func (s *syslogSink) Sync() error {
+	return nil
+}
+
+// Reopen closes the connection to the server, connected again on the next write.
+func (s *syslogSink) Reopen() error {
+	return s.Close()
+}
+
+func (s *syslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func TestNewSyslogSink(t *testing.T) {
+	tests := []struct {
+		url      string
+		network  string
+		addr     string
+		facility int
+		tag      string
+		wantErr  string
+	}{
+		{url: "syslog://localhost", network: "udp", addr: "localhost:514", facility: 1},
+		{url: "syslog://127.0.0.1:1514?facility=local0&network=tcp&tag=otelcol", network: "tcp", addr: "127.0.0.1:1514", facility: 16, tag: "otelcol"},
+		{url: "syslog:///dev/log?facility=daemon", network: "unixgram", addr: "/dev/log", facility: 3},
+		{url: "syslog://", wantErr: `missing syslog address in "syslog:"`},
+		{url: "syslog://localhost?facility=local8", wantErr: `unknown syslog facility "local8"`},
+		{url: "syslog://localhost?network=icmp", wantErr: `unsupported syslog network "icmp"`},
+		{url: "syslog://localhost?level=debug", wantErr: `unknown parameter "level"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			require.NoError(t, err)
+			s, err := newSyslogSink(u)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				require.ErrorContains(t, checkLogSink(tt.url), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, checkLogSink(tt.url))
+			assert.Equal(t, tt.network, s.network)
+			assert.Equal(t, tt.addr, s.addr)
+			assert.Equal(t, tt.facility, s.facility)
+			if tt.tag != "" {
+				assert.Equal(t, tt.tag, s.tag)
+			}
+		})
+	}
+}
+
+func TestSyslogSinkUDP(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)}
+	now = clock.now
+	t.Cleanup(func() { now = time.Now })
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sink, err := openLogSink(fmt.Sprintf("syslog://%s?facility=local0&tag=otelcol", conn.LocalAddr()))
+	require.NoError(t, err)
+	defer sink.Close()
+
+	hostname, _ := os.Hostname()
+	for _, tt := range []struct {
+		level zapcore.Level
+		pri   int
+	}{
+		{level: zapcore.DebugLevel, pri: 135},
+		{level: zapcore.InfoLevel, pri: 134},
+		{level: zapcore.WarnLevel, pri: 132},
+		{level: zapcore.ErrorLevel, pri: 131},
+	} {
+		_, err = sink.WriteLevel(tt.level, []byte("Everything is ready\n"))
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("<%d>2025-03-01T02:00:00Z %s otelcol[%d]: Everything is ready", tt.pri, hostname, os.Getpid()), readPacket(t, conn))
+	}
+}
+
+func TestSyslogSinkTCPReopen(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	sink, err := openLogSink(fmt.Sprintf("syslog://%s?network=tcp", listener.Addr()))
+	require.NoError(t, err)
+	defer sink.Close()
+
+	for _, msg := range []string{"before the reopen", "after the reopen"} {
+		_, err = sink.Write([]byte(msg))
+		require.NoError(t, err)
+		// Each message is read from a new connection, the sink connecting again once reopened.
+		conn, err := listener.Accept()
+		require.NoError(t, err)
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(line, "<14>"), line)
+		assert.True(t, strings.HasSuffix(line, ": "+msg+"\n"), line)
+		require.NoError(t, conn.Close())
+		require.NoError(t, sink.Reopen())
+	}
+}
+
+func TestSyslogSinkWriteTimeout(t *testing.T) {
+	syslogTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { syslogTimeout = 5 * time.Second })
+
+	// The server accepts the connections, but never reads the messages.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	sink, err := openLogSink(fmt.Sprintf("syslog://%s?network=tcp", listener.Addr()))
+	require.NoError(t, err)
+	defer sink.Close()
+
+	msg := []byte(strings.Repeat("x", 16<<20))
+	start := time.Now()
+	_, err = sink.Write(msg)
+	require.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestSyslogSinkDialFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unixgram sockets are not supported on Windows")
+	}
+	syslogTimeout = time.Hour
+	t.Cleanup(func() { syslogTimeout = 5 * time.Second })
+
+	path := filepath.Join(t.TempDir(), "log")
+	u, err := url.Parse("syslog://" + path)
+	require.NoError(t, err)
+	sink, err := newSyslogSink(u)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	_, err = sink.Write([]byte("no server"))
+	require.Error(t, err)
+
+	conn, err := net.ListenPacket("unixgram", path)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The sink does not connect again until the timeout elapsed.
+	_, err = sink.Write([]byte("before the timeout"))
+	require.Error(t, err)
+	sink.retryAt = time.Time{}
+	_, err = sink.Write([]byte("after the timeout"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(readPacket(t, conn), ": after the timeout"))
+}
+
+func TestCreateLoggerSyslog(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.Encoding = "logfmt"
+	cfg.Logs.OutputPaths = []string{fmt.Sprintf("syslog://%s?facility=local0", conn.LocalAddr())}
+	// The logs sent to syslog are not rotated.
+	cfg.Logs.Rotation = &LogsRotationConfig{MaxSizeMB: 10}
+	require.NoError(t, cfg.Validate())
+
+	logger, shutdown, err := createLogger(context.Background(), telemetry.LoggerSettings{}, cfg, &logFiles{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, shutdown(context.Background()))
+	}()
+	logger.Debug("disabled")
+	logger.Warn("Exporting failed", zap.String("error", "timeout"))
+
+	msg := readPacket(t, conn)
+	assert.True(t, strings.HasPrefix(msg, "<132>"), msg)
+	assert.Contains(t, msg, `level=warn`)
+	assert.Contains(t, msg, `msg="Exporting failed"`)
+	assert.Contains(t, msg, `error=timeout`)
+}
+
+func TestCreateLoggerSyslogBuffer(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Logs.OutputPaths = []string{fmt.Sprintf("syslog://%s?facility=local0", conn.LocalAddr())}
+	cfg.Logs.Buffer = &LogsBufferConfig{FlushInterval: time.Hour}
+	require.NoError(t, cfg.Validate())
+
+	logger, shutdown, err := createLogger(context.Background(), telemetry.LoggerSettings{}, cfg, &logFiles{})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, shutdown(context.Background()))
+	}()
+	logger.Warn("Exporting failed")
+
+	// The buffered logs are sent once synced, with the severity of their level.
+	require.NoError(t, logger.Sync())
+	msg := readPacket(t, conn)
+	assert.True(t, strings.HasPrefix(msg, "<132>"), msg)
+	assert.Contains(t, msg, "Exporting failed")
+}
+
+func readPacket(t *testing.T, conn net.PacketConn) string {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 4096)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	return string(buf[:n])
+}
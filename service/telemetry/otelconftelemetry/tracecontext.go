@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry // import "go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// The keys of the fields of the span context added to the logs.
+const (
+	traceIDKey    = "trace_id"
+	spanIDKey     = "span_id"
+	traceFlagsKey = "trace_flags"
+)
+
+// traceContextCore wraps a Zap core writing the logs, replacing the fields holding a context.Context,
+// e.g. zap.Any("ctx", ctx), by the trace_id, span_id and trace_flags fields of the span context of the
+// context, if valid, in order to correlate the logs with the traces. The context fields are used as is
+// by the core copying the logs to the LoggerProvider, so the core must wrap the cores writing the logs
+// rather than the core of the logger: it must be enabled for all the logs its Check is called for.
+type traceContextCore struct {
+	zapcore.Core
+}
+
+func newTraceContextCore(c zapcore.Core) zapcore.Core {
+	return &traceContextCore{Core: c}
+}
+
+func (c *traceContextCore) With(fields []zapcore.Field) zapcore.Core {
+	return &traceContextCore{Core: c.Core.With(traceContextFields(fields))}
+}
+
+func (c *traceContextCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *traceContextCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, traceContextFields(fields))
+}
+
+// traceContextFields returns the fields with the fields holding a context.Context replaced by the
+// fields of its span context, or removed if it has no valid span context.
+func traceContextFields(fields []zapcore.Field) []zapcore.Field {
+	i := 0
+	for ; i < len(fields); i++ {
+		if _, ok := fields[i].Interface.(context.Context); ok {
+			break
+		}
+	}
+	if i == len(fields) {
+		return fields
+	}
+	replaced := make([]zapcore.Field, 0, len(fields)+2)
+	replaced = append(replaced, fields[:i]...)
+	for _, f := range fields[i:] {
+		ctx, ok := f.Interface.(context.Context)
+		if !ok {
+			replaced = append(replaced, f)
+			continue
+		}
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			replaced = append(replaced,
+				zap.String(traceIDKey, sc.TraceID().String()),
+				zap.String(spanIDKey, sc.SpanID().String()),
+				zap.String(traceFlagsKey, sc.TraceFlags().String()),
+			)
+		}
+	}
+	return replaced
+}
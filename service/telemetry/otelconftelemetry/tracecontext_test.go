@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelconftelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestTraceContextCore(t *testing.T) {
+	core, observed := observer.New(zapcore.InfoLevel)
+	logger := zap.New(newTraceContextCore(core))
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+		SpanID:     trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	logger.Info("in a span", zap.String("key", "value"), zap.Any("ctx", ctx))
+	logger.Info("without span", zap.Any("ctx", context.Background()))
+	logger.With(zap.Any("ctx", ctx)).Info("with context")
+	logger.Debug("disabled", zap.Any("ctx", ctx))
+
+	entries := observed.All()
+	require.Len(t, entries, 3)
+	assert.Equal(t, map[string]any{
+		"key":         "value",
+		"trace_id":    "0102030405060708090a0b0c0d0e0f10",
+		"span_id":     "0102030405060708",
+		"trace_flags": "01",
+	}, entries[0].ContextMap())
+	assert.Empty(t, entries[1].ContextMap())
+	assert.Equal(t, "0102030405060708", entries[2].ContextMap()["span_id"])
+}
+
+func TestTraceContextFields(t *testing.T) {
+	fields := []zapcore.Field{zap.String("key", "value")}
+	// The fields without context are returned as is.
+	assert.Same(t, &fields[0], &traceContextFields(fields)[0])
+}
@@ -97,6 +97,11 @@ type Factory interface {
 	// the `Unwrap() trace.TracerProvider` method to grant components access to the underlying SDK.
 	CreateTracerProvider(context.Context, TracerSettings, component.Config) (TracerProvider, error)
 
+	// ReopenLogs closes and reopens the log files written by the loggers created by
+	// CreateLogger and not shut down yet, e.g. after an external tool like logrotate
+	// moved them.
+	ReopenLogs(context.Context) error
+
 	// unexportedFactoryFunc is used to prevent external implementations of Factory.
 	unexportedFactoryFunc()
 }
@@ -130,6 +135,7 @@ type factory struct {
 	createLoggerFunc         CreateLoggerFunc
 	createMeterProviderFunc  CreateMeterProviderFunc
 	createTracerProviderFunc CreateTracerProviderFunc
+	reopenLogsFunc           ReopenLogsFunc
 }
 
 // NewFactory returns a Factory.
@@ -186,6 +192,17 @@ func WithCreateTracerProvider(createTracerProvider CreateTracerProviderFunc) Fac
 // CreateTracerProviderFunc is the equivalent of Factory.CreateTracerProvider.
 type CreateTracerProviderFunc func(context.Context, TracerSettings, component.Config) (TracerProvider, error)
 
+// WithReopenLogs overrides the default ReopenLogs implementation,
+// which does nothing.
+func WithReopenLogs(reopenLogs ReopenLogsFunc) FactoryOption {
+	return factoryOptionFunc(func(f *factory) {
+		f.reopenLogsFunc = reopenLogs
+	})
+}
+
+// ReopenLogsFunc is the equivalent of Factory.ReopenLogs.
+type ReopenLogsFunc func(context.Context) error
+
 func (*factory) unexportedFactoryFunc() {}
 
 func (f *factory) CreateResource(ctx context.Context, settings Settings, cfg component.Config) (pcommon.Resource, error) {
@@ -218,6 +235,13 @@ func (f *factory) CreateTracerProvider(ctx context.Context, settings TracerSetti
 	return f.createTracerProviderFunc(ctx, settings, cfg)
 }
 
+func (f *factory) ReopenLogs(ctx context.Context) error {
+	if f.reopenLogsFunc == nil {
+		return nil
+	}
+	return f.reopenLogsFunc(ctx)
+}
+
 type noopMeterProvider struct {
 	noopmetric.MeterProvider
 	component.ShutdownFunc
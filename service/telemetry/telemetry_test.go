@@ -45,6 +45,8 @@ func TestNewFactory_Defaults(t *testing.T) {
 	tracerProvider, err := factory.CreateTracerProvider(context.Background(), TracerSettings{}, nil)
 	require.NoError(t, err)
 	assert.Equal(t, noopTracerProvider{TracerProvider: nooptrace.NewTracerProvider()}, tracerProvider)
+
+	require.NoError(t, factory.ReopenLogs(context.Background()))
 }
 
 func TestNewFactory_Options(t *testing.T) {
@@ -161,3 +163,18 @@ func TestNewFactory_CreateTracerProvider(t *testing.T) {
 	require.EqualError(t, err, "not implemented")
 	assert.Equal(t, &dummyTracerProvider, tracerProvider)
 }
+
+func TestNewFactory_ReopenLogs(t *testing.T) {
+	type contextKey struct{}
+	ctx := context.WithValue(context.Background(), contextKey{}, 123)
+
+	factory := NewFactory(nil, WithReopenLogs(
+		func(ctx context.Context) error {
+			assert.Equal(t, 123, ctx.Value(contextKey{}))
+			return errors.New("not implemented")
+		},
+	))
+	require.NotNil(t, factory)
+
+	require.EqualError(t, factory.ReopenLogs(ctx), "not implemented")
+}
@@ -46,6 +46,7 @@ require (
 	go.opentelemetry.io/collector/confmap v1.43.0 // indirect
 	go.opentelemetry.io/collector/featuregate v1.43.0 // indirect
 	go.opentelemetry.io/collector/internal/telemetry v0.137.0 // indirect
+	go.opentelemetry.io/collector/pipeline v1.43.0 // indirect
 	go.opentelemetry.io/contrib/bridges/otelzap v0.13.0 // indirect
 	go.opentelemetry.io/contrib/otelconf v0.18.0 // indirect
 	go.opentelemetry.io/otel v1.38.0 // indirect
@@ -187,3 +188,9 @@ replace go.opentelemetry.io/collector/connector/connectortest => ../../../connec
 replace go.opentelemetry.io/collector/component/componenttest => ../../../component/componenttest
 
 replace go.opentelemetry.io/collector/component/componentstatus => ../../../component/componentstatus
+
+replace go.opentelemetry.io/collector/config/confignet => ../../../config/confignet
+
+replace go.opentelemetry.io/collector/internal/memorylimiter => ../../../internal/memorylimiter
+
+replace go.opentelemetry.io/collector/internal/panicguard => ../../../internal/panicguard
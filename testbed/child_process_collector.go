@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed // import "go.opentelemetry.io/collector/testbed"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/process"
+)
+
+const (
+	// resourceCheckInterval is the interval at which the resources used by a child process are sampled.
+	resourceCheckInterval = time.Second
+	// stopTimeout is the time a child process is given to exit once interrupted, before it is killed.
+	stopTimeout = 10 * time.Second
+)
+
+// childProcessCollector runs the collector binary of a build as a child process.
+type childProcessCollector struct {
+	path       string
+	args       []string
+	configFile string
+	logFile    *os.File
+
+	cmd    *exec.Cmd
+	waitCh chan error
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+
+	mu        sync.Mutex
+	resources ResourceConsumption
+	cpuSum    float64
+	ramSum    uint64
+	samples   int
+}
+
+var _ OtelcolRunner = (*childProcessCollector)(nil)
+
+// NewChildProcessCollector returns an OtelcolRunner running the collector binary at path with the args,
+// to which the --config flag is added. The output of the collector is written to the collector.log file
+// of the dir, which also holds its configuration, and the resources it uses are sampled every second.
+func NewChildProcessCollector(dir, path string, args ...string) OtelcolRunner {
+	return &childProcessCollector{
+		path:       path,
+		args:       args,
+		configFile: filepath.Join(dir, "config.yaml"),
+	}
+}
+
+func (cpc *childProcessCollector) PrepareConfig(configStr string) error {
+	return os.WriteFile(cpc.configFile, []byte(configStr), 0o600)
+}
+
+func (cpc *childProcessCollector) Start() error {
+	logFile, err := os.Create(filepath.Join(filepath.Dir(cpc.configFile), "collector.log"))
+	if err != nil {
+		return err
+	}
+	cpc.logFile = logFile
+
+	//nolint:gosec // The binary and its arguments are provided by the test.
+	cmd := exec.Command(cpc.path, append(cpc.args, "--config", cpc.configFile)...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err = cmd.Start(); err != nil {
+		return errors.Join(err, logFile.Close())
+	}
+	cpc.cmd = cmd
+	cpc.waitCh = make(chan error, 1)
+	go func() {
+		cpc.waitCh <- cmd.Wait()
+	}()
+
+	proc, err := process.NewProcess(int32(cmd.Process.Pid)) //nolint:gosec // The pid fits in an int32.
+	if err != nil {
+		return errors.Join(err, cpc.Stop())
+	}
+	cpc.stopCh = make(chan struct{})
+	cpc.wg.Add(1)
+	go func() {
+		defer cpc.wg.Done()
+		cpc.monitor(proc)
+	}()
+	return nil
+}
+
+func (cpc *childProcessCollector) monitor(proc *process.Process) {
+	ticker := time.NewTicker(resourceCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-cpc.stopCh:
+			return
+		case <-ticker.C:
+		}
+		// The CPU usage is measured since the previous call.
+		cpu, err := proc.Percent(0)
+		if err != nil {
+			continue
+		}
+		mem, err := proc.MemoryInfo()
+		if err != nil {
+			continue
+		}
+		ram := uint32(mem.RSS >> 20) //nolint:gosec // The resident memory in MiB fits in an uint32.
+
+		cpc.mu.Lock()
+		cpc.samples++
+		cpc.cpuSum += cpu
+		cpc.ramSum += uint64(ram)
+		cpc.resources.CPUPercentMax = max(cpc.resources.CPUPercentMax, cpu)
+		cpc.resources.RAMMiBMax = max(cpc.resources.RAMMiBMax, ram)
+		cpc.resources.CPUPercentAvg = cpc.cpuSum / float64(cpc.samples)
+		cpc.resources.RAMMiBAvg = uint32(cpc.ramSum / uint64(cpc.samples)) //nolint:gosec // The average is at most the maximum.
+		cpc.mu.Unlock()
+	}
+}
+
+func (cpc *childProcessCollector) Stop() error {
+	if cpc.cmd == nil {
+		return nil
+	}
+	if cpc.stopCh != nil {
+		close(cpc.stopCh)
+		cpc.wg.Wait()
+		cpc.stopCh = nil
+	}
+
+	var errs error
+	// Interrupting a process is not supported on Windows, where it is killed instead.
+	killed := false
+	if err := cpc.cmd.Process.Signal(os.Interrupt); err != nil {
+		errs = errors.Join(errs, cpc.cmd.Process.Kill())
+		killed = true
+	}
+	select {
+	case err := <-cpc.waitCh:
+		if !killed {
+			errs = errors.Join(errs, err)
+		}
+	case <-time.After(stopTimeout):
+		errs = errors.Join(errs, fmt.Errorf("collector did not exit %v after being interrupted", stopTimeout), cpc.cmd.Process.Kill())
+		<-cpc.waitCh
+	}
+	cpc.cmd = nil
+	return errors.Join(errs, cpc.logFile.Close())
+}
+
+func (cpc *childProcessCollector) ResourceConsumption() ResourceConsumption {
+	cpc.mu.Lock()
+	defer cpc.mu.Unlock()
+	return cpc.resources
+}
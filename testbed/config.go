@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed // import "go.opentelemetry.io/collector/testbed"
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+// ProcessorConfig is the configuration of a processor of the pipeline of the collector under test.
+type ProcessorConfig struct {
+	// Name is the component ID of the processor.
+	Name string
+	// Body is the YAML configuration of the processor, indented by 4 spaces.
+	Body string
+}
+
+// GetAvailablePort returns a port of the loopback interface available to listen to.
+func GetAvailablePort(tb testing.TB) int {
+	_, portStr, err := net.SplitHostPort(testutil.GetAvailableLocalAddress(tb))
+	require.NoError(tb, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(tb, err)
+	return port
+}
+
+// CreateConfigYAML returns the configuration of a collector receiving the data of the sender, processing
+// it with the processors in order and exporting it to the receiver. The internal metrics of the collector
+// are disabled so that they do not consume its resources.
+func CreateConfigYAML(sender DataSender, receiver DataReceiver, processors []ProcessorConfig) (string, error) {
+	var signal string
+	switch sender.(type) {
+	case TraceDataSender:
+		signal = "traces"
+	case MetricDataSender:
+		signal = "metrics"
+	case LogDataSender:
+		signal = "logs"
+	default:
+		return "", fmt.Errorf("unsupported data sender %T", sender)
+	}
+
+	var processorsSections strings.Builder
+	processorNames := make([]string, 0, len(processors))
+	for _, p := range processors {
+		processorsSections.WriteString("\n  " + p.Name + ":")
+		if p.Body != "" {
+			processorsSections.WriteString("\n" + p.Body)
+		}
+		processorNames = append(processorNames, p.Name)
+	}
+
+	return fmt.Sprintf(`
+receivers:%s
+exporters:%s
+processors:%s
+service:
+  telemetry:
+    metrics:
+      level: none
+    logs:
+      level: warn
+  pipelines:
+    %s:
+      receivers: [%s]
+      processors: [%s]
+      exporters: [%s]
+`,
+		sender.GenConfigYAMLStr(),
+		receiver.GenConfigYAMLStr(),
+		processorsSections.String(),
+		signal,
+		sender.ProtocolName(),
+		strings.Join(processorNames, ","),
+		receiver.ProtocolName(),
+	), nil
+}
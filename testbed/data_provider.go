@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed // import "go.opentelemetry.io/collector/testbed"
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// SequenceNumberKey is the attribute of the sequence number of each data item generated by the
+// DataProvider returned by NewPerfTestDataProvider, used to validate that every item is delivered once.
+const SequenceNumberKey = "testbed.sequence_number"
+
+// DataProvider generates the batches of data sent by a LoadGenerator. It must be safe for concurrent use.
+type DataProvider interface {
+	// GenerateTraces returns a batch of spans.
+	GenerateTraces() ptrace.Traces
+	// GenerateMetrics returns a batch of metric data points.
+	GenerateMetrics() pmetric.Metrics
+	// GenerateLogs returns a batch of log records.
+	GenerateLogs() plog.Logs
+}
+
+type perfTestDataProvider struct {
+	options  LoadOptions
+	sequence atomic.Int64
+}
+
+// NewPerfTestDataProvider returns a DataProvider generating batches of options.ItemsPerBatch items, each
+// with the options.Attributes and a unique SequenceNumberKey attribute.
+func NewPerfTestDataProvider(options LoadOptions) DataProvider {
+	return &perfTestDataProvider{options: options.withDefaults()}
+}
+
+// nextSequenceNumber reserves the sequence numbers of a batch and returns the first one.
+func (dp *perfTestDataProvider) nextSequenceNumber() int64 {
+	n := int64(dp.options.ItemsPerBatch)
+	return dp.sequence.Add(n) - n
+}
+
+func (dp *perfTestDataProvider) fillAttributes(attrs pcommon.Map, seq int64) {
+	for k, v := range dp.options.Attributes {
+		attrs.PutStr(k, v)
+	}
+	attrs.PutInt(SequenceNumberKey, seq)
+}
+
+func (dp *perfTestDataProvider) GenerateTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.EnsureCapacity(dp.options.ItemsPerBatch)
+	first := dp.nextSequenceNumber()
+	now := time.Now()
+	for i := range dp.options.ItemsPerBatch {
+		seq := first + int64(i)
+		span := spans.AppendEmpty()
+		span.SetTraceID(traceID(seq))
+		span.SetSpanID(spanID(seq))
+		span.SetName("load-generator-span-" + strconv.FormatInt(seq, 10))
+		span.SetKind(ptrace.SpanKindClient)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(now))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(now.Add(time.Millisecond)))
+		dp.fillAttributes(span.Attributes(), seq)
+	}
+	return td
+}
+
+func (dp *perfTestDataProvider) GenerateMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("load_generator_gauge")
+	metric.SetUnit("1")
+	dps := metric.SetEmptyGauge().DataPoints()
+	dps.EnsureCapacity(dp.options.ItemsPerBatch)
+	first := dp.nextSequenceNumber()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for i := range dp.options.ItemsPerBatch {
+		seq := first + int64(i)
+		dataPoint := dps.AppendEmpty()
+		dataPoint.SetTimestamp(now)
+		dataPoint.SetIntValue(seq)
+		dp.fillAttributes(dataPoint.Attributes(), seq)
+	}
+	return md
+}
+
+func (dp *perfTestDataProvider) GenerateLogs() plog.Logs {
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.EnsureCapacity(dp.options.ItemsPerBatch)
+	first := dp.nextSequenceNumber()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for i := range dp.options.ItemsPerBatch {
+		seq := first + int64(i)
+		record := records.AppendEmpty()
+		record.SetTimestamp(now)
+		record.SetSeverityNumber(plog.SeverityNumberInfo)
+		record.SetSeverityText("INFO")
+		record.Body().SetStr("Load generator log record " + strconv.FormatInt(seq, 10))
+		dp.fillAttributes(record.Attributes(), seq)
+	}
+	return ld
+}
+
+func traceID(seq int64) pcommon.TraceID {
+	var id pcommon.TraceID
+	putSequenceNumber(id[8:], seq)
+	id[0] = 1
+	return id
+}
+
+func spanID(seq int64) pcommon.SpanID {
+	var id pcommon.SpanID
+	putSequenceNumber(id[:], seq)
+	id[0] |= 0x80
+	return id
+}
+
+func putSequenceNumber(b []byte, seq int64) {
+	for i := range 8 {
+		b[7-i] = byte(seq >> (8 * i))
+	}
+}
+
+// sequenceNumber returns the SequenceNumberKey attribute of an item, if any.
+func sequenceNumber(attrs pcommon.Map) (int64, bool) {
+	v, ok := attrs.Get(SequenceNumberKey)
+	if !ok || v.Type() != pcommon.ValueTypeInt {
+		return 0, false
+	}
+	return v.Int(), true
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerfTestDataProvider(t *testing.T) {
+	dp := NewPerfTestDataProvider(LoadOptions{ItemsPerBatch: 3, Attributes: map[string]string{"key": "value"}})
+	mb := NewMockBackend(nil)
+	mb.EnableRecording()
+
+	td := dp.GenerateTraces()
+	require.Equal(t, 3, td.SpanCount())
+	span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1)
+	assert.False(t, span.TraceID().IsEmpty())
+	assert.False(t, span.SpanID().IsEmpty())
+	v, ok := span.Attributes().Get("key")
+	require.True(t, ok)
+	assert.Equal(t, "value", v.Str())
+	require.NoError(t, mb.ConsumeTraces(context.Background(), td))
+
+	md := dp.GenerateMetrics()
+	require.Equal(t, 3, md.DataPointCount())
+	require.NoError(t, mb.ConsumeMetrics(context.Background(), md))
+
+	ld := dp.GenerateLogs()
+	require.Equal(t, 3, ld.LogRecordCount())
+	require.NoError(t, mb.ConsumeLogs(context.Background(), ld))
+
+	assert.Equal(t, uint64(9), mb.DataItemsReceived())
+	assert.Equal(t, map[int64]int{0: 1, 1: 1, 2: 1, 3: 1, 4: 1, 5: 1, 6: 1, 7: 1, 8: 1}, mb.SequenceNumbers())
+}
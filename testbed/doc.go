@@ -0,0 +1,12 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package testbed runs standardized load and correctness tests against a collector: a LoadGenerator
+// sends data through a DataSender to the collector under test, which exports it to a MockBackend
+// listening with a DataReceiver. A TestCase orchestrates them, validates the data received by the
+// backend and checks the resources used by the collector.
+//
+// The collector under test runs either in the test process with the factories of a custom build, see
+// NewInProcessCollector, or as a child process running the binary of the build, see
+// NewChildProcessCollector.
+package testbed // import "go.opentelemetry.io/collector/testbed"
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed // import "go.opentelemetry.io/collector/testbed"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
+	"go.opentelemetry.io/collector/otelcol"
+)
+
+// startTimeout is the time a collector is given to be running once started.
+const startTimeout = 10 * time.Second
+
+// inProcessCollector runs the collector in the test process.
+type inProcessCollector struct {
+	factories func() (otelcol.Factories, error)
+	configStr string
+
+	col   *otelcol.Collector
+	runCh chan error
+}
+
+var _ OtelcolRunner = (*inProcessCollector)(nil)
+
+// NewInProcessCollector returns an OtelcolRunner running a collector built with the factories in the
+// test process. The resources it uses are not measured since they cannot be told apart from the ones
+// of the test.
+func NewInProcessCollector(factories func() (otelcol.Factories, error)) OtelcolRunner {
+	return &inProcessCollector{factories: factories}
+}
+
+func (ipc *inProcessCollector) PrepareConfig(configStr string) error {
+	ipc.configStr = configStr
+	return nil
+}
+
+func (ipc *inProcessCollector) Start() error {
+	col, err := otelcol.NewCollector(otelcol.CollectorSettings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: ipc.factories,
+		ConfigProviderSettings: otelcol.ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:              []string{"yaml:" + ipc.configStr},
+				ProviderFactories: []confmap.ProviderFactory{yamlprovider.NewFactory()},
+			},
+		},
+		// The test process must not exit on the signals the collector handles.
+		DisableGracefulShutdown: true,
+		// The gRPC logger is global, and used by the senders and receivers of the test.
+		SkipSettingGRPCLogger: true,
+	})
+	if err != nil {
+		return err
+	}
+	ipc.col = col
+	ipc.runCh = make(chan error, 1)
+	go func() {
+		ipc.runCh <- col.Run(context.Background())
+	}()
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(startTimeout)
+	for {
+		select {
+		case err = <-ipc.runCh:
+			ipc.col = nil
+			return errors.Join(errors.New("collector exited while starting"), err)
+		case <-timeout:
+			return errors.Join(fmt.Errorf("collector not running after %v", startTimeout), ipc.Stop())
+		case <-ticker.C:
+			if col.GetState() == otelcol.StateRunning {
+				return nil
+			}
+		}
+	}
+}
+
+func (ipc *inProcessCollector) Stop() error {
+	if ipc.col == nil {
+		return nil
+	}
+	ipc.col.Shutdown()
+	ipc.col = nil
+	return <-ipc.runCh
+}
+
+func (ipc *inProcessCollector) ResourceConsumption() ResourceConsumption {
+	return ResourceConsumption{}
+}
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed // import "go.opentelemetry.io/collector/testbed"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// LoadOptions configures the load generated by a LoadGenerator.
+type LoadOptions struct {
+	// DataItemsPerSecond is the number of spans, metric data points or log records to send per second.
+	DataItemsPerSecond int
+	// ItemsPerBatch is the number of items of each batch sent. The default is 10.
+	ItemsPerBatch int
+	// Parallel is the number of goroutines sending the batches concurrently. The default is 1.
+	Parallel int
+	// Attributes are added to every item generated.
+	Attributes map[string]string
+}
+
+func (o LoadOptions) withDefaults() LoadOptions {
+	if o.ItemsPerBatch <= 0 {
+		o.ItemsPerBatch = 10
+	}
+	if o.Parallel <= 0 {
+		o.Parallel = 1
+	}
+	return o
+}
+
+// retryInterval is the time waited by the LoadGenerator before sending again a batch refused with a
+// retryable error.
+const retryInterval = 10 * time.Millisecond
+
+// LoadGenerator sends the data generated by a DataProvider through a DataSender at a constant rate.
+type LoadGenerator struct {
+	sender   DataSender
+	provider DataProvider
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+
+	dataItemsSent      atomic.Uint64
+	permanentErrors    atomic.Uint64
+	nonPermanentErrors atomic.Uint64
+}
+
+// NewLoadGenerator returns a LoadGenerator sending through the sender, which must be a TraceDataSender,
+// a MetricDataSender or a LogDataSender, the data generated by the provider.
+func NewLoadGenerator(provider DataProvider, sender DataSender) (*LoadGenerator, error) {
+	switch sender.(type) {
+	case TraceDataSender, MetricDataSender, LogDataSender:
+	default:
+		return nil, fmt.Errorf("unsupported data sender %T", sender)
+	}
+	return &LoadGenerator{
+		sender:   sender,
+		provider: provider,
+		stopCh:   make(chan struct{}),
+	}, nil
+}
+
+// Start starts generating the load. The batches refused with a retryable error are sent again until they
+// are accepted or the LoadGenerator is stopped, while the ones rejected with a permanent error are
+// counted by PermanentErrors and not sent again.
+func (lg *LoadGenerator) Start(options LoadOptions) {
+	options = options.withDefaults()
+	if options.DataItemsPerSecond <= 0 {
+		return
+	}
+	// Each goroutine sends its share of the batches per second.
+	interval := time.Duration(float64(time.Second) * float64(options.ItemsPerBatch*options.Parallel) / float64(options.DataItemsPerSecond))
+	for range options.Parallel {
+		lg.wg.Add(1)
+		go func() {
+			defer lg.wg.Done()
+			lg.generate(interval)
+		}()
+	}
+}
+
+func (lg *LoadGenerator) generate(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-lg.stopCh:
+			return
+		case <-ticker.C:
+			lg.sendBatch()
+		}
+	}
+}
+
+func (lg *LoadGenerator) sendBatch() {
+	var send func(context.Context) error
+	var numItems int
+	switch s := lg.sender.(type) {
+	case TraceDataSender:
+		td := lg.provider.GenerateTraces()
+		numItems = td.SpanCount()
+		send = func(ctx context.Context) error { return s.ConsumeTraces(ctx, td) }
+	case MetricDataSender:
+		md := lg.provider.GenerateMetrics()
+		numItems = md.DataPointCount()
+		send = func(ctx context.Context) error { return s.ConsumeMetrics(ctx, md) }
+	case LogDataSender:
+		ld := lg.provider.GenerateLogs()
+		numItems = ld.LogRecordCount()
+		send = func(ctx context.Context) error { return s.ConsumeLogs(ctx, ld) }
+	}
+
+	for {
+		err := send(context.Background())
+		switch {
+		case err == nil:
+			lg.dataItemsSent.Add(uint64(numItems))
+			return
+		case consumererror.IsPermanent(err):
+			lg.permanentErrors.Add(uint64(numItems))
+			return
+		}
+		lg.nonPermanentErrors.Add(uint64(numItems))
+		select {
+		case <-lg.stopCh:
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// Stop stops generating the load and waits for the batches being sent. The sender is flushed.
+func (lg *LoadGenerator) Stop() {
+	lg.stopOnce.Do(func() {
+		close(lg.stopCh)
+		lg.wg.Wait()
+		lg.sender.Flush()
+	})
+}
+
+// DataItemsSent returns the number of items accepted by the collector.
+func (lg *LoadGenerator) DataItemsSent() uint64 {
+	return lg.dataItemsSent.Load()
+}
+
+// PermanentErrors returns the number of items rejected by the collector with a permanent error.
+func (lg *LoadGenerator) PermanentErrors() uint64 {
+	return lg.permanentErrors.Load()
+}
+
+// NonPermanentErrors returns the number of items refused by the collector with a retryable error, each
+// time they were sent.
+func (lg *LoadGenerator) NonPermanentErrors() uint64 {
+	return lg.nonPermanentErrors.Load()
+}
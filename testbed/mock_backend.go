@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed // import "go.opentelemetry.io/collector/testbed"
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// DecisionFunc decides whether the MockBackend accepts the data it receives, by returning nil, or
+// rejects it with the returned error, e.g. to check how the collector handles the errors of its backend.
+type DecisionFunc func() error
+
+// MockBackend is the backend of the collector under test, counting the items it receives through its
+// DataReceiver and, once recording is enabled, the times it received each sequence number.
+type MockBackend struct {
+	receiver DataReceiver
+	decision DecisionFunc
+
+	dataItemsReceived atomic.Uint64
+
+	mu              sync.Mutex
+	isRecording     bool
+	sequenceNumbers map[int64]int
+}
+
+var (
+	_ consumer.Traces  = (*MockBackend)(nil)
+	_ consumer.Metrics = (*MockBackend)(nil)
+	_ consumer.Logs    = (*MockBackend)(nil)
+)
+
+// NewMockBackend returns a MockBackend receiving the data with the receiver.
+func NewMockBackend(receiver DataReceiver) *MockBackend {
+	return &MockBackend{
+		receiver:        receiver,
+		sequenceNumbers: map[int64]int{},
+	}
+}
+
+// WithDecisionFunc sets the DecisionFunc of the backend. It must be called before Start.
+func (mb *MockBackend) WithDecisionFunc(decision DecisionFunc) {
+	mb.decision = decision
+}
+
+// Start starts receiving the data.
+func (mb *MockBackend) Start() error {
+	return mb.receiver.Start(mb, mb, mb)
+}
+
+// Stop stops receiving the data.
+func (mb *MockBackend) Stop() error {
+	return mb.receiver.Stop()
+}
+
+// EnableRecording starts recording the sequence numbers of the items received.
+func (mb *MockBackend) EnableRecording() {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.isRecording = true
+}
+
+// DataItemsReceived returns the number of items accepted by the backend.
+func (mb *MockBackend) DataItemsReceived() uint64 {
+	return mb.dataItemsReceived.Load()
+}
+
+// SequenceNumbers returns the number of times each recorded sequence number was accepted by the backend.
+func (mb *MockBackend) SequenceNumbers() map[int64]int {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	return maps.Clone(mb.sequenceNumbers)
+}
+
+func (mb *MockBackend) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (mb *MockBackend) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
+	return mb.consume(td.SpanCount(), func(record func(pcommon.Map)) {
+		for _, rs := range td.ResourceSpans().All() {
+			for _, ss := range rs.ScopeSpans().All() {
+				for _, span := range ss.Spans().All() {
+					record(span.Attributes())
+				}
+			}
+		}
+	})
+}
+
+func (mb *MockBackend) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	return mb.consume(md.DataPointCount(), func(record func(pcommon.Map)) {
+		for _, rm := range md.ResourceMetrics().All() {
+			for _, sm := range rm.ScopeMetrics().All() {
+				for _, m := range sm.Metrics().All() {
+					forEachDataPointAttributes(m, record)
+				}
+			}
+		}
+	})
+}
+
+func (mb *MockBackend) ConsumeLogs(_ context.Context, ld plog.Logs) error {
+	return mb.consume(ld.LogRecordCount(), func(record func(pcommon.Map)) {
+		for _, rl := range ld.ResourceLogs().All() {
+			for _, sl := range rl.ScopeLogs().All() {
+				for _, lr := range sl.LogRecords().All() {
+					record(lr.Attributes())
+				}
+			}
+		}
+	})
+}
+
+// consume accepts or rejects the numItems items, calling forEach to record their sequence numbers if
+// recording is enabled.
+func (mb *MockBackend) consume(numItems int, forEach func(record func(pcommon.Map))) error {
+	if mb.decision != nil {
+		if err := mb.decision(); err != nil {
+			return err
+		}
+	}
+	mb.dataItemsReceived.Add(uint64(numItems))
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	if !mb.isRecording {
+		return nil
+	}
+	forEach(func(attrs pcommon.Map) {
+		if seq, ok := sequenceNumber(attrs); ok {
+			mb.sequenceNumbers[seq]++
+		}
+	})
+	return nil
+}
+
+func forEachDataPointAttributes(m pmetric.Metric, record func(pcommon.Map)) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for _, dp := range m.Gauge().DataPoints().All() {
+			record(dp.Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for _, dp := range m.Sum().DataPoints().All() {
+			record(dp.Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for _, dp := range m.Histogram().DataPoints().All() {
+			record(dp.Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for _, dp := range m.ExponentialHistogram().DataPoints().All() {
+			record(dp.Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for _, dp := range m.Summary().DataPoints().All() {
+			record(dp.Attributes())
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed // import "go.opentelemetry.io/collector/testbed"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// DataReceiver receives the data exported by the collector under test, on behalf of a MockBackend.
+type DataReceiver interface {
+	// Start starts the receiver, passing the data it receives to the consumers.
+	Start(tc consumer.Traces, mc consumer.Metrics, lc consumer.Logs) error
+	// Stop stops the receiver.
+	Stop() error
+	// GenConfigYAMLStr returns the configuration of the exporter of the collector sending the data to
+	// the receiver, to be inserted under the exporters key of the collector configuration.
+	GenConfigYAMLStr() string
+	// ProtocolName returns the component ID of the exporter of the collector sending the data.
+	ProtocolName() string
+}
+
+// otlpDataReceiver receives the data with an OTLP receiver.
+type otlpDataReceiver struct {
+	host    string
+	port    int
+	useHTTP bool
+
+	components []component.Component
+}
+
+// NewOTLPDataReceiver returns a DataReceiver listening with gRPC on 127.0.0.1:port.
+func NewOTLPDataReceiver(port int) DataReceiver {
+	return &otlpDataReceiver{host: "127.0.0.1", port: port}
+}
+
+// NewOTLPHTTPDataReceiver returns a DataReceiver listening with HTTP on 127.0.0.1:port.
+func NewOTLPHTTPDataReceiver(port int) DataReceiver {
+	return &otlpDataReceiver{host: "127.0.0.1", port: port, useHTTP: true}
+}
+
+func (dr *otlpDataReceiver) endpoint() string {
+	return net.JoinHostPort(dr.host, strconv.Itoa(dr.port))
+}
+
+func (dr *otlpDataReceiver) protocol() string {
+	if dr.useHTTP {
+		return "http"
+	}
+	return "grpc"
+}
+
+func (dr *otlpDataReceiver) Start(tc consumer.Traces, mc consumer.Metrics, lc consumer.Logs) error {
+	factory := otlpreceiver.NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	conf := confmap.NewFromStringMap(map[string]any{
+		"protocols": map[string]any{
+			dr.protocol(): map[string]any{"endpoint": dr.endpoint()},
+		},
+	})
+	if err := conf.Unmarshal(cfg); err != nil {
+		return err
+	}
+
+	// The OTLP receiver shares the server of the signals created with the same settings.
+	set := receivertest.NewNopSettings(factory.Type())
+	ctx := context.Background()
+	tr, err := factory.CreateTraces(ctx, set, cfg, tc)
+	if err != nil {
+		return err
+	}
+	mr, err := factory.CreateMetrics(ctx, set, cfg, mc)
+	if err != nil {
+		return err
+	}
+	lr, err := factory.CreateLogs(ctx, set, cfg, lc)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range []component.Component{tr, mr, lr} {
+		if err = c.Start(ctx, componenttest.NewNopHost()); err != nil {
+			return errors.Join(err, dr.Stop())
+		}
+		dr.components = append(dr.components, c)
+	}
+	return nil
+}
+
+func (dr *otlpDataReceiver) Stop() error {
+	var errs error
+	for _, c := range dr.components {
+		errs = errors.Join(errs, c.Shutdown(context.Background()))
+	}
+	dr.components = nil
+	return errs
+}
+
+func (dr *otlpDataReceiver) GenConfigYAMLStr() string {
+	if dr.useHTTP {
+		return fmt.Sprintf(`
+  otlphttp:
+    endpoint: "http://%s"`, dr.endpoint())
+	}
+	return fmt.Sprintf(`
+  otlp:
+    endpoint: "%s"
+    tls:
+      insecure: true`, dr.endpoint())
+}
+
+func (dr *otlpDataReceiver) ProtocolName() string {
+	if dr.useHTTP {
+		return "otlphttp"
+	}
+	return "otlp"
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed // import "go.opentelemetry.io/collector/testbed"
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// TestResult is the outcome of a TestCase.
+type TestResult struct {
+	// Name is the name of the test.
+	Name string
+	// Passed is whether the validation and the checks of the resources passed.
+	Passed bool
+	// Duration is the time the test case ran.
+	Duration time.Duration
+	// SentItems and ReceivedItems are the number of items accepted by the collector and by the backend.
+	SentItems     uint64
+	ReceivedItems uint64
+	// Resources are the resources used by the collector, if measured.
+	Resources ResourceConsumption
+	// Errors are the errors of the validation and of the checks of the resources.
+	Errors []string
+}
+
+// TestResultsSummary collects the results of the test cases, e.g. to compare the builds of a distribution.
+type TestResultsSummary interface {
+	// Add adds the result of a test case.
+	Add(result TestResult)
+	// Save writes the results added.
+	Save() error
+}
+
+// PerformanceResults is a TestResultsSummary writing the results as a Markdown table.
+type PerformanceResults struct {
+	w io.Writer
+
+	mu      sync.Mutex
+	results []TestResult
+}
+
+var _ TestResultsSummary = (*PerformanceResults)(nil)
+
+// NewPerformanceResults returns a PerformanceResults writing the results to w.
+func NewPerformanceResults(w io.Writer) *PerformanceResults {
+	return &PerformanceResults{w: w}
+}
+
+func (r *PerformanceResults) Add(result TestResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, result)
+}
+
+func (r *PerformanceResults) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := io.WriteString(r.w, "| Test | Result | Duration | CPU avg/max (%) | RAM avg/max (MiB) | Sent items | Received items |\n"+
+		"|------|--------|----------|-----------------|-------------------|------------|----------------|\n"); err != nil {
+		return err
+	}
+	for _, result := range r.results {
+		status := "PASS"
+		if !result.Passed {
+			status = "FAIL"
+		}
+		if _, err := fmt.Fprintf(r.w, "| %s | %s | %.1fs | %.1f/%.1f | %d/%d | %d | %d |\n",
+			result.Name,
+			status,
+			result.Duration.Seconds(),
+			result.Resources.CPUPercentAvg,
+			result.Resources.CPUPercentMax,
+			result.Resources.RAMMiBAvg,
+			result.Resources.RAMMiBMax,
+			result.SentItems,
+			result.ReceivedItems,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
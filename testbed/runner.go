@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed // import "go.opentelemetry.io/collector/testbed"
+
+// OtelcolRunner runs the collector under test.
+type OtelcolRunner interface {
+	// PrepareConfig sets the YAML configuration of the collector, before it is started.
+	PrepareConfig(configStr string) error
+	// Start starts the collector and waits until it is running.
+	Start() error
+	// Stop stops the collector.
+	Stop() error
+	// ResourceConsumption returns the resources used by the collector while it was running, if the
+	// runner measures them.
+	ResourceConsumption() ResourceConsumption
+}
+
+// ResourceConsumption is the CPU and RAM used by the collector.
+type ResourceConsumption struct {
+	// CPUPercentAvg and CPUPercentMax are the average and maximum CPU usage, in percent of one core.
+	CPUPercentAvg float64
+	CPUPercentMax float64
+	// RAMMiBAvg and RAMMiBMax are the average and maximum resident memory, in MiB.
+	RAMMiBAvg uint32
+	RAMMiBMax uint32
+}
+
+// ResourceSpec is the maximum resources the collector is expected to use during a TestCase. A zero
+// value disables the check of the resource.
+type ResourceSpec struct {
+	// ExpectedMaxCPU is the maximum CPU usage, in percent of one core.
+	ExpectedMaxCPU uint32
+	// ExpectedMaxRAM is the maximum resident memory, in MiB.
+	ExpectedMaxRAM uint32
+}
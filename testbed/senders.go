@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed // import "go.opentelemetry.io/collector/testbed"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+)
+
+// DataSender sends the data generated by a LoadGenerator to the collector under test.
+type DataSender interface {
+	// Start starts the sender, connecting it to the receiver of the collector.
+	Start() error
+	// Stop stops the sender.
+	Stop() error
+	// Flush sends the data buffered by the sender, if any.
+	Flush()
+	// GenConfigYAMLStr returns the configuration of the receiver of the collector receiving the data
+	// of the sender, to be inserted under the receivers key of the collector configuration.
+	GenConfigYAMLStr() string
+	// ProtocolName returns the component ID of the receiver of the collector receiving the data.
+	ProtocolName() string
+}
+
+// TraceDataSender is a DataSender of traces.
+type TraceDataSender interface {
+	DataSender
+	consumer.Traces
+}
+
+// MetricDataSender is a DataSender of metrics.
+type MetricDataSender interface {
+	DataSender
+	consumer.Metrics
+}
+
+// LogDataSender is a DataSender of logs.
+type LogDataSender interface {
+	DataSender
+	consumer.Logs
+}
+
+// otlpDataSender sends the data with an OTLP exporter, with neither a sending queue nor retries so
+// the LoadGenerator is the one handling the errors returned by the collector.
+type otlpDataSender struct {
+	host    string
+	port    int
+	useHTTP bool
+	factory exporter.Factory
+
+	exp component.Component
+}
+
+func newOTLPDataSender(host string, port int, useHTTP bool) otlpDataSender {
+	ds := otlpDataSender{host: host, port: port, useHTTP: useHTTP, factory: otlpexporter.NewFactory()}
+	if useHTTP {
+		ds.factory = otlphttpexporter.NewFactory()
+	}
+	return ds
+}
+
+func (ds *otlpDataSender) createConfig() (component.Config, error) {
+	endpoint := net.JoinHostPort(ds.host, strconv.Itoa(ds.port))
+	if ds.useHTTP {
+		endpoint = "http://" + endpoint
+	}
+	cfg := ds.factory.CreateDefaultConfig()
+	conf := confmap.NewFromStringMap(map[string]any{
+		"endpoint":         endpoint,
+		"tls":              map[string]any{"insecure": true},
+		"sending_queue":    map[string]any{"enabled": false},
+		"retry_on_failure": map[string]any{"enabled": false},
+	})
+	if err := conf.Unmarshal(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (ds *otlpDataSender) start(exp component.Component) error {
+	ds.exp = exp
+	return exp.Start(context.Background(), componenttest.NewNopHost())
+}
+
+func (ds *otlpDataSender) settings() exporter.Settings {
+	return exportertest.NewNopSettings(ds.factory.Type())
+}
+
+func (ds *otlpDataSender) Stop() error {
+	if ds.exp == nil {
+		return nil
+	}
+	return ds.exp.Shutdown(context.Background())
+}
+
+func (ds *otlpDataSender) Flush() {}
+
+func (ds *otlpDataSender) GenConfigYAMLStr() string {
+	protocol := "grpc"
+	if ds.useHTTP {
+		protocol = "http"
+	}
+	return fmt.Sprintf(`
+  otlp:
+    protocols:
+      %s:
+        endpoint: "%s"`, protocol, net.JoinHostPort(ds.host, strconv.Itoa(ds.port)))
+}
+
+func (ds *otlpDataSender) ProtocolName() string {
+	return "otlp"
+}
+
+type otlpTraceDataSender struct {
+	otlpDataSender
+	consumer.Traces
+}
+
+// NewOTLPTraceDataSender returns a TraceDataSender sending traces to the OTLP receiver of the collector
+// listening with gRPC on host:port.
+func NewOTLPTraceDataSender(host string, port int) TraceDataSender {
+	return &otlpTraceDataSender{otlpDataSender: newOTLPDataSender(host, port, false)}
+}
+
+// NewOTLPHTTPTraceDataSender returns a TraceDataSender sending traces to the OTLP receiver of the
+// collector listening with HTTP on host:port.
+func NewOTLPHTTPTraceDataSender(host string, port int) TraceDataSender {
+	return &otlpTraceDataSender{otlpDataSender: newOTLPDataSender(host, port, true)}
+}
+
+func (ds *otlpTraceDataSender) Start() error {
+	cfg, err := ds.createConfig()
+	if err != nil {
+		return err
+	}
+	exp, err := ds.factory.CreateTraces(context.Background(), ds.settings(), cfg)
+	if err != nil {
+		return err
+	}
+	ds.Traces = exp
+	return ds.start(exp)
+}
+
+type otlpMetricDataSender struct {
+	otlpDataSender
+	consumer.Metrics
+}
+
+// NewOTLPMetricDataSender returns a MetricDataSender sending metrics to the OTLP receiver of the
+// collector listening with gRPC on host:port.
+func NewOTLPMetricDataSender(host string, port int) MetricDataSender {
+	return &otlpMetricDataSender{otlpDataSender: newOTLPDataSender(host, port, false)}
+}
+
+// NewOTLPHTTPMetricDataSender returns a MetricDataSender sending metrics to the OTLP receiver of the
+// collector listening with HTTP on host:port.
+func NewOTLPHTTPMetricDataSender(host string, port int) MetricDataSender {
+	return &otlpMetricDataSender{otlpDataSender: newOTLPDataSender(host, port, true)}
+}
+
+func (ds *otlpMetricDataSender) Start() error {
+	cfg, err := ds.createConfig()
+	if err != nil {
+		return err
+	}
+	exp, err := ds.factory.CreateMetrics(context.Background(), ds.settings(), cfg)
+	if err != nil {
+		return err
+	}
+	ds.Metrics = exp
+	return ds.start(exp)
+}
+
+type otlpLogDataSender struct {
+	otlpDataSender
+	consumer.Logs
+}
+
+// NewOTLPLogDataSender returns a LogDataSender sending logs to the OTLP receiver of the collector
+// listening with gRPC on host:port.
+func NewOTLPLogDataSender(host string, port int) LogDataSender {
+	return &otlpLogDataSender{otlpDataSender: newOTLPDataSender(host, port, false)}
+}
+
+// NewOTLPHTTPLogDataSender returns a LogDataSender sending logs to the OTLP receiver of the collector
+// listening with HTTP on host:port.
+func NewOTLPHTTPLogDataSender(host string, port int) LogDataSender {
+	return &otlpLogDataSender{otlpDataSender: newOTLPDataSender(host, port, true)}
+}
+
+func (ds *otlpLogDataSender) Start() error {
+	cfg, err := ds.createConfig()
+	if err != nil {
+		return err
+	}
+	exp, err := ds.factory.CreateLogs(context.Background(), ds.settings(), cfg)
+	if err != nil {
+		return err
+	}
+	ds.Logs = exp
+	return ds.start(exp)
+}
@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed // import "go.opentelemetry.io/collector/testbed"
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// waitInterval is the interval at which the conditions of TestCase.WaitFor are checked.
+const waitInterval = 10 * time.Millisecond
+
+// TestCase runs a collector between a LoadGenerator and a MockBackend, validates the data received by the
+// backend and checks the resources used by the collector.
+type TestCase struct {
+	tb testing.TB
+
+	// LoadGenerator sends the data to the collector.
+	LoadGenerator *LoadGenerator
+	// MockBackend receives the data exported by the collector.
+	MockBackend *MockBackend
+
+	sender         DataSender
+	receiver       DataReceiver
+	runner         OtelcolRunner
+	validator      TestCaseValidator
+	resultsSummary TestResultsSummary
+	resourceSpec   ResourceSpec
+	processors     []ProcessorConfig
+
+	startTime time.Time
+	errs      []string
+	stopped   bool
+}
+
+// TestCaseOption configures a TestCase.
+type TestCaseOption func(*TestCase)
+
+// WithResourceLimits sets the resources the collector is expected to use at most.
+func WithResourceLimits(spec ResourceSpec) TestCaseOption {
+	return func(tc *TestCase) {
+		tc.resourceSpec = spec
+	}
+}
+
+// WithProcessors sets the processors of the pipeline of the collector.
+func WithProcessors(processors ...ProcessorConfig) TestCaseOption {
+	return func(tc *TestCase) {
+		tc.processors = processors
+	}
+}
+
+// WithResultsSummary sets the TestResultsSummary the result of the test case is added to once stopped.
+func WithResultsSummary(summary TestResultsSummary) TestCaseOption {
+	return func(tc *TestCase) {
+		tc.resultsSummary = summary
+	}
+}
+
+// NewTestCase returns a TestCase sending the data of the provider with the sender to a collector run by
+// the runner and exporting it to the receiver, and validating it with the validator. The test case is
+// stopped when the test ends.
+func NewTestCase(
+	tb testing.TB,
+	provider DataProvider,
+	sender DataSender,
+	receiver DataReceiver,
+	runner OtelcolRunner,
+	validator TestCaseValidator,
+	opts ...TestCaseOption,
+) *TestCase {
+	lg, err := NewLoadGenerator(provider, sender)
+	require.NoError(tb, err)
+	tc := &TestCase{
+		tb:            tb,
+		LoadGenerator: lg,
+		MockBackend:   NewMockBackend(receiver),
+		sender:        sender,
+		receiver:      receiver,
+		runner:        runner,
+		validator:     validator,
+		startTime:     time.Now(),
+	}
+	for _, opt := range opts {
+		opt(tc)
+	}
+	tb.Cleanup(tc.Stop)
+	return tc
+}
+
+// StartBackend starts the MockBackend, recording the sequence numbers of the items it receives.
+func (tc *TestCase) StartBackend() {
+	tc.MockBackend.EnableRecording()
+	require.NoError(tc.tb, tc.MockBackend.Start(), "cannot start the backend")
+}
+
+// StartAgent configures and starts the collector.
+func (tc *TestCase) StartAgent() {
+	cfg, err := CreateConfigYAML(tc.sender, tc.receiver, tc.processors)
+	require.NoError(tc.tb, err)
+	require.NoError(tc.tb, tc.runner.PrepareConfig(cfg), "cannot configure the collector")
+	require.NoError(tc.tb, tc.runner.Start(), "cannot start the collector")
+}
+
+// StartLoad starts the sender and generating the load.
+func (tc *TestCase) StartLoad(options LoadOptions) {
+	require.NoError(tc.tb, tc.sender.Start(), "cannot start the sender")
+	tc.LoadGenerator.Start(options)
+}
+
+// StopLoad stops generating the load.
+func (tc *TestCase) StopLoad() {
+	tc.LoadGenerator.Stop()
+}
+
+// WaitFor waits until cond returns true, for up to timeout. It records a failure with the errMsg and
+// returns false otherwise.
+func (tc *TestCase) WaitFor(cond func() bool, timeout time.Duration, errMsg string) bool {
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			tc.fail(fmt.Sprintf("%s: timed out after %v", errMsg, timeout))
+			return false
+		}
+		time.Sleep(waitInterval)
+	}
+	return true
+}
+
+// WaitForAllReceived waits until the MockBackend received all the items accepted by the collector.
+func (tc *TestCase) WaitForAllReceived(timeout time.Duration) bool {
+	return tc.WaitFor(func() bool {
+		return tc.MockBackend.DataItemsReceived() >= tc.LoadGenerator.DataItemsSent()
+	}, timeout, "not all items were received")
+}
+
+// ValidateData validates the data received by the MockBackend with the validator of the test case.
+func (tc *TestCase) ValidateData() {
+	if err := tc.validator.Validate(tc); err != nil {
+		tc.fail(err.Error())
+	}
+}
+
+func (tc *TestCase) fail(msg string) {
+	tc.errs = append(tc.errs, msg)
+	tc.tb.Error(msg)
+}
+
+// Stop stops the load, the collector and the backend, checks the resources used by the collector and
+// adds the result of the test case to the results summary, if any. It does nothing if already called.
+func (tc *TestCase) Stop() {
+	if tc.stopped {
+		return
+	}
+	tc.stopped = true
+
+	tc.LoadGenerator.Stop()
+	if err := tc.sender.Stop(); err != nil {
+		tc.fail(fmt.Sprintf("cannot stop the sender: %v", err))
+	}
+	if err := tc.runner.Stop(); err != nil {
+		tc.fail(fmt.Sprintf("cannot stop the collector: %v", err))
+	}
+	if err := tc.MockBackend.Stop(); err != nil {
+		tc.fail(fmt.Sprintf("cannot stop the backend: %v", err))
+	}
+
+	resources := tc.runner.ResourceConsumption()
+	if limit := tc.resourceSpec.ExpectedMaxCPU; limit != 0 && resources.CPUPercentMax > float64(limit) {
+		tc.fail(fmt.Sprintf("CPU usage %.1f%% exceeds the expected maximum of %d%%", resources.CPUPercentMax, limit))
+	}
+	if limit := tc.resourceSpec.ExpectedMaxRAM; limit != 0 && resources.RAMMiBMax > limit {
+		tc.fail(fmt.Sprintf("RAM usage %d MiB exceeds the expected maximum of %d MiB", resources.RAMMiBMax, limit))
+	}
+
+	if tc.resultsSummary != nil {
+		tc.resultsSummary.Add(tc.Result())
+	}
+}
+
+// Result returns the result of the test case so far.
+func (tc *TestCase) Result() TestResult {
+	return TestResult{
+		Name:          tc.tb.Name(),
+		Passed:        len(tc.errs) == 0,
+		Duration:      time.Since(tc.startTime),
+		SentItems:     tc.LoadGenerator.DataItemsSent(),
+		ReceivedItems: tc.MockBackend.DataItemsReceived(),
+		Resources:     tc.runner.ResourceConsumption(),
+		Errors:        tc.errs,
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver"
+)
+
+func factories() (otelcol.Factories, error) {
+	receivers, err := otelcol.MakeFactoryMap[receiver.Factory](otlpreceiver.NewFactory())
+	if err != nil {
+		return otelcol.Factories{}, err
+	}
+	exporters, err := otelcol.MakeFactoryMap[exporter.Factory](otlpexporter.NewFactory(), otlphttpexporter.NewFactory())
+	if err != nil {
+		return otelcol.Factories{}, err
+	}
+	return otelcol.Factories{Receivers: receivers, Exporters: exporters}, nil
+}
+
+func TestInProcessCollector(t *testing.T) {
+	tests := []struct {
+		name     string
+		sender   func(port int) DataSender
+		receiver func(port int) DataReceiver
+	}{
+		{
+			name:     "traces_grpc",
+			sender:   func(port int) DataSender { return NewOTLPTraceDataSender("127.0.0.1", port) },
+			receiver: NewOTLPDataReceiver,
+		},
+		{
+			name:     "metrics_http",
+			sender:   func(port int) DataSender { return NewOTLPHTTPMetricDataSender("127.0.0.1", port) },
+			receiver: NewOTLPHTTPDataReceiver,
+		},
+		{
+			name:     "logs_grpc_to_http",
+			sender:   func(port int) DataSender { return NewOTLPLogDataSender("127.0.0.1", port) },
+			receiver: NewOTLPHTTPDataReceiver,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := LoadOptions{DataItemsPerSecond: 1000, ItemsPerBatch: 10, Parallel: 2, Attributes: map[string]string{"test": tt.name}}
+			var buf bytes.Buffer
+			results := NewPerformanceResults(&buf)
+			tc := NewTestCase(t,
+				NewPerfTestDataProvider(options),
+				tt.sender(GetAvailablePort(t)),
+				tt.receiver(GetAvailablePort(t)),
+				NewInProcessCollector(factories),
+				NewCorrectnessValidator(),
+				WithResultsSummary(results),
+			)
+			tc.StartBackend()
+			tc.StartAgent()
+			tc.StartLoad(options)
+			tc.WaitFor(func() bool { return tc.LoadGenerator.DataItemsSent() >= 100 }, 10*time.Second, "load not generated")
+			tc.StopLoad()
+			tc.WaitForAllReceived(10 * time.Second)
+			tc.ValidateData()
+			tc.Stop()
+
+			result := tc.Result()
+			assert.True(t, result.Passed, result.Errors)
+			assert.Equal(t, result.SentItems, result.ReceivedItems)
+			require.NoError(t, results.Save())
+			assert.Contains(t, buf.String(), "| "+t.Name()+" | PASS |")
+		})
+	}
+}
+
+func TestInProcessCollectorInvalidConfig(t *testing.T) {
+	runner := NewInProcessCollector(func() (otelcol.Factories, error) { return otelcol.Factories{}, nil })
+	require.NoError(t, runner.PrepareConfig("receivers: {unknown: {}}"))
+	require.Error(t, runner.Start())
+	require.NoError(t, runner.Stop())
+	assert.Equal(t, ResourceConsumption{}, runner.ResourceConsumption())
+}
+
+func TestCreateConfigYAML(t *testing.T) {
+	cfg, err := CreateConfigYAML(
+		NewOTLPTraceDataSender("127.0.0.1", 4317),
+		NewOTLPHTTPDataReceiver(4318),
+		[]ProcessorConfig{{Name: "batch"}, {Name: "memory_limiter", Body: "    check_interval: 1s\n    limit_mib: 100"}},
+	)
+	require.NoError(t, err)
+	assert.Contains(t, cfg, `
+  otlp:
+    protocols:
+      grpc:
+        endpoint: "127.0.0.1:4317"`)
+	assert.Contains(t, cfg, `
+  otlphttp:
+    endpoint: "http://127.0.0.1:4318"`)
+	assert.Contains(t, cfg, `
+  memory_limiter:
+    check_interval: 1s`)
+	assert.Contains(t, cfg, `
+    traces:
+      receivers: [otlp]
+      processors: [batch,memory_limiter]
+      exporters: [otlphttp]`)
+
+	_, err = CreateConfigYAML(nil, NewOTLPDataReceiver(4317), nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed // import "go.opentelemetry.io/collector/testbed"
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// TestCaseValidator validates the outcome of a TestCase once its load is stopped.
+type TestCaseValidator interface {
+	// Validate returns an error describing the discrepancies found, if any.
+	Validate(tc *TestCase) error
+}
+
+type countValidator struct{}
+
+// NewCountValidator returns a TestCaseValidator checking that the MockBackend received as many items as
+// the collector accepted from the LoadGenerator.
+func NewCountValidator() TestCaseValidator {
+	return countValidator{}
+}
+
+func (countValidator) Validate(tc *TestCase) error {
+	sent := tc.LoadGenerator.DataItemsSent()
+	received := tc.MockBackend.DataItemsReceived()
+	if sent != received {
+		return fmt.Errorf("received %d items but %d were sent", received, sent)
+	}
+	return nil
+}
+
+// maxReportedSequenceNumbers limits the number of sequence numbers listed in the errors of the
+// correctness validator.
+const maxReportedSequenceNumbers = 10
+
+type correctnessValidator struct{}
+
+// NewCorrectnessValidator returns a TestCaseValidator checking that the MockBackend received every item
+// accepted by the collector exactly once, using the SequenceNumberKey attribute of the items generated
+// by the DataProvider returned by NewPerfTestDataProvider. The recording of the backend must be enabled
+// before the load is started.
+func NewCorrectnessValidator() TestCaseValidator {
+	return correctnessValidator{}
+}
+
+func (correctnessValidator) Validate(tc *TestCase) error {
+	var errs error
+	received := tc.MockBackend.SequenceNumbers()
+	var duplicates []int64
+	for seq, count := range received {
+		if count > 1 {
+			duplicates = append(duplicates, seq)
+		}
+	}
+	if len(duplicates) > 0 {
+		errs = errors.Join(errs, fmt.Errorf("received %d items more than once: %v", len(duplicates), firstSequenceNumbers(duplicates)))
+	}
+
+	sent := tc.LoadGenerator.DataItemsSent()
+	if uint64(len(received)) < sent {
+		errs = errors.Join(errs, fmt.Errorf("received %d distinct items but %d were sent", len(received), sent))
+	}
+	return errs
+}
+
+func firstSequenceNumbers(seqs []int64) []int64 {
+	slices.Sort(seqs)
+	if len(seqs) > maxReportedSequenceNumbers {
+		return seqs[:maxReportedSequenceNumbers]
+	}
+	return seqs
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testbed
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidators(t *testing.T) {
+	dp := NewPerfTestDataProvider(LoadOptions{ItemsPerBatch: 2})
+	lg, err := NewLoadGenerator(dp, NewOTLPTraceDataSender("127.0.0.1", 0))
+	require.NoError(t, err)
+	tc := &TestCase{LoadGenerator: lg, MockBackend: NewMockBackend(nil)}
+	tc.MockBackend.EnableRecording()
+
+	td := dp.GenerateTraces()
+	lg.dataItemsSent.Add(uint64(td.SpanCount()))
+	require.EqualError(t, NewCountValidator().Validate(tc), "received 0 items but 2 were sent")
+	require.EqualError(t, NewCorrectnessValidator().Validate(tc), "received 0 distinct items but 2 were sent")
+
+	require.NoError(t, tc.MockBackend.ConsumeTraces(context.Background(), td))
+	require.NoError(t, NewCountValidator().Validate(tc))
+	require.NoError(t, NewCorrectnessValidator().Validate(tc))
+
+	require.NoError(t, tc.MockBackend.ConsumeTraces(context.Background(), td))
+	require.EqualError(t, NewCountValidator().Validate(tc), "received 4 items but 2 were sent")
+	require.EqualError(t, NewCorrectnessValidator().Validate(tc), "received 2 items more than once: [0 1]")
+}
+
+func TestMockBackendDecisionFunc(t *testing.T) {
+	mb := NewMockBackend(nil)
+	rejected := errors.New("rejected")
+	mb.WithDecisionFunc(func() error { return rejected })
+	mb.EnableRecording()
+	td := NewPerfTestDataProvider(LoadOptions{ItemsPerBatch: 1}).GenerateTraces()
+	require.ErrorIs(t, mb.ConsumeTraces(context.Background(), td), rejected)
+	assert.Zero(t, mb.DataItemsReceived())
+	assert.Empty(t, mb.SequenceNumbers())
+}
+
+func TestNewLoadGeneratorUnsupportedSender(t *testing.T) {
+	_, err := NewLoadGenerator(NewPerfTestDataProvider(LoadOptions{}), nil)
+	require.Error(t, err)
+}